@@ -0,0 +1,53 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package main
+
+import (
+	"os"
+
+	"github.com/mdlayher/vsock"
+	"github.com/spf13/pflag"
+
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/client-go/version"
+
+	"kubevirt.io/kubevirt/pkg/vsock/agent"
+)
+
+func main() {
+	log.InitializeLogging("minimal-guest-agent")
+
+	var port uint32
+	pflag.Uint32Var(&port, "port", agent.MinimalAgentVSOCKPort, "vsock port to listen on")
+	pflag.Parse()
+
+	listener, err := vsock.Listen(port, &vsock.Config{})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to start vsock server")
+		os.Exit(1)
+	}
+	defer listener.Close()
+
+	log.DefaultLogger().Infof("listening on vsock port %d", port)
+	if err := agent.Serve(listener, agent.NewMinimalAgent(version.Get().GitVersion)); err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to serve")
+		os.Exit(1)
+	}
+}
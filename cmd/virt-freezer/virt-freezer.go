@@ -123,7 +123,7 @@ func run(config *FreezerConfig, client cmdclient.LauncherClient) error {
 	}
 
 	if config.Freeze {
-		err = client.FreezeVirtualMachine(vmi, config.UnfreezeTimeoutSeconds)
+		err = client.FreezeVirtualMachine(vmi, config.UnfreezeTimeoutSeconds, nil)
 		if err != nil {
 			if strings.Contains(err.Error(), gaNotAvailableError) {
 				client.UnfreezeVirtualMachine(vmi)
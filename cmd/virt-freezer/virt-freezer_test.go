@@ -108,7 +108,7 @@ var _ = Describe("Freezer", func() {
 		It("should succeed if Freeze VirtualMachine", func() {
 			client.EXPECT().GetGuestInfo().Return(guestInfo, nil)
 			client.EXPECT().GetDomain().Return(&api.Domain{Status: api.DomainStatus{Status: api.Running}}, true, nil)
-			client.EXPECT().FreezeVirtualMachine(gomock.Any(), gomock.Any()).Return(nil)
+			client.EXPECT().FreezeVirtualMachine(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 			err := run(config, client)
 			Expect(err).ToNot(HaveOccurred())
@@ -117,7 +117,7 @@ var _ = Describe("Freezer", func() {
 		It("returns error if FreezeVirtualMachine fails", func() {
 			client.EXPECT().GetGuestInfo().Return(guestInfo, nil)
 			client.EXPECT().GetDomain().Return(&api.Domain{Status: api.DomainStatus{Status: api.Running}}, true, nil)
-			client.EXPECT().FreezeVirtualMachine(gomock.Any(), gomock.Any()).Return(errors.New("freeze failed"))
+			client.EXPECT().FreezeVirtualMachine(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("freeze failed"))
 
 			err := run(config, client)
 			Expect(err).To(HaveOccurred())
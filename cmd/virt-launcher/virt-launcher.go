@@ -49,6 +49,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/hooks"
 	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
+	"kubevirt.io/kubevirt/pkg/storage/localscratch"
 	putil "kubevirt.io/kubevirt/pkg/util"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
@@ -176,6 +177,14 @@ func initializeDirs(ephemeralDiskDir string,
 		panic(err)
 	}
 
+	// Lets virt-handler release the directory when it is backed by the local scratch pool
+	if _, ok := os.LookupEnv(putil.ENV_VAR_LOCAL_SCRATCH); ok {
+		err = localscratch.Claim(ephemeralDiskDir, uid)
+		if err != nil {
+			panic(err)
+		}
+	}
+
 	err = cloudinit.SetLocalDirectory(filepath.Join(ephemeralDiskDir, "cloud-init-data"))
 	if err != nil {
 		panic(err)
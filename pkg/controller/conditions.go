@@ -24,6 +24,8 @@ import (
 
 	v1 "kubevirt.io/api/core/v1"
 	poolv1 "kubevirt.io/api/pool/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/util/errorhints"
 )
 
 type VirtualMachinePoolConditionManager struct {
@@ -151,7 +153,7 @@ func (d *VirtualMachineInstanceConditionManager) CheckFailure(vmi *v1.VirtualMac
 		vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
 			Type:               v1.VirtualMachineInstanceSynchronized,
 			Reason:             reason,
-			Message:            syncErr.Error(),
+			Message:            errorhints.Annotate(syncErr.Error()),
 			LastTransitionTime: metav1.Now(),
 			Status:             k8sv1.ConditionFalse,
 		})
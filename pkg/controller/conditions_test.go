@@ -19,6 +19,8 @@
 package controller
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -72,6 +74,18 @@ var _ = Describe("VirtualMachineInstance ConditionManager", func() {
 		})
 	})
 
+	When("checking for a sync failure", func() {
+		It("should add an actionable hint to known errors", func() {
+			syncErr := errors.New(`pods "virt-launcher-testvmi-abcde" is forbidden: exceeded quota: mem-quota, requested: requests.hugepages-2Mi=2Gi`)
+			Expect(cm.CheckFailure(vmi, syncErr, FailedCreatePodReason)).To(BeTrue())
+
+			condition := cm.GetCondition(vmi, v1.VirtualMachineInstanceSynchronized)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Message).To(HavePrefix(syncErr.Error()))
+			Expect(condition.Message).To(ContainSubstring("Hint: The ResourceQuota of the namespace does not allow the requested hugepages"))
+		})
+	})
+
 	When("VMI is nil", func() {
 		It("should gracefully report condition not available", func() {
 			var vmi2 *v1.VirtualMachineInstance
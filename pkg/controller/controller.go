@@ -113,6 +113,9 @@ const (
 	// NoSuitableNodesForHostModelMigration is set when a VMI with host-model CPU mode tries to migrate but no node
 	// is suitable for migration (since CPU model / required features are not supported)
 	NoSuitableNodesForHostModelMigration = "NoSuitableNodesForHostModelMigration"
+	// NoSuitableNodesForCPUFeatures is set when a VMI with explicit CPU feature policies tries to migrate but no node
+	// satisfies its CPU model and the required / forbidden features
+	NoSuitableNodesForCPUFeatures = "NoSuitableNodesForCPUFeatures"
 	// FailedPodPatchReason is set when a pod patch error occurs during sync
 	FailedPodPatchReason = "FailedPodPatch"
 	// MigrationBackoffReason is set when an error has occured while migrating
@@ -63,6 +63,7 @@ import (
 	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
 	"kubevirt.io/api/migrations"
 	migrationsv1 "kubevirt.io/api/migrations/v1alpha1"
+	operationsv1 "kubevirt.io/api/operations/v1alpha1"
 	poolv1 "kubevirt.io/api/pool/v1beta1"
 	"kubevirt.io/api/snapshot"
 	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
@@ -87,6 +88,7 @@ const (
 	ByVMINameIndex      = "byVMIName"
 	ByMigrationUIDIndex = "byMigrationUID"
 	UnfinishedIndex     = "unfinished"
+	ByStorageClassIndex = "byStorageClass"
 )
 
 var unexpectedObjectError = errors.New("unexpected object")
@@ -156,6 +158,9 @@ type KubeInformerFactory interface {
 	// Watches VirtualMachineClone objects
 	VirtualMachineClone() cache.SharedIndexInformer
 
+	// Watches VirtualMachineOperation objects
+	VirtualMachineOperation() cache.SharedIndexInformer
+
 	// Watches VirtualMachineInstancetype objects
 	VirtualMachineInstancetype() cache.SharedIndexInformer
 
@@ -186,6 +191,9 @@ type KubeInformerFactory interface {
 	// Watches for the kubevirt export service
 	ExportService() cache.SharedIndexInformer
 
+	// Watches for Services published through Gateway API routes
+	GatewayService() cache.SharedIndexInformer
+
 	// ConfigMaps which are managed by the operator
 	OperatorConfigMap() cache.SharedIndexInformer
 
@@ -330,6 +338,9 @@ type KubeInformerFactory interface {
 	// PVC StorageClasses
 	StorageClass() cache.SharedIndexInformer
 
+	// CSIStorageCapacity objects of all namespaces, indexed by StorageClass
+	CSIStorageCapacity() cache.SharedIndexInformer
+
 	// Pod returns an informer for ALL Pods in the system
 	Pod() cache.SharedIndexInformer
 
@@ -934,6 +945,36 @@ func (f *kubeInformerFactory) VirtualMachineClone() cache.SharedIndexInformer {
 	})
 }
 
+func GetVirtualMachineOperationInformerIndexers() cache.Indexers {
+	return cache.Indexers{
+		// Gets: vm key. Returns: operations currently running on the specified vm
+		"vm": func(obj interface{}) ([]string, error) {
+			operation, ok := obj.(*operationsv1.VirtualMachineOperation)
+			if !ok {
+				return nil, unexpectedObjectError
+			}
+			if operation.Status == nil {
+				return nil, nil
+			}
+
+			var keys []string
+			for _, target := range operation.Status.Targets {
+				if target.Phase == operationsv1.TargetRunning {
+					keys = append(keys, fmt.Sprintf("%s/%s", operation.Namespace, target.Name))
+				}
+			}
+			return keys, nil
+		},
+	}
+}
+
+func (f *kubeInformerFactory) VirtualMachineOperation() cache.SharedIndexInformer {
+	return f.getInformer("virtualMachineOperationInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().OperationsV1alpha1().RESTClient(), "virtualmachineoperations", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &operationsv1.VirtualMachineOperation{}, f.defaultResync, GetVirtualMachineOperationInformerIndexers())
+	})
+}
+
 func (f *kubeInformerFactory) VirtualMachineInstancetype() cache.SharedIndexInformer {
 	return f.getInformer("vmInstancetypeInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().InstancetypeV1beta1().RESTClient(), instancetypeapi.PluralResourceName, k8sv1.NamespaceAll, fields.Everything())
@@ -1101,6 +1142,18 @@ func (f *kubeInformerFactory) ExportService() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) GatewayService() cache.SharedIndexInformer {
+	return f.getInformer("gatewayService", func() cache.SharedIndexInformer {
+		labelSelector, err := labels.Parse(kubev1.GatewayRouteLabel)
+		if err != nil {
+			panic(err)
+		}
+
+		lw := NewListWatchFromClient(f.clientSet.CoreV1().RESTClient(), "services", k8sv1.NamespaceAll, fields.Everything(), labelSelector)
+		return cache.NewSharedIndexInformer(lw, &k8sv1.Service{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
 func (f *kubeInformerFactory) PersistentVolumeClaim() cache.SharedIndexInformer {
 	return f.getInformer("persistentVolumeClaimInformer", func() cache.SharedIndexInformer {
 		restClient := f.clientSet.CoreV1().RESTClient()
@@ -1544,6 +1597,18 @@ func (f *kubeInformerFactory) StorageClass() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) CSIStorageCapacity() cache.SharedIndexInformer {
+	return f.getInformer("csiStorageCapacityInformer", func() cache.SharedIndexInformer {
+		restClient := f.clientSet.StorageV1().RESTClient()
+		lw := cache.NewListWatchFromClient(restClient, "csistoragecapacities", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &storagev1.CSIStorageCapacity{}, f.defaultResync, cache.Indexers{
+			ByStorageClassIndex: func(obj interface{}) ([]string, error) {
+				return []string{obj.(*storagev1.CSIStorageCapacity).StorageClassName}, nil
+			},
+		})
+	})
+}
+
 func (f *kubeInformerFactory) Pod() cache.SharedIndexInformer {
 	return f.getInformer("podInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.clientSet.CoreV1().RESTClient(), "pods", k8sv1.NamespaceAll, fields.Everything())
@@ -27,8 +27,11 @@ import (
 	"path/filepath"
 
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
 
 	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	"kubevirt.io/kubevirt/pkg/os/disk"
+	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
@@ -43,6 +46,7 @@ type EphemeralDiskCreatorInterface interface {
 	CreateBackedImageForVolume(volume v1.Volume, backingFile string, backingFormat string) error
 	CreateEphemeralImages(vmi *v1.VirtualMachineInstance, domain *api.Domain) error
 	GetFilePath(volumeName string) string
+	GetOverlayClaimFilePath(volumeName string) string
 	Init() error
 }
 
@@ -51,6 +55,7 @@ type ephemeralDiskCreator struct {
 	pvcBaseDir      string
 	blockDevBaseDir string
 	discCreateFunc  func(backingFile string, backingFormat string, imagePath string) ([]byte, error)
+	diskInfoFunc    func(imagePath string) (*disk.DiskInfo, error)
 }
 
 func NewEphemeralDiskCreator(mountBaseDir string) *ephemeralDiskCreator {
@@ -59,6 +64,7 @@ func NewEphemeralDiskCreator(mountBaseDir string) *ephemeralDiskCreator {
 		pvcBaseDir:      ephemeralDiskPVCBaseDir,
 		blockDevBaseDir: ephemeralDiskBlockDeviceBaseDir,
 		discCreateFunc:  createBackingDisk,
+		diskInfoFunc:    disk.GetDiskInfo,
 	}
 }
 
@@ -93,24 +99,39 @@ func (c *ephemeralDiskCreator) GetFilePath(volumeName string) string {
 	return filepath.Join(volumeMountDir, "disk.qcow2")
 }
 
+// GetOverlayClaimFilePath returns the path of the overlay image of an ephemeral volume which keeps
+// its overlay on a PVC.
+func (c *ephemeralDiskCreator) GetOverlayClaimFilePath(volumeName string) string {
+	return filepath.Join(c.pvcBaseDir, storagetypes.EphemeralOverlayVolumeName(volumeName), "disk.qcow2")
+}
+
 func (c *ephemeralDiskCreator) CreateBackedImageForVolume(volume v1.Volume, backingFile string, backingFormat string) error {
 	err := c.createVolumeDirectory(volume.Name)
 	if err != nil {
 		return err
 	}
 
-	imagePath := c.GetFilePath(volume.Name)
+	return c.createBackedImage(c.GetFilePath(volume.Name), backingFile, backingFormat)
+}
 
+func (c *ephemeralDiskCreator) createBackedImage(imagePath string, backingFile string, backingFormat string) error {
 	if _, err := os.Stat(imagePath); err == nil {
-		return nil
+		reusable, err := c.isBackedBy(imagePath, backingFile, backingFormat)
+		if err != nil || reusable {
+			return err
+		}
+		if err := os.Remove(imagePath); err != nil {
+			return err
+		}
 	} else if !errors.Is(err, os.ErrNotExist) {
 		return err
 	}
 
 	output, err := c.discCreateFunc(backingFile, backingFormat, imagePath)
-
-	// Cleanup of previous images isn't really necessary as they're all on EmptyDir.
 	if err != nil {
+		// Images on EmptyDir are cleaned up with the pod, but an overlay PVC outlives it and a
+		// partially created image would be picked up on the next start.
+		_ = os.Remove(imagePath)
 		return fmt.Errorf("qemu-img failed with output '%s': %v", string(output), err)
 	}
 
@@ -124,16 +145,38 @@ func (c *ephemeralDiskCreator) CreateBackedImageForVolume(volume v1.Volume, back
 	return err
 }
 
+// isBackedBy reports whether the existing image is a qcow2 overlay of the backing file. An overlay
+// on an overlay PVC outlives the pod and may have been created for a different backing file.
+func (c *ephemeralDiskCreator) isBackedBy(imagePath string, backingFile string, backingFormat string) (bool, error) {
+	info, err := c.diskInfoFunc(imagePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect the existing image %s: %v", imagePath, err)
+	}
+	if info.Format == "qcow2" && info.BackingFile == backingFile && info.BackingFormat == backingFormat {
+		return true, nil
+	}
+	log.Log.Warningf("Recreating image %s since it is a %s image backed by %q (%s) instead of %q (%s)",
+		imagePath, info.Format, info.BackingFile, info.BackingFormat, backingFile, backingFormat)
+	return false, nil
+}
+
 func (c *ephemeralDiskCreator) CreateEphemeralImages(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
 	// The domain is setup to use the COW image instead of the base image. What we have
 	// to do here is only create the image where the domain expects it (GetFilePath)
 	// for each disk that requires it.
 	isBlockVolumes := diskutils.GetEphemeralBackingSourceBlockDevices(domain)
 	for _, volume := range vmi.Spec.Volumes {
-		if volume.VolumeSource.Ephemeral != nil {
-			if err := c.CreateBackedImageForVolume(volume, c.getBackingFilePath(volume.Name, isBlockVolumes[volume.Name]), ephemeralDiskFormat); err != nil {
+		if volume.VolumeSource.Ephemeral == nil {
+			continue
+		}
+		backingFile := c.getBackingFilePath(volume.Name, isBlockVolumes[volume.Name])
+		// An existing overlay on the overlay PVC is reused to keep the changes of previous runs
+		if volume.VolumeSource.Ephemeral.OverlayClaimName != "" {
+			if err := c.createBackedImage(c.GetOverlayClaimFilePath(volume.Name), backingFile, ephemeralDiskFormat); err != nil {
 				return err
 			}
+		} else if err := c.CreateBackedImageForVolume(volume, backingFile, ephemeralDiskFormat); err != nil {
+			return err
 		}
 	}
 
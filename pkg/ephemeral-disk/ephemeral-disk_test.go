@@ -20,6 +20,7 @@
 package ephemeraldisk
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -28,7 +29,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	v1 "kubevirt.io/api/core/v1"
+
 	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/os/disk"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
@@ -70,6 +74,7 @@ var _ = Describe("ContainerDisk", func() {
 			pvcBaseDir:      pvcBaseTempDirPath,
 			blockDevBaseDir: blockDevBaseDir,
 			discCreateFunc:  fakeCreateBackingDisk,
+			diskInfoFunc:    fakeDiskInfo,
 		}
 	})
 
@@ -171,6 +176,66 @@ var _ = Describe("ContainerDisk", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
+
+		Context("With an overlay claim", func() {
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				vmi = libvmi.New(
+					libvmi.WithEphemeralPersistentVolumeClaim("fake-disk", "fake-pvc"),
+				)
+				vmi.Spec.Volumes[0].Ephemeral.OverlayClaimName = "fake-overlay-pvc"
+
+				Expect(createBackingImageForPVC("fake-disk", false)).To(Succeed())
+				Expect(os.Mkdir(filepath.Join(pvcBaseTempDirPath, "fake-disk-overlay"), 0755)).To(Succeed())
+			})
+
+			It("Should create the COW image on the overlay claim", func() {
+				Expect(creator.CreateEphemeralImages(vmi, &api.Domain{})).To(Succeed())
+
+				_, err := os.Stat(filepath.Join(pvcBaseTempDirPath, "fake-disk-overlay", "disk.qcow2"))
+				Expect(err).NotTo(HaveOccurred())
+				_, err = os.Stat(filepath.Join(creator.mountBaseDir, "fake-disk", "disk.qcow2"))
+				Expect(err).To(MatchError(os.ErrNotExist))
+			})
+
+			It("Should keep an existing COW image on the overlay claim", func() {
+				overlayPath := creator.GetOverlayClaimFilePath("fake-disk")
+				previousRun := fakeImage(creator.getBackingFilePath("fake-disk", false), "raw", "previous run")
+				Expect(os.WriteFile(overlayPath, previousRun, 0640)).To(Succeed())
+
+				Expect(creator.CreateEphemeralImages(vmi, &api.Domain{})).To(Succeed())
+
+				Expect(os.ReadFile(overlayPath)).To(Equal(previousRun))
+			})
+
+			DescribeTable("Should recreate an existing COW image on the overlay claim", func(backingFile, backingFormat string) {
+				if backingFile == "" {
+					backingFile = creator.getBackingFilePath("fake-disk", false)
+				}
+				overlayPath := creator.GetOverlayClaimFilePath("fake-disk")
+				Expect(os.WriteFile(overlayPath, fakeImage(backingFile, backingFormat, "previous run"), 0640)).To(Succeed())
+
+				Expect(creator.CreateEphemeralImages(vmi, &api.Domain{})).To(Succeed())
+
+				info, err := fakeDiskInfo(overlayPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(info.BackingFile).To(Equal(creator.getBackingFilePath("fake-disk", false)))
+				Expect(info.BackingFormat).To(Equal("raw"))
+			},
+				Entry("backed by another file", "/var/run/kubevirt-private/vmi-disks/other/disk.img", "raw"),
+				Entry("backed by another format", "", "qcow2"),
+			)
+
+			It("Should fail if the existing COW image on the overlay claim cannot be inspected", func() {
+				overlayPath := creator.GetOverlayClaimFilePath("fake-disk")
+				Expect(os.WriteFile(overlayPath, []byte("corrupted"), 0640)).To(Succeed())
+
+				Expect(creator.CreateEphemeralImages(vmi, &api.Domain{})).To(MatchError(ContainSubstring("failed to inspect the existing image")))
+
+				Expect(os.ReadFile(overlayPath)).To(BeEquivalentTo("corrupted"))
+			})
+		})
 	})
 })
 
@@ -182,10 +247,24 @@ func fakeCreateBackingDisk(backingFile string, backingFormat string, imagePath s
 	if errors.Is(err, os.ErrNotExist) {
 		return nil, err
 	}
-	f, err := os.Create(imagePath)
+	return nil, os.WriteFile(imagePath, fakeImage(backingFile, backingFormat, ""), 0640)
+}
+
+// fakeImage stands in for a qcow2 overlay, its content is the qemu-img info of the overlay
+func fakeImage(backingFile, backingFormat, data string) []byte {
+	image, err := json.Marshal(struct {
+		disk.DiskInfo
+		Data string `json:"data"`
+	}{disk.DiskInfo{Format: "qcow2", BackingFile: backingFile, BackingFormat: backingFormat}, data})
+	Expect(err).ToNot(HaveOccurred())
+	return image
+}
+
+func fakeDiskInfo(imagePath string) (*disk.DiskInfo, error) {
+	image, err := os.ReadFile(imagePath)
 	if err != nil {
 		return nil, err
 	}
-	err = f.Close()
-	return nil, err
+	info := &disk.DiskInfo{}
+	return info, json.Unmarshal(image, info)
 }
@@ -24,6 +24,10 @@ func (m *MockEphemeralDiskImageCreator) GetFilePath(volumeName string) string {
 	return filepath.Join(m.BaseDir, volumeName, "disk.qcow2")
 }
 
+func (m *MockEphemeralDiskImageCreator) GetOverlayClaimFilePath(volumeName string) string {
+	return filepath.Join(m.BaseDir, volumeName+"-overlay", "disk.qcow2")
+}
+
 func (m *MockEphemeralDiskImageCreator) Init() error {
 	return nil
 }
@@ -46,6 +46,8 @@ It has these top-level messages:
 	BackupRequest
 	RedefineCheckpointRequest
 	RedefineCheckpointResponse
+	SEVSNPAttestationReportRequest
+	SEVSNPAttestationReportResponse
 */
 package v1
 
@@ -444,6 +446,7 @@ type VirtualMachineOptions struct {
 	ClusterConfig             *ClusterConfig                        `protobuf:"bytes,7,opt,name=clusterConfig" json:"clusterConfig,omitempty"`
 	InterfaceDomainAttachment map[string]string                     `protobuf:"bytes,8,rep,name=interfaceDomainAttachment" json:"interfaceDomainAttachment,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	InterfaceMigration        map[string]*InterfaceBindingMigration `protobuf:"bytes,9,rep,name=interfaceMigration" json:"interfaceMigration,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	SoftRebootFallback        string                                `protobuf:"bytes,10,opt,name=softRebootFallback" json:"softRebootFallback,omitempty"`
 }
 
 func (m *VirtualMachineOptions) Reset()                    { *m = VirtualMachineOptions{} }
@@ -514,6 +517,13 @@ func (m *VirtualMachineOptions) GetInterfaceMigration() map[string]*InterfaceBin
 	return nil
 }
 
+func (m *VirtualMachineOptions) GetSoftRebootFallback() string {
+	if m != nil {
+		return m.SoftRebootFallback
+	}
+	return ""
+}
+
 type VMIRequest struct {
 	Vmi     *VMI                   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
 	Options *VirtualMachineOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
@@ -827,8 +837,9 @@ func (m *GuestPingResponse) GetResponse() *Response {
 }
 
 type FreezeRequest struct {
-	Vmi                    *VMI  `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
-	UnfreezeTimeoutSeconds int32 `protobuf:"varint,2,opt,name=unfreezeTimeoutSeconds" json:"unfreezeTimeoutSeconds,omitempty"`
+	Vmi                    *VMI     `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	UnfreezeTimeoutSeconds int32    `protobuf:"varint,2,opt,name=unfreezeTimeoutSeconds" json:"unfreezeTimeoutSeconds,omitempty"`
+	Mountpoints            []string `protobuf:"bytes,3,rep,name=mountpoints" json:"mountpoints,omitempty"`
 }
 
 func (m *FreezeRequest) Reset()                    { *m = FreezeRequest{} }
@@ -850,6 +861,13 @@ func (m *FreezeRequest) GetUnfreezeTimeoutSeconds() int32 {
 	return 0
 }
 
+func (m *FreezeRequest) GetMountpoints() []string {
+	if m != nil {
+		return m.Mountpoints
+	}
+	return nil
+}
+
 type MemoryDumpRequest struct {
 	Vmi      *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
 	DumpPath string `protobuf:"bytes,2,opt,name=dumpPath" json:"dumpPath,omitempty"`
@@ -1074,6 +1092,58 @@ func (m *RedefineCheckpointResponse) GetCheckpointInvalid() bool {
 	return false
 }
 
+type SEVSNPAttestationReportRequest struct {
+	Vmi     *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Options []byte `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *SEVSNPAttestationReportRequest) Reset()         { *m = SEVSNPAttestationReportRequest{} }
+func (m *SEVSNPAttestationReportRequest) String() string { return proto.CompactTextString(m) }
+func (*SEVSNPAttestationReportRequest) ProtoMessage()    {}
+func (*SEVSNPAttestationReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{36}
+}
+
+func (m *SEVSNPAttestationReportRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *SEVSNPAttestationReportRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type SEVSNPAttestationReportResponse struct {
+	Response          *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	AttestationReport []byte    `protobuf:"bytes,2,opt,name=attestationReport,proto3" json:"attestationReport,omitempty"`
+}
+
+func (m *SEVSNPAttestationReportResponse) Reset()         { *m = SEVSNPAttestationReportResponse{} }
+func (m *SEVSNPAttestationReportResponse) String() string { return proto.CompactTextString(m) }
+func (*SEVSNPAttestationReportResponse) ProtoMessage()    {}
+func (*SEVSNPAttestationReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{37}
+}
+
+func (m *SEVSNPAttestationReportResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *SEVSNPAttestationReportResponse) GetAttestationReport() []byte {
+	if m != nil {
+		return m.AttestationReport
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*QemuVersionResponse)(nil), "kubevirt.cmd.v1.QemuVersionResponse")
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
@@ -1111,6 +1181,8 @@ func init() {
 	proto.RegisterType((*BackupRequest)(nil), "kubevirt.cmd.v1.BackupRequest")
 	proto.RegisterType((*RedefineCheckpointRequest)(nil), "kubevirt.cmd.v1.RedefineCheckpointRequest")
 	proto.RegisterType((*RedefineCheckpointResponse)(nil), "kubevirt.cmd.v1.RedefineCheckpointResponse")
+	proto.RegisterType((*SEVSNPAttestationReportRequest)(nil), "kubevirt.cmd.v1.SEVSNPAttestationReportRequest")
+	proto.RegisterType((*SEVSNPAttestationReportResponse)(nil), "kubevirt.cmd.v1.SEVSNPAttestationReportResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1159,6 +1231,9 @@ type CmdClient interface {
 	GetScreenshot(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*ScreenshotResponse, error)
 	BackupVirtualMachine(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error)
 	RedefineCheckpoint(ctx context.Context, in *RedefineCheckpointRequest, opts ...grpc.CallOption) (*RedefineCheckpointResponse, error)
+	GenerateDomain(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*DomainResponse, error)
+	InjectNMIVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	GetSEVSNPAttestationReport(ctx context.Context, in *SEVSNPAttestationReportRequest, opts ...grpc.CallOption) (*SEVSNPAttestationReportResponse, error)
 }
 
 type cmdClient struct {
@@ -1484,6 +1559,33 @@ func (c *cmdClient) RedefineCheckpoint(ctx context.Context, in *RedefineCheckpoi
 	return out, nil
 }
 
+func (c *cmdClient) GenerateDomain(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*DomainResponse, error) {
+	out := new(DomainResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GenerateDomain", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) InjectNMIVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/InjectNMIVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GetSEVSNPAttestationReport(ctx context.Context, in *SEVSNPAttestationReportRequest, opts ...grpc.CallOption) (*SEVSNPAttestationReportResponse, error) {
+	out := new(SEVSNPAttestationReportResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetSEVSNPAttestationReport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Cmd service
 
 type CmdServer interface {
@@ -1522,6 +1624,9 @@ type CmdServer interface {
 	GetScreenshot(context.Context, *VMIRequest) (*ScreenshotResponse, error)
 	BackupVirtualMachine(context.Context, *BackupRequest) (*Response, error)
 	RedefineCheckpoint(context.Context, *RedefineCheckpointRequest) (*RedefineCheckpointResponse, error)
+	GenerateDomain(context.Context, *VMIRequest) (*DomainResponse, error)
+	InjectNMIVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	GetSEVSNPAttestationReport(context.Context, *SEVSNPAttestationReportRequest) (*SEVSNPAttestationReportResponse, error)
 }
 
 func RegisterCmdServer(s *grpc.Server, srv CmdServer) {
@@ -2158,6 +2263,60 @@ func _Cmd_RedefineCheckpoint_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_GenerateDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GenerateDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GenerateDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GenerateDomain(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_InjectNMIVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).InjectNMIVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/InjectNMIVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).InjectNMIVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_GetSEVSNPAttestationReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SEVSNPAttestationReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GetSEVSNPAttestationReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GetSEVSNPAttestationReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GetSEVSNPAttestationReport(ctx, req.(*SEVSNPAttestationReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cmd_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubevirt.cmd.v1.Cmd",
 	HandlerType: (*CmdServer)(nil),
@@ -2302,6 +2461,18 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RedefineCheckpoint",
 			Handler:    _Cmd_RedefineCheckpoint_Handler,
 		},
+		{
+			MethodName: "GenerateDomain",
+			Handler:    _Cmd_GenerateDomain_Handler,
+		},
+		{
+			MethodName: "InjectNMIVirtualMachine",
+			Handler:    _Cmd_InjectNMIVirtualMachine_Handler,
+		},
+		{
+			MethodName: "GetSEVSNPAttestationReport",
+			Handler:    _Cmd_GetSEVSNPAttestationReport_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/handler-launcher-com/cmd/v1/cmd.proto",
@@ -2310,131 +2481,138 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2011 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xef, 0x72, 0x1b, 0xb7,
-	0x11, 0x37, 0x45, 0x4a, 0x26, 0x57, 0x7f, 0x62, 0xc3, 0x92, 0x7c, 0x62, 0x6a, 0x5b, 0x45, 0x3b,
-	0xae, 0xd3, 0x26, 0x52, 0xed, 0x38, 0x99, 0x8e, 0xa7, 0x93, 0xb1, 0x45, 0xd1, 0x8a, 0x12, 0x53,
-	0xa6, 0x8f, 0x92, 0x3c, 0x4d, 0x9b, 0xc9, 0x40, 0x77, 0x10, 0x89, 0xea, 0x0e, 0x60, 0x0e, 0x38,
-	0xc6, 0xcc, 0xa7, 0x76, 0xd2, 0xe9, 0x87, 0xce, 0xf4, 0x79, 0xfa, 0x0c, 0x7d, 0x82, 0x7e, 0xeb,
-	0xb3, 0x64, 0x80, 0xbb, 0xa3, 0x8e, 0xbc, 0x3b, 0xd1, 0x1a, 0xf2, 0x13, 0x01, 0xec, 0xee, 0x6f,
-	0x17, 0x8b, 0xc5, 0x62, 0xf7, 0x08, 0x1f, 0xf5, 0x2f, 0xba, 0xbb, 0x3d, 0xc2, 0x5d, 0x8f, 0x06,
-	0x9f, 0x78, 0x24, 0xe4, 0x4e, 0x8f, 0x06, 0x9f, 0x38, 0xc2, 0xdf, 0x75, 0x7c, 0x77, 0x77, 0xf0,
-	0x58, 0xff, 0xec, 0xf4, 0x03, 0xa1, 0x04, 0xfa, 0xe0, 0x22, 0x3c, 0xa3, 0x03, 0x16, 0xa8, 0x1d,
-	0xbd, 0x36, 0x78, 0x8c, 0xcf, 0xe1, 0xce, 0x1b, 0xea, 0x87, 0xa7, 0x34, 0x90, 0x4c, 0x70, 0x9b,
-	0xca, 0xbe, 0xe0, 0x92, 0xa2, 0xcf, 0xa0, 0x1a, 0xc4, 0x63, 0xab, 0xb4, 0x5d, 0x7a, 0xb4, 0xfc,
-	0x64, 0x6b, 0x67, 0x42, 0x74, 0x27, 0x61, 0xb6, 0x47, 0xac, 0xc8, 0x82, 0x9b, 0x83, 0x08, 0xc9,
-	0x5a, 0xd8, 0x2e, 0x3d, 0xaa, 0xd9, 0xc9, 0x14, 0x3f, 0x80, 0xf2, 0x69, 0xeb, 0xd0, 0x30, 0xf8,
-	0xec, 0x2b, 0x29, 0xb8, 0x81, 0x5d, 0xb1, 0x93, 0x29, 0x7e, 0x0c, 0xe5, 0x46, 0xfb, 0x04, 0xad,
-	0xc1, 0x02, 0x73, 0x0d, 0x6d, 0xd5, 0x5e, 0x60, 0x2e, 0xaa, 0x43, 0x55, 0xb2, 0x33, 0x8f, 0xf1,
-	0xae, 0xb4, 0x16, 0xb6, 0xcb, 0x8f, 0x56, 0xed, 0xd1, 0x1c, 0xef, 0xc2, 0xcd, 0x4e, 0x34, 0xce,
-	0x88, 0xad, 0xc3, 0xe2, 0x80, 0x78, 0x21, 0x35, 0x66, 0x54, 0xec, 0x68, 0x82, 0x9b, 0xb0, 0xd8,
-	0x26, 0x5d, 0x2a, 0x35, 0xd9, 0x11, 0x21, 0x57, 0x46, 0xa2, 0x62, 0x47, 0x13, 0x84, 0xa0, 0x12,
-	0x72, 0xa6, 0x62, 0xd3, 0xcd, 0x58, 0xaf, 0x49, 0xf6, 0x23, 0xb5, 0xca, 0x06, 0xda, 0x8c, 0xf1,
-	0x53, 0x58, 0x6a, 0x51, 0x5f, 0x04, 0x43, 0xb4, 0x09, 0x4b, 0xc4, 0x4f, 0x01, 0xc5, 0xb3, 0x3c,
-	0x24, 0xfc, 0xbf, 0x12, 0x54, 0x1a, 0xd4, 0xf3, 0x32, 0xb6, 0xee, 0xc2, 0x92, 0x6f, 0xe0, 0x0c,
-	0xfb, 0xf2, 0x93, 0xbb, 0x19, 0x4f, 0x47, 0xda, 0xec, 0x98, 0x0d, 0x7d, 0x0c, 0x8b, 0x7d, 0xbd,
-	0x0d, 0xab, 0xbc, 0x5d, 0x7e, 0xb4, 0xfc, 0x64, 0x33, 0xc3, 0x6f, 0x36, 0x69, 0x47, 0x4c, 0xe8,
-	0x73, 0xa8, 0xb9, 0x4c, 0x2a, 0xc2, 0x1d, 0x2a, 0xad, 0x8a, 0x91, 0xb0, 0x32, 0x12, 0xb1, 0x1f,
-	0xed, 0x4b, 0x56, 0xf4, 0x08, 0x2a, 0x4e, 0x3f, 0x94, 0xd6, 0xa2, 0x11, 0x59, 0xcf, 0x88, 0x34,
-	0xda, 0x27, 0xb6, 0xe1, 0xc0, 0xcf, 0xa1, 0x7a, 0x2c, 0xfa, 0xc2, 0x13, 0xdd, 0x21, 0x7a, 0x0a,
-	0xc0, 0x43, 0x9f, 0x7c, 0xe7, 0x50, 0xcf, 0x93, 0x56, 0xc9, 0xc8, 0x6e, 0x64, 0x65, 0xa9, 0xe7,
-	0xd9, 0x35, 0xcd, 0xa8, 0x47, 0x12, 0xff, 0xab, 0x04, 0x4b, 0x9d, 0xd6, 0x1e, 0x13, 0x12, 0x61,
-	0x58, 0xf1, 0x09, 0x0f, 0xcf, 0x89, 0xa3, 0xc2, 0x80, 0x06, 0xc6, 0x4f, 0x35, 0x7b, 0x6c, 0x4d,
-	0x47, 0x51, 0x3f, 0x10, 0x6e, 0xe8, 0x24, 0x1e, 0x4e, 0xa6, 0xe9, 0x00, 0x2c, 0x8f, 0x05, 0x20,
-	0xba, 0x05, 0x65, 0x79, 0x11, 0x5a, 0x15, 0xb3, 0xaa, 0x87, 0xfa, 0xf0, 0xce, 0x89, 0xcf, 0xbc,
-	0xa1, 0xb5, 0x68, 0x16, 0xe3, 0x19, 0xfe, 0x67, 0x09, 0xaa, 0xfb, 0x4c, 0x5e, 0x1c, 0xf2, 0x73,
-	0x61, 0x98, 0x44, 0xe0, 0x13, 0x15, 0x1b, 0x12, 0xcf, 0xd0, 0x36, 0x2c, 0x9f, 0x11, 0xe7, 0x82,
-	0xf1, 0xee, 0x4b, 0xe6, 0xd1, 0xd8, 0x8c, 0xf4, 0x12, 0xba, 0x0f, 0xa0, 0xed, 0x25, 0x5e, 0x27,
-	0x89, 0x9f, 0x8a, 0x9d, 0x5a, 0xd1, 0x08, 0xda, 0x25, 0x09, 0x43, 0xc5, 0x30, 0xa4, 0x97, 0xf0,
-	0x7f, 0x16, 0x60, 0xb5, 0xe1, 0x85, 0x52, 0xd1, 0xa0, 0x21, 0xf8, 0x39, 0xeb, 0xa2, 0x1d, 0x40,
-	0xcd, 0x77, 0x7d, 0xc2, 0x5d, 0x6d, 0x9f, 0x6c, 0x72, 0x72, 0xe6, 0xd1, 0x28, 0x94, 0xaa, 0x76,
-	0x0e, 0x05, 0xfd, 0x11, 0xb6, 0x5e, 0x06, 0x94, 0xea, 0x78, 0xb0, 0x69, 0x5f, 0x04, 0x8a, 0xf1,
-	0xee, 0x3e, 0x93, 0x91, 0xd8, 0x82, 0x11, 0x2b, 0x66, 0x40, 0xcf, 0xc0, 0xda, 0x13, 0x4e, 0x4f,
-	0xee, 0x33, 0xd9, 0xf7, 0xc8, 0xf0, 0xa5, 0x08, 0x9a, 0x2f, 0x0f, 0x0f, 0x42, 0x2a, 0x95, 0x34,
-	0xfb, 0xa9, 0xda, 0x85, 0x74, 0x2d, 0xdb, 0xa1, 0x01, 0x23, 0x5e, 0x43, 0x70, 0x29, 0x3c, 0xfa,
-	0x4a, 0x5c, 0x2a, 0xae, 0x44, 0xb2, 0x45, 0x74, 0xf4, 0x1c, 0x3e, 0x6c, 0x37, 0x0e, 0x8f, 0x4e,
-	0x5a, 0x2f, 0x5e, 0xfc, 0x40, 0x02, 0x9a, 0xc4, 0x56, 0xb2, 0xdd, 0x45, 0x23, 0x7e, 0x15, 0x0b,
-	0xfe, 0x14, 0xb6, 0x0e, 0xb9, 0xa2, 0xc1, 0x39, 0x71, 0xe8, 0x1e, 0xe3, 0x2e, 0xe3, 0xdd, 0x16,
-	0xeb, 0x06, 0x44, 0xe9, 0x48, 0xd8, 0xd4, 0xd7, 0x57, 0xf5, 0x84, 0x9b, 0x1c, 0x69, 0x34, 0xc3,
-	0xff, 0xbf, 0x09, 0x1b, 0xa7, 0x91, 0xfb, 0x5b, 0xc4, 0xe9, 0x31, 0x4e, 0x5f, 0xf7, 0xb5, 0x80,
-	0x44, 0x5f, 0xc3, 0xfa, 0x38, 0x21, 0x8a, 0xd5, 0x38, 0x33, 0x66, 0xef, 0x6b, 0x44, 0xb6, 0x73,
-	0x85, 0xd0, 0x53, 0xd8, 0x68, 0x51, 0x7f, 0x8f, 0x78, 0x9e, 0x10, 0xbc, 0xa3, 0x88, 0x92, 0x6d,
-	0x1a, 0x30, 0x11, 0x9d, 0xc7, 0xaa, 0x9d, 0x4f, 0x44, 0xbf, 0x87, 0x3b, 0xed, 0x80, 0xea, 0x75,
-	0x87, 0x28, 0xea, 0x9e, 0x0a, 0x2f, 0xf4, 0xe3, 0x0c, 0x50, 0xb3, 0xf3, 0x48, 0x3a, 0x85, 0xab,
-	0xd8, 0x2d, 0xc6, 0xe3, 0x79, 0x29, 0x3c, 0xf1, 0x9b, 0x3d, 0x62, 0x45, 0x1d, 0xa8, 0x99, 0x10,
-	0xd2, 0xd1, 0x1f, 0xdf, 0xfd, 0xcf, 0x32, 0x72, 0xb9, 0x6e, 0xda, 0x19, 0xc9, 0x35, 0xb9, 0x0a,
-	0x86, 0xf6, 0x25, 0x4e, 0x41, 0xdc, 0x2e, 0x15, 0xc6, 0xed, 0x3e, 0xac, 0x3a, 0xe9, 0xc0, 0xb7,
-	0x6e, 0x9a, 0x0d, 0xdc, 0xcf, 0x26, 0x92, 0x34, 0x97, 0x3d, 0x2e, 0x84, 0x7e, 0x2a, 0xc1, 0x16,
-	0x4b, 0xc2, 0x60, 0x5f, 0xf8, 0x84, 0xf1, 0x17, 0x4a, 0x11, 0xa7, 0xe7, 0x53, 0xae, 0xac, 0xaa,
-	0xd9, 0x5b, 0xf3, 0x3d, 0xf7, 0x76, 0x58, 0x84, 0x13, 0xed, 0xb5, 0x58, 0x0f, 0xe2, 0x80, 0x46,
-	0xc4, 0x51, 0x10, 0x5a, 0x35, 0xa3, 0xfd, 0x8b, 0xeb, 0x6a, 0x1f, 0x01, 0x44, 0x6a, 0x73, 0x90,
-	0xeb, 0x6f, 0x61, 0x6d, 0xfc, 0x20, 0x74, 0xea, 0xbb, 0xa0, 0xc3, 0x38, 0xda, 0xf5, 0x10, 0xed,
-	0xa6, 0x9f, 0xc7, 0xbc, 0xc0, 0x48, 0xf2, 0x5f, 0xfc, 0x72, 0x3e, 0x5b, 0xf8, 0x43, 0xa9, 0xfe,
-	0x0a, 0xee, 0x5f, 0xed, 0x85, 0x1c, 0x45, 0x63, 0xef, 0x70, 0x2d, 0x8d, 0xf6, 0x3d, 0xdc, 0x2d,
-	0xd8, 0x55, 0x0e, 0xcc, 0xf3, 0x71, 0x7b, 0x7f, 0x9b, 0xb1, 0xb7, 0xf0, 0xb6, 0xa7, 0x54, 0xe2,
-	0x01, 0xc0, 0x69, 0xeb, 0xd0, 0xa6, 0xdf, 0xeb, 0x14, 0x85, 0x1e, 0x42, 0x79, 0xe0, 0xb3, 0xf8,
-	0x0e, 0x67, 0x9f, 0x37, 0xcd, 0xa9, 0x19, 0xd0, 0x73, 0xb8, 0x29, 0xa2, 0x63, 0x88, 0xb5, 0x3f,
-	0x7c, 0xbf, 0x43, 0xb3, 0x13, 0x31, 0x7c, 0x0c, 0xb7, 0x2e, 0xed, 0xb9, 0xa6, 0x76, 0x6b, 0x5c,
-	0xfb, 0xca, 0x25, 0xea, 0x4f, 0x25, 0x58, 0x6e, 0xbe, 0xa3, 0x4e, 0x82, 0x78, 0x1f, 0xc0, 0x35,
-	0xa7, 0x72, 0x44, 0x7c, 0x1a, 0x3b, 0x2f, 0xb5, 0xa2, 0x91, 0x1a, 0xc2, 0xf7, 0x09, 0x77, 0x93,
-	0x47, 0x33, 0x9e, 0xea, 0x6a, 0xe5, 0x45, 0xd0, 0x4d, 0x92, 0x89, 0x19, 0xa3, 0x87, 0xb0, 0xa6,
-	0x98, 0x4f, 0x45, 0xa8, 0x3a, 0xd4, 0x11, 0xdc, 0x95, 0x26, 0x87, 0x2c, 0xda, 0x13, 0xab, 0x78,
-	0x0d, 0x56, 0x9a, 0x7e, 0x5f, 0x0d, 0x63, 0x2b, 0xf0, 0x17, 0x50, 0xb5, 0x53, 0xd5, 0xa0, 0x0c,
-	0x1d, 0x87, 0x4a, 0x19, 0x3f, 0x51, 0xc9, 0x54, 0x53, 0x7c, 0x2a, 0x25, 0xe9, 0x26, 0x81, 0x91,
-	0x4c, 0xf1, 0x77, 0xb0, 0x16, 0xc5, 0xd6, 0xac, 0xa5, 0xe8, 0x26, 0x2c, 0x45, 0x9b, 0x8f, 0x35,
-	0xc4, 0x33, 0xcc, 0xe1, 0x4e, 0xa4, 0xc0, 0x64, 0xd7, 0x59, 0xb5, 0x6c, 0xc3, 0xb2, 0x7b, 0x89,
-	0x96, 0x94, 0x01, 0xa9, 0x25, 0xfc, 0x0e, 0x6e, 0x9b, 0x27, 0xd1, 0xdc, 0xa6, 0x19, 0xb5, 0x7d,
-	0x0c, 0xb7, 0xbb, 0x93, 0x58, 0xb1, 0xce, 0x2c, 0x01, 0xff, 0xa3, 0x04, 0x1b, 0x46, 0xf5, 0x89,
-	0xa4, 0xc1, 0x2b, 0x26, 0xd5, 0xac, 0xea, 0x9f, 0xc2, 0x46, 0x37, 0x0f, 0x2f, 0x36, 0x21, 0x9f,
-	0x88, 0xff, 0x5d, 0x02, 0xcb, 0x98, 0xa1, 0xab, 0x22, 0x39, 0x94, 0x8a, 0xfa, 0x33, 0xbb, 0xfd,
-	0x19, 0x58, 0xdd, 0x02, 0xc8, 0xd8, 0x98, 0x42, 0x3a, 0x1e, 0xc2, 0x4a, 0x74, 0x6d, 0x66, 0x33,
-	0xa1, 0x0e, 0x55, 0xfa, 0x8e, 0xa9, 0x86, 0x70, 0x23, 0x95, 0x8b, 0xf6, 0x68, 0xae, 0x63, 0x4f,
-	0x2a, 0xf7, 0x75, 0xa8, 0xe2, 0x22, 0x34, 0x9e, 0xe1, 0x6f, 0xe0, 0x96, 0xf1, 0x44, 0x5b, 0x97,
-	0xda, 0xef, 0x79, 0x6d, 0xb3, 0x17, 0x71, 0x21, 0xf7, 0x22, 0x7e, 0x15, 0xc7, 0x59, 0x84, 0x3d,
-	0xd3, 0xde, 0xb0, 0x80, 0x55, 0x5d, 0x15, 0xfe, 0x48, 0xaf, 0x9b, 0xad, 0x3e, 0x87, 0xcd, 0x90,
-	0x9f, 0x1b, 0xd1, 0xe3, 0x3c, 0xa3, 0x0b, 0xa8, 0xf8, 0x2d, 0xdc, 0x8e, 0x7a, 0x9c, 0xfd, 0xd0,
-	0xef, 0x5f, 0x57, 0x69, 0x1d, 0xaa, 0x6e, 0xe8, 0xf7, 0xdb, 0x44, 0xf5, 0xe2, 0xc3, 0x1f, 0xcd,
-	0xf1, 0x19, 0x7c, 0xd0, 0x69, 0x9e, 0xce, 0xe3, 0xee, 0xe9, 0x64, 0x46, 0x07, 0xa6, 0x2a, 0x8a,
-	0x13, 0x71, 0x3c, 0xc5, 0x7f, 0x2b, 0xc1, 0xd6, 0x2b, 0xd3, 0x75, 0xb7, 0x28, 0x91, 0x61, 0x40,
-	0xf5, 0x83, 0x38, 0x87, 0xab, 0xee, 0x4d, 0x62, 0xc6, 0x8a, 0xb3, 0x04, 0xfc, 0xad, 0xae, 0x77,
-	0xff, 0x4a, 0x1d, 0x15, 0xd9, 0xd1, 0xa1, 0x4e, 0x40, 0xd5, 0xfc, 0x9e, 0x1a, 0x09, 0x9b, 0xfb,
-	0x2c, 0x50, 0x43, 0x9b, 0x28, 0x3a, 0x97, 0xb4, 0x89, 0x61, 0xc5, 0x4d, 0x00, 0x5b, 0x67, 0x91,
-	0xbe, 0xb2, 0x3d, 0xb6, 0x86, 0x25, 0xa0, 0x8e, 0x13, 0x50, 0xca, 0x65, 0x4f, 0xcc, 0xec, 0x4e,
-	0x04, 0x15, 0x9f, 0xf9, 0x49, 0x72, 0x30, 0x63, 0xbd, 0xe6, 0x12, 0x45, 0xcc, 0x1d, 0x5d, 0xb1,
-	0xcd, 0x18, 0xbf, 0x81, 0xd5, 0x3d, 0xe2, 0x5c, 0x84, 0xfd, 0xf9, 0x39, 0xcf, 0x81, 0x2d, 0x9b,
-	0xba, 0xf4, 0x9c, 0x71, 0xda, 0xe8, 0x51, 0xe7, 0xa2, 0x2f, 0x18, 0xbf, 0xf6, 0xd9, 0xdc, 0x07,
-	0x70, 0x46, 0xc2, 0xb1, 0x86, 0xd4, 0x0a, 0xfe, 0x7b, 0x09, 0xea, 0x79, 0x5a, 0x66, 0x0e, 0xc2,
-	0x4b, 0x1d, 0x87, 0x7c, 0x40, 0x3c, 0x96, 0xb4, 0x8d, 0x59, 0xc2, 0x93, 0xff, 0xde, 0x85, 0x72,
-	0xc3, 0x77, 0xd1, 0x11, 0xa0, 0xce, 0x90, 0x3b, 0xe3, 0x45, 0x11, 0xfa, 0x30, 0x77, 0x73, 0x91,
-	0x1b, 0xea, 0xc5, 0xd6, 0xe0, 0x1b, 0xe8, 0x35, 0xdc, 0x69, 0x93, 0x50, 0xd2, 0xb9, 0x01, 0xbe,
-	0x81, 0x8d, 0x13, 0xde, 0x9f, 0x2b, 0x64, 0x07, 0xd6, 0xa3, 0x8c, 0x39, 0x81, 0x98, 0xed, 0x58,
-	0xc6, 0x12, 0xeb, 0xd5, 0xa0, 0x36, 0x6c, 0x9e, 0xc4, 0xf9, 0x72, 0x9e, 0xce, 0xb4, 0xa9, 0xa4,
-	0x6a, 0x6e, 0x80, 0xc7, 0x60, 0x75, 0xc4, 0xb9, 0xb2, 0xe9, 0x99, 0x10, 0xf3, 0x43, 0xb5, 0x61,
-	0xb3, 0xd3, 0x0b, 0x95, 0x2b, 0x7e, 0xe0, 0x73, 0xc3, 0x3c, 0x02, 0xf4, 0x35, 0xf3, 0xbc, 0xb9,
-	0xe1, 0xb5, 0x61, 0x7d, 0x9f, 0x7a, 0x54, 0xcd, 0xef, 0x70, 0xde, 0xc2, 0x46, 0xd4, 0x28, 0x4c,
-	0x42, 0xfe, 0x32, 0xfb, 0x49, 0x70, 0xa2, 0xa1, 0x98, 0x7a, 0xea, 0xfa, 0x4a, 0x8e, 0x84, 0x8e,
-	0x49, 0xd0, 0xa5, 0x6a, 0x06, 0x4b, 0xff, 0x04, 0xf7, 0x1a, 0x84, 0x3b, 0x74, 0xc2, 0x9b, 0x97,
-	0x1f, 0x59, 0x66, 0x3b, 0x7a, 0xd6, 0xe5, 0xc4, 0x8b, 0x8c, 0x6c, 0x0b, 0xb7, 0xe1, 0x51, 0xc2,
-	0xc3, 0xfe, 0x0c, 0x98, 0x7f, 0x86, 0x07, 0x2f, 0x19, 0x27, 0x1e, 0x9b, 0xbc, 0x49, 0xf3, 0x30,
-	0xf8, 0x08, 0xd0, 0x97, 0x42, 0xf5, 0xbd, 0xb0, 0xfb, 0xa5, 0x90, 0x6a, 0x9f, 0x0e, 0x98, 0x43,
-	0xe5, 0x0c, 0x78, 0x2d, 0xa8, 0x1d, 0x50, 0x15, 0x35, 0x29, 0xe8, 0x5e, 0x86, 0x33, 0xdd, 0x6e,
-	0xd5, 0x1f, 0x64, 0x3b, 0xf7, 0xb1, 0xee, 0xc9, 0x04, 0xd5, 0xda, 0x08, 0xce, 0x3c, 0xde, 0xd3,
-	0x30, 0x7f, 0x5d, 0x80, 0x39, 0xf6, 0xf2, 0x9b, 0x9c, 0xb7, 0x72, 0x40, 0xd5, 0xa8, 0xb9, 0x99,
-	0x06, 0x8b, 0x33, 0xe4, 0x4c, 0x5f, 0x64, 0x40, 0xab, 0x07, 0xd4, 0x34, 0x11, 0x53, 0xed, 0x7c,
-	0x98, 0x0f, 0x98, 0x69, 0x40, 0x6e, 0xa0, 0xbf, 0x18, 0x17, 0xa4, 0x9a, 0x81, 0x69, 0xd0, 0x1f,
-	0xe5, 0x43, 0xe7, 0xb5, 0x13, 0x37, 0xd0, 0x1e, 0x54, 0x74, 0xd1, 0x3d, 0x0d, 0xf3, 0xca, 0x33,
-	0x6f, 0x42, 0x45, 0x37, 0x25, 0xe8, 0x17, 0x59, 0x8c, 0xcb, 0x16, 0xbf, 0x7e, 0xaf, 0x80, 0x9a,
-	0x4a, 0xc6, 0xb5, 0x51, 0x13, 0x90, 0x93, 0x34, 0x26, 0x9b, 0x8f, 0xa2, 0x33, 0x49, 0xf7, 0x10,
-	0xe6, 0xf6, 0x58, 0x13, 0xb7, 0x66, 0x54, 0xab, 0x23, 0x5c, 0xf0, 0x67, 0x45, 0xaa, 0x90, 0x9f,
-	0x96, 0xf3, 0xf4, 0xd9, 0xa4, 0xfe, 0x83, 0xba, 0x7e, 0x78, 0xe6, 0xfc, 0x81, 0x15, 0xe7, 0x91,
-	0x4c, 0x19, 0xd2, 0x68, 0x9f, 0xc8, 0x19, 0x1f, 0xbb, 0x0c, 0x66, 0xfc, 0x5f, 0xd0, 0x2c, 0x6f,
-	0x32, 0x1c, 0x50, 0x15, 0xf7, 0x29, 0xd3, 0xb6, 0xbf, 0x9d, 0xfd, 0xda, 0x3c, 0xde, 0xe0, 0xe0,
-	0x1b, 0x88, 0xc0, 0xfa, 0x01, 0x55, 0x99, 0x9e, 0xe4, 0x6a, 0x13, 0xb3, 0x1f, 0xd5, 0x0a, 0x9b,
-	0x1a, 0x7c, 0x03, 0x7d, 0x0b, 0x28, 0xdb, 0x71, 0xa0, 0xbc, 0x0f, 0x73, 0x05, 0x6d, 0xc9, 0xd5,
-	0x2e, 0x71, 0xe0, 0xee, 0x28, 0x69, 0x8d, 0xb7, 0x1e, 0xd3, 0xfc, 0xf3, 0x9b, 0x9c, 0x6f, 0x99,
-	0x79, 0xad, 0x8b, 0xc9, 0x35, 0xab, 0xda, 0xef, 0xa3, 0x26, 0xe3, 0x6a, 0xff, 0xfc, 0x2a, 0xeb,
-	0xf8, 0x4c, 0x7b, 0x12, 0x55, 0x82, 0x51, 0x07, 0x31, 0xb5, 0x12, 0x1c, 0x6b, 0x34, 0xae, 0x76,
-	0x87, 0x00, 0x94, 0xad, 0xee, 0x73, 0xbc, 0x5d, 0xd8, 0x68, 0xd4, 0x7f, 0xf7, 0x5e, 0xbc, 0x89,
-	0xc2, 0xbd, 0xca, 0x37, 0x0b, 0x83, 0xc7, 0x67, 0x4b, 0xe6, 0x4f, 0xe3, 0x4f, 0x7f, 0x0e, 0x00,
-	0x00, 0xff, 0xff, 0x46, 0x9c, 0xe3, 0x6c, 0x61, 0x1e, 0x00, 0x00,
+	// 2115 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xef, 0x72, 0x1b, 0xb7,
+	0x11, 0x37, 0x45, 0x4a, 0xa6, 0x56, 0x7f, 0x62, 0xc3, 0x92, 0x7c, 0x62, 0x6a, 0x59, 0x45, 0x3b,
+	0xae, 0xd3, 0x26, 0x52, 0xec, 0x38, 0x99, 0x8e, 0xa7, 0x93, 0xb1, 0x45, 0x51, 0x8a, 0x12, 0x53,
+	0xa6, 0x8f, 0x92, 0x3c, 0x4d, 0x9b, 0xc9, 0x40, 0x77, 0x10, 0x85, 0xea, 0x0e, 0x60, 0x0e, 0x38,
+	0xc6, 0xf4, 0xa7, 0x76, 0xd2, 0xc9, 0x87, 0xce, 0xb4, 0x33, 0x7d, 0x9a, 0x3e, 0x4a, 0x5f, 0xa4,
+	0x0f, 0xd0, 0x01, 0xee, 0x8e, 0x3a, 0xf2, 0xee, 0x28, 0x69, 0xc8, 0x4f, 0x3e, 0x60, 0x77, 0x7f,
+	0xbb, 0x58, 0x2c, 0x16, 0xf8, 0x51, 0x86, 0x8f, 0xba, 0x17, 0x9d, 0xed, 0x73, 0xc2, 0x5d, 0x8f,
+	0x06, 0x9f, 0x78, 0x24, 0xe4, 0xce, 0x39, 0x0d, 0x3e, 0x71, 0x84, 0xbf, 0xed, 0xf8, 0xee, 0x76,
+	0xef, 0x89, 0xfe, 0x67, 0xab, 0x1b, 0x08, 0x25, 0xd0, 0x07, 0x17, 0xe1, 0x29, 0xed, 0xb1, 0x40,
+	0x6d, 0xe9, 0xb9, 0xde, 0x13, 0x7c, 0x06, 0xf7, 0xde, 0x50, 0x3f, 0x3c, 0xa1, 0x81, 0x64, 0x82,
+	0xdb, 0x54, 0x76, 0x05, 0x97, 0x14, 0x7d, 0x0e, 0xd5, 0x20, 0xfe, 0xb6, 0x4a, 0x9b, 0xa5, 0xc7,
+	0x0b, 0x4f, 0xd7, 0xb7, 0x46, 0x4c, 0xb7, 0x12, 0x65, 0x7b, 0xa0, 0x8a, 0x2c, 0xb8, 0xdd, 0x8b,
+	0x90, 0xac, 0x99, 0xcd, 0xd2, 0xe3, 0x79, 0x3b, 0x19, 0xe2, 0x87, 0x50, 0x3e, 0x69, 0x1e, 0x18,
+	0x05, 0x9f, 0x7d, 0x2d, 0x05, 0x37, 0xb0, 0x8b, 0x76, 0x32, 0xc4, 0x4f, 0xa0, 0x5c, 0x6f, 0x1d,
+	0xa3, 0x65, 0x98, 0x61, 0xae, 0x91, 0x2d, 0xd9, 0x33, 0xcc, 0x45, 0x35, 0xa8, 0x4a, 0x76, 0xea,
+	0x31, 0xde, 0x91, 0xd6, 0xcc, 0x66, 0xf9, 0xf1, 0x92, 0x3d, 0x18, 0xe3, 0x6d, 0xb8, 0xdd, 0x8e,
+	0xbe, 0x33, 0x66, 0x2b, 0x30, 0xdb, 0x23, 0x5e, 0x48, 0x4d, 0x18, 0x15, 0x3b, 0x1a, 0xe0, 0x06,
+	0xcc, 0xb6, 0x48, 0x87, 0x4a, 0x2d, 0x76, 0x44, 0xc8, 0x95, 0xb1, 0xa8, 0xd8, 0xd1, 0x00, 0x21,
+	0xa8, 0x84, 0x9c, 0xa9, 0x38, 0x74, 0xf3, 0xad, 0xe7, 0x24, 0x7b, 0x4f, 0xad, 0xb2, 0x81, 0x36,
+	0xdf, 0xf8, 0x19, 0xcc, 0x35, 0xa9, 0x2f, 0x82, 0x3e, 0x5a, 0x83, 0x39, 0xe2, 0xa7, 0x80, 0xe2,
+	0x51, 0x1e, 0x12, 0xfe, 0x6f, 0x09, 0x2a, 0x75, 0xea, 0x79, 0x99, 0x58, 0xb7, 0x61, 0xce, 0x37,
+	0x70, 0x46, 0x7d, 0xe1, 0xe9, 0xfd, 0x4c, 0xa6, 0x23, 0x6f, 0x76, 0xac, 0x86, 0x3e, 0x86, 0xd9,
+	0xae, 0x5e, 0x86, 0x55, 0xde, 0x2c, 0x3f, 0x5e, 0x78, 0xba, 0x96, 0xd1, 0x37, 0x8b, 0xb4, 0x23,
+	0x25, 0xf4, 0x05, 0xcc, 0xbb, 0x4c, 0x2a, 0xc2, 0x1d, 0x2a, 0xad, 0x8a, 0xb1, 0xb0, 0x32, 0x16,
+	0x71, 0x1e, 0xed, 0x4b, 0x55, 0xf4, 0x18, 0x2a, 0x4e, 0x37, 0x94, 0xd6, 0xac, 0x31, 0x59, 0xc9,
+	0x98, 0xd4, 0x5b, 0xc7, 0xb6, 0xd1, 0xc0, 0x2f, 0xa0, 0x7a, 0x24, 0xba, 0xc2, 0x13, 0x9d, 0x3e,
+	0x7a, 0x06, 0xc0, 0x43, 0x9f, 0x7c, 0xef, 0x50, 0xcf, 0x93, 0x56, 0xc9, 0xd8, 0xae, 0x66, 0x6d,
+	0xa9, 0xe7, 0xd9, 0xf3, 0x5a, 0x51, 0x7f, 0x49, 0xfc, 0x8f, 0x12, 0xcc, 0xb5, 0x9b, 0x3b, 0x4c,
+	0x48, 0x84, 0x61, 0xd1, 0x27, 0x3c, 0x3c, 0x23, 0x8e, 0x0a, 0x03, 0x1a, 0x98, 0x3c, 0xcd, 0xdb,
+	0x43, 0x73, 0xba, 0x8a, 0xba, 0x81, 0x70, 0x43, 0x27, 0xc9, 0x70, 0x32, 0x4c, 0x17, 0x60, 0x79,
+	0xa8, 0x00, 0xd1, 0x1d, 0x28, 0xcb, 0x8b, 0xd0, 0xaa, 0x98, 0x59, 0xfd, 0xa9, 0x37, 0xef, 0x8c,
+	0xf8, 0xcc, 0xeb, 0x5b, 0xb3, 0x66, 0x32, 0x1e, 0xe1, 0x9f, 0x4b, 0x50, 0xdd, 0x65, 0xf2, 0xe2,
+	0x80, 0x9f, 0x09, 0xa3, 0x24, 0x02, 0x9f, 0xa8, 0x38, 0x90, 0x78, 0x84, 0x36, 0x61, 0xe1, 0x94,
+	0x38, 0x17, 0x8c, 0x77, 0xf6, 0x98, 0x47, 0xe3, 0x30, 0xd2, 0x53, 0x68, 0x03, 0x40, 0xc7, 0x4b,
+	0xbc, 0x76, 0x52, 0x3f, 0x15, 0x3b, 0x35, 0xa3, 0x11, 0x74, 0x4a, 0x12, 0x85, 0x8a, 0x51, 0x48,
+	0x4f, 0xe1, 0xff, 0xcc, 0xc0, 0x52, 0xdd, 0x0b, 0xa5, 0xa2, 0x41, 0x5d, 0xf0, 0x33, 0xd6, 0x41,
+	0x5b, 0x80, 0x1a, 0xef, 0xba, 0x84, 0xbb, 0x3a, 0x3e, 0xd9, 0xe0, 0xe4, 0xd4, 0xa3, 0x51, 0x29,
+	0x55, 0xed, 0x1c, 0x09, 0xfa, 0x03, 0xac, 0xef, 0x05, 0x94, 0xea, 0x7a, 0xb0, 0x69, 0x57, 0x04,
+	0x8a, 0xf1, 0xce, 0x2e, 0x93, 0x91, 0xd9, 0x8c, 0x31, 0x2b, 0x56, 0x40, 0xcf, 0xc1, 0xda, 0x11,
+	0xce, 0xb9, 0xdc, 0x65, 0xb2, 0xeb, 0x91, 0xfe, 0x9e, 0x08, 0x1a, 0x7b, 0x07, 0xfb, 0x21, 0x95,
+	0x4a, 0x9a, 0xf5, 0x54, 0xed, 0x42, 0xb9, 0xb6, 0x6d, 0xd3, 0x80, 0x11, 0xaf, 0x2e, 0xb8, 0x14,
+	0x1e, 0x7d, 0x25, 0x2e, 0x1d, 0x57, 0x22, 0xdb, 0x22, 0x39, 0x7a, 0x01, 0x1f, 0xb6, 0xea, 0x07,
+	0x87, 0xc7, 0xcd, 0x97, 0x2f, 0x7f, 0x24, 0x01, 0x4d, 0x6a, 0x2b, 0x59, 0xee, 0xac, 0x31, 0x1f,
+	0xa7, 0x82, 0x3f, 0x83, 0xf5, 0x03, 0xae, 0x68, 0x70, 0x46, 0x1c, 0xba, 0xc3, 0xb8, 0xcb, 0x78,
+	0xa7, 0xc9, 0x3a, 0x01, 0x51, 0xba, 0x12, 0xd6, 0xf4, 0xf1, 0x55, 0xe7, 0xc2, 0x4d, 0xb6, 0x34,
+	0x1a, 0xe1, 0x7f, 0x55, 0x61, 0xf5, 0x24, 0x4a, 0x7f, 0x93, 0x38, 0xe7, 0x8c, 0xd3, 0xd7, 0x5d,
+	0x6d, 0x20, 0xd1, 0x37, 0xb0, 0x32, 0x2c, 0x88, 0x6a, 0xd5, 0x2a, 0x15, 0x9c, 0xd7, 0x48, 0x6c,
+	0xe7, 0x1a, 0xa1, 0x67, 0xb0, 0xda, 0xa4, 0xfe, 0x0e, 0xf1, 0x3c, 0x21, 0x78, 0x5b, 0x11, 0x25,
+	0x5b, 0x34, 0x60, 0x22, 0xda, 0x8f, 0x25, 0x3b, 0x5f, 0x88, 0x3e, 0x85, 0x7b, 0xad, 0x80, 0xea,
+	0x79, 0x87, 0x28, 0xea, 0x9e, 0x08, 0x2f, 0xf4, 0xe3, 0x0e, 0x30, 0x6f, 0xe7, 0x89, 0x74, 0x0b,
+	0x57, 0x71, 0x5a, 0xac, 0x4a, 0x41, 0x0b, 0x4f, 0xf2, 0x66, 0x0f, 0x54, 0x51, 0x1b, 0xe6, 0x4d,
+	0x09, 0xe9, 0xea, 0x8f, 0xcf, 0xfe, 0xe7, 0x19, 0xbb, 0xdc, 0x34, 0x6d, 0x0d, 0xec, 0x1a, 0x5c,
+	0x05, 0x7d, 0xfb, 0x12, 0xa7, 0xa0, 0x6e, 0xe7, 0x0a, 0xeb, 0x76, 0x17, 0x96, 0x9c, 0x74, 0xe1,
+	0x5b, 0xb7, 0xcd, 0x02, 0x36, 0xb2, 0x8d, 0x24, 0xad, 0x65, 0x0f, 0x1b, 0xa1, 0x9f, 0x4a, 0xb0,
+	0xce, 0x92, 0x32, 0xd8, 0x15, 0x3e, 0x61, 0xfc, 0xa5, 0x52, 0xc4, 0x39, 0xf7, 0x29, 0x57, 0x56,
+	0xd5, 0xac, 0xad, 0x71, 0xcd, 0xb5, 0x1d, 0x14, 0xe1, 0x44, 0x6b, 0x2d, 0xf6, 0x83, 0x38, 0xa0,
+	0x81, 0x70, 0x50, 0x84, 0xd6, 0xbc, 0xf1, 0xfe, 0xe5, 0x4d, 0xbd, 0x0f, 0x00, 0x22, 0xb7, 0x39,
+	0xc8, 0x3a, 0xd7, 0x52, 0x9c, 0x29, 0x9b, 0x9e, 0x0a, 0xa1, 0xf6, 0x88, 0xe7, 0xe9, 0xa6, 0x64,
+	0x81, 0x29, 0xf5, 0x1c, 0x49, 0xed, 0x2d, 0x2c, 0x0f, 0x6f, 0x9c, 0x6e, 0x95, 0x17, 0xb4, 0x1f,
+	0x9f, 0x0e, 0xfd, 0x89, 0xb6, 0xd3, 0xd7, 0x69, 0x5e, 0x21, 0x25, 0xfd, 0x32, 0xbe, 0x69, 0x9f,
+	0xcf, 0xfc, 0xbe, 0x54, 0x7b, 0x05, 0x1b, 0xe3, 0xb3, 0x96, 0xe3, 0x68, 0xe8, 0xde, 0x9e, 0x4f,
+	0xa3, 0xfd, 0x00, 0xf7, 0x0b, 0xb2, 0x90, 0x03, 0xf3, 0x62, 0x38, 0xde, 0xdf, 0x66, 0xe2, 0x2d,
+	0xec, 0x0e, 0x29, 0x97, 0xb8, 0x07, 0x70, 0xd2, 0x3c, 0xb0, 0xe9, 0x0f, 0xba, 0xa5, 0xa1, 0x47,
+	0x50, 0xee, 0xf9, 0x2c, 0x3e, 0xf3, 0xd9, 0xeb, 0x50, 0x6b, 0x6a, 0x05, 0xf4, 0x02, 0x6e, 0x8b,
+	0x68, 0xdb, 0x62, 0xef, 0x8f, 0xae, 0xb7, 0xc9, 0x76, 0x62, 0x86, 0x8f, 0xe0, 0xce, 0x65, 0x3c,
+	0x37, 0xf4, 0x6e, 0x0d, 0x7b, 0x5f, 0xbc, 0x44, 0xfd, 0xa9, 0x04, 0x0b, 0x8d, 0x77, 0xd4, 0x49,
+	0x10, 0x37, 0x00, 0x5c, 0xb3, 0x2b, 0x87, 0xc4, 0xa7, 0x71, 0xf2, 0x52, 0x33, 0x1a, 0xa9, 0x2e,
+	0x7c, 0x9f, 0x70, 0x37, 0xb9, 0x64, 0xe3, 0xa1, 0x7e, 0xdd, 0xbc, 0x0c, 0x3a, 0x49, 0xf3, 0x31,
+	0xdf, 0xe8, 0x11, 0x2c, 0x2b, 0xe6, 0x53, 0x11, 0xaa, 0x36, 0x75, 0x04, 0x77, 0xa5, 0xe9, 0x39,
+	0xb3, 0xf6, 0xc8, 0x2c, 0x5e, 0x86, 0xc5, 0x86, 0xdf, 0x55, 0xfd, 0x38, 0x0a, 0xfc, 0x25, 0x54,
+	0xed, 0xd4, 0xeb, 0x51, 0x86, 0x8e, 0x43, 0xa5, 0x8c, 0xaf, 0xb4, 0x64, 0xa8, 0x25, 0x3e, 0x95,
+	0x92, 0x74, 0x92, 0xc2, 0x48, 0x86, 0xf8, 0x7b, 0x58, 0x8e, 0x6a, 0x6b, 0xd2, 0xa7, 0xeb, 0x1a,
+	0xcc, 0x45, 0x8b, 0x8f, 0x3d, 0xc4, 0x23, 0xcc, 0xe1, 0x5e, 0xe4, 0xc0, 0x74, 0xe3, 0x49, 0xbd,
+	0x6c, 0xc2, 0x82, 0x7b, 0x89, 0x96, 0x3c, 0x1b, 0x52, 0x53, 0xf8, 0x1d, 0xdc, 0x35, 0x57, 0xa8,
+	0x39, 0x4d, 0x13, 0x7a, 0xfb, 0x18, 0xee, 0x76, 0x46, 0xb1, 0x62, 0x9f, 0x59, 0x01, 0xfe, 0x7b,
+	0x09, 0x56, 0x8d, 0xeb, 0x63, 0x49, 0x83, 0x57, 0x4c, 0xaa, 0x49, 0xdd, 0x3f, 0x83, 0xd5, 0x4e,
+	0x1e, 0x5e, 0x1c, 0x42, 0xbe, 0x10, 0xff, 0xb3, 0x04, 0x96, 0x09, 0x43, 0xbf, 0xa2, 0x64, 0x5f,
+	0x2a, 0xea, 0x4f, 0x9c, 0xf6, 0xe7, 0x60, 0x75, 0x0a, 0x20, 0xe3, 0x60, 0x0a, 0xe5, 0xb8, 0x0f,
+	0x8b, 0xd1, 0xb1, 0x99, 0x2c, 0x84, 0x1a, 0x54, 0xe9, 0x3b, 0xa6, 0xea, 0xc2, 0x8d, 0x5c, 0xce,
+	0xda, 0x83, 0xb1, 0xae, 0x3d, 0xa9, 0xdc, 0xd7, 0xa1, 0x8a, 0x1f, 0xad, 0xf1, 0x08, 0x7f, 0x0b,
+	0x77, 0x4c, 0x26, 0x5a, 0xfa, 0x69, 0x7e, 0xcd, 0x63, 0x9b, 0x3d, 0x88, 0x33, 0xb9, 0x07, 0xf1,
+	0x6b, 0xb8, 0x9b, 0xc2, 0x9e, 0x68, 0x6d, 0xf8, 0xdf, 0x25, 0x58, 0xd2, 0xcf, 0xc8, 0xf7, 0xf4,
+	0xa6, 0xed, 0xea, 0x0b, 0x58, 0x0b, 0xf9, 0x99, 0x31, 0x3d, 0xca, 0x8b, 0xba, 0x40, 0xaa, 0xcf,
+	0x91, 0x61, 0x5a, 0x5d, 0xc1, 0xb8, 0x4a, 0x3a, 0x51, 0x7a, 0x0a, 0xbf, 0x85, 0xbb, 0x11, 0x6d,
+	0xda, 0x0d, 0xfd, 0xee, 0x4d, 0xc3, 0xaa, 0x41, 0xd5, 0x0d, 0xfd, 0x6e, 0x8b, 0xa8, 0xf3, 0xb8,
+	0x3e, 0x06, 0x63, 0x7c, 0x0a, 0x1f, 0xb4, 0x1b, 0x27, 0xd3, 0x38, 0x9e, 0xba, 0xdf, 0xd1, 0x9e,
+	0x79, 0x68, 0xc5, 0xbd, 0x3a, 0x1e, 0xe2, 0xbf, 0x96, 0x60, 0xfd, 0x95, 0x21, 0xf2, 0x4d, 0x4a,
+	0x64, 0x18, 0x50, 0x7d, 0x67, 0x4e, 0xa1, 0x1b, 0x78, 0xa3, 0x98, 0xb1, 0xe3, 0xac, 0x00, 0x7f,
+	0xa7, 0x9f, 0xd0, 0x7f, 0xa1, 0x8e, 0x8a, 0xe2, 0x68, 0x53, 0x27, 0xa0, 0x6a, 0x7a, 0xb7, 0x91,
+	0x84, 0xb5, 0x5d, 0x16, 0xa8, 0xbe, 0x4d, 0x14, 0x9d, 0x4a, 0x67, 0xc5, 0xb0, 0xe8, 0x26, 0x80,
+	0xcd, 0xd3, 0xc8, 0x5f, 0xd9, 0x1e, 0x9a, 0xc3, 0x12, 0x50, 0xdb, 0x09, 0x28, 0xe5, 0xf2, 0x5c,
+	0x4c, 0x9c, 0x4e, 0x04, 0x15, 0x9f, 0xf9, 0x49, 0xff, 0x30, 0xdf, 0x7a, 0xce, 0x25, 0x8a, 0x98,
+	0x63, 0xbc, 0x68, 0x9b, 0x6f, 0xfc, 0x06, 0x96, 0x76, 0x88, 0x73, 0x11, 0x76, 0xa7, 0x97, 0x3c,
+	0x07, 0xd6, 0x6d, 0xea, 0xd2, 0x33, 0xc6, 0x69, 0xfd, 0x9c, 0x3a, 0x17, 0xa6, 0xe4, 0x6f, 0x0a,
+	0xbf, 0x01, 0xe0, 0x0c, 0x8c, 0x63, 0x0f, 0xa9, 0x19, 0xfc, 0xb7, 0x12, 0xd4, 0xf2, 0xbc, 0x4c,
+	0x5c, 0x84, 0x97, 0x3e, 0x0e, 0x78, 0x8f, 0x78, 0x2c, 0x61, 0xa2, 0x59, 0x01, 0x3e, 0x85, 0x8d,
+	0x76, 0xe3, 0xa4, 0x7d, 0xd8, 0x7a, 0xa9, 0x14, 0x95, 0x2a, 0x7e, 0x11, 0x69, 0x9e, 0x3a, 0xbd,
+	0x64, 0xfe, 0x5c, 0x82, 0x87, 0x85, 0x4e, 0x26, 0x5e, 0x2c, 0x19, 0xc5, 0x4c, 0x4e, 0x5c, 0x46,
+	0xf0, 0xf4, 0x7f, 0xeb, 0x50, 0xae, 0xfb, 0x2e, 0x3a, 0x04, 0xd4, 0xee, 0x73, 0x67, 0xf8, 0x91,
+	0x88, 0x3e, 0xcc, 0x5d, 0x5b, 0x94, 0x85, 0x5a, 0x71, 0x34, 0xf8, 0x16, 0x7a, 0x0d, 0xf7, 0x5a,
+	0x24, 0x94, 0x74, 0x6a, 0x80, 0x6f, 0x60, 0xf5, 0x98, 0x77, 0xa7, 0x0a, 0xd9, 0x86, 0x95, 0xe8,
+	0x02, 0x19, 0x41, 0xcc, 0x32, 0xbe, 0xa1, 0x7b, 0x66, 0x3c, 0xa8, 0x0d, 0x6b, 0xc7, 0xfc, 0x2c,
+	0x0f, 0x76, 0xa2, 0x64, 0xda, 0x54, 0x52, 0x35, 0x35, 0xc0, 0x23, 0xb0, 0xda, 0x03, 0x52, 0x36,
+	0x35, 0x54, 0x1b, 0xd6, 0xda, 0xe7, 0xa1, 0x72, 0xc5, 0x8f, 0x7c, 0x6a, 0x98, 0x87, 0x80, 0xbe,
+	0x61, 0x9e, 0x37, 0x35, 0xbc, 0x16, 0xac, 0xec, 0x52, 0x8f, 0xaa, 0xe9, 0x6d, 0xce, 0x5b, 0x58,
+	0x8d, 0x88, 0xd3, 0x28, 0xe4, 0x2f, 0x33, 0x56, 0xa3, 0x04, 0xeb, 0xca, 0x5d, 0xd7, 0x47, 0x72,
+	0x60, 0x74, 0x44, 0x82, 0x0e, 0x55, 0x13, 0x44, 0xfa, 0x47, 0x78, 0x50, 0xd7, 0x3f, 0xb3, 0x8e,
+	0x64, 0x73, 0xe0, 0x60, 0xc2, 0xad, 0x67, 0x1d, 0x4e, 0xbc, 0x28, 0xc8, 0x96, 0x70, 0xeb, 0x1e,
+	0x25, 0x3c, 0xec, 0x4e, 0x80, 0xf9, 0x27, 0x78, 0xb8, 0xc7, 0x38, 0xf1, 0xd8, 0x7b, 0x3a, 0xfd,
+	0x80, 0x0f, 0x01, 0x7d, 0x25, 0x54, 0xd7, 0x0b, 0x3b, 0x5f, 0x09, 0xa9, 0x76, 0x69, 0x8f, 0x39,
+	0x54, 0x4e, 0x80, 0xd7, 0x84, 0xf9, 0x7d, 0xaa, 0x22, 0xd2, 0x86, 0x1e, 0x64, 0x34, 0xd3, 0xf4,
+	0xb3, 0xf6, 0x30, 0x23, 0x1e, 0x66, 0x93, 0xa6, 0xa8, 0x96, 0x07, 0x70, 0xe6, 0xa5, 0x72, 0x15,
+	0xe6, 0xaf, 0x0b, 0x30, 0x87, 0x9e, 0x39, 0xa6, 0xe7, 0x2d, 0xee, 0x53, 0x35, 0x20, 0x7b, 0x57,
+	0xc1, 0xe2, 0x8c, 0x38, 0xc3, 0x13, 0x0d, 0x68, 0x75, 0x9f, 0x1a, 0x52, 0x75, 0x65, 0x9c, 0x8f,
+	0xf2, 0x01, 0x33, 0x84, 0xec, 0x16, 0xfa, 0xb3, 0x49, 0x41, 0x8a, 0x1c, 0x5d, 0x05, 0xfd, 0x51,
+	0x3e, 0x74, 0x1e, 0xbd, 0xba, 0x85, 0x76, 0xa0, 0xa2, 0x49, 0xc8, 0x55, 0x98, 0x63, 0xf7, 0xbc,
+	0x01, 0x15, 0x4d, 0xd2, 0xd0, 0x2f, 0xb2, 0x18, 0x97, 0x3f, 0x79, 0xd4, 0x1e, 0x14, 0x48, 0x53,
+	0xcd, 0x78, 0x7e, 0x40, 0x8a, 0x72, 0x9a, 0xc6, 0x28, 0x19, 0xab, 0xe1, 0x71, 0x2a, 0xa9, 0xd3,
+	0x63, 0x8d, 0x9c, 0x9a, 0x01, 0x31, 0x41, 0xb8, 0xe0, 0x8f, 0x3d, 0x29, 0xd6, 0x72, 0x55, 0xcf,
+	0xd3, 0x7b, 0x93, 0xfa, 0x1b, 0xde, 0xcd, 0xcb, 0x33, 0xe7, 0x0f, 0x80, 0x71, 0x1f, 0xc9, 0x3c,
+	0x43, 0xea, 0xad, 0x63, 0x39, 0xe1, 0x65, 0x97, 0xc1, 0x8c, 0x16, 0x3c, 0xd1, 0x9d, 0x0c, 0xfb,
+	0x54, 0xc5, 0xa4, 0xec, 0xaa, 0xe5, 0x6f, 0x66, 0xc4, 0x23, 0x6c, 0x0e, 0xdf, 0x42, 0x04, 0x56,
+	0xf6, 0xa9, 0xca, 0x10, 0xb0, 0xf1, 0x21, 0x66, 0x7f, 0x64, 0x2c, 0x64, 0x70, 0xf8, 0x16, 0xfa,
+	0x0e, 0x50, 0x96, 0x5e, 0xa1, 0xbc, 0x1f, 0x2a, 0x0b, 0x38, 0xd8, 0xf8, 0x94, 0x38, 0x70, 0x7f,
+	0xd0, 0xb4, 0x86, 0x79, 0xd6, 0x55, 0xf9, 0xf9, 0x4d, 0xce, 0x6f, 0xbb, 0x79, 0x3c, 0xcd, 0xf4,
+	0x9a, 0x25, 0x9d, 0xf7, 0x01, 0xa3, 0x1a, 0x9f, 0x9f, 0x5f, 0x65, 0x13, 0x9f, 0xe1, 0x62, 0xd1,
+	0x4b, 0x30, 0xa2, 0x4b, 0x57, 0xbe, 0x04, 0x87, 0x58, 0xd5, 0xf8, 0x74, 0x08, 0x40, 0x59, 0x2a,
+	0x93, 0x93, 0xed, 0x42, 0x56, 0x55, 0xfb, 0xdd, 0xb5, 0x74, 0x53, 0x6f, 0x9b, 0xe5, 0x7d, 0xca,
+	0xa9, 0x7e, 0x8a, 0xc4, 0x17, 0xd1, 0xd8, 0xdc, 0x5c, 0xe3, 0x1a, 0x6a, 0xc3, 0xfd, 0xa8, 0x16,
+	0x0e, 0x9b, 0x07, 0x53, 0x7b, 0x30, 0x69, 0x8e, 0x17, 0x1d, 0x9d, 0x3c, 0xfa, 0x83, 0xb6, 0xf3,
+	0xce, 0xca, 0x18, 0x36, 0x56, 0xfb, 0xf4, 0xfa, 0x06, 0x49, 0x0c, 0x3b, 0x95, 0x6f, 0x67, 0x7a,
+	0x4f, 0x4e, 0xe7, 0xcc, 0xff, 0x4f, 0xf8, 0xec, 0xff, 0x03, 0x00, 0xfd, 0xfa, 0xbe, 0x85, 0xcc,
+	0x20, 0x00, 0x00,
 }
@@ -161,6 +161,26 @@ func (mr *MockCmdClientMockRecorder) FreezeVirtualMachine(ctx, in any, opts ...a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeVirtualMachine", reflect.TypeOf((*MockCmdClient)(nil).FreezeVirtualMachine), varargs...)
 }
 
+// GenerateDomain mocks base method.
+func (m *MockCmdClient) GenerateDomain(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*DomainResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GenerateDomain", varargs...)
+	ret0, _ := ret[0].(*DomainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateDomain indicates an expected call of GenerateDomain.
+func (mr *MockCmdClientMockRecorder) GenerateDomain(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateDomain", reflect.TypeOf((*MockCmdClient)(nil).GenerateDomain), varargs...)
+}
+
 // GetDomain mocks base method.
 func (m *MockCmdClient) GetDomain(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*DomainResponse, error) {
 	m.ctrl.T.Helper()
@@ -321,6 +341,26 @@ func (mr *MockCmdClientMockRecorder) GetSEVInfo(ctx, in any, opts ...any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSEVInfo", reflect.TypeOf((*MockCmdClient)(nil).GetSEVInfo), varargs...)
 }
 
+// GetSEVSNPAttestationReport mocks base method.
+func (m *MockCmdClient) GetSEVSNPAttestationReport(ctx context.Context, in *SEVSNPAttestationReportRequest, opts ...grpc.CallOption) (*SEVSNPAttestationReportResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSEVSNPAttestationReport", varargs...)
+	ret0, _ := ret[0].(*SEVSNPAttestationReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSEVSNPAttestationReport indicates an expected call of GetSEVSNPAttestationReport.
+func (mr *MockCmdClientMockRecorder) GetSEVSNPAttestationReport(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSEVSNPAttestationReport", reflect.TypeOf((*MockCmdClient)(nil).GetSEVSNPAttestationReport), varargs...)
+}
+
 // GetScreenshot mocks base method.
 func (m *MockCmdClient) GetScreenshot(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*ScreenshotResponse, error) {
 	m.ctrl.T.Helper()
@@ -421,6 +461,26 @@ func (mr *MockCmdClientMockRecorder) InjectLaunchSecret(ctx, in any, opts ...any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectLaunchSecret", reflect.TypeOf((*MockCmdClient)(nil).InjectLaunchSecret), varargs...)
 }
 
+// InjectNMIVirtualMachine mocks base method.
+func (m *MockCmdClient) InjectNMIVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InjectNMIVirtualMachine", varargs...)
+	ret0, _ := ret[0].(*Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectNMIVirtualMachine indicates an expected call of InjectNMIVirtualMachine.
+func (mr *MockCmdClientMockRecorder) InjectNMIVirtualMachine(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectNMIVirtualMachine", reflect.TypeOf((*MockCmdClient)(nil).InjectNMIVirtualMachine), varargs...)
+}
+
 // KillVirtualMachine mocks base method.
 func (m *MockCmdClient) KillVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
 	m.ctrl.T.Helper()
@@ -855,6 +915,21 @@ func (mr *MockCmdServerMockRecorder) FreezeVirtualMachine(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeVirtualMachine", reflect.TypeOf((*MockCmdServer)(nil).FreezeVirtualMachine), arg0, arg1)
 }
 
+// GenerateDomain mocks base method.
+func (m *MockCmdServer) GenerateDomain(arg0 context.Context, arg1 *VMIRequest) (*DomainResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateDomain", arg0, arg1)
+	ret0, _ := ret[0].(*DomainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateDomain indicates an expected call of GenerateDomain.
+func (mr *MockCmdServerMockRecorder) GenerateDomain(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateDomain", reflect.TypeOf((*MockCmdServer)(nil).GenerateDomain), arg0, arg1)
+}
+
 // GetDomain mocks base method.
 func (m *MockCmdServer) GetDomain(arg0 context.Context, arg1 *EmptyRequest) (*DomainResponse, error) {
 	m.ctrl.T.Helper()
@@ -975,6 +1050,21 @@ func (mr *MockCmdServerMockRecorder) GetSEVInfo(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSEVInfo", reflect.TypeOf((*MockCmdServer)(nil).GetSEVInfo), arg0, arg1)
 }
 
+// GetSEVSNPAttestationReport mocks base method.
+func (m *MockCmdServer) GetSEVSNPAttestationReport(arg0 context.Context, arg1 *SEVSNPAttestationReportRequest) (*SEVSNPAttestationReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSEVSNPAttestationReport", arg0, arg1)
+	ret0, _ := ret[0].(*SEVSNPAttestationReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSEVSNPAttestationReport indicates an expected call of GetSEVSNPAttestationReport.
+func (mr *MockCmdServerMockRecorder) GetSEVSNPAttestationReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSEVSNPAttestationReport", reflect.TypeOf((*MockCmdServer)(nil).GetSEVSNPAttestationReport), arg0, arg1)
+}
+
 // GetScreenshot mocks base method.
 func (m *MockCmdServer) GetScreenshot(arg0 context.Context, arg1 *VMIRequest) (*ScreenshotResponse, error) {
 	m.ctrl.T.Helper()
@@ -1050,6 +1140,21 @@ func (mr *MockCmdServerMockRecorder) InjectLaunchSecret(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectLaunchSecret", reflect.TypeOf((*MockCmdServer)(nil).InjectLaunchSecret), arg0, arg1)
 }
 
+// InjectNMIVirtualMachine mocks base method.
+func (m *MockCmdServer) InjectNMIVirtualMachine(arg0 context.Context, arg1 *VMIRequest) (*Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectNMIVirtualMachine", arg0, arg1)
+	ret0, _ := ret[0].(*Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InjectNMIVirtualMachine indicates an expected call of InjectNMIVirtualMachine.
+func (mr *MockCmdServerMockRecorder) InjectNMIVirtualMachine(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectNMIVirtualMachine", reflect.TypeOf((*MockCmdServer)(nil).InjectNMIVirtualMachine), arg0, arg1)
+}
+
 // KillVirtualMachine mocks base method.
 func (m *MockCmdServer) KillVirtualMachine(arg0 context.Context, arg1 *VMIRequest) (*Response, error) {
 	m.ctrl.T.Helper()
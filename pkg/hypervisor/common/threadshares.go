@@ -0,0 +1,105 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/go-ps"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/virt-handler/cgroup"
+)
+
+// ioThreadPrefix is the prefix of the names QEMU gives to the threads of the iothread objects
+const ioThreadPrefix = "IO iothread"
+
+var threadExecutable = func(tid int) (string, error) {
+	proc, err := ps.FindProcess(tid)
+	if err != nil {
+		return "", err
+	}
+	if proc == nil {
+		return "", fmt.Errorf("failed to find process with tid: %d", tid)
+	}
+	return proc.Executable(), nil
+}
+
+// ConfigureThreadShares moves the emulator and IO threads of a VMI without dedicated CPUs into
+// child cgroups of the cpu controller, weighted with the shares requested in the VMI spec.
+// The vCPU threads, identified by isVCPU, are left in the cgroup of the VMI. The applied
+// shares are reported in the VMI status.
+func ConfigureThreadShares(vmi *v1.VirtualMachineInstance, cgroupManager cgroup.Manager, isVCPU func(comm string) bool) error {
+	var threadShares *v1.CPUThreadShares
+	if vmi.Spec.Domain.CPU != nil {
+		threadShares = vmi.Spec.Domain.CPU.ThreadShares
+	}
+	if threadShares == nil || (threadShares.Emulator == nil && threadShares.IOThreads == nil) {
+		vmi.Status.CPUThreadShares = nil
+		return nil
+	}
+
+	shares := map[string]*uint32{
+		cgroup.EmulatorCgroup:  threadShares.Emulator,
+		cgroup.IOThreadsCgroup: threadShares.IOThreads,
+	}
+	for name, value := range shares {
+		if value == nil {
+			continue
+		}
+		if err := cgroupManager.CreateChildCgroup(name, "cpu"); err != nil {
+			return fmt.Errorf("failed to create the %s cgroup: %w", name, err)
+		}
+		if err := cgroupManager.SetCpuShares(name, uint64(*value)); err != nil {
+			return fmt.Errorf("failed to set the cpu shares of the %s cgroup: %w", name, err)
+		}
+	}
+
+	tids, err := cgroupManager.GetCgroupThreads()
+	if err != nil {
+		return err
+	}
+	for _, tid := range tids {
+		comm, err := threadExecutable(tid)
+		if err != nil {
+			return err
+		}
+		if isVCPU(comm) {
+			continue
+		}
+		name := cgroup.EmulatorCgroup
+		if strings.HasPrefix(comm, ioThreadPrefix) {
+			name = cgroup.IOThreadsCgroup
+		}
+		if shares[name] == nil {
+			continue
+		}
+		if err := cgroupManager.AttachTID("cpu", name, tid); err != nil {
+			return fmt.Errorf("failed to attach thread %d to the %s cgroup: %w", tid, name, err)
+		}
+	}
+
+	log.Log.Object(vmi).V(3).Info("Configured the cpu shares of the emulator and IO threads")
+	vmi.Status.CPUThreadShares = threadShares.DeepCopy()
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virt-handler/cgroup"
+)
+
+var _ = Describe("thread shares", func() {
+	var (
+		cgroupManager *cgroup.MockManager
+		vmi           *v1.VirtualMachineInstance
+	)
+
+	threads := map[int]string{
+		10: "qemu-kvm",
+		11: "CPU 0/KVM",
+		12: "CPU 1/KVM",
+		13: "IO iothread1",
+		14: "IO mon_iothread",
+		15: "virt-launcher",
+	}
+
+	isVCPU := func(comm string) bool {
+		return strings.Contains(comm, "CPU ") && strings.Contains(comm, "KVM")
+	}
+
+	BeforeEach(func() {
+		cgroupManager = cgroup.NewMockManager(gomock.NewController(GinkgoT()))
+		vmi = libvmi.New(libvmi.WithCPUCount(2, 1, 1))
+
+		originalThreadExecutable := threadExecutable
+		threadExecutable = func(tid int) (string, error) {
+			comm, exists := threads[tid]
+			if !exists {
+				return "", fmt.Errorf("failed to find process with tid: %d", tid)
+			}
+			return comm, nil
+		}
+		DeferCleanup(func() {
+			threadExecutable = originalThreadExecutable
+		})
+	})
+
+	It("should not touch the cgroups if no shares are requested", func() {
+		vmi.Status.CPUThreadShares = &v1.CPUThreadShares{Emulator: pointer.P(uint32(2048))}
+		Expect(ConfigureThreadShares(vmi, cgroupManager, isVCPU)).To(Succeed())
+		Expect(vmi.Status.CPUThreadShares).To(BeNil())
+	})
+
+	It("should move the emulator and IO threads into weighted cgroups", func() {
+		vmi.Spec.Domain.CPU.ThreadShares = &v1.CPUThreadShares{
+			Emulator:  pointer.P(uint32(512)),
+			IOThreads: pointer.P(uint32(4096)),
+		}
+		cgroupManager.EXPECT().CreateChildCgroup(cgroup.EmulatorCgroup, "cpu").Return(nil)
+		cgroupManager.EXPECT().SetCpuShares(cgroup.EmulatorCgroup, uint64(512)).Return(nil)
+		cgroupManager.EXPECT().CreateChildCgroup(cgroup.IOThreadsCgroup, "cpu").Return(nil)
+		cgroupManager.EXPECT().SetCpuShares(cgroup.IOThreadsCgroup, uint64(4096)).Return(nil)
+		cgroupManager.EXPECT().GetCgroupThreads().Return([]int{10, 11, 12, 13, 14, 15}, nil)
+		for _, tid := range []int{10, 14, 15} {
+			cgroupManager.EXPECT().AttachTID("cpu", cgroup.EmulatorCgroup, tid).Return(nil)
+		}
+		cgroupManager.EXPECT().AttachTID("cpu", cgroup.IOThreadsCgroup, 13).Return(nil)
+
+		Expect(ConfigureThreadShares(vmi, cgroupManager, isVCPU)).To(Succeed())
+		Expect(vmi.Status.CPUThreadShares).To(Equal(vmi.Spec.Domain.CPU.ThreadShares))
+	})
+
+	It("should leave the threads without requested shares in the cgroup of the VMI", func() {
+		vmi.Spec.Domain.CPU.ThreadShares = &v1.CPUThreadShares{IOThreads: pointer.P(uint32(4096))}
+		cgroupManager.EXPECT().CreateChildCgroup(cgroup.IOThreadsCgroup, "cpu").Return(nil)
+		cgroupManager.EXPECT().SetCpuShares(cgroup.IOThreadsCgroup, uint64(4096)).Return(nil)
+		cgroupManager.EXPECT().GetCgroupThreads().Return([]int{10, 11, 13}, nil)
+		cgroupManager.EXPECT().AttachTID("cpu", cgroup.IOThreadsCgroup, 13).Return(nil)
+
+		Expect(ConfigureThreadShares(vmi, cgroupManager, isVCPU)).To(Succeed())
+		Expect(vmi.Status.CPUThreadShares.Emulator).To(BeNil())
+		Expect(vmi.Status.CPUThreadShares.IOThreads).To(HaveValue(Equal(uint32(4096))))
+	})
+
+	It("should not report the shares if they could not be applied", func() {
+		vmi.Spec.Domain.CPU.ThreadShares = &v1.CPUThreadShares{Emulator: pointer.P(uint32(512))}
+		cgroupManager.EXPECT().CreateChildCgroup(cgroup.EmulatorCgroup, "cpu").Return(nil)
+		cgroupManager.EXPECT().SetCpuShares(cgroup.EmulatorCgroup, uint64(512)).Return(fmt.Errorf("write error"))
+
+		Expect(ConfigureThreadShares(vmi, cgroupManager, isVCPU)).To(MatchError(ContainSubstring("write error")))
+		Expect(vmi.Status.CPUThreadShares).To(BeNil())
+	})
+})
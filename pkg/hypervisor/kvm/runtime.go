@@ -130,6 +130,11 @@ func (k *KvmVirtRuntime) HandleHousekeeping(vmi *v1.VirtualMachineInstance, cgro
 			return err
 		}
 	}
+	if !vmi.IsCPUDedicated() && vmi.IsRunning() {
+		if err := common.ConfigureThreadShares(vmi, cgroupManager, isVCPUThread); err != nil {
+			return err
+		}
+	}
 
 	// Configure vcpu scheduler for realtime workloads and affine PIT thread for dedicated CPU
 	if vmi.IsRealtimeEnabled() && !vmi.IsRunning() && !vmi.IsFinal() {
@@ -239,7 +244,7 @@ func (k *KvmVirtRuntime) configureHousekeepingCgroup(vmi *v1.VirtualMachineInsta
 			return fmt.Errorf("failed to find process with tid: %d", tid)
 		}
 		comm := proc.Executable()
-		if strings.Contains(comm, "CPU ") && strings.Contains(comm, "KVM") {
+		if isVCPUThread(comm) {
 			continue
 		}
 		hktids = append(hktids, tid)
@@ -257,6 +262,10 @@ func (k *KvmVirtRuntime) configureHousekeepingCgroup(vmi *v1.VirtualMachineInsta
 	return nil
 }
 
+func isVCPUThread(comm string) bool {
+	return strings.Contains(comm, "CPU ") && strings.Contains(comm, "KVM")
+}
+
 var qemuProcessExecutablePrefixes = []string{"qemu-system", "qemu-kvm"}
 
 // GetQEMUProcess encapsulates and exposes the logic to retrieve the QEMU process ID
@@ -125,6 +125,11 @@ func (m *MshvVirtRuntime) HandleHousekeeping(vmi *v1.VirtualMachineInstance, cgr
 			return err
 		}
 	}
+	if !vmi.IsCPUDedicated() && vmi.IsRunning() {
+		if err := common.ConfigureThreadShares(vmi, cgroupManager, isVCPUThread); err != nil {
+			return err
+		}
+	}
 
 	// Configure vcpu scheduler for realtime workloads and affine PIT thread for dedicated CPU
 	if vmi.IsRealtimeEnabled() && !vmi.IsRunning() && !vmi.IsFinal() {
@@ -179,7 +184,7 @@ func (m *MshvVirtRuntime) configureHousekeepingCgroup(vmi *v1.VirtualMachineInst
 			return fmt.Errorf("failed to find process with tid: %d", tid)
 		}
 		comm := proc.Executable()
-		if strings.Contains(comm, "CPU ") && strings.Contains(comm, "MSHV") {
+		if isVCPUThread(comm) {
 			continue
 		}
 		hktids = append(hktids, tid)
@@ -197,6 +202,10 @@ func (m *MshvVirtRuntime) configureHousekeepingCgroup(vmi *v1.VirtualMachineInst
 	return nil
 }
 
+func isVCPUThread(comm string) bool {
+	return strings.Contains(comm, "CPU ") && strings.Contains(comm, "MSHV")
+}
+
 var qemuProcessExecutablePrefixes = []string{"qemu-system"}
 
 // getQEMUProcess encapsulates and exposes the logic to retrieve the QEMU process ID
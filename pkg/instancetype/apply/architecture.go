@@ -0,0 +1,66 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package apply
+
+import (
+	virtv1 "kubevirt.io/api/core/v1"
+	v1beta1 "kubevirt.io/api/instancetype/v1beta1"
+)
+
+// resolveArchitectureVariant returns a copy of the instancetypeSpec with the variant matching the architecture of the VMI
+// merged into it, falling back to the PreferredArchitecture of the preference when the VMI does not define one yet.
+func resolveArchitectureVariant(
+	instancetypeSpec *v1beta1.VirtualMachineInstancetypeSpec,
+	preferenceSpec *v1beta1.VirtualMachinePreferenceSpec,
+	vmiSpec *virtv1.VirtualMachineInstanceSpec,
+) *v1beta1.VirtualMachineInstancetypeSpec {
+	if len(instancetypeSpec.ArchitectureVariants) == 0 {
+		return instancetypeSpec
+	}
+
+	architecture := vmiSpec.Architecture
+	if architecture == "" && preferenceSpec != nil && preferenceSpec.PreferredArchitecture != nil {
+		architecture = *preferenceSpec.PreferredArchitecture
+	}
+	if architecture == "" {
+		return instancetypeSpec
+	}
+
+	for i := range instancetypeSpec.ArchitectureVariants {
+		if instancetypeSpec.ArchitectureVariants[i].Architecture != architecture {
+			continue
+		}
+		resolvedSpec := instancetypeSpec.DeepCopy()
+		variant := resolvedSpec.ArchitectureVariants[i]
+		if variant.CPU != nil {
+			resolvedSpec.CPU = *variant.CPU
+		}
+		if variant.Memory != nil {
+			resolvedSpec.Memory = *variant.Memory
+		}
+		if len(variant.GPUs) > 0 {
+			resolvedSpec.GPUs = variant.GPUs
+		}
+		if len(variant.HostDevices) > 0 {
+			resolvedSpec.HostDevices = variant.HostDevices
+		}
+		return resolvedSpec
+	}
+	return instancetypeSpec
+}
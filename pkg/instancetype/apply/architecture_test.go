@@ -0,0 +1,122 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package apply_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	v1beta1 "kubevirt.io/api/instancetype/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/instancetype/apply"
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+var _ = Describe("instancetype.Spec.ArchitectureVariants", func() {
+	var (
+		vmi              *virtv1.VirtualMachineInstance
+		instancetypeSpec *v1beta1.VirtualMachineInstancetypeSpec
+		preferenceSpec   *v1beta1.VirtualMachinePreferenceSpec
+
+		vmiApplier = apply.NewVMIApplier()
+		field      = k8sfield.NewPath("spec", "template", "spec")
+	)
+
+	BeforeEach(func() {
+		vmi = libvmi.New()
+		preferenceSpec = nil
+		instancetypeSpec = &v1beta1.VirtualMachineInstancetypeSpec{
+			CPU: v1beta1.CPUInstancetype{
+				Guest: uint32(2),
+			},
+			Memory: v1beta1.MemoryInstancetype{
+				Guest: resource.MustParse("4Gi"),
+			},
+			GPUs: []virtv1.GPU{{
+				Name:       "gpu",
+				DeviceName: "vendor.com/amd64_gpu",
+			}},
+			ArchitectureVariants: []v1beta1.InstancetypeArchitectureVariant{{
+				Architecture: "arm64",
+				CPU: &v1beta1.CPUInstancetype{
+					Guest: uint32(4),
+				},
+				Memory: &v1beta1.MemoryInstancetype{
+					Guest: resource.MustParse("8Gi"),
+				},
+			}, {
+				Architecture: "s390x",
+				GPUs: []virtv1.GPU{{
+					Name:       "gpu",
+					DeviceName: "vendor.com/s390x_gpu",
+				}},
+			}},
+		}
+	})
+
+	DescribeTable("should apply the variant matching the architecture", func(
+		architecture string, expectedCPU uint32, expectedMemory string, expectedGPUDeviceName string,
+	) {
+		vmi.Spec.Architecture = architecture
+		Expect(vmiApplier.ApplyToVMI(field, instancetypeSpec, preferenceSpec, &vmi.Spec, &vmi.ObjectMeta)).To(Succeed())
+
+		Expect(vmi.Spec.Domain.CPU.Sockets).To(Equal(expectedCPU))
+		Expect(vmi.Spec.Domain.Memory.Guest.String()).To(Equal(expectedMemory))
+		Expect(vmi.Spec.Domain.Devices.GPUs).To(HaveLen(1))
+		Expect(vmi.Spec.Domain.Devices.GPUs[0].DeviceName).To(Equal(expectedGPUDeviceName))
+	},
+		Entry("without a matching variant", "amd64", uint32(2), "4Gi", "vendor.com/amd64_gpu"),
+		Entry("with a CPU and Memory variant", "arm64", uint32(4), "8Gi", "vendor.com/amd64_gpu"),
+		Entry("with a GPU variant", "s390x", uint32(2), "4Gi", "vendor.com/s390x_gpu"),
+	)
+
+	It("should select the variant by the preferred architecture when the VMI has none", func() {
+		preferenceSpec = &v1beta1.VirtualMachinePreferenceSpec{
+			PreferredArchitecture: pointer.P("arm64"),
+		}
+		Expect(vmiApplier.ApplyToVMI(field, instancetypeSpec, preferenceSpec, &vmi.Spec, &vmi.ObjectMeta)).To(Succeed())
+
+		Expect(vmi.Spec.Architecture).To(Equal("arm64"))
+		Expect(vmi.Spec.Domain.CPU.Sockets).To(Equal(uint32(4)))
+		Expect(vmi.Spec.Domain.Memory.Guest.String()).To(Equal("8Gi"))
+	})
+
+	It("should prefer the architecture of the VMI over the preferred architecture", func() {
+		vmi.Spec.Architecture = "amd64"
+		preferenceSpec = &v1beta1.VirtualMachinePreferenceSpec{
+			PreferredArchitecture: pointer.P("arm64"),
+		}
+		Expect(vmiApplier.ApplyToVMI(field, instancetypeSpec, preferenceSpec, &vmi.Spec, &vmi.ObjectMeta)).To(Succeed())
+
+		Expect(vmi.Spec.Domain.CPU.Sockets).To(Equal(uint32(2)))
+	})
+
+	It("should not modify the instancetype", func() {
+		vmi.Spec.Architecture = "arm64"
+		instancetypeSpecCopy := instancetypeSpec.DeepCopy()
+		Expect(vmiApplier.ApplyToVMI(field, instancetypeSpec, preferenceSpec, &vmi.Spec, &vmi.ObjectMeta)).To(Succeed())
+
+		Expect(instancetypeSpec).To(Equal(instancetypeSpecCopy))
+	})
+})
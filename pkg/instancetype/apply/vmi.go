@@ -55,6 +55,7 @@ func (a *vmiApplier) ApplyToVMI(
 	}
 
 	if instancetypeSpec != nil {
+		instancetypeSpec = resolveArchitectureVariant(instancetypeSpec, preferenceSpec, vmiSpec)
 		baseConflict := conflict.NewFromPath(field)
 		conflicts := conflict.Conflicts{}
 		conflicts = append(conflicts, applyNodeSelector(baseConflict, instancetypeSpec, vmiSpec)...)
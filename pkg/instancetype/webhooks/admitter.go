@@ -44,21 +44,22 @@ func (f *InstancetypeAdmitter) Admit(_ context.Context, ar *admissionv1.Admissio
 func ValidateInstanceTypeSpec(field *k8sfield.Path, spec *instancetypev1beta1.VirtualMachineInstancetypeSpec) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 
-	causes = append(causes, validateMemoryOvercommitPercentSetting(field, spec)...)
-	causes = append(causes, validateMemoryOvercommitPercentNoHugepages(field, spec)...)
+	causes = append(causes, validateMemoryOvercommitPercentSetting(field.Child("memory"), &spec.Memory)...)
+	causes = append(causes, validateMemoryOvercommitPercentNoHugepages(field.Child("memory"), &spec.Memory)...)
+	causes = append(causes, validateArchitectureVariants(field.Child("architectureVariants"), spec.ArchitectureVariants)...)
 	return causes
 }
 
 func validateMemoryOvercommitPercentSetting(
 	field *k8sfield.Path,
-	spec *instancetypev1beta1.VirtualMachineInstancetypeSpec,
+	memory *instancetypev1beta1.MemoryInstancetype,
 ) (causes []metav1.StatusCause) {
-	if spec.Memory.OvercommitPercent < 0 || spec.Memory.OvercommitPercent > 100 {
+	if memory.OvercommitPercent < 0 || memory.OvercommitPercent > 100 {
 		causes = append(causes, metav1.StatusCause{
 			Type: metav1.CauseTypeFieldValueInvalid,
-			Message: fmt.Sprintf(percentValueMustBeInRangeMessagePattern, field.Child("memory", "overcommitPercent").String(),
-				spec.Memory.OvercommitPercent),
-			Field: field.Child("memory", "overcommitPercent").String(),
+			Message: fmt.Sprintf(percentValueMustBeInRangeMessagePattern, field.Child("overcommitPercent").String(),
+				memory.OvercommitPercent),
+			Field: field.Child("overcommitPercent").String(),
 		})
 	}
 	return causes
@@ -66,20 +67,50 @@ func validateMemoryOvercommitPercentSetting(
 
 func validateMemoryOvercommitPercentNoHugepages(
 	field *k8sfield.Path,
-	spec *instancetypev1beta1.VirtualMachineInstancetypeSpec,
+	memory *instancetypev1beta1.MemoryInstancetype,
 ) (causes []metav1.StatusCause) {
-	if spec.Memory.OvercommitPercent != 0 && spec.Memory.Hugepages != nil {
+	if memory.OvercommitPercent != 0 && memory.Hugepages != nil {
 		causes = append(causes, metav1.StatusCause{
 			Type: metav1.CauseTypeFieldValueInvalid,
 			Message: fmt.Sprintf("%s and %s should not be requested together.",
-				field.Child("memory", "overcommitPercent").String(),
-				field.Child("memory", "hugepages").String()),
-			Field: field.Child("memory", "overcommitPercent").String(),
+				field.Child("overcommitPercent").String(),
+				field.Child("hugepages").String()),
+			Field: field.Child("overcommitPercent").String(),
 		})
 	}
 	return causes
 }
 
+func validateArchitectureVariants(
+	field *k8sfield.Path,
+	variants []instancetypev1beta1.InstancetypeArchitectureVariant,
+) (causes []metav1.StatusCause) {
+	seen := map[string]struct{}{}
+	for i, variant := range variants {
+		variantField := field.Index(i)
+		if variant.Architecture == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must be set.", variantField.Child("architecture").String()),
+				Field:   variantField.Child("architecture").String(),
+			})
+		} else if _, exists := seen[variant.Architecture]; exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s '%s' is defined by more than one variant.", variantField.Child("architecture").String(), variant.Architecture),
+				Field:   variantField.Child("architecture").String(),
+			})
+		}
+		seen[variant.Architecture] = struct{}{}
+
+		if variant.Memory != nil {
+			causes = append(causes, validateMemoryOvercommitPercentSetting(variantField.Child("memory"), variant.Memory)...)
+			causes = append(causes, validateMemoryOvercommitPercentNoHugepages(variantField.Child("memory"), variant.Memory)...)
+		}
+	}
+	return causes
+}
+
 type ClusterInstancetypeAdmitter struct{}
 
 func (f *ClusterInstancetypeAdmitter) Admit(_ context.Context, ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
@@ -105,6 +105,71 @@ var _ = Describe("Validating Instancetype Admitter", func() {
 		Expect(response.Result.Code).To(
 			Equal(int32(http.StatusUnprocessableEntity)), "overCommitPercent and hugepages should not be requested together.")
 	})
+
+	DescribeTable("should reject invalid architecture variants", func(variants []instancetypev1beta1.InstancetypeArchitectureVariant, expectedField string) {
+		version := instancetypev1beta1.SchemeGroupVersion.Version
+		instancetypeObj.Spec = instancetypev1beta1.VirtualMachineInstancetypeSpec{
+			CPU: instancetypev1beta1.CPUInstancetype{
+				Guest: uint32(1),
+			},
+			Memory: instancetypev1beta1.MemoryInstancetype{
+				Guest: resource.MustParse("128M"),
+			},
+			ArchitectureVariants: variants,
+		}
+		ar := createInstancetypeAdmissionReview(instancetypeObj, version)
+		response := admitter.Admit(context.Background(), ar)
+
+		Expect(response.Allowed).To(BeFalse(), "Expected instancetype to not be allowed")
+		Expect(response.Result.Details.Causes).To(HaveLen(1))
+		Expect(response.Result.Details.Causes[0].Field).To(Equal(expectedField))
+	},
+		Entry("without architecture",
+			[]instancetypev1beta1.InstancetypeArchitectureVariant{{}},
+			"spec.architectureVariants[0].architecture",
+		),
+		Entry("with duplicate architecture",
+			[]instancetypev1beta1.InstancetypeArchitectureVariant{{Architecture: "arm64"}, {Architecture: "arm64"}},
+			"spec.architectureVariants[1].architecture",
+		),
+		Entry("with memory overcommit and hugepages",
+			[]instancetypev1beta1.InstancetypeArchitectureVariant{{
+				Architecture: "arm64",
+				Memory: &instancetypev1beta1.MemoryInstancetype{
+					Guest:             resource.MustParse("128M"),
+					OvercommitPercent: 15,
+					Hugepages: &v1.Hugepages{
+						PageSize: "1Gi",
+					},
+				},
+			}},
+			"spec.architectureVariants[0].memory.overcommitPercent",
+		),
+	)
+
+	It("should accept architecture variants", func() {
+		version := instancetypev1beta1.SchemeGroupVersion.Version
+		instancetypeObj.Spec = instancetypev1beta1.VirtualMachineInstancetypeSpec{
+			CPU: instancetypev1beta1.CPUInstancetype{
+				Guest: uint32(1),
+			},
+			Memory: instancetypev1beta1.MemoryInstancetype{
+				Guest: resource.MustParse("128M"),
+			},
+			ArchitectureVariants: []instancetypev1beta1.InstancetypeArchitectureVariant{{
+				Architecture: "arm64",
+				CPU: &instancetypev1beta1.CPUInstancetype{
+					Guest: uint32(2),
+				},
+			}, {
+				Architecture: "s390x",
+			}},
+		}
+		ar := createInstancetypeAdmissionReview(instancetypeObj, version)
+		response := admitter.Admit(context.Background(), ar)
+
+		Expect(response.Allowed).To(BeTrue(), "Expected instancetype to be allowed.")
+	})
 })
 
 var _ = Describe("Validating ClusterInstancetype Admitter", func() {
@@ -27,12 +27,15 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
 
 	virtv1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/api/instancetype/v1beta1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 
+	instancetypeApply "kubevirt.io/kubevirt/pkg/instancetype/apply"
+	"kubevirt.io/kubevirt/pkg/instancetype/find"
 	"kubevirt.io/kubevirt/pkg/instancetype/infer"
 	"kubevirt.io/kubevirt/pkg/instancetype/preference/apply"
 	preferenceFind "kubevirt.io/kubevirt/pkg/instancetype/preference/find"
@@ -51,17 +54,29 @@ type findPreferenceSpecHandler interface {
 type mutator struct {
 	inferHandler
 	findPreferenceSpecHandler
+	instancetypeFinder
+	applyVMIHandler
+	namespaceStore cache.Store
 }
 
-func NewMutator(virtClient kubecli.KubevirtClient) *mutator {
+func NewMutator(virtClient kubecli.KubevirtClient, namespaceStore cache.Store) *mutator {
 	return &mutator{
 		inferHandler: infer.New(virtClient),
 		// TODO(lyarwood): Wire up informers for use here to speed up lookups
 		findPreferenceSpecHandler: preferenceFind.NewSpecFinder(nil, nil, nil, virtClient),
+		instancetypeFinder:        find.NewSpecFinder(nil, nil, nil, virtClient),
+		applyVMIHandler:           instancetypeApply.NewVMIApplier(),
+		namespaceStore:            namespaceStore,
 	}
 }
 
 func (m *mutator) Mutate(vm, oldVM *virtv1.VirtualMachine, ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request.Operation == admissionv1.Create {
+		if err := m.applyNamespaceDefaults(vm); err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+	}
+
 	if response := m.validateMatchers(vm, oldVM, ar); response != nil {
 		return response
 	}
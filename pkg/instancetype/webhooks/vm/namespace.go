@@ -0,0 +1,98 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package vm
+
+import (
+	"fmt"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	api "kubevirt.io/api/instancetype"
+	"kubevirt.io/client-go/log"
+)
+
+// applyNamespaceDefaults references the default instancetype and preference annotated on the namespace
+// in a VirtualMachine created without them. The default instancetype is not referenced when the
+// VirtualMachine sizes itself in a way conflicting with it, or when it cannot be found.
+func (m *mutator) applyNamespaceDefaults(vm *virtv1.VirtualMachine) error {
+	if m.namespaceStore == nil || vm.Spec.Template == nil {
+		return nil
+	}
+	if vm.Spec.Instancetype != nil && vm.Spec.Preference != nil {
+		return nil
+	}
+
+	obj, exists, err := m.namespaceStore.GetByKey(vm.Namespace)
+	if err != nil {
+		return fmt.Errorf("unable to look up namespace %s: %v", vm.Namespace, err)
+	}
+	if !exists {
+		return nil
+	}
+	annotations := obj.(*k8sv1.Namespace).Annotations
+
+	if name, ok := annotations[api.DefaultPreferenceLabel]; ok && vm.Spec.Preference == nil {
+		vm.Spec.Preference = &virtv1.PreferenceMatcher{
+			Name: name,
+			Kind: annotations[api.DefaultPreferenceKindLabel],
+		}
+		log.Log.Object(vm).V(3).Infof("Referencing default preference %s of the namespace", name)
+	}
+
+	if name, ok := annotations[api.DefaultInstancetypeLabel]; ok && vm.Spec.Instancetype == nil {
+		matcher := &virtv1.InstancetypeMatcher{
+			Name: name,
+			Kind: annotations[api.DefaultInstancetypeKindLabel],
+		}
+		conflicts, err := m.conflictsWithInstancetype(vm, matcher)
+		if err != nil {
+			log.Log.Object(vm).Warningf("Not referencing default instancetype %s of the namespace, it cannot be found: %v", name, err)
+			return nil
+		}
+		if conflicts {
+			log.Log.Object(vm).V(3).Infof("Not referencing default instancetype %s of the namespace, it conflicts with the VM", name)
+			return nil
+		}
+		vm.Spec.Instancetype = matcher
+		log.Log.Object(vm).V(3).Infof("Referencing default instancetype %s of the namespace", name)
+	}
+
+	return nil
+}
+
+func (m *mutator) conflictsWithInstancetype(vm *virtv1.VirtualMachine, matcher *virtv1.InstancetypeMatcher) (bool, error) {
+	vmCopy := vm.DeepCopy()
+	vmCopy.Spec.Instancetype = matcher
+
+	instancetypeSpec, err := m.Find(vmCopy)
+	if err != nil {
+		return false, err
+	}
+
+	conflicts := m.ApplyToVMI(
+		k8sfield.NewPath("spec", "template", "spec"),
+		instancetypeSpec,
+		nil,
+		&vmCopy.Spec.Template.Spec,
+		&vmCopy.Spec.Template.ObjectMeta,
+	)
+	return len(conflicts) > 0, nil
+}
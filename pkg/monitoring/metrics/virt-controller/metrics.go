@@ -64,6 +64,7 @@ var (
 		migrationMetrics,
 		perfscaleMetrics,
 		vmSnapshotMetrics,
+		storageCapacityMetrics,
 	}
 
 	indexers       *Indexers
@@ -126,7 +127,7 @@ func SetupMetrics(
 }
 
 func RegisterLeaderMetrics() error {
-	if err := operatormetrics.RegisterMetrics(leaderMetrics); err != nil {
+	if err := operatormetrics.RegisterMetrics(leaderMetrics, nodeHeadroomMetrics); err != nil {
 		return err
 	}
 
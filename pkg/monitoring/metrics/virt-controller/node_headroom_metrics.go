@@ -0,0 +1,84 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virt_controller
+
+import (
+	ioprometheusclient "github.com/prometheus/client_model/go"
+	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+)
+
+var (
+	nodeHeadroomMetrics = []operatormetrics.Metric{
+		nodeMigrationHeadroomCPUCores,
+		nodeMigrationHeadroomMemoryBytes,
+		nodeMigrationHeadroomExhausted,
+	}
+
+	nodeMigrationHeadroomCPUCores = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_node_migration_headroom_cpu_cores",
+			Help: "Allocatable CPU cores of the node which are not requested by any pod and can receive live migrations.",
+		},
+		[]string{"node"},
+	)
+
+	nodeMigrationHeadroomMemoryBytes = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_node_migration_headroom_memory_bytes",
+			Help: "Allocatable memory of the node in bytes which is not requested by any pod and can receive live migrations.",
+		},
+		[]string{"node"},
+	)
+
+	nodeMigrationHeadroomExhausted = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_node_migration_headroom_exhausted",
+			Help: "Indication for a node whose free capacity is below the configured migration headroom. New VMIs are not scheduled on such nodes.",
+		},
+		[]string{"node"},
+	)
+)
+
+// SetNodeMigrationHeadroom reports the free capacity of the node and whether it is below the configured headroom.
+func SetNodeMigrationHeadroom(node string, cpuCores, memoryBytes float64, exhausted bool) {
+	nodeMigrationHeadroomCPUCores.WithLabelValues(node).Set(cpuCores)
+	nodeMigrationHeadroomMemoryBytes.WithLabelValues(node).Set(memoryBytes)
+	value := 0.0
+	if exhausted {
+		value = 1
+	}
+	nodeMigrationHeadroomExhausted.WithLabelValues(node).Set(value)
+}
+
+// ResetNodeMigrationHeadroom stops reporting the headroom of all nodes.
+func ResetNodeMigrationHeadroom() {
+	nodeMigrationHeadroomCPUCores.Reset()
+	nodeMigrationHeadroomMemoryBytes.Reset()
+	nodeMigrationHeadroomExhausted.Reset()
+}
+
+// GetNodeMigrationHeadroomExhausted returns whether the headroom of the node was reported as exhausted.
+func GetNodeMigrationHeadroomExhausted(node string) (bool, error) {
+	dto := &ioprometheusclient.Metric{}
+	if err := nodeMigrationHeadroomExhausted.WithLabelValues(node).Write(dto); err != nil {
+		return false, err
+	}
+	return dto.GetGauge().GetValue() == 1, nil
+}
@@ -0,0 +1,53 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virt_controller
+
+import (
+	ioprometheusclient "github.com/prometheus/client_model/go"
+	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+)
+
+var (
+	storageCapacityMetrics = []operatormetrics.Metric{
+		vmStorageCapacityBlockedStarts,
+	}
+
+	vmStorageCapacityBlockedStarts = operatormetrics.NewCounterVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vm_storage_capacity_blocked_starts_total",
+			Help: "The total number of attempts to start a VM which were delayed because the available capacity of a StorageClass of its volumes is below the annotated minimum.",
+		},
+		[]string{"namespace", "storage_class"},
+	)
+)
+
+// IncStorageCapacityBlockedStarts counts a start of a VM in the namespace which was delayed due to the StorageClass.
+func IncStorageCapacityBlockedStarts(namespace, storageClass string) {
+	vmStorageCapacityBlockedStarts.WithLabelValues(namespace, storageClass).Inc()
+}
+
+// GetStorageCapacityBlockedStarts returns the number of delayed starts of VMs in the namespace due to the StorageClass.
+func GetStorageCapacityBlockedStarts(namespace, storageClass string) (float64, error) {
+	dto := &ioprometheusclient.Metric{}
+	if err := vmStorageCapacityBlockedStarts.WithLabelValues(namespace, storageClass).Write(dto); err != nil {
+		return 0, err
+	}
+	return dto.Counter.GetValue(), nil
+}
@@ -64,6 +64,7 @@ var (
 			vmiVnicInfo,
 			vmiLauncherMemoryOverhead,
 			vmiEphemeralHotplugVolume,
+			vmiBootPhaseDuration,
 		},
 		CollectCallback: vmiStatsCollectorCallback,
 	}
@@ -147,6 +148,15 @@ var (
 		},
 		[]string{"namespace", "name", "volume_name"},
 	)
+
+	vmiBootPhaseDuration = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_boot_phase_duration_seconds",
+			Help: "The duration of the phases of the VirtualMachineInstance start. The 'phase' label is one of " +
+				"storage_bind, scheduling, image_pull, firmware or guest_boot. A phase is only reported once it ended.",
+		},
+		[]string{"namespace", "name", "phase"},
+	)
 )
 
 func vmiStatsCollectorCallback() []operatormetrics.CollectorResult {
@@ -176,6 +186,7 @@ func reportVmisStats(vmis []*k6tv1.VirtualMachineInstance) []operatormetrics.Col
 		crs = append(crs, CollectVmisVnicInfo(vmi)...)
 		crs = append(crs, collectVMILauncherMemoryOverhead(vmi))
 		crs = append(crs, collectVMIEphemeralHotplug(vmi)...)
+		crs = append(crs, collectVMIBootPhaseDurations(vmi)...)
 	}
 
 	return crs
@@ -524,3 +535,37 @@ func collectVMIEphemeralHotplug(vmi *k6tv1.VirtualMachineInstance) []operatormet
 
 	return results
 }
+
+func collectVMIBootPhaseDurations(vmi *k6tv1.VirtualMachineInstance) []operatormetrics.CollectorResult {
+	var results []operatormetrics.CollectorResult
+
+	bootTimes := vmi.Status.BootTimes
+	if bootTimes == nil {
+		return results
+	}
+
+	phases := []struct {
+		name       string
+		start, end *v1.Time
+	}{
+		{"storage_bind", &vmi.CreationTimestamp, bootTimes.PodCreationTime},
+		{"scheduling", bootTimes.PodCreationTime, bootTimes.PodScheduledTime},
+		{"image_pull", bootTimes.PodScheduledTime, bootTimes.LauncherStartTime},
+		{"firmware", bootTimes.LauncherStartTime, bootTimes.DomainStartTime},
+		{"guest_boot", bootTimes.DomainStartTime, bootTimes.GuestAgentConnectTime},
+	}
+
+	for _, phase := range phases {
+		if phase.start == nil || phase.end == nil || phase.start.IsZero() || phase.end.Before(phase.start) {
+			continue
+		}
+
+		results = append(results, operatormetrics.CollectorResult{
+			Metric: vmiBootPhaseDuration,
+			Labels: []string{vmi.Namespace, vmi.Name, phase.name},
+			Value:  phase.end.Sub(phase.start.Time).Seconds(),
+		})
+	}
+
+	return results
+}
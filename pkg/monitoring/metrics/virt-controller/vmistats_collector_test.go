@@ -20,6 +20,8 @@
 package virt_controller
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -693,6 +695,70 @@ var _ = Describe("VMI Stats Collector", func() {
 			Expect(metric1.Value).To(BeNumerically("<", metric2.Value))
 		})
 	})
+
+	Context("VMI boot phase durations", func() {
+		timeAt := func(seconds int64) *metav1.Time {
+			t := metav1.NewTime(time.Unix(seconds, 0))
+			return &t
+		}
+
+		newVMIWithBootTimes := func(bootTimes *k6tv1.VirtualMachineInstanceBootTimes) *k6tv1.VirtualMachineInstance {
+			return &k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "test-ns",
+					Name:              "test-vmi",
+					CreationTimestamp: *timeAt(100),
+				},
+				Status: k6tv1.VirtualMachineInstanceStatus{
+					BootTimes: bootTimes,
+				},
+			}
+		}
+
+		durations := func(results []operatormetrics.CollectorResult) map[string]float64 {
+			phases := map[string]float64{}
+			for _, result := range results {
+				Expect(result.Metric.GetOpts().Name).To(Equal("kubevirt_vmi_boot_phase_duration_seconds"))
+				Expect(result.Labels[:2]).To(Equal([]string{"test-ns", "test-vmi"}))
+				phases[result.Labels[2]] = result.Value
+			}
+			return phases
+		}
+
+		It("should not collect anything without boot times", func() {
+			Expect(collectVMIBootPhaseDurations(newVMIWithBootTimes(nil))).To(BeEmpty())
+		})
+
+		It("should collect the duration of every phase", func() {
+			vmi := newVMIWithBootTimes(&k6tv1.VirtualMachineInstanceBootTimes{
+				PodCreationTime:       timeAt(105),
+				PodScheduledTime:      timeAt(107),
+				LauncherStartTime:     timeAt(120),
+				DomainStartTime:       timeAt(124),
+				GuestAgentConnectTime: timeAt(154),
+			})
+
+			Expect(durations(collectVMIBootPhaseDurations(vmi))).To(Equal(map[string]float64{
+				"storage_bind": 5,
+				"scheduling":   2,
+				"image_pull":   13,
+				"firmware":     4,
+				"guest_boot":   30,
+			}))
+		})
+
+		It("should only collect the phases which ended", func() {
+			vmi := newVMIWithBootTimes(&k6tv1.VirtualMachineInstanceBootTimes{
+				PodCreationTime:  timeAt(105),
+				PodScheduledTime: timeAt(107),
+			})
+
+			Expect(durations(collectVMIBootPhaseDurations(vmi))).To(Equal(map[string]float64{
+				"storage_bind": 5,
+				"scheduling":   2,
+			}))
+		})
+	})
 })
 
 func interfacesFor(values [][]string) []k6tv1.VirtualMachineInstanceNetworkInterface {
@@ -40,6 +40,7 @@ var (
 		networkMetrics{},
 		cpuAffinityMetrics{},
 		filesystemMetrics{},
+		gpuMetrics{},
 	}
 
 	Collector = operatormetrics.Collector{
@@ -58,15 +59,22 @@ type resourceMetrics interface {
 type collectorSettings struct {
 	maxRequestsInFlight int
 	vmiInformer         cache.SharedIndexInformer
+	guestDataRedacted   func(string, k6tv1.GuestAgentDataKind) bool
 }
 
-func SetupDomainStatsCollector(maxRequestsInFlight int, vmiInformer cache.SharedIndexInformer) {
+func SetupDomainStatsCollector(maxRequestsInFlight int, vmiInformer cache.SharedIndexInformer, guestDataRedacted func(string, k6tv1.GuestAgentDataKind) bool) {
 	settings = &collectorSettings{
 		maxRequestsInFlight: maxRequestsInFlight,
 		vmiInformer:         vmiInformer,
+		guestDataRedacted:   guestDataRedacted,
 	}
 }
 
+// isGuestDataRedacted returns whether the data policies of the namespace do not allow to expose the guest data
+func isGuestDataRedacted(namespace string, kind k6tv1.GuestAgentDataKind) bool {
+	return settings != nil && settings.guestDataRedacted != nil && settings.guestDataRedacted(namespace, kind)
+}
+
 func domainStatsMetrics(rms ...resourceMetrics) []operatormetrics.Metric {
 	var metrics []operatormetrics.Metric
 
@@ -45,6 +45,15 @@ type VirtualMachineInstanceReport struct {
 type VirtualMachineInstanceStats struct {
 	DomainStats *stats.DomainStats
 	FsStats     k6tv1.VirtualMachineInstanceFileSystemList
+	GPUStats    []GPUStats
+}
+
+type GPUStats struct {
+	// Name of the GPU in the VMI spec
+	Name     string
+	MDevUUID string
+	// Utilization is the share of the physical GPU used by the vGPU, between 0 and 1
+	Utilization float64
 }
 
 func newVirtualMachineInstanceReport(vmi *k6tv1.VirtualMachineInstance, vmiStats *VirtualMachineInstanceStats) *VirtualMachineInstanceReport {
@@ -19,7 +19,10 @@
 
 package domainstats
 
-import "github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+import (
+	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+	k6tv1 "kubevirt.io/api/core/v1"
+)
 
 var (
 	filesystemCapacityBytes = operatormetrics.NewGauge(
@@ -49,10 +52,24 @@ func (filesystemMetrics) Describe() []operatormetrics.Metric {
 func (filesystemMetrics) Collect(vmiReport *VirtualMachineInstanceReport) []operatormetrics.CollectorResult {
 	var crs []operatormetrics.CollectorResult
 
+	redacted := isGuestDataRedacted(vmiReport.vmi.Namespace, k6tv1.GuestAgentDataFilesystems)
+	reported := map[string]bool{}
+
 	for _, fsStat := range vmiReport.vmiStats.FsStats.Items {
+		mountPoint := fsStat.MountPoint
+		if redacted {
+			// Without their mount points, filesystems of the same disk would be reported twice
+			key := fsStat.DiskName + "/" + fsStat.FileSystemType
+			if reported[key] {
+				continue
+			}
+			reported[key] = true
+			mountPoint = ""
+		}
+
 		fsLabels := map[string]string{
 			"disk_name":        fsStat.DiskName,
-			"mount_point":      fsStat.MountPoint,
+			"mount_point":      mountPoint,
 			"file_system_type": fsStat.FileSystemType,
 		}
 
@@ -69,4 +69,53 @@ var _ = Describe("filesystem metrics", func() {
 			Expect(crs).To(BeEmpty())
 		})
 	})
+
+	Context("with the filesystems redacted", func() {
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vmi-1",
+				Namespace: "test-ns-1",
+			},
+		}
+
+		vmiStats := &VirtualMachineInstanceStats{
+			FsStats: k6tv1.VirtualMachineInstanceFileSystemList{
+				Items: []k6tv1.VirtualMachineInstanceFileSystem{
+					{DiskName: "vda2", MountPoint: "/", FileSystemType: "btrfs", TotalBytes: 1, UsedBytes: 2},
+					{DiskName: "vda2", MountPoint: "/home/secret", FileSystemType: "btrfs", TotalBytes: 1, UsedBytes: 2},
+					{DiskName: "vdb", MountPoint: "/srv/data", FileSystemType: "ext4", TotalBytes: 3, UsedBytes: 4},
+				},
+			},
+		}
+
+		vmiReport := newVirtualMachineInstanceReport(vmi, vmiStats)
+
+		BeforeEach(func() {
+			settings = &collectorSettings{
+				guestDataRedacted: func(namespace string, kind k6tv1.GuestAgentDataKind) bool {
+					return namespace == "test-ns-1" && kind == k6tv1.GuestAgentDataFilesystems
+				},
+			}
+			DeferCleanup(func() {
+				settings = nil
+			})
+		})
+
+		It("should not expose the mount points", func() {
+			crs := filesystemMetrics{}.Collect(vmiReport)
+			Expect(crs).To(HaveLen(4))
+			for _, cr := range crs {
+				Expect(cr.ConstLabels).To(HaveKeyWithValue("mount_point", ""))
+			}
+			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(filesystemCapacityBytes, 3.0)))
+		})
+
+		It("should expose the mount points in other namespaces", func() {
+			otherVMI := vmi.DeepCopy()
+			otherVMI.Namespace = "test-ns-2"
+			crs := filesystemMetrics{}.Collect(newVirtualMachineInstanceReport(otherVMI, vmiStats))
+			Expect(crs).To(HaveLen(6))
+			Expect(crs[2].ConstLabels).To(HaveKeyWithValue("mount_point", "/home/secret"))
+		})
+	})
 })
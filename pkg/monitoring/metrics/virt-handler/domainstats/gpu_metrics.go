@@ -0,0 +1,54 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import "github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+
+var (
+	gpuUtilizationRatio = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_gpu_utilization_ratio",
+			Help: "Share of the physical GPU used by a vGPU of the VM, between 0 and 1. Requires a vendor driver supporting vGPU time-slicing.",
+		},
+	)
+)
+
+type gpuMetrics struct{}
+
+func (gpuMetrics) Describe() []operatormetrics.Metric {
+	return []operatormetrics.Metric{
+		gpuUtilizationRatio,
+	}
+}
+
+func (gpuMetrics) Collect(vmiReport *VirtualMachineInstanceReport) []operatormetrics.CollectorResult {
+	var crs []operatormetrics.CollectorResult
+
+	for _, gpuStat := range vmiReport.vmiStats.GPUStats {
+		gpuLabels := map[string]string{
+			"gpu_name":  gpuStat.Name,
+			"mdev_uuid": gpuStat.MDevUUID,
+		}
+
+		crs = append(crs, vmiReport.newCollectorResultWithLabels(gpuUtilizationRatio, gpuStat.Utilization, gpuLabels))
+	}
+
+	return crs
+}
@@ -0,0 +1,67 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/monitoring/metrics/testing"
+)
+
+var _ = Describe("gpu metrics", func() {
+	Context("on Collect", func() {
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vmi-1",
+				Namespace: "test-ns-1",
+			},
+		}
+
+		vmiStats := &VirtualMachineInstanceStats{
+			GPUStats: []GPUStats{
+				{
+					Name:        "gpu1",
+					MDevUUID:    "4b20d080-1b54-4048-85b3-a6a62d165c01",
+					Utilization: 0.25,
+				},
+			},
+		}
+
+		vmiReport := newVirtualMachineInstanceReport(vmi, vmiStats)
+
+		It("should collect the utilization of every vGPU", func() {
+			crs := gpuMetrics{}.Collect(vmiReport)
+			Expect(crs).To(HaveLen(1))
+			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(gpuUtilizationRatio, 0.25)))
+			Expect(crs[0].ConstLabels).To(HaveKeyWithValue("gpu_name", "gpu1"))
+			Expect(crs[0].ConstLabels).To(HaveKeyWithValue("mdev_uuid", "4b20d080-1b54-4048-85b3-a6a62d165c01"))
+		})
+
+		It("result should be empty if stat not populated", func() {
+			vmiStats.GPUStats = nil
+			crs := gpuMetrics{}.Collect(vmiReport)
+			Expect(crs).To(BeEmpty())
+		})
+	})
+})
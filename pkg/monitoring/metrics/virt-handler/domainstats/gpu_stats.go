@@ -0,0 +1,92 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	// gpuAliasPrefix is the prefix of the alias given by virt-launcher to the host devices of GPUs
+	gpuAliasPrefix = "gpu-"
+	// Vendor drivers supporting vGPU time-slicing report the share of the physical
+	// GPU used by an mdev during the last sampling period, in percent, through this attribute
+	vgpuUtilizationAttribute = "vgpu_utilization"
+)
+
+// Not a const for static test purposes
+var mdevDevicesPath = "/sys/bus/mdev/devices"
+
+// gatherGPUStats reads the utilization of the mdevs backing the GPUs of the domain
+func gatherGPUStats(cli cmdclient.LauncherClient) ([]GPUStats, error) {
+	domain, exists, err := cli.GetDomain()
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	return gpuStatsFromDomain(domain), nil
+}
+
+func gpuStatsFromDomain(domain *api.Domain) []GPUStats {
+	var gpuStats []GPUStats
+	for _, hostDevice := range domain.Spec.Devices.HostDevices {
+		if hostDevice.Type != api.HostDeviceMDev || hostDevice.Source.Address == nil || hostDevice.Alias == nil {
+			continue
+		}
+		name, isGPU := strings.CutPrefix(hostDevice.Alias.GetName(), gpuAliasPrefix)
+		if !isGPU {
+			continue
+		}
+
+		mdevUUID := hostDevice.Source.Address.UUID
+		utilization, err := readVGPUUtilization(mdevUUID)
+		if err != nil {
+			continue
+		}
+		gpuStats = append(gpuStats, GPUStats{
+			Name:        name,
+			MDevUUID:    mdevUUID,
+			Utilization: utilization,
+		})
+	}
+	return gpuStats
+}
+
+func readVGPUUtilization(mdevUUID string) (float64, error) {
+	// #nosec No risk for path injection. mdevUUID comes from the domain spec
+	content, err := os.ReadFile(filepath.Join(mdevDevicesPath, mdevUUID, vgpuUtilizationAttribute))
+	if err != nil {
+		return 0, err
+	}
+	percent, err := strconv.ParseFloat(strings.TrimSpace(string(content)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid utilization of mdev %s: %v", mdevUUID, err)
+	}
+	return percent / 100, nil
+}
@@ -0,0 +1,82 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+var _ = Describe("gpu stats", func() {
+	const (
+		gpuMdevUUID  = "4b20d080-1b54-4048-85b3-a6a62d165c01"
+		hostMdevUUID = "9d8a2c7e-5f1b-4e0a-8d43-2f6b1c0e7a11"
+	)
+
+	newMdevHostDevice := func(alias, mdevUUID string) api.HostDevice {
+		return api.HostDevice{
+			Type:   api.HostDeviceMDev,
+			Alias:  api.NewUserDefinedAlias(alias),
+			Source: api.HostDeviceSource{Address: &api.Address{UUID: mdevUUID}},
+		}
+	}
+
+	writeUtilization := func(mdevUUID, value string) {
+		Expect(os.MkdirAll(filepath.Join(mdevDevicesPath, mdevUUID), 0700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(mdevDevicesPath, mdevUUID, vgpuUtilizationAttribute), []byte(value), 0600)).To(Succeed())
+	}
+
+	var domain *api.Domain
+
+	BeforeEach(func() {
+		origMdevDevicesPath := mdevDevicesPath
+		mdevDevicesPath = GinkgoT().TempDir()
+		DeferCleanup(func() { mdevDevicesPath = origMdevDevicesPath })
+
+		domain = &api.Domain{}
+		domain.Spec.Devices.HostDevices = []api.HostDevice{
+			newMdevHostDevice(gpuAliasPrefix+"gpu1", gpuMdevUUID),
+			newMdevHostDevice("hostdevice-hostdev1", hostMdevUUID),
+		}
+	})
+
+	It("should report the utilization of the mdevs backing GPUs", func() {
+		writeUtilization(gpuMdevUUID, "42\n")
+		writeUtilization(hostMdevUUID, "10\n")
+
+		Expect(gpuStatsFromDomain(domain)).To(ConsistOf(GPUStats{
+			Name:        "gpu1",
+			MDevUUID:    gpuMdevUUID,
+			Utilization: 0.42,
+		}))
+	})
+
+	It("should skip mdevs without a readable utilization", func() {
+		Expect(gpuStatsFromDomain(domain)).To(BeEmpty())
+
+		writeUtilization(gpuMdevUUID, "n/a\n")
+		Expect(gpuStatsFromDomain(domain)).To(BeEmpty())
+	})
+})
@@ -43,7 +43,7 @@ func NewDomainstatsScraper(channelLength int) *DomainstatsScraper {
 func (d DomainstatsScraper) Scrape(socketFile string, vmi *k6tv1.VirtualMachineInstance) {
 	ts := time.Now()
 
-	exists, vmStats, err := d.gatherMetrics(socketFile)
+	exists, vmStats, err := d.gatherMetrics(socketFile, vmi)
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to scrape metrics from %s", socketFile)
 		return
@@ -79,7 +79,7 @@ func (d DomainstatsScraper) Complete() {
 	close(d.ch)
 }
 
-func (d DomainstatsScraper) gatherMetrics(socketFile string) (bool, *VirtualMachineInstanceStats, error) {
+func (d DomainstatsScraper) gatherMetrics(socketFile string, vmi *k6tv1.VirtualMachineInstance) (bool, *VirtualMachineInstanceStats, error) {
 	cli, err := cmdclient.NewClient(socketFile)
 	if err != nil {
 		// Ignore failure to connect to client.
@@ -103,5 +103,13 @@ func (d DomainstatsScraper) gatherMetrics(socketFile string) (bool, *VirtualMach
 		return false, nil, fmt.Errorf("failed to update filesystem stats from socket %s: %w", socketFile, err)
 	}
 
+	if len(vmi.Spec.Domain.Devices.GPUs) > 0 {
+		vmStats.GPUStats, err = gatherGPUStats(cli)
+		if err != nil {
+			// vGPU utilization is optional, do not drop the remaining stats of the VMI
+			log.Log.Object(vmi).Reason(err).V(3).Info("failed to gather GPU stats")
+		}
+	}
+
 	return exists, vmStats, nil
 }
@@ -0,0 +1,65 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package virt_handler
+
+import (
+	"time"
+
+	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+)
+
+var (
+	networkGatewayProbeMetrics = []operatormetrics.Metric{
+		networkGatewayReachable,
+		networkGatewayRTT,
+	}
+
+	networkGatewayReachable = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_network_gateway_reachable",
+			Help: "Indication whether the gateway of a secondary network of a VMI answers the probes of virt-handler.",
+		},
+		[]string{"namespace", "name", "network"},
+	)
+
+	networkGatewayRTT = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_network_gateway_rtt_seconds",
+			Help: "Round trip time of the last successful probe of the gateway of a secondary network of a VMI.",
+		},
+		[]string{"namespace", "name", "network"},
+	)
+)
+
+// NetworkGatewayProbeReporter exposes the results of network gateway probes as metrics
+type NetworkGatewayProbeReporter struct{}
+
+func (NetworkGatewayProbeReporter) Report(namespace, name, network string, reachable bool, rtt time.Duration) {
+	value := 0.0
+	if reachable {
+		value = 1
+		networkGatewayRTT.WithLabelValues(namespace, name, network).Set(rtt.Seconds())
+	}
+	networkGatewayReachable.WithLabelValues(namespace, name, network).Set(value)
+}
+
+func (NetworkGatewayProbeReporter) Delete(namespace, name, network string) {
+	networkGatewayReachable.DeleteLabelValues(namespace, name, network)
+	networkGatewayRTT.DeleteLabelValues(namespace, name, network)
+}
@@ -270,6 +270,59 @@ func validateDHCPOptions(field *k8sfield.Path, idx int, iface v1.Interface) []me
 	if iface.DHCPOptions != nil {
 		causes = append(causes, validateDHCPExtraOptions(field, iface)...)
 		causes = append(causes, validateDHCPNTPServersAreValidIPv4Addresses(field, iface, idx)...)
+		causes = append(causes, validateDHCPDomainOptions(field, iface, idx)...)
+		causes = append(causes, validateDHCPNetBIOSOptions(field, iface, idx)...)
+	}
+	return causes
+}
+
+func validateDHCPDomainOptions(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	dhcpOptionsField := field.Child("domain", "devices", "interfaces").Index(idx).Child("dhcpOptions")
+	if domainName := iface.DHCPOptions.DomainName; domainName != "" {
+		if errs := k8svalidation.IsDNS1123Subdomain(domainName); len(errs) > 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Domain name %q is not a valid DNS domain name.", domainName),
+				Field:   dhcpOptionsField.Child("domainName").String(),
+			})
+		}
+	}
+	for index, domain := range iface.DHCPOptions.DomainSearch {
+		if errs := k8svalidation.IsDNS1123Subdomain(domain); len(errs) > 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Search domain %q is not a valid DNS domain name.", domain),
+				Field:   dhcpOptionsField.Child("domainSearch").Index(index).String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateDHCPNetBIOSOptions(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	netBIOS := iface.DHCPOptions.NetBIOS
+	if netBIOS == nil {
+		return nil
+	}
+	netBIOSField := field.Child("domain", "devices", "interfaces").Index(idx).Child("dhcpOptions", "netBIOS")
+	for index, ip := range netBIOS.NameServers {
+		if net.ParseIP(ip).To4() == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "NetBIOS name servers must be a list of valid IPv4 addresses.",
+				Field:   netBIOSField.Child("nameServers").Index(index).String(),
+			})
+		}
+	}
+	switch netBIOS.NodeType {
+	case "", v1.NetBIOSNodeTypeBroadcast, v1.NetBIOSNodeTypePeer, v1.NetBIOSNodeTypeMixed, v1.NetBIOSNodeTypeHybrid:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("NetBIOS node type %q is not supported, must be one of %s, %s, %s or %s.", netBIOS.NodeType,
+				v1.NetBIOSNodeTypeBroadcast, v1.NetBIOSNodeTypePeer, v1.NetBIOSNodeTypeMixed, v1.NetBIOSNodeTypeHybrid),
+			Field: netBIOSField.Child("nodeType").String(),
+		})
 	}
 	return causes
 }
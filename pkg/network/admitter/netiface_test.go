@@ -362,6 +362,37 @@ var _ = Describe("Validating VMI network spec", func() {
 					Field:   "fake.domain.devices.interfaces[0].dhcpOptions.ntpServers[1]",
 				}},
 			),
+			Entry(
+				"invalid domain name and search domains",
+				v1.DHCPOptions{DomainName: "AD_Example", DomainSearch: []string{"ad.example.com", "-bad.com"}},
+				[]metav1.StatusCause{{
+					Type:    "FieldValueInvalid",
+					Message: `Domain name "AD_Example" is not a valid DNS domain name.`,
+					Field:   "fake.domain.devices.interfaces[0].dhcpOptions.domainName",
+				}, {
+					Type:    "FieldValueInvalid",
+					Message: `Search domain "-bad.com" is not a valid DNS domain name.`,
+					Field:   "fake.domain.devices.interfaces[0].dhcpOptions.domainSearch[1]",
+				}},
+			),
+			Entry(
+				"non-IPv4 NetBIOS name servers",
+				v1.DHCPOptions{NetBIOS: &v1.DHCPNetBIOSOptions{NameServers: []string{"::1"}}},
+				[]metav1.StatusCause{{
+					Type:    "FieldValueInvalid",
+					Message: "NetBIOS name servers must be a list of valid IPv4 addresses.",
+					Field:   "fake.domain.devices.interfaces[0].dhcpOptions.netBIOS.nameServers[0]",
+				}},
+			),
+			Entry(
+				"unsupported NetBIOS node type",
+				v1.DHCPOptions{NetBIOS: &v1.DHCPNetBIOSOptions{NodeType: "X"}},
+				[]metav1.StatusCause{{
+					Type:    "FieldValueNotSupported",
+					Message: `NetBIOS node type "X" is not supported, must be one of B, P, M or H.`,
+					Field:   "fake.domain.devices.interfaces[0].dhcpOptions.netBIOS.nodeType",
+				}},
+			),
 		)
 
 		DescribeTable("should accept interface DHCP options with", func(dhcpOpts v1.DHCPOptions) {
@@ -380,6 +411,15 @@ var _ = Describe("Validating VMI network spec", func() {
 				PrivateOptions: []v1.DHCPPrivateOptions{{Option: 240, Value: "extra.options.kubevirt.io"}},
 			}),
 			Entry(" valid NTP servers", v1.DHCPOptions{NTPServers: []string{"127.0.0.1", "127.0.0.2"}}),
+			Entry("valid domain name and search domains", v1.DHCPOptions{
+				DomainName:   "ad.example.com",
+				DomainSearch: []string{"ad.example.com", "example.com"},
+			}),
+			Entry("valid NetBIOS options", v1.DHCPOptions{NetBIOS: &v1.DHCPNetBIOSOptions{
+				NameServers: []string{"10.0.0.10"},
+				NodeType:    v1.NetBIOSNodeTypeHybrid,
+				Scope:       "corp",
+			}}),
 			Entry(
 				"unique DHCPPrivateOptions",
 				v1.DHCPOptions{
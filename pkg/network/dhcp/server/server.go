@@ -39,12 +39,22 @@ import (
 )
 
 const (
-	infiniteLease             = 999 * 24 * time.Hour
-	errorSearchDomainNotValid = "Search domain is not valid"
-	errorSearchDomainTooLong  = "Search domains length exceeded allowable size"
-	errorNTPConfiguration     = "Could not parse NTP server as IPv4 address: %s"
+	infiniteLease                       = 999 * 24 * time.Hour
+	errorSearchDomainNotValid           = "Search domain is not valid"
+	errorSearchDomainTooLong            = "Search domains length exceeded allowable size"
+	errorNTPConfiguration               = "Could not parse NTP server as IPv4 address: %s"
+	errorNetBIOSNameServerConfiguration = "Could not parse NetBIOS name server as IPv4 address: %s"
+	errorNetBIOSNodeTypeConfiguration   = "Unknown NetBIOS node type: %s"
 )
 
+// NetBIOS node type values as defined by RFC 2132 section 8.7
+var netBIOSNodeTypes = map[v1.NetBIOSNodeType]byte{
+	v1.NetBIOSNodeTypeBroadcast: 0x1,
+	v1.NetBIOSNodeTypePeer:      0x2,
+	v1.NetBIOSNodeTypeMixed:     0x4,
+	v1.NetBIOSNodeTypeHybrid:    0x8,
+}
+
 // simple domain validation regex. Put it here to avoid compiling each time.
 // Note this requires that unicode domains be presented in their ASCII format
 var searchDomainValidationRegex = regexp.MustCompile(`^(?:[_a-z0-9](?:[_a-z0-9-]{0,61}[a-z0-9])?\.)*(?:[a-z](?:[a-z0-9-]{0,61}[a-z0-9])?)?$`)
@@ -131,6 +141,11 @@ func prepareDHCPOptions(
 		dhcpOptions[dhcp.OptionClasslessRouteFormat] = netRoutes
 	}
 
+	if customDHCPOptions != nil && len(customDHCPOptions.DomainSearch) > 0 {
+		log.Log.Infof("Setting dhcp option domain search to %s", customDHCPOptions.DomainSearch)
+		searchDomains = append(append([]string{}, customDHCPOptions.DomainSearch...), searchDomains...)
+	}
+
 	searchDomainBytes, err := convertSearchDomainsToBytes(searchDomains)
 	if err != nil {
 		return nil, err
@@ -143,6 +158,10 @@ func prepareDHCPOptions(
 
 	// Windows will ask for the domain name and use it for DNS resolution
 	domainName := dns.GetDomainName(searchDomains)
+	if customDHCPOptions != nil && customDHCPOptions.DomainName != "" {
+		log.Log.Infof("Setting dhcp option domain name to %s", customDHCPOptions.DomainName)
+		domainName = customDHCPOptions.DomainName
+	}
 	if len(domainName) > 0 {
 		dhcpOptions[dhcp.OptionDomainName] = []byte(domainName)
 	}
@@ -174,6 +193,12 @@ func prepareDHCPOptions(
 			dhcpOptions[dhcp.OptionNetworkTimeProtocolServers] = bytes.Join(ntpServers, nil)
 		}
 
+		if customDHCPOptions.NetBIOS != nil {
+			if err := setNetBIOSOptions(dhcpOptions, customDHCPOptions.NetBIOS); err != nil {
+				return nil, err
+			}
+		}
+
 		if customDHCPOptions.PrivateOptions != nil {
 			for _, privateOptions := range customDHCPOptions.PrivateOptions {
 				if privateOptions.Option >= 224 && privateOptions.Option <= 254 {
@@ -186,6 +211,35 @@ func prepareDHCPOptions(
 	return dhcpOptions, nil
 }
 
+func setNetBIOSOptions(dhcpOptions dhcp.Options, netBIOS *v1.DHCPNetBIOSOptions) error {
+	if len(netBIOS.NameServers) > 0 {
+		log.Log.Infof("Setting dhcp option NetBIOS name servers to %s", netBIOS.NameServers)
+
+		nameServers := [][]byte{}
+		for _, server := range netBIOS.NameServers {
+			ip := net.ParseIP(server).To4()
+			if ip == nil {
+				return fmt.Errorf(errorNetBIOSNameServerConfiguration, server)
+			}
+			nameServers = append(nameServers, []byte(ip))
+		}
+		dhcpOptions[dhcp.OptionNetBIOSOverTCPIPNameServer] = bytes.Join(nameServers, nil)
+	}
+
+	if netBIOS.NodeType != "" {
+		nodeType, ok := netBIOSNodeTypes[netBIOS.NodeType]
+		if !ok {
+			return fmt.Errorf(errorNetBIOSNodeTypeConfiguration, netBIOS.NodeType)
+		}
+		dhcpOptions[dhcp.OptionNetBIOSOverTCPIPNodeType] = []byte{nodeType}
+	}
+
+	if netBIOS.Scope != "" {
+		dhcpOptions[dhcp.OptionNetBIOSOverTCPIPScope] = []byte(netBIOS.Scope)
+	}
+	return nil
+}
+
 type DHCPHandler struct {
 	serverIP      net.IP
 	clientIP      net.IP
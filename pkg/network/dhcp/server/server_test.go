@@ -212,6 +212,55 @@ var _ = Describe("DHCP Server", func() {
 			Expect(options[240]).To(Equal([]byte("private.options.kubevirt.io")))
 		})
 
+		It("should prepend custom search domains and override the domain name", func() {
+			searchDomains := []string{"default.svc.cluster.local", "svc.cluster.local", "cluster.local"}
+			ip := net.ParseIP("192.168.2.1")
+
+			dhcpOptions := &v1.DHCPOptions{
+				DomainName:   "ad.example.com",
+				DomainSearch: []string{"ad.example.com"},
+			}
+
+			options, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, nil, searchDomains, 1500, "myhost", dhcpOptions)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[dhcp4.OptionDomainName]).To(Equal([]byte("ad.example.com")))
+			expectedSearchDomains, err := convertSearchDomainsToBytes(append([]string{"ad.example.com"}, searchDomains...))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[dhcp4.OptionDomainSearch]).To(Equal(expectedSearchDomains))
+			Expect(searchDomains).To(HaveLen(3), "the pod search domains should not be modified")
+		})
+
+		It("should contain NetBIOS options", func() {
+			ip := net.ParseIP("192.168.2.1")
+
+			dhcpOptions := &v1.DHCPOptions{
+				NetBIOS: &v1.DHCPNetBIOSOptions{
+					NameServers: []string{"192.168.2.10", "192.168.2.11"},
+					NodeType:    v1.NetBIOSNodeTypeHybrid,
+					Scope:       "corp",
+				},
+			}
+
+			options, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, nil, nil, 1500, "myhost", dhcpOptions)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[dhcp4.OptionNetBIOSOverTCPIPNameServer]).To(Equal([]byte{
+				192, 168, 2, 10, 192, 168, 2, 11,
+			}))
+			Expect(options[dhcp4.OptionNetBIOSOverTCPIPNodeType]).To(Equal([]byte{0x8}))
+			Expect(options[dhcp4.OptionNetBIOSOverTCPIPScope]).To(Equal([]byte("corp")))
+		})
+
+		DescribeTable("should reject invalid NetBIOS options", func(netBIOS *v1.DHCPNetBIOSOptions) {
+			ip := net.ParseIP("192.168.2.1")
+			_, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, nil, nil, 1500, "myhost", &v1.DHCPOptions{NetBIOS: netBIOS})
+			Expect(err).To(HaveOccurred())
+		},
+			Entry("with a non IPv4 name server", &v1.DHCPNetBIOSOptions{NameServers: []string{"::1"}}),
+			Entry("with an unknown node type", &v1.DHCPNetBIOSOptions{NodeType: "X"}),
+		)
+
 		It("expects the gateway as an IPv4 addresses", func() {
 			gw := net.ParseIP("192.168.2.1")
 			options, err := prepareDHCPOptions(gw.DefaultMask(), gw, nil, nil, nil, 1500, "myhost", nil)
@@ -0,0 +1,145 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package gatewayprobe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"kubevirt.io/kubevirt/pkg/network/netns"
+)
+
+const (
+	defaultProbeTimeout = time.Second
+
+	arpRequest  = 1
+	arpReply    = 2
+	arpHTypeEth = 1
+	arpPacketV4 = 28
+)
+
+var errProbeTimeout = errors.New("no reply received")
+
+// ARPProber probes a gateway with an ARP request sent from the launcher network namespace.
+// ARP is answered by the gateway regardless of any firewalling of IP traffic, and does not
+// require an IP address on the probing link.
+type ARPProber struct {
+	timeout time.Duration
+}
+
+func NewARPProber() ARPProber {
+	return ARPProber{timeout: defaultProbeTimeout}
+}
+
+func (p ARPProber) Probe(pid int, linkName string, gateway net.IP) (time.Duration, error) {
+	gateway4 := gateway.To4()
+	if gateway4 == nil {
+		return 0, fmt.Errorf("gateway %s is not an IPv4 address", gateway)
+	}
+	var rtt time.Duration
+	err := netns.New(pid).Do(func() error {
+		var probeErr error
+		rtt, probeErr = p.probe(linkName, gateway4)
+		return probeErr
+	})
+	return rtt, err
+}
+
+func (p ARPProber) probe(linkName string, gateway net.IP) (time.Duration, error) {
+	iface, err := net.InterfaceByName(linkName)
+	if err != nil {
+		return 0, err
+	}
+
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_DGRAM, int(htons(unix.ETH_P_ARP)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to open packet socket: %v", err)
+	}
+	defer unix.Close(fd)
+
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: htons(unix.ETH_P_ARP), Ifindex: iface.Index}); err != nil {
+		return 0, fmt.Errorf("failed to bind to %s: %v", linkName, err)
+	}
+
+	broadcast := &unix.SockaddrLinklayer{
+		Protocol: htons(unix.ETH_P_ARP),
+		Ifindex:  iface.Index,
+		Halen:    6,
+		Addr:     [8]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+	start := time.Now()
+	if err := unix.Sendto(fd, arpRequestPacket(iface.HardwareAddr, gateway), 0, broadcast); err != nil {
+		return 0, fmt.Errorf("failed to send ARP request: %v", err)
+	}
+
+	deadline := start.Add(p.timeout)
+	buf := make([]byte, 128)
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return 0, errProbeTimeout
+		}
+		tv := unix.NsecToTimeval(remaining.Nanoseconds())
+		if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+			return 0, err
+		}
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to receive ARP reply: %v", err)
+		}
+		if isARPReplyFrom(buf[:n], gateway) {
+			return time.Since(start), nil
+		}
+	}
+}
+
+func arpRequestPacket(hwAddr net.HardwareAddr, target net.IP) []byte {
+	packet := make([]byte, arpPacketV4)
+	binary.BigEndian.PutUint16(packet[0:2], arpHTypeEth)
+	binary.BigEndian.PutUint16(packet[2:4], unix.ETH_P_IP)
+	packet[4] = 6
+	packet[5] = net.IPv4len
+	binary.BigEndian.PutUint16(packet[6:8], arpRequest)
+	copy(packet[8:14], hwAddr)
+	// the sender protocol address stays 0.0.0.0, the link has no IP address
+	copy(packet[24:28], target.To4())
+	return packet
+}
+
+func isARPReplyFrom(packet []byte, gateway net.IP) bool {
+	if len(packet) < arpPacketV4 {
+		return false
+	}
+	return binary.BigEndian.Uint16(packet[6:8]) == arpReply && bytes.Equal(packet[14:18], gateway.To4())
+}
+
+func htons(v uint16) uint16 {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+	return binary.NativeEndian.Uint16(b)
+}
@@ -0,0 +1,352 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+// Package gatewayprobe periodically probes the gateways of the secondary bridge networks of
+// VMIs from the network namespace of their launcher pods. Dead networks are detected without
+// the help of the guest, the results are exposed as metrics and as a VMI condition.
+package gatewayprobe
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/network/cache"
+	virtnetlink "kubevirt.io/kubevirt/pkg/network/link"
+	"kubevirt.io/kubevirt/pkg/network/namescheme"
+	"kubevirt.io/kubevirt/pkg/network/vmispec"
+)
+
+const (
+	defaultInterval = 30 * time.Second
+	// failureThreshold consecutive failed probes mark a gateway as unreachable
+	failureThreshold = 3
+)
+
+// Prober probes a gateway through a link in the network namespace of the given process
+type Prober interface {
+	Probe(pid int, linkName string, gateway net.IP) (time.Duration, error)
+}
+
+type reporter interface {
+	Report(namespace, name, network string, reachable bool, rtt time.Duration)
+	Delete(namespace, name, network string)
+}
+
+type cacheCreator interface {
+	New(filePath string) *cache.Cache
+}
+
+type target struct {
+	network  string
+	linkName string
+	gateway  net.IP
+
+	probed    bool
+	failures  int
+	reachable bool
+}
+
+type vmiTargets struct {
+	namespace string
+	name      string
+	pid       int
+	targets   []*target
+}
+
+func (v *vmiTargets) key() string {
+	return v.namespace + "/" + v.name
+}
+
+type Manager struct {
+	prober       Prober
+	reporter     reporter
+	cacheCreator cacheCreator
+	interval     time.Duration
+
+	lock sync.Mutex
+	vmis map[types.UID]*vmiTargets
+}
+
+func NewManager(prober Prober, reporter reporter, cacheCreator cacheCreator) *Manager {
+	return NewManagerWithInterval(prober, reporter, cacheCreator, defaultInterval)
+}
+
+func NewManagerWithInterval(prober Prober, reporter reporter, cacheCreator cacheCreator, interval time.Duration) *Manager {
+	return &Manager{
+		prober:       prober,
+		reporter:     reporter,
+		cacheCreator: cacheCreator,
+		interval:     interval,
+		vmis:         map[types.UID]*vmiTargets{},
+	}
+}
+
+// Start registers the secondary bridge networks of the VMI with a known gateway for probing.
+// It is called on every sync of the VMI, so that hotplugged networks are picked up. The
+// probing state of the networks which did not change is kept.
+func (m *Manager) Start(vmi *v1.VirtualMachineInstance, pid int) {
+	targets := m.collectTargets(vmi, pid)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	existing, exists := m.vmis[vmi.UID]
+	if !exists || existing.pid != pid {
+		m.stop(vmi.UID)
+		existing = nil
+	}
+	if len(targets) == 0 {
+		m.stop(vmi.UID)
+		return
+	}
+
+	if existing != nil {
+		for i, t := range targets {
+			if previous := lookupTarget(existing.targets, t.network); previous != nil && previous.gateway.Equal(t.gateway) {
+				targets[i] = previous
+			}
+		}
+		for _, t := range existing.targets {
+			if lookupTarget(targets, t.network) == nil {
+				m.reporter.Delete(existing.namespace, existing.name, t.network)
+			}
+		}
+	}
+	m.vmis[vmi.UID] = &vmiTargets{
+		namespace: vmi.Namespace,
+		name:      vmi.Name,
+		pid:       pid,
+		targets:   targets,
+	}
+}
+
+func lookupTarget(targets []*target, network string) *target {
+	for _, t := range targets {
+		if t.network == network {
+			return t
+		}
+	}
+	return nil
+}
+
+// Stop removes the VMI and the metrics of its networks
+func (m *Manager) Stop(vmi *v1.VirtualMachineInstance) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.stop(vmi.UID)
+}
+
+func (m *Manager) stop(uid types.UID) {
+	vmi, exists := m.vmis[uid]
+	if !exists {
+		return
+	}
+	for _, t := range vmi.targets {
+		m.reporter.Delete(vmi.namespace, vmi.name, t.network)
+	}
+	delete(m.vmis, uid)
+}
+
+func (m *Manager) collectTargets(vmi *v1.VirtualMachineInstance, pid int) []*target {
+	var targets []*target
+	for _, network := range vmispec.FilterMultusNonDefaultNetworks(vmi.Spec.Networks) {
+		iface := vmispec.LookupInterfaceByName(vmi.Spec.Domain.Devices.Interfaces, network.Name)
+		if iface == nil || iface.Bridge == nil {
+			continue
+		}
+		podIfaceName := namescheme.GenerateHashedInterfaceName(network.Name)
+		if ifaceStatus := vmispec.LookupInterfaceStatusByName(vmi.Status.Interfaces, network.Name); ifaceStatus != nil && ifaceStatus.PodInterfaceName != "" {
+			podIfaceName = ifaceStatus.PodInterfaceName
+		}
+
+		dhcpConfig, err := cache.ReadDHCPInterfaceCache(m.cacheCreator, strconv.Itoa(pid), podIfaceName)
+		if err != nil {
+			log.Log.Object(vmi).V(4).Infof("not probing network %s, its DHCP configuration is not available: %v", network.Name, err)
+			continue
+		}
+		if dhcpConfig.IPAMDisabled || dhcpConfig.Gateway == nil || dhcpConfig.Gateway.To4() == nil {
+			continue
+		}
+		targets = append(targets, &target{
+			network:   network.Name,
+			linkName:  virtnetlink.GenerateNewBridgedVmiInterfaceName(podIfaceName),
+			gateway:   dhcpConfig.Gateway,
+			reachable: true,
+		})
+	}
+	return targets
+}
+
+// Run probes all registered VMIs every interval until stopCh is closed. onChange is called
+// with the namespace/name key of a VMI whose condition changed.
+func (m *Manager) Run(stopCh chan struct{}, onChange func(key string)) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			m.probeAll(onChange)
+		}
+	}
+}
+
+type probeTask struct {
+	uid     types.UID
+	pid     int
+	targets []target
+}
+
+func (m *Manager) probeAll(onChange func(key string)) {
+	m.lock.Lock()
+	tasks := make([]probeTask, 0, len(m.vmis))
+	for uid, vmi := range m.vmis {
+		task := probeTask{uid: uid, pid: vmi.pid}
+		for _, t := range vmi.targets {
+			task.targets = append(task.targets, *t)
+		}
+		tasks = append(tasks, task)
+	}
+	m.lock.Unlock()
+
+	// Probes of unreachable gateways take until their timeout, VMIs are probed in parallel
+	// so that a dead network shared by many VMIs does not delay the other probes.
+	wg := sync.WaitGroup{}
+	for _, task := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results := make([]probeResult, len(task.targets))
+			for i, t := range task.targets {
+				results[i].network = t.network
+				results[i].rtt, results[i].err = m.prober.Probe(task.pid, t.linkName, t.gateway)
+			}
+			if m.record(task.uid, task.pid, results) {
+				m.notify(task.uid, onChange)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type probeResult struct {
+	network string
+	rtt     time.Duration
+	err     error
+}
+
+// record stores the results and reports whether the condition of the VMI changed
+func (m *Manager) record(uid types.UID, pid int, results []probeResult) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	vmi, exists := m.vmis[uid]
+	if !exists || vmi.pid != pid {
+		return false
+	}
+	changed := false
+	for _, result := range results {
+		// the targets may have changed while probing
+		t := lookupTarget(vmi.targets, result.network)
+		if t == nil {
+			continue
+		}
+		wasProbed, wasReachable := t.probed, t.reachable
+		t.probed = true
+		if result.err == nil {
+			t.failures = 0
+			t.reachable = true
+		} else {
+			t.failures++
+			log.Log.V(4).Infof("probing gateway %s of network %s of VMI %s failed: %v", t.gateway, t.network, vmi.key(), result.err)
+			if t.failures >= failureThreshold {
+				t.reachable = false
+			}
+		}
+		m.reporter.Report(vmi.namespace, vmi.name, t.network, t.reachable, result.rtt)
+		if !wasProbed || wasReachable != t.reachable {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (m *Manager) notify(uid types.UID, onChange func(key string)) {
+	m.lock.Lock()
+	vmi, exists := m.vmis[uid]
+	m.lock.Unlock()
+	if exists {
+		onChange(vmi.key())
+	}
+}
+
+// Condition returns the gateway reachability condition of the VMI, or nil if none of its
+// networks were probed yet.
+func (m *Manager) Condition(vmi *v1.VirtualMachineInstance) *v1.VirtualMachineInstanceCondition {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	probes, exists := m.vmis[vmi.UID]
+	if !exists {
+		return nil
+	}
+	var unreachable []string
+	probed := false
+	for _, t := range probes.targets {
+		if !t.probed {
+			continue
+		}
+		probed = true
+		if !t.reachable {
+			unreachable = append(unreachable, fmt.Sprintf("%s (gateway %s)", t.network, t.gateway))
+		}
+	}
+	if !probed {
+		return nil
+	}
+
+	now := metav1.Now()
+	condition := &v1.VirtualMachineInstanceCondition{
+		Type:               v1.VirtualMachineInstanceNetworkGatewaysReachable,
+		Status:             k8sv1.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             v1.VirtualMachineInstanceReasonGatewaysReachable,
+	}
+	if len(unreachable) > 0 {
+		sort.Strings(unreachable)
+		condition.Status = k8sv1.ConditionFalse
+		condition.Reason = v1.VirtualMachineInstanceReasonGatewayUnreachable
+		condition.Message = fmt.Sprintf("The gateways of the networks %s did not answer %d consecutive probes",
+			strings.Join(unreachable, ", "), failureThreshold)
+	}
+	return condition
+}
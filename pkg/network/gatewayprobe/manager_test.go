@@ -0,0 +1,257 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package gatewayprobe_test
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	dutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/network/cache"
+	"kubevirt.io/kubevirt/pkg/network/gatewayprobe"
+	"kubevirt.io/kubevirt/pkg/network/namescheme"
+)
+
+const (
+	launcherPID     = 1234
+	probeInterval   = 10 * time.Millisecond
+	blueNetwork     = "blue"
+	redNetwork      = "red"
+	blueGateway     = "10.1.0.1"
+	redGateway      = "10.2.0.1"
+	blueBridgedLink = "16477688c0e-nic"
+)
+
+var _ = Describe("Network gateway probe manager", func() {
+	var (
+		cacheCreator *tempCacheCreator
+		prober       *fakeProber
+		reporter     *fakeReporter
+		manager      *gatewayprobe.Manager
+		stopCh       chan struct{}
+		changes      chan string
+	)
+
+	BeforeEach(dutils.MockDefaultOwnershipManager)
+
+	BeforeEach(func() {
+		cacheCreator = &tempCacheCreator{}
+		prober = &fakeProber{failing: map[string]bool{}}
+		reporter = &fakeReporter{reports: map[string]bool{}}
+		manager = gatewayprobe.NewManagerWithInterval(prober, reporter, cacheCreator, probeInterval)
+		stopCh = make(chan struct{})
+		changes = make(chan string, 100)
+		DeferCleanup(func() { close(stopCh) })
+	})
+
+	run := func() {
+		m, stop, notified := manager, stopCh, changes
+		go m.Run(stop, func(key string) { notified <- key })
+	}
+
+	writeDHCPConfig := func(network, gateway string) {
+		config := &cache.DHCPConfig{Name: network}
+		if gateway != "" {
+			config.Gateway = net.ParseIP(gateway)
+		}
+		Expect(cache.WriteDHCPInterfaceCache(cacheCreator, strconv.Itoa(launcherPID),
+			namescheme.GenerateHashedInterfaceName(network), config)).To(Succeed())
+	}
+
+	newVMI := func() *v1.VirtualMachineInstance {
+		return libvmi.New(
+			libvmi.WithNamespace("default"),
+			libvmi.WithUID("uid"),
+			libvmi.WithInterface(*v1.DefaultMasqueradeNetworkInterface()),
+			libvmi.WithNetwork(v1.DefaultPodNetwork()),
+			libvmi.WithInterface(libvmi.InterfaceDeviceWithBridgeBinding(blueNetwork)),
+			libvmi.WithNetwork(libvmi.MultusNetwork(blueNetwork, "blue-nad")),
+			libvmi.WithInterface(libvmi.InterfaceDeviceWithBridgeBinding(redNetwork)),
+			libvmi.WithNetwork(libvmi.MultusNetwork(redNetwork, "red-nad")),
+		)
+	}
+
+	It("should not report a condition before the first probe", func() {
+		writeDHCPConfig(blueNetwork, blueGateway)
+		vmi := newVMI()
+		manager.Start(vmi, launcherPID)
+		Expect(manager.Condition(vmi)).To(BeNil())
+	})
+
+	It("should not probe networks without a gateway", func() {
+		writeDHCPConfig(blueNetwork, "")
+		vmi := newVMI()
+		manager.Start(vmi, launcherPID)
+		run()
+
+		Consistently(prober.probeCount).WithTimeout(10 * probeInterval).Should(BeZero())
+		Expect(manager.Condition(vmi)).To(BeNil())
+	})
+
+	It("should probe the bridged link of the network and report reachable gateways", func() {
+		writeDHCPConfig(blueNetwork, blueGateway)
+		writeDHCPConfig(redNetwork, redGateway)
+		vmi := newVMI()
+		manager.Start(vmi, launcherPID)
+		run()
+
+		Eventually(changes).Should(Receive(Equal("default/" + vmi.Name)))
+		Expect(prober.probedLinks()).To(ContainElement(blueBridgedLink))
+
+		condition := manager.Condition(vmi)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Type).To(Equal(v1.VirtualMachineInstanceNetworkGatewaysReachable))
+		Expect(condition.Status).To(Equal(k8sv1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonGatewaysReachable))
+		Expect(reporter.reachable("default", vmi.Name, blueNetwork)).To(BeTrue())
+		Expect(reporter.reachable("default", vmi.Name, redNetwork)).To(BeTrue())
+	})
+
+	It("should report a gateway unreachable only after consecutive failures", func() {
+		writeDHCPConfig(blueNetwork, blueGateway)
+		writeDHCPConfig(redNetwork, redGateway)
+		prober.fail(redGateway)
+		vmi := newVMI()
+		manager.Start(vmi, launcherPID)
+		run()
+
+		Eventually(changes).Should(Receive())
+		Eventually(func() k8sv1.ConditionStatus {
+			if condition := manager.Condition(vmi); condition != nil {
+				return condition.Status
+			}
+			return ""
+		}).Should(Equal(k8sv1.ConditionFalse))
+		Expect(prober.probeCount()).To(BeNumerically(">=", 3*2))
+
+		condition := manager.Condition(vmi)
+		Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonGatewayUnreachable))
+		Expect(condition.Message).To(ContainSubstring("red (gateway 10.2.0.1)"))
+		Expect(condition.Message).ToNot(ContainSubstring(blueNetwork))
+		Expect(reporter.reachable("default", vmi.Name, redNetwork)).To(BeFalse())
+		Expect(reporter.reachable("default", vmi.Name, blueNetwork)).To(BeTrue())
+	})
+
+	It("should notify when an unreachable gateway recovers", func() {
+		writeDHCPConfig(blueNetwork, blueGateway)
+		prober.fail(blueGateway)
+		vmi := newVMI()
+		manager.Start(vmi, launcherPID)
+		run()
+
+		Eventually(func() *v1.VirtualMachineInstanceCondition { return manager.Condition(vmi) }).
+			Should(HaveField("Status", k8sv1.ConditionFalse))
+		Eventually(changes).Should(HaveLen(2))
+		Expect(<-changes).To(Equal("default/" + vmi.Name))
+		Expect(<-changes).To(Equal("default/" + vmi.Name))
+
+		prober.recover(blueGateway)
+		Eventually(changes).Should(Receive())
+		Expect(manager.Condition(vmi).Status).To(Equal(k8sv1.ConditionTrue))
+	})
+
+	It("should delete the metrics and the condition when the VMI is stopped", func() {
+		writeDHCPConfig(blueNetwork, blueGateway)
+		vmi := newVMI()
+		manager.Start(vmi, launcherPID)
+		run()
+		Eventually(changes).Should(Receive())
+
+		manager.Stop(vmi)
+		Expect(manager.Condition(vmi)).To(BeNil())
+		Expect(reporter.deleted).To(ConsistOf("default/" + vmi.Name + "/" + blueNetwork))
+	})
+})
+
+type fakeProber struct {
+	lock    sync.Mutex
+	failing map[string]bool
+	links   []string
+	probes  int
+}
+
+func (p *fakeProber) Probe(_ int, linkName string, gateway net.IP) (time.Duration, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.probes++
+	p.links = append(p.links, linkName)
+	if p.failing[gateway.String()] {
+		return 0, errors.New("timeout")
+	}
+	return time.Millisecond, nil
+}
+
+func (p *fakeProber) fail(gateway string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.failing[gateway] = true
+}
+
+func (p *fakeProber) recover(gateway string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.failing, gateway)
+}
+
+func (p *fakeProber) probeCount() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.probes
+}
+
+func (p *fakeProber) probedLinks() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return append([]string{}, p.links...)
+}
+
+type fakeReporter struct {
+	lock    sync.Mutex
+	reports map[string]bool
+	deleted []string
+}
+
+func (r *fakeReporter) Report(namespace, name, network string, reachable bool, _ time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reports[namespace+"/"+name+"/"+network] = reachable
+}
+
+func (r *fakeReporter) Delete(namespace, name, network string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.deleted = append(r.deleted, namespace+"/"+name+"/"+network)
+}
+
+func (r *fakeReporter) reachable(namespace, name, network string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.reports[namespace+"/"+name+"/"+network]
+}
@@ -8,10 +8,11 @@ import (
 )
 
 type DiskInfo struct {
-	Format      string `json:"format"`
-	BackingFile string `json:"backing-filename"`
-	ActualSize  int64  `json:"actual-size"`
-	VirtualSize int64  `json:"virtual-size"`
+	Format        string `json:"format"`
+	BackingFile   string `json:"backing-filename"`
+	BackingFormat string `json:"backing-filename-format"`
+	ActualSize    int64  `json:"actual-size"`
+	VirtualSize   int64  `json:"virtual-size"`
 }
 
 const (
@@ -44,6 +44,8 @@ const (
 
 var isValidExpression = regexp.MustCompile(`^[A-Za-z0-9_.+-]+$`).MatchString
 
+var isValidWWN = regexp.MustCompile(`^[0-9A-Fa-f]{16}$`).MatchString
+
 func ValidateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	for idx, disk := range disks {
@@ -54,9 +56,13 @@ func ValidateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 		causes = append(causes, validateBusSupport(field, idx, disk)...)
 		causes = append(causes, validateSerialNumValue(field, idx, disk)...)
 		causes = append(causes, validateSerialNumLength(field, idx, disk)...)
+		causes = append(causes, validateSerialNumUniqueness(field, idx, disks)...)
+		causes = append(causes, validateWWN(field, idx, disk)...)
+		causes = append(causes, validateWWNUniqueness(field, idx, disks)...)
 		causes = append(causes, validateCacheMode(field, idx, disk)...)
 		causes = append(causes, validateIOMode(field, idx, disk)...)
 		causes = append(causes, validateErrorPolicy(field, idx, disk)...)
+		causes = append(causes, validateReadErrorPolicy(field, idx, disk)...)
 		// Verify disk and volume name can be a valid container name since disk
 		// name can become a container name which will fail to schedule if invalid
 		causes = append(causes, validateDiskNameAsContainerName(field, idx, disk)...)
@@ -254,6 +260,57 @@ func validateSerialNumLength(field *k8sfield.Path, idx int, disk v1.Disk) []meta
 	return causes
 }
 
+func validateSerialNumUniqueness(field *k8sfield.Path, idx int, disks []v1.Disk) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for otherIdx, disk := range disks {
+		if otherIdx < idx && disk.Serial != "" && disk.Serial == disks[idx].Serial {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s and %s must not have the same serial.", field.Index(idx).String(), field.Index(otherIdx).String()),
+				Field:   field.Index(idx).Child("serial").String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateWWN(field *k8sfield.Path, idx int, disk v1.Disk) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if disk.WWN == "" {
+		return causes
+	}
+	if !isValidWWN(disk.WWN) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must consist of 16 hexadecimal digits, if specified", field.Index(idx).Child("wwn").String()),
+			Field:   field.Index(idx).Child("wwn").String(),
+		})
+	}
+	// libvirt only supports a WWN for hard-disks and CD-ROMs on the sata and scsi buses
+	if bus := getDiskBus(disk); disk.LUN != nil || bus == v1.DiskBusVirtio || bus == v1.DiskBusUSB {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s is only supported for disks and CD-ROMs on the sata and scsi buses", field.Index(idx).Child("wwn").String()),
+			Field:   field.Index(idx).Child("wwn").String(),
+		})
+	}
+	return causes
+}
+
+func validateWWNUniqueness(field *k8sfield.Path, idx int, disks []v1.Disk) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for otherIdx, disk := range disks {
+		if otherIdx < idx && disk.WWN != "" && strings.EqualFold(disk.WWN, disks[idx].WWN) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s and %s must not have the same wwn.", field.Index(idx).String(), field.Index(otherIdx).String()),
+				Field:   field.Index(idx).Child("wwn").String(),
+			})
+		}
+	}
+	return causes
+}
+
 func validateCacheMode(field *k8sfield.Path, idx int, disk v1.Disk) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	if disk.Cache != "" && disk.Cache != v1.CacheNone && disk.Cache != v1.CacheWriteThrough && disk.Cache != v1.CacheWriteBack {
@@ -290,6 +347,18 @@ func validateErrorPolicy(field *k8sfield.Path, idx int, disk v1.Disk) []metav1.S
 	return causes
 }
 
+func validateReadErrorPolicy(field *k8sfield.Path, idx int, disk v1.Disk) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if disk.ReadErrorPolicy != nil && *disk.ReadErrorPolicy != v1.DiskErrorPolicyStop && *disk.ReadErrorPolicy != v1.DiskErrorPolicyIgnore && *disk.ReadErrorPolicy != v1.DiskErrorPolicyReport {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s has invalid value \"%s\"", field.Index(idx).Child("readErrorPolicy").String(), *disk.ReadErrorPolicy),
+			Field:   field.Index(idx).Child("readErrorPolicy").String(),
+		})
+	}
+	return causes
+}
+
 func validateDiskNameAsContainerName(field *k8sfield.Path, idx int, disk v1.Disk) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	for _, err := range validation.IsDNS1123Label(disk.Name) {
@@ -26,6 +26,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/api/core/v1"
@@ -329,6 +330,35 @@ var _ = Describe("Disk Validation", func() {
 			Entry("enospace", v1.DiskErrorPolicyEnospace),
 		)
 
+		DescribeTable("should reject disk with invalid readErrorPolicy", func(policy string) {
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk", ReadErrorPolicy: pointer.P(v1.DiskErrorPolicy(policy)), DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{}}})
+
+			causes := ValidateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(1))
+			Expect(string(causes[0].Type)).To(Equal("FieldValueInvalid"))
+			Expect(causes[0].Field).To(Equal("fake[0].readErrorPolicy"))
+			Expect(causes[0].Message).To(Equal(fmt.Sprintf("fake[0].readErrorPolicy has invalid value \"%s\"", policy)))
+		},
+			Entry("with arbitrary string", "unsupported"),
+			Entry("with empty string", ""),
+			Entry("with enospace", "enospace"),
+		)
+
+		DescribeTable("It should accept a disk with a valid readErrorPolicy", func(mode v1.DiskErrorPolicy) {
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk", ReadErrorPolicy: pointer.P(mode), DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{}}})
+
+			causes := ValidateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(BeEmpty())
+		},
+			Entry("stop", v1.DiskErrorPolicyStop),
+			Entry("report", v1.DiskErrorPolicyReport),
+			Entry("ignore", v1.DiskErrorPolicyIgnore),
+		)
+
 		It("should reject invalid SN characters", func() {
 			order := uint(1)
 			sn := "$$$$"
@@ -381,6 +411,63 @@ var _ = Describe("Disk Validation", func() {
 			Expect(causes).To(BeEmpty())
 		})
 
+		It("should reject disks with duplicate serials", func() {
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks,
+				v1.Disk{Name: "testdisk1", Serial: "SN-1", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}},
+				v1.Disk{Name: "testdisk2", Serial: "SN-1", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}},
+			)
+
+			causes := ValidateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueDuplicate))
+			Expect(causes[0].Field).To(Equal("fake[1].serial"))
+		})
+
+		DescribeTable("should accept a valid WWN", func(wwn string, device v1.DiskDevice) {
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name:       "testdisk",
+				WWN:        wwn,
+				DiskDevice: device,
+			})
+
+			causes := ValidateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(BeEmpty())
+		},
+			Entry("on a sata disk", "5000c50015ea71ac", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusSATA}}),
+			Entry("on a scsi disk", "5000C50015EA71AC", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusSCSI}}),
+			Entry("on a sata cdrom", "5000c50015ea71ac", v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: v1.DiskBusSATA}}),
+		)
+
+		DescribeTable("should reject an invalid WWN", func(wwn string, device v1.DiskDevice) {
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name:       "testdisk",
+				WWN:        wwn,
+				DiskDevice: device,
+			})
+
+			causes := ValidateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake[0].wwn"))
+		},
+			Entry("with too few digits", "5000c50015ea71a", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusSATA}}),
+			Entry("with non-hexadecimal digits", "5000c50015ea71ag", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusSATA}}),
+			Entry("on a virtio disk", "5000c50015ea71ac", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusVirtio}}),
+			Entry("on a usb disk", "5000c50015ea71ac", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusUSB}}),
+			Entry("on a lun", "5000c50015ea71ac", v1.DiskDevice{LUN: &v1.LunTarget{Bus: v1.DiskBusSCSI}}),
+		)
+
+		It("should reject disks with duplicate WWNs", func() {
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks,
+				v1.Disk{Name: "testdisk1", WWN: "5000c50015ea71ac", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusSCSI}}},
+				v1.Disk{Name: "testdisk2", WWN: "5000C50015EA71AC", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.DiskBusSCSI}}},
+			)
+
+			causes := ValidateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueDuplicate))
+			Expect(causes[0].Field).To(Equal("fake[1].wwn"))
+		})
+
 		DescribeTable("Should reject disk with DedicatedIOThread and non-virtio bus", func(bus v1.DiskBus) {
 			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks,
 				v1.Disk{
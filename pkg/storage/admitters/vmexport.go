@@ -98,6 +98,8 @@ func (admitter *VMExportAdmitter) Admit(_ context.Context, ar *admissionv1.Admis
 				},
 			}
 		}
+		causes = append(causes, validatePositiveDuration(k8sfield.NewPath("spec", "ttlDuration"), vmExport.Spec.TTLDuration)...)
+		causes = append(causes, validatePositiveDuration(k8sfield.NewPath("spec", "maxLifetime"), vmExport.Spec.MaxLifetime)...)
 
 	case admissionv1.Update:
 		prevObj := &exportv1.VirtualMachineExport{}
@@ -212,3 +214,17 @@ func (admitter *VMExportAdmitter) validateVMApiGroup(field *k8sfield.Path, apigr
 
 	return []metav1.StatusCause{}
 }
+
+func validatePositiveDuration(field *k8sfield.Path, duration *metav1.Duration) []metav1.StatusCause {
+	if duration != nil && duration.Duration <= 0 {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be positive", field.String()),
+				Field:   field.String(),
+			},
+		}
+	}
+
+	return []metav1.StatusCause{}
+}
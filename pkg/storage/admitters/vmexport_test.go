@@ -22,6 +22,7 @@ package admitters
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -161,6 +162,22 @@ var _ = Describe("Validating VirtualMachineExport Admitter", func() {
 			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.source.kind"))
 		})
 
+		DescribeTable("it should reject non-positive durations", func(spec exportv1.VirtualMachineExportSpec, field string) {
+			spec.Source = corev1.TypedLocalObjectReference{
+				Kind: pvc,
+				Name: "test",
+			}
+			ar := createExportAdmissionReview(&exportv1.VirtualMachineExport{Spec: spec})
+			resp := createTestVMExportAdmitter(config).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			Entry("zero TTL", exportv1.VirtualMachineExportSpec{TTLDuration: &metav1.Duration{}}, "spec.ttlDuration"),
+			Entry("negative TTL", exportv1.VirtualMachineExportSpec{TTLDuration: &metav1.Duration{Duration: -time.Hour}}, "spec.ttlDuration"),
+			Entry("zero max lifetime", exportv1.VirtualMachineExportSpec{MaxLifetime: &metav1.Duration{}}, "spec.maxLifetime"),
+		)
+
 		It("should reject spec update", func() {
 			export := &exportv1.VirtualMachineExport{
 				Spec: exportv1.VirtualMachineExportSpec{
@@ -114,6 +114,11 @@ func (admitter *VMRestoreAdmitter) Admit(ctx context.Context, ar *admissionv1.Ad
 					if newCauses != nil {
 						causes = append(causes, newCauses...)
 					}
+
+					newCauses = ValidateIdentityPolicy(vmRestore.Spec.IdentityPolicy, k8sfield.NewPath("spec").Child("identityPolicy"))
+					if newCauses != nil {
+						causes = append(causes, newCauses...)
+					}
 				default:
 					causes = []metav1.StatusCause{
 						{
@@ -350,3 +355,36 @@ func (admitter *VMRestoreAdmitter) validateVolumeOwnershipPolicy(ctx context.Con
 
 	return causes
 }
+
+// ValidateIdentityPolicy verifies that every identity attribute of the policy is either preserved or regenerated
+func ValidateIdentityPolicy(policy *snapshotv1.IdentityPolicy, field *k8sfield.Path) (causes []metav1.StatusCause) {
+	if policy == nil {
+		return nil
+	}
+
+	attributes := []struct {
+		name   string
+		policy *snapshotv1.IdentityRegenerationPolicy
+	}{
+		{"macAddresses", policy.MacAddresses},
+		{"smbiosSerial", policy.SMBiosSerial},
+		{"firmwareUUID", policy.FirmwareUUID},
+		{"hostname", policy.Hostname},
+	}
+	for _, attribute := range attributes {
+		if attribute.policy == nil {
+			continue
+		}
+		switch *attribute.policy {
+		case snapshotv1.IdentityPreserve, snapshotv1.IdentityRegenerate:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("identity policy \"%s\" doesn't exist, valid values are %s and %s", *attribute.policy, snapshotv1.IdentityPreserve, snapshotv1.IdentityRegenerate),
+				Field:   field.Child(attribute.name).String(),
+			})
+		}
+	}
+
+	return causes
+}
@@ -573,6 +573,47 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 				Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.volumeOwnershipPolicy"))
 			})
 
+			DescribeTable("should validate the identity policy", func(identityPolicy *snapshotv1.IdentityPolicy, expectedField string) {
+				restore := &snapshotv1.VirtualMachineRestore{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "restore",
+						Namespace: "default",
+					},
+					Spec: snapshotv1.VirtualMachineRestoreSpec{
+						Target: corev1.TypedLocalObjectReference{
+							APIGroup: &apiGroup,
+							Kind:     "VirtualMachine",
+							Name:     vmName,
+						},
+						VirtualMachineSnapshotName: vmSnapshotName,
+						IdentityPolicy:             identityPolicy,
+					},
+				}
+
+				ar := createRestoreAdmissionReview(restore)
+				resp := createTestVMRestoreAdmitter(config, vm, snapshot).Admit(context.Background(), ar)
+
+				if expectedField == "" {
+					Expect(resp.Allowed).To(BeTrue())
+					return
+				}
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Details.Causes).To(HaveLen(1))
+				Expect(resp.Result.Details.Causes[0].Field).To(Equal(expectedField))
+			},
+				Entry("accept preserve and regenerate", &snapshotv1.IdentityPolicy{
+					MacAddresses: pointer.P(snapshotv1.IdentityRegenerate),
+					Hostname:     pointer.P(snapshotv1.IdentityPreserve),
+				}, ""),
+				Entry("reject invalid MAC address policy", &snapshotv1.IdentityPolicy{
+					MacAddresses: pointer.P(snapshotv1.IdentityRegenerationPolicy("invalid")),
+				}, "spec.identityPolicy.macAddresses"),
+				Entry("reject invalid firmware UUID policy", &snapshotv1.IdentityPolicy{
+					SMBiosSerial: pointer.P(snapshotv1.IdentityRegenerate),
+					FirmwareUUID: pointer.P(snapshotv1.IdentityRegenerationPolicy("invalid")),
+				}, "spec.identityPolicy.firmwareUUID"),
+			)
+
 			DescribeTable("Should reject restore when using backend storage and restoring to different VM", func(doesTargetExist bool) {
 				const targetVMName = "new-test-vm"
 				targetVM := &v1.VirtualMachine{}
@@ -108,6 +108,16 @@ func (admitter *VMSnapshotAdmitter) Admit(ctx context.Context, ar *admissionv1.A
 			}
 		}
 
+		// the memory state is saved to a hotplugged memory dump volume
+		if vmSnapshot.Spec.IncludeMemory != nil && *vmSnapshot.Spec.IncludeMemory &&
+			!admitter.Config.HotplugVolumesEnabled() && !admitter.Config.DeclarativeHotplugVolumesEnabled() {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "including the memory requires the HotplugVolumes or DeclarativeHotplugVolumes feature gate",
+				Field:   k8sfield.NewPath("spec", "includeMemory").String(),
+			})
+		}
+
 	case admissionv1.Update:
 		prevObj := &snapshotv1.VirtualMachineSnapshot{}
 		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
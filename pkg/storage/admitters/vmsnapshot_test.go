@@ -64,12 +64,12 @@ var _ = Describe("Validating VirtualMachineSnapshot Admitter", func() {
 	})
 
 	Context("With feature gate enabled", func() {
-		enableFeatureGate := func(featureGate string) {
+		enableFeatureGate := func(featureGates ...string) {
 			testutils.UpdateFakeKubeVirtClusterConfig(kvStore, &v1.KubeVirt{
 				Spec: v1.KubeVirtSpec{
 					Configuration: v1.KubeVirtConfiguration{
 						DeveloperConfiguration: &v1.DeveloperConfiguration{
-							FeatureGates: []string{featureGate},
+							FeatureGates: featureGates,
 						},
 					},
 				},
@@ -135,6 +135,32 @@ var _ = Describe("Validating VirtualMachineSnapshot Admitter", func() {
 			Expect(resp.Allowed).To(BeTrue())
 		})
 
+		DescribeTable("should only allow including the memory with hotplug volumes", func(featureGates []string, expectAllowed bool) {
+			enableFeatureGate(featureGates...)
+			snapshot := &snapshotv1.VirtualMachineSnapshot{
+				Spec: snapshotv1.VirtualMachineSnapshotSpec{
+					Source: corev1.TypedLocalObjectReference{
+						APIGroup: &apiGroup,
+						Kind:     "VirtualMachine",
+						Name:     vmName,
+					},
+					IncludeMemory: pointer.P(true),
+				},
+			}
+
+			ar := createSnapshotAdmissionReview(snapshot)
+			resp := createTestVMSnapshotAdmitter(config, nil).Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(Equal(expectAllowed))
+			if !expectAllowed {
+				Expect(resp.Result.Details.Causes).To(HaveLen(1))
+				Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.includeMemory"))
+			}
+		},
+			Entry("reject without hotplug volumes", []string{"Snapshot"}, false),
+			Entry("allow with HotplugVolumes", []string{"Snapshot", "HotplugVolumes"}, true),
+			Entry("allow with DeclarativeHotplugVolumes", []string{"Snapshot", "DeclarativeHotplugVolumes"}, true),
+		)
+
 		It("should reject spec update", func() {
 			snapshot := &snapshotv1.VirtualMachineSnapshot{
 				Spec: snapshotv1.VirtualMachineSnapshotSpec{
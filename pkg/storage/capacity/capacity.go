@@ -0,0 +1,190 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package capacity
+
+import (
+	"fmt"
+	"sort"
+
+	k8sv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/tools/cache"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	virtcontroller "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-controller"
+	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	defaultVirtStorageClassAnnotation = "storageclass.kubevirt.io/is-default-virt-class"
+)
+
+// Checker delays the start of VirtualMachines whose volumes are on a StorageClass which
+// is about to run out of capacity, e.g. an exhausted thin pool would corrupt the guests.
+// The minimum capacity is set per StorageClass with the MinAvailableCapacityAnnotation and
+// compared with the largest capacity the CSI driver reports for the StorageClass.
+type Checker struct {
+	storageClassStore       cache.Store
+	csiStorageCapacityIndex cache.Indexer
+	pvcStore                cache.Store
+	clusterConfig           *virtconfig.ClusterConfig
+}
+
+func NewChecker(storageClassStore cache.Store, csiStorageCapacityIndex cache.Indexer, pvcStore cache.Store, clusterConfig *virtconfig.ClusterConfig) *Checker {
+	return &Checker{
+		storageClassStore:       storageClassStore,
+		csiStorageCapacityIndex: csiStorageCapacityIndex,
+		pvcStore:                pvcStore,
+		clusterConfig:           clusterConfig,
+	}
+}
+
+// AllowStart reports whether the VirtualMachine may be started with regard to the
+// capacity of the StorageClasses of its volumes. If not, the reason is returned.
+func (c *Checker) AllowStart(vm *virtv1.VirtualMachine) (bool, string) {
+	if !c.clusterConfig.StorageCapacityAwarenessEnabled() || vm.Spec.Template == nil {
+		return true, ""
+	}
+	if vm.Annotations[virtv1.IgnoreStorageCapacityAnnotation] == "true" {
+		return true, ""
+	}
+
+	for _, storageClass := range c.storageClasses(vm) {
+		minimum, exists := c.minAvailableCapacity(storageClass)
+		if !exists {
+			continue
+		}
+		available, reported := c.availableCapacity(storageClass)
+		if !reported || available.Cmp(minimum) >= 0 {
+			continue
+		}
+		// The VM controller sets the condition once the start is blocked, count each blocked start only once
+		if !controller.NewVirtualMachineConditionManager().HasCondition(vm, virtv1.VirtualMachineStorageCapacityExhausted) {
+			virtcontroller.IncStorageCapacityBlockedStarts(vm.Namespace, storageClass)
+		}
+		return false, fmt.Sprintf("available capacity %s of StorageClass %s is below the minimum of %s",
+			available.String(), storageClass, minimum.String())
+	}
+	return true, ""
+}
+
+// storageClasses returns the sorted StorageClasses of the PVCs used by the VirtualMachine. The
+// StorageClass of a PVC which is not created yet is taken from its DataVolumeTemplate.
+func (c *Checker) storageClasses(vm *virtv1.VirtualMachine) []string {
+	classes := map[string]struct{}{}
+	for i := range vm.Spec.Template.Spec.Volumes {
+		claimName := storagetypes.PVCNameFromVirtVolume(&vm.Spec.Template.Spec.Volumes[i])
+		if claimName == "" {
+			continue
+		}
+		if storageClass := c.claimStorageClass(vm, claimName); storageClass != "" {
+			classes[storageClass] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(classes))
+	for class := range classes {
+		sorted = append(sorted, class)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func (c *Checker) claimStorageClass(vm *virtv1.VirtualMachine, claimName string) string {
+	obj, exists, err := c.pvcStore.GetByKey(controller.NamespacedKey(vm.Namespace, claimName))
+	if err == nil && exists {
+		if storageClass := obj.(*k8sv1.PersistentVolumeClaim).Spec.StorageClassName; storageClass != nil {
+			return *storageClass
+		}
+		return ""
+	}
+
+	for _, template := range vm.Spec.DataVolumeTemplates {
+		if template.Name != claimName {
+			continue
+		}
+		if template.Spec.PVC != nil && template.Spec.PVC.StorageClassName != nil && *template.Spec.PVC.StorageClassName != "" {
+			return *template.Spec.PVC.StorageClassName
+		}
+		if template.Spec.Storage != nil && template.Spec.Storage.StorageClassName != nil && *template.Spec.Storage.StorageClassName != "" {
+			return *template.Spec.Storage.StorageClassName
+		}
+		return c.defaultStorageClass()
+	}
+	return ""
+}
+
+// defaultStorageClass returns the StorageClass CDI provisions DataVolumes without a StorageClass on
+func (c *Checker) defaultStorageClass() string {
+	k8sDefault := ""
+	for _, obj := range c.storageClassStore.List() {
+		sc := obj.(*storagev1.StorageClass)
+		if sc.Annotations[defaultVirtStorageClassAnnotation] == "true" {
+			return sc.Name
+		}
+		if sc.Annotations[defaultStorageClassAnnotation] == "true" {
+			k8sDefault = sc.Name
+		}
+	}
+	return k8sDefault
+}
+
+func (c *Checker) minAvailableCapacity(storageClass string) (resource.Quantity, bool) {
+	obj, exists, err := c.storageClassStore.GetByKey(storageClass)
+	if err != nil || !exists {
+		return resource.Quantity{}, false
+	}
+	value, exists := obj.(*storagev1.StorageClass).Annotations[virtv1.MinAvailableCapacityAnnotation]
+	if !exists {
+		return resource.Quantity{}, false
+	}
+	minimum, err := resource.ParseQuantity(value)
+	if err != nil {
+		log.Log.Reason(err).Warningf("Ignoring invalid %s annotation on StorageClass %s", virtv1.MinAvailableCapacityAnnotation, storageClass)
+		return resource.Quantity{}, false
+	}
+	return minimum, true
+}
+
+// availableCapacity returns the largest capacity reported for the StorageClass in any topology segment
+func (c *Checker) availableCapacity(storageClass string) (resource.Quantity, bool) {
+	objs, err := c.csiStorageCapacityIndex.ByIndex(controller.ByStorageClassIndex, storageClass)
+	if err != nil {
+		return resource.Quantity{}, false
+	}
+
+	var available *resource.Quantity
+	for _, obj := range objs {
+		capacity := obj.(*storagev1.CSIStorageCapacity).Capacity
+		if capacity != nil && (available == nil || capacity.Cmp(*available) > 0) {
+			available = capacity
+		}
+	}
+	if available == nil {
+		return resource.Quantity{}, false
+	}
+	return *available, true
+}
@@ -0,0 +1,246 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package capacity_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	virtcontroller "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-controller"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/storage/capacity"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+var _ = Describe("Storage capacity checker", func() {
+	const (
+		thinPool = "thin-pool"
+		other    = "other"
+	)
+
+	var (
+		storageClassStore cache.Store
+		capacityIndexer   cache.Indexer
+		pvcStore          cache.Store
+		kvStore           cache.Store
+		checker           *capacity.Checker
+	)
+
+	addPVC := func(name, storageClass string) {
+		Expect(pvcStore.Add(&k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Spec:       k8sv1.PersistentVolumeClaimSpec{StorageClassName: pointer.P(storageClass)},
+		})).To(Succeed())
+	}
+
+	addCapacity := func(name, storageClass, value string) {
+		Expect(capacityIndexer.Add(&storagev1.CSIStorageCapacity{
+			ObjectMeta:       metav1.ObjectMeta{Name: name, Namespace: "csi-driver"},
+			StorageClassName: storageClass,
+			Capacity:         pointer.P(resource.MustParse(value)),
+		})).To(Succeed())
+	}
+
+	addStorageClass := func(name, minAvailable string) {
+		sc := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if minAvailable != "" {
+			sc.Annotations = map[string]string{virtv1.MinAvailableCapacityAnnotation: minAvailable}
+		}
+		Expect(storageClassStore.Add(sc)).To(Succeed())
+	}
+
+	newVM := func(claimNames ...string) *virtv1.VirtualMachine {
+		var opts []libvmi.Option
+		for _, claimName := range claimNames {
+			opts = append(opts, libvmi.WithPersistentVolumeClaim(claimName, claimName))
+		}
+		vm := libvmi.NewVirtualMachine(libvmi.New(opts...))
+		vm.Namespace = metav1.NamespaceDefault
+		return vm
+	}
+
+	BeforeEach(func() {
+		storageClassStore = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		capacityIndexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+			controller.ByStorageClassIndex: func(obj interface{}) ([]string, error) {
+				return []string{obj.(*storagev1.CSIStorageCapacity).StorageClassName}, nil
+			},
+		})
+		pvcStore = cache.NewStore(cache.MetaNamespaceKeyFunc)
+
+		var config = &virtv1.KubeVirtConfiguration{
+			DeveloperConfiguration: &virtv1.DeveloperConfiguration{
+				FeatureGates: []string{featuregate.StorageCapacityAwareness},
+			},
+		}
+		clusterConfig, _, store := testutils.NewFakeClusterConfigUsingKVConfig(config)
+		kvStore = store
+		checker = capacity.NewChecker(storageClassStore, capacityIndexer, pvcStore, clusterConfig)
+
+		addStorageClass(thinPool, "100Gi")
+		addStorageClass(other, "")
+		addPVC("root", other)
+		addPVC("data", thinPool)
+		addCapacity("node01", thinPool, "50Gi")
+		addCapacity("node02", thinPool, "80Gi")
+		addCapacity("other", other, "1Gi")
+	})
+
+	It("should block the start if the largest capacity of a StorageClass is below its minimum", func() {
+		before, err := virtcontroller.GetStorageCapacityBlockedStarts(metav1.NamespaceDefault, thinPool)
+		Expect(err).ToNot(HaveOccurred())
+
+		allowed, reason := checker.AllowStart(newVM("root", "data"))
+		Expect(allowed).To(BeFalse())
+		Expect(reason).To(Equal("available capacity 80Gi of StorageClass thin-pool is below the minimum of 100Gi"))
+
+		after, err := virtcontroller.GetStorageCapacityBlockedStarts(metav1.NamespaceDefault, thinPool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(after - before).To(Equal(float64(1)))
+	})
+
+	It("should count a blocked start only once", func() {
+		vm := newVM("data")
+		vm.Status.Conditions = []virtv1.VirtualMachineCondition{{
+			Type:   virtv1.VirtualMachineStorageCapacityExhausted,
+			Status: k8sv1.ConditionTrue,
+		}}
+		before, err := virtcontroller.GetStorageCapacityBlockedStarts(metav1.NamespaceDefault, thinPool)
+		Expect(err).ToNot(HaveOccurred())
+
+		allowed, _ := checker.AllowStart(vm)
+		Expect(allowed).To(BeFalse())
+
+		after, err := virtcontroller.GetStorageCapacityBlockedStarts(metav1.NamespaceDefault, thinPool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(after).To(Equal(before))
+	})
+
+	Context("with DataVolumeTemplates", func() {
+		newDataVolumeVM := func(template cdiv1.DataVolumeSpec) *virtv1.VirtualMachine {
+			vm := libvmi.NewVirtualMachine(libvmi.New(libvmi.WithDataVolume("disk", "disk-dv")))
+			vm.Namespace = metav1.NamespaceDefault
+			vm.Spec.DataVolumeTemplates = []virtv1.DataVolumeTemplateSpec{{
+				ObjectMeta: metav1.ObjectMeta{Name: "disk-dv"},
+				Spec:       template,
+			}}
+			return vm
+		}
+
+		markDefault := func(name, annotation string) {
+			obj, exists, err := storageClassStore.GetByKey(name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			sc := obj.(*storagev1.StorageClass).DeepCopy()
+			if sc.Annotations == nil {
+				sc.Annotations = map[string]string{}
+			}
+			sc.Annotations[annotation] = "true"
+			Expect(storageClassStore.Update(sc)).To(Succeed())
+		}
+
+		DescribeTable("should resolve the StorageClass of a PVC which is not created yet", func(template cdiv1.DataVolumeSpec) {
+			allowed, reason := checker.AllowStart(newDataVolumeVM(template))
+			Expect(allowed).To(BeFalse())
+			Expect(reason).To(ContainSubstring("StorageClass thin-pool"))
+		},
+			Entry("from the storage spec", cdiv1.DataVolumeSpec{Storage: &cdiv1.StorageSpec{StorageClassName: pointer.P(thinPool)}}),
+			Entry("from the PVC spec", cdiv1.DataVolumeSpec{PVC: &k8sv1.PersistentVolumeClaimSpec{StorageClassName: pointer.P(thinPool)}}),
+		)
+
+		It("should fall back to the default StorageClass", func() {
+			markDefault(thinPool, "storageclass.kubernetes.io/is-default-class")
+			allowed, _ := checker.AllowStart(newDataVolumeVM(cdiv1.DataVolumeSpec{Storage: &cdiv1.StorageSpec{}}))
+			Expect(allowed).To(BeFalse())
+		})
+
+		It("should prefer the default virt StorageClass", func() {
+			markDefault(thinPool, "storageclass.kubernetes.io/is-default-class")
+			markDefault(other, "storageclass.kubevirt.io/is-default-virt-class")
+			allowed, _ := checker.AllowStart(newDataVolumeVM(cdiv1.DataVolumeSpec{Storage: &cdiv1.StorageSpec{}}))
+			Expect(allowed).To(BeTrue())
+		})
+
+		It("should prefer the StorageClass of an existing PVC", func() {
+			addPVC("disk-dv", other)
+			allowed, _ := checker.AllowStart(newDataVolumeVM(cdiv1.DataVolumeSpec{Storage: &cdiv1.StorageSpec{StorageClassName: pointer.P(thinPool)}}))
+			Expect(allowed).To(BeTrue())
+		})
+	})
+
+	It("should allow the start if one topology segment has enough capacity", func() {
+		addCapacity("node03", thinPool, "200Gi")
+		allowed, _ := checker.AllowStart(newVM("root", "data"))
+		Expect(allowed).To(BeTrue())
+	})
+
+	DescribeTable("should allow the start", func(vm *virtv1.VirtualMachine) {
+		allowed, reason := checker.AllowStart(vm)
+		Expect(allowed).To(BeTrue())
+		Expect(reason).To(BeEmpty())
+	},
+		Entry("of a VM without volumes on a StorageClass with a minimum", newVM("root")),
+		Entry("of a VM with a volume whose PVC does not exist yet", newVM("missing")),
+		Entry("of a VM overriding the check", func() *virtv1.VirtualMachine {
+			vm := newVM("data")
+			vm.Annotations = map[string]string{virtv1.IgnoreStorageCapacityAnnotation: "true"}
+			return vm
+		}()),
+	)
+
+	It("should allow the start if the StorageClass has no reported capacity", func() {
+		addStorageClass("unreported", "1Ti")
+		addPVC("unreported", "unreported")
+		allowed, _ := checker.AllowStart(newVM("unreported"))
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should ignore an invalid minimum", func() {
+		Expect(storageClassStore.Update(&storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        thinPool,
+				Annotations: map[string]string{virtv1.MinAvailableCapacityAnnotation: "a lot"},
+			},
+		})).To(Succeed())
+		allowed, _ := checker.AllowStart(newVM("data"))
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should allow the start if the feature gate is disabled", func() {
+		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, &virtv1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+			Spec:       virtv1.KubeVirtSpec{Configuration: virtv1.KubeVirtConfiguration{}},
+		})
+		allowed, _ := checker.AllowStart(newVM("data"))
+		Expect(allowed).To(BeTrue())
+	})
+})
@@ -0,0 +1,225 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package export
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	exportv1 "kubevirt.io/api/export/v1beta1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	optutil "kubevirt.io/kubevirt/pkg/virt-operator/util"
+)
+
+const (
+	// renewalCheckInterval is the interval at which the downloads of an export which is about to
+	// expire are checked, and the minimum time between two renewals
+	renewalCheckInterval = time.Minute
+
+	downloadActivityTimeout = 10 * time.Second
+)
+
+// DownloadActivity is reported by the exporter server on DownloadActivityPath
+type DownloadActivity struct {
+	ActiveDownloads int64 `json:"activeDownloads"`
+}
+
+type downloadActivityChecker interface {
+	ActiveDownloads(vmExport *exportv1.VirtualMachineExport, service *corev1.Service) (int64, error)
+}
+
+// httpDownloadActivityChecker asks the exporter server behind the service of the export for its downloads
+type httpDownloadActivityChecker struct {
+	getCA func() (string, error)
+}
+
+func (c *httpDownloadActivityChecker) ActiveDownloads(vmExport *exportv1.VirtualMachineExport, service *corev1.Service) (int64, error) {
+	ca, err := c.getCA()
+	if err != nil {
+		return 0, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(ca)) {
+		return 0, fmt.Errorf("failed to parse the export CA")
+	}
+	client := &http.Client{
+		Timeout: downloadActivityTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    pool,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+
+	resp, err := client.Get(fmt.Sprintf("https://%s.%s.svc%s", service.Name, service.Namespace, DownloadActivityPath))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %d from the exporter of %s/%s", resp.StatusCode, vmExport.Namespace, vmExport.Name)
+	}
+
+	activity := &DownloadActivity{}
+	if err := json.NewDecoder(resp.Body).Decode(activity); err != nil {
+		return 0, err
+	}
+	return activity.ActiveDownloads, nil
+}
+
+func (ctrl *VMExportController) getTTL(vmExport *exportv1.VirtualMachineExport) time.Duration {
+	if vmExport.Spec.TTLDuration != nil {
+		return vmExport.Spec.TTLDuration.Duration
+	}
+	return ctrl.clusterConfig.GetVirtualMachineExportConfiguration().DefaultTTL.Duration
+}
+
+// getMaxLifetime returns the lifetime limit of the export, or zero if it is not limited
+func (ctrl *VMExportController) getMaxLifetime(vmExport *exportv1.VirtualMachineExport) time.Duration {
+	var maxLifetime time.Duration
+	if vmExport.Spec.MaxLifetime != nil {
+		maxLifetime = vmExport.Spec.MaxLifetime.Duration
+	}
+	if clusterMaxLifetime := ctrl.clusterConfig.GetVirtualMachineExportConfiguration().MaxLifetime; clusterMaxLifetime != nil {
+		if maxLifetime == 0 || clusterMaxLifetime.Duration < maxLifetime {
+			maxLifetime = clusterMaxLifetime.Duration
+		}
+	}
+	return maxLifetime
+}
+
+func (ctrl *VMExportController) isAutoRenew(vmExport *exportv1.VirtualMachineExport) bool {
+	if vmExport.Spec.AutoRenew != nil {
+		return *vmExport.Spec.AutoRenew
+	}
+	return *ctrl.clusterConfig.GetVirtualMachineExportConfiguration().AutoRenew
+}
+
+// getExpirationTime returns the time at which the export is deleted. The TTL counts from the
+// last renewal of the export, or from its creation, and is capped by the max lifetime.
+func (ctrl *VMExportController) getExpirationTime(vmExport *exportv1.VirtualMachineExport) time.Time {
+	created := vmExport.GetCreationTimestamp().Time
+	start := created
+	if vmExport.Status != nil && vmExport.Status.LastRenewalTime != nil {
+		start = vmExport.Status.LastRenewalTime.Time
+	}
+
+	expiration := start.Add(ctrl.getTTL(vmExport))
+	if maxLifetime := ctrl.getMaxLifetime(vmExport); maxLifetime > 0 {
+		if deadline := created.Add(maxLifetime); deadline.Before(expiration) {
+			expiration = deadline
+		}
+	}
+	return expiration
+}
+
+// deleteExpiredVMExport deletes the export once it expired and reports whether it did so
+func (ctrl *VMExportController) deleteExpiredVMExport(vmExport *exportv1.VirtualMachineExport) (bool, error) {
+	if currentTime().Time.Before(ctrl.getExpirationTime(vmExport)) {
+		return false, nil
+	}
+
+	log.Log.V(3).Infof("VirtualMachineExport %s/%s expired, deleting it", vmExport.Namespace, vmExport.Name)
+	err := ctrl.Client.VirtualMachineExport(vmExport.Namespace).Delete(context.Background(), vmExport.Name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	return true, nil
+}
+
+// updateExpiration records the expiration time in the status of the export. Once the export is about
+// to expire, it is renewed if auto-renewal is enabled and downloads are in progress, otherwise a warning
+// is emitted once. The export is requeued to be checked again before it expires.
+func (ctrl *VMExportController) updateExpiration(vmExport *exportv1.VirtualMachineExport, exporterPod *corev1.Pod, service *corev1.Service) {
+	// The times in the status are serialized with a precision of seconds
+	now := currentTime().Rfc3339Copy().Time
+	expiryWarning := ctrl.clusterConfig.GetVirtualMachineExportConfiguration().ExpiryWarning.Duration
+	autoRenew := ctrl.isAutoRenew(vmExport)
+
+	expiration := ctrl.getExpirationTime(vmExport)
+	if !now.Before(expiration.Add(-expiryWarning)) && autoRenew && ctrl.canRenew(vmExport, now) &&
+		ctrl.hasActiveDownloads(vmExport, exporterPod, service) {
+		lastRenewal := vmExport.Status.LastRenewalTime
+		vmExport.Status.LastRenewalTime = &metav1.Time{Time: now}
+		if renewed := ctrl.getExpirationTime(vmExport); renewed.After(expiration) {
+			ctrl.Recorder.Eventf(vmExport, corev1.EventTypeNormal, exportRenewedEvent, "Downloads in progress, expiration extended to %s", renewed.Format(time.RFC3339))
+			expiration = renewed
+		} else {
+			// The max lifetime is reached
+			vmExport.Status.LastRenewalTime = lastRenewal
+		}
+	}
+	vmExport.Status.TTLExpirationTime = &metav1.Time{Time: expiration}
+
+	if warningTime := expiration.Add(-expiryWarning); now.Before(warningTime) {
+		vmExport.Status.Conditions = removeCondition(vmExport.Status.Conditions, exportv1.ConditionExpiring)
+		ctrl.enqueueVMExportAfter(vmExport, warningTime.Sub(now))
+		return
+	}
+
+	// The condition records the warning, so that it is emitted once per expiration time
+	expiring := newExpiringCondition(fmt.Sprintf("VirtualMachineExport expires at %s", expiration.Format(time.RFC3339)))
+	if !hasCondition(vmExport.Status.Conditions, expiring) {
+		ctrl.Recorder.Event(vmExport, corev1.EventTypeWarning, exportExpiringEvent, expiring.Message)
+		vmExport.Status.Conditions = updateCondition(vmExport.Status.Conditions, expiring)
+	}
+	recheck := expiration.Sub(now)
+	if autoRenew && recheck > renewalCheckInterval {
+		recheck = renewalCheckInterval
+	}
+	ctrl.enqueueVMExportAfter(vmExport, recheck)
+}
+
+// canRenew limits the renewals, which update the status of the export, to one per renewalCheckInterval
+func (ctrl *VMExportController) canRenew(vmExport *exportv1.VirtualMachineExport, now time.Time) bool {
+	lastRenewal := vmExport.Status.LastRenewalTime
+	return lastRenewal == nil || now.Sub(lastRenewal.Time) >= renewalCheckInterval
+}
+
+func (ctrl *VMExportController) hasActiveDownloads(vmExport *exportv1.VirtualMachineExport, exporterPod *corev1.Pod, service *corev1.Service) bool {
+	if exporterPod == nil || service == nil || !optutil.PodIsReady(exporterPod) {
+		return false
+	}
+	activeDownloads, err := ctrl.downloadActivityChecker.ActiveDownloads(vmExport, service)
+	if err != nil {
+		log.Log.Reason(err).Warningf("Failed to check the downloads of VirtualMachineExport %s/%s", vmExport.Namespace, vmExport.Name)
+		return false
+	}
+	return activeDownloads > 0
+}
+
+func (ctrl *VMExportController) enqueueVMExportAfter(vmExport *exportv1.VirtualMachineExport, duration time.Duration) {
+	if duration <= 0 {
+		duration = requeueTime
+	}
+	ctrl.vmExportQueue.AddAfter(controller.NamespacedKey(vmExport.Namespace, vmExport.Name), duration)
+}
@@ -0,0 +1,271 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package export
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	exportv1 "kubevirt.io/api/export/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+type fakeDownloadActivityChecker struct {
+	activeDownloads int64
+	err             error
+}
+
+func (c *fakeDownloadActivityChecker) ActiveDownloads(_ *exportv1.VirtualMachineExport, _ *k8sv1.Service) (int64, error) {
+	return c.activeDownloads, c.err
+}
+
+var _ = Describe("Export expiration", func() {
+	var (
+		controller      *VMExportController
+		recorder        *record.FakeRecorder
+		vmExportClient  *kubevirtfake.Clientset
+		activityChecker *fakeDownloadActivityChecker
+		queue           *testutils.MockWorkQueue[string]
+		now             time.Time
+		service         *k8sv1.Service
+		readyPod        *k8sv1.Pod
+	)
+
+	newController := func(exportConfig *virtv1.VirtualMachineExportConfiguration) {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&virtv1.KubeVirtConfiguration{
+			VirtualMachineExport: exportConfig,
+		})
+		virtClient := kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		virtClient.EXPECT().VirtualMachineExport(testNamespace).
+			Return(vmExportClient.ExportV1beta1().VirtualMachineExports(testNamespace)).AnyTimes()
+		queue = testutils.NewMockWorkQueue(workqueue.NewTypedRateLimitingQueue[string](workqueue.DefaultTypedControllerRateLimiter[string]()))
+		DeferCleanup(queue.ShutDown)
+
+		controller = &VMExportController{
+			Client:                  virtClient,
+			Recorder:                recorder,
+			clusterConfig:           clusterConfig,
+			vmExportQueue:           queue,
+			downloadActivityChecker: activityChecker,
+		}
+	}
+
+	newVMExport := func(age time.Duration) *exportv1.VirtualMachineExport {
+		vmExport := createPVCVMExport()
+		vmExport.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		vmExport.Status = &exportv1.VirtualMachineExportStatus{}
+		return vmExport
+	}
+
+	BeforeEach(func() {
+		now = time.Now().Truncate(time.Second)
+		originalCurrentTime := currentTime
+		currentTime = func() *metav1.Time {
+			t := metav1.NewTime(now)
+			return &t
+		}
+		DeferCleanup(func() {
+			currentTime = originalCurrentTime
+		})
+
+		recorder = record.NewFakeRecorder(10)
+		vmExportClient = kubevirtfake.NewSimpleClientset()
+		activityChecker = &fakeDownloadActivityChecker{}
+		service = &k8sv1.Service{ObjectMeta: metav1.ObjectMeta{Name: "virt-export-test", Namespace: testNamespace}}
+		readyPod = &k8sv1.Pod{
+			Status: k8sv1.PodStatus{
+				Phase: k8sv1.PodRunning,
+				Conditions: []k8sv1.PodCondition{
+					{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue},
+				},
+			},
+		}
+		newController(nil)
+	})
+
+	DescribeTable("should compute the expiration time", func(exportConfig *virtv1.VirtualMachineExportConfiguration, spec exportv1.VirtualMachineExportSpec, expected time.Duration) {
+		newController(exportConfig)
+		vmExport := newVMExport(0)
+		vmExport.Spec.TTLDuration = spec.TTLDuration
+		vmExport.Spec.MaxLifetime = spec.MaxLifetime
+		Expect(controller.getExpirationTime(vmExport)).To(Equal(now.Add(expected)))
+	},
+		Entry("with the default TTL", nil, exportv1.VirtualMachineExportSpec{}, exportv1.DefaultDurationTTL),
+		Entry("with the TTL of the KubeVirt configuration",
+			&virtv1.VirtualMachineExportConfiguration{DefaultTTL: &metav1.Duration{Duration: time.Hour}},
+			exportv1.VirtualMachineExportSpec{}, time.Hour),
+		Entry("with the TTL of the export",
+			&virtv1.VirtualMachineExportConfiguration{DefaultTTL: &metav1.Duration{Duration: time.Hour}},
+			exportv1.VirtualMachineExportSpec{TTLDuration: &metav1.Duration{Duration: 3 * time.Hour}}, 3*time.Hour),
+		Entry("capped by the max lifetime of the KubeVirt configuration",
+			&virtv1.VirtualMachineExportConfiguration{MaxLifetime: &metav1.Duration{Duration: time.Hour}},
+			exportv1.VirtualMachineExportSpec{TTLDuration: &metav1.Duration{Duration: 3 * time.Hour}}, time.Hour),
+		Entry("capped by the max lifetime of the export",
+			&virtv1.VirtualMachineExportConfiguration{MaxLifetime: &metav1.Duration{Duration: time.Hour}},
+			exportv1.VirtualMachineExportSpec{MaxLifetime: &metav1.Duration{Duration: 30 * time.Minute}}, 30*time.Minute),
+		Entry("not exceeding the max lifetime of the KubeVirt configuration",
+			&virtv1.VirtualMachineExportConfiguration{MaxLifetime: &metav1.Duration{Duration: time.Hour}},
+			exportv1.VirtualMachineExportSpec{MaxLifetime: &metav1.Duration{Duration: 5 * time.Hour}}, time.Hour),
+	)
+
+	It("should count the TTL from the last renewal", func() {
+		vmExport := newVMExport(3 * time.Hour)
+		vmExport.Status.LastRenewalTime = &metav1.Time{Time: now.Add(-time.Hour)}
+		Expect(controller.getExpirationTime(vmExport)).To(Equal(now.Add(exportv1.DefaultDurationTTL - time.Hour)))
+	})
+
+	It("should delete the export once it expired", func() {
+		var deleted bool
+		vmExportClient.Fake.PrependReactor("delete", "virtualmachineexports", func(action testing.Action) (bool, runtime.Object, error) {
+			deleted = true
+			return true, nil, nil
+		})
+
+		expired, err := controller.deleteExpiredVMExport(newVMExport(time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expired).To(BeFalse())
+		Expect(deleted).To(BeFalse())
+
+		expired, err = controller.deleteExpiredVMExport(newVMExport(exportv1.DefaultDurationTTL))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expired).To(BeTrue())
+		Expect(deleted).To(BeTrue())
+	})
+
+	It("should record the expiration and requeue the export when it is about to expire", func() {
+		vmExport := newVMExport(time.Hour)
+		controller.updateExpiration(vmExport, readyPod, service)
+		Expect(vmExport.Status.TTLExpirationTime.Time).To(Equal(now.Add(time.Hour)))
+		Expect(vmExport.Status.LastRenewalTime).To(BeNil())
+		Expect(queue.GetAddAfterEnqueueCount()).To(Equal(1))
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should warn about an export which is about to expire", func() {
+		vmExport := newVMExport(exportv1.DefaultDurationTTL - 5*time.Minute)
+		controller.updateExpiration(vmExport, readyPod, service)
+		Expect(vmExport.Status.TTLExpirationTime.Time).To(Equal(now.Add(5 * time.Minute)))
+		testutils.ExpectEvent(recorder, exportExpiringEvent)
+		Expect(vmExport.Status.Conditions).To(ContainElement(HaveField("Type", exportv1.ConditionExpiring)))
+	})
+
+	It("should warn only once about an export which is about to expire", func() {
+		vmExport := newVMExport(exportv1.DefaultDurationTTL - 5*time.Minute)
+		controller.updateExpiration(vmExport, readyPod, service)
+		testutils.ExpectEvent(recorder, exportExpiringEvent)
+
+		controller.updateExpiration(vmExport, readyPod, service)
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	Context("with auto-renewal", func() {
+		BeforeEach(func() {
+			newController(&virtv1.VirtualMachineExportConfiguration{AutoRenew: pointer.P(true)})
+		})
+
+		It("should renew an export which is about to expire while downloads are in progress", func() {
+			activityChecker.activeDownloads = 1
+			vmExport := newVMExport(exportv1.DefaultDurationTTL - 5*time.Minute)
+			controller.updateExpiration(vmExport, readyPod, service)
+			Expect(vmExport.Status.LastRenewalTime.Time).To(BeTemporally("==", now))
+			Expect(vmExport.Status.TTLExpirationTime.Time).To(BeTemporally("==", now.Add(exportv1.DefaultDurationTTL)))
+			testutils.ExpectEvent(recorder, exportRenewedEvent)
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should warn again once a renewed export is about to expire", func() {
+			vmExport := newVMExport(exportv1.DefaultDurationTTL - 5*time.Minute)
+			controller.updateExpiration(vmExport, readyPod, service)
+			testutils.ExpectEvent(recorder, exportExpiringEvent)
+
+			activityChecker.activeDownloads = 1
+			controller.updateExpiration(vmExport, readyPod, service)
+			testutils.ExpectEvent(recorder, exportRenewedEvent)
+			Expect(vmExport.Status.Conditions).ToNot(ContainElement(HaveField("Type", exportv1.ConditionExpiring)))
+
+			activityChecker.activeDownloads = 0
+			vmExport.Spec.TTLDuration = &metav1.Duration{Duration: 5 * time.Minute}
+			controller.updateExpiration(vmExport, readyPod, service)
+			testutils.ExpectEvent(recorder, exportExpiringEvent)
+		})
+
+		It("should not renew an export which was renewed recently", func() {
+			activityChecker.activeDownloads = 1
+			vmExport := newVMExport(time.Hour)
+			vmExport.Spec.TTLDuration = &metav1.Duration{Duration: 5 * time.Minute}
+			lastRenewal := metav1.NewTime(now.Add(-30 * time.Second))
+			vmExport.Status.LastRenewalTime = &lastRenewal
+			controller.updateExpiration(vmExport, readyPod, service)
+			Expect(vmExport.Status.LastRenewalTime).To(Equal(&lastRenewal))
+			testutils.ExpectEvent(recorder, exportExpiringEvent)
+		})
+
+		DescribeTable("should not renew an export", func(activeDownloads int64, err error, pod *k8sv1.Pod) {
+			activityChecker.activeDownloads = activeDownloads
+			activityChecker.err = err
+			vmExport := newVMExport(exportv1.DefaultDurationTTL - 5*time.Minute)
+			controller.updateExpiration(vmExport, pod, service)
+			Expect(vmExport.Status.LastRenewalTime).To(BeNil())
+			Expect(vmExport.Status.TTLExpirationTime.Time).To(Equal(now.Add(5 * time.Minute)))
+			testutils.ExpectEvent(recorder, exportExpiringEvent)
+		},
+			Entry("without downloads in progress", int64(0), nil, &k8sv1.Pod{}),
+			Entry("if the downloads cannot be checked", int64(1), fmt.Errorf("connection refused"), &k8sv1.Pod{}),
+			Entry("without a ready exporter pod", int64(1), nil, &k8sv1.Pod{}),
+			Entry("without an exporter pod", int64(1), nil, nil),
+		)
+
+		It("should not renew an export beyond its max lifetime", func() {
+			activityChecker.activeDownloads = 1
+			vmExport := newVMExport(exportv1.DefaultDurationTTL - 5*time.Minute)
+			vmExport.Spec.MaxLifetime = &metav1.Duration{Duration: exportv1.DefaultDurationTTL}
+			controller.updateExpiration(vmExport, readyPod, service)
+			Expect(vmExport.Status.LastRenewalTime).To(BeNil())
+			Expect(vmExport.Status.TTLExpirationTime.Time).To(Equal(now.Add(5 * time.Minute)))
+			testutils.ExpectEvent(recorder, exportExpiringEvent)
+		})
+
+		It("should not limit the exporter pod to the expiration of the export", func() {
+			vmExport := newVMExport(exportv1.DefaultDurationTTL - 5*time.Minute)
+			Expect(controller.getDeadlineValue(time.Hour, vmExport)).To(Equal(now.Add(time.Hour)))
+		})
+	})
+
+	It("should limit the exporter pod to the expiration of the export", func() {
+		vmExport := newVMExport(exportv1.DefaultDurationTTL - 5*time.Minute)
+		Expect(controller.getDeadlineValue(time.Hour, vmExport)).To(Equal(now.Add(5 * time.Minute)))
+	})
+})
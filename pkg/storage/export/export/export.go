@@ -88,6 +88,7 @@ const (
 	noVolumeSnapshotReason    = "VMSnapshotNoVolumes"
 	notAllPVCsCreatedReason   = "NotAllPVCsCreated"
 	VMSnapshotNotFoundReason  = "VMSnapshotNotFound"
+	expiringReason            = "Expiring"
 
 	exportServiceLabel = "kubevirt.io.virt-export-service"
 
@@ -117,6 +118,8 @@ const (
 	serviceCreatedEvent                   = "ServiceCreated"
 	certParamsChangedEvent                = "CertificateParametersChanged"
 	exporterManifestConfigMapCreatedEvent = "DataManifestCreated"
+	exportExpiringEvent                   = "ExportExpiring"
+	exportRenewedEvent                    = "ExportRenewed"
 
 	kvm = 107
 
@@ -137,6 +140,8 @@ const (
 
 	// ReadinessPath is the endpoint used to check the readiness probe
 	ReadinessPath = "/exportready"
+	// DownloadActivityPath is the endpoint used to check the downloads in progress
+	DownloadActivityPath = "/exportactivity"
 )
 
 // variable so can be overridden in tests
@@ -351,6 +356,8 @@ type VMExportController struct {
 	clusterConfig *virtconfig.ClusterConfig
 
 	instancetypeHandler instancetypeVMHandler
+
+	downloadActivityChecker downloadActivityChecker
 }
 
 type CertParams struct {
@@ -484,6 +491,7 @@ func (ctrl *VMExportController) Init() error {
 			ctrl.Client,
 		),
 	)
+	ctrl.downloadActivityChecker = &httpDownloadActivityChecker{getCA: ctrl.internalExportCa}
 
 	initCert(ctrl)
 	return nil
@@ -603,7 +611,8 @@ func (ctrl *VMExportController) handleKubeVirt(oldObj, newObj interface{}) {
 		return
 	}
 
-	if equality.Semantic.DeepEqual(okv.Spec.CertificateRotationStrategy, nkv.Spec.CertificateRotationStrategy) {
+	if equality.Semantic.DeepEqual(okv.Spec.CertificateRotationStrategy, nkv.Spec.CertificateRotationStrategy) &&
+		equality.Semantic.DeepEqual(okv.Spec.Configuration.VirtualMachineExport, nkv.Spec.Configuration.VirtualMachineExport) {
 		return
 	}
 
@@ -640,7 +649,11 @@ func (ctrl *VMExportController) updateVMExport(vmExport *exportv1.VirtualMachine
 	}
 
 	if vmExport.Status == nil {
-		populateInitialVMExportStatus(vmExport)
+		ctrl.populateInitialVMExportStatus(vmExport)
+	}
+
+	if deleted, err := ctrl.deleteExpiredVMExport(vmExport); deleted || err != nil {
+		return 0, err
 	}
 
 	if ctrl.isSourcePvc(&vmExport.Spec) {
@@ -743,13 +756,6 @@ func (ctrl *VMExportController) checkPod(vmExport *exportv1.VirtualMachineExport
 		return nil
 	}
 
-	if ttlExpiration := getExpirationTime(vmExport); !time.Now().Before(ttlExpiration) {
-		if err := ctrl.Client.VirtualMachineExport(vmExport.Namespace).Delete(context.Background(), vmExport.Name, metav1.DeleteOptions{}); err != nil {
-			return err
-		}
-		return nil
-	}
-
 	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
 		// The server died or completed, delete the pod.
 		return ctrl.deleteExporterPod(vmExport, pod, exporterPodFailedOrCompletedEvent, fmt.Sprintf("Exporter pod %s/%s is in phase %s", pod.Namespace, pod.Name, pod.Status.Phase))
@@ -1098,7 +1104,7 @@ func (ctrl *VMExportController) createExporterPodManifest(vmExport *exportv1.Vir
 		Value: "/token/token",
 	}, corev1.EnvVar{
 		Name:  "DEADLINE",
-		Value: getDeadlineValue(deadline, vmExport).Format(time.RFC3339),
+		Value: ctrl.getDeadlineValue(deadline, vmExport).Format(time.RFC3339),
 	}, corev1.EnvVar{
 		Name:  "EXPORT_VM_DEF_URI",
 		Value: manifestsPath,
@@ -1368,6 +1374,8 @@ func (ctrl *VMExportController) updateStatus(vmExport *exportv1.VirtualMachineEx
 		return requeue, err
 	}
 
+	ctrl.updateExpiration(vmExportCopy, exporterPod, service)
+
 	if err := ctrl.updateVMExportStatus(vmExport, vmExportCopy); err != nil {
 		return requeue, err
 	}
@@ -1431,8 +1439,8 @@ func (ctrl *VMExportController) getCertParams() (*CertParams, error) {
 	}, nil
 }
 
-func populateInitialVMExportStatus(vmExport *exportv1.VirtualMachineExport) {
-	expireAt := metav1.NewTime(getExpirationTime(vmExport))
+func (ctrl *VMExportController) populateInitialVMExportStatus(vmExport *exportv1.VirtualMachineExport) {
+	expireAt := metav1.NewTime(ctrl.getExpirationTime(vmExport))
 	vmExport.Status = &exportv1.VirtualMachineExportStatus{
 		Phase: exportv1.Pending,
 		Conditions: []exportv1.Condition{
@@ -1442,10 +1450,14 @@ func populateInitialVMExportStatus(vmExport *exportv1.VirtualMachineExport) {
 	}
 }
 
-func getDeadlineValue(deadline time.Duration, vmExport *exportv1.VirtualMachineExport) time.Time {
+func (ctrl *VMExportController) getDeadlineValue(deadline time.Duration, vmExport *exportv1.VirtualMachineExport) time.Time {
 	// Pod needs to shutdown to either cert rotate or because export TTL expired altogether
 	rotate := currentTime().Add(deadline)
-	ttlExpiration := getExpirationTime(vmExport)
+	if ctrl.isAutoRenew(vmExport) {
+		// The expiration of the export can still be extended, the pod is deleted along with it
+		return rotate
+	}
+	ttlExpiration := ctrl.getExpirationTime(vmExport)
 
 	if ttlExpiration.After(rotate) {
 		return rotate
@@ -1453,15 +1465,6 @@ func getDeadlineValue(deadline time.Duration, vmExport *exportv1.VirtualMachineE
 	return ttlExpiration
 }
 
-func getExpirationTime(vmExport *exportv1.VirtualMachineExport) time.Time {
-	ttl := exportv1.DefaultDurationTTL
-	if vmExport.Spec.TTLDuration != nil {
-		ttl = vmExport.Spec.TTLDuration.Duration
-	}
-
-	return vmExport.GetCreationTimestamp().Time.Add(ttl)
-}
-
 func newReadyCondition(status corev1.ConditionStatus, reason, message string) exportv1.Condition {
 	return exportv1.Condition{
 		Type:               exportv1.ConditionReady,
@@ -1492,6 +1495,16 @@ func newVolumesCreatedCondition(status corev1.ConditionStatus, reason, message s
 	}
 }
 
+func newExpiringCondition(message string) exportv1.Condition {
+	return exportv1.Condition{
+		Type:               exportv1.ConditionExpiring,
+		Status:             corev1.ConditionTrue,
+		Reason:             expiringReason,
+		Message:            message,
+		LastTransitionTime: *currentTime(),
+	}
+}
+
 func updateCondition(conditions []exportv1.Condition, c exportv1.Condition) []exportv1.Condition {
 	found := false
 	for i := range conditions {
@@ -1511,6 +1524,25 @@ func updateCondition(conditions []exportv1.Condition, c exportv1.Condition) []ex
 	return conditions
 }
 
+func removeCondition(conditions []exportv1.Condition, conditionType exportv1.ConditionType) []exportv1.Condition {
+	filtered := make([]exportv1.Condition, 0, len(conditions))
+	for _, c := range conditions {
+		if c.Type != conditionType {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+func hasCondition(conditions []exportv1.Condition, c exportv1.Condition) bool {
+	for _, condition := range conditions {
+		if condition.Type == c.Type && condition.Status == c.Status && condition.Reason == c.Reason && condition.Message == c.Message {
+			return true
+		}
+	}
+	return false
+}
+
 func (ctrl *VMExportController) pvcConditionFromPVC(pvcs []*corev1.PersistentVolumeClaim) exportv1.Condition {
 	cond := exportv1.Condition{
 		Type:               exportv1.ConditionPVC,
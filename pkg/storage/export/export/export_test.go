@@ -676,7 +676,8 @@ var _ = Describe("Export controller", func() {
 			},
 		}
 		syncCaches(stop)
-		mockVMExportQueue.ExpectAdds(2)
+		// The sync also requeues the vmexport to check its expiration
+		mockVMExportQueue.ExpectAdds(3)
 		vmExportSource.Add(vmExport)
 		controller.processVMExportWorkItem()
 		pvcInformer.GetStore().Add(pvc)
@@ -719,7 +720,8 @@ var _ = Describe("Export controller", func() {
 			},
 		}
 		syncCaches(stop)
-		mockVMExportQueue.ExpectAdds(1)
+		// The sync requeues the vmexport to check its expiration
+		mockVMExportQueue.ExpectAdds(2)
 		vmExportSource.Add(vmExport)
 		controller.processVMExportWorkItem()
 		vmiInformer.GetStore().Add(vmi)
@@ -838,7 +840,7 @@ var _ = Describe("Export controller", func() {
 			},
 		}
 		testVMExport := populateExportFunc()
-		populateInitialVMExportStatus(testVMExport)
+		controller.populateInitialVMExportStatus(testVMExport)
 
 		sv := &sourceVolumes{
 			volumes:     controller.pvcsToSourceVolumes(testPVC),
@@ -980,7 +982,7 @@ var _ = Describe("Export controller", func() {
 				VolumeMode: (*k8sv1.PersistentVolumeMode)(pointer.P(string(k8sv1.PersistentVolumeBlock))),
 			},
 		}
-		populateInitialVMExportStatus(testVMExport)
+		controller.populateInitialVMExportStatus(testVMExport)
 		sv := &sourceVolumes{
 			volumes:     controller.pvcsToSourceVolumes(testPVC),
 			isPopulated: true,
@@ -1095,7 +1097,7 @@ var _ = Describe("Export controller", func() {
 		scp, err := serializeCertParams(cp)
 		Expect(err).ToNot(HaveOccurred())
 		testVMExport := createPVCVMExport()
-		populateInitialVMExportStatus(testVMExport)
+		controller.populateInitialVMExportStatus(testVMExport)
 		sv := &sourceVolumes{}
 		pvcSource := NewPVCSource(sv)
 		err = controller.handleVMExportToken(testVMExport, pvcSource)
@@ -1184,7 +1186,7 @@ var _ = Describe("Export controller", func() {
 			isPopulated: true,
 		}
 		pvcSource := NewPVCSource(sv)
-		populateInitialVMExportStatus(testVMExport)
+		controller.populateInitialVMExportStatus(testVMExport)
 		err := controller.handleVMExportToken(testVMExport, pvcSource)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(testVMExport.Status.TokenSecretRef).ToNot(BeNil())
@@ -1204,7 +1206,7 @@ var _ = Describe("Export controller", func() {
 			Expect(secret.GetNamespace()).To(Equal(testNamespace))
 			return true, secret, nil
 		})
-		populateInitialVMExportStatus(testVMExport)
+		controller.populateInitialVMExportStatus(testVMExport)
 		sv := &sourceVolumes{
 			volumes:     nil,
 			isPopulated: false,
@@ -1255,7 +1257,7 @@ var _ = Describe("Export controller", func() {
 		testVMExport := createPVCVMExport()
 		Expect(testVMExport.Spec.TokenSecretRef).ToNot(BeNil())
 		expectedName := *testVMExport.Spec.TokenSecretRef
-		populateInitialVMExportStatus(testVMExport)
+		controller.populateInitialVMExportStatus(testVMExport)
 		sv := &sourceVolumes{}
 		pvcSource := NewPVCSource(sv)
 		err := controller.handleVMExportToken(testVMExport, pvcSource)
@@ -1291,9 +1293,7 @@ var _ = Describe("Export controller", func() {
 		})
 		retry, err := controller.updateVMExport(testVMExport)
 		Expect(deleted).To(BeTrue())
-		// Status update fails (call UPDATE on deleted VMExport), but its fine in real world
-		// since requeue will back out of the reconcile loop if a deletion timestamp is set
-		Expect(err).To(HaveOccurred())
+		Expect(err).ToNot(HaveOccurred())
 		Expect(retry).To(BeEquivalentTo(0))
 	})
 
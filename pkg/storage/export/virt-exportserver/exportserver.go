@@ -34,9 +34,12 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	gzip "github.com/klauspost/pgzip"
 	flag "github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
@@ -66,6 +69,8 @@ const (
 
 	external = "/external"
 	internal = "/internal"
+
+	encodingZstd = "zstd"
 )
 
 var excludeMap = map[string]struct{}{
@@ -107,6 +112,9 @@ type execReader struct {
 type exportServer struct {
 	ExportServerConfig
 	handler http.Handler
+
+	// activeDownloads counts the volume downloads in progress, which keep an auto-renewed export alive
+	activeDownloads atomic.Int64
 }
 
 func (er *execReader) Read(p []byte) (int, error) {
@@ -133,7 +141,7 @@ func (s *exportServer) initHandler() {
 		}
 		for path, handler := range s.getHandlerMap(vi) {
 			log.Log.Infof("Handling path %s\n", path)
-			mux.Handle(path, tokenChecker(s.TokenGetter, handler))
+			mux.Handle(path, tokenChecker(s.TokenGetter, s.trackDownload(handler)))
 		}
 	}
 	if s.Paths.VMURI != "" {
@@ -146,6 +154,8 @@ func (s *exportServer) initHandler() {
 	}
 	// Readiness probe
 	mux.HandleFunc(export.ReadinessPath, s.readyHandler)
+	// Polled by virt-controller to decide whether to renew the export, only reports a count
+	mux.HandleFunc(export.DownloadActivityPath, s.activityHandler)
 
 	s.handler = mux
 }
@@ -447,6 +457,53 @@ func pipeToGzip(reader io.ReadCloser) io.ReadCloser {
 	return pr
 }
 
+func pipeToZstd(reader io.Reader) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	zw, err := zstd.NewWriter(pw)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		n, err := io.Copy(zw, reader)
+		if err != nil {
+			log.Log.Reason(err).Error("error piping to zstd")
+		}
+		if err = zw.Close(); err != nil {
+			log.Log.Reason(err).Error("error closing zstd writer")
+		}
+		if err = pw.Close(); err != nil {
+			log.Log.Reason(err).Error("error closing pipe writer")
+		}
+		log.Log.Infof("Wrote %d bytes\n", n)
+	}()
+
+	return pr, nil
+}
+
+// acceptsZstd checks whether the client negotiated a zstd compressed response.
+// Range requests are always served uncompressed.
+func acceptsZstd(r *http.Request) bool {
+	if r.Header.Get("Range") != "" {
+		return false
+	}
+	for _, value := range r.Header.Values("Accept-Encoding") {
+		for _, coding := range strings.Split(value, ",") {
+			name, params, _ := strings.Cut(coding, ";")
+			if strings.TrimSpace(name) != encodingZstd {
+				continue
+			}
+			if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+				if quality, err := strconv.ParseFloat(q, 64); err == nil && quality == 0 {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
 func getTokenQueryParam(r *http.Request) (token string) {
 	q := r.URL.Query()
 	if keys, ok := q[authHeader]; ok {
@@ -718,7 +775,31 @@ func fileHandler(file string) http.Handler {
 			return
 		}
 		defer f.Close()
-		http.ServeContent(w, r, "disk.img", time.Time{}, f)
+		if !acceptsZstd(r) {
+			http.ServeContent(w, r, "disk.img", time.Time{}, f)
+			return
+		}
+
+		sparseReader, err := newSparseReader(f)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error reading %s", file)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		zstdReader, err := pipeToZstd(sparseReader)
+		if err != nil {
+			log.Log.Reason(err).Error("error creating zstd writer")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer zstdReader.Close()
+		w.Header().Set("Content-Encoding", encodingZstd)
+		w.Header().Add("Vary", "Accept-Encoding")
+		n, err := io.Copy(w, zstdReader)
+		if err != nil {
+			log.Log.Reason(err).Error("error writing response body")
+		}
+		log.Log.Infof("Wrote %d bytes\n", n)
 	})
 }
 
@@ -785,3 +866,18 @@ func secretHandler(tokenGetter TokenGetterFunc) http.Handler {
 func (s *exportServer) readyHandler(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, "OK")
 }
+
+func (s *exportServer) trackDownload(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.activeDownloads.Add(1)
+		defer s.activeDownloads.Add(-1)
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func (s *exportServer) activityHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(export.DownloadActivity{ActiveDownloads: s.activeDownloads.Load()}); err != nil {
+		log.Log.Reason(err).Error("Failed to write the download activity")
+	}
+}
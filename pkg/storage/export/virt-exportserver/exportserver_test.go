@@ -25,8 +25,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/klauspost/compress/zstd"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
@@ -770,4 +774,138 @@ var _ = Describe("exportserver", func() {
 			verifySecret(string(list.Items[0].Raw))
 		})
 	})
+
+	Context("File handler", func() {
+		const size = 8 * 1024 * 1024
+
+		var (
+			filePath string
+			data     []byte
+		)
+
+		BeforeEach(func() {
+			filePath = filepath.Join(GinkgoT().TempDir(), "disk.img")
+			f, err := os.Create(filePath)
+			Expect(err).ToNot(HaveOccurred())
+			defer f.Close()
+
+			// Leave holes around a single extent of data
+			data = make([]byte, size)
+			copy(data[size/2:], strings.Repeat("kubevirt", 1024))
+			_, err = f.WriteAt(data[size/2:size/2+8*1024], size/2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(f.Truncate(size)).To(Succeed())
+		})
+
+		serve := func(headers map[string]string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/volume/disk.img", nil)
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+			rr := httptest.NewRecorder()
+			fileHandler(filePath).ServeHTTP(rr, req)
+			Expect(rr.Code).To(Or(Equal(http.StatusOK), Equal(http.StatusPartialContent)))
+			return rr
+		}
+
+		It("should serve the raw file if zstd is not accepted", func() {
+			rr := serve(nil)
+			Expect(rr.Header().Get("Content-Encoding")).To(BeEmpty())
+			Expect(rr.Body.Bytes()).To(Equal(data))
+		})
+
+		It("should serve the raw file if zstd is refused", func() {
+			rr := serve(map[string]string{"Accept-Encoding": "gzip, zstd;q=0"})
+			Expect(rr.Header().Get("Content-Encoding")).To(BeEmpty())
+			Expect(rr.Body.Bytes()).To(Equal(data))
+		})
+
+		It("should serve ranges uncompressed", func() {
+			rr := serve(map[string]string{"Accept-Encoding": "zstd", "Range": fmt.Sprintf("bytes=%d-%d", size/2, size/2+7)})
+			Expect(rr.Header().Get("Content-Encoding")).To(BeEmpty())
+			Expect(rr.Body.String()).To(Equal("kubevirt"))
+		})
+
+		It("should serve a zstd compressed file if negotiated", func() {
+			rr := serve(map[string]string{"Accept-Encoding": "gzip, zstd"})
+			Expect(rr.Header().Get("Content-Encoding")).To(Equal("zstd"))
+			Expect(rr.Body.Len()).To(BeNumerically("<", size/100))
+
+			decoder, err := zstd.NewReader(rr.Body)
+			Expect(err).ToNot(HaveOccurred())
+			defer decoder.Close()
+			decoded, err := io.ReadAll(decoder)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decoded).To(Equal(data))
+		})
+	})
+
+	It("should report the downloads in progress", func() {
+		token := "foo"
+		started := make(chan struct{})
+		release := make(chan struct{})
+		es := newTestServer(token)
+		es.FileHandler = func(string) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				<-release
+			})
+		}
+		es.Paths = &export.ServerPaths{Volumes: []export.VolumeInfo{{Path: "/tmp", RawURI: "/volume/v1/disk.img"}}}
+		es.initHandler()
+
+		httpServer := httptest.NewServer(es.handler)
+		defer httpServer.Close()
+
+		activeDownloads := func() int64 {
+			res, err := http.Get(httpServer.URL + export.DownloadActivityPath)
+			Expect(err).ToNot(HaveOccurred())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			activity := &export.DownloadActivity{}
+			Expect(json.NewDecoder(res.Body).Decode(activity)).To(Succeed())
+			return activity.ActiveDownloads
+		}
+		Expect(activeDownloads()).To(BeZero())
+
+		done := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			req, err := http.NewRequest(http.MethodGet, httpServer.URL+"/volume/v1/disk.img", nil)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("x-kubevirt-export-token", token)
+			res, err := http.DefaultClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			res.Body.Close()
+		}()
+		Eventually(started).Should(BeClosed())
+		Expect(activeDownloads()).To(Equal(int64(1)))
+
+		close(release)
+		Eventually(done).Should(BeClosed())
+		Expect(activeDownloads()).To(BeZero())
+	})
+
+	It("sparse reader should return the file contents", func() {
+		filePath := filepath.Join(GinkgoT().TempDir(), "disk.img")
+		f, err := os.Create(filePath)
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+
+		const size = 3 * 1024 * 1024
+		expected := make([]byte, size)
+		for offset, chunk := range map[int]string{0: "head", size / 2: "middle", size - 4: "tail"} {
+			copy(expected[offset:], chunk)
+			_, err = f.WriteAt([]byte(chunk), int64(offset))
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(f.Truncate(size)).To(Succeed())
+
+		reader, err := newSparseReader(f)
+		Expect(err).ToNot(HaveOccurred())
+		contents, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contents).To(Equal(expected))
+	})
 })
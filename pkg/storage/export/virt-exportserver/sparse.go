@@ -0,0 +1,104 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package virtexportserver
+
+import (
+	"errors"
+	"io"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// sparseReader reads a file while skipping over its holes. Holes are returned
+// as zeroes without reading them from disk, which keeps streaming mostly empty
+// disk images cheap. Files on filesystems which can't report holes are read
+// as a single data extent.
+type sparseReader struct {
+	file   *os.File
+	size   int64
+	offset int64
+	// [offset, dataEnd) is known to hold data, [offset, holeEnd) is known to be a hole
+	dataEnd int64
+	holeEnd int64
+}
+
+func newSparseReader(file *os.File) (*sparseReader, error) {
+	// Seeking to the end works for block devices too, Stat reports a size of zero for them
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	return &sparseReader{file: file, size: size}, nil
+}
+
+func (r *sparseReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.offset >= r.dataEnd && r.offset >= r.holeEnd {
+		if err := r.nextExtent(); err != nil {
+			return 0, err
+		}
+	}
+
+	if r.offset < r.holeEnd {
+		n := int(min(int64(len(p)), r.holeEnd-r.offset))
+		clear(p[:n])
+		r.offset += int64(n)
+		return n, nil
+	}
+
+	n, err := r.file.ReadAt(p[:min(int64(len(p)), r.dataEnd-r.offset)], r.offset)
+	r.offset += int64(n)
+	if err == io.EOF && r.offset < r.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// nextExtent determines whether the current offset starts a data extent or a
+// hole, and where it ends.
+func (r *sparseReader) nextExtent() error {
+	data, err := r.file.Seek(r.offset, unix.SEEK_DATA)
+	switch {
+	case errors.Is(err, syscall.ENXIO):
+		// No more data up to the end of the file
+		r.holeEnd = r.size
+		return nil
+	case errors.Is(err, syscall.EINVAL):
+		// Holes can't be detected, treat the remainder as data
+		r.dataEnd = r.size
+		return nil
+	case err != nil:
+		return err
+	}
+	if data > r.offset {
+		r.holeEnd = min(data, r.size)
+		return nil
+	}
+
+	hole, err := r.file.Seek(r.offset, unix.SEEK_HOLE)
+	if err != nil {
+		return err
+	}
+	r.dataEnd = min(hole, r.size)
+	return nil
+}
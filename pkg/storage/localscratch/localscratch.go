@@ -0,0 +1,131 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package localscratch
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+const (
+	// ResourceName is the device plugin resource accounting the scratch pool in units of UnitSize
+	ResourceName = "scratch"
+	// UnitSize is the capacity of a single scratch pool unit
+	UnitSize = 1024 * 1024 * 1024
+	// PoolDir is the host directory holding the scratch directories handed out to virt-launcher pods
+	PoolDir = "/var/lib/kubevirt/scratch"
+	// EphemeralDiskDir is where virt-launcher keeps its ephemeral disks and where the scratch directory is mounted
+	EphemeralDiskDir = "/var/run/kubevirt-ephemeral-disks"
+
+	ownerFile = ".vmi-uid"
+)
+
+func IsLocalScratchVMI(vmi *v1.VirtualMachineInstance) bool {
+	_, exists := vmi.Annotations[v1.LocalScratchCapacityAnnotation]
+	return exists
+}
+
+// RequestedUnits returns the number of pool units requested by the VMI, rounding the capacity up to whole units
+func RequestedUnits(vmi *v1.VirtualMachineInstance) (int64, error) {
+	value := vmi.Annotations[v1.LocalScratchCapacityAnnotation]
+	capacity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid local scratch capacity %q: %v", value, err)
+	}
+	if capacity.Sign() <= 0 {
+		return 0, fmt.Errorf("local scratch capacity %q must be greater than zero", value)
+	}
+	return (capacity.Value() + UnitSize - 1) / UnitSize, nil
+}
+
+// Allocate creates a new scratch directory in the pool and returns its name
+func Allocate(poolRoot string) (string, error) {
+	name := string(uuid.NewUUID())
+	if err := os.MkdirAll(filepath.Join(poolRoot, name), 0755); err != nil {
+		return "", fmt.Errorf("failed to create scratch directory %s: %v", name, err)
+	}
+	return name, nil
+}
+
+// Claim records the VMI owning the scratch directory mounted at dir, allowing
+// virt-handler to release it once the VMI is gone
+func Claim(dir, vmiUID string) error {
+	return os.WriteFile(filepath.Join(dir, ownerFile), []byte(vmiUID), 0644)
+}
+
+// Release removes the scratch directories claimed by the VMI
+func Release(poolRoot, vmiUID string) error {
+	entries, err := os.ReadDir(poolRoot)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(poolRoot, entry.Name())
+		owner, err := os.ReadFile(filepath.Join(dir, ownerFile))
+		if err != nil || strings.TrimSpace(string(owner)) != vmiUID {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to release scratch directory %s: %v", dir, err)
+		}
+	}
+	return nil
+}
+
+// RemoveUnclaimed removes the scratch directories which were not claimed within
+// the grace period after their allocation, e.g. because the pod never started
+func RemoveUnclaimed(poolRoot string, gracePeriod time.Duration) error {
+	entries, err := os.ReadDir(poolRoot)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(poolRoot, entry.Name())
+		if _, err := os.Stat(filepath.Join(dir, ownerFile)); err == nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < gracePeriod {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to remove unclaimed scratch directory %s: %v", dir, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package localscratch_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/storage/localscratch"
+)
+
+var _ = Describe("Local scratch", func() {
+	DescribeTable("should round the requested capacity up to whole units", func(capacity string, expectedUnits int64) {
+		vmi := libvmi.New(libvmi.WithAnnotation(v1.LocalScratchCapacityAnnotation, capacity))
+		Expect(localscratch.IsLocalScratchVMI(vmi)).To(BeTrue())
+		Expect(localscratch.RequestedUnits(vmi)).To(Equal(expectedUnits))
+	},
+		Entry("with a whole number of GiB", "2Gi", int64(2)),
+		Entry("with a fraction of a GiB", "1500Mi", int64(2)),
+		Entry("with decimal units", "1G", int64(1)),
+	)
+
+	DescribeTable("should reject an invalid capacity", func(capacity string) {
+		vmi := libvmi.New(libvmi.WithAnnotation(v1.LocalScratchCapacityAnnotation, capacity))
+		_, err := localscratch.RequestedUnits(vmi)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("with an unparsable value", "lots"),
+		Entry("with zero", "0"),
+		Entry("with a negative value", "-1Gi"),
+	)
+
+	It("should not consider a VMI without the annotation", func() {
+		Expect(localscratch.IsLocalScratchVMI(libvmi.New())).To(BeFalse())
+	})
+
+	Context("pool", func() {
+		var poolRoot string
+
+		BeforeEach(func() {
+			poolRoot = GinkgoT().TempDir()
+		})
+
+		It("should release only the directories claimed by the VMI", func() {
+			owned, err := localscratch.Allocate(poolRoot)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(localscratch.Claim(filepath.Join(poolRoot, owned), "uid-1")).To(Succeed())
+			other, err := localscratch.Allocate(poolRoot)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(localscratch.Claim(filepath.Join(poolRoot, other), "uid-2")).To(Succeed())
+
+			Expect(localscratch.Release(poolRoot, "uid-1")).To(Succeed())
+			Expect(filepath.Join(poolRoot, owned)).ToNot(BeADirectory())
+			Expect(filepath.Join(poolRoot, other)).To(BeADirectory())
+		})
+
+		It("should remove unclaimed directories once the grace period expired", func() {
+			expired, err := localscratch.Allocate(poolRoot)
+			Expect(err).ToNot(HaveOccurred())
+			past := time.Now().Add(-time.Hour)
+			Expect(os.Chtimes(filepath.Join(poolRoot, expired), past, past)).To(Succeed())
+			recent, err := localscratch.Allocate(poolRoot)
+			Expect(err).ToNot(HaveOccurred())
+			claimed, err := localscratch.Allocate(poolRoot)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(localscratch.Claim(filepath.Join(poolRoot, claimed), "uid-1")).To(Succeed())
+			Expect(os.Chtimes(filepath.Join(poolRoot, claimed), past, past)).To(Succeed())
+
+			Expect(localscratch.RemoveUnclaimed(poolRoot, time.Minute)).To(Succeed())
+			Expect(filepath.Join(poolRoot, expired)).ToNot(BeADirectory())
+			Expect(filepath.Join(poolRoot, recent)).To(BeADirectory())
+			Expect(filepath.Join(poolRoot, claimed)).To(BeADirectory())
+		})
+
+		It("should tolerate a missing pool", func() {
+			missing := filepath.Join(poolRoot, "missing")
+			Expect(localscratch.Release(missing, "uid-1")).To(Succeed())
+			Expect(localscratch.RemoveUnclaimed(missing, time.Minute)).To(Succeed())
+		})
+	})
+})
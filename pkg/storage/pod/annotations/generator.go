@@ -21,7 +21,6 @@ package annotations
 
 import (
 	"fmt"
-	"strconv"
 
 	v1 "kubevirt.io/api/core/v1"
 
@@ -53,19 +52,14 @@ func (g Generator) ManagedAnnotationKeys() []string {
 }
 
 func (g Generator) Generate(vmi *v1.VirtualMachineInstance) (map[string]string, error) {
-	// Check VMI annotation first, fallback to kubevirt CR if not set
-	skipValue, hasSkipValue := vmi.Annotations[velero.SkipHooksAnnotation]
-	if !hasSkipValue && g.clusterConfig != nil {
-		kubeVirtCR := g.clusterConfig.GetConfigFromKubeVirtCR()
-		if kubeVirtCR != nil {
-			skipValue = kubeVirtCR.Annotations[velero.SkipHooksAnnotation]
-		}
+	var kubeVirtCR *v1.KubeVirt
+	if g.clusterConfig != nil {
+		kubeVirtCR = g.clusterConfig.GetConfigFromKubeVirtCR()
 	}
 
 	annotations := map[string]string{}
 
-	skip, _ := strconv.ParseBool(skipValue)
-	if !skip {
+	if !velero.SkipHooks(vmi, kubeVirtCR) {
 		annotations[velero.PreBackupHookContainerAnnotation] = computeContainerName
 		annotations[velero.PreBackupHookCommandAnnotation] = fmt.Sprintf(
 			"[\"/usr/bin/virt-freezer\", \"--freeze\", \"--name\", %q, \"--namespace\", %q]",
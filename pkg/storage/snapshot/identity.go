@@ -0,0 +1,101 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+// RestoreIdentityDefaults returns the identity policy of a restore, the restored VM keeps the identity of the snapshot
+func RestoreIdentityDefaults() snapshotv1.IdentityPolicy {
+	return snapshotv1.IdentityPolicy{
+		MacAddresses: pointer.P(snapshotv1.IdentityPreserve),
+		SMBiosSerial: pointer.P(snapshotv1.IdentityPreserve),
+		FirmwareUUID: pointer.P(snapshotv1.IdentityPreserve),
+		Hostname:     pointer.P(snapshotv1.IdentityPreserve),
+	}
+}
+
+// CloneIdentityDefaults returns the identity policy of a clone, the hardware and network identity is regenerated
+// so that the clone doesn't collide with its source while the hostname is kept
+func CloneIdentityDefaults() snapshotv1.IdentityPolicy {
+	return snapshotv1.IdentityPolicy{
+		MacAddresses: pointer.P(snapshotv1.IdentityRegenerate),
+		SMBiosSerial: pointer.P(snapshotv1.IdentityRegenerate),
+		FirmwareUUID: pointer.P(snapshotv1.IdentityRegenerate),
+		Hostname:     pointer.P(snapshotv1.IdentityPreserve),
+	}
+}
+
+// ResolveIdentityPolicy fills the attributes which are not set in the policy with the given defaults
+func ResolveIdentityPolicy(policy *snapshotv1.IdentityPolicy, defaults snapshotv1.IdentityPolicy) snapshotv1.IdentityPolicy {
+	if policy == nil {
+		return defaults
+	}
+
+	resolved := defaults
+	if policy.MacAddresses != nil {
+		resolved.MacAddresses = policy.MacAddresses
+	}
+	if policy.SMBiosSerial != nil {
+		resolved.SMBiosSerial = policy.SMBiosSerial
+	}
+	if policy.FirmwareUUID != nil {
+		resolved.FirmwareUUID = policy.FirmwareUUID
+	}
+	if policy.Hostname != nil {
+		resolved.Hostname = policy.Hostname
+	}
+	return resolved
+}
+
+// ShouldRegenerate returns whether the identity attribute has to be regenerated
+func ShouldRegenerate(attributePolicy *snapshotv1.IdentityRegenerationPolicy) bool {
+	return attributePolicy != nil && *attributePolicy == snapshotv1.IdentityRegenerate
+}
+
+// applyIdentityPolicy drops the identity attributes which have to be regenerated from the VM spec,
+// they are generated anew when the VM starts
+func applyIdentityPolicy(vm *kubevirtv1.VirtualMachine, policy snapshotv1.IdentityPolicy) {
+	if vm.Spec.Template == nil {
+		return
+	}
+	vmiSpec := &vm.Spec.Template.Spec
+
+	if ShouldRegenerate(policy.MacAddresses) {
+		for i := range vmiSpec.Domain.Devices.Interfaces {
+			vmiSpec.Domain.Devices.Interfaces[i].MacAddress = ""
+		}
+	}
+	if firmware := vmiSpec.Domain.Firmware; firmware != nil {
+		if ShouldRegenerate(policy.SMBiosSerial) {
+			firmware.Serial = ""
+		}
+		if ShouldRegenerate(policy.FirmwareUUID) {
+			firmware.UUID = ""
+		}
+	}
+	if ShouldRegenerate(policy.Hostname) {
+		vmiSpec.Hostname = ""
+	}
+}
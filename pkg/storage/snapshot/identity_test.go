@@ -0,0 +1,93 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+var _ = Describe("Identity policy", func() {
+	const (
+		macAddress = "DE-AD-00-00-BE-EF"
+		serial     = "serial"
+		uuid       = "uuid"
+		hostname   = "hostname"
+	)
+
+	newVM := func() *kubevirtv1.VirtualMachine {
+		return &kubevirtv1.VirtualMachine{
+			Spec: kubevirtv1.VirtualMachineSpec{
+				Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+					Spec: kubevirtv1.VirtualMachineInstanceSpec{
+						Hostname: hostname,
+						Domain: kubevirtv1.DomainSpec{
+							Firmware: &kubevirtv1.Firmware{Serial: serial, UUID: uuid},
+							Devices: kubevirtv1.Devices{
+								Interfaces: []kubevirtv1.Interface{{Name: "default", MacAddress: macAddress}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	It("should fill the attributes which are not set with the defaults", func() {
+		policy := ResolveIdentityPolicy(&snapshotv1.IdentityPolicy{
+			MacAddresses: pointer.P(snapshotv1.IdentityRegenerate),
+		}, RestoreIdentityDefaults())
+
+		Expect(policy).To(Equal(snapshotv1.IdentityPolicy{
+			MacAddresses: pointer.P(snapshotv1.IdentityRegenerate),
+			SMBiosSerial: pointer.P(snapshotv1.IdentityPreserve),
+			FirmwareUUID: pointer.P(snapshotv1.IdentityPreserve),
+			Hostname:     pointer.P(snapshotv1.IdentityPreserve),
+		}))
+		Expect(ResolveIdentityPolicy(nil, CloneIdentityDefaults())).To(Equal(CloneIdentityDefaults()))
+	})
+
+	It("should keep the identity of the VM with the restore defaults", func() {
+		vm := newVM()
+		applyIdentityPolicy(vm, RestoreIdentityDefaults())
+		Expect(vm).To(Equal(newVM()))
+	})
+
+	It("should drop the regenerated attributes", func() {
+		vm := newVM()
+		applyIdentityPolicy(vm, snapshotv1.IdentityPolicy{
+			MacAddresses: pointer.P(snapshotv1.IdentityRegenerate),
+			SMBiosSerial: pointer.P(snapshotv1.IdentityPreserve),
+			FirmwareUUID: pointer.P(snapshotv1.IdentityRegenerate),
+			Hostname:     pointer.P(snapshotv1.IdentityRegenerate),
+		})
+
+		vmiSpec := vm.Spec.Template.Spec
+		Expect(vmiSpec.Domain.Devices.Interfaces[0].MacAddress).To(BeEmpty())
+		Expect(vmiSpec.Domain.Firmware.Serial).To(Equal(serial))
+		Expect(vmiSpec.Domain.Firmware.UUID).To(BeEmpty())
+		Expect(vmiSpec.Hostname).To(BeEmpty())
+	})
+})
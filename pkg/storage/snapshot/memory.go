@@ -0,0 +1,181 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	"kubevirt.io/client-go/log"
+
+	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
+	utils "kubevirt.io/kubevirt/pkg/util"
+)
+
+const sourceLockedMsg = "Source locked and operation in progress"
+
+func includeMemory(snapshot *snapshotv1.VirtualMachineSnapshot) bool {
+	return snapshot.Spec.IncludeMemory != nil && *snapshot.Spec.IncludeMemory
+}
+
+// isSavedMemoryState returns whether the volume holds a memory state the VM can be resumed from
+func isSavedMemoryState(volume *kubevirtv1.Volume) bool {
+	return volume.MemoryDump != nil && volume.MemoryDump.Format == kubevirtv1.MemoryDumpFormatSavedState
+}
+
+// memoryClaimName returns the name of the PVC the memory state of the source is saved to
+func memoryClaimName(snapshot *snapshotv1.VirtualMachineSnapshot) string {
+	return fmt.Sprintf("vmsnapshot-memory-%s", snapshot.UID)
+}
+
+func hasMemoryDumpRequest(vm *kubevirtv1.VirtualMachine, snapshot *snapshotv1.VirtualMachineSnapshot) bool {
+	return vm.Status.MemoryDumpRequest != nil && vm.Status.MemoryDumpRequest.ClaimName == memoryClaimName(snapshot)
+}
+
+// memoryStateProgress returns whether the memory state of the locked source has been saved,
+// and the lock message describing the progress.
+func memoryStateProgress(vm *kubevirtv1.VirtualMachine, snapshot *snapshotv1.VirtualMachineSnapshot) (bool, string) {
+	if !hasMemoryDumpRequest(vm, snapshot) {
+		return false, "Source locked, memory dump not requested"
+	}
+
+	request := vm.Status.MemoryDumpRequest
+	switch request.Phase {
+	case kubevirtv1.MemoryDumpCompleted:
+		return true, sourceLockedMsg
+	case kubevirtv1.MemoryDumpFailed:
+		return false, fmt.Sprintf("Source locked, saving the memory state failed: %s", request.Message)
+	default:
+		return false, "Source locked, saving the memory state"
+	}
+}
+
+// prepareMemoryDump creates the PVC the memory state is saved to and returns the memory dump request
+// which has to be set when locking the source. False is returned while the source cannot be locked.
+func (s *vmSnapshotSource) prepareMemoryDump() (*kubevirtv1.VirtualMachineMemoryDumpRequest, bool, error) {
+	if !s.Online() || !s.Paused() {
+		s.state.lockMsg += " source has to be running and paused to include the memory"
+		log.Log.V(3).Info(s.state.lockMsg)
+		return nil, false, nil
+	}
+
+	if request := s.vm.Status.MemoryDumpRequest; request != nil && !hasMemoryDumpRequest(s.vm, s.snapshot) {
+		s.state.lockMsg += fmt.Sprintf(" memory dump to %q has to be removed first", request.ClaimName)
+		log.Log.V(3).Info(s.state.lockMsg)
+		return nil, false, nil
+	}
+
+	claimName := memoryClaimName(s.snapshot)
+	obj, exists, err := s.controller.PVCInformer.GetStore().GetByKey(cacheKeyFunc(s.vm.Namespace, claimName))
+	if err != nil {
+		return nil, false, err
+	}
+	if !exists {
+		if err := s.createMemoryClaim(claimName); err != nil {
+			return nil, false, err
+		}
+		s.state.lockMsg += fmt.Sprintf(" waiting for memory PVC %s", claimName)
+		return nil, false, nil
+	}
+
+	pvc := obj.(*corev1.PersistentVolumeClaim)
+	if pvc.Spec.StorageClassName == nil {
+		s.state.lockMsg += fmt.Sprintf(" memory PVC %s has no storage class", claimName)
+		return nil, false, nil
+	}
+	volumeSnapshotClass, err := s.controller.getVolumeSnapshotClassName(*pvc.Spec.StorageClassName)
+	if err != nil {
+		return nil, false, err
+	}
+	if volumeSnapshotClass == "" {
+		s.state.lockMsg += fmt.Sprintf(" no VolumeSnapshotClass for the memory storage class %s", *pvc.Spec.StorageClassName)
+		log.Log.V(3).Info(s.state.lockMsg)
+		return nil, false, nil
+	}
+
+	if hasMemoryDumpRequest(s.vm, s.snapshot) {
+		return nil, true, nil
+	}
+
+	return &kubevirtv1.VirtualMachineMemoryDumpRequest{
+		ClaimName: claimName,
+		Phase:     kubevirtv1.MemoryDumpAssociating,
+		Format:    kubevirtv1.MemoryDumpFormatSavedState,
+	}, true, nil
+}
+
+func (s *vmSnapshotSource) createMemoryClaim(claimName string) error {
+	vmi, exists, err := s.controller.getVMI(s.vm)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("can't create memory PVC, vmi doesn't exist")
+	}
+
+	size, err := storagetypes.GetSizeIncludingDefaultFSOverhead(utils.CalcExpectedMemoryDumpSize(vmi))
+	if err != nil {
+		return err
+	}
+
+	volumeMode := corev1.PersistentVolumeFilesystem
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: s.vm.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(s.snapshot, snapshotv1.SchemeGroupVersion.WithKind("VirtualMachineSnapshot")),
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			VolumeMode:       &volumeMode,
+			StorageClassName: s.snapshot.Spec.MemoryStorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: *size,
+				},
+			},
+		},
+	}
+
+	log.Log.Infof("Creating memory PVC %s/%s for snapshot %s", pvc.Namespace, pvc.Name, s.snapshot.Name)
+	_, err = s.controller.Client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// deleteMemoryClaim deletes the PVC the memory state was saved to, the snapshot keeps a volume snapshot of it
+func (s *vmSnapshotSource) deleteMemoryClaim() error {
+	claimName := memoryClaimName(s.snapshot)
+	err := s.controller.Client.CoreV1().PersistentVolumeClaims(s.vm.Namespace).Delete(context.Background(), claimName, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
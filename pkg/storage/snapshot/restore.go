@@ -944,8 +944,18 @@ func (t *vmRestoreTarget) generateRestoredVMSpec(snapshotVM *snapshotv1.VirtualM
 				}
 			}
 		} else if nv.MemoryDump != nil {
-			// don't restore memory dump volume in the new spec
-			continue
+			if !isSavedMemoryState(nv) {
+				// don't restore memory dump volume in the new spec
+				continue
+			}
+
+			// the VM resumes from the saved memory state on its next start
+			for _, vr := range t.vmRestore.Status.Restores {
+				if vr.VolumeName == nv.Name {
+					nv.MemoryDump.ClaimName = vr.PersistentVolumeClaimName
+				}
+			}
+			nv.MemoryDump.Hotpluggable = false
 		}
 		newVolumes = append(newVolumes, *nv)
 	}
@@ -987,7 +997,9 @@ func (t *vmRestoreTarget) generateRestoredVMSpec(snapshotVM *snapshotv1.VirtualM
 	newVM.Spec.DataVolumeTemplates = newTemplates
 	newVM.Spec.Template.Spec.Volumes = newVolumes
 	setLastRestoreAnnotation(t.vmRestore, newVM)
-	if snapshotVM.Name == newVM.Name {
+	identityPolicy := ResolveIdentityPolicy(t.vmRestore.Spec.IdentityPolicy, RestoreIdentityDefaults())
+	applyIdentityPolicy(newVM, identityPolicy)
+	if snapshotVM.Name == newVM.Name && !ShouldRegenerate(identityPolicy.FirmwareUUID) {
 		setLegacyFirmwareUUID(newVM)
 	}
 
@@ -1685,7 +1697,7 @@ func updateRestoreCondition(r *snapshotv1.VirtualMachineRestore, c snapshotv1.Co
 }
 
 // Returns a set of volumes not for restore
-// Currently only memory dump volumes should not be restored
+// Currently only memory dump volumes which don't hold a saved memory state should not be restored
 func (ctrl *VMRestoreController) volumesNotForRestore(content *snapshotv1.VirtualMachineSnapshotContent) (sets.String, error) {
 	noRestore := sets.NewString()
 
@@ -1695,7 +1707,7 @@ func (ctrl *VMRestoreController) volumesNotForRestore(content *snapshotv1.Virtua
 	}
 
 	for _, volume := range volumes {
-		if volume.MemoryDump != nil {
+		if volume.MemoryDump != nil && !isSavedMemoryState(&volume) {
 			noRestore.Insert(volume.Name)
 		}
 	}
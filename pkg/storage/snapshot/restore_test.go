@@ -1673,6 +1673,56 @@ var _ = Describe("Restore controller", func() {
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(res).To(BeTrue())
 				})
+
+				DescribeTable("should restore the memory dump volume", func(format kubevirtv1.MemoryDumpFormat, expectRestored bool) {
+					addRestoreVolumes(true, cdiv1.Succeeded)
+					memoryVolume := kubevirtv1.Volume{
+						Name: "memory",
+						VolumeSource: kubevirtv1.VolumeSource{
+							MemoryDump: &kubevirtv1.MemoryDumpVolumeSource{
+								PersistentVolumeClaimVolumeSource: kubevirtv1.PersistentVolumeClaimVolumeSource{
+									PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+										ClaimName: "memory",
+									},
+									Hotpluggable: true,
+								},
+								Format: format,
+							},
+						},
+					}
+					sc.Spec.Source.VirtualMachine.Spec.Template.Spec.Volumes = append(sc.Spec.Source.VirtualMachine.Spec.Template.Spec.Volumes, memoryVolume)
+					r.Status.Restores = append(r.Status.Restores, snapshotv1.VolumeRestore{
+						VolumeName:                "memory",
+						PersistentVolumeClaimName: "restore-uid-memory",
+					})
+					addVirtualMachineRestore(r)
+
+					noRestore, err := controller.volumesNotForRestore(sc)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(noRestore.Has("memory")).To(Equal(!expectRestored))
+
+					Expect(controller.VMInformer.GetStore().Add(vm)).To(Succeed())
+					updatedVM := createSnapshotVM()
+					updatedVM.Status.RestoreInProgress = &vmRestoreName
+					updatedVM.Annotations = map[string]string{"restore.kubevirt.io/lastRestoreUID": "restore-uid"}
+					updatedVM.Spec.DataVolumeTemplates[0].Name = "restore-uid-disk1"
+					updatedVM.Spec.Template.Spec.Volumes[0].DataVolume.Name = "restore-uid-disk1"
+					if expectRestored {
+						restoredVolume := memoryVolume.DeepCopy()
+						restoredVolume.MemoryDump.ClaimName = "restore-uid-memory"
+						restoredVolume.MemoryDump.Hotpluggable = false
+						updatedVM.Spec.Template.Spec.Volumes = append(updatedVM.Spec.Template.Spec.Volumes, *restoredVolume)
+					}
+					setLegacyFirmwareUUID(updatedVM)
+					updateVMCalls := expectVMUpdate(kubevirtClient, updatedVM)
+					res, err := targetVM.Reconcile()
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(res).To(BeTrue())
+					Expect(*updateVMCalls).To(Equal(1))
+				},
+					Entry("when it holds a saved memory state", kubevirtv1.MemoryDumpFormatSavedState, true),
+					Entry("not when it holds a raw memory dump", kubevirtv1.MemoryDumpFormatRaw, false),
+				)
 			})
 
 			Context("target VM is different than source VM", func() {
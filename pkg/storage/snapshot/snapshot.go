@@ -77,6 +77,7 @@ var snapshotIndicationMessages = map[snapshotv1.Indication]string{
 	snapshotv1.VMSnapshotNoGuestAgentIndication:   "Guest agent was not available. Snapshot is crash-consistent and may not be application-consistent.",
 	snapshotv1.VMSnapshotQuiesceTimeoutIndication: "Guest agent quiesced the filesystem, but the freeze window timed out before completion. Snapshot is crash-consistent and may not be application-consistent.",
 	snapshotv1.VMSnapshotPausedIndication:         "Snapshot taken while the VM was paused. Snapshot is crash-consistent and may not be application-consistent.",
+	snapshotv1.VMSnapshotMemoryIndication:         "Snapshot includes the memory state of the VM. Restoring the snapshot resumes the VM instead of booting it.",
 }
 
 func VmSnapshotReady(vmSnapshot *snapshotv1.VirtualMachineSnapshot) bool {
@@ -209,8 +210,9 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 
 					retry = snapshotRetryInterval
 				} else {
-					// create content if does not exist
-					if content == nil {
+					// create content if does not exist,
+					// a snapshot including the memory waits for the memory state to be saved
+					if content == nil && source.MemoryStateSaved() {
 						if err := ctrl.createContent(vmSnapshot); err != nil {
 							return 0, err
 						}
@@ -634,6 +636,9 @@ func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMa
 		}
 
 		if pvc == nil {
+			if includeMemory(vmSnapshot) && pvcName == memoryClaimName(vmSnapshot) {
+				return fmt.Errorf("memory PVC %s/%s can't be snapshotted", vmSnapshot.Namespace, pvcName)
+			}
 			log.Log.Warningf("No snapshot PVC for %s/%s", vmSnapshot.Namespace, pvcName)
 			continue
 		}
@@ -867,6 +872,9 @@ func updateSnapshotSourceIndications(snapshot *snapshotv1.VirtualMachineSnapshot
 		indications := sets.New(snapshot.Status.Indications...)
 		indications = sets.Insert(indications, snapshotv1.VMSnapshotOnlineSnapshotIndication)
 
+		if includeMemory(snapshot) {
+			indications = sets.Insert(indications, snapshotv1.VMSnapshotMemoryIndication)
+		}
 		if source.Paused() {
 			indications = sets.Insert(indications, snapshotv1.VMSnapshotPausedIndication)
 		} else if source.GuestAgent() {
@@ -934,6 +934,203 @@ var _ = Describe("Snapshot controlleer", func() {
 				Expect(*updateStatusCalls).To(Equal(1))
 			})
 
+			Context("with the memory state included", func() {
+				memoryClaim := fmt.Sprintf("vmsnapshot-memory-%s", vmSnapshotUID)
+
+				createMemoryVMSnapshot := func() *snapshotv1.VirtualMachineSnapshot {
+					vmSnapshot := createVMSnapshotInProgress()
+					vmSnapshot.Spec.IncludeMemory = pointer.P(true)
+					vmSnapshot.Spec.MemoryStorageClassName = &storageClassName
+					return vmSnapshot
+				}
+
+				createPausedVMI := func(vm *v1.VirtualMachine) *v1.VirtualMachineInstance {
+					vmi := createVMI(vm)
+					vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+						{
+							Type:   v1.VirtualMachineInstancePaused,
+							Status: corev1.ConditionTrue,
+						},
+					}
+					return vmi
+				}
+
+				expectStatus := func(vmSnapshot *snapshotv1.VirtualMachineSnapshot, progressing corev1.ConditionStatus, reason string, indications ...snapshotv1.Indication) *int {
+					updatedSnapshot := vmSnapshot.DeepCopy()
+					updatedSnapshot.ResourceVersion = "1"
+					updatedSnapshot.Status.Conditions = []snapshotv1.Condition{
+						newProgressingCondition(progressing, reason),
+						newReadyCondition(corev1.ConditionFalse, "Not ready"),
+					}
+					updatedSnapshot.Status.Indications = indications
+					updatedSnapshot.Status.SourceIndications = nil
+					for _, indication := range indications {
+						updatedSnapshot.Status.SourceIndications = append(updatedSnapshot.Status.SourceIndications, snapshotv1.SourceIndication{
+							Indication: indication,
+							Message:    IndicationMessage(indication),
+						})
+					}
+					return expectVMSnapshotUpdateStatus(vmSnapshotClient, updatedSnapshot)
+				}
+
+				BeforeEach(func() {
+					virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+				})
+
+				It("should not lock source if the VM is not paused", func() {
+					vmSnapshot := createMemoryVMSnapshot()
+					vm := createVM()
+					vmSource.Add(vm)
+					vmiSource.Add(createVMI(vm))
+
+					updateStatusCalls := expectStatus(vmSnapshot, corev1.ConditionFalse,
+						"Source not locked source has to be running and paused to include the memory",
+						snapshotv1.VMSnapshotMemoryIndication,
+						snapshotv1.VMSnapshotNoGuestAgentIndication,
+						snapshotv1.VMSnapshotOnlineSnapshotIndication,
+					)
+
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					Expect(*updateStatusCalls).To(Equal(1))
+				})
+
+				It("should create the memory PVC before locking source", func() {
+					vmSnapshot := createMemoryVMSnapshot()
+					vm := createVM()
+					vmSource.Add(vm)
+					vmiSource.Add(createPausedVMI(vm))
+
+					pvcCreates := 0
+					k8sClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+						create, ok := action.(testing.CreateAction)
+						Expect(ok).To(BeTrue())
+						pvc := create.GetObject().(*corev1.PersistentVolumeClaim)
+						Expect(pvc.Name).To(Equal(memoryClaim))
+						Expect(pvc.Spec.StorageClassName).To(HaveValue(Equal(storageClassName)))
+						Expect(pvc.Spec.VolumeMode).To(HaveValue(Equal(corev1.PersistentVolumeFilesystem)))
+						Expect(pvc.OwnerReferences).To(HaveLen(1))
+						Expect(pvc.OwnerReferences[0].Name).To(Equal(vmSnapshot.Name))
+						pvcCreates++
+						return true, pvc, nil
+					})
+
+					updateStatusCalls := expectStatus(vmSnapshot, corev1.ConditionFalse,
+						fmt.Sprintf("Source not locked waiting for memory PVC %s", memoryClaim),
+						snapshotv1.VMSnapshotMemoryIndication,
+						snapshotv1.VMSnapshotOnlineSnapshotIndication,
+						snapshotv1.VMSnapshotPausedIndication,
+					)
+
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					Expect(*updateStatusCalls).To(Equal(1))
+					Expect(pvcCreates).To(Equal(1))
+				})
+
+				It("should lock source and request the memory dump", func() {
+					vmSnapshot := createMemoryVMSnapshot()
+					vm := createVM()
+					vmSource.Add(vm)
+					vmiSource.Add(createPausedVMI(vm))
+					pvc := memoryDumpPVC()
+					pvc.Name = memoryClaim
+					pvcSource.Add(&pvc)
+					storageClassSource.Add(createStorageClass())
+					addVolumeSnapshotClass(createVolumeSnapshotClasses()[0])
+
+					vmUpdate := vm.DeepCopy()
+					vmUpdate.ResourceVersion = "1"
+					vmUpdate.Status.SnapshotInProgress = &vmSnapshotName
+					vmUpdate.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+						ClaimName: memoryClaim,
+						Phase:     v1.MemoryDumpAssociating,
+						Format:    v1.MemoryDumpFormatSavedState,
+					}
+					vmInterface.EXPECT().UpdateStatus(context.Background(), vmUpdate, metav1.UpdateOptions{}).Return(vmUpdate, nil).Times(1)
+
+					vmUpdate2 := vmUpdate.DeepCopy()
+					vmUpdate2.Finalizers = []string{"snapshot.kubevirt.io/snapshot-source-protection"}
+					patchBytes, err := patch.GenerateTestReplacePatch("/metadata/finalizers", nil, []string{"snapshot.kubevirt.io/snapshot-source-protection"})
+					Expect(err).ToNot(HaveOccurred())
+					vmInterface.EXPECT().Patch(context.Background(), vmUpdate2.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{}).Return(vmUpdate2, nil).Times(1)
+
+					updateStatusCalls := expectStatus(vmSnapshot, corev1.ConditionTrue,
+						"Source locked, saving the memory state",
+						snapshotv1.VMSnapshotMemoryIndication,
+						snapshotv1.VMSnapshotOnlineSnapshotIndication,
+						snapshotv1.VMSnapshotPausedIndication,
+					)
+
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					Expect(*updateStatusCalls).To(Equal(1))
+				})
+
+				DescribeTable("should not create VirtualMachineSnapshotContent before the memory state is saved", func(phase v1.MemoryDumpPhase, message, expectedReason string) {
+					vmSnapshot := createMemoryVMSnapshot()
+					vm := createLockedVM()
+					vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+						ClaimName: memoryClaim,
+						Phase:     phase,
+						Message:   message,
+						Format:    v1.MemoryDumpFormatSavedState,
+					}
+					vmSource.Add(vm)
+					vmiSource.Add(createPausedVMI(vm))
+
+					updateStatusCalls := expectStatus(vmSnapshot, corev1.ConditionTrue, expectedReason,
+						snapshotv1.VMSnapshotMemoryIndication,
+						snapshotv1.VMSnapshotOnlineSnapshotIndication,
+						snapshotv1.VMSnapshotPausedIndication,
+					)
+
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					Expect(*updateStatusCalls).To(Equal(1))
+				},
+					Entry("while the memory dump is in progress", v1.MemoryDumpInProgress, "", "Source locked, saving the memory state"),
+					Entry("when the memory dump failed", v1.MemoryDumpFailed, "dump failed", "Source locked, saving the memory state failed: dump failed"),
+				)
+
+				It("should remove the memory dump and the memory PVC when unlocking source", func() {
+					vmSnapshot := createVMSnapshotSuccess()
+					vmSnapshot.Spec.IncludeMemory = pointer.P(true)
+					vm := createLockedVM()
+					vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+						ClaimName: memoryClaim,
+						Phase:     v1.MemoryDumpCompleted,
+						Format:    v1.MemoryDumpFormatSavedState,
+					}
+					updatedVM := vm.DeepCopy()
+					updatedVM.Finalizers = []string{}
+					updatedVM.ResourceVersion = "1"
+					vmSource.Add(vm)
+
+					patchBytes, err := patch.GenerateTestReplacePatch("/metadata/finalizers", []string{"snapshot.kubevirt.io/snapshot-source-protection"}, []string{})
+					Expect(err).ToNot(HaveOccurred())
+					vmInterface.EXPECT().Patch(context.Background(), updatedVM.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{}).Return(updatedVM, nil).Times(1)
+
+					statusUpdate := updatedVM.DeepCopy()
+					statusUpdate.Status.SnapshotInProgress = nil
+					statusUpdate.Status.MemoryDumpRequest.Remove = true
+					vmInterface.EXPECT().UpdateStatus(context.Background(), statusUpdate, metav1.UpdateOptions{}).Return(statusUpdate, nil).Times(1)
+
+					pvcDeletes := 0
+					k8sClient.Fake.PrependReactor("delete", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+						del, ok := action.(testing.DeleteAction)
+						Expect(ok).To(BeTrue())
+						Expect(del.GetName()).To(Equal(memoryClaim))
+						pvcDeletes++
+						return true, nil, nil
+					})
+
+					addVirtualMachineSnapshot(vmSnapshot)
+					controller.processVMSnapshotWorkItem()
+					Expect(pvcDeletes).To(Equal(1))
+				})
+			})
+
 			It("should create VirtualMachineSnapshotContent", func() {
 				vmSnapshot := createVMSnapshotInProgress()
 				vm := createLockedVM()
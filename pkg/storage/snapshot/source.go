@@ -75,6 +75,7 @@ type snapshotSource interface {
 	Paused() bool
 	GuestAgent() bool
 	Frozen() bool
+	MemoryStateSaved() bool
 	Freeze() error
 	Unfreeze() error
 	Spec() (snapshotv1.SourceSpec, error)
@@ -82,12 +83,13 @@ type snapshotSource interface {
 }
 
 type sourceState struct {
-	online     bool
-	paused     bool
-	guestAgent bool
-	frozen     bool
-	locked     bool
-	lockMsg    string
+	online           bool
+	paused           bool
+	guestAgent       bool
+	frozen           bool
+	memoryStateSaved bool
+	locked           bool
+	lockMsg          string
 }
 
 type vmSnapshotSource struct {
@@ -114,17 +116,22 @@ func (s *vmSnapshotSource) UpdateSourceState() error {
 		controller.HasFinalizer(s.vm, sourceFinalizer)
 	lockMsg := "Source not locked"
 	if locked {
-		lockMsg = "Source locked and operation in progress"
+		lockMsg = sourceLockedMsg
+	}
+	memoryStateSaved := !includeMemory(s.snapshot)
+	if locked && !memoryStateSaved {
+		memoryStateSaved, lockMsg = memoryStateProgress(s.vm, s.snapshot)
 	}
 	frozen := exists && vmi.Status.FSFreezeStatus == launcherapi.FSFrozen
 
 	s.state = &sourceState{
-		online:     online,
-		paused:     paused,
-		guestAgent: guestAgent,
-		locked:     locked,
-		frozen:     frozen,
-		lockMsg:    lockMsg,
+		online:           online,
+		paused:           paused,
+		guestAgent:       guestAgent,
+		locked:           locked,
+		frozen:           frozen,
+		memoryStateSaved: memoryStateSaved,
+		lockMsg:          lockMsg,
 	}
 
 	return nil
@@ -188,10 +195,23 @@ func (s *vmSnapshotSource) Lock() (bool, error) {
 		return false, nil
 	}
 
+	var memoryDumpRequest *kubevirtv1.VirtualMachineMemoryDumpRequest
+	if includeMemory(s.snapshot) {
+		var ready bool
+		memoryDumpRequest, ready, err = s.prepareMemoryDump()
+		if err != nil || !ready {
+			return false, err
+		}
+	}
+
 	vmCopy := s.vm.DeepCopy()
 
 	if vmCopy.Status.SnapshotInProgress == nil {
 		vmCopy.Status.SnapshotInProgress = &s.snapshot.Name
+		// the memory dump is requested together with the lock, the memory state has to match the disks
+		if memoryDumpRequest != nil {
+			vmCopy.Status.MemoryDumpRequest = memoryDumpRequest
+		}
 		vmCopy, err = s.controller.Client.VirtualMachine(vmCopy.Namespace).UpdateStatus(context.Background(), vmCopy, metav1.UpdateOptions{})
 		if err != nil {
 			return false, err
@@ -214,7 +234,11 @@ func (s *vmSnapshotSource) Lock() (bool, error) {
 
 	s.vm = vmCopy
 	s.state.locked = true
-	s.state.lockMsg = "Source locked and operation in progress"
+	s.state.lockMsg = sourceLockedMsg
+	s.state.memoryStateSaved = !includeMemory(s.snapshot)
+	if !s.state.memoryStateSaved {
+		s.state.memoryStateSaved, s.state.lockMsg = memoryStateProgress(s.vm, s.snapshot)
+	}
 
 	return true, nil
 }
@@ -241,6 +265,9 @@ func (s *vmSnapshotSource) Unlock() (bool, error) {
 	}
 
 	vmCopy.Status.SnapshotInProgress = nil
+	if hasMemoryDumpRequest(vmCopy, s.snapshot) {
+		vmCopy.Status.MemoryDumpRequest.Remove = true
+	}
 	vmCopy, err = s.controller.Client.VirtualMachine(vmCopy.Namespace).UpdateStatus(context.Background(), vmCopy, metav1.UpdateOptions{})
 	if err != nil {
 		return false, err
@@ -248,6 +275,12 @@ func (s *vmSnapshotSource) Unlock() (bool, error) {
 
 	s.vm = vmCopy
 
+	if includeMemory(s.snapshot) {
+		if err := s.deleteMemoryClaim(); err != nil {
+			return false, err
+		}
+	}
+
 	return true, nil
 }
 
@@ -434,6 +467,10 @@ func (s *vmSnapshotSource) Frozen() bool {
 	return s.state.frozen
 }
 
+func (s *vmSnapshotSource) MemoryStateSaved() bool {
+	return s.state.memoryStateSaved
+}
+
 func (s *vmSnapshotSource) Freeze() error {
 	if !s.Locked() {
 		return fmt.Errorf("attempting to freeze unlocked VM")
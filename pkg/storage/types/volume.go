@@ -30,6 +30,12 @@ func IsStorageVolume(volume *v1.Volume) bool {
 	return volume.PersistentVolumeClaim != nil || volume.DataVolume != nil
 }
 
+// EphemeralOverlayVolumeName returns the name of the pod volume which holds the persistent
+// overlay of an ephemeral volume.
+func EphemeralOverlayVolumeName(volumeName string) string {
+	return volumeName + "-overlay"
+}
+
 func IsDeclarativeHotplugVolume(vol *v1.Volume) bool {
 	if vol == nil {
 		return false
@@ -19,6 +19,12 @@
 
 package velero
 
+import (
+	"strconv"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
 // For additional information please see https://velero.io/docs/v1.14/backup-hooks/#specifying-hooks-as-pod-annotations
 const (
 	// PreBackupHookContainerAnnotation specifies the container where the command should be executed.
@@ -36,7 +42,21 @@ const (
 	// PostBackupHookCommandAnnotation specifies the command to execute.
 	PostBackupHookCommandAnnotation = "post.hook.backup.velero.io/command"
 
+	// ExcludeFromBackupLabel excludes an object from Velero backups when set to "true".
+	ExcludeFromBackupLabel = "velero.io/exclude-from-backup"
+
 	// SkipHooksAnnotation signals that Velero backup freeze/unfreeze hooks should not be injected in virt-launcher.
 	// Can be set on VM or VMI. Value must be "true" to skip hook injection.
 	SkipHooksAnnotation = "kubevirt.io/skip-backup-hooks"
 )
+
+// SkipHooks reports whether the backup hooks are skipped for the VMI. The SkipHooksAnnotation of the
+// VMI takes precedence over the one of the KubeVirt CR, which may be nil.
+func SkipHooks(vmi *v1.VirtualMachineInstance, kubeVirt *v1.KubeVirt) bool {
+	value, exists := vmi.Annotations[SkipHooksAnnotation]
+	if !exists && kubeVirt != nil {
+		value = kubeVirt.Annotations[SkipHooksAnnotation]
+	}
+	skip, _ := strconv.ParseBool(value)
+	return skip
+}
@@ -0,0 +1,90 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package velero
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+const (
+	// RestoreNameLabel is set by Velero on every object it restores.
+	RestoreNameLabel = "velero.io/restore-name"
+
+	// RestoreIdentityPolicyAnnotation controls how the identity of a restored VM is reconciled.
+	// Can be set on the VM. Supported values are RestoreIdentityPreserve and RestoreIdentityRegenerate.
+	RestoreIdentityPolicyAnnotation = "kubevirt.io/restore-identity-policy"
+
+	// RestoreIdentityPreserve keeps the MAC addresses and firmware UUID/serial of the backed up VM.
+	// This is the default and suits disaster recovery, where the original VM no longer exists.
+	RestoreIdentityPreserve = "preserve"
+
+	// RestoreIdentityRegenerate drops the MAC addresses and firmware UUID/serial of the backed up VM,
+	// so that a restored copy can run next to the original.
+	RestoreIdentityRegenerate = "regenerate"
+)
+
+// includedResources lists the resources a Velero backup has to include to be able to restore a VM.
+var includedResources = []string{
+	"virtualmachines.kubevirt.io",
+	"virtualmachineinstancetypes.instancetype.kubevirt.io",
+	"virtualmachinepreferences.instancetype.kubevirt.io",
+	"controllerrevisions.apps",
+	"datavolumes.cdi.kubevirt.io",
+	"persistentvolumeclaims",
+	"persistentvolumes",
+	"secrets",
+	"configmaps",
+}
+
+func isRestored(obj metav1.Object) bool {
+	_, exists := obj.GetLabels()[RestoreNameLabel]
+	return exists
+}
+
+// skipRestore returns true for objects which must not be restored by Velero.
+// virt-launcher pods are still backed up when they carry the freeze hooks. They are
+// owned by a VMI and recreated once the VM is restored, restoring them would leave
+// orphaned pods behind.
+func skipRestore(obj metav1.Object) bool {
+	return obj.GetLabels()[v1.AppLabel] == "virt-launcher"
+}
+
+// ReconcileRestoredVM applies the restore identity policy of a VM restored by Velero.
+// It returns true if the VM was modified. Cleared firmware fields are expected to be
+// regenerated by the caller.
+func ReconcileRestoredVM(vm *v1.VirtualMachine) bool {
+	if !isRestored(vm) || vm.Annotations[RestoreIdentityPolicyAnnotation] != RestoreIdentityRegenerate {
+		return false
+	}
+	if vm.Spec.Template == nil {
+		return false
+	}
+
+	for i := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+		vm.Spec.Template.Spec.Domain.Devices.Interfaces[i].MacAddress = ""
+	}
+	if fw := vm.Spec.Template.Spec.Domain.Firmware; fw != nil {
+		fw.UUID = ""
+		fw.Serial = ""
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package velero
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+)
+
+var _ = Describe("Velero restore", func() {
+	const (
+		uuid   = "2fbd5e56-9d38-4a74-9ca0-21c2d3e1e2b4"
+		serial = "backed-up-serial"
+		mac    = "de:ad:00:00:be:ef"
+	)
+
+	newVM := func(labels, annotations map[string]string) *v1.VirtualMachine {
+		vmi := libvmi.New(
+			libvmi.WithFirmwareUUID(uuid),
+			libvmi.WithInterface(v1.Interface{Name: "default", MacAddress: mac}),
+		)
+		vmi.Spec.Domain.Firmware.Serial = serial
+		return libvmi.NewVirtualMachine(vmi, libvmi.WithLabels(labels), libvmi.WithAnnotations(annotations))
+	}
+
+	restored := map[string]string{RestoreNameLabel: "restore"}
+	regenerate := map[string]string{RestoreIdentityPolicyAnnotation: RestoreIdentityRegenerate}
+	preserve := map[string]string{RestoreIdentityPolicyAnnotation: RestoreIdentityPreserve}
+
+	It("should regenerate identity of a restored VM when requested", func() {
+		vm := newVM(restored, regenerate)
+		Expect(ReconcileRestoredVM(vm)).To(BeTrue())
+		Expect(vm.Spec.Template.Spec.Domain.Firmware.UUID).To(BeEmpty())
+		Expect(vm.Spec.Template.Spec.Domain.Firmware.Serial).To(BeEmpty())
+		Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(BeEmpty())
+	})
+
+	DescribeTable("should preserve identity", func(labels, annotations map[string]string) {
+		vm := newVM(labels, annotations)
+		Expect(ReconcileRestoredVM(vm)).To(BeFalse())
+		Expect(vm.Spec.Template.Spec.Domain.Firmware.UUID).To(BeEquivalentTo(uuid))
+		Expect(vm.Spec.Template.Spec.Domain.Firmware.Serial).To(Equal(serial))
+		Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal(mac))
+	},
+		Entry("of a restored VM by default", restored, nil),
+		Entry("of a restored VM with preserve policy", restored, preserve),
+		Entry("of a VM not created by a restore", nil, regenerate),
+	)
+
+	DescribeTable("should skip restore", func(labels, annotations map[string]string, expected bool) {
+		pod := &k8sv1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: labels, Annotations: annotations}}
+		Expect(skipRestore(pod)).To(Equal(expected))
+	},
+		Entry("of virt-launcher pods backed up with the freeze hooks",
+			map[string]string{v1.AppLabel: "virt-launcher"},
+			map[string]string{PreBackupHookCommandAnnotation: `["/usr/bin/virt-freezer", "--freeze"]`},
+			true,
+		),
+		Entry("of virt-launcher pods", map[string]string{v1.AppLabel: "virt-launcher"}, nil, true),
+		Entry("but not of other pods", map[string]string{"app": "web"}, nil, false),
+	)
+
+	It("should include the VM and its volumes in backups", func() {
+		Expect(includedResources).To(ContainElements(
+			"virtualmachines.kubevirt.io",
+			"datavolumes.cdi.kubevirt.io",
+			"persistentvolumeclaims",
+		))
+	})
+})
@@ -0,0 +1,126 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+// Package errorhints translates frequent low level Kubernetes errors into
+// actionable hints for users.
+package errorhints
+
+import (
+	"fmt"
+	"strings"
+)
+
+const hintPrefix = "Hint: "
+
+type hint struct {
+	matches func(message string) bool
+	text    string
+	docURL  string
+}
+
+func containsAll(substrings ...string) func(string) bool {
+	return func(message string) bool {
+		for _, s := range substrings {
+			if !strings.Contains(message, s) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func containsAny(substrings ...string) func(string) bool {
+	return func(message string) bool {
+		for _, s := range substrings {
+			if strings.Contains(message, s) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// hints are evaluated in order, more specific ones have to come first.
+var hints = []hint{
+	{
+		matches: func(message string) bool {
+			return strings.Contains(message, "failed calling webhook") &&
+				containsAny("x509:", "tls:", "certificate")(message)
+		},
+		text: "The API server does not trust the certificate of an admission webhook. " +
+			"KubeVirt rotates its webhook certificates automatically, check that the virt-api pods are running " +
+			"and that the KubeVirt CR is Available, or the certificates of the webhook named in the error.",
+		docURL: "https://kubernetes.io/docs/reference/access-authn-authz/extensible-admission-controllers/",
+	},
+	{
+		matches: containsAll("WaitForFirstConsumer"),
+		text: "The PersistentVolumeClaim uses a storage class with volumeBindingMode WaitForFirstConsumer " +
+			"and is only bound once a pod using it is scheduled. Start the VM to bind it, or use a storage class " +
+			"with Immediate binding.",
+		docURL: "https://kubernetes.io/docs/concepts/storage/storage-classes/#volume-binding-mode",
+	},
+	{
+		matches: containsAll("exceeded quota", "hugepages-"),
+		text: "The ResourceQuota of the namespace does not allow the requested hugepages. " +
+			"Ask the namespace administrator to raise the hugepages quota, or reduce the guest memory of the VM.",
+		docURL: "https://kubernetes.io/docs/tasks/manage-hugepages/scheduling-hugepages/",
+	},
+	{
+		matches: containsAll("exceeded quota"),
+		text: "The ResourceQuota of the namespace does not allow the requested resources. " +
+			"Ask the namespace administrator to raise the quota named in the error, or reduce the resources of the VM.",
+		docURL: "https://kubernetes.io/docs/concepts/policy/resource-quotas/",
+	},
+	{
+		matches: containsAll("violates PodSecurity"),
+		text: "The Pod Security Admission level enforced on the namespace rejects the virt-launcher pod. " +
+			"Label the namespace with pod-security.kubernetes.io/enforce=privileged, or ask the cluster administrator " +
+			"to enable the PSA feature gate of KubeVirt.",
+		docURL: "https://kubernetes.io/docs/concepts/security/pod-security-admission/",
+	},
+	{
+		matches: containsAll("unable to validate against any security context constraint"),
+		text: "No SecurityContextConstraints allow the virt-launcher pod. " +
+			"Check that the SCCs installed by KubeVirt exist and that the service account of the VM may use them.",
+		docURL: "https://docs.openshift.com/container-platform/latest/authentication/managing-security-context-constraints.html",
+	},
+}
+
+// Hint returns an actionable explanation with a link to the documentation
+// for the given error message, or an empty string if the message is not
+// known.
+func Hint(message string) string {
+	for _, h := range hints {
+		if h.matches(message) {
+			return fmt.Sprintf("%s%s See %s", hintPrefix, h.text, h.docURL)
+		}
+	}
+	return ""
+}
+
+// Annotate appends the hint for the message to it. Messages which are not
+// known or already carry a hint are returned unchanged.
+func Annotate(message string) string {
+	if strings.Contains(message, hintPrefix) {
+		return message
+	}
+	if hint := Hint(message); hint != "" {
+		return message + ". " + hint
+	}
+	return message
+}
@@ -0,0 +1,81 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package errorhints_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/util/errorhints"
+)
+
+var _ = Describe("Error hints", func() {
+	DescribeTable("should explain", func(message, expectedHint, expectedURL string) {
+		hint := errorhints.Hint(message)
+		Expect(hint).To(HavePrefix("Hint: "))
+		Expect(hint).To(ContainSubstring(expectedHint))
+		Expect(hint).To(HaveSuffix("See " + expectedURL))
+	},
+		Entry("untrusted webhook certificates",
+			`Internal error occurred: failed calling webhook "virtualmachine-validator.kubevirt.io": failed to call webhook: Post "https://virt-api.kubevirt.svc:443/virtualmachines-validate?timeout=10s": tls: failed to verify certificate: x509: certificate signed by unknown authority`,
+			"does not trust the certificate of an admission webhook",
+			"https://kubernetes.io/docs/reference/access-authn-authz/extensible-admission-controllers/"),
+		Entry("WaitForFirstConsumer PVCs",
+			"PVC default/disk is in WaitForFirstConsumer state",
+			"volumeBindingMode WaitForFirstConsumer",
+			"https://kubernetes.io/docs/concepts/storage/storage-classes/#volume-binding-mode"),
+		Entry("exceeded hugepages quota",
+			`pods "virt-launcher-vm-abcde" is forbidden: exceeded quota: mem-quota, requested: requests.hugepages-2Mi=2Gi, used: requests.hugepages-2Mi=0, limited: requests.hugepages-2Mi=1Gi`,
+			"does not allow the requested hugepages",
+			"https://kubernetes.io/docs/tasks/manage-hugepages/scheduling-hugepages/"),
+		Entry("exceeded quota",
+			`pods "virt-launcher-vm-abcde" is forbidden: exceeded quota: cpu-quota, requested: requests.cpu=4, used: requests.cpu=0, limited: requests.cpu=2`,
+			"does not allow the requested resources",
+			"https://kubernetes.io/docs/concepts/policy/resource-quotas/"),
+		Entry("Pod Security Admission denials",
+			`pods "virt-launcher-vm-abcde" is forbidden: violates PodSecurity "restricted:latest": privileged (container "compute" must not set securityContext.privileged=true)`,
+			"pod-security.kubernetes.io/enforce=privileged",
+			"https://kubernetes.io/docs/concepts/security/pod-security-admission/"),
+		Entry("SecurityContextConstraints denials",
+			`pods "virt-launcher-vm-abcde" is forbidden: unable to validate against any security context constraint: [provider "anyuid": Forbidden: not usable by user or serviceaccount]`,
+			"No SecurityContextConstraints allow the virt-launcher pod",
+			"https://docs.openshift.com/container-platform/latest/authentication/managing-security-context-constraints.html"),
+	)
+
+	It("should not explain unknown errors", func() {
+		Expect(errorhints.Hint(`virtualmachines.kubevirt.io "vm" not found`)).To(BeEmpty())
+	})
+
+	Context("Annotate", func() {
+		const quotaError = `pods "virt-launcher-vm-abcde" is forbidden: exceeded quota: cpu-quota`
+
+		It("should append the hint to known errors", func() {
+			Expect(errorhints.Annotate(quotaError)).To(Equal(quotaError + ". " + errorhints.Hint(quotaError)))
+		})
+
+		It("should not append a hint twice", func() {
+			annotated := errorhints.Annotate(quotaError)
+			Expect(errorhints.Annotate(annotated)).To(Equal(annotated))
+		})
+
+		It("should keep unknown errors unchanged", func() {
+			Expect(errorhints.Annotate("some error")).To(Equal("some error"))
+		})
+	})
+})
@@ -0,0 +1,130 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package migrations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+const (
+	CPUFeaturePolicyRequire = "require"
+	CPUFeaturePolicyForbid  = "forbid"
+	CPUFeaturePolicyDisable = "disable"
+)
+
+// HasNodeBoundCPUFeatures returns true if the VMI lists CPU features whose
+// policy constrains the nodes it may run on.
+func HasNodeBoundCPUFeatures(vmi *v1.VirtualMachineInstance) bool {
+	if vmi.Spec.Domain.CPU == nil {
+		return false
+	}
+	for _, feature := range vmi.Spec.Domain.CPU.Features {
+		switch feature.Policy {
+		case "", CPUFeaturePolicyRequire, CPUFeaturePolicyForbid:
+			return true
+		}
+	}
+	return false
+}
+
+// CPUIncompatibilities returns the reasons why the VMI cannot run on the given
+// node with respect to its CPU model and explicit CPU features. Required
+// features must be advertised by the node while forbidden features must not.
+// Disabled features are masked from the guest and therefore never restrict
+// the node. If sourceNode is not nil and the VMI uses the host-model CPU, the
+// node must additionally support the host model of the source node.
+// An empty result means the node is compatible.
+func CPUIncompatibilities(vmi *v1.VirtualMachineInstance, node, sourceNode *k8sv1.Node) []string {
+	var reasons []string
+
+	if node.Labels[v1.NodeSchedulable] != "true" {
+		reasons = append(reasons, "node is not schedulable for virtual machines")
+	}
+
+	cpu := vmi.Spec.Domain.CPU
+	if cpu == nil {
+		return reasons
+	}
+
+	switch cpu.Model {
+	case "", v1.CPUModeHostPassthrough:
+	case v1.CPUModeHostModel:
+		if sourceNode != nil {
+			reasons = append(reasons, hostModelIncompatibilities(node, sourceNode)...)
+		}
+	default:
+		if node.Labels[v1.CPUModelLabel+cpu.Model] != "true" {
+			reasons = append(reasons, fmt.Sprintf("CPU model %q is not supported", cpu.Model))
+		}
+	}
+
+	for _, feature := range cpu.Features {
+		_, advertised := node.Labels[v1.CPUFeatureLabel+feature.Name]
+		switch feature.Policy {
+		case "", CPUFeaturePolicyRequire:
+			if !advertised {
+				reasons = append(reasons, fmt.Sprintf("required CPU feature %q is not supported", feature.Name))
+			}
+		case CPUFeaturePolicyForbid:
+			if advertised {
+				reasons = append(reasons, fmt.Sprintf("forbidden CPU feature %q is present", feature.Name))
+			}
+		}
+	}
+
+	return reasons
+}
+
+func hostModelIncompatibilities(node, sourceNode *k8sv1.Node) []string {
+	var reasons []string
+	var requiredFeatures []string
+
+	hostModel := ""
+	for key := range sourceNode.Labels {
+		if strings.HasPrefix(key, v1.HostModelCPULabel) {
+			hostModel = strings.TrimPrefix(key, v1.HostModelCPULabel)
+		}
+		if strings.HasPrefix(key, v1.HostModelRequiredFeaturesLabel) {
+			requiredFeatures = append(requiredFeatures, strings.TrimPrefix(key, v1.HostModelRequiredFeaturesLabel))
+		}
+	}
+	if hostModel == "" {
+		return []string{fmt.Sprintf("source node %s does not expose its host CPU model", sourceNode.Name)}
+	}
+
+	if _, ok := node.Labels[v1.SupportedHostModelMigrationCPU+hostModel]; !ok {
+		reasons = append(reasons, fmt.Sprintf("host CPU model %q of the source node is not supported", hostModel))
+	}
+
+	sort.Strings(requiredFeatures)
+	for _, feature := range requiredFeatures {
+		if _, ok := node.Labels[v1.CPUFeatureLabel+feature]; !ok {
+			reasons = append(reasons, fmt.Sprintf("CPU feature %q required by the source host model is not supported", feature))
+		}
+	}
+
+	return reasons
+}
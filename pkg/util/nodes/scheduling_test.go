@@ -0,0 +1,102 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package nodes_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/kubevirt/pkg/util/nodes"
+)
+
+var _ = Describe("IsSchedulable", func() {
+	newNode := func() *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node01",
+				Labels: map[string]string{"zone": "a", "cpus": "16"},
+			},
+		}
+	}
+
+	requiredAffinity := func(terms ...corev1.NodeSelectorTerm) *corev1.Affinity {
+		return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+		}}
+	}
+
+	expression := func(key string, operator corev1.NodeSelectorOperator, values ...string) corev1.NodeSelectorTerm {
+		return corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: key, Operator: operator, Values: values}}}
+	}
+
+	It("should accept a node without constraints", func() {
+		Expect(nodes.IsSchedulable(newNode(), nil, nil, nil)).To(BeTrue())
+	})
+
+	It("should reject a cordoned node", func() {
+		node := newNode()
+		node.Spec.Unschedulable = true
+		Expect(nodes.IsSchedulable(node, nil, nil, nil)).To(BeFalse())
+	})
+
+	DescribeTable("should match the node selector", func(nodeSelector map[string]string, expected bool) {
+		Expect(nodes.IsSchedulable(newNode(), nodeSelector, nil, nil)).To(Equal(expected))
+	},
+		Entry("when the labels match", map[string]string{"zone": "a"}, true),
+		Entry("when a label differs", map[string]string{"zone": "b"}, false),
+		Entry("when a label is missing", map[string]string{"gpu": "true"}, false),
+	)
+
+	DescribeTable("should match the required node affinity", func(affinity *corev1.Affinity, expected bool) {
+		Expect(nodes.IsSchedulable(newNode(), nil, affinity, nil)).To(Equal(expected))
+	},
+		Entry("with In", requiredAffinity(expression("zone", corev1.NodeSelectorOpIn, "a", "b")), true),
+		Entry("with NotIn", requiredAffinity(expression("zone", corev1.NodeSelectorOpNotIn, "a")), false),
+		Entry("with Exists", requiredAffinity(expression("zone", corev1.NodeSelectorOpExists)), true),
+		Entry("with DoesNotExist", requiredAffinity(expression("zone", corev1.NodeSelectorOpDoesNotExist)), false),
+		Entry("with Gt", requiredAffinity(expression("cpus", corev1.NodeSelectorOpGt, "8")), true),
+		Entry("with Lt", requiredAffinity(expression("cpus", corev1.NodeSelectorOpLt, "8")), false),
+		Entry("with any matching term", requiredAffinity(expression("zone", corev1.NodeSelectorOpIn, "b"), expression("zone", corev1.NodeSelectorOpIn, "a")), true),
+		Entry("with the node name as field", requiredAffinity(corev1.NodeSelectorTerm{
+			MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node02"}}},
+		}), false),
+		Entry("without terms", requiredAffinity(), false),
+		Entry("with preferred terms only", &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{Weight: 1, Preference: expression("zone", corev1.NodeSelectorOpIn, "b")}},
+		}}, true),
+	)
+
+	DescribeTable("should consider the taints of the node", func(effect corev1.TaintEffect, tolerations []corev1.Toleration, expected bool) {
+		node := newNode()
+		node.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "db", Effect: effect}}
+		Expect(nodes.IsSchedulable(node, nil, nil, tolerations)).To(Equal(expected))
+	},
+		Entry("rejecting untolerated NoSchedule taints", corev1.TaintEffectNoSchedule, nil, false),
+		Entry("rejecting untolerated NoExecute taints", corev1.TaintEffectNoExecute, nil, false),
+		Entry("ignoring PreferNoSchedule taints", corev1.TaintEffectPreferNoSchedule, nil, true),
+		Entry("accepting tolerated taints", corev1.TaintEffectNoSchedule,
+			[]corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "db", Effect: corev1.TaintEffectNoSchedule}}, true),
+		Entry("rejecting tolerations for another value", corev1.TaintEffectNoSchedule,
+			[]corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "web"}}, false),
+	)
+})
@@ -37,6 +37,7 @@ const (
 	ENV_VAR_LIBVIRT_DEBUG_LOGS          = "LIBVIRT_DEBUG_LOGS"
 	ENV_VAR_VIRTIOFSD_DEBUG_LOGS        = "VIRTIOFSD_DEBUG_LOGS"
 	ENV_VAR_VIRT_LAUNCHER_LOG_VERBOSITY = "VIRT_LAUNCHER_LOG_VERBOSITY"
+	ENV_VAR_LOCAL_SCRATCH               = "LOCAL_SCRATCH"
 )
 
 func IsNonRootVMI(vmi *v1.VirtualMachineInstance) bool {
@@ -96,9 +96,10 @@ const (
 	defaultHandlerCertFilePath = "/etc/virt-handler/clientcertificates/tls.crt"
 	defaultHandlerKeyFilePath  = "/etc/virt-handler/clientcertificates/tls.key"
 
-	httpStatusNotFoundMessage     = "Not Found"
-	httpStatusBadRequestMessage   = "Bad Request"
-	httpStatusInternalServerError = "Internal Server Error"
+	httpStatusNotFoundMessage       = "Not Found"
+	httpStatusBadRequestMessage     = "Bad Request"
+	httpStatusInternalServerError   = "Internal Server Error"
+	httpStatusAcceptedDryRunMessage = "Accepted, returns the changes which would be applied for dry-run requests"
 )
 
 type VirtApi interface {
@@ -126,6 +127,8 @@ type virtAPIApp struct {
 	certmanager             certificate2.Manager
 	handlerTLSConfiguration *tls.Config
 	handlerCertManager      certificate2.Manager
+	subresourceTokens       *rest.SubresourceTokens
+	nodeStore               cache.Store
 
 	caConfigMapName              string
 	tlsCertFilePath              string
@@ -230,16 +233,29 @@ func (app *virtAPIApp) composeSubresources() {
 
 	var subwss []*restful.WebService
 
+	app.subresourceTokens = rest.NewSubresourceTokens(rest.NewSecretTokenKey(app.virtCli, app.namespace, components.VirtApiSubresourceTokenKeySecretName), app.authorizor, app.clusterConfig)
+	vmiSummaries := rest.NewVMISummaries(app.virtCli, app.clusterConfig)
+	subresourceSessions := rest.NewSubresourceSessions(app.authorizor, app.clusterConfig, app.host)
+	subresourceLimiter := rest.NewSubresourceLimiter(app.authorizor, app.clusterConfig)
+
 	for _, version := range v1.SubresourceGroupVersions {
 		subresourcesvmGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachines"}
 		subresourcesvmiGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachineinstances"}
 		expandvmspecGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "expand-vm-spec"}
+		vmisummariesGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachineinstancesummaries"}
+		bulkvmlifecycleGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "bulk-vm-lifecycle"}
 
 		subws := new(restful.WebService)
 		subws.Doc(fmt.Sprintf("KubeVirt \"%s\" Subresource API.", version.Version))
 		subws.Path(definitions.GroupVersionBasePath(version))
+		subws.Filter(subresourceLimiter.Filter)
 
-		subresourceApp := rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort, app.handlerTLSConfiguration, app.clusterConfig)
+		subresourceApp := rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort, app.handlerTLSConfiguration, app.clusterConfig).
+			WithSubresourceTokens(app.subresourceTokens).
+			WithSubresourceSessions(subresourceSessions).
+			WithSubresourceLimiter(subresourceLimiter).
+			WithAuthorizor(app.authorizor).
+			WithNodeStore(app.nodeStore)
 
 		restartRouteBuilder := subws.PUT(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("restart")).
 			To(subresourceApp.RestartVMRequestHandler).
@@ -249,6 +265,7 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version+"Restart").
 			Doc("Restart a VirtualMachine object.").
 			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusAccepted, httpStatusAcceptedDryRunMessage, v1.DryRunResult{}).
 			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "")
 		restartRouteBuilder.ParameterNamed("body").Required(false)
@@ -262,6 +279,7 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version+"Migrate").
 			Doc("Migrate a running VirtualMachine to another node.").
 			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusAccepted, httpStatusAcceptedDryRunMessage, v1.DryRunResult{}).
 			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
@@ -273,6 +291,7 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version+"Start").
 			Doc("Start a VirtualMachine object.").
 			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusAccepted, httpStatusAcceptedDryRunMessage, v1.DryRunResult{}).
 			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
@@ -284,6 +303,7 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version+"Stop").
 			Doc("Stop a VirtualMachine object.").
 			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusAccepted, httpStatusAcceptedDryRunMessage, v1.DryRunResult{}).
 			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "")
 		stopRouteBuilder.ParameterNamed("body").Required(false)
@@ -327,10 +347,21 @@ func (app *virtAPIApp) composeSubresources() {
 
 		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("softreboot")).
 			To(subresourceApp.SoftRebootVMIRequestHandler).
+			Consumes(mime.MIME_ANY).
+			Reads(v1.SoftRebootOptions{}).
 			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
 			Operation(version.Version+"SoftReboot").
 			Doc("Soft reboot a VirtualMachineInstance object.").
 			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
+
+		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("injectnmi")).
+			To(subresourceApp.InjectNMIVMIRequestHandler).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Operation(version.Version+"InjectNMI").
+			Doc("Inject a non-maskable interrupt into a VirtualMachineInstance object. Guests configured accordingly crash and write a memory dump.").
+			Returns(http.StatusOK, "OK", "").
 			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
 
 		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("pause")).
@@ -373,8 +404,10 @@ func (app *virtAPIApp) composeSubresources() {
 			Param(definitions.NamespaceParam(subws)).
 			Param(definitions.NameParam(subws)).
 			Param(definitions.MoveCursorParam(subws)).
+			Param(definitions.StreamParam(subws)).
+			Param(definitions.FPSParam(subws)).
 			Operation(version.Version + "VNCScreenshot").
-			Doc("Get a PNG VNC screenshot of the specified VirtualMachineInstance."))
+			Doc("Get a PNG VNC screenshot of the specified VirtualMachineInstance, or a low framerate MJPEG stream of its display if stream is set."))
 		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmiGVR) + definitions.SubResourcePath("usbredir")).
 			To(subresourceApp.USBRedirRequestHandler).
 			Param(definitions.NamespaceParam(subws)).
@@ -428,6 +461,34 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
 			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
 
+		subws.Route(subws.GET(definitions.NamespacedResourceBasePath(vmisummariesGVR)).
+			To(vmiSummaries.ListRequestHandler).
+			Param(definitions.NamespaceParam(subws)).
+			Param(subws.QueryParameter("labelSelector", "A selector to restrict the list of returned VirtualMachineInstances by their labels. Defaults to everything.")).
+			Param(subws.QueryParameter("sortBy", "The field to sort the VirtualMachineInstances by: name, phase or node. Defaults to name.")).
+			Param(subws.QueryParameter("limit", "The maximum number of VirtualMachineInstances to return. Defaults to 500.").DataType("integer")).
+			Param(subws.QueryParameter("continue", "The continue token returned with the previous page.")).
+			Operation(version.Version+"VMISummaries").
+			Produces(restful.MIME_JSON).
+			Doc("List summaries of VirtualMachineInstances from the cache of virt-api.").
+			Writes(v1.VirtualMachineInstanceSummaryList{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceSummaryList{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusServiceUnavailable, "Service Unavailable", ""))
+
+		subws.Route(subws.PUT(definitions.NamespacedResourceBasePath(bulkvmlifecycleGVR)).
+			To(subresourceApp.BulkLifecycleRequestHandler).
+			Param(definitions.NamespaceParam(subws)).
+			Reads(v1.BulkLifecycleOptions{}).
+			Operation(version.Version+"BulkVMLifecycle").
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Doc("Start, stop or restart the VirtualMachines selected by their names or a label selector.").
+			Writes(v1.BulkLifecycleResult{}).
+			Returns(http.StatusOK, "OK", v1.BulkLifecycleResult{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
+
 		subws.Route(subws.GET(definitions.SubResourcePath("version")).Produces(restful.MIME_JSON).
 			To(func(request *restful.Request, response *restful.Response) {
 				response.WriteAsJson(virtversion.Get())
@@ -468,6 +529,36 @@ func (app *virtAPIApp) composeSubresources() {
 			Writes(v1.VirtualMachineInstanceGuestAgentInfo{}).
 			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestAgentInfo{}))
 
+		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("usage")).
+			To(subresourceApp.ResourceUsage).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"Usage").
+			Doc("Get a sample of the guest CPU and memory usage").
+			Writes(v1.VirtualMachineInstanceResourceUsage{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceResourceUsage{}))
+
+		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("domainstats")).
+			To(subresourceApp.DomainStats).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"DomainStats").
+			Doc("Get the live libvirt statistics of the domain").
+			Writes(v1.VirtualMachineInstanceDomainStats{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceDomainStats{}))
+
+		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("domain")).
+			To(subresourceApp.Domain).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"Domain").
+			Doc("Get the live libvirt domain and the domain generated from the current spec").
+			Writes(v1.VirtualMachineInstanceDomain{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceDomain{}))
+
 		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("userlist")).
 			To(subresourceApp.UserList).
 			Consumes(restful.MIME_JSON).
@@ -499,6 +590,21 @@ func (app *virtAPIApp) composeSubresources() {
 			Writes(v1.ObjectGraphNode{}).
 			Returns(http.StatusOK, "OK", v1.ObjectGraphNode{}))
 
+		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("tokenexchange")).
+			To(subresourceApp.TokenExchangeRequestHandler).
+			Consumes(restful.MIME_JSON).
+			Reads(v1.TokenExchangeOptions{}).
+			Produces(restful.MIME_JSON).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Operation(version.Version+"TokenExchange").
+			Doc("Exchange an OIDC ID token for a short-lived token granting access to the console or VNC of a VirtualMachineInstance.").
+			Writes(v1.TokenExchangeResult{}).
+			Returns(http.StatusOK, "OK", v1.TokenExchangeResult{}).
+			Returns(http.StatusUnauthorized, "Unauthorized", "").
+			Returns(http.StatusForbidden, "Forbidden", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		subws.Route(subws.GET(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("objectgraph")).
 			To(subresourceApp.VMObjectGraph).
 			Consumes(restful.MIME_JSON).
@@ -510,6 +616,32 @@ func (app *virtAPIApp) composeSubresources() {
 			Writes(v1.ObjectGraphNode{}).
 			Returns(http.StatusOK, "OK", v1.ObjectGraphNode{}))
 
+		subws.Route(subws.POST(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("preflight")).
+			To(subresourceApp.VMPreflightRequestHandler).
+			Produces(restful.MIME_JSON).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Operation(version.Version+"vm-preflight").
+			Doc("Simulate the start of a Virtual Machine and report whether the resources it depends on are available").
+			Writes(v1.VirtualMachinePreflightReport{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachinePreflightReport{}).
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("rename")).
+			To(subresourceApp.VMRenameRequestHandler).
+			Consumes(mime.MIME_ANY).
+			Reads(v1.RenameOptions{}).
+			Produces(restful.MIME_JSON).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Operation(version.Version+"vm-rename").
+			Doc("Rename a stopped Virtual Machine together with the objects it controls").
+			Writes(v1.VirtualMachine{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachine{}).
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusConflict, "Conflict", "").
+			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
+
 		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("addvolume")).
 			To(subresourceApp.VMIAddVolumeRequestHandler).
 			Consumes(mime.MIME_ANY).
@@ -518,6 +650,7 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version+"vmi-addvolume").
 			Doc("Add a volume and disk to a running Virtual Machine Instance").
 			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusAccepted, httpStatusAcceptedDryRunMessage, v1.DryRunResult{}).
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
 		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("removevolume")).
@@ -528,6 +661,7 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version+"vmi-removevolume").
 			Doc("Removes a volume and disk from a running Virtual Machine Instance").
 			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusAccepted, httpStatusAcceptedDryRunMessage, v1.DryRunResult{}).
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
 		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("addvolume")).
@@ -538,6 +672,7 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version+"vm-addvolume").
 			Doc("Add a volume and disk to a running Virtual Machine.").
 			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusAccepted, httpStatusAcceptedDryRunMessage, v1.DryRunResult{}).
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
 		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("removevolume")).
@@ -548,6 +683,7 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version+"vm-removevolume").
 			Doc("Removes a volume and disk from a running Virtual Machine.").
 			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusAccepted, httpStatusAcceptedDryRunMessage, v1.DryRunResult{}).
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
 		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("memorydump")).
@@ -609,6 +745,18 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusOK, "OK", "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
+		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmiGVR)+definitions.SubResourcePath("sev/fetchattestationreport")).
+			To(subresourceApp.SEVFetchAttestationReportHandler).
+			Consumes(mime.MIME_ANY).
+			Reads(v1.SEVSNPAttestationReportOptions{}).
+			Param(definitions.NamespaceParam(subws)).Param(definitions.NameParam(subws)).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"SEVFetchAttestationReport").
+			Doc("Fetch an SEV-SNP attestation report and the certificate chain from a Virtual Machine").
+			Writes(v1.SEVSNPAttestationReport{}).
+			Returns(http.StatusOK, "OK", v1.SEVSNPAttestationReport{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		subws.Route(subws.PUT(definitions.NamespacedResourcePath(subresourcesvmGVR)+definitions.SubResourcePath("evacuate/cancel")).
 			To(subresourceApp.EvacuateCancelHandler(subresourceApp.FetchVirtualMachineInstanceForVM)).
 			Consumes(mime.MIME_ANY).
@@ -673,6 +821,14 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "expand-vm-spec",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstancesummaries",
+						Namespaced: true,
+					},
+					{
+						Name:       "bulk-vm-lifecycle",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/vnc",
 						Namespaced: true,
@@ -721,6 +877,10 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/softreboot",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/injectnmi",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachines/start",
 						Namespaced: true,
@@ -745,6 +905,14 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachines/objectgraph",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachines/preflight",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/rename",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachines/evacuate/cancel",
 						Namespaced: true,
@@ -753,6 +921,18 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/guestosinfo",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/usage",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/domainstats",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/domain",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/userlist",
 						Namespaced: true,
@@ -773,6 +953,10 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/objectgraph",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/tokenexchange",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/sev/fetchcertchain",
 						Namespaced: true,
@@ -789,6 +973,10 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/sev/injectlaunchsecret",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/sev/fetchattestationreport",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/evacuate/cancel",
 						Namespaced: true,
@@ -884,6 +1072,15 @@ func (app *virtAPIApp) Compose() {
 	restful.Filter(filter.RequestLoggingFilter())
 	restful.Filter(restful.OPTIONSFilter())
 	restful.Filter(func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		if allowed, reason, handled := app.subresourceTokens.Authorize(req); handled {
+			if allowed {
+				chain.ProcessFilter(req, resp)
+				return
+			}
+			resp.WriteErrorString(http.StatusUnauthorized, reason)
+			return
+		}
+
 		allowed, reason, err := app.authorizor.Authorize(req)
 		if err != nil {
 
@@ -994,6 +1191,9 @@ func (app *virtAPIApp) registerValidatingWebhooks(informers *webhooks.Informers)
 	http.HandleFunc(components.VMValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMs(w, r, app.clusterConfig, app.virtCli, informers, app.kubeVirtServiceAccounts)
 	})
+	http.HandleFunc(components.VMDeleteValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMDelete(w, r, app.kubeVirtServiceAccounts)
+	})
 	http.HandleFunc(components.VMIRSValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMIRS(w, r, app.clusterConfig)
 	})
@@ -1048,12 +1248,15 @@ func (app *virtAPIApp) registerValidatingWebhooks(informers *webhooks.Informers)
 	http.HandleFunc(components.VMCloneCreateValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVirtualMachineClones(w, r, app.clusterConfig, app.virtCli)
 	})
+	http.HandleFunc(components.VMOperationValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMOperations(w, r, app.clusterConfig)
+	})
 }
 
 func (app *virtAPIApp) registerMutatingWebhook(informers *webhooks.Informers) {
 
 	http.HandleFunc(components.VMMutatePath, func(w http.ResponseWriter, r *http.Request) {
-		mutating_webhook.ServeVMs(w, r, app.clusterConfig, app.virtCli)
+		mutating_webhook.ServeVMs(w, r, app.clusterConfig, app.virtCli, informers)
 	})
 	http.HandleFunc(components.VMIMutatePath, func(w http.ResponseWriter, r *http.Request) {
 		mutating_webhook.ServeVMIs(w, r, app.clusterConfig, informers, app.kubeVirtServiceAccounts)
@@ -1200,6 +1403,7 @@ func (app *virtAPIApp) Run() {
 	vmRestoreInformer := kubeInformerFactory.VirtualMachineRestore()
 	vmBackupInformer := kubeInformerFactory.VirtualMachineBackup()
 	namespaceInformer := kubeInformerFactory.Namespace()
+	app.nodeStore = kubeInformerFactory.KubeVirtNode().GetStore()
 
 	stopChan := make(chan struct{}, 1)
 	defer close(stopChan)
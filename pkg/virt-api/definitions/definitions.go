@@ -43,6 +43,7 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	exportv1 "kubevirt.io/api/export/v1beta1"
 	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	operationsv1 "kubevirt.io/api/operations/v1alpha1"
 	poolv1beta1 "kubevirt.io/api/pool/v1beta1"
 	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
 
@@ -62,6 +63,7 @@ func ComposeAPIDefinitions() []*restful.WebService {
 		snapshotApiServiceDefinitions,
 		exportApiServiceDefinitions,
 		backupApiServiceDefinitions,
+		operationsApiServiceDefinitions,
 		instancetypeApiServiceDefinitions,
 		migrationPoliciesApiServiceDefinitions,
 		poolApiServiceDefinitions,
@@ -295,6 +297,26 @@ func backupApiServiceDefinitions() []*restful.WebService {
 	return []*restful.WebService{ws, ws2}
 }
 
+func operationsApiServiceDefinitions() []*restful.WebService {
+	operationsGVR := operationsv1.SchemeGroupVersion.WithResource("virtualmachineoperations")
+
+	ws, err := groupVersionProxyBase(operationsv1.SchemeGroupVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	ws, err = genericNamespacedResourceProxy(ws, operationsGVR, &operationsv1.VirtualMachineOperation{}, "VirtualMachineOperation", &operationsv1.VirtualMachineOperationList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws2, err := resourceProxyAutodiscovery(operationsGVR)
+	if err != nil {
+		panic(err)
+	}
+	return []*restful.WebService{ws, ws2}
+}
+
 func groupVersionProxyBase(gv schema.GroupVersion) (*restful.WebService, error) {
 	ws := new(restful.WebService)
 	ws.Doc("The KubeVirt API, a virtual machine management.")
@@ -591,6 +613,8 @@ const (
 	NameParamName            = "name"
 	MoveCursorParamName      = "moveCursor"
 	PreserveSessionParamName = "preserveSession"
+	StreamParamName          = "stream"
+	FPSParamName             = "fps"
 )
 
 func NameParam(ws *restful.WebService) *restful.Parameter {
@@ -605,6 +629,20 @@ func MoveCursorParam(ws *restful.WebService) *restful.Parameter {
 	return ws.QueryParameter(MoveCursorParamName, "Move the cursor on the VNC display to wake up the screen").DataType("boolean").DefaultValue("false")
 }
 
+func StreamParam(ws *restful.WebService) *restful.Parameter {
+	return ws.
+		QueryParameter(StreamParamName, "Stream the display as a multipart MJPEG stream instead of returning a single PNG screenshot.").
+		DataType("boolean").
+		DefaultValue("false")
+}
+
+func FPSParam(ws *restful.WebService) *restful.Parameter {
+	return ws.
+		QueryParameter(FPSParamName, "Frames per second of the MJPEG stream, between 1 and 5.").
+		DataType("integer").
+		DefaultValue("1")
+}
+
 func PreserveSessionParam(ws *restful.WebService) *restful.Parameter {
 	return ws.
 		QueryParameter(PreserveSessionParamName, "Connect only if ongoing session is not disturbed.").
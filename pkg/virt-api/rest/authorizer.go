@@ -79,6 +79,7 @@ var noAuthEndpoints = map[string]struct{}{
 
 type VirtApiAuthorizor interface {
 	Authorize(req *restful.Request) (bool, string, error)
+	AuthorizeResource(req *restful.Request, attributes *authv1.ResourceAttributes) (bool, string, error)
 	AddUserHeaders(header []string)
 	GetUserHeaders() []string
 	AddGroupHeaders(header []string)
@@ -106,7 +107,11 @@ func (a *authorizor) getUserGroups(header http.Header) ([]string, error) {
 }
 
 func (a *authorizor) getUserName(header http.Header) (string, error) {
-	for _, key := range a.userHeaders {
+	return getUserName(header, a.userHeaders)
+}
+
+func getUserName(header http.Header, userHeaders []string) (string, error) {
+	for _, key := range userHeaders {
 		user, ok := header[key]
 		if ok {
 			return user[0], nil
@@ -167,20 +172,13 @@ func (a *authorizor) GetExtraPrefixHeaders() []string {
 	return a.userExtraHeaderPrefixes
 }
 
-func (a *authorizor) generateAccessReview(req *restful.Request) (*authv1.SubjectAccessReview, error) {
-	if req.Request == nil {
-		return nil, fmt.Errorf("empty http request")
-	}
-	if req.Request.URL == nil {
-		return nil, fmt.Errorf("no URL in http request")
-	}
-
-	userName, err := a.getUserName(req.Request.Header)
+func (a *authorizor) newAccessReview(header http.Header) (*authv1.SubjectAccessReview, error) {
+	userName, err := a.getUserName(header)
 	if err != nil {
 		return nil, err
 	}
 
-	userGroups, err := a.getUserGroups(req.Request.Header)
+	userGroups, err := a.getUserGroups(header)
 	if err != nil {
 		return nil, err
 	}
@@ -189,12 +187,29 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authv1.Subject
 	r.Spec = authv1.SubjectAccessReviewSpec{
 		User:   userName,
 		Groups: userGroups,
-		Extra:  a.getUserExtras(req.Request.Header),
+		Extra:  a.getUserExtras(header),
+	}
+	return r, nil
+}
+
+func (a *authorizor) generateAccessReview(req *restful.Request) (*authv1.SubjectAccessReview, error) {
+	if req.Request == nil {
+		return nil, fmt.Errorf("empty http request")
+	}
+	if req.Request.URL == nil {
+		return nil, fmt.Errorf("no URL in http request")
+	}
+
+	r, err := a.newAccessReview(req.Request.Header)
+	if err != nil {
+		return nil, err
 	}
 
 	// URL examples
 	// /apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/console
 	// /apis/subresources.kubevirt.io/v1alpha3/namespaces/default/expand-vm-spec
+	// /apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstancesummaries
+	// /apis/subresources.kubevirt.io/v1/namespaces/default/bulk-vm-lifecycle
 	pathSplit := strings.Split(req.Request.URL.Path, "/")
 	if len(pathSplit) >= namespacedResourceAttributesMinParts {
 		if err := addNamespacedResourceAttributes(pathSplit, req.Request.Method, r); err != nil {
@@ -251,7 +266,7 @@ func addNamespacedResourceBaseAttributes(pathSplit []string, requestMethod strin
 	namespace := pathSplit[5]
 	resource := pathSplit[6]
 
-	if resource != "expand-vm-spec" {
+	if resource != "expand-vm-spec" && resource != "virtualmachineinstancesummaries" && resource != "bulk-vm-lifecycle" {
 		return fmt.Errorf("unknown resource type %s", resource)
 	}
 
@@ -351,6 +366,31 @@ func (a *authorizor) Authorize(req *restful.Request) (bool, string, error) {
 	return false, result.Status.Reason, nil
 }
 
+// AuthorizeResource checks if the user of the request may access the resource described by the attributes.
+// It is used by requests which access several resources on behalf of the user.
+func (a *authorizor) AuthorizeResource(req *restful.Request, attributes *authv1.ResourceAttributes) (bool, string, error) {
+	if req.Request == nil {
+		return false, "empty http request", nil
+	}
+
+	r, err := a.newAccessReview(req.Request.Header)
+	if err != nil {
+		return false, fmt.Sprintf("%v", err), nil
+	}
+	r.Spec.ResourceAttributes = attributes
+
+	result, err := a.client.Create(context.Background(), r, metav1.CreateOptions{})
+	if err != nil {
+		return false, "internal server error", err
+	}
+
+	if result.Status.Allowed {
+		return true, "", nil
+	}
+
+	return false, result.Status.Reason, nil
+}
+
 func NewAuthorizorFromClient(client authclientv1.SubjectAccessReviewInterface) VirtApiAuthorizor {
 	return &authorizor{
 		userHeaders:             []string{userHeader},
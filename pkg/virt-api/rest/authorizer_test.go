@@ -189,6 +189,85 @@ var _ = Describe("Authorizer", func() {
 					Expect(result).To(BeTrue())
 				})
 
+				It("should check the list verb for VMI summaries", func() {
+					req.Request.Method = http.MethodGet
+					req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstancesummaries"
+					allowedFn = func(sar *authv1.SubjectAccessReview) (*authv1.SubjectAccessReview, error) {
+						Expect(sar.Spec.ResourceAttributes).ToNot(BeNil())
+						Expect(sar.Spec.ResourceAttributes.Namespace).To(Equal("default"))
+						Expect(sar.Spec.ResourceAttributes.Verb).To(Equal("list"))
+						Expect(sar.Spec.ResourceAttributes.Group).To(Equal("subresources.kubevirt.io"))
+						Expect(sar.Spec.ResourceAttributes.Version).To(Equal("v1"))
+						Expect(sar.Spec.ResourceAttributes.Resource).To(Equal("virtualmachineinstancesummaries"))
+						sar.Status.Allowed = true
+						return sar, nil
+					}
+					result, _, err := app.Authorize(req)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(BeTrue())
+				})
+
+				It("should check the update verb for bulk VM lifecycle", func() {
+					req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1/namespaces/default/bulk-vm-lifecycle"
+					allowedFn = func(sar *authv1.SubjectAccessReview) (*authv1.SubjectAccessReview, error) {
+						Expect(sar.Spec.ResourceAttributes).ToNot(BeNil())
+						Expect(sar.Spec.ResourceAttributes.Namespace).To(Equal("default"))
+						Expect(sar.Spec.ResourceAttributes.Verb).To(Equal("update"))
+						Expect(sar.Spec.ResourceAttributes.Resource).To(Equal("bulk-vm-lifecycle"))
+						sar.Status.Allowed = true
+						return sar, nil
+					}
+					result, _, err := app.Authorize(req)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(BeTrue())
+				})
+
+			})
+
+			Context("with resource attributes", func() {
+				attributes := &authv1.ResourceAttributes{
+					Namespace:   "default",
+					Verb:        "update",
+					Group:       "subresources.kubevirt.io",
+					Version:     "v1",
+					Resource:    "virtualmachines",
+					Subresource: "start",
+					Name:        "testvm",
+				}
+
+				It("should review the attributes for the user of the request", func() {
+					allowedFn = func(sar *authv1.SubjectAccessReview) (*authv1.SubjectAccessReview, error) {
+						Expect(sar.Spec.User).To(Equal("user"))
+						Expect(sar.Spec.Groups).To(Equal([]string{"userGroup"}))
+						Expect(sar.Spec.Extra).To(HaveKeyWithValue("test", authv1.ExtraValue{"userExtraValue"}))
+						Expect(sar.Spec.ResourceAttributes).To(Equal(attributes))
+						sar.Status.Allowed = true
+						return sar, nil
+					}
+					result, _, err := app.AuthorizeResource(req, attributes)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(BeTrue())
+				})
+
+				It("should reject unauthorized user", func() {
+					allowedFn = func(sar *authv1.SubjectAccessReview) (*authv1.SubjectAccessReview, error) {
+						sar.Status.Reason = "just because"
+						return sar, nil
+					}
+					result, reason, err := app.AuthorizeResource(req, attributes)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(BeFalse())
+					Expect(reason).To(Equal("just because"))
+				})
+
+				It("should return the error if the review fails", func() {
+					allowedFn = func(sar *authv1.SubjectAccessReview) (*authv1.SubjectAccessReview, error) {
+						return nil, errors.New("internal error")
+					}
+					result, _, err := app.AuthorizeResource(req, attributes)
+					Expect(err).To(HaveOccurred())
+					Expect(result).To(BeFalse())
+				})
 			})
 
 			DescribeTable("should allow all users for info endpoints", func(path string) {
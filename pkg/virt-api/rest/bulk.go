@@ -0,0 +1,222 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/emicklei/go-restful/v3"
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// bulkLifecycleWorkers bounds the number of VirtualMachines processed in parallel by a bulk lifecycle request
+	bulkLifecycleWorkers = 10
+	// maxBulkLifecycleVirtualMachines bounds the number of VirtualMachines a single bulk lifecycle request can select
+	maxBulkLifecycleVirtualMachines = 5000
+)
+
+// WithAuthorizor sets the authorizor used to check the access of requests which act on several resources
+func (app *SubresourceAPIApp) WithAuthorizor(authorizor VirtApiAuthorizor) *SubresourceAPIApp {
+	app.authorizor = authorizor
+	return app
+}
+
+// BulkLifecycleRequestHandler applies a lifecycle action to several VirtualMachines of a namespace.
+// Every VirtualMachine is processed like a single start, stop or restart request, including the
+// authorization of the caller, and the outcome is returned per VirtualMachine.
+func (app *SubresourceAPIApp) BulkLifecycleRequestHandler(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+
+	opts := &v1.BulkLifecycleOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("request body is required"), response)
+		return
+	}
+	if err := decodeBody(request, opts); err != nil {
+		writeError(err, response)
+		return
+	}
+	if statusErr := validateBulkLifecycleOptions(opts); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	names, statusErr := app.selectBulkLifecycleVirtualMachines(namespace, opts)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	result := &v1.BulkLifecycleResult{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "BulkLifecycleResult",
+			APIVersion: v1.GroupVersion.String(),
+		},
+		Results: make([]v1.BulkLifecycleVirtualMachineResult, len(names)),
+	}
+	workqueue.ParallelizeUntil(request.Request.Context(), bulkLifecycleWorkers, len(names), func(i int) {
+		result.Results[i] = app.applyBulkLifecycleAction(request, namespace, names[i], opts)
+	})
+
+	log.Log.V(2).Infof("Applied %s to %d VirtualMachines in namespace %s", opts.Action, len(names), namespace)
+	if err := response.WriteHeaderAndJson(http.StatusOK, result, restful.MIME_JSON); err != nil {
+		log.Log.Reason(err).Error("Failed to write http response.")
+	}
+}
+
+func validateBulkLifecycleOptions(opts *v1.BulkLifecycleOptions) *errors.StatusError {
+	switch opts.Action {
+	case v1.BulkLifecycleActionStart, v1.BulkLifecycleActionStop, v1.BulkLifecycleActionRestart:
+	default:
+		return errors.NewBadRequest(fmt.Sprintf("unsupported action %q, supported actions are %s, %s and %s",
+			opts.Action, v1.BulkLifecycleActionStart, v1.BulkLifecycleActionStop, v1.BulkLifecycleActionRestart))
+	}
+
+	if len(opts.Names) > 0 && opts.LabelSelector != nil {
+		return errors.NewBadRequest("names and labelSelector are mutually exclusive")
+	}
+	if len(opts.Names) == 0 && opts.LabelSelector == nil {
+		return errors.NewBadRequest("either names or labelSelector is required")
+	}
+	if len(opts.Names) > maxBulkLifecycleVirtualMachines {
+		return errors.NewBadRequest(fmt.Sprintf("at most %d VirtualMachines can be selected", maxBulkLifecycleVirtualMachines))
+	}
+	for _, name := range opts.Names {
+		if name == "" {
+			return errors.NewBadRequest("names must not be empty")
+		}
+	}
+
+	return nil
+}
+
+// selectBulkLifecycleVirtualMachines returns the sorted names of the VirtualMachines selected by the request
+func (app *SubresourceAPIApp) selectBulkLifecycleVirtualMachines(namespace string, opts *v1.BulkLifecycleOptions) ([]string, *errors.StatusError) {
+	if opts.LabelSelector == nil {
+		return sets.List(sets.New(opts.Names...)), nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(opts.LabelSelector)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid labelSelector: %v", err))
+	}
+
+	vms, err := app.virtCli.VirtualMachine(namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	if len(vms.Items) > maxBulkLifecycleVirtualMachines {
+		return nil, errors.NewBadRequest(fmt.Sprintf("the labelSelector matches %d VirtualMachines, at most %d can be selected", len(vms.Items), maxBulkLifecycleVirtualMachines))
+	}
+
+	names := make([]string, 0, len(vms.Items))
+	for _, vm := range vms.Items {
+		names = append(names, vm.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (app *SubresourceAPIApp) applyBulkLifecycleAction(request *restful.Request, namespace, name string, opts *v1.BulkLifecycleOptions) v1.BulkLifecycleVirtualMachineResult {
+	if statusErr := app.authorizeBulkLifecycleAction(request, namespace, name, opts.Action); statusErr != nil {
+		return bulkLifecycleFailure(name, statusErr)
+	}
+
+	recorder := newDryRunRecorder(opts.DryRun)
+	var statusErr *errors.StatusError
+	switch opts.Action {
+	case v1.BulkLifecycleActionStart:
+		startOpts := &v1.StartOptions{}
+		if opts.StartOptions != nil {
+			startOpts = opts.StartOptions.DeepCopy()
+		}
+		startOpts.DryRun = opts.DryRun
+		statusErr = app.startVM(name, namespace, startOpts, recorder)
+	case v1.BulkLifecycleActionStop:
+		stopOpts := &v1.StopOptions{}
+		if opts.StopOptions != nil {
+			stopOpts = opts.StopOptions.DeepCopy()
+		}
+		stopOpts.DryRun = opts.DryRun
+		statusErr = app.stopVM(name, namespace, stopOpts, recorder)
+	case v1.BulkLifecycleActionRestart:
+		restartOpts := &v1.RestartOptions{}
+		if opts.RestartOptions != nil {
+			restartOpts = opts.RestartOptions.DeepCopy()
+		}
+		restartOpts.DryRun = opts.DryRun
+		statusErr = app.restartVM(name, namespace, restartOpts, recorder)
+	}
+	if statusErr != nil {
+		return bulkLifecycleFailure(name, statusErr)
+	}
+
+	return v1.BulkLifecycleVirtualMachineResult{
+		Name:    name,
+		Code:    http.StatusAccepted,
+		Changes: recorder.result.Changes,
+	}
+}
+
+// authorizeBulkLifecycleAction checks that the caller may use the subresource of the action on the VirtualMachine,
+// the bulk request must not grant more than the single requests.
+func (app *SubresourceAPIApp) authorizeBulkLifecycleAction(request *restful.Request, namespace, name string, action v1.BulkLifecycleAction) *errors.StatusError {
+	if app.authorizor == nil {
+		return errors.NewInternalError(fmt.Errorf("no authorizor configured"))
+	}
+
+	allowed, reason, err := app.authorizor.AuthorizeResource(request, &authv1.ResourceAttributes{
+		Namespace:   namespace,
+		Verb:        "update",
+		Group:       v1.SubresourceGroupName,
+		Version:     v1.SubresourceStorageGroupVersion.Version,
+		Resource:    "virtualmachines",
+		Subresource: string(action),
+		Name:        name,
+	})
+	if err != nil {
+		return errors.NewInternalError(fmt.Errorf("unable to review access: %v", err))
+	}
+	if !allowed {
+		return errors.NewForbidden(v1.Resource("virtualmachines/"+string(action)), name, fmt.Errorf("%s", reason))
+	}
+
+	return nil
+}
+
+func bulkLifecycleFailure(name string, statusErr *errors.StatusError) v1.BulkLifecycleVirtualMachineResult {
+	return v1.BulkLifecycleVirtualMachineResult{
+		Name:    name,
+		Code:    statusErr.ErrStatus.Code,
+		Reason:  statusErr.ErrStatus.Reason,
+		Message: statusErr.ErrStatus.Message,
+	}
+}
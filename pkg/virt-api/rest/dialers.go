@@ -20,9 +20,11 @@
 package rest
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 
 	"github.com/gorilla/websocket"
 
@@ -47,16 +49,11 @@ type handlerDial struct {
 	app    *SubresourceAPIApp
 }
 
+type websocketDialFunc func(address string, tlsConfig *tls.Config) (*websocket.Conn, *http.Response, error)
+
 func (h handlerDial) Dial(vmi *v1.VirtualMachineInstance) (*websocket.Conn, *k8serrors.StatusError) {
-	url, _, statusError := h.app.getVirtHandlerFor(vmi, h.getURL)
-	if statusError != nil {
-		return nil, statusError
-	}
-	conn, resp, err := kvcorev1.Dial(url, h.app.handlerTLSConfiguration)
-	if err != nil {
-		return nil, k8serrors.NewInternalError(kvcorev1.EnrichError(err, resp))
-	}
-	return conn, nil
+	conn, _, err := h.dial(vmi, kvcorev1.Dial)
+	return conn, err
 }
 
 func (h handlerDial) DialUnderlying(vmi *v1.VirtualMachineInstance) (net.Conn, *k8serrors.StatusError) {
@@ -67,6 +64,26 @@ func (h handlerDial) DialUnderlying(vmi *v1.VirtualMachineInstance) (net.Conn, *
 	return conn.UnderlyingConn(), nil
 }
 
+func (h handlerDial) DialUnderlyingWithCompression(vmi *v1.VirtualMachineInstance) (net.Conn, bool, *k8serrors.StatusError) {
+	conn, resp, err := h.dial(vmi, kvcorev1.DialWithCompression)
+	if err != nil {
+		return nil, false, err
+	}
+	return conn.UnderlyingConn(), kvcorev1.HasCompressionExtension(resp.Header), nil
+}
+
+func (h handlerDial) dial(vmi *v1.VirtualMachineInstance, dial websocketDialFunc) (*websocket.Conn, *http.Response, *k8serrors.StatusError) {
+	url, _, statusError := h.app.getVirtHandlerFor(vmi, h.getURL)
+	if statusError != nil {
+		return nil, nil, statusError
+	}
+	conn, resp, err := dial(url, h.app.handlerTLSConfiguration)
+	if err != nil {
+		return nil, nil, k8serrors.NewInternalError(kvcorev1.EnrichError(err, resp))
+	}
+	return conn, resp, nil
+}
+
 func (n netDial) Dial(vmi *v1.VirtualMachineInstance) (*websocket.Conn, *k8serrors.StatusError) {
 	panic("don't call me")
 }
@@ -0,0 +1,83 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/emicklei/go-restful/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+)
+
+// dryRunRecorder collects the changes applied by a lifecycle subresource request.
+// For dry-run requests they are returned to the client instead of an empty response.
+type dryRunRecorder struct {
+	dryRun bool
+	result v1.DryRunResult
+}
+
+func newDryRunRecorder(dryRun []string) *dryRunRecorder {
+	return &dryRunRecorder{
+		dryRun: len(dryRun) > 0 && dryRun[0] == metav1.DryRunAll,
+	}
+}
+
+// record adds a change to the result. obj is the object returned by the API server,
+// it is only serialized for dry-run requests.
+func (r *dryRunRecorder) record(kind, name string, operation v1.DryRunOperation, patchBytes []byte, obj interface{}) {
+	if !r.dryRun {
+		return
+	}
+
+	change := v1.DryRunChange{
+		Kind:      kind,
+		Name:      name,
+		Operation: operation,
+		Patch:     string(patchBytes),
+	}
+	if obj != nil {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to serialize the dry-run result of %s %s", kind, name)
+		} else if string(raw) != "null" {
+			change.Object = &runtime.RawExtension{Raw: raw}
+		}
+	}
+	r.result.Changes = append(r.result.Changes, change)
+}
+
+// writeAccepted acknowledges the request and returns the recorded changes for dry-run requests
+func (r *dryRunRecorder) writeAccepted(response *restful.Response) {
+	if !r.dryRun {
+		response.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	r.result.Kind = "DryRunResult"
+	r.result.APIVersion = v1.GroupVersion.String()
+	if err := response.WriteHeaderAndJson(http.StatusAccepted, &r.result, restful.MIME_JSON); err != nil {
+		log.Log.Reason(err).Error("Failed to write http response.")
+	}
+}
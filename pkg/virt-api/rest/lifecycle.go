@@ -20,9 +20,11 @@
 package rest
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
-	"net/http"
+	"io"
 	"strings"
 
 	"github.com/emicklei/go-restful/v3"
@@ -47,47 +49,54 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
-	vm, statusErr := app.fetchVirtualMachine(name, namespace)
-	if statusErr != nil {
+	bodyStruct := &v1.StartOptions{}
+	if request.Request.Body != nil {
+		if err := decodeBody(request, bodyStruct); err != nil {
+			writeError(err, response)
+			return
+		}
+	}
+
+	recorder := newDryRunRecorder(bodyStruct.DryRun)
+	if statusErr := app.startVM(name, namespace, bodyStruct, recorder); statusErr != nil {
 		writeError(statusErr, response)
 		return
 	}
+	recorder.writeAccepted(response)
+}
+
+func (app *SubresourceAPIApp) startVM(name, namespace string, bodyStruct *v1.StartOptions, recorder *dryRunRecorder) *errors.StatusError {
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		return statusErr
+	}
 
 	vmi, err := app.virtCli.VirtualMachineInstance(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil && !errors.IsNotFound(err) {
-		writeError(errors.NewInternalError(err), response)
-		return
+		return errors.NewInternalError(err)
 	}
 
 	if vmi != nil && !vmi.IsFinal() && vmi.Status.Phase != v1.Unknown && vmi.Status.Phase != v1.VmPhaseUnset {
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is already running")), response)
-		return
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is already running"))
 	}
 	if controller.NewVirtualMachineConditionManager().HasConditionWithStatus(vm, v1.VirtualMachineManualRecoveryRequired, k8sv1.ConditionTrue) {
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf(volumeMigrationManualRecoveryRequiredErr)), response)
-		return
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf(volumeMigrationManualRecoveryRequiredErr))
 	}
 
-	startPaused := false
+	startPaused := bodyStruct.Paused
 	startChangeRequestData := make(map[string]string)
-	bodyStruct := &v1.StartOptions{}
-	if request.Request.Body != nil {
-		if err := decodeBody(request, bodyStruct); err != nil {
-			writeError(err, response)
-			return
-		}
-		startPaused = bodyStruct.Paused
-	}
 	if startPaused {
 		startChangeRequestData[v1.StartRequestDataPausedKey] = v1.StartRequestDataPausedTrue
 	}
 
 	var patchErr error
+	var patchedVM *v1.VirtualMachine
+	var patchBytes []byte
+	operation := v1.DryRunOperationPatchStatus
 
 	runStrategy, err := vm.RunStrategy()
 	if err != nil {
-		writeError(errors.NewInternalError(err), response)
-		return
+		return errors.NewInternalError(err)
 	}
 	// RunStrategyHalted         -> spec.running = true / send start request for paused start
 	// RunStrategyManual         -> send start request
@@ -100,24 +109,23 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 		// Send start request if VM should start paused. virt-controller will update RunStrategy upon this request.
 		// No need to send the request if StartStrategy is already set to Paused in VMI Spec.
 		if startPaused && (vm.Spec.Template == nil || vm.Spec.Template.Spec.StartStrategy != &pausedStartStrategy) {
-			patchBytes, err := getChangeRequestJson(vm, v1.VirtualMachineStateChangeRequest{
+			patchBytes, err = getChangeRequestJson(vm, v1.VirtualMachineStateChangeRequest{
 				Action: v1.StartRequest,
 				Data:   startChangeRequestData,
 			})
 			if err != nil {
-				writeError(errors.NewInternalError(err), response)
-				return
+				return errors.NewInternalError(err)
 			}
 			log.Log.Object(vm).V(4).Infof(patchingVMStatusFmt, string(patchBytes))
-			_, patchErr = app.virtCli.VirtualMachine(vm.Namespace).PatchStatus(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
+			patchedVM, patchErr = app.virtCli.VirtualMachine(vm.Namespace).PatchStatus(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
 		} else {
-			patchBytes, err := getRunningPatch(vm, true)
+			patchBytes, err = getRunningPatch(vm, true)
 			if err != nil {
-				writeError(errors.NewInternalError(err), response)
-				return
+				return errors.NewInternalError(err)
 			}
+			operation = v1.DryRunOperationPatch
 			log.Log.Object(vm).V(4).Infof(patchingVMFmt, string(patchBytes))
-			_, patchErr = app.virtCli.VirtualMachine(namespace).Patch(context.Background(), vm.GetName(), types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
+			patchedVM, patchErr = app.virtCli.VirtualMachine(namespace).Patch(context.Background(), vm.GetName(), types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
 		}
 
 	case v1.RunStrategyRerunOnFailure, v1.RunStrategyManual:
@@ -126,11 +134,9 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 			(runStrategy == v1.RunStrategyManual && vmi != nil && vmi.IsFinal()) {
 			needsRestart = true
 		} else if runStrategy == v1.RunStrategyRerunOnFailure && vmi != nil && vmi.Status.Phase == v1.Failed {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support starting VM from failed state", v1.RunStrategyRerunOnFailure)), response)
-			return
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support starting VM from failed state", v1.RunStrategyRerunOnFailure))
 		}
 
-		var patchBytes []byte
 		if needsRestart {
 			patchBytes, err = getChangeRequestJson(vm,
 				v1.VirtualMachineStateChangeRequest{Action: v1.StopRequest, UID: &vmi.UID},
@@ -140,26 +146,23 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 				v1.VirtualMachineStateChangeRequest{Action: v1.StartRequest, Data: startChangeRequestData})
 		}
 		if err != nil {
-			writeError(errors.NewInternalError(err), response)
-			return
+			return errors.NewInternalError(err)
 		}
 		log.Log.Object(vm).V(4).Infof(patchingVMStatusFmt, string(patchBytes))
-		_, patchErr = app.virtCli.VirtualMachine(vm.Namespace).PatchStatus(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
+		patchedVM, patchErr = app.virtCli.VirtualMachine(vm.Namespace).PatchStatus(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
 	case v1.RunStrategyAlways, v1.RunStrategyOnce:
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual start requests", runStrategy)), response)
-		return
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual start requests", runStrategy))
 	}
 
 	if patchErr != nil {
 		if strings.Contains(patchErr.Error(), jsonpatchTestErr) {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, patchErr), response)
-		} else {
-			writeError(errors.NewInternalError(patchErr), response)
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, patchErr)
 		}
-		return
+		return errors.NewInternalError(patchErr)
 	}
 
-	response.WriteHeader(http.StatusAccepted)
+	recorder.record(v1.VirtualMachineGroupVersionKind.Kind, vm.Name, operation, patchBytes, patchedVM)
+	return nil
 }
 
 func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, response *restful.Response) {
@@ -180,16 +183,23 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 		}
 	}
 
-	vm, statusErr := app.fetchVirtualMachine(name, namespace)
-	if statusErr != nil {
+	recorder := newDryRunRecorder(bodyStruct.DryRun)
+	if statusErr := app.stopVM(name, namespace, bodyStruct, recorder); statusErr != nil {
 		writeError(statusErr, response)
 		return
 	}
+	recorder.writeAccepted(response)
+}
+
+func (app *SubresourceAPIApp) stopVM(name, namespace string, bodyStruct *v1.StopOptions, recorder *dryRunRecorder) *errors.StatusError {
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		return statusErr
+	}
 
 	runStrategy, err := vm.RunStrategy()
 	if err != nil {
-		writeError(errors.NewInternalError(err), response)
-		return
+		return errors.NewInternalError(err)
 	}
 
 	hasVMI := true
@@ -197,8 +207,7 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 	if err != nil && errors.IsNotFound(err) {
 		hasVMI = false
 	} else if err != nil {
-		writeError(errors.NewInternalError(err), response)
-		return
+		return errors.NewInternalError(err)
 	}
 
 	var oldGracePeriodSeconds int64
@@ -216,64 +225,61 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 		patchSet.AddOption(patch.WithReplace("/spec/terminationGracePeriodSeconds", *bodyStruct.GracePeriod))
 		patchBytes, err := patchSet.GeneratePayload()
 		if err != nil {
-			writeError(errors.NewInternalError(err), response)
-			return
+			return errors.NewInternalError(err)
 		}
 
 		log.Log.Object(vmi).V(2).Infof("Patching VMI: %s", string(patchBytes))
-		_, err = app.virtCli.VirtualMachineInstance(namespace).Patch(context.Background(), vmi.GetName(), types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
+		patchedVMI, err := app.virtCli.VirtualMachineInstance(namespace).Patch(context.Background(), vmi.GetName(), types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
 		if err != nil {
-			writeError(errors.NewInternalError(err), response)
-			return
+			return errors.NewInternalError(err)
 		}
+		recorder.record(v1.VirtualMachineInstanceGroupVersionKind.Kind, vmi.Name, v1.DryRunOperationPatch, patchBytes, patchedVMI)
 	}
 
 	switch runStrategy {
 	case v1.RunStrategyHalted:
 		if !hasVMI || vmi.IsFinal() {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf(vmNotRunning)), response)
-			return
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf(vmNotRunning))
 		}
 		if bodyStruct.GracePeriod == nil || (vmi.Spec.TerminationGracePeriodSeconds != nil && *bodyStruct.GracePeriod >= oldGracePeriodSeconds) {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v only supports manual stop requests with a shorter graceperiod", v1.RunStrategyHalted)), response)
-			return
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v only supports manual stop requests with a shorter graceperiod", v1.RunStrategyHalted))
 		}
 		// same behavior as RunStrategyManual
-		patchErr, err = app.patchVMStatusStopped(vmi, vm, response, bodyStruct)
+		patchErr, err = app.patchVMStatusStopped(vmi, vm, bodyStruct, recorder)
 		if err != nil {
-			return
+			return errors.NewInternalError(err)
 		}
 	case v1.RunStrategyRerunOnFailure, v1.RunStrategyManual:
 		if !hasVMI || vmi.IsFinal() {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf(vmNotRunning)), response)
-			return
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf(vmNotRunning))
 		}
 		// pass the buck and ask virt-controller to stop the VM. this way the
 		// VM will retain RunStrategy = manual
-		patchErr, err = app.patchVMStatusStopped(vmi, vm, response, bodyStruct)
+		patchErr, err = app.patchVMStatusStopped(vmi, vm, bodyStruct, recorder)
 		if err != nil {
-			return
+			return errors.NewInternalError(err)
 		}
 	case v1.RunStrategyAlways, v1.RunStrategyOnce:
 		patchBytes, err := getRunningPatch(vm, false)
 		if err != nil {
-			writeError(errors.NewInternalError(err), response)
-			return
+			return errors.NewInternalError(err)
 		}
 		log.Log.Object(vm).V(4).Infof(patchingVMFmt, string(patchBytes))
-		_, patchErr = app.virtCli.VirtualMachine(namespace).Patch(context.Background(), vm.GetName(), types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
+		var patchedVM *v1.VirtualMachine
+		patchedVM, patchErr = app.virtCli.VirtualMachine(namespace).Patch(context.Background(), vm.GetName(), types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
+		if patchErr == nil {
+			recorder.record(v1.VirtualMachineGroupVersionKind.Kind, vm.Name, v1.DryRunOperationPatch, patchBytes, patchedVM)
+		}
 	}
 
 	if patchErr != nil {
 		if strings.Contains(patchErr.Error(), jsonpatchTestErr) {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, patchErr), response)
-		} else {
-			writeError(errors.NewInternalError(patchErr), response)
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, patchErr)
 		}
-		return
+		return errors.NewInternalError(patchErr)
 	}
 
-	response.WriteHeader(http.StatusAccepted)
+	return nil
 }
 
 func (app *SubresourceAPIApp) PauseVMIRequestHandler(request *restful.Request, response *restful.Response) {
@@ -307,6 +313,29 @@ func (app *SubresourceAPIApp) PauseVMIRequestHandler(request *restful.Request, r
 	if len(bodyStruct.DryRun) > 0 && bodyStruct.DryRun[0] == metav1.DryRunAll {
 		dryRun = true
 	}
+
+	if bodyStruct.SyncGuest {
+		validatePause := validate
+		validate = func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+			if err := validatePause(vmi); err != nil {
+				return err
+			}
+			condManager := controller.NewVirtualMachineInstanceConditionManager()
+			if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) &&
+				!condManager.HasCondition(vmi, v1.VirtualMachineInstanceAlternativeAgentConnected) {
+				return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("guest agent is required to sync the guest before pausing"))
+			}
+			return nil
+		}
+
+		// The body was consumed while decoding, virt-handler needs the options to quiesce the guest
+		body, err := json.Marshal(bodyStruct)
+		if err != nil {
+			writeError(errors.NewInternalError(err), response)
+			return
+		}
+		request.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
 	app.putRequestHandler(request, response, validate, getURL, dryRun)
 
 }
@@ -428,65 +457,65 @@ func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request,
 			return
 		}
 	}
+
+	recorder := newDryRunRecorder(bodyStruct.DryRun)
+	if statusErr := app.restartVM(name, namespace, bodyStruct, recorder); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+	recorder.writeAccepted(response)
+}
+
+func (app *SubresourceAPIApp) restartVM(name, namespace string, bodyStruct *v1.RestartOptions, recorder *dryRunRecorder) *errors.StatusError {
 	if bodyStruct.GracePeriodSeconds != nil {
 		if *bodyStruct.GracePeriodSeconds > 0 {
-			writeError(errors.NewBadRequest(fmt.Sprintf("For force restart, only gracePeriod=0 is supported for now")), response)
-			return
+			return errors.NewBadRequest(fmt.Sprintf("For force restart, only gracePeriod=0 is supported for now"))
 		} else if *bodyStruct.GracePeriodSeconds < 0 {
-			writeError(errors.NewBadRequest(fmt.Sprintf("gracePeriod has to be greater or equal to 0")), response)
-			return
+			return errors.NewBadRequest(fmt.Sprintf("gracePeriod has to be greater or equal to 0"))
 		}
 	}
 
 	vm, statusErr := app.fetchVirtualMachine(name, namespace)
 	if statusErr != nil {
-		writeError(statusErr, response)
-		return
+		return statusErr
 	}
 	if controller.NewVirtualMachineConditionManager().HasConditionWithStatus(vm,
 		v1.VirtualMachineConditionType(v1.VirtualMachineInstanceVolumesChange), k8sv1.ConditionTrue) {
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf(volumeMigrationManualRecoveryRequiredErr)), response)
-		return
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf(volumeMigrationManualRecoveryRequiredErr))
 	}
 
 	runStrategy, err := vm.RunStrategy()
 	if err != nil {
-		writeError(errors.NewInternalError(err), response)
-		return
+		return errors.NewInternalError(err)
 	}
 	if runStrategy == v1.RunStrategyHalted || runStrategy == v1.RunStrategyOnce {
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("RunStategy %v does not support manual restart requests", runStrategy)), response)
-		return
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("RunStategy %v does not support manual restart requests", runStrategy))
 	}
 
 	vmi, err := app.virtCli.VirtualMachineInstance(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
 		if !errors.IsNotFound(err) {
-			writeError(errors.NewInternalError(err), response)
-			return
+			return errors.NewInternalError(err)
 		}
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is not running: %v", v1.RunStrategyHalted)), response)
-		return
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is not running: %v", v1.RunStrategyHalted))
 	}
 
 	patchBytes, err := getChangeRequestJson(vm,
 		v1.VirtualMachineStateChangeRequest{Action: v1.StopRequest, UID: &vmi.UID},
 		v1.VirtualMachineStateChangeRequest{Action: v1.StartRequest})
 	if err != nil {
-		writeError(errors.NewInternalError(err), response)
-		return
+		return errors.NewInternalError(err)
 	}
 
 	log.Log.Object(vm).V(4).Infof(patchingVMFmt, string(patchBytes))
-	_, err = app.virtCli.VirtualMachine(vm.Namespace).PatchStatus(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
+	patchedVM, err := app.virtCli.VirtualMachine(vm.Namespace).PatchStatus(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
 	if err != nil {
 		if strings.Contains(err.Error(), jsonpatchTestErr) {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, err), response)
-		} else {
-			writeError(errors.NewInternalError(err), response)
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, err)
 		}
-		return
+		return errors.NewInternalError(err)
 	}
+	recorder.record(v1.VirtualMachineGroupVersionKind.Kind, vm.Name, v1.DryRunOperationPatchStatus, patchBytes, patchedVM)
 
 	// Only force restart with GracePeriodSeconds=0 is supported for now
 	// Here we are deleting the Pod because CRDs don't support gracePeriodSeconds at the moment
@@ -494,28 +523,40 @@ func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request,
 		if *bodyStruct.GracePeriodSeconds == 0 {
 			vmiPodname, err := app.findPod(namespace, vmi)
 			if err != nil {
-				writeError(errors.NewInternalError(err), response)
-				return
+				return errors.NewInternalError(err)
 			}
 			if vmiPodname == "" {
-				response.WriteHeader(http.StatusAccepted)
-				return
+				return nil
 			}
 			// set terminationGracePeriod to 1 (which is the shorted safe restart period) and delete the VMI pod to trigger a swift restart.
-			err = app.virtCli.CoreV1().Pods(namespace).Delete(context.Background(), vmiPodname, metav1.DeleteOptions{GracePeriodSeconds: pointer.P(int64(1))})
-			if err != nil {
-				if !errors.IsNotFound(err) {
-					writeError(errors.NewInternalError(err), response)
-					return
-				}
+			err = app.virtCli.CoreV1().Pods(namespace).Delete(context.Background(), vmiPodname, metav1.DeleteOptions{GracePeriodSeconds: pointer.P(int64(1)), DryRun: bodyStruct.DryRun})
+			if err == nil {
+				recorder.record("Pod", vmiPodname, v1.DryRunOperationDelete, nil, nil)
+			} else if !errors.IsNotFound(err) {
+				return errors.NewInternalError(err)
 			}
 		}
 	}
 
-	response.WriteHeader(http.StatusAccepted)
+	return nil
 }
 
 func (app *SubresourceAPIApp) SoftRebootVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	bodyStruct := &v1.SoftRebootOptions{}
+	if request.Request.Body != nil {
+		if err := decodeBody(request, bodyStruct); err != nil {
+			writeError(err, response)
+			return
+		}
+	}
+	switch bodyStruct.Fallback {
+	case "":
+		bodyStruct.Fallback = v1.SoftRebootFallbackACPI
+	case v1.SoftRebootFallbackACPI, v1.SoftRebootFallbackFail:
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("unsupported soft reboot fallback %q, supported values are %s and %s", bodyStruct.Fallback, v1.SoftRebootFallbackACPI, v1.SoftRebootFallbackFail)), response)
+		return
+	}
 
 	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
 		if vmi.Status.Phase != v1.Running {
@@ -526,6 +567,9 @@ func (app *SubresourceAPIApp) SoftRebootVMIRequestHandler(request *restful.Reque
 			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is paused"))
 		}
 		if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+			if bodyStruct.Fallback == v1.SoftRebootFallbackFail {
+				return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not have the agent connected and the ACPI fallback is disabled"))
+			}
 			if features := vmi.Spec.Domain.Features; features != nil && features.ACPI.Enabled != nil && !(*features.ACPI.Enabled) {
 				return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI neither have the agent connected nor the ACPI feature enabled"))
 			}
@@ -537,6 +581,34 @@ func (app *SubresourceAPIApp) SoftRebootVMIRequestHandler(request *restful.Reque
 		return conn.SoftRebootURI(vmi)
 	}
 
+	// The body was consumed while decoding, virt-handler needs the fallback
+	body, err := json.Marshal(bodyStruct)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	request.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	app.putRequestHandler(request, response, validate, getURL, false)
+}
+
+func (app *SubresourceAPIApp) InjectNMIVMIRequestHandler(request *restful.Request, response *restful.Response) {
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf(vmNotRunning))
+		}
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstancePaused, k8sv1.ConditionTrue) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is paused"))
+		}
+		return nil
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.InjectNMIURI(vmi)
+	}
+
 	app.putRequestHandler(request, response, validate, getURL, false)
 }
 
@@ -568,19 +640,22 @@ func (app *SubresourceAPIApp) MigrateVMRequestHandler(request *restful.Request,
 		return
 	}
 
+	recorder := newDryRunRecorder(bodyStruct.DryRun)
 	createMigrationJob := func() *errors.StatusError {
-		_, err := app.virtCli.VirtualMachineInstanceMigration(namespace).Create(context.Background(), &v1.VirtualMachineInstanceMigration{
+		migration, err := app.virtCli.VirtualMachineInstanceMigration(namespace).Create(context.Background(), &v1.VirtualMachineInstanceMigration{
 			ObjectMeta: metav1.ObjectMeta{
 				GenerateName: "kubevirt-migrate-vm-",
 			},
 			Spec: v1.VirtualMachineInstanceMigrationSpec{
-				VMIName:           name,
-				AddedNodeSelector: bodyStruct.AddedNodeSelector,
+				VMIName:             name,
+				AddedNodeSelector:   bodyStruct.AddedNodeSelector,
+				MigrationPolicyName: bodyStruct.MigrationPolicyName,
 			},
 		}, metav1.CreateOptions{DryRun: bodyStruct.DryRun})
 		if err != nil {
 			return errors.NewInternalError(err)
 		}
+		recorder.record(v1.VirtualMachineInstanceMigrationGroupVersionKind.Kind, "", v1.DryRunOperationCreate, nil, migration)
 		return nil
 	}
 
@@ -589,7 +664,7 @@ func (app *SubresourceAPIApp) MigrateVMRequestHandler(request *restful.Request,
 		return
 	}
 
-	response.WriteHeader(http.StatusAccepted)
+	recorder.writeAccepted(response)
 }
 
 func (app *SubresourceAPIApp) findPod(namespace string, vmi *v1.VirtualMachineInstance) (string, error) {
@@ -623,15 +698,17 @@ func (app *SubresourceAPIApp) findPod(namespace string, vmi *v1.VirtualMachineIn
 	return "", nil
 }
 
-func (app *SubresourceAPIApp) patchVMStatusStopped(vmi *v1.VirtualMachineInstance, vm *v1.VirtualMachine, response *restful.Response, bodyStruct *v1.StopOptions) (error, error) {
+func (app *SubresourceAPIApp) patchVMStatusStopped(vmi *v1.VirtualMachineInstance, vm *v1.VirtualMachine, bodyStruct *v1.StopOptions, recorder *dryRunRecorder) (error, error) {
 	patchBytes, err := getChangeRequestJson(vm,
 		v1.VirtualMachineStateChangeRequest{Action: v1.StopRequest, UID: &vmi.UID})
 	if err != nil {
-		writeError(errors.NewInternalError(err), response)
 		return nil, err
 	}
 	log.Log.Object(vm).V(4).Infof(patchingVMStatusFmt, string(patchBytes))
-	_, err = app.virtCli.VirtualMachine(vm.Namespace).PatchStatus(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
+	patchedVM, err := app.virtCli.VirtualMachine(vm.Namespace).PatchStatus(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: bodyStruct.DryRun})
+	if err == nil {
+		recorder.record(v1.VirtualMachineGroupVersionKind.Kind, vm.Name, v1.DryRunOperationPatchStatus, patchBytes, patchedVM)
+	}
 	return err, nil
 }
 
@@ -0,0 +1,278 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-api/definitions"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	// limiterCleanupInterval is the interval in which the request limiters of idle clients are dropped
+	limiterCleanupInterval = time.Minute
+
+	// streamRetryAfterSeconds is suggested to clients which exceeded their concurrent streams
+	streamRetryAfterSeconds = 5
+)
+
+// SubresourceLimiter enforces the per user and per namespace subresource limits configured
+// in the KubeVirt CR. The limits are tracked by each virt-api replica separately.
+type SubresourceLimiter struct {
+	clusterConfig *virtconfig.ClusterConfig
+	authorizor    VirtApiAuthorizor
+	now           func() time.Time
+
+	lock sync.Mutex
+	// config is the configuration the request limiters were created with
+	config      *v1.SubresourceLimitsConfiguration
+	lastCleanup time.Time
+	users       clientLimits
+	namespaces  clientLimits
+}
+
+// clientLimits holds the state of the limits of either all users or all namespaces
+type clientLimits struct {
+	kind     string
+	streams  map[string]uint32
+	limiters map[string]*rate.Limiter
+}
+
+func newClientLimits(kind string) clientLimits {
+	return clientLimits{
+		kind:     kind,
+		streams:  map[string]uint32{},
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+func NewSubresourceLimiter(authorizor VirtApiAuthorizor, clusterConfig *virtconfig.ClusterConfig) *SubresourceLimiter {
+	return &SubresourceLimiter{
+		clusterConfig: clusterConfig,
+		authorizor:    authorizor,
+		now:           time.Now,
+		users:         newClientLimits("user"),
+		namespaces:    newClientLimits("namespace"),
+	}
+}
+
+// WithSubresourceLimiter enables limiting the streams opened by the clients of virt-api
+func (app *SubresourceAPIApp) WithSubresourceLimiter(limiter *SubresourceLimiter) *SubresourceAPIApp {
+	app.subresourceLimiter = limiter
+	return app
+}
+
+// Filter rejects the namespaced subresource requests which exceed the request rate of their
+// user or namespace with 429 Too Many Requests.
+func (l *SubresourceLimiter) Filter(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+	namespace := request.PathParameter(definitions.NamespaceParamName)
+	if namespace == "" {
+		chain.ProcessFilter(request, response)
+		return
+	}
+
+	if statusErr := l.allowRequest(l.userName(request), namespace); statusErr != nil {
+		writeTooManyRequests(statusErr, response)
+		return
+	}
+	chain.ProcessFilter(request, response)
+}
+
+// acquireStream reserves a stream for the user and the namespace of the request. The returned
+// function has to be called once the stream is closed.
+func (l *SubresourceLimiter) acquireStream(request *restful.Request) (func(), *errors.StatusError) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	user := l.userName(request)
+	namespace := request.PathParameter(definitions.NamespaceParamName)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	config := l.syncConfig()
+	if statusErr := l.users.checkStreams(user, config.PerUser); statusErr != nil {
+		return nil, statusErr
+	}
+	if statusErr := l.namespaces.checkStreams(namespace, config.PerNamespace); statusErr != nil {
+		return nil, statusErr
+	}
+	l.users.addStream(user)
+	l.namespaces.addStream(namespace)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.lock.Lock()
+			defer l.lock.Unlock()
+			l.users.removeStream(user)
+			l.namespaces.removeStream(namespace)
+		})
+	}, nil
+}
+
+func (l *SubresourceLimiter) allowRequest(user, namespace string) *errors.StatusError {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	config := l.syncConfig()
+	now := l.now()
+	if now.Sub(l.lastCleanup) > limiterCleanupInterval {
+		l.users.dropIdleLimiters(now)
+		l.namespaces.dropIdleLimiters(now)
+		l.lastCleanup = now
+	}
+
+	// Reserve the tokens of both limiters before allowing the request, so that a request
+	// rejected by the namespace does not consume the tokens of the user and vice versa
+	userReservation := l.users.reserve(user, config.PerUser, now)
+	if delay := userReservation.delay(now); delay > 0 {
+		userReservation.cancel(now)
+		return tooManyRequests(fmt.Sprintf("subresource request rate of user %s exceeded", user), delay)
+	}
+	namespaceReservation := l.namespaces.reserve(namespace, config.PerNamespace, now)
+	if delay := namespaceReservation.delay(now); delay > 0 {
+		userReservation.cancel(now)
+		namespaceReservation.cancel(now)
+		return tooManyRequests(fmt.Sprintf("subresource request rate of namespace %s exceeded", namespace), delay)
+	}
+	return nil
+}
+
+// syncConfig returns the current limits and drops the request limiters if they changed
+func (l *SubresourceLimiter) syncConfig() *v1.SubresourceLimitsConfiguration {
+	config := l.clusterConfig.GetConfig().SubresourceLimits
+	if config == nil {
+		config = &v1.SubresourceLimitsConfiguration{}
+	}
+	if !equality.Semantic.DeepEqual(config, l.config) {
+		l.config = config.DeepCopy()
+		l.users.limiters = map[string]*rate.Limiter{}
+		l.namespaces.limiters = map[string]*rate.Limiter{}
+	}
+	return l.config
+}
+
+// userName returns the user of the request or an empty string if it can not be determined,
+// e.g. for requests authorized by a subresource token, which are only limited per namespace
+func (l *SubresourceLimiter) userName(request *restful.Request) string {
+	user, err := getUserName(request.Request.Header, l.authorizor.GetUserHeaders())
+	if err != nil {
+		return ""
+	}
+	return user
+}
+
+func (c *clientLimits) checkStreams(key string, limits *v1.SubresourceClientLimits) *errors.StatusError {
+	if key == "" || limits == nil || limits.MaxConcurrentStreams == nil {
+		return nil
+	}
+	if c.streams[key] >= *limits.MaxConcurrentStreams {
+		return tooManyRequests(
+			fmt.Sprintf("maximum of %d concurrent streams of %s %s reached", *limits.MaxConcurrentStreams, c.kind, key),
+			streamRetryAfterSeconds*time.Second,
+		)
+	}
+	return nil
+}
+
+func (c *clientLimits) addStream(key string) {
+	if key != "" {
+		c.streams[key]++
+	}
+}
+
+func (c *clientLimits) removeStream(key string) {
+	if key == "" {
+		return
+	}
+	if c.streams[key] <= 1 {
+		delete(c.streams, key)
+		return
+	}
+	c.streams[key]--
+}
+
+func (c *clientLimits) reserve(key string, limits *v1.SubresourceClientLimits, now time.Time) *limiterReservation {
+	if key == "" || limits == nil || limits.RequestsPerSecond == nil {
+		return nil
+	}
+	limiter, exists := c.limiters[key]
+	if !exists {
+		burst := *limits.RequestsPerSecond
+		if limits.Burst != nil {
+			burst = *limits.Burst
+		}
+		limiter = rate.NewLimiter(rate.Limit(*limits.RequestsPerSecond), max(int(burst), 1))
+		c.limiters[key] = limiter
+	}
+	return &limiterReservation{Reservation: limiter.ReserveN(now, 1)}
+}
+
+// dropIdleLimiters drops the limiters which are full again, they behave like new ones
+func (c *clientLimits) dropIdleLimiters(now time.Time) {
+	for key, limiter := range c.limiters {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(c.limiters, key)
+		}
+	}
+}
+
+// limiterReservation allows treating requests which are not limited like admitted ones
+type limiterReservation struct {
+	*rate.Reservation
+}
+
+func (r *limiterReservation) delay(now time.Time) time.Duration {
+	if r == nil {
+		return 0
+	}
+	return r.DelayFrom(now)
+}
+
+func (r *limiterReservation) cancel(now time.Time) {
+	if r != nil {
+		r.CancelAt(now)
+	}
+}
+
+func tooManyRequests(msg string, retryAfter time.Duration) *errors.StatusError {
+	return errors.NewTooManyRequests(msg, int(math.Ceil(retryAfter.Seconds())))
+}
+
+func writeTooManyRequests(statusErr *errors.StatusError, response *restful.Response) {
+	if details := statusErr.ErrStatus.Details; details != nil && details.RetryAfterSeconds > 0 {
+		response.AddHeader("Retry-After", strconv.Itoa(int(details.RetryAfterSeconds)))
+	}
+	writeError(statusErr, response)
+}
@@ -0,0 +1,256 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Subresource limiter", func() {
+	var (
+		kvStore cache.Store
+		kv      *v1.KubeVirt
+		limiter *SubresourceLimiter
+		now     time.Time
+	)
+
+	newRequest := func(namespace, user string) *restful.Request {
+		httpRequest := &http.Request{URL: &url.URL{}, Header: http.Header{}}
+		if user != "" {
+			httpRequest.Header.Set(userHeader, user)
+		}
+		request := restful.NewRequest(httpRequest)
+		if namespace != "" {
+			request.PathParameters()["namespace"] = namespace
+		}
+		return request
+	}
+
+	filter := func(namespace, user string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		response := restful.NewResponse(recorder)
+		response.SetRequestAccepts(restful.MIME_JSON)
+		chain := &restful.FilterChain{Target: func(_ *restful.Request, response *restful.Response) {
+			response.WriteHeader(http.StatusOK)
+		}}
+		limiter.Filter(newRequest(namespace, user), response, chain)
+		return recorder
+	}
+
+	setLimits := func(limits *v1.SubresourceLimitsConfiguration) {
+		newKV := kv.DeepCopy()
+		newKV.Spec.Configuration.SubresourceLimits = limits
+		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, newKV)
+	}
+
+	BeforeEach(func() {
+		kv = &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+			Status:     v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeployed},
+		}
+		config, _, store := testutils.NewFakeClusterConfigUsingKV(kv)
+		kvStore = store
+
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		limiter = NewSubresourceLimiter(NewAuthorizorFromClient(nil), config)
+		limiter.now = func() time.Time {
+			return now
+		}
+	})
+
+	It("should not limit requests and streams if no limits are configured", func() {
+		for i := 0; i < 100; i++ {
+			Expect(filter(metav1.NamespaceDefault, "alice").Code).To(Equal(http.StatusOK))
+			_, statusErr := limiter.acquireStream(newRequest(metav1.NamespaceDefault, "alice"))
+			Expect(statusErr).ToNot(HaveOccurred())
+		}
+	})
+
+	It("should not limit streams without a limiter", func() {
+		var noLimiter *SubresourceLimiter
+		release, statusErr := noLimiter.acquireStream(newRequest(metav1.NamespaceDefault, "alice"))
+		Expect(statusErr).ToNot(HaveOccurred())
+		release()
+	})
+
+	Context("with concurrent streams limits", func() {
+		It("should limit the streams per user", func() {
+			setLimits(&v1.SubresourceLimitsConfiguration{
+				PerUser: &v1.SubresourceClientLimits{MaxConcurrentStreams: pointer.P(uint32(2))},
+			})
+
+			release, statusErr := limiter.acquireStream(newRequest(metav1.NamespaceDefault, "alice"))
+			Expect(statusErr).ToNot(HaveOccurred())
+			_, statusErr = limiter.acquireStream(newRequest("other", "alice"))
+			Expect(statusErr).ToNot(HaveOccurred())
+
+			_, statusErr = limiter.acquireStream(newRequest(metav1.NamespaceDefault, "alice"))
+			Expect(statusErr).To(MatchError("maximum of 2 concurrent streams of user alice reached"))
+			Expect(statusErr.ErrStatus.Code).To(Equal(int32(http.StatusTooManyRequests)))
+			Expect(statusErr.ErrStatus.Details.RetryAfterSeconds).To(Equal(int32(streamRetryAfterSeconds)))
+
+			By("not limiting other users")
+			_, statusErr = limiter.acquireStream(newRequest(metav1.NamespaceDefault, "bob"))
+			Expect(statusErr).ToNot(HaveOccurred())
+
+			By("releasing a stream only once")
+			release()
+			release()
+			_, statusErr = limiter.acquireStream(newRequest(metav1.NamespaceDefault, "alice"))
+			Expect(statusErr).ToNot(HaveOccurred())
+			_, statusErr = limiter.acquireStream(newRequest(metav1.NamespaceDefault, "alice"))
+			Expect(statusErr).To(HaveOccurred())
+		})
+
+		It("should limit the streams per namespace", func() {
+			setLimits(&v1.SubresourceLimitsConfiguration{
+				PerNamespace: &v1.SubresourceClientLimits{MaxConcurrentStreams: pointer.P(uint32(1))},
+			})
+
+			release, statusErr := limiter.acquireStream(newRequest(metav1.NamespaceDefault, "alice"))
+			Expect(statusErr).ToNot(HaveOccurred())
+			_, statusErr = limiter.acquireStream(newRequest(metav1.NamespaceDefault, "bob"))
+			Expect(statusErr).To(MatchError("maximum of 1 concurrent streams of namespace default reached"))
+			_, statusErr = limiter.acquireStream(newRequest("other", "bob"))
+			Expect(statusErr).ToNot(HaveOccurred())
+
+			release()
+			_, statusErr = limiter.acquireStream(newRequest(metav1.NamespaceDefault, "bob"))
+			Expect(statusErr).ToNot(HaveOccurred())
+		})
+
+		It("should not count a stream rejected by the namespace against the user", func() {
+			setLimits(&v1.SubresourceLimitsConfiguration{
+				PerUser:      &v1.SubresourceClientLimits{MaxConcurrentStreams: pointer.P(uint32(1))},
+				PerNamespace: &v1.SubresourceClientLimits{MaxConcurrentStreams: pointer.P(uint32(1))},
+			})
+
+			_, statusErr := limiter.acquireStream(newRequest(metav1.NamespaceDefault, "bob"))
+			Expect(statusErr).ToNot(HaveOccurred())
+			_, statusErr = limiter.acquireStream(newRequest(metav1.NamespaceDefault, "alice"))
+			Expect(statusErr).To(HaveOccurred())
+			_, statusErr = limiter.acquireStream(newRequest("other", "alice"))
+			Expect(statusErr).ToNot(HaveOccurred())
+		})
+
+		It("should only limit requests without a user per namespace", func() {
+			setLimits(&v1.SubresourceLimitsConfiguration{
+				PerUser: &v1.SubresourceClientLimits{MaxConcurrentStreams: pointer.P(uint32(1))},
+			})
+
+			for i := 0; i < 3; i++ {
+				_, statusErr := limiter.acquireStream(newRequest(metav1.NamespaceDefault, ""))
+				Expect(statusErr).ToNot(HaveOccurred())
+			}
+		})
+	})
+
+	Context("with request rate limits", func() {
+		It("should limit the request rate per user and allow a burst", func() {
+			setLimits(&v1.SubresourceLimitsConfiguration{
+				PerUser: &v1.SubresourceClientLimits{RequestsPerSecond: pointer.P(uint32(1)), Burst: pointer.P(uint32(2))},
+			})
+
+			Expect(filter(metav1.NamespaceDefault, "alice").Code).To(Equal(http.StatusOK))
+			Expect(filter(metav1.NamespaceDefault, "alice").Code).To(Equal(http.StatusOK))
+			recorder := filter(metav1.NamespaceDefault, "alice")
+			Expect(recorder.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(recorder.Header().Get("Retry-After")).To(Equal("1"))
+			Expect(recorder.Body.String()).To(ContainSubstring("subresource request rate of user alice exceeded"))
+
+			By("not limiting other users")
+			Expect(filter(metav1.NamespaceDefault, "bob").Code).To(Equal(http.StatusOK))
+
+			By("refilling the tokens over time")
+			now = now.Add(time.Second)
+			Expect(filter(metav1.NamespaceDefault, "alice").Code).To(Equal(http.StatusOK))
+			Expect(filter(metav1.NamespaceDefault, "alice").Code).To(Equal(http.StatusTooManyRequests))
+		})
+
+		It("should limit the request rate per namespace without consuming the tokens of the user", func() {
+			setLimits(&v1.SubresourceLimitsConfiguration{
+				PerUser:      &v1.SubresourceClientLimits{RequestsPerSecond: pointer.P(uint32(1))},
+				PerNamespace: &v1.SubresourceClientLimits{RequestsPerSecond: pointer.P(uint32(1))},
+			})
+
+			Expect(filter(metav1.NamespaceDefault, "bob").Code).To(Equal(http.StatusOK))
+			recorder := filter(metav1.NamespaceDefault, "alice")
+			Expect(recorder.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(recorder.Body.String()).To(ContainSubstring("subresource request rate of namespace default exceeded"))
+			Expect(filter("other", "alice").Code).To(Equal(http.StatusOK))
+		})
+
+		It("should not limit requests which are not namespaced", func() {
+			setLimits(&v1.SubresourceLimitsConfiguration{
+				PerUser: &v1.SubresourceClientLimits{RequestsPerSecond: pointer.P(uint32(1))},
+			})
+
+			for i := 0; i < 3; i++ {
+				Expect(filter("", "alice").Code).To(Equal(http.StatusOK))
+			}
+		})
+
+		It("should reset the limiters when the limits change", func() {
+			setLimits(&v1.SubresourceLimitsConfiguration{
+				PerUser: &v1.SubresourceClientLimits{RequestsPerSecond: pointer.P(uint32(1))},
+			})
+			Expect(filter(metav1.NamespaceDefault, "alice").Code).To(Equal(http.StatusOK))
+			Expect(filter(metav1.NamespaceDefault, "alice").Code).To(Equal(http.StatusTooManyRequests))
+
+			setLimits(&v1.SubresourceLimitsConfiguration{
+				PerUser: &v1.SubresourceClientLimits{RequestsPerSecond: pointer.P(uint32(2))},
+			})
+			Expect(filter(metav1.NamespaceDefault, "alice").Code).To(Equal(http.StatusOK))
+			Expect(filter(metav1.NamespaceDefault, "alice").Code).To(Equal(http.StatusOK))
+			Expect(filter(metav1.NamespaceDefault, "alice").Code).To(Equal(http.StatusTooManyRequests))
+		})
+
+		It("should drop the limiters of idle clients", func() {
+			setLimits(&v1.SubresourceLimitsConfiguration{
+				PerUser: &v1.SubresourceClientLimits{RequestsPerSecond: pointer.P(uint32(1)), Burst: pointer.P(uint32(5))},
+			})
+			Expect(filter(metav1.NamespaceDefault, "alice").Code).To(Equal(http.StatusOK))
+			for i := 0; i < 5; i++ {
+				filter(metav1.NamespaceDefault, "bob")
+			}
+			Expect(limiter.users.limiters).To(HaveLen(2))
+
+			now = now.Add(limiterCleanupInterval + time.Second)
+			filter(metav1.NamespaceDefault, "bob")
+			Expect(limiter.users.limiters).To(HaveLen(1))
+			Expect(limiter.users.limiters).To(HaveKey("bob"))
+		})
+	})
+})
@@ -42,7 +42,7 @@ func (app *SubresourceAPIApp) PortForwardRequestHandler(fetcher vmiFetcher) rest
 			fetcher,
 			validateVMIForPortForward,
 			netDial{request: request},
-		)
+		).WithSessions(app.subresourceSessions, "portforward").WithLimiter(app.subresourceLimiter)
 
 		streamer.Handle(request, response)
 	}
@@ -0,0 +1,387 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package rest
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/emicklei/go-restful/v3"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/network/multus"
+	"kubevirt.io/kubevirt/pkg/util/nodes"
+)
+
+// VMPreflightRequestHandler simulates the start of a VirtualMachine. It checks whether the
+// resources the VirtualMachineInstance depends on are available and reports the outcome of
+// every check without creating the VirtualMachineInstance.
+func (app *SubresourceAPIApp) VMPreflightRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	expandedVM, err := app.instancetypeExpander.Expand(vm)
+	if err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("failed to expand the VirtualMachine spec: %v", err)), response)
+		return
+	}
+
+	p := &preflight{
+		ctx:       request.Request.Context(),
+		app:       app,
+		vm:        expandedVM,
+		namespace: namespace,
+		nodes:     app.schedulableNodes(&expandedVM.Spec.Template.Spec),
+	}
+	report := p.run()
+
+	if err := response.WriteEntity(report); err != nil {
+		log.Log.Reason(err).Error("Failed to write http response.")
+	}
+}
+
+// WithNodeStore sets the node informer store the preflight checks run against
+func (app *SubresourceAPIApp) WithNodeStore(nodeStore cache.Store) *SubresourceAPIApp {
+	app.nodeStore = nodeStore
+	return app
+}
+
+// schedulableNodes returns the nodes virt-handler marked schedulable which match the node selector,
+// the required node affinity and the tolerations of the VMI
+func (app *SubresourceAPIApp) schedulableNodes(spec *v1.VirtualMachineInstanceSpec) []*k8sv1.Node {
+	if app.nodeStore == nil {
+		return nil
+	}
+	var schedulable []*k8sv1.Node
+	for _, obj := range app.nodeStore.List() {
+		node, ok := obj.(*k8sv1.Node)
+		if !ok || node.Labels[v1.NodeSchedulable] != "true" {
+			continue
+		}
+		if nodes.IsSchedulable(node, spec.NodeSelector, spec.Affinity, spec.Tolerations) {
+			schedulable = append(schedulable, node)
+		}
+	}
+	return schedulable
+}
+
+type preflight struct {
+	ctx       context.Context
+	app       *SubresourceAPIApp
+	vm        *v1.VirtualMachine
+	namespace string
+	nodes     []*k8sv1.Node
+	checks    []v1.VirtualMachinePreflightCheck
+}
+
+func (p *preflight) run() *v1.VirtualMachinePreflightReport {
+	spec := &p.vm.Spec.Template.Spec
+	p.checkImages(spec)
+	p.checkStorage(spec)
+	p.checkDevices(spec)
+	p.checkCompute(spec)
+	p.checkNetworks(spec)
+
+	report := &v1.VirtualMachinePreflightReport{Ready: true, Checks: p.checks}
+	for _, check := range p.checks {
+		if check.Status == v1.PreflightCheckFailed {
+			report.Ready = false
+		}
+	}
+	return report
+}
+
+func (p *preflight) report(category v1.VirtualMachinePreflightCheckCategory, name string, status v1.VirtualMachinePreflightCheckStatus, format string, args ...interface{}) {
+	p.checks = append(p.checks, v1.VirtualMachinePreflightCheck{
+		Category: category,
+		Name:     name,
+		Status:   status,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// checkImages verifies that container images are set. Whether an image can be pulled is only
+// known once the node pulls it, so images which are not cached on any node are reported as warning.
+func (p *preflight) checkImages(spec *v1.VirtualMachineInstanceSpec) {
+	for _, volume := range spec.Volumes {
+		if volume.ContainerDisk != nil {
+			p.checkImage(volume.Name, volume.ContainerDisk.Image)
+		}
+	}
+	if spec.Domain.Firmware != nil && spec.Domain.Firmware.KernelBoot != nil && spec.Domain.Firmware.KernelBoot.Container != nil {
+		p.checkImage("kernelboot", spec.Domain.Firmware.KernelBoot.Container.Image)
+	}
+}
+
+func (p *preflight) checkImage(name, image string) {
+	if image == "" {
+		p.report(v1.PreflightCheckCategoryImage, name, v1.PreflightCheckFailed, "no image is set")
+		return
+	}
+	cached := 0
+	for _, node := range p.nodes {
+		if nodeHasImage(node, image) {
+			cached++
+		}
+	}
+	if cached == 0 {
+		p.report(v1.PreflightCheckCategoryImage, name, v1.PreflightCheckWarning,
+			"image %s is not present on any schedulable node, whether it can be pulled is only known once the VM starts", image)
+		return
+	}
+	p.report(v1.PreflightCheckCategoryImage, name, v1.PreflightCheckPassed, "image %s is present on %d schedulable node(s)", image, cached)
+}
+
+func nodeHasImage(node *k8sv1.Node, image string) bool {
+	for _, nodeImage := range node.Status.Images {
+		for _, name := range nodeImage.Names {
+			if name == image || strings.TrimPrefix(name, "docker.io/") == image || strings.TrimPrefix(name, "docker.io/library/") == image {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkStorage verifies that the PVCs and DataVolumes used by the VM exist and are, or will be, bound.
+func (p *preflight) checkStorage(spec *v1.VirtualMachineInstanceSpec) {
+	for _, volume := range spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			p.checkPVC(volume.Name, volume.PersistentVolumeClaim.ClaimName)
+		case volume.DataVolume != nil:
+			p.checkDataVolume(volume.Name, volume.DataVolume.Name)
+		}
+	}
+}
+
+func (p *preflight) checkPVC(volumeName, claimName string) {
+	pvc, err := p.app.virtCli.CoreV1().PersistentVolumeClaims(p.namespace).Get(p.ctx, claimName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		p.report(v1.PreflightCheckCategoryStorage, volumeName, v1.PreflightCheckFailed, "PVC %s does not exist", claimName)
+		return
+	} else if err != nil {
+		p.report(v1.PreflightCheckCategoryStorage, volumeName, v1.PreflightCheckWarning, "failed to get PVC %s: %v", claimName, err)
+		return
+	}
+	p.reportPVCPhase(volumeName, pvc)
+}
+
+func (p *preflight) reportPVCPhase(volumeName string, pvc *k8sv1.PersistentVolumeClaim) {
+	switch pvc.Status.Phase {
+	case k8sv1.ClaimBound:
+		p.report(v1.PreflightCheckCategoryStorage, volumeName, v1.PreflightCheckPassed, "PVC %s is bound", pvc.Name)
+	case k8sv1.ClaimLost:
+		p.report(v1.PreflightCheckCategoryStorage, volumeName, v1.PreflightCheckFailed, "PVC %s lost its persistent volume", pvc.Name)
+	default:
+		p.report(v1.PreflightCheckCategoryStorage, volumeName, v1.PreflightCheckWarning,
+			"PVC %s is not bound yet, it is bound once the VM is scheduled if its storage class uses the WaitForFirstConsumer binding mode", pvc.Name)
+	}
+}
+
+func (p *preflight) checkDataVolume(volumeName, dvName string) {
+	pvc, err := p.app.virtCli.CoreV1().PersistentVolumeClaims(p.namespace).Get(p.ctx, dvName, metav1.GetOptions{})
+	if err == nil && pvc.Status.Phase != k8sv1.ClaimPending {
+		p.reportPVCPhase(volumeName, pvc)
+		return
+	}
+
+	dv, err := p.app.virtCli.CdiClient().CdiV1beta1().DataVolumes(p.namespace).Get(p.ctx, dvName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if p.hasDataVolumeTemplate(dvName) {
+			p.report(v1.PreflightCheckCategoryStorage, volumeName, v1.PreflightCheckPassed, "DataVolume %s is created from its template when the VM starts", dvName)
+			return
+		}
+		p.report(v1.PreflightCheckCategoryStorage, volumeName, v1.PreflightCheckFailed, "DataVolume %s does not exist", dvName)
+		return
+	} else if err != nil {
+		p.report(v1.PreflightCheckCategoryStorage, volumeName, v1.PreflightCheckWarning, "failed to get DataVolume %s: %v", dvName, err)
+		return
+	}
+
+	switch dv.Status.Phase {
+	case cdiv1.Succeeded, cdiv1.WaitForFirstConsumer, cdiv1.PendingPopulation:
+		p.report(v1.PreflightCheckCategoryStorage, volumeName, v1.PreflightCheckPassed, "DataVolume %s is in phase %s", dvName, dv.Status.Phase)
+	case cdiv1.Failed:
+		p.report(v1.PreflightCheckCategoryStorage, volumeName, v1.PreflightCheckFailed, "DataVolume %s failed", dvName)
+	default:
+		p.report(v1.PreflightCheckCategoryStorage, volumeName, v1.PreflightCheckWarning,
+			"DataVolume %s is in phase %s, the VM starts once it succeeded", dvName, dv.Status.Phase)
+	}
+}
+
+func (p *preflight) hasDataVolumeTemplate(name string) bool {
+	for _, template := range p.vm.Spec.DataVolumeTemplates {
+		if template.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDevices verifies that the GPUs and host devices requested by the VM are allocatable on a schedulable node.
+func (p *preflight) checkDevices(spec *v1.VirtualMachineInstanceSpec) {
+	for _, gpu := range spec.Domain.Devices.GPUs {
+		if gpu.DeviceName != "" {
+			p.checkDeviceResource(v1.PreflightCheckCategoryDevice, gpu.Name, gpu.DeviceName, countDevices(spec, gpu.DeviceName))
+		}
+	}
+	for _, hostDevice := range spec.Domain.Devices.HostDevices {
+		if hostDevice.DeviceName != "" {
+			p.checkDeviceResource(v1.PreflightCheckCategoryDevice, hostDevice.Name, hostDevice.DeviceName, countDevices(spec, hostDevice.DeviceName))
+		}
+	}
+}
+
+func countDevices(spec *v1.VirtualMachineInstanceSpec, deviceName string) int64 {
+	var count int64
+	for _, gpu := range spec.Domain.Devices.GPUs {
+		if gpu.DeviceName == deviceName {
+			count++
+		}
+	}
+	for _, hostDevice := range spec.Domain.Devices.HostDevices {
+		if hostDevice.DeviceName == deviceName {
+			count++
+		}
+	}
+	return count
+}
+
+func (p *preflight) checkDeviceResource(category v1.VirtualMachinePreflightCheckCategory, name, resourceName string, count int64) {
+	matching := 0
+	for _, node := range p.nodes {
+		if allocatable, ok := node.Status.Allocatable[k8sv1.ResourceName(resourceName)]; ok && allocatable.Cmp(*resource.NewQuantity(count, resource.DecimalSI)) >= 0 {
+			matching++
+		}
+	}
+	if matching == 0 {
+		p.report(category, name, v1.PreflightCheckFailed, "no schedulable node has %d %s allocatable", count, resourceName)
+		return
+	}
+	p.report(category, name, v1.PreflightCheckPassed, "%s is allocatable on %d schedulable node(s)", resourceName, matching)
+}
+
+// checkCompute verifies the machine type against the cluster configuration, that the VM can be scheduled
+// on a node and that a schedulable node provides the CPU model, CPU features and machine type requested by the VM.
+func (p *preflight) checkCompute(spec *v1.VirtualMachineInstanceSpec) {
+	if spec.Domain.Machine != nil && spec.Domain.Machine.Type != "" {
+		supportedMachines := p.app.clusterConfig.GetEmulatedMachines(spec.Architecture)
+		supported := slices.ContainsFunc(supportedMachines, func(pattern string) bool {
+			match, _ := filepath.Match(pattern, spec.Domain.Machine.Type)
+			return match
+		})
+		if !supported {
+			p.report(v1.PreflightCheckCategoryCompute, "machine", v1.PreflightCheckFailed,
+				"machine type %s is not supported by the cluster (allowed values: %v)", spec.Domain.Machine.Type, supportedMachines)
+			return
+		}
+	}
+
+	if len(p.nodes) == 0 {
+		p.report(v1.PreflightCheckCategoryCompute, "scheduling", v1.PreflightCheckFailed,
+			"no schedulable node matches the node selector, affinity and tolerations of the VM")
+		return
+	}
+
+	selector := requiredNodeLabels(spec)
+	matching := 0
+	for _, node := range p.nodes {
+		if nodeHasLabels(node, selector) {
+			matching++
+		}
+	}
+	if matching == 0 {
+		p.report(v1.PreflightCheckCategoryCompute, "cpu", v1.PreflightCheckFailed, "no schedulable node has the labels %v", selector)
+		return
+	}
+	p.report(v1.PreflightCheckCategoryCompute, "cpu", v1.PreflightCheckPassed, "%d schedulable node(s) provide the requested CPU and machine type", matching)
+}
+
+func requiredNodeLabels(spec *v1.VirtualMachineInstanceSpec) map[string]string {
+	labels := map[string]string{}
+	if cpu := spec.Domain.CPU; cpu != nil {
+		if cpu.Model != "" && cpu.Model != v1.CPUModeHostModel && cpu.Model != v1.CPUModeHostPassthrough {
+			labels[v1.CPUModelLabel+cpu.Model] = "true"
+		}
+		for _, feature := range cpu.Features {
+			if feature.Policy == "" || feature.Policy == "require" {
+				labels[v1.CPUFeatureLabel+feature.Name] = "true"
+			}
+		}
+		if cpu.DedicatedCPUPlacement {
+			labels[v1.CPUManager] = "true"
+		}
+	}
+	if spec.Domain.Machine != nil && spec.Domain.Machine.Type != "" {
+		labels[v1.SupportedMachineTypeLabel+spec.Domain.Machine.Type] = "true"
+	}
+	return labels
+}
+
+func nodeHasLabels(node *k8sv1.Node, labels map[string]string) bool {
+	for key, value := range labels {
+		if node.Labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// checkNetworks verifies that the network attachment definitions used by the VM exist and that the
+// resources they require are allocatable on a schedulable node.
+func (p *preflight) checkNetworks(spec *v1.VirtualMachineInstanceSpec) {
+	for _, network := range spec.Networks {
+		if network.Multus == nil {
+			continue
+		}
+		nadName := multus.NetAttachDefNamespacedName(p.namespace, network.Multus.NetworkName)
+		nad, err := p.app.virtCli.NetworkClient().K8sCniCncfIoV1().NetworkAttachmentDefinitions(nadName.Namespace).Get(p.ctx, nadName.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			p.report(v1.PreflightCheckCategoryNetwork, network.Name, v1.PreflightCheckFailed, "network attachment definition %s does not exist", nadName)
+			continue
+		} else if err != nil {
+			p.report(v1.PreflightCheckCategoryNetwork, network.Name, v1.PreflightCheckWarning, "failed to get network attachment definition %s: %v", nadName, err)
+			continue
+		}
+		if resourceName := nad.Annotations[multus.ResourceNameAnnotation]; resourceName != "" {
+			p.checkDeviceResource(v1.PreflightCheckCategoryNetwork, network.Name, resourceName, 1)
+			continue
+		}
+		p.report(v1.PreflightCheckCategoryNetwork, network.Name, v1.PreflightCheckPassed, "network attachment definition %s exists", nadName)
+	}
+}
@@ -0,0 +1,330 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package rest
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful/v3"
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+	cdifake "kubevirt.io/client-go/containerizeddataimporter/fake"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+	fakenetworkclient "kubevirt.io/client-go/networkattachmentdefinitionclient/fake"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/network/multus"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("VM preflight", func() {
+	const (
+		image    = "quay.io/kubevirt/fedora:latest"
+		nodeName = "node01"
+	)
+
+	var (
+		kubeClient    *fake.Clientset
+		virtClient    *kubevirtfake.Clientset
+		cdiClient     *cdifake.Clientset
+		networkClient *fakenetworkclient.Clientset
+		nodeStore     cache.Store
+		app           *SubresourceAPIApp
+	)
+
+	newNode := func() *k8sv1.Node {
+		return &k8sv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName,
+				Labels: map[string]string{
+					v1.NodeSchedulable:                       "true",
+					v1.CPUModelLabel + "Skylake":             "true",
+					v1.CPUFeatureLabel + "vmx":               "true",
+					v1.SupportedMachineTypeLabel + "q35":     "true",
+					v1.SupportedMachineTypeLabel + "pc-q35-": "true",
+				},
+			},
+			Status: k8sv1.NodeStatus{
+				Allocatable: k8sv1.ResourceList{
+					"nvidia.com/GV100GL": resource.MustParse("1"),
+				},
+				Images: []k8sv1.ContainerImage{{Names: []string{image}}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kvClient := kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fake.NewClientset()
+		virtClient = kubevirtfake.NewSimpleClientset()
+		cdiClient = cdifake.NewSimpleClientset()
+		networkClient = fakenetworkclient.NewSimpleClientset()
+
+		kvClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		kvClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+		kvClient.EXPECT().NetworkClient().Return(networkClient).AnyTimes()
+		kvClient.EXPECT().VirtualMachine(metav1.NamespaceDefault).Return(virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault)).AnyTimes()
+
+		config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			ArchitectureConfiguration: &v1.ArchConfiguration{
+				Amd64: &v1.ArchSpecificConfiguration{EmulatedMachines: []string{"q35*", "pc-q35*"}},
+			},
+		})
+		nodeStore = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		app = NewSubresourceAPIApp(kvClient, 0, &tls.Config{InsecureSkipVerify: true}, config).WithNodeStore(nodeStore)
+	})
+
+	createVM := func(opts ...libvmi.Option) *v1.VirtualMachine {
+		opts = append([]libvmi.Option{libvmi.WithName(testVMName), libvmi.WithNamespace(metav1.NamespaceDefault)}, opts...)
+		vm := libvmi.NewVirtualMachine(libvmi.New(opts...))
+		vm, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), vm, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return vm
+	}
+
+	create := func(obj runtime.Object) {
+		switch o := obj.(type) {
+		case *k8sv1.Node:
+			Expect(nodeStore.Add(o)).To(Succeed())
+		case *k8sv1.PersistentVolumeClaim:
+			_, err := kubeClient.CoreV1().PersistentVolumeClaims(metav1.NamespaceDefault).Create(context.Background(), o, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		case *cdiv1.DataVolume:
+			_, err := cdiClient.CdiV1beta1().DataVolumes(metav1.NamespaceDefault).Create(context.Background(), o, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		case *networkv1.NetworkAttachmentDefinition:
+			_, err := networkClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(metav1.NamespaceDefault).Create(context.Background(), o, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+	}
+
+	preflight := func() *v1.VirtualMachinePreflightReport {
+		request := restful.NewRequest(&http.Request{})
+		request.PathParameters()["name"] = testVMName
+		request.PathParameters()["namespace"] = metav1.NamespaceDefault
+		recorder := httptest.NewRecorder()
+		response := restful.NewResponse(recorder)
+		response.SetRequestAccepts(restful.MIME_JSON)
+
+		app.VMPreflightRequestHandler(request, response)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		report := &v1.VirtualMachinePreflightReport{}
+		Expect(json.NewDecoder(recorder.Body).Decode(report)).To(Succeed())
+		return report
+	}
+
+	check := func(category v1.VirtualMachinePreflightCheckCategory, name string, status v1.VirtualMachinePreflightCheckStatus) interface{} {
+		return And(
+			HaveField("Category", category),
+			HaveField("Name", name),
+			HaveField("Status", status),
+		)
+	}
+
+	It("should return NotFound if the VM does not exist", func() {
+		request := restful.NewRequest(&http.Request{})
+		request.PathParameters()["name"] = testVMName
+		request.PathParameters()["namespace"] = metav1.NamespaceDefault
+		recorder := httptest.NewRecorder()
+		app.VMPreflightRequestHandler(request, restful.NewResponse(recorder))
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should be ready if all resources the VM depends on are available", func() {
+		create(newNode())
+		create(&k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc", Namespace: metav1.NamespaceDefault},
+			Status:     k8sv1.PersistentVolumeClaimStatus{Phase: k8sv1.ClaimBound},
+		})
+		create(&networkv1.NetworkAttachmentDefinition{ObjectMeta: metav1.ObjectMeta{Name: "nad", Namespace: metav1.NamespaceDefault}})
+		createVM(
+			libvmi.WithContainerDisk("containerdisk", image),
+			libvmi.WithPersistentVolumeClaim("disk", "pvc"),
+			libvmi.WithCPUModel("Skylake"),
+			libvmi.WithCPUFeature("vmx", "require"),
+			libvmi.WithInterface(libvmi.InterfaceDeviceWithBridgeBinding("secondary")),
+			libvmi.WithNetwork(libvmi.MultusNetwork("secondary", "nad")),
+			func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Machine = &v1.Machine{Type: "q35"}
+				vmi.Spec.Domain.Devices.GPUs = []v1.GPU{{Name: "gpu", DeviceName: "nvidia.com/GV100GL"}}
+			},
+		)
+
+		report := preflight()
+		Expect(report.Ready).To(BeTrue())
+		Expect(report.Checks).To(ConsistOf(
+			check(v1.PreflightCheckCategoryImage, "containerdisk", v1.PreflightCheckPassed),
+			check(v1.PreflightCheckCategoryStorage, "disk", v1.PreflightCheckPassed),
+			check(v1.PreflightCheckCategoryDevice, "gpu", v1.PreflightCheckPassed),
+			check(v1.PreflightCheckCategoryCompute, "cpu", v1.PreflightCheckPassed),
+			check(v1.PreflightCheckCategoryNetwork, "secondary", v1.PreflightCheckPassed),
+		))
+	})
+
+	It("should not be ready if resources the VM depends on are missing", func() {
+		create(newNode())
+		createVM(
+			libvmi.WithPersistentVolumeClaim("disk", "missing-pvc"),
+			libvmi.WithDataVolume("dvdisk", "missing-dv"),
+			libvmi.WithCPUModel("EPYC"),
+			libvmi.WithInterface(libvmi.InterfaceDeviceWithBridgeBinding("secondary")),
+			libvmi.WithNetwork(libvmi.MultusNetwork("secondary", "missing-nad")),
+			func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{{Name: "hostdev", DeviceName: "intel.com/qat"}}
+			},
+		)
+
+		report := preflight()
+		Expect(report.Ready).To(BeFalse())
+		Expect(report.Checks).To(ConsistOf(
+			check(v1.PreflightCheckCategoryStorage, "disk", v1.PreflightCheckFailed),
+			check(v1.PreflightCheckCategoryStorage, "dvdisk", v1.PreflightCheckFailed),
+			check(v1.PreflightCheckCategoryDevice, "hostdev", v1.PreflightCheckFailed),
+			check(v1.PreflightCheckCategoryCompute, "cpu", v1.PreflightCheckFailed),
+			check(v1.PreflightCheckCategoryNetwork, "secondary", v1.PreflightCheckFailed),
+		))
+	})
+
+	It("should report the number of matching nodes instead of their names", func() {
+		create(newNode())
+		createVM(
+			libvmi.WithCPUModel("Skylake"),
+			func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.GPUs = []v1.GPU{{Name: "gpu", DeviceName: "nvidia.com/GV100GL"}}
+			},
+		)
+
+		report := preflight()
+		Expect(report.Checks).To(ConsistOf(
+			HaveField("Message", "nvidia.com/GV100GL is allocatable on 1 schedulable node(s)"),
+			HaveField("Message", "1 schedulable node(s) provide the requested CPU and machine type"),
+		))
+	})
+
+	DescribeTable("should only consider nodes the VM can be scheduled on", func(nodeModifier func(*k8sv1.Node), vmiOption libvmi.Option) {
+		node := newNode()
+		nodeModifier(node)
+		create(node)
+		createVM(vmiOption)
+
+		report := preflight()
+		Expect(report.Ready).To(BeFalse())
+		Expect(report.Checks).To(ConsistOf(check(v1.PreflightCheckCategoryCompute, "scheduling", v1.PreflightCheckFailed)))
+	},
+		Entry("when the node is not marked schedulable by virt-handler",
+			func(node *k8sv1.Node) { node.Labels[v1.NodeSchedulable] = "false" },
+			func(*v1.VirtualMachineInstance) {}),
+		Entry("when the node is cordoned",
+			func(node *k8sv1.Node) { node.Spec.Unschedulable = true },
+			func(*v1.VirtualMachineInstance) {}),
+		Entry("when the node selector does not match",
+			func(*k8sv1.Node) {},
+			libvmi.WithNodeSelector("zone", "b")),
+		Entry("when the required node affinity does not match",
+			func(*k8sv1.Node) {},
+			libvmi.WithNodeAffinityFor(nodeName+"-other")),
+		Entry("when a taint of the node is not tolerated",
+			func(node *k8sv1.Node) {
+				node.Spec.Taints = []k8sv1.Taint{{Key: "dedicated", Value: "db", Effect: k8sv1.TaintEffectNoSchedule}}
+			},
+			func(*v1.VirtualMachineInstance) {}),
+	)
+
+	It("should consider nodes whose taints are tolerated", func() {
+		node := newNode()
+		node.Spec.Taints = []k8sv1.Taint{{Key: "dedicated", Value: "db", Effect: k8sv1.TaintEffectNoSchedule}}
+		create(node)
+		createVM(libvmi.WithToleration(k8sv1.Toleration{Key: "dedicated", Operator: k8sv1.TolerationOpEqual, Value: "db", Effect: k8sv1.TaintEffectNoSchedule}))
+
+		report := preflight()
+		Expect(report.Ready).To(BeTrue())
+		Expect(report.Checks).To(ConsistOf(check(v1.PreflightCheckCategoryCompute, "cpu", v1.PreflightCheckPassed)))
+	})
+
+	It("should reject machine types which are not supported by the cluster", func() {
+		create(newNode())
+		createVM(func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Machine = &v1.Machine{Type: "pc-i440fx"}
+		})
+
+		report := preflight()
+		Expect(report.Ready).To(BeFalse())
+		Expect(report.Checks).To(ConsistOf(check(v1.PreflightCheckCategoryCompute, "machine", v1.PreflightCheckFailed)))
+	})
+
+	It("should warn about conditions which are only resolved when the VM starts", func() {
+		node := newNode()
+		node.Status.Images = nil
+		create(node)
+		create(&k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending-pvc", Namespace: metav1.NamespaceDefault},
+			Status:     k8sv1.PersistentVolumeClaimStatus{Phase: k8sv1.ClaimPending},
+		})
+		create(&cdiv1.DataVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "importing-dv", Namespace: metav1.NamespaceDefault},
+			Status:     cdiv1.DataVolumeStatus{Phase: cdiv1.ImportInProgress},
+		})
+		create(&networkv1.NetworkAttachmentDefinition{ObjectMeta: metav1.ObjectMeta{
+			Name:        "sriov",
+			Namespace:   metav1.NamespaceDefault,
+			Annotations: map[string]string{multus.ResourceNameAnnotation: "nvidia.com/GV100GL"},
+		}})
+		vm := libvmi.NewVirtualMachine(libvmi.New(
+			libvmi.WithName(testVMName),
+			libvmi.WithContainerDisk("containerdisk", image),
+			libvmi.WithPersistentVolumeClaim("disk", "pending-pvc"),
+			libvmi.WithDataVolume("dvdisk", "importing-dv"),
+			libvmi.WithDataVolume("templatedisk", "template-dv"),
+			libvmi.WithInterface(libvmi.InterfaceDeviceWithBridgeBinding("secondary")),
+			libvmi.WithNetwork(libvmi.MultusNetwork("secondary", "sriov")),
+		))
+		vm.Spec.DataVolumeTemplates = []v1.DataVolumeTemplateSpec{{ObjectMeta: metav1.ObjectMeta{Name: "template-dv"}}}
+		_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), vm, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		report := preflight()
+		Expect(report.Ready).To(BeTrue())
+		Expect(report.Checks).To(ConsistOf(
+			check(v1.PreflightCheckCategoryImage, "containerdisk", v1.PreflightCheckWarning),
+			check(v1.PreflightCheckCategoryStorage, "disk", v1.PreflightCheckWarning),
+			check(v1.PreflightCheckCategoryStorage, "dvdisk", v1.PreflightCheckWarning),
+			check(v1.PreflightCheckCategoryStorage, "templatedisk", v1.PreflightCheckPassed),
+			check(v1.PreflightCheckCategoryCompute, "cpu", v1.PreflightCheckPassed),
+			check(v1.PreflightCheckCategoryNetwork, "secondary", v1.PreflightCheckPassed),
+		))
+	})
+})
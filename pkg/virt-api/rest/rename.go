@@ -0,0 +1,233 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/emicklei/go-restful/v3"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+const vmNotStoppedErr = "VM must be stopped to be renamed"
+
+// VMRenameRequestHandler renames a stopped VirtualMachine. A VirtualMachine with the new name
+// and the same spec is created, the DataVolumes created from its templates and the
+// ControllerRevisions it controls are handed over to it and the old VirtualMachine is deleted
+// without deleting its dependents. A rename which failed halfway can be retried.
+func (app *SubresourceAPIApp) VMRenameRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+	ctx := request.Request.Context()
+
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body"), response)
+		return
+	}
+	opts := &v1.RenameOptions{}
+	defer request.Request.Body.Close()
+	if statusErr := decodeBody(request, opts); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+	if statusErr := validateRenameOptions(name, opts); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+	if statusErr = app.validateVMStopped(vm); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	newVM, statusErr := app.createRenamedVM(ctx, vm, opts.NewName)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	if err := app.reparentVMDependents(ctx, vm, newVM); err != nil {
+		writeError(errors.NewInternalError(fmt.Errorf("failed to hand over the dependents of VM %s to VM %s, the rename can be retried: %v", name, opts.NewName, err)), response)
+		return
+	}
+
+	err := app.virtCli.VirtualMachine(namespace).Delete(ctx, name, k8smetav1.DeleteOptions{
+		Preconditions:     &k8smetav1.Preconditions{UID: &vm.UID},
+		PropagationPolicy: pointer.P(k8smetav1.DeletePropagationOrphan),
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		writeError(errors.NewInternalError(fmt.Errorf("failed to delete VM %s, the rename can be retried: %v", name, err)), response)
+		return
+	}
+
+	log.Log.Object(vm).Infof("Renamed VM to %s", opts.NewName)
+	if err := response.WriteEntity(newVM); err != nil {
+		log.Log.Reason(err).Error("Failed to write http response.")
+	}
+}
+
+func validateRenameOptions(name string, opts *v1.RenameOptions) *errors.StatusError {
+	if opts.NewName == "" {
+		return errors.NewBadRequest("newName must be set")
+	}
+	if opts.NewName == name {
+		return errors.NewBadRequest("newName must differ from the current name")
+	}
+	if errs := k8svalidation.IsDNS1123Subdomain(opts.NewName); len(errs) > 0 {
+		return errors.NewBadRequest(fmt.Sprintf("invalid newName %q: %s", opts.NewName, strings.Join(errs, ", ")))
+	}
+	return nil
+}
+
+// validateVMStopped makes sure the VM neither runs nor would be started by its run strategy,
+// as the VMI cannot follow the VM to its new name.
+func (app *SubresourceAPIApp) validateVMStopped(vm *v1.VirtualMachine) *errors.StatusError {
+	if vm.DeletionTimestamp != nil {
+		return errors.NewConflict(v1.Resource("virtualmachine"), vm.Name, fmt.Errorf("VM is being deleted"))
+	}
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return errors.NewInternalError(err)
+	}
+	if runStrategy != v1.RunStrategyHalted && runStrategy != v1.RunStrategyManual {
+		return errors.NewConflict(v1.Resource("virtualmachine"), vm.Name, fmt.Errorf("%s, its run strategy is %s", vmNotStoppedErr, runStrategy))
+	}
+	_, statusErr := app.FetchVirtualMachineInstance(vm.Namespace, vm.Name)
+	if statusErr == nil {
+		return errors.NewConflict(v1.Resource("virtualmachine"), vm.Name, fmt.Errorf("%s, its VMI exists", vmNotStoppedErr))
+	}
+	if !errors.IsNotFound(statusErr) {
+		return statusErr
+	}
+	return nil
+}
+
+// createRenamedVM creates the VM with the new name, or returns it if an earlier attempt to
+// rename the same VM already created it.
+func (app *SubresourceAPIApp) createRenamedVM(ctx context.Context, vm *v1.VirtualMachine, newName string) (*v1.VirtualMachine, *errors.StatusError) {
+	annotations := map[string]string{}
+	for key, value := range vm.Annotations {
+		annotations[key] = value
+	}
+	annotations[v1.RenamedFromAnnotation] = string(vm.UID)
+
+	newVM := &v1.VirtualMachine{
+		ObjectMeta: k8smetav1.ObjectMeta{
+			Name:            newName,
+			Namespace:       vm.Namespace,
+			Labels:          vm.Labels,
+			Annotations:     annotations,
+			OwnerReferences: vm.OwnerReferences,
+		},
+		Spec: *vm.Spec.DeepCopy(),
+	}
+
+	created, err := app.virtCli.VirtualMachine(vm.Namespace).Create(ctx, newVM, k8smetav1.CreateOptions{})
+	if err == nil {
+		return created, nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return nil, errors.NewInternalError(fmt.Errorf("failed to create VM %s: %v", newName, err))
+	}
+
+	existing, err := app.virtCli.VirtualMachine(vm.Namespace).Get(ctx, newName, k8smetav1.GetOptions{})
+	if err != nil {
+		return nil, errors.NewInternalError(fmt.Errorf("failed to retrieve VM %s: %v", newName, err))
+	}
+	if existing.Annotations[v1.RenamedFromAnnotation] != string(vm.UID) {
+		return nil, errors.NewConflict(v1.Resource("virtualmachine"), vm.Name, fmt.Errorf("VM %s already exists", newName))
+	}
+	return existing, nil
+}
+
+// reparentVMDependents replaces the controller reference to the old VM on its DataVolumes and
+// ControllerRevisions. The names of both are kept, so the references in the spec stay valid.
+func (app *SubresourceAPIApp) reparentVMDependents(ctx context.Context, vm, newVM *v1.VirtualMachine) error {
+	for _, template := range vm.Spec.DataVolumeTemplates {
+		dv, err := app.virtCli.CdiClient().CdiV1beta1().DataVolumes(vm.Namespace).Get(ctx, template.Name, k8smetav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !k8smetav1.IsControlledBy(dv, vm) {
+			continue
+		}
+		patchBytes, err := reparentPatch(dv.OwnerReferences, vm, newVM)
+		if err != nil {
+			return err
+		}
+		if _, err := app.virtCli.CdiClient().CdiV1beta1().DataVolumes(vm.Namespace).Patch(ctx, dv.Name, types.JSONPatchType, patchBytes, k8smetav1.PatchOptions{}); err != nil {
+			return err
+		}
+	}
+
+	revisions, err := app.virtCli.AppsV1().ControllerRevisions(vm.Namespace).List(ctx, k8smetav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range revisions.Items {
+		revision := &revisions.Items[i]
+		if !k8smetav1.IsControlledBy(revision, vm) {
+			continue
+		}
+		patchBytes, err := reparentPatch(revision.OwnerReferences, vm, newVM)
+		if err != nil {
+			return err
+		}
+		if _, err := app.virtCli.AppsV1().ControllerRevisions(vm.Namespace).Patch(ctx, revision.Name, types.JSONPatchType, patchBytes, k8smetav1.PatchOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func reparentPatch(ownerReferences []k8smetav1.OwnerReference, vm, newVM *v1.VirtualMachine) ([]byte, error) {
+	newOwnerReferences := make([]k8smetav1.OwnerReference, 0, len(ownerReferences))
+	for _, ownerReference := range ownerReferences {
+		if ownerReference.UID == vm.UID {
+			ownerReference.Name = newVM.Name
+			ownerReference.UID = newVM.UID
+		}
+		newOwnerReferences = append(newOwnerReferences, ownerReference)
+	}
+	return patch.New(
+		patch.WithTest("/metadata/ownerReferences", ownerReferences),
+		patch.WithReplace("/metadata/ownerReferences", newOwnerReferences),
+	).GeneratePayload()
+}
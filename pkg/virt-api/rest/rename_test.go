@@ -0,0 +1,214 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	testing "k8s.io/client-go/testing"
+
+	v1 "kubevirt.io/api/core/v1"
+	cdifake "kubevirt.io/client-go/containerizeddataimporter/fake"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("VM rename", func() {
+	const (
+		newVMName = "renamed"
+		oldVMUID  = types.UID("old-uid")
+		newVMUID  = types.UID("new-uid")
+	)
+
+	var (
+		kubeClient *fake.Clientset
+		virtClient *kubevirtfake.Clientset
+		cdiClient  *cdifake.Clientset
+		app        *SubresourceAPIApp
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kvClient := kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fake.NewClientset()
+		virtClient = kubevirtfake.NewSimpleClientset()
+		cdiClient = cdifake.NewSimpleClientset()
+
+		virtClient.PrependReactor("create", "virtualmachines", func(action testing.Action) (bool, runtime.Object, error) {
+			vm := action.(testing.CreateAction).GetObject().(*v1.VirtualMachine)
+			if vm.UID == "" {
+				vm.UID = newVMUID
+			}
+			return false, nil, nil
+		})
+
+		kvClient.EXPECT().AppsV1().Return(kubeClient.AppsV1()).AnyTimes()
+		kvClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+		kvClient.EXPECT().VirtualMachine(metav1.NamespaceDefault).Return(virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault)).AnyTimes()
+		kvClient.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault)).AnyTimes()
+
+		config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+		app = NewSubresourceAPIApp(kvClient, 0, &tls.Config{InsecureSkipVerify: true}, config)
+	})
+
+	createVM := func(opts ...libvmi.VMOption) *v1.VirtualMachine {
+		opts = append([]libvmi.VMOption{libvmi.WithRunStrategy(v1.RunStrategyHalted)}, opts...)
+		vm := libvmi.NewVirtualMachine(libvmi.New(libvmi.WithName(testVMName), libvmi.WithNamespace(metav1.NamespaceDefault)), opts...)
+		vm.UID = oldVMUID
+		vm, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), vm, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return vm
+	}
+
+	controllerRef := func(vm *v1.VirtualMachine) []metav1.OwnerReference {
+		return []metav1.OwnerReference{*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)}
+	}
+
+	rename := func(opts *v1.RenameOptions) *httptest.ResponseRecorder {
+		body, err := json.Marshal(opts)
+		Expect(err).ToNot(HaveOccurred())
+		httpRequest, err := http.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		request := restful.NewRequest(httpRequest)
+		request.PathParameters()["name"] = testVMName
+		request.PathParameters()["namespace"] = metav1.NamespaceDefault
+		recorder := httptest.NewRecorder()
+		response := restful.NewResponse(recorder)
+		response.SetRequestAccepts(restful.MIME_JSON)
+
+		app.VMRenameRequestHandler(request, response)
+		return recorder
+	}
+
+	DescribeTable("should reject an invalid new name", func(newName string) {
+		createVM()
+		Expect(rename(&v1.RenameOptions{NewName: newName}).Code).To(Equal(http.StatusBadRequest))
+	},
+		Entry("when it is empty", ""),
+		Entry("when it equals the current name", testVMName),
+		Entry("when it is not a DNS subdomain", "Not_Valid"),
+	)
+
+	It("should return NotFound if the VM does not exist", func() {
+		Expect(rename(&v1.RenameOptions{NewName: newVMName}).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should reject a VM whose run strategy starts it", func() {
+		createVM(libvmi.WithRunStrategy(v1.RunStrategyAlways))
+		Expect(rename(&v1.RenameOptions{NewName: newVMName}).Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should reject a VM whose VMI exists", func() {
+		createVM(libvmi.WithRunStrategy(v1.RunStrategyManual))
+		vmi := libvmi.New(libvmi.WithName(testVMName), libvmi.WithNamespace(metav1.NamespaceDefault))
+		_, err := virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).Create(context.Background(), vmi, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(rename(&v1.RenameOptions{NewName: newVMName}).Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should reject a new name taken by another VM", func() {
+		createVM()
+		other := libvmi.NewVirtualMachine(libvmi.New(libvmi.WithName(newVMName), libvmi.WithNamespace(metav1.NamespaceDefault)))
+		_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), other, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(rename(&v1.RenameOptions{NewName: newVMName}).Code).To(Equal(http.StatusConflict))
+		_, err = virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Get(context.Background(), testVMName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should move the VM and the objects it controls to the new name", func() {
+		vm := createVM(
+			libvmi.WithDataVolumeTemplate(&cdiv1.DataVolume{ObjectMeta: metav1.ObjectMeta{Name: "rootdisk"}}),
+			libvmi.WithLabels(map[string]string{"app": "test"}),
+		)
+
+		_, err := cdiClient.CdiV1beta1().DataVolumes(metav1.NamespaceDefault).Create(context.Background(), &cdiv1.DataVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "rootdisk", Namespace: metav1.NamespaceDefault, OwnerReferences: controllerRef(vm)},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = kubeClient.AppsV1().ControllerRevisions(metav1.NamespaceDefault).Create(context.Background(), &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "owned", Namespace: metav1.NamespaceDefault, OwnerReferences: controllerRef(vm)},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = kubeClient.AppsV1().ControllerRevisions(metav1.NamespaceDefault).Create(context.Background(), &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: metav1.NamespaceDefault},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		recorder := rename(&v1.RenameOptions{NewName: newVMName})
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		_, err = virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Get(context.Background(), testVMName, metav1.GetOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		newVM, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Get(context.Background(), newVMName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(newVM.Spec).To(Equal(vm.Spec))
+		Expect(newVM.Labels).To(HaveKeyWithValue("app", "test"))
+		Expect(newVM.Annotations).To(HaveKeyWithValue(v1.RenamedFromAnnotation, string(oldVMUID)))
+
+		dv, err := cdiClient.CdiV1beta1().DataVolumes(metav1.NamespaceDefault).Get(context.Background(), "rootdisk", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dv.OwnerReferences).To(Equal(controllerRef(newVM)))
+
+		owned, err := kubeClient.AppsV1().ControllerRevisions(metav1.NamespaceDefault).Get(context.Background(), "owned", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(owned.OwnerReferences).To(Equal(controllerRef(newVM)))
+
+		unrelated, err := kubeClient.AppsV1().ControllerRevisions(metav1.NamespaceDefault).Get(context.Background(), "unrelated", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unrelated.OwnerReferences).To(BeEmpty())
+	})
+
+	It("should resume a rename which already created the new VM", func() {
+		createVM()
+		renamed := libvmi.NewVirtualMachine(libvmi.New(libvmi.WithName(newVMName), libvmi.WithNamespace(metav1.NamespaceDefault)),
+			libvmi.WithAnnotations(map[string]string{v1.RenamedFromAnnotation: string(oldVMUID)}),
+		)
+		_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), renamed, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(rename(&v1.RenameOptions{NewName: newVMName}).Code).To(Equal(http.StatusOK))
+		_, err = virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Get(context.Background(), testVMName, metav1.GetOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+})
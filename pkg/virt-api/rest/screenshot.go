@@ -0,0 +1,155 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	restful "github.com/emicklei/go-restful/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/virt-api/definitions"
+)
+
+const (
+	defaultScreenshotStreamFPS = 1
+	maxScreenshotStreamFPS     = 5
+
+	screenshotStreamBoundary = "kubevirt-screenshot"
+	screenshotStreamQuality  = 75
+)
+
+// screenshotStreamHandler streams the display of the VMI as multipart/x-mixed-replace MJPEG,
+// which browsers render in an img element. Every frame is a screenshot taken by virt-handler,
+// which is why the framerate is kept low.
+func (app *SubresourceAPIApp) screenshotStreamHandler(request *restful.Request, response *restful.Response) {
+	fps, statusErr := screenshotStreamFPS(request)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	release, statusErr := app.subresourceLimiter.acquireStream(request)
+	if statusErr != nil {
+		writeTooManyRequests(statusErr, response)
+		return
+	}
+	defer release()
+
+	vmi, url, conn, statusErr := app.prepareConnection(request, vmiHasDisplay, func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.ScreenshotURI(vmi)
+	})
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	// Take the first frame before the stream is started to still be able to report errors
+	frame, err := screenshotFrame(conn, url)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to take a screenshot for the stream")
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	parts := multipart.NewWriter(response)
+	if err := parts.SetBoundary(screenshotStreamBoundary); err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	response.AddHeader("Content-Type", "multipart/x-mixed-replace; boundary="+screenshotStreamBoundary)
+	response.AddHeader("Cache-Control", "no-cache")
+	response.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(time.Second / time.Duration(fps))
+	defer ticker.Stop()
+	for {
+		if err := writeScreenshotFrame(parts, frame); err != nil {
+			log.Log.Object(vmi).Reason(err).V(3).Info("Screenshot stream closed")
+			return
+		}
+		response.Flush()
+
+		select {
+		case <-request.Request.Context().Done():
+			return
+		case <-ticker.C:
+		}
+
+		if frame, err = screenshotFrame(conn, url); err != nil {
+			log.Log.Object(vmi).Reason(err).Error("Failed to take a screenshot for the stream")
+			return
+		}
+	}
+}
+
+func screenshotStreamFPS(request *restful.Request) (int, *errors.StatusError) {
+	param := request.QueryParameter(definitions.FPSParamName)
+	if param == "" {
+		return defaultScreenshotStreamFPS, nil
+	}
+	fps, err := strconv.Atoi(param)
+	if err != nil || fps < 1 || fps > maxScreenshotStreamFPS {
+		return 0, errors.NewBadRequest(fmt.Sprintf("invalid %s %q, must be between 1 and %d", definitions.FPSParamName, param, maxScreenshotStreamFPS))
+	}
+	return fps, nil
+}
+
+// screenshotFrame takes a PNG screenshot through virt-handler and re-encodes it as JPEG
+func screenshotFrame(conn kubecli.VirtHandlerConn, url string) ([]byte, error) {
+	screenshot, err := conn.Get(url, "")
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader([]byte(screenshot)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the screenshot: %w", err)
+	}
+	frame := &bytes.Buffer{}
+	if err := jpeg.Encode(frame, img, &jpeg.Options{Quality: screenshotStreamQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode the frame: %w", err)
+	}
+	return frame.Bytes(), nil
+}
+
+func writeScreenshotFrame(parts *multipart.Writer, frame []byte) error {
+	part, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":   {"image/jpeg"},
+		"Content-Length": {strconv.Itoa(len(frame))},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(frame)
+	return err
+}
@@ -0,0 +1,139 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	"kubevirt.io/client-go/log"
+
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const unknownSessionUser = "unknown"
+
+// SubresourceSessions writes a structured audit log entry whenever a console, VNC, usbredir or
+// port-forward session proxied by this virt-api replica starts or stops. The entries are meant
+// to be collected by the cluster log pipeline, virt-api does not keep the sessions itself.
+type SubresourceSessions struct {
+	clusterConfig *virtconfig.ClusterConfig
+	authorizor    VirtApiAuthorizor
+	server        string
+	logger        *log.FilteredLogger
+	now           func() time.Time
+}
+
+type subresourceSession struct {
+	id           string
+	user         string
+	namespace    string
+	name         string
+	subresource  string
+	start        time.Time
+	bytesToVMI   atomic.Int64
+	bytesFromVMI atomic.Int64
+}
+
+func NewSubresourceSessions(authorizor VirtApiAuthorizor, clusterConfig *virtconfig.ClusterConfig, server string) *SubresourceSessions {
+	return &SubresourceSessions{
+		clusterConfig: clusterConfig,
+		authorizor:    authorizor,
+		server:        server,
+		logger:        log.Log,
+		now:           time.Now,
+	}
+}
+
+// WithSubresourceSessions enables auditing the interactive subresource sessions proxied by virt-api
+func (app *SubresourceAPIApp) WithSubresourceSessions(sessions *SubresourceSessions) *SubresourceAPIApp {
+	app.subresourceSessions = sessions
+	return app
+}
+
+// start audits a new session for the request. It returns nil if session auditing is disabled.
+func (s *SubresourceSessions) start(request *restful.Request, subresource string) *subresourceSession {
+	if s == nil || !s.clusterConfig.SubresourceSessionAuditEnabled() {
+		return nil
+	}
+
+	user, err := getUserName(request.Request.Header, s.authorizor.GetUserHeaders())
+	if err != nil {
+		user = unknownSessionUser
+	}
+	session := &subresourceSession{
+		id:          string(uuid.NewUUID()),
+		user:        user,
+		namespace:   request.PathParameter("namespace"),
+		name:        request.PathParameter("name"),
+		subresource: subresource,
+		start:       s.now(),
+	}
+
+	s.entry(session).Info("Subresource session started")
+	return session
+}
+
+// stop audits the end of the session together with its duration and byte counters
+func (s *SubresourceSessions) stop(session *subresourceSession) {
+	s.entry(session).With(
+		"duration", s.now().Sub(session.start).String(),
+		"bytesToVMI", session.bytesToVMI.Load(),
+		"bytesFromVMI", session.bytesFromVMI.Load(),
+	).Info("Subresource session stopped")
+}
+
+func (s *SubresourceSessions) entry(session *subresourceSession) *log.FilteredLogger {
+	return s.logger.With(
+		"audit", true,
+		"sessionID", session.id,
+		"user", session.user,
+		"namespace", session.namespace,
+		"name", session.name,
+		"subresource", session.subresource,
+		"server", s.server,
+	)
+}
+
+// countingConn counts the bytes exchanged with the VMI side of a stream
+func (s *subresourceSession) countingConn(conn net.Conn) net.Conn {
+	return &sessionConn{Conn: conn, session: s}
+}
+
+type sessionConn struct {
+	net.Conn
+	session *subresourceSession
+}
+
+func (c *sessionConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.session.bytesFromVMI.Add(int64(n))
+	return n, err
+}
+
+func (c *sessionConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.session.bytesToVMI.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,165 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+// withAuditBuffer makes the sessions write their audit entries to the returned buffer
+func withAuditBuffer(sessions *SubresourceSessions) *bytes.Buffer {
+	buffer := &bytes.Buffer{}
+	sessions.logger = log.MakeLogger(log.NullLogger{})
+	sessions.logger.SetIOWriter(buffer)
+	return buffer
+}
+
+func auditEntries(buffer *bytes.Buffer) []map[string]interface{} {
+	entries := []map[string]interface{}{}
+	scanner := bufio.NewScanner(buffer)
+	for scanner.Scan() {
+		entry := map[string]interface{}{}
+		Expect(json.Unmarshal(scanner.Bytes(), &entry)).To(Succeed())
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+var _ = Describe("Subresource sessions", func() {
+	var (
+		kvStore  cache.Store
+		kv       *v1.KubeVirt
+		sessions *SubresourceSessions
+		auditLog *bytes.Buffer
+		now      time.Time
+	)
+
+	newRequest := func(namespace, name, user string) *restful.Request {
+		httpRequest := &http.Request{URL: &url.URL{}, Header: http.Header{}}
+		if user != "" {
+			httpRequest.Header.Set(userHeader, user)
+		}
+		request := restful.NewRequest(httpRequest)
+		request.PathParameters()["namespace"] = namespace
+		request.PathParameters()["name"] = name
+		return request
+	}
+
+	BeforeEach(func() {
+		kv = &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{
+						FeatureGates: []string{featuregate.SubresourceSessionAudit},
+					},
+				},
+			},
+			Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeployed},
+		}
+		config, _, store := testutils.NewFakeClusterConfigUsingKV(kv)
+		kvStore = store
+
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		sessions = NewSubresourceSessions(NewAuthorizorFromClient(nil), config, "virt-api-1")
+		sessions.now = func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		}
+		auditLog = withAuditBuffer(sessions)
+	})
+
+	It("should not audit sessions if the feature gate is not enabled", func() {
+		newKV := kv.DeepCopy()
+		newKV.Spec.Configuration.DeveloperConfiguration.FeatureGates = nil
+		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, newKV)
+
+		Expect(sessions.start(newRequest(metav1.NamespaceDefault, "testvmi", "alice"), "console")).To(BeNil())
+		Expect(auditLog.Len()).To(BeZero())
+	})
+
+	It("should not audit sessions without a session tracker", func() {
+		var noSessions *SubresourceSessions
+		Expect(noSessions.start(newRequest(metav1.NamespaceDefault, "testvmi", "alice"), "console")).To(BeNil())
+	})
+
+	It("should audit the user and the bytes exchanged with the VMI", func() {
+		session := sessions.start(newRequest(metav1.NamespaceDefault, "testvmi", "alice"), "vnc")
+		Expect(session).ToNot(BeNil())
+
+		clientSide, vmiSide := net.Pipe()
+		conn := session.countingConn(clientSide)
+		go func() {
+			defer GinkgoRecover()
+			buf := make([]byte, 5)
+			_, err := vmiSide.Read(buf)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = vmiSide.Write([]byte("hi"))
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		_, err := conn.Write([]byte("hello"))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = conn.Read(make([]byte, 2))
+		Expect(err).ToNot(HaveOccurred())
+		sessions.stop(session)
+
+		entries := auditEntries(auditLog)
+		Expect(entries).To(HaveLen(2))
+		for _, entry := range entries {
+			Expect(entry).To(HaveKeyWithValue("audit", true))
+			Expect(entry).To(HaveKeyWithValue("sessionID", session.id))
+			Expect(entry).To(HaveKeyWithValue("user", "alice"))
+			Expect(entry).To(HaveKeyWithValue("namespace", metav1.NamespaceDefault))
+			Expect(entry).To(HaveKeyWithValue("name", "testvmi"))
+			Expect(entry).To(HaveKeyWithValue("subresource", "vnc"))
+			Expect(entry).To(HaveKeyWithValue("server", "virt-api-1"))
+		}
+		Expect(entries[0]).To(HaveKeyWithValue("msg", "Subresource session started"))
+		Expect(entries[0]).ToNot(HaveKey("bytesToVMI"))
+		Expect(entries[1]).To(HaveKeyWithValue("msg", "Subresource session stopped"))
+		Expect(entries[1]).To(HaveKeyWithValue("duration", "1s"))
+		Expect(entries[1]).To(HaveKeyWithValue("bytesToVMI", BeNumerically("==", 5)))
+		Expect(entries[1]).To(HaveKeyWithValue("bytesFromVMI", BeNumerically("==", 2)))
+	})
+
+	It("should fall back to an unknown user if the request carries no user", func() {
+		session := sessions.start(newRequest(metav1.NamespaceDefault, "testvmi", ""), "console")
+		Expect(session.user).To(Equal(unknownSessionUser))
+	})
+})
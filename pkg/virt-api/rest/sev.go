@@ -20,8 +20,12 @@
 package rest
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/emicklei/go-restful/v3"
@@ -41,6 +45,10 @@ import (
 
 const (
 	vmiNoAttestationErr = "Attestation not requested for VMI"
+	vmiNotSEVSNPErr     = "VMI does not use SEV-SNP"
+
+	// maxSEVSNPReportDataSize is the size of the guest provided data field of an SEV-SNP attestation report
+	maxSEVSNPReportDataSize = 64
 )
 
 func (app *SubresourceAPIApp) ensureSEVEnabled(response *restful.Response) bool {
@@ -170,6 +178,69 @@ func (app *SubresourceAPIApp) SEVInjectLaunchSecretHandler(request *restful.Requ
 	app.putRequestHandler(request, response, validateVMIForSEVAttestation, getURL, false)
 }
 
+func (app *SubresourceAPIApp) SEVFetchAttestationReportHandler(request *restful.Request, response *restful.Response) {
+	if !app.ensureSEVEnabled(response) {
+		return
+	}
+
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body: SEV-SNP attestation report parameters are required"), response)
+		return
+	}
+
+	opts := &v1.SEVSNPAttestationReportOptions{}
+	if err := decodeBody(request, opts); err != nil {
+		writeError(err, response)
+		return
+	}
+
+	if statusErr := validateSEVSNPReportData(opts.ReportData); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SEVFetchAttestationReportURI(vmi)
+	}
+
+	// The body was consumed while decoding, virt-handler needs the report data
+	body, err := json.Marshal(opts)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	request.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	app.httpPutRequestHandler(request, response, validateVMIForSEVSNPAttestationReport, getURL, v1.SEVSNPAttestationReport{})
+}
+
+func validateSEVSNPReportData(reportData string) *errors.StatusError {
+	data, err := base64.StdEncoding.DecodeString(reportData)
+	if err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("Report data must be base64 encoded: %v", err))
+	}
+	if len(data) > maxSEVSNPReportDataSize {
+		return errors.NewBadRequest(fmt.Sprintf("Report data must not exceed %d bytes", maxSEVSNPReportDataSize))
+	}
+	return nil
+}
+
+// Validate a VMI for an SEV-SNP attestation report: Running, with SNP and the guest agent connected,
+// since the report is requested by the guest.
+func validateVMIForSEVSNPAttestationReport(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+	if !vmi.IsRunning() {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf(vmiNotRunning))
+	}
+	if !kutil.IsSEVSNPVMI(vmi) {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf(vmiNotSEVSNPErr))
+	}
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf(vmiGuestAgentErr))
+	}
+	return nil
+}
+
 // Validate a VMI for SEV attestation: Running, Paused and with Attestation requested.
 func validateVMIForSEVAttestation(vmi *v1.VirtualMachineInstance) *errors.StatusError {
 	if !vmi.IsRunning() {
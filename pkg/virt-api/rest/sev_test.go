@@ -23,6 +23,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -253,4 +254,66 @@ var _ = Describe("SEV Subresources", func() {
 		Expect(response.Error()).ToNot(HaveOccurred())
 		Expect(response.StatusCode()).To(Equal(http.StatusOK))
 	})
+
+	Context("SEV-SNP attestation report", func() {
+		agentConnected := libvmistatus.WithCondition(v1.VirtualMachineInstanceCondition{
+			Type:   v1.VirtualMachineInstanceAgentConnected,
+			Status: k8sv1.ConditionTrue,
+		})
+
+		setReportData := func(reportData string) {
+			body, err := json.Marshal(&v1.SEVSNPAttestationReportOptions{ReportData: reportData})
+			Expect(err).ToNot(HaveOccurred())
+			request.Request.Body = &readCloserWrapper{bytes.NewReader(body)}
+		}
+
+		BeforeEach(func() {
+			setReportData("")
+		})
+
+		It("Should allow to fetch an attestation report when the guest agent is connected", func() {
+			reportData := base64.StdEncoding.EncodeToString([]byte("nonce"))
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/sev/fetchattestationreport"),
+					ghttp.VerifyBody([]byte(`{"reportData":"`+reportData+`"}`)),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.SEVSNPAttestationReport{Report: "AAABBB"}),
+				),
+			)
+			response.SetRequestAccepts(restful.MIME_JSON)
+			setReportData(reportData)
+
+			createVMI(Running, UnPaused, []libvmi.Option{libvmi.WithSEV(false, true)}, []libvmistatus.Option{agentConnected})
+			app.SEVFetchAttestationReportHandler(request, response)
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should reject a request without body", func() {
+			request.Request.Body = nil
+			app.SEVFetchAttestationReportHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		})
+
+		DescribeTable("Should reject invalid report data", func(reportData string) {
+			setReportData(reportData)
+			createVMI(Running, UnPaused, []libvmi.Option{libvmi.WithSEV(false, true)}, []libvmistatus.Option{agentConnected})
+			app.SEVFetchAttestationReportHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		},
+			Entry("when it is not base64 encoded", "not base64"),
+			Entry("when it exceeds 64 bytes", base64.StdEncoding.EncodeToString(make([]byte, 65))),
+		)
+
+		DescribeTable("Should fail to fetch an attestation report",
+			func(running bool, specOpts []libvmi.Option, statusOpts []libvmistatus.Option) {
+				createVMI(running, UnPaused, specOpts, statusOpts)
+				app.SEVFetchAttestationReportHandler(request, response)
+				Expect(response.StatusCode()).To(Equal(http.StatusConflict))
+			},
+			Entry("when VMI is not running", NotRunning, []libvmi.Option{libvmi.WithSEV(false, true)}, []libvmistatus.Option{agentConnected}),
+			Entry("when VMI does not use SEV-SNP", Running, []libvmi.Option{libvmi.WithSEV(false, false)}, []libvmistatus.Option{agentConnected}),
+			Entry("when the guest agent is not connected", Running, []libvmi.Option{libvmi.WithSEV(false, true)}, nil),
+		)
+	})
 })
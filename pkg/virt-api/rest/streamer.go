@@ -46,10 +46,24 @@ type dialer interface {
 	DialUnderlying(vmi *v1.VirtualMachineInstance) (net.Conn, *errors.StatusError)
 }
 
+// compressionDialer is implemented by dialers whose server side speaks websocket and can
+// therefore negotiate permessage-deflate compression for frames proxied as is.
+type compressionDialer interface {
+	DialUnderlyingWithCompression(vmi *v1.VirtualMachineInstance) (conn net.Conn, compressed bool, err *errors.StatusError)
+}
+
 type Streamer struct {
 	dialer          *DirectDialer
 	keepAliveClient func(ctx context.Context, conn *websocket.Conn, cancel func())
 
+	// forwardCompression allows negotiating permessage-deflate with the client when the
+	// server side agreed to it as well, since raw streams copy websocket frames unmodified.
+	forwardCompression bool
+
+	sessions    *SubresourceSessions
+	subresource string
+	limiter     *SubresourceLimiter
+
 	streamToClient streamFunc
 	streamToServer streamFunc
 }
@@ -62,7 +76,8 @@ type DirectDialer struct {
 
 func NewRawStreamer(fetch vmiFetcher, validate validator, dial dialer) *Streamer {
 	return &Streamer{
-		dialer: NewDirectDialer(fetch, validate, dial),
+		dialer:             NewDirectDialer(fetch, validate, dial),
+		forwardCompression: true,
 		streamToServer: func(clientConn *websocket.Conn, serverConn net.Conn, result chan<- streamFuncResult) {
 			_, err := io.Copy(serverConn, clientConn.UnderlyingConn())
 			result <- err
@@ -89,22 +104,47 @@ func NewWebsocketStreamer(fetch vmiFetcher, validate validator, dial dialer) *St
 	}
 }
 
+// WithSessions records the streams handled by the streamer as sessions of the given subresource
+func (s *Streamer) WithSessions(sessions *SubresourceSessions, subresource string) *Streamer {
+	s.sessions = sessions
+	s.subresource = subresource
+	return s
+}
+
+// WithLimiter rejects streams exceeding the concurrent streams of the user or the namespace
+func (s *Streamer) WithLimiter(limiter *SubresourceLimiter) *Streamer {
+	s.limiter = limiter
+	return s
+}
+
 func (s *Streamer) Handle(request *restful.Request, response *restful.Response) error {
+	release, statusErr := s.limiter.acquireStream(request)
+	if statusErr != nil {
+		writeTooManyRequests(statusErr, response)
+		return statusErr
+	}
+	defer release()
+
 	namespace := request.PathParameter(definitions.NamespaceParamName)
 	name := request.PathParameter(definitions.NameParamName)
-	serverConn, statusErr := s.dialer.DialUnderlying(namespace, name)
+	serverConn, compressed, statusErr := s.dialServer(request, namespace, name)
 
 	if statusErr != nil {
 		writeError(statusErr, response)
 		return statusErr
 	}
 
-	clientConn, err := clientConnectionUpgrade(request, response)
+	clientConn, err := clientConnectionUpgrade(request, response, compressed)
 	if err != nil {
 		writeError(errors.NewBadRequest(err.Error()), response)
 		return err
 	}
 
+	if session := s.sessions.start(request, s.subresource); session != nil {
+		defer s.sessions.stop(session)
+		serverConn = session.countingConn(serverConn)
+	}
+
 	ctx, cancel := context.WithCancel(request.Request.Context())
 	defer cancel()
 	go s.cleanupOnClosedContext(ctx, clientConn, serverConn)
@@ -130,11 +170,22 @@ func (s *Streamer) Handle(request *restful.Request, response *restful.Response)
 	return result2
 }
 
+// dialServer offers compression to the server only if the client asked for it, so that
+// compression stays opt-in per connection.
+func (s *Streamer) dialServer(request *restful.Request, namespace, name string) (net.Conn, bool, *errors.StatusError) {
+	if s.forwardCompression && kvcorev1.HasCompressionExtension(request.Request.Header) {
+		return s.dialer.DialUnderlyingWithCompression(namespace, name)
+	}
+	serverConn, statusErr := s.dialer.DialUnderlying(namespace, name)
+	return serverConn, false, statusErr
+}
+
 const streamTimeout = 10 * time.Second
 
-func clientConnectionUpgrade(request *restful.Request, response *restful.Response) (*websocket.Conn, error) {
+func clientConnectionUpgrade(request *restful.Request, response *restful.Response, enableCompression bool) (*websocket.Conn, error) {
 	upgrader := kvcorev1.NewUpgrader()
 	upgrader.HandshakeTimeout = streamTimeout
+	upgrader.EnableCompression = enableCompression
 	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
 	if err != nil {
 		return nil, err
@@ -199,6 +250,21 @@ func (d *DirectDialer) DialUnderlying(namespace, name string) (net.Conn, *errors
 	return d.dial.DialUnderlying(vmi)
 }
 
+// DialUnderlyingWithCompression offers permessage-deflate compression to the server when the
+// underlying dialer supports it and reports whether it was negotiated.
+func (d *DirectDialer) DialUnderlyingWithCompression(namespace, name string) (net.Conn, bool, *errors.StatusError) {
+	vmi, err := d.fetchAndValidateVMI(namespace, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if dial, ok := d.dial.(compressionDialer); ok {
+		return dial.DialUnderlyingWithCompression(vmi)
+	}
+	conn, err := d.dial.DialUnderlying(vmi)
+	return conn, false, err
+}
+
 func (d *DirectDialer) fetchAndValidateVMI(namespace, name string) (*v1.VirtualMachineInstance, *errors.StatusError) {
 	vmi, err := d.fetchVMI(namespace, name)
 	if err != nil {
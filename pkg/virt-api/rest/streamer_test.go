@@ -40,7 +40,10 @@ import (
 
 	v1 "kubevirt.io/api/core/v1"
 
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/definitions"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
 )
 
 var _ = Describe("Streamer", func() {
@@ -215,7 +218,7 @@ var _ = Describe("Streamer", func() {
 			srv, ws, wsResp, err := testWebsocketDial(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 				defer wg.Done()
 				defer GinkgoRecover()
-				_, upgradeErr := clientConnectionUpgrade(restful.NewRequest(r), restful.NewResponse(rw))
+				_, upgradeErr := clientConnectionUpgrade(restful.NewRequest(r), restful.NewResponse(rw), false)
 				Expect(upgradeErr).NotTo(HaveOccurred())
 			}))
 			Expect(err).NotTo(HaveOccurred())
@@ -225,6 +228,140 @@ var _ = Describe("Streamer", func() {
 			wg.Wait()
 		})
 	})
+	Context("compression", func() {
+		var compressionRequested bool
+
+		BeforeEach(func() {
+			compressionRequested = false
+			directDialer.dial = mockCompressionDialer{
+				mockDialer: directDialer.dial.(mockDialer),
+				dialUnderlyingWithCompression: func(vmi *v1.VirtualMachineInstance) (net.Conn, bool, *errors.StatusError) {
+					compressionRequested = true
+					return serverConn, true, nil
+				},
+			}
+			streamer.forwardCompression = true
+		})
+
+		handleWithClient := func(dialer *websocket.Dialer) *http.Response {
+			var wg sync.WaitGroup
+			wg.Add(1)
+			srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				defer wg.Done()
+				defer GinkgoRecover()
+				Expect(streamer.Handle(restful.NewRequest(r), restful.NewResponse(rw))).To(Succeed())
+			}))
+			defer srv.Close()
+			ws, wsResp, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer ws.Close()
+			wg.Wait()
+			return wsResp
+		}
+
+		It("is negotiated with the client when the client and the server agree to it", func() {
+			wsResp := handleWithClient(&websocket.Dialer{EnableCompression: true})
+			Expect(compressionRequested).To(BeTrue())
+			Expect(wsResp.Header.Get("Sec-WebSocket-Extensions")).To(ContainSubstring("permessage-deflate"))
+		})
+
+		It("is not offered to the server when the client did not ask for it", func() {
+			wsResp := handleWithClient(websocket.DefaultDialer)
+			Expect(compressionRequested).To(BeFalse())
+			Expect(dialCalled).To(BeTrue())
+			Expect(wsResp.Header.Get("Sec-WebSocket-Extensions")).To(BeEmpty())
+		})
+
+		It("is not negotiated with the client when the server declined it", func() {
+			directDialer.dial = mockCompressionDialer{
+				mockDialer: directDialer.dial.(mockCompressionDialer).mockDialer,
+				dialUnderlyingWithCompression: func(vmi *v1.VirtualMachineInstance) (net.Conn, bool, *errors.StatusError) {
+					compressionRequested = true
+					return serverConn, false, nil
+				},
+			}
+			wsResp := handleWithClient(&websocket.Dialer{EnableCompression: true})
+			Expect(compressionRequested).To(BeTrue())
+			Expect(wsResp.Header.Get("Sec-WebSocket-Extensions")).To(BeEmpty())
+		})
+
+		It("is not negotiated when the streamer does not forward compression", func() {
+			streamer.forwardCompression = false
+			wsResp := handleWithClient(&websocket.Dialer{EnableCompression: true})
+			Expect(compressionRequested).To(BeFalse())
+			Expect(wsResp.Header.Get("Sec-WebSocket-Extensions")).To(BeEmpty())
+		})
+	})
+	It("audits the session while streaming if sessions are configured", func() {
+		kv := &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{
+						FeatureGates: []string{featuregate.SubresourceSessionAudit},
+					},
+				},
+			},
+			Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeployed},
+		}
+		config, _, _ := testutils.NewFakeClusterConfigUsingKV(kv)
+		sessions := NewSubresourceSessions(NewAuthorizorFromClient(nil), config, "virt-api")
+		auditLog := withAuditBuffer(sessions)
+		streamer.WithSessions(sessions, "console")
+		streamer.streamToClient = func(clientSocket *websocket.Conn, serverConn net.Conn, result chan<- streamFuncResult) {
+			result <- nil
+			streamToClientCalled <- struct{}{}
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		srv, ws, _, err := testWebsocketDial(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			defer wg.Done()
+			defer GinkgoRecover()
+			request := restful.NewRequest(r)
+			request.PathParameters()[definitions.NamespaceParamName] = testNamespace
+			request.PathParameters()[definitions.NameParamName] = testName
+			Expect(streamer.Handle(request, restful.NewResponse(rw))).To(Succeed())
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		defer srv.Close()
+		defer ws.Close()
+		Eventually(streamToClientCalled, defaultTestTimeout).Should(Receive())
+		wg.Wait()
+
+		entries := auditEntries(auditLog)
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0]).To(HaveKeyWithValue("msg", "Subresource session started"))
+		Expect(entries[1]).To(HaveKeyWithValue("msg", "Subresource session stopped"))
+		Expect(entries[1]).To(HaveKeyWithValue("subresource", "console"))
+	})
+	It("rejects the stream without dialing when the concurrent streams are exhausted", func() {
+		kv := &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					SubresourceLimits: &v1.SubresourceLimitsConfiguration{
+						PerNamespace: &v1.SubresourceClientLimits{MaxConcurrentStreams: pointer.P(uint32(1))},
+					},
+				},
+			},
+			Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeployed},
+		}
+		config, _, _ := testutils.NewFakeClusterConfigUsingKV(kv)
+		limiter := NewSubresourceLimiter(NewAuthorizorFromClient(nil), config)
+		streamer.WithLimiter(limiter)
+		req.PathParameters()[definitions.NamespaceParamName] = testNamespace
+
+		release, statusErr := limiter.acquireStream(req)
+		Expect(statusErr).ToNot(HaveOccurred())
+		defer release()
+
+		Expect(streamer.Handle(req, resp)).To(MatchError(ContainSubstring("maximum of 1 concurrent streams of namespace test-namespace reached")))
+		Expect(respRecorder.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(respRecorder.Header().Get("Retry-After")).To(Equal("5"))
+		Expect(fetchVMICalled).To(BeFalse())
+		Expect(dialCalled).To(BeFalse())
+	})
 	It("calls keepAliveClient if set", func() {
 		call := make(chan struct{})
 		streamer.keepAliveClient = func(ctx context.Context, conn *websocket.Conn, _ func()) {
@@ -536,3 +673,12 @@ func (m mockDialer) Dial(vmi *v1.VirtualMachineInstance) (*websocket.Conn, *erro
 func (m mockDialer) DialUnderlying(vmi *v1.VirtualMachineInstance) (net.Conn, *errors.StatusError) {
 	return m.dialUnderlying(vmi)
 }
+
+type mockCompressionDialer struct {
+	mockDialer
+	dialUnderlyingWithCompression func(vmi *v1.VirtualMachineInstance) (net.Conn, bool, *errors.StatusError)
+}
+
+func (m mockCompressionDialer) DialUnderlyingWithCompression(vmi *v1.VirtualMachineInstance) (net.Conn, bool, *errors.StatusError) {
+	return m.dialUnderlyingWithCompression(vmi)
+}
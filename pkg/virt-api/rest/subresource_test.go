@@ -25,9 +25,14 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -39,6 +44,7 @@ import (
 	gomegatypes "github.com/onsi/gomega/types"
 	"go.uber.org/mock/gomock"
 
+	authv1 "k8s.io/api/authorization/v1"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -895,6 +901,278 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		)
 	})
 
+	Context("Subresource api - dry-run result", func() {
+		setBody := func(options interface{}) {
+			bytesRepresentation, err := json.Marshal(options)
+			Expect(err).ToNot(HaveOccurred())
+			request.Request.Body = io.NopCloser(bytes.NewReader(bytesRepresentation))
+		}
+
+		expectDryRunResult := func() *v1.DryRunResult {
+			Expect(recorder.Code).To(Equal(http.StatusAccepted))
+			result := &v1.DryRunResult{}
+			Expect(json.NewDecoder(recorder.Body).Decode(result)).To(Succeed())
+			Expect(result.Kind).To(Equal("DryRunResult"))
+			return result
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = testVMName
+			request.PathParameters()["namespace"] = k8smetav1.NamespaceDefault
+		})
+
+		It("should return the patch of a started VirtualMachine", func() {
+			vm := newVirtualMachineWithRunStrategy(v1.RunStrategyHalted)
+			patchedVM := vm.DeepCopy()
+			patchedVM.Spec.RunStrategy = pointer.P(v1.RunStrategyAlways)
+			setBody(&v1.StartOptions{DryRun: withDryRun()})
+
+			vmClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(vm, nil)
+			vmiClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), vm.Name))
+			vmClient.EXPECT().Patch(context.Background(), vm.Name, types.JSONPatchType, gomock.Any(), k8smetav1.PatchOptions{DryRun: withDryRun()}).Return(patchedVM, nil)
+
+			app.StartVMRequestHandler(request, response)
+
+			result := expectDryRunResult()
+			Expect(result.Changes).To(HaveLen(1))
+			Expect(result.Changes[0].Kind).To(Equal("VirtualMachine"))
+			Expect(result.Changes[0].Name).To(Equal(vm.Name))
+			Expect(result.Changes[0].Operation).To(Equal(v1.DryRunOperationPatch))
+			Expect(result.Changes[0].Patch).To(ContainSubstring(`"path":"/spec/runStrategy","value":"Always"`))
+
+			returnedVM := &v1.VirtualMachine{}
+			Expect(json.Unmarshal(result.Changes[0].Object.Raw, returnedVM)).To(Succeed())
+			Expect(returnedVM.Spec.RunStrategy).To(HaveValue(Equal(v1.RunStrategyAlways)))
+		})
+
+		It("should not return a body without dry-run", func() {
+			vm := newVirtualMachineWithRunStrategy(v1.RunStrategyHalted)
+			setBody(&v1.StartOptions{})
+
+			vmClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(vm, nil)
+			vmiClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), vm.Name))
+			vmClient.EXPECT().Patch(context.Background(), vm.Name, types.JSONPatchType, gomock.Any(), k8smetav1.PatchOptions{}).Return(vm, nil)
+
+			app.StartVMRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusAccepted))
+			Expect(recorder.Body.Len()).To(BeZero())
+		})
+
+		It("should return the grace period patch and the stop request of a stopped VirtualMachine", func() {
+			vm := newVirtualMachineWithRunStrategy(v1.RunStrategyManual)
+			vmi := newVirtualMachineInstanceInPhase(v1.Running)
+			vmi.Name = vm.Name
+			setBody(&v1.StopOptions{GracePeriod: pointer.P(int64(0)), DryRun: withDryRun()})
+
+			vmClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(vm, nil)
+			vmiClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(vmi, nil)
+			vmiClient.EXPECT().Patch(context.Background(), vmi.Name, types.JSONPatchType, gomock.Any(), k8smetav1.PatchOptions{DryRun: withDryRun()}).Return(vmi, nil)
+			vmClient.EXPECT().PatchStatus(context.Background(), vm.Name, types.JSONPatchType, gomock.Any(), k8smetav1.PatchOptions{DryRun: withDryRun()}).Return(vm, nil)
+
+			app.StopVMRequestHandler(request, response)
+
+			result := expectDryRunResult()
+			Expect(result.Changes).To(HaveLen(2))
+			Expect(result.Changes[0].Kind).To(Equal("VirtualMachineInstance"))
+			Expect(result.Changes[0].Operation).To(Equal(v1.DryRunOperationPatch))
+			Expect(result.Changes[0].Patch).To(ContainSubstring("/spec/terminationGracePeriodSeconds"))
+			Expect(result.Changes[1].Kind).To(Equal("VirtualMachine"))
+			Expect(result.Changes[1].Operation).To(Equal(v1.DryRunOperationPatchStatus))
+			Expect(result.Changes[1].Patch).To(ContainSubstring(string(v1.StopRequest)))
+		})
+
+		It("should not delete the pod of a force restarted VirtualMachine", func() {
+			vm := newVirtualMachineWithRunStrategy(v1.RunStrategyAlways)
+			vmi := newVirtualMachineInstanceInPhase(v1.Running)
+			setBody(&v1.RestartOptions{GracePeriodSeconds: pointer.P(int64(0)), DryRun: withDryRun()})
+
+			pod := k8sv1.Pod{}
+			pod.Name = "virt-launcher-testvm"
+			pod.Labels = map[string]string{
+				v1.AppLabel:       "virt-launcher",
+				v1.CreatedByLabel: string(vmi.UID),
+			}
+			kubeClient.Fake.PrependReactor("list", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				return true, &k8sv1.PodList{Items: []k8sv1.Pod{pod}}, nil
+			})
+			kubeClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				deleteAction, ok := action.(testing.DeleteAction)
+				Expect(ok).To(BeTrue())
+				Expect(deleteAction.GetDeleteOptions().DryRun).To(Equal(withDryRun()))
+				return true, nil, nil
+			})
+			vmClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(vm, nil)
+			vmiClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(vmi, nil)
+			vmClient.EXPECT().PatchStatus(context.Background(), vm.Name, types.JSONPatchType, gomock.Any(), k8smetav1.PatchOptions{DryRun: withDryRun()}).Return(vm, nil)
+
+			app.RestartVMRequestHandler(request, response)
+
+			result := expectDryRunResult()
+			Expect(result.Changes).To(HaveLen(2))
+			Expect(result.Changes[0].Kind).To(Equal("VirtualMachine"))
+			Expect(result.Changes[0].Operation).To(Equal(v1.DryRunOperationPatchStatus))
+			Expect(result.Changes[1]).To(Equal(v1.DryRunChange{Kind: "Pod", Name: pod.Name, Operation: v1.DryRunOperationDelete}))
+		})
+
+		It("should return the migration of a migrated VirtualMachine", func() {
+			vmi := newVirtualMachineInstanceInPhase(v1.Running)
+			setBody(&v1.MigrateOptions{DryRun: withDryRun()})
+			migration := &v1.VirtualMachineInstanceMigration{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "kubevirt-migrate-vm-abcde"},
+				Spec:       v1.VirtualMachineInstanceMigrationSpec{VMIName: testVMName},
+			}
+
+			vmClient.EXPECT().Get(context.Background(), testVMName, k8smetav1.GetOptions{}).Return(&v1.VirtualMachine{}, nil)
+			vmiClient.EXPECT().Get(context.Background(), testVMName, k8smetav1.GetOptions{}).Return(vmi, nil)
+			migrateClient.EXPECT().Create(context.Background(), gomock.Any(), k8smetav1.CreateOptions{DryRun: withDryRun()}).Return(migration, nil)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			result := expectDryRunResult()
+			Expect(result.Changes).To(HaveLen(1))
+			Expect(result.Changes[0].Kind).To(Equal("VirtualMachineInstanceMigration"))
+			Expect(result.Changes[0].Operation).To(Equal(v1.DryRunOperationCreate))
+
+			returnedMigration := &v1.VirtualMachineInstanceMigration{}
+			Expect(json.Unmarshal(result.Changes[0].Object.Raw, returnedMigration)).To(Succeed())
+			Expect(returnedMigration.Name).To(Equal(migration.Name))
+			Expect(returnedMigration.Spec.VMIName).To(Equal(testVMName))
+		})
+
+	})
+
+	Context("Subresource api - bulk lifecycle", func() {
+		var authorizor *MockVirtApiAuthorizor
+
+		setBody := func(options *v1.BulkLifecycleOptions) {
+			bytesRepresentation, err := json.Marshal(options)
+			Expect(err).ToNot(HaveOccurred())
+			request.Request.Body = io.NopCloser(bytes.NewReader(bytesRepresentation))
+		}
+
+		newHaltedVM := func(name string) *v1.VirtualMachine {
+			vm := newVirtualMachineWithRunStrategy(v1.RunStrategyHalted)
+			vm.Name = name
+			return vm
+		}
+
+		expectStart := func(vm *v1.VirtualMachine, dryRun []string) {
+			patchedVM := vm.DeepCopy()
+			patchedVM.Spec.RunStrategy = pointer.P(v1.RunStrategyAlways)
+			vmClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(vm, nil)
+			vmiClient.EXPECT().Get(context.Background(), vm.Name, k8smetav1.GetOptions{}).Return(nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), vm.Name))
+			vmClient.EXPECT().Patch(context.Background(), vm.Name, types.JSONPatchType, gomock.Any(), k8smetav1.PatchOptions{DryRun: dryRun}).Return(patchedVM, nil)
+		}
+
+		expectAuthorization := func(name string, allowed bool) {
+			authorizor.EXPECT().AuthorizeResource(request, &authv1.ResourceAttributes{
+				Namespace:   k8smetav1.NamespaceDefault,
+				Verb:        "update",
+				Group:       v1.SubresourceGroupName,
+				Version:     v1.SubresourceStorageGroupVersion.Version,
+				Resource:    "virtualmachines",
+				Subresource: string(v1.BulkLifecycleActionStart),
+				Name:        name,
+			}).Return(allowed, "denied", nil)
+		}
+
+		expectBulkLifecycleResult := func() *v1.BulkLifecycleResult {
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			result := &v1.BulkLifecycleResult{}
+			Expect(json.NewDecoder(recorder.Body).Decode(result)).To(Succeed())
+			Expect(result.Kind).To(Equal("BulkLifecycleResult"))
+			return result
+		}
+
+		BeforeEach(func() {
+			authorizor = NewMockVirtApiAuthorizor(ctrl)
+			app.authorizor = authorizor
+			request.PathParameters()["namespace"] = k8smetav1.NamespaceDefault
+		})
+
+		AfterEach(func() {
+			app.authorizor = nil
+		})
+
+		DescribeTable("should reject invalid options", func(options *v1.BulkLifecycleOptions, expectedMessage string) {
+			setBody(options)
+
+			app.BulkLifecycleRequestHandler(request, response)
+
+			status := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(status.Error()).To(ContainSubstring(expectedMessage))
+		},
+			Entry("with an unsupported action",
+				&v1.BulkLifecycleOptions{Action: "pause", Names: []string{"vm-a"}}, "unsupported action"),
+			Entry("with names and a labelSelector",
+				&v1.BulkLifecycleOptions{Action: v1.BulkLifecycleActionStart, Names: []string{"vm-a"}, LabelSelector: &k8smetav1.LabelSelector{}},
+				"mutually exclusive"),
+			Entry("without names and labelSelector",
+				&v1.BulkLifecycleOptions{Action: v1.BulkLifecycleActionStart}, "either names or labelSelector is required"),
+			Entry("with an empty name",
+				&v1.BulkLifecycleOptions{Action: v1.BulkLifecycleActionStart, Names: []string{"vm-a", ""}}, "names must not be empty"),
+		)
+
+		It("should start the named VirtualMachines the caller may start", func() {
+			setBody(&v1.BulkLifecycleOptions{Action: v1.BulkLifecycleActionStart, Names: []string{"vm-b", "vm-a", "vm-a"}})
+			expectAuthorization("vm-a", true)
+			expectAuthorization("vm-b", false)
+			expectStart(newHaltedVM("vm-a"), nil)
+
+			app.BulkLifecycleRequestHandler(request, response)
+
+			result := expectBulkLifecycleResult()
+			Expect(result.Results).To(HaveLen(2))
+			Expect(result.Results[0].Name).To(Equal("vm-a"))
+			Expect(result.Results[0].Code).To(BeEquivalentTo(http.StatusAccepted))
+			Expect(result.Results[0].Changes).To(BeEmpty())
+			Expect(result.Results[1].Name).To(Equal("vm-b"))
+			Expect(result.Results[1].Code).To(BeEquivalentTo(http.StatusForbidden))
+			Expect(result.Results[1].Reason).To(Equal(k8smetav1.StatusReasonForbidden))
+			Expect(result.Results[1].Message).To(ContainSubstring("denied"))
+		})
+
+		It("should return the failure of a single VirtualMachine", func() {
+			setBody(&v1.BulkLifecycleOptions{Action: v1.BulkLifecycleActionStart, Names: []string{"vm-a"}})
+			expectAuthorization("vm-a", true)
+			vmClient.EXPECT().Get(context.Background(), "vm-a", k8smetav1.GetOptions{}).Return(nil, errors.NewNotFound(v1.Resource("virtualmachine"), "vm-a"))
+
+			app.BulkLifecycleRequestHandler(request, response)
+
+			result := expectBulkLifecycleResult()
+			Expect(result.Results).To(HaveLen(1))
+			Expect(result.Results[0].Code).To(BeEquivalentTo(http.StatusNotFound))
+			Expect(result.Results[0].Reason).To(Equal(k8smetav1.StatusReasonNotFound))
+		})
+
+		It("should return the changes of the VirtualMachines selected by labels on dry-run", func() {
+			selector := &k8smetav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+			setBody(&v1.BulkLifecycleOptions{Action: v1.BulkLifecycleActionStart, LabelSelector: selector, DryRun: withDryRun()})
+
+			vmA, vmB := newHaltedVM("vm-a"), newHaltedVM("vm-b")
+			vmClient.EXPECT().List(context.Background(), k8smetav1.ListOptions{LabelSelector: "app=test"}).
+				Return(&v1.VirtualMachineList{Items: []v1.VirtualMachine{*vmB, *vmA}}, nil)
+			expectAuthorization("vm-a", true)
+			expectAuthorization("vm-b", true)
+			expectStart(vmA, withDryRun())
+			expectStart(vmB, withDryRun())
+
+			app.BulkLifecycleRequestHandler(request, response)
+
+			result := expectBulkLifecycleResult()
+			Expect(result.Results).To(HaveLen(2))
+			for i, name := range []string{"vm-a", "vm-b"} {
+				Expect(result.Results[i].Name).To(Equal(name))
+				Expect(result.Results[i].Code).To(BeEquivalentTo(http.StatusAccepted))
+				Expect(result.Results[i].Changes).To(HaveLen(1))
+				Expect(result.Results[i].Changes[0].Kind).To(Equal("VirtualMachine"))
+				Expect(result.Results[i].Changes[0].Name).To(Equal(name))
+				Expect(result.Results[i].Changes[0].Operation).To(Equal(v1.DryRunOperationPatch))
+			}
+		})
+	})
+
 	Context("Subresource api - Guest OS Info", func() {
 		type subRes func(request *restful.Request, response *restful.Response)
 
@@ -913,6 +1191,9 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			Entry("for GuestOSInfo", app.GuestOSInfo),
 			Entry("for UserList", app.UserList),
 			Entry("for Filesystem", app.FilesystemList),
+			Entry("for ResourceUsage", app.ResourceUsage),
+			Entry("for DomainStats", app.DomainStats),
+			Entry("for Domain", app.Domain),
 		)
 
 		DescribeTable("should fail when the VMI is not running", func(fn subRes) {
@@ -932,6 +1213,9 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			Entry("for GuestOSInfo", app.GuestOSInfo),
 			Entry("for UserList", app.UserList),
 			Entry("for FilesystemList", app.FilesystemList),
+			Entry("for ResourceUsage", app.ResourceUsage),
+			Entry("for DomainStats", app.DomainStats),
+			Entry("for Domain", app.Domain),
 		)
 
 		DescribeTable("should fail when VMI does not have agent connected", func(fn subRes) {
@@ -1184,6 +1468,78 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		})
 	})
 
+	Context("Screenshot stream", func() {
+		const screenshotPath = "/v1/namespaces/default/virtualmachineinstances/testvmi/vnc/screenshot"
+
+		newScreenshot := func() []byte {
+			img := image.NewRGBA(image.Rect(0, 0, 16, 8))
+			buf := &bytes.Buffer{}
+			Expect(png.Encode(buf, img)).To(Succeed())
+			return buf.Bytes()
+		}
+
+		streamRequest := func(ctx context.Context, query string) {
+			request = restful.NewRequest((&http.Request{URL: &url.URL{RawQuery: query}}).WithContext(ctx))
+		}
+
+		It("should stream the screenshots as MJPEG until the client disconnects", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			streamRequest(ctx, "stream=true&fps=5")
+			backend.RouteToHandler(http.MethodGet, screenshotPath, ghttp.RespondWith(http.StatusOK, newScreenshot()))
+			expectVMI(Running, UnPaused)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				app.ScreenshotRequestHandler(request, response)
+			}()
+			Eventually(func() int { return len(backend.ReceivedRequests()) }).WithTimeout(5 * time.Second).Should(BeNumerically(">=", 2))
+			cancel()
+			Eventually(done).WithTimeout(5 * time.Second).Should(BeClosed())
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("multipart/x-mixed-replace; boundary=" + screenshotStreamBoundary))
+			frames := multipart.NewReader(recorder.Body, screenshotStreamBoundary)
+			for i := 0; i < 2; i++ {
+				part, err := frames.NextPart()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(part.Header.Get("Content-Type")).To(Equal("image/jpeg"))
+				frame, err := jpeg.Decode(part)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(frame.Bounds()).To(Equal(image.Rect(0, 0, 16, 8)))
+			}
+		})
+
+		It("should fail if the first screenshot is not an image", func() {
+			streamRequest(context.Background(), "stream=true")
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, screenshotPath),
+					ghttp.RespondWith(http.StatusOK, "not an image"),
+				),
+			)
+			expectVMI(Running, UnPaused)
+
+			app.ScreenshotRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusInternalServerError)
+		})
+
+		DescribeTable("should reject an invalid framerate", func(fps string) {
+			streamRequest(context.Background(), "stream=true&fps="+fps)
+
+			app.ScreenshotRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(backend.ReceivedRequests()).To(BeEmpty())
+		},
+			Entry("not a number", "fast"),
+			Entry("zero", "0"),
+			Entry("above the maximum", "6"),
+		)
+	})
+
 	Context("Reset", func() {
 		It("Should reset a running VMI", func() {
 			backend.AppendHandlers(
@@ -1252,6 +1608,78 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 
 			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
 		})
+
+		It("Should fail soft reboot a guest agent disconnected VMI when the ACPI fallback is disabled", func() {
+			expectVMI(true, false, func(vmi *v1.VirtualMachineInstance) {})
+
+			bytesRepresentation, _ := json.Marshal(&v1.SoftRebootOptions{Fallback: v1.SoftRebootFallbackFail})
+			request.Request.Body = io.NopCloser(bytes.NewReader(bytesRepresentation))
+
+			app.SoftRebootVMIRequestHandler(request, response)
+
+			status := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(status.Error()).To(ContainSubstring("the ACPI fallback is disabled"))
+		})
+
+		It("Should reject an unsupported fallback", func() {
+			bytesRepresentation, _ := json.Marshal(&v1.SoftRebootOptions{Fallback: "nmi"})
+			request.Request.Body = io.NopCloser(bytes.NewReader(bytesRepresentation))
+
+			app.SoftRebootVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		DescribeTable("Should forward the fallback to virt-handler", func(options *v1.SoftRebootOptions, expectedFallback v1.SoftRebootFallback) {
+			expectedBody, _ := json.Marshal(&v1.SoftRebootOptions{Fallback: expectedFallback})
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/softreboot"),
+					ghttp.VerifyBody(expectedBody),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMI(true, false, guestAgentConnected)
+
+			bytesRepresentation, _ := json.Marshal(options)
+			request.Request.Body = io.NopCloser(bytes.NewReader(bytesRepresentation))
+
+			app.SoftRebootVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(backend.ReceivedRequests()).To(HaveLen(1))
+		},
+			Entry("defaulting to acpi", &v1.SoftRebootOptions{}, v1.SoftRebootFallbackACPI),
+			Entry("with fail", &v1.SoftRebootOptions{Fallback: v1.SoftRebootFallbackFail}, v1.SoftRebootFallbackFail),
+		)
+	})
+
+	Context("InjectNMI", func() {
+		It("Should inject an NMI into a running VMI", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/injectnmi"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+
+			expectVMI(Running, UnPaused)
+
+			app.InjectNMIVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		DescribeTable("Should fail to inject an NMI into", func(running, paused bool) {
+			expectVMI(running, paused)
+
+			app.InjectNMIVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		},
+			Entry("a not running VMI", NotRunning, UnPaused),
+			Entry("a paused VMI", Running, Paused),
+		)
 	})
 
 	Context("Pausing", func() {
@@ -1311,6 +1739,35 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 
 			Entry("a running VMI with LivenessProbe", Running, UnPaused, withLivenessProbe, &v1.PauseOptions{}, http.StatusForbidden, "Pausing VMIs with LivenessProbe is currently not supported"),
 			Entry("a running VMI with LivenessProbe with dry-run option", Running, UnPaused, withLivenessProbe, &v1.PauseOptions{DryRun: withDryRun()}, http.StatusForbidden, "Pausing VMIs with LivenessProbe is currently not supported"),
+
+			Entry("a running VMI without guest agent with sync-guest option", Running, UnPaused, nilAdditionalOps, &v1.PauseOptions{SyncGuest: true}, http.StatusConflict, "guest agent is required to sync the guest before pausing"),
+		)
+
+		DescribeTable("Should forward the sync-guest option to virt-handler", func(agentCondition v1.VirtualMachineInstanceConditionType) {
+			bytesRepresentation, _ := json.Marshal(&v1.PauseOptions{SyncGuest: true})
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/pause"),
+					ghttp.VerifyBody(bytesRepresentation),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMI(Running, UnPaused, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:   agentCondition,
+					Status: k8sv1.ConditionTrue,
+				})
+			})
+
+			request.Request.Body = io.NopCloser(bytes.NewReader(bytesRepresentation))
+
+			app.PauseVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(backend.ReceivedRequests()).To(HaveLen(1))
+		},
+			Entry("with the QEMU guest agent connected", v1.VirtualMachineInstanceAgentConnected),
+			Entry("with an alternative guest agent connected", v1.VirtualMachineInstanceAlternativeAgentConnected),
 		)
 
 		DescribeTable("Should fail unpausing due to VMI state", func(running bool, paused bool, unpauseOptions *v1.UnpauseOptions, expectedError string) {
@@ -0,0 +1,420 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	authnv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	// SubresourceTokenHeader carries a token issued by the token exchange endpoint.
+	SubresourceTokenHeader = "X-KubeVirt-Subresource-Token"
+
+	subresourceTokenKeySecretKey = "key"
+	subresourceTokenKeySize      = 32
+
+	defaultSubresourceTokenExpirationSeconds = 300
+	maxSubresourceTokenExpirationSeconds     = 600
+)
+
+var tokenExchangeSubresources = map[string]struct{}{
+	"console": {},
+	"vnc":     {},
+}
+
+type subresourceTokenClaims struct {
+	User        string `json:"user"`
+	Caller      string `json:"caller"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Subresource string `json:"subresource"`
+	Expiration  int64  `json:"exp"`
+}
+
+type tokenKeyProvider interface {
+	Key() ([]byte, error)
+}
+
+// SecretTokenKey provides the key used to sign subresource tokens. The key is kept in a
+// Secret in the install namespace, so it is shared by all virt-api replicas, and is
+// created on first use.
+type SecretTokenKey struct {
+	client     kubecli.KubevirtClient
+	namespace  string
+	secretName string
+
+	lock sync.Mutex
+	key  []byte
+}
+
+func NewSecretTokenKey(client kubecli.KubevirtClient, namespace, secretName string) *SecretTokenKey {
+	return &SecretTokenKey{
+		client:     client,
+		namespace:  namespace,
+		secretName: secretName,
+	}
+}
+
+func (k *SecretTokenKey) Key() ([]byte, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if k.key != nil {
+		return k.key, nil
+	}
+
+	secrets := k.client.CoreV1().Secrets(k.namespace)
+	secret, err := secrets.Get(context.Background(), k.secretName, k8smetav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		key := make([]byte, subresourceTokenKeySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		secret, err = secrets.Create(context.Background(), &k8sv1.Secret{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name:      k.secretName,
+				Namespace: k.namespace,
+				Labels: map[string]string{
+					v1.AppLabel: "virt-api",
+				},
+			},
+			Type: k8sv1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				subresourceTokenKeySecretKey: key,
+			},
+		}, k8smetav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			// Another replica was faster
+			secret, err = secrets.Get(context.Background(), k.secretName, k8smetav1.GetOptions{})
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subresource token key: %v", err)
+	}
+
+	key := secret.Data[subresourceTokenKeySecretKey]
+	if len(key) < subresourceTokenKeySize {
+		return nil, fmt.Errorf("secret %s/%s does not contain a valid subresource token key", k.namespace, k.secretName)
+	}
+	k.key = key
+	return k.key, nil
+}
+
+// SubresourceTokens issues and verifies tokens granting access to a subresource of a single VMI.
+// A token is only valid for the caller it was issued to.
+type SubresourceTokens struct {
+	keys          tokenKeyProvider
+	authorizor    VirtApiAuthorizor
+	clusterConfig *virtconfig.ClusterConfig
+	now           func() time.Time
+}
+
+func NewSubresourceTokens(keys tokenKeyProvider, authorizor VirtApiAuthorizor, clusterConfig *virtconfig.ClusterConfig) *SubresourceTokens {
+	return &SubresourceTokens{
+		keys:          keys,
+		authorizor:    authorizor,
+		clusterConfig: clusterConfig,
+		now:           time.Now,
+	}
+}
+
+func (t *SubresourceTokens) sign(payload []byte) ([]byte, error) {
+	key, err := t.keys.Key()
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil), nil
+}
+
+func (t *SubresourceTokens) caller(header http.Header) (string, error) {
+	return getUserName(header, t.authorizor.GetUserHeaders())
+}
+
+func (t *SubresourceTokens) issue(claims *subresourceTokenClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signature, err := t.sign(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (t *SubresourceTokens) verify(token string) (*subresourceTokenClaims, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, fmt.Errorf("malformed token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token: %v", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token: %v", err)
+	}
+
+	expected, err := t.sign(payload)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(signature, expected) {
+		return nil, fmt.Errorf("invalid token signature")
+	}
+
+	claims := &subresourceTokenClaims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, fmt.Errorf("malformed token: %v", err)
+	}
+	if t.now().Unix() >= claims.Expiration {
+		return nil, fmt.Errorf("token expired")
+	}
+
+	return claims, nil
+}
+
+func isSubresourceGroupVersion(version string) bool {
+	for _, gv := range v1.SubresourceGroupVersions {
+		if gv.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize checks the subresource token of a request, if there is one.
+// The returned handled flag is false if the request does not carry a token
+// or the SubresourceTokenExchange feature gate is disabled.
+func (t *SubresourceTokens) Authorize(req *restful.Request) (allowed bool, reason string, handled bool) {
+	if req.Request == nil || req.Request.URL == nil {
+		return false, "", false
+	}
+	token := req.Request.Header.Get(SubresourceTokenHeader)
+	if token == "" || !t.clusterConfig.SubresourceTokenExchangeEnabled() {
+		return false, "", false
+	}
+	if !isAuthenticated(req) {
+		return false, "request is not authenticated", true
+	}
+
+	claims, err := t.verify(token)
+	if err != nil {
+		return false, err.Error(), true
+	}
+
+	caller, err := t.caller(req.Request.Header)
+	if err != nil {
+		return false, err.Error(), true
+	}
+	if caller != claims.Caller {
+		return false, "subresource token was issued to a different caller", true
+	}
+
+	// URL example
+	// /apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/testvmi/vnc
+	pathSplit := strings.Split(req.Request.URL.Path, "/")
+	if len(pathSplit) != namespacedResourceAttributesMinParts ||
+		pathSplit[0] != "" ||
+		pathSplit[1] != "apis" ||
+		pathSplit[2] != v1.SubresourceGroupName ||
+		!isSubresourceGroupVersion(pathSplit[3]) ||
+		pathSplit[4] != "namespaces" ||
+		pathSplit[5] != claims.Namespace ||
+		pathSplit[6] != "virtualmachineinstances" ||
+		pathSplit[7] != claims.Name ||
+		pathSplit[8] != claims.Subresource {
+		return false, "subresource token is not valid for the requested resource", true
+	}
+
+	log.Log.V(3).Infof("Authorized %s of vmi %s/%s for user %s by subresource token",
+		claims.Subresource, claims.Namespace, claims.Name, claims.User)
+	return true, "", true
+}
+
+// WithSubresourceTokens enables issuing subresource tokens by the token exchange endpoint
+func (app *SubresourceAPIApp) WithSubresourceTokens(tokens *SubresourceTokens) *SubresourceAPIApp {
+	app.subresourceTokens = tokens
+	return app
+}
+
+// TokenExchangeRequestHandler exchanges an OIDC ID token for a token granting access to a subresource of a VMI.
+// The ID token is validated by the Kubernetes API server against its configured issuers and the
+// authenticated user needs to be allowed to access the requested subresource.
+func (app *SubresourceAPIApp) TokenExchangeRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if !app.clusterConfig.SubresourceTokenExchangeEnabled() {
+		writeError(errors.NewBadRequest("SubresourceTokenExchange feature gate not enabled: Unable to exchange token."), response)
+		return
+	}
+	if app.subresourceTokens == nil {
+		writeError(errors.NewInternalError(fmt.Errorf("subresource tokens are not configured")), response)
+		return
+	}
+
+	opts := &v1.TokenExchangeOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body: token exchange parameters are required"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	if err := decodeBody(request, opts); err != nil {
+		writeError(err, response)
+		return
+	}
+	if opts.IDToken == "" {
+		writeError(errors.NewBadRequest("idToken must be specified"), response)
+		return
+	}
+	if _, ok := tokenExchangeSubresources[opts.Subresource]; !ok {
+		writeError(errors.NewBadRequest(fmt.Sprintf("unsupported subresource %q, supported are console and vnc", opts.Subresource)), response)
+		return
+	}
+	expirationSeconds := int64(defaultSubresourceTokenExpirationSeconds)
+	if opts.ExpirationSeconds != nil {
+		if *opts.ExpirationSeconds <= 0 || *opts.ExpirationSeconds > maxSubresourceTokenExpirationSeconds {
+			writeError(errors.NewBadRequest(fmt.Sprintf("expirationSeconds must be between 1 and %d", maxSubresourceTokenExpirationSeconds)), response)
+			return
+		}
+		expirationSeconds = *opts.ExpirationSeconds
+	}
+
+	if _, statusErr := app.FetchVirtualMachineInstance(namespace, name); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	caller, err := app.subresourceTokens.caller(request.Request.Header)
+	if err != nil {
+		writeError(errors.NewUnauthorized(err.Error()), response)
+		return
+	}
+
+	user, statusErr := app.authenticateIDToken(opts.IDToken)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	if statusErr := app.authorizeSubresourceAccess(user, namespace, name, opts.Subresource); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	expiration := app.subresourceTokens.now().Add(time.Duration(expirationSeconds) * time.Second)
+	token, err := app.subresourceTokens.issue(&subresourceTokenClaims{
+		User:        user.Username,
+		Caller:      caller,
+		Namespace:   namespace,
+		Name:        name,
+		Subresource: opts.Subresource,
+		Expiration:  expiration.Unix(),
+	})
+	if err != nil {
+		writeError(errors.NewInternalError(fmt.Errorf("failed to issue token: %v", err)), response)
+		return
+	}
+
+	if err := response.WriteEntity(&v1.TokenExchangeResult{
+		Token:               token,
+		ExpirationTimestamp: k8smetav1.NewTime(expiration),
+	}); err != nil {
+		log.Log.Reason(err).Error("Failed to write http response.")
+	}
+}
+
+func (app *SubresourceAPIApp) authenticateIDToken(idToken string) (*authnv1.UserInfo, *errors.StatusError) {
+	review, err := app.virtCli.AuthenticationV1().TokenReviews().Create(context.Background(), &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{
+			Token: idToken,
+		},
+	}, k8smetav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.NewInternalError(fmt.Errorf("unable to review token: %v", err))
+	}
+	if !review.Status.Authenticated {
+		return nil, errors.NewUnauthorized(fmt.Sprintf("idToken is not valid: %s", review.Status.Error))
+	}
+
+	return &review.Status.User, nil
+}
+
+func (app *SubresourceAPIApp) authorizeSubresourceAccess(user *authnv1.UserInfo, namespace, name, subresource string) *errors.StatusError {
+	extra := map[string]authv1.ExtraValue{}
+	for k, v := range user.Extra {
+		extra[k] = authv1.ExtraValue(v)
+	}
+
+	review, err := app.virtCli.AuthorizationV1().SubjectAccessReviews().Create(context.Background(), &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        "get",
+				Group:       v1.SubresourceGroupName,
+				Version:     v1.SubresourceStorageGroupVersion.Version,
+				Resource:    "virtualmachineinstances",
+				Subresource: subresource,
+				Name:        name,
+			},
+		},
+	}, k8smetav1.CreateOptions{})
+	if err != nil {
+		return errors.NewInternalError(fmt.Errorf("unable to review access: %v", err))
+	}
+	if !review.Status.Allowed {
+		return errors.NewForbidden(v1.Resource("virtualmachineinstances/"+subresource), name,
+			fmt.Errorf("user %s is not allowed to access %s: %s", user.Username, subresource, review.Status.Reason))
+	}
+
+	return nil
+}
@@ -0,0 +1,333 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+type staticTokenKey []byte
+
+func (k staticTokenKey) Key() ([]byte, error) {
+	return k, nil
+}
+
+var _ = Describe("Token exchange", func() {
+	const (
+		idToken    = "id-token"
+		userName   = "oidc:jdoe"
+		callerName = "system:serviceaccount:console:proxy"
+	)
+
+	var (
+		kvClient   *kubecli.MockKubevirtClient
+		kubeClient *fake.Clientset
+		kvStore    cache.Store
+		kv         *v1.KubeVirt
+		app        *SubresourceAPIApp
+		tokens     *SubresourceTokens
+		recorder   *httptest.ResponseRecorder
+		authorized bool
+		reviewed   *authv1.SubjectAccessReview
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kvClient = kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fake.NewClientset()
+		virtClient := kubevirtfake.NewSimpleClientset(
+			libvmi.New(libvmi.WithName(testVMIName), libvmi.WithNamespace(metav1.NamespaceDefault)),
+		)
+
+		kvClient.EXPECT().AuthenticationV1().Return(kubeClient.AuthenticationV1()).AnyTimes()
+		kvClient.EXPECT().AuthorizationV1().Return(kubeClient.AuthorizationV1()).AnyTimes()
+		kvClient.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault)).AnyTimes()
+
+		kubeClient.Fake.PrependReactor("create", "tokenreviews", func(action testing.Action) (bool, runtime.Object, error) {
+			review := action.(testing.CreateAction).GetObject().(*authnv1.TokenReview)
+			if review.Spec.Token == idToken {
+				review.Status.Authenticated = true
+				review.Status.User = authnv1.UserInfo{Username: userName, Groups: []string{"oidc:admins"}}
+			} else {
+				review.Status.Error = "invalid bearer token"
+			}
+			return true, review, nil
+		})
+		authorized = true
+		reviewed = nil
+		kubeClient.Fake.PrependReactor("create", "subjectaccessreviews", func(action testing.Action) (bool, runtime.Object, error) {
+			reviewed = action.(testing.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+			reviewed.Status.Allowed = authorized
+			return true, reviewed, nil
+		})
+
+		kv = &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{
+						FeatureGates: []string{featuregate.SubresourceTokenExchange},
+					},
+				},
+			},
+			Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeployed},
+		}
+		config, _, store := testutils.NewFakeClusterConfigUsingKV(kv)
+		kvStore = store
+
+		authorizor := NewAuthorizorFromClient(kubeClient.AuthorizationV1().SubjectAccessReviews())
+		authorizor.AddUserHeaders([]string{userHeader})
+		tokens = NewSubresourceTokens(staticTokenKey("0123456789abcdef0123456789abcdef"), authorizor, config)
+		app = NewSubresourceAPIApp(kvClient, 0, &tls.Config{InsecureSkipVerify: true}, config).WithSubresourceTokens(tokens)
+		recorder = httptest.NewRecorder()
+	})
+
+	exchange := func(opts *v1.TokenExchangeOptions) {
+		body, err := json.Marshal(opts)
+		Expect(err).ToNot(HaveOccurred())
+		request := restful.NewRequest(&http.Request{
+			Body:   io.NopCloser(bytes.NewReader(body)),
+			Header: http.Header{userHeader: []string{callerName}},
+		})
+		request.PathParameters()["name"] = testVMIName
+		request.PathParameters()["namespace"] = metav1.NamespaceDefault
+		response := restful.NewResponse(recorder)
+		response.SetRequestAccepts(restful.MIME_JSON)
+		app.TokenExchangeRequestHandler(request, response)
+	}
+
+	subresourceRequest := func(path, token string) *restful.Request {
+		req := restful.NewRequest(&http.Request{
+			URL:    &url.URL{Path: path},
+			Header: http.Header{userHeader: []string{callerName}},
+			TLS:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{}}},
+		})
+		if token != "" {
+			req.Request.Header.Set(SubresourceTokenHeader, token)
+		}
+		return req
+	}
+
+	vncPath := "/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/" + testVMIName + "/vnc"
+
+	It("should fail if the feature gate is not enabled", func() {
+		newKV := kv.DeepCopy()
+		newKV.Spec.Configuration.DeveloperConfiguration.FeatureGates = nil
+		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, newKV)
+
+		exchange(&v1.TokenExchangeOptions{IDToken: idToken, Subresource: "vnc"})
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should issue a token valid for the requested subresource only", func() {
+		exchange(&v1.TokenExchangeOptions{IDToken: idToken, Subresource: "vnc"})
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		Expect(reviewed).ToNot(BeNil())
+		Expect(reviewed.Spec.User).To(Equal(userName))
+		Expect(reviewed.Spec.ResourceAttributes).To(Equal(&authv1.ResourceAttributes{
+			Namespace:   metav1.NamespaceDefault,
+			Verb:        "get",
+			Group:       v1.SubresourceGroupName,
+			Version:     "v1",
+			Resource:    "virtualmachineinstances",
+			Subresource: "vnc",
+			Name:        testVMIName,
+		}))
+
+		result := &v1.TokenExchangeResult{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+		Expect(result.ExpirationTimestamp.Time).To(BeTemporally("~", time.Now().Add(defaultSubresourceTokenExpirationSeconds*time.Second), 5*time.Second))
+
+		allowed, _, handled := tokens.Authorize(subresourceRequest(vncPath, result.Token))
+		Expect(handled).To(BeTrue())
+		Expect(allowed).To(BeTrue())
+
+		allowed, reason, handled := tokens.Authorize(subresourceRequest(
+			"/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/"+testVMIName+"/console", result.Token))
+		Expect(handled).To(BeTrue())
+		Expect(allowed).To(BeFalse())
+		Expect(reason).To(Equal("subresource token is not valid for the requested resource"))
+
+		allowed, _, handled = tokens.Authorize(subresourceRequest(
+			"/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/other-vmi/vnc", result.Token))
+		Expect(handled).To(BeTrue())
+		Expect(allowed).To(BeFalse())
+	})
+
+	It("should reject expired and tampered tokens", func() {
+		exchange(&v1.TokenExchangeOptions{IDToken: idToken, Subresource: "vnc", ExpirationSeconds: pointer.P(int64(60))})
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		result := &v1.TokenExchangeResult{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+
+		allowed, reason, _ := tokens.Authorize(subresourceRequest(vncPath, "x"+result.Token))
+		Expect(allowed).To(BeFalse())
+		Expect(reason).ToNot(BeEmpty())
+
+		tokens.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+		allowed, reason, _ = tokens.Authorize(subresourceRequest(vncPath, result.Token))
+		Expect(allowed).To(BeFalse())
+		Expect(reason).To(Equal("token expired"))
+	})
+
+	DescribeTable("should reject a token for", func(path string) {
+		exchange(&v1.TokenExchangeOptions{IDToken: idToken, Subresource: "vnc"})
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		result := &v1.TokenExchangeResult{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+
+		allowed, reason, handled := tokens.Authorize(subresourceRequest(path, result.Token))
+		Expect(handled).To(BeTrue())
+		Expect(allowed).To(BeFalse())
+		Expect(reason).To(Equal("subresource token is not valid for the requested resource"))
+	},
+		Entry("a deeper path", "/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/"+testVMIName+"/vnc/screenshot"),
+		Entry("another API group", "/apis/other.kubevirt.io/v1/namespaces/default/virtualmachineinstances/"+testVMIName+"/vnc"),
+		Entry("an unknown version", "/apis/subresources.kubevirt.io/v2/namespaces/default/virtualmachineinstances/"+testVMIName+"/vnc"),
+		Entry("a path without namespaces", "/apis/subresources.kubevirt.io/v1/clusters/default/virtualmachineinstances/"+testVMIName+"/vnc"),
+		Entry("a relative path", "apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/"+testVMIName+"/vnc"),
+	)
+
+	It("should reject a token presented by another caller", func() {
+		exchange(&v1.TokenExchangeOptions{IDToken: idToken, Subresource: "vnc"})
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		result := &v1.TokenExchangeResult{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+
+		req := subresourceRequest(vncPath, result.Token)
+		req.Request.Header.Set(userHeader, "system:serviceaccount:default:other")
+		allowed, reason, handled := tokens.Authorize(req)
+		Expect(handled).To(BeTrue())
+		Expect(allowed).To(BeFalse())
+		Expect(reason).To(Equal("subresource token was issued to a different caller"))
+	})
+
+	It("should reject a token signed with another key", func() {
+		exchange(&v1.TokenExchangeOptions{IDToken: idToken, Subresource: "vnc"})
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		result := &v1.TokenExchangeResult{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+
+		tokens.keys = staticTokenKey("fedcba9876543210fedcba9876543210")
+		allowed, reason, _ := tokens.Authorize(subresourceRequest(vncPath, result.Token))
+		Expect(allowed).To(BeFalse())
+		Expect(reason).To(Equal("invalid token signature"))
+	})
+
+	It("should not handle requests without a token", func() {
+		_, _, handled := tokens.Authorize(subresourceRequest(vncPath, ""))
+		Expect(handled).To(BeFalse())
+	})
+
+	It("should not handle tokens if the feature gate is not enabled", func() {
+		newKV := kv.DeepCopy()
+		newKV.Spec.Configuration.DeveloperConfiguration.FeatureGates = nil
+		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, newKV)
+
+		_, _, handled := tokens.Authorize(subresourceRequest(vncPath, "token"))
+		Expect(handled).To(BeFalse())
+	})
+
+	DescribeTable("should fail to exchange", func(opts *v1.TokenExchangeOptions, allowed bool, expectedCode int) {
+		authorized = allowed
+		exchange(opts)
+		Expect(recorder.Code).To(Equal(expectedCode))
+	},
+		Entry("an invalid ID token", &v1.TokenExchangeOptions{IDToken: "invalid", Subresource: "vnc"}, true, http.StatusUnauthorized),
+		Entry("without an ID token", &v1.TokenExchangeOptions{Subresource: "vnc"}, true, http.StatusBadRequest),
+		Entry("for an unsupported subresource", &v1.TokenExchangeOptions{IDToken: idToken, Subresource: "portforward"}, true, http.StatusBadRequest),
+		Entry("with a too long expiration", &v1.TokenExchangeOptions{IDToken: idToken, Subresource: "vnc", ExpirationSeconds: pointer.P(int64(3600))}, true, http.StatusBadRequest),
+		Entry("when the user is not allowed to access the subresource", &v1.TokenExchangeOptions{IDToken: idToken, Subresource: "console"}, false, http.StatusForbidden),
+	)
+})
+
+var _ = Describe("Subresource token key", func() {
+	const secretName = "token-key"
+
+	var (
+		kvClient   *kubecli.MockKubevirtClient
+		kubeClient *fake.Clientset
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kvClient = kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fake.NewClientset()
+		kvClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+	})
+
+	It("should create the secret with a random key if it does not exist", func() {
+		key, err := NewSecretTokenKey(kvClient, "kubevirt", secretName).Key()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(key).To(HaveLen(subresourceTokenKeySize))
+
+		secret, err := kubeClient.CoreV1().Secrets("kubevirt").Get(context.Background(), secretName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Data).To(HaveKeyWithValue(subresourceTokenKeySecretKey, key))
+
+		otherKey, err := NewSecretTokenKey(kvClient, "kubevirt", secretName).Key()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(otherKey).To(Equal(key))
+	})
+
+	It("should fail if the secret does not contain a valid key", func() {
+		_, err := kubeClient.CoreV1().Secrets("kubevirt").Create(context.Background(), &k8sv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "kubevirt"},
+			Data:       map[string][]byte{subresourceTokenKeySecretKey: []byte("short")},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = NewSecretTokenKey(kvClient, "kubevirt", secretName).Key()
+		Expect(err).To(MatchError(ContainSubstring("does not contain a valid subresource token key")))
+	})
+})
@@ -43,7 +43,7 @@ func (app *SubresourceAPIApp) USBRedirRequestHandler(request *restful.Request, r
 		app.virtHandlerDialer(func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
 			return conn.USBRedirURI(vmi)
 		}),
-	)
+	).WithSessions(app.subresourceSessions, "usbredir").WithLimiter(app.subresourceLimiter)
 
 	streamer.Handle(request, response)
 }
@@ -0,0 +1,241 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/emicklei/go-restful/v3"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+const (
+	VMISummariesSortByName  = "name"
+	VMISummariesSortByPhase = "phase"
+	VMISummariesSortByNode  = "node"
+
+	vmiSummariesDefaultLimit = 500
+	vmiSummariesMaxLimit     = 5000
+)
+
+// VMISummaries serves pages of VirtualMachineInstance summaries from a cache, so that UIs
+// refreshing large lists do not have to list all VirtualMachineInstances from the kube-apiserver.
+// The cache is only started on the first request.
+type VMISummaries struct {
+	clusterConfig *virtconfig.ClusterConfig
+	newInformer   func() cache.SharedIndexInformer
+
+	once     sync.Once
+	informer cache.SharedIndexInformer
+}
+
+func NewVMISummaries(virtCli kubecli.KubevirtClient, clusterConfig *virtconfig.ClusterConfig) *VMISummaries {
+	return &VMISummaries{
+		clusterConfig: clusterConfig,
+		newInformer: func() cache.SharedIndexInformer {
+			lw := cache.NewListWatchFromClient(virtCli.RestClient(), "virtualmachineinstances", k8sv1.NamespaceAll, fields.Everything())
+			return cache.NewSharedIndexInformer(lw, &v1.VirtualMachineInstance{}, 0, cache.Indexers{
+				cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+			})
+		},
+	}
+}
+
+type vmiSummariesOptions struct {
+	selector labels.Selector
+	sortBy   string
+	limit    int
+	offset   int
+}
+
+func (s *VMISummaries) ListRequestHandler(request *restful.Request, response *restful.Response) {
+	if !s.clusterConfig.VMISummariesEnabled() {
+		writeError(errors.NewBadRequest(fmt.Sprintf(featureGateDisabledErrFmt, featuregate.VMISummaries)), response)
+		return
+	}
+
+	opts, err := parseVMISummariesOptions(request)
+	if err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+
+	informer, err := s.syncedInformer(request.Request.Context())
+	if err != nil {
+		writeError(errors.NewServiceUnavailable(err.Error()), response)
+		return
+	}
+
+	objs, err := informer.GetIndexer().ByIndex(cache.NamespaceIndex, request.PathParameter("namespace"))
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	list := newVMISummaryList(objs, opts)
+	list.ResourceVersion = informer.LastSyncResourceVersion()
+
+	if err := response.WriteEntity(list); err != nil {
+		log.Log.Reason(err).Error("Failed to write HTTP response.")
+	}
+}
+
+func (s *VMISummaries) syncedInformer(ctx context.Context) (cache.SharedIndexInformer, error) {
+	s.once.Do(func() {
+		s.informer = s.newInformer()
+		go s.informer.Run(wait.NeverStop)
+	})
+
+	if !cache.WaitForCacheSync(ctx.Done(), s.informer.HasSynced) {
+		return nil, fmt.Errorf("the VirtualMachineInstance cache is not synced yet")
+	}
+	return s.informer, nil
+}
+
+func parseVMISummariesOptions(request *restful.Request) (*vmiSummariesOptions, error) {
+	opts := &vmiSummariesOptions{
+		selector: labels.Everything(),
+		sortBy:   VMISummariesSortByName,
+		limit:    vmiSummariesDefaultLimit,
+	}
+
+	if selector := request.QueryParameter("labelSelector"); selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labelSelector: %v", err)
+		}
+		opts.selector = parsed
+	}
+
+	switch sortBy := request.QueryParameter("sortBy"); sortBy {
+	case "":
+	case VMISummariesSortByName, VMISummariesSortByPhase, VMISummariesSortByNode:
+		opts.sortBy = sortBy
+	default:
+		return nil, fmt.Errorf("invalid sortBy %q, supported values are: %s, %s, %s",
+			sortBy, VMISummariesSortByName, VMISummariesSortByPhase, VMISummariesSortByNode)
+	}
+
+	if limit := request.QueryParameter("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 || parsed > vmiSummariesMaxLimit {
+			return nil, fmt.Errorf("invalid limit %q, must be between 1 and %d", limit, vmiSummariesMaxLimit)
+		}
+		opts.limit = parsed
+	}
+
+	// The continue token is the offset of the next page. Pages are computed from the
+	// current content of the cache, so changes between requests can shift items.
+	if token := request.QueryParameter("continue"); token != "" {
+		parsed, err := strconv.Atoi(token)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid continue token %q", token)
+		}
+		opts.offset = parsed
+	}
+
+	return opts, nil
+}
+
+func newVMISummaryList(objs []interface{}, opts *vmiSummariesOptions) *v1.VirtualMachineInstanceSummaryList {
+	list := &v1.VirtualMachineInstanceSummaryList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "VirtualMachineInstanceSummaryList",
+			APIVersion: v1.SubresourceStorageGroupVersion.String(),
+		},
+		PhaseCounts: map[v1.VirtualMachineInstancePhase]int{},
+		Items:       []v1.VirtualMachineInstanceSummary{},
+	}
+
+	summaries := make([]v1.VirtualMachineInstanceSummary, 0, len(objs))
+	for _, obj := range objs {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if !opts.selector.Matches(labels.Set(vmi.Labels)) {
+			continue
+		}
+		summaries = append(summaries, newVMISummary(vmi))
+		list.PhaseCounts[vmi.Status.Phase]++
+	}
+	list.Total = len(summaries)
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		switch opts.sortBy {
+		case VMISummariesSortByPhase:
+			if a.Phase != b.Phase {
+				return a.Phase < b.Phase
+			}
+		case VMISummariesSortByNode:
+			if a.NodeName != b.NodeName {
+				return a.NodeName < b.NodeName
+			}
+		}
+		return a.Name < b.Name
+	})
+
+	if opts.offset >= len(summaries) {
+		return list
+	}
+	end := min(opts.offset+opts.limit, len(summaries))
+	list.Items = summaries[opts.offset:end]
+	if remaining := int64(len(summaries) - end); remaining > 0 {
+		list.Continue = strconv.Itoa(end)
+		list.RemainingItemCount = &remaining
+	}
+
+	return list
+}
+
+func newVMISummary(vmi *v1.VirtualMachineInstance) v1.VirtualMachineInstanceSummary {
+	summary := v1.VirtualMachineInstanceSummary{
+		Name:              vmi.Name,
+		Namespace:         vmi.Namespace,
+		CreationTimestamp: vmi.CreationTimestamp,
+		Phase:             vmi.Status.Phase,
+		NodeName:          vmi.Status.NodeName,
+		Ready: controller.NewVirtualMachineInstanceConditionManager().
+			HasConditionWithStatus(vmi, v1.VirtualMachineInstanceReady, k8sv1.ConditionTrue),
+	}
+	for _, iface := range vmi.Status.Interfaces {
+		if iface.IP != "" {
+			summary.IPAddress = iface.IP
+			break
+		}
+	}
+	return summary
+}
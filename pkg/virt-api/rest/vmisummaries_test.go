@@ -0,0 +1,178 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/emicklei/go-restful/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+var _ = Describe("VMI summaries", func() {
+	var (
+		kvStore   cache.Store
+		kv        *v1.KubeVirt
+		summaries *VMISummaries
+		recorder  *httptest.ResponseRecorder
+	)
+
+	newVMI := func(name, namespace string, phase v1.VirtualMachineInstancePhase, node string, opts ...libvmi.Option) *v1.VirtualMachineInstance {
+		vmi := libvmi.New(append(opts, libvmi.WithName(name), libvmi.WithNamespace(namespace))...)
+		vmi.Status.Phase = phase
+		vmi.Status.NodeName = node
+		return vmi
+	}
+
+	BeforeEach(func() {
+		kv = &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{
+						FeatureGates: []string{featuregate.VMISummaries},
+					},
+				},
+			},
+			Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeployed},
+		}
+		config, _, store := testutils.NewFakeClusterConfigUsingKV(kv)
+		kvStore = store
+
+		informer, source := testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		ready := newVMI("vmi-c", metav1.NamespaceDefault, v1.Running, "node02", libvmi.WithLabel("app", "web"))
+		ready.Status.Conditions = []v1.VirtualMachineInstanceCondition{{Type: v1.VirtualMachineInstanceReady, Status: k8sv1.ConditionTrue}}
+		ready.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{{Name: "secondary"}, {Name: "default", IP: "10.0.0.2"}}
+		source.Add(ready)
+		source.Add(newVMI("vmi-a", metav1.NamespaceDefault, v1.Scheduling, "node01", libvmi.WithLabel("app", "db")))
+		source.Add(newVMI("vmi-b", metav1.NamespaceDefault, v1.Running, "node01", libvmi.WithLabel("app", "web")))
+		source.Add(newVMI("vmi-d", "other", v1.Running, "node01"))
+
+		summaries = NewVMISummaries(nil, config)
+		summaries.newInformer = func() cache.SharedIndexInformer { return informer }
+		recorder = httptest.NewRecorder()
+	})
+
+	list := func(query url.Values) *v1.VirtualMachineInstanceSummaryList {
+		request := restful.NewRequest(&http.Request{URL: &url.URL{RawQuery: query.Encode()}})
+		request.PathParameters()["namespace"] = metav1.NamespaceDefault
+		response := restful.NewResponse(recorder)
+		response.SetRequestAccepts(restful.MIME_JSON)
+		summaries.ListRequestHandler(request, response)
+		if recorder.Code != http.StatusOK {
+			return nil
+		}
+		result := &v1.VirtualMachineInstanceSummaryList{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+		return result
+	}
+
+	names := func(list *v1.VirtualMachineInstanceSummaryList) []string {
+		var names []string
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+		return names
+	}
+
+	It("should fail if the feature gate is not enabled", func() {
+		newKV := kv.DeepCopy()
+		newKV.Spec.Configuration.DeveloperConfiguration.FeatureGates = nil
+		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, newKV)
+
+		Expect(list(nil)).To(BeNil())
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should list summaries of the VMIs in the namespace sorted by name", func() {
+		result := list(nil)
+		Expect(result).ToNot(BeNil())
+		Expect(names(result)).To(Equal([]string{"vmi-a", "vmi-b", "vmi-c"}))
+		Expect(result.Total).To(Equal(3))
+		Expect(result.PhaseCounts).To(Equal(map[v1.VirtualMachineInstancePhase]int{v1.Running: 2, v1.Scheduling: 1}))
+		Expect(result.Continue).To(BeEmpty())
+		Expect(result.Items[2]).To(Equal(v1.VirtualMachineInstanceSummary{
+			Name:      "vmi-c",
+			Namespace: metav1.NamespaceDefault,
+			Phase:     v1.Running,
+			NodeName:  "node02",
+			Ready:     true,
+			IPAddress: "10.0.0.2",
+		}))
+	})
+
+	DescribeTable("should sort by", func(sortBy string, expected []string) {
+		result := list(url.Values{"sortBy": {sortBy}})
+		Expect(result).ToNot(BeNil())
+		Expect(names(result)).To(Equal(expected))
+	},
+		Entry("phase", VMISummariesSortByPhase, []string{"vmi-b", "vmi-c", "vmi-a"}),
+		Entry("node", VMISummariesSortByNode, []string{"vmi-a", "vmi-b", "vmi-c"}),
+	)
+
+	It("should filter by label selector", func() {
+		result := list(url.Values{"labelSelector": {"app=web"}})
+		Expect(result).ToNot(BeNil())
+		Expect(names(result)).To(Equal([]string{"vmi-b", "vmi-c"}))
+		Expect(result.Total).To(Equal(2))
+		Expect(result.PhaseCounts).To(Equal(map[v1.VirtualMachineInstancePhase]int{v1.Running: 2}))
+	})
+
+	It("should paginate with continue tokens", func() {
+		result := list(url.Values{"limit": {"2"}})
+		Expect(result).ToNot(BeNil())
+		Expect(names(result)).To(Equal([]string{"vmi-a", "vmi-b"}))
+		Expect(result.Total).To(Equal(3))
+		Expect(result.Continue).ToNot(BeEmpty())
+		Expect(result.RemainingItemCount).To(HaveValue(BeEquivalentTo(1)))
+
+		recorder = httptest.NewRecorder()
+		result = list(url.Values{"limit": {"2"}, "continue": {result.Continue}})
+		Expect(result).ToNot(BeNil())
+		Expect(names(result)).To(Equal([]string{"vmi-c"}))
+		Expect(result.Continue).To(BeEmpty())
+		Expect(result.RemainingItemCount).To(BeNil())
+	})
+
+	DescribeTable("should reject", func(query url.Values) {
+		Expect(list(query)).To(BeNil())
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	},
+		Entry("an invalid label selector", url.Values{"labelSelector": {"app in (web"}}),
+		Entry("an unknown sort field", url.Values{"sortBy": {"memory"}}),
+		Entry("a zero limit", url.Values{"limit": {"0"}}),
+		Entry("a too large limit", url.Values{"limit": {"5001"}}),
+		Entry("an invalid continue token", url.Values{"continue": {"abc"}}),
+	)
+})
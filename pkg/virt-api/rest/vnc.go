@@ -58,12 +58,19 @@ func (app *SubresourceAPIApp) VNCRequestHandler(request *restful.Request, respon
 		app.virtHandlerDialer(func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
 			return conn.VNCURI(vmi, preserveSessionParam)
 		}),
-	)
+	).WithSessions(app.subresourceSessions, "vnc").WithLimiter(app.subresourceLimiter)
 
 	streamer.Handle(request, response)
 }
 
 func (app *SubresourceAPIApp) ScreenshotRequestHandler(request *restful.Request, response *restful.Response) {
+	if request.Request != nil && request.Request.URL != nil {
+		if stream, _ := strconv.ParseBool(request.QueryParameter(definitions.StreamParamName)); stream {
+			app.screenshotStreamHandler(request, response)
+			return
+		}
+	}
+
 	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
 		return conn.ScreenshotURI(vmi)
 	}
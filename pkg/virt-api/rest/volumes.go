@@ -22,7 +22,6 @@ package rest
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/emicklei/go-restful/v3"
 
@@ -110,25 +109,26 @@ func (app *SubresourceAPIApp) addVolumeRequestHandler(request *restful.Request,
 		opts.VolumeSource.PersistentVolumeClaim.Hotpluggable = true
 	}
 
+	recorder := newDryRunRecorder(getDryRunOption(&volumeRequest))
 	// inject into VMI if ephemeral, else set as a request on the VM to both make permanent and hotplug.
 	if ephemeral {
-		if err := app.vmiVolumePatch(name, namespace, &volumeRequest); err != nil {
+		if err := app.vmiVolumePatch(name, namespace, &volumeRequest, recorder); err != nil {
 			writeError(err, response)
 			return
 		}
 	} else if app.clusterConfig.HotplugVolumesEnabled() {
-		if err := app.vmVolumePatchStatus(name, namespace, &volumeRequest); err != nil {
+		if err := app.vmVolumePatchStatus(name, namespace, &volumeRequest, recorder); err != nil {
 			writeError(err, response)
 			return
 		}
 	} else {
-		if err := app.vmVolumePatch(name, namespace, &volumeRequest); err != nil {
+		if err := app.vmVolumePatch(name, namespace, &volumeRequest, recorder); err != nil {
 			writeError(err, response)
 			return
 		}
 	}
 
-	response.WriteHeader(http.StatusAccepted)
+	recorder.writeAccepted(response)
 }
 
 func (app *SubresourceAPIApp) removeVolumeRequestHandler(request *restful.Request, response *restful.Response, ephemeral bool) {
@@ -160,28 +160,29 @@ func (app *SubresourceAPIApp) removeVolumeRequestHandler(request *restful.Reques
 		RemoveVolumeOptions: opts,
 	}
 
+	recorder := newDryRunRecorder(getDryRunOption(&volumeRequest))
 	// inject into VMI if ephemeral, else set as a request on the VM to both make permanent and hotplug.
 	if ephemeral {
-		if err := app.vmiVolumePatch(name, namespace, &volumeRequest); err != nil {
+		if err := app.vmiVolumePatch(name, namespace, &volumeRequest, recorder); err != nil {
 			writeError(err, response)
 			return
 		}
 	} else if app.clusterConfig.HotplugVolumesEnabled() {
-		if err := app.vmVolumePatchStatus(name, namespace, &volumeRequest); err != nil {
+		if err := app.vmVolumePatchStatus(name, namespace, &volumeRequest, recorder); err != nil {
 			writeError(err, response)
 			return
 		}
 	} else {
-		if err := app.vmVolumePatch(name, namespace, &volumeRequest); err != nil {
+		if err := app.vmVolumePatch(name, namespace, &volumeRequest, recorder); err != nil {
 			writeError(err, response)
 			return
 		}
 	}
 
-	response.WriteHeader(http.StatusAccepted)
+	recorder.writeAccepted(response)
 }
 
-func (app *SubresourceAPIApp) vmVolumePatch(name, namespace string, volumeRequest *v1.VirtualMachineVolumeRequest) *errors.StatusError {
+func (app *SubresourceAPIApp) vmVolumePatch(name, namespace string, volumeRequest *v1.VirtualMachineVolumeRequest, recorder *dryRunRecorder) *errors.StatusError {
 	vm, statErr := app.fetchVirtualMachine(name, namespace)
 	if statErr != nil {
 		return statErr
@@ -199,7 +200,8 @@ func (app *SubresourceAPIApp) vmVolumePatch(name, namespace string, volumeReques
 
 	dryRunOption := getDryRunOption(volumeRequest)
 	log.Log.Object(vm).V(4).Infof("Patching VM: %s", string(patchBytes))
-	if _, err := app.virtCli.VirtualMachine(vm.Namespace).Patch(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: dryRunOption}); err != nil {
+	patchedVM, err := app.virtCli.VirtualMachine(vm.Namespace).Patch(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: dryRunOption})
+	if err != nil {
 		log.Log.Object(vm).Errorf("unable to patch vm: %v", err)
 		if errors.IsInvalid(err) {
 			if statErr, ok := err.(*errors.StatusError); ok {
@@ -208,10 +210,11 @@ func (app *SubresourceAPIApp) vmVolumePatch(name, namespace string, volumeReques
 		}
 		return errors.NewInternalError(fmt.Errorf("unable to patch vm: %v", err))
 	}
+	recorder.record(v1.VirtualMachineGroupVersionKind.Kind, vm.Name, v1.DryRunOperationPatch, patchBytes, patchedVM)
 	return nil
 }
 
-func (app *SubresourceAPIApp) vmiVolumePatch(name, namespace string, volumeRequest *v1.VirtualMachineVolumeRequest) *errors.StatusError {
+func (app *SubresourceAPIApp) vmiVolumePatch(name, namespace string, volumeRequest *v1.VirtualMachineVolumeRequest, recorder *dryRunRecorder) *errors.StatusError {
 	vmi, statErr := app.FetchVirtualMachineInstance(namespace, name)
 	if statErr != nil {
 		return statErr
@@ -237,7 +240,8 @@ func (app *SubresourceAPIApp) vmiVolumePatch(name, namespace string, volumeReque
 
 	dryRunOption := getDryRunOption(volumeRequest)
 	log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", string(patchBytes))
-	if _, err := app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(context.Background(), vmi.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: dryRunOption}); err != nil {
+	patchedVMI, err := app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(context.Background(), vmi.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: dryRunOption})
+	if err != nil {
 		log.Log.Object(vmi).Errorf("unable to patch vmi: %v", err)
 		if errors.IsInvalid(err) {
 			if statErr, ok := err.(*errors.StatusError); ok {
@@ -246,10 +250,11 @@ func (app *SubresourceAPIApp) vmiVolumePatch(name, namespace string, volumeReque
 		}
 		return errors.NewInternalError(fmt.Errorf("unable to patch vmi: %v", err))
 	}
+	recorder.record(v1.VirtualMachineInstanceGroupVersionKind.Kind, vmi.Name, v1.DryRunOperationPatch, patchBytes, patchedVMI)
 	return nil
 }
 
-func (app *SubresourceAPIApp) vmVolumePatchStatus(name, namespace string, volumeRequest *v1.VirtualMachineVolumeRequest) *errors.StatusError {
+func (app *SubresourceAPIApp) vmVolumePatchStatus(name, namespace string, volumeRequest *v1.VirtualMachineVolumeRequest, recorder *dryRunRecorder) *errors.StatusError {
 	vm, statErr := app.fetchVirtualMachine(name, namespace)
 	if statErr != nil {
 		return statErr
@@ -267,7 +272,8 @@ func (app *SubresourceAPIApp) vmVolumePatchStatus(name, namespace string, volume
 
 	dryRunOption := getDryRunOption(volumeRequest)
 	log.Log.Object(vm).V(4).Infof(patchingVMFmt, string(patchBytes))
-	if _, err = app.virtCli.VirtualMachine(vm.Namespace).PatchStatus(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: dryRunOption}); err != nil {
+	patchedVM, err := app.virtCli.VirtualMachine(vm.Namespace).PatchStatus(context.Background(), vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: dryRunOption})
+	if err != nil {
 		log.Log.Object(vm).Errorf("unable to patch vm status: %v", err)
 		if errors.IsInvalid(err) {
 			if statErr, ok := err.(*errors.StatusError); ok {
@@ -276,6 +282,7 @@ func (app *SubresourceAPIApp) vmVolumePatchStatus(name, namespace string, volume
 		}
 		return errors.NewInternalError(fmt.Errorf("unable to patch vm status: %v", err))
 	}
+	recorder.record(v1.VirtualMachineGroupVersionKind.Kind, vm.Name, v1.DryRunOperationPatchStatus, patchBytes, patchedVM)
 	return nil
 }
 
@@ -55,6 +55,7 @@ import (
 var _ = Describe("Add/Remove Volume Subresource api", func() {
 	var (
 		request    *restful.Request
+		recorder   *httptest.ResponseRecorder
 		response   *restful.Response
 		virtClient *kubecli.MockKubevirtClient
 		vmClient   *kubecli.MockVirtualMachineInterface
@@ -93,7 +94,7 @@ var _ = Describe("Add/Remove Volume Subresource api", func() {
 		request = restful.NewRequest(&http.Request{})
 		request.PathParameters()["name"] = testVMName
 		request.PathParameters()["namespace"] = metav1.NamespaceDefault
-		recorder := httptest.NewRecorder()
+		recorder = httptest.NewRecorder()
 		response = restful.NewResponse(recorder)
 
 		backend := ghttp.NewTLSServer()
@@ -313,6 +314,35 @@ var _ = Describe("Add/Remove Volume Subresource api", func() {
 		VolumeUpdateTests(featuregate.HotplugVolumesGate)
 	})
 
+	It("Should return the patch of a dry-run add volume request", func() {
+		enableFeatureGates(featuregate.HotplugVolumesGate)
+		request.Request.Body = newAddVolumeBody(&v1.AddVolumeOptions{
+			Name:         "vol1",
+			Disk:         &v1.Disk{},
+			VolumeSource: &v1.HotplugVolumeSource{},
+			DryRun:       []string{metav1.DryRunAll},
+		})
+		vmi := libvmi.New(
+			libvmi.WithName(testVMName),
+			libvmi.WithNamespace(metav1.NamespaceDefault),
+			libvmistatus.WithStatus(libvmistatus.New(libvmistatus.WithPhase(v1.Running))),
+		)
+
+		vmiClient.EXPECT().Get(context.Background(), vmi.Name, metav1.GetOptions{}).Return(vmi, nil)
+		vmiClient.EXPECT().Patch(context.Background(), vmi.Name, types.JSONPatchType, gomock.Any(), metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}}).Return(vmi, nil)
+		app.VMIAddVolumeRequestHandler(request, response)
+
+		Expect(recorder.Code).To(Equal(http.StatusAccepted))
+		result := &v1.DryRunResult{}
+		Expect(json.NewDecoder(recorder.Body).Decode(result)).To(Succeed())
+		Expect(result.Changes).To(HaveLen(1))
+		Expect(result.Changes[0].Kind).To(Equal("VirtualMachineInstance"))
+		Expect(result.Changes[0].Name).To(Equal(vmi.Name))
+		Expect(result.Changes[0].Operation).To(Equal(v1.DryRunOperationPatch))
+		Expect(result.Changes[0].Patch).To(ContainSubstring(`"name":"vol1"`))
+		Expect(result.Changes[0].Object).ToNot(BeNil())
+	})
+
 	DescribeTable("Should handle VMI with owner and", func(addOpts *v1.AddVolumeOptions, removeOpts *v1.RemoveVolumeOptions, code int, featuregates ...string) {
 		enableFeatureGates(featuregates...)
 		if addOpts != nil {
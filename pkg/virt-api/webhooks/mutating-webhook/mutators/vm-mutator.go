@@ -34,6 +34,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/defaults"
 	instancetypeVMWebhooks "kubevirt.io/kubevirt/pkg/instancetype/webhooks/vm"
+	"kubevirt.io/kubevirt/pkg/storage/velero"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -49,10 +50,10 @@ type VMsMutator struct {
 	virtClient          kubecli.KubevirtClient
 }
 
-func NewVMsMutator(clusterConfig *virtconfig.ClusterConfig, virtCli kubecli.KubevirtClient) *VMsMutator {
+func NewVMsMutator(clusterConfig *virtconfig.ClusterConfig, virtCli kubecli.KubevirtClient, informers *webhooks.Informers) *VMsMutator {
 	return &VMsMutator{
 		ClusterConfig:       clusterConfig,
-		instancetypeMutator: instancetypeVMWebhooks.NewMutator(virtCli),
+		instancetypeMutator: instancetypeVMWebhooks.NewMutator(virtCli, informers.NamespaceInformer.GetStore()),
 		virtClient:          virtCli,
 	}
 }
@@ -87,6 +88,9 @@ func (mutator *VMsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1.
 	// On update, the mutator does not modify the UUID field to avoid
 	// race conditions with the VM controller.
 	if ar.Request.Operation == admissionv1.Create {
+		if velero.ReconcileRestoredVM(vm) {
+			log.Log.Object(vm).V(4).Info("Regenerated identity of VM restored by Velero")
+		}
 		setFirmwareDefaultsIfEmpty(vm)
 	}
 
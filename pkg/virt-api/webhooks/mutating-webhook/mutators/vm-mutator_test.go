@@ -29,6 +29,7 @@ import (
 	"go.uber.org/mock/gomock"
 	admissionv1 "k8s.io/api/admission/v1"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -48,6 +49,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	instancetypeVMWebhooks "kubevirt.io/kubevirt/pkg/instancetype/webhooks/vm"
+	"kubevirt.io/kubevirt/pkg/storage/velero"
 	"kubevirt.io/kubevirt/pkg/testutils"
 )
 
@@ -62,6 +64,7 @@ var _ = Describe("VirtualMachine Mutator", func() {
 	var fakeClusterPreferenceClient instancetypeclientset.VirtualMachineClusterPreferenceInterface
 	var k8sClient *k8sfake.Clientset
 	var cdiClient *cdifake.Clientset
+	var namespaceStore cache.Store
 
 	machineTypeFromConfig := "pc-q35-3.0"
 	ignoreInferFromVolumeFailure := v1.IgnoreInferFromVolumeFailure
@@ -158,13 +161,15 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		fakeClusterPreferenceClient = fakeInstancetypeClients.VirtualMachineClusterPreferences()
 		virtClient.EXPECT().VirtualMachinePreference(gomock.Any()).Return(fakePreferenceClient).AnyTimes()
 		virtClient.EXPECT().VirtualMachineClusterPreference().Return(fakeClusterPreferenceClient).AnyTimes()
+		virtClient.EXPECT().VirtualMachineClusterInstancetype().Return(fakeInstancetypeClients.VirtualMachineClusterInstancetypes()).AnyTimes()
 
 		k8sClient = k8sfake.NewSimpleClientset()
 		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
 		cdiClient = cdifake.NewSimpleClientset()
 		virtClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
 
-		mutator.instancetypeMutator = instancetypeVMWebhooks.NewMutator(virtClient)
+		namespaceStore = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		mutator.instancetypeMutator = instancetypeVMWebhooks.NewMutator(virtClient, namespaceStore)
 	})
 
 	It("should allow VM being deleted without applying mutations", func() {
@@ -186,6 +191,37 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		Entry("amd64", "amd64", "q35"),
 	)
 
+	Context("VM restored by Velero", func() {
+		const (
+			restoredUUID   = "restored-uuid"
+			restoredSerial = "restored-serial"
+			restoredMAC    = "de:ad:00:00:be:ef"
+		)
+
+		BeforeEach(func() {
+			vm.Labels[velero.RestoreNameLabel] = "restore"
+			vm.Spec.Template.Spec.Domain.Firmware = &v1.Firmware{UUID: restoredUUID, Serial: restoredSerial}
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", MacAddress: restoredMAC}}
+		})
+
+		It("should preserve identity by default", func() {
+			vmSpec, _ := getVMSpecMetaFromResponseCreate()
+			Expect(vmSpec.Template.Spec.Domain.Firmware.UUID).To(Equal(types.UID(restoredUUID)))
+			Expect(vmSpec.Template.Spec.Domain.Firmware.Serial).To(Equal(restoredSerial))
+			Expect(vmSpec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal(restoredMAC))
+		})
+
+		It("should regenerate identity when requested", func() {
+			vm.Annotations = map[string]string{velero.RestoreIdentityPolicyAnnotation: velero.RestoreIdentityRegenerate}
+			vmSpec, _ := getVMSpecMetaFromResponseCreate()
+			Expect(vmSpec.Template.Spec.Domain.Firmware.UUID).ToNot(BeEmpty())
+			Expect(vmSpec.Template.Spec.Domain.Firmware.UUID).ToNot(Equal(types.UID(restoredUUID)))
+			Expect(vmSpec.Template.Spec.Domain.Firmware.Serial).ToNot(BeEmpty())
+			Expect(vmSpec.Template.Spec.Domain.Firmware.Serial).ToNot(Equal(restoredSerial))
+			Expect(vmSpec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(BeEmpty())
+		})
+	})
+
 	DescribeTable("should apply configurable defaults on VM create", func(arch string, amd64MachineType string, arm64MachineType string, s390xMachineType string, result string) {
 		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, &v1.KubeVirt{
 			Spec: v1.KubeVirtSpec{
@@ -473,6 +509,96 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		})
 	})
 
+	Context("with defaults of the namespace", func() {
+		const (
+			defaultInstancetype = "namespace-instancetype"
+			defaultPreference   = "namespace-preference"
+		)
+
+		BeforeEach(func() {
+			Expect(namespaceStore.Add(&k8sv1.Namespace{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Name: vm.Namespace,
+					Annotations: map[string]string{
+						apiinstancetype.DefaultInstancetypeLabel: defaultInstancetype,
+						apiinstancetype.DefaultPreferenceLabel:   defaultPreference,
+					},
+				},
+			})).To(Succeed())
+
+			instancetype := &instancetypev1beta1.VirtualMachineClusterInstancetype{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Name: defaultInstancetype,
+				},
+				Spec: instancetypev1beta1.VirtualMachineInstancetypeSpec{
+					CPU: instancetypev1beta1.CPUInstancetype{
+						Guest: uint32(2),
+					},
+					Memory: instancetypev1beta1.MemoryInstancetype{
+						Guest: resource.MustParse("1Gi"),
+					},
+				},
+			}
+			_, err := virtClient.VirtualMachineClusterInstancetype().Create(context.Background(), instancetype, k8smetav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should reference the default instancetype and preference on VM create", func() {
+			vmSpec, _ := getVMSpecMetaFromResponseCreate()
+			Expect(vmSpec.Instancetype).To(Equal(&v1.InstancetypeMatcher{Name: defaultInstancetype}))
+			Expect(vmSpec.Preference).To(Equal(&v1.PreferenceMatcher{Name: defaultPreference}))
+		})
+
+		It("should reference the kind of the defaults", func() {
+			obj, exists, err := namespaceStore.GetByKey(vm.Namespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			namespace := obj.(*k8sv1.Namespace).DeepCopy()
+			namespace.Annotations[apiinstancetype.DefaultPreferenceKindLabel] = apiinstancetype.SingularPreferenceResourceName
+			Expect(namespaceStore.Update(namespace)).To(Succeed())
+
+			vmSpec, _ := getVMSpecMetaFromResponseCreate()
+			Expect(vmSpec.Preference).To(Equal(&v1.PreferenceMatcher{
+				Name: defaultPreference,
+				Kind: apiinstancetype.SingularPreferenceResourceName,
+			}))
+		})
+
+		It("should not override the instancetype and preference of the VM", func() {
+			vm.Spec.Instancetype = &v1.InstancetypeMatcher{Name: "instancetype"}
+			vm.Spec.Preference = &v1.PreferenceMatcher{Name: "preference"}
+
+			vmSpec, _ := getVMSpecMetaFromResponseCreate()
+			Expect(vmSpec.Instancetype).To(Equal(&v1.InstancetypeMatcher{Name: "instancetype"}))
+			Expect(vmSpec.Preference).To(Equal(&v1.PreferenceMatcher{Name: "preference"}))
+		})
+
+		It("should not reference the default instancetype if it conflicts with the VM", func() {
+			vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{Sockets: 4}
+
+			vmSpec, _ := getVMSpecMetaFromResponseCreate()
+			Expect(vmSpec.Instancetype).To(BeNil())
+			Expect(vmSpec.Preference).To(Equal(&v1.PreferenceMatcher{Name: defaultPreference}))
+		})
+
+		It("should not reference the default instancetype if it cannot be found", func() {
+			Expect(virtClient.VirtualMachineClusterInstancetype().Delete(context.Background(), defaultInstancetype, k8smetav1.DeleteOptions{})).To(Succeed())
+
+			vmSpec, _ := getVMSpecMetaFromResponseCreate()
+			Expect(vmSpec.Instancetype).To(BeNil())
+			Expect(vmSpec.Preference).To(Equal(&v1.PreferenceMatcher{Name: defaultPreference}))
+		})
+
+		It("should not reference the defaults on VM update", func() {
+			virtClient.EXPECT().AppsV1().Return(k8sClient.AppsV1()).AnyTimes()
+			resp := getResponseFromVMUpdate(vm, vm)
+			Expect(resp.Allowed).To(BeTrue())
+			vmSpec, _ := getVMSpecMetaFromResponse(resp)
+			Expect(vmSpec.Instancetype).To(BeNil())
+			Expect(vmSpec.Preference).To(BeNil())
+		})
+	})
+
 	Context("on update", func() {
 		var oldVM, newVM *v1.VirtualMachine
 		BeforeEach(func() {
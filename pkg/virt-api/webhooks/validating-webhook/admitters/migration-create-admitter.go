@@ -131,6 +131,19 @@ func (admitter *MigrationCreateAdmitter) Admit(ctx context.Context, ar *admissio
 		}
 	}
 
+	if policyName := migration.Spec.MigrationPolicyName; policyName != nil {
+		_, err := admitter.virtClient.MigrationsV1alpha1().MigrationPolicies().Get(ctx, *policyName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueNotFound,
+				Message: fmt.Sprintf("the MigrationPolicy %q does not exist", *policyName),
+				Field:   k8sfield.NewPath("spec", "migrationPolicyName").String(),
+			}})
+		} else if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+	}
+
 	vmi, err := admitter.virtClient.KubevirtV1().VirtualMachineInstances(migration.Namespace).Get(ctx, migration.Spec.VMIName, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		// ensure VMI exists for the migration
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
 	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
 
 	"kubevirt.io/kubevirt/pkg/libvmi"
@@ -130,6 +131,37 @@ var _ = Describe("Validating MigrationCreate Admitter", func() {
 			Expect(resp.Allowed).To(BeTrue())
 		})
 
+		It("should accept Migration spec on create with an existing migration policy", func() {
+			vmi := libvmi.New(libvmi.WithNamespace(k8sv1.NamespaceDefault))
+			policy := kubecli.NewMinimalMigrationPolicy("testpolicy")
+
+			migration := createMigration(vmi.Namespace, testMigrationName, vmi.Name)
+			migration.Spec.MigrationPolicyName = pointer.P(policy.Name)
+			virtClient := kubevirtfake.NewSimpleClientset(vmi, policy)
+			migrationCreateAdmitter := admitters.NewMigrationCreateAdmitter(virtClient, config, nil)
+			ar, err := newAdmissionReviewForVMIMCreation(migration)
+			Expect(err).ToNot(HaveOccurred())
+
+			resp := migrationCreateAdmitter.Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject Migration spec on create with a missing migration policy", func() {
+			vmi := libvmi.New(libvmi.WithNamespace(k8sv1.NamespaceDefault))
+
+			migration := createMigration(vmi.Namespace, testMigrationName, vmi.Name)
+			migration.Spec.MigrationPolicyName = pointer.P("missing")
+			virtClient := kubevirtfake.NewSimpleClientset(vmi)
+			migrationCreateAdmitter := admitters.NewMigrationCreateAdmitter(virtClient, config, nil)
+			ar, err := newAdmissionReviewForVMIMCreation(migration)
+			Expect(err).ToNot(HaveOccurred())
+
+			resp := migrationCreateAdmitter.Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.migrationPolicyName"))
+		})
+
 		It("should accept Migration spec on create when previous VMI migration completed", func() {
 			vmi := libvmi.New(libvmi.WithNamespace(k8sv1.NamespaceDefault))
 			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
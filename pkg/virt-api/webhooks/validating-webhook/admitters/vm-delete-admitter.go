@@ -0,0 +1,111 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+// kubernetesDeleters are the service accounts of the Kubernetes controllers deleting the contents of
+// terminating namespaces and the dependents of deleted owners. Blocking them would keep namespaces
+// terminating until the VirtualMachines are unlocked.
+var kubernetesDeleters = map[string]struct{}{
+	"system:serviceaccount:kube-system:namespace-controller":      {},
+	"system:serviceaccount:kube-system:generic-garbage-collector": {},
+}
+
+// VMDeleteAdmitter rejects the deletion of VirtualMachines with DeletionProtection which were not unlocked
+type VMDeleteAdmitter struct {
+	KubeVirtServiceAccounts map[string]struct{}
+}
+
+func NewVMDeleteAdmitter(kubeVirtServiceAccounts map[string]struct{}) *VMDeleteAdmitter {
+	return &VMDeleteAdmitter{
+		KubeVirtServiceAccounts: kubeVirtServiceAccounts,
+	}
+}
+
+func (admitter *VMDeleteAdmitter) Admit(_ context.Context, ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if !webhookutils.ValidateRequestResource(ar.Request.Resource, webhooks.VirtualMachineGroupVersionResource.Group, webhooks.VirtualMachineGroupVersionResource.Resource) {
+		err := fmt.Errorf("expect resource to be '%s'", webhooks.VirtualMachineGroupVersionResource.Resource)
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	if ar.Request.Operation != admissionv1.Delete {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	// VirtualMachines owned by KubeVirt, like the ones of a VirtualMachinePool, are deleted by its controllers
+	if _, isKubeVirtServiceAccount := admitter.KubeVirtServiceAccounts[ar.Request.UserInfo.Username]; isKubeVirtServiceAccount {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	if _, isKubernetesDeleter := kubernetesDeleters[ar.Request.UserInfo.Username]; isKubernetesDeleter {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	// The VirtualMachine to delete is passed as the old object
+	vm := &v1.VirtualMachine{}
+	if err := json.Unmarshal(ar.Request.OldObject.Raw, vm); err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	if vm.DeletionTimestamp != nil || !isDeletionProtected(vm) || isDeletionUnlocked(vm, time.Now()) {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Message: fmt.Sprintf("VirtualMachine %s/%s is protected from deletion, set the %s annotation to %q or the %s annotation to a past RFC3339 time to unlock it",
+				vm.Namespace, vm.Name, v1.DeletionUnlockAnnotation, vm.Name, v1.DeletionUnlockAfterAnnotation),
+			Reason: metav1.StatusReasonForbidden,
+			Code:   http.StatusForbidden,
+		},
+	}
+}
+
+func isDeletionProtected(vm *v1.VirtualMachine) bool {
+	return vm.Spec.DeletionProtection != nil && *vm.Spec.DeletionProtection
+}
+
+func isDeletionUnlocked(vm *v1.VirtualMachine, now time.Time) bool {
+	if vm.Annotations[v1.DeletionUnlockAnnotation] == vm.Name {
+		return true
+	}
+
+	unlockAfter, exists := vm.Annotations[v1.DeletionUnlockAfterAnnotation]
+	if !exists {
+		return false
+	}
+	unlockTime, err := time.Parse(time.RFC3339, unlockAfter)
+	return err == nil && !now.Before(unlockTime)
+}
@@ -0,0 +1,121 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitters
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+var _ = Describe("Validating VM Delete Admitter", func() {
+	const kubeVirtServiceAccount = "system:serviceaccount:kubevirt:kubevirt-controller"
+
+	admitter := NewVMDeleteAdmitter(map[string]struct{}{kubeVirtServiceAccount: {}})
+
+	newProtectedVM := func(opts ...libvmi.VMOption) *v1.VirtualMachine {
+		vm := libvmi.NewVirtualMachine(libvmi.New(libvmi.WithName("testvm"), libvmi.WithNamespace(metav1.NamespaceDefault)), opts...)
+		vm.Spec.DeletionProtection = pointer.P(true)
+		return vm
+	}
+
+	admitDelete := func(vm *v1.VirtualMachine, username string) *admissionv1.AdmissionResponse {
+		vmBytes, err := json.Marshal(vm)
+		Expect(err).ToNot(HaveOccurred())
+		return admitter.Admit(context.Background(), &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Operation: admissionv1.Delete,
+				Resource:  webhooks.VirtualMachineGroupVersionResource,
+				UserInfo:  authv1.UserInfo{Username: username},
+				OldObject: runtime.RawExtension{Raw: vmBytes},
+			},
+		})
+	}
+
+	It("should reject the deletion of a protected VM", func() {
+		resp := admitDelete(newProtectedVM(), "user")
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Code).To(Equal(int32(http.StatusForbidden)))
+		Expect(resp.Result.Message).To(ContainSubstring(v1.DeletionUnlockAnnotation))
+	})
+
+	DescribeTable("should reject the deletion of a protected VM", func(annotations map[string]string) {
+		resp := admitDelete(newProtectedVM(libvmi.WithAnnotations(annotations)), "user")
+		Expect(resp.Allowed).To(BeFalse())
+	},
+		Entry("with an unlock annotation for another VM", map[string]string{v1.DeletionUnlockAnnotation: "othervm"}),
+		Entry("with an unlock time in the future", map[string]string{
+			v1.DeletionUnlockAfterAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+		}),
+		Entry("with an invalid unlock time", map[string]string{v1.DeletionUnlockAfterAnnotation: "tomorrow"}),
+	)
+
+	DescribeTable("should allow the deletion of an unlocked VM", func(annotations map[string]string) {
+		resp := admitDelete(newProtectedVM(libvmi.WithAnnotations(annotations)), "user")
+		Expect(resp.Allowed).To(BeTrue())
+	},
+		Entry("with the unlock annotation set to the name of the VM", map[string]string{v1.DeletionUnlockAnnotation: "testvm"}),
+		Entry("with an unlock time in the past", map[string]string{
+			v1.DeletionUnlockAfterAnnotation: time.Now().Add(-time.Minute).Format(time.RFC3339),
+		}),
+	)
+
+	It("should allow the deletion of a VM without protection", func() {
+		vm := newProtectedVM()
+		vm.Spec.DeletionProtection = pointer.P(false)
+		Expect(admitDelete(vm, "user").Allowed).To(BeTrue())
+	})
+
+	It("should allow the deletion of a protected VM which is already being deleted", func() {
+		vm := newProtectedVM()
+		vm.DeletionTimestamp = pointer.P(metav1.Now())
+		Expect(admitDelete(vm, "user").Allowed).To(BeTrue())
+	})
+
+	It("should allow KubeVirt service accounts to delete a protected VM", func() {
+		Expect(admitDelete(newProtectedVM(), kubeVirtServiceAccount).Allowed).To(BeTrue())
+	})
+
+	It("should allow the namespace controller to delete a protected VM of a terminating namespace", func() {
+		Expect(admitDelete(newProtectedVM(), "system:serviceaccount:kube-system:namespace-controller").Allowed).To(BeTrue())
+	})
+
+	It("should allow the garbage collector to delete a protected VM of a deleted owner", func() {
+		Expect(admitDelete(newProtectedVM(), "system:serviceaccount:kube-system:generic-garbage-collector").Allowed).To(BeTrue())
+	})
+
+	It("should reject the deletion of a protected VM by other kube-system service accounts", func() {
+		Expect(admitDelete(newProtectedVM(), "system:serviceaccount:kube-system:default").Allowed).To(BeFalse())
+	})
+})
@@ -29,12 +29,14 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	clonebase "kubevirt.io/api/clone"
 	clone "kubevirt.io/api/clone/v1beta1"
 	"kubevirt.io/client-go/kubecli"
 
+	storageadmitters "kubevirt.io/kubevirt/pkg/storage/admitters"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
@@ -112,6 +114,14 @@ func (admitter *VirtualMachineCloneAdmitter) Admit(ctx context.Context, ar *admi
 		causes = append(causes, newCauses...)
 	}
 
+	if newCauses := validateCustomization(vmClone); newCauses != nil {
+		causes = append(causes, newCauses...)
+	}
+
+	if newCauses := storageadmitters.ValidateIdentityPolicy(vmClone.Spec.IdentityPolicy, k8sfield.NewPath("spec").Child("identityPolicy")); newCauses != nil {
+		causes = append(causes, newCauses...)
+	}
+
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
 	}
@@ -280,6 +290,38 @@ func validatePatches(vmClone *clone.VirtualMachineClone) []metav1.StatusCause {
 	return causes
 }
 
+func validateCustomization(vmClone *clone.VirtualMachineClone) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	customization := vmClone.Spec.Customization
+	if customization == nil {
+		return causes
+	}
+
+	field := k8sfield.NewPath("spec").Child("customization")
+	if customization.Hostname == nil &&
+		(customization.ResetMachineID == nil || !*customization.ResetMachineID) &&
+		(customization.RegenerateSSHHostKeys == nil || !*customization.RegenerateSSHHostKeys) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "customization must set a hostname, reset the machine-id or regenerate the SSH host keys",
+			Field:   field.String(),
+		})
+	}
+
+	if customization.Hostname != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(*customization.Hostname) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("hostname %q is invalid: %s", *customization.Hostname, msg),
+				Field:   field.Child("hostname").String(),
+			})
+		}
+	}
+
+	return causes
+}
+
 func doesSliceContainStr(slice []string, str string) (isFound bool) {
 	for _, curSliceStr := range slice {
 		if curSliceStr == str {
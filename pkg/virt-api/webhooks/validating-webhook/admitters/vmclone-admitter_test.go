@@ -416,6 +416,26 @@ var _ = Describe("Validating VirtualMachineClone Admitter", func() {
 		Entry("invalid mac address", "00:00:00:00:00", false),
 	)
 
+	DescribeTable("customization", func(customization *clone.VirtualMachineCloneCustomization, expectAllowed bool) {
+		vmClone.Spec.Customization = customization
+		admitter.admitAndExpect(vmClone, expectAllowed)
+	},
+		Entry("with hostname", &clone.VirtualMachineCloneCustomization{Hostname: pointer.P("clone")}, true),
+		Entry("with machine-id reset", &clone.VirtualMachineCloneCustomization{ResetMachineID: pointer.P(true)}, true),
+		Entry("with SSH host keys regeneration", &clone.VirtualMachineCloneCustomization{RegenerateSSHHostKeys: pointer.P(true)}, true),
+		Entry("without any operation", &clone.VirtualMachineCloneCustomization{ResetMachineID: pointer.P(false)}, false),
+		Entry("with invalid hostname", &clone.VirtualMachineCloneCustomization{Hostname: pointer.P("Not_Valid")}, false),
+	)
+
+	DescribeTable("identity policy", func(identityPolicy *snapshotv1.IdentityPolicy, expectAllowed bool) {
+		vmClone.Spec.IdentityPolicy = identityPolicy
+		admitter.admitAndExpect(vmClone, expectAllowed)
+	},
+		Entry("preserving MAC addresses", &snapshotv1.IdentityPolicy{MacAddresses: pointer.P(snapshotv1.IdentityPreserve)}, true),
+		Entry("regenerating hostname", &snapshotv1.IdentityPolicy{Hostname: pointer.P(snapshotv1.IdentityRegenerate)}, true),
+		Entry("with invalid SMBIOS serial policy", &snapshotv1.IdentityPolicy{SMBiosSerial: pointer.P(snapshotv1.IdentityRegenerationPolicy("Keep"))}, false),
+	)
+
 	Context("Custom patches", func() {
 		It("Should accept valid JSON patches", func() {
 			validPatch := patch.New(patch.WithReplace("/spec/template/spec/domain/devices/interfaces/0/macAddress", "DE-AD-00-FF-FF-FF"))
@@ -43,6 +43,7 @@ import (
 	netadmitter "kubevirt.io/kubevirt/pkg/network/admitter"
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
 	storageadmitters "kubevirt.io/kubevirt/pkg/storage/admitters"
+	"kubevirt.io/kubevirt/pkg/storage/localscratch"
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 
@@ -51,6 +52,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+	vsockservice "kubevirt.io/kubevirt/pkg/vsock/service"
 )
 
 const requiredFieldFmt = "%s is a required field"
@@ -67,6 +69,10 @@ const (
 	maxDNSNameservers     = 3
 	maxDNSSearchPaths     = 6
 	maxDNSSearchListChars = 256
+
+	// The range of the cgroup v1 cpu.shares
+	minCPUThreadShares = 2
+	maxCPUThreadShares = 262144
 )
 
 var validIOThreadsPolicies = []v1.IOThreadsPolicy{v1.IOThreadsPolicyShared, v1.IOThreadsPolicyAuto, v1.IOThreadsPolicySupplementalPool}
@@ -189,6 +195,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateCpuPinning(field, spec, config)...)
 	causes = append(causes, validateNUMA(field, spec, config)...)
 	causes = append(causes, validateCPUIsolatorThread(field, spec)...)
+	causes = append(causes, validateCPUThreadShares(field, spec)...)
 	causes = append(causes, validateCPUFeaturePolicies(field, spec)...)
 	causes = append(causes, validateCPUHotplug(field, spec)...)
 	causes = append(causes, validateStartStrategy(field, spec)...)
@@ -565,10 +572,10 @@ func validateSoundDevices(field *k8sfield.Path, spec *v1.VirtualMachineInstanceS
 		return causes
 	}
 	model := spec.Domain.Devices.Sound.Model
-	if model != "" && model != "ich9" && model != "ac97" {
+	if model != "" && model != "ich9" && model != "ac97" && model != "virtio" {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
-			Message: "Sound device type is not supported. Options: 'ich9' or 'ac97'",
+			Message: "Sound device type is not supported. Options: 'ich9', 'ac97' or 'virtio'",
 			Field:   field.Child("Sound").String(),
 		})
 	}
@@ -579,7 +586,32 @@ func validateSoundDevices(field *k8sfield.Path, spec *v1.VirtualMachineInstanceS
 			Field:   field.Child("Sound").String(),
 		})
 	}
+	if stream := spec.Domain.Devices.Sound.Stream; stream != nil {
+		causes = append(causes, validateSoundStream(field.Child("Sound", "stream"), spec, stream)...)
+	}
+
+	return causes
+}
+
+// minSoundStreamBandwidth is the bandwidth of an 8kHz 16 bit mono stream, the lowest quality offered
+const minSoundStreamBandwidth = 8000 * 2
 
+func validateSoundStream(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, stream *v1.SoundStream) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if autoattach := spec.Domain.Devices.AutoattachGraphicsDevice; autoattach != nil && !*autoattach {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "Sound stream requires a graphics device to forward the audio through.",
+			Field:   field.String(),
+		})
+	}
+	if stream.MaxBandwidth != nil && stream.MaxBandwidth.Value() < minSoundStreamBandwidth {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Sound stream bandwidth must be at least %d bytes per second.", minSoundStreamBandwidth),
+			Field:   field.Child("maxBandwidth").String(),
+		})
+	}
 	return causes
 }
 
@@ -712,6 +744,38 @@ func validateCPUIsolatorThread(field *k8sfield.Path, spec *v1.VirtualMachineInst
 	return causes
 }
 
+func validateCPUThreadShares(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	if spec.Domain.CPU == nil || spec.Domain.CPU.ThreadShares == nil {
+		return nil
+	}
+	var causes []metav1.StatusCause
+	sharesField := field.Child("domain", "cpu", "threadShares")
+	if spec.Domain.CPU.DedicatedCPUPlacement {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is not supported in combination with DedicatedCPUPlacement", sharesField.String()),
+			Field:   sharesField.String(),
+		})
+	}
+	for _, threads := range []struct {
+		name   string
+		shares *uint32
+	}{
+		{"emulator", spec.Domain.CPU.ThreadShares.Emulator},
+		{"ioThreads", spec.Domain.CPU.ThreadShares.IOThreads},
+	} {
+		if threads.shares != nil && (*threads.shares < minCPUThreadShares || *threads.shares > maxCPUThreadShares) {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be between %d and %d",
+					sharesField.Child(threads.name).String(), minCPUThreadShares, maxCPUThreadShares),
+				Field: sharesField.Child(threads.name).String(),
+			})
+		}
+	}
+	return causes
+}
+
 func validateCpuPinning(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	if spec.Domain.CPU != nil && spec.Domain.CPU.DedicatedCPUPlacement {
@@ -1266,6 +1330,10 @@ func ValidateVirtualMachineInstanceMetadata(field *k8sfield.Path, metadata *meta
 		})
 	}
 
+	if _, exists := annotations[v1.LocalScratchCapacityAnnotation]; exists {
+		causes = append(causes, validateLocalScratchAnnotation(field, metadata, config)...)
+	}
+
 	// Validate sidecar feature gate if set when the corresponding annotation is found
 	if annotations[hooks.HookSidecarListAnnotationName] != "" && !config.SidecarEnabled() {
 		causes = append(causes, metav1.StatusCause{
@@ -1279,6 +1347,25 @@ func ValidateVirtualMachineInstanceMetadata(field *k8sfield.Path, metadata *meta
 	return causes
 }
 
+func validateLocalScratchAnnotation(field *k8sfield.Path, metadata *metav1.ObjectMeta, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	annotationField := field.Child("annotations", v1.LocalScratchCapacityAnnotation)
+	if !config.LocalScratchEnabled() {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config, invalid entry %s", featuregate.LocalScratch, annotationField.String()),
+			Field:   field.Child("annotations").String(),
+		}}
+	}
+	if _, err := localscratch.RequestedUnits(&v1.VirtualMachineInstance{ObjectMeta: *metadata}); err != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: err.Error(),
+			Field:   annotationField.String(),
+		}}
+	}
+	return nil
+}
+
 // Copied from kubernetes/pkg/apis/core/validation/validation.go
 func validatePodDNSConfig(dnsConfig *k8sv1.PodDNSConfig, dnsPolicy *k8sv1.DNSPolicy, field *k8sfield.Path) []metav1.StatusCause {
 	var causes []metav1.StatusCause
@@ -1904,6 +1991,44 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 		})
 	}
 
+	for idx, volume := range volumes {
+		if volume.Ephemeral != nil && volume.Ephemeral.OverlayClaimName != "" {
+			causes = append(causes, validateEphemeralOverlayClaim(field.Index(idx), volume, nameMap)...)
+		}
+	}
+
+	return causes
+}
+
+func validateEphemeralOverlayClaim(field *k8sfield.Path, volume v1.Volume, volumeNames map[string]int) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	overlayClaimField := field.Child("ephemeral", "overlayClaimName")
+
+	if volume.Ephemeral.PersistentVolumeClaim != nil && volume.Ephemeral.OverlayClaimName == volume.Ephemeral.PersistentVolumeClaim.ClaimName {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must not reference the base PersistentVolumeClaim", overlayClaimField.String()),
+			Field:   overlayClaimField.String(),
+		})
+	}
+
+	overlayVolumeName := storagetypes.EphemeralOverlayVolumeName(volume.Name)
+	if _, exists := volumeNames[overlayVolumeName]; exists {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires the volume name %s which is already in use", overlayClaimField.String(), overlayVolumeName),
+			Field:   overlayClaimField.String(),
+		})
+	}
+	if len(overlayVolumeName) > validation.DNS1123LabelMaxLength {
+		maxNameLength := validation.DNS1123LabelMaxLength - (len(overlayVolumeName) - len(volume.Name))
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires the volume name to be at most %d characters long", overlayClaimField.String(), maxNameLength),
+			Field:   field.Child("name").String(),
+		})
+	}
+
 	return causes
 }
 
@@ -2001,6 +2126,13 @@ func validateSpecTopologySpreadConstraints(field *k8sfield.Path, spec *v1.Virtua
 func validateVSOCK(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	if spec.Domain.Devices.AutoattachVSOCK == nil || !*spec.Domain.Devices.AutoattachVSOCK {
+		if len(spec.Domain.Devices.VSOCKServices) > 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "vsock services require autoattachVSOCK to be enabled",
+				Field:   field.Child("domain", "devices", "vsockServices").String(),
+			})
+		}
 		return causes
 	}
 
@@ -2012,6 +2144,49 @@ func validateVSOCK(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, co
 		})
 	}
 
+	return append(causes, validateVSOCKServices(field.Child("domain", "devices", "vsockServices"), spec.Domain.Devices.VSOCKServices)...)
+}
+
+func validateVSOCKServices(field *k8sfield.Path, services []v1.VSOCKService) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	names := make(map[string]struct{})
+	ports := make(map[uint32]struct{})
+	for idx, service := range services {
+		if errs := validation.IsDNS1123Label(service.Name); len(errs) > 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("vsock service name %q is invalid: %s", service.Name, strings.Join(errs, ", ")),
+				Field:   field.Index(idx).Child("name").String(),
+			})
+		}
+		if _, exists := names[service.Name]; exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("vsock service name %q is used more than once", service.Name),
+				Field:   field.Index(idx).Child("name").String(),
+			})
+		}
+		names[service.Name] = struct{}{}
+
+		if service.Port == nil {
+			continue
+		}
+		if *service.Port < vsockservice.MinPort {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("vsock service port %d is reserved, ports must be at least %d", *service.Port, vsockservice.MinPort),
+				Field:   field.Index(idx).Child("port").String(),
+			})
+		}
+		if _, exists := ports[*service.Port]; exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("vsock service port %d is used more than once", *service.Port),
+				Field:   field.Index(idx).Child("port").String(),
+			})
+		}
+		ports[*service.Port] = struct{}{}
+	}
 	return causes
 }
 
@@ -454,6 +454,10 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				map[string]string{hooks.HookSidecarListAnnotationName: "[{'image': 'fake-image'}]"},
 				fmt.Sprintf("invalid entry metadata.annotations.%s", hooks.HookSidecarListAnnotationName),
 			),
+			Entry("without LocalScratch feature gate enabled",
+				map[string]string{v1.LocalScratchCapacityAnnotation: "1Gi"},
+				fmt.Sprintf("invalid entry metadata.annotations.%s", v1.LocalScratchCapacityAnnotation),
+			),
 		)
 
 		DescribeTable("should accept annotations which require feature gate enabled", func(annotations map[string]string, featureGate string) {
@@ -477,7 +481,26 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				map[string]string{hooks.HookSidecarListAnnotationName: "[{'image': 'fake-image'}]"},
 				featuregate.SidecarGate,
 			),
+			Entry("with LocalScratch feature gate enabled",
+				map[string]string{v1.LocalScratchCapacityAnnotation: "1Gi"},
+				featuregate.LocalScratch,
+			),
 		)
+
+		It("should reject an invalid local scratch capacity", func() {
+			enableFeatureGates(featuregate.LocalScratch)
+			vmi := newBaseVmi()
+			vmi.Annotations = map[string]string{v1.LocalScratchCapacityAnnotation: "0"}
+
+			ar, err := newAdmissionReviewForVMICreation(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			ar.Request.UserInfo = authv1.UserInfo{Username: "fake-account"}
+
+			resp := vmiCreateAdmitter.Admit(context.Background(), ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("metadata.annotations." + v1.LocalScratchCapacityAnnotation))
+		})
 	})
 
 	Context("with VirtualMachineInstance spec", func() {
@@ -592,7 +615,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].name"))
 		})
 		It("should allow supported audio devices", func() {
-			supportedDevices := [...]string{"", "ich9", "ac97"}
+			supportedDevices := [...]string{"", "ich9", "ac97", "virtio"}
 
 			for _, deviceName := range supportedDevices {
 				vmi.Spec.Domain.Devices.Sound = &v1.SoundDevice{
@@ -626,6 +649,36 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Field).To(Equal("fake.Sound"))
 		})
 
+		It("should allow streaming audio with a bandwidth cap", func() {
+			vmi.Spec.Domain.Devices.Sound = &v1.SoundDevice{
+				Name:   "audio-device",
+				Stream: &v1.SoundStream{MaxBandwidth: pointer.P(resource.MustParse("64Ki"))},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject streaming audio without a graphics device", func() {
+			vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = pointer.P(false)
+			vmi.Spec.Domain.Devices.Sound = &v1.SoundDevice{
+				Name:   "audio-device",
+				Stream: &v1.SoundStream{},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.Sound.stream"))
+		})
+
+		It("should reject a sound stream bandwidth below the lowest quality", func() {
+			vmi.Spec.Domain.Devices.Sound = &v1.SoundDevice{
+				Name:   "audio-device",
+				Stream: &v1.SoundStream{MaxBandwidth: pointer.P(resource.MustParse("8k"))},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.Sound.stream.maxBandwidth"))
+		})
+
 		It("should reject volume with missing disk / file system", func() {
 			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
 				Name: "testvolume",
@@ -1688,6 +1741,35 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Field).To(Equal("fake.domain.cpu.isolateEmulatorThread"))
 		})
 
+		It("should reject thread shares with DedicatedCPUPlacement set", func() {
+			vmi.Spec.Domain.CPU.Cores = 4
+			vmi.Spec.Domain.CPU.ThreadShares = &v1.CPUThreadShares{Emulator: pointer.P(uint32(2048))}
+			vmi.Spec.Domain.Resources.Limits = k8sv1.ResourceList{
+				k8sv1.ResourceCPU: resource.MustParse("4"),
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.cpu.threadShares"))
+		})
+
+		DescribeTable("should validate the range of thread shares without DedicatedCPUPlacement", func(threadShares *v1.CPUThreadShares, expectedFields ...string) {
+			vmi.Spec.Domain.CPU.DedicatedCPUPlacement = false
+			vmi.Spec.Domain.CPU.ThreadShares = threadShares
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			Entry("accept shares within the range",
+				&v1.CPUThreadShares{Emulator: pointer.P(uint32(2)), IOThreads: pointer.P(uint32(262144))}),
+			Entry("accept unset shares", &v1.CPUThreadShares{}),
+			Entry("reject emulator shares below the range",
+				&v1.CPUThreadShares{Emulator: pointer.P(uint32(1))}, "fake.domain.cpu.threadShares.emulator"),
+			Entry("reject IO thread shares above the range",
+				&v1.CPUThreadShares{IOThreads: pointer.P(uint32(262145))}, "fake.domain.cpu.threadShares.ioThreads"),
+		)
+
 		It("should reject specs without inconsistent cpu reqirements", func() {
 			vmi.Spec.Domain.CPU.Cores = 4
 			vmi.Spec.Domain.Resources.Limits = k8sv1.ResourceList{
@@ -2256,6 +2338,56 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Message).To(ContainSubstring("fake must have max one memory dump volume set"))
 		})
 
+		Context("with an ephemeral volume and an overlay claim", func() {
+			newEphemeralVolume := func(name, baseClaimName, overlayClaimName string) v1.Volume {
+				return v1.Volume{
+					Name: name,
+					VolumeSource: v1.VolumeSource{
+						Ephemeral: &v1.EphemeralVolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: baseClaimName},
+							OverlayClaimName:      overlayClaimName,
+						},
+					},
+				}
+			}
+
+			It("should accept a distinct overlay claim", func() {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, newEphemeralVolume("rootdisk", "golden-image", "rootdisk-overlay"))
+				Expect(validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)).To(BeEmpty())
+			})
+
+			It("should reject the base claim as overlay claim", func() {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, newEphemeralVolume("rootdisk", "golden-image", "golden-image"))
+				causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake[0].ephemeral.overlayClaimName"))
+				Expect(causes[0].Message).To(ContainSubstring("must not reference the base PersistentVolumeClaim"))
+			})
+
+			It("should reject a volume named like the overlay volume", func() {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes,
+					newEphemeralVolume("rootdisk", "golden-image", "rootdisk-overlay"),
+					v1.Volume{
+						Name: "rootdisk-overlay",
+						VolumeSource: v1.VolumeSource{
+							EmptyDisk: &v1.EmptyDiskSource{Capacity: resource.MustParse("1Gi")},
+						},
+					},
+				)
+				causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Message).To(ContainSubstring("requires the volume name rootdisk-overlay which is already in use"))
+			})
+
+			It("should reject a volume name too long for the overlay volume", func() {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, newEphemeralVolume(strings.Repeat("a", 60), "golden-image", "rootdisk-overlay"))
+				causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake[0].name"))
+				Expect(causes[0].Message).To(ContainSubstring("at most 55 characters long"))
+			})
+		})
+
 		It("should accept containerPath volume with matching filesystem", func() {
 			vmi.Spec.Domain.Devices.Filesystems = append(vmi.Spec.Domain.Devices.Filesystems, v1.Filesystem{
 				Name:     "testcontainerpath",
@@ -2879,6 +3011,40 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 				Expect(causes).To(BeEmpty())
 			})
+
+			It("should accept vmi with vsock services defined", func() {
+				vmi.Spec.Domain.Devices.AutoattachVSOCK = pointer.P(true)
+				vmi.Spec.Domain.Devices.VSOCKServices = []v1.VSOCKService{
+					{Name: "logs"},
+					{Name: "metrics", Port: pointer.P(uint32(2048))},
+				}
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+
+			It("should reject vsock services without autoattachVSOCK", func() {
+				vmi.Spec.Domain.Devices.VSOCKServices = []v1.VSOCKService{{Name: "logs"}}
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.vsockServices"))
+			})
+
+			DescribeTable("should reject invalid vsock services", func(services []v1.VSOCKService, expectedField string) {
+				vmi.Spec.Domain.Devices.AutoattachVSOCK = pointer.P(true)
+				vmi.Spec.Domain.Devices.VSOCKServices = services
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			},
+				Entry("with an empty name", []v1.VSOCKService{{Name: ""}}, "fake.domain.devices.vsockServices[0].name"),
+				Entry("with an invalid name", []v1.VSOCKService{{Name: "Logs_1"}}, "fake.domain.devices.vsockServices[0].name"),
+				Entry("with a duplicate name", []v1.VSOCKService{{Name: "logs"}, {Name: "logs"}}, "fake.domain.devices.vsockServices[1].name"),
+				Entry("with a reserved port", []v1.VSOCKService{{Name: "logs", Port: pointer.P(uint32(1))}}, "fake.domain.devices.vsockServices[0].port"),
+				Entry("with a duplicate port", []v1.VSOCKService{
+					{Name: "logs", Port: pointer.P(uint32(2048))},
+					{Name: "metrics", Port: pointer.P(uint32(2048))},
+				}, "fake.domain.devices.vsockServices[1].port"),
+			)
 		})
 
 		Context("feature gate disabled", func() {
@@ -0,0 +1,58 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitters
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+
+	operationsv1 "kubevirt.io/api/operations/v1alpha1"
+
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+// VMOperationAdmitter validates VirtualMachineOperations
+type VMOperationAdmitter struct {
+	Config *virtconfig.ClusterConfig
+}
+
+// NewVMOperationAdmitter creates a VMOperationAdmitter
+func NewVMOperationAdmitter(config *virtconfig.ClusterConfig) *VMOperationAdmitter {
+	return &VMOperationAdmitter{Config: config}
+}
+
+// Admit rejects the creation of VirtualMachineOperations while the feature gate is disabled,
+// the spec itself is validated by the CRD schema
+func (admitter *VMOperationAdmitter) Admit(_ context.Context, ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request.Resource.Group != operationsv1.SchemeGroupVersion.Group ||
+		ar.Request.Resource.Resource != "virtualmachineoperations" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	if ar.Request.Operation == admissionv1.Create && !admitter.Config.VirtualMachineOperationsEnabled() {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("%s feature gate not enabled", featuregate.VirtualMachineOperations))
+	}
+
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
@@ -0,0 +1,81 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitters
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	operationsv1 "kubevirt.io/api/operations/v1alpha1"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+var _ = Describe("Validating VirtualMachineOperation Admitter", func() {
+	newAdmissionReview := func(operation admissionv1.Operation) *admissionv1.AdmissionReview {
+		return &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Operation: operation,
+				Resource: metav1.GroupVersionResource{
+					Group:    operationsv1.SchemeGroupVersion.Group,
+					Version:  operationsv1.SchemeGroupVersion.Version,
+					Resource: "virtualmachineoperations",
+				},
+			},
+		}
+	}
+
+	newAdmitter := func(featureGates ...string) *VMOperationAdmitter {
+		config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{FeatureGates: featureGates},
+		})
+		return NewVMOperationAdmitter(config)
+	}
+
+	It("should reject the creation when the feature gate is disabled", func() {
+		resp := newAdmitter().Admit(context.Background(), newAdmissionReview(admissionv1.Create))
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(Equal("VirtualMachineOperations feature gate not enabled"))
+	})
+
+	It("should allow updates when the feature gate is disabled", func() {
+		resp := newAdmitter().Admit(context.Background(), newAdmissionReview(admissionv1.Update))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("should allow the creation when the feature gate is enabled", func() {
+		resp := newAdmitter(featuregate.VirtualMachineOperations).Admit(context.Background(), newAdmissionReview(admissionv1.Create))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("should reject unexpected resources", func() {
+		ar := newAdmissionReview(admissionv1.Create)
+		ar.Request.Resource.Resource = "virtualmachines"
+		resp := newAdmitter(featuregate.VirtualMachineOperations).Admit(context.Background(), ar)
+		Expect(resp.Allowed).To(BeFalse())
+	})
+})
@@ -187,13 +187,14 @@ func defaultClusterConfig(cpuArch string) *v1.KubeVirtConfiguration {
 	return &v1.KubeVirtConfiguration{
 		ImagePullPolicy: DefaultImagePullPolicy,
 		DeveloperConfiguration: &v1.DeveloperConfiguration{
-			UseEmulation:           DefaultAllowEmulation,
-			MemoryOvercommit:       DefaultMemoryOvercommit,
-			LessPVCSpaceToleration: DefaultLessPVCSpaceToleration,
-			MinimumReservePVCBytes: DefaultMinimumReservePVCBytes,
-			NodeSelectors:          nodeSelectorsDefault,
-			CPUAllocationRatio:     DefaultCPUAllocationRatio,
-			DiskVerification:       defaultDiskVerification,
+			UseEmulation:             DefaultAllowEmulation,
+			MemoryOvercommit:         DefaultMemoryOvercommit,
+			LessPVCSpaceToleration:   DefaultLessPVCSpaceToleration,
+			MinimumReservePVCBytes:   DefaultMinimumReservePVCBytes,
+			NodeSelectors:            nodeSelectorsDefault,
+			CPUAllocationRatio:       DefaultCPUAllocationRatio,
+			TSCFrequencyTolerancePPM: pointer.P(DefaultTSCFrequencyTolerancePPM),
+			DiskVerification:         defaultDiskVerification,
 			LogVerbosity: &v1.LogVerbosity{
 				VirtAPI:        DefaultVirtAPILogVerbosity,
 				VirtOperator:   DefaultVirtOperatorLogVerbosity,
@@ -475,6 +476,10 @@ func validateConfig(config *v1.KubeVirtConfiguration) error {
 		return fmt.Errorf("invalid lessPVCSpaceToleration in ConfigMap: %d", toleration)
 	}
 
+	if tolerance := config.DeveloperConfiguration.TSCFrequencyTolerancePPM; tolerance != nil && (*tolerance < 0 || *tolerance > DefaultTSCFrequencyTolerancePPM) {
+		return fmt.Errorf("invalid tscFrequencyTolerancePPM in ConfigMap: %d", *tolerance)
+	}
+
 	// set default network interface
 	switch config.NetworkConfiguration.NetworkInterface {
 	case "", string(v1.BridgeInterface), string(v1.DeprecatedSlirpInterface), string(v1.MasqueradeInterface):
@@ -107,6 +107,21 @@ var _ = Describe("test configuration", func() {
 		Entry("is invalid, GetLessPVCSpaceToleration should return the default", -1, virtconfig.DefaultLessPVCSpaceToleration),
 	)
 
+	DescribeTable(" when tscFrequencyTolerancePPM", func(value *int64, result int64) {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				TSCFrequencyTolerancePPM: value,
+			},
+		})
+		Expect(clusterConfig.GetTSCFrequencyTolerancePPM()).To(Equal(result))
+	},
+		Entry("is set, GetTSCFrequencyTolerancePPM should return correct value", pointer.P(int64(100)), int64(100)),
+		Entry("is zero, GetTSCFrequencyTolerancePPM should return zero", pointer.P(int64(0)), int64(0)),
+		Entry("is unset, GetTSCFrequencyTolerancePPM should return the default", nil, virtconfig.DefaultTSCFrequencyTolerancePPM),
+		Entry("is above what QEMU tolerates, GetTSCFrequencyTolerancePPM should return the default", pointer.P(int64(251)), virtconfig.DefaultTSCFrequencyTolerancePPM),
+		Entry("is negative, GetTSCFrequencyTolerancePPM should return the default", pointer.P(int64(-1)), virtconfig.DefaultTSCFrequencyTolerancePPM),
+	)
+
 	nodeSelectors := map[string]string{
 		kubev1.LabelHostname:              "node02",
 		"node-role.kubernetes.io/compute": "true",
@@ -692,7 +707,7 @@ var _ = Describe("test configuration", func() {
 			func(c *v1.KubeVirtConfiguration) interface{} {
 				return c.DeveloperConfiguration
 			},
-			`{"featureGates":["test1","test2"],"pvcTolerateLessSpaceUpToPercent":5,"minimumReservePVCBytes":131072,"memoryOvercommit":150,"nodeSelectors":{"test":"test"},"useEmulation":true,"cpuAllocationRatio":25,"diskVerification":{"memoryLimit":"1G"},"logVerbosity":{"virtAPI":2,"virtController":2,"virtHandler":2,"virtLauncher":2,"virtOperator":2}}`),
+			`{"featureGates":["test1","test2"],"pvcTolerateLessSpaceUpToPercent":5,"minimumReservePVCBytes":131072,"memoryOvercommit":150,"nodeSelectors":{"test":"test"},"useEmulation":true,"cpuAllocationRatio":25,"tscFrequencyTolerancePPM":250,"diskVerification":{"memoryLimit":"1G"},"logVerbosity":{"virtAPI":2,"virtController":2,"virtHandler":2,"virtLauncher":2,"virtOperator":2}}`),
 		Entry("when wrong networkConfiguration set, should use the default",
 			v1.KubeVirtConfiguration{
 				NetworkConfiguration: &v1.NetworkConfiguration{
@@ -1009,4 +1024,35 @@ var _ = Describe("test configuration", func() {
 			Entry("should return hyperv-direct when feature gate is enabled with hyperv config", true, &HyperVDirectHypervisorConfig, v1.HyperVDirectHypervisorName),
 		)
 	})
+
+	DescribeTable("IsGuestAgentDataRedacted", func(guestAgent *v1.GuestAgentConfiguration, namespace string, kind v1.GuestAgentDataKind, expected bool) {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			GuestAgent: guestAgent,
+		})
+
+		Expect(clusterConfig.IsGuestAgentDataRedacted(namespace, kind)).To(Equal(expected))
+	},
+		Entry("should not redact without a guest agent configuration", nil, "default", v1.GuestAgentDataUsers, false),
+		Entry("should redact for every namespace when the policy has no namespaces",
+			&v1.GuestAgentConfiguration{DataPolicies: []v1.GuestAgentDataPolicy{
+				{Redact: []v1.GuestAgentDataKind{v1.GuestAgentDataUsers}},
+			}}, "default", v1.GuestAgentDataUsers, true),
+		Entry("should not redact kinds the policy does not list",
+			&v1.GuestAgentConfiguration{DataPolicies: []v1.GuestAgentDataPolicy{
+				{Redact: []v1.GuestAgentDataKind{v1.GuestAgentDataUsers}},
+			}}, "default", v1.GuestAgentDataOSInfo, false),
+		Entry("should redact in a namespace listed by the policy",
+			&v1.GuestAgentConfiguration{DataPolicies: []v1.GuestAgentDataPolicy{
+				{Namespaces: []string{"tenant"}, Redact: []v1.GuestAgentDataKind{v1.GuestAgentDataFilesystems}},
+			}}, "tenant", v1.GuestAgentDataFilesystems, true),
+		Entry("should not redact in a namespace the policy does not list",
+			&v1.GuestAgentConfiguration{DataPolicies: []v1.GuestAgentDataPolicy{
+				{Namespaces: []string{"tenant"}, Redact: []v1.GuestAgentDataKind{v1.GuestAgentDataFilesystems}},
+			}}, "default", v1.GuestAgentDataFilesystems, false),
+		Entry("should combine the policies matching the namespace",
+			&v1.GuestAgentConfiguration{DataPolicies: []v1.GuestAgentDataPolicy{
+				{Redact: []v1.GuestAgentDataKind{v1.GuestAgentDataUsers}},
+				{Namespaces: []string{"tenant"}, Redact: []v1.GuestAgentDataKind{v1.GuestAgentDataOSInfo}},
+			}}, "tenant", v1.GuestAgentDataOSInfo, true),
+	)
 })
@@ -244,3 +244,43 @@ func (config *ClusterConfig) OptOutRoleAggregationEnabled() bool {
 func (config *ClusterConfig) LiveUpdateNADRefEnabled() bool {
 	return config.isFeatureGateEnabled(featuregate.LiveUpdateNADRef)
 }
+
+func (config *ClusterConfig) SubresourceTokenExchangeEnabled() bool {
+	return config.isFeatureGateEnabled(featuregate.SubresourceTokenExchange)
+}
+
+func (config *ClusterConfig) LocalScratchEnabled() bool {
+	return config.isFeatureGateEnabled(featuregate.LocalScratch)
+}
+
+func (config *ClusterConfig) VolumeProtectionEnabled() bool {
+	return config.isFeatureGateEnabled(featuregate.VolumeProtection)
+}
+
+func (config *ClusterConfig) NetworkGatewayProbesEnabled() bool {
+	return config.isFeatureGateEnabled(featuregate.NetworkGatewayProbes)
+}
+
+func (config *ClusterConfig) GuestAgentAlternativesEnabled() bool {
+	return config.isFeatureGateEnabled(featuregate.GuestAgentAlternatives)
+}
+
+func (config *ClusterConfig) VMISummariesEnabled() bool {
+	return config.isFeatureGateEnabled(featuregate.VMISummaries)
+}
+
+func (config *ClusterConfig) StorageCapacityAwarenessEnabled() bool {
+	return config.isFeatureGateEnabled(featuregate.StorageCapacityAwareness)
+}
+
+func (config *ClusterConfig) GatewayAPIExposeEnabled() bool {
+	return config.isFeatureGateEnabled(featuregate.GatewayAPIExpose)
+}
+
+func (config *ClusterConfig) SubresourceSessionAuditEnabled() bool {
+	return config.isFeatureGateEnabled(featuregate.SubresourceSessionAudit)
+}
+
+func (config *ClusterConfig) VirtualMachineOperationsEnabled() bool {
+	return config.isFeatureGateEnabled(featuregate.VirtualMachineOperations)
+}
@@ -229,6 +229,76 @@ const (
 	// Owner: SIG network
 	// Beta: v1.8
 	LiveUpdateNADRef = "LiveUpdateNADRef"
+
+	// Owner: sig-compute
+	// Alpha: v1.8.0
+	//
+	// SubresourceTokenExchange enables exchanging an OIDC ID token for a short-lived token
+	// granting access to the console or VNC of a single VirtualMachineInstance.
+	SubresourceTokenExchange = "SubresourceTokenExchange"
+
+	// Owner: sig-storage
+	// Alpha: v1.8.0
+	//
+	// LocalScratch enables backing the ephemeral disks of a VirtualMachineInstance with a
+	// node-local scratch pool managed by virt-handler instead of the pod's emptyDir.
+	LocalScratch = "LocalScratch"
+
+	// Owner: sig-storage
+	// Alpha: v1.8.0
+	//
+	// VolumeProtection enables a finalizer which blocks the deletion of PersistentVolumeClaims
+	// and DataVolumes while they are used by a VirtualMachineInstance.
+	VolumeProtection = "VolumeProtection"
+
+	// Owner: sig-network
+	// Alpha: v1.8.0
+	//
+	// NetworkGatewayProbes enables virt-handler to periodically probe the gateways of secondary
+	// bridge networks from the network namespace of the VMI, independent of the guest agent.
+	NetworkGatewayProbes = "NetworkGatewayProbes"
+
+	// Owner: sig-compute
+	// Alpha: v1.8.0
+	//
+	// GuestAgentAlternatives allows virt-handler to talk to the alternative in-guest agents configured
+	// in the KubeVirt CR over VSOCK when qemu-guest-agent is not connected.
+	GuestAgentAlternatives = "GuestAgentAlternatives"
+
+	// Owner: sig-compute
+	// Alpha: v1.8.0
+	//
+	// VMISummaries serves paginated and sorted summaries of VMIs from a cache in virt-api,
+	// sparing the kube-apiserver from full lists issued by UIs.
+	VMISummaries = "VMISummaries"
+
+	// Owner: sig-storage
+	// Alpha: v1.8.0
+	//
+	// StorageCapacityAwareness delays the start of VMs whose volumes are on a StorageClass
+	// with less available capacity than the minimum annotated on the StorageClass.
+	StorageCapacityAwareness = "StorageCapacityAwareness"
+
+	// Owner: sig-network
+	// Alpha: v1.8.0
+	//
+	// GatewayAPIExpose publishes Services labeled with kubevirt.io/gateway-route through
+	// Gateway API HTTPRoutes or TCPRoutes.
+	GatewayAPIExpose = "GatewayAPIExpose"
+
+	// Owner: sig-compute
+	// Alpha: v1.8.0
+	//
+	// SubresourceSessionAudit makes virt-api write structured audit log entries for console, VNC,
+	// usbredir and port-forward sessions.
+	SubresourceSessionAudit = "SubresourceSessionAudit"
+
+	// Owner: sig-compute
+	// Alpha: v1.8.0
+	//
+	// VirtualMachineOperations enables the VirtualMachineOperation API, which starts, stops,
+	// migrates or snapshots all VirtualMachines matching a selector.
+	VirtualMachineOperations = "VirtualMachineOperations"
 )
 
 func init() {
@@ -275,4 +345,14 @@ func init() {
 	RegisterFeatureGate(FeatureGate{Name: ReservedOverheadMemlock, State: Alpha})
 	RegisterFeatureGate(FeatureGate{Name: OptOutRoleAggregation, State: Alpha})
 	RegisterFeatureGate(FeatureGate{Name: LiveUpdateNADRef, State: Beta})
+	RegisterFeatureGate(FeatureGate{Name: SubresourceTokenExchange, State: Alpha})
+	RegisterFeatureGate(FeatureGate{Name: LocalScratch, State: Alpha})
+	RegisterFeatureGate(FeatureGate{Name: VolumeProtection, State: Alpha})
+	RegisterFeatureGate(FeatureGate{Name: NetworkGatewayProbes, State: Alpha})
+	RegisterFeatureGate(FeatureGate{Name: GuestAgentAlternatives, State: Alpha})
+	RegisterFeatureGate(FeatureGate{Name: VMISummaries, State: Alpha})
+	RegisterFeatureGate(FeatureGate{Name: StorageCapacityAwareness, State: Alpha})
+	RegisterFeatureGate(FeatureGate{Name: GatewayAPIExpose, State: Alpha})
+	RegisterFeatureGate(FeatureGate{Name: SubresourceSessionAudit, State: Alpha})
+	RegisterFeatureGate(FeatureGate{Name: VirtualMachineOperations, State: Alpha})
 }
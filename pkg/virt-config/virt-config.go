@@ -24,13 +24,18 @@ package virtconfig
 */
 
 import (
+	"slices"
+	"time"
+
 	"kubevirt.io/client-go/log"
 
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/api/core/v1"
 
+	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
 )
 
@@ -70,6 +75,7 @@ const (
 	DefaultS390xOVMFPath                            = ""
 	DefaultMemBalloonStatsPeriod             uint32 = 10
 	DefaultCPUAllocationRatio                       = 10
+	DefaultTSCFrequencyTolerancePPM          int64  = 250
 	DefaultDiskVerificationMemoryLimitBytes         = 2000 * 1024 * 1024
 	DefaultVirtAPILogVerbosity                      = 2
 	DefaultVirtControllerLogVerbosity               = 2
@@ -93,6 +99,14 @@ const (
 
 	DefaultMaxHotplugRatio   = 4
 	DefaultVMRolloutStrategy = v1.VMRolloutStrategyLiveUpdate
+
+	DefaultClusterRecoveryOutageThresholdPercentage uint32 = 50
+	DefaultClusterRecoveryMaxStartsPerNode          uint32 = 2
+	DefaultClusterRecoveryStorageReadinessCheck            = true
+
+	DefaultVirtualMachineExportTTL           = 2 * time.Hour
+	DefaultVirtualMachineExportAutoRenew     = false
+	DefaultVirtualMachineExportExpiryWarning = 10 * time.Minute
 )
 
 func IsARM64(arch string) bool {
@@ -262,6 +276,10 @@ func (c *ClusterConfig) GetMinimumClusterTSCFrequency() *int64 {
 	return c.GetConfig().DeveloperConfiguration.MinimumClusterTSCFrequency
 }
 
+func (c *ClusterConfig) GetTSCFrequencyTolerancePPM() int64 {
+	return *c.GetConfig().DeveloperConfiguration.TSCFrequencyTolerancePPM
+}
+
 func (c *ClusterConfig) GetPermittedHostDevices() *v1.PermittedHostDevices {
 	return c.GetConfig().PermittedHostDevices
 }
@@ -424,6 +442,78 @@ func (c *ClusterConfig) GetVMStateStorageClass() string {
 	return c.GetConfig().VMStateStorageClass
 }
 
+func (c *ClusterConfig) GetVolumePermissionRepairPolicy() v1.VolumePermissionRepairPolicy {
+	if policy := c.GetConfig().VolumePermissionRepairPolicy; policy != nil {
+		return *policy
+	}
+	return v1.VolumePermissionRepairDisabled
+}
+
+// GetClusterRecoveryConfiguration returns the configuration of the staggered start of
+// VirtualMachines after a cluster-wide outage with all defaults applied, or nil if it is disabled.
+func (c *ClusterConfig) GetClusterRecoveryConfiguration() *v1.ClusterRecoveryConfiguration {
+	recovery := c.GetConfig().ClusterRecovery
+	if recovery == nil {
+		return nil
+	}
+	recovery = recovery.DeepCopy()
+	if recovery.OutageThresholdPercentage == nil {
+		recovery.OutageThresholdPercentage = pointer.P(DefaultClusterRecoveryOutageThresholdPercentage)
+	}
+	if recovery.MaxStartsPerNode == nil {
+		recovery.MaxStartsPerNode = pointer.P(DefaultClusterRecoveryMaxStartsPerNode)
+	}
+	if recovery.StorageReadinessCheck == nil {
+		recovery.StorageReadinessCheck = pointer.P(DefaultClusterRecoveryStorageReadinessCheck)
+	}
+	return recovery
+}
+
+// GetVirtualMachineExportConfiguration returns the expiration policy of VirtualMachineExports
+// with all defaults applied. MaxLifetime stays nil if it is not limited.
+func (c *ClusterConfig) GetVirtualMachineExportConfiguration() *v1.VirtualMachineExportConfiguration {
+	exportConfig := &v1.VirtualMachineExportConfiguration{}
+	if config := c.GetConfig().VirtualMachineExport; config != nil {
+		exportConfig = config.DeepCopy()
+	}
+	if exportConfig.DefaultTTL == nil {
+		exportConfig.DefaultTTL = &metav1.Duration{Duration: DefaultVirtualMachineExportTTL}
+	}
+	if exportConfig.AutoRenew == nil {
+		exportConfig.AutoRenew = pointer.P(DefaultVirtualMachineExportAutoRenew)
+	}
+	if exportConfig.ExpiryWarning == nil {
+		exportConfig.ExpiryWarning = &metav1.Duration{Duration: DefaultVirtualMachineExportExpiryWarning}
+	}
+	return exportConfig
+}
+
+// GetGuestAgentAlternatives returns the alternative in-guest agents in the order they should be tried.
+func (c *ClusterConfig) GetGuestAgentAlternatives() []v1.GuestAgentAlternative {
+	if guestAgent := c.GetConfig().GuestAgent; guestAgent != nil {
+		return guestAgent.Alternatives
+	}
+	return nil
+}
+
+// IsGuestAgentDataRedacted returns whether the given kind of guest agent data must not be stored
+// for VirtualMachineInstances in the namespace. Policies without namespaces apply cluster-wide.
+func (c *ClusterConfig) IsGuestAgentDataRedacted(namespace string, kind v1.GuestAgentDataKind) bool {
+	guestAgent := c.GetConfig().GuestAgent
+	if guestAgent == nil {
+		return false
+	}
+	for _, policy := range guestAgent.DataPolicies {
+		if len(policy.Namespaces) > 0 && !slices.Contains(policy.Namespaces, namespace) {
+			continue
+		}
+		if slices.Contains(policy.Redact, kind) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *ClusterConfig) IsFreePageReportingDisabled() bool {
 	return c.GetConfig().VirtualMachineOptions != nil && c.GetConfig().VirtualMachineOptions.DisableFreePageReporting != nil
 }
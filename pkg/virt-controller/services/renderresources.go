@@ -391,6 +391,15 @@ func WithPersistentReservation() ResourceRendererOption {
 	}
 }
 
+func WithLocalScratch(units int64) ResourceRendererOption {
+	return func(renderer *ResourceRenderer) {
+		name := k8sv1.ResourceName(LocalScratchDevice)
+		quantity := *resource.NewQuantity(units, resource.DecimalSI)
+		renderer.calculatedLimits[name] = quantity
+		renderer.calculatedRequests[name] = quantity
+	}
+}
+
 func copyResources(srcResources, dstResources k8sv1.ResourceList) {
 	for key, value := range srcResources {
 		dstResources[key] = value
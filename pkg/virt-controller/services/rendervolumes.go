@@ -42,6 +42,7 @@ type VolumeRenderer struct {
 	podVolumeMounts       []k8sv1.VolumeMount
 	sharedFilesystemPaths []string
 	volumeDevices         []k8sv1.VolumeDevice
+	localScratch          bool
 }
 
 func NewVolumeRenderer(clusterConfig *virtconfig.ClusterConfig, imageVolumeFeatureGateEnabled bool, launcherImage string, imageIDs map[string]string, namespace string, ephemeralDisk string, containerDiskDir string, virtShareDir string, volumeOptions ...VolumeRendererOption) (*VolumeRenderer, error) {
@@ -67,10 +68,13 @@ func (vr *VolumeRenderer) Mounts() []k8sv1.VolumeMount {
 	volumeMounts := []k8sv1.VolumeMount{
 		mountPath("private", util.VirtPrivateDir),
 		mountPath("public", util.VirtShareDir),
-		mountPath("ephemeral-disks", vr.ephemeralDiskDir),
 		mountPath("libvirt-runtime", "/var/run/libvirt"),
 		mountPath("sockets", filepath.Join(vr.virtShareDir, "sockets")),
 	}
+	// The local scratch device plugin mounts the ephemeral disk directory itself
+	if !vr.localScratch {
+		volumeMounts = append(volumeMounts, mountPath("ephemeral-disks", vr.ephemeralDiskDir))
+	}
 	if !vr.useImageVolumes {
 		volumeMounts = append(volumeMounts, mountPathWithPropagation(containerDisks, vr.containerDiskDir, k8sv1.MountPropagationHostToContainer))
 	}
@@ -84,7 +88,9 @@ func (vr *VolumeRenderer) Volumes() []k8sv1.Volume {
 		emptyDirVolume("sockets"),
 		emptyDirVolume(virtBinDir),
 		emptyDirVolume("libvirt-runtime"),
-		emptyDirVolume("ephemeral-disks"),
+	}
+	if !vr.localScratch {
+		volumes = append(volumes, emptyDirVolume("ephemeral-disks"))
 	}
 	if !vr.useImageVolumes {
 		volumes = append(volumes, emptyDirVolume(containerDisks))
@@ -155,6 +161,12 @@ func withVMIVolumes(pvcStore cache.Store, vmiSpecVolumes []v1.Volume, vmiVolumeS
 				}
 			}
 
+			if volume.MemoryDump != nil && !volume.MemoryDump.Hotpluggable {
+				if err := renderer.handleMemoryDumpVolume(volume, pvcStore); err != nil {
+					return err
+				}
+			}
+
 			if volume.Ephemeral != nil {
 				if err := renderer.handleEphemeralVolume(volume, pvcStore); err != nil {
 					return err
@@ -525,6 +537,13 @@ func withHotplugSupport(hotplugDiskDir string) VolumeRendererOption {
 	}
 }
 
+func withLocalScratch() VolumeRendererOption {
+	return func(renderer *VolumeRenderer) error {
+		renderer.localScratch = true
+		return nil
+	}
+}
+
 func withNetworkDeviceInfoMapAnnotation() VolumeRendererOption {
 	return func(renderer *VolumeRenderer) error {
 		renderer.podVolumes = append(renderer.podVolumes,
@@ -603,15 +622,65 @@ func (vr *VolumeRenderer) handlePVCVolume(volume v1.Volume, pvcStore cache.Store
 	return nil
 }
 
+// handleMemoryDumpVolume attaches a memory dump volume at start, e.g. to restore the memory state saved to it
+func (vr *VolumeRenderer) handleMemoryDumpVolume(volume v1.Volume, pvcStore cache.Store) error {
+	claimName := volume.MemoryDump.ClaimName
+	if err := vr.addPVCToLaunchManifest(pvcStore, volume, claimName); err != nil {
+		return err
+	}
+	vr.podVolumes = append(vr.podVolumes, k8sv1.Volume{
+		Name: volume.Name,
+		VolumeSource: k8sv1.VolumeSource{
+			PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
+		},
+	})
+	return nil
+}
+
 func (vr *VolumeRenderer) handleEphemeralVolume(volume v1.Volume, pvcStore cache.Store) error {
 	claimName := volume.Ephemeral.PersistentVolumeClaim.ClaimName
 	if err := vr.addPVCToLaunchManifest(pvcStore, volume, claimName); err != nil {
 		return err
 	}
+	// The base claim is never written to, attaching it read-only allows to share it between many VMIs
 	vr.podVolumes = append(vr.podVolumes, k8sv1.Volume{
 		Name: volume.Name,
 		VolumeSource: k8sv1.VolumeSource{
-			PersistentVolumeClaim: volume.Ephemeral.PersistentVolumeClaim,
+			PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+				ReadOnly:  true,
+			},
+		},
+	})
+	if volume.Ephemeral.OverlayClaimName != "" {
+		return vr.handleEphemeralOverlay(volume, pvcStore)
+	}
+	return nil
+}
+
+func (vr *VolumeRenderer) handleEphemeralOverlay(volume v1.Volume, pvcStore cache.Store) error {
+	claimName := volume.Ephemeral.OverlayClaimName
+	_, exists, isBlock, err := types.IsPVCBlockFromStore(pvcStore, vr.namespace, claimName)
+	if err != nil {
+		return err
+	} else if !exists {
+		return types.PvcNotFoundError{Reason: fmt.Sprintf("didn't find PVC %v", claimName)}
+	} else if isBlock {
+		return fmt.Errorf("overlay PVC %s of volume %s must have the Filesystem volume mode", claimName, volume.Name)
+	}
+	overlayVolumeName := types.EphemeralOverlayVolumeName(volume.Name)
+	vr.podVolumeMounts = append(vr.podVolumeMounts, k8sv1.VolumeMount{
+		Name:      overlayVolumeName,
+		MountPath: hostdisk.GetMountedHostDiskDir(overlayVolumeName),
+	})
+	vr.podVolumes = append(vr.podVolumes, k8sv1.Volume{
+		Name: overlayVolumeName,
+		VolumeSource: k8sv1.VolumeSource{
+			PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
 		},
 	})
 	return nil
@@ -92,7 +92,7 @@ var _ = Describe("Container spec renderer", func() {
 					k8sv1.Volume{
 						Name: ephemeralVolumeName,
 						VolumeSource: k8sv1.VolumeSource{
-							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{},
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ReadOnly: true},
 						},
 					})))
 		})
@@ -102,6 +102,82 @@ var _ = Describe("Container spec renderer", func() {
 		})
 	})
 
+	Context("with ephemeral volume option and an overlay claim", func() {
+		const (
+			ephemeralVolumeName = "evn"
+			baseClaimName       = "golden-image"
+			overlayClaimName    = "evn-overlay-claim"
+		)
+
+		var ephemeralVolume v1.Volume
+
+		newPVCStore := func(overlayVolumeMode k8sv1.PersistentVolumeMode) cache.Store {
+			return &cache.FakeCustomStore{
+				GetByKeyFunc: func(key string) (item interface{}, exists bool, err error) {
+					if key == namespace+"/"+overlayClaimName {
+						return &k8sv1.PersistentVolumeClaim{
+							Spec: k8sv1.PersistentVolumeClaimSpec{VolumeMode: &overlayVolumeMode},
+						}, true, nil
+					}
+					return &k8sv1.PersistentVolumeClaim{
+						Spec: k8sv1.PersistentVolumeClaimSpec{
+							AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadOnlyMany},
+						},
+					}, true, nil
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			ephemeralVolume = v1.Volume{
+				Name: ephemeralVolumeName,
+				VolumeSource: v1.VolumeSource{
+					Ephemeral: &v1.EphemeralVolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: baseClaimName},
+						OverlayClaimName:      overlayClaimName,
+					},
+				},
+			}
+		})
+
+		It("should attach the base claim read-only and mount the overlay claim", func() {
+			var err error
+			vsr, err = NewVolumeRenderer(config, false, launcherImage, make(map[string]string), namespace, ephemeralDisk, containerDisk, virtShareDir, withVMIVolumes(newPVCStore(k8sv1.PersistentVolumeFilesystem), []v1.Volume{ephemeralVolume}, nil))
+			Expect(err).NotTo(HaveOccurred())
+
+			overlayVolumeName := ephemeralVolumeName + "-overlay"
+			Expect(vsr.Mounts()).To(ConsistOf(
+				append(
+					defaultVolumeMounts(),
+					k8sv1.VolumeMount{
+						Name:      ephemeralVolumeName,
+						MountPath: vmiDiskPath(ephemeralVolumeName)},
+					k8sv1.VolumeMount{
+						Name:      overlayVolumeName,
+						MountPath: vmiDiskPath(overlayVolumeName)})))
+			Expect(vsr.Volumes()).To(ConsistOf(
+				append(
+					defaultVolumes(),
+					k8sv1.Volume{
+						Name: ephemeralVolumeName,
+						VolumeSource: k8sv1.VolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: baseClaimName, ReadOnly: true},
+						},
+					},
+					k8sv1.Volume{
+						Name: overlayVolumeName,
+						VolumeSource: k8sv1.VolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: overlayClaimName},
+						},
+					})))
+		})
+
+		It("should fail if the overlay claim is a block volume", func() {
+			_, err := NewVolumeRenderer(config, false, launcherImage, make(map[string]string), namespace, ephemeralDisk, containerDisk, virtShareDir, withVMIVolumes(newPVCStore(k8sv1.PersistentVolumeBlock), []v1.Volume{ephemeralVolume}, nil))
+			Expect(err).To(MatchError(ContainSubstring("must have the Filesystem volume mode")))
+		})
+	})
+
 	Context("with host disk volume option", func() {
 		const (
 			hostDiskName = "tiny-winy-disk"
@@ -296,6 +372,68 @@ var _ = Describe("Container spec renderer", func() {
 		})
 	})
 
+	Context("with memory dump volume option", func() {
+		const (
+			memoryDumpVolumeName = "memory"
+			memoryDumpClaimName  = "memory-pvc"
+		)
+
+		newMemoryDumpVolume := func(hotpluggable bool) v1.Volume {
+			return v1.Volume{
+				Name: memoryDumpVolumeName,
+				VolumeSource: v1.VolumeSource{MemoryDump: &v1.MemoryDumpVolumeSource{
+					PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: memoryDumpClaimName,
+						},
+						Hotpluggable: hotpluggable,
+					},
+					Format: v1.MemoryDumpFormatSavedState,
+				}},
+			}
+		}
+
+		pvcStore := &cache.FakeCustomStore{
+			GetByKeyFunc: func(key string) (item interface{}, exists bool, err error) {
+				return &k8sv1.PersistentVolumeClaim{}, true, nil
+			},
+		}
+
+		It("should mount the claim of a memory dump volume which is not hotpluggable", func() {
+			var err error
+			vsr, err = NewVolumeRenderer(config, false, launcherImage, make(map[string]string), namespace, ephemeralDisk, containerDisk, virtShareDir, withVMIVolumes(pvcStore, []v1.Volume{newMemoryDumpVolume(false)}, nil))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vsr.Mounts()).To(ConsistOf(
+				append(
+					defaultVolumeMounts(),
+					k8sv1.VolumeMount{
+						Name:      memoryDumpVolumeName,
+						MountPath: "/var/run/kubevirt-private/vmi-disks/memory",
+					})))
+			Expect(vsr.Volumes()).To(ConsistOf(
+				append(
+					defaultVolumes(),
+					k8sv1.Volume{
+						Name: memoryDumpVolumeName,
+						VolumeSource: k8sv1.VolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: memoryDumpClaimName,
+							},
+						},
+					})))
+		})
+
+		It("should not mount the claim of a hotpluggable memory dump volume", func() {
+			var err error
+			vsr, err = NewVolumeRenderer(config, false, launcherImage, make(map[string]string), namespace, ephemeralDisk, containerDisk, virtShareDir, withVMIVolumes(pvcStore, []v1.Volume{newMemoryDumpVolume(true)}, nil))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vsr.Mounts()).To(ConsistOf(defaultVolumeMounts()))
+			Expect(vsr.Volumes()).To(ConsistOf(defaultVolumes()))
+		})
+	})
+
 	Context("with Downward API option", func() {
 		const (
 			downwardAPIVolumeName = "downward-then-upward"
@@ -57,8 +57,10 @@ import (
 	"kubevirt.io/kubevirt/pkg/network/multus"
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
 	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
+	"kubevirt.io/kubevirt/pkg/storage/localscratch"
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
 	"kubevirt.io/kubevirt/pkg/storage/types"
+	"kubevirt.io/kubevirt/pkg/storage/velero"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -87,6 +89,7 @@ const SevDeviceName = "sev"
 const TdxDeviceName = "tdx"
 const SevDevice = K8sDevicePrefix + "/" + SevDeviceName
 const TdxDevice = K8sDevicePrefix + "/" + TdxDeviceName
+const LocalScratchDevice = K8sDevicePrefix + "/" + localscratch.ResourceName
 
 const debugLogs = "debugLogs"
 const logVerbosity = "logVerbosity"
@@ -160,6 +163,15 @@ func setNodeAffinityForPod(vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) {
 	setNodeAffinityForbiddenFeaturePolicy(vmi, pod)
 }
 
+// setNodeAffinityForMigrationHeadroom keeps new VMIs away from nodes whose free
+// capacity is below the migration headroom. It is not applied to migration
+// targets, which are the reason for the headroom.
+func (t *TemplateService) setNodeAffinityForMigrationHeadroom(pod *k8sv1.Pod) {
+	if t.clusterConfig.GetMigrationConfiguration().NodeHeadroom != nil {
+		pod.Spec.Affinity = modifyNodeAffintyToRejectLabel(pod.Spec.Affinity, v1.NodeMigrationHeadroomExhaustedLabel)
+	}
+}
+
 func setNodeAffinityForHostModelCpuModel(vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) {
 	if vmi.Spec.Domain.CPU == nil || vmi.Spec.Domain.CPU.Model == "" || vmi.Spec.Domain.CPU.Model == v1.CPUModeHostModel {
 		pod.Spec.Affinity = modifyNodeAffintyToRejectLabel(pod.Spec.Affinity, v1.NodeHostModelIsObsoleteLabel)
@@ -253,7 +265,12 @@ func (t *TemplateService) RenderLaunchManifestNoVm(vmi *v1.VirtualMachineInstanc
 		backendStoragePVCName = backendStoragePVC.Name
 	}
 	memoryOverhead := CalculateMemoryOverhead(t.clusterConfig, t.netMemoryCalculator, vmi, t.launcherHypervisorResources)
-	return t.renderLaunchManifest(vmi, nil, backendStoragePVCName, true, memoryOverhead)
+	pod, err := t.renderLaunchManifest(vmi, nil, backendStoragePVCName, true, memoryOverhead)
+	if err != nil {
+		return nil, err
+	}
+	t.setNodeAffinityForMigrationHeadroom(pod)
+	return pod, nil
 }
 
 func (t *TemplateService) RenderMigrationManifest(vmi *v1.VirtualMachineInstance, migration *v1.VirtualMachineInstanceMigration, sourcePod *k8sv1.Pod) (*k8sv1.Pod, error) {
@@ -297,7 +314,12 @@ func (t *TemplateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 		backendStoragePVCName = backendStoragePVC.Name
 	}
 	memoryOverhead := CalculateMemoryOverhead(t.clusterConfig, t.netMemoryCalculator, vmi, t.launcherHypervisorResources)
-	return t.renderLaunchManifest(vmi, nil, backendStoragePVCName, false, memoryOverhead)
+	pod, err := t.renderLaunchManifest(vmi, nil, backendStoragePVCName, false, memoryOverhead)
+	if err != nil {
+		return nil, err
+	}
+	t.setNodeAffinityForMigrationHeadroom(pod)
+	return pod, nil
 }
 
 func generateQemuTimeoutWithJitter(qemuTimeoutBaseSeconds int) string {
@@ -339,13 +361,8 @@ func (t *TemplateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, i
 		userId = util.NonRootUID
 	}
 
-	// Pad the virt-launcher grace period.
-	// Ideally we want virt-handler to handle tearing down
-	// the vmi without virt-launcher's termination forcing
-	// the vmi down.
-	const gracePeriodPaddingSeconds int64 = 15
 	gracePeriodSeconds := gracePeriodInSeconds(vmi) + gracePeriodPaddingSeconds
-	gracePeriodKillAfter := gracePeriodSeconds + gracePeriodPaddingSeconds
+	gracePeriodKillAfter := LauncherTerminationGracePeriodSeconds(vmi)
 
 	imagePullSecrets := imgPullSecrets(vmi.Spec.Volumes...)
 	if util.HasKernelBootContainerImage(vmi) && vmi.Spec.Domain.Firmware.KernelBoot.Container.ImagePullSecret != "" {
@@ -469,6 +486,10 @@ func (t *TemplateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, i
 		compute.Env = append(compute.Env, k8sv1.EnvVar{Name: util.ENV_VAR_VIRTIOFSD_DEBUG_LOGS, Value: "1"})
 	}
 
+	if t.isLocalScratchVMI(vmi) {
+		compute.Env = append(compute.Env, k8sv1.EnvVar{Name: util.ENV_VAR_LOCAL_SCRATCH, Value: "1"})
+	}
+
 	compute.Env = append(compute.Env, k8sv1.EnvVar{
 		Name: ENV_VAR_POD_NAME,
 		ValueFrom: &k8sv1.EnvVarSource{
@@ -639,7 +660,7 @@ func (t *TemplateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, i
 	pod := k8sv1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "virt-launcher-" + domain + "-",
-			Labels:       podLabels(vmi, hostName),
+			Labels:       podLabels(vmi, hostName, velero.SkipHooks(vmi, t.clusterConfig.GetConfigFromKubeVirtCR())),
 			Annotations:  podAnnotations,
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(vmi, v1.VirtualMachineInstanceGroupVersionKind),
@@ -894,6 +915,10 @@ func (t *TemplateService) newVolumeRenderer(vmi *v1.VirtualMachineInstance, imag
 		volumeOpts = append(volumeOpts, withVirioFS())
 	}
 
+	if t.isLocalScratchVMI(vmi) {
+		volumeOpts = append(volumeOpts, withLocalScratch())
+	}
+
 	volumeRenderer, err := NewVolumeRenderer(
 		t.clusterConfig,
 		imageVolumeFeatureGateEnabled,
@@ -923,10 +948,22 @@ func (t *TemplateService) newResourceRenderer(vmi *v1.VirtualMachineInstance, ne
 		return nil, err
 	}
 
+	if t.isLocalScratchVMI(vmi) {
+		units, err := localscratch.RequestedUnits(vmi)
+		if err != nil {
+			return nil, err
+		}
+		baseOptions = append(baseOptions, WithLocalScratch(units))
+	}
+
 	options := append(baseOptions, t.VMIResourcePredicates(vmi, networkToResourceMap, memoryOverhead).Apply()...)
 	return NewResourceRenderer(vmiResources.Limits, vmiResources.Requests, options...), nil
 }
 
+func (t *TemplateService) isLocalScratchVMI(vmi *v1.VirtualMachineInstance) bool {
+	return t.clusterConfig.LocalScratchEnabled() && localscratch.IsLocalScratchVMI(vmi)
+}
+
 func ConstructHypervisorResourceName(l hypervisor.LauncherHypervisorResources) k8sv1.ResourceName {
 	return k8sv1.ResourceName(K8sDevicePrefix + "/" + l.GetHypervisorDevice())
 }
@@ -954,6 +991,19 @@ func pvcVolumeMount(v hooks.PVC) k8sv1.VolumeMount {
 	}
 }
 
+// Pad the virt-launcher grace period.
+// Ideally we want virt-handler to handle tearing down
+// the vmi without virt-launcher's termination forcing
+// the vmi down.
+const gracePeriodPaddingSeconds int64 = 15
+
+// LauncherTerminationGracePeriodSeconds returns the grace period of the
+// virt-launcher pod of the VMI, which is padded twice so that virt-handler
+// can shut the domain down before the pod is killed.
+func LauncherTerminationGracePeriodSeconds(vmi *v1.VirtualMachineInstance) int64 {
+	return gracePeriodInSeconds(vmi) + 2*gracePeriodPaddingSeconds
+}
+
 func gracePeriodInSeconds(vmi *v1.VirtualMachineInstance) int64 {
 	if vmi.Spec.TerminationGracePeriodSeconds != nil {
 		return *vmi.Spec.TerminationGracePeriodSeconds
@@ -1608,7 +1658,7 @@ func (p VMIResourcePredicates) Apply() []ResourceRendererOption {
 	return options
 }
 
-func podLabels(vmi *v1.VirtualMachineInstance, hostName string) map[string]string {
+func podLabels(vmi *v1.VirtualMachineInstance, hostName string, skipBackupHooks bool) map[string]string {
 	labels := map[string]string{}
 
 	for k, v := range vmi.Labels {
@@ -1621,6 +1671,11 @@ func podLabels(vmi *v1.VirtualMachineInstance, hostName string) map[string]strin
 	if val, exists := vmi.Annotations[istio.InjectSidecarAnnotation]; exists {
 		labels[istio.InjectSidecarLabel] = val
 	}
+	// Launcher pods are recreated on restore. Velero only runs the freeze hooks of the
+	// pods it backs up, so they are only excluded when the hooks are skipped.
+	if skipBackupHooks {
+		labels[velero.ExcludeFromBackupLabel] = "true"
+	}
 	return labels
 }
 
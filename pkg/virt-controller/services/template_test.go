@@ -36,6 +36,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gstruct"
+	gomegatypes "github.com/onsi/gomega/types"
 	"go.uber.org/mock/gomock"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -60,6 +61,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/network/istio"
 	"kubevirt.io/kubevirt/pkg/network/multus"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
+	"kubevirt.io/kubevirt/pkg/storage/velero"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/util"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -1960,6 +1962,36 @@ var _ = Describe("Template", func() {
 				))
 			})
 
+			DescribeTable("should exclude the pod from Velero backups only when the backup hooks are skipped", func(annotations, kvAnnotations map[string]string, matcher gomegatypes.GomegaMatcher) {
+				config, kvStore, svc = configFactory(defaultArch)
+				kvWithAnnotations := kv.DeepCopy()
+				kvWithAnnotations.Annotations = kvAnnotations
+				testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kvWithAnnotations)
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{Name: "testvmi",
+						Namespace:   "default",
+						UID:         "1234",
+						Annotations: annotations,
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+						},
+					},
+				}
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Labels).To(matcher)
+			},
+				Entry("with hooks", nil, nil, Not(HaveKey(velero.ExcludeFromBackupLabel))),
+				Entry("with skipped hooks", map[string]string{velero.SkipHooksAnnotation: "true"}, nil, HaveKeyWithValue(velero.ExcludeFromBackupLabel, "true")),
+				Entry("with hooks skipped on the KubeVirt CR", nil, map[string]string{velero.SkipHooksAnnotation: "true"}, HaveKeyWithValue(velero.ExcludeFromBackupLabel, "true")),
+				Entry("with hooks enabled on the VMI but skipped on the KubeVirt CR",
+					map[string]string{velero.SkipHooksAnnotation: "false"}, map[string]string{velero.SkipHooksAnnotation: "true"}, Not(HaveKey(velero.ExcludeFromBackupLabel))),
+			)
+
 			It("should not add empty affinity to pod", func() {
 				config, kvStore, svc = configFactory(defaultArch)
 				vmi := v1.VirtualMachineInstance{
@@ -1980,6 +2012,39 @@ var _ = Describe("Template", func() {
 
 				Expect(pod.Spec.Affinity).To(BeNil())
 			})
+			It("should reject nodes with exhausted migration headroom when a headroom is configured", func() {
+				config, kvStore, svc = configFactory(defaultArch)
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.MigrationConfiguration = &v1.MigrationConfiguration{
+					NodeHeadroom: &v1.MigrationNodeHeadroom{
+						CPUPercentage:    pointer.P(uint32(10)),
+						MemoryPercentage: pointer.P(uint32(10)),
+					},
+				}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kvConfig)
+
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{Name: "testvm", Namespace: "default", UID: "1234"},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+						},
+					},
+				}
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Affinity).ToNot(BeNil())
+				Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(HaveLen(1))
+				Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions).To(ContainElement(
+					k8sv1.NodeSelectorRequirement{
+						Key:      v1.NodeMigrationHeadroomExhaustedLabel,
+						Operator: k8sv1.NodeSelectorOpDoesNotExist,
+					},
+				))
+			})
 			DescribeTable("should add affinity to pod of vmi host model", func(model string) {
 				config, kvStore, svc = configFactory(defaultArch)
 				foundNodeSelectorRequirement := false
@@ -3583,6 +3648,52 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with local scratch", func() {
+			newLocalScratchVMI := func() *v1.VirtualMachineInstance {
+				vmi := api.NewMinimalVMI("testvmi")
+				vmi.Annotations = map[string]string{v1.LocalScratchCapacityAnnotation: "1500Mi"}
+				return vmi
+			}
+
+			hasEphemeralDisks := func(pod *k8sv1.Pod) bool {
+				for _, volume := range pod.Spec.Volumes {
+					if volume.Name == "ephemeral-disks" {
+						return true
+					}
+				}
+				return false
+			}
+
+			BeforeEach(func() {
+				config, kvStore, svc = configFactory(defaultArch)
+			})
+
+			It("should request scratch units instead of the ephemeral disk emptyDir", func() {
+				enableFeatureGate(featuregate.LocalScratch)
+
+				pod, err := svc.RenderLaunchManifest(newLocalScratchVMI())
+				Expect(err).ToNot(HaveOccurred())
+
+				resources := pod.Spec.Containers[0].Resources
+				Expect(resources.Limits).To(HaveKeyWithValue(k8sv1.ResourceName(LocalScratchDevice), *resource.NewQuantity(2, resource.DecimalSI)))
+				Expect(resources.Requests).To(HaveKeyWithValue(k8sv1.ResourceName(LocalScratchDevice), *resource.NewQuantity(2, resource.DecimalSI)))
+				Expect(hasEphemeralDisks(pod)).To(BeFalse())
+				for _, volumeMount := range pod.Spec.Containers[0].VolumeMounts {
+					Expect(volumeMount.Name).ToNot(Equal("ephemeral-disks"))
+				}
+				Expect(pod.Spec.Containers[0].Env).To(ContainElement(k8sv1.EnvVar{Name: util.ENV_VAR_LOCAL_SCRATCH, Value: "1"}))
+			})
+
+			It("should ignore the annotation when the feature gate is disabled", func() {
+				pod, err := svc.RenderLaunchManifest(newLocalScratchVMI())
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Resources.Limits).ToNot(HaveKey(k8sv1.ResourceName(LocalScratchDevice)))
+				Expect(hasEphemeralDisks(pod)).To(BeTrue())
+				Expect(pod.Spec.Containers[0].Env).ToNot(ContainElement(HaveField("Name", util.ENV_VAR_LOCAL_SCRATCH)))
+			})
+		})
+
 		Context("with specified priorityClass", func() {
 			It("should add priorityClass", func() {
 				config, kvStore, svc = configFactory(defaultArch)
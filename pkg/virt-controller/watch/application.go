@@ -40,10 +40,13 @@ import (
 	clonecontroller "kubevirt.io/kubevirt/pkg/virt-controller/watch/clone"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/migration"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/node"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/operations"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/pool"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/recovery"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/replicaset"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/vm"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/vmi"
+	volumeprotection "kubevirt.io/kubevirt/pkg/virt-controller/watch/volume-protection"
 
 	"github.com/emicklei/go-restful/v3"
 	vsv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
@@ -66,6 +69,8 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/util/ratelimiter"
 
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/gateway"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/headroom"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/topology"
 
 	"kubevirt.io/kubevirt/pkg/healthz"
@@ -73,6 +78,7 @@ import (
 
 	backupv1 "kubevirt.io/api/backup/v1alpha1"
 	exportv1 "kubevirt.io/api/export/v1beta1"
+	operationsv1alpha1 "kubevirt.io/api/operations/v1alpha1"
 	poolv1 "kubevirt.io/api/pool/v1beta1"
 	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
 	"kubevirt.io/client-go/kubecli"
@@ -88,6 +94,7 @@ import (
 	clientmetrics "kubevirt.io/kubevirt/pkg/monitoring/metrics/common/client"
 	metrics "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-controller"
 	"kubevirt.io/kubevirt/pkg/service"
+	"kubevirt.io/kubevirt/pkg/storage/capacity"
 	backup "kubevirt.io/kubevirt/pkg/storage/cbt"
 	"kubevirt.io/kubevirt/pkg/storage/export/export"
 	"kubevirt.io/kubevirt/pkg/storage/snapshot"
@@ -114,6 +121,7 @@ const (
 
 	launcherImage       = "virt-launcher"
 	exporterImage       = "virt-exportserver"
+	libguestfsImage     = "libguestfs-tools"
 	launcherQemuTimeout = 240
 
 	migrationControllerRestTimeout = 30 * time.Second
@@ -132,6 +140,7 @@ const (
 	defaultLauncherSubGid                 = 107
 	defaultSnapshotControllerResyncPeriod = 5 * time.Minute
 	defaultNodeTopologyUpdatePeriod       = 30 * time.Second
+	defaultNodeHeadroomUpdatePeriod       = 30 * time.Second
 
 	defaultPromCertFilePath = "/etc/virt-controller/certificates/tls.crt"
 	defaultPromKeyFilePath  = "/etc/virt-controller/certificates/tls.key"
@@ -185,6 +194,13 @@ type VirtControllerApp struct {
 	vmController *vm.Controller
 	vmInformer   cache.SharedIndexInformer
 
+	clusterRecoveryController *recovery.Controller
+
+	volumeProtectionController *volumeprotection.Controller
+
+	gatewayController      *gateway.Controller
+	gatewayServiceInformer cache.SharedIndexInformer
+
 	controllerRevisionInformer cache.SharedIndexInformer
 
 	dataVolumeInformer     cache.SharedIndexInformer
@@ -212,6 +228,7 @@ type VirtControllerApp struct {
 	vmSnapshotContentInformer    cache.SharedIndexInformer
 	vmRestoreInformer            cache.SharedIndexInformer
 	storageClassInformer         cache.SharedIndexInformer
+	csiStorageCapacityInformer   cache.SharedIndexInformer
 	allPodInformer               cache.SharedIndexInformer
 	resourceQuotaInformer        cache.SharedIndexInformer
 
@@ -226,6 +243,9 @@ type VirtControllerApp struct {
 	vmBackupTrackerInformer cache.SharedIndexInformer
 	vmBackupController      *backup.VMBackupController
 
+	vmOperationInformer   cache.SharedIndexInformer
+	vmOperationController *operations.Controller
+
 	instancetypeInformer        cache.SharedIndexInformer
 	clusterInstancetypeInformer cache.SharedIndexInformer
 	preferenceInformer          cache.SharedIndexInformer
@@ -235,6 +255,7 @@ type VirtControllerApp struct {
 
 	launcherImage              string
 	exporterImage              string
+	libguestfsImage            string
 	launcherQemuTimeout        int
 	imagePullSecret            string
 	virtShareDir               string
@@ -266,6 +287,8 @@ type VirtControllerApp struct {
 	migrationControllerThreads        int
 	evacuationControllerThreads       int
 	disruptionBudgetControllerThreads int
+	volumeProtectionControllerThreads int
+	gatewayControllerThreads          int
 	launcherSubGid                    int64
 	exportControllerThreads           int
 	snapshotControllerThreads         int
@@ -275,11 +298,14 @@ type VirtControllerApp struct {
 	additionalLauncherAnnotationsSync []string
 	additionalLauncherLabelsSync      []string
 	backupControllerThreads           int
+	operationControllerThreads        int
 
 	promCertFilePath         string
 	promKeyFilePath          string
 	nodeTopologyUpdater      topology.NodeTopologyUpdater
 	nodeTopologyUpdatePeriod time.Duration
+	nodeHeadroomUpdater      headroom.NodeHeadroomUpdater
+	nodeHeadroomUpdatePeriod time.Duration
 	reloadableRateLimiter    *ratelimiter.ReloadableRateLimiter
 	leaderElector            *leaderelection.LeaderElector
 
@@ -295,6 +321,7 @@ func init() {
 	utilruntime.Must(poolv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(clone.AddToScheme(scheme.Scheme))
 	utilruntime.Must(backupv1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(operationsv1alpha1.AddToScheme(scheme.Scheme))
 }
 
 func Execute() {
@@ -415,11 +442,13 @@ func Execute() {
 	app.vmSnapshotContentInformer = app.informerFactory.VirtualMachineSnapshotContent()
 	app.vmRestoreInformer = app.informerFactory.VirtualMachineRestore()
 	app.storageClassInformer = app.informerFactory.StorageClass()
+	app.csiStorageCapacityInformer = app.informerFactory.CSIStorageCapacity()
 	app.caExportConfigMapInformer = app.informerFactory.KubeVirtExportCAConfigMap()
 	app.exportRouteConfigMapInformer = app.informerFactory.ExportRouteConfigMap()
 	app.unmanagedSecretInformer = app.informerFactory.UnmanagedSecrets()
 	app.allPodInformer = app.informerFactory.Pod()
 	app.exportServiceInformer = app.informerFactory.ExportService()
+	app.gatewayServiceInformer = app.informerFactory.GatewayService()
 	app.resourceQuotaInformer = app.informerFactory.ResourceQuota()
 
 	if app.hasCDI {
@@ -456,6 +485,7 @@ func Execute() {
 	app.migrationPolicyInformer = app.informerFactory.MigrationPolicy()
 
 	app.vmCloneInformer = app.informerFactory.VirtualMachineClone()
+	app.vmOperationInformer = app.informerFactory.VirtualMachineOperation()
 
 	app.instancetypeInformer = app.informerFactory.VirtualMachineInstancetype()
 	app.clusterInstancetypeInformer = app.informerFactory.VirtualMachineClusterInstancetype()
@@ -492,8 +522,11 @@ func Execute() {
 	app.initCommon()
 	app.initReplicaSet()
 	app.initPool()
+	app.initClusterRecoveryController()
 	app.initVirtualMachines()
 	app.initDisruptionBudgetController()
+	app.initVolumeProtectionController()
+	app.initGatewayController()
 	app.initEvacuationController()
 	app.initSnapshotController()
 	app.initRestoreController()
@@ -501,6 +534,7 @@ func Execute() {
 	app.initWorkloadUpdaterController()
 	app.initCloneController()
 	app.initBackupController()
+	app.initVirtualMachineOperationController()
 	go app.Run()
 
 	<-app.reInitChan
@@ -616,6 +650,8 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 
 		go vca.evacuationController.Run(vca.evacuationControllerThreads, stop)
 		go vca.disruptionBudgetController.Run(vca.disruptionBudgetControllerThreads, stop)
+		go vca.volumeProtectionController.Run(vca.volumeProtectionControllerThreads, stop)
+		go vca.gatewayController.Run(vca.gatewayControllerThreads, stop)
 		go vca.nodeController.Run(vca.nodeControllerThreads, stop)
 		go vca.vmiController.Run(vca.vmiControllerThreads, stop)
 		if vca.isDRAEnabled {
@@ -624,6 +660,7 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 		go vca.rsController.Run(vca.rsControllerThreads, stop)
 		go vca.poolController.Run(vca.poolControllerThreads, stop)
 		go vca.vmController.Run(vca.vmControllerThreads, stop)
+		go vca.clusterRecoveryController.Run(stop)
 		go vca.migrationController.Run(vca.migrationControllerThreads, stop)
 		go func() {
 			if err := vca.snapshotController.Run(vca.snapshotControllerThreads, stop); err != nil {
@@ -642,6 +679,7 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 		}()
 		go vca.workloadUpdateController.Run(stop)
 		go vca.nodeTopologyUpdater.Run(vca.nodeTopologyUpdatePeriod, stop)
+		go vca.nodeHeadroomUpdater.Run(vca.nodeHeadroomUpdatePeriod, stop)
 		go func() {
 			if err := vca.vmCloneController.Run(vca.cloneControllerThreads, stop); err != nil {
 				log.Log.Warningf("error running the clone controller: %v", err)
@@ -652,6 +690,11 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 				log.Log.Warningf("error running the backup controller: %v", err)
 			}
 		}()
+		go func() {
+			if err := vca.vmOperationController.Run(vca.operationControllerThreads, stop); err != nil {
+				log.Log.Warningf("error running the vm operation controller: %v", err)
+			}
+		}()
 
 		cache.WaitForCacheSync(stop, vca.persistentVolumeClaimInformer.HasSynced, vca.namespaceInformer.HasSynced, vca.resourceQuotaInformer.HasSynced)
 		close(vca.readyChan)
@@ -779,6 +822,7 @@ func (vca *VirtControllerApp) initCommon() {
 	}
 
 	vca.nodeTopologyUpdater = topology.NewNodeTopologyUpdater(vca.clientSet, topologyHinter, vca.nodeInformer)
+	vca.nodeHeadroomUpdater = headroom.NewNodeHeadroomUpdater(vca.clientSet, vca.clusterConfig, vca.nodeInformer, vca.allPodInformer)
 }
 
 func (vca *VirtControllerApp) initReplicaSet() {
@@ -807,6 +851,22 @@ func (vca *VirtControllerApp) initPool() {
 	}
 }
 
+func (vca *VirtControllerApp) initClusterRecoveryController() {
+	var err error
+	vca.clusterRecoveryController, err = recovery.NewController(
+		vca.vmInformer,
+		vca.vmiInformer,
+		vca.nodeInformer,
+		vca.persistentVolumeClaimInformer,
+		vca.kubeVirtInformer,
+		vca.clientSet,
+		vca.clusterConfig,
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (vca *VirtControllerApp) initVirtualMachines() {
 	var err error
 	recorder := vca.newRecorder(k8sv1.NamespaceAll, "virtualmachine-controller")
@@ -820,6 +880,7 @@ func (vca *VirtControllerApp) initVirtualMachines() {
 		vca.namespaceInformer,
 		vca.persistentVolumeClaimInformer,
 		vca.controllerRevisionInformer,
+		vca.vmSnapshotInformer,
 		recorder,
 		vca.clientSet,
 		vca.clusterConfig,
@@ -828,6 +889,13 @@ func (vca *VirtControllerApp) initVirtualMachines() {
 			vca.clusterConfig,
 		),
 		vm.NewFirmwareController(vca.clientSet.GeneratedKubeVirtClient()),
+		vca.clusterRecoveryController,
+		capacity.NewChecker(
+			vca.storageClassInformer.GetStore(),
+			vca.csiStorageCapacityInformer.GetIndexer(),
+			vca.persistentVolumeClaimInformer.GetStore(),
+			vca.clusterConfig,
+		),
 		instancetypecontroller.New(
 			vca.instancetypeInformer.GetStore(),
 			vca.clusterInstancetypeInformer.GetStore(),
@@ -846,6 +914,36 @@ func (vca *VirtControllerApp) initVirtualMachines() {
 	}
 }
 
+func (vca *VirtControllerApp) initVolumeProtectionController() {
+	var err error
+	recorder := vca.newRecorder(k8sv1.NamespaceAll, "volumeprotection-controller")
+	vca.volumeProtectionController, err = volumeprotection.NewController(
+		vca.vmiInformer,
+		vca.persistentVolumeClaimInformer,
+		vca.dataVolumeInformer,
+		recorder,
+		vca.clientSet,
+		vca.clusterConfig,
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (vca *VirtControllerApp) initGatewayController() {
+	var err error
+	recorder := vca.newRecorder(k8sv1.NamespaceAll, "gateway-controller")
+	vca.gatewayController, err = gateway.NewController(
+		vca.gatewayServiceInformer,
+		gateway.NewDynamicRouteClient(vca.clientSet.DynamicClient()),
+		recorder,
+		vca.clusterConfig,
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (vca *VirtControllerApp) initDisruptionBudgetController() {
 	var err error
 	recorder := vca.newRecorder(k8sv1.NamespaceAll, "disruptionbudget-controller")
@@ -979,7 +1077,7 @@ func (vca *VirtControllerApp) initCloneController() {
 	var err error
 	recorder := vca.newRecorder(k8sv1.NamespaceAll, "clone-controller")
 	vca.vmCloneController, err = clonecontroller.NewVmCloneController(
-		vca.clientSet, vca.vmCloneInformer, vca.vmSnapshotInformer, vca.vmRestoreInformer, vca.vmInformer, vca.vmSnapshotContentInformer, vca.persistentVolumeClaimInformer, recorder,
+		vca.clientSet, vca.vmCloneInformer, vca.vmSnapshotInformer, vca.vmRestoreInformer, vca.vmInformer, vca.vmSnapshotContentInformer, vca.persistentVolumeClaimInformer, recorder, vca.libguestfsImage,
 	)
 	if err != nil {
 		panic(err)
@@ -997,6 +1095,17 @@ func (vca *VirtControllerApp) initBackupController() {
 	}
 }
 
+func (vca *VirtControllerApp) initVirtualMachineOperationController() {
+	var err error
+	recorder := vca.newRecorder(k8sv1.NamespaceAll, "vm-operation-controller")
+	vca.vmOperationController, err = operations.NewController(
+		vca.clientSet, vca.vmOperationInformer, vca.vmInformer, vca.migrationInformer, vca.vmSnapshotInformer, recorder, vca.clusterConfig,
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (vca *VirtControllerApp) leaderProbe(_ *restful.Request, response *restful.Response) {
 	res := map[string]interface{}{}
 
@@ -1033,6 +1142,9 @@ func (vca *VirtControllerApp) AddFlags() {
 	flag.StringVar(&vca.exporterImage, "exporter-image", exporterImage,
 		"Container for exporting VMs and VM images")
 
+	flag.StringVar(&vca.libguestfsImage, "libguestfs-image", libguestfsImage,
+		"Container for customizing the guests of cloned VMs")
+
 	flag.IntVar(&vca.launcherQemuTimeout, "launcher-qemu-timeout", launcherQemuTimeout,
 		"Amount of time to wait for qemu")
 
@@ -1079,6 +1191,12 @@ func (vca *VirtControllerApp) AddFlags() {
 	flag.IntVar(&vca.disruptionBudgetControllerThreads, "disruption-budget-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for disruption budget controller")
 
+	flag.IntVar(&vca.volumeProtectionControllerThreads, "volume-protection-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for volume protection controller")
+
+	flag.IntVar(&vca.gatewayControllerThreads, "gateway-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for gateway controller")
+
 	flag.Int64Var(&vca.launcherSubGid, "launcher-subgid", defaultLauncherSubGid,
 		"ID of subgroup to virt-launcher")
 
@@ -1097,6 +1215,9 @@ func (vca *VirtControllerApp) AddFlags() {
 	flag.DurationVar(&vca.nodeTopologyUpdatePeriod, "node-topology-update-period", defaultNodeTopologyUpdatePeriod,
 		"Update period for the node topology updater")
 
+	flag.DurationVar(&vca.nodeHeadroomUpdatePeriod, "node-headroom-update-period", defaultNodeHeadroomUpdatePeriod,
+		"Update period for the node migration headroom updater")
+
 	flag.StringVar(&vca.promCertFilePath, "prom-cert-file", defaultPromCertFilePath,
 		"Client certificate used to prove the identity of the virt-controller when it must call out Promethus during a request")
 
@@ -1114,6 +1235,9 @@ func (vca *VirtControllerApp) AddFlags() {
 
 	flag.IntVar(&vca.backupControllerThreads, "backup-controller-threads", defaultBackupControllerThreads,
 		"Number of goroutines to run for backup controller")
+
+	flag.IntVar(&vca.operationControllerThreads, "operation-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for vm operation controller")
 }
 
 func (vca *VirtControllerApp) setupLeaderElector() (err error) {
@@ -47,6 +47,7 @@ import (
 	exportv1 "kubevirt.io/api/export/v1beta1"
 	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
 	migrationsv1 "kubevirt.io/api/migrations/v1alpha1"
+	operationsv1alpha1 "kubevirt.io/api/operations/v1alpha1"
 	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
@@ -64,12 +65,17 @@ import (
 	clonecontroller "kubevirt.io/kubevirt/pkg/virt-controller/watch/clone"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/disruptionbudget"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/evacuation"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/gateway"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/headroom"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/migration"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/node"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/operations"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/recovery"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/replicaset"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/topology"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/vm"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/vmi"
+	volumeprotection "kubevirt.io/kubevirt/pkg/virt-controller/watch/volume-protection"
 )
 
 func newValidGetRequest() *http.Request {
@@ -85,6 +91,8 @@ var _ = Describe("Application", func() {
 		virtClient := kubecli.NewMockKubevirtClient(ctrl)
 		topologyUpdater := topology.NewMockNodeTopologyUpdater(ctrl)
 		topologyUpdater.EXPECT().Run(gomock.Any(), gomock.Any())
+		headroomUpdater := headroom.NewMockNodeHeadroomUpdater(ctrl)
+		headroomUpdater.EXPECT().Run(gomock.Any(), gomock.Any())
 
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
@@ -125,6 +133,7 @@ var _ = Describe("Application", func() {
 		cloneInformer, _ := testutils.NewFakeInformerFor(&clone.VirtualMachineClone{})
 		backupInformer, _ := testutils.NewFakeInformerFor(&backupv1.VirtualMachineBackup{})
 		backupTrackerInformer, _ := testutils.NewFakeInformerFor(&backupv1.VirtualMachineBackupTracker{})
+		vmOperationInformer, _ := testutils.NewFakeInformerFor(&operationsv1alpha1.VirtualMachineOperation{})
 		secretInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Secret{})
 		instancetypeInformer, _ := testutils.NewFakeInformerFor(&instancetypev1beta1.VirtualMachineInstancetype{})
 		clusterInstancetypeInformer, _ := testutils.NewFakeInformerFor(&instancetypev1beta1.VirtualMachineClusterInstancetype{})
@@ -136,6 +145,7 @@ var _ = Describe("Application", func() {
 
 		app.vmiInformer = vmiInformer
 		app.nodeTopologyUpdater = topologyUpdater
+		app.nodeHeadroomUpdater = headroomUpdater
 		app.informerFactory = controller.NewKubeInformerFactory(nil, nil, nil, "test")
 		app.evacuationController, _ = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, recorder, virtClient, config)
 		app.disruptionBudgetController, _ = disruptionbudget.NewDisruptionBudgetController(vmiInformer, pdbInformer, podInformer, migrationInformer, recorder, virtClient)
@@ -175,15 +185,21 @@ var _ = Describe("Application", func() {
 			namespaceInformer,
 			pvcInformer,
 			crInformer,
+			vmSnapshotInformer,
 			recorder,
 			virtClient,
 			config,
 			nil,
 			nil,
+			nil,
+			nil,
 			instancetypecontroller.NewControllerStub(),
 			[]string{},
 			[]string{},
 		)
+		app.clusterRecoveryController, _ = recovery.NewController(vmInformer, vmiInformer, nodeInformer, pvcInformer, kvInformer, virtClient, config)
+		app.volumeProtectionController, _ = volumeprotection.NewController(vmiInformer, pvcInformer, dataVolumeInformer, recorder, virtClient, config)
+		app.gatewayController, _ = gateway.NewController(exportServiceInformer, nil, recorder, config)
 		app.migrationController, _ = migration.NewController(services.NewTemplateService("a", 240, "b", "c", "d", "e", "f", pvcInformer.GetStore(), virtClient, config, qemuGid, "g", resourceQuotaInformer.GetStore(), namespaceInformer.GetStore()),
 			vmiInformer,
 			podInformer,
@@ -267,6 +283,7 @@ var _ = Describe("Application", func() {
 			vmSnapshotContentInformer,
 			pvcInformer,
 			recorder,
+			"libguestfs-tools",
 		)
 		app.vmBackupController, _ = backup.NewVMBackupController(
 			virtClient,
@@ -277,6 +294,15 @@ var _ = Describe("Application", func() {
 			pvcInformer,
 			recorder,
 		)
+		app.vmOperationController, _ = operations.NewController(
+			virtClient,
+			vmOperationInformer,
+			vmInformer,
+			migrationInformer,
+			vmSnapshotInformer,
+			recorder,
+			config,
+		)
 
 		app.readyChan = make(chan bool)
 
@@ -64,6 +64,9 @@ type syncInfoType struct {
 	targetVMCreated bool
 	pvcBound        bool
 
+	customizationJobName string
+	customizationDone    bool
+
 	event          Event
 	reason         string
 	isCloneFailing bool
@@ -256,6 +259,17 @@ func (ctrl *VMCloneController) syncTargetVM(vmCloneInfo *vmCloneInfo) syncInfoTy
 
 		fallthrough
 
+	case clone.CustomizationInProgress:
+
+		if vmClone.Spec.Customization != nil {
+			syncInfo = ctrl.customizeTargetVM(vmCloneInfo, syncInfo)
+			if syncInfo.isFailingOrError() || !syncInfo.customizationDone {
+				return syncInfo
+			}
+		}
+
+		fallthrough
+
 	case clone.Succeeded:
 
 		if vmClone.Status.RestoreName != nil {
@@ -269,6 +283,13 @@ func (ctrl *VMCloneController) syncTargetVM(vmCloneInfo *vmCloneInfo) syncInfoTy
 				return syncInfo
 			}
 
+			if vmClone.Status.CustomizationJobName != nil {
+				syncInfo = ctrl.cleanupCustomizationJob(vmClone, syncInfo)
+				if syncInfo.isFailingOrError() {
+					return syncInfo
+				}
+			}
+
 			if vmCloneInfo.sourceType == sourceTypeVM {
 				syncInfo = ctrl.cleanupSnapshot(vmClone, syncInfo)
 				if syncInfo.isFailingOrError() {
@@ -341,8 +362,20 @@ func (ctrl *VMCloneController) updateStatus(origClone *clone.VirtualMachineClone
 		}
 
 		if syncInfo.targetVMCreated {
-			assignPhase(clone.Succeeded)
+			if vmClone.Spec.Customization != nil {
+				assignPhase(clone.CustomizationInProgress)
+			} else {
+				assignPhase(clone.Succeeded)
+			}
+		}
+	}
+	if isInPhase(vmClone, clone.CustomizationInProgress) {
+		if jobName := syncInfo.customizationJobName; jobName != "" {
+			vmClone.Status.CustomizationJobName = pointer.P(jobName)
+		}
 
+		if syncInfo.customizationDone {
+			assignPhase(clone.Succeeded)
 		}
 	}
 	if isInPhase(vmClone, clone.Succeeded) {
@@ -355,6 +388,7 @@ func (ctrl *VMCloneController) updateStatus(origClone *clone.VirtualMachineClone
 	if syncInfo.pvcBound {
 		vmClone.Status.SnapshotName = nil
 		vmClone.Status.RestoreName = nil
+		vmClone.Status.CustomizationJobName = nil
 	}
 
 	if !equality.Semantic.DeepEqual(vmClone.Status, origClone.Status) {
@@ -37,6 +37,10 @@ const (
 	TargetVMCreated       Event = "TargetVMCreated"
 	PVCBound              Event = "PVCBound"
 
+	CustomizationJobCreated Event = "CustomizationJobCreated"
+	CustomizationSucceeded  Event = "CustomizationSucceeded"
+	CustomizationFailed     Event = "CustomizationFailed"
+
 	SnapshotDeleted                 Event = "SnapshotDeleted"
 	SnapshotContentInvalid          Event = "SnapshotContentInvalid"
 	SourceDoesNotExist              Event = "SourceDoesNotExist"
@@ -62,12 +66,13 @@ type VMCloneController struct {
 	snapshotContentStore cache.Store
 	pvcStore             cache.Store
 	recorder             record.EventRecorder
+	libguestfsImage      string
 
 	vmCloneQueue workqueue.TypedRateLimitingInterface[string]
 	hasSynced    func() bool
 }
 
-func NewVmCloneController(client kubecli.KubevirtClient, vmCloneInformer, snapshotInformer, restoreInformer, vmInformer, snapshotContentInformer, pvcInformer cache.SharedIndexInformer, recorder record.EventRecorder) (*VMCloneController, error) {
+func NewVmCloneController(client kubecli.KubevirtClient, vmCloneInformer, snapshotInformer, restoreInformer, vmInformer, snapshotContentInformer, pvcInformer cache.SharedIndexInformer, recorder record.EventRecorder, libguestfsImage string) (*VMCloneController, error) {
 	ctrl := VMCloneController{
 		client:               client,
 		vmCloneIndexer:       vmCloneInformer.GetIndexer(),
@@ -77,6 +82,7 @@ func NewVmCloneController(client kubecli.KubevirtClient, vmCloneInformer, snapsh
 		snapshotContentStore: snapshotContentInformer.GetStore(),
 		pvcStore:             pvcInformer.GetStore(),
 		recorder:             recorder,
+		libguestfsImage:      libguestfsImage,
 		vmCloneQueue: workqueue.NewTypedRateLimitingQueueWithConfig[string](
 			workqueue.DefaultTypedControllerRateLimiter[string](),
 			workqueue.TypedRateLimitingQueueConfig[string]{Name: "virt-controller-vmclone"},
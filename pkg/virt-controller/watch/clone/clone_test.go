@@ -28,6 +28,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	batchv1 "k8s.io/api/batch/v1"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -59,6 +60,8 @@ const (
 	testSnapshotName        = "tmp-snapshot-clone-uid"
 	testSnapshotContentName = "vmsnapshot-content-snapshot-UID"
 	testRestoreName         = "tmp-restore-clone-uid"
+
+	testCustomizationJobName = "tmp-customization-clone-uid"
 )
 
 var _ = Describe("Clone", func() {
@@ -69,6 +72,7 @@ var _ = Describe("Clone", func() {
 
 		client    *kubevirtfake.Clientset
 		k8sClient *k8sfake.Clientset
+		jobClient *k8sfake.Clientset
 		sourceVM  *virtv1.VirtualMachine
 		vmClone   *clone.VirtualMachineClone
 	)
@@ -226,7 +230,8 @@ var _ = Describe("Clone", func() {
 			vmInformer,
 			snapshotContentInformer,
 			pvcInformer,
-			recorder)
+			recorder,
+			"libguestfs-tools")
 		mockQueue = testutils.NewMockWorkQueue(controller.vmCloneQueue)
 		controller.vmCloneQueue = mockQueue
 
@@ -245,6 +250,10 @@ var _ = Describe("Clone", func() {
 			return true, nil, nil
 		})
 		virtClient.EXPECT().AppsV1().Return(k8sClient.AppsV1()).AnyTimes()
+
+		jobClient = k8sfake.NewSimpleClientset()
+		virtClient.EXPECT().BatchV1().Return(jobClient.BatchV1()).AnyTimes()
+		virtClient.EXPECT().VirtualMachine(metav1.NamespaceDefault).Return(client.KubevirtV1().VirtualMachines(metav1.NamespaceDefault)).AnyTimes()
 	})
 
 	sanityExecute := func() {
@@ -540,6 +549,124 @@ var _ = Describe("Clone", func() {
 				})
 			})
 
+			When("the target VM is created and a customization is requested", func() {
+				var (
+					snapshot *snapshotv1.VirtualMachineSnapshot
+					restore  *snapshotv1.VirtualMachineRestore
+					targetVM *virtv1.VirtualMachine
+				)
+
+				getJob := func() *batchv1.Job {
+					job, err := jobClient.BatchV1().Jobs(metav1.NamespaceDefault).Get(context.TODO(), testCustomizationJobName, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					return job
+				}
+
+				addJob := func(status batchv1.JobStatus) {
+					_, err := jobClient.BatchV1().Jobs(metav1.NamespaceDefault).Create(context.TODO(), &batchv1.Job{
+						ObjectMeta: metav1.ObjectMeta{Name: testCustomizationJobName, Namespace: metav1.NamespaceDefault},
+						Status:     status,
+					}, metav1.CreateOptions{})
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				BeforeEach(func() {
+					sourceVM.Spec.RunStrategy = pointer.P(virtv1.RunStrategyAlways)
+					snapshot = createVirtualMachineSnapshot(sourceVM)
+					snapshot.Status.ReadyToUse = pointer.P(true)
+					restore = createVirtualMachineRestore(sourceVM, snapshot.Name)
+					restore.Status.Complete = pointer.P(true)
+					vmClone.Status.SnapshotName = pointer.P(snapshot.Name)
+					vmClone.Status.RestoreName = pointer.P(restore.Name)
+					vmClone.Spec.Customization = &clone.VirtualMachineCloneCustomization{
+						Hostname:       pointer.P("clone"),
+						ResetMachineID: pointer.P(true),
+					}
+
+					targetVM = sourceVM.DeepCopy()
+					targetVM.Name = vmClone.Spec.Target.Name
+					targetVM.Spec.RunStrategy = pointer.P(virtv1.RunStrategyHalted)
+					targetVM.Spec.Template.Spec.Volumes = append(targetVM.Spec.Template.Spec.Volumes, virtv1.Volume{
+						Name: "disk0",
+						VolumeSource: virtv1.VolumeSource{
+							PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{
+								PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "restore-pvc"},
+							},
+						},
+					})
+					_, err := client.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.TODO(), targetVM, metav1.CreateOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					addVM(sourceVM)
+					addVM(targetVM)
+					addSnapshot(snapshot)
+					addSnapshotContent(createVirtualMachineSnapshotContent(sourceVM))
+					addRestore(restore)
+					addPVC(createPVC(sourceVM.Namespace, k8sv1.ClaimPending))
+				})
+
+				It("should create the customization job", func() {
+					vmClone.Status.Phase = clone.CreatingTargetVM
+					addClone(vmClone)
+
+					sanityExecute()
+					expectEvent(TargetVMCreated)
+					expectEvent(CustomizationJobCreated)
+					expectCloneBeInPhase(clone.CustomizationInProgress)
+
+					job := getJob()
+					Expect(job.OwnerReferences).To(HaveLen(1))
+					validateOwnerReference(job.OwnerReferences[0], vmClone)
+					container := job.Spec.Template.Spec.Containers[0]
+					Expect(container.Image).To(Equal("libguestfs-tools"))
+					Expect(container.Args).To(Equal([]string{
+						"--operations", "machine-id,customize", "--hostname", "clone", "-a", "/disks/disk0/disk.img",
+					}))
+					Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("VolumeSource.PersistentVolumeClaim.ClaimName", "restore-pvc")))
+				})
+
+				It("should wait for the customization job to finish", func() {
+					vmClone.Status.Phase = clone.CustomizationInProgress
+					vmClone.Status.CustomizationJobName = pointer.P(testCustomizationJobName)
+					addClone(vmClone)
+					addJob(batchv1.JobStatus{Active: 1})
+
+					controller.Execute()
+					Expect(recorder.Events).To(BeEmpty())
+					expectCloneBeInPhase(clone.CustomizationInProgress)
+				})
+
+				It("should restore the run strategy and succeed once the customization job succeeded", func() {
+					vmClone.Status.Phase = clone.CustomizationInProgress
+					vmClone.Status.CustomizationJobName = pointer.P(testCustomizationJobName)
+					addClone(vmClone)
+					addJob(batchv1.JobStatus{Succeeded: 1})
+
+					sanityExecute()
+					expectEvent(CustomizationSucceeded)
+					expectCloneBeInPhase(clone.Succeeded)
+
+					vm, err := client.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Get(context.TODO(), targetVM.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vm.Spec.RunStrategy).To(HaveValue(Equal(virtv1.RunStrategyAlways)))
+				})
+
+				It("should fail the clone if the customization job failed", func() {
+					vmClone.Status.Phase = clone.CustomizationInProgress
+					vmClone.Status.CustomizationJobName = pointer.P(testCustomizationJobName)
+					addClone(vmClone)
+					addJob(batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{
+							{Type: batchv1.JobFailed, Status: k8sv1.ConditionTrue, Message: "BackoffLimitExceeded"},
+						},
+					})
+
+					sanityExecute()
+					expectEvent(CustomizationFailed)
+					expectCloneBeInPhase(clone.Failed)
+				})
+			})
+
 			When("the clone process is finished and involves one or more PVCs", func() {
 				var (
 					pvc      *k8sv1.PersistentVolumeClaim
@@ -996,6 +1123,70 @@ var _ = Describe("Clone", func() {
 			})
 		})
 
+		Context("Identity policy", func() {
+			const (
+				originalMacAddress = "DE-AD-00-00-BE-01"
+				originalSerial     = "original-serial"
+				originalUUID       = "original-uuid"
+				originalHostname   = "original-hostname"
+			)
+
+			BeforeEach(func() {
+				interfaces := sourceVM.Spec.Template.Spec.Domain.Devices.Interfaces
+				Expect(interfaces).To(HaveLen(1))
+				interfaces[0].Name = "test-interface"
+				interfaces[0].MacAddress = originalMacAddress
+				sourceVM.Spec.Template.Spec.Domain.Firmware = &virtv1.Firmware{Serial: originalSerial, UUID: originalUUID}
+				sourceVM.Spec.Template.Spec.Hostname = originalHostname
+			})
+
+			It("should regenerate MAC addresses, SMBios serial and firmware UUID but keep the hostname by default", func() {
+				addClone(vmClone)
+
+				expectedVM := sourceVM.DeepCopy()
+				expectedVM.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress = ""
+				expectedVM.Spec.Template.Spec.Domain.Firmware = &virtv1.Firmware{}
+
+				sanityExecute()
+				expectVMCreationFromPatches(expectedVM)
+			})
+
+			It("should preserve the attributes the policy asks for and regenerate the hostname", func() {
+				vmClone.Spec.IdentityPolicy = &snapshotv1.IdentityPolicy{
+					MacAddresses: pointer.P(snapshotv1.IdentityPreserve),
+					SMBiosSerial: pointer.P(snapshotv1.IdentityPreserve),
+					FirmwareUUID: pointer.P(snapshotv1.IdentityPreserve),
+					Hostname:     pointer.P(snapshotv1.IdentityRegenerate),
+				}
+				addClone(vmClone)
+
+				expectedVM := sourceVM.DeepCopy()
+				expectedVM.Spec.Template.Spec.Hostname = ""
+
+				sanityExecute()
+				expectVMCreationFromPatches(expectedVM)
+			})
+
+			It("should give precedence to manually set MAC addresses and SMBios serial over the policy", func() {
+				const newMacAddress = "DE-AD-00-00-BE-02"
+				const newSerial = "new-serial"
+				vmClone.Spec.IdentityPolicy = &snapshotv1.IdentityPolicy{
+					MacAddresses: pointer.P(snapshotv1.IdentityPreserve),
+					SMBiosSerial: pointer.P(snapshotv1.IdentityPreserve),
+				}
+				vmClone.Spec.NewMacAddresses = map[string]string{"test-interface": newMacAddress}
+				vmClone.Spec.NewSMBiosSerial = pointer.P(newSerial)
+				addClone(vmClone)
+
+				expectedVM := sourceVM.DeepCopy()
+				expectedVM.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress = newMacAddress
+				expectedVM.Spec.Template.Spec.Domain.Firmware = &virtv1.Firmware{Serial: newSerial}
+
+				sanityExecute()
+				expectVMCreationFromPatches(expectedVM)
+			})
+		})
+
 		Context("Labels and annotations", func() {
 			type mapType string
 			const labels mapType = "labels"
@@ -1192,6 +1383,21 @@ var _ = Describe("Clone", func() {
 			})
 		})
 
+		Context("Customization", func() {
+			It("should keep the target VM halted", func() {
+				sourceVM.Spec.RunStrategy = pointer.P(virtv1.RunStrategyAlways)
+				vmClone.Spec.Customization = &clone.VirtualMachineCloneCustomization{
+					RegenerateSSHHostKeys: pointer.P(true),
+				}
+				addClone(vmClone)
+
+				expectedVM := sourceVM.DeepCopy()
+				expectedVM.Spec.RunStrategy = pointer.P(virtv1.RunStrategyHalted)
+				sanityExecute()
+				expectVMCreationFromPatches(expectedVM)
+			})
+		})
+
 		Context("Target VM name", func() {
 			expectTargetVMNameExist := func() {
 				restore, err := client.SnapshotV1beta1().VirtualMachineRestores(metav1.NamespaceDefault).Get(context.TODO(), testRestoreName, metav1.GetOptions{})
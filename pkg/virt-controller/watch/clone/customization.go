@@ -0,0 +1,312 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	clone "kubevirt.io/api/clone/v1beta1"
+	k6tv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+const (
+	kvmDevice = "devices.kubevirt.io/kvm"
+
+	customizationContainerName = "virt-sysprep"
+	customizationDiskDir       = "/disks"
+	customizationTmpVolumeName = "libguestfs-tmp-dir"
+	customizationTmpDir        = "/tmp/guestfs"
+	customizationBackoffLimit  = 2
+)
+
+func generateCustomizationJobName(vmCloneUID types.UID) string {
+	return fmt.Sprintf("tmp-customization-%s", string(vmCloneUID))
+}
+
+// virtSysprepArgs translates the clone customization into virt-sysprep arguments.
+// Only the requested operations are enabled, all other guest data is left untouched.
+func virtSysprepArgs(customization *clone.VirtualMachineCloneCustomization) []string {
+	var operations, args []string
+	if customization.ResetMachineID != nil && *customization.ResetMachineID {
+		operations = append(operations, "machine-id")
+	}
+	if customization.RegenerateSSHHostKeys != nil && *customization.RegenerateSSHHostKeys {
+		operations = append(operations, "ssh-hostkeys")
+	}
+	if customization.Hostname != nil && *customization.Hostname != "" {
+		operations = append(operations, "customize")
+		args = append(args, "--hostname", *customization.Hostname)
+	}
+
+	return append([]string{"--operations", strings.Join(operations, ",")}, args...)
+}
+
+func generateCustomizationJob(vmClone *clone.VirtualMachineClone, image string, pvcs []*corev1.PersistentVolumeClaim) *batchv1.Job {
+	container := corev1.Container{
+		Name:    customizationContainerName,
+		Image:   image,
+		Command: []string{"virt-sysprep"},
+		Args:    virtSysprepArgs(vmClone.Spec.Customization),
+		Env: []corev1.EnvVar{
+			{Name: "LIBGUESTFS_BACKEND", Value: "direct"},
+			{Name: "LIBGUESTFS_TMPDIR", Value: customizationTmpDir},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: customizationTmpVolumeName, MountPath: customizationTmpDir},
+		},
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				kvmDevice: resource.MustParse("1"),
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: pointer.P(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		},
+	}
+	volumes := []corev1.Volume{
+		{
+			Name: customizationTmpVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+
+	for i, pvc := range pvcs {
+		volumeName := fmt.Sprintf("disk%d", i)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvc.Name,
+				},
+			},
+		})
+
+		diskPath := fmt.Sprintf("%s/%s", customizationDiskDir, volumeName)
+		if pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == corev1.PersistentVolumeBlock {
+			container.VolumeDevices = append(container.VolumeDevices, corev1.VolumeDevice{
+				Name:       volumeName,
+				DevicePath: diskPath,
+			})
+		} else {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: diskPath,
+			})
+			diskPath = diskPath + "/disk.img"
+		}
+		container.Args = append(container.Args, "-a", diskPath)
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      generateCustomizationJobName(vmClone.UID),
+			Namespace: vmClone.Namespace,
+			OwnerReferences: []v1.OwnerReference{
+				getCloneOwnerReference(vmClone.Name, vmClone.UID),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.P(int32(customizationBackoffLimit)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: pointer.P(true),
+						FSGroup:      pointer.P(int64(util.NonRootUID)),
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{container},
+					Volumes:    volumes,
+				},
+			},
+		},
+	}
+}
+
+// addCustomizationPatches keeps the target halted, virt-sysprep must not run against disks in use.
+// The original run strategy is restored once the customization finished.
+func addCustomizationPatches(patchSet *patch.PatchSet, source *k6tv1.VirtualMachine, customization *clone.VirtualMachineCloneCustomization) {
+	if customization == nil {
+		return
+	}
+
+	if source.Spec.Running != nil {
+		patchSet.AddOption(patch.WithRemove("/spec/running"))
+	}
+	patchSet.AddOption(patch.WithAdd("/spec/runStrategy", k6tv1.RunStrategyHalted))
+}
+
+func (ctrl *VMCloneController) getTargetPVCs(vmClone *clone.VirtualMachineClone) ([]*corev1.PersistentVolumeClaim, error) {
+	obj, exists, err := ctrl.vmStore.GetByKey(getKey(vmClone.Spec.Target.Name, vmClone.Namespace))
+	if err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, fmt.Errorf("target VM %s does not exist", vmClone.Spec.Target.Name)
+	}
+	vm := obj.(*k6tv1.VirtualMachine)
+
+	var pvcs []*corev1.PersistentVolumeClaim
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		var claimName string
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		case volume.DataVolume != nil:
+			claimName = volume.DataVolume.Name
+		default:
+			continue
+		}
+
+		obj, exists, err := ctrl.pvcStore.GetByKey(getKey(claimName, vmClone.Namespace))
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, fmt.Errorf("PVC %s is not created yet", claimName)
+		}
+		pvcs = append(pvcs, obj.(*corev1.PersistentVolumeClaim))
+	}
+
+	return pvcs, nil
+}
+
+func (ctrl *VMCloneController) customizeTargetVM(vmCloneInfo *vmCloneInfo, syncInfo syncInfoType) syncInfoType {
+	vmClone := vmCloneInfo.vmClone
+
+	if vmClone.Status.CustomizationJobName == nil {
+		return ctrl.createCustomizationJob(vmClone, syncInfo)
+	}
+
+	jobName := *vmClone.Status.CustomizationJobName
+	job, err := ctrl.client.BatchV1().Jobs(vmClone.Namespace).Get(context.Background(), jobName, v1.GetOptions{})
+	if err != nil {
+		syncInfo.setError(fmt.Errorf("error getting customization job %s for clone %s: %v", jobName, vmClone.Name, err))
+		return syncInfo
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			syncInfo.isCloneFailing = true
+			syncInfo.event = CustomizationFailed
+			syncInfo.reason = fmt.Sprintf("customization job %s for clone %s failed: %s", jobName, vmClone.Name, condition.Message)
+			return syncInfo
+		}
+	}
+
+	if job.Status.Succeeded == 0 {
+		syncInfo.setError(fmt.Errorf("customization job %s for clone %s is not finished yet", jobName, vmClone.Name))
+		return syncInfo
+	}
+
+	if vmCloneInfo.snapshot == nil {
+		vmCloneInfo.snapshot, syncInfo = ctrl.getSnapshot(vmCloneInfo.snapshotName, vmClone.Namespace, syncInfo)
+		if syncInfo.isFailingOrError() {
+			return syncInfo
+		}
+	}
+
+	if err := ctrl.restoreTargetRunStrategy(vmClone, vmCloneInfo); err != nil {
+		syncInfo.setError(fmt.Errorf("cannot restore run strategy of target VM %s for clone %s: %v", vmClone.Spec.Target.Name, vmClone.Name, err))
+		return syncInfo
+	}
+
+	ctrl.logAndRecord(vmClone, CustomizationSucceeded, fmt.Sprintf("customized target VM %s for clone %s", vmClone.Spec.Target.Name, vmClone.Name))
+	syncInfo.customizationDone = true
+
+	return syncInfo
+}
+
+func (ctrl *VMCloneController) createCustomizationJob(vmClone *clone.VirtualMachineClone, syncInfo syncInfoType) syncInfoType {
+	pvcs, err := ctrl.getTargetPVCs(vmClone)
+	if err != nil {
+		syncInfo.setError(fmt.Errorf("cannot get disks of target VM %s for clone %s: %v", vmClone.Spec.Target.Name, vmClone.Name, err))
+		return syncInfo
+	}
+
+	job := generateCustomizationJob(vmClone, ctrl.libguestfsImage, pvcs)
+	log.Log.Object(vmClone).Infof("creating customization job %s for clone %s", job.Name, vmClone.Name)
+
+	_, err = ctrl.client.BatchV1().Jobs(job.Namespace).Create(context.Background(), job, v1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		syncInfo.setError(fmt.Errorf("failed creating customization job %s for clone %s: %v", job.Name, vmClone.Name, err))
+		return syncInfo
+	}
+	if err == nil {
+		ctrl.logAndRecord(vmClone, CustomizationJobCreated, fmt.Sprintf("created customization job %s for clone %s", job.Name, vmClone.Name))
+	}
+	syncInfo.customizationJobName = job.Name
+
+	return syncInfo
+}
+
+func (ctrl *VMCloneController) restoreTargetRunStrategy(vmClone *clone.VirtualMachineClone, vmCloneInfo *vmCloneInfo) error {
+	vm, err := ctrl.getVmFromSnapshot(vmCloneInfo.snapshot)
+	if err != nil {
+		return err
+	}
+
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return err
+	}
+	if runStrategy == k6tv1.RunStrategyHalted {
+		return nil
+	}
+
+	payload, err := patch.New(patch.WithReplace("/spec/runStrategy", runStrategy)).GeneratePayload()
+	if err != nil {
+		return err
+	}
+
+	_, err = ctrl.client.VirtualMachine(vmClone.Namespace).Patch(context.Background(), vmClone.Spec.Target.Name, types.JSONPatchType, payload, v1.PatchOptions{})
+	return err
+}
+
+func (ctrl *VMCloneController) cleanupCustomizationJob(vmClone *clone.VirtualMachineClone, syncInfo syncInfoType) syncInfoType {
+	err := ctrl.client.BatchV1().Jobs(vmClone.Namespace).Delete(context.Background(), *vmClone.Status.CustomizationJobName, v1.DeleteOptions{
+		PropagationPolicy: pointer.P(v1.DeletePropagationBackground),
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		syncInfo.setError(fmt.Errorf("cannot clean up customization job %s for clone %s", *vmClone.Status.CustomizationJobName, vmClone.Name))
+		return syncInfo
+	}
+
+	return syncInfo
+}
@@ -31,17 +31,26 @@ import (
 	k6tv1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	virtsnapshot "kubevirt.io/kubevirt/pkg/storage/snapshot"
 )
 
 func generatePatches(source *k6tv1.VirtualMachine, cloneSpec *clone.VirtualMachineCloneSpec) ([]string, error) {
+	identityPolicy := virtsnapshot.ResolveIdentityPolicy(cloneSpec.IdentityPolicy, virtsnapshot.CloneIdentityDefaults())
+
 	patchSet := patch.New()
-	addMacAddressPatches(patchSet, source.Spec.Template.Spec.Domain.Devices.Interfaces, cloneSpec.NewMacAddresses)
-	addSmbiosSerialPatches(patchSet, source.Spec.Template.Spec.Domain.Firmware, cloneSpec.NewSMBiosSerial)
+	addMacAddressPatches(patchSet, source.Spec.Template.Spec.Domain.Devices.Interfaces, cloneSpec.NewMacAddresses, virtsnapshot.ShouldRegenerate(identityPolicy.MacAddresses))
+	addSmbiosSerialPatches(patchSet, source.Spec.Template.Spec.Domain.Firmware, cloneSpec.NewSMBiosSerial, virtsnapshot.ShouldRegenerate(identityPolicy.SMBiosSerial))
 	addRemovePatchesFromFilter(patchSet, source.Labels, cloneSpec.LabelFilters, "/metadata/labels")
 	addAnnotationPatches(patchSet, source.Annotations, cloneSpec.AnnotationFilters)
 	addRemovePatchesFromFilter(patchSet, source.Spec.Template.ObjectMeta.Labels, cloneSpec.Template.LabelFilters, "/spec/template/metadata/labels")
 	addRemovePatchesFromFilter(patchSet, source.Spec.Template.ObjectMeta.Annotations, cloneSpec.Template.AnnotationFilters, "/spec/template/metadata/annotations")
-	addFirmwareUUIDPatches(patchSet, source.Spec.Template.Spec.Domain.Firmware)
+	if virtsnapshot.ShouldRegenerate(identityPolicy.FirmwareUUID) {
+		addFirmwareUUIDPatches(patchSet, source.Spec.Template.Spec.Domain.Firmware)
+	}
+	if virtsnapshot.ShouldRegenerate(identityPolicy.Hostname) {
+		addHostnamePatches(patchSet, source.Spec.Template.Spec.Hostname)
+	}
+	addCustomizationPatches(patchSet, source, cloneSpec.Customization)
 
 	patches, err := generateStringPatchOperations(patchSet)
 	if err != nil {
@@ -67,18 +76,21 @@ func generateStringPatchOperations(set *patch.PatchSet) ([]string, error) {
 	return patches, nil
 }
 
-func addMacAddressPatches(patchSet *patch.PatchSet, interfaces []k6tv1.Interface, newMacAddresses map[string]string) {
+func addMacAddressPatches(patchSet *patch.PatchSet, interfaces []k6tv1.Interface, newMacAddresses map[string]string, regenerate bool) {
 	for idx, iface := range interfaces {
 		// If a new mac address is not specified for the current interface an empty mac address would be assigned.
 		// This is OK for clusters that have Kube Mac Pool enabled. For clusters that don't have KMP it is the users'
 		// responsibility to assign new mac address to every network interface.
-		newMac := newMacAddresses[iface.Name]
+		newMac, isSet := newMacAddresses[iface.Name]
+		if !isSet && !regenerate {
+			continue
+		}
 		patchSet.AddOption(patch.WithReplace(fmt.Sprintf("/spec/template/spec/domain/devices/interfaces/%d/macAddress", idx), newMac))
 	}
 }
 
-func addSmbiosSerialPatches(patchSet *patch.PatchSet, firmware *k6tv1.Firmware, newSMBiosSerial *string) {
-	if firmware == nil {
+func addSmbiosSerialPatches(patchSet *patch.PatchSet, firmware *k6tv1.Firmware, newSMBiosSerial *string, regenerate bool) {
+	if firmware == nil || (newSMBiosSerial == nil && !regenerate) {
 		return
 	}
 
@@ -159,3 +171,11 @@ func addFirmwareUUIDPatches(patchSet *patch.PatchSet, firmware *k6tv1.Firmware)
 
 	patchSet.AddOption(patch.WithReplace("/spec/template/spec/domain/firmware/uuid", ""))
 }
+
+func addHostnamePatches(patchSet *patch.PatchSet, hostname string) {
+	if hostname == "" {
+		return
+	}
+
+	patchSet.AddOption(patch.WithRemove("/spec/template/spec/hostname"))
+}
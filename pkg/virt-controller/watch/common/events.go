@@ -29,4 +29,6 @@ const (
 	SuccessfulDeleteVirtualMachineReason = "SuccessfulDelete"
 	// FailedUpdateVirtualMachineReason when a virtual machine is failed to be updated.
 	FailedUpdateVirtualMachineReason = "FailedUpdate"
+	// StorageCapacityExhaustedReason when the start of a virtual machine is delayed due to the capacity of its storage.
+	StorageCapacityExhaustedReason = "StorageCapacityExhausted"
 )
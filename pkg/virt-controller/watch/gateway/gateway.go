@@ -0,0 +1,407 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	// InvalidGatewayRouteReason is added in an event to a Service whose Gateway API route
+	// can not be created from its labels and annotations.
+	InvalidGatewayRouteReason = "InvalidGatewayRoute"
+
+	// HTTPRouteType and TCPRouteType are the supported values of the GatewayRouteLabel.
+	HTTPRouteType = "http"
+	TCPRouteType  = "tcp"
+
+	// specHashAnnotation records the hash of the route spec generated for the Service, the
+	// API server defaults the spec and it can not be compared to the generated one.
+	specHashAnnotation = "kubevirt.io/gateway-route-spec-hash"
+)
+
+var (
+	HTTPRouteGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}
+	TCPRouteGVR  = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Resource: "tcproutes"}
+
+	routeKinds = map[string]schema.GroupVersionKind{
+		HTTPRouteType: HTTPRouteGVR.GroupVersion().WithKind("HTTPRoute"),
+		TCPRouteType:  TCPRouteGVR.GroupVersion().WithKind("TCPRoute"),
+	}
+	routeResources = map[string]schema.GroupVersionResource{
+		HTTPRouteType: HTTPRouteGVR,
+		TCPRouteType:  TCPRouteGVR,
+	}
+)
+
+// RouteClient manages Gateway API routes. The Gateway API types are not part of the
+// Kubernetes API, routes are handled as unstructured objects.
+type RouteClient interface {
+	Get(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error)
+	Create(ctx context.Context, gvr schema.GroupVersionResource, route *unstructured.Unstructured) error
+	Update(ctx context.Context, gvr schema.GroupVersionResource, route *unstructured.Unstructured) error
+	Delete(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) error
+}
+
+type dynamicRouteClient struct {
+	client dynamic.Interface
+}
+
+// NewDynamicRouteClient returns a RouteClient backed by the dynamic client.
+func NewDynamicRouteClient(client dynamic.Interface) RouteClient {
+	return &dynamicRouteClient{client: client}
+}
+
+func (d *dynamicRouteClient) Get(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	return d.client.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (d *dynamicRouteClient) Create(ctx context.Context, gvr schema.GroupVersionResource, route *unstructured.Unstructured) error {
+	_, err := d.client.Resource(gvr).Namespace(route.GetNamespace()).Create(ctx, route, metav1.CreateOptions{})
+	return err
+}
+
+func (d *dynamicRouteClient) Update(ctx context.Context, gvr schema.GroupVersionResource, route *unstructured.Unstructured) error {
+	_, err := d.client.Resource(gvr).Namespace(route.GetNamespace()).Update(ctx, route, metav1.UpdateOptions{})
+	return err
+}
+
+func (d *dynamicRouteClient) Delete(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) error {
+	return d.client.Resource(gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// Controller publishes Services labeled with the GatewayRouteLabel through a Gateway API
+// HTTPRoute or TCPRoute of the same name. The route is owned by the Service and is garbage
+// collected together with it.
+type Controller struct {
+	queue         workqueue.TypedRateLimitingInterface[string]
+	serviceStore  cache.Store
+	routeClient   RouteClient
+	recorder      record.EventRecorder
+	clusterConfig *virtconfig.ClusterConfig
+	hasSynced     func() bool
+}
+
+func NewController(
+	serviceInformer cache.SharedIndexInformer,
+	routeClient RouteClient,
+	recorder record.EventRecorder,
+	clusterConfig *virtconfig.ClusterConfig,
+) (*Controller, error) {
+	c := &Controller{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig[string](
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "virt-controller-gateway"},
+		),
+		serviceStore:  serviceInformer.GetStore(),
+		routeClient:   routeClient,
+		recorder:      recorder,
+		clusterConfig: clusterConfig,
+		hasSynced:     serviceInformer.HasSynced,
+	}
+
+	_, err := serviceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueService,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueService(curr) },
+		// Services without the label are removed from the informer as well
+		DeleteFunc: c.enqueueService,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Controller) enqueueService(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to extract key from service.")
+		return
+	}
+	c.queue.Add(key)
+}
+
+// Run runs the gateway controller.
+func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.queue.ShutDown()
+	log.Log.Info("Starting gateway controller.")
+
+	cache.WaitForCacheSync(stopCh, c.hasSynced)
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping gateway controller.")
+}
+
+func (c *Controller) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *Controller) Execute() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.execute(key); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing gateway route for %v", key)
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+func (c *Controller) execute(key string) error {
+	if !c.clusterConfig.GatewayAPIExposeEnabled() {
+		return nil
+	}
+
+	obj, exists, err := c.serviceStore.GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// The service was deleted or is no longer labeled
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return err
+		}
+		for _, gvr := range routeResources {
+			if err := c.deleteOwnedRoute(namespace, name, gvr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	service := obj.(*k8sv1.Service)
+	if service.DeletionTimestamp != nil {
+		return nil
+	}
+
+	routeType := service.Labels[virtv1.GatewayRouteLabel]
+	route, err := newRoute(service, routeType)
+	if err != nil {
+		c.recorder.Eventf(service, k8sv1.EventTypeWarning, InvalidGatewayRouteReason, "Failed to publish service through the Gateway API: %v", err)
+		return nil
+	}
+
+	if err := c.syncRoute(service, routeResources[routeType], route); err != nil {
+		return err
+	}
+
+	// Remove the route of the other kind once the route type of the service changed
+	for otherType, gvr := range routeResources {
+		if otherType == routeType {
+			continue
+		}
+		if err := c.deleteOwnedRoute(service.Namespace, service.Name, gvr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Controller) syncRoute(service *k8sv1.Service, gvr schema.GroupVersionResource, route *unstructured.Unstructured) error {
+	existing, err := c.routeClient.Get(context.Background(), gvr, service.Namespace, service.Name)
+	if k8serrors.IsNotFound(err) {
+		if err := c.routeClient.Create(context.Background(), gvr, route); err != nil {
+			return fmt.Errorf("failed to create %s %s/%s: %v", route.GetKind(), route.GetNamespace(), route.GetName(), err)
+		}
+		log.Log.Infof("Created %s %s/%s", route.GetKind(), route.GetNamespace(), route.GetName())
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if !metav1.IsControlledBy(existing, service) {
+		c.recorder.Eventf(service, k8sv1.EventTypeWarning, InvalidGatewayRouteReason,
+			"%s %s already exists and is not owned by the service", route.GetKind(), route.GetName())
+		return nil
+	}
+	if existing.GetAnnotations()[specHashAnnotation] == route.GetAnnotations()[specHashAnnotation] {
+		return nil
+	}
+
+	existing.Object["spec"] = route.Object["spec"]
+	annotations := existing.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[specHashAnnotation] = route.GetAnnotations()[specHashAnnotation]
+	existing.SetAnnotations(annotations)
+	if err := c.routeClient.Update(context.Background(), gvr, existing); err != nil {
+		return fmt.Errorf("failed to update %s %s/%s: %v", route.GetKind(), route.GetNamespace(), route.GetName(), err)
+	}
+	log.Log.Infof("Updated %s %s/%s", route.GetKind(), route.GetNamespace(), route.GetName())
+	return nil
+}
+
+// deleteOwnedRoute deletes the route of the given kind which is controlled by the service
+// with the given name.
+func (c *Controller) deleteOwnedRoute(namespace, name string, gvr schema.GroupVersionResource) error {
+	existing, err := c.routeClient.Get(context.Background(), gvr, namespace, name)
+	if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	owner := metav1.GetControllerOf(existing)
+	if owner == nil || owner.Kind != "Service" || owner.Name != name {
+		return nil
+	}
+	err = c.routeClient.Delete(context.Background(), gvr, namespace, name)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s %s/%s: %v", existing.GetKind(), namespace, name, err)
+	}
+	log.Log.Infof("Deleted %s %s/%s", existing.GetKind(), namespace, name)
+	return nil
+}
+
+// newRoute generates the route of the given type which forwards the traffic of the
+// Gateway referenced by the service to its single port.
+func newRoute(service *k8sv1.Service, routeType string) (*unstructured.Unstructured, error) {
+	gvk, ok := routeKinds[routeType]
+	if !ok {
+		return nil, fmt.Errorf("unknown route type %q of label %s, expected %s or %s", routeType, virtv1.GatewayRouteLabel, HTTPRouteType, TCPRouteType)
+	}
+
+	parentRef, err := parentReference(service)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(service.Spec.Ports) != 1 {
+		return nil, fmt.Errorf("a route requires a service with a single port, the service has %d", len(service.Spec.Ports))
+	}
+	port := service.Spec.Ports[0]
+	if port.Protocol != "" && port.Protocol != k8sv1.ProtocolTCP {
+		return nil, fmt.Errorf("a route requires a TCP port, got %s", port.Protocol)
+	}
+
+	spec := map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": service.Name,
+						"port": int64(port.Port),
+					},
+				},
+			},
+		},
+	}
+
+	hostnames := hostnames(service)
+	if len(hostnames) > 0 {
+		if routeType != HTTPRouteType {
+			return nil, fmt.Errorf("annotation %s is only supported by HTTPRoutes", virtv1.GatewayHostnamesAnnotation)
+		}
+		spec["hostnames"] = hostnames
+	}
+
+	hash, err := specHash(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	route.SetGroupVersionKind(gvk)
+	route.SetName(service.Name)
+	route.SetNamespace(service.Namespace)
+	route.SetAnnotations(map[string]string{specHashAnnotation: hash})
+	// The deletion of the service is not blocked, which would require permissions on its finalizers
+	ownerRef := metav1.NewControllerRef(service, k8sv1.SchemeGroupVersion.WithKind("Service"))
+	ownerRef.BlockOwnerDeletion = pointer.P(false)
+	route.SetOwnerReferences([]metav1.OwnerReference{*ownerRef})
+	return route, nil
+}
+
+func parentReference(service *k8sv1.Service) (map[string]interface{}, error) {
+	parent := service.Annotations[virtv1.GatewayParentAnnotation]
+	if parent == "" {
+		return nil, fmt.Errorf("annotation %s is missing", virtv1.GatewayParentAnnotation)
+	}
+
+	parentRef := map[string]interface{}{}
+	namespace, name, found := strings.Cut(parent, "/")
+	if !found {
+		name = namespace
+	} else if namespace != "" && namespace != service.Namespace {
+		parentRef["namespace"] = namespace
+	}
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("annotation %s must reference a Gateway as [namespace/]name, got %q", virtv1.GatewayParentAnnotation, parent)
+	}
+	parentRef["name"] = name
+	return parentRef, nil
+}
+
+func hostnames(service *k8sv1.Service) []interface{} {
+	var hostnames []interface{}
+	for _, hostname := range strings.Split(service.Annotations[virtv1.GatewayHostnamesAnnotation], ",") {
+		if hostname = strings.TrimSpace(hostname); hostname != "" {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	return hostnames
+}
+
+func specHash(spec map[string]interface{}) (string, error) {
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	hasher := fnv.New32a()
+	hasher.Write(specBytes)
+	return fmt.Sprintf("%x", hasher.Sum32()), nil
+}
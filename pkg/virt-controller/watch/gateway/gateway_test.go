@@ -0,0 +1,272 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package gateway
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+var _ = Describe("Gateway", func() {
+	const (
+		namespace   = "default"
+		serviceName = "vm-http"
+	)
+
+	var (
+		ctrl        *Controller
+		recorder    *record.FakeRecorder
+		routeClient *fakeRouteClient
+	)
+
+	newController := func(featureGates ...string) {
+		serviceInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Service{})
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{FeatureGates: featureGates},
+		})
+		recorder = record.NewFakeRecorder(10)
+		recorder.IncludeObject = true
+		routeClient = &fakeRouteClient{routes: map[string]*unstructured.Unstructured{}}
+
+		var err error
+		ctrl, err = NewController(serviceInformer, routeClient, recorder, clusterConfig)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	newService := func(routeType string, annotations map[string]string, ports ...k8sv1.ServicePort) *k8sv1.Service {
+		if len(ports) == 0 {
+			ports = []k8sv1.ServicePort{{Port: 80, Protocol: k8sv1.ProtocolTCP}}
+		}
+		return &k8sv1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        serviceName,
+				Namespace:   namespace,
+				UID:         "service-uid",
+				Labels:      map[string]string{v1.GatewayRouteLabel: routeType},
+				Annotations: annotations,
+			},
+			Spec: k8sv1.ServiceSpec{Ports: ports},
+		}
+	}
+
+	addService := func(service *k8sv1.Service) {
+		Expect(ctrl.serviceStore.Add(service)).To(Succeed())
+		ctrl.queue.Add(namespace + "/" + serviceName)
+	}
+
+	getRoute := func(gvr schema.GroupVersionResource) *unstructured.Unstructured {
+		route, err := routeClient.Get(context.Background(), gvr, namespace, serviceName)
+		Expect(err).ToNot(HaveOccurred())
+		return route
+	}
+
+	expectNoRoute := func(gvr schema.GroupVersionResource) {
+		_, err := routeClient.Get(context.Background(), gvr, namespace, serviceName)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	}
+
+	It("should not publish services when the feature gate is disabled", func() {
+		newController()
+		addService(newService(HTTPRouteType, map[string]string{v1.GatewayParentAnnotation: "gw"}))
+
+		ctrl.Execute()
+
+		expectNoRoute(HTTPRouteGVR)
+	})
+
+	Context("with the GatewayAPIExpose feature gate", func() {
+		BeforeEach(func() {
+			newController(featuregate.GatewayAPIExpose)
+		})
+
+		It("should create an HTTPRoute owned by the service", func() {
+			service := newService(HTTPRouteType, map[string]string{
+				v1.GatewayParentAnnotation:    "infra/gw",
+				v1.GatewayHostnamesAnnotation: "vm.example.com, www.example.com",
+			})
+			addService(service)
+
+			ctrl.Execute()
+
+			route := getRoute(HTTPRouteGVR)
+			Expect(route.GetKind()).To(Equal("HTTPRoute"))
+			Expect(route.GetAPIVersion()).To(Equal("gateway.networking.k8s.io/v1"))
+			Expect(metav1.IsControlledBy(route, service)).To(BeTrue())
+			Expect(route.Object["spec"]).To(Equal(map[string]interface{}{
+				"parentRefs": []interface{}{
+					map[string]interface{}{"name": "gw", "namespace": "infra"},
+				},
+				"hostnames": []interface{}{"vm.example.com", "www.example.com"},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"backendRefs": []interface{}{
+							map[string]interface{}{"name": serviceName, "port": int64(80)},
+						},
+					},
+				},
+			}))
+		})
+
+		It("should create a TCPRoute attached to a gateway of the same namespace", func() {
+			addService(newService(TCPRouteType, map[string]string{v1.GatewayParentAnnotation: namespace + "/gw"},
+				k8sv1.ServicePort{Port: 22}))
+
+			ctrl.Execute()
+
+			route := getRoute(TCPRouteGVR)
+			Expect(route.GetKind()).To(Equal("TCPRoute"))
+			parentRefs, _, err := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parentRefs).To(ConsistOf(map[string]interface{}{"name": "gw"}))
+		})
+
+		It("should update the route when the service changes", func() {
+			addService(newService(HTTPRouteType, map[string]string{v1.GatewayParentAnnotation: "gw"}))
+			ctrl.Execute()
+			Expect(routeClient.updates).To(BeZero())
+
+			// Resyncs do not update an unchanged route
+			ctrl.queue.Add(namespace + "/" + serviceName)
+			ctrl.Execute()
+			Expect(routeClient.updates).To(BeZero())
+
+			addService(newService(HTTPRouteType, map[string]string{v1.GatewayParentAnnotation: "other"}))
+			ctrl.Execute()
+
+			Expect(routeClient.updates).To(Equal(1))
+			parentRefs, _, err := unstructured.NestedSlice(getRoute(HTTPRouteGVR).Object, "spec", "parentRefs")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parentRefs).To(ConsistOf(map[string]interface{}{"name": "other"}))
+		})
+
+		It("should replace the route when the route type changes", func() {
+			annotations := map[string]string{v1.GatewayParentAnnotation: "gw"}
+			addService(newService(HTTPRouteType, annotations))
+			ctrl.Execute()
+			getRoute(HTTPRouteGVR)
+
+			addService(newService(TCPRouteType, annotations))
+			ctrl.Execute()
+
+			getRoute(TCPRouteGVR)
+			expectNoRoute(HTTPRouteGVR)
+		})
+
+		It("should delete the route once the service is no longer published", func() {
+			addService(newService(HTTPRouteType, map[string]string{v1.GatewayParentAnnotation: "gw"}))
+			ctrl.Execute()
+			getRoute(HTTPRouteGVR)
+
+			Expect(ctrl.serviceStore.Delete(newService(HTTPRouteType, nil))).To(Succeed())
+			ctrl.queue.Add(namespace + "/" + serviceName)
+			ctrl.Execute()
+
+			expectNoRoute(HTTPRouteGVR)
+		})
+
+		It("should not modify routes which are not owned by the service", func() {
+			route := &unstructured.Unstructured{}
+			route.SetGroupVersionKind(routeKinds[HTTPRouteType])
+			route.SetName(serviceName)
+			route.SetNamespace(namespace)
+			Expect(routeClient.Create(context.Background(), HTTPRouteGVR, route)).To(Succeed())
+
+			addService(newService(HTTPRouteType, map[string]string{v1.GatewayParentAnnotation: "gw"}))
+			ctrl.Execute()
+
+			Expect(getRoute(HTTPRouteGVR).Object).ToNot(HaveKey("spec"))
+			testutils.ExpectEvent(recorder, InvalidGatewayRouteReason)
+		})
+
+		DescribeTable("should reject invalid services", func(service *k8sv1.Service) {
+			addService(service)
+
+			ctrl.Execute()
+
+			expectNoRoute(HTTPRouteGVR)
+			expectNoRoute(TCPRouteGVR)
+			testutils.ExpectEvent(recorder, InvalidGatewayRouteReason)
+		},
+			Entry("with an unknown route type", newService("udp", map[string]string{v1.GatewayParentAnnotation: "gw"})),
+			Entry("without a gateway", newService(HTTPRouteType, nil)),
+			Entry("with an invalid gateway reference", newService(HTTPRouteType, map[string]string{v1.GatewayParentAnnotation: "a/b/c"})),
+			Entry("with hostnames on a TCPRoute", newService(TCPRouteType, map[string]string{
+				v1.GatewayParentAnnotation:    "gw",
+				v1.GatewayHostnamesAnnotation: "vm.example.com",
+			})),
+			Entry("with multiple ports", newService(HTTPRouteType, map[string]string{v1.GatewayParentAnnotation: "gw"},
+				k8sv1.ServicePort{Name: "http", Port: 80}, k8sv1.ServicePort{Name: "https", Port: 443})),
+			Entry("with a UDP port", newService(TCPRouteType, map[string]string{v1.GatewayParentAnnotation: "gw"},
+				k8sv1.ServicePort{Port: 53, Protocol: k8sv1.ProtocolUDP})),
+		)
+	})
+})
+
+type fakeRouteClient struct {
+	routes  map[string]*unstructured.Unstructured
+	updates int
+}
+
+func routeKey(gvr schema.GroupVersionResource, namespace, name string) string {
+	return gvr.String() + "/" + types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
+
+func (f *fakeRouteClient) Get(_ context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	route, exists := f.routes[routeKey(gvr, namespace, name)]
+	if !exists {
+		return nil, k8serrors.NewNotFound(gvr.GroupResource(), name)
+	}
+	return route.DeepCopy(), nil
+}
+
+func (f *fakeRouteClient) Create(_ context.Context, gvr schema.GroupVersionResource, route *unstructured.Unstructured) error {
+	key := routeKey(gvr, route.GetNamespace(), route.GetName())
+	if _, exists := f.routes[key]; exists {
+		return k8serrors.NewAlreadyExists(gvr.GroupResource(), route.GetName())
+	}
+	f.routes[key] = route.DeepCopy()
+	return nil
+}
+
+func (f *fakeRouteClient) Update(_ context.Context, gvr schema.GroupVersionResource, route *unstructured.Unstructured) error {
+	f.routes[routeKey(gvr, route.GetNamespace(), route.GetName())] = route.DeepCopy()
+	f.updates++
+	return nil
+}
+
+func (f *fakeRouteClient) Delete(_ context.Context, gvr schema.GroupVersionResource, namespace, name string) error {
+	delete(f.routes, routeKey(gvr, namespace, name))
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package headroom
+
+//go:generate mockgen -source $GOFILE -package=$GOPACKAGE -destination=generated_mock_$GOFILE
+
+import (
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	metrics "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-controller"
+	nodeutils "kubevirt.io/kubevirt/pkg/util/nodes"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// NodeHeadroomUpdater keeps the free capacity of every node above the
+// migration headroom by labeling nodes whose free capacity fell below it.
+// New VMIs are not scheduled on labeled nodes, migration targets are.
+type NodeHeadroomUpdater interface {
+	Run(interval time.Duration, stopChan <-chan struct{})
+}
+
+type nodeHeadroomUpdater struct {
+	client        kubecli.KubevirtClient
+	clusterConfig *virtconfig.ClusterConfig
+	nodeInformer  cache.SharedIndexInformer
+	podInformer   cache.SharedIndexInformer
+}
+
+// capacity is an amount of CPU in millicores and memory in bytes.
+type capacity struct {
+	cpuMillis   int64
+	memoryBytes int64
+}
+
+func NewNodeHeadroomUpdater(client kubecli.KubevirtClient, clusterConfig *virtconfig.ClusterConfig, nodeInformer, podInformer cache.SharedIndexInformer) NodeHeadroomUpdater {
+	return &nodeHeadroomUpdater{
+		client:        client,
+		clusterConfig: clusterConfig,
+		nodeInformer:  nodeInformer,
+		podInformer:   podInformer,
+	}
+}
+
+func (n *nodeHeadroomUpdater) Run(interval time.Duration, stopChan <-chan struct{}) {
+	cache.WaitForCacheSync(stopChan, n.nodeInformer.HasSynced, n.podInformer.HasSynced)
+	wait.JitterUntil(func() {
+		n.sync(n.nodeInformer.GetStore().List(), n.podInformer.GetStore().List())
+	}, interval, 1.2, true, stopChan)
+}
+
+func (n *nodeHeadroomUpdater) sync(nodes, pods []interface{}) {
+	headroom := n.clusterConfig.GetMigrationConfiguration().NodeHeadroom
+	requested := requestedByNode(pods)
+
+	metrics.ResetNodeMigrationHeadroom()
+	for _, obj := range nodes {
+		node := obj.(*k8sv1.Node)
+
+		exhausted := false
+		if headroom != nil {
+			free := freeCapacity(node, requested[node.Name])
+			exhausted = isExhausted(node, free, headroom)
+			metrics.SetNodeMigrationHeadroom(node.Name, float64(free.cpuMillis)/1000, float64(free.memoryBytes), exhausted)
+		}
+
+		if _, labeled := node.Labels[v1.NodeMigrationHeadroomExhaustedLabel]; labeled == exhausted {
+			continue
+		}
+		nodeCopy := node.DeepCopy()
+		if exhausted {
+			if nodeCopy.Labels == nil {
+				nodeCopy.Labels = map[string]string{}
+			}
+			nodeCopy.Labels[v1.NodeMigrationHeadroomExhaustedLabel] = "true"
+		} else {
+			delete(nodeCopy.Labels, v1.NodeMigrationHeadroomExhaustedLabel)
+		}
+		if err := nodeutils.PatchNode(n.client, node, nodeCopy); err != nil {
+			log.DefaultLogger().Object(node).Reason(err).Error("Could not update the migration headroom label of the node")
+			continue
+		}
+		log.DefaultLogger().Object(node).Infof("Migration headroom of node %s exhausted: %t", node.Name, exhausted)
+	}
+}
+
+// requestedByNode sums up the resources requested by the pods on each node.
+func requestedByNode(pods []interface{}) map[string]capacity {
+	requested := map[string]capacity{}
+	for _, obj := range pods {
+		pod := obj.(*k8sv1.Pod)
+		if pod.Spec.NodeName == "" || pod.Status.Phase == k8sv1.PodSucceeded || pod.Status.Phase == k8sv1.PodFailed {
+			continue
+		}
+		podRequests := podRequests(pod)
+		nodeRequests := requested[pod.Spec.NodeName]
+		nodeRequests.cpuMillis += podRequests.Cpu().MilliValue()
+		nodeRequests.memoryBytes += podRequests.Memory().Value()
+		requested[pod.Spec.NodeName] = nodeRequests
+	}
+	return requested
+}
+
+// podRequests returns the resources the scheduler accounts for the pod: the
+// sum of the requests of its containers, at least the requests of each init
+// container, plus the pod overhead.
+func podRequests(pod *k8sv1.Pod) k8sv1.ResourceList {
+	requests := k8sv1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(requests, container.Resources.Requests)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	addResources(requests, pod.Spec.Overhead)
+	return requests
+}
+
+func addResources(total, add k8sv1.ResourceList) {
+	for name, quantity := range add {
+		current := total[name]
+		current.Add(quantity)
+		total[name] = current
+	}
+}
+
+func freeCapacity(node *k8sv1.Node, requested capacity) capacity {
+	return capacity{
+		cpuMillis:   node.Status.Allocatable.Cpu().MilliValue() - requested.cpuMillis,
+		memoryBytes: node.Status.Allocatable.Memory().Value() - requested.memoryBytes,
+	}
+}
+
+// isExhausted returns true if the free CPU or memory of the node is below the
+// percentage of its allocatable resources reserved for incoming migrations.
+func isExhausted(node *k8sv1.Node, free capacity, headroom *v1.MigrationNodeHeadroom) bool {
+	return free.cpuMillis < reserved(node.Status.Allocatable.Cpu().MilliValue(), headroom.CPUPercentage) ||
+		free.memoryBytes < reserved(node.Status.Allocatable.Memory().Value(), headroom.MemoryPercentage)
+}
+
+func reserved(allocatable int64, percentage *uint32) int64 {
+	if percentage == nil {
+		return 0
+	}
+	return allocatable * int64(*percentage) / 100
+}
@@ -0,0 +1,179 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package headroom
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	metrics "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-controller"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Node headroom updater", func() {
+	var kubeClient *fake.Clientset
+	var virtClient *kubecli.MockKubevirtClient
+
+	newUpdater := func(headroom *v1.MigrationNodeHeadroom) *nodeHeadroomUpdater {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			MigrationConfiguration: &v1.MigrationConfiguration{
+				NodeHeadroom: headroom,
+			},
+		})
+		return &nodeHeadroomUpdater{
+			client:        virtClient,
+			clusterConfig: clusterConfig,
+		}
+	}
+
+	newNode := func(name, cpu, memory string, labels map[string]string) *k8sv1.Node {
+		node := &k8sv1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status: k8sv1.NodeStatus{
+				Allocatable: k8sv1.ResourceList{
+					k8sv1.ResourceCPU:    resource.MustParse(cpu),
+					k8sv1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		}
+		_, err := kubeClient.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return node
+	}
+
+	newPod := func(nodeName, cpu, memory string) *k8sv1.Pod {
+		return &k8sv1.Pod{
+			Spec: k8sv1.PodSpec{
+				NodeName: nodeName,
+				Containers: []k8sv1.Container{{
+					Resources: k8sv1.ResourceRequirements{
+						Requests: k8sv1.ResourceList{
+							k8sv1.ResourceCPU:    resource.MustParse(cpu),
+							k8sv1.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				}},
+			},
+			Status: k8sv1.PodStatus{Phase: k8sv1.PodRunning},
+		}
+	}
+
+	getLabels := func(name string) map[string]string {
+		node, err := kubeClient.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return node.Labels
+	}
+
+	exhaustedLabel := map[string]string{v1.NodeMigrationHeadroomExhaustedLabel: "true"}
+
+	headroom := &v1.MigrationNodeHeadroom{
+		CPUPercentage:    pointer.P(uint32(20)),
+		MemoryPercentage: pointer.P(uint32(20)),
+	}
+
+	BeforeEach(func() {
+		virtClient = kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+	})
+
+	It("should label nodes whose free capacity is below the headroom", func() {
+		full := newNode("full", "10", "10Gi", nil)
+		free := newNode("free", "10", "10Gi", nil)
+
+		newUpdater(headroom).sync(
+			[]interface{}{full, free},
+			[]interface{}{newPod("full", "9", "1Gi"), newPod("free", "1", "1Gi")},
+		)
+
+		Expect(getLabels("full")).To(HaveKeyWithValue(v1.NodeMigrationHeadroomExhaustedLabel, "true"))
+		Expect(getLabels("free")).ToNot(HaveKey(v1.NodeMigrationHeadroomExhaustedLabel))
+
+		exhausted, err := metrics.GetNodeMigrationHeadroomExhausted("full")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exhausted).To(BeTrue())
+		exhausted, err = metrics.GetNodeMigrationHeadroomExhausted("free")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exhausted).To(BeFalse())
+	})
+
+	It("should consider a single exhausted resource", func() {
+		node := newNode("node", "10", "10Gi", nil)
+
+		newUpdater(headroom).sync([]interface{}{node}, []interface{}{newPod("node", "1", "9Gi")})
+
+		Expect(getLabels("node")).To(HaveKey(v1.NodeMigrationHeadroomExhaustedLabel))
+	})
+
+	It("should ignore pods which are not running on the node anymore", func() {
+		node := newNode("node", "10", "10Gi", nil)
+		succeeded := newPod("node", "9", "9Gi")
+		succeeded.Status.Phase = k8sv1.PodSucceeded
+
+		newUpdater(headroom).sync([]interface{}{node}, []interface{}{succeeded, newPod("", "9", "9Gi")})
+
+		Expect(getLabels("node")).ToNot(HaveKey(v1.NodeMigrationHeadroomExhaustedLabel))
+	})
+
+	It("should remove the label once enough capacity is free again", func() {
+		node := newNode("node", "10", "10Gi", exhaustedLabel)
+
+		newUpdater(headroom).sync([]interface{}{node}, []interface{}{newPod("node", "1", "1Gi")})
+
+		Expect(getLabels("node")).ToNot(HaveKey(v1.NodeMigrationHeadroomExhaustedLabel))
+	})
+
+	It("should remove the label and stop reporting the headroom when no headroom is configured", func() {
+		node := newNode("node", "10", "10Gi", exhaustedLabel)
+		metrics.SetNodeMigrationHeadroom("node", 1, 1, true)
+
+		newUpdater(nil).sync([]interface{}{node}, []interface{}{newPod("node", "9", "9Gi")})
+
+		Expect(getLabels("node")).ToNot(HaveKey(v1.NodeMigrationHeadroomExhaustedLabel))
+		exhausted, err := metrics.GetNodeMigrationHeadroomExhausted("node")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exhausted).To(BeFalse())
+	})
+
+	It("should account init containers and the pod overhead", func() {
+		node := newNode("node", "10", "10Gi", nil)
+		pod := newPod("node", "1", "1Gi")
+		pod.Spec.InitContainers = []k8sv1.Container{{
+			Resources: k8sv1.ResourceRequirements{
+				Requests: k8sv1.ResourceList{k8sv1.ResourceCPU: resource.MustParse("7")},
+			},
+		}}
+		pod.Spec.Overhead = k8sv1.ResourceList{k8sv1.ResourceCPU: resource.MustParse("2")}
+
+		newUpdater(headroom).sync([]interface{}{node}, []interface{}{pod})
+
+		Expect(getLabels("node")).To(HaveKey(v1.NodeMigrationHeadroomExhaustedLabel))
+	})
+})
@@ -64,8 +64,10 @@ import (
 	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	migrationsutil "kubevirt.io/kubevirt/pkg/util/migrations"
+	"kubevirt.io/kubevirt/pkg/util/nodes"
 	traceUtils "kubevirt.io/kubevirt/pkg/util/trace"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/topology"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
 )
@@ -85,6 +87,10 @@ const vmiPodIndex = "vmiPodIndex"
 // a pending unschedulable state.
 const defaultUnschedulablePendingTimeoutSeconds = int64(60 * 5)
 
+// errNoCPUCompatibleTargetNode is returned by sync while no node satisfies the CPU
+// feature policies of the vmi, the migration is retried with exponential backoff.
+var errNoCPUCompatibleTargetNode = errors.New("no node satisfies the CPU model and CPU feature policies of the vmi")
+
 // This is how many finalized migration objects left in
 // the system before we begin garbage collecting the oldest
 // migration objects
@@ -765,6 +771,15 @@ func (c *Controller) processMigrationPhase(
 				} else {
 					migrationCopy.Status.Phase = virtv1.MigrationScheduling
 				}
+			} else if errors.Is(syncError, errNoCPUCompatibleTargetNode) {
+				if !conditionManager.HasCondition(migration, virtv1.VirtualMachineInstanceMigrationBlockedByCPUFeatures) {
+					migrationCopy.Status.Conditions = append(migrationCopy.Status.Conditions, virtv1.VirtualMachineInstanceMigrationCondition{
+						Type:          virtv1.VirtualMachineInstanceMigrationBlockedByCPUFeatures,
+						Status:        k8sv1.ConditionTrue,
+						LastProbeTime: v1.Now(),
+						Reason:        controller.NoSuitableNodesForCPUFeatures,
+					})
+				}
 			} else if syncError != nil && strings.Contains(syncError.Error(), "exceeded quota") && !conditionManager.HasCondition(migration, virtv1.VirtualMachineInstanceMigrationRejectedByResourceQuota) {
 				condition := virtv1.VirtualMachineInstanceMigrationCondition{
 					Type:          virtv1.VirtualMachineInstanceMigrationRejectedByResourceQuota,
@@ -792,6 +807,9 @@ func (c *Controller) processMigrationPhase(
 		if conditionManager.HasCondition(migrationCopy, virtv1.VirtualMachineInstanceMigrationRejectedByResourceQuota) {
 			conditionManager.RemoveCondition(migrationCopy, virtv1.VirtualMachineInstanceMigrationRejectedByResourceQuota)
 		}
+		if conditionManager.HasCondition(migrationCopy, virtv1.VirtualMachineInstanceMigrationBlockedByCPUFeatures) {
+			conditionManager.RemoveCondition(migrationCopy, virtv1.VirtualMachineInstanceMigrationBlockedByCPUFeatures)
+		}
 		if migration.IsDecentralizedSource() {
 			if err := c.patchMigratedVolumesForDecentralizedMigration(vmi); err != nil {
 				return err
@@ -1247,7 +1265,7 @@ func (c *Controller) handleTargetPodHandoff(migration *virtv1.VirtualMachineInst
 	}
 
 	clusterMigrationConfigs := c.clusterConfig.GetMigrationConfiguration().DeepCopy()
-	err := c.matchMigrationPolicy(vmiCopy, clusterMigrationConfigs)
+	err := c.matchMigrationPolicy(vmiCopy, migration, clusterMigrationConfigs)
 	if err != nil {
 		return fmt.Errorf("failed to match migration policy: %v", err)
 	}
@@ -1396,6 +1414,10 @@ func (c *Controller) handleTargetPodCreation(key string, migration *virtv1.Virtu
 	// migration was accepted into the system, now see if we
 	// should create the target pod
 	if vmi.IsRunning() || migration.IsDecentralizedTarget() {
+		if !migration.IsDecentralizedTarget() && !c.hasCPUCompatibleTargetNode(migration, vmi) {
+			// Node labels may change, check again later
+			return errNoCPUCompatibleTargetNode
+		}
 		err = c.handleBackendStorage(migration, vmi)
 		if err != nil {
 			return err
@@ -1530,7 +1552,7 @@ func timeSinceCreationSeconds(objectMeta *metav1.ObjectMeta) int64 {
 	return seconds
 }
 
-func (c *Controller) deleteTimedOutTargetPod(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod, message string) error {
+func (c *Controller) deleteTargetPod(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod, message string) error {
 
 	migrationKey, err := controller.KeyFunc(migration)
 	if err != nil {
@@ -1542,7 +1564,7 @@ func (c *Controller) deleteTimedOutTargetPod(migration *virtv1.VirtualMachineIns
 	if err != nil {
 		c.podExpectations.DeletionObserved(migrationKey, controller.PodKey(pod))
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, controller.FailedDeletePodReason, "Error deleted migration target pod: %v", err)
-		return fmt.Errorf("failed to delete vmi migration target pod that reached pending pod timeout period.: %v", err)
+		return fmt.Errorf("failed to delete vmi migration target pod: %v", err)
 	}
 	log.Log.Object(vmi).Infof("Deleted pending migration target pod with uuid %s for migration %s with uuid %s with reason [%s]", string(pod.UID), migration.Name, string(migration.UID), message)
 	c.recorder.Event(migration, k8sv1.EventTypeNormal, controller.SuccessfulDeletePodReason, message)
@@ -1746,7 +1768,7 @@ func (c *Controller) handlePendingPodTimeout(migration *virtv1.VirtualMachineIns
 			"Migration target pod for VMI [%s/%s] is currently unschedulable.", vmi.Namespace, vmi.Name)
 		log.Log.Object(migration).Warningf("Migration target pod for VMI [%s/%s] is currently unschedulable.", vmi.Namespace, vmi.Name)
 		if secondsSpentPending >= unschedulableTimeout {
-			return c.deleteTimedOutTargetPod(migration, vmi, pod, fmt.Sprintf("unschedulable pod %s/%s timeout period exceeded", pod.Namespace, pod.Name))
+			return c.deleteTargetPod(migration, vmi, pod, fmt.Sprintf("unschedulable pod %s/%s timeout period exceeded", pod.Namespace, pod.Name))
 		} else {
 			// Make sure we check this again after some time
 			delay := time.Second * time.Duration(unschedulableTimeout-secondsSpentPending)
@@ -1755,7 +1777,7 @@ func (c *Controller) handlePendingPodTimeout(migration *virtv1.VirtualMachineIns
 	}
 
 	if secondsSpentPending >= catchAllTimeout {
-		return c.deleteTimedOutTargetPod(migration, vmi, pod, fmt.Sprintf("pending pod %s/%s timeout period exceeded", pod.Namespace, pod.Name))
+		return c.deleteTargetPod(migration, vmi, pod, fmt.Sprintf("pending pod %s/%s timeout period exceeded", pod.Namespace, pod.Name))
 	} else {
 		// Make sure we check this again after some time
 		delay := time.Second * time.Duration(catchAllTimeout-secondsSpentPending)
@@ -1895,6 +1917,10 @@ func (c *Controller) sync(key string, migration *virtv1.VirtualMachineInstanceMi
 		// once target pod is running, then alert the VMI of the migration by
 		// setting the target and source nodes. This kicks off the preparation stage.
 		if targetPodExists && controller.IsPodReady(pod) {
+			if err := c.validateTargetTSCFrequency(vmi, pod); err != nil {
+				c.recorder.Eventf(migration, k8sv1.EventTypeWarning, controller.FailedMigrationReason, "Migration target is not compatible with the vmi: %v", err)
+				return c.deleteTargetPod(migration, vmi, pod, err.Error())
+			}
 			if err := c.updateTargetPodNetworkInfo(vmi, pod); err != nil {
 				return err
 			}
@@ -2493,6 +2519,77 @@ func (c *Controller) alertIfHostModelIsUnschedulable(vmi *virtv1.VirtualMachineI
 	}
 }
 
+// hasCPUCompatibleTargetNode validates the CPU model and CPU feature policies of the vmi against the
+// nodes the target pod may be scheduled to, before the target pod is created. The warning event is
+// only recorded when the migration becomes blocked.
+func (c *Controller) hasCPUCompatibleTargetNode(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) bool {
+	if !migrationsutil.HasNodeBoundCPUFeatures(vmi) {
+		return true
+	}
+
+	var sourceNode *k8sv1.Node
+	if obj, exists, err := c.nodeStore.GetByKey(vmi.Status.NodeName); err == nil && exists {
+		sourceNode = obj.(*k8sv1.Node)
+		if reasons := migrationsutil.CPUIncompatibilities(vmi, sourceNode, nil); len(reasons) > 0 {
+			log.Log.Object(vmi).Warningf("Source node %s no longer satisfies the CPU features of the vmi: %s", sourceNode.Name, strings.Join(reasons, "; "))
+		}
+	}
+
+	nodeSelector := map[string]string{}
+	maps.Copy(nodeSelector, vmi.Spec.NodeSelector)
+	maps.Copy(nodeSelector, migration.Spec.AddedNodeSelector)
+	for _, nodeInterface := range c.nodeStore.List() {
+		node := nodeInterface.(*k8sv1.Node)
+
+		if node.Name == vmi.Status.NodeName {
+			continue // avoid checking the VMI's source node
+		}
+		if !nodes.IsSchedulable(node, nodeSelector, vmi.Spec.Affinity, vmi.Spec.Tolerations) {
+			continue
+		}
+
+		if len(migrationsutil.CPUIncompatibilities(vmi, node, sourceNode)) == 0 {
+			log.Log.Object(vmi).V(4).Infof("Node %s satisfies the CPU features of vmi %s (more nodes may fit as well)", node.Name, vmi.Name)
+			return true
+		}
+	}
+
+	if !controller.NewVirtualMachineInstanceMigrationConditionManager().HasCondition(migration, virtv1.VirtualMachineInstanceMigrationBlockedByCPUFeatures) {
+		warningMsg := fmt.Sprintf("Migration target pod for VMI [%s/%s] is not created since no node satisfies the CPU model and CPU feature policies of the VMI", vmi.Namespace, vmi.Name)
+		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, controller.NoSuitableNodesForCPUFeatures, warningMsg)
+		log.Log.Object(migration).Warning(warningMsg)
+	}
+	return false
+}
+
+// validateTargetTSCFrequency re-validates that the node of the target pod can provide the TSC frequency
+// the vmi is pinned to. The scheduling labels of the node may be stale, e.g. after its TSC frequency changed.
+func (c *Controller) validateTargetTSCFrequency(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) error {
+	if !topology.IsManualTSCFrequencyRequired(vmi) || pod.Spec.NodeName == "" {
+		return nil
+	}
+
+	obj, exists, err := c.nodeStore.GetByKey(pod.Spec.NodeName)
+	if err != nil {
+		return err
+	} else if !exists {
+		return fmt.Errorf("target node %s does not exist", pod.Spec.NodeName)
+	}
+	nodeFrequency, scalable, err := topology.TSCFrequencyFromNode(obj.(*k8sv1.Node))
+	if err != nil {
+		return err
+	} else if nodeFrequency == 0 {
+		return fmt.Errorf("the TSC frequency of target node %s is unknown", pod.Spec.NodeName)
+	}
+
+	frequency := *vmi.Status.TopologyHints.TSCFrequency
+	if !topology.IsTSCFrequencyCompatible(frequency, nodeFrequency, scalable, c.clusterConfig.GetTSCFrequencyTolerancePPM()) {
+		return fmt.Errorf("target node %s with TSC frequency %d Hz (scalable: %t) cannot provide the TSC frequency %d Hz of the vmi within a tolerance of %d PPM",
+			pod.Spec.NodeName, nodeFrequency, scalable, frequency, c.clusterConfig.GetTSCFrequencyTolerancePPM())
+	}
+	return nil
+}
+
 func getNodeSelectorsFromVMIMigrationSourceState(sourceState *virtv1.VirtualMachineInstanceMigrationSourceState) (map[string]string, error) {
 	result, nodeSelectorKeyForHostModel, err := getHostCpuModelFromMap(sourceState.NodeSelectors)
 	if err != nil {
@@ -2576,26 +2673,38 @@ func getCPUVendorLabelKey(labels map[string]string) string {
 	return ""
 }
 
-func (c *Controller) matchMigrationPolicy(vmi *virtv1.VirtualMachineInstance, clusterMigrationConfiguration *virtv1.MigrationConfiguration) error {
-	vmiNamespace, err := c.clientset.CoreV1().Namespaces().Get(context.Background(), vmi.Namespace, v1.GetOptions{})
-	if err != nil {
-		return err
-	}
+func (c *Controller) matchMigrationPolicy(vmi *virtv1.VirtualMachineInstance, migration *virtv1.VirtualMachineInstanceMigration, clusterMigrationConfiguration *virtv1.MigrationConfiguration) error {
+	var matchedPolicy *v1alpha1.MigrationPolicy
+	if policyName := migration.Spec.MigrationPolicyName; policyName != nil && *policyName != "" {
+		obj, exists, err := c.migrationPolicyStore.GetByKey(*policyName)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("migration policy %s requested by the migration does not exist", *policyName)
+		}
+		matchedPolicy = obj.(*v1alpha1.MigrationPolicy)
+	} else {
+		vmiNamespace, err := c.clientset.CoreV1().Namespaces().Get(context.Background(), vmi.Namespace, v1.GetOptions{})
+		if err != nil {
+			return err
+		}
 
-	// Fetch cluster policies
-	var policies []v1alpha1.MigrationPolicy
-	migrationInterfaceList := c.migrationPolicyStore.List()
-	for _, obj := range migrationInterfaceList {
-		policy := obj.(*v1alpha1.MigrationPolicy)
-		policies = append(policies, *policy)
-	}
-	policiesListObj := v1alpha1.MigrationPolicyList{Items: policies}
+		// Fetch cluster policies
+		var policies []v1alpha1.MigrationPolicy
+		migrationInterfaceList := c.migrationPolicyStore.List()
+		for _, obj := range migrationInterfaceList {
+			policy := obj.(*v1alpha1.MigrationPolicy)
+			policies = append(policies, *policy)
+		}
+		policiesListObj := v1alpha1.MigrationPolicyList{Items: policies}
 
-	// Override cluster-wide migration configuration if migration policy is matched
-	matchedPolicy := matchPolicy(&policiesListObj, vmi, vmiNamespace)
+		// Override cluster-wide migration configuration if migration policy is matched
+		matchedPolicy = matchPolicy(&policiesListObj, vmi, vmiNamespace)
+	}
 
 	if matchedPolicy == nil {
-		log.Log.Object(vmi).Reason(err).Infof("no migration policy matched for VMI %s", vmi.Name)
+		log.Log.Object(vmi).Infof("no migration policy matched for VMI %s", vmi.Name)
 		return nil
 	}
 
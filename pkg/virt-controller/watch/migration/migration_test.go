@@ -66,6 +66,7 @@ import (
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/topology"
 )
 
 var _ = Describe("Migration watcher", func() {
@@ -1684,6 +1685,50 @@ var _ = Describe("Migration watcher", func() {
 			),
 		)
 
+		DescribeTable("should re-validate the TSC frequency of the target node before handing the pod over", func(nodeLabels map[string]string, expectHandOver bool) {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			addNodeNameToVMI(vmi, "node02")
+			vmi.Spec.Domain.CPU = &v1.CPU{Features: []v1.CPUFeature{{Name: "invtsc", Policy: "require"}}}
+			vmi.Status.TopologyHints = &v1.TopologyHints{TSCFrequency: pointer.P(int64(2400000000))}
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationScheduled)
+			targetPod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			targetPod.Spec.NodeName = "node01"
+			targetPod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+				Name: "compute", State: k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}},
+			}}
+			node := newNode("node01")
+			node.Labels = nodeLabels
+
+			addNode(node)
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			addPod(newSourcePodForVirtualMachine(vmi))
+			addPod(targetPod)
+
+			sanityExecute()
+
+			if expectHandOver {
+				testutils.ExpectEvent(recorder, virtcontroller.SuccessfulHandOverPodReason)
+				expectVirtualMachineInstanceMigrationState(vmi.Namespace, vmi.Name, PointTo(MatchFields(IgnoreExtras, Fields{
+					"TargetNode": Equal("node01"),
+				})))
+			} else {
+				testutils.ExpectEvents(recorder, virtcontroller.FailedMigrationReason, virtcontroller.SuccessfulDeletePodReason)
+				expectPodDoesNotExist(vmi.Namespace, string(vmi.UID), string(migration.UID))
+				expectVirtualMachineInstanceMigrationState(vmi.Namespace, vmi.Name, BeNil())
+			}
+		},
+			Entry("with the same frequency",
+				map[string]string{topology.TSCFrequencyLabel: "2400000000", topology.TSCScalableLabel: "false"}, true),
+			Entry("with a higher frequency on a scalable node",
+				map[string]string{topology.TSCFrequencyLabel: "2500000000", topology.TSCScalableLabel: "true"}, true),
+			Entry("with a frequency within the tolerance on a non-scalable node",
+				map[string]string{topology.TSCFrequencyLabel: "2400500000", topology.TSCScalableLabel: "false"}, true),
+			Entry("with a frequency outside of the tolerance on a non-scalable node",
+				map[string]string{topology.TSCFrequencyLabel: "2500000000", topology.TSCScalableLabel: "false"}, false),
+			Entry("without a known frequency", nil, false),
+		)
+
 		Context("target pod annotations generation", func() {
 			const (
 				key1   = "key1"
@@ -2401,6 +2446,41 @@ var _ = Describe("Migration watcher", func() {
 				false,
 			),
 		)
+
+		It("should apply the migration policy requested by the migration instead of the matched one", func() {
+			vmi = newVirtualMachine("testvmi", v1.Running)
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationScheduled)
+			migration.Spec.MigrationPolicyName = pointer.P("requested")
+
+			targetPod = newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			targetPod.Spec.NodeName = "node01"
+			targetPod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+				Name: "compute", State: k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}},
+			}}
+
+			matchedPolicy := generatePolicyAndAlignVMI(vmi)
+			matchedPolicy.Spec.AllowAutoConverge = pointer.P(true)
+			requestedPolicy := kubecli.NewMinimalMigrationPolicy("requested")
+			requestedPolicy.Spec.AllowPostCopy = pointer.P(true)
+
+			addMigrationPolicies(*matchedPolicy, *requestedPolicy)
+			addMigration(migration)
+			addPod(targetPod)
+			addVirtualMachineInstance(vmi)
+			addPod(newSourcePodForVirtualMachine(vmi))
+
+			expectedConfigs := getDefaultMigrationConfiguration()
+			_, err := requestedPolicy.GetMigrationConfByPolicy(expectedConfigs)
+			Expect(err).ToNot(HaveOccurred())
+
+			sanityExecute()
+
+			testutils.ExpectEvent(recorder, virtcontroller.SuccessfulHandOverPodReason)
+			expectVirtualMachineInstanceMigrationState(vmi.Namespace, vmi.Name, PointTo(MatchFields(IgnoreExtras, Fields{
+				"MigrationPolicyName": Equal(pointer.P("requested")),
+			})))
+			expectVirtualMachineInstanceMigrationConfiguration(vmi.Namespace, vmi.Name, getMigrationConfig(expectedConfigs))
+		})
 	})
 
 	Context("Migration of host-model VMI", func() {
@@ -2452,6 +2532,166 @@ var _ = Describe("Migration watcher", func() {
 		})
 	})
 
+	Context("Migration of VMI with CPU feature policies", func() {
+		const (
+			sourceNodeName = "sourceNode"
+			targetNodeName = "targetNode"
+		)
+
+		newCPUFeaturesVMI := func() *v1.VirtualMachineInstance {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			addNodeNameToVMI(vmi, sourceNodeName)
+			vmi.Spec.Domain.CPU = &v1.CPU{
+				Features: []v1.CPUFeature{
+					{Name: "avx512f", Policy: "require"},
+					{Name: "svm", Policy: "forbid"},
+					{Name: "pcid", Policy: "disable"},
+				},
+			}
+			return vmi
+		}
+
+		addSourceNode := func() {
+			sourceNode := newNode(sourceNodeName)
+			sourceNode.Labels = map[string]string{
+				v1.NodeSchedulable:             "true",
+				v1.CPUFeatureLabel + "avx512f": "true",
+			}
+			addNode(sourceNode)
+		}
+
+		DescribeTable("should create the target pod only when a node satisfies the CPU features", func(targetLabels map[string]string, expectPod bool) {
+			addSourceNode()
+			targetNode := newNode(targetNodeName)
+			targetNode.Labels = targetLabels
+			addNode(targetNode)
+
+			vmi := newCPUFeaturesVMI()
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationPending)
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			addPod(newSourcePodForVirtualMachine(vmi))
+
+			sanityExecute()
+
+			if expectPod {
+				testutils.ExpectEvent(recorder, virtcontroller.SuccessfulCreatePodReason)
+				expectPodCreation(vmi.Namespace, vmi.UID, migration.UID, 1, 0, 0)
+			} else {
+				testutils.ExpectEvent(recorder, virtcontroller.NoSuitableNodesForCPUFeatures)
+				expectPodDoesNotExist(vmi.Namespace, string(vmi.UID), string(migration.UID))
+				expectMigrationCondition(migration.Namespace, migration.Name, v1.VirtualMachineInstanceMigrationBlockedByCPUFeatures)
+				Expect(controller.Queue.NumRequeues(fmt.Sprintf("%s/%s", migration.Namespace, migration.Name))).To(Equal(1))
+			}
+		},
+			Entry("target node satisfies all features",
+				map[string]string{v1.NodeSchedulable: "true", v1.CPUFeatureLabel + "avx512f": "true"}, true),
+			Entry("target node misses a required feature",
+				map[string]string{v1.NodeSchedulable: "true"}, false),
+			Entry("target node has a forbidden feature",
+				map[string]string{v1.NodeSchedulable: "true", v1.CPUFeatureLabel + "avx512f": "true", v1.CPUFeatureLabel + "svm": "true"}, false),
+			Entry("target node is not schedulable",
+				map[string]string{v1.CPUFeatureLabel + "avx512f": "true"}, false),
+		)
+
+		It("should not record the event again while the migration stays blocked", func() {
+			addSourceNode()
+			targetNode := newNode(targetNodeName)
+			targetNode.Labels = map[string]string{v1.NodeSchedulable: "true"}
+			addNode(targetNode)
+
+			vmi := newCPUFeaturesVMI()
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationPending)
+			migration.Status.Conditions = []v1.VirtualMachineInstanceMigrationCondition{{
+				Type:   v1.VirtualMachineInstanceMigrationBlockedByCPUFeatures,
+				Status: k8sv1.ConditionTrue,
+			}}
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			addPod(newSourcePodForVirtualMachine(vmi))
+
+			sanityExecute()
+
+			expectPodDoesNotExist(vmi.Namespace, string(vmi.UID), string(migration.UID))
+		})
+
+		DescribeTable("should not consider nodes the target pod cannot be scheduled to", func(updateNode func(*k8sv1.Node), updateVMI func(*v1.VirtualMachineInstance)) {
+			addSourceNode()
+			targetNode := newNode(targetNodeName)
+			targetNode.Labels = map[string]string{v1.NodeSchedulable: "true", v1.CPUFeatureLabel + "avx512f": "true", "zone": "a"}
+			updateNode(targetNode)
+			addNode(targetNode)
+
+			vmi := newCPUFeaturesVMI()
+			updateVMI(vmi)
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationPending)
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			addPod(newSourcePodForVirtualMachine(vmi))
+
+			sanityExecute()
+
+			testutils.ExpectEvent(recorder, virtcontroller.NoSuitableNodesForCPUFeatures)
+			expectPodDoesNotExist(vmi.Namespace, string(vmi.UID), string(migration.UID))
+		},
+			Entry("when the node is cordoned", func(node *k8sv1.Node) {
+				node.Spec.Unschedulable = true
+			}, func(*v1.VirtualMachineInstance) {}),
+			Entry("when the node has an untolerated taint", func(node *k8sv1.Node) {
+				node.Spec.Taints = []k8sv1.Taint{{Key: "dedicated", Value: "db", Effect: k8sv1.TaintEffectNoSchedule}}
+			}, func(*v1.VirtualMachineInstance) {}),
+			Entry("when the node does not match the node selector of the VMI", func(*k8sv1.Node) {}, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.NodeSelector = map[string]string{"zone": "b"}
+			}),
+			Entry("when the node does not match the node affinity of the VMI", func(*k8sv1.Node) {}, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Affinity = &k8sv1.Affinity{NodeAffinity: &k8sv1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &k8sv1.NodeSelector{NodeSelectorTerms: []k8sv1.NodeSelectorTerm{{
+						MatchExpressions: []k8sv1.NodeSelectorRequirement{{Key: "zone", Operator: k8sv1.NodeSelectorOpNotIn, Values: []string{"a"}}},
+					}}},
+				}}
+			}),
+		)
+
+		It("should consider tainted nodes tolerated by the VMI", func() {
+			addSourceNode()
+			targetNode := newNode(targetNodeName)
+			targetNode.Labels = map[string]string{v1.NodeSchedulable: "true", v1.CPUFeatureLabel + "avx512f": "true"}
+			targetNode.Spec.Taints = []k8sv1.Taint{{Key: "dedicated", Value: "db", Effect: k8sv1.TaintEffectNoSchedule}}
+			addNode(targetNode)
+
+			vmi := newCPUFeaturesVMI()
+			vmi.Spec.Tolerations = []k8sv1.Toleration{{Key: "dedicated", Operator: k8sv1.TolerationOpEqual, Value: "db", Effect: k8sv1.TaintEffectNoSchedule}}
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationPending)
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			addPod(newSourcePodForVirtualMachine(vmi))
+
+			sanityExecute()
+
+			testutils.ExpectEvent(recorder, virtcontroller.SuccessfulCreatePodReason)
+			expectPodCreation(vmi.Namespace, vmi.UID, migration.UID, 1, 0, 0)
+		})
+
+		It("should only consider the nodes selected by the migration", func() {
+			addSourceNode()
+			targetNode := newNode(targetNodeName)
+			targetNode.Labels = map[string]string{v1.NodeSchedulable: "true", v1.CPUFeatureLabel + "avx512f": "true"}
+			addNode(targetNode)
+
+			vmi := newCPUFeaturesVMI()
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationPending)
+			migration.Spec.AddedNodeSelector = map[string]string{"zone": "b"}
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			addPod(newSourcePodForVirtualMachine(vmi))
+
+			sanityExecute()
+
+			testutils.ExpectEvent(recorder, virtcontroller.NoSuitableNodesForCPUFeatures)
+			expectPodDoesNotExist(vmi.Namespace, string(vmi.UID), string(migration.UID))
+		})
+	})
+
 	Context("CPU vendor label constraints", func() {
 		const nodeName = "testNode"
 		const intelVendorLabel = v1.CPUModelVendorLabel + "Intel"
@@ -0,0 +1,478 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	operationsv1 "kubevirt.io/api/operations/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	// OperationAnnotation links the objects created for a target to their VirtualMachineOperation
+	OperationAnnotation = "operations.kubevirt.io/operation"
+
+	defaultVerbosityLevel = 2
+	defaultMaxConcurrency = 1
+	defaultTargetTimeout  = 10 * time.Minute
+
+	OperationStartedReason   = "OperationStarted"
+	OperationCompletedReason = "OperationCompleted"
+	InvalidSelectorReason    = "InvalidSelector"
+	TargetFailedReason       = "TargetFailed"
+)
+
+type Controller struct {
+	client           kubecli.KubevirtClient
+	operationIndexer cache.Indexer
+	vmIndexer        cache.Indexer
+	migrationIndexer cache.Indexer
+	snapshotIndexer  cache.Indexer
+	recorder         record.EventRecorder
+	clusterConfig    *virtconfig.ClusterConfig
+	queue            workqueue.TypedRateLimitingInterface[string]
+	hasSynced        func() bool
+}
+
+func NewController(client kubecli.KubevirtClient, operationInformer, vmInformer, migrationInformer, snapshotInformer cache.SharedIndexInformer, recorder record.EventRecorder, clusterConfig *virtconfig.ClusterConfig) (*Controller, error) {
+	c := &Controller{
+		client:           client,
+		operationIndexer: operationInformer.GetIndexer(),
+		vmIndexer:        vmInformer.GetIndexer(),
+		migrationIndexer: migrationInformer.GetIndexer(),
+		snapshotIndexer:  snapshotInformer.GetIndexer(),
+		recorder:         recorder,
+		clusterConfig:    clusterConfig,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig[string](
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "virt-controller-vm-operation"},
+		),
+	}
+
+	c.hasSynced = func() bool {
+		return operationInformer.HasSynced() && vmInformer.HasSynced() &&
+			migrationInformer.HasSynced() && snapshotInformer.HasSynced()
+	}
+
+	_, err := operationInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleOperation,
+		UpdateFunc: func(_, newObj interface{}) { c.handleOperation(newObj) },
+		DeleteFunc: c.handleOperation,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = vmInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, newObj interface{}) { c.handleVM(newObj) },
+		DeleteFunc: c.handleVM,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = migrationInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleChild,
+		UpdateFunc: func(_, newObj interface{}) { c.handleChild(newObj) },
+		DeleteFunc: c.handleChild,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = snapshotInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleChild,
+		UpdateFunc: func(_, newObj interface{}) { c.handleChild(newObj) },
+		DeleteFunc: c.handleChild,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Controller) handleOperation(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	operation, ok := obj.(*operationsv1.VirtualMachineOperation)
+	if !ok {
+		log.Log.Errorf("unexpected obj %#v", obj)
+		return
+	}
+
+	key, err := controller.KeyFunc(operation)
+	if err != nil {
+		log.Log.Object(operation).Reason(err).Error("cannot get operation key")
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *Controller) handleVM(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	vm, ok := obj.(*virtv1.VirtualMachine)
+	if !ok {
+		log.Log.Errorf("unexpected obj %#v", obj)
+		return
+	}
+
+	vmKey, err := controller.KeyFunc(vm)
+	if err != nil {
+		log.Log.Object(vm).Reason(err).Error("cannot get vm key")
+		return
+	}
+
+	keys, err := c.operationIndexer.IndexKeys("vm", vmKey)
+	if err != nil {
+		log.Log.Object(vm).Reason(err).Error("cannot get operations from vm indexer")
+		return
+	}
+	for _, key := range keys {
+		c.queue.Add(key)
+	}
+}
+
+func (c *Controller) handleChild(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	child, ok := obj.(metav1.Object)
+	if !ok {
+		log.Log.Errorf("unexpected obj %#v", obj)
+		return
+	}
+
+	if name, exists := child.GetAnnotations()[OperationAnnotation]; exists {
+		c.queue.Add(child.GetNamespace() + "/" + name)
+	}
+}
+
+func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	log.Log.Info("Starting vm operation controller")
+	defer log.Log.Info("Shutting down vm operation controller")
+
+	if !cache.WaitForCacheSync(stopCh, c.hasSynced) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	return nil
+}
+
+func (c *Controller) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *Controller) Execute() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	requeueAfter, err := c.execute(key)
+	if err != nil {
+		log.Log.Reason(err).Infof("reenqueuing vm operation %v", key)
+		c.queue.AddRateLimited(key)
+	} else {
+		log.Log.V(defaultVerbosityLevel).Infof("processed vm operation %v", key)
+		c.queue.Forget(key)
+		if requeueAfter > 0 {
+			c.queue.AddAfter(key, requeueAfter)
+		}
+	}
+	return true
+}
+
+func (c *Controller) execute(key string) (time.Duration, error) {
+	if !c.clusterConfig.VirtualMachineOperationsEnabled() {
+		return 0, nil
+	}
+
+	obj, exists, err := c.operationIndexer.GetByKey(key)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	operation := obj.(*operationsv1.VirtualMachineOperation)
+	if operation.DeletionTimestamp != nil || isFinished(operation) {
+		return 0, nil
+	}
+
+	operationCopy := operation.DeepCopy()
+	if operationCopy.Status == nil {
+		operationCopy.Status = &operationsv1.VirtualMachineOperationStatus{}
+	}
+
+	var requeueAfter time.Duration
+	if operationCopy.Status.Phase == "" || operationCopy.Status.Phase == operationsv1.OperationPending {
+		err = c.resolveTargets(operationCopy)
+	} else {
+		requeueAfter, err = c.sync(operationCopy)
+	}
+
+	if !equality.Semantic.DeepEqual(operation.Status, operationCopy.Status) {
+		_, updateErr := c.client.VirtualMachineOperation(operationCopy.Namespace).UpdateStatus(context.Background(), operationCopy, metav1.UpdateOptions{})
+		if updateErr != nil {
+			return 0, updateErr
+		}
+	}
+
+	return requeueAfter, err
+}
+
+// resolveTargets lists the VirtualMachines matching the selector and moves the operation to Running
+func (c *Controller) resolveTargets(operation *operationsv1.VirtualMachineOperation) error {
+	now := metav1.Now()
+	status := operation.Status
+
+	selector, err := metav1.LabelSelectorAsSelector(&operation.Spec.Selector)
+	if err != nil {
+		c.recorder.Eventf(operation, "Warning", InvalidSelectorReason, "Invalid selector: %v", err)
+		status.Phase = operationsv1.OperationFailed
+		status.StartTime = &now
+		status.CompletionTime = &now
+		return nil
+	}
+
+	objs, err := c.vmIndexer.ByIndex(cache.NamespaceIndex, operation.Namespace)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, obj := range objs {
+		vm := obj.(*virtv1.VirtualMachine)
+		if selector.Matches(labels.Set(vm.Labels)) {
+			names = append(names, vm.Name)
+		}
+	}
+	sort.Strings(names)
+
+	status.Targets = make([]operationsv1.VirtualMachineOperationTarget, 0, len(names))
+	for _, name := range names {
+		status.Targets = append(status.Targets, operationsv1.VirtualMachineOperationTarget{
+			Name:  name,
+			Phase: operationsv1.TargetPending,
+		})
+	}
+	status.Phase = operationsv1.OperationRunning
+	status.StartTime = &now
+	status.Progress = progress(status.Targets)
+
+	c.recorder.Eventf(operation, "Normal", OperationStartedReason, "%s started on %d VirtualMachines", operation.Spec.Operation, len(names))
+	return nil
+}
+
+// sync checks the running targets, starts pending ones within the concurrency limit
+// and returns when the operation has to be checked again for timeouts.
+// A target is only moved to Running once the request triggering the operation on it
+// succeeded. It stays Pending on errors which may be resolved by retrying.
+func (c *Controller) sync(operation *operationsv1.VirtualMachineOperation) (time.Duration, error) {
+	status := operation.Status
+	timeout := targetTimeout(operation)
+	now := time.Now()
+
+	var requeueAfter time.Duration
+	for i := range status.Targets {
+		target := &status.Targets[i]
+		if target.Phase != operationsv1.TargetRunning {
+			continue
+		}
+
+		done, err := c.checkTarget(operation, target)
+		if err != nil {
+			return 0, err
+		}
+		if done {
+			continue
+		}
+
+		remaining := timeout - now.Sub(target.StartTime.Time)
+		if remaining <= 0 {
+			c.finishTarget(operation, target, operationsv1.TargetFailed, fmt.Sprintf("timed out after %s", timeout))
+			continue
+		}
+		if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+
+	aborted := operation.Spec.MaxFailures != nil && progress(status.Targets).Failed > *operation.Spec.MaxFailures
+	if aborted {
+		for i := range status.Targets {
+			if status.Targets[i].Phase == operationsv1.TargetPending {
+				status.Targets[i].Phase = operationsv1.TargetSkipped
+				status.Targets[i].Message = "maximum number of failures exceeded"
+			}
+		}
+	}
+
+	maxConcurrency := int32(defaultMaxConcurrency)
+	if operation.Spec.MaxConcurrency != nil {
+		maxConcurrency = *operation.Spec.MaxConcurrency
+	}
+
+	var startErr error
+	for i := range status.Targets {
+		if progress(status.Targets).Running >= maxConcurrency {
+			break
+		}
+		target := &status.Targets[i]
+		if target.Phase != operationsv1.TargetPending {
+			continue
+		}
+
+		if err := c.startTarget(operation, target); err != nil {
+			if !isPermanentError(err) {
+				target.Message = fmt.Sprintf("failed to start, retrying: %v", err)
+				startErr = err
+				break
+			}
+			c.finishTarget(operation, target, operationsv1.TargetFailed, err.Error())
+			continue
+		}
+		if target.Phase != operationsv1.TargetPending {
+			continue
+		}
+
+		startTime := metav1.Now()
+		target.Phase = operationsv1.TargetRunning
+		target.StartTime = &startTime
+		target.Message = ""
+		if requeueAfter == 0 || timeout < requeueAfter {
+			requeueAfter = timeout
+		}
+	}
+
+	status.Progress = progress(status.Targets)
+	if startErr != nil {
+		return 0, startErr
+	}
+	if status.Progress.Pending > 0 || status.Progress.Running > 0 {
+		return requeueAfter, nil
+	}
+
+	completionTime := metav1.Now()
+	status.CompletionTime = &completionTime
+	switch {
+	case status.Progress.Failed == 0:
+		status.Phase = operationsv1.OperationSucceeded
+	case aborted || status.Progress.Succeeded == 0:
+		status.Phase = operationsv1.OperationFailed
+	default:
+		status.Phase = operationsv1.OperationPartiallyFailed
+	}
+	c.recorder.Eventf(operation, "Normal", OperationCompletedReason, "%s completed with phase %s: %d succeeded, %d failed, %d skipped",
+		operation.Spec.Operation, status.Phase, status.Progress.Succeeded, status.Progress.Failed, status.Progress.Skipped)
+
+	return 0, nil
+}
+
+func (c *Controller) finishTarget(operation *operationsv1.VirtualMachineOperation, target *operationsv1.VirtualMachineOperationTarget, phase operationsv1.VirtualMachineOperationTargetPhase, message string) {
+	now := metav1.Now()
+	target.Phase = phase
+	target.Message = message
+	target.CompletionTime = &now
+	if phase == operationsv1.TargetFailed {
+		c.recorder.Eventf(operation, "Warning", TargetFailedReason, "%s failed on VirtualMachine %s: %s", operation.Spec.Operation, target.Name, message)
+	}
+}
+
+func (c *Controller) getVM(namespace, name string) (*virtv1.VirtualMachine, error) {
+	obj, exists, err := c.vmIndexer.GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return obj.(*virtv1.VirtualMachine), nil
+}
+
+// isPermanentError returns true for errors rejecting the request itself, which are not resolved by retrying it
+func isPermanentError(err error) bool {
+	return errors.IsBadRequest(err) || errors.IsInvalid(err) || errors.IsForbidden(err) ||
+		errors.IsNotFound(err) || errors.IsConflict(err) || errors.IsMethodNotSupported(err)
+}
+
+func isFinished(operation *operationsv1.VirtualMachineOperation) bool {
+	return operation.Status != nil && operation.Status.CompletionTime != nil
+}
+
+func targetTimeout(operation *operationsv1.VirtualMachineOperation) time.Duration {
+	if operation.Spec.TargetTimeout != nil {
+		return operation.Spec.TargetTimeout.Duration
+	}
+	return defaultTargetTimeout
+}
+
+func progress(targets []operationsv1.VirtualMachineOperationTarget) operationsv1.VirtualMachineOperationProgress {
+	p := operationsv1.VirtualMachineOperationProgress{Total: int32(len(targets))}
+	for _, target := range targets {
+		switch target.Phase {
+		case operationsv1.TargetPending:
+			p.Pending++
+		case operationsv1.TargetRunning:
+			p.Running++
+		case operationsv1.TargetSucceeded:
+			p.Succeeded++
+		case operationsv1.TargetFailed:
+			p.Failed++
+		case operationsv1.TargetSkipped:
+			p.Skipped++
+		}
+	}
+	return p
+}
@@ -0,0 +1,607 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	operationsv1 "kubevirt.io/api/operations/v1alpha1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	kvcontroller "kubevirt.io/kubevirt/pkg/controller"
+	controllertesting "kubevirt.io/kubevirt/pkg/controller/testing"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+var _ = Describe("VirtualMachineOperation controller", func() {
+	var (
+		controller *Controller
+		recorder   *record.FakeRecorder
+		mockQueue  *testutils.MockWorkQueue[string]
+		client     *kubevirtfake.Clientset
+		kvStore    cache.Store
+	)
+
+	setFeatureGates := func(featureGates ...string) {
+		testutils.UpdateFakeKubeVirtClusterConfig(kvStore, &virtv1.KubeVirt{
+			Spec: virtv1.KubeVirtSpec{
+				Configuration: virtv1.KubeVirtConfiguration{
+					DeveloperConfiguration: &virtv1.DeveloperConfiguration{FeatureGates: featureGates},
+				},
+			},
+		})
+	}
+
+	BeforeEach(func() {
+		operationInformer, _ := testutils.NewFakeInformerWithIndexersFor(&operationsv1.VirtualMachineOperation{}, kvcontroller.GetVirtualMachineOperationInformerIndexers())
+		vmInformer, _ := testutils.NewFakeInformerWithIndexersFor(&virtv1.VirtualMachine{}, kvcontroller.GetVirtualMachineInformerIndexers())
+		migrationInformer, _ := testutils.NewFakeInformerWithIndexersFor(&virtv1.VirtualMachineInstanceMigration{}, kvcontroller.GetVirtualMachineInstanceMigrationInformerIndexers())
+		snapshotInformer, _ := testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshot{}, kvcontroller.GetVirtualMachineSnapshotInformerIndexers())
+
+		recorder = record.NewFakeRecorder(100)
+		recorder.IncludeObject = true
+
+		client = kubevirtfake.NewSimpleClientset()
+		// The fake tracker does not honour generateName
+		client.PrependReactor("create", "*", func(action testing.Action) (bool, runtime.Object, error) {
+			obj := action.(testing.CreateAction).GetObject().(metav1.Object)
+			if obj.GetName() == "" && obj.GetGenerateName() != "" {
+				obj.SetName(obj.GetGenerateName() + rand.String(5))
+			}
+			return false, nil, nil
+		})
+
+		virtClient := kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		virtClient.EXPECT().VirtualMachineOperation(metav1.NamespaceDefault).Return(client.OperationsV1alpha1().VirtualMachineOperations(metav1.NamespaceDefault)).AnyTimes()
+		virtClient.EXPECT().VirtualMachine(metav1.NamespaceDefault).Return(client.KubevirtV1().VirtualMachines(metav1.NamespaceDefault)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstanceMigration(metav1.NamespaceDefault).Return(client.KubevirtV1().VirtualMachineInstanceMigrations(metav1.NamespaceDefault)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineSnapshot(metav1.NamespaceDefault).Return(client.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault)).AnyTimes()
+
+		var config *virtconfig.ClusterConfig
+		config, _, kvStore = testutils.NewFakeClusterConfigUsingKVConfig(&virtv1.KubeVirtConfiguration{})
+		setFeatureGates(featuregate.VirtualMachineOperations, featuregate.SnapshotGate)
+
+		var err error
+		controller, err = NewController(virtClient, operationInformer, vmInformer, migrationInformer, snapshotInformer, recorder, config)
+		Expect(err).ToNot(HaveOccurred())
+		mockQueue = testutils.NewMockWorkQueue(controller.queue)
+		controller.queue = mockQueue
+	})
+
+	AfterEach(func() {
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	addVM := func(vm *virtv1.VirtualMachine) {
+		Expect(controller.vmIndexer.Add(vm)).To(Succeed())
+	}
+
+	addOperation := func(operation *operationsv1.VirtualMachineOperation) {
+		operation, err := client.OperationsV1alpha1().VirtualMachineOperations(operation.Namespace).Create(context.Background(), operation, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(controller.operationIndexer.Add(operation)).To(Succeed())
+		key, err := kvcontroller.KeyFunc(operation)
+		Expect(err).ToNot(HaveOccurred())
+		mockQueue.Add(key)
+	}
+
+	getOperationStatus := func(name string) *operationsv1.VirtualMachineOperationStatus {
+		operation, err := client.OperationsV1alpha1().VirtualMachineOperations(metav1.NamespaceDefault).Get(context.Background(), name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(operation.Status).ToNot(BeNil())
+		return operation.Status
+	}
+
+	sanityExecute := func() {
+		controllertesting.SanityExecute(controller, []cache.Store{
+			controller.operationIndexer, controller.vmIndexer, controller.migrationIndexer, controller.snapshotIndexer,
+		}, Default)
+	}
+
+	subresourceActions := func(subresource string) []string {
+		var names []string
+		for _, action := range client.Actions() {
+			if action.GetSubresource() == subresource {
+				names = append(names, action.(interface{ GetName() string }).GetName())
+			}
+		}
+		return names
+	}
+
+	expectOwnedByOperation := func(obj metav1.Object) {
+		ownerRef := metav1.GetControllerOf(obj)
+		Expect(ownerRef).ToNot(BeNil())
+		Expect(ownerRef.Kind).To(Equal("VirtualMachineOperation"))
+		Expect(ownerRef.Name).To(Equal(testOperationName))
+	}
+
+	It("should not process operations while the feature gate is disabled", func() {
+		setFeatureGates()
+		addVM(newVM("vm-a", nil))
+		addOperation(newOperation(operationsv1.OperationStart, ""))
+		client.ClearActions()
+
+		sanityExecute()
+
+		Expect(client.Actions()).To(BeEmpty())
+	})
+
+	Context("with a pending operation", func() {
+		It("should resolve the targets from the selector", func() {
+			addVM(newVM("vm-b", map[string]string{"app": "web"}))
+			addVM(newVM("vm-a", map[string]string{"app": "web"}))
+			addVM(newVM("vm-c", map[string]string{"app": "db"}))
+			addOperation(newOperation(operationsv1.OperationStart, "app=web"))
+
+			sanityExecute()
+
+			status := getOperationStatus(testOperationName)
+			Expect(status.Phase).To(Equal(operationsv1.OperationRunning))
+			Expect(status.StartTime).ToNot(BeNil())
+			Expect(status.Targets).To(HaveLen(2))
+			Expect(status.Targets[0].Name).To(Equal("vm-a"))
+			Expect(status.Targets[1].Name).To(Equal("vm-b"))
+			Expect(status.Progress).To(Equal(operationsv1.VirtualMachineOperationProgress{Total: 2, Pending: 2}))
+			testutils.ExpectEvent(recorder, OperationStartedReason)
+		})
+
+		It("should fail on an invalid selector", func() {
+			operation := newOperation(operationsv1.OperationStart, "")
+			operation.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Invalid"}}
+			addOperation(operation)
+
+			sanityExecute()
+
+			status := getOperationStatus(testOperationName)
+			Expect(status.Phase).To(Equal(operationsv1.OperationFailed))
+			Expect(status.CompletionTime).ToNot(BeNil())
+			testutils.ExpectEvent(recorder, InvalidSelectorReason)
+		})
+	})
+
+	Context("with a running operation", func() {
+		It("should start no more targets than MaxConcurrency", func() {
+			for _, name := range []string{"vm-a", "vm-b", "vm-c"} {
+				addVM(newVM(name, nil))
+			}
+			operation := newRunningOperation(operationsv1.OperationStart, "vm-a", "vm-b", "vm-c")
+			operation.Spec.MaxConcurrency = pointer.P(int32(2))
+			addOperation(operation)
+
+			sanityExecute()
+
+			Expect(subresourceActions("start")).To(ConsistOf("vm-a", "vm-b"))
+			status := getOperationStatus(testOperationName)
+			Expect(status.Progress).To(Equal(operationsv1.VirtualMachineOperationProgress{Total: 3, Pending: 1, Running: 2}))
+			Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+		})
+
+		It("should not start targets which are already in the desired state", func() {
+			vm := newVM("vm-a", nil)
+			vm.Status.Ready = true
+			addVM(vm)
+			addOperation(newRunningOperation(operationsv1.OperationStart, "vm-a"))
+
+			sanityExecute()
+
+			Expect(subresourceActions("start")).To(BeEmpty())
+			status := getOperationStatus(testOperationName)
+			Expect(status.Phase).To(Equal(operationsv1.OperationSucceeded))
+			Expect(status.CompletionTime).ToNot(BeNil())
+			testutils.ExpectEvent(recorder, OperationCompletedReason)
+		})
+
+		It("should stop the targets", func() {
+			vm := newVM("vm-a", nil)
+			vm.Status.PrintableStatus = virtv1.VirtualMachineStatusRunning
+			addVM(vm)
+			addOperation(newRunningOperation(operationsv1.OperationStop, "vm-a"))
+
+			sanityExecute()
+
+			Expect(subresourceActions("stop")).To(ConsistOf("vm-a"))
+			Expect(getOperationStatus(testOperationName).Targets[0].Phase).To(Equal(operationsv1.TargetRunning))
+		})
+
+		It("should keep the target pending and retry when starting it fails", func() {
+			addVM(newVM("vm-a", nil))
+			addOperation(newRunningOperation(operationsv1.OperationStart, "vm-a"))
+			client.PrependReactor("put", "virtualmachines", func(testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewServiceUnavailable("virt-api is not ready")
+			})
+
+			sanityExecute()
+
+			target := getOperationStatus(testOperationName).Targets[0]
+			Expect(target.Phase).To(Equal(operationsv1.TargetPending))
+			Expect(target.StartTime).To(BeNil())
+			Expect(target.Message).To(ContainSubstring("virt-api is not ready"))
+			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
+		})
+
+		It("should fail the target with the error when starting it is rejected", func() {
+			addVM(newVM("vm-a", nil))
+			addOperation(newRunningOperation(operationsv1.OperationStart, "vm-a"))
+			client.PrependReactor("put", "virtualmachines", func(testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewConflict(virtv1.Resource("virtualmachines"), "vm-a", fmt.Errorf("halted does not support manual start requests"))
+			})
+
+			sanityExecute()
+
+			status := getOperationStatus(testOperationName)
+			Expect(status.Targets[0].Phase).To(Equal(operationsv1.TargetFailed))
+			Expect(status.Targets[0].Message).To(ContainSubstring("halted does not support manual start requests"))
+			Expect(status.Phase).To(Equal(operationsv1.OperationFailed))
+			testutils.ExpectEvents(recorder, TargetFailedReason, OperationCompletedReason)
+		})
+
+		DescribeTable("should complete a running start target", func(ready bool, printableStatus virtv1.VirtualMachinePrintableStatus, expectedPhase operationsv1.VirtualMachineOperationTargetPhase) {
+			vm := newVM("vm-a", nil)
+			vm.Status.Ready = ready
+			vm.Status.PrintableStatus = printableStatus
+			addVM(vm)
+			operation := newRunningOperation(operationsv1.OperationStart, "vm-a")
+			setTargetRunning(operation, 0, time.Now())
+			addOperation(operation)
+
+			sanityExecute()
+
+			status := getOperationStatus(testOperationName)
+			Expect(status.Targets[0].Phase).To(Equal(expectedPhase))
+			Expect(status.Targets[0].CompletionTime).ToNot(BeNil())
+			if expectedPhase == operationsv1.TargetFailed {
+				testutils.ExpectEvents(recorder, TargetFailedReason, OperationCompletedReason)
+				Expect(status.Phase).To(Equal(operationsv1.OperationFailed))
+			} else {
+				testutils.ExpectEvent(recorder, OperationCompletedReason)
+				Expect(status.Phase).To(Equal(operationsv1.OperationSucceeded))
+			}
+		},
+			Entry("when the VM is ready", true, virtv1.VirtualMachineStatusRunning, operationsv1.TargetSucceeded),
+			Entry("when the VM is crashlooping", false, virtv1.VirtualMachineStatusCrashLoopBackOff, operationsv1.TargetFailed),
+			Entry("when the VM is unschedulable", false, virtv1.VirtualMachineStatusUnschedulable, operationsv1.TargetFailed),
+		)
+
+		It("should fail a target which exceeds the timeout", func() {
+			addVM(newVM("vm-a", nil))
+			operation := newRunningOperation(operationsv1.OperationStart, "vm-a")
+			operation.Spec.TargetTimeout = &metav1.Duration{Duration: time.Minute}
+			setTargetRunning(operation, 0, time.Now().Add(-2*time.Minute))
+			addOperation(operation)
+
+			sanityExecute()
+
+			status := getOperationStatus(testOperationName)
+			Expect(status.Targets[0].Phase).To(Equal(operationsv1.TargetFailed))
+			Expect(status.Targets[0].Message).To(ContainSubstring("timed out"))
+			testutils.ExpectEvents(recorder, TargetFailedReason, OperationCompletedReason)
+		})
+
+		It("should fail a target whose VM was deleted", func() {
+			operation := newRunningOperation(operationsv1.OperationStart, "vm-a")
+			setTargetRunning(operation, 0, time.Now())
+			addOperation(operation)
+
+			sanityExecute()
+
+			status := getOperationStatus(testOperationName)
+			Expect(status.Targets[0].Phase).To(Equal(operationsv1.TargetFailed))
+			Expect(status.Targets[0].Message).To(Equal(vmDeletedMessage))
+			testutils.ExpectEvents(recorder, TargetFailedReason, OperationCompletedReason)
+		})
+
+		It("should skip the remaining targets once MaxFailures is exceeded", func() {
+			for _, name := range []string{"vm-a", "vm-b", "vm-c"} {
+				addVM(newVM(name, nil))
+			}
+			operation := newRunningOperation(operationsv1.OperationStart, "vm-a", "vm-b", "vm-c")
+			operation.Spec.MaxFailures = pointer.P(int32(0))
+			operation.Status.Targets[0].Phase = operationsv1.TargetFailed
+			addOperation(operation)
+
+			sanityExecute()
+
+			Expect(subresourceActions("start")).To(BeEmpty())
+			status := getOperationStatus(testOperationName)
+			Expect(status.Phase).To(Equal(operationsv1.OperationFailed))
+			Expect(status.Progress).To(Equal(operationsv1.VirtualMachineOperationProgress{Total: 3, Failed: 1, Skipped: 2}))
+			testutils.ExpectEvent(recorder, OperationCompletedReason)
+		})
+
+		It("should be partially failed when only some targets failed", func() {
+			operation := newRunningOperation(operationsv1.OperationStart, "vm-a", "vm-b")
+			operation.Status.Targets[0].Phase = operationsv1.TargetFailed
+			operation.Status.Targets[1].Phase = operationsv1.TargetSucceeded
+			addOperation(operation)
+
+			sanityExecute()
+
+			Expect(getOperationStatus(testOperationName).Phase).To(Equal(operationsv1.OperationPartiallyFailed))
+			testutils.ExpectEvent(recorder, OperationCompletedReason)
+		})
+
+		It("should not touch a finished operation", func() {
+			operation := newRunningOperation(operationsv1.OperationStart, "vm-a")
+			operation.Status.Phase = operationsv1.OperationSucceeded
+			operation.Status.CompletionTime = pointer.P(metav1.Now())
+			addOperation(operation)
+			client.ClearActions()
+
+			sanityExecute()
+
+			Expect(client.Actions()).To(BeEmpty())
+		})
+	})
+
+	Context("with a migrate operation", func() {
+		It("should create a migration for a running VM", func() {
+			vm := newVM("vm-a", nil)
+			vm.Status.Ready = true
+			addVM(vm)
+			addOperation(newRunningOperation(operationsv1.OperationMigrate, "vm-a"))
+
+			sanityExecute()
+
+			migrations, err := client.KubevirtV1().VirtualMachineInstanceMigrations(metav1.NamespaceDefault).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(migrations.Items).To(HaveLen(1))
+			Expect(migrations.Items[0].Spec.VMIName).To(Equal("vm-a"))
+			Expect(migrations.Items[0].Annotations).To(HaveKeyWithValue(OperationAnnotation, testOperationName))
+			expectOwnedByOperation(&migrations.Items[0])
+
+			target := getOperationStatus(testOperationName).Targets[0]
+			Expect(target.Phase).To(Equal(operationsv1.TargetRunning))
+			Expect(target.Reference).ToNot(BeNil())
+			Expect(target.Reference.Kind).To(Equal("VirtualMachineInstanceMigration"))
+			Expect(target.Reference.Name).To(Equal(migrations.Items[0].Name))
+		})
+
+		It("should not create a second migration for the same target", func() {
+			vm := newVM("vm-a", nil)
+			vm.Status.Ready = true
+			addVM(vm)
+			Expect(controller.migrationIndexer.Add(newMigration("existing", "vm-a", ""))).To(Succeed())
+			addOperation(newRunningOperation(operationsv1.OperationMigrate, "vm-a"))
+
+			sanityExecute()
+
+			migrations, err := client.KubevirtV1().VirtualMachineInstanceMigrations(metav1.NamespaceDefault).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(migrations.Items).To(BeEmpty())
+			Expect(getOperationStatus(testOperationName).Targets[0].Reference.Name).To(Equal("existing"))
+		})
+
+		It("should keep the target pending when creating the migration fails", func() {
+			vm := newVM("vm-a", nil)
+			vm.Status.Ready = true
+			addVM(vm)
+			addOperation(newRunningOperation(operationsv1.OperationMigrate, "vm-a"))
+			client.PrependReactor("create", "virtualmachineinstancemigrations", func(testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewTimeoutError("request timed out", 1)
+			})
+
+			sanityExecute()
+
+			target := getOperationStatus(testOperationName).Targets[0]
+			Expect(target.Phase).To(Equal(operationsv1.TargetPending))
+			Expect(target.Reference).To(BeNil())
+			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
+		})
+
+		It("should fail the target when the VM is not running", func() {
+			addVM(newVM("vm-a", nil))
+			addOperation(newRunningOperation(operationsv1.OperationMigrate, "vm-a"))
+
+			sanityExecute()
+
+			Expect(getOperationStatus(testOperationName).Targets[0].Message).To(Equal(vmNotRunningMessage))
+			testutils.ExpectEvents(recorder, TargetFailedReason, OperationCompletedReason)
+		})
+
+		DescribeTable("should complete the target with the migration", func(migrationPhase virtv1.VirtualMachineInstanceMigrationPhase, expectedPhase operationsv1.VirtualMachineOperationTargetPhase, expectedEvents ...string) {
+			vm := newVM("vm-a", nil)
+			vm.Status.Ready = true
+			addVM(vm)
+			Expect(controller.migrationIndexer.Add(newMigration("existing", "vm-a", migrationPhase))).To(Succeed())
+			operation := newRunningOperation(operationsv1.OperationMigrate, "vm-a")
+			setTargetRunning(operation, 0, time.Now())
+			addOperation(operation)
+
+			sanityExecute()
+
+			Expect(getOperationStatus(testOperationName).Targets[0].Phase).To(Equal(expectedPhase))
+			testutils.ExpectEvents(recorder, expectedEvents...)
+		},
+			Entry("when it is still running", virtv1.MigrationRunning, operationsv1.TargetRunning),
+			Entry("when it succeeded", virtv1.MigrationSucceeded, operationsv1.TargetSucceeded, OperationCompletedReason),
+			Entry("when it failed", virtv1.MigrationFailed, operationsv1.TargetFailed, TargetFailedReason, OperationCompletedReason),
+		)
+	})
+
+	Context("with a snapshot operation", func() {
+		It("should create a snapshot owned by the operation", func() {
+			addVM(newVM("vm-a", nil))
+			addOperation(newRunningOperation(operationsv1.OperationSnapshot, "vm-a"))
+
+			sanityExecute()
+
+			snapshots, err := client.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshots.Items).To(HaveLen(1))
+			Expect(snapshots.Items[0].Spec.Source.Name).To(Equal("vm-a"))
+			Expect(snapshots.Items[0].Spec.Source.Kind).To(Equal("VirtualMachine"))
+			Expect(snapshots.Items[0].Annotations).To(HaveKeyWithValue(OperationAnnotation, testOperationName))
+			expectOwnedByOperation(&snapshots.Items[0])
+		})
+
+		It("should fail the target when the Snapshot feature gate is disabled", func() {
+			setFeatureGates(featuregate.VirtualMachineOperations)
+			addVM(newVM("vm-a", nil))
+			addOperation(newRunningOperation(operationsv1.OperationSnapshot, "vm-a"))
+
+			sanityExecute()
+
+			snapshots, err := client.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshots.Items).To(BeEmpty())
+			Expect(getOperationStatus(testOperationName).Targets[0].Message).To(Equal("Snapshot feature gate is not enabled"))
+			testutils.ExpectEvents(recorder, TargetFailedReason, OperationCompletedReason)
+		})
+
+		It("should complete the target once the snapshot is ready", func() {
+			addVM(newVM("vm-a", nil))
+			vmSnapshot := newSnapshot("existing", "vm-a")
+			vmSnapshot.Status = &snapshotv1.VirtualMachineSnapshotStatus{ReadyToUse: pointer.P(true)}
+			Expect(controller.snapshotIndexer.Add(vmSnapshot)).To(Succeed())
+			operation := newRunningOperation(operationsv1.OperationSnapshot, "vm-a")
+			setTargetRunning(operation, 0, time.Now())
+			addOperation(operation)
+
+			sanityExecute()
+
+			Expect(getOperationStatus(testOperationName).Phase).To(Equal(operationsv1.OperationSucceeded))
+			testutils.ExpectEvent(recorder, OperationCompletedReason)
+		})
+	})
+
+	Context("event handlers", func() {
+		It("should enqueue the operation of an annotated child", func() {
+			controller.handleChild(newMigration("migration", "vm-a", virtv1.MigrationRunning))
+			Expect(mockQueue.Len()).To(Equal(1))
+		})
+
+		It("should ignore children of other controllers", func() {
+			migration := newMigration("migration", "vm-a", virtv1.MigrationRunning)
+			migration.Annotations = nil
+			controller.handleChild(migration)
+			Expect(mockQueue.Len()).To(Equal(0))
+		})
+
+		It("should enqueue operations running on an updated VM", func() {
+			operation := newRunningOperation(operationsv1.OperationStart, "vm-a", "vm-b")
+			setTargetRunning(operation, 1, time.Now())
+			Expect(controller.operationIndexer.Add(operation)).To(Succeed())
+
+			controller.handleVM(newVM("vm-a", nil))
+			Expect(mockQueue.Len()).To(Equal(0))
+			controller.handleVM(newVM("vm-b", nil))
+			Expect(mockQueue.Len()).To(Equal(1))
+		})
+	})
+})
+
+const testOperationName = "test-operation"
+
+func newVM(name string, labels map[string]string) *virtv1.VirtualMachine {
+	return &virtv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			Labels:    labels,
+		},
+		Spec: virtv1.VirtualMachineSpec{
+			Template: &virtv1.VirtualMachineInstanceTemplateSpec{},
+		},
+	}
+}
+
+func newOperation(operation operationsv1.VirtualMachineOperationType, selector string) *operationsv1.VirtualMachineOperation {
+	labelSelector, err := metav1.ParseToLabelSelector(selector)
+	Expect(err).ToNot(HaveOccurred())
+	return &operationsv1.VirtualMachineOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testOperationName,
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: operationsv1.VirtualMachineOperationSpec{
+			Operation: operation,
+			Selector:  *labelSelector,
+		},
+	}
+}
+
+func newRunningOperation(operation operationsv1.VirtualMachineOperationType, targets ...string) *operationsv1.VirtualMachineOperation {
+	op := newOperation(operation, "")
+	op.Status = &operationsv1.VirtualMachineOperationStatus{
+		Phase:     operationsv1.OperationRunning,
+		StartTime: pointer.P(metav1.Now()),
+	}
+	for _, target := range targets {
+		op.Status.Targets = append(op.Status.Targets, operationsv1.VirtualMachineOperationTarget{
+			Name:  target,
+			Phase: operationsv1.TargetPending,
+		})
+	}
+	return op
+}
+
+func setTargetRunning(operation *operationsv1.VirtualMachineOperation, index int, startTime time.Time) {
+	operation.Status.Targets[index].Phase = operationsv1.TargetRunning
+	operation.Status.Targets[index].StartTime = pointer.P(metav1.NewTime(startTime))
+}
+
+func newMigration(name, vmName string, phase virtv1.VirtualMachineInstanceMigrationPhase) *virtv1.VirtualMachineInstanceMigration {
+	return &virtv1.VirtualMachineInstanceMigration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   metav1.NamespaceDefault,
+			Annotations: map[string]string{OperationAnnotation: testOperationName},
+		},
+		Spec:   virtv1.VirtualMachineInstanceMigrationSpec{VMIName: vmName},
+		Status: virtv1.VirtualMachineInstanceMigrationStatus{Phase: phase},
+	}
+}
+
+func newSnapshot(name, vmName string) *snapshotv1.VirtualMachineSnapshot {
+	return &snapshotv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   metav1.NamespaceDefault,
+			Annotations: map[string]string{OperationAnnotation: testOperationName},
+		},
+		Spec: snapshotv1.VirtualMachineSnapshotSpec{
+			Source: corev1.TypedLocalObjectReference{
+				APIGroup: pointer.P("kubevirt.io"),
+				Kind:     "VirtualMachine",
+				Name:     vmName,
+			},
+		},
+	}
+}
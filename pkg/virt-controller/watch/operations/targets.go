@@ -0,0 +1,261 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package operations
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/api/core"
+	virtv1 "kubevirt.io/api/core/v1"
+	operationsv1 "kubevirt.io/api/operations/v1alpha1"
+	"kubevirt.io/api/snapshot"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+const (
+	vmDeletedMessage              = "VirtualMachine was deleted"
+	vmNotRunningMessage           = "VirtualMachine is not running"
+	featureGateDisabledMessageFmt = "%s feature gate is not enabled"
+	migrationKind                 = "VirtualMachineInstanceMigration"
+	snapshotKind                  = "VirtualMachineSnapshot"
+	virtualMachineKind            = "VirtualMachine"
+)
+
+// startTarget triggers the operation on a pending target.
+// Targets which are already in the desired state, or on which the operation cannot run, are finished right away.
+func (c *Controller) startTarget(operation *operationsv1.VirtualMachineOperation, target *operationsv1.VirtualMachineOperationTarget) error {
+	vm, err := c.getVM(operation.Namespace, target.Name)
+	if err != nil {
+		return err
+	}
+	if vm == nil {
+		c.finishTarget(operation, target, operationsv1.TargetFailed, vmDeletedMessage)
+		return nil
+	}
+
+	switch operation.Spec.Operation {
+	case operationsv1.OperationStart:
+		if vm.Status.Ready {
+			c.finishTarget(operation, target, operationsv1.TargetSucceeded, "")
+			return nil
+		}
+		return c.client.VirtualMachine(vm.Namespace).Start(context.Background(), vm.Name, &virtv1.StartOptions{})
+	case operationsv1.OperationStop:
+		if vm.Status.PrintableStatus == virtv1.VirtualMachineStatusStopped {
+			c.finishTarget(operation, target, operationsv1.TargetSucceeded, "")
+			return nil
+		}
+		return c.client.VirtualMachine(vm.Namespace).Stop(context.Background(), vm.Name, &virtv1.StopOptions{})
+	case operationsv1.OperationMigrate:
+		if !c.clusterConfig.LiveMigrationEnabled() {
+			c.finishTarget(operation, target, operationsv1.TargetFailed, fmt.Sprintf(featureGateDisabledMessageFmt, featuregate.LiveMigrationGate))
+			return nil
+		}
+		if !vm.Status.Ready {
+			c.finishTarget(operation, target, operationsv1.TargetFailed, vmNotRunningMessage)
+			return nil
+		}
+		return c.createMigration(operation, target)
+	case operationsv1.OperationSnapshot:
+		if !c.clusterConfig.SnapshotEnabled() {
+			c.finishTarget(operation, target, operationsv1.TargetFailed, fmt.Sprintf(featureGateDisabledMessageFmt, featuregate.SnapshotGate))
+			return nil
+		}
+		return c.createSnapshot(operation, target)
+	default:
+		c.finishTarget(operation, target, operationsv1.TargetFailed, fmt.Sprintf("unknown operation %q", operation.Spec.Operation))
+		return nil
+	}
+}
+
+// checkTarget finishes a running target once the operation completed on it.
+// It returns true when the target is finished.
+func (c *Controller) checkTarget(operation *operationsv1.VirtualMachineOperation, target *operationsv1.VirtualMachineOperationTarget) (bool, error) {
+	vm, err := c.getVM(operation.Namespace, target.Name)
+	if err != nil {
+		return false, err
+	}
+	if vm == nil {
+		c.finishTarget(operation, target, operationsv1.TargetFailed, vmDeletedMessage)
+		return true, nil
+	}
+
+	switch operation.Spec.Operation {
+	case operationsv1.OperationStart:
+		if vm.Status.Ready {
+			c.finishTarget(operation, target, operationsv1.TargetSucceeded, "")
+			return true, nil
+		}
+		if isFailingToStart(vm) {
+			c.finishTarget(operation, target, operationsv1.TargetFailed, fmt.Sprintf("VirtualMachine is in status %s", vm.Status.PrintableStatus))
+			return true, nil
+		}
+	case operationsv1.OperationStop:
+		if vm.Status.PrintableStatus == virtv1.VirtualMachineStatusStopped {
+			c.finishTarget(operation, target, operationsv1.TargetSucceeded, "")
+			return true, nil
+		}
+	case operationsv1.OperationMigrate:
+		migration, err := c.findMigration(operation, target)
+		if err != nil || migration == nil {
+			return false, err
+		}
+		switch migration.Status.Phase {
+		case virtv1.MigrationSucceeded:
+			c.finishTarget(operation, target, operationsv1.TargetSucceeded, "")
+			return true, nil
+		case virtv1.MigrationFailed:
+			c.finishTarget(operation, target, operationsv1.TargetFailed, fmt.Sprintf("migration %s failed", migration.Name))
+			return true, nil
+		}
+	case operationsv1.OperationSnapshot:
+		vmSnapshot, err := c.findSnapshot(operation, target)
+		if err != nil || vmSnapshot == nil {
+			return false, err
+		}
+		if vmSnapshot.Status != nil && vmSnapshot.Status.ReadyToUse != nil && *vmSnapshot.Status.ReadyToUse {
+			c.finishTarget(operation, target, operationsv1.TargetSucceeded, "")
+			return true, nil
+		}
+		if vmSnapshot.Status != nil && vmSnapshot.Status.Phase == snapshotv1.Failed {
+			c.finishTarget(operation, target, operationsv1.TargetFailed, fmt.Sprintf("snapshot %s failed", vmSnapshot.Name))
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *Controller) createMigration(operation *operationsv1.VirtualMachineOperation, target *operationsv1.VirtualMachineOperationTarget) error {
+	migration, err := c.findMigration(operation, target)
+	if err != nil {
+		return err
+	}
+	if migration == nil {
+		migration = &virtv1.VirtualMachineInstanceMigration{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName:    fmt.Sprintf("%s-%s-", operation.Name, target.Name),
+				Annotations:     map[string]string{OperationAnnotation: operation.Name},
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(operation, operationsv1.VirtualMachineOperationGroupVersionKind)},
+			},
+			Spec: virtv1.VirtualMachineInstanceMigrationSpec{
+				VMIName: target.Name,
+			},
+		}
+		migration, err = c.client.VirtualMachineInstanceMigration(operation.Namespace).Create(context.Background(), migration, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	target.Reference = &corev1.TypedLocalObjectReference{
+		APIGroup: pointer.P(core.GroupName),
+		Kind:     migrationKind,
+		Name:     migration.Name,
+	}
+	return nil
+}
+
+func (c *Controller) createSnapshot(operation *operationsv1.VirtualMachineOperation, target *operationsv1.VirtualMachineOperationTarget) error {
+	vmSnapshot, err := c.findSnapshot(operation, target)
+	if err != nil {
+		return err
+	}
+	if vmSnapshot == nil {
+		vmSnapshot = &snapshotv1.VirtualMachineSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName:    fmt.Sprintf("%s-%s-", operation.Name, target.Name),
+				Annotations:     map[string]string{OperationAnnotation: operation.Name},
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(operation, operationsv1.VirtualMachineOperationGroupVersionKind)},
+			},
+			Spec: snapshotv1.VirtualMachineSnapshotSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: pointer.P(core.GroupName),
+					Kind:     virtualMachineKind,
+					Name:     target.Name,
+				},
+			},
+		}
+		vmSnapshot, err = c.client.VirtualMachineSnapshot(operation.Namespace).Create(context.Background(), vmSnapshot, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	target.Reference = &corev1.TypedLocalObjectReference{
+		APIGroup: pointer.P(snapshot.GroupName),
+		Kind:     snapshotKind,
+		Name:     vmSnapshot.Name,
+	}
+	return nil
+}
+
+// findMigration returns the migration created by the operation for the target, if any.
+// The informer is searched instead of the target reference, so that a migration whose
+// creation was not recorded in the status yet is not created twice.
+func (c *Controller) findMigration(operation *operationsv1.VirtualMachineOperation, target *operationsv1.VirtualMachineOperationTarget) (*virtv1.VirtualMachineInstanceMigration, error) {
+	objs, err := c.migrationIndexer.ByIndex(controller.ByVMINameIndex, operation.Namespace+"/"+target.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		migration := obj.(*virtv1.VirtualMachineInstanceMigration)
+		if migration.Annotations[OperationAnnotation] == operation.Name {
+			return migration, nil
+		}
+	}
+	return nil, nil
+}
+
+// findSnapshot returns the snapshot created by the operation for the target, if any.
+func (c *Controller) findSnapshot(operation *operationsv1.VirtualMachineOperation, target *operationsv1.VirtualMachineOperationTarget) (*snapshotv1.VirtualMachineSnapshot, error) {
+	objs, err := c.snapshotIndexer.ByIndex("vm", operation.Namespace+"/"+target.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		vmSnapshot := obj.(*snapshotv1.VirtualMachineSnapshot)
+		if vmSnapshot.Annotations[OperationAnnotation] == operation.Name {
+			return vmSnapshot, nil
+		}
+	}
+	return nil, nil
+}
+
+func isFailingToStart(vm *virtv1.VirtualMachine) bool {
+	switch vm.Status.PrintableStatus {
+	case virtv1.VirtualMachineStatusCrashLoopBackOff,
+		virtv1.VirtualMachineStatusUnschedulable,
+		virtv1.VirtualMachineStatusDataVolumeError,
+		virtv1.VirtualMachineStatusPvcNotFound,
+		virtv1.VirtualMachineStatusErrImagePull,
+		virtv1.VirtualMachineStatusImagePullBackOff:
+		return true
+	default:
+		return false
+	}
+}
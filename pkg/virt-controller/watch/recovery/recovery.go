@@ -0,0 +1,478 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/util/nodes"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	// resyncInterval is the interval in which the recovery status is re-evaluated
+	resyncInterval = 10 * time.Second
+	// stateTTL is the maximum age of the evaluated state used to admit VirtualMachine starts
+	stateTTL = time.Second
+	// reservationTimeout is the time after which an admitted start which did not
+	// result in a VirtualMachineInstance no longer counts as starting
+	reservationTimeout = time.Minute
+
+	clusterRecoveryStatusPath = "/status/clusterRecovery"
+)
+
+// Controller detects cluster-wide outages and staggers the automatic start of
+// VirtualMachines while the cluster recovers. It keeps the recovery status on the
+// KubeVirt object up to date and is consulted by the VirtualMachine controller
+// before a VirtualMachineInstance is created.
+type Controller struct {
+	clientset     kubecli.KubevirtClient
+	queue         workqueue.TypedRateLimitingInterface[string]
+	vmStore       cache.Store
+	vmiStore      cache.Store
+	nodeStore     cache.Store
+	pvcStore      cache.Store
+	kubeVirtStore cache.Store
+	clusterConfig *virtconfig.ClusterConfig
+	hasSynced     func() bool
+
+	lock sync.Mutex
+	// status is the last evaluated recovery status, nil until the first evaluation
+	status *virtv1.ClusterRecoveryStatus
+	// evaluated is the time of the last evaluation
+	evaluated time.Time
+	// reservations holds the VirtualMachines whose start was admitted, but whose
+	// VirtualMachineInstance was not observed yet
+	reservations map[string]time.Time
+}
+
+func NewController(
+	vmInformer cache.SharedIndexInformer,
+	vmiInformer cache.SharedIndexInformer,
+	nodeInformer cache.SharedIndexInformer,
+	pvcInformer cache.SharedIndexInformer,
+	kubeVirtInformer cache.SharedIndexInformer,
+	clientset kubecli.KubevirtClient,
+	clusterConfig *virtconfig.ClusterConfig,
+) (*Controller, error) {
+	c := &Controller{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig[string](
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "virt-controller-cluster-recovery"},
+		),
+		vmStore:       vmInformer.GetStore(),
+		vmiStore:      vmiInformer.GetStore(),
+		nodeStore:     nodeInformer.GetStore(),
+		pvcStore:      pvcInformer.GetStore(),
+		kubeVirtStore: kubeVirtInformer.GetStore(),
+		clientset:     clientset,
+		clusterConfig: clusterConfig,
+		reservations:  map[string]time.Time{},
+		hasSynced: func() bool {
+			return vmInformer.HasSynced() && vmiInformer.HasSynced() && nodeInformer.HasSynced() &&
+				pvcInformer.HasSynced() && kubeVirtInformer.HasSynced()
+		},
+	}
+
+	_, err := kubeVirtInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueKubeVirt,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueKubeVirt(curr) },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Controller) enqueueKubeVirt(obj interface{}) {
+	kv, ok := obj.(*virtv1.KubeVirt)
+	if !ok {
+		return
+	}
+	key, err := controller.KeyFunc(kv)
+	if err != nil {
+		log.Log.Object(kv).Reason(err).Error("Failed to extract key from KubeVirt.")
+		return
+	}
+	c.queue.Add(key)
+}
+
+// Run runs the cluster recovery controller.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.queue.ShutDown()
+	log.Log.Info("Starting cluster recovery controller.")
+
+	cache.WaitForCacheSync(stopCh, c.hasSynced)
+
+	// The queue only ever holds the key of the single KubeVirt install object.
+	go wait.Until(c.runWorker, time.Second, stopCh)
+
+	<-stopCh
+	log.Log.Info("Stopping cluster recovery controller.")
+}
+
+func (c *Controller) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *Controller) Execute() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.execute(key); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing cluster recovery for KubeVirt %v", key)
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+func (c *Controller) execute(key string) error {
+	obj, exists, err := c.kubeVirtStore.GetByKey(key)
+	if err != nil || !exists {
+		return err
+	}
+	kv := obj.(*virtv1.KubeVirt)
+
+	config := c.clusterConfig.GetClusterRecoveryConfiguration()
+	if config == nil {
+		c.lock.Lock()
+		c.status = nil
+		c.lock.Unlock()
+		if kv.Status.ClusterRecovery == nil {
+			return nil
+		}
+		return c.patchStatus(kv, patch.New(patch.WithRemove(clusterRecoveryStatusPath)))
+	}
+
+	c.lock.Lock()
+	c.evaluate(config, time.Now())
+	status := c.status.DeepCopy()
+	c.lock.Unlock()
+
+	// Re-evaluate periodically, the recovery is driven by the state of all
+	// VirtualMachines and watching all of them here is not worth it.
+	c.queue.AddAfter(key, resyncInterval)
+
+	if status == nil || equality.Semantic.DeepEqual(status, kv.Status.ClusterRecovery) {
+		return nil
+	}
+	return c.patchStatus(kv, patch.New(patch.WithAdd(clusterRecoveryStatusPath, status)))
+}
+
+func (c *Controller) patchStatus(kv *virtv1.KubeVirt, patchSet *patch.PatchSet) error {
+	patchBytes, err := patchSet.GeneratePayload()
+	if err != nil {
+		return err
+	}
+	_, err = c.clientset.KubeVirt(kv.Namespace).PatchStatus(context.Background(), kv.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to patch the cluster recovery status of KubeVirt %s: %v", kv.Name, err)
+	}
+	return nil
+}
+
+// AllowStart reports whether the VirtualMachineInstance of the given VirtualMachine may
+// be created now. While the cluster recovers from an outage, starts are admitted in the
+// order of the priority tiers and limited by the number of VirtualMachineInstances which
+// are already starting on the nodes the VirtualMachine can be scheduled to. If the start
+// is not allowed, the reason is returned.
+func (c *Controller) AllowStart(vm *virtv1.VirtualMachine) (bool, string) {
+	config := c.clusterConfig.GetClusterRecoveryConfiguration()
+	if config == nil {
+		return true, ""
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.hasSynced() {
+		return false, "waiting for the cluster recovery controller to sync"
+	}
+
+	now := time.Now()
+	if c.status == nil || now.Sub(c.evaluated) > stateTTL {
+		c.evaluate(config, now)
+	}
+	if c.status == nil || c.status.Phase != virtv1.ClusterRecoveryRecovering {
+		return true, ""
+	}
+
+	key := controller.NamespacedKey(vm.Namespace, vm.Name)
+	if _, reserved := c.reservations[key]; reserved {
+		return true, ""
+	}
+	if *config.StorageReadinessCheck && !c.storageReady(vm) {
+		return false, "waiting for its PersistentVolumeClaims to be bound"
+	}
+	if current := c.status.CurrentPriority; current != nil && priority(vm) < *current {
+		return false, fmt.Sprintf("waiting for VirtualMachines with priority %d to be started first", *current)
+	}
+	if free, starting := c.freeStartSlots(vm, int(*config.MaxStartsPerNode)); free <= 0 {
+		return false, fmt.Sprintf("%d VirtualMachineInstances are already starting", starting)
+	}
+
+	c.reservations[key] = now
+	return true, ""
+}
+
+// evaluate recomputes the recovery status. It has to be
+// called with the lock held.
+func (c *Controller) evaluate(config *virtv1.ClusterRecoveryConfiguration, now time.Time) {
+	c.expireReservations(now)
+
+	previous := c.status
+	if previous == nil {
+		previous = c.storedStatus()
+	}
+
+	status := &virtv1.ClusterRecoveryStatus{}
+	for _, obj := range c.vmStore.List() {
+		vm := obj.(*virtv1.VirtualMachine)
+		vmi := c.getVMI(vm)
+		if vm.DeletionTimestamp != nil || !startsAutomatically(vm, vmi) {
+			continue
+		}
+		status.VirtualMachines++
+
+		switch {
+		case vmi != nil && vmi.Status.Phase == virtv1.Running:
+			status.Running++
+		case vmi != nil && !vmi.IsFinal():
+			status.Starting++
+		default:
+			status.Pending++
+			if *config.StorageReadinessCheck && !c.storageReady(vm) {
+				status.WaitingForStorage++
+				continue
+			}
+			if !canBlockLowerPriorities(vm) {
+				continue
+			}
+			if p := priority(vm); status.CurrentPriority == nil || p > *status.CurrentPriority {
+				status.CurrentPriority = pointer.P(p)
+			}
+		}
+	}
+
+	down := status.Pending + status.Starting
+	wasRecovering := previous != nil && previous.Phase == virtv1.ClusterRecoveryRecovering
+	outage := status.VirtualMachines > 0 &&
+		int64(down)*100 >= int64(*config.OutageThresholdPercentage)*int64(status.VirtualMachines)
+
+	switch {
+	case wasRecovering && down == 0:
+		status.Phase = virtv1.ClusterRecoveryCompleted
+		status.StartTime = previous.StartTime
+		status.CompletionTime = pointer.P(metav1.NewTime(now))
+		status.CurrentPriority = nil
+		log.Log.Infof("Cluster recovery completed, %d VirtualMachines are running", status.Running)
+	case wasRecovering:
+		status.Phase = virtv1.ClusterRecoveryRecovering
+		status.StartTime = previous.StartTime
+	case outage && down > 0:
+		status.Phase = virtv1.ClusterRecoveryRecovering
+		status.StartTime = pointer.P(metav1.NewTime(now))
+		log.Log.Infof("Detected cluster-wide outage, %d of %d VirtualMachines are down, staggering their start", down, status.VirtualMachines)
+	default:
+		// Not recovering, keep reporting the outcome of the last recovery.
+		status = previous
+	}
+
+	c.status = status
+	c.evaluated = now
+}
+
+func (c *Controller) storedStatus() *virtv1.ClusterRecoveryStatus {
+	for _, obj := range c.kubeVirtStore.List() {
+		if kv := obj.(*virtv1.KubeVirt); kv.Status.ClusterRecovery != nil {
+			return kv.Status.ClusterRecovery.DeepCopy()
+		}
+	}
+	return nil
+}
+
+func (c *Controller) expireReservations(now time.Time) {
+	for key, admitted := range c.reservations {
+		if _, exists, _ := c.vmiStore.GetByKey(key); exists || now.Sub(admitted) > reservationTimeout {
+			delete(c.reservations, key)
+		}
+	}
+}
+
+func (c *Controller) getVMI(vm *virtv1.VirtualMachine) *virtv1.VirtualMachineInstance {
+	obj, exists, err := c.vmiStore.GetByKey(controller.NamespacedKey(vm.Namespace, vm.Name))
+	if err != nil || !exists {
+		return nil
+	}
+	return obj.(*virtv1.VirtualMachineInstance)
+}
+
+// freeStartSlots returns the number of VirtualMachineInstances which may still start on the
+// nodes the VirtualMachine can be scheduled to, and the number of VirtualMachineInstances
+// which are starting. A VirtualMachineInstance which was created but is not running yet
+// counts against the node it is scheduled to. Those which are not scheduled yet and the
+// admitted starts may end up on any node and take a free slot of the VirtualMachine's nodes.
+// If the VirtualMachine cannot be scheduled to any node yet, the starts are limited as if
+// the cluster had a single node.
+func (c *Controller) freeStartSlots(vm *virtv1.VirtualMachine, maxStartsPerNode int) (int, int) {
+	startingOnNode := map[string]int{}
+	unscheduled := len(c.reservations)
+	for _, obj := range c.vmiStore.List() {
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		if vmi.Status.Phase == virtv1.Running || vmi.IsFinal() {
+			continue
+		}
+		if vmi.Status.NodeName != "" {
+			startingOnNode[vmi.Status.NodeName]++
+		} else {
+			unscheduled++
+		}
+	}
+	starting := unscheduled
+	for _, count := range startingOnNode {
+		starting += count
+	}
+
+	free := 0
+	schedulable := false
+	for _, obj := range c.nodeStore.List() {
+		node := obj.(*k8sv1.Node)
+		if !canBeScheduledTo(vm, node) {
+			continue
+		}
+		schedulable = true
+		free += max(maxStartsPerNode-startingOnNode[node.Name], 0)
+	}
+	if !schedulable {
+		return maxStartsPerNode - starting, starting
+	}
+	return free - unscheduled, starting
+}
+
+func canBeScheduledTo(vm *virtv1.VirtualMachine, node *k8sv1.Node) bool {
+	if node.Labels[virtv1.NodeSchedulable] != "true" {
+		return false
+	}
+	if vm.Spec.Template == nil {
+		return !node.Spec.Unschedulable
+	}
+	spec := vm.Spec.Template.Spec
+	return nodes.IsSchedulable(node, spec.NodeSelector, spec.Affinity, spec.Tolerations)
+}
+
+// storageReady reports whether all existing PersistentVolumeClaims of the VirtualMachine
+// are bound. Claims which do not exist yet are created together with their DataVolumes
+// when the VirtualMachine is started and therefore do not delay the start.
+func (c *Controller) storageReady(vm *virtv1.VirtualMachine) bool {
+	if vm.Spec.Template == nil {
+		return true
+	}
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		var claimName string
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		case volume.DataVolume != nil:
+			claimName = volume.DataVolume.Name
+		default:
+			continue
+		}
+		obj, exists, err := c.pvcStore.GetByKey(controller.NamespacedKey(vm.Namespace, claimName))
+		if err != nil || !exists {
+			continue
+		}
+		if obj.(*k8sv1.PersistentVolumeClaim).Status.Phase != k8sv1.ClaimBound {
+			return false
+		}
+	}
+	return true
+}
+
+// startsAutomatically reports whether the VirtualMachine is started by the
+// VirtualMachine controller without user interaction.
+func startsAutomatically(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) bool {
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return false
+	}
+	switch runStrategy {
+	case virtv1.RunStrategyAlways:
+		return true
+	case virtv1.RunStrategyRerunOnFailure:
+		return vmi == nil || vmi.Status.Phase != virtv1.Succeeded
+	default:
+		return false
+	}
+}
+
+// canBlockLowerPriorities reports whether a pending VirtualMachine holds back the
+// start of VirtualMachines with a lower priority. VirtualMachines which are in a
+// start failure backoff or whose storage is being provisioned or broken would
+// otherwise block the lower priority tiers indefinitely.
+func canBlockLowerPriorities(vm *virtv1.VirtualMachine) bool {
+	if vm.Status.StartFailure != nil {
+		return false
+	}
+	switch vm.Status.PrintableStatus {
+	case virtv1.VirtualMachineStatusProvisioning, virtv1.VirtualMachineStatusDataVolumeError, virtv1.VirtualMachineStatusPvcNotFound:
+		return false
+	}
+	return true
+}
+
+func priority(vm *virtv1.VirtualMachine) int32 {
+	value, ok := vm.Annotations[virtv1.ClusterRecoveryPriorityAnnotation]
+	if !ok {
+		return 0
+	}
+	p, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		log.Log.Object(vm).Warningf("Ignoring invalid %s annotation %q", virtv1.ClusterRecoveryPriorityAnnotation, value)
+		return 0
+	}
+	return int32(p)
+}
@@ -0,0 +1,333 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package recovery
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Cluster recovery", func() {
+	const kvNamespace = "kubevirt"
+
+	var (
+		controller     *Controller
+		fakeVirtClient *kubevirtfake.Clientset
+		kv             *v1.KubeVirt
+	)
+
+	newController := func(config *v1.ClusterRecoveryConfiguration) {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		fakeVirtClient = kubevirtfake.NewSimpleClientset()
+		virtClient.EXPECT().KubeVirt(kvNamespace).Return(fakeVirtClient.KubevirtV1().KubeVirts(kvNamespace)).AnyTimes()
+
+		vmInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		nodeInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Node{})
+		pvcInformer, _ := testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+		kubeVirtInformer, _ := testutils.NewFakeInformerFor(&v1.KubeVirt{})
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			ClusterRecovery: config,
+		})
+
+		var err error
+		controller, err = NewController(vmInformer, vmiInformer, nodeInformer, pvcInformer, kubeVirtInformer, virtClient, clusterConfig)
+		Expect(err).ToNot(HaveOccurred())
+		controller.hasSynced = func() bool { return true }
+
+		kv = &v1.KubeVirt{ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: kvNamespace}}
+		_, err = fakeVirtClient.KubevirtV1().KubeVirts(kvNamespace).Create(context.Background(), kv, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(controller.kubeVirtStore.Add(kv)).To(Succeed())
+	}
+
+	addNodes := func(count int) {
+		for i := 0; i < count; i++ {
+			Expect(controller.nodeStore.Add(&k8sv1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   fmt.Sprintf("node%d", i),
+					Labels: map[string]string{v1.NodeSchedulable: "true", k8sv1.LabelHostname: fmt.Sprintf("node%d", i)},
+				},
+			})).To(Succeed())
+		}
+	}
+
+	addVM := func(name string, vmOpts []libvmi.VMOption, vmiOpts ...libvmi.Option) *v1.VirtualMachine {
+		vmiOpts = append([]libvmi.Option{libvmi.WithNamespace(metav1.NamespaceDefault), libvmi.WithName(name)}, vmiOpts...)
+		vmOpts = append([]libvmi.VMOption{libvmi.WithRunStrategy(v1.RunStrategyAlways)}, vmOpts...)
+		vm := libvmi.NewVirtualMachine(libvmi.New(vmiOpts...), vmOpts...)
+		Expect(controller.vmStore.Add(vm)).To(Succeed())
+		return vm
+	}
+
+	addVMI := func(name string, phase v1.VirtualMachineInstancePhase) {
+		Expect(controller.vmiStore.Add(&v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Status:     v1.VirtualMachineInstanceStatus{Phase: phase},
+		})).To(Succeed())
+	}
+
+	addScheduledVMI := func(name, nodeName string) {
+		Expect(controller.vmiStore.Add(&v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Status:     v1.VirtualMachineInstanceStatus{Phase: v1.Scheduled, NodeName: nodeName},
+		})).To(Succeed())
+	}
+
+	addPVC := func(name string, phase k8sv1.PersistentVolumeClaimPhase) {
+		Expect(controller.pvcStore.Add(&k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Status:     k8sv1.PersistentVolumeClaimStatus{Phase: phase},
+		})).To(Succeed())
+	}
+
+	withPriority := func(priority int) []libvmi.VMOption {
+		return []libvmi.VMOption{libvmi.WithAnnotations(map[string]string{
+			v1.ClusterRecoveryPriorityAnnotation: fmt.Sprint(priority),
+		})}
+	}
+
+	execute := func() *v1.ClusterRecoveryStatus {
+		key, err := cache.MetaNamespaceKeyFunc(kv)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(controller.execute(key)).To(Succeed())
+		updated, err := fakeVirtClient.KubevirtV1().KubeVirts(kvNamespace).Get(context.Background(), kv.Name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		kv = updated
+		Expect(controller.kubeVirtStore.Update(kv)).To(Succeed())
+		return kv.Status.ClusterRecovery
+	}
+
+	It("should always allow starts if the cluster recovery is not configured", func() {
+		newController(nil)
+		addNodes(1)
+		var vms []*v1.VirtualMachine
+		for i := 0; i < 5; i++ {
+			vms = append(vms, addVM(fmt.Sprintf("vm%d", i), nil))
+		}
+		for _, vm := range vms {
+			allowed, _ := controller.AllowStart(vm)
+			Expect(allowed).To(BeTrue())
+		}
+		Expect(execute()).To(BeNil())
+	})
+
+	It("should not throttle starts if less VMs than the outage threshold are down", func() {
+		newController(&v1.ClusterRecoveryConfiguration{MaxStartsPerNode: pointer.P(uint32(1))})
+		addNodes(1)
+		for i := 0; i < 3; i++ {
+			addVM(fmt.Sprintf("running%d", i), nil)
+			addVMI(fmt.Sprintf("running%d", i), v1.Running)
+		}
+		vm1 := addVM("vm1", nil)
+		vm2 := addVM("vm2", nil)
+
+		for _, vm := range []*v1.VirtualMachine{vm1, vm2} {
+			allowed, _ := controller.AllowStart(vm)
+			Expect(allowed).To(BeTrue())
+		}
+		Expect(execute()).To(BeNil())
+	})
+
+	It("should limit the number of concurrently starting VMs per schedulable node", func() {
+		newController(&v1.ClusterRecoveryConfiguration{MaxStartsPerNode: pointer.P(uint32(2))})
+		addNodes(1)
+		var vms []*v1.VirtualMachine
+		for i := 0; i < 4; i++ {
+			vms = append(vms, addVM(fmt.Sprintf("vm%d", i), nil))
+		}
+		addVMI("vm0", v1.Scheduling)
+
+		allowed, _ := controller.AllowStart(vms[1])
+		Expect(allowed).To(BeTrue())
+		allowed, reason := controller.AllowStart(vms[2])
+		Expect(allowed).To(BeFalse())
+		Expect(reason).To(Equal("2 VirtualMachineInstances are already starting"))
+
+		By("admitting the next VM once a VM is running")
+		addVMI("vm0", v1.Running)
+		allowed, _ = controller.AllowStart(vms[2])
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should count the starting VMs against the node they are scheduled to", func() {
+		newController(&v1.ClusterRecoveryConfiguration{MaxStartsPerNode: pointer.P(uint32(1))})
+		addNodes(2)
+		for i := 0; i < 2; i++ {
+			addVM(fmt.Sprintf("starting%d", i), nil)
+			addScheduledVMI(fmt.Sprintf("starting%d", i), "node0")
+		}
+		pinned := addVM("pinned", nil, libvmi.WithNodeSelectorFor("node0"))
+		vm := addVM("vm", nil)
+
+		allowed, reason := controller.AllowStart(pinned)
+		Expect(allowed).To(BeFalse())
+		Expect(reason).To(Equal("2 VirtualMachineInstances are already starting"))
+		allowed, _ = controller.AllowStart(vm)
+		Expect(allowed).To(BeTrue())
+
+		By("counting the admitted start against the free node")
+		allowed, _ = controller.AllowStart(addVM("other", nil))
+		Expect(allowed).To(BeFalse())
+	})
+
+	It("should only count the nodes the VM can be scheduled to", func() {
+		newController(&v1.ClusterRecoveryConfiguration{MaxStartsPerNode: pointer.P(uint32(1))})
+		addNodes(2)
+		node, exists, err := controller.nodeStore.GetByKey("node1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeTrue())
+		cordoned := node.(*k8sv1.Node).DeepCopy()
+		cordoned.Spec.Unschedulable = true
+		Expect(controller.nodeStore.Update(cordoned)).To(Succeed())
+		vm1 := addVM("vm1", nil)
+		vm2 := addVM("vm2", nil)
+
+		allowed, _ := controller.AllowStart(vm1)
+		Expect(allowed).To(BeTrue())
+		allowed, _ = controller.AllowStart(vm2)
+		Expect(allowed).To(BeFalse())
+	})
+
+	It("should start the VMs with the highest priority first", func() {
+		newController(&v1.ClusterRecoveryConfiguration{MaxStartsPerNode: pointer.P(uint32(10))})
+		addNodes(1)
+		high := addVM("high", withPriority(10))
+		low := addVM("low", withPriority(1))
+
+		allowed, reason := controller.AllowStart(low)
+		Expect(allowed).To(BeFalse())
+		Expect(reason).To(Equal("waiting for VirtualMachines with priority 10 to be started first"))
+
+		allowed, _ = controller.AllowStart(high)
+		Expect(allowed).To(BeTrue())
+
+		By("admitting the lower priority once the higher priority VM is starting")
+		addVMI("high", v1.Scheduling)
+		controller.lock.Lock()
+		controller.evaluated = controller.evaluated.Add(-2 * stateTTL)
+		controller.lock.Unlock()
+		allowed, _ = controller.AllowStart(low)
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should not let VMs in a start failure backoff hold back lower priorities", func() {
+		newController(&v1.ClusterRecoveryConfiguration{})
+		addNodes(1)
+		failing := addVM("failing", withPriority(10))
+		failing.Status.StartFailure = &v1.VirtualMachineStartFailure{RetryAfterTimestamp: pointer.P(metav1.Now())}
+		low := addVM("low", nil)
+
+		allowed, _ := controller.AllowStart(low)
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should delay the start of VMs whose PVCs are not bound", func() {
+		newController(&v1.ClusterRecoveryConfiguration{})
+		addNodes(1)
+		vm := addVM("vm", nil, libvmi.WithPersistentVolumeClaim("disk", "claim"))
+		addPVC("claim", k8sv1.ClaimPending)
+
+		allowed, reason := controller.AllowStart(vm)
+		Expect(allowed).To(BeFalse())
+		Expect(reason).To(Equal("waiting for its PersistentVolumeClaims to be bound"))
+
+		status := execute()
+		Expect(status.Pending).To(BeEquivalentTo(1))
+		Expect(status.WaitingForStorage).To(BeEquivalentTo(1))
+	})
+
+	It("should ignore unbound PVCs if the storage readiness check is disabled", func() {
+		newController(&v1.ClusterRecoveryConfiguration{StorageReadinessCheck: pointer.P(false)})
+		addNodes(1)
+		vm := addVM("vm", nil, libvmi.WithPersistentVolumeClaim("disk", "claim"))
+		addPVC("claim", k8sv1.ClaimPending)
+
+		allowed, _ := controller.AllowStart(vm)
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should report the progress of the recovery on the KubeVirt status", func() {
+		newController(&v1.ClusterRecoveryConfiguration{})
+		addNodes(1)
+		addVM("vm0", withPriority(5))
+		addVM("vm1", nil)
+		addVMI("vm1", v1.Scheduled)
+		addVM("halted", []libvmi.VMOption{libvmi.WithRunStrategy(v1.RunStrategyHalted)})
+
+		status := execute()
+		Expect(status).ToNot(BeNil())
+		Expect(status.Phase).To(Equal(v1.ClusterRecoveryRecovering))
+		Expect(status.StartTime).ToNot(BeNil())
+		Expect(status.CompletionTime).To(BeNil())
+		Expect(status.CurrentPriority).To(HaveValue(BeEquivalentTo(5)))
+		Expect(status.VirtualMachines).To(BeEquivalentTo(2))
+		Expect(status.Pending).To(BeEquivalentTo(1))
+		Expect(status.Starting).To(BeEquivalentTo(1))
+		Expect(status.Running).To(BeZero())
+
+		By("completing the recovery once all VMs are running")
+		addVMI("vm0", v1.Running)
+		addVMI("vm1", v1.Running)
+		status = execute()
+		Expect(status.Phase).To(Equal(v1.ClusterRecoveryCompleted))
+		Expect(status.CompletionTime).ToNot(BeNil())
+		Expect(status.CurrentPriority).To(BeNil())
+		Expect(status.Running).To(BeEquivalentTo(2))
+	})
+
+	It("should continue a recovery reported on the KubeVirt status after a restart", func() {
+		newController(&v1.ClusterRecoveryConfiguration{MaxStartsPerNode: pointer.P(uint32(1))})
+		addNodes(1)
+		startTime := metav1.Now()
+		kv.Status.ClusterRecovery = &v1.ClusterRecoveryStatus{
+			Phase:     v1.ClusterRecoveryRecovering,
+			StartTime: &startTime,
+		}
+		Expect(controller.kubeVirtStore.Update(kv)).To(Succeed())
+		for i := 0; i < 3; i++ {
+			addVM(fmt.Sprintf("running%d", i), nil)
+			addVMI(fmt.Sprintf("running%d", i), v1.Running)
+		}
+		addVM("starting", nil)
+		addVMI("starting", v1.Scheduling)
+		vm := addVM("vm", nil)
+
+		allowed, _ := controller.AllowStart(vm)
+		Expect(allowed).To(BeFalse())
+	})
+})
@@ -14,7 +14,6 @@ import (
 const TSCFrequencyLabel = virtv1.CPUTimerLabel + "tsc-frequency"
 const TSCFrequencySchedulingLabel = "scheduling.node.kubevirt.io/tsc-frequency"
 const TSCScalableLabel = virtv1.CPUTimerLabel + "tsc-scalable"
-const TSCTolerancePPM float64 = 250
 
 type FilterPredicateFunc func(node *v1.Node) bool
 
@@ -122,7 +121,7 @@ func IsNodeRunningVmis(vmiStore cache.Store) FilterPredicateFunc {
 	}
 }
 
-// ToleranceForFrequency returns TSCTolerancePPM parts per million of freq, rounded down to the nearest Hz
-func ToleranceForFrequency(freq int64) int64 {
-	return int64(math.Floor(float64(freq) * (TSCTolerancePPM / 1000000)))
+// ToleranceForFrequency returns tolerancePPM parts per million of freq, rounded down to the nearest Hz
+func ToleranceForFrequency(freq int64, tolerancePPM int64) int64 {
+	return int64(math.Floor(float64(freq) * (float64(tolerancePPM) / 1000000)))
 }
@@ -83,6 +83,20 @@ func (mr *MockHinterMockRecorder) TSCFrequenciesInUse() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TSCFrequenciesInUse", reflect.TypeOf((*MockHinter)(nil).TSCFrequenciesInUse))
 }
 
+// TSCFrequencyTolerancePPM mocks base method.
+func (m *MockHinter) TSCFrequencyTolerancePPM() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TSCFrequencyTolerancePPM")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// TSCFrequencyTolerancePPM indicates an expected call of TSCFrequencyTolerancePPM.
+func (mr *MockHinterMockRecorder) TSCFrequencyTolerancePPM() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TSCFrequencyTolerancePPM", reflect.TypeOf((*MockHinter)(nil).TSCFrequencyTolerancePPM))
+}
+
 // TopologyHintsForVMI mocks base method.
 func (m *MockHinter) TopologyHintsForVMI(vmi *v1.VirtualMachineInstance) (*v1.TopologyHints, TscFrequencyRequirementType, error) {
 	m.ctrl.T.Helper()
@@ -98,3 +112,18 @@ func (mr *MockHinterMockRecorder) TopologyHintsForVMI(vmi any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopologyHintsForVMI", reflect.TypeOf((*MockHinter)(nil).TopologyHintsForVMI), vmi)
 }
+
+// TopologyHintsFromSourceNode mocks base method.
+func (m *MockHinter) TopologyHintsFromSourceNode(vmi *v1.VirtualMachineInstance) (*v1.TopologyHints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopologyHintsFromSourceNode", vmi)
+	ret0, _ := ret[0].(*v1.TopologyHints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopologyHintsFromSourceNode indicates an expected call of TopologyHintsFromSourceNode.
+func (mr *MockHinterMockRecorder) TopologyHintsFromSourceNode(vmi any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopologyHintsFromSourceNode", reflect.TypeOf((*MockHinter)(nil).TopologyHintsFromSourceNode), vmi)
+}
@@ -5,6 +5,7 @@ package topology
 import (
 	"fmt"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	"kubevirt.io/kubevirt/pkg/pointer"
@@ -16,9 +17,11 @@ import (
 
 type Hinter interface {
 	TopologyHintsForVMI(vmi *k6tv1.VirtualMachineInstance) (hints *k6tv1.TopologyHints, requirement TscFrequencyRequirementType, err error)
+	TopologyHintsFromSourceNode(vmi *k6tv1.VirtualMachineInstance) (*k6tv1.TopologyHints, error)
 	IsTscFrequencyRequired(vmi *k6tv1.VirtualMachineInstance) bool
 	TSCFrequenciesInUse() []int64
 	LowestTSCFrequencyOnCluster() (int64, error)
+	TSCFrequencyTolerancePPM() int64
 }
 
 type topologyHinter struct {
@@ -46,6 +49,32 @@ func (t *topologyHinter) TopologyHintsForVMI(vmi *k6tv1.VirtualMachineInstance)
 	return
 }
 
+// TopologyHintsFromSourceNode returns hints which pin a running VMI to the TSC frequency of the node it runs on.
+// This covers VMIs which require a TSC frequency but booted without one, e.g. because no node exposed its frequency
+// at that time. Such a guest observes the TSC frequency of its node, which any migration target has to preserve.
+func (t *topologyHinter) TopologyHintsFromSourceNode(vmi *k6tv1.VirtualMachineInstance) (*k6tv1.TopologyHints, error) {
+	if !t.IsTscFrequencyRequired(vmi) || AreTSCFrequencyTopologyHintsDefined(vmi) || vmi.Status.NodeName == "" {
+		return nil, nil
+	}
+
+	obj, exists, err := t.nodeStore.GetByKey(vmi.Status.NodeName)
+	if err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, nil
+	}
+	freq, _, err := TSCFrequencyFromNode(obj.(*v1.Node))
+	if err != nil || freq == 0 {
+		return nil, err
+	}
+
+	return &k6tv1.TopologyHints{TSCFrequency: pointer.P(freq)}, nil
+}
+
+func (t *topologyHinter) TSCFrequencyTolerancePPM() int64 {
+	return t.clusterConfig.GetTSCFrequencyTolerancePPM()
+}
+
 func (t *topologyHinter) LowestTSCFrequencyOnCluster() (int64, error) {
 	configTSCFrequency := t.clusterConfig.GetMinimumClusterTSCFrequency()
 	if configTSCFrequency != nil {
@@ -72,6 +72,52 @@ var _ = Describe("Hinter", func() {
 		g.Expect(hinter.TSCFrequenciesInUse()).To(g.ConsistOf(int64(100), int64(90), int64(123), int64(80)))
 	})
 
+	Context("capturing the TSC frequency of the source node", func() {
+		var hinter *topologyHinter
+
+		BeforeEach(func() {
+			hinter = hinterWithNodes(
+				NodeWithTSC("node1", 1234, true),
+				NodeWithInvalidTSC("node2"),
+				&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node3"}},
+			)
+		})
+
+		It("should pin a running VMI without TSC frequency to the frequency of its node", func() {
+			vmi := vmiWithoutTSCFrequency("myvmi")
+			vmi.Spec.Architecture = "amd64"
+			vmi.Status.NodeName = "node1"
+			g.Expect(hinter.TopologyHintsFromSourceNode(vmi)).To(g.Equal(
+				&virtv1.TopologyHints{
+					TSCFrequency: pointer.P(int64(1234)),
+				},
+			))
+		})
+
+		It("should keep the TSC frequency which is already set on the VMI", func() {
+			vmi := vmiWithTSCFrequencyOnNode("myvmi", 12, "node1")
+			g.Expect(hinter.TopologyHintsFromSourceNode(vmi)).To(g.BeNil())
+		})
+
+		DescribeTable("should not propose a TSC frequency", func(nodeName string, expectError bool) {
+			vmi := vmiWithoutTSCFrequency("myvmi")
+			vmi.Spec.Architecture = "amd64"
+			vmi.Status.NodeName = nodeName
+			hints, err := hinter.TopologyHintsFromSourceNode(vmi)
+			g.Expect(hints).To(g.BeNil())
+			if expectError {
+				g.Expect(err).To(g.HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(g.HaveOccurred())
+			}
+		},
+			Entry("if the VMI is not scheduled yet", "", false),
+			Entry("if the node does not exist", "unknown", false),
+			Entry("if the node does not expose its TSC frequency", "node3", false),
+			Entry("if the node exposes an invalid TSC frequency", "node2", true),
+		)
+	})
+
 	DescribeTable("should not propose a TSC frequency on architectures like", func(arch string) {
 		hinter := hinterWithNodes(
 			NodeWithInvalidTSC("node0"),
@@ -96,6 +142,14 @@ func hinterWithNodes(nodes ...*v1.Node) *topologyHinter {
 			ListFunc: func() []interface{} {
 				return NodesToObjects(nodes...)
 			},
+			GetByKeyFunc: func(key string) (interface{}, bool, error) {
+				for _, node := range nodes {
+					if node.Name == key {
+						return node, true, nil
+					}
+				}
+				return nil, false, nil
+			},
 		},
 	}
 }
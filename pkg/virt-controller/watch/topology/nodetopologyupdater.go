@@ -53,9 +53,10 @@ func (n *nodeTopologyUpdater) sync(nodes []*v1.Node) *updateStats {
 		log.DefaultLogger().Reason(err).Error("Skipping TSC frequency updates on all nodes")
 		return &updateStats{skipped: len(nodes)}
 	}
+	tolerancePPM := n.hinter.TSCFrequencyTolerancePPM()
 	stats := &updateStats{}
 	for _, node := range nodes {
-		nodeCopy, err := calculateNodeLabelChanges(node, requiredFrequencies)
+		nodeCopy, err := calculateNodeLabelChanges(node, requiredFrequencies, tolerancePPM)
 		if err != nil {
 			stats.error++
 			log.DefaultLogger().Object(node).Reason(err).Error("Could not calculate TSC frequencies for node")
@@ -75,14 +76,14 @@ func (n *nodeTopologyUpdater) sync(nodes []*v1.Node) *updateStats {
 	return stats
 }
 
-func calculateNodeLabelChanges(original *v1.Node, requiredFrequencies []int64) (modified *v1.Node, err error) {
+func calculateNodeLabelChanges(original *v1.Node, requiredFrequencies []int64, tolerancePPM int64) (modified *v1.Node, err error) {
 	nodeFreq, scalable, err := TSCFrequencyFromNode(original)
 	if err != nil {
 		log.DefaultLogger().Reason(err).Object(original).Errorf("Can't determine original TSC frequency of node %s", original.Name)
 		return nil, err
 	}
 	freqsOnNode := TSCFrequenciesOnNode(original)
-	toAdd, toRemove := CalculateTSCLabelDiff(requiredFrequencies, freqsOnNode, nodeFreq, scalable)
+	toAdd, toRemove := CalculateTSCLabelDiff(requiredFrequencies, freqsOnNode, nodeFreq, scalable, tolerancePPM)
 	toAddLabels := ToTSCSchedulableLabels(toAdd)
 	toRemoveLabels := ToTSCSchedulableLabels(toRemove)
 
@@ -32,6 +32,7 @@ var _ = Describe("Nodetopologyupdater", func() {
 		}
 		kubeClient = fake.NewSimpleClientset()
 		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		hinter.EXPECT().TSCFrequencyTolerancePPM().Return(int64(250)).AnyTimes()
 	})
 
 	Context("with no VMs with TSC frequency set running", func() {
@@ -75,18 +75,24 @@ func distance(freq1, freq2 int64) int64 {
 	return freq2 - freq1
 }
 
-func CalculateTSCLabelDiff(frequenciesInUse []int64, frequenciesOnNode []int64, nodeFrequency int64, scalable bool) (toAdd []int64, toRemove []int64) {
+// IsTSCFrequencyCompatible returns true if a guest with the given TSC frequency can run on a node with nodeFrequency.
+func IsTSCFrequencyCompatible(frequency int64, nodeFrequency int64, scalable bool, tolerancePPM int64) bool {
+	// A scalable node can accept frequencies that are lower than its own.
+	if scalable && frequency <= nodeFrequency {
+		return true
+	}
+	// Any node can accept frequencies that are within the tolerance:
+	// nodeFrequency*(1-tolerance) <= acceptableFrequency <= nodeFrequency*(1+tolerance).
+	return distance(frequency, nodeFrequency) <= ToleranceForFrequency(nodeFrequency, tolerancePPM)
+}
+
+func CalculateTSCLabelDiff(frequenciesInUse []int64, frequenciesOnNode []int64, nodeFrequency int64, scalable bool, tolerancePPM int64) (toAdd []int64, toRemove []int64) {
 	frequenciesInUse = append(frequenciesInUse, nodeFrequency)
-	tolerance := ToleranceForFrequency(nodeFrequency)
 	requiredMap := map[int64]struct{}{}
 	for _, freq := range frequenciesInUse {
-		if !scalable && distance(freq, nodeFrequency) > tolerance {
-			// A non-scalable node can only accept frequencies that are within Qemu's tolerance:
-			// nodeFrequency*(1-0.000250) < acceptableFrequency < nodeFrequency*(1+0.000250).
-			// Skip the frequencies that are outside that range
-			continue
+		if IsTSCFrequencyCompatible(freq, nodeFrequency, scalable, tolerancePPM) {
+			requiredMap[freq] = struct{}{}
 		}
-		requiredMap[freq] = struct{}{}
 	}
 
 	for _, freq := range frequenciesOnNode {
@@ -96,11 +102,7 @@ func CalculateTSCLabelDiff(frequenciesInUse []int64, frequenciesOnNode []int64,
 	}
 
 	for freq := range requiredMap {
-		// For the non-scalable case, the map was already sanitized above.
-		// For the scalable case, a node can accept frequencies that are either lower than its own or within the tolerance range
-		if !scalable || freq <= nodeFrequency || distance(freq, nodeFrequency) <= tolerance {
-			toAdd = append(toAdd, freq)
-		}
+		toAdd = append(toAdd, freq)
 	}
 
 	return
@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"kubevirt.io/kubevirt/pkg/libvmi"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/topology"
 )
 
@@ -40,7 +41,7 @@ var _ = Describe("TSC", func() {
 	})
 
 	DescribeTable("should calculate the node label diff", func(frequenciesInUse []int64, frequenciesOnNode []int64, nodeFrequency int64, scalable bool, expectedToAdd []int64, expectedToRemove []int64) {
-		toAdd, toRemove := topology.CalculateTSCLabelDiff(frequenciesInUse, frequenciesOnNode, nodeFrequency, scalable)
+		toAdd, toRemove := topology.CalculateTSCLabelDiff(frequenciesInUse, frequenciesOnNode, nodeFrequency, scalable, virtconfig.DefaultTSCFrequencyTolerancePPM)
 		Expect(toAdd).To(ConsistOf(expectedToAdd))
 		Expect(toRemove).To(ConsistOf(expectedToRemove))
 	},
@@ -80,6 +81,27 @@ var _ = Describe("TSC", func() {
 			[]int64{123123, 123120, 123130},
 			[]int64{2, 4},
 		),
+		Entry(
+			"on a scalable node which carries a label of a frequency that became incompatible",
+			[]int64{1, 200000},
+			[]int64{1, 200000},
+			int64(123123),
+			true,
+			[]int64{1, 123123},
+			[]int64{200000},
+		),
+	)
+
+	DescribeTable("should check the TSC frequency compatibility", func(frequency int64, nodeFrequency int64, scalable bool, tolerancePPM int64, expected bool) {
+		Expect(topology.IsTSCFrequencyCompatible(frequency, nodeFrequency, scalable, tolerancePPM)).To(Equal(expected))
+	},
+		Entry("with an identical frequency", int64(1000000), int64(1000000), false, int64(0), true),
+		Entry("with a lower frequency on a scalable node", int64(900000), int64(1000000), true, int64(0), true),
+		Entry("with a lower frequency on a non-scalable node", int64(900000), int64(1000000), false, int64(250), false),
+		Entry("with a higher frequency within the tolerance on a scalable node", int64(1000250), int64(1000000), true, int64(250), true),
+		Entry("with a higher frequency outside of the tolerance on a scalable node", int64(1000251), int64(1000000), true, int64(250), false),
+		Entry("with a lower frequency within the tolerance on a non-scalable node", int64(999900), int64(1000000), false, int64(100), true),
+		Entry("with a lower frequency outside of a reduced tolerance on a non-scalable node", int64(999900), int64(1000000), false, int64(50), false),
 	)
 
 	Context("needs to be set when", func() {
@@ -0,0 +1,179 @@
+/*
+Copyright The KubeVirt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	k8score "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+type degradedReason struct {
+	reason  string
+	message string
+}
+
+// syncDegradedCondition aggregates the health of the resources backing the VM
+// into the Degraded condition. The condition is removed once the VM is healthy again.
+func (c *Controller) syncDegradedCondition(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	reasons := vmiDegradedReasons(vm, vmi)
+	if reason := c.snapshotDegradedReason(vm); reason != nil {
+		reasons = append(reasons, *reason)
+	}
+
+	cm := controller.NewVirtualMachineConditionManager()
+	if len(reasons) == 0 {
+		cm.RemoveCondition(vm, virtv1.VirtualMachineDegraded)
+		return
+	}
+
+	messages := make([]string, 0, len(reasons))
+	for _, r := range reasons {
+		messages = append(messages, r.message)
+	}
+	message := strings.Join(messages, "; ")
+
+	// UpdateCondition only compares status and reason, keep the message in sync with the full list
+	for i, cond := range vm.Status.Conditions {
+		if cond.Type == virtv1.VirtualMachineDegraded && cond.Status == k8score.ConditionTrue && cond.Reason == reasons[0].reason {
+			vm.Status.Conditions[i].Message = message
+			return
+		}
+	}
+
+	cm.UpdateCondition(vm, &virtv1.VirtualMachineCondition{
+		Type:               virtv1.VirtualMachineDegraded,
+		Status:             k8score.ConditionTrue,
+		Reason:             reasons[0].reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+func vmiDegradedReasons(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) []degradedReason {
+	var reasons []degradedReason
+
+	if vmi != nil && !vmi.IsFinal() {
+		vmiCM := controller.NewVirtualMachineInstanceConditionManager()
+		if vmiCM.HasConditionWithStatus(vmi, virtv1.VirtualMachineInstancePausedOnIOError, k8score.ConditionTrue) {
+			reasons = append(reasons, degradedReason{
+				reason:  virtv1.VirtualMachineDegradedPausedOnIOError,
+				message: "VMI is paused because of an I/O error",
+			})
+		}
+
+		// GuestOSInfo is only reported by the guest agent, so it tells that an agent was connected before
+		if vmi.IsRunning() && vmi.Status.GuestOSInfo.Name != "" &&
+			!vmiCM.HasConditionWithStatus(vmi, virtv1.VirtualMachineInstanceAgentConnected, k8score.ConditionTrue) {
+			reasons = append(reasons, degradedReason{
+				reason:  virtv1.VirtualMachineDegradedGuestAgentDisconnected,
+				message: "guest agent is disconnected",
+			})
+		}
+
+		if vmi.Status.MigrationState != nil && vmi.Status.MigrationState.Failed {
+			reasons = append(reasons, degradedReason{
+				reason:  virtv1.VirtualMachineDegradedMigrationFailed,
+				message: "last migration failed",
+			})
+		}
+	}
+
+	if pending := pendingHotplugVolumes(vm, vmi); len(pending) > 0 {
+		reasons = append(reasons, degradedReason{
+			reason:  virtv1.VirtualMachineDegradedHotplugPending,
+			message: fmt.Sprintf("hotplug of volumes %s is pending", strings.Join(pending, ", ")),
+		})
+	}
+
+	return reasons
+}
+
+func pendingHotplugVolumes(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) []string {
+	var pending []string
+	for _, request := range vm.Status.VolumeRequests {
+		switch {
+		case request.AddVolumeOptions != nil:
+			pending = append(pending, request.AddVolumeOptions.Name)
+		case request.RemoveVolumeOptions != nil:
+			pending = append(pending, request.RemoveVolumeOptions.Name)
+		}
+	}
+
+	if vmi == nil || vmi.IsFinal() {
+		return pending
+	}
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.HotplugVolume != nil && volumeStatus.Phase != virtv1.VolumeReady {
+			pending = append(pending, volumeStatus.Name)
+		}
+	}
+	return pending
+}
+
+// snapshotDegradedReason reports the snapshot in progress as stale when it no longer exists,
+// failed or exceeded its failure deadline. A VM is requeued to be reevaluated at the deadline.
+func (c *Controller) snapshotDegradedReason(vm *virtv1.VirtualMachine) *degradedReason {
+	if vm.Status.SnapshotInProgress == nil {
+		return nil
+	}
+	name := *vm.Status.SnapshotInProgress
+
+	obj, exists, err := c.vmSnapshotStore.GetByKey(controller.NamespacedKey(vm.Namespace, name))
+	if err != nil {
+		return nil
+	}
+	if !exists {
+		return &degradedReason{
+			reason:  virtv1.VirtualMachineDegradedSnapshotStale,
+			message: fmt.Sprintf("snapshot %s in progress does not exist", name),
+		}
+	}
+
+	snapshot := obj.(*snapshotv1.VirtualMachineSnapshot)
+	if snapshot.Status != nil && snapshot.Status.Phase == snapshotv1.Failed {
+		return &degradedReason{
+			reason:  virtv1.VirtualMachineDegradedSnapshotStale,
+			message: fmt.Sprintf("snapshot %s in progress failed", name),
+		}
+	}
+
+	failureDeadline := snapshotv1.DefaultFailureDeadline
+	if snapshot.Spec.FailureDeadline != nil {
+		failureDeadline = snapshot.Spec.FailureDeadline.Duration
+	}
+	// No deadline set by the user
+	if failureDeadline == 0 {
+		return nil
+	}
+	if remaining := time.Until(snapshot.CreationTimestamp.Add(failureDeadline)); remaining > 0 {
+		c.Queue.AddAfter(controller.VirtualMachineKey(vm), remaining)
+		return nil
+	}
+	return &degradedReason{
+		reason:  virtv1.VirtualMachineDegradedSnapshotStale,
+		message: fmt.Sprintf("snapshot %s in progress exceeded its failure deadline of %s", name, failureDeadline),
+	}
+}
@@ -73,6 +73,7 @@ import (
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/storage/velero"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/errorhints"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
 	"kubevirt.io/kubevirt/pkg/util/migrations"
 	traceUtils "kubevirt.io/kubevirt/pkg/util/trace"
@@ -130,6 +131,9 @@ const (
 
 const defaultMaxCrashLoopBackoffDelaySeconds = 300
 
+// startGateRequeueInterval is the interval in which VMs whose start was delayed are re-evaluated
+const startGateRequeueInterval = 10 * time.Second
+
 func NewController(vmiInformer cache.SharedIndexInformer,
 	vmInformer cache.SharedIndexInformer,
 	dataVolumeInformer cache.SharedIndexInformer,
@@ -138,11 +142,14 @@ func NewController(vmiInformer cache.SharedIndexInformer,
 	namespaceInformer cache.SharedIndexInformer,
 	pvcInformer cache.SharedIndexInformer,
 	crInformer cache.SharedIndexInformer,
+	vmSnapshotInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
 	clusterConfig *virtconfig.ClusterConfig,
 	netSynchronizer synchronizer,
 	firmwareSynchronizer synchronizer,
+	startGate startGate,
+	storageCapacityGate startGate,
 	instancetypeController instancetypeHandler,
 	additionalLauncherAnnotationsSync []string,
 	additionalLauncherLabelsSync []string,
@@ -160,6 +167,7 @@ func NewController(vmiInformer cache.SharedIndexInformer,
 		namespaceStore:         namespaceInformer.GetStore(),
 		pvcStore:               pvcInformer.GetStore(),
 		crIndexer:              crInformer.GetIndexer(),
+		vmSnapshotStore:        vmSnapshotInformer.GetStore(),
 		instancetypeController: instancetypeController,
 		recorder:               recorder,
 		clientset:              clientset,
@@ -172,6 +180,8 @@ func NewController(vmiInformer cache.SharedIndexInformer,
 		clusterConfig:                     clusterConfig,
 		netSynchronizer:                   netSynchronizer,
 		firmwareSynchronizer:              firmwareSynchronizer,
+		startGate:                         startGate,
+		storageCapacityGate:               storageCapacityGate,
 		additionalLauncherAnnotationsSync: additionalLauncherAnnotationsSync,
 		additionalLauncherLabelsSync:      additionalLauncherLabelsSync,
 	}
@@ -179,7 +189,8 @@ func NewController(vmiInformer cache.SharedIndexInformer,
 	c.hasSynced = func() bool {
 		return vmiInformer.HasSynced() && vmInformer.HasSynced() &&
 			dataVolumeInformer.HasSynced() && dataSourceInformer.HasSynced() &&
-			pvcInformer.HasSynced() && crInformer.HasSynced()
+			pvcInformer.HasSynced() && crInformer.HasSynced() &&
+			vmSnapshotInformer.HasSynced()
 	}
 
 	_, err := vmInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -272,6 +283,12 @@ type synchronizer interface {
 	Sync(*virtv1.VirtualMachine, *virtv1.VirtualMachineInstance) (*virtv1.VirtualMachine, error)
 }
 
+// startGate decides whether a VirtualMachine may be started automatically now,
+// e.g. while the cluster recovers from an outage.
+type startGate interface {
+	AllowStart(*virtv1.VirtualMachine) (bool, string)
+}
+
 type instancetypeHandler interface {
 	synchronizer
 	ApplyToVM(*virtv1.VirtualMachine) error
@@ -289,6 +306,7 @@ type Controller struct {
 	namespaceStore         cache.Store
 	pvcStore               cache.Store
 	crIndexer              cache.Indexer
+	vmSnapshotStore        cache.Store
 	instancetypeController instancetypeHandler
 	recorder               record.EventRecorder
 	expectations           *controller.UIDTrackingControllerExpectations
@@ -299,6 +317,8 @@ type Controller struct {
 
 	netSynchronizer      synchronizer
 	firmwareSynchronizer synchronizer
+	startGate            startGate
+	storageCapacityGate  startGate
 
 	additionalLauncherAnnotationsSync []string
 	additionalLauncherLabelsSync      []string
@@ -582,9 +602,15 @@ func (c *Controller) VMICPUsPatch(vm *virtv1.VirtualMachine, vmi *virtv1.Virtual
 
 	logMsg := fmt.Sprintf("hotplugging cpu to %v sockets", vm.Spec.Template.Spec.Domain.CPU.Sockets)
 
+	sockets := vm.Spec.Template.Spec.Domain.CPU.Sockets
+	liveUpdated := liveUpdatedResources(vmi)
+	liveUpdated.Sockets = &sockets
+
 	if !vm.Spec.Template.Spec.Domain.Resources.Requests.Cpu().IsZero() {
 		newCpuReq := vmi.Spec.Domain.Resources.Requests.Cpu().DeepCopy()
 		newCpuReq.Add(*resourcesDelta)
+		newCpuReq = maxQuantity(newCpuReq, *vm.Spec.Template.Spec.Domain.Resources.Requests.Cpu())
+		liveUpdated.Requests = withQuantity(liveUpdated.Requests, k8score.ResourceCPU, newCpuReq)
 
 		patchSet.AddOption(
 			patch.WithTest("/spec/domain/resources/requests/cpu", vmi.Spec.Domain.Resources.Requests.Cpu().String()),
@@ -596,6 +622,8 @@ func (c *Controller) VMICPUsPatch(vm *virtv1.VirtualMachine, vmi *virtv1.Virtual
 	if !vm.Spec.Template.Spec.Domain.Resources.Limits.Cpu().IsZero() {
 		newCpuLimit := vmi.Spec.Domain.Resources.Limits.Cpu().DeepCopy()
 		newCpuLimit.Add(*resourcesDelta)
+		newCpuLimit = maxQuantity(newCpuLimit, *vm.Spec.Template.Spec.Domain.Resources.Limits.Cpu())
+		liveUpdated.Limits = withQuantity(liveUpdated.Limits, k8score.ResourceCPU, newCpuLimit)
 
 		patchSet.AddOption(
 			patch.WithTest("/spec/domain/resources/limits/cpu", vmi.Spec.Domain.Resources.Limits.Cpu().String()),
@@ -605,6 +633,8 @@ func (c *Controller) VMICPUsPatch(vm *virtv1.VirtualMachine, vmi *virtv1.Virtual
 		logMsg = fmt.Sprintf("%s, setting limits to %s", logMsg, newCpuLimit.String())
 	}
 
+	patchSet.AddOption(patch.WithAdd("/status/liveUpdatedResources", liveUpdated))
+
 	patchBytes, err := patchSet.GeneratePayload()
 	if err != nil {
 		return err
@@ -1028,6 +1058,10 @@ func (c *Controller) syncRunStrategy(vm *virtv1.VirtualMachine, vmi *virtv1.Virt
 			return vm, nil
 		}
 
+		if c.isStartDelayed(vm, vmKey) {
+			return vm, nil
+		}
+
 		log.Log.Object(vm).Infof("%s due to runStrategy: %s", startingVmMsg, runStrategy)
 		vm, err = c.startVMI(vm)
 		if err != nil {
@@ -1077,6 +1111,10 @@ func (c *Controller) syncRunStrategy(vm *virtv1.VirtualMachine, vmi *virtv1.Virt
 			return vm, nil
 		}
 
+		if c.isStartDelayed(vm, vmKey) {
+			return vm, nil
+		}
+
 		log.Log.Object(vm).Infof("%s due to runStrategy: %s", startingVmMsg, runStrategy)
 		vm, err = c.startVMI(vm)
 		if err != nil {
@@ -1176,6 +1214,21 @@ func (c *Controller) syncRunStrategy(vm *virtv1.VirtualMachine, vmi *virtv1.Virt
 	}
 }
 
+// isStartDelayed determines whether the automatic start of the VM has to be delayed
+// and re-enqueues the VM in that case.
+func (c *Controller) isStartDelayed(vm *virtv1.VirtualMachine, vmKey string) bool {
+	if c.startGate == nil {
+		return false
+	}
+	allowed, reason := c.startGate.AllowStart(vm)
+	if allowed {
+		return false
+	}
+	log.Log.Object(vm).V(3).Infof("Delaying start of VM: %s", reason)
+	c.Queue.AddAfter(vmKey, startGateRequeueInterval)
+	return true
+}
+
 // isVMIStartExpected determines whether a VMI is expected to be started for this VM.
 func (c *Controller) isVMIStartExpected(vm *virtv1.VirtualMachine) bool {
 	vmKey, err := controller.KeyFunc(vm)
@@ -1276,6 +1329,26 @@ func (c *Controller) startVMI(vm *virtv1.VirtualMachine) (*virtv1.VirtualMachine
 		return vm, nil
 	}
 
+	if c.storageCapacityGate != nil {
+		vmConditionManager := controller.NewVirtualMachineConditionManager()
+		if allowed, reason := c.storageCapacityGate.AllowStart(vm); !allowed {
+			log.Log.Object(vm).V(3).Infof("Delaying start of VM: %s", reason)
+			if !vmConditionManager.HasCondition(vm, virtv1.VirtualMachineStorageCapacityExhausted) {
+				c.recorder.Eventf(vm, k8score.EventTypeWarning, common.StorageCapacityExhaustedReason, "Delaying start of the virtual machine: %s", reason)
+				vmConditionManager.UpdateCondition(vm, &virtv1.VirtualMachineCondition{
+					Type:               virtv1.VirtualMachineStorageCapacityExhausted,
+					Status:             k8score.ConditionTrue,
+					Reason:             common.StorageCapacityExhaustedReason,
+					Message:            reason,
+					LastTransitionTime: metav1.Now(),
+				})
+			}
+			c.Queue.AddAfter(vmKey, startGateRequeueInterval)
+			return vm, nil
+		}
+		vmConditionManager.RemoveCondition(vm, virtv1.VirtualMachineStorageCapacityExhausted)
+	}
+
 	vm = c.cleanupRestartRequired(vm)
 
 	// start it
@@ -2490,6 +2563,8 @@ func (c *Controller) updateStatus(vm, vmOrig *virtv1.VirtualMachine, vmi *virtv1
 	// condition to the VM
 	syncVolumeMigration(vm, vmi)
 	syncConditions(vm, vmi, syncErr)
+	c.syncDegradedCondition(vm, vmi)
+	syncInstanceStatus(vm, vmi)
 	c.setPrintableStatus(vm, vmi)
 	cbt.SyncVMChangedBlockTrackingState(vm, vmi, c.clusterConfig, c.namespaceStore)
 
@@ -2735,6 +2810,51 @@ func syncReadyConditionFromVMI(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMac
 	}
 }
 
+// syncInstanceStatus mirrors the IP addresses, the node name and the guest agent
+// state of the VMI onto the VM, so that consumers do not need to watch VMIs.
+func syncInstanceStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	var ipAddresses []string
+	nodeName := ""
+	guestAgentConnected := false
+	if vmi != nil && !vmi.IsFinal() {
+		ipAddresses = instanceIPAddresses(vmi)
+		nodeName = vmi.Status.NodeName
+		guestAgentConnected = controller.NewVirtualMachineInstanceConditionManager().
+			HasConditionWithStatus(vmi, virtv1.VirtualMachineInstanceAgentConnected, k8score.ConditionTrue)
+	}
+
+	if equality.Semantic.DeepEqual(vm.Status.IPAddresses, ipAddresses) &&
+		vm.Status.NodeName == nodeName &&
+		vm.Status.GuestAgentConnected == guestAgentConnected {
+		return
+	}
+
+	now := metav1.Now()
+	vm.Status.IPAddresses = ipAddresses
+	vm.Status.NodeName = nodeName
+	vm.Status.GuestAgentConnected = guestAgentConnected
+	vm.Status.InstanceStatusLastUpdateTime = &now
+}
+
+func instanceIPAddresses(vmi *virtv1.VirtualMachineInstance) []string {
+	var ipAddresses []string
+	seen := map[string]struct{}{}
+	for _, iface := range vmi.Status.Interfaces {
+		ips := iface.IPs
+		if len(ips) == 0 && iface.IP != "" {
+			ips = []string{iface.IP}
+		}
+		for _, ip := range ips {
+			if _, exists := seen[ip]; exists {
+				continue
+			}
+			seen[ip] = struct{}{}
+			ipAddresses = append(ipAddresses, ip)
+		}
+	}
+	return ipAddresses
+}
+
 func syncConditions(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, syncErr common.SyncError) {
 	cm := controller.NewVirtualMachineConditionManager()
 
@@ -2752,6 +2872,7 @@ func syncConditions(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstanc
 		string(virtv1.VirtualMachineReady):           nil,
 		string(virtv1.VirtualMachineFailure):         nil,
 		string(virtv1.VirtualMachineRestartRequired): nil,
+		string(virtv1.VirtualMachineDegraded):        nil,
 	}
 	vmiCondMap := make(map[string]interface{})
 
@@ -2801,7 +2922,7 @@ func processFailureCondition(vm *virtv1.VirtualMachine, syncErr common.SyncError
 	vmConditionManager.UpdateCondition(vm, &virtv1.VirtualMachineCondition{
 		Type:               virtv1.VirtualMachineFailure,
 		Reason:             syncErr.Reason(),
-		Message:            syncErr.Error(),
+		Message:            errorhints.Annotate(syncErr.Error()),
 		LastTransitionTime: metav1.Now(),
 		Status:             k8score.ConditionTrue,
 	})
@@ -3016,6 +3137,9 @@ func (c *Controller) syncRestartRequired(lastSeenVMSpec *virtv1.VirtualMachineSp
 	// Note2: destroying lastSeenVM here is fine, we don't need it later
 	if c.clusterConfig.IsVMRolloutStrategyLiveUpdate() {
 		if lastSeenVM.Spec.Template.Spec.Domain.CPU != nil && currentVM.Spec.Template.Spec.Domain.CPU != nil {
+			if lastSeenVM.Spec.Template.Spec.Domain.CPU.Sockets != currentVM.Spec.Template.Spec.Domain.CPU.Sockets {
+				syncLiveUpdatableResource(&lastSeenVM.Spec.Template.Spec.Domain.Resources, &currentVM.Spec.Template.Spec.Domain.Resources, k8score.ResourceCPU)
+			}
 			lastSeenVM.Spec.Template.Spec.Domain.CPU.Sockets = currentVM.Spec.Template.Spec.Domain.CPU.Sockets
 		}
 
@@ -3023,6 +3147,9 @@ func (c *Controller) syncRestartRequired(lastSeenVMSpec *virtv1.VirtualMachineSp
 			if lastSeenVM.Spec.Template.Spec.Domain.Memory == nil {
 				lastSeenVM.Spec.Template.Spec.Domain.Memory = &virtv1.Memory{}
 			}
+			if !equality.Semantic.DeepEqual(lastSeenVM.Spec.Template.Spec.Domain.Memory.Guest, currentVM.Spec.Template.Spec.Domain.Memory.Guest) {
+				syncLiveUpdatableResource(&lastSeenVM.Spec.Template.Spec.Domain.Resources, &currentVM.Spec.Template.Spec.Domain.Resources, k8score.ResourceMemory)
+			}
 			lastSeenVM.Spec.Template.Spec.Domain.Memory.Guest = currentVM.Spec.Template.Spec.Domain.Memory.Guest
 		}
 
@@ -3446,6 +3573,9 @@ func (c *Controller) handleMemoryHotplugRequest(vm *virtv1.VirtualMachine, vmi *
 
 	logMsg := fmt.Sprintf("hotplugging memory to %s", vmCopyWithInstancetype.Spec.Template.Spec.Domain.Memory.Guest.String())
 
+	liveUpdated := liveUpdatedResources(vmi)
+	liveUpdated.Guest = pointer.P(vmCopyWithInstancetype.Spec.Template.Spec.Domain.Memory.Guest.DeepCopy())
+
 	if !vmi.Spec.Domain.Resources.Requests.Memory().IsZero() {
 		newMemoryReq := vmi.Spec.Domain.Resources.Requests.Memory().DeepCopy()
 		newMemoryReq.Add(*memoryDelta)
@@ -3457,6 +3587,8 @@ func (c *Controller) handleMemoryHotplugRequest(vm *virtv1.VirtualMachine, vmi *
 			// adjusting memoryDelta too for the new limits computation (if required)
 			memoryDelta = resource.NewQuantity(vmCopyWithInstancetype.Spec.Template.Spec.Domain.Memory.Guest.Value()-newMemoryReq.Value(), resource.BinarySI)
 		}
+		newMemoryReq = maxQuantity(newMemoryReq, *vmCopyWithInstancetype.Spec.Template.Spec.Domain.Resources.Requests.Memory())
+		liveUpdated.Requests = withQuantity(liveUpdated.Requests, k8score.ResourceMemory, newMemoryReq)
 
 		patchSet.AddOption(
 			patch.WithTest("/spec/domain/resources/requests/memory", vmi.Spec.Domain.Resources.Requests.Memory().String()),
@@ -3468,6 +3600,8 @@ func (c *Controller) handleMemoryHotplugRequest(vm *virtv1.VirtualMachine, vmi *
 	if !vmCopyWithInstancetype.Spec.Template.Spec.Domain.Resources.Limits.Memory().IsZero() {
 		newMemoryLimit := vmi.Spec.Domain.Resources.Limits.Memory().DeepCopy()
 		newMemoryLimit.Add(*memoryDelta)
+		newMemoryLimit = maxQuantity(newMemoryLimit, *vmCopyWithInstancetype.Spec.Template.Spec.Domain.Resources.Limits.Memory())
+		liveUpdated.Limits = withQuantity(liveUpdated.Limits, k8score.ResourceMemory, newMemoryLimit)
 
 		patchSet.AddOption(
 			patch.WithTest("/spec/domain/resources/limits/memory", vmi.Spec.Domain.Resources.Limits.Memory().String()),
@@ -3477,6 +3611,8 @@ func (c *Controller) handleMemoryHotplugRequest(vm *virtv1.VirtualMachine, vmi *
 		logMsg = fmt.Sprintf("%s, setting limits to %s", logMsg, newMemoryLimit.String())
 	}
 
+	patchSet.AddOption(patch.WithAdd("/status/liveUpdatedResources", liveUpdated))
+
 	patchBytes, err := patchSet.GeneratePayload()
 	if err != nil {
 		return err
@@ -3492,6 +3628,46 @@ func (c *Controller) handleMemoryHotplugRequest(vm *virtv1.VirtualMachine, vmi *
 	return nil
 }
 
+// liveUpdatedResources returns a copy of the resources recorded in the VMI status by previous live updates
+func liveUpdatedResources(vmi *virtv1.VirtualMachineInstance) *virtv1.LiveUpdatedResources {
+	if vmi.Status.LiveUpdatedResources == nil {
+		return &virtv1.LiveUpdatedResources{}
+	}
+	return vmi.Status.LiveUpdatedResources.DeepCopy()
+}
+
+func withQuantity(list k8score.ResourceList, name k8score.ResourceName, quantity resource.Quantity) k8score.ResourceList {
+	if list == nil {
+		list = k8score.ResourceList{}
+	}
+	list[name] = quantity
+	return list
+}
+
+// maxQuantity honours requests and limits raised in the VM template beyond what the hotplug itself requires
+func maxQuantity(computed, fromTemplate resource.Quantity) resource.Quantity {
+	if fromTemplate.Cmp(computed) > 0 {
+		return fromTemplate.DeepCopy()
+	}
+	return computed
+}
+
+// syncLiveUpdatableResource treats a change of the request and limit of a resource in the template spec
+// as live-updatable, they are applied together with the hotplug of CPU sockets or guest memory.
+func syncLiveUpdatableResource(lastSeen, current *virtv1.ResourceRequirements, name k8score.ResourceName) {
+	lastSeen.Requests = syncResourceListEntry(lastSeen.Requests, current.Requests, name)
+	lastSeen.Limits = syncResourceListEntry(lastSeen.Limits, current.Limits, name)
+}
+
+func syncResourceListEntry(lastSeen, current k8score.ResourceList, name k8score.ResourceName) k8score.ResourceList {
+	quantity, exists := current[name]
+	if !exists {
+		delete(lastSeen, name)
+		return lastSeen
+	}
+	return withQuantity(lastSeen, name, quantity)
+}
+
 func (c *Controller) handleDeclarativeVolumeHotplug(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
 	if c.clusterConfig.HotplugVolumesEnabled() || !c.clusterConfig.DeclarativeHotplugVolumesEnabled() {
 		log.Log.Object(vm).V(4).Info("Declarative hotplug volumes are not enabled, skipping")
@@ -29,6 +29,7 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	instancetypeapi "kubevirt.io/api/instancetype"
 	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
 	"kubevirt.io/client-go/api"
 	cdifake "kubevirt.io/client-go/containerizeddataimporter/fake"
 	"kubevirt.io/client-go/kubecli"
@@ -84,6 +85,7 @@ var _ = Describe("VirtualMachine", func() {
 		var kvStore cache.Store
 		var virtFakeClient *fake.Clientset
 		var dataVolumeInformer cache.SharedIndexInformer
+		var vmSnapshotInformer cache.SharedIndexInformer
 
 		BeforeEach(func() {
 			virtClient = kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
@@ -103,6 +105,7 @@ var _ = Describe("VirtualMachine", func() {
 
 			dataVolumeInformer, _ = testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
 			dataSourceInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataSource{})
+			vmSnapshotInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshot{})
 			kvInformer, _ := testutils.NewFakeInformerFor(&v1.KubeVirt{})
 			vmiInformer, _ := testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachineInstance{}, virtcontroller.GetVMIInformerIndexers())
 			vmInformer, _ := testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachine{}, virtcontroller.GetVirtualMachineInformerIndexers())
@@ -147,11 +150,14 @@ var _ = Describe("VirtualMachine", func() {
 				namespaceInformer,
 				pvcInformer,
 				crInformer,
+				vmSnapshotInformer,
 				recorder,
 				virtClient,
 				config,
 				nil,
 				nil,
+				nil,
+				nil,
 				instancetypecontroller.NewControllerStub(),
 				[]string{},
 				[]string{},
@@ -327,7 +333,7 @@ var _ = Describe("VirtualMachine", func() {
 		sanityExecute := func(vm *v1.VirtualMachine) {
 			controllertesting.SanityExecute(controller, []cache.Store{
 				controller.vmiIndexer, controller.vmIndexer, controller.dataSourceStore, controller.dataVolumeStore,
-				controller.namespaceStore, controller.pvcStore, controller.crIndexer,
+				controller.namespaceStore, controller.pvcStore, controller.crIndexer, controller.vmSnapshotStore,
 			}, Default)
 		}
 
@@ -3111,6 +3117,195 @@ var _ = Describe("VirtualMachine", func() {
 			Expect(cond).To(BeNil())
 		})
 
+		Context("storage capacity", func() {
+			It("should delay the start while the storage capacity gate does not allow it", func() {
+				controller.storageCapacityGate = staticStartGate{reason: "available capacity 1Gi of StorageClass thin-pool is below the minimum of 10Gi"}
+				vm, _ := watchtesting.DefaultVirtualMachine(true)
+				vm, err := virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Create(context.TODO(), vm, metav1.CreateOptions{})
+				Expect(err).To(Succeed())
+				addVirtualMachine(vm)
+
+				sanityExecute(vm)
+
+				_, err = virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).To(MatchError(k8serrors.IsNotFound, "IsNotFound"))
+				testutils.ExpectEvent(recorder, common.StorageCapacityExhaustedReason)
+
+				vm, err = virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(vm, v1.VirtualMachineStorageCapacityExhausted)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Status).To(Equal(k8sv1.ConditionTrue))
+				Expect(cond.Message).To(Equal("available capacity 1Gi of StorageClass thin-pool is below the minimum of 10Gi"))
+			})
+
+			It("should not record the event again while the start stays delayed", func() {
+				controller.storageCapacityGate = staticStartGate{reason: "available capacity 1Gi of StorageClass thin-pool is below the minimum of 10Gi"}
+				vm, _ := watchtesting.DefaultVirtualMachine(true)
+				vm.Status.Conditions = []v1.VirtualMachineCondition{{
+					Type:   v1.VirtualMachineStorageCapacityExhausted,
+					Status: k8sv1.ConditionTrue,
+				}}
+				vm, err := virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Create(context.TODO(), vm, metav1.CreateOptions{})
+				Expect(err).To(Succeed())
+				addVirtualMachine(vm)
+
+				sanityExecute(vm)
+
+				_, err = virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).To(MatchError(k8serrors.IsNotFound, "IsNotFound"))
+			})
+
+			It("should start the VM once the storage capacity gate allows it", func() {
+				controller.storageCapacityGate = staticStartGate{allowed: true}
+				vm, _ := watchtesting.DefaultVirtualMachine(true)
+				vm.Status.Conditions = []v1.VirtualMachineCondition{{
+					Type:   v1.VirtualMachineStorageCapacityExhausted,
+					Status: k8sv1.ConditionTrue,
+				}}
+				vm, err := virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Create(context.TODO(), vm, metav1.CreateOptions{})
+				Expect(err).To(Succeed())
+				addVirtualMachine(vm)
+
+				sanityExecute(vm)
+
+				_, err = virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				testutils.ExpectEvent(recorder, common.SuccessfulCreateVirtualMachineReason)
+
+				vm, err = virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(virtcontroller.NewVirtualMachineConditionManager().HasCondition(vm, v1.VirtualMachineStorageCapacityExhausted)).To(BeFalse())
+			})
+		})
+
+		Context("Degraded condition", func() {
+			runWithVMI := func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) *v1.VirtualMachine {
+				vm, err := virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Create(context.TODO(), vm, metav1.CreateOptions{})
+				Expect(err).To(Succeed())
+				addVirtualMachine(vm)
+
+				if vmi != nil {
+					vmi, err = virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Create(context.TODO(), vmi, metav1.CreateOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(controller.vmiIndexer.Add(vmi)).To(Succeed())
+				}
+
+				sanityExecute(vm)
+
+				vm, err = virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				return vm
+			}
+
+			It("should not be added to a healthy VM", func() {
+				vm, vmi := watchtesting.DefaultVirtualMachine(true)
+				watchtesting.MarkAsReady(vmi)
+
+				vm = runWithVMI(vm, vmi)
+				Expect(vm).To(matcher.HaveConditionMissingOrFalse(v1.VirtualMachineDegraded))
+			})
+
+			DescribeTable("should be added with the matching reason", func(mutate func(vmi *v1.VirtualMachineInstance), expectedReason string) {
+				vm, vmi := watchtesting.DefaultVirtualMachine(true)
+				watchtesting.MarkAsReady(vmi)
+				mutate(vmi)
+
+				vm = runWithVMI(vm, vmi)
+				Expect(vm).To(matcher.HaveConditionTrueWithReason(v1.VirtualMachineDegraded, expectedReason))
+			},
+				Entry("when the VMI is paused on an I/O error", func(vmi *v1.VirtualMachineInstance) {
+					vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+						Type:   v1.VirtualMachineInstancePausedOnIOError,
+						Status: k8sv1.ConditionTrue,
+					})
+				}, v1.VirtualMachineDegradedPausedOnIOError),
+				Entry("when a previously connected guest agent disconnected", func(vmi *v1.VirtualMachineInstance) {
+					vmi.Status.GuestOSInfo.Name = "Fedora"
+				}, v1.VirtualMachineDegradedGuestAgentDisconnected),
+				Entry("when the last migration failed", func(vmi *v1.VirtualMachineInstance) {
+					vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{Failed: true}
+				}, v1.VirtualMachineDegradedMigrationFailed),
+				Entry("when a hotplugged volume is not ready", func(vmi *v1.VirtualMachineInstance) {
+					vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+						Name:          "hotplug",
+						Phase:         v1.HotplugVolumeAttachedToNode,
+						HotplugVolume: &v1.HotplugVolumeStatus{},
+					})
+				}, v1.VirtualMachineDegradedHotplugPending),
+			)
+
+			It("should not report a connected guest agent", func() {
+				vm, vmi := watchtesting.DefaultVirtualMachine(true)
+				watchtesting.MarkAsReady(vmi)
+				vmi.Status.GuestOSInfo.Name = "Fedora"
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:   v1.VirtualMachineInstanceAgentConnected,
+					Status: k8sv1.ConditionTrue,
+				})
+
+				vm = runWithVMI(vm, vmi)
+				Expect(vm).To(matcher.HaveConditionMissingOrFalse(v1.VirtualMachineDegraded))
+			})
+
+			It("should use the first reason and list all of them in the message", func() {
+				vm, vmi := watchtesting.DefaultVirtualMachine(true)
+				watchtesting.MarkAsReady(vmi)
+				vmi.Status.GuestOSInfo.Name = "Fedora"
+				vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{Failed: true}
+
+				vm = runWithVMI(vm, vmi)
+				Expect(vm).To(matcher.HaveConditionTrueWithReason(v1.VirtualMachineDegraded, v1.VirtualMachineDegradedGuestAgentDisconnected))
+				Expect(vm).To(matcher.HaveConditionTrueWithMessage(v1.VirtualMachineDegraded, "guest agent is disconnected; last migration failed"))
+			})
+
+			It("should be removed once the VM is healthy again", func() {
+				vm, vmi := watchtesting.DefaultVirtualMachine(true)
+				watchtesting.MarkAsReady(vmi)
+				vm.Status.Conditions = append(vm.Status.Conditions, v1.VirtualMachineCondition{
+					Type:   v1.VirtualMachineDegraded,
+					Status: k8sv1.ConditionTrue,
+					Reason: v1.VirtualMachineDegradedMigrationFailed,
+				})
+
+				vm = runWithVMI(vm, vmi)
+				Expect(vm).To(matcher.HaveConditionMissingOrFalse(v1.VirtualMachineDegraded))
+			})
+
+			DescribeTable("should report the snapshot in progress", func(snapshot *snapshotv1.VirtualMachineSnapshot, expectStale bool) {
+				vm, _ := watchtesting.DefaultVirtualMachine(false)
+				vm.Status.SnapshotInProgress = pointer.P("snapshot")
+				if snapshot != nil {
+					snapshot.Name = "snapshot"
+					snapshot.Namespace = vm.Namespace
+					Expect(vmSnapshotInformer.GetStore().Add(snapshot)).To(Succeed())
+				}
+
+				vm = runWithVMI(vm, nil)
+				if expectStale {
+					Expect(vm).To(matcher.HaveConditionTrueWithReason(v1.VirtualMachineDegraded, v1.VirtualMachineDegradedSnapshotStale))
+				} else {
+					Expect(vm).To(matcher.HaveConditionMissingOrFalse(v1.VirtualMachineDegraded))
+				}
+			},
+				Entry("as stale when it does not exist", nil, true),
+				Entry("as stale when it failed", &snapshotv1.VirtualMachineSnapshot{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+					Status:     &snapshotv1.VirtualMachineSnapshotStatus{Phase: snapshotv1.Failed},
+				}, true),
+				Entry("as stale when it exceeded its failure deadline", &snapshotv1.VirtualMachineSnapshot{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Minute))},
+				}, true),
+				Entry("as healthy when it is within its failure deadline", &snapshotv1.VirtualMachineSnapshot{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+				}, false),
+				Entry("as healthy when it has no failure deadline", &snapshotv1.VirtualMachineSnapshot{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Minute))},
+					Spec:       snapshotv1.VirtualMachineSnapshotSpec{FailureDeadline: &metav1.Duration{}},
+				}, false),
+			)
+		})
+
 		It("should back off if a sync error occurs", func() {
 			vm, vmi := watchtesting.DefaultVirtualMachine(false)
 
@@ -5422,6 +5617,51 @@ var _ = Describe("VirtualMachine", func() {
 					Expect(vmi.Spec.Domain.Resources.Limits.Cpu().String()).To(Equal(expectedCpuLim.String()))
 				})
 
+				It("should honour CPU requests and limits raised in the VM alongside the sockets", func() {
+					vm, _ := watchtesting.DefaultVirtualMachine(true)
+					vm.Spec.Template.Spec.Domain.Resources = v1.ResourceRequirements{
+						Requests: k8sv1.ResourceList{
+							k8sv1.ResourceCPU: resource.MustParse("1"),
+						},
+						Limits: k8sv1.ResourceList{
+							k8sv1.ResourceCPU: resource.MustParse("4"),
+						},
+					}
+					vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{
+						Sockets: 2,
+					}
+
+					vmi := api.NewMinimalVMI(vm.Name)
+					vmi.Spec.Domain.CPU = &v1.CPU{
+						Sockets:    1,
+						MaxSockets: 4,
+					}
+					vmi.Spec.Domain.Resources = v1.ResourceRequirements{
+						Requests: k8sv1.ResourceList{
+							k8sv1.ResourceCPU: resource.MustParse("100m"),
+						},
+						Limits: k8sv1.ResourceList{
+							k8sv1.ResourceCPU: resource.MustParse("1"),
+						},
+					}
+
+					vmi, err := virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Create(context.Background(), vmi, metav1.CreateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(controller.handleCPUChangeRequest(vm, vmi)).To(Succeed())
+
+					vmi, err = virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Get(context.Background(), vmi.Name, metav1.GetOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(vmi.Spec.Domain.CPU.Sockets).To(Equal(uint32(2)))
+					Expect(vmi.Spec.Domain.Resources.Requests.Cpu().String()).To(Equal("1"))
+					Expect(vmi.Spec.Domain.Resources.Limits.Cpu().String()).To(Equal("4"))
+
+					Expect(vmi.Status.LiveUpdatedResources).ToNot(BeNil())
+					Expect(vmi.Status.LiveUpdatedResources.Sockets).To(HaveValue(Equal(uint32(2))))
+					Expect(vmi.Status.LiveUpdatedResources.Requests.Cpu().String()).To(Equal("1"))
+					Expect(vmi.Status.LiveUpdatedResources.Limits.Cpu().String()).To(Equal("4"))
+				})
+
 				It("should raise RestartRequired condition for ARM64 VM", func() {
 					vm, _ := watchtesting.DefaultVirtualMachine(true)
 					vm.Spec.Template.Spec.Architecture = "arm64"
@@ -5524,6 +5764,9 @@ var _ = Describe("VirtualMachine", func() {
 						expectedMemReq := resources.Requests.Memory().Value() + newMemory.Value() - guestMemory.Value()
 						Expect(vmi.Spec.Domain.Resources.Requests.Memory().Value()).To(Equal(expectedMemReq))
 					}
+
+					Expect(vmi.Status.LiveUpdatedResources).ToNot(BeNil())
+					Expect(vmi.Status.LiveUpdatedResources.Guest).To(HaveValue(Equal(newMemory)))
 				},
 					Entry("with memory request set", v1.ResourceRequirements{
 						Requests: k8sv1.ResourceList{
@@ -5540,6 +5783,57 @@ var _ = Describe("VirtualMachine", func() {
 					}),
 				)
 
+				It("should honour a memory limit raised in the VM alongside the guest memory", func() {
+					vm, _ := watchtesting.DefaultVirtualMachine(true)
+					newMemory := resource.MustParse("2Gi")
+					vm.Spec.Template.Spec.Domain.Resources = v1.ResourceRequirements{
+						Requests: k8sv1.ResourceList{
+							k8sv1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+						Limits: k8sv1.ResourceList{
+							k8sv1.ResourceMemory: resource.MustParse("8Gi"),
+						},
+					}
+					vm.Spec.Template.Spec.Domain.Memory = &v1.Memory{Guest: &newMemory}
+					vm.Spec.Template.Spec.Architecture = "amd64"
+
+					vmi := api.NewMinimalVMI(vm.Name)
+					guestMemory := resource.MustParse("1Gi")
+					vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guestMemory, MaxGuest: &maxGuestFromSpec}
+					vmi.Spec.Domain.Resources = v1.ResourceRequirements{
+						Requests: k8sv1.ResourceList{
+							k8sv1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+						Limits: k8sv1.ResourceList{
+							k8sv1.ResourceMemory: resource.MustParse("4Gi"),
+						},
+					}
+					vmi.Status.Memory = &v1.MemoryStatus{
+						GuestAtBoot:    &guestMemory,
+						GuestCurrent:   &guestMemory,
+						GuestRequested: &guestMemory,
+					}
+					vmiCondManager := virtcontroller.NewVirtualMachineInstanceConditionManager()
+					vmiCondManager.UpdateCondition(vmi, &v1.VirtualMachineInstanceCondition{
+						Type:   v1.VirtualMachineInstanceIsMigratable,
+						Status: k8sv1.ConditionTrue,
+					})
+
+					vmi, err := virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Create(context.Background(), vmi, metav1.CreateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(controller.handleMemoryHotplugRequest(vm, vmi)).To(Succeed())
+
+					vmi, err = virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Get(context.Background(), vmi.Name, metav1.GetOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(vmi.Spec.Domain.Resources.Requests.Memory().String()).To(Equal("2Gi"))
+					Expect(vmi.Spec.Domain.Resources.Limits.Memory().String()).To(Equal("8Gi"))
+
+					Expect(vmi.Status.LiveUpdatedResources).ToNot(BeNil())
+					Expect(vmi.Status.LiveUpdatedResources.Requests.Memory().String()).To(Equal("2Gi"))
+					Expect(vmi.Status.LiveUpdatedResources.Limits.Memory().String()).To(Equal("8Gi"))
+				})
+
 				It("should not patch VMI if memory hotplug is already in progress", func() {
 					vm, _ := watchtesting.DefaultVirtualMachine(true)
 					newMemory := resource.MustParse("128Mi")
@@ -6446,6 +6740,55 @@ var _ = Describe("VirtualMachine", func() {
 					&liveUpdate, Not(restartRequiredMatcher(k8sv1.ConditionTrue))),
 			)
 
+			It("should live-update the CPU limits changed together with the sockets", func() {
+				vm.Status.Created = true
+				vm.Status.Ready = true
+				vm.Status.PrintableStatus = "Running"
+				virtcontroller.NewVirtualMachineConditionManager().UpdateCondition(vm, &v1.VirtualMachineCondition{
+					Type:   v1.VirtualMachineReady,
+					Status: k8sv1.ConditionTrue,
+				})
+				kv.Spec.Configuration.VMRolloutStrategy = &liveUpdate
+				testutils.UpdateFakeKubeVirtClusterConfig(kvStore, kv)
+
+				By("Creating a VM with CPU sockets set to 2 and a CPU limit of 2")
+				vm.Spec.Template.Spec.Domain.CPU.Sockets = 2
+				vm.Spec.Template.Spec.Domain.CPU.MaxSockets = 8
+				vm.Spec.Template.Spec.Domain.Resources.Limits = k8sv1.ResourceList{
+					k8sv1.ResourceCPU: resource.MustParse("2"),
+				}
+				controller.crIndexer.Add(createVMRevision(vm))
+
+				By("Creating a VMI")
+				vmi = SetupVMIFromVM(vm)
+				watchtesting.MarkAsReady(vmi)
+				vmi, err := virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Create(context.Background(), vmi, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				controller.vmiIndexer.Add(vmi)
+
+				By("Bumping the VM sockets to 4 and the CPU limit to 6")
+				vm.Spec.Template.Spec.Domain.CPU.Sockets = 4
+				vm.Spec.Template.Spec.Domain.Resources.Limits[k8sv1.ResourceCPU] = resource.MustParse("6")
+
+				vm, err = virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Create(context.TODO(), vm, metav1.CreateOptions{})
+				Expect(err).To(Succeed())
+				addVirtualMachine(vm)
+
+				By("Executing the controller expecting no RestartRequired condition")
+				sanityExecute(vm)
+				vm, err = virtFakeClient.KubevirtV1().VirtualMachines(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				Expect(vm.Status.Conditions).To(Not(restartRequiredMatcher(k8sv1.ConditionTrue)), "restart Required")
+
+				vmi, err = virtFakeClient.KubevirtV1().VirtualMachineInstances(vm.Namespace).Get(context.TODO(), vm.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vmi.Spec.Domain.CPU.Sockets).To(Equal(uint32(4)))
+				Expect(vmi.Spec.Domain.Resources.Limits.Cpu().String()).To(Equal("6"))
+				Expect(vmi.Status.LiveUpdatedResources).ToNot(BeNil())
+				Expect(vmi.Status.LiveUpdatedResources.Sockets).To(HaveValue(Equal(uint32(4))))
+				Expect(vmi.Status.LiveUpdatedResources.Limits.Cpu().String()).To(Equal("6"))
+			})
+
 			Context("for hotplugged volumes", func() {
 				BeforeEach(func() {
 					kv.Spec.Configuration.VMRolloutStrategy = pointer.P(stage)
@@ -7113,6 +7456,75 @@ var _ = Describe("VirtualMachine", func() {
 		})
 	})
 
+	Context("syncInstanceStatus", func() {
+		var vm *v1.VirtualMachine
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vm, vmi = watchtesting.DefaultVirtualMachineWithNames(true, "test", "test")
+			vmi.Status.Phase = v1.Running
+			vmi.Status.NodeName = "node01"
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+				{Name: "default", IP: "10.244.0.10", IPs: []string{"10.244.0.10", "fd10:244::a"}},
+				{Name: "secondary", IP: "192.168.1.10"},
+				{Name: "duplicate", IPs: []string{"10.244.0.10"}},
+			}
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+				Type:   v1.VirtualMachineInstanceAgentConnected,
+				Status: k8sv1.ConditionTrue,
+			}}
+		})
+
+		It("should mirror the IP addresses, node name and guest agent state of the VMI", func() {
+			syncInstanceStatus(vm, vmi)
+			Expect(vm.Status.IPAddresses).To(Equal([]string{"10.244.0.10", "fd10:244::a", "192.168.1.10"}))
+			Expect(vm.Status.NodeName).To(Equal("node01"))
+			Expect(vm.Status.GuestAgentConnected).To(BeTrue())
+			Expect(vm.Status.InstanceStatusLastUpdateTime).ToNot(BeNil())
+		})
+
+		It("should not update the timestamp when nothing changed", func() {
+			syncInstanceStatus(vm, vmi)
+			lastUpdate := metav1.NewTime(time.Now().Add(-time.Hour))
+			vm.Status.InstanceStatusLastUpdateTime = &lastUpdate
+
+			syncInstanceStatus(vm, vmi)
+			Expect(vm.Status.InstanceStatusLastUpdateTime).To(Equal(&lastUpdate))
+		})
+
+		It("should update the timestamp when the guest agent disconnects", func() {
+			syncInstanceStatus(vm, vmi)
+			lastUpdate := metav1.NewTime(time.Now().Add(-time.Hour))
+			vm.Status.InstanceStatusLastUpdateTime = &lastUpdate
+
+			vmi.Status.Conditions = nil
+			syncInstanceStatus(vm, vmi)
+			Expect(vm.Status.GuestAgentConnected).To(BeFalse())
+			Expect(vm.Status.InstanceStatusLastUpdateTime.After(lastUpdate.Time)).To(BeTrue())
+		})
+
+		DescribeTable("should clear the mirrored state", func(getVMI func() *v1.VirtualMachineInstance) {
+			syncInstanceStatus(vm, vmi)
+
+			syncInstanceStatus(vm, getVMI())
+			Expect(vm.Status.IPAddresses).To(BeEmpty())
+			Expect(vm.Status.NodeName).To(BeEmpty())
+			Expect(vm.Status.GuestAgentConnected).To(BeFalse())
+			Expect(vm.Status.InstanceStatusLastUpdateTime).ToNot(BeNil())
+		},
+			Entry("when the VMI is gone", func() *v1.VirtualMachineInstance { return nil }),
+			Entry("when the VMI is final", func() *v1.VirtualMachineInstance {
+				vmi.Status.Phase = v1.Succeeded
+				return vmi
+			}),
+		)
+
+		It("should not set a timestamp for a VM which never ran", func() {
+			syncInstanceStatus(vm, nil)
+			Expect(vm.Status.InstanceStatusLastUpdateTime).To(BeNil())
+		})
+	})
+
 	Context("Live updates", func() {
 		createPVCVol := func(volName, claimName string, hotpluggable bool) v1.Volume {
 			return v1.Volume{
@@ -7371,6 +7783,7 @@ var _ = Describe("VirtualMachine", func() {
 			kvInformer, _ := testutils.NewFakeInformerFor(&v1.KubeVirt{})
 			namespaceInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Namespace{})
 			crInformer, _ := testutils.NewFakeInformerWithIndexersFor(&appsv1.ControllerRevision{}, cache.Indexers{})
+			vmSnapshotInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshot{})
 
 			config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
 			testController, _ = NewController(
@@ -7382,6 +7795,7 @@ var _ = Describe("VirtualMachine", func() {
 				namespaceInformer,
 				pvcInformer,
 				crInformer,
+				vmSnapshotInformer,
 				record.NewFakeRecorder(100),
 				virtClient,
 				config,
@@ -7390,6 +7804,8 @@ var _ = Describe("VirtualMachine", func() {
 				nil,
 				nil,
 				nil,
+				nil,
+				nil,
 			)
 		})
 
@@ -7540,3 +7956,12 @@ type testSynchronizer struct {
 func (t testSynchronizer) Sync(vm *v1.VirtualMachine, _ *v1.VirtualMachineInstance) (*v1.VirtualMachine, error) {
 	return vm, t.err
 }
+
+type staticStartGate struct {
+	allowed bool
+	reason  string
+}
+
+func (g staticStartGate) AllowStart(_ *v1.VirtualMachine) (bool, string) {
+	return g.allowed, g.reason
+}
@@ -0,0 +1,109 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vmi
+
+import (
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+// syncBootTimes records when the VMI reached the stages of its start. Each time is recorded once.
+// pod is the virt-launcher pod the VMI is started in, it must be nil once the VMI runs since later pods belong to migrations.
+func syncBootTimes(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) {
+	if vmi.IsMigrationTarget() {
+		return
+	}
+
+	bootTimes := &virtv1.VirtualMachineInstanceBootTimes{}
+	if vmi.Status.BootTimes != nil {
+		bootTimes = vmi.Status.BootTimes.DeepCopy()
+	}
+
+	if pod != nil {
+		if bootTimes.PodCreationTime == nil && !pod.CreationTimestamp.IsZero() {
+			bootTimes.PodCreationTime = pod.CreationTimestamp.DeepCopy()
+		}
+		if bootTimes.PodScheduledTime == nil {
+			bootTimes.PodScheduledTime = podScheduledTime(pod)
+		}
+		if bootTimes.LauncherStartTime == nil {
+			bootTimes.LauncherStartTime = launcherStartTime(pod)
+		}
+	}
+
+	if bootTimes.DomainStartTime == nil {
+		for _, ts := range vmi.Status.PhaseTransitionTimestamps {
+			if ts.Phase == virtv1.Running && !ts.PhaseTransitionTimestamp.IsZero() {
+				bootTimes.DomainStartTime = ts.PhaseTransitionTimestamp.DeepCopy()
+				break
+			}
+		}
+	}
+
+	if bootTimes.GuestAgentConnectTime == nil {
+		bootTimes.GuestAgentConnectTime = guestAgentConnectTime(vmi)
+	}
+
+	if *bootTimes != (virtv1.VirtualMachineInstanceBootTimes{}) {
+		vmi.Status.BootTimes = bootTimes
+	}
+}
+
+func podScheduledTime(pod *k8sv1.Pod) *metav1.Time {
+	condition := controller.NewPodConditionManager().GetCondition(pod, k8sv1.PodScheduled)
+	if condition == nil || condition.Status != k8sv1.ConditionTrue || condition.LastTransitionTime.IsZero() {
+		return nil
+	}
+	return condition.LastTransitionTime.DeepCopy()
+}
+
+func launcherStartTime(pod *k8sv1.Pod) *metav1.Time {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != "compute" {
+			continue
+		}
+		if status.State.Running != nil && !status.State.Running.StartedAt.IsZero() {
+			return status.State.Running.StartedAt.DeepCopy()
+		}
+		if status.State.Terminated != nil && !status.State.Terminated.StartedAt.IsZero() {
+			return status.State.Terminated.StartedAt.DeepCopy()
+		}
+	}
+	return nil
+}
+
+func guestAgentConnectTime(vmi *virtv1.VirtualMachineInstance) *metav1.Time {
+	condition := controller.NewVirtualMachineInstanceConditionManager().GetCondition(vmi, virtv1.VirtualMachineInstanceAgentConnected)
+	if condition == nil || condition.Status != k8sv1.ConditionTrue {
+		return nil
+	}
+	// virt-handler only sets the probe time when the agent connects
+	if !condition.LastTransitionTime.IsZero() {
+		return condition.LastTransitionTime.DeepCopy()
+	}
+	if !condition.LastProbeTime.IsZero() {
+		return condition.LastProbeTime.DeepCopy()
+	}
+	return nil
+}
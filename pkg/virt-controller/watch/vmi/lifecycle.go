@@ -53,6 +53,7 @@ import (
 	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/errorhints"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
 	"kubevirt.io/kubevirt/pkg/util/migrations"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
@@ -60,6 +61,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/descheduler"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/topology"
 	"kubevirt.io/kubevirt/pkg/virtiofs"
+	vsockservice "kubevirt.io/kubevirt/pkg/vsock/service"
 )
 
 func (c *Controller) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod, dataVolumes []*cdiv1.DataVolume) (common.SyncError, *k8sv1.Pod) {
@@ -360,7 +362,7 @@ func (c *Controller) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8sv1
 				condition := virtv1.VirtualMachineInstanceCondition{
 					Type:    virtv1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled),
 					Reason:  k8sv1.PodReasonUnschedulable,
-					Message: syncErr.Error(),
+					Message: errorhints.Annotate(syncErr.Error()),
 					Status:  k8sv1.ConditionFalse,
 				}
 				if conditionManager.HasCondition(vmiCopy, condition.Type) {
@@ -440,6 +442,7 @@ func (c *Controller) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8sv1
 					if err := c.cidsMap.Allocate(vmiCopy); err != nil {
 						return err
 					}
+					vsockservice.AllocatePorts(vmiCopy)
 				}
 			} else if controller.IsPodDownOrGoingDown(pod) {
 				vmiCopy.Status.Phase = virtv1.Failed
@@ -508,6 +511,8 @@ func (c *Controller) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8sv1
 
 		c.checkEphemeralHotplugVolumes(vmiCopy)
 
+		c.addSourceTopologyHints(vmi, vmiCopy)
+
 	case vmi.IsScheduled():
 		if !vmiPodExists {
 			if vmiCopy.IsDecentralizedMigration() && vmiCopy.IsMigrationTarget() {
@@ -591,6 +596,12 @@ func (c *Controller) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8sv1
 	conditionManager.CheckFailure(vmiCopy, syncErr, reason)
 	controller.SetVMIPhaseTransitionTimestamp(&vmi.Status, &vmiCopy.Status)
 
+	var bootPod *k8sv1.Pod
+	if vmiPodExists && !vmi.IsRunning() && !vmi.IsFinal() {
+		bootPod = pod
+	}
+	syncBootTimes(vmiCopy, bootPod)
+
 	// If we detect a change on the vmi we update the vmi
 	vmiChanged := !equality.Semantic.DeepEqual(vmi.Status, vmiCopy.Status) || !equality.Semantic.DeepEqual(vmi.Finalizers, vmiCopy.Finalizers) || !equality.Semantic.DeepEqual(vmi.Annotations, vmiCopy.Annotations) || !equality.Semantic.DeepEqual(vmi.Labels, vmiCopy.Labels)
 	if vmiChanged {
@@ -617,6 +628,18 @@ func (c *Controller) addTopologyHints(vmi *virtv1.VirtualMachineInstance, vmiCop
 	return nil
 }
 
+func (c *Controller) addSourceTopologyHints(vmi *virtv1.VirtualMachineInstance, vmiCopy *virtv1.VirtualMachineInstance) {
+	topologyHints, err := c.topologyHinter.TopologyHintsFromSourceNode(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("Failed to determine the TSC frequency of the node the VMI is running on")
+		return
+	}
+	if topologyHints != nil {
+		log.Log.Object(vmi).Infof("Pinning the TSC frequency of the VMI to %d Hz, the TSC frequency of node %s", *topologyHints.TSCFrequency, vmi.Status.NodeName)
+		vmiCopy.Status.TopologyHints = topologyHints
+	}
+}
+
 // prepareVMIPatch generates a patch set for updating the VMI status.
 func prepareVMIPatch(oldVMI, newVMI *virtv1.VirtualMachineInstance) *patch.PatchSet {
 	patchSet := patch.New()
@@ -671,6 +694,18 @@ func prepareVMIPatch(oldVMI, newVMI *virtv1.VirtualMachineInstance) *patch.Patch
 		}
 	}
 
+	if !equality.Semantic.DeepEqual(newVMI.Status.TopologyHints, oldVMI.Status.TopologyHints) {
+		if oldVMI.Status.TopologyHints == nil {
+			patchSet.AddOption(patch.WithAdd("/status/topologyHints", newVMI.Status.TopologyHints))
+		} else {
+			patchSet.AddOption(
+				patch.WithTest("/status/topologyHints", oldVMI.Status.TopologyHints),
+				patch.WithReplace("/status/topologyHints", newVMI.Status.TopologyHints),
+			)
+		}
+		log.Log.V(3).Object(oldVMI).Infof("Patching VMI topology hints")
+	}
+
 	if !equality.Semantic.DeepEqual(oldVMI.Labels, newVMI.Labels) {
 		if oldVMI.Labels == nil {
 			patchSet.AddOption(patch.WithAdd("/metadata/labels", newVMI.Labels))
@@ -956,7 +991,7 @@ func (c *Controller) deleteAllMatchingPods(vmi *virtv1.VirtualMachineInstance) e
 		if pod.DeletionTimestamp != nil && !isPodFinal(pod) || !v1.IsControlledBy(pod, vmi) {
 			continue
 		}
-		if err = c.deletePod(vmiKey, pod, v1.DeleteOptions{}); err != nil {
+		if err = c.deletePod(vmiKey, pod, podDeleteOptions(vmi, pod)); err != nil {
 			c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, controller.FailedDeletePodReason, "Failed to delete virtual machine pod %s", pod.Name)
 			return err
 		}
@@ -965,6 +1000,17 @@ func (c *Controller) deleteAllMatchingPods(vmi *virtv1.VirtualMachineInstance) e
 	return nil
 }
 
+// podDeleteOptions propagates a grace period which was shortened after the pod
+// was created, e.g. by a stop request with a grace period, to the termination
+// of the pod. Otherwise the pod would be given its original grace period.
+func podDeleteOptions(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) v1.DeleteOptions {
+	gracePeriod := services.LauncherTerminationGracePeriodSeconds(vmi)
+	if pod.Spec.TerminationGracePeriodSeconds == nil || gracePeriod >= *pod.Spec.TerminationGracePeriodSeconds {
+		return v1.DeleteOptions{}
+	}
+	return v1.DeleteOptions{GracePeriodSeconds: &gracePeriod}
+}
+
 func isPodFinal(pod *k8sv1.Pod) bool {
 	return pod.Status.Phase == k8sv1.PodSucceeded || pod.Status.Phase == k8sv1.PodFailed
 }
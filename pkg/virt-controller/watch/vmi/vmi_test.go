@@ -1113,6 +1113,35 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			expectPodDoesNotExist(finalizedPod.Namespace, finalizedPod.Name)
 		})
 
+		DescribeTable("on VMI deletion should delete the pod with", func(vmiGracePeriod, podGracePeriod *int64, expectedGracePeriod *int64) {
+			vmi := newPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = virtv1.Running
+			vmi.DeletionTimestamp = pointer.P(metav1.Now())
+			vmi.Spec.TerminationGracePeriodSeconds = vmiGracePeriod
+			pod := newPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Spec.TerminationGracePeriodSeconds = podGracePeriod
+
+			var deleteOptions metav1.DeleteOptions
+			kubeClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				deleteOptions = action.(testing.DeleteAction).GetDeleteOptions()
+				return false, nil, nil
+			})
+
+			addVirtualMachine(vmi)
+			addPod(pod)
+			addActivePods(vmi, pod.UID, "")
+
+			sanityExecute()
+
+			testutils.ExpectEvent(recorder, kvcontroller.SuccessfulDeletePodReason)
+			expectPodDoesNotExist(pod.Namespace, pod.Name)
+			Expect(deleteOptions.GracePeriodSeconds).To(Equal(expectedGracePeriod))
+		},
+			Entry("the grace period of the pod if the grace period of the VMI was not shortened", pointer.P(int64(30)), pointer.P(int64(60)), nil),
+			Entry("the shortened grace period of the VMI", pointer.P(int64(0)), pointer.P(int64(60)), pointer.P(int64(30))),
+			Entry("the grace period of the pod if it has none set", pointer.P(int64(0)), nil, nil),
+		)
+
 		It("should not try to delete a pod again, which is already marked for deletion and go to failed state, when in scheduling state", func() {
 			vmi := newPendingVirtualMachine("testvmi")
 			setReadyCondition(vmi, k8sv1.ConditionFalse, virtv1.GuestNotRunningReason)
@@ -4294,6 +4323,29 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			})
 		})
 
+		Context("on a running VMI", func() {
+			It("should pin the TSC frequency to the one of the source node if it is missing", func() {
+				vmi := getVmiWithReenlightenment()
+				setReadyCondition(vmi, k8sv1.ConditionTrue, "")
+				vmi.Status.Phase = virtv1.Running
+				vmi.Status.NodeName = "node01"
+				pod := newPodForVirtualMachine(vmi, k8sv1.PodRunning)
+				pod.Spec.NodeName = vmi.Status.NodeName
+
+				mockHinter := topology.NewMockHinter(gomock.NewController(GinkgoT()))
+				mockHinter.EXPECT().TopologyHintsFromSourceNode(gomock.Any()).Return(&virtv1.TopologyHints{TSCFrequency: pointer.P(int64(2400000000))}, nil)
+				controller.topologyHinter = mockHinter
+
+				addVirtualMachine(vmi)
+				addPod(pod)
+				sanityExecute()
+				updatedVmi, err := virtClientset.KubevirtV1().VirtualMachineInstances(vmi.Namespace).Get(context.Background(), vmi.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedVmi.Status.TopologyHints).ToNot(BeNil())
+				Expect(updatedVmi.Status.TopologyHints.TSCFrequency).To(HaveValue(Equal(int64(2400000000))))
+			})
+		})
+
 		Context("decentralized live migration", func() {
 			It("should set the topology hints when the VMI is created", func() {
 				vmi := getVmiWithReenlightenment()
@@ -4326,6 +4378,23 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			Expect(updatedVmi.Status.VSOCKCID).NotTo(BeNil())
 		})
 
+		It("should allocate the ports of the vsock services when VirtualMachineInstance is scheduled", func() {
+			vmi := newPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionFalse, virtv1.GuestNotRunningReason)
+			vmi.Status.Phase = virtv1.Scheduling
+			vmi.Spec.Domain.Devices.AutoattachVSOCK = pointer.P(true)
+			vmi.Spec.Domain.Devices.VSOCKServices = []virtv1.VSOCKService{{Name: "logs"}}
+			pod := newPodForVirtualMachine(vmi, k8sv1.PodRunning)
+
+			addVirtualMachine(vmi)
+			addPod(pod)
+			sanityExecute()
+
+			updatedVmi, err := virtClientset.KubevirtV1().VirtualMachineInstances(vmi.Namespace).Get(context.Background(), vmi.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updatedVmi.Status.VSOCKServices).To(ConsistOf(virtv1.VSOCKServiceStatus{Name: "logs", Port: 10000}))
+		})
+
 		It("should recycle the CID when the pods are deleted", func() {
 			alc := &fakeAllocator{}
 			controller.cidsMap = alc
@@ -4501,6 +4570,107 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		)
 	})
 
+	Context("Boot times", func() {
+		var (
+			podCreated   = metav1.NewTime(time.Unix(100, 0))
+			podScheduled = metav1.NewTime(time.Unix(110, 0))
+			podStarted   = metav1.NewTime(time.Unix(120, 0))
+			vmiRunning   = metav1.NewTime(time.Unix(130, 0))
+			agentConnect = metav1.NewTime(time.Unix(140, 0))
+		)
+
+		newBootPod := func(vmi *virtv1.VirtualMachineInstance) *k8sv1.Pod {
+			pod := newPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.CreationTimestamp = podCreated
+			pod.Status.Conditions = append(pod.Status.Conditions, k8sv1.PodCondition{
+				Type:               k8sv1.PodScheduled,
+				Status:             k8sv1.ConditionTrue,
+				LastTransitionTime: podScheduled,
+			})
+			pod.Status.ContainerStatuses[0].State.Running.StartedAt = podStarted
+			return pod
+		}
+
+		It("should record the times of the virt-launcher pod", func() {
+			vmi := newPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = virtv1.Scheduled
+
+			syncBootTimes(vmi, newBootPod(vmi))
+
+			Expect(vmi.Status.BootTimes).To(Equal(&virtv1.VirtualMachineInstanceBootTimes{
+				PodCreationTime:   &podCreated,
+				PodScheduledTime:  &podScheduled,
+				LauncherStartTime: &podStarted,
+			}))
+		})
+
+		It("should record the domain start and the guest agent connection", func() {
+			vmi := newPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = virtv1.Running
+			vmi.Status.PhaseTransitionTimestamps = []virtv1.VirtualMachineInstancePhaseTransitionTimestamp{
+				{Phase: virtv1.Scheduled, PhaseTransitionTimestamp: podStarted},
+				{Phase: virtv1.Running, PhaseTransitionTimestamp: vmiRunning},
+			}
+			vmi.Status.Conditions = append(vmi.Status.Conditions, virtv1.VirtualMachineInstanceCondition{
+				Type:          virtv1.VirtualMachineInstanceAgentConnected,
+				Status:        k8sv1.ConditionTrue,
+				LastProbeTime: agentConnect,
+			})
+
+			syncBootTimes(vmi, nil)
+
+			Expect(vmi.Status.BootTimes).To(Equal(&virtv1.VirtualMachineInstanceBootTimes{
+				DomainStartTime:       &vmiRunning,
+				GuestAgentConnectTime: &agentConnect,
+			}))
+		})
+
+		It("should not overwrite recorded times", func() {
+			vmi := newPendingVirtualMachine("testvmi")
+			earlier := metav1.NewTime(time.Unix(50, 0))
+			vmi.Status.BootTimes = &virtv1.VirtualMachineInstanceBootTimes{PodCreationTime: &earlier}
+
+			syncBootTimes(vmi, newBootPod(vmi))
+
+			Expect(vmi.Status.BootTimes.PodCreationTime).To(Equal(&earlier))
+			Expect(vmi.Status.BootTimes.PodScheduledTime).To(Equal(&podScheduled))
+		})
+
+		It("should not record anything for migration targets", func() {
+			vmi := newPendingVirtualMachine("testvmi")
+			vmi.Annotations[virtv1.CreateMigrationTarget] = "true"
+
+			syncBootTimes(vmi, newBootPod(vmi))
+
+			Expect(vmi.Status.BootTimes).To(BeNil())
+		})
+
+		It("should leave the status empty when nothing happened yet", func() {
+			vmi := newPendingVirtualMachine("testvmi")
+
+			syncBootTimes(vmi, nil)
+
+			Expect(vmi.Status.BootTimes).To(BeNil())
+		})
+
+		It("should record the boot times when the vmi goes to scheduled state", func() {
+			vmi := newPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = virtv1.Scheduling
+			pod := newBootPod(vmi)
+
+			addVirtualMachine(vmi)
+			addPod(pod)
+
+			sanityExecute()
+
+			updatedVMI, err := virtClientset.KubevirtV1().VirtualMachineInstances(vmi.Namespace).Get(context.Background(), vmi.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updatedVMI.Status.BootTimes).ToNot(BeNil())
+			Expect(updatedVMI.Status.BootTimes.PodCreationTime.Equal(&podCreated)).To(BeTrue())
+			Expect(updatedVMI.Status.BootTimes.LauncherStartTime.Equal(&podStarted)).To(BeTrue())
+		})
+	})
+
 	Context("When a migration exists", func() {
 		It("should delay pod creation if the migration is running", func() {
 			vmi := newPendingVirtualMachine("testvmi")
@@ -0,0 +1,344 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package volumeprotection
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/controller"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	// VolumeDeletionBlockedReason is added in an event to a VirtualMachineInstance whose
+	// volume is being deleted while it is in use.
+	VolumeDeletionBlockedReason = "VolumeDeletionBlocked"
+
+	finalizersPath  = "/metadata/finalizers"
+	annotationsPath = "/metadata/annotations"
+)
+
+// Controller protects PersistentVolumeClaims and DataVolumes from being deleted while
+// they are used by a VirtualMachineInstance. Similar to the Kubernetes pvc-protection,
+// it keeps a finalizer on the volumes of active VirtualMachineInstances, but it also
+// reports which VirtualMachines block the deletion of a volume.
+//
+// The queue is keyed by the namespaced name of the claim, a DataVolume and the
+// PersistentVolumeClaim it owns share the same name and are reconciled together.
+type Controller struct {
+	clientset       kubecli.KubevirtClient
+	queue           workqueue.TypedRateLimitingInterface[string]
+	vmiIndexer      cache.Indexer
+	pvcStore        cache.Store
+	dataVolumeStore cache.Store
+	recorder        record.EventRecorder
+	clusterConfig   *virtconfig.ClusterConfig
+	hasSynced       func() bool
+}
+
+func NewController(
+	vmiInformer cache.SharedIndexInformer,
+	pvcInformer cache.SharedIndexInformer,
+	dataVolumeInformer cache.SharedIndexInformer,
+	recorder record.EventRecorder,
+	clientset kubecli.KubevirtClient,
+	clusterConfig *virtconfig.ClusterConfig,
+) (*Controller, error) {
+	c := &Controller{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig[string](
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "virt-controller-volume-protection"},
+		),
+		vmiIndexer:      vmiInformer.GetIndexer(),
+		pvcStore:        pvcInformer.GetStore(),
+		dataVolumeStore: dataVolumeInformer.GetStore(),
+		recorder:        recorder,
+		clientset:       clientset,
+		clusterConfig:   clusterConfig,
+		hasSynced: func() bool {
+			return vmiInformer.HasSynced() && pvcInformer.HasSynced() && dataVolumeInformer.HasSynced()
+		},
+	}
+
+	_, err := vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueVMIVolumes,
+		UpdateFunc: func(old, curr interface{}) {
+			// Enqueue the volumes of both versions to catch unplugged volumes
+			c.enqueueVMIVolumes(old)
+			c.enqueueVMIVolumes(curr)
+		},
+		DeleteFunc: c.enqueueVMIVolumes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = pvcInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueVolume,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueVolume(curr) },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = dataVolumeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueVolume,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueVolume(curr) },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Controller) enqueueVMIVolumes(obj interface{}) {
+	vmi, ok := obj.(*virtv1.VirtualMachineInstance)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		if vmi, ok = tombstone.Obj.(*virtv1.VirtualMachineInstance); !ok {
+			return
+		}
+	}
+	for _, name := range claimNames(vmi) {
+		c.queue.Add(controller.NamespacedKey(vmi.Namespace, name))
+	}
+}
+
+func (c *Controller) enqueueVolume(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to extract key from volume.")
+		return
+	}
+	c.queue.Add(key)
+}
+
+// Run runs the volume protection controller.
+func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.queue.ShutDown()
+	log.Log.Info("Starting volume protection controller.")
+
+	cache.WaitForCacheSync(stopCh, c.hasSynced)
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping volume protection controller.")
+}
+
+func (c *Controller) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *Controller) Execute() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.execute(key); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing volume protection for %v", key)
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+func (c *Controller) execute(key string) error {
+	var users []*virtv1.VirtualMachineInstance
+	if c.clusterConfig.VolumeProtectionEnabled() {
+		var err error
+		if users, err = c.activeUsers(key); err != nil {
+			return err
+		}
+	}
+
+	obj, exists, err := c.pvcStore.GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		pvc := obj.(*k8sv1.PersistentVolumeClaim)
+		err = c.sync(pvc, "PersistentVolumeClaim", users, func(patchBytes []byte) error {
+			_, err := c.clientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Patch(context.Background(), pvc.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	obj, exists, err = c.dataVolumeStore.GetByKey(key)
+	if err != nil || !exists {
+		return err
+	}
+	dv := obj.(*cdiv1.DataVolume)
+	return c.sync(dv, "DataVolume", users, func(patchBytes []byte) error {
+		_, err := c.clientset.CdiClient().CdiV1beta1().DataVolumes(dv.Namespace).Patch(context.Background(), dv.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+		return err
+	})
+}
+
+// sync keeps the finalizer on the volume as long as it has users and reports the users
+// once the deletion of the volume is blocked.
+func (c *Controller) sync(volume metav1.Object, kind string, users []*virtv1.VirtualMachineInstance, patchVolume func([]byte) error) error {
+	protected := len(users) > 0
+	hasFinalizer := controller.HasFinalizer(volume, virtv1.VolumeProtectionFinalizer)
+
+	inUseBy := ""
+	if protected && volume.GetDeletionTimestamp() != nil {
+		inUseBy = describeUsers(users)
+	}
+	currentInUseBy, annotated := volume.GetAnnotations()[virtv1.VolumeInUseByAnnotation]
+
+	patchSet := patch.New()
+	switch {
+	case protected && !hasFinalizer:
+		if volume.GetDeletionTimestamp() != nil {
+			// Finalizers can not be added to objects which are already being deleted
+			break
+		}
+		patchSet.AddOption(
+			patch.WithTest(finalizersPath, volume.GetFinalizers()),
+			patch.WithReplace(finalizersPath, append(volume.GetFinalizers(), virtv1.VolumeProtectionFinalizer)),
+		)
+	case !protected && hasFinalizer:
+		finalizers := []string{}
+		for _, f := range volume.GetFinalizers() {
+			if f != virtv1.VolumeProtectionFinalizer {
+				finalizers = append(finalizers, f)
+			}
+		}
+		patchSet.AddOption(
+			patch.WithTest(finalizersPath, volume.GetFinalizers()),
+			patch.WithReplace(finalizersPath, finalizers),
+		)
+	}
+
+	annotationPath := fmt.Sprintf("%s/%s", annotationsPath, patch.EscapeJSONPointer(virtv1.VolumeInUseByAnnotation))
+	switch {
+	case inUseBy != "" && volume.GetAnnotations() == nil:
+		patchSet.AddOption(patch.WithAdd(annotationsPath, map[string]string{virtv1.VolumeInUseByAnnotation: inUseBy}))
+	case inUseBy != "" && inUseBy != currentInUseBy:
+		patchSet.AddOption(patch.WithAdd(annotationPath, inUseBy))
+	case inUseBy == "" && annotated:
+		patchSet.AddOption(
+			patch.WithTest(annotationPath, currentInUseBy),
+			patch.WithRemove(annotationPath),
+		)
+	}
+
+	if patchSet.IsEmpty() {
+		return nil
+	}
+	patchBytes, err := patchSet.GeneratePayload()
+	if err != nil {
+		return err
+	}
+	if err := patchVolume(patchBytes); err != nil {
+		return fmt.Errorf("failed to update the volume protection of %s %s/%s: %v", kind, volume.GetNamespace(), volume.GetName(), err)
+	}
+
+	if inUseBy != "" && !annotated {
+		log.Log.Infof("Deletion of %s %s/%s is blocked, it is in use by %s", kind, volume.GetNamespace(), volume.GetName(), inUseBy)
+		for _, vmi := range users {
+			c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, VolumeDeletionBlockedReason,
+				"Deletion of %s %s is blocked while it is in use", kind, volume.GetName())
+		}
+	}
+	return nil
+}
+
+// activeUsers returns the VirtualMachineInstances which use the claim with the given key,
+// either directly or through a DataVolume, and are not in a final phase.
+func (c *Controller) activeUsers(key string) ([]*virtv1.VirtualMachineInstance, error) {
+	seen := map[string]bool{}
+	var users []*virtv1.VirtualMachineInstance
+	for _, index := range []string{"pvc", "dv"} {
+		objs, err := c.vmiIndexer.ByIndex(index, key)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			vmi := obj.(*virtv1.VirtualMachineInstance)
+			if vmi.IsFinal() || seen[vmi.Name] {
+				continue
+			}
+			seen[vmi.Name] = true
+			users = append(users, vmi)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users, nil
+}
+
+// describeUsers lists the users of a volume, VirtualMachineInstances owned by a
+// VirtualMachine are reported as the VirtualMachine.
+func describeUsers(users []*virtv1.VirtualMachineInstance) string {
+	var descriptions []string
+	for _, vmi := range users {
+		if owner := metav1.GetControllerOf(vmi); owner != nil && owner.Kind == virtv1.VirtualMachineGroupVersionKind.Kind {
+			descriptions = append(descriptions, fmt.Sprintf("%s/%s", owner.Kind, owner.Name))
+			continue
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s/%s", virtv1.VirtualMachineInstanceGroupVersionKind.Kind, vmi.Name))
+	}
+	return strings.Join(descriptions, ",")
+}
+
+func claimNames(vmi *virtv1.VirtualMachineInstance) []string {
+	var names []string
+	for _, volume := range vmi.Spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			names = append(names, volume.PersistentVolumeClaim.ClaimName)
+		case volume.DataVolume != nil:
+			names = append(names, volume.DataVolume.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,222 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package volumeprotection
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/api/core/v1"
+	cdifake "kubevirt.io/client-go/containerizeddataimporter/fake"
+	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	libvmistatus "kubevirt.io/kubevirt/pkg/libvmi/status"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+var _ = Describe("Volume protection", func() {
+	const (
+		namespace = "default"
+		claimName = "disk"
+	)
+
+	var (
+		ctrl      *Controller
+		recorder  *record.FakeRecorder
+		k8sClient *k8sfake.Clientset
+		cdiClient *cdifake.Clientset
+	)
+
+	newController := func(featureGates ...string) {
+		mockCtrl := gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(mockCtrl)
+		k8sClient = k8sfake.NewSimpleClientset()
+		cdiClient = cdifake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+		virtClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+
+		vmiInformer, _ := testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachineInstance{}, controller.GetVMIInformerIndexers())
+		pvcInformer, _ := testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+		dataVolumeInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{FeatureGates: featureGates},
+		})
+		recorder = record.NewFakeRecorder(10)
+		recorder.IncludeObject = true
+
+		var err error
+		ctrl, err = NewController(vmiInformer, pvcInformer, dataVolumeInformer, recorder, virtClient, clusterConfig)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	addVMI := func(vmi *v1.VirtualMachineInstance) {
+		Expect(ctrl.vmiIndexer.Add(vmi)).To(Succeed())
+	}
+
+	addPVC := func(pvc *k8sv1.PersistentVolumeClaim) {
+		_, err := k8sClient.CoreV1().PersistentVolumeClaims(namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctrl.pvcStore.Add(pvc)).To(Succeed())
+	}
+
+	getPVC := func() *k8sv1.PersistentVolumeClaim {
+		pvc, err := k8sClient.CoreV1().PersistentVolumeClaims(namespace).Get(context.Background(), claimName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return pvc
+	}
+
+	newPVC := func(finalizers ...string) *k8sv1.PersistentVolumeClaim {
+		return &k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: namespace, Finalizers: finalizers},
+		}
+	}
+
+	newRunningVMI := func(name string, opts ...libvmi.Option) *v1.VirtualMachineInstance {
+		opts = append(opts,
+			libvmi.WithName(name),
+			libvmi.WithNamespace(namespace),
+			libvmistatus.WithStatus(libvmistatus.New(libvmistatus.WithPhase(v1.Running))),
+		)
+		return libvmi.New(opts...)
+	}
+
+	execute := func() {
+		Expect(ctrl.execute(controller.NamespacedKey(namespace, claimName))).To(Succeed())
+	}
+
+	Context("with the VolumeProtection feature gate", func() {
+		BeforeEach(func() {
+			newController(featuregate.VolumeProtection)
+		})
+
+		It("should add the finalizer to a PVC used by a running VMI", func() {
+			addPVC(newPVC("kubernetes.io/pvc-protection"))
+			addVMI(newRunningVMI("testvmi", libvmi.WithPersistentVolumeClaim("disk0", claimName)))
+
+			execute()
+
+			Expect(getPVC().Finalizers).To(ConsistOf("kubernetes.io/pvc-protection", v1.VolumeProtectionFinalizer))
+		})
+
+		It("should add the finalizer to a DataVolume and its PVC used by a running VMI", func() {
+			addPVC(newPVC())
+			dv := &cdiv1.DataVolume{ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: namespace}}
+			_, err := cdiClient.CdiV1beta1().DataVolumes(namespace).Create(context.Background(), dv, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ctrl.dataVolumeStore.Add(dv)).To(Succeed())
+			addVMI(newRunningVMI("testvmi", libvmi.WithDataVolume("disk0", claimName)))
+
+			execute()
+
+			Expect(getPVC().Finalizers).To(ConsistOf(v1.VolumeProtectionFinalizer))
+			dv, err = cdiClient.CdiV1beta1().DataVolumes(namespace).Get(context.Background(), claimName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Finalizers).To(ConsistOf(v1.VolumeProtectionFinalizer))
+		})
+
+		It("should not protect a PVC used by a VMI in a final phase", func() {
+			addPVC(newPVC())
+			vmi := newRunningVMI("testvmi", libvmi.WithPersistentVolumeClaim("disk0", claimName))
+			vmi.Status.Phase = v1.Succeeded
+			addVMI(vmi)
+
+			execute()
+
+			Expect(getPVC().Finalizers).To(BeEmpty())
+			Expect(k8sClient.Actions()).To(HaveLen(2), "only the create and get actions are expected")
+		})
+
+		It("should remove the finalizer once the PVC is no longer used", func() {
+			addPVC(newPVC("kubernetes.io/pvc-protection", v1.VolumeProtectionFinalizer))
+
+			execute()
+
+			Expect(getPVC().Finalizers).To(ConsistOf("kubernetes.io/pvc-protection"))
+		})
+
+		It("should report the VM blocking the deletion of a PVC", func() {
+			pvc := newPVC(v1.VolumeProtectionFinalizer)
+			pvc.DeletionTimestamp = &metav1.Time{}
+			addPVC(pvc)
+			vmi := newRunningVMI("testvm", libvmi.WithPersistentVolumeClaim("disk0", claimName))
+			vmi.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: v1.VirtualMachineGroupVersionKind.GroupVersion().String(),
+				Kind:       v1.VirtualMachineGroupVersionKind.Kind,
+				Name:       "testvm",
+				Controller: pointer.P(true),
+			}}
+			addVMI(vmi)
+			addVMI(newRunningVMI("othervmi", libvmi.WithPersistentVolumeClaim("disk0", claimName)))
+
+			execute()
+
+			pvc = getPVC()
+			Expect(pvc.Finalizers).To(ConsistOf(v1.VolumeProtectionFinalizer))
+			Expect(pvc.Annotations).To(HaveKeyWithValue(v1.VolumeInUseByAnnotation, "VirtualMachineInstance/othervmi,VirtualMachine/testvm"))
+			testutils.ExpectEvents(recorder, VolumeDeletionBlockedReason, VolumeDeletionBlockedReason)
+		})
+
+		It("should release a PVC pending deletion once the VMI is gone", func() {
+			pvc := newPVC(v1.VolumeProtectionFinalizer)
+			pvc.DeletionTimestamp = &metav1.Time{}
+			pvc.Annotations = map[string]string{v1.VolumeInUseByAnnotation: "VirtualMachine/testvm"}
+			addPVC(pvc)
+
+			execute()
+
+			pvc = getPVC()
+			Expect(pvc.Finalizers).To(BeEmpty())
+			Expect(pvc.Annotations).ToNot(HaveKey(v1.VolumeInUseByAnnotation))
+		})
+	})
+
+	It("should remove the finalizer when the VolumeProtection feature gate is disabled", func() {
+		newController()
+		addPVC(newPVC(v1.VolumeProtectionFinalizer))
+		addVMI(newRunningVMI("testvmi", libvmi.WithPersistentVolumeClaim("disk0", claimName)))
+
+		execute()
+
+		Expect(getPVC().Finalizers).To(BeEmpty())
+	})
+
+	It("should enqueue the claims of a VMI", func() {
+		newController(featuregate.VolumeProtection)
+		ctrl.enqueueVMIVolumes(newRunningVMI("testvmi",
+			libvmi.WithPersistentVolumeClaim("disk0", "pvc"),
+			libvmi.WithDataVolume("disk1", "dv"),
+			libvmi.WithContainerDisk("disk2", "image"),
+		))
+
+		Expect(ctrl.queue.Len()).To(Equal(2))
+	})
+})
@@ -73,8 +73,19 @@ type Manager interface {
 
 	// Get list of threads attached to cgroup
 	GetCgroupThreads() ([]int, error)
+
+	// SetCpuShares sets the cpu shares of a child cgroup. The shares are
+	// converted to the cpu weight on cgroup v2.
+	SetCpuShares(subcgroup string, shares uint64) error
 }
 
+const (
+	// EmulatorCgroup holds the emulator threads of VMIs with thread CPU shares
+	EmulatorCgroup = "emulator"
+	// IOThreadsCgroup holds the IO threads of VMIs with thread CPU shares
+	IOThreadsCgroup = "iothreads"
+)
+
 // This is here so that mockgen would create a mock out of it. That way we would have a mocked runc manager.
 type runcManager interface {
 	runc_cgroups.Manager
@@ -82,12 +93,13 @@ type runcManager interface {
 
 // If a task is moved into a sub-cgroup, we want the manager to
 // reference the root cgroup, not the sub-cgroup.
-// Currently the only sub-cgroup create is named "housekeeping".
+// The sub-cgroups created are "housekeeping", "emulator" and "iothreads".
 
 func managerPath(taskPath string) string {
 	retPath := taskPath
 	s := strings.Split(taskPath, "/")
-	if s[len(s)-1] == "housekeeping" {
+	switch s[len(s)-1] {
+	case "housekeeping", EmulatorCgroup, IOThreadsCgroup:
 		fStr := "/" + strings.Join(s[1:len(s)-1], "/") + "/"
 		retPath = fStr
 	}
@@ -241,3 +241,14 @@ var _ = Describe("GetMiscCapacity", func() {
 		),
 	)
 })
+
+var _ = Describe("managerPath", func() {
+	DescribeTable("should reference the cgroup of the pod", func(taskPath, expectedPath string) {
+		Expect(managerPath(taskPath)).To(Equal(expectedPath))
+	},
+		Entry("for a task in the pod cgroup", "/kubepods/pod123/crio-456", "/kubepods/pod123/crio-456"),
+		Entry("for a task in the housekeeping cgroup", "/kubepods/pod123/crio-456/housekeeping", "/kubepods/pod123/crio-456/"),
+		Entry("for a task in the emulator cgroup", "/kubepods/pod123/crio-456/emulator", "/kubepods/pod123/crio-456/"),
+		Entry("for a task in the iothreads cgroup", "/kubepods/pod123/crio-456/iothreads", "/kubepods/pod123/crio-456/"),
+	)
+})
@@ -224,3 +224,7 @@ func (v *v1Manager) GetCgroupThreads() ([]int, error) {
 func (v *v1Manager) SetCpuSet(subcgroup string, cpulist []int) error {
 	return setCpuSetHelper(v, subcgroup, cpulist)
 }
+
+func (v *v1Manager) SetCpuShares(subcgroup string, shares uint64) error {
+	return setCpuControllerHelper(v, subcgroup, "cpu.shares", shares)
+}
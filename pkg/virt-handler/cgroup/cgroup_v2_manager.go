@@ -181,3 +181,7 @@ func (v *v2Manager) GetCgroupThreads() ([]int, error) {
 func (v *v2Manager) SetCpuSet(subcgroup string, cpulist []int) error {
 	return setCpuSetHelper(v, subcgroup, cpulist)
 }
+
+func (v *v2Manager) SetCpuShares(subcgroup string, shares uint64) error {
+	return setCpuControllerHelper(v, subcgroup, "cpu.weight", runc_cgroups.ConvertCPUSharesToCgroupV2Value(shares))
+}
@@ -156,6 +156,20 @@ func (mr *MockManagerMockRecorder) SetCpuSet(subcgroup, cpulist any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCpuSet", reflect.TypeOf((*MockManager)(nil).SetCpuSet), subcgroup, cpulist)
 }
 
+// SetCpuShares mocks base method.
+func (m *MockManager) SetCpuShares(subcgroup string, shares uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCpuShares", subcgroup, shares)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCpuShares indicates an expected call of SetCpuShares.
+func (mr *MockManagerMockRecorder) SetCpuShares(subcgroup, shares any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCpuShares", reflect.TypeOf((*MockManager)(nil).SetCpuShares), subcgroup, shares)
+}
+
 // MockruncManager is a mock of runcManager interface.
 type MockruncManager struct {
 	ctrl     *gomock.Controller
@@ -384,3 +384,18 @@ func setCpuSetHelper(manager Manager, subCgroup string, cpusList []int) error {
 
 	return runc_cgroups.WriteFile(subSysPath, "cpuset.cpus", wVal)
 }
+
+// set "value" on the "fname" file of the cpu controller. Optionally on a
+// subcgroup of the pods control group (if subcgroup != nil).
+func setCpuControllerHelper(manager Manager, subCgroup string, fname string, value uint64) error {
+	subSysPath, err := manager.GetBasePathToHostSubsystem("cpu")
+	if err != nil {
+		return err
+	}
+
+	if subCgroup != "" {
+		subSysPath = filepath.Join(subSysPath, subCgroup)
+	}
+
+	return runc_cgroups.WriteFile(subSysPath, fname, strconv.FormatUint(value, 10))
+}
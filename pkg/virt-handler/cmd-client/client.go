@@ -81,11 +81,12 @@ type LauncherClient interface {
 	SyncVirtualMachine(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
 	PauseVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	UnpauseVirtualMachine(vmi *v1.VirtualMachineInstance) error
-	FreezeVirtualMachine(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32) error
+	FreezeVirtualMachine(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32, mountpoints []string) error
 	UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	SyncMigrationTarget(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
 	ResetVirtualMachine(vmi *v1.VirtualMachineInstance) error
-	SoftRebootVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	SoftRebootVirtualMachine(vmi *v1.VirtualMachineInstance, fallback v1.SoftRebootFallback) error
+	InjectNMIVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	SignalTargetPodCleanup(vmi *v1.VirtualMachineInstance) error
 	ShutdownVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	KillVirtualMachine(vmi *v1.VirtualMachineInstance) error
@@ -95,6 +96,7 @@ type LauncherClient interface {
 	HotplugHostDevices(vmi *v1.VirtualMachineInstance) error
 	DeleteDomain(vmi *v1.VirtualMachineInstance) error
 	GetDomain() (*api.Domain, bool, error)
+	GenerateDomain(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error)
 	GetDomainStats() (*stats.DomainStats, bool, error)
 	GetGuestInfo() (*v1.VirtualMachineInstanceGuestAgentInfo, error)
 	GetUsers() (v1.VirtualMachineInstanceGuestOSUserList, error)
@@ -109,6 +111,7 @@ type LauncherClient interface {
 	GetSEVInfo() (*v1.SEVPlatformInfo, error)
 	GetLaunchMeasurement(*v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error)
 	InjectLaunchSecret(*v1.VirtualMachineInstance, *v1.SEVSecretOptions) error
+	GetSEVSNPAttestationReport(*v1.VirtualMachineInstance, *v1.SEVSNPAttestationReportOptions) (*v1.SEVSNPAttestationReport, error)
 	SyncVirtualMachineMemory(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
 	GetDomainDirtyRateStats() (dirtyRateMbps int64, err error)
 	GetScreenshot(*v1.VirtualMachineInstance) (*cmdv1.ScreenshotResponse, error)
@@ -320,7 +323,7 @@ func (c *VirtLauncherClient) UnpauseVirtualMachine(vmi *v1.VirtualMachineInstanc
 	return c.genericSendVMICmd("Unpause", c.v1client.UnpauseVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
 
-func (c *VirtLauncherClient) FreezeVirtualMachine(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32) error {
+func (c *VirtLauncherClient) FreezeVirtualMachine(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32, mountpoints []string) error {
 	vmiJson, err := json.Marshal(vmi)
 	if err != nil {
 		return err
@@ -331,6 +334,7 @@ func (c *VirtLauncherClient) FreezeVirtualMachine(vmi *v1.VirtualMachineInstance
 			VmiJson: vmiJson,
 		},
 		UnfreezeTimeoutSeconds: unfreezeTimeoutSeconds,
+		Mountpoints:            mountpoints,
 	}
 
 	// Use extended timeout as Windows VSS can take up to 60 seconds
@@ -366,8 +370,12 @@ func (c *VirtLauncherClient) VirtualMachineMemoryDump(vmi *v1.VirtualMachineInst
 	return err
 }
 
-func (c *VirtLauncherClient) SoftRebootVirtualMachine(vmi *v1.VirtualMachineInstance) error {
-	return c.genericSendVMICmd("SoftReboot", c.v1client.SoftRebootVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
+func (c *VirtLauncherClient) SoftRebootVirtualMachine(vmi *v1.VirtualMachineInstance, fallback v1.SoftRebootFallback) error {
+	return c.genericSendVMICmd("SoftReboot", c.v1client.SoftRebootVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{SoftRebootFallback: string(fallback)})
+}
+
+func (c *VirtLauncherClient) InjectNMIVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("InjectNMI", c.v1client.InjectNMIVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
 
 func (c *VirtLauncherClient) ResetVirtualMachine(vmi *v1.VirtualMachineInstance) error {
@@ -467,6 +475,35 @@ func (c *VirtLauncherClient) GetDomain() (*api.Domain, bool, error) {
 	return domain, exists, nil
 }
 
+func (c *VirtLauncherClient) GenerateDomain(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error) {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &cmdv1.VMIRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Options: options,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+
+	domainResponse, err := c.v1client.GenerateDomain(ctx, request)
+	if err = handleError(err, "GenerateDomain", domainResponse.GetResponse()); err != nil {
+		return nil, err
+	}
+
+	spec := &api.DomainSpec{}
+	if err := json.Unmarshal([]byte(domainResponse.GetDomain()), spec); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling generated domain")
+		return nil, err
+	}
+	return spec, nil
+}
+
 func (c *VirtLauncherClient) GetDomainDirtyRateStats() (dirtyRateMbps int64, err error) {
 	request := &cmdv1.EmptyRequest{}
 	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
@@ -775,6 +812,42 @@ func (c *VirtLauncherClient) InjectLaunchSecret(vmi *v1.VirtualMachineInstance,
 	return handleError(err, "InjectLaunchSecret", response)
 }
 
+func (c *VirtLauncherClient) GetSEVSNPAttestationReport(vmi *v1.VirtualMachineInstance, options *v1.SEVSNPAttestationReportOptions) (*v1.SEVSNPAttestationReport, error) {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return nil, err
+	}
+
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &cmdv1.SEVSNPAttestationReportRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Options: optionsJson,
+	}
+
+	// The report is requested by a command executed in the guest
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	defer cancel()
+
+	attestationReportResponse, err := c.v1client.GetSEVSNPAttestationReport(ctx, request)
+	if err = handleError(err, "GetSEVSNPAttestationReport", attestationReportResponse.GetResponse()); err != nil {
+		return nil, err
+	}
+
+	attestationReport := &v1.SEVSNPAttestationReport{}
+	if err := json.Unmarshal(attestationReportResponse.GetAttestationReport(), attestationReport); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling attestation report response")
+		return nil, err
+	}
+
+	return attestationReport, nil
+}
+
 func (c *VirtLauncherClient) SyncVirtualMachineMemory(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error {
 	return c.genericSendVMICmd("SyncVirtualMachineMemory", c.v1client.SyncVirtualMachineMemory, vmi, options)
 }
@@ -116,17 +116,32 @@ func (mr *MockLauncherClientMockRecorder) FinalizeVirtualMachineMigration(vmi, o
 }
 
 // FreezeVirtualMachine mocks base method.
-func (m *MockLauncherClient) FreezeVirtualMachine(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32) error {
+func (m *MockLauncherClient) FreezeVirtualMachine(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32, mountpoints []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FreezeVirtualMachine", vmi, unfreezeTimeoutSeconds)
+	ret := m.ctrl.Call(m, "FreezeVirtualMachine", vmi, unfreezeTimeoutSeconds, mountpoints)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // FreezeVirtualMachine indicates an expected call of FreezeVirtualMachine.
-func (mr *MockLauncherClientMockRecorder) FreezeVirtualMachine(vmi, unfreezeTimeoutSeconds any) *gomock.Call {
+func (mr *MockLauncherClientMockRecorder) FreezeVirtualMachine(vmi, unfreezeTimeoutSeconds, mountpoints any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeVirtualMachine", reflect.TypeOf((*MockLauncherClient)(nil).FreezeVirtualMachine), vmi, unfreezeTimeoutSeconds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeVirtualMachine", reflect.TypeOf((*MockLauncherClient)(nil).FreezeVirtualMachine), vmi, unfreezeTimeoutSeconds, mountpoints)
+}
+
+// GenerateDomain mocks base method.
+func (m *MockLauncherClient) GenerateDomain(vmi *v1.VirtualMachineInstance, options *v10.VirtualMachineOptions) (*api.DomainSpec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateDomain", vmi, options)
+	ret0, _ := ret[0].(*api.DomainSpec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateDomain indicates an expected call of GenerateDomain.
+func (mr *MockLauncherClientMockRecorder) GenerateDomain(vmi, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateDomain", reflect.TypeOf((*MockLauncherClient)(nil).GenerateDomain), vmi, options)
 }
 
 // GetDomain mocks base method.
@@ -251,6 +266,21 @@ func (mr *MockLauncherClientMockRecorder) GetSEVInfo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSEVInfo", reflect.TypeOf((*MockLauncherClient)(nil).GetSEVInfo))
 }
 
+// GetSEVSNPAttestationReport mocks base method.
+func (m *MockLauncherClient) GetSEVSNPAttestationReport(arg0 *v1.VirtualMachineInstance, arg1 *v1.SEVSNPAttestationReportOptions) (*v1.SEVSNPAttestationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSEVSNPAttestationReport", arg0, arg1)
+	ret0, _ := ret[0].(*v1.SEVSNPAttestationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSEVSNPAttestationReport indicates an expected call of GetSEVSNPAttestationReport.
+func (mr *MockLauncherClientMockRecorder) GetSEVSNPAttestationReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSEVSNPAttestationReport", reflect.TypeOf((*MockLauncherClient)(nil).GetSEVSNPAttestationReport), arg0, arg1)
+}
+
 // GetScreenshot mocks base method.
 func (m *MockLauncherClient) GetScreenshot(arg0 *v1.VirtualMachineInstance) (*v10.ScreenshotResponse, error) {
 	m.ctrl.T.Helper()
@@ -323,6 +353,20 @@ func (mr *MockLauncherClientMockRecorder) InjectLaunchSecret(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectLaunchSecret", reflect.TypeOf((*MockLauncherClient)(nil).InjectLaunchSecret), arg0, arg1)
 }
 
+// InjectNMIVirtualMachine mocks base method.
+func (m *MockLauncherClient) InjectNMIVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectNMIVirtualMachine", vmi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectNMIVirtualMachine indicates an expected call of InjectNMIVirtualMachine.
+func (mr *MockLauncherClientMockRecorder) InjectNMIVirtualMachine(vmi any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectNMIVirtualMachine", reflect.TypeOf((*MockLauncherClient)(nil).InjectNMIVirtualMachine), vmi)
+}
+
 // KillVirtualMachine mocks base method.
 func (m *MockLauncherClient) KillVirtualMachine(vmi *v1.VirtualMachineInstance) error {
 	m.ctrl.T.Helper()
@@ -437,17 +481,17 @@ func (mr *MockLauncherClientMockRecorder) SignalTargetPodCleanup(vmi any) *gomoc
 }
 
 // SoftRebootVirtualMachine mocks base method.
-func (m *MockLauncherClient) SoftRebootVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+func (m *MockLauncherClient) SoftRebootVirtualMachine(vmi *v1.VirtualMachineInstance, fallback v1.SoftRebootFallback) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SoftRebootVirtualMachine", vmi)
+	ret := m.ctrl.Call(m, "SoftRebootVirtualMachine", vmi, fallback)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SoftRebootVirtualMachine indicates an expected call of SoftRebootVirtualMachine.
-func (mr *MockLauncherClientMockRecorder) SoftRebootVirtualMachine(vmi any) *gomock.Call {
+func (mr *MockLauncherClientMockRecorder) SoftRebootVirtualMachine(vmi, fallback any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftRebootVirtualMachine", reflect.TypeOf((*MockLauncherClient)(nil).SoftRebootVirtualMachine), vmi)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftRebootVirtualMachine", reflect.TypeOf((*MockLauncherClient)(nil).SoftRebootVirtualMachine), vmi, fallback)
 }
 
 // SyncMigrationTarget mocks base method.
@@ -37,7 +37,9 @@ import (
 
 	"kubevirt.io/client-go/log"
 
+	"kubevirt.io/kubevirt/pkg/storage/localscratch"
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
+	"kubevirt.io/kubevirt/pkg/util"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-handler/selinux"
 )
@@ -244,6 +246,15 @@ func (c *DeviceController) updatePermittedHostDevicePlugins() []Device {
 		}
 	}
 
+	if c.virtConfig.LocalScratchEnabled() {
+		d, err := NewScratchDevicePlugin(util.HostRootMount, localscratch.PoolDir, selinux.SELinuxExecutor{}, NewPermissionManager())
+		if err != nil {
+			log.Log.Reason(err).Errorf("failed to configure the local scratch device plugin")
+		} else {
+			permittedDevices = append(permittedDevices, d)
+		}
+	}
+
 	hostDevs := c.virtConfig.GetPermittedHostDevices()
 	if hostDevs == nil {
 		return permittedDevices
@@ -330,9 +341,18 @@ func (c *DeviceController) RefreshMediatedDeviceTypes() {
 		if c.refreshMediatedDeviceTypes() {
 			c.refreshPermittedDevices()
 		}
+		c.refreshVGPUSchedulingPolicies()
 	}()
 }
 
+// refreshVGPUSchedulingPolicies also applies to externally created mdevs,
+// so it does not depend on the handling of mediated devices being enabled
+func (c *DeviceController) refreshVGPUSchedulingPolicies() {
+	if hostDevs := c.virtConfig.GetPermittedHostDevices(); hostDevs != nil {
+		applyVGPUSchedulingPolicies(hostDevs.MediatedDevices)
+	}
+}
+
 func (c *DeviceController) getExternallyProvidedMdevs() map[string]struct{} {
 	externalMdevResourcesMap := make(map[string]struct{})
 	if hostDevs := c.virtConfig.GetPermittedHostDevices(); hostDevs != nil {
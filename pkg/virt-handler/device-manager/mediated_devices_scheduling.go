@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package device_manager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+)
+
+// Vendor drivers supporting vGPU time-slicing expose the scheduler of a
+// physical GPU through these attributes of the mdev parent device
+const (
+	vgpuSchedulerAttribute = "vgpu_scheduler"
+	vgpuTimeSliceAttribute = "vgpu_timeslice_us"
+)
+
+var vgpuSchedulers = map[v1.VGPUScheduler]string{
+	v1.VGPUSchedulerBestEffort: "best_effort",
+	v1.VGPUSchedulerFixedShare: "fixed_share",
+}
+
+// applyVGPUSchedulingPolicies configures the scheduler of every physical GPU
+// hosting mdevs of a type that has a scheduling policy. A physical GPU hosts
+// mdevs of a single type, so its policy is taken from the first mdev found.
+func applyVGPUSchedulingPolicies(mediatedDevices []v1.MediatedHostDevice) {
+	policies := make(map[string]*v1.VGPUSchedulingPolicy)
+	for _, mdev := range mediatedDevices {
+		if mdev.SchedulingPolicy != nil {
+			policies[removeSelectorSpaces(mdev.MDEVNameSelector)] = mdev.SchedulingPolicy
+		}
+	}
+	if len(policies) == 0 {
+		return
+	}
+
+	files, err := os.ReadDir(mdevBasePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Log.Reason(err).Errorf("failed to apply vGPU scheduling policies: failed to read the content of %s directory", mdevBasePath)
+		}
+		return
+	}
+
+	configuredParents := make(map[string]struct{})
+	for _, file := range files {
+		typeLink, err := os.Readlink(filepath.Join(mdevBasePath, file.Name(), "mdev_type"))
+		if err != nil {
+			continue
+		}
+		// typeLink points to <parent>/mdev_supported_types/<type>
+		parentID := filepath.Base(filepath.Dir(filepath.Dir(typeLink)))
+		if _, configured := configuredParents[parentID]; configured {
+			continue
+		}
+
+		policy, exists := policies[filepath.Base(typeLink)]
+		if !exists {
+			rawName, err := os.ReadFile(filepath.Join(mdevBasePath, file.Name(), "mdev_type/name"))
+			if err != nil {
+				continue
+			}
+			if policy, exists = policies[removeSelectorSpaces(string(rawName))]; !exists {
+				continue
+			}
+		}
+
+		configuredParents[parentID] = struct{}{}
+		if err := setVGPUSchedulingPolicy(parentID, policy); err != nil {
+			log.Log.Reason(err).Errorf("failed to apply the vGPU scheduling policy of %s", parentID)
+		}
+	}
+}
+
+func setVGPUSchedulingPolicy(parentID string, policy *v1.VGPUSchedulingPolicy) error {
+	scheduler, supported := vgpuSchedulers[policy.Scheduler]
+	if !supported {
+		return fmt.Errorf("unknown vGPU scheduler %s", policy.Scheduler)
+	}
+	if err := writeMdevParentAttribute(parentID, vgpuSchedulerAttribute, scheduler); err != nil {
+		return err
+	}
+	if policy.TimeSliceMicroseconds != nil {
+		timeSlice := strconv.FormatUint(uint64(*policy.TimeSliceMicroseconds), 10)
+		if err := writeMdevParentAttribute(parentID, vgpuTimeSliceAttribute, timeSlice); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMdevParentAttribute only writes the attribute when its value differs,
+// since changing the scheduler may reset the time-slicing of running vGPUs
+func writeMdevParentAttribute(parentID, attribute, value string) error {
+	path := filepath.Join(mdevClassBusPath, parentID, attribute)
+	// #nosec No risk for path injection. parentID comes from the mdev sysfs links
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("the driver of %s does not support vGPU scheduling policies: %v", parentID, err)
+	}
+	if strings.TrimSpace(string(current)) == value {
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(value), 0644); err != nil {
+		return fmt.Errorf("failed to write %s to %s: %v", value, path, err)
+	}
+	log.Log.Infof("Set %s of %s to %s", attribute, parentID, value)
+	return nil
+}
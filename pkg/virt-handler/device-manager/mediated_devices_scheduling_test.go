@@ -0,0 +1,129 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package device_manager
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/ptr"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+var _ = Describe("vGPU scheduling policies", func() {
+	const (
+		parentT4  = "0000:65:00.0"
+		parentA16 = "0000:66:00.0"
+	)
+
+	createMdev := func(parentID, mdevType, name string) {
+		typePath := filepath.Join(mdevClassBusPath, parentID, "mdev_supported_types", mdevType)
+		Expect(os.MkdirAll(typePath, 0700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(typePath, "name"), []byte(name+"\n"), 0600)).To(Succeed())
+		for _, attribute := range []string{vgpuSchedulerAttribute, vgpuTimeSliceAttribute} {
+			Expect(os.WriteFile(filepath.Join(mdevClassBusPath, parentID, attribute), []byte("0\n"), 0600)).To(Succeed())
+		}
+
+		mdevPath := filepath.Join(mdevBasePath, string(uuid.NewUUID()))
+		Expect(os.MkdirAll(mdevPath, 0700)).To(Succeed())
+		Expect(os.Symlink(typePath, filepath.Join(mdevPath, "mdev_type"))).To(Succeed())
+	}
+
+	readAttribute := func(parentID, attribute string) string {
+		content, err := os.ReadFile(filepath.Join(mdevClassBusPath, parentID, attribute))
+		Expect(err).ToNot(HaveOccurred())
+		return string(content)
+	}
+
+	BeforeEach(func() {
+		origMdevBasePath, origMdevClassBusPath := mdevBasePath, mdevClassBusPath
+		mdevBasePath = GinkgoT().TempDir()
+		mdevClassBusPath = GinkgoT().TempDir()
+		DeferCleanup(func() {
+			mdevBasePath, mdevClassBusPath = origMdevBasePath, origMdevClassBusPath
+		})
+
+		createMdev(parentT4, "nvidia-222", "GRID T4-1B")
+		createMdev(parentA16, "nvidia-700", "NVIDIA A16-2Q")
+	})
+
+	It("should configure the GPUs hosting mdevs selected by name", func() {
+		applyVGPUSchedulingPolicies([]v1.MediatedHostDevice{
+			{
+				MDEVNameSelector: "GRID T4-1B",
+				ResourceName:     "nvidia.com/GRID_T4-1B",
+				SchedulingPolicy: &v1.VGPUSchedulingPolicy{
+					Scheduler:             v1.VGPUSchedulerFixedShare,
+					TimeSliceMicroseconds: ptr.To[uint32](2000),
+				},
+			},
+			{
+				MDEVNameSelector: "NVIDIA A16-2Q",
+				ResourceName:     "nvidia.com/A16-2Q",
+				SchedulingPolicy: &v1.VGPUSchedulingPolicy{Scheduler: v1.VGPUSchedulerBestEffort},
+			},
+		})
+
+		Expect(readAttribute(parentT4, vgpuSchedulerAttribute)).To(Equal("fixed_share"))
+		Expect(readAttribute(parentT4, vgpuTimeSliceAttribute)).To(Equal("2000"))
+		Expect(readAttribute(parentA16, vgpuSchedulerAttribute)).To(Equal("best_effort"))
+		Expect(readAttribute(parentA16, vgpuTimeSliceAttribute)).To(Equal("0\n"))
+	})
+
+	It("should configure the GPUs hosting mdevs selected by type", func() {
+		applyVGPUSchedulingPolicies([]v1.MediatedHostDevice{{
+			MDEVNameSelector: "nvidia-222",
+			ResourceName:     "nvidia.com/GRID_T4-1B",
+			SchedulingPolicy: &v1.VGPUSchedulingPolicy{Scheduler: v1.VGPUSchedulerBestEffort},
+		}})
+
+		Expect(readAttribute(parentT4, vgpuSchedulerAttribute)).To(Equal("best_effort"))
+		Expect(readAttribute(parentA16, vgpuSchedulerAttribute)).To(Equal("0\n"))
+	})
+
+	It("should leave the GPUs of mdev types without a policy untouched", func() {
+		applyVGPUSchedulingPolicies([]v1.MediatedHostDevice{{
+			MDEVNameSelector: "GRID T4-1B",
+			ResourceName:     "nvidia.com/GRID_T4-1B",
+		}})
+
+		Expect(readAttribute(parentT4, vgpuSchedulerAttribute)).To(Equal("0\n"))
+		Expect(readAttribute(parentA16, vgpuSchedulerAttribute)).To(Equal("0\n"))
+	})
+
+	It("should not rewrite a scheduler which is already set", func() {
+		schedulerPath := filepath.Join(mdevClassBusPath, parentT4, vgpuSchedulerAttribute)
+		Expect(os.WriteFile(schedulerPath, []byte("best_effort\n"), 0400)).To(Succeed())
+
+		Expect(setVGPUSchedulingPolicy(parentT4, &v1.VGPUSchedulingPolicy{Scheduler: v1.VGPUSchedulerBestEffort})).To(Succeed())
+		Expect(readAttribute(parentT4, vgpuSchedulerAttribute)).To(Equal("best_effort\n"))
+	})
+
+	It("should fail when the driver does not support scheduling policies", func() {
+		Expect(os.Remove(filepath.Join(mdevClassBusPath, parentT4, vgpuSchedulerAttribute))).To(Succeed())
+
+		err := setVGPUSchedulingPolicy(parentT4, &v1.VGPUSchedulingPolicy{Scheduler: v1.VGPUSchedulerBestEffort})
+		Expect(err).To(MatchError(ContainSubstring("does not support vGPU scheduling policies")))
+	})
+})
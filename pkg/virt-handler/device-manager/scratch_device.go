@@ -0,0 +1,313 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package device_manager
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/safepath"
+	"kubevirt.io/kubevirt/pkg/storage/localscratch"
+	"kubevirt.io/kubevirt/pkg/util"
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+	"kubevirt.io/kubevirt/pkg/virt-handler/selinux"
+)
+
+// unclaimedScratchGracePeriod leaves enough time for the images of a pod to be
+// pulled before a scratch directory nobody claimed is considered leaked
+const unclaimedScratchGracePeriod = time.Hour
+
+// scratchCapacityRefreshInterval is how often the free capacity of the pool is
+// re-read, so that the advertised units follow the disk usage of the node
+const scratchCapacityRefreshInterval = time.Minute
+
+// ScratchDevicePlugin exposes the node-local scratch pool as one device per
+// localscratch.UnitSize of free capacity and hands out a fresh directory of the
+// pool, mounted as the ephemeral disk directory, to every container allocating it
+type ScratchDevicePlugin struct {
+	*DevicePluginBase
+	hostRoot string
+	poolDir  string
+	executor selinux.Executor
+	p        PermissionManager
+	statfs   func(path string, stat *unix.Statfs_t) error
+	// capacityChanged signals ListAndWatch that the advertised units changed
+	capacityChanged chan struct{}
+}
+
+func NewScratchDevicePlugin(hostRoot, poolDir string, executor selinux.Executor, p PermissionManager) (*ScratchDevicePlugin, error) {
+	dpi := &ScratchDevicePlugin{
+		DevicePluginBase: &DevicePluginBase{
+			health:       make(chan deviceHealth),
+			resourceName: fmt.Sprintf("%s/%s", DeviceNamespace, localscratch.ResourceName),
+			initialized:  false,
+			lock:         &sync.Mutex{},
+			done:         make(chan struct{}),
+			deregistered: make(chan struct{}),
+			socketPath:   SocketPath(localscratch.ResourceName),
+		},
+		hostRoot:        hostRoot,
+		poolDir:         poolDir,
+		executor:        executor,
+		p:               p,
+		statfs:          unix.Statfs,
+		capacityChanged: make(chan struct{}, 1),
+	}
+
+	poolRoot := dpi.poolRoot()
+	if err := os.MkdirAll(poolRoot, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create the scratch pool %s: %v", poolRoot, err)
+	}
+	devs, err := dpi.scratchDevices()
+	if err != nil {
+		return nil, err
+	}
+	dpi.devs = devs
+
+	return dpi, nil
+}
+
+// scratchDevices returns one device per localscratch.UnitSize currently free in the pool
+func (dpi *ScratchDevicePlugin) scratchDevices() ([]*pluginapi.Device, error) {
+	var stat unix.Statfs_t
+	if err := dpi.statfs(dpi.poolRoot(), &stat); err != nil {
+		return nil, fmt.Errorf("failed to determine the capacity of the scratch pool %s: %v", dpi.poolRoot(), err)
+	}
+	units := int64(stat.Bavail) * int64(stat.Bsize) / localscratch.UnitSize
+	devs := make([]*pluginapi.Device, 0, units)
+	for i := int64(0); i < units; i++ {
+		devs = append(devs, &pluginapi.Device{
+			ID:     localscratch.ResourceName + strconv.FormatInt(i, 10),
+			Health: pluginapi.Healthy,
+		})
+	}
+	return devs, nil
+}
+
+func (dpi *ScratchDevicePlugin) devices() []*pluginapi.Device {
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	return dpi.devs
+}
+
+// refreshCapacity replaces the advertised devices and notifies ListAndWatch
+// when the number of free units in the pool changed
+func (dpi *ScratchDevicePlugin) refreshCapacity() {
+	devs, err := dpi.scratchDevices()
+	if err != nil {
+		log.DefaultLogger().Reason(err).Warning("failed to refresh the scratch pool capacity")
+		return
+	}
+
+	dpi.lock.Lock()
+	changed := len(devs) != len(dpi.devs)
+	if changed {
+		dpi.devs = devs
+	}
+	dpi.lock.Unlock()
+
+	if !changed {
+		return
+	}
+	log.DefaultLogger().Infof("%s device plugin capacity changed to %d units", dpi.resourceName, len(devs))
+	select {
+	case dpi.capacityChanged <- struct{}{}:
+	default:
+		// a notification is already pending and ListAndWatch will send the latest devices
+	}
+}
+
+func (dpi *ScratchDevicePlugin) watchCapacity() {
+	ticker := time.NewTicker(scratchCapacityRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-dpi.stop:
+			return
+		case <-dpi.done:
+			return
+		case <-ticker.C:
+			dpi.refreshCapacity()
+		}
+	}
+}
+
+func (dpi *ScratchDevicePlugin) poolRoot() string {
+	return filepath.Join(dpi.hostRoot, dpi.poolDir)
+}
+
+func (dpi *ScratchDevicePlugin) Start(stop <-chan struct{}) (err error) {
+	logger := log.DefaultLogger()
+	dpi.stop = stop
+
+	err = dpi.cleanup()
+	if err != nil {
+		return err
+	}
+
+	sock, err := net.Listen("unix", dpi.socketPath)
+	if err != nil {
+		return fmt.Errorf("error creating GRPC server socket: %v", err)
+	}
+
+	dpi.server = grpc.NewServer([]grpc.ServerOption{}...)
+	defer dpi.stopDevicePlugin()
+
+	pluginapi.RegisterDevicePluginServer(dpi.server, dpi)
+
+	errChan := make(chan error, 2)
+
+	go func() {
+		errChan <- dpi.server.Serve(sock)
+	}()
+
+	err = waitForGRPCServer(dpi.socketPath, connectionTimeout)
+	if err != nil {
+		return fmt.Errorf("error starting the GRPC server: %v", err)
+	}
+
+	err = dpi.register()
+	if err != nil {
+		return fmt.Errorf("error registering with device plugin manager: %v", err)
+	}
+
+	go func() {
+		errChan <- dpi.healthCheck()
+	}()
+
+	go dpi.watchCapacity()
+
+	dpi.setInitialized(true)
+	logger.Infof("%s device plugin started with %d units", dpi.resourceName, len(dpi.devices()))
+	err = <-errChan
+
+	return err
+}
+
+func (dpi *ScratchDevicePlugin) ListAndWatch(_ *pluginapi.Empty, s pluginapi.DevicePlugin_ListAndWatchServer) error {
+	s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devices()})
+
+	done := false
+	for {
+		select {
+		case <-dpi.capacityChanged:
+			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devices()})
+		case <-dpi.stop:
+			done = true
+		case <-dpi.done:
+			done = true
+		}
+		if done {
+			break
+		}
+	}
+	emptyList := []*pluginapi.Device{}
+	if err := s.Send(&pluginapi.ListAndWatchResponse{Devices: emptyList}); err != nil {
+		log.DefaultLogger().Reason(err).Infof("%s device plugin failed to deregister", dpi.resourceName)
+	}
+	close(dpi.deregistered)
+	return nil
+}
+
+func (dpi *ScratchDevicePlugin) Allocate(_ context.Context, r *pluginapi.AllocateRequest) (*pluginapi.AllocateResponse, error) {
+	logger := log.DefaultLogger()
+	if err := localscratch.RemoveUnclaimed(dpi.poolRoot(), unclaimedScratchGracePeriod); err != nil {
+		logger.Reason(err).Warning("failed to remove unclaimed scratch directories")
+	}
+
+	response := &pluginapi.AllocateResponse{}
+	for _, request := range r.ContainerRequests {
+		name, err := localscratch.Allocate(dpi.poolRoot())
+		if err != nil {
+			return nil, err
+		}
+		if err := dpi.setScratchPermissions(name); err != nil {
+			return nil, err
+		}
+		logger.Infof("Scratch Allocate: directory %s for %d units", name, len(request.DevicesIDs))
+		response.ContainerResponses = append(response.ContainerResponses, &pluginapi.ContainerAllocateResponse{
+			Mounts: []*pluginapi.Mount{{
+				HostPath:      filepath.Join(dpi.poolDir, name),
+				ContainerPath: localscratch.EphemeralDiskDir,
+			}},
+		})
+	}
+
+	return response, nil
+}
+
+func (dpi *ScratchDevicePlugin) setScratchPermissions(name string) error {
+	dir, err := safepath.JoinAndResolveWithRelativeRoot(dpi.hostRoot, dpi.poolDir, name)
+	if err != nil {
+		return fmt.Errorf("error opening the scratch directory %s: %v", name, err)
+	}
+	if err := dpi.p.ChownAtNoFollow(dir, util.NonRootUID, util.NonRootUID); err != nil {
+		return fmt.Errorf("error setting the permissions of the scratch directory %s: %v", name, err)
+	}
+	if se, exists, err := dpi.executor.NewSELinux(); err == nil && exists {
+		if err := selinux.RelabelFilesUnprivileged(se.IsPermissive(), dir); err != nil {
+			return fmt.Errorf("error relabeling the scratch directory %s: %v", name, err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to detect the presence of selinux: %v", err)
+	}
+	return nil
+}
+
+func (dpi *ScratchDevicePlugin) healthCheck() error {
+	logger := log.DefaultLogger()
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to creating a fsnotify watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(dpi.socketPath)); err != nil {
+		return fmt.Errorf("failed to add the device-plugin kubelet path to the watcher: %v", err)
+	} else if _, err = os.Stat(dpi.socketPath); err != nil {
+		return fmt.Errorf("failed to stat the device-plugin socket: %v", err)
+	}
+
+	for {
+		select {
+		case <-dpi.stop:
+			return nil
+		case err := <-watcher.Errors:
+			logger.Reason(err).Errorf("error watching the device plugin directory")
+		case event := <-watcher.Events:
+			logger.V(4).Infof("health Event: %v", event)
+			if event.Name == dpi.socketPath && event.Op == fsnotify.Remove {
+				logger.Infof("device socket file for device %s was removed, kubelet probably restarted.", dpi.resourceName)
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package device_manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+
+	"kubevirt.io/kubevirt/pkg/storage/localscratch"
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+)
+
+var _ = Describe("Scratch device", func() {
+	const poolDir = "/scratch"
+	var dpi *ScratchDevicePlugin
+	var hostRoot string
+
+	BeforeEach(func() {
+		var err error
+		hostRoot = GinkgoT().TempDir()
+		mockExec, mockPermManager := socketDeviceMocks()
+		dpi, err = NewScratchDevicePlugin(hostRoot, poolDir, mockExec, mockPermManager)
+		Expect(err).ToNot(HaveOccurred())
+		dpi.server = grpc.NewServer([]grpc.ServerOption{}...)
+		dpi.socketPath = filepath.Join(hostRoot, "kubevirt-scratch.sock")
+		createFile(dpi.socketPath)
+		stop := make(chan struct{})
+		dpi.stop = stop
+		DeferCleanup(func() { close(stop) })
+	})
+
+	It("should expose the free capacity of the pool", func() {
+		Expect(filepath.Join(hostRoot, poolDir)).To(BeADirectory())
+		Expect(dpi.devs).ToNot(BeEmpty())
+		Expect(dpi.devs[0].ID).To(Equal("scratch0"))
+	})
+
+	It("should mount a new scratch directory as the ephemeral disk directory", func() {
+		response, err := dpi.Allocate(context.Background(), &pluginapi.AllocateRequest{
+			ContainerRequests: []*pluginapi.ContainerAllocateRequest{{DevicesIDs: []string{"scratch0", "scratch1"}}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.ContainerResponses).To(HaveLen(1))
+		mounts := response.ContainerResponses[0].Mounts
+		Expect(mounts).To(HaveLen(1))
+		Expect(mounts[0].ContainerPath).To(Equal(localscratch.EphemeralDiskDir))
+		Expect(filepath.Dir(mounts[0].HostPath)).To(Equal(poolDir))
+		Expect(filepath.Join(hostRoot, mounts[0].HostPath)).To(BeADirectory())
+	})
+
+	Context("capacity", func() {
+		var freeUnits uint64
+
+		BeforeEach(func() {
+			freeUnits = 2
+			dpi.statfs = func(_ string, stat *unix.Statfs_t) error {
+				stat.Bsize = 1024
+				stat.Bavail = freeUnits * localscratch.UnitSize / 1024
+				return nil
+			}
+			dpi.refreshCapacity()
+			Eventually(dpi.capacityChanged).Should(Receive())
+		})
+
+		It("should advertise the units freed or consumed since the last refresh", func() {
+			Expect(dpi.devices()).To(HaveLen(2))
+
+			freeUnits = 3
+			dpi.refreshCapacity()
+			Expect(dpi.capacityChanged).To(Receive())
+			Expect(dpi.devices()).To(HaveLen(3))
+
+			freeUnits = 1
+			dpi.refreshCapacity()
+			Expect(dpi.capacityChanged).To(Receive())
+			Expect(dpi.devices()).To(HaveLen(1))
+			Expect(dpi.devices()[0].ID).To(Equal("scratch0"))
+		})
+
+		It("should not notify when the number of free units is unchanged", func() {
+			dpi.refreshCapacity()
+			Expect(dpi.capacityChanged).ToNot(Receive())
+			Expect(dpi.devices()).To(HaveLen(2))
+		})
+
+		It("should send the refreshed devices to the kubelet", func() {
+			server := &fakeListAndWatchServer{responses: make(chan *pluginapi.ListAndWatchResponse, 10)}
+			go dpi.ListAndWatch(&pluginapi.Empty{}, server)
+			Eventually(server.responses).Should(Receive(HaveField("Devices", HaveLen(2))))
+
+			freeUnits = 4
+			dpi.refreshCapacity()
+			Eventually(server.responses).Should(Receive(HaveField("Devices", HaveLen(4))))
+		})
+	})
+
+	It("should stop if the device plugin socket file is deleted", func() {
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- dpi.healthCheck()
+		}()
+		Consistently(errChan, 500*time.Millisecond, 100*time.Millisecond).ShouldNot(Receive())
+		Expect(os.Remove(dpi.socketPath)).To(Succeed())
+		Eventually(errChan).Should(Receive(Not(HaveOccurred())))
+	})
+})
+
+type fakeListAndWatchServer struct {
+	grpc.ServerStream
+	responses chan *pluginapi.ListAndWatchResponse
+}
+
+func (s *fakeListAndWatchServer) Send(response *pluginapi.ListAndWatchResponse) error {
+	s.responses <- response
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package virthandler
+
+import (
+	"fmt"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/vsock/agent"
+)
+
+// AlternativeGuestAgent negotiates with the alternative guest agent of the VMI, if the VMI
+// uses one instead of qemu-guest-agent. It returns nil if no alternative agent is used.
+// The caller has to close the agent of the negotiation.
+func (c *VirtualMachineController) AlternativeGuestAgent(vmi *v1.VirtualMachineInstance) (*agent.Negotiation, error) {
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAlternativeAgentConnected) ||
+		condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+		return nil, nil
+	}
+	return c.negotiateAlternativeGuestAgent(vmi)
+}
+
+func (c *VirtualMachineController) negotiateAlternativeGuestAgent(vmi *v1.VirtualMachineInstance) (*agent.Negotiation, error) {
+	if !c.clusterConfig.GuestAgentAlternativesEnabled() || vmi.Status.VSOCKCID == nil {
+		return nil, nil
+	}
+	alternatives := c.clusterConfig.GetGuestAgentAlternatives()
+	if len(alternatives) == 0 {
+		return nil, nil
+	}
+	return agent.Negotiate(c.guestAgentDialer, *vmi.Status.VSOCKCID, alternatives)
+}
+
+// updateAlternativeGuestAgentStatus negotiates with the alternative guest agents when qemu-guest-agent
+// is not connected and reflects the negotiated capabilities in the AlternativeAgentConnected condition.
+// The interfaces reported by the agent are merged into the returned domain, so that they are handled
+// the same way as the interfaces reported by qemu-guest-agent.
+func (c *VirtualMachineController) updateAlternativeGuestAgentStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) *api.Domain {
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+
+	// The agent cannot answer while the vCPUs are paused, keep the last negotiated state
+	if domain != nil && domain.Status.Status == api.Paused {
+		return domain
+	}
+
+	var negotiation *agent.Negotiation
+	if domain != nil && !isGuestAgentChannelConnected(domain) {
+		var err error
+		negotiation, err = c.negotiateAlternativeGuestAgent(vmi)
+		if err != nil {
+			c.logger.Object(vmi).V(4).Reason(err).Info("No alternative guest agent answered")
+		}
+	}
+	if negotiation == nil {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceAlternativeAgentConnected)
+		return domain
+	}
+	defer negotiation.Agent.Close()
+
+	message := fmt.Sprintf("Capabilities: %s", negotiation.CapabilitiesString())
+	if existing := condManager.GetCondition(vmi, v1.VirtualMachineInstanceAlternativeAgentConnected); existing == nil ||
+		existing.Reason != negotiation.Alternative.Name || existing.Message != message {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceAlternativeAgentConnected)
+		now := metav1.Now()
+		vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+			Type:               v1.VirtualMachineInstanceAlternativeAgentConnected,
+			Status:             k8sv1.ConditionTrue,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+			Reason:             negotiation.Alternative.Name,
+			Message:            message,
+		})
+	}
+
+	if negotiation.Supports(agent.CapabilityFSFreeze) {
+		if negotiation.Info.FSFreezeStatus == agent.FSFrozen {
+			vmi.Status.FSFreezeStatus = api.FSFrozen
+		} else {
+			vmi.Status.FSFreezeStatus = ""
+		}
+	}
+
+	if !negotiation.Supports(agent.CapabilityNetworkInterfaces) {
+		return domain
+	}
+	interfaces, err := negotiation.Agent.Interfaces()
+	if err != nil {
+		c.logger.Object(vmi).Reason(err).Errorf("Failed to get the interfaces from guest agent %s", negotiation.Alternative.Name)
+		return domain
+	}
+	domain = domain.DeepCopy()
+	domain.Status.Interfaces = nil
+	for _, iface := range interfaces {
+		status := api.InterfaceStatus{
+			Mac:           iface.MAC,
+			IPs:           iface.IPAddresses,
+			InterfaceName: iface.Name,
+		}
+		if len(iface.IPAddresses) > 0 {
+			status.Ip = iface.IPAddresses[0]
+		}
+		domain.Status.Interfaces = append(domain.Status.Interfaces, status)
+	}
+	return domain
+}
+
+func isGuestAgentChannelConnected(domain *api.Domain) bool {
+	for _, channel := range domain.Spec.Devices.Channels {
+		if channel.Target != nil && channel.Target.Name == guestAgentChannel && channel.Target.State == "connected" {
+			return true
+		}
+	}
+	return false
+}
@@ -21,6 +21,8 @@ package virthandler
 
 import v1 "kubevirt.io/api/core/v1"
 
+const guestAgentChannel = "org.qemu.guest_agent.0"
+
 var requiredGuestAgentCommands = []string{
 	"guest-ping",
 	"guest-get-time",
@@ -172,10 +172,9 @@ func (h *HeartBeat) do() {
 	// A configuration of mediated devices types on this node depends on the existing node labels
 	// and a MediatedDevicesConfiguration in KubeVirt CR.
 	// When labels change we should initialize a refresh to create/remove mdev types and start/stop
-	// relevant device plugins. This operation should be async.
-	if !h.clusterConfig.MediatedDevicesHandlingDisabled() {
-		h.deviceManagerController.RefreshMediatedDeviceTypes()
-	}
+	// relevant device plugins, and reapply the vGPU scheduling policies. This operation should be async.
+	// The creation and removal of mdev types is skipped when the handling of mediated devices is disabled.
+	h.deviceManagerController.RefreshMediatedDeviceTypes()
 
 	log.DefaultLogger().V(4).Infof("Heartbeat sent")
 }
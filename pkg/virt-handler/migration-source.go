@@ -209,6 +209,18 @@ func (c *MigrationSourceController) setMigrationProgressStatus(vmi *v1.VirtualMa
 	}
 
 	vmi.Status.MigrationState.Mode = migrationMetadata.Mode
+
+	if transferStatus := migrationMetadata.TransferStatus; transferStatus != nil {
+		vmi.Status.MigrationState.TransferStatus = &v1.VirtualMachineInstanceMigrationTransferStatus{
+			DataProcessedBytes:            int64(transferStatus.DataProcessed),
+			DataRemainingBytes:            int64(transferStatus.DataRemaining),
+			DataTotalBytes:                int64(transferStatus.DataTotal),
+			MemoryDirtyRateBytesPerSecond: int64(transferStatus.MemoryDirtyRate),
+			MemoryBandwidthBytesPerSecond: int64(transferStatus.MemoryBandwidth),
+			MemoryIteration:               int64(transferStatus.MemoryIteration),
+			LastUpdateTimestamp:           transferStatus.UpdateTimestamp,
+		}
+	}
 }
 
 func (c *MigrationSourceController) updateStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
@@ -358,6 +358,37 @@ var _ = Describe("VirtualMachineInstance migration target", func() {
 				d.Spec.Metadata.KubeVirt.Migration.AbortStatus)))
 		})
 
+		It("should report the transfer status of the migration", func() {
+			d := newDomainMigrationKubevirtMetadata("1234", nil, false, false, v1.MigrationPreCopy)
+			now := metav1.Now()
+			d.Spec.Metadata.KubeVirt.Migration.TransferStatus = &api.MigrationTransferStatus{
+				DataProcessed:   1024,
+				DataRemaining:   3072,
+				DataTotal:       4096,
+				MemoryDirtyRate: 512,
+				MemoryBandwidth: 2048,
+				MemoryIteration: 2,
+				UpdateTimestamp: &now,
+			}
+			vmi := libvmi.New(libvmistatus.WithStatus(libvmistatus.New(
+				libvmistatus.WithMigrationState(v1.VirtualMachineInstanceMigrationState{
+					MigrationUID:      "1234",
+					SourceNode:        host,
+					TargetNodeAddress: "othernode",
+				}), libvmistatus.WithNodeName(host)),
+			))
+			controller.setMigrationProgressStatus(vmi, d)
+			Expect(vmi.Status.MigrationState.TransferStatus).To(Equal(&v1.VirtualMachineInstanceMigrationTransferStatus{
+				DataProcessedBytes:            1024,
+				DataRemainingBytes:            3072,
+				DataTotalBytes:                4096,
+				MemoryDirtyRateBytesPerSecond: 512,
+				MemoryBandwidthBytesPerSecond: 2048,
+				MemoryIteration:               2,
+				LastUpdateTimestamp:           &now,
+			}))
+		})
+
 		It("should send an event if the migration failed", func() {
 			d := newDomainMigrationKubevirtMetadata("1234", pointer.P(metav1.NewTime(time.Now())),
 				true, true, v1.MigrationPreCopy)
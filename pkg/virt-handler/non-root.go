@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	k8sv1 "k8s.io/api/core/v1"
 
@@ -36,6 +37,8 @@ import (
 	"kubevirt.io/kubevirt/pkg/safepath"
 	"kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
+	"kubevirt.io/kubevirt/pkg/virt-handler/selinux"
+	volume_permissions "kubevirt.io/kubevirt/pkg/virt-handler/volume-permissions"
 )
 
 func changeOwnershipOfBlockDevices(vmi *v1.VirtualMachineInstance, res isolation.IsolationResult) error {
@@ -117,10 +120,40 @@ func changeOwnershipOfHostDisks(vmiWithAllPVCs *v1.VirtualMachineInstance, res i
 	return nil
 }
 
+func relabelUnprivileged(path *safepath.Path) error {
+	return selinux.RelabelFilesUnprivileged(false, path)
+}
+
+// repairVolumePermissions detects filesystem volumes whose disk images are not accessible
+// by the qemu user, e.g. because the backing storage was moved from another node,
+// and repairs them according to the configured policy before the VM is started.
+func (c *BaseController) repairVolumePermissions(vmi *v1.VirtualMachineInstance, res isolation.IsolationResult) error {
+	root, err := res.MountRoot()
+	if err != nil {
+		return err
+	}
+
+	policy := c.clusterConfig.GetVolumePermissionRepairPolicy()
+	repairer := volume_permissions.NewRepairer(policy, diskutils.DefaultOwnershipManager.SetFileOwnership, relabelUnprivileged)
+	result, err := repairer.Repair(vmi, root)
+	if len(result.Repaired) > 0 {
+		c.recorder.Event(vmi, k8sv1.EventTypeNormal, "VolumePermissionsRepaired", strings.Join(result.Repaired, "; "))
+	}
+	if len(result.Mismatches) > 0 {
+		c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, "VolumePermissionsMismatch",
+			"%s, set volumePermissionRepairPolicy in the KubeVirt configuration to repair them (current policy: %s)",
+			strings.Join(result.Mismatches, "; "), policy)
+	}
+	return err
+}
+
 func (c *BaseController) prepareStorage(vmi *v1.VirtualMachineInstance, res isolation.IsolationResult) error {
 	if err := changeOwnershipOfBlockDevices(vmi, res); err != nil {
 		return err
 	}
+	if err := c.repairVolumePermissions(vmi, res); err != nil {
+		return err
+	}
 	return changeOwnershipOfHostDisks(vmi, res)
 }
 
@@ -0,0 +1,109 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package rest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	windowsGuestOSID              = "mswindows"
+	crashControlRegistryKey       = `HKLM\SYSTEM\CurrentControlSet\Control\CrashControl`
+	registryQueryTimeoutSeconds   = 10
+	registryQueryValueNotFoundMsg = "%s\\%s is not set in the guest, %s"
+)
+
+// crashDumpRegistryValue is a value of the CrashControl registry key which
+// Windows requires to crash and write a memory dump on an NMI.
+type crashDumpRegistryValue struct {
+	name  string
+	valid func(value uint64) bool
+	hint  string
+}
+
+var crashDumpRegistryValues = []crashDumpRegistryValue{
+	{
+		name:  "NMICrashDump",
+		valid: func(value uint64) bool { return value == 1 },
+		hint:  "set it to 1 to make Windows crash when it receives an NMI",
+	},
+	{
+		name:  "CrashDumpEnabled",
+		valid: func(value uint64) bool { return value != 0 },
+		hint:  "set it to 1 (complete), 2 (kernel) or 3 (small) to make Windows write a memory dump",
+	},
+}
+
+// validateCrashDumpPrerequisites checks through the guest agent that a Windows
+// guest is configured to write a memory dump when it receives an NMI. Guests
+// without a connected agent are not checked, as a hanging guest is the main
+// reason to force a crash dump.
+func validateCrashDumpPrerequisites(client cmdclient.LauncherClient, vmi *v1.VirtualMachineInstance) error {
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if vmi.Status.GuestOSInfo.ID != windowsGuestOSID ||
+		!condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstanceAgentConnected, k8sv1.ConditionTrue) {
+		return nil
+	}
+
+	domainName := api.VMINamespaceKeyFunc(vmi)
+	for _, registryValue := range crashDumpRegistryValues {
+		exitCode, stdOut, err := client.Exec(domainName, "reg", []string{"query", crashControlRegistryKey, "/v", registryValue.name}, registryQueryTimeoutSeconds)
+		if err != nil {
+			return fmt.Errorf("failed to query %s in the guest: %v", registryValue.name, err)
+		}
+		if exitCode != 0 {
+			return fmt.Errorf(registryQueryValueNotFoundMsg, crashControlRegistryKey, registryValue.name, registryValue.hint)
+		}
+		value, err := parseRegDWORD(stdOut, registryValue.name)
+		if err != nil {
+			return err
+		}
+		if !registryValue.valid(value) {
+			return fmt.Errorf("%s\\%s is set to %d in the guest, %s", crashControlRegistryKey, registryValue.name, value, registryValue.hint)
+		}
+	}
+	return nil
+}
+
+// parseRegDWORD extracts a REG_DWORD value from the output of reg query,
+// which prints it as "    <name>    REG_DWORD    0x<value>".
+func parseRegDWORD(output, name string) (uint64, error) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || !strings.EqualFold(fields[0], name) || fields[1] != "REG_DWORD" {
+			continue
+		}
+		value, err := strconv.ParseUint(strings.TrimPrefix(fields[2], "0x"), 16, 32)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse the value of %s: %v", name, err)
+		}
+		return value, nil
+	}
+	return 0, fmt.Errorf("failed to find %s in the output of reg query: %q", name, output)
+}
@@ -0,0 +1,242 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/emicklei/go-restful/v3"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+func (lh *LifecycleHandler) GetDomainStats(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	domainStats, exists, err := client.GetDomainStats()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get domain stats")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	if !exists || domainStats == nil {
+		response.WriteError(http.StatusNotFound, fmt.Errorf("no domain stats available for VMI %s", vmi.Name))
+		return
+	}
+
+	response.WriteEntity(convertDomainStats(domainStats))
+}
+
+// convertDomainStats converts the statistics reported by libvirt to their API representation.
+// Statistics libvirt did not report are left zero.
+func convertDomainStats(domainStats *stats.DomainStats) v1.VirtualMachineInstanceDomainStats {
+	out := v1.VirtualMachineInstanceDomainStats{
+		Timestamp: metav1.NowMicro(),
+	}
+
+	if cpu := domainStats.Cpu; cpu != nil {
+		out.CPU = &v1.DomainStatsCPU{}
+		if cpu.TimeSet {
+			out.CPU.TimeNanoseconds = cpu.Time
+		}
+		if cpu.UserSet {
+			out.CPU.UserNanoseconds = cpu.User
+		}
+		if cpu.SystemSet {
+			out.CPU.SystemNanoseconds = cpu.System
+		}
+	}
+
+	for id, vcpu := range domainStats.Vcpu {
+		vcpuStats := v1.DomainStatsVCPU{ID: uint32(id)}
+		if vcpu.StateSet {
+			vcpuStats.State = vcpuStateName(vcpu.State)
+		}
+		if vcpu.TimeSet {
+			vcpuStats.TimeNanoseconds = vcpu.Time
+		}
+		if vcpu.WaitSet {
+			vcpuStats.WaitNanoseconds = vcpu.Wait
+		}
+		if vcpu.DelaySet {
+			vcpuStats.DelayNanoseconds = vcpu.Delay
+		}
+		out.VCPUs = append(out.VCPUs, vcpuStats)
+	}
+
+	if mem := domainStats.Memory; mem != nil {
+		out.Memory = convertDomainStatsMemory(mem)
+	}
+
+	for _, block := range domainStats.Block {
+		// Backing images of a disk are reported as separate entries
+		if !block.NameSet || block.BackingIndexSet {
+			continue
+		}
+		out.Disks = append(out.Disks, convertDomainStatsBlock(block))
+	}
+
+	for _, net := range domainStats.Net {
+		if !net.NameSet {
+			continue
+		}
+		out.Interfaces = append(out.Interfaces, convertDomainStatsNet(net))
+	}
+
+	return out
+}
+
+func vcpuStateName(state int) string {
+	switch state {
+	case stats.VCPUOffline:
+		return "offline"
+	case stats.VCPURunning:
+		return "running"
+	case stats.VCPUBlocked:
+		return "blocked"
+	default:
+		return ""
+	}
+}
+
+// convertDomainStatsMemory converts the memory statistics libvirt reports in KiB to bytes.
+func convertDomainStatsMemory(mem *stats.DomainStatsMemory) *v1.DomainStatsMemory {
+	const kib = 1024
+	out := &v1.DomainStatsMemory{}
+	if mem.ActualBalloonSet {
+		out.ActualBalloonBytes = mem.ActualBalloon * kib
+	}
+	if mem.AvailableSet {
+		out.AvailableBytes = mem.Available * kib
+	}
+	if mem.UsableSet {
+		out.UsableBytes = mem.Usable * kib
+	}
+	if mem.UnusedSet {
+		out.UnusedBytes = mem.Unused * kib
+	}
+	if mem.CachedSet {
+		out.CachedBytes = mem.Cached * kib
+	}
+	if mem.RSSSet {
+		out.RSSBytes = mem.RSS * kib
+	}
+	if mem.SwapInSet {
+		out.SwapInBytes = mem.SwapIn * kib
+	}
+	if mem.SwapOutSet {
+		out.SwapOutBytes = mem.SwapOut * kib
+	}
+	if mem.MajorFaultSet {
+		out.MajorPageFaults = mem.MajorFault
+	}
+	if mem.MinorFaultSet {
+		out.MinorPageFaults = mem.MinorFault
+	}
+	return out
+}
+
+func convertDomainStatsBlock(block stats.DomainStatsBlock) v1.DomainStatsDisk {
+	out := v1.DomainStatsDisk{Name: block.Name}
+	if block.Alias != "" {
+		out.Name = block.Alias
+	}
+	if block.PathSet {
+		out.Path = block.Path
+	}
+	if block.RdReqsSet {
+		out.ReadRequests = block.RdReqs
+	}
+	if block.RdBytesSet {
+		out.ReadBytes = block.RdBytes
+	}
+	if block.RdTimesSet {
+		out.ReadTimeNanoseconds = block.RdTimes
+	}
+	if block.WrReqsSet {
+		out.WriteRequests = block.WrReqs
+	}
+	if block.WrBytesSet {
+		out.WriteBytes = block.WrBytes
+	}
+	if block.WrTimesSet {
+		out.WriteTimeNanoseconds = block.WrTimes
+	}
+	if block.FlReqsSet {
+		out.FlushRequests = block.FlReqs
+	}
+	if block.FlTimesSet {
+		out.FlushTimeNanoseconds = block.FlTimes
+	}
+	if block.ErrorsSet {
+		out.Errors = block.Errors
+	}
+	if block.CapacitySet {
+		out.CapacityBytes = block.Capacity
+	}
+	if block.AllocationSet {
+		out.AllocationBytes = block.Allocation
+	}
+	if block.PhysicalSet {
+		out.PhysicalBytes = block.Physical
+	}
+	return out
+}
+
+func convertDomainStatsNet(net stats.DomainStatsNet) v1.DomainStatsInterface {
+	out := v1.DomainStatsInterface{Name: net.Name}
+	if net.AliasSet {
+		out.Name = net.Alias
+	}
+	if net.RxBytesSet {
+		out.RxBytes = net.RxBytes
+	}
+	if net.RxPktsSet {
+		out.RxPackets = net.RxPkts
+	}
+	if net.RxErrsSet {
+		out.RxErrors = net.RxErrs
+	}
+	if net.RxDropSet {
+		out.RxDropped = net.RxDrop
+	}
+	if net.TxBytesSet {
+		out.TxBytes = net.TxBytes
+	}
+	if net.TxPktsSet {
+		out.TxPackets = net.TxPkts
+	}
+	if net.TxErrsSet {
+		out.TxErrors = net.TxErrs
+	}
+	if net.TxDropSet {
+		out.TxDropped = net.TxDrop
+	}
+	return out
+}
@@ -20,6 +20,7 @@
 package rest
 
 import (
+	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,6 +28,7 @@ import (
 	"github.com/emicklei/go-restful/v3"
 
 	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -35,7 +37,12 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
 
+	"kubevirt.io/kubevirt/pkg/controller"
+	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+	"kubevirt.io/kubevirt/pkg/vsock/agent"
 )
 
 const (
@@ -46,16 +53,24 @@ const (
 )
 
 type LifecycleHandler struct {
-	recorder     record.EventRecorder
-	vmiStore     cache.Store
-	virtShareDir string
+	recorder              record.EventRecorder
+	vmiStore              cache.Store
+	virtShareDir          string
+	domainOptions         func(*v1.VirtualMachineInstance) *cmdv1.VirtualMachineOptions
+	alternativeGuestAgent func(*v1.VirtualMachineInstance) (*agent.Negotiation, error)
+	setGuestQuiesced      func(*v1.VirtualMachineInstance, bool)
+	guestDataRedacted     func(string, v1.GuestAgentDataKind) bool
 }
 
-func NewLifecycleHandler(recorder record.EventRecorder, vmiStore cache.Store, virtShareDir string) *LifecycleHandler {
+func NewLifecycleHandler(recorder record.EventRecorder, vmiStore cache.Store, virtShareDir string, domainOptions func(*v1.VirtualMachineInstance) *cmdv1.VirtualMachineOptions, alternativeGuestAgent func(*v1.VirtualMachineInstance) (*agent.Negotiation, error), setGuestQuiesced func(*v1.VirtualMachineInstance, bool), guestDataRedacted func(string, v1.GuestAgentDataKind) bool) *LifecycleHandler {
 	return &LifecycleHandler{
-		recorder:     recorder,
-		vmiStore:     vmiStore,
-		virtShareDir: virtShareDir,
+		recorder:              recorder,
+		vmiStore:              vmiStore,
+		virtShareDir:          virtShareDir,
+		domainOptions:         domainOptions,
+		alternativeGuestAgent: alternativeGuestAgent,
+		setGuestQuiesced:      setGuestQuiesced,
+		guestDataRedacted:     guestDataRedacted,
 	}
 }
 
@@ -66,14 +81,49 @@ func (lh *LifecycleHandler) PauseHandler(request *restful.Request, response *res
 	}
 	defer client.Close()
 
+	pauseOptions := &v1.PauseOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err = yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(pauseOptions)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			log.Log.Object(vmi).Reason(err).Error("Failed to unmarshal pause options")
+			response.WriteError(http.StatusBadRequest, fmt.Errorf("failed to unmarshal pause options"))
+			return
+		}
+	}
+
+	if pauseOptions.SyncGuest {
+		// Freeze without a safety unfreeze timeout, the filesystems are thawed on unpause
+		if err = lh.freezeGuest(vmi, client); err != nil {
+			log.Log.Object(vmi).Reason(err).Error(failedFreezeVMI)
+			response.WriteError(http.StatusInternalServerError, err)
+			lh.recorder.Eventf(vmi, k8sv1.EventTypeWarning, "FreezeError", "%s: %s", failedFreezeVMI, err.Error())
+			return
+		}
+		lh.setGuestQuiesced(vmi, true)
+	}
+
 	err = client.PauseVirtualMachine(vmi)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to pause VMI")
+		if pauseOptions.SyncGuest {
+			lh.setGuestQuiesced(vmi, false)
+			if thawErr := lh.thawGuest(vmi, client); thawErr != nil {
+				log.Log.Object(vmi).Reason(thawErr).Error("Failed to unfreeze VMI")
+			}
+		}
 		response.WriteError(http.StatusInternalServerError, err)
 		return
 	}
 
-	lh.recorder.Eventf(vmi, k8sv1.EventTypeNormal, "Paused", "VirtualMachineInstance paused")
+	if pauseOptions.SyncGuest {
+		lh.recorder.Eventf(vmi, k8sv1.EventTypeNormal, "Paused", "VirtualMachineInstance paused after quiescing the guest")
+	} else {
+		lh.recorder.Eventf(vmi, k8sv1.EventTypeNormal, "Paused", "VirtualMachineInstance paused")
+	}
 	response.WriteHeader(http.StatusAccepted)
 }
 
@@ -92,6 +142,16 @@ func (lh *LifecycleHandler) UnpauseHandler(request *restful.Request, response *r
 	}
 
 	lh.recorder.Eventf(vmi, k8sv1.EventTypeNormal, "Unpaused", "VirtualMachineInstance unpaused")
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if condManager.HasCondition(vmi, v1.VirtualMachineInstanceGuestQuiesced) {
+		if err = lh.thawGuest(vmi, client); err != nil {
+			log.Log.Object(vmi).Reason(err).Error("Failed to unfreeze VMI")
+			response.WriteError(http.StatusInternalServerError, fmt.Errorf("VMI was unpaused but its filesystems could not be thawed, retry with unfreeze: %v", err))
+			return
+		}
+	}
+	lh.setGuestQuiesced(vmi, false)
 	response.WriteHeader(http.StatusAccepted)
 }
 
@@ -127,7 +187,15 @@ func (lh *LifecycleHandler) FreezeHandler(request *restful.Request, response *re
 	}
 
 	unfreezeTimeoutSeconds := int32(unfreezeTimeout.UnfreezeTimeout.Seconds())
-	err = client.FreezeVirtualMachine(vmi, unfreezeTimeoutSeconds)
+	handled, err := lh.withAlternativeGuestAgent(vmi, func(guestAgent agent.Agent) error {
+		return guestAgent.Freeze(agent.FreezeArguments{
+			Mountpoints:            unfreezeTimeout.Mountpoints,
+			UnfreezeTimeoutSeconds: unfreezeTimeoutSeconds,
+		})
+	})
+	if !handled {
+		err = client.FreezeVirtualMachine(vmi, unfreezeTimeoutSeconds, unfreezeTimeout.Mountpoints)
+	}
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error(failedFreezeVMI)
 		response.WriteError(http.StatusBadRequest, err)
@@ -145,7 +213,7 @@ func (lh *LifecycleHandler) UnfreezeHandler(request *restful.Request, response *
 	}
 	defer client.Close()
 
-	err = client.UnfreezeVirtualMachine(vmi)
+	err = lh.thawGuest(vmi, client)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to unfreeze VMI")
 		response.WriteError(http.StatusBadRequest, err)
@@ -155,6 +223,44 @@ func (lh *LifecycleHandler) UnfreezeHandler(request *restful.Request, response *
 	response.WriteHeader(http.StatusAccepted)
 }
 
+func (lh *LifecycleHandler) freezeGuest(vmi *v1.VirtualMachineInstance, client cmdclient.LauncherClient) error {
+	handled, err := lh.withAlternativeGuestAgent(vmi, func(guestAgent agent.Agent) error {
+		return guestAgent.Freeze(agent.FreezeArguments{})
+	})
+	if !handled {
+		err = client.FreezeVirtualMachine(vmi, 0, nil)
+	}
+	return err
+}
+
+func (lh *LifecycleHandler) thawGuest(vmi *v1.VirtualMachineInstance, client cmdclient.LauncherClient) error {
+	handled, err := lh.withAlternativeGuestAgent(vmi, func(guestAgent agent.Agent) error {
+		return guestAgent.Thaw()
+	})
+	if !handled {
+		err = client.UnfreezeVirtualMachine(vmi)
+	}
+	return err
+}
+
+// withAlternativeGuestAgent runs the freeze or thaw operation with the alternative guest agent of the VMI.
+// It returns false if the VMI does not use an alternative guest agent and the operation has to go through libvirt.
+func (lh *LifecycleHandler) withAlternativeGuestAgent(vmi *v1.VirtualMachineInstance, operation func(agent.Agent) error) (bool, error) {
+	negotiation, err := lh.alternativeGuestAgent(vmi)
+	if err != nil {
+		return true, err
+	}
+	if negotiation == nil {
+		return false, nil
+	}
+	defer negotiation.Agent.Close()
+
+	if !negotiation.Supports(agent.CapabilityFSFreeze) {
+		return true, fmt.Errorf("guest agent %s does not support freezing the filesystems", negotiation.Alternative.Name)
+	}
+	return true, operation(negotiation.Agent)
+}
+
 func (lh *LifecycleHandler) ResetHandler(request *restful.Request, response *restful.Response) {
 	vmi, client, err := lh.getVMILauncherClient(request, response)
 	if err != nil {
@@ -180,7 +286,21 @@ func (lh *LifecycleHandler) SoftRebootHandler(request *restful.Request, response
 	}
 	defer client.Close()
 
-	err = client.SoftRebootVirtualMachine(vmi)
+	softRebootOptions := &v1.SoftRebootOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err = yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(softRebootOptions)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			log.Log.Object(vmi).Reason(err).Error("Failed to unmarshal soft reboot options")
+			response.WriteError(http.StatusBadRequest, fmt.Errorf("failed to unmarshal soft reboot options"))
+			return
+		}
+	}
+
+	err = client.SoftRebootVirtualMachine(vmi, softRebootOptions.Fallback)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to soft reboot VMI")
 		response.WriteError(http.StatusInternalServerError, err)
@@ -191,6 +311,30 @@ func (lh *LifecycleHandler) SoftRebootHandler(request *restful.Request, response
 	response.WriteHeader(http.StatusAccepted)
 }
 
+func (lh *LifecycleHandler) InjectNMIHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	if err := validateCrashDumpPrerequisites(client, vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("VMI is not prepared to write a crash dump")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	err = client.InjectNMIVirtualMachine(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to inject an NMI into VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	lh.recorder.Eventf(vmi, k8sv1.EventTypeNormal, "InjectedNMI", "NMI injected into VirtualMachineInstance")
+	response.WriteHeader(http.StatusAccepted)
+}
+
 func (lh *LifecycleHandler) GetGuestInfo(request *restful.Request, response *restful.Response) {
 	log.Log.Info("Retreiving guestinfo")
 	vmi, client, err := lh.getVMILauncherClient(request, response)
@@ -207,6 +351,7 @@ func (lh *LifecycleHandler) GetGuestInfo(request *restful.Request, response *res
 		response.WriteError(http.StatusInternalServerError, err)
 		return
 	}
+	lh.redactGuestInfo(vmi, guestInfo)
 
 	log.Log.Object(vmi).Infof("returning guestinfo :%v", guestInfo)
 	response.WriteEntity(guestInfo)
@@ -219,6 +364,11 @@ func (lh *LifecycleHandler) GetUsers(request *restful.Request, response *restful
 	}
 	defer client.Close()
 
+	if lh.guestDataRedacted(vmi.Namespace, v1.GuestAgentDataUsers) {
+		response.WriteEntity(v1.VirtualMachineInstanceGuestOSUserList{Items: []v1.VirtualMachineInstanceGuestOSUser{}})
+		return
+	}
+
 	log.Log.Object(vmi).Infof("Retreiving userlist from %s", vmi.Name)
 
 	userList, err := client.GetUsers()
@@ -238,6 +388,11 @@ func (lh *LifecycleHandler) GetFilesystems(request *restful.Request, response *r
 	}
 	defer client.Close()
 
+	if lh.guestDataRedacted(vmi.Namespace, v1.GuestAgentDataFilesystems) {
+		response.WriteEntity(v1.VirtualMachineInstanceFileSystemList{Items: []v1.VirtualMachineInstanceFileSystem{}})
+		return
+	}
+
 	log.Log.Object(vmi).Infof("Retreiving filesystem list from %s", vmi.Name)
 
 	fsList, err := client.GetFilesystems()
@@ -250,6 +405,119 @@ func (lh *LifecycleHandler) GetFilesystems(request *restful.Request, response *r
 	response.WriteEntity(fsList)
 }
 
+// redactGuestInfo drops the guest data the data policies of the VMI namespace do not allow to expose.
+func (lh *LifecycleHandler) redactGuestInfo(vmi *v1.VirtualMachineInstance, guestInfo *v1.VirtualMachineInstanceGuestAgentInfo) {
+	if lh.guestDataRedacted(vmi.Namespace, v1.GuestAgentDataOSInfo) {
+		guestInfo.OS = v1.VirtualMachineInstanceGuestOSInfo{}
+	}
+	if lh.guestDataRedacted(vmi.Namespace, v1.GuestAgentDataUsers) {
+		guestInfo.UserList = nil
+	}
+	if lh.guestDataRedacted(vmi.Namespace, v1.GuestAgentDataFilesystems) {
+		guestInfo.FSInfo = v1.VirtualMachineInstanceFileSystemInfo{}
+	}
+}
+
+func (lh *LifecycleHandler) GetResourceUsage(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	domainStats, exists, err := client.GetDomainStats()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get domain stats")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	if !exists || domainStats == nil {
+		response.WriteError(http.StatusNotFound, fmt.Errorf("no domain stats available for VMI %s", vmi.Name))
+		return
+	}
+
+	response.WriteEntity(resourceUsageFromDomainStats(domainStats))
+}
+
+// GetDomain returns the domain currently defined in libvirt next to the domain
+// virt-launcher would generate from the current VMI spec.
+func (lh *LifecycleHandler) GetDomain(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	domain, exists, err := client.GetDomain()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get domain")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	if !exists || domain == nil {
+		response.WriteError(http.StatusNotFound, fmt.Errorf("no domain available for VMI %s", vmi.Name))
+		return
+	}
+
+	vmiCopy := vmi.DeepCopy()
+	if err := hostdisk.ReplacePVCByHostDisk(vmiCopy); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to prepare VMI for domain generation")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	expected, err := client.GenerateDomain(vmiCopy, lh.domainOptions(vmi))
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to generate domain")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	liveXML, err := xml.MarshalIndent(domain.Spec, "", "  ")
+	if err != nil {
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	expectedXML, err := xml.MarshalIndent(expected, "", "  ")
+	if err != nil {
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(v1.VirtualMachineInstanceDomain{
+		Live:     string(liveXML),
+		Expected: string(expectedXML),
+	})
+}
+
+func resourceUsageFromDomainStats(domainStats *stats.DomainStats) v1.VirtualMachineInstanceResourceUsage {
+	usage := v1.VirtualMachineInstanceResourceUsage{
+		Timestamp: metav1.NowMicro(),
+		VCPUs:     uint32(domainStats.NrVirtCpu),
+	}
+
+	if domainStats.Cpu != nil && domainStats.Cpu.TimeSet {
+		usage.CPUTimeNanoseconds = domainStats.Cpu.Time
+	} else {
+		for _, vcpu := range domainStats.Vcpu {
+			if vcpu.TimeSet {
+				usage.CPUTimeNanoseconds += vcpu.Time
+			}
+		}
+	}
+
+	// libvirt reports memory statistics in KiB
+	if mem := domainStats.Memory; mem != nil && mem.AvailableSet {
+		usage.MemoryAvailableBytes = mem.Available * 1024
+		if mem.UsableSet && mem.Usable <= mem.Available {
+			usage.MemoryUsedBytes = (mem.Available - mem.Usable) * 1024
+		} else if mem.UnusedSet && mem.Unused <= mem.Available {
+			usage.MemoryUsedBytes = (mem.Available - mem.Unused) * 1024
+		}
+	}
+
+	return usage
+}
+
 func (lh *LifecycleHandler) getVMILauncherClient(request *restful.Request, response *restful.Response) (*v1.VirtualMachineInstance, cmdclient.LauncherClient, error) {
 	vmi, code, err := getVMI(request, lh.vmiStore)
 	if err != nil {
@@ -312,6 +580,42 @@ func (lh *LifecycleHandler) SEVQueryLaunchMeasurementHandler(request *restful.Re
 	response.WriteEntity(sevMeasurementInfo)
 }
 
+func (lh *LifecycleHandler) SEVFetchAttestationReportHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	if request.Request.Body == nil {
+		log.Log.Object(vmi).Reason(err).Error("Request with no body: SEV-SNP attestation report parameters are required")
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("failed to retrieve SEV-SNP attestation report parameters from request"))
+		return
+	}
+
+	opts := &v1.SEVSNPAttestationReportOptions{}
+	err = yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		log.Log.Object(vmi).Reason(err).Error("Failed to decode SEV-SNP attestation report parameters")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	log.Log.Object(vmi).Infof("Retrieving SEV-SNP attestation report")
+
+	attestationReport, err := client.GetSEVSNPAttestationReport(vmi, opts)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get SEV-SNP attestation report")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(attestationReport)
+}
+
 func (lh *LifecycleHandler) SEVInjectLaunchSecretHandler(request *restful.Request, response *restful.Response) {
 	vmi, client, err := lh.getVMILauncherClient(request, response)
 	if err != nil {
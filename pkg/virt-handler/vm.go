@@ -30,6 +30,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/opencontainers/runc/libcontainer/cgroups"
@@ -54,6 +55,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/controller"
 	drautil "kubevirt.io/kubevirt/pkg/dra"
 	"kubevirt.io/kubevirt/pkg/executor"
+	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/hypervisor"
@@ -62,6 +64,7 @@ import (
 	netsetup "kubevirt.io/kubevirt/pkg/network/setup"
 	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
 	"kubevirt.io/kubevirt/pkg/safepath"
+	"kubevirt.io/kubevirt/pkg/storage/localscratch"
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/util"
@@ -81,6 +84,7 @@ import (
 	multipathmonitor "kubevirt.io/kubevirt/pkg/virt-handler/multipath-monitor"
 	"kubevirt.io/kubevirt/pkg/virt-handler/selinux"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/vsock/agent"
 )
 
 type netstat interface {
@@ -94,6 +98,13 @@ type downwardMetricsManager interface {
 	StopServer(vmi *v1.VirtualMachineInstance)
 }
 
+type networkGatewayProber interface {
+	Run(stopCh chan struct{}, onChange func(key string))
+	Start(vmi *v1.VirtualMachineInstance, pid int)
+	Stop(vmi *v1.VirtualMachineInstance)
+	Condition(vmi *v1.VirtualMachineInstance) *v1.VirtualMachineInstanceCondition
+}
+
 type VirtualMachineController struct {
 	*BaseController
 	capabilities             *libvirtxml.Caps
@@ -107,6 +118,9 @@ type VirtualMachineController struct {
 	heartBeat                *heartbeat.HeartBeat
 	heartBeatInterval        time.Duration
 	netConf                  netconf
+	networkGatewayProber     networkGatewayProber
+	guestAgentDialer         agent.Dialer
+	quiescedGuests           sync.Map
 	sriovHotplugExecutorPool *executor.RateLimitedExecutorPool
 	vmiExpectations          *controller.UIDTrackingControllerExpectations
 	vmiGlobalStore           cache.Store
@@ -138,6 +152,7 @@ func NewVirtualMachineController(
 	hostCpuModel string,
 	netConf netconf,
 	netStat netstat,
+	networkGatewayProber networkGatewayProber,
 	cbtHandler *CBTHandler,
 ) (*VirtualMachineController, error) {
 
@@ -191,6 +206,8 @@ func NewVirtualMachineController(
 		ioErrorRetryManager:      NewFailRetryManager("io-error-retry", 10*time.Second, 3*time.Minute, 30*time.Second),
 		heartBeatInterval:        1 * time.Minute,
 		netConf:                  netConf,
+		networkGatewayProber:     networkGatewayProber,
+		guestAgentDialer:         agent.VSOCKDialer,
 		sriovHotplugExecutorPool: executor.NewRateLimitedExecutorPool(executor.NewExponentialLimitedBackoffCreator()),
 		vmiExpectations:          controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
 		vmiGlobalStore:           vmiGlobalStore,
@@ -248,6 +265,8 @@ func (c *VirtualMachineController) Run(threadiness int, stopCh chan struct{}) {
 
 	go c.downwardMetricsManager.Run(stopCh)
 
+	go c.networkGatewayProber.Run(stopCh, func(key string) { c.queue.Add(key) })
+
 	cache.WaitForCacheSync(stopCh, c.hasSynced)
 
 	// queue keys for previous Domains on the host that no longer exist
@@ -668,6 +687,11 @@ func (c *VirtualMachineController) updateVolumeStatusesFromDomain(vmi *v1.Virtua
 
 func (c *VirtualMachineController) updateGuestInfoFromDomain(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
 
+	if c.clusterConfig.IsGuestAgentDataRedacted(vmi.Namespace, v1.GuestAgentDataOSInfo) {
+		vmi.Status.GuestOSInfo = v1.VirtualMachineInstanceGuestOSInfo{}
+		return
+	}
+
 	if domain == nil || domain.Status.OSInfo.Name == "" || vmi.Status.GuestOSInfo.Name == domain.Status.OSInfo.Name {
 		return
 	}
@@ -759,7 +783,7 @@ func (c *VirtualMachineController) updateGuestAgentConditions(vmi *v1.VirtualMac
 		for _, channel := range domain.Spec.Devices.Channels {
 			if channel.Target != nil {
 				c.logger.V(4).Infof("Channel: %s, %s", channel.Target.Name, channel.Target.State)
-				if channel.Target.Name == "org.qemu.guest_agent.0" {
+				if channel.Target.Name == guestAgentChannel {
 					if channel.Target.State == "connected" {
 						channelConnected = true
 					}
@@ -829,6 +853,14 @@ func (c *VirtualMachineController) updateGuestAgentConditions(vmi *v1.VirtualMac
 	return nil
 }
 
+func (c *VirtualMachineController) updateNetworkGatewaysCondition(vmi *v1.VirtualMachineInstance, condManager *controller.VirtualMachineInstanceConditionManager) {
+	if condition := c.networkGatewayProber.Condition(vmi); condition != nil {
+		condManager.UpdateCondition(vmi, condition)
+	} else if condManager.HasCondition(vmi, v1.VirtualMachineInstanceNetworkGatewaysReachable) {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceNetworkGatewaysReachable)
+	}
+}
+
 func (c *VirtualMachineController) updatePausedConditions(vmi *v1.VirtualMachineInstance, domain *api.Domain, condManager *controller.VirtualMachineInstanceConditionManager) {
 
 	// Update paused condition in case VMI was paused / unpaused
@@ -840,6 +872,25 @@ func (c *VirtualMachineController) updatePausedConditions(vmi *v1.VirtualMachine
 		c.logger.Object(vmi).V(3).Info("Removing paused condition")
 		condManager.RemoveCondition(vmi, v1.VirtualMachineInstancePaused)
 	}
+
+	// Update paused on I/O error condition, the domain is resumed once the storage backend recovers
+	if domain != nil && domain.Status.Status == api.Paused && domain.Status.Reason == api.ReasonPausedIOError {
+		if !condManager.HasCondition(vmi, v1.VirtualMachineInstancePausedOnIOError) {
+			c.logger.Object(vmi).V(3).Info("Adding paused on I/O error condition")
+			now := metav1.NewTime(time.Now())
+			vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+				Type:               v1.VirtualMachineInstancePausedOnIOError,
+				Status:             k8sv1.ConditionTrue,
+				LastProbeTime:      now,
+				LastTransitionTime: now,
+				Reason:             "PausedIOError",
+				Message:            "VMI was paused because of an I/O error, it is resumed once the storage backend recovers",
+			})
+		}
+	} else if condManager.HasCondition(vmi, v1.VirtualMachineInstancePausedOnIOError) {
+		c.logger.Object(vmi).V(3).Info("Removing paused on I/O error condition")
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstancePausedOnIOError)
+	}
 }
 
 func dumpTargetFile(vmiName, volName string) string {
@@ -901,6 +952,14 @@ func (c *VirtualMachineController) updateFSFreezeStatus(vmi *v1.VirtualMachineIn
 
 }
 
+func (c *VirtualMachineController) updateSSHHostKeys(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if domain == nil || len(domain.Status.SSHHostKeys) == 0 {
+		return
+	}
+
+	vmi.Status.SSHHostKeys = domain.Status.SSHHostKeys
+}
+
 func IsoGuestVolumePath(namespace, name string, volume *v1.Volume) string {
 	const basepath = "/var/run"
 	switch {
@@ -1028,6 +1087,7 @@ func (c *VirtualMachineController) updateVMIStatusFromDomain(vmi *v1.VirtualMach
 	c.updateGuestInfoFromDomain(vmi, domain)
 	c.updateVolumeStatusesFromDomain(vmi, domain)
 	c.updateFSFreezeStatus(vmi, domain)
+	c.updateSSHHostKeys(vmi, domain)
 	c.updateBackupStatus(vmi, domain)
 	c.updateMachineType(vmi, domain)
 	if err = c.updateMemoryInfo(vmi, domain); err != nil {
@@ -1036,6 +1096,7 @@ func (c *VirtualMachineController) updateVMIStatusFromDomain(vmi *v1.VirtualMach
 	if err = c.cbtHandler.HandleChangedBlockTracking(vmi, domain); err != nil {
 		return err
 	}
+	domain = c.updateAlternativeGuestAgentStatus(vmi, domain)
 	err = c.netStat.UpdateStatus(vmi, domain)
 	return err
 }
@@ -1048,6 +1109,8 @@ func (c *VirtualMachineController) updateVMIConditions(vmi *v1.VirtualMachineIns
 		return err
 	}
 	c.updatePausedConditions(vmi, domain, condManager)
+	c.updateGuestQuiescedCondition(vmi, domain, condManager)
+	c.updateNetworkGatewaysCondition(vmi, condManager)
 
 	return nil
 }
@@ -1142,6 +1205,41 @@ func (c *VirtualMachineController) recordPhaseChangeEvent(vmi *v1.VirtualMachine
 	}
 }
 
+// SetGuestQuiesced records whether the guest filesystems of the VMI were frozen before it was paused.
+// The record is reflected in the GuestQuiesced condition once the domain is paused.
+func (c *VirtualMachineController) SetGuestQuiesced(vmi *v1.VirtualMachineInstance, quiesced bool) {
+	if quiesced {
+		c.quiescedGuests.Store(vmi.UID, struct{}{})
+	} else {
+		c.quiescedGuests.Delete(vmi.UID)
+	}
+}
+
+func (c *VirtualMachineController) updateGuestQuiescedCondition(vmi *v1.VirtualMachineInstance, domain *api.Domain, condManager *controller.VirtualMachineInstanceConditionManager) {
+	if domain == nil || domain.Status.Status != api.Paused {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceGuestQuiesced)
+		return
+	}
+
+	if _, quiesced := c.quiescedGuests.Load(vmi.UID); quiesced && !condManager.HasCondition(vmi, v1.VirtualMachineInstanceGuestQuiesced) {
+		c.logger.Object(vmi).V(3).Info("Adding guest quiesced condition")
+		now := metav1.NewTime(time.Now())
+		vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+			Type:               v1.VirtualMachineInstanceGuestQuiesced,
+			Status:             k8sv1.ConditionTrue,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+			Reason:             "PausedWithGuestSync",
+			Message:            "Guest filesystems were frozen before the VMI was paused",
+		})
+	}
+
+	// The guest agent cannot report the freeze status while the vCPUs are paused
+	if condManager.HasCondition(vmi, v1.VirtualMachineInstanceGuestQuiesced) {
+		vmi.Status.FSFreezeStatus = api.FSFrozen
+	}
+}
+
 func (c *VirtualMachineController) calculatePausedCondition(vmi *v1.VirtualMachineInstance, reason api.StateChangeReason) {
 	now := metav1.NewTime(time.Now())
 	switch reason {
@@ -1520,6 +1618,8 @@ func (c *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstan
 	c.migrationProxy.StopSourceListener(vmiId)
 
 	c.downwardMetricsManager.StopServer(vmi)
+	c.networkGatewayProber.Stop(vmi)
+	c.SetGuestQuiesced(vmi, false)
 
 	// Unmount container disks and clean up remaining files
 	if err := c.containerDiskMounter.Unmount(vmi); err != nil {
@@ -1533,6 +1633,12 @@ func (c *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstan
 		return err
 	}
 
+	if localscratch.IsLocalScratchVMI(vmi) {
+		if err := localscratch.Release(filepath.Join(util.HostRootMount, localscratch.PoolDir), vmiId); err != nil {
+			return err
+		}
+	}
+
 	c.teardownNetwork(vmi)
 
 	c.sriovHotplugExecutorPool.Delete(vmi.UID)
@@ -1792,6 +1898,9 @@ func (c *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachi
 			if !shared {
 				return true, fmt.Errorf("cannot migrate VMI with non-shared HostDisk")
 			}
+		} else if volSrc.Ephemeral != nil && volSrc.Ephemeral.OverlayClaimName != "" {
+			// The overlay is written in place on its PVC, copying it to the target would overwrite it while in use
+			return true, fmt.Errorf("cannot migrate VMI: ephemeral volume %s keeps its overlay on PVC %s", volume.Name, volSrc.Ephemeral.OverlayClaimName)
 		} else {
 			if _, ok := filesystems[volume.Name]; ok {
 				c.logger.Object(vmi).Infof("Volume %s is shared with virtiofs, allow live migration", volume.Name)
@@ -1831,7 +1940,7 @@ func (c *VirtualMachineController) vmUpdateHelperDefault(vmi *v1.VirtualMachineI
 		return fmt.Errorf(unableCreateVirtLauncherConnectionFmt, err)
 	}
 
-	preallocatedVolumes := c.getPreallocatedVolumes(vmi)
+	options := c.DomainOptions(vmi)
 
 	err = hostdisk.ReplacePVCByHostDisk(vmi)
 	if err != nil {
@@ -1854,7 +1963,7 @@ func (c *VirtualMachineController) vmUpdateHelperDefault(vmi *v1.VirtualMachineI
 	}
 
 	// Synchronize the VirtualMachineInstance state
-	err = c.syncVirtualMachine(client, vmi, preallocatedVolumes)
+	err = c.syncVirtualMachine(client, vmi, options)
 	if err != nil {
 		return err
 	}
@@ -1920,6 +2029,12 @@ func (c *VirtualMachineController) handleRunningVMI(vmi *v1.VirtualMachineInstan
 		*errorTolerantFeaturesError = append(*errorTolerantFeaturesError, err)
 	}
 
+	if c.clusterConfig.NetworkGatewayProbesEnabled() {
+		c.networkGatewayProber.Start(vmi, isolationRes.Pid())
+	} else {
+		c.networkGatewayProber.Stop(vmi)
+	}
+
 	return nil
 }
 
@@ -2001,13 +2116,18 @@ func (c *VirtualMachineController) shouldWaitForSEVAttestation(vmi *v1.VirtualMa
 	return false
 }
 
-func (c *VirtualMachineController) syncVirtualMachine(client cmdclient.LauncherClient, vmi *v1.VirtualMachineInstance, preallocatedVolumes []string) error {
+// DomainOptions returns the options virt-launcher needs to convert the VMI
+// spec into a libvirt domain on this node.
+func (c *VirtualMachineController) DomainOptions(vmi *v1.VirtualMachineInstance) *cmdv1.VirtualMachineOptions {
 	smbios := c.clusterConfig.GetSMBIOS()
 	period := c.clusterConfig.GetMemBalloonStatsPeriod()
 
-	options := virtualMachineOptions(smbios, period, preallocatedVolumes, c.capabilities, c.clusterConfig)
+	options := virtualMachineOptions(smbios, period, c.getPreallocatedVolumes(vmi), c.capabilities, c.clusterConfig)
 	options.InterfaceDomainAttachment = domainspec.DomainAttachmentByInterfaceName(vmi.Spec.Domain.Devices.Interfaces, c.clusterConfig.GetNetworkBindings())
+	return options
+}
 
+func (c *VirtualMachineController) syncVirtualMachine(client cmdclient.LauncherClient, vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error {
 	err := client.SyncVirtualMachine(vmi, options)
 	if err != nil {
 		if strings.Contains(err.Error(), "EFI OVMF rom missing") {
@@ -23,6 +23,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"sync"
@@ -63,6 +64,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/util"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
 	virtcache "kubevirt.io/kubevirt/pkg/virt-handler/cache"
 	"kubevirt.io/kubevirt/pkg/virt-handler/cgroup"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
@@ -73,6 +75,7 @@ import (
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
 	notifyserver "kubevirt.io/kubevirt/pkg/virt-handler/notify-server"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/vsock/agent"
 )
 
 var _ = Describe("VirtualMachineInstance", func() {
@@ -200,6 +203,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 			"",  // host cpu model
 			&netConfStub{},
 			&netStatStub{},
+			&networkGatewayProberStub{},
 			cbtHandler,
 		)
 
@@ -987,6 +991,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 			domainStateChangeReason api.StateChangeReason
 			vmiMigrationState       v1.VirtualMachineInstanceMigrationState
 			expectPausedCondition   bool
+			expectIOErrorCondition  bool
 			expectEvents            bool
 		}
 		DescribeTable("when domain is paused", func(td domainIsPausedTest) {
@@ -1037,6 +1042,20 @@ var _ = Describe("VirtualMachineInstance", func() {
 				)),
 			)
 			expectEvent(VMIMigrating, td.expectEvents)
+			if td.expectIOErrorCondition {
+				Expect(updatedVMI.Status.Conditions).To(ContainElements(
+					MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(v1.VirtualMachineInstancePausedOnIOError),
+						"Status": Equal(k8sv1.ConditionTrue)},
+					)),
+				)
+			} else {
+				Expect(updatedVMI.Status.Conditions).ToNot(ContainElements(
+					MatchFields(IgnoreExtras, Fields{
+						"Type": Equal(v1.VirtualMachineInstancePausedOnIOError)},
+					)),
+				)
+			}
 
 			By("unpausing domain")
 			domain = domain.DeepCopy()
@@ -1059,12 +1078,21 @@ var _ = Describe("VirtualMachineInstance", func() {
 					"Type":   Equal(v1.VirtualMachineInstancePaused),
 					"Status": Equal(k8sv1.ConditionTrue)},
 				)))
+			Expect(updatedVMI.Status.Conditions).NotTo(ContainElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Type": Equal(v1.VirtualMachineInstancePausedOnIOError)},
+				)))
 			expectEvent(VMIMigrating, td.expectEvents)
 		},
 			Entry("by user should add and remove paused condition", domainIsPausedTest{
 				domainStateChangeReason: api.ReasonPausedUser,
 				expectPausedCondition:   true,
 			}),
+			Entry("by I/O error should add and remove paused and paused on I/O error conditions", domainIsPausedTest{
+				domainStateChangeReason: api.ReasonPausedIOError,
+				expectPausedCondition:   true,
+				expectIOErrorCondition:  true,
+			}),
 			Entry("by qemu during migration should skip paused condition", domainIsPausedTest{
 				domainStateChangeReason: api.ReasonPausedMigration,
 				expectPausedCondition:   false,
@@ -2160,6 +2188,24 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(blockMigrate).To(BeTrue())
 			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with non-shared HostDisk")))
 		})
+		It("should refuse to migrate an ephemeral volume with an overlay claim", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						Ephemeral: &v1.EphemeralVolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "golden-image"},
+							OverlayClaimName:      "myvolume-overlay",
+						},
+					},
+				},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(MatchError("cannot migrate VMI: ephemeral volume myvolume keeps its overlay on PVC myvolume-overlay"))
+		})
 		DescribeTable("with host model", func(hostCpuModel string) {
 			vmi := api2.NewMinimalVMI("testvmi")
 			vmi.Spec.Domain.CPU = &v1.CPU{Model: v1.CPUModeHostModel}
@@ -2560,6 +2606,255 @@ var _ = Describe("VirtualMachineInstance", func() {
 		})
 	})
 
+	Context("VirtualMachineInstance network gateways condition", func() {
+		var (
+			vmi    *v1.VirtualMachineInstance
+			prober *networkGatewayProberStub
+		)
+
+		BeforeEach(func() {
+			const vmiName = "testvmi"
+			vmi = api2.NewMinimalVMI(vmiName)
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+
+			domain := api.NewMinimalDomainWithUUID(vmiName, vmiTestUUID)
+			domain.Status.Status = api.Running
+
+			prober = &networkGatewayProberStub{}
+			controller.networkGatewayProber = prober
+			addVMI(vmi, domain)
+		})
+
+		It("should report the condition provided by the prober", func() {
+			prober.condition = &v1.VirtualMachineInstanceCondition{
+				Type:    v1.VirtualMachineInstanceNetworkGatewaysReachable,
+				Status:  k8sv1.ConditionFalse,
+				Reason:  v1.VirtualMachineInstanceReasonGatewayUnreachable,
+				Message: "unreachable",
+			}
+			sanityExecute()
+
+			testutils.ExpectEvent(recorder, VMIStarted)
+			updatedVMI, err := virtfakeClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).Get(context.TODO(), vmi.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedVMI.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(v1.VirtualMachineInstanceNetworkGatewaysReachable),
+				"Status": Equal(k8sv1.ConditionFalse),
+				"Reason": Equal(v1.VirtualMachineInstanceReasonGatewayUnreachable),
+			})))
+		})
+
+		It("should remove the condition when the prober does not report one", func() {
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+				Type:   v1.VirtualMachineInstanceNetworkGatewaysReachable,
+				Status: k8sv1.ConditionTrue,
+			}}
+
+			controller.updateNetworkGatewaysCondition(vmi, virtcontroller.NewVirtualMachineInstanceConditionManager())
+			Expect(vmi.Status.Conditions).To(BeEmpty())
+		})
+	})
+
+	Context("VirtualMachineInstance alternative guest agent", func() {
+		const agentPort = 1030
+
+		var (
+			vmi          *v1.VirtualMachineInstance
+			domain       *api.Domain
+			guestAgent   *alternativeGuestAgentStub
+			condManager  *virtcontroller.VirtualMachineInstanceConditionManager
+			dialedPorts  []uint32
+			dialerFailed bool
+		)
+
+		BeforeEach(func() {
+			vmi = api2.NewMinimalVMI("testvmi")
+			vmi.Status.VSOCKCID = pointer.P(uint32(3))
+			domain = api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			condManager = virtcontroller.NewVirtualMachineInstanceConditionManager()
+
+			config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				DeveloperConfiguration: &v1.DeveloperConfiguration{
+					FeatureGates: []string{featuregate.GuestAgentAlternatives},
+				},
+				GuestAgent: &v1.GuestAgentConfiguration{
+					Alternatives: []v1.GuestAgentAlternative{{Name: agent.MinimalAgentName, VSOCKPort: agentPort}},
+				},
+			})
+			controller.clusterConfig = config
+
+			guestAgent = &alternativeGuestAgentStub{info: agent.Info{
+				Name:           agent.MinimalAgentName,
+				Capabilities:   []agent.Capability{agent.CapabilityNetworkInterfaces, agent.CapabilityFSFreeze},
+				FSFreezeStatus: agent.FSFrozen,
+			}}
+			dialedPorts = nil
+			dialerFailed = false
+			controller.guestAgentDialer = func(cid, port uint32) (net.Conn, error) {
+				dialedPorts = append(dialedPorts, port)
+				if dialerFailed {
+					return nil, fmt.Errorf("connection reset by peer")
+				}
+				clientConn, serverConn := net.Pipe()
+				go agent.ServeConn(serverConn, guestAgent)
+				return clientConn, nil
+			}
+		})
+
+		It("should report the negotiated capabilities and the interfaces of the agent", func() {
+			guestAgent.interfaces = []agent.Interface{{Name: "eth0", MAC: "02:00:00:00:00:01", IPAddresses: []string{"10.0.2.2", "fd10:0:2::2"}}}
+
+			updatedDomain := controller.updateAlternativeGuestAgentStatus(vmi, domain)
+
+			Expect(dialedPorts).To(Equal([]uint32{agentPort}))
+			Expect(vmi.Status.Conditions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Type":    Equal(v1.VirtualMachineInstanceAlternativeAgentConnected),
+				"Status":  Equal(k8sv1.ConditionTrue),
+				"Reason":  Equal(agent.MinimalAgentName),
+				"Message": Equal("Capabilities: network-interfaces, fsfreeze"),
+			})))
+			Expect(vmi.Status.FSFreezeStatus).To(Equal(api.FSFrozen))
+			Expect(updatedDomain.Status.Interfaces).To(Equal([]api.InterfaceStatus{{
+				Mac:           "02:00:00:00:00:01",
+				Ip:            "10.0.2.2",
+				IPs:           []string{"10.0.2.2", "fd10:0:2::2"},
+				InterfaceName: "eth0",
+			}}))
+			Expect(domain.Status.Interfaces).To(BeEmpty())
+		})
+
+		It("should update the condition when the capabilities change", func() {
+			controller.updateAlternativeGuestAgentStatus(vmi, domain)
+			guestAgent.info.Capabilities = []agent.Capability{agent.CapabilityNetworkInterfaces}
+
+			controller.updateAlternativeGuestAgentStatus(vmi, domain)
+			Expect(vmi.Status.Conditions).To(HaveLen(1))
+			Expect(vmi.Status.Conditions[0].Message).To(Equal("Capabilities: network-interfaces"))
+		})
+
+		It("should not talk to the alternative agents when qemu-guest-agent is connected", func() {
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+				Type:   v1.VirtualMachineInstanceAlternativeAgentConnected,
+				Status: k8sv1.ConditionTrue,
+			}}
+			domain.Spec.Devices.Channels = []api.Channel{{
+				Type:   "unix",
+				Target: &api.ChannelTarget{Name: "org.qemu.guest_agent.0", State: "connected"},
+			}}
+
+			controller.updateAlternativeGuestAgentStatus(vmi, domain)
+			Expect(dialedPorts).To(BeEmpty())
+			Expect(condManager.HasCondition(vmi, v1.VirtualMachineInstanceAlternativeAgentConnected)).To(BeFalse())
+		})
+
+		It("should remove the condition when the agent does not answer anymore", func() {
+			controller.updateAlternativeGuestAgentStatus(vmi, domain)
+			Expect(condManager.HasCondition(vmi, v1.VirtualMachineInstanceAlternativeAgentConnected)).To(BeTrue())
+
+			dialerFailed = true
+			controller.updateAlternativeGuestAgentStatus(vmi, domain)
+			Expect(condManager.HasCondition(vmi, v1.VirtualMachineInstanceAlternativeAgentConnected)).To(BeFalse())
+		})
+
+		It("should not talk to the alternative agents when the feature gate is disabled", func() {
+			config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				GuestAgent: &v1.GuestAgentConfiguration{
+					Alternatives: []v1.GuestAgentAlternative{{Name: agent.MinimalAgentName, VSOCKPort: agentPort}},
+				},
+			})
+			controller.clusterConfig = config
+
+			controller.updateAlternativeGuestAgentStatus(vmi, domain)
+			Expect(dialedPorts).To(BeEmpty())
+			Expect(vmi.Status.Conditions).To(BeEmpty())
+		})
+
+		It("should only hand out the alternative agent when the VMI uses it", func() {
+			negotiation, err := controller.AlternativeGuestAgent(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(negotiation).To(BeNil())
+
+			controller.updateAlternativeGuestAgentStatus(vmi, domain)
+			negotiation, err = controller.AlternativeGuestAgent(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(negotiation.Agent.Freeze(agent.FreezeArguments{UnfreezeTimeoutSeconds: 10})).To(Succeed())
+			Expect(negotiation.Agent.Close()).To(Succeed())
+			Expect(guestAgent.freezeArgs).To(Equal(&agent.FreezeArguments{UnfreezeTimeoutSeconds: 10}))
+		})
+
+		It("should keep the condition without talking to the agent while the domain is paused", func() {
+			controller.updateAlternativeGuestAgentStatus(vmi, domain)
+			dialedPorts = nil
+
+			domain.Status.Status = api.Paused
+			controller.updateAlternativeGuestAgentStatus(vmi, domain)
+
+			Expect(dialedPorts).To(BeEmpty())
+			Expect(condManager.HasCondition(vmi, v1.VirtualMachineInstanceAlternativeAgentConnected)).To(BeTrue())
+		})
+	})
+
+	Context("VirtualMachineInstance paused with guest sync", func() {
+		var (
+			vmi         *v1.VirtualMachineInstance
+			domain      *api.Domain
+			condManager *virtcontroller.VirtualMachineInstanceConditionManager
+		)
+
+		BeforeEach(func() {
+			vmi = api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			domain = api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Paused
+			domain.Status.Reason = api.ReasonPausedUser
+			condManager = virtcontroller.NewVirtualMachineInstanceConditionManager()
+		})
+
+		It("should add the guest quiesced condition once the domain is paused", func() {
+			controller.SetGuestQuiesced(vmi, true)
+
+			controller.updateGuestQuiescedCondition(vmi, domain, condManager)
+
+			Expect(vmi.Status.Conditions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(v1.VirtualMachineInstanceGuestQuiesced),
+				"Status": Equal(k8sv1.ConditionTrue),
+				"Reason": Equal("PausedWithGuestSync"),
+			})))
+			Expect(vmi.Status.FSFreezeStatus).To(Equal(api.FSFrozen))
+		})
+
+		It("should not add the guest quiesced condition when the guest was not synced", func() {
+			controller.updateGuestQuiescedCondition(vmi, domain, condManager)
+
+			Expect(vmi.Status.Conditions).To(BeEmpty())
+			Expect(vmi.Status.FSFreezeStatus).To(BeEmpty())
+		})
+
+		It("should keep the guest quiesced condition while the domain is paused", func() {
+			controller.SetGuestQuiesced(vmi, true)
+			controller.updateGuestQuiescedCondition(vmi, domain, condManager)
+			controller.SetGuestQuiesced(vmi, false)
+			vmi.Status.FSFreezeStatus = ""
+
+			controller.updateGuestQuiescedCondition(vmi, domain, condManager)
+
+			Expect(condManager.HasCondition(vmi, v1.VirtualMachineInstanceGuestQuiesced)).To(BeTrue())
+			Expect(vmi.Status.FSFreezeStatus).To(Equal(api.FSFrozen))
+		})
+
+		It("should remove the guest quiesced condition once the domain is running", func() {
+			controller.SetGuestQuiesced(vmi, true)
+			controller.updateGuestQuiescedCondition(vmi, domain, condManager)
+
+			domain.Status.Status = api.Running
+			controller.updateGuestQuiescedCondition(vmi, domain, condManager)
+
+			Expect(vmi.Status.Conditions).To(BeEmpty())
+		})
+	})
+
 	Context("VirtualMachineInstance controller gets informed about changes in a Domain", func() {
 		It("should update Guest OS Information in VMI status", func() {
 			vmi := api2.NewMinimalVMI("testvmi")
@@ -2611,6 +2906,37 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(updatedVMI.Status.GuestOSInfo.KernelVersion).To(Equal(domain.Status.OSInfo.KernelVersion))
 		})
 
+		It("should not store Guest OS Information in VMI status when it is redacted", func() {
+			config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				GuestAgent: &v1.GuestAgentConfiguration{
+					DataPolicies: []v1.GuestAgentDataPolicy{{
+						Namespaces: []string{metav1.NamespaceDefault},
+						Redact:     []v1.GuestAgentDataKind{v1.GuestAgentDataOSInfo},
+					}},
+				},
+			})
+			controller.clusterConfig = config
+
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			vmi.Status.GuestOSInfo = v1.VirtualMachineInstanceGuestOSInfo{ID: "fedora", Name: "Fedora Linux"}
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Status.OSInfo = api.GuestOSInfo{Id: "fedora", Name: "Fedora Linux"}
+
+			addVMI(vmi, domain)
+
+			sanityExecute()
+
+			testutils.ExpectEvent(recorder, VMIStarted)
+			updatedVMI, err := virtfakeClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).Get(context.TODO(), vmi.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedVMI.Status.GuestOSInfo).To(Equal(v1.VirtualMachineInstanceGuestOSInfo{}))
+		})
+
 		It("should update Guest FSFreeze Status in VMI status if fs frozen", func() {
 			vmi := api2.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -2655,6 +2981,27 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(updatedVMI.Status.FSFreezeStatus).To(BeEmpty())
 		})
 
+		It("should update Guest SSH host keys in VMI status", func() {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			sshHostKeys := []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHostKey"}
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Status.SSHHostKeys = sshHostKeys
+
+			addVMI(vmi, domain)
+
+			sanityExecute()
+
+			testutils.ExpectEvent(recorder, VMIStarted)
+			updatedVMI, err := virtfakeClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).Get(context.TODO(), vmi.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedVMI.Status.SSHHostKeys).To(Equal(sshHostKeys))
+		})
+
 		It("should update Memory information in VMI status", func() {
 			initialMemory := resource.MustParse("128Ki")
 			vmi := api2.NewMinimalVMI("testvmi")
@@ -3246,6 +3593,40 @@ func (ns *netStatStub) UpdateStatus(vmi *v1.VirtualMachineInstance, domain *api.
 
 func (ns *netStatStub) Teardown(_ *v1.VirtualMachineInstance) {}
 
+type networkGatewayProberStub struct {
+	condition *v1.VirtualMachineInstanceCondition
+}
+
+func (*networkGatewayProberStub) Run(_ chan struct{}, _ func(string))       {}
+func (*networkGatewayProberStub) Start(_ *v1.VirtualMachineInstance, _ int) {}
+func (*networkGatewayProberStub) Stop(_ *v1.VirtualMachineInstance)         {}
+func (p *networkGatewayProberStub) Condition(_ *v1.VirtualMachineInstance) *v1.VirtualMachineInstanceCondition {
+	return p.condition
+}
+
+type alternativeGuestAgentStub struct {
+	info       agent.Info
+	interfaces []agent.Interface
+	freezeArgs *agent.FreezeArguments
+}
+
+func (a *alternativeGuestAgentStub) Info() (*agent.Info, error) {
+	return &a.info, nil
+}
+
+func (a *alternativeGuestAgentStub) Interfaces() ([]agent.Interface, error) {
+	return a.interfaces, nil
+}
+
+func (a *alternativeGuestAgentStub) Freeze(args agent.FreezeArguments) error {
+	a.freezeArgs = &args
+	return nil
+}
+
+func (a *alternativeGuestAgentStub) Thaw() error {
+	return nil
+}
+
 func newFakeManager() *fakeManager {
 	return &fakeManager{}
 }
@@ -0,0 +1,199 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package volume_permissions
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
+	"kubevirt.io/kubevirt/pkg/safepath"
+	"kubevirt.io/kubevirt/pkg/storage/types"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+const (
+	selinuxXattr         = "security.selinux"
+	containerFileType    = "container_file_t"
+	unprivilegedMCSLevel = "s0"
+	diskImageName        = "disk.img"
+)
+
+// Result lists what was changed on the volumes of a VMI and which
+// mismatches were left in place because the policy does not allow to repair them.
+type Result struct {
+	Repaired   []string
+	Mismatches []string
+}
+
+type Repairer struct {
+	policy       v1.VolumePermissionRepairPolicy
+	setOwnership func(path *safepath.Path) error
+	relabel      func(path *safepath.Path) error
+}
+
+func NewRepairer(policy v1.VolumePermissionRepairPolicy, setOwnership, relabel func(path *safepath.Path) error) *Repairer {
+	return &Repairer{
+		policy:       policy,
+		setOwnership: setOwnership,
+		relabel:      relabel,
+	}
+}
+
+// Repair checks that the disk images of the filesystem PVCs and DataVolumes of the VMI,
+// as seen from the given launcher mount root, can be accessed by the qemu user.
+// Depending on the policy mismatching ownership and SELinux labels are repaired.
+func (r *Repairer) Repair(vmi *v1.VirtualMachineInstance, root *safepath.Path) (Result, error) {
+	result := Result{}
+
+	rootLevel := ""
+	if rootLabel, err := safepath.GetxattrNoFollow(root, selinuxXattr); err == nil {
+		_, rootLevel = parseLabel(string(rootLabel))
+	}
+
+	for _, volumeName := range filesystemVolumes(vmi) {
+		diskPath, err := root.AppendAndResolveWithRelativeRoot(hostdisk.GetMountedHostDiskPath(volumeName, diskImageName))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// The image will be created by virt-launcher
+				continue
+			}
+			return result, fmt.Errorf("failed to resolve disk image of volume %s: %v", volumeName, err)
+		}
+
+		if err := r.checkOwnership(volumeName, diskPath, &result); err != nil {
+			return result, err
+		}
+		if err := r.checkSELinuxLabel(volumeName, diskPath, rootLevel, &result); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func (r *Repairer) checkOwnership(volumeName string, diskPath *safepath.Path, result *Result) error {
+	fileInfo, err := safepath.StatAtNoFollow(diskPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat disk image of volume %s: %v", volumeName, err)
+	}
+	stat, ok := fileInfo.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("failed to convert stat info of disk image of volume %s", volumeName)
+	}
+	if isAccessible(stat.Uid, stat.Gid, fileInfo.Mode()) {
+		return nil
+	}
+
+	if r.policy == v1.VolumePermissionRepairDisabled {
+		result.Mismatches = append(result.Mismatches, fmt.Sprintf("volume %s is owned by %d:%d with mode %o", volumeName, stat.Uid, stat.Gid, fileInfo.Mode().Perm()))
+		return nil
+	}
+	if err := r.setOwnership(diskPath); err != nil {
+		return fmt.Errorf("failed to repair ownership of volume %s: %v", volumeName, err)
+	}
+	result.Repaired = append(result.Repaired, fmt.Sprintf("changed owner of volume %s from %d:%d to %d:%d", volumeName, stat.Uid, stat.Gid, util.NonRootUID, util.NonRootUID))
+	return nil
+}
+
+func (r *Repairer) checkSELinuxLabel(volumeName string, diskPath *safepath.Path, rootLevel string, result *Result) error {
+	label, err := safepath.GetxattrNoFollow(diskPath, selinuxXattr)
+	if err != nil {
+		// Labels are not supported or SELinux is not in use
+		return nil
+	}
+	labelType, level := parseLabel(string(label))
+	if labelType == containerFileType && (level == unprivilegedMCSLevel || level == rootLevel) {
+		return nil
+	}
+
+	if r.policy != v1.VolumePermissionRepairOwnershipAndSELinux {
+		result.Mismatches = append(result.Mismatches, fmt.Sprintf("volume %s is labeled %s", volumeName, label))
+		return nil
+	}
+	if err := r.relabel(diskPath); err != nil {
+		return fmt.Errorf("failed to repair SELinux label of volume %s: %v", volumeName, err)
+	}
+	result.Repaired = append(result.Repaired, fmt.Sprintf("relabeled volume %s from %s", volumeName, label))
+	return nil
+}
+
+func isAccessible(uid, gid uint32, mode os.FileMode) bool {
+	const (
+		groupReadWrite = 0060
+		otherReadWrite = 0006
+	)
+	switch {
+	case uid == util.NonRootUID:
+		return true
+	case gid == util.NonRootUID && mode.Perm()&groupReadWrite == groupReadWrite:
+		return true
+	default:
+		return mode.Perm()&otherReadWrite == otherReadWrite
+	}
+}
+
+// parseLabel returns the type and the MCS level of a SELinux label like
+// system_u:object_r:container_file_t:s0:c1,c2
+func parseLabel(label string) (labelType, level string) {
+	parts := strings.SplitN(label, ":", 4)
+	if len(parts) < 3 {
+		return "", ""
+	}
+	if len(parts) == 4 {
+		level = parts[3]
+	}
+	return parts[2], level
+}
+
+func filesystemVolumes(vmi *v1.VirtualMachineInstance) []string {
+	volumeStatuses := map[string]v1.VolumeStatus{}
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		volumeStatuses[volumeStatus.Name] = volumeStatus
+	}
+	filesystems := map[string]struct{}{}
+	for _, fs := range vmi.Spec.Domain.Devices.Filesystems {
+		filesystems[fs.Name] = struct{}{}
+	}
+
+	var names []string
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil && volume.DataVolume == nil {
+			continue
+		}
+		if _, isFilesystem := filesystems[volume.Name]; isFilesystem {
+			continue
+		}
+		volumeStatus, exists := volumeStatuses[volume.Name]
+		if !exists || volumeStatus.PersistentVolumeClaimInfo == nil || volumeStatus.HotplugVolume != nil {
+			continue
+		}
+		if types.IsPVCBlock(volumeStatus.PersistentVolumeClaimInfo.VolumeMode) {
+			continue
+		}
+		names = append(names, volume.Name)
+	}
+	return names
+}
@@ -0,0 +1,159 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package volume_permissions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
+	"kubevirt.io/kubevirt/pkg/safepath"
+)
+
+var _ = Describe("Volume permission repair", func() {
+	var (
+		tmpDir       string
+		root         *safepath.Path
+		chowned      []string
+		relabeled    []string
+		setOwnership func(path *safepath.Path) error
+		relabel      func(path *safepath.Path) error
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir = GinkgoT().TempDir()
+		root, err = safepath.JoinAndResolveWithRelativeRoot(tmpDir)
+		Expect(err).ToNot(HaveOccurred())
+
+		chowned = nil
+		relabeled = nil
+		setOwnership = func(path *safepath.Path) error {
+			chowned = append(chowned, path.String())
+			return nil
+		}
+		relabel = func(path *safepath.Path) error {
+			relabeled = append(relabeled, path.String())
+			return nil
+		}
+	})
+
+	createDiskImage := func(volumeName string, mode os.FileMode) {
+		diskPath := filepath.Join(tmpDir, hostdisk.GetMountedHostDiskPath(volumeName, diskImageName))
+		Expect(os.MkdirAll(filepath.Dir(diskPath), 0755)).To(Succeed())
+		Expect(os.WriteFile(diskPath, nil, mode)).To(Succeed())
+		Expect(os.Chmod(diskPath, mode)).To(Succeed())
+	}
+
+	newVMI := func(volumeMode k8sv1.PersistentVolumeMode, volumeNames ...string) *v1.VirtualMachineInstance {
+		vmi := &v1.VirtualMachineInstance{}
+		for _, name := range volumeNames {
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: name,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{},
+				},
+			})
+			vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+				Name: name,
+				PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+					VolumeMode: &volumeMode,
+				},
+			})
+		}
+		return vmi
+	}
+
+	It("should only report mismatching ownership when repair is disabled", func() {
+		createDiskImage("disk0", 0600)
+		vmi := newVMI(k8sv1.PersistentVolumeFilesystem, "disk0")
+
+		result, err := NewRepairer(v1.VolumePermissionRepairDisabled, setOwnership, relabel).Repair(vmi, root)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Repaired).To(BeEmpty())
+		Expect(result.Mismatches).To(ConsistOf(ContainSubstring("volume disk0 is owned by")))
+		Expect(chowned).To(BeEmpty())
+	})
+
+	It("should repair mismatching ownership", func() {
+		createDiskImage("disk0", 0600)
+		vmi := newVMI(k8sv1.PersistentVolumeFilesystem, "disk0")
+
+		result, err := NewRepairer(v1.VolumePermissionRepairOwnership, setOwnership, relabel).Repair(vmi, root)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Mismatches).To(BeEmpty())
+		Expect(result.Repaired).To(ConsistOf(ContainSubstring("changed owner of volume disk0")))
+		Expect(chowned).To(ConsistOf(HaveSuffix(filepath.Join("disk0", diskImageName))))
+	})
+
+	It("should fail if the ownership cannot be repaired", func() {
+		createDiskImage("disk0", 0600)
+		vmi := newVMI(k8sv1.PersistentVolumeFilesystem, "disk0")
+		setOwnership = func(_ *safepath.Path) error {
+			return fmt.Errorf("chown failed")
+		}
+
+		_, err := NewRepairer(v1.VolumePermissionRepairOwnership, setOwnership, relabel).Repair(vmi, root)
+		Expect(err).To(MatchError(ContainSubstring("failed to repair ownership of volume disk0: chown failed")))
+	})
+
+	It("should skip accessible, missing and block volumes", func() {
+		createDiskImage("accessible", 0666)
+		vmi := newVMI(k8sv1.PersistentVolumeFilesystem, "accessible", "missing")
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, newVMI(k8sv1.PersistentVolumeBlock, "block").Spec.Volumes...)
+		vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, newVMI(k8sv1.PersistentVolumeBlock, "block").Status.VolumeStatus...)
+
+		result, err := NewRepairer(v1.VolumePermissionRepairOwnershipAndSELinux, setOwnership, relabel).Repair(vmi, root)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Repaired).To(BeEmpty())
+		Expect(result.Mismatches).To(BeEmpty())
+		Expect(chowned).To(BeEmpty())
+		Expect(relabeled).To(BeEmpty())
+	})
+
+	DescribeTable("should determine if the qemu user can access a file", func(uid, gid uint32, mode os.FileMode, expected bool) {
+		Expect(isAccessible(uid, gid, mode)).To(Equal(expected))
+	},
+		Entry("owned by qemu", uint32(107), uint32(0), os.FileMode(0600), true),
+		Entry("group qemu with read-write", uint32(0), uint32(107), os.FileMode(0660), true),
+		Entry("group qemu read-only", uint32(0), uint32(107), os.FileMode(0640), false),
+		Entry("world read-write", uint32(0), uint32(0), os.FileMode(0666), true),
+		Entry("owned by root", uint32(0), uint32(0), os.FileMode(0644), false),
+	)
+
+	DescribeTable("should parse SELinux labels", func(label, expectedType, expectedLevel string) {
+		labelType, level := parseLabel(label)
+		Expect(labelType).To(Equal(expectedType))
+		Expect(level).To(Equal(expectedLevel))
+	},
+		Entry("with categories", "system_u:object_r:container_file_t:s0:c1,c2", "container_file_t", "s0:c1,c2"),
+		Entry("without categories", "system_u:object_r:nfs_t:s0", "nfs_t", "s0"),
+		Entry("without level", "system_u:object_r:nfs_t", "nfs_t", ""),
+		Entry("invalid", "unlabeled", "", ""),
+	)
+})
@@ -48,14 +48,6 @@ type openReturn struct {
 	Return int `json:"return"`
 }
 
-type readReturnData struct {
-	Count  int    `json:"count"`
-	BufB64 string `json:"buf-b64"`
-}
-type readReturn struct {
-	Return readReturnData `json:"return"`
-}
-
 type AccessCredentialManager struct {
 	virConn cli.Connection
 
@@ -171,47 +163,7 @@ func (l *AccessCredentialManager) writeGuestFile(contents, domName, filePath, ow
 }
 
 func (l *AccessCredentialManager) readGuestFile(domName, filePath string) (string, error) {
-	contents := ""
-
-	cmdOpenFile := fmt.Sprintf(`{"execute": "guest-file-open", "arguments": { "path": %q, "mode":"r" } }`, filePath)
-	output, err := l.virConn.QemuAgentCommand(cmdOpenFile, domName)
-	if err != nil {
-		return contents, err
-	}
-
-	openRes := &openReturn{}
-	err = json.Unmarshal([]byte(output), openRes)
-	if err != nil {
-		return contents, err
-	}
-
-	cmdReadFile := fmt.Sprintf(`{"execute": "guest-file-read", "arguments": { "handle": %d } }`, openRes.Return)
-	readOutput, err := l.virConn.QemuAgentCommand(cmdReadFile, domName)
-	if err != nil {
-		return contents, err
-	}
-
-	readRes := &readReturn{}
-	err = json.Unmarshal([]byte(readOutput), readRes)
-	if err != nil {
-		return contents, err
-	}
-
-	if readRes.Return.Count > 0 {
-		readBytes, decodingErr := base64.StdEncoding.DecodeString(readRes.Return.BufB64)
-		if decodingErr != nil {
-			return contents, decodingErr
-		}
-		contents = string(readBytes)
-	}
-
-	cmdCloseFile := fmt.Sprintf(`{"execute": "guest-file-close", "arguments": { "handle": %d } }`, openRes.Return)
-	_, err = l.virConn.QemuAgentCommand(cmdCloseFile, domName)
-	if err != nil {
-		return contents, err
-	}
-
-	return contents, nil
+	return agent.GuestReadFile(l.virConn, domName, filePath)
 }
 
 func (l *AccessCredentialManager) agentGuestExec(domName, command string, args []string) (string, error) {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"kubevirt.io/client-go/log"
 
@@ -74,6 +75,22 @@ func ParseFSFreezeStatus(agentReply string) (api.FSFreeze, error) {
 	}, nil
 }
 
+// parseSSHHostKeys extracts the "<key type> <base64 key>" pairs from the
+// contents of an OpenSSH public key file, dropping the comments
+func parseSSHHostKeys(contents string) []string {
+	keys := []string{}
+	for _, line := range strings.Split(contents, "\n") {
+		fields := strings.Fields(line)
+		const minKeyFields = 2
+		if len(fields) < minKeyFields || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		keys = append(keys, fields[0]+" "+fields[1])
+	}
+
+	return keys
+}
+
 // parseFilesystem from the agent response
 func parseFilesystem(agentReply string) ([]api.Filesystem, error) {
 	result := []Filesystem{}
@@ -46,6 +46,12 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(err).To(HaveOccurred(), "FSFreezeStatus should not be parsed")
 		})
 
+		It("should parse SSH host keys and drop the comments", func() {
+			contents := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHostKey root@fedora\n\n# comment\n"
+
+			Expect(parseSSHHostKeys(contents)).To(Equal([]string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHostKey"}))
+		})
+
 		It("should parse Agent", func() {
 			jsonInput := `{
                 "return":{
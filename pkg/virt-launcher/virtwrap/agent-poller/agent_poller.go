@@ -29,6 +29,7 @@ import (
 
 	"kubevirt.io/client-go/log"
 
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
@@ -44,12 +45,21 @@ const (
 	GetFilesystem     AgentCommand = "guest-get-fsinfo"
 	GetAgent          AgentCommand = "guest-info"
 	GetFSFreezeStatus AgentCommand = "guest-fsfreeze-status"
+	// GetSSHHostKeys has no guest agent counterpart, the keys are read from the
+	// guest with the guest-file-* commands
+	GetSSHHostKeys AgentCommand = "ssh-host-keys"
 
 	pollInitialInterval = 10 * time.Second
 
 	repeatingLogLevel = 3
 )
 
+var sshHostKeyFiles = []string{
+	"/etc/ssh/ssh_host_ecdsa_key.pub",
+	"/etc/ssh/ssh_host_ed25519_key.pub",
+	"/etc/ssh/ssh_host_rsa_key.pub",
+}
+
 // AgentUpdatedEvent fire up when data is changes in the store
 type AgentUpdatedEvent struct {
 	DomainInfo api.DomainGuestInfo
@@ -82,7 +92,7 @@ func (s *AsyncAgentStore) Store(key, value any) {
 
 	domainInfo := api.DomainGuestInfo{}
 	switch key {
-	case libvirt.DOMAIN_GUEST_INFO_OS, libvirt.DOMAIN_GUEST_INFO_INTERFACES, GetFSFreezeStatus:
+	case libvirt.DOMAIN_GUEST_INFO_OS, libvirt.DOMAIN_GUEST_INFO_INTERFACES, GetFSFreezeStatus, GetSSHHostKeys:
 		updated := (oldData == nil) || !equality.Semantic.DeepEqual(oldData, value)
 		if !updated {
 			return
@@ -90,6 +100,7 @@ func (s *AsyncAgentStore) Store(key, value any) {
 		domainInfo.OSInfo = s.GetGuestOSInfo()
 		domainInfo.Interfaces = s.GetInterfaceStatus()
 		domainInfo.FSFreezeStatus = s.GetFSFreezeStatus()
+		domainInfo.SSHHostKeys = s.GetSSHHostKeys()
 
 		s.AgentUpdated <- AgentUpdatedEvent{
 			DomainInfo: domainInfo,
@@ -172,6 +183,16 @@ func (s *AsyncAgentStore) GetFSFreezeStatus() *api.FSFreeze {
 	return &fsfreezeStatus
 }
 
+// GetSSHHostKeys returns the SSH host public keys found in the guest
+func (s *AsyncAgentStore) GetSSHHostKeys() []string {
+	data, ok := s.store.Load(GetSSHHostKeys)
+	if !ok {
+		return nil
+	}
+
+	return data.([]string)
+}
+
 // GetFS returns the filesystem list limited to the limit set
 // set limit to -1 to return the whole list
 func (s *AsyncAgentStore) GetFS(limit int) []api.Filesystem {
@@ -303,7 +324,7 @@ func CreatePoller(
 			// Polling for QEMU agent commands
 			{
 				CallTick:      qemuAgentVersionInterval,
-				AgentCommands: []AgentCommand{GetAgent},
+				AgentCommands: []AgentCommand{GetAgent, GetSSHHostKeys},
 			},
 			{
 				CallTick:      qemuAgentFileInterval,
@@ -413,6 +434,11 @@ func executeAgentCommands(commands []AgentCommand, agentPoller *AgentPoller) {
 	log.Log.V(repeatingLogLevel).Infof("Polling command: %v", commands)
 
 	for _, command := range commands {
+		if command == GetSSHHostKeys {
+			fetchAndStoreSSHHostKeys(agentPoller)
+			continue
+		}
+
 		cmdResult, err := agentPoller.Connection.QemuAgentCommand(`{"execute":"`+string(command)+`"}`, agentPoller.domainName)
 		if err != nil {
 			// skip the command on error, it is not vital
@@ -445,6 +471,26 @@ func executeAgentCommands(commands []AgentCommand, agentPoller *AgentPoller) {
 	}
 }
 
+// fetchAndStoreSSHHostKeys reads the OpenSSH host public keys from their default
+// location in the guest. Guests without an SSH server or without the guest-file-*
+// commands enabled simply do not report any key.
+func fetchAndStoreSSHHostKeys(agentPoller *AgentPoller) {
+	var keys []string
+	for _, keyFile := range sshHostKeyFiles {
+		contents, err := agent.GuestReadFile(agentPoller.Connection, agentPoller.domainName, keyFile)
+		if err != nil {
+			log.Log.V(repeatingLogLevel).Infof("Cannot read SSH host key %s: %v", keyFile, err)
+			continue
+		}
+		keys = append(keys, parseSSHHostKeys(contents)...)
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+	agentPoller.agentStore.Store(GetSSHHostKeys, keys)
+}
+
 func fetchAndStoreGuestInfo(infoTypes libvirt.DomainGuestInfoTypes, agentPoller *AgentPoller) {
 	log.Log.Infof("Polling API operations: %v", infoTypes)
 
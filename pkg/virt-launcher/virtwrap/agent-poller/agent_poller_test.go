@@ -20,6 +20,9 @@
 package agentpoller
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -105,6 +108,66 @@ var _ = Describe("Qemu agent poller", func() {
 		})
 	})
 
+	Context("with SSH host keys", func() {
+		const (
+			ed25519KeyFile = "/etc/ssh/ssh_host_ed25519_key.pub"
+			ed25519Key     = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHostKey"
+		)
+
+		var agentPoller *AgentPoller
+
+		BeforeEach(func() {
+			agentPoller = &AgentPoller{
+				Connection: mockLibvirt.VirtConnection,
+				domainName: "fake",
+				agentStore: &agentStore,
+			}
+		})
+
+		expectReadFile := func(path, contents string) {
+			mockLibvirt.ConnectionEXPECT().QemuAgentCommand(
+				fmt.Sprintf(`{"execute": "guest-file-open", "arguments": { "path": %q, "mode":"r" } }`, path), "fake",
+			).Return(`{"return": 1}`, nil)
+			mockLibvirt.ConnectionEXPECT().QemuAgentCommand(
+				`{"execute": "guest-file-read", "arguments": { "handle": 1 } }`, "fake",
+			).Return(fmt.Sprintf(`{"return": {"count": %d, "buf-b64": %q}}`,
+				len(contents), base64.StdEncoding.EncodeToString([]byte(contents))), nil)
+			mockLibvirt.ConnectionEXPECT().QemuAgentCommand(
+				`{"execute": "guest-file-close", "arguments": { "handle": 1 } }`, "fake",
+			).Return(`{"return": {}}`, nil)
+		}
+
+		expectMissingFile := func(path string) {
+			mockLibvirt.ConnectionEXPECT().QemuAgentCommand(
+				fmt.Sprintf(`{"execute": "guest-file-open", "arguments": { "path": %q, "mode":"r" } }`, path), "fake",
+			).Return("", errors.New("No such file or directory"))
+		}
+
+		It("should store the host keys found in the guest and fire an event", func() {
+			expectMissingFile("/etc/ssh/ssh_host_ecdsa_key.pub")
+			expectReadFile(ed25519KeyFile, ed25519Key+" root@fedora\n")
+			expectMissingFile("/etc/ssh/ssh_host_rsa_key.pub")
+
+			executeAgentCommands([]AgentCommand{GetSSHHostKeys}, agentPoller)
+
+			Expect(agentStore.GetSSHHostKeys()).To(Equal([]string{ed25519Key}))
+			Expect(agentStore.AgentUpdated).To(Receive(Equal(AgentUpdatedEvent{
+				DomainInfo: api.DomainGuestInfo{SSHHostKeys: []string{ed25519Key}},
+			})))
+		})
+
+		It("should not store anything when no host key can be read", func() {
+			for _, keyFile := range sshHostKeyFiles {
+				expectMissingFile(keyFile)
+			}
+
+			executeAgentCommands([]AgentCommand{GetSSHHostKeys}, agentPoller)
+
+			Expect(agentStore.GetSSHHostKeys()).To(BeNil())
+			Expect(agentStore.AgentUpdated).ToNot(Receive())
+		})
+	})
+
 	Context("with AsyncAgentStore", func() {
 		It("should store and load the data", func() {
 			agentVersion := AgentInfo{Version: "4.1"}
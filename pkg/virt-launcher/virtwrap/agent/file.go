@@ -0,0 +1,86 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package agent
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+type openReturn struct {
+	Return int `json:"return"`
+}
+
+type readReturnData struct {
+	Count  int    `json:"count"`
+	BufB64 string `json:"buf-b64"`
+}
+type readReturn struct {
+	Return readReturnData `json:"return"`
+}
+
+// GuestReadFile reads the file at filePath inside the guest using the guest-file-* agent commands
+// and returns its contents
+func GuestReadFile(virConn cli.Connection, domName, filePath string) (string, error) {
+	contents := ""
+
+	cmdOpenFile := fmt.Sprintf(`{"execute": "guest-file-open", "arguments": { "path": %q, "mode":"r" } }`, filePath)
+	output, err := virConn.QemuAgentCommand(cmdOpenFile, domName)
+	if err != nil {
+		return contents, err
+	}
+
+	openRes := &openReturn{}
+	err = json.Unmarshal([]byte(output), openRes)
+	if err != nil {
+		return contents, err
+	}
+
+	cmdReadFile := fmt.Sprintf(`{"execute": "guest-file-read", "arguments": { "handle": %d } }`, openRes.Return)
+	readOutput, err := virConn.QemuAgentCommand(cmdReadFile, domName)
+	if err != nil {
+		return contents, err
+	}
+
+	readRes := &readReturn{}
+	err = json.Unmarshal([]byte(readOutput), readRes)
+	if err != nil {
+		return contents, err
+	}
+
+	if readRes.Return.Count > 0 {
+		readBytes, decodingErr := base64.StdEncoding.DecodeString(readRes.Return.BufB64)
+		if decodingErr != nil {
+			return contents, decodingErr
+		}
+		contents = string(readBytes)
+	}
+
+	cmdCloseFile := fmt.Sprintf(`{"execute": "guest-file-close", "arguments": { "handle": %d } }`, openRes.Return)
+	_, err = virConn.QemuAgentCommand(cmdCloseFile, domName)
+	if err != nil {
+		return contents, err
+	}
+
+	return contents, nil
+}
@@ -124,6 +124,80 @@ func (in *Arg) DeepCopy() *Arg {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Audio) DeepCopyInto(out *Audio) {
+	*out = *in
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = new(AudioOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Audio.
+func (in *Audio) DeepCopy() *Audio {
+	if in == nil {
+		return nil
+	}
+	out := new(Audio)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AudioOutput) DeepCopyInto(out *AudioOutput) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(AudioSettings)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AudioOutput.
+func (in *AudioOutput) DeepCopy() *AudioOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(AudioOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AudioRef) DeepCopyInto(out *AudioRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AudioRef.
+func (in *AudioRef) DeepCopy() *AudioRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AudioRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AudioSettings) DeepCopyInto(out *AudioSettings) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AudioSettings.
+func (in *AudioSettings) DeepCopy() *AudioSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(AudioSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BIOS) DeepCopyInto(out *BIOS) {
 	*out = *in
@@ -1013,6 +1087,13 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Audios != nil {
+		in, out := &in.Audios, &out.Audios
+		*out = make([]Audio, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.TPMs != nil {
 		in, out := &in.TPMs, &out.TPMs
 		*out = make([]TPM, len(*in))
@@ -1394,6 +1475,11 @@ func (in *DomainGuestInfo) DeepCopyInto(out *DomainGuestInfo) {
 		*out = new(FSFreeze)
 		**out = **in
 	}
+	if in.SSHHostKeys != nil {
+		in, out := &in.SSHHostKeys, &out.SSHHostKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1514,6 +1600,11 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 		*out = new(IOThreads)
 		**out = **in
 	}
+	if in.IOThreadIDs != nil {
+		in, out := &in.IOThreadIDs, &out.IOThreadIDs
+		*out = new(IOThreadIDs)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LaunchSecurity != nil {
 		in, out := &in.LaunchSecurity, &out.LaunchSecurity
 		*out = new(LaunchSecurity)
@@ -1544,6 +1635,11 @@ func (in *DomainStatus) DeepCopyInto(out *DomainStatus) {
 	}
 	out.OSInfo = in.OSInfo
 	out.FSFreezeStatus = in.FSFreezeStatus
+	if in.SSHHostKeys != nil {
+		in, out := &in.SSHHostKeys, &out.SSHHostKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2104,6 +2200,11 @@ func (in *Graphics) DeepCopyInto(out *Graphics) {
 		*out = new(GraphicsListen)
 		**out = **in
 	}
+	if in.Audio != nil {
+		in, out := &in.Audio, &out.Audio
+		*out = new(AudioRef)
+		**out = **in
+	}
 	return
 }
 
@@ -2256,6 +2357,43 @@ func (in *HugePages) DeepCopy() *HugePages {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOThreadID) DeepCopyInto(out *IOThreadID) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOThreadID.
+func (in *IOThreadID) DeepCopy() *IOThreadID {
+	if in == nil {
+		return nil
+	}
+	out := new(IOThreadID)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOThreadIDs) DeepCopyInto(out *IOThreadIDs) {
+	*out = *in
+	if in.IOThreads != nil {
+		in, out := &in.IOThreads, &out.IOThreads
+		*out = make([]IOThreadID, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOThreadIDs.
+func (in *IOThreadIDs) DeepCopy() *IOThreadIDs {
+	if in == nil {
+		return nil
+	}
+	out := new(IOThreadIDs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IOThreads) DeepCopyInto(out *IOThreads) {
 	*out = *in
@@ -2960,6 +3098,11 @@ func (in *MigrationMetadata) DeepCopyInto(out *MigrationMetadata) {
 		in, out := &in.EndTimestamp, &out.EndTimestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.TransferStatus != nil {
+		in, out := &in.TransferStatus, &out.TransferStatus
+		*out = new(MigrationTransferStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2973,6 +3116,26 @@ func (in *MigrationMetadata) DeepCopy() *MigrationMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationTransferStatus) DeepCopyInto(out *MigrationTransferStatus) {
+	*out = *in
+	if in.UpdateTimestamp != nil {
+		in, out := &in.UpdateTimestamp, &out.UpdateTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationTransferStatus.
+func (in *MigrationTransferStatus) DeepCopy() *MigrationTransferStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationTransferStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Model) DeepCopyInto(out *Model) {
 	*out = *in
@@ -3631,6 +3794,11 @@ func (in *SoundCard) DeepCopyInto(out *SoundCard) {
 		*out = new(Alias)
 		**out = **in
 	}
+	if in.Audio != nil {
+		in, out := &in.Audio, &out.Audio
+		*out = new(AudioRef)
+		**out = **in
+	}
 	return
 }
 
@@ -122,6 +122,7 @@ type DomainStatus struct {
 	Interfaces     []InterfaceStatus
 	OSInfo         GuestOSInfo
 	FSFreezeStatus FSFreeze
+	SSHHostKeys    []string
 }
 
 // GuestPanicInfo contains details about a guest panic event from QEMU
@@ -199,6 +200,7 @@ type DomainGuestInfo struct {
 	Interfaces     []InterfaceStatus
 	OSInfo         *GuestOSInfo
 	FSFreezeStatus *FSFreeze
+	SSHHostKeys    []string
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -235,6 +237,7 @@ type DomainSpec struct {
 	CPUTune        *CPUTune        `xml:"cputune"`
 	NUMATune       *NUMATune       `xml:"numatune"`
 	IOThreads      *IOThreads      `xml:"iothreads,omitempty"`
+	IOThreadIDs    *IOThreadIDs    `xml:"iothreadids,omitempty"`
 	LaunchSecurity *LaunchSecurity `xml:"launchSecurity,omitempty"`
 	OnReboot       string          `xml:"on_reboot,omitempty"`
 }
@@ -442,6 +445,18 @@ type MigrationMetadata struct {
 	FailureReason  string           `xml:"failureReason,omitempty"`
 	AbortStatus    string           `xml:"abortStatus,omitempty"`
 	Mode           v1.MigrationMode `xml:"mode,omitempty"`
+	// TransferStatus is refreshed periodically by the migration monitor of the source
+	TransferStatus *MigrationTransferStatus `xml:"transferStatus,omitempty"`
+}
+
+type MigrationTransferStatus struct {
+	DataProcessed   uint64       `xml:"dataProcessed,omitempty"`
+	DataRemaining   uint64       `xml:"dataRemaining,omitempty"`
+	DataTotal       uint64       `xml:"dataTotal,omitempty"`
+	MemoryDirtyRate uint64       `xml:"memoryDirtyRate,omitempty"`
+	MemoryBandwidth uint64       `xml:"memoryBandwidth,omitempty"`
+	MemoryIteration uint64       `xml:"memoryIteration,omitempty"`
+	UpdateTimestamp *metav1.Time `xml:"updateTimestamp,omitempty"`
 }
 
 type BackupMetadata struct {
@@ -508,6 +523,28 @@ type CheckpointParent struct {
 	Name string `xml:"name"`
 }
 
+// DomainSnapshot mirroring libvirt XML under https://libvirt.org/formatsnapshot.html#snapshot-xml
+type DomainSnapshot struct {
+	XMLName       xml.Name        `xml:"domainsnapshot"`
+	Name          string          `xml:"name,omitempty"`
+	Memory        *SnapshotMemory `xml:"memory,omitempty"`
+	SnapshotDisks *SnapshotDisks  `xml:"disks,omitempty"`
+}
+
+type SnapshotMemory struct {
+	Snapshot string `xml:"snapshot,attr"`
+	File     string `xml:"file,attr,omitempty"`
+}
+
+type SnapshotDisks struct {
+	Disks []SnapshotDisk `xml:"disk"`
+}
+
+type SnapshotDisk struct {
+	Name     string `xml:"name,attr"`
+	Snapshot string `xml:"snapshot,attr"`
+}
+
 type Commandline struct {
 	QEMUEnv []Env `xml:"qemu:env,omitempty"`
 	QEMUArg []Arg `xml:"qemu:arg,omitempty"`
@@ -619,6 +656,7 @@ type Devices struct {
 	Filesystems  []FilesystemDevice `xml:"filesystem,omitempty"`
 	Redirs       []RedirectedDevice `xml:"redirdev,omitempty"`
 	SoundCards   []SoundCard        `xml:"sound,omitempty"`
+	Audios       []Audio            `xml:"audio,omitempty"`
 	TPMs         []TPM              `xml:"tpm,omitempty"`
 	VSOCK        *VSOCK             `xml:"vsock,omitempty"`
 	Memory       *MemoryDevice      `xml:"memory,omitempty"`
@@ -771,6 +809,7 @@ type Disk struct {
 	Source             DiskSource    `xml:"source"`
 	Target             DiskTarget    `xml:"target"`
 	Serial             string        `xml:"serial,omitempty"`
+	WWN                string        `xml:"wwn,omitempty"`
 	Driver             *DiskDriver   `xml:"driver,omitempty"`
 	ReadOnly           *ReadOnly     `xml:"readonly,omitempty"`
 	Auth               *DiskAuth     `xml:"auth,omitempty"`
@@ -817,16 +856,17 @@ type DiskTarget struct {
 }
 
 type DiskDriver struct {
-	Cache       string             `xml:"cache,attr,omitempty"`
-	ErrorPolicy v1.DiskErrorPolicy `xml:"error_policy,attr,omitempty"`
-	IO          v1.DriverIO        `xml:"io,attr,omitempty"`
-	Name        string             `xml:"name,attr"`
-	Type        string             `xml:"type,attr"`
-	IOThread    *uint              `xml:"iothread,attr,omitempty"`
-	IOThreads   *DiskIOThreads     `xml:"iothreads"`
-	Queues      *uint              `xml:"queues,attr,omitempty"`
-	Discard     string             `xml:"discard,attr,omitempty"`
-	IOMMU       string             `xml:"iommu,attr,omitempty"`
+	Cache           string             `xml:"cache,attr,omitempty"`
+	ErrorPolicy     v1.DiskErrorPolicy `xml:"error_policy,attr,omitempty"`
+	ReadErrorPolicy v1.DiskErrorPolicy `xml:"rerror_policy,attr,omitempty"`
+	IO              v1.DriverIO        `xml:"io,attr,omitempty"`
+	Name            string             `xml:"name,attr"`
+	Type            string             `xml:"type,attr"`
+	IOThread        *uint              `xml:"iothread,attr,omitempty"`
+	IOThreads       *DiskIOThreads     `xml:"iothreads"`
+	Queues          *uint              `xml:"queues,attr,omitempty"`
+	Discard         string             `xml:"discard,attr,omitempty"`
+	IOMMU           string             `xml:"iommu,attr,omitempty"`
 }
 
 type DiskIOThreads struct {
@@ -1226,8 +1266,31 @@ type ChannelSource struct {
 //BEGIN Sound -------------------
 
 type SoundCard struct {
-	Alias *Alias `xml:"alias,omitempty"`
-	Model string `xml:"model,attr"`
+	Alias *Alias    `xml:"alias,omitempty"`
+	Model string    `xml:"model,attr"`
+	Audio *AudioRef `xml:"audio,omitempty"`
+}
+
+type Audio struct {
+	ID     uint         `xml:"id,attr"`
+	Type   string       `xml:"type,attr"`
+	Output *AudioOutput `xml:"output,omitempty"`
+}
+
+type AudioOutput struct {
+	MixingEngine  string         `xml:"mixingEngine,attr,omitempty"`
+	FixedSettings string         `xml:"fixedSettings,attr,omitempty"`
+	Settings      *AudioSettings `xml:"settings,omitempty"`
+}
+
+type AudioSettings struct {
+	Frequency uint   `xml:"frequency,attr"`
+	Channels  uint   `xml:"channels,attr"`
+	Format    string `xml:"format,attr"`
+}
+
+type AudioRef struct {
+	ID uint `xml:"id,attr"`
 }
 
 //END Sound -------------------
@@ -1254,6 +1317,7 @@ type Graphics struct {
 	Port          int32           `xml:"port,attr,omitempty"`
 	TLSPort       int             `xml:"tlsPort,attr,omitempty"`
 	Type          string          `xml:"type,attr"`
+	Audio         *AudioRef       `xml:"audio,omitempty"`
 }
 
 type GraphicsListen struct {
@@ -1352,6 +1416,14 @@ type IOThreads struct {
 	IOThreads uint `xml:",chardata"`
 }
 
+type IOThreadIDs struct {
+	IOThreads []IOThreadID `xml:"iothread"`
+}
+
+type IOThreadID struct {
+	ID uint `xml:"id,attr"`
+}
+
 // TODO ballooning, rng, cpu ...
 
 type SecretUsage struct {
@@ -172,6 +172,20 @@ func (mr *MockConnectionMockRecorder) DomainEventMemoryDeviceSizeChangeRegister(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DomainEventMemoryDeviceSizeChangeRegister", reflect.TypeOf((*MockConnection)(nil).DomainEventMemoryDeviceSizeChangeRegister), callback)
 }
 
+// DomainRestoreFlags mocks base method.
+func (m *MockConnection) DomainRestoreFlags(srcFile, xmlConf string, flags libvirt.DomainSaveRestoreFlags) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DomainRestoreFlags", srcFile, xmlConf, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DomainRestoreFlags indicates an expected call of DomainRestoreFlags.
+func (mr *MockConnectionMockRecorder) DomainRestoreFlags(srcFile, xmlConf, flags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DomainRestoreFlags", reflect.TypeOf((*MockConnection)(nil).DomainRestoreFlags), srcFile, xmlConf, flags)
+}
+
 // GetAllDomainStats mocks base method.
 func (m *MockConnection) GetAllDomainStats(statsTypes libvirt.DomainStatsTypes, flags libvirt.ConnectGetAllDomainStatsFlags) ([]libvirt.DomainStats, error) {
 	m.ctrl.T.Helper()
@@ -454,6 +468,20 @@ func (mr *MockVirDomainMockRecorder) AbortJob() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortJob", reflect.TypeOf((*MockVirDomain)(nil).AbortJob))
 }
 
+// AddIOThread mocks base method.
+func (m *MockVirDomain) AddIOThread(id uint, flags libvirt.DomainModificationImpact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIOThread", id, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddIOThread indicates an expected call of AddIOThread.
+func (mr *MockVirDomainMockRecorder) AddIOThread(id, flags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIOThread", reflect.TypeOf((*MockVirDomain)(nil).AddIOThread), id, flags)
+}
+
 // AttachDeviceFlags mocks base method.
 func (m *MockVirDomain) AttachDeviceFlags(xml string, flags libvirt.DomainDeviceModifyFlags) error {
 	m.ctrl.T.Helper()
@@ -539,6 +567,21 @@ func (mr *MockVirDomainMockRecorder) CreateCheckpointXML(xmlConfig, flags any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckpointXML", reflect.TypeOf((*MockVirDomain)(nil).CreateCheckpointXML), xmlConfig, flags)
 }
 
+// CreateSnapshotXML mocks base method.
+func (m *MockVirDomain) CreateSnapshotXML(xml string, flags libvirt.DomainSnapshotCreateFlags) (*libvirt.DomainSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSnapshotXML", xml, flags)
+	ret0, _ := ret[0].(*libvirt.DomainSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSnapshotXML indicates an expected call of CreateSnapshotXML.
+func (mr *MockVirDomainMockRecorder) CreateSnapshotXML(xml, flags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSnapshotXML", reflect.TypeOf((*MockVirDomain)(nil).CreateSnapshotXML), xml, flags)
+}
+
 // CreateWithFlags mocks base method.
 func (m *MockVirDomain) CreateWithFlags(flags libvirt.DomainCreateFlags) error {
 	m.ctrl.T.Helper()
@@ -774,6 +817,20 @@ func (mr *MockVirDomainMockRecorder) GetXMLDesc(flags any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXMLDesc", reflect.TypeOf((*MockVirDomain)(nil).GetXMLDesc), flags)
 }
 
+// InjectNMI mocks base method.
+func (m *MockVirDomain) InjectNMI(flags uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectNMI", flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectNMI indicates an expected call of InjectNMI.
+func (mr *MockVirDomainMockRecorder) InjectNMI(flags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectNMI", reflect.TypeOf((*MockVirDomain)(nil).InjectNMI), flags)
+}
+
 // MemoryStats mocks base method.
 func (m *MockVirDomain) MemoryStats(nrStats, flags uint32) ([]libvirt.DomainMemoryStat, error) {
 	m.ctrl.T.Helper()
@@ -831,6 +888,20 @@ func (mr *MockVirDomainMockRecorder) PinEmulator(cpumap, flags any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinEmulator", reflect.TypeOf((*MockVirDomain)(nil).PinEmulator), cpumap, flags)
 }
 
+// PinIOThread mocks base method.
+func (m *MockVirDomain) PinIOThread(iothreadid uint, cpumap []bool, flags libvirt.DomainModificationImpact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinIOThread", iothreadid, cpumap, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinIOThread indicates an expected call of PinIOThread.
+func (mr *MockVirDomainMockRecorder) PinIOThread(iothreadid, cpumap, flags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinIOThread", reflect.TypeOf((*MockVirDomain)(nil).PinIOThread), iothreadid, cpumap, flags)
+}
+
 // PinVcpuFlags mocks base method.
 func (m *MockVirDomain) PinVcpuFlags(vcpu uint, cpuMap []bool, flags libvirt.DomainModificationImpact) error {
 	m.ctrl.T.Helper()
@@ -267,7 +267,7 @@ func (l *Launcher) FreezeVirtualMachine(_ context.Context, request *cmdv1.Freeze
 		return response, nil
 	}
 
-	if err := l.domainManager.FreezeVMI(vmi, request.UnfreezeTimeoutSeconds); err != nil {
+	if err := l.domainManager.FreezeVMI(vmi, request.UnfreezeTimeoutSeconds, request.Mountpoints); err != nil {
 		log.Log.Object(vmi).Reason(err).Errorf("Failed to freeze vmi")
 		response.Success = false
 		response.Message = getErrorMessage(err)
@@ -318,7 +318,7 @@ func (l *Launcher) SoftRebootVirtualMachine(_ context.Context, request *cmdv1.VM
 		return response, nil
 	}
 
-	if err := l.domainManager.SoftRebootVMI(vmi); err != nil {
+	if err := l.domainManager.SoftRebootVMI(vmi, v1.SoftRebootFallback(request.Options.GetSoftRebootFallback())); err != nil {
 		log.Log.Object(vmi).Reason(err).Errorf("Failed to soft reboot vmi")
 		response.Success = false
 		response.Message = getErrorMessage(err)
@@ -329,6 +329,23 @@ func (l *Launcher) SoftRebootVirtualMachine(_ context.Context, request *cmdv1.VM
 	return response, nil
 }
 
+func (l *Launcher) InjectNMIVirtualMachine(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.InjectNMIVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to inject an NMI into vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Injected an NMI into vmi")
+	return response, nil
+}
+
 func (l *Launcher) KillVirtualMachine(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 
 	vmi, response := getVMIFromRequest(request.Vmi)
@@ -452,6 +469,35 @@ func (l *Launcher) GetDomain(_ context.Context, _ *cmdv1.EmptyRequest) (*cmdv1.D
 	return response, nil
 }
 
+func (l *Launcher) GenerateDomain(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.DomainResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	domainResponse := &cmdv1.DomainResponse{
+		Response: response,
+	}
+	if !response.Success {
+		return domainResponse, nil
+	}
+
+	spec, err := l.domainManager.GenerateDomainSpec(vmi, l.allowEmulation, request.Options)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to generate domain")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return domainResponse, nil
+	}
+
+	domain, err := json.Marshal(spec)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to marshal domain")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return domainResponse, nil
+	}
+	domainResponse.Domain = string(domain)
+
+	return domainResponse, nil
+}
+
 func (l *Launcher) GetQemuVersion(_ context.Context, _ *cmdv1.EmptyRequest) (*cmdv1.QemuVersionResponse, error) {
 	response := &cmdv1.QemuVersionResponse{
 		Response: &cmdv1.Response{},
@@ -762,6 +808,43 @@ func (l *Launcher) InjectLaunchSecret(_ context.Context, request *cmdv1.InjectLa
 	return response, nil
 }
 
+func (l *Launcher) GetSEVSNPAttestationReport(_ context.Context, request *cmdv1.SEVSNPAttestationReportRequest) (*cmdv1.SEVSNPAttestationReportResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	attestationReportResponse := &cmdv1.SEVSNPAttestationReportResponse{
+		Response: response,
+	}
+
+	if !attestationReportResponse.Response.Success {
+		return attestationReportResponse, nil
+	}
+
+	var options v1.SEVSNPAttestationReportOptions
+	if err := json.Unmarshal(request.Options, &options); err != nil {
+		attestationReportResponse.Response.Success = false
+		attestationReportResponse.Response.Message = "No valid attestation report options present in command server request"
+		return attestationReportResponse, nil
+	}
+
+	attestationReport, err := l.domainManager.GetSEVSNPAttestationReport(vmi, &options)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to get SEV-SNP attestation report")
+		attestationReportResponse.Response.Success = false
+		attestationReportResponse.Response.Message = getErrorMessage(err)
+		return attestationReportResponse, nil
+	}
+
+	attestationReportJson, err := json.Marshal(attestationReport)
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to marshal SEV-SNP attestation report")
+		attestationReportResponse.Response.Success = false
+		attestationReportResponse.Response.Message = getErrorMessage(err)
+		return attestationReportResponse, nil
+	}
+	attestationReportResponse.AttestationReport = attestationReportJson
+
+	return attestationReportResponse, nil
+}
+
 func (l *Launcher) SyncVirtualMachineMemory(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 	vmi, response := getVMIFromRequest(request.Vmi)
 	if !response.Success {
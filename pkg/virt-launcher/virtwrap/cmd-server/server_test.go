@@ -107,8 +107,8 @@ var _ = Describe("Virt remote commands", func() {
 
 		It("should freeze a vmi", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
-			domainManager.EXPECT().FreezeVMI(vmi, int32(0))
-			Expect(client.FreezeVirtualMachine(vmi, int32(0))).To(Succeed())
+			domainManager.EXPECT().FreezeVMI(vmi, int32(0), []string{"/data"})
+			Expect(client.FreezeVirtualMachine(vmi, int32(0), []string{"/data"})).To(Succeed())
 		})
 
 		It("should unfreeze a vmi", func() {
@@ -125,8 +125,14 @@ var _ = Describe("Virt remote commands", func() {
 
 		It("should soft reboot a vmi", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
-			domainManager.EXPECT().SoftRebootVMI(vmi)
-			Expect(client.SoftRebootVirtualMachine(vmi)).To(Succeed())
+			domainManager.EXPECT().SoftRebootVMI(vmi, v1.SoftRebootFallbackFail)
+			Expect(client.SoftRebootVirtualMachine(vmi, v1.SoftRebootFallbackFail)).To(Succeed())
+		})
+
+		It("should inject an NMI into a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().InjectNMIVMI(vmi)
+			Expect(client.InjectNMIVirtualMachine(vmi)).To(Succeed())
 		})
 
 		It("should call memory dump", func() {
@@ -190,6 +196,24 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(domain.Status.Interfaces).To(Equal(fakeInterfaces))
 		})
 
+		It("should generate the domain of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domain := api.NewMinimalDomain("testvmi")
+			domainManager.EXPECT().GenerateDomainSpec(vmi, allowEmulation, &cmdv1.VirtualMachineOptions{}).Return(&domain.Spec, nil)
+
+			generated, err := client.GenerateDomain(vmi, &cmdv1.VirtualMachineOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(generated.Name).To(Equal(domain.Spec.Name))
+		})
+
+		It("should fail to generate the domain of a vmi if the conversion fails", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().GenerateDomainSpec(vmi, allowEmulation, &cmdv1.VirtualMachineOptions{}).Return(nil, errors.New("conversion failed"))
+
+			_, err := client.GenerateDomain(vmi, &cmdv1.VirtualMachineOptions{})
+			Expect(err).To(MatchError(ContainSubstring("conversion failed")))
+		})
+
 		It("should list no domain if no domain is there yet", func() {
 			var list []*api.Domain
 
@@ -334,6 +358,19 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should return a vmi SEV-SNP attestation report", func() {
+			options := &v1.SEVSNPAttestationReportOptions{ReportData: "AAABBBCCC"}
+			attestationReport := &v1.SEVSNPAttestationReport{
+				Report:    "DDDEEEFFF",
+				CertChain: "GGGHHHIII",
+			}
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().GetSEVSNPAttestationReport(vmi, options).Return(attestationReport, nil)
+			fetchedAttestationReport, err := client.GetSEVSNPAttestationReport(vmi, options)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fetchedAttestationReport).To(Equal(attestationReport))
+		})
+
 		It("should call UpdateGuestMemory", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
 			domainManager.EXPECT().UpdateGuestMemory(vmi).Return(nil)
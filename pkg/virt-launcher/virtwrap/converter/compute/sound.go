@@ -29,6 +29,22 @@ import (
 
 type SoundDomainConfigurator struct{}
 
+const soundStreamAudioID = 1
+
+type soundStreamSettings struct {
+	frequency uint
+	channels  uint
+}
+
+// soundStreamQualities lists the stream qualities offered, best first, all with 16 bit samples
+var soundStreamQualities = []soundStreamSettings{
+	{frequency: 44100, channels: 2},
+	{frequency: 22050, channels: 2},
+	{frequency: 22050, channels: 1},
+	{frequency: 11025, channels: 1},
+	{frequency: 8000, channels: 1},
+}
+
 func (s SoundDomainConfigurator) Configure(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
 	vmiSoundDevice := vmi.Spec.Domain.Devices.Sound
 	if vmiSoundDevice == nil {
@@ -39,17 +55,57 @@ func (s SoundDomainConfigurator) Configure(vmi *v1.VirtualMachineInstance, domai
 	switch model {
 	case "":
 		model = "ich9"
-	case "ich9", "ac97":
+	case "ich9", "ac97", "virtio":
 	default:
 		return fmt.Errorf("invalid model: %s", model)
 	}
 
-	domain.Spec.Devices.SoundCards = []api.SoundCard{
-		{
-			Alias: api.NewUserDefinedAlias(vmiSoundDevice.Name),
-			Model: model,
-		},
+	soundCard := api.SoundCard{
+		Alias: api.NewUserDefinedAlias(vmiSoundDevice.Name),
+		Model: model,
+	}
+
+	if vmiSoundDevice.Stream != nil && len(domain.Spec.Devices.Graphics) > 0 {
+		settings, err := selectSoundStreamSettings(vmiSoundDevice.Stream)
+		if err != nil {
+			return err
+		}
+		// The VNC server captures the output of the audio backend and forwards it to its clients
+		domain.Spec.Devices.Audios = []api.Audio{
+			{
+				ID:   soundStreamAudioID,
+				Type: "none",
+				Output: &api.AudioOutput{
+					MixingEngine:  "yes",
+					FixedSettings: "yes",
+					Settings: &api.AudioSettings{
+						Frequency: settings.frequency,
+						Channels:  settings.channels,
+						Format:    "s16",
+					},
+				},
+			},
+		}
+		soundCard.Audio = &api.AudioRef{ID: soundStreamAudioID}
+		for i := range domain.Spec.Devices.Graphics {
+			domain.Spec.Devices.Graphics[i].Audio = &api.AudioRef{ID: soundStreamAudioID}
+		}
 	}
 
+	domain.Spec.Devices.SoundCards = []api.SoundCard{soundCard}
+
 	return nil
 }
+
+func selectSoundStreamSettings(stream *v1.SoundStream) (soundStreamSettings, error) {
+	if stream.MaxBandwidth == nil {
+		return soundStreamQualities[0], nil
+	}
+	const bytesPerSample = 2
+	for _, settings := range soundStreamQualities {
+		if int64(settings.frequency*settings.channels*bytesPerSample) <= stream.MaxBandwidth.Value() {
+			return settings, nil
+		}
+	}
+	return soundStreamSettings{}, fmt.Errorf("sound stream bandwidth %s is too low", stream.MaxBandwidth.String())
+}
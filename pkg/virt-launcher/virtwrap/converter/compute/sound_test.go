@@ -23,9 +23,12 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	v1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter/compute"
 )
@@ -69,8 +72,52 @@ var _ = Describe("Sound Domain Configurator", func() {
 			v1.SoundDevice{Name: deviceName, Model: "ac97"},
 			api.SoundCard{Alias: api.NewUserDefinedAlias(deviceName), Model: "ac97"},
 		),
+		Entry("when name and virtio model are specified",
+			v1.SoundDevice{Name: deviceName, Model: "virtio"},
+			api.SoundCard{Alias: api.NewUserDefinedAlias(deviceName), Model: "virtio"},
+		),
+		Entry("when a stream is requested without a graphics device",
+			v1.SoundDevice{Name: deviceName, Stream: &v1.SoundStream{}},
+			api.SoundCard{Alias: api.NewUserDefinedAlias(deviceName), Model: "ich9"},
+		),
+	)
+
+	DescribeTable("Should forward the audio through the graphics device when a stream is requested",
+		func(stream v1.SoundStream, expectedFrequency, expectedChannels uint) {
+			vmi := libvmi.New(withSound(v1.SoundDevice{Name: deviceName, Stream: &stream}))
+			domain := api.Domain{Spec: api.DomainSpec{Devices: api.Devices{Graphics: []api.Graphics{{Type: "vnc"}}}}}
+
+			Expect(compute.SoundDomainConfigurator{}.Configure(vmi, &domain)).To(Succeed())
+			audioRef := &api.AudioRef{ID: 1}
+			Expect(domain.Spec.Devices.SoundCards).To(Equal([]api.SoundCard{
+				{Alias: api.NewUserDefinedAlias(deviceName), Model: "ich9", Audio: audioRef},
+			}))
+			Expect(domain.Spec.Devices.Graphics).To(Equal([]api.Graphics{{Type: "vnc", Audio: audioRef}}))
+			Expect(domain.Spec.Devices.Audios).To(Equal([]api.Audio{{
+				ID:   1,
+				Type: "none",
+				Output: &api.AudioOutput{
+					MixingEngine:  "yes",
+					FixedSettings: "yes",
+					Settings:      &api.AudioSettings{Frequency: expectedFrequency, Channels: expectedChannels, Format: "s16"},
+				},
+			}}))
+		},
+		Entry("in CD quality by default", v1.SoundStream{}, uint(44100), uint(2)),
+		Entry("in the best quality fitting the bandwidth cap",
+			v1.SoundStream{MaxBandwidth: pointer.P(resource.MustParse("64Ki"))}, uint(22050), uint(1)),
+		Entry("in the lowest quality with the minimal bandwidth",
+			v1.SoundStream{MaxBandwidth: pointer.P(resource.MustParse("16k"))}, uint(8000), uint(1)),
 	)
 
+	It("should fail when the bandwidth cap is too low for any stream", func() {
+		vmi := libvmi.New(withSound(v1.SoundDevice{Name: deviceName, Stream: &v1.SoundStream{MaxBandwidth: pointer.P(resource.MustParse("1k"))}}))
+		domain := api.Domain{Spec: api.DomainSpec{Devices: api.Devices{Graphics: []api.Graphics{{Type: "vnc"}}}}}
+
+		Expect(compute.SoundDomainConfigurator{}.Configure(vmi, &domain)).
+			To(MatchError("sound stream bandwidth 1k is too low"))
+	})
+
 	It("should fail when an invalid model is specified", func() {
 		vmi := libvmi.New(withSound(v1.SoundDevice{Name: deviceName, Model: "invalid-model"}))
 		var domain api.Domain
@@ -107,6 +107,7 @@ func Convert_v1_Disk_To_api_Disk(c *convertertypes.ConverterContext, diskDevice
 		}
 		disk.ReadOnly = toApiReadOnly(diskDevice.Disk.ReadOnly)
 		disk.Serial = diskDevice.Serial
+		disk.WWN = diskDevice.WWN
 		if diskDevice.Shareable != nil {
 			if *diskDevice.Shareable {
 				if diskDevice.Cache == "" {
@@ -195,6 +196,21 @@ func setErrorPolicy(diskDevice *v1.Disk, disk *api.Disk) error {
 	return nil
 }
 
+// setReadErrorPolicy sets the policy libvirt applies to read errors. If unset,
+// libvirt falls back to the error policy of the disk.
+func setReadErrorPolicy(diskDevice *v1.Disk, disk *api.Disk) error {
+	if diskDevice.ReadErrorPolicy == nil {
+		return nil
+	}
+	switch *diskDevice.ReadErrorPolicy {
+	case v1.DiskErrorPolicyStop, v1.DiskErrorPolicyIgnore, v1.DiskErrorPolicyReport:
+		disk.Driver.ReadErrorPolicy = *diskDevice.ReadErrorPolicy
+	default:
+		return fmt.Errorf("read error policy %s not recognized", *diskDevice.ReadErrorPolicy)
+	}
+	return nil
+}
+
 type DirectIOChecker interface {
 	CheckBlockDevice(path string) (bool, error)
 	CheckFile(path string) (bool, error)
@@ -572,7 +588,7 @@ func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c *convert
 	}
 
 	if source.Ephemeral != nil {
-		return Convert_v1_EphemeralVolumeSource_To_api_Disk(source.Name, disk, c)
+		return Convert_v1_EphemeralVolumeSource_To_api_Disk(source.Name, source.Ephemeral, disk, c)
 	}
 	if source.EmptyDisk != nil {
 		return Convert_v1_EmptyDiskSource_To_api_Disk(source.Name, source.EmptyDisk, disk)
@@ -934,10 +950,14 @@ func Convert_v1_ContainerDiskSource_To_api_Disk(volumeName string, _ *v1.Contain
 	return nil
 }
 
-func Convert_v1_EphemeralVolumeSource_To_api_Disk(volumeName string, disk *api.Disk, c *convertertypes.ConverterContext) error {
+func Convert_v1_EphemeralVolumeSource_To_api_Disk(volumeName string, source *v1.EphemeralVolumeSource, disk *api.Disk, c *convertertypes.ConverterContext) error {
 	disk.Type = "file"
 	setDiskDriver(disk, "qcow2", true)
-	disk.Source.File = c.EphemeraldiskCreator.GetFilePath(volumeName)
+	if source.OverlayClaimName != "" {
+		disk.Source.File = c.EphemeraldiskCreator.GetOverlayClaimFilePath(volumeName)
+	} else {
+		disk.Source.File = c.EphemeraldiskCreator.GetFilePath(volumeName)
+	}
 	disk.BackingStore = &api.BackingStore{
 		Format: &api.BackingStoreFormat{},
 		Source: &api.DiskSource{},
@@ -1117,6 +1137,9 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		if err := setErrorPolicy(&disk, &newDisk); err != nil {
 			return err
 		}
+		if err := setReadErrorPolicy(&disk, &newDisk); err != nil {
+			return err
+		}
 		if hasIOThreads {
 			currentDedicatedThread, currentAutoThread = assignDiskIOThread(&disk, &newDisk, supplementalIOThreads, autoThreads, currentDedicatedThread, currentAutoThread)
 		}
@@ -319,6 +319,21 @@ var _ = Describe("Converter", func() {
 			}),
 		)
 
+		It("Should set the serial and the WWN of the disk", func() {
+			v1Disk := v1.Disk{
+				Name:   "myvolume",
+				Serial: "SN-1",
+				WWN:    "5000c50015ea71ac",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{Bus: v1.DiskBusSCSI},
+				},
+			}
+			apiDisk := api.Disk{}
+			Expect(Convert_v1_Disk_To_api_Disk(&convertertypes.ConverterContext{}, &v1Disk, &apiDisk, map[string]deviceNamer{}, nil, make(map[string]v1.VolumeStatus))).To(Succeed())
+			Expect(apiDisk.Serial).To(Equal("SN-1"))
+			Expect(apiDisk.WWN).To(Equal("5000c50015ea71ac"))
+		})
+
 		DescribeTable("Should add boot order when provided", func(arch, expectedModel string) {
 			order := uint(1)
 			kubevirtDisk := &v1.Disk{
@@ -1831,6 +1846,66 @@ var _ = Describe("Converter", func() {
 			Entry("ErrorPolicy equal to report", pointer.P(v1.DiskErrorPolicyReport), "report"),
 			Entry("ErrorPolicy equal to enospace", pointer.P(v1.DiskErrorPolicyEnospace), "enospace"),
 		)
+		DescribeTable("Should set the read error policy", func(epolicy *v1.DiskErrorPolicy, expected string) {
+			vmi.Spec.Domain.Devices.Disks[0] = v1.Disk{
+				Name: "mydisk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{
+						Bus: v1.VirtIO,
+					},
+				},
+				ErrorPolicy:     pointer.P(v1.DiskErrorPolicyStop),
+				ReadErrorPolicy: epolicy,
+			}
+			vmi.Spec.Volumes[0] = v1.Volume{
+				Name: "mydisk",
+				VolumeSource: v1.VolumeSource{
+					Ephemeral: &v1.EphemeralVolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testclaim",
+						},
+					},
+				},
+			}
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(string(domainSpec.Devices.Disks[0].Driver.ReadErrorPolicy)).To(Equal(expected))
+		},
+			Entry("ReadErrorPolicy not specified", nil, ""),
+			Entry("ReadErrorPolicy equal to stop", pointer.P(v1.DiskErrorPolicyStop), "stop"),
+			Entry("ReadErrorPolicy equal to ignore", pointer.P(v1.DiskErrorPolicyIgnore), "ignore"),
+			Entry("ReadErrorPolicy equal to report", pointer.P(v1.DiskErrorPolicyReport), "report"),
+		)
+		DescribeTable("Should place the ephemeral overlay", func(overlayClaimName string, expectedFile func() string) {
+			vmi.Spec.Domain.Devices.Disks[0] = v1.Disk{
+				Name: "mydisk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{
+						Bus: v1.VirtIO,
+					},
+				},
+			}
+			vmi.Spec.Volumes[0] = v1.Volume{
+				Name: "mydisk",
+				VolumeSource: v1.VolumeSource{
+					Ephemeral: &v1.EphemeralVolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testclaim",
+						},
+						OverlayClaimName: overlayClaimName,
+					},
+				},
+			}
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.Devices.Disks[0].Source.File).To(Equal(expectedFile()))
+			Expect(domainSpec.Devices.Disks[0].BackingStore.Source.File).To(Equal("/var/run/kubevirt-private/vmi-disks/mydisk/disk.img"))
+		},
+			Entry("on the local ephemeral storage without an overlay claim", "", func() string {
+				return c.EphemeraldiskCreator.GetFilePath("mydisk")
+			}),
+			Entry("on the overlay claim", "overlay-claim", func() string {
+				return c.EphemeraldiskCreator.GetOverlayClaimFilePath("mydisk")
+			}),
+		)
 		DescribeTable("Should set the vmport by arch", func(arch string) {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			c.Architecture = archconverter.NewConverter(arch)
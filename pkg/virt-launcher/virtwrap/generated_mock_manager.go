@@ -119,17 +119,32 @@ func (mr *MockDomainManagerMockRecorder) FinalizeVirtualMachineMigration(arg0, a
 }
 
 // FreezeVMI mocks base method.
-func (m *MockDomainManager) FreezeVMI(arg0 *v1.VirtualMachineInstance, arg1 int32) error {
+func (m *MockDomainManager) FreezeVMI(arg0 *v1.VirtualMachineInstance, arg1 int32, arg2 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FreezeVMI", arg0, arg1)
+	ret := m.ctrl.Call(m, "FreezeVMI", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // FreezeVMI indicates an expected call of FreezeVMI.
-func (mr *MockDomainManagerMockRecorder) FreezeVMI(arg0, arg1 any) *gomock.Call {
+func (mr *MockDomainManagerMockRecorder) FreezeVMI(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeVMI", reflect.TypeOf((*MockDomainManager)(nil).FreezeVMI), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeVMI", reflect.TypeOf((*MockDomainManager)(nil).FreezeVMI), arg0, arg1, arg2)
+}
+
+// GenerateDomainSpec mocks base method.
+func (m *MockDomainManager) GenerateDomainSpec(arg0 *v1.VirtualMachineInstance, arg1 bool, arg2 *v10.VirtualMachineOptions) (*api.DomainSpec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateDomainSpec", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*api.DomainSpec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateDomainSpec indicates an expected call of GenerateDomainSpec.
+func (mr *MockDomainManagerMockRecorder) GenerateDomainSpec(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateDomainSpec", reflect.TypeOf((*MockDomainManager)(nil).GenerateDomainSpec), arg0, arg1, arg2)
 }
 
 // GetDomainDirtyRateStats mocks base method.
@@ -249,6 +264,21 @@ func (mr *MockDomainManagerMockRecorder) GetSEVInfo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSEVInfo", reflect.TypeOf((*MockDomainManager)(nil).GetSEVInfo))
 }
 
+// GetSEVSNPAttestationReport mocks base method.
+func (m *MockDomainManager) GetSEVSNPAttestationReport(arg0 *v1.VirtualMachineInstance, arg1 *v1.SEVSNPAttestationReportOptions) (*v1.SEVSNPAttestationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSEVSNPAttestationReport", arg0, arg1)
+	ret0, _ := ret[0].(*v1.SEVSNPAttestationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSEVSNPAttestationReport indicates an expected call of GetSEVSNPAttestationReport.
+func (mr *MockDomainManagerMockRecorder) GetSEVSNPAttestationReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSEVSNPAttestationReport", reflect.TypeOf((*MockDomainManager)(nil).GetSEVSNPAttestationReport), arg0, arg1)
+}
+
 // GetScreenshot mocks base method.
 func (m *MockDomainManager) GetScreenshot(vmi *v1.VirtualMachineInstance) (*v10.ScreenshotResponse, error) {
 	m.ctrl.T.Helper()
@@ -320,6 +350,20 @@ func (mr *MockDomainManagerMockRecorder) InjectLaunchSecret(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectLaunchSecret", reflect.TypeOf((*MockDomainManager)(nil).InjectLaunchSecret), arg0, arg1)
 }
 
+// InjectNMIVMI mocks base method.
+func (m *MockDomainManager) InjectNMIVMI(arg0 *v1.VirtualMachineInstance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectNMIVMI", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectNMIVMI indicates an expected call of InjectNMIVMI.
+func (mr *MockDomainManagerMockRecorder) InjectNMIVMI(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectNMIVMI", reflect.TypeOf((*MockDomainManager)(nil).InjectNMIVMI), arg0)
+}
+
 // InterfacesStatus mocks base method.
 func (m *MockDomainManager) InterfacesStatus() []api.InterfaceStatus {
 	m.ctrl.T.Helper()
@@ -475,17 +519,17 @@ func (mr *MockDomainManagerMockRecorder) SignalShutdownVMI(arg0 any) *gomock.Cal
 }
 
 // SoftRebootVMI mocks base method.
-func (m *MockDomainManager) SoftRebootVMI(arg0 *v1.VirtualMachineInstance) error {
+func (m *MockDomainManager) SoftRebootVMI(arg0 *v1.VirtualMachineInstance, arg1 v1.SoftRebootFallback) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SoftRebootVMI", arg0)
+	ret := m.ctrl.Call(m, "SoftRebootVMI", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SoftRebootVMI indicates an expected call of SoftRebootVMI.
-func (mr *MockDomainManagerMockRecorder) SoftRebootVMI(arg0 any) *gomock.Call {
+func (mr *MockDomainManagerMockRecorder) SoftRebootVMI(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftRebootVMI", reflect.TypeOf((*MockDomainManager)(nil).SoftRebootVMI), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftRebootVMI", reflect.TypeOf((*MockDomainManager)(nil).SoftRebootVMI), arg0, arg1)
 }
 
 // SyncVMI mocks base method.
@@ -0,0 +1,83 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package launchsecurity
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+const (
+	// SNPReportDataSize is the size of the guest provided data included in an SNP attestation report
+	SNPReportDataSize = 64
+
+	snpTSMReportPath = "/sys/kernel/config/tsm/report"
+)
+
+// DecodeSNPReportData decodes the base64 encoded report data and pads it with zeros to SNPReportDataSize
+func DecodeSNPReportData(reportData string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(reportData)
+	if err != nil {
+		return nil, fmt.Errorf("report data is not base64 encoded: %w", err)
+	}
+	if len(data) > SNPReportDataSize {
+		return nil, fmt.Errorf("report data must not exceed %d bytes, got %d", SNPReportDataSize, len(data))
+	}
+	padded := make([]byte, SNPReportDataSize)
+	copy(padded, data)
+	return padded, nil
+}
+
+// SNPAttestationReportScript returns a shell script requesting an attestation report through the
+// configfs-tsm interface of the guest kernel. The script prints the base64 encoded report on the
+// first line and the certificate table provided by the host on the second one.
+// The script is passed through the guest agent as a JSON string, it must not contain double quotes or backslashes.
+func SNPAttestationReportScript(reportData []byte) string {
+	return strings.Join([]string{
+		"set -e",
+		fmt.Sprintf("d=$(mktemp -d %s/kubevirt.XXXXXX)", snpTSMReportPath),
+		"trap 'rmdir $d' EXIT",
+		fmt.Sprintf("printf %%s %s | base64 -d > $d/inblob", base64.StdEncoding.EncodeToString(reportData)),
+		"base64 -w0 $d/outblob",
+		"echo",
+		"base64 -w0 $d/auxblob 2>/dev/null || true",
+	}, "; ")
+}
+
+// ParseSNPAttestationReport parses the output of the script returned by SNPAttestationReportScript
+func ParseSNPAttestationReport(output string) (*v1.SEVSNPAttestationReport, error) {
+	lines := strings.SplitN(strings.TrimSpace(output), "\n", 2)
+	report := strings.TrimSpace(lines[0])
+	if report == "" {
+		return nil, fmt.Errorf("the guest did not return an attestation report")
+	}
+	if _, err := base64.StdEncoding.DecodeString(report); err != nil {
+		return nil, fmt.Errorf("the attestation report returned by the guest is not base64 encoded: %w", err)
+	}
+
+	attestationReport := &v1.SEVSNPAttestationReport{Report: report}
+	if len(lines) > 1 {
+		attestationReport.CertChain = strings.TrimSpace(lines[1])
+	}
+	return attestationReport, nil
+}
@@ -0,0 +1,72 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package launchsecurity_test
+
+import (
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/launchsecurity"
+)
+
+var _ = Describe("LaunchSecurity: AMD SEV-SNP attestation report", func() {
+	It("should pad the report data", func() {
+		reportData, err := launchsecurity.DecodeSNPReportData(base64.StdEncoding.EncodeToString([]byte("nonce")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reportData).To(HaveLen(launchsecurity.SNPReportDataSize))
+		Expect(reportData[:5]).To(Equal([]byte("nonce")))
+		Expect(reportData[5:]).To(Equal(make([]byte, launchsecurity.SNPReportDataSize-5)))
+	})
+
+	DescribeTable("should reject invalid report data", func(reportData string) {
+		_, err := launchsecurity.DecodeSNPReportData(reportData)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("which is not base64 encoded", "not base64"),
+		Entry("which exceeds 64 bytes", base64.StdEncoding.EncodeToString(make([]byte, 65))),
+	)
+
+	It("should generate a script which can be passed to the guest agent", func() {
+		script := launchsecurity.SNPAttestationReportScript(make([]byte, launchsecurity.SNPReportDataSize))
+		Expect(script).ToNot(ContainSubstring(`"`))
+		Expect(script).ToNot(ContainSubstring(`\`))
+		Expect(script).ToNot(ContainSubstring("\n"))
+		Expect(script).To(ContainSubstring("/sys/kernel/config/tsm/report"))
+	})
+
+	DescribeTable("should parse the output of the guest", func(output string, expected *v1.SEVSNPAttestationReport) {
+		Expect(launchsecurity.ParseSNPAttestationReport(output)).To(Equal(expected))
+	},
+		Entry("with a certificate chain", "AAAA\nBBBB\n", &v1.SEVSNPAttestationReport{Report: "AAAA", CertChain: "BBBB"}),
+		Entry("without a certificate chain", "AAAA\n", &v1.SEVSNPAttestationReport{Report: "AAAA"}),
+	)
+
+	DescribeTable("should fail to parse the output of the guest", func(output string) {
+		_, err := launchsecurity.ParseSNPAttestationReport(output)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("without a report", "\n"),
+		Entry("with an invalid report", "not base64\n"),
+	)
+})
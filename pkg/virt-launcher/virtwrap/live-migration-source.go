@@ -396,6 +396,25 @@ func (l *LibvirtDomainManager) setMigrationResultHelper(failed bool, reason stri
 	return nil
 }
 
+// setMigrationTransferStatus records the progress of the ongoing migration in the
+// metadata, from where virt-handler reports it on the VMI status.
+func (l *LibvirtDomainManager) setMigrationTransferStatus(info *libvirt.DomainJobInfo) {
+	l.metadataCache.Migration.WithSafeBlock(func(migrationMetadata *api.MigrationMetadata, _ bool) {
+		if migrationMetadata.EndTimestamp != nil {
+			return
+		}
+		migrationMetadata.TransferStatus = &api.MigrationTransferStatus{
+			DataProcessed:   info.DataProcessed,
+			DataRemaining:   info.DataRemaining,
+			DataTotal:       info.DataTotal,
+			MemoryDirtyRate: info.MemDirtyRate * info.MemPageSize,
+			MemoryBandwidth: info.MemBps,
+			MemoryIteration: info.MemIteration,
+			UpdateTimestamp: pointer.P(metav1.Now()),
+		}
+	})
+}
+
 func (l *LibvirtDomainManager) setMigrationResult(failed bool, reason string, abortStatus v1.MigrationAbortStatus) error {
 	return l.setMigrationResultHelper(failed, reason, abortStatus)
 }
@@ -664,6 +683,7 @@ func (m *migrationMonitor) startMonitor() {
 			logInterval++
 			if logInterval%monitorLogInterval == 0 {
 				logMigrationInfo(logger, string(migrationUID), jobStats)
+				m.l.setMigrationTransferStatus(jobStats)
 			}
 		case libvirt.DOMAIN_JOB_NONE:
 			completedJobInfo = m.determineNonRunningMigrationStatus(dom)
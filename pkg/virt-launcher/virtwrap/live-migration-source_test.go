@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	"k8s.io/apimachinery/pkg/types"
+	"libvirt.org/go/libvirt"
 	"libvirt.org/go/libvirtxml"
 
 	v1 "kubevirt.io/api/core/v1"
@@ -144,6 +145,36 @@ var _ = Describe("Live migration source", func() {
 			Entry("marking the migration as failed without an abortion result should return an error", true, v1.MigrationAbortStatus(""), false, errors.MigrationAbortInProgressError),
 			Entry("marking the migration as completed without an abortion result should return an error", false, v1.MigrationAbortStatus(""), false, errors.MigrationAbortInProgressError),
 		)
+
+		It("should record the transfer status of an ongoing migration", func() {
+			libvirtDomainManager.setMigrationTransferStatus(&libvirt.DomainJobInfo{
+				DataProcessed: 1024,
+				DataRemaining: 3072,
+				DataTotal:     4096,
+				MemDirtyRate:  10,
+				MemPageSize:   4096,
+				MemBps:        2048,
+				MemIteration:  3,
+			})
+			migrationMetadata, exists := libvirtDomainManager.metadataCache.Migration.Load()
+			Expect(exists).To(BeTrue(), "migrationMetadata not found")
+			Expect(migrationMetadata.TransferStatus).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"DataProcessed":   BeEquivalentTo(1024),
+				"DataRemaining":   BeEquivalentTo(3072),
+				"DataTotal":       BeEquivalentTo(4096),
+				"MemoryDirtyRate": BeEquivalentTo(40960),
+				"MemoryBandwidth": BeEquivalentTo(2048),
+				"MemoryIteration": BeEquivalentTo(3),
+				"UpdateTimestamp": Not(BeNil()),
+			})))
+		})
+
+		It("should not record the transfer status once the migration has ended", func() {
+			libvirtDomainManager.setMigrationResult(false, "", "")
+			libvirtDomainManager.setMigrationTransferStatus(&libvirt.DomainJobInfo{DataProcessed: 1024})
+			migrationMetadata, _ := libvirtDomainManager.metadataCache.Migration.Load()
+			Expect(migrationMetadata.TransferStatus).To(BeNil())
+		})
 	})
 
 	Context("classifyVolumesForMigration", func() {
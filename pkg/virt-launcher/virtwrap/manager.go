@@ -68,6 +68,7 @@ import (
 	ephemeraldisk "kubevirt.io/kubevirt/pkg/ephemeral-disk"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	"kubevirt.io/kubevirt/pkg/hooks"
+	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
 	"kubevirt.io/kubevirt/pkg/liveupdate/memory"
 	"kubevirt.io/kubevirt/pkg/network/cache"
@@ -93,6 +94,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter/arch"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter/iothreads"
 	convertertypes "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter/types"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter/vcpu"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice"
@@ -101,6 +103,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/sriov"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/efi"
 	domainerrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/launchsecurity"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
 	virtcache "kubevirt.io/kubevirt/tools/cache"
@@ -131,12 +134,14 @@ type contextStore struct {
 
 type DomainManager interface {
 	SyncVMI(*v1.VirtualMachineInstance, bool, *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error)
+	GenerateDomainSpec(*v1.VirtualMachineInstance, bool, *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error)
 	PauseVMI(*v1.VirtualMachineInstance) error
 	UnpauseVMI(*v1.VirtualMachineInstance) error
-	FreezeVMI(*v1.VirtualMachineInstance, int32) error
+	FreezeVMI(*v1.VirtualMachineInstance, int32, []string) error
 	UnfreezeVMI(*v1.VirtualMachineInstance) error
 	ResetVMI(*v1.VirtualMachineInstance) error
-	SoftRebootVMI(*v1.VirtualMachineInstance) error
+	SoftRebootVMI(*v1.VirtualMachineInstance, v1.SoftRebootFallback) error
+	InjectNMIVMI(*v1.VirtualMachineInstance) error
 	KillVMI(*v1.VirtualMachineInstance) error
 	DeleteVMI(*v1.VirtualMachineInstance) error
 	SignalShutdownVMI(*v1.VirtualMachineInstance) error
@@ -163,6 +168,7 @@ type DomainManager interface {
 	GetSEVInfo() (*v1.SEVPlatformInfo, error)
 	GetLaunchMeasurement(*v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error)
 	InjectLaunchSecret(*v1.VirtualMachineInstance, *v1.SEVSecretOptions) error
+	GetSEVSNPAttestationReport(*v1.VirtualMachineInstance, *v1.SEVSNPAttestationReportOptions) (*v1.SEVSNPAttestationReport, error)
 	UpdateGuestMemory(vmi *v1.VirtualMachineInstance) error
 	GetDomainDirtyRateStats(calculationDuration time.Duration) (*stats.DomainStatsDirtyRate, error)
 	GetScreenshot(vmi *v1.VirtualMachineInstance) (*cmdv1.ScreenshotResponse, error)
@@ -1150,6 +1156,36 @@ func isSerialConsoleLogEnabled(clusterSerialConsoleLogDisabled bool, vmi *v1.Vir
 	return (vmi.Spec.Domain.Devices.LogSerialConsole != nil && *vmi.Spec.Domain.Devices.LogSerialConsole) || (vmi.Spec.Domain.Devices.LogSerialConsole == nil && !clusterSerialConsoleLogDisabled)
 }
 
+// GenerateDomainSpec returns the domain spec which the converter generates from the given VMI,
+// without defining or modifying the libvirt domain.
+func (l *LibvirtDomainManager) GenerateDomainSpec(vmi *v1.VirtualMachineInstance, allowEmulation bool, options *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error) {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	c, err := l.generateConverterContext(vmi, allowEmulation, options, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if cbt.HasCBTStateEnabled(vmi.Status.ChangedBlockTracking) {
+		// The overlays of a running domain already exist, only point the disks at them
+		c.ApplyCBT = map[string]string{}
+		for i := range vmi.Spec.Volumes {
+			if cbt.IsCBTEligibleVolume(&vmi.Spec.Volumes[i]) {
+				c.ApplyCBT[vmi.Spec.Volumes[i].Name] = cbt.GetQCOW2OverlayPath(vmi, vmi.Spec.Volumes[i].Name)
+			}
+		}
+	}
+
+	domain := &api.Domain{}
+	if err := converter.Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c); err != nil {
+		return nil, err
+	}
+	api.NewDefaulter(c.Architecture.GetArchitecture()).SetObjectDefaults_Domain(domain)
+
+	return &domain.Spec, nil
+}
+
 func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmulation bool, options *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error) {
 	l.domainModifyLock.Lock()
 	defer l.domainModifyLock.Unlock()
@@ -1239,6 +1275,78 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 	return oldSpec, nil
 }
 
+// needsDedicatedIOThread returns true if a disk about to be hotplugged requests its own IOThread.
+// IOThreads of a domain are only created when it starts, hence a new one has to be added to the
+// running domain before such a disk can be attached.
+func needsDedicatedIOThread(vmi *v1.VirtualMachineInstance, disk api.Disk) bool {
+	if disk.Driver == nil || disk.Driver.IOThread == nil {
+		return false
+	}
+	for _, vmiDisk := range vmi.Spec.Domain.Devices.Disks {
+		if vmiDisk.Name == disk.Alias.GetName() {
+			return iothreads.HasDedicatedIOThread(vmiDisk)
+		}
+	}
+	return false
+}
+
+// liveIOThreadIDs returns the IDs of the IOThreads of the running domain. Without explicit
+// IDs libvirt numbers the IOThreads from 1.
+func liveIOThreadIDs(spec *api.DomainSpec) map[uint]struct{} {
+	ids := map[uint]struct{}{}
+	if spec.IOThreadIDs != nil && len(spec.IOThreadIDs.IOThreads) > 0 {
+		for _, ioThread := range spec.IOThreadIDs.IOThreads {
+			ids[ioThread.ID] = struct{}{}
+		}
+	} else if spec.IOThreads != nil {
+		for id := uint(1); id <= spec.IOThreads.IOThreads; id++ {
+			ids[id] = struct{}{}
+		}
+	}
+	return ids
+}
+
+// addDedicatedIOThread adds the IOThread of a hotplugged disk to the running domain. The ID assigned by
+// the converter is kept unless the domain already uses it, and the thread is pinned like the converter
+// pins the IOThreads of VMIs with dedicated CPUs.
+func addDedicatedIOThread(dom cli.VirDomain, domain *api.Domain, disk *api.Disk, ioThreadIDs map[uint]struct{}) error {
+	convertedID := *disk.Driver.IOThread
+	id := convertedID
+	if _, inUse := ioThreadIDs[id]; inUse || id == 0 {
+		id = 1
+		for usedID := range ioThreadIDs {
+			id = max(id, usedID+1)
+		}
+	}
+
+	if err := dom.AddIOThread(id, affectDomainLiveAndConfigLibvirtFlags); err != nil {
+		return err
+	}
+	ioThreadIDs[id] = struct{}{}
+	disk.Driver.IOThread = pointer.P(id)
+
+	if domain.Spec.CPUTune == nil {
+		return nil
+	}
+	for _, pin := range domain.Spec.CPUTune.IOThreadPin {
+		if uint(pin.IOThread) != convertedID {
+			continue
+		}
+		cpus, err := hw_utils.ParseCPUSetLine(pin.CPUSet, 100)
+		if err != nil {
+			return fmt.Errorf("failed to parse cpuset %s of IOThread %d: %v", pin.CPUSet, id, err)
+		} else if len(cpus) == 0 {
+			return nil
+		}
+		cpuMap := make([]bool, maxSlice(cpus)+1)
+		for _, cpu := range cpus {
+			cpuMap[cpu] = true
+		}
+		return dom.PinIOThread(id, cpuMap, affectDomainLiveAndConfigLibvirtFlags)
+	}
+	return nil
+}
+
 func (l *LibvirtDomainManager) syncDisks(
 	domain *api.Domain,
 	spec *api.DomainSpec,
@@ -1267,6 +1375,7 @@ func (l *LibvirtDomainManager) syncDisks(
 		}
 	}
 	// Look up all the disks to attach
+	ioThreadIDs := liveIOThreadIDs(spec)
 	for _, attachDisk := range getAttachedDisks(spec.Devices.Disks, domain.Spec.Devices.Disks) {
 		allowAttach, err := checkIfDiskReadyToUse(getBackendSource(attachDisk))
 		if err != nil {
@@ -1281,6 +1390,12 @@ func (l *LibvirtDomainManager) syncDisks(
 		if err != nil {
 			return err
 		}
+		if needsDedicatedIOThread(vmi, attachDisk) {
+			if err := addDedicatedIOThread(dom, domain, &attachDisk, ioThreadIDs); err != nil {
+				logger.Reason(err).Errorf("adding dedicated IOThread for disk %s", attachDisk.Alias.GetName())
+				return err
+			}
+		}
 		converter.SetOptimalIOMode(&attachDisk, converter.IsPreAllocated)
 
 		attachBytes, err := xml.Marshal(attachDisk)
@@ -1354,7 +1469,9 @@ func (l *LibvirtDomainManager) startDomain(
 	}
 
 	createFlags := getDomainCreateFlags(vmi)
-	if err := dom.CreateWithFlags(createFlags); err != nil {
+	if l.restoreMemoryState(vmi, dom, createFlags) {
+		logger.Info("Domain restored from the saved memory state.")
+	} else if err := dom.CreateWithFlags(createFlags); err != nil {
 		logger.Reason(err).
 			Errorf("Failed to start VirtualMachineInstance with flags %v.", createFlags)
 		return err
@@ -1367,6 +1484,63 @@ func (l *LibvirtDomainManager) startDomain(
 	return nil
 }
 
+var getMemoryStateDir = hostdisk.GetMountedHostDiskDir
+
+// restoreMemoryState resumes the domain from the memory state saved to a memory dump volume, e.g. by a VM snapshot.
+// The saved state is removed after the attempt, it is only valid for the disks it was saved with. If the state
+// cannot be restored, false is returned and the domain has to be booted.
+func (l *LibvirtDomainManager) restoreMemoryState(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, createFlags libvirt.DomainCreateFlags) bool {
+	logger := log.Log.Object(vmi)
+
+	statePath := findSavedMemoryState(vmi)
+	if statePath == "" {
+		return false
+	}
+	defer func() {
+		if err := os.Remove(statePath); err != nil {
+			logger.Reason(err).Warningf("failed to remove the saved memory state %s", statePath)
+		}
+	}()
+
+	domXML, err := dom.GetXMLDesc(0)
+	if err != nil {
+		logger.Reason(err).Warning("failed to get the domain XML, booting instead of restoring the saved memory state")
+		return false
+	}
+
+	restoreFlags := libvirt.DOMAIN_SAVE_RUNNING
+	if createFlags&libvirt.DOMAIN_START_PAUSED != 0 {
+		restoreFlags = libvirt.DOMAIN_SAVE_PAUSED
+	}
+	if err := l.virConn.DomainRestoreFlags(statePath, domXML, restoreFlags); err != nil {
+		logger.Reason(err).Warningf("failed to restore the saved memory state %s, booting instead", statePath)
+		return false
+	}
+	return true
+}
+
+// findSavedMemoryState returns the path of the memory state saved to a memory dump volume attached at start,
+// or an empty string if there is none.
+func findSavedMemoryState(vmi *v1.VirtualMachineInstance) string {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.MemoryDump == nil || volume.MemoryDump.Hotpluggable || volume.MemoryDump.Format != v1.MemoryDumpFormatSavedState {
+			continue
+		}
+		dir := getMemoryStateDir(volume.Name)
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Warningf("failed to read the memory dump volume %s", volume.Name)
+			continue
+		}
+		for _, file := range files {
+			if !file.IsDir() && strings.HasSuffix(file.Name(), ".memory.dump") {
+				return filepath.Join(dir, file.Name())
+			}
+		}
+	}
+	return ""
+}
+
 func (l *LibvirtDomainManager) lookupOrCreateVirDomain(
 	domain *api.Domain,
 	vmi *v1.VirtualMachineInstance,
@@ -1739,8 +1913,8 @@ func (l *LibvirtDomainManager) UnpauseVMI(vmi *v1.VirtualMachineInstance) error
 	return nil
 }
 
-func (l *LibvirtDomainManager) FreezeVMI(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32) error {
-	return l.storageManager.FreezeVMI(vmi, unfreezeTimeoutSeconds)
+func (l *LibvirtDomainManager) FreezeVMI(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32, mountpoints []string) error {
+	return l.storageManager.FreezeVMI(vmi, unfreezeTimeoutSeconds, mountpoints)
 }
 
 func (l *LibvirtDomainManager) UnfreezeVMI(vmi *v1.VirtualMachineInstance) error {
@@ -1764,16 +1938,35 @@ func (l *LibvirtDomainManager) ResetVMI(vmi *v1.VirtualMachineInstance) error {
 	return nil
 }
 
-func (l *LibvirtDomainManager) SoftRebootVMI(vmi *v1.VirtualMachineInstance) error {
-	domainRebootFlagValues := libvirt.DOMAIN_REBOOT_GUEST_AGENT
+func (l *LibvirtDomainManager) InjectNMIVMI(vmi *v1.VirtualMachineInstance) error {
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Getting the domain for NMI injection failed.")
+		return err
+	}
+
+	defer dom.Free()
+	if err = dom.InjectNMI(0); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Injecting an NMI into the domain failed.")
+		return err
+	}
+
+	return nil
+}
+
+func (l *LibvirtDomainManager) SoftRebootVMI(vmi *v1.VirtualMachineInstance, fallback v1.SoftRebootFallback) error {
+	acpiFallback := fallback != v1.SoftRebootFallbackFail
+	if features := vmi.Spec.Domain.Features; features != nil && features.ACPI.Enabled != nil && !(*features.ACPI.Enabled) {
+		acpiFallback = false
+	}
+
 	condManager := controller.NewVirtualMachineInstanceConditionManager()
-	if !condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstanceAgentConnected, k8sv1.ConditionTrue) {
-		if features := vmi.Spec.Domain.Features; features != nil && features.ACPI.Enabled != nil && !(*features.ACPI.Enabled) {
-			err := fmt.Errorf("VMI neither have the agent connected nor the ACPI feature enabled")
-			log.Log.Object(vmi).Reason(err).Error("Setting the domain reboot flag failed.")
-			return err
-		}
-		domainRebootFlagValues = libvirt.DOMAIN_REBOOT_ACPI_POWER_BTN
+	agentConnected := condManager.HasConditionWithStatus(vmi, v1.VirtualMachineInstanceAgentConnected, k8sv1.ConditionTrue)
+	if !agentConnected && !acpiFallback {
+		err := fmt.Errorf("VMI neither have the agent connected nor the ACPI fallback enabled")
+		log.Log.Object(vmi).Reason(err).Error("Setting the domain reboot flag failed.")
+		return err
 	}
 
 	domName := api.VMINamespaceKeyFunc(vmi)
@@ -1782,14 +1975,28 @@ func (l *LibvirtDomainManager) SoftRebootVMI(vmi *v1.VirtualMachineInstance) err
 		log.Log.Object(vmi).Reason(err).Error("Getting the domain for soft reboot failed.")
 		return err
 	}
-
 	defer dom.Free()
-	if err = dom.Reboot(domainRebootFlagValues); err != nil {
+
+	if agentConnected {
+		err = dom.Reboot(libvirt.DOMAIN_REBOOT_GUEST_AGENT)
+		if err == nil {
+			return nil
+		}
 		libvirtError, ok := err.(libvirt.Error)
 		if !ok || libvirtError.Code != libvirt.ERR_AGENT_UNRESPONSIVE {
 			log.Log.Object(vmi).Reason(err).Error("Soft rebooting the domain failed.")
 			return err
 		}
+		if !acpiFallback {
+			log.Log.Object(vmi).Reason(err).Error("Soft rebooting the domain through the unresponsive guest agent failed.")
+			return fmt.Errorf("the guest agent is unresponsive and the ACPI fallback is disabled: %v", err)
+		}
+		log.Log.Object(vmi).Reason(err).Warning("The guest agent is unresponsive, falling back to an ACPI reboot.")
+	}
+
+	if err = dom.Reboot(libvirt.DOMAIN_REBOOT_ACPI_POWER_BTN); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Soft rebooting the domain failed.")
+		return err
 	}
 
 	return nil
@@ -2404,6 +2611,26 @@ func (l *LibvirtDomainManager) InjectLaunchSecret(vmi *v1.VirtualMachineInstance
 	return nil
 }
 
+// GetSEVSNPAttestationReport requests an attestation report from the guest through the guest agent,
+// since only the guest itself can ask the AMD secure processor for a report of an SEV-SNP VM.
+func (l *LibvirtDomainManager) GetSEVSNPAttestationReport(vmi *v1.VirtualMachineInstance, options *v1.SEVSNPAttestationReportOptions) (*v1.SEVSNPAttestationReport, error) {
+	reportData, err := launchsecurity.DecodeSNPReportData(options.ReportData)
+	if err != nil {
+		return nil, err
+	}
+
+	const timeoutSeconds = int32(10)
+	domName := api.VMINamespaceKeyFunc(vmi)
+	script := launchsecurity.SNPAttestationReportScript(reportData)
+	stdOut, err := agent.GuestExec(l.virConn, domName, "/bin/sh", []string{"-c", script}, timeoutSeconds)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Requesting the attestation report from the guest failed")
+		return nil, err
+	}
+
+	return launchsecurity.ParseSNPAttestationReport(stdOut)
+}
+
 func (l *LibvirtDomainManager) parseFSDisks(fsDisks []api.FSDisk) []v1.VirtualMachineInstanceFileSystemDisk {
 	disks := []v1.VirtualMachineInstanceFileSystemDisk{}
 	for _, fsDisk := range fsDisks {
@@ -492,6 +492,74 @@ var _ = Describe("Manager", func() {
 			Expect(newspec).ToNot(BeNil())
 		})
 
+		Context("with a saved memory state", func() {
+			var statePath string
+
+			BeforeEach(func() {
+				stateDir := GinkgoT().TempDir()
+				statePath = filepath.Join(stateDir, "vm.memory.dump")
+				Expect(os.WriteFile(statePath, []byte("state"), 0600)).To(Succeed())
+
+				origGetMemoryStateDir := getMemoryStateDir
+				getMemoryStateDir = func(_ string) string { return stateDir }
+				DeferCleanup(func() { getMemoryStateDir = origGetMemoryStateDir })
+			})
+
+			newVMIWithSavedMemoryState := func() *v1.VirtualMachineInstance {
+				vmi := newVMI(testNamespace, testVmName)
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "memory",
+					VolumeSource: v1.VolumeSource{
+						MemoryDump: &v1.MemoryDumpVolumeSource{
+							PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{
+								PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "memory-pvc"},
+							},
+							Format: v1.MemoryDumpFormatSavedState,
+						},
+					},
+				})
+				return vmi
+			}
+
+			It("should restore the VirtualMachineInstance from the saved memory state", func() {
+				vmi := newVMIWithSavedMemoryState()
+				mockLibvirt.ConnectionEXPECT().LookupDomainByName(testDomainName).Return(nil, libvirt.Error{Code: libvirt.ERR_NO_DOMAIN})
+
+				domainXML, err := xml.MarshalIndent(expectedDomainFor(vmi), "", "\t")
+				Expect(err).ToNot(HaveOccurred())
+				mockLibvirt.DomainEXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(domainXML), nil)
+				setDomainExpectations(vmi)
+
+				mockLibvirt.DomainEXPECT().GetState().Return(libvirt.DOMAIN_SHUTDOWN, 1, nil)
+				mockLibvirt.ConnectionEXPECT().DomainRestoreFlags(statePath, string(domainXML), libvirt.DOMAIN_SAVE_RUNNING).Return(nil)
+				mockLibvirt.DomainEXPECT().CreateWithFlags(gomock.Any()).Times(0)
+				manager, _ := newLibvirtDomainManagerDefault()
+				newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newspec).ToNot(BeNil())
+				Expect(statePath).ToNot(BeAnExistingFile())
+			})
+
+			It("should boot the VirtualMachineInstance if the saved memory state cannot be restored", func() {
+				vmi := newVMIWithSavedMemoryState()
+				mockLibvirt.ConnectionEXPECT().LookupDomainByName(testDomainName).Return(nil, libvirt.Error{Code: libvirt.ERR_NO_DOMAIN})
+
+				domainXML, err := xml.MarshalIndent(expectedDomainFor(vmi), "", "\t")
+				Expect(err).ToNot(HaveOccurred())
+				mockLibvirt.DomainEXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(domainXML), nil)
+				setDomainExpectations(vmi)
+
+				mockLibvirt.DomainEXPECT().GetState().Return(libvirt.DOMAIN_SHUTDOWN, 1, nil)
+				mockLibvirt.ConnectionEXPECT().DomainRestoreFlags(statePath, string(domainXML), libvirt.DOMAIN_SAVE_RUNNING).Return(fmt.Errorf("incompatible state"))
+				mockLibvirt.DomainEXPECT().CreateWithFlags(libvirt.DOMAIN_NONE).Return(nil)
+				manager, _ := newLibvirtDomainManagerDefault()
+				newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newspec).ToNot(BeNil())
+				Expect(statePath).ToNot(BeAnExistingFile())
+			})
+		})
+
 		It("should define and start a new VirtualMachineInstance with userData", func() {
 			vmi := newVMI(testNamespace, testVmName)
 			mockLibvirt.ConnectionEXPECT().LookupDomainByName(testDomainName).Return(nil, libvirt.Error{Code: libvirt.ERR_NO_DOMAIN})
@@ -2873,6 +2941,101 @@ var _ = Describe("getDetachedDisks", func() {
 	)
 })
 
+var _ = Describe("needsDedicatedIOThread", func() {
+	newHotplugDisk := func(ioThread *uint) api.Disk {
+		return api.Disk{
+			Target: api.DiskTarget{Bus: v1.DiskBusVirtio, Device: "vdb"},
+			Driver: &api.DiskDriver{IOThread: ioThread},
+			Alias:  api.NewUserDefinedAlias("hpvolume1"),
+		}
+	}
+
+	DescribeTable("should return", func(dedicatedIOThread *bool, disk api.Disk, expected bool) {
+		vmi := newVMI(testNamespace, testVmName)
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+			{
+				Name:              "hpvolume1",
+				DedicatedIOThread: dedicatedIOThread,
+			},
+		}
+		Expect(needsDedicatedIOThread(vmi, disk)).To(Equal(expected))
+	},
+		Entry("true if the disk requests a dedicated IOThread", virtpointer.P(true), newHotplugDisk(virtpointer.P(uint(1))), true),
+		Entry("false if the disk does not request a dedicated IOThread", virtpointer.P(false), newHotplugDisk(virtpointer.P(uint(1))), false),
+		Entry("false if the dedicated IOThread is not set", nil, newHotplugDisk(virtpointer.P(uint(1))), false),
+		Entry("false if the disk has no IOThread assigned", virtpointer.P(true), newHotplugDisk(nil), false),
+	)
+})
+
+var _ = Describe("syncDisks", func() {
+	var mockLibvirt *testing.Libvirt
+
+	BeforeEach(func() {
+		mockLibvirt = testing.NewLibvirt(gomock.NewController(GinkgoT()))
+		mockLibvirt.DomainEXPECT().GetBlockInfo(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("no block info")).AnyTimes()
+		checkIfDiskReadyToUse = func(string) (bool, error) {
+			return true, nil
+		}
+		DeferCleanup(func() {
+			checkIfDiskReadyToUse = checkIfDiskReadyToUseFunc
+		})
+	})
+
+	newHotplugDisk := func(ioThread uint) api.Disk {
+		return api.Disk{
+			Device: "disk",
+			Type:   "file",
+			Source: api.DiskSource{File: filepath.Join(v1.HotplugDiskDir, "hpvolume1.img")},
+			Target: api.DiskTarget{Bus: v1.DiskBusVirtio, Device: "vdb"},
+			Driver: &api.DiskDriver{
+				Cache:    string(v1.CacheWriteBack),
+				IO:       v1.IOThreads,
+				Name:     "qemu",
+				Type:     "raw",
+				IOThread: virtpointer.P(ioThread),
+			},
+			Alias: api.NewUserDefinedAlias("hpvolume1"),
+		}
+	}
+
+	DescribeTable("should add a dedicated IOThread for a hotplugged disk", func(liveSpec *api.DomainSpec, cpuTune *api.CPUTune, expectedID uint, expectedCPUMap []bool) {
+		vmi := newVMI(testNamespace, testVmName)
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "hpvolume1", DedicatedIOThread: virtpointer.P(true)}}
+
+		domain := &api.Domain{}
+		domain.Spec.CPUTune = cpuTune
+		domain.Spec.Devices.Disks = []api.Disk{newHotplugDisk(3)}
+
+		attachDisk := newHotplugDisk(expectedID)
+		attachBytes, err := xml.Marshal(attachDisk)
+		Expect(err).ToNot(HaveOccurred())
+
+		mockLibvirt.DomainEXPECT().AddIOThread(expectedID, affectDomainLiveAndConfigLibvirtFlags).Return(nil)
+		if expectedCPUMap != nil {
+			mockLibvirt.DomainEXPECT().PinIOThread(expectedID, expectedCPUMap, affectDomainLiveAndConfigLibvirtFlags).Return(nil)
+		}
+		mockLibvirt.DomainEXPECT().AttachDeviceFlags(strings.ToLower(string(attachBytes)), affectDeviceLiveAndConfigLibvirtFlags).Return(nil)
+
+		manager := &LibvirtDomainManager{directIOChecker: converter.NewDirectIOChecker()}
+		Expect(manager.syncDisks(domain, liveSpec, mockLibvirt.VirtDomain, vmi)).To(Succeed())
+	},
+		Entry("with the ID assigned by the converter if it is free",
+			&api.DomainSpec{IOThreads: &api.IOThreads{IOThreads: 2}}, nil, uint(3), nil),
+		Entry("with the next free ID of the running domain if the converter's ID is in use",
+			&api.DomainSpec{
+				IOThreads:   &api.IOThreads{IOThreads: 3},
+				IOThreadIDs: &api.IOThreadIDs{IOThreads: []api.IOThreadID{{ID: 1}, {ID: 2}, {ID: 3}}},
+			}, nil, uint(4), nil),
+		Entry("pinned like the converter pins it for dedicated CPUs",
+			&api.DomainSpec{
+				IOThreads:   &api.IOThreads{IOThreads: 3},
+				IOThreadIDs: &api.IOThreadIDs{IOThreads: []api.IOThreadID{{ID: 1}, {ID: 2}, {ID: 3}}},
+			},
+			&api.CPUTune{IOThreadPin: []api.CPUTuneIOThreadPin{{IOThread: 1, CPUSet: "1"}, {IOThread: 3, CPUSet: "4-5"}}},
+			uint(4), []bool{false, false, false, false, true, true}),
+	)
+})
+
 var _ = Describe("getUpdatedDisks", func() {
 	DescribeTable("should return the correct values", func(oldDisks, newDisks, expected []api.Disk) {
 		res := getUpdatedDisks(oldDisks, newDisks)
@@ -33,7 +33,10 @@ import (
 	api "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
-func (m *StorageManager) FreezeVMI(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32) error {
+// FreezeVMI freezes the guest filesystems. When mountpoints is empty all
+// freezable filesystems are frozen, otherwise only the given ones, which
+// requires the guest agent to support guest-fsfreeze-freeze-list.
+func (m *StorageManager) FreezeVMI(vmi *v1.VirtualMachineInstance, unfreezeTimeoutSeconds int32, mountpoints []string) error {
 	if m.MigrationInProgress() {
 		return fmt.Errorf("failed to freeze VMI, VMI is currently during migration")
 	}
@@ -70,8 +73,11 @@ func (m *StorageManager) FreezeVMI(vmi *v1.VirtualMachineInstance, unfreezeTimeo
 	}
 	defer domain.Free()
 
-	if err := domain.FSFreeze(nil, 0); err != nil {
+	if err := domain.FSFreeze(mountpoints, 0); err != nil {
 		log.Log.Errorf("Failed to freeze vmi, %s", err.Error())
+		if len(mountpoints) > 0 {
+			return fmt.Errorf("failed to freeze filesystems %v, the guest agent may not support freezing individual filesystems: %v", mountpoints, err)
+		}
 		return err
 	}
 
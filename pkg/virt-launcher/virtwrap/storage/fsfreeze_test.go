@@ -85,7 +85,29 @@ var _ = Describe("FSFreeze", func() {
 		mockDomain.EXPECT().Free().Times(1)
 		mockDomain.EXPECT().FSFreeze(nil, uint32(0)).Times(1)
 
-		Expect(manager.FreezeVMI(vmi, 0)).To(Succeed())
+		Expect(manager.FreezeVMI(vmi, 0, nil)).To(Succeed())
+	})
+
+	It("should freeze only the requested filesystems of a VirtualMachineInstance", func() {
+		vmi := newVMI(testNamespace, testVmName)
+
+		mockConn.EXPECT().QemuAgentCommand(`{"execute":"`+string(agentpoller.GetFSFreezeStatus)+`"}`, testDomainName).Return(expectedThawedOutput, nil)
+		mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil).Times(1)
+		mockDomain.EXPECT().Free().Times(1)
+		mockDomain.EXPECT().FSFreeze([]string{"/data"}, uint32(0)).Times(1)
+
+		Expect(manager.FreezeVMI(vmi, 0, []string{"/data"})).To(Succeed())
+	})
+
+	It("should report when the guest agent cannot freeze individual filesystems", func() {
+		vmi := newVMI(testNamespace, testVmName)
+
+		mockConn.EXPECT().QemuAgentCommand(`{"execute":"`+string(agentpoller.GetFSFreezeStatus)+`"}`, testDomainName).Return(expectedThawedOutput, nil)
+		mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil).Times(1)
+		mockDomain.EXPECT().Free().Times(1)
+		mockDomain.EXPECT().FSFreeze([]string{"/data"}, uint32(0)).Return(fmt.Errorf("command not supported"))
+
+		Expect(manager.FreezeVMI(vmi, 0, []string{"/data"})).To(MatchError(ContainSubstring("may not support freezing individual filesystems")))
 	})
 
 	It("should fail freeze a VirtualMachineInstance during migration", func() {
@@ -95,7 +117,7 @@ var _ = Describe("FSFreeze", func() {
 		migrationMetadata.StartTimestamp = &now
 		metadataCache.Migration.Store(migrationMetadata)
 
-		Expect(manager.FreezeVMI(vmi, 0)).To(MatchError(ContainSubstring("VMI is currently during migration")))
+		Expect(manager.FreezeVMI(vmi, 0, nil)).To(MatchError(ContainSubstring("VMI is currently during migration")))
 	})
 
 	It("should unfreeze a VirtualMachineInstance", func() {
@@ -120,7 +142,7 @@ var _ = Describe("FSFreeze", func() {
 		mockDomain.EXPECT().FSThaw(nil, uint32(0)).Times(1)
 
 		var unfreezeTimeout time.Duration = 3 * time.Second
-		Expect(manager.FreezeVMI(vmi, int32(unfreezeTimeout.Seconds()))).To(Succeed())
+		Expect(manager.FreezeVMI(vmi, int32(unfreezeTimeout.Seconds()), nil)).To(Succeed())
 		// wait for the unfreeze timeout
 		time.Sleep(unfreezeTimeout + 2*time.Second)
 	})
@@ -136,7 +158,7 @@ var _ = Describe("FSFreeze", func() {
 		mockDomain.EXPECT().FSThaw(nil, uint32(0)).Times(1)
 
 		var unfreezeTimeout time.Duration = 3 * time.Second
-		Expect(manager.FreezeVMI(vmi, int32(unfreezeTimeout.Seconds()))).To(Succeed())
+		Expect(manager.FreezeVMI(vmi, int32(unfreezeTimeout.Seconds()), nil)).To(Succeed())
 		time.Sleep(time.Second)
 		Expect(manager.UnfreezeVMI(vmi)).To(Succeed())
 		// wait for the unfreeze timeout
@@ -20,6 +20,7 @@
 package storage
 
 import (
+	"encoding/xml"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -32,6 +33,8 @@ import (
 	"kubevirt.io/client-go/log"
 
 	api "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
 )
 
 func (m *StorageManager) MemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error {
@@ -68,10 +71,15 @@ func (m *StorageManager) memoryDump(vmi *v1.VirtualMachineInstance, dumpPath str
 	// keep trying to do memory dump even if remove previous one failed
 	removePreviousMemoryDump(filepath.Dir(dumpPath))
 
-	logger.Infof("Starting memory dump")
+	format := memoryDumpFormat(vmi, dumpPath)
+	logger.Infof("Starting memory dump in format %s", format)
 	failed := false
 	reason := ""
-	err = dom.CoreDumpWithFormat(dumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY)
+	if format == v1.MemoryDumpFormatSavedState {
+		err = saveMemoryState(dom, dumpPath)
+	} else {
+		err = dom.CoreDumpWithFormat(dumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY)
+	}
 	if err != nil {
 		failed = true
 		reason = fmt.Sprintf("%s: %s", FailedDomainMemoryDump, err)
@@ -83,6 +91,63 @@ func (m *StorageManager) memoryDump(vmi *v1.VirtualMachineInstance, dumpPath str
 	return err
 }
 
+// memoryDumpFormat returns the format of the memory dump volume the dump is written to,
+// the dump is written to the directory named after the volume.
+func memoryDumpFormat(vmi *v1.VirtualMachineInstance, dumpPath string) v1.MemoryDumpFormat {
+	volumeName := filepath.Base(filepath.Dir(dumpPath))
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == volumeName && volume.MemoryDump != nil && volume.MemoryDump.Format != "" {
+			return volume.MemoryDump.Format
+		}
+	}
+	return v1.MemoryDumpFormatRaw
+}
+
+// saveMemoryState saves the memory and device state of the domain to dumpPath without touching its disks.
+// Unlike a core dump, the saved state can be restored with virDomainRestore.
+func saveMemoryState(dom cli.VirDomain, dumpPath string) error {
+	disks, err := util.GetAllDomainDisks(dom)
+	if err != nil {
+		return fmt.Errorf("failed to get the disks of the domain: %v", err)
+	}
+
+	snapshotXML, err := xml.Marshal(generateMemoryStateSnapshot(disks, dumpPath))
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := dom.CreateSnapshotXML(string(snapshotXML), libvirt.DOMAIN_SNAPSHOT_CREATE_NO_METADATA)
+	if err != nil {
+		return err
+	}
+	if snapshot != nil {
+		if err := snapshot.Free(); err != nil {
+			log.Log.Reason(err).Warning("failed to free the memory state snapshot")
+		}
+	}
+	return nil
+}
+
+func generateMemoryStateSnapshot(disks []api.Disk, dumpPath string) *api.DomainSnapshot {
+	snapshot := &api.DomainSnapshot{
+		Memory: &api.SnapshotMemory{
+			Snapshot: "external",
+			File:     dumpPath,
+		},
+		SnapshotDisks: &api.SnapshotDisks{},
+	}
+	for _, disk := range disks {
+		if disk.Target.Device == "" {
+			continue
+		}
+		snapshot.SnapshotDisks.Disks = append(snapshot.SnapshotDisks.Disks, api.SnapshotDisk{
+			Name:     disk.Target.Device,
+			Snapshot: "no",
+		})
+	}
+	return snapshot
+}
+
 func (m *StorageManager) shouldSkipMemoryDump(dumpPath string) bool {
 	memoryDumpMetadata, _ := m.metadataCache.MemoryDump.Load()
 	if memoryDumpMetadata.FileName == filepath.Base(dumpPath) {
@@ -115,6 +115,39 @@ var _ = Describe("MemoryDump", func() {
 		Expect(manager.MemoryDump(vmi, testDumpPath)).To(Succeed())
 	})
 
+	It("should save the memory state without the disks when the volume requests the saved state format", func() {
+		const domainXML = `<domain type='kvm'>
+				<devices>
+					<disk type='file' device='disk'>
+						<source file='/path/to/disk.qcow2'/>
+						<target dev='vda' bus='virtio'/>
+					</disk>
+				</devices>
+			</domain>`
+		const expectedSnapshotXML = `<domainsnapshot><memory snapshot="external" file="/test/dump/path/vol1.memory.dump"></memory>` +
+			`<disks><disk name="vda" snapshot="no"></disk></disks></domainsnapshot>`
+
+		mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+		mockDomain.EXPECT().GetXMLDesc(gomock.Any()).Return(domainXML, nil)
+		mockDomain.EXPECT().CreateSnapshotXML(expectedSnapshotXML, libvirt.DOMAIN_SNAPSHOT_CREATE_NO_METADATA).Return(nil, nil)
+
+		vmi := newVMI(testNamespace, testVmName)
+		vmi.Spec.Volumes = []v1.Volume{{
+			Name: "path",
+			VolumeSource: v1.VolumeSource{
+				MemoryDump: &v1.MemoryDumpVolumeSource{
+					Format: v1.MemoryDumpFormatSavedState,
+				},
+			},
+		}}
+		Expect(manager.MemoryDump(vmi, testDumpPath)).To(Succeed())
+
+		Eventually(func() bool {
+			memoryDump, _ := metadataCache.MemoryDump.Load()
+			return memoryDump.Completed && !memoryDump.Failed
+		}, 5*time.Second).Should(BeTrue())
+	})
+
 	It("should update domain with memory dump info if memory dump failed", func() {
 		mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
 		dumpFailure := fmt.Errorf("Memory dump failed!!")
@@ -408,6 +408,10 @@ func NewControllerDeployment(config *operatorutil.KubeVirtDeploymentConfig, prod
 	if exporterImage == "" {
 		exporterImage = fmt.Sprintf("%s/%s%s%s", config.GetImageRegistry(), config.GetImagePrefix(), "virt-exportserver", AddVersionSeparatorPrefix(config.GetExportServerVersion()))
 	}
+	libguestfsImage := config.GsImage
+	if libguestfsImage == "" {
+		libguestfsImage = fmt.Sprintf("%s/%s%s%s", config.GetImageRegistry(), config.GetImagePrefix(), "libguestfs-tools", AddVersionSeparatorPrefix(config.GetKubeVirtVersion()))
+	}
 
 	pod := &deployment.Spec.Template.Spec
 	pod.ServiceAccountName = ControllerServiceAccountName
@@ -425,6 +429,8 @@ func NewControllerDeployment(config *operatorutil.KubeVirtDeploymentConfig, prod
 		launcherImage,
 		"--exporter-image",
 		exporterImage,
+		"--libguestfs-image",
+		libguestfsImage,
 		portName,
 		"8443",
 		"-v",
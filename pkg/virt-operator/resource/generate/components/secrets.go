@@ -32,6 +32,7 @@ const (
 	VirtHandlerVsockClientCertSecretName              = "kubevirt-virt-handler-vsock-client-certs"
 	VirtOperatorCertSecretName                        = "kubevirt-operator-certs"
 	VirtApiCertSecretName                             = "kubevirt-virt-api-certs"
+	VirtApiSubresourceTokenKeySecretName              = "kubevirt-virt-api-subresource-token-key"
 	VirtControllerCertSecretName                      = "kubevirt-controller-certs"
 	VirtExportProxyCertSecretName                     = "kubevirt-exportproxy-certs"
 	VirtSynchronizationControllerCertSecretName       = "kubevirt-synchronization-controller-certs"
@@ -954,6 +954,34 @@ var CRDsValidation map[string]string = map[string]string{
                   type: object
                   x-kubernetes-map-type: atomic
               type: object
+            clusterRecovery:
+              description: |-
+                ClusterRecovery configures how virt-controller staggers the automatic start of
+                VirtualMachines after a cluster-wide outage. When not specified, VirtualMachines
+                are started as soon as possible.
+              properties:
+                maxStartsPerNode:
+                  description: |-
+                    MaxStartsPerNode is the maximum number of VirtualMachineInstances per schedulable
+                    node which may be starting at the same time during recovery. Defaults to 2.
+                  format: int32
+                  minimum: 1
+                  type: integer
+                outageThresholdPercentage:
+                  description: |-
+                    OutageThresholdPercentage is the percentage of VirtualMachines with the Always or
+                    RerunOnFailure run strategy which have to be down at the same time for the cluster
+                    to be considered recovering from an outage. Defaults to 50.
+                  format: int32
+                  maximum: 100
+                  minimum: 1
+                  type: integer
+                storageReadinessCheck:
+                  description: |-
+                    StorageReadinessCheck delays the start of a VirtualMachine during recovery until
+                    all of its PersistentVolumeClaims are bound. Defaults to true.
+                  type: boolean
+              type: object
             commonInstancetypesDeployment:
               description: CommonInstancetypesDeployment controls the deployment of
                 common-instancetypes resources
@@ -1134,6 +1162,17 @@ var CRDsValidation map[string]string = map[string]string{
                     allowed to be compared to the requested size (to account for various overheads).
                     Defaults to 10
                   type: integer
+                tscFrequencyTolerancePPM:
+                  description: |-
+                    TSCFrequencyTolerancePPM is the deviation, in parts per million, which is accepted between
+                    the TSC frequency of a VMI and the TSC frequency of a node that cannot scale it.
+                    The same tolerance is used when a migration target is validated.
+                    QEMU refuses deviations above 250 PPM.
+                    Defaults to 250
+                  format: int64
+                  maximum: 250
+                  minimum: 0
+                  type: integer
                 useEmulation:
                   description: |-
                     UseEmulation can be set to true to allow fallback to software emulation
@@ -1151,6 +1190,75 @@ var CRDsValidation map[string]string = map[string]string{
                 migrated instead of shut-off in case of a node drain. If the VirtualMachineInstance specific
                 field is set it overrides the cluster level one.
               type: string
+            guestAgent:
+              description: |-
+                GuestAgent configures the in-guest agents which can be used when qemu-guest-agent
+                is not available in the guest.
+              properties:
+                alternatives:
+                  description: |-
+                    Alternatives lists the agents virt-handler talks to over VSOCK when qemu-guest-agent
+                    is not connected. They are tried in order and the first one answering is used for
+                    IP reporting and filesystem freeze/thaw, depending on the capabilities it announces.
+                  items:
+                    description: GuestAgentAlternative describes an in-guest agent
+                      reachable over VSOCK.
+                    properties:
+                      name:
+                        description: |-
+                          Name of the agent. The agent has to announce the same name during the capability negotiation.
+                          The minimal agent shipped with KubeVirt announces itself as kubevirt-minimal-agent.
+                        type: string
+                      vsockPort:
+                        description: VSOCKPort is the port the agent listens on inside
+                          the guest.
+                        format: int32
+                        minimum: 1024
+                        type: integer
+                    required:
+                    - name
+                    - vsockPort
+                    type: object
+                  type: array
+                  x-kubernetes-list-map-keys:
+                  - name
+                  x-kubernetes-list-type: map
+                dataPolicies:
+                  description: |-
+                    DataPolicies restrict the guest data collected from the guest agents of the
+                    VirtualMachineInstances per namespace. Redacted data is neither stored in the status of
+                    the VirtualMachineInstances nor returned by their guest agent subresources. When several
+                    policies apply to a namespace, the data redacted by any of them is redacted.
+                  items:
+                    description: GuestAgentDataPolicy redacts guest data reported
+                      by the guest agents in a set of namespaces.
+                    properties:
+                      namespaces:
+                        description: Namespaces the policy applies to. A policy without
+                          namespaces applies to all namespaces.
+                        items:
+                          type: string
+                        type: array
+                        x-kubernetes-list-type: set
+                      redact:
+                        description: Redact lists the kinds of guest data which are
+                          not collected.
+                        items:
+                          description: GuestAgentDataKind is a kind of guest data
+                            reported by the guest agent.
+                          enum:
+                          - Users
+                          - Filesystems
+                          - OSInfo
+                          type: string
+                        type: array
+                        x-kubernetes-list-type: set
+                    required:
+                    - redact
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+              type: object
             handlerConfiguration:
               description: |-
                 ReloadableComponentConfiguration holds all generic k8s configuration options which can
@@ -1427,6 +1535,27 @@ var CRDsValidation map[string]string = map[string]string{
                     NodeDrainTaintKey defines the taint key that indicates a node should be drained.
                     Note: this option relies on the deprecated node taint feature. Default: kubevirt.io/drain
                   type: string
+                nodeHeadroom:
+                  description: |-
+                    NodeHeadroom reserves a share of the allocatable resources of every node to receive live
+                    migrations, e.g. during node drains. New VMIs are not scheduled on nodes whose free
+                    capacity is below the headroom. Disabled by default.
+                  properties:
+                    cpuPercentage:
+                      description: CPUPercentage is the percentage of the allocatable CPU
+                        of a node kept free for incoming migrations.
+                      format: int32
+                      maximum: 100
+                      minimum: 0
+                      type: integer
+                    memoryPercentage:
+                      description: MemoryPercentage is the percentage of the allocatable
+                        memory of a node kept free for incoming migrations.
+                      format: int32
+                      maximum: 100
+                      minimum: 0
+                      type: integer
+                  type: object
                 parallelMigrationsPerCluster:
                   description: |-
                     ParallelMigrationsPerCluster is the total number of concurrent live migrations
@@ -1569,6 +1698,29 @@ var CRDsValidation map[string]string = map[string]string{
                         type: string
                       resourceName:
                         type: string
+                      schedulingPolicy:
+                        description: |-
+                          SchedulingPolicy configures how the physical GPUs backing devices of
+                          this type are time-sliced between the VMs using them
+                        properties:
+                          scheduler:
+                            description: |-
+                              Scheduler selects the time-slicing policy of the vendor driver.
+                              BestEffort hands the GPU to any vGPU with pending work, FixedShare
+                              reserves an equal share of the GPU for every vGPU, busy or not
+                            enum:
+                            - BestEffort
+                            - FixedShare
+                            type: string
+                          timeSliceMicroseconds:
+                            description: |-
+                              TimeSliceMicroseconds overrides the length of the time slice given
+                              to a vGPU by the vendor driver
+                            format: int32
+                            type: integer
+                        required:
+                        - scheduler
+                        type: object
                     required:
                     - mdevNameSelector
                     - resourceName
@@ -1682,6 +1834,65 @@ var CRDsValidation map[string]string = map[string]string{
                 version:
                   type: string
               type: object
+            subresourceLimits:
+              description: |-
+                SubresourceLimits restricts the console, VNC, usbredir and port-forward streams and the
+                subresource requests which each virt-api replica accepts per user and per namespace.
+                Requests exceeding the limits are rejected with 429 Too Many Requests. When not specified,
+                subresources are not limited.
+              properties:
+                perNamespace:
+                  description: PerNamespace limits the subresource usage in every
+                    namespace.
+                  properties:
+                    burst:
+                      description: |-
+                        Burst is the number of subresource requests which can be issued at once before
+                        RequestsPerSecond applies. Defaults to RequestsPerSecond.
+                      format: int32
+                      minimum: 1
+                      type: integer
+                    maxConcurrentStreams:
+                      description: |-
+                        MaxConcurrentStreams is the maximum number of console, VNC, usbredir and port-forward
+                        streams which can be open at the same time. Not limited if not specified.
+                      format: int32
+                      minimum: 1
+                      type: integer
+                    requestsPerSecond:
+                      description: |-
+                        RequestsPerSecond is the sustained rate of subresource requests which is accepted.
+                        Not limited if not specified.
+                      format: int32
+                      minimum: 1
+                      type: integer
+                  type: object
+                perUser:
+                  description: PerUser limits the subresource usage of every user.
+                  properties:
+                    burst:
+                      description: |-
+                        Burst is the number of subresource requests which can be issued at once before
+                        RequestsPerSecond applies. Defaults to RequestsPerSecond.
+                      format: int32
+                      minimum: 1
+                      type: integer
+                    maxConcurrentStreams:
+                      description: |-
+                        MaxConcurrentStreams is the maximum number of console, VNC, usbredir and port-forward
+                        streams which can be open at the same time. Not limited if not specified.
+                      format: int32
+                      minimum: 1
+                      type: integer
+                    requestsPerSecond:
+                      description: |-
+                        RequestsPerSecond is the sustained rate of subresource requests which is accepted.
+                        Not limited if not specified.
+                      format: int32
+                      minimum: 1
+                      type: integer
+                  type: object
+              type: object
             supportContainerResources:
               description: SupportContainerResources specifies the resource requirements
                 for various types of supporting containers such as container disks/virtiofs/sidecars
@@ -1773,6 +1984,31 @@ var CRDsValidation map[string]string = map[string]string{
                   nullable: true
                   type: boolean
               type: object
+            virtualMachineExport:
+              description: VirtualMachineExport configures the expiration and renewal
+                of VirtualMachineExports.
+              properties:
+                autoRenew:
+                  description: |-
+                    AutoRenew extends the expiration of VirtualMachineExports which do not specify autoRenew
+                    while downloads are in progress. Defaults to false.
+                  type: boolean
+                defaultTTL:
+                  description: |-
+                    DefaultTTL is the time to live of VirtualMachineExports which do not specify a ttlDuration.
+                    Defaults to 2h.
+                  type: string
+                expiryWarning:
+                  description: |-
+                    ExpiryWarning is the time before the expiration of a VirtualMachineExport at which a
+                    warning event is emitted, and at which in progress downloads renew it. Defaults to 10m.
+                  type: string
+                maxLifetime:
+                  description: |-
+                    MaxLifetime is the maximum time a VirtualMachineExport can exist, counting from its creation
+                    and including its renewals. Not limited if not specified.
+                  type: string
+              type: object
             virtualMachineInstancesPerNode:
               type: integer
             virtualMachineOptions:
@@ -1806,6 +2042,24 @@ var CRDsValidation map[string]string = map[string]string{
               description: VMStateStorageClass is the name of the storage class to
                 use for the PVCs created to preserve VM state, like TPM.
               type: string
+            volumePermissionRepairPolicy:
+              allOf:
+              - enum:
+                - Disabled
+                - Ownership
+                - OwnershipAndSELinux
+              - enum:
+                - Disabled
+                - Ownership
+                - OwnershipAndSELinux
+              description: |-
+                VolumePermissionRepairPolicy controls whether virt-handler repairs the ownership
+                and SELinux labels of filesystem volumes which are not accessible by the VM,
+                e.g. because the backing storage was moved from another node.
+                When set to "Disabled" (default) or not specified, mismatches are only reported.
+                When set to "Ownership", the disk images are chowned to the qemu user.
+                When set to "OwnershipAndSELinux", the disk images are additionally relabeled.
+              type: string
             webhookConfiguration:
               description: |-
                 ReloadableComponentConfiguration holds all generic k8s configuration options which can
@@ -3976,6 +4230,63 @@ var CRDsValidation map[string]string = map[string]string{
       description: KubeVirtStatus represents information pertaining to a KubeVirt
         deployment.
       properties:
+        clusterRecovery:
+          description: ClusterRecovery reports the progress of starting VirtualMachines
+            after a cluster-wide outage.
+          properties:
+            completionTime:
+              description: CompletionTime is the time at which all VirtualMachines
+                were started.
+              format: date-time
+              nullable: true
+              type: string
+            currentPriority:
+              description: CurrentPriority is the priority tier which is currently
+                being started.
+              format: int32
+              type: integer
+            pending:
+              description: Pending is the number of those VirtualMachines which are
+                waiting to be started.
+              format: int32
+              type: integer
+            phase:
+              description: ClusterRecoveryPhase is the phase of the recovery from
+                a cluster-wide outage.
+              type: string
+            running:
+              description: Running is the number of those VirtualMachines which are
+                running.
+              format: int32
+              type: integer
+            startTime:
+              description: StartTime is the time at which the outage was detected.
+              format: date-time
+              nullable: true
+              type: string
+            starting:
+              description: Starting is the number of those VirtualMachines which are
+                being started.
+              format: int32
+              type: integer
+            virtualMachines:
+              description: VirtualMachines is the number of VirtualMachines with the
+                Always or RerunOnFailure run strategy.
+              format: int32
+              type: integer
+            waitingForStorage:
+              description: WaitingForStorage is the number of pending VirtualMachines
+                whose PersistentVolumeClaims are not bound yet.
+              format: int32
+              type: integer
+          required:
+          - pending
+          - phase
+          - running
+          - starting
+          - virtualMachines
+          - waitingForStorage
+          type: object
         conditions:
           items:
             description: KubeVirtCondition represents a condition of a KubeVirt deployment
@@ -4810,6 +5121,12 @@ var CRDsValidation map[string]string = map[string]string{
             - spec
             type: object
           type: array
+        deletionProtection:
+          description: |-
+            DeletionProtection rejects the deletion of the VirtualMachine until it is unlocked, either by
+            setting the kubevirt.io/deletion-unlock annotation to the name of the VirtualMachine, or once
+            the time set in the kubevirt.io/deletion-unlock-after annotation passed.
+          type: boolean
         instancetype:
           description: InstancetypeMatcher references a instancetype that is used
             to fill fields in Template
@@ -6184,6 +6501,28 @@ var CRDsValidation map[string]string = map[string]string{
                             Must be a value greater or equal 1.
                           format: int32
                           type: integer
+                        threadShares:
+                          description: |-
+                            ThreadShares weights the CPU time of the emulator and IO threads against the vCPU threads
+                            of the VMI. Only applies to VMIs without dedicated CPUs.
+                          properties:
+                            emulator:
+                              description: |-
+                                Emulator is the CPU shares of the emulator threads.
+                                Threads are left in the cgroup of the VMI if not set.
+                              format: int32
+                              maximum: 262144
+                              minimum: 2
+                              type: integer
+                            ioThreads:
+                              description: |-
+                                IOThreads is the CPU shares of the IO threads.
+                                Threads are left in the cgroup of the VMI if not set.
+                              format: int32
+                              maximum: 262144
+                              minimum: 2
+                              type: integer
+                          type: object
                         threads:
                           description: |-
                             Threads specifies the number of threads inside the vmi.
@@ -6366,6 +6705,11 @@ var CRDsValidation map[string]string = map[string]string{
                               name:
                                 description: Name is the device name
                                 type: string
+                              readErrorPolicy:
+                                description: |-
+                                  If specified, it can change the error policy applied to read errors on the disk.
+                                  Defaults to the errorPolicy. Supported values are stop, report and ignore.
+                                type: string
                               serial:
                                 description: Serial provides the ability to specify
                                   a serial number for the disk device.
@@ -6378,6 +6722,12 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: If specified, disk address and its tag
                                   will be provided to the guest via config drive metadata
                                 type: string
+                              wwn:
+                                description: WWN provides the ability to specify a World Wide
+                                  Name for the disk device. It must consist of 16 hexadecimal
+                                  digits and is only supported for disks and CD-ROMs on the
+                                  sata and scsi buses.
+                                type: string
                             required:
                             - name
                             type: object
@@ -6554,6 +6904,40 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: If specified will pass option 67
                                       to interface's DHCP server
                                     type: string
+                                  domainName:
+                                    description: |-
+                                      If specified will pass the configured domain name to the VM via DHCP option 015,
+                                      instead of the one derived from the pod search domains.
+                                    type: string
+                                  domainSearch:
+                                    description: |-
+                                      If specified the configured DNS search suffixes are passed to the VM via DHCP option 119,
+                                      ahead of the search domains inherited from the pod.
+                                    items:
+                                      type: string
+                                    type: array
+                                  netBIOS:
+                                    description: If specified will pass NetBIOS over
+                                      TCP/IP (WINS) settings to the VM via DHCP options
+                                      044, 046 and 047.
+                                    properties:
+                                      nameServers:
+                                        description: If specified will pass the configured
+                                          NetBIOS name servers (WINS) to the VM via
+                                          DHCP option 044.
+                                        items:
+                                          type: string
+                                        type: array
+                                      nodeType:
+                                        description: |-
+                                          If specified will pass the NetBIOS node type to the VM via DHCP option 046.
+                                          Allowed values are B, P, M and H.
+                                        type: string
+                                      scope:
+                                        description: If specified will pass the NetBIOS
+                                          scope to the VM via DHCP option 047.
+                                        type: string
+                                    type: object
                                   ntpServers:
                                     description: If specified will pass the configured
                                       NTP server to the VM via DHCP option 042.
@@ -6721,13 +7105,29 @@ var CRDsValidation map[string]string = map[string]string{
                           properties:
                             model:
                               description: |-
-                                We only support ich9 or ac97.
+                                We only support ich9, ac97 or virtio.
                                 If SoundDevice is not set: No sound card is emulated.
                                 If SoundDevice is set but Model is not: ich9
                               type: string
                             name:
                               description: User's defined name for this sound device
                               type: string
+                            stream:
+                              description: |-
+                                Stream forwards the audio output of the sound device through the VNC connection.
+                                If Stream is not set the audio output is discarded.
+                              properties:
+                                maxBandwidth:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: |-
+                                    MaxBandwidth caps the bandwidth of the uncompressed audio stream per second.
+                                    The sample rate and the number of channels are lowered until the stream fits.
+                                    Defaults to 44.1kHz 16 bit stereo, around 172Ki per second.
+                                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                  x-kubernetes-int-or-string: true
+                              type: object
                           required:
                           - name
                           type: object
@@ -6761,6 +7161,29 @@ var CRDsValidation map[string]string = map[string]string{
                                 If not specified, the default is architecture-dependent (VGA for BIOS-based VMs, Bochs for EFI-based VMs on AMD64; virtio for Arm and s390x).
                               type: string
                           type: object
+                        vsockServices:
+                          description: |-
+                            VSOCKServices are the named guest services reachable over VSOCK.
+                            Requires autoattachVSOCK. The ports which are not set are allocated
+                            when the VMI is scheduled and reported in status.vsockServices.
+                          items:
+                            description: VSOCKService is a named guest service listening on a VSOCK
+                              port
+                            properties:
+                              name:
+                                description: Name identifies the service within the VMI
+                                type: string
+                              port:
+                                description: |-
+                                  Port the guest service listens on.
+                                  A free port is allocated when it is not set.
+                                format: int32
+                                type: integer
+                            required:
+                            - name
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
                         watchdog:
                           description: Watchdog describes a watchdog device which
                             can be added to the vmi.
@@ -8321,6 +8744,13 @@ var CRDsValidation map[string]string = map[string]string{
                           specified source and provides copy-on-write image on top
                           of it.
                         properties:
+                          overlayClaimName:
+                            description: |-
+                              OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                              keeps the writable overlay of the disk across restarts of the vmi.
+                              If not set, the overlay is transient and discarded when the vmi stops.
+                              The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                            type: string
                           persistentVolumeClaim:
                             description: |-
                               PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
@@ -8378,6 +8808,10 @@ var CRDsValidation map[string]string = map[string]string{
                               claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
                               More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
                             type: string
+                          format:
+                            description: Format is the format the memory is dumped
+                              in, defaults to Raw.
+                            type: string
                           hotpluggable:
                             description: Hotpluggable indicates whether the volume
                               can be hotplugged and hotunplugged.
@@ -8396,6 +8830,13 @@ var CRDsValidation map[string]string = map[string]string{
                           Must be a DNS_LABEL and unique within the vmi.
                           More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
                         type: string
+                      overlayClaimName:
+                        description: |-
+                          OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                          keeps the writable overlay of the disk across restarts of the vmi.
+                          If not set, the overlay is transient and discarded when the vmi stops.
+                          The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                        type: string
                       persistentVolumeClaim:
                         description: |-
                           PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
@@ -8607,6 +9048,16 @@ var CRDsValidation map[string]string = map[string]string{
             updated through an Update() before ObservedGeneration in Status.
           format: int64
           type: integer
+        guestAgentConnected:
+          description: GuestAgentConnected mirrors whether the guest agent of the
+            VirtualMachineInstance is connected
+          type: boolean
+        instanceStatusLastUpdateTime:
+          description: InstanceStatusLastUpdateTime is the last time ipAddresses,
+            nodeName or guestAgentConnected changed
+          format: date-time
+          nullable: true
+          type: string
         instancetypeRef:
           description: InstancetypeRef captures the state of any referenced instance
             type from the VirtualMachine
@@ -8636,6 +9087,13 @@ var CRDsValidation map[string]string = map[string]string{
               description: Name is the name of resource
               type: string
           type: object
+        ipAddresses:
+          description: IPAddresses mirrors the IP addresses reported on the interfaces
+            of the VirtualMachineInstance
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: atomic
         memoryDumpRequest:
           description: |-
             MemoryDumpRequest tracks memory dump request phase and info of getting a memory
@@ -8653,6 +9111,10 @@ var CRDsValidation map[string]string = map[string]string{
             fileName:
               description: FileName represents the name of the output file
               type: string
+            format:
+              description: Format is the format the memory is dumped in, defaults
+                to Raw
+              type: string
             message:
               description: Message is a detailed message about failure of the memory
                 dump
@@ -8672,6 +9134,10 @@ var CRDsValidation map[string]string = map[string]string{
           - claimName
           - phase
           type: object
+        nodeName:
+          description: NodeName mirrors the name of the node the VirtualMachineInstance
+            is running on
+          type: string
         observedGeneration:
           description: ObservedGeneration is the generation observed by the vmi when
             started.
@@ -8904,6 +9370,11 @@ var CRDsValidation map[string]string = map[string]string{
                       name:
                         description: Name is the device name
                         type: string
+                      readErrorPolicy:
+                        description: |-
+                          If specified, it can change the error policy applied to read errors on the disk.
+                          Defaults to the errorPolicy. Supported values are stop, report and ignore.
+                        type: string
                       serial:
                         description: Serial provides the ability to specify a serial
                           number for the disk device.
@@ -8916,6 +9387,12 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
                         type: string
+                      wwn:
+                        description: WWN provides the ability to specify a World Wide
+                          Name for the disk device. It must consist of 16 hexadecimal
+                          digits and is only supported for disks and CD-ROMs on the
+                          sata and scsi buses.
+                        type: string
                     required:
                     - name
                     type: object
@@ -8956,6 +9433,13 @@ var CRDsValidation map[string]string = map[string]string{
                         required:
                         - name
                         type: object
+                      overlayClaimName:
+                        description: |-
+                          OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                          keeps the writable overlay of the disk across restarts of the vmi.
+                          If not set, the overlay is transient and discarded when the vmi stops.
+                          The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                        type: string
                       persistentVolumeClaim:
                         description: |-
                           PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
@@ -9448,6 +9932,61 @@ var CRDsValidation map[string]string = map[string]string{
             type: string
           type: array
           x-kubernetes-list-type: atomic
+        customization:
+          description: |-
+            Customization defines guest customizations applied to the target's disks with virt-sysprep
+            after they were restored, so that the clone doesn't collide with its source on the network.
+            The target is kept halted until the customization finished.
+          properties:
+            hostname:
+              description: Hostname sets the hostname of the guest.
+              type: string
+            regenerateSSHHostKeys:
+              description: RegenerateSSHHostKeys removes the SSH host keys of the
+                guest so that new ones are generated on the next boot.
+              type: boolean
+            resetMachineID:
+              description: ResetMachineID removes the machine-id of the guest so
+                that a new one is generated on the next boot.
+              type: boolean
+          type: object
+        identityPolicy:
+          description: |-
+            IdentityPolicy defines which identity attributes of the source VM are regenerated in the target.
+            MAC addresses, SMBIOS serial and firmware UUID are regenerated by default while the hostname is preserved.
+            NewMacAddresses and NewSMBiosSerial take precedence over this policy.
+          properties:
+            firmwareUUID:
+              description: |-
+                FirmwareUUID controls the SMBIOS system UUID of the VM, which guests commonly use as a hint
+                to initialize their machine-id. A regenerated UUID is derived from the target VM name.
+              enum:
+              - Preserve
+              - Regenerate
+              type: string
+            hostname:
+              description: Hostname controls the hostname of the VM. A regenerated
+                hostname defaults to the target VM name.
+              enum:
+              - Preserve
+              - Regenerate
+              type: string
+            macAddresses:
+              description: |-
+                MacAddresses controls the MAC addresses of the VM interfaces.
+                Regenerated addresses are assigned by the cluster, e.g. by KubeMacPool.
+              enum:
+              - Preserve
+              - Regenerate
+              type: string
+            smbiosSerial:
+              description: SMBiosSerial controls the SMBIOS serial number of the
+                VM.
+              enum:
+              - Preserve
+              - Regenerate
+              type: string
+          type: object
         labelFilters:
           description: |-
             Example use: "!some/key*".
@@ -9590,6 +10129,9 @@ var CRDsValidation map[string]string = map[string]string{
           format: date-time
           nullable: true
           type: string
+        customizationJobName:
+          nullable: true
+          type: string
         phase:
           type: string
         restoreName:
@@ -9636,44 +10178,243 @@ var CRDsValidation map[string]string = map[string]string{
           description: Optionally defines the required Annotations to be used by the
             instance type and applied to the VirtualMachineInstance
           type: object
-        cpu:
-          description: Required CPU related attributes of the instancetype.
-          properties:
-            dedicatedCPUPlacement:
-              description: |-
-                DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node
-                with enough dedicated pCPUs and pin the vCPUs to it.
-              type: boolean
-            guest:
-              description: |-
-                Required number of vCPUs to expose to the guest.
+        architectureVariants:
+          description: |-
+            Optionally defines architecture specific variants of the instancetype.
 
-                The resulting CPU topology being derived from the optional PreferredCPUTopology attribute of CPUPreferences that itself defaults to PreferSockets.
-              format: int32
-              type: integer
-            isolateEmulatorThread:
-              description: |-
-                IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place
-                the emulator thread on it.
-              type: boolean
-            maxSockets:
-              description: MaxSockets specifies the maximum amount of sockets that
-                can be hotplugged
-              format: int32
-              type: integer
-            model:
-              description: |-
-                Model specifies the CPU model inside the VMI.
-                List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.
-                It is possible to specify special cases like "host-passthrough" to get the same CPU as the node
-                and "host-model" to get CPU closest to the node one.
-                Defaults to host-model.
-              type: string
-            numa:
-              description: NUMA allows specifying settings for the guest NUMA topology
-              properties:
-                guestMappingPassthrough:
-                  description: |-
+            The variant matching the architecture of the VirtualMachineInstance, or the PreferredArchitecture of the preference if the former is not set,
+            replaces the CPU, Memory, GPUs and HostDevices it provides before the instancetype is applied.
+          items:
+            properties:
+              architecture:
+                description: Required architecture the variant applies to,
+                  e.g. amd64, arm64 or s390x.
+                type: string
+              cpu:
+                description: Optionally replaces the CPU related attributes of
+                  the instancetype.
+                properties:
+                  dedicatedCPUPlacement:
+                    description: |-
+                      DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node
+                      with enough dedicated pCPUs and pin the vCPUs to it.
+                    type: boolean
+                  guest:
+                    description: |-
+                      Required number of vCPUs to expose to the guest.
+
+                      The resulting CPU topology being derived from the optional PreferredCPUTopology attribute of CPUPreferences that itself defaults to PreferSockets.
+                    format: int32
+                    type: integer
+                  isolateEmulatorThread:
+                    description: |-
+                      IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place
+                      the emulator thread on it.
+                    type: boolean
+                  maxSockets:
+                    description: MaxSockets specifies the maximum amount of sockets that
+                      can be hotplugged
+                    format: int32
+                    type: integer
+                  model:
+                    description: |-
+                      Model specifies the CPU model inside the VMI.
+                      List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.
+                      It is possible to specify special cases like "host-passthrough" to get the same CPU as the node
+                      and "host-model" to get CPU closest to the node one.
+                      Defaults to host-model.
+                    type: string
+                  numa:
+                    description: NUMA allows specifying settings for the guest NUMA topology
+                    properties:
+                      guestMappingPassthrough:
+                        description: |-
+                          GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod.
+                          The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.
+                        type: object
+                    type: object
+                  realtime:
+                    description: Realtime instructs the virt-launcher to tune the VMI for
+                      lower latency, optional for real time workloads
+                    properties:
+                      mask:
+                        description: |-
+                          Mask defines the vcpu mask expression that defines which vcpus are used for realtime. Format matches libvirt's expressions.
+                          Example: "0-3,^1","0,2,3","2-3"
+                        type: string
+                    type: object
+                required:
+                - guest
+                type: object
+              gpus:
+                description: Optionally replaces the GPU devices associated with
+                  the instancetype.
+                items:
+                  properties:
+                    claimName:
+                      description: |-
+                        ClaimName needs to be provided from the list vmi.spec.resourceClaims[].name where this
+                        device is allocated
+                      type: string
+                    deviceName:
+                      description: DeviceName is the name of the device provisioned by device-plugins
+                      type: string
+                    name:
+                      description: Name of the GPU device as exposed by a device plugin
+                      type: string
+                    requestName:
+                      description: |-
+                        RequestName needs to be provided from resourceClaim.spec.devices.requests[].name where this
+                        device is requested
+                      type: string
+                    tag:
+                      description: If specified, the virtual network interface address and
+                        its tag will be provided to the guest via config drive
+                      type: string
+                    virtualGPUOptions:
+                      properties:
+                        display:
+                          properties:
+                            enabled:
+                              description: |-
+                                Enabled determines if a display addapter backed by a vGPU should be enabled or disabled on the guest.
+                                Defaults to true.
+                              type: boolean
+                            ramFB:
+                              description: |-
+                                Enables a boot framebuffer, until the guest OS loads a real GPU driver
+                                Defaults to true.
+                              properties:
+                                enabled:
+                                  description: |-
+                                    Enabled determines if the feature should be enabled or disabled on the guest.
+                                    Defaults to true.
+                                  type: boolean
+                              type: object
+                          type: object
+                      type: object
+                  required:
+                  - name
+                  type: object
+                type: array
+                x-kubernetes-list-type: atomic
+              hostDevices:
+                description: Optionally replaces the HostDevices associated with
+                  the instancetype.
+                items:
+                  properties:
+                    claimName:
+                      description: |-
+                        ClaimName needs to be provided from the list vmi.spec.resourceClaims[].name where this
+                        device is allocated
+                      type: string
+                    deviceName:
+                      description: DeviceName is the name of the device provisioned by device-plugins
+                      type: string
+                    name:
+                      type: string
+                    requestName:
+                      description: |-
+                        RequestName needs to be provided from resourceClaim.spec.devices.requests[].name where this
+                        device is requested
+                      type: string
+                    tag:
+                      description: If specified, the virtual network interface address and
+                        its tag will be provided to the guest via config drive
+                      type: string
+                  required:
+                  - name
+                  type: object
+                type: array
+                x-kubernetes-list-type: atomic
+              memory:
+                description: Optionally replaces the Memory related attributes
+                  of the instancetype.
+                properties:
+                  guest:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: Required amount of memory which is visible inside the guest
+                      OS.
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                  hugepages:
+                    description: Optionally enables the use of hugepages for the VirtualMachineInstance
+                      instead of regular memory.
+                    properties:
+                      pageSize:
+                        description: PageSize specifies the hugepage size, for x86_64 architecture
+                          valid values are 1Gi and 2Mi.
+                        type: string
+                    type: object
+                  maxGuest:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: |-
+                      MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS.
+                      The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                  overcommitPercent:
+                    description: |-
+                      OvercommitPercent is the percentage of the guest memory which will be overcommitted.
+                      This means that the VMIs parent pod (virt-launcher) will request less
+                      physical memory by a factor specified by the OvercommitPercent.
+                      Overcommits can lead to memory exhaustion, which in turn can lead to crashes. Use carefully.
+                      Defaults to 0
+                    maximum: 100
+                    minimum: 0
+                    type: integer
+                required:
+                - guest
+                type: object
+            required:
+            - architecture
+            type: object
+          type: array
+          x-kubernetes-list-map-keys:
+          - architecture
+          x-kubernetes-list-type: map
+        cpu:
+          description: Required CPU related attributes of the instancetype.
+          properties:
+            dedicatedCPUPlacement:
+              description: |-
+                DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node
+                with enough dedicated pCPUs and pin the vCPUs to it.
+              type: boolean
+            guest:
+              description: |-
+                Required number of vCPUs to expose to the guest.
+
+                The resulting CPU topology being derived from the optional PreferredCPUTopology attribute of CPUPreferences that itself defaults to PreferSockets.
+              format: int32
+              type: integer
+            isolateEmulatorThread:
+              description: |-
+                IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place
+                the emulator thread on it.
+              type: boolean
+            maxSockets:
+              description: MaxSockets specifies the maximum amount of sockets that
+                can be hotplugged
+              format: int32
+              type: integer
+            model:
+              description: |-
+                Model specifies the CPU model inside the VMI.
+                List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.
+                It is possible to specify special cases like "host-passthrough" to get the same CPU as the node
+                and "host-model" to get CPU closest to the node one.
+                Defaults to host-model.
+              type: string
+            numa:
+              description: NUMA allows specifying settings for the guest NUMA topology
+              properties:
+                guestMappingPassthrough:
+                  description: |-
                     GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod.
                     The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.
                   type: object
@@ -10618,6 +11359,16 @@ var CRDsValidation map[string]string = map[string]string{
       description: VirtualMachineExportSpec is the spec for a VirtualMachineExport
         resource
       properties:
+        autoRenew:
+          description: |-
+            autoRenew extends the expiration of the export by ttlDuration while downloads are in progress.
+            If this field is omitted, the autoRenew setting of the KubeVirt configuration is applied.
+          type: boolean
+        maxLifetime:
+          description: |-
+            maxLifetime limits the lifetime of an export including its renewals, counting from CreationTimestamp.
+            It is capped by the maxLifetime of the KubeVirt configuration.
+          type: string
         source:
           description: |-
             TypedLocalObjectReference contains enough information to let you locate the
@@ -10685,6 +11436,12 @@ var CRDsValidation map[string]string = map[string]string{
             type: object
           type: array
           x-kubernetes-list-type: atomic
+        lastRenewalTime:
+          description: |-
+            LastRenewalTime is the time at which the expiration of the VM Export was last extended
+            because of downloads in progress
+          format: date-time
+          type: string
         links:
           description: VirtualMachineExportLinks contains the links that point the
             exported VM resources
@@ -10843,7 +11600,7 @@ var CRDsValidation map[string]string = map[string]string{
         ttlExpirationTime:
           description: |-
             The time at which the VM Export will be completely removed according to specified TTL
-            Formula is CreationTimestamp + TTL
+            Formula is CreationTimestamp + TTL, or LastRenewalTime + TTL once renewed, capped by the max lifetime
           format: date-time
           type: string
         virtualMachineName:
@@ -12146,6 +12903,28 @@ var CRDsValidation map[string]string = map[string]string{
                     Must be a value greater or equal 1.
                   format: int32
                   type: integer
+                threadShares:
+                  description: |-
+                    ThreadShares weights the CPU time of the emulator and IO threads against the vCPU threads
+                    of the VMI. Only applies to VMIs without dedicated CPUs.
+                  properties:
+                    emulator:
+                      description: |-
+                        Emulator is the CPU shares of the emulator threads.
+                        Threads are left in the cgroup of the VMI if not set.
+                      format: int32
+                      maximum: 262144
+                      minimum: 2
+                      type: integer
+                    ioThreads:
+                      description: |-
+                        IOThreads is the CPU shares of the IO threads.
+                        Threads are left in the cgroup of the VMI if not set.
+                      format: int32
+                      maximum: 262144
+                      minimum: 2
+                      type: integer
+                  type: object
                 threads:
                   description: |-
                     Threads specifies the number of threads inside the vmi.
@@ -12324,6 +13103,11 @@ var CRDsValidation map[string]string = map[string]string{
                       name:
                         description: Name is the device name
                         type: string
+                      readErrorPolicy:
+                        description: |-
+                          If specified, it can change the error policy applied to read errors on the disk.
+                          Defaults to the errorPolicy. Supported values are stop, report and ignore.
+                        type: string
                       serial:
                         description: Serial provides the ability to specify a serial
                           number for the disk device.
@@ -12336,6 +13120,12 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
                         type: string
+                      wwn:
+                        description: WWN provides the ability to specify a World Wide
+                          Name for the disk device. It must consist of 16 hexadecimal
+                          digits and is only supported for disks and CD-ROMs on the
+                          sata and scsi buses.
+                        type: string
                     required:
                     - name
                     type: object
@@ -12510,6 +13300,40 @@ var CRDsValidation map[string]string = map[string]string{
                             description: If specified will pass option 67 to interface's
                               DHCP server
                             type: string
+                          domainName:
+                            description: |-
+                              If specified will pass the configured domain name to the VM via DHCP option 015,
+                              instead of the one derived from the pod search domains.
+                            type: string
+                          domainSearch:
+                            description: |-
+                              If specified the configured DNS search suffixes are passed to the VM via DHCP option 119,
+                              ahead of the search domains inherited from the pod.
+                            items:
+                              type: string
+                            type: array
+                          netBIOS:
+                            description: If specified will pass NetBIOS over TCP/IP
+                              (WINS) settings to the VM via DHCP options 044, 046
+                              and 047.
+                            properties:
+                              nameServers:
+                                description: If specified will pass the configured
+                                  NetBIOS name servers (WINS) to the VM via DHCP option
+                                  044.
+                                items:
+                                  type: string
+                                type: array
+                              nodeType:
+                                description: |-
+                                  If specified will pass the NetBIOS node type to the VM via DHCP option 046.
+                                  Allowed values are B, P, M and H.
+                                type: string
+                              scope:
+                                description: If specified will pass the NetBIOS scope
+                                  to the VM via DHCP option 047.
+                                type: string
+                            type: object
                           ntpServers:
                             description: If specified will pass the configured NTP
                               server to the VM via DHCP option 042.
@@ -12675,13 +13499,29 @@ var CRDsValidation map[string]string = map[string]string{
                   properties:
                     model:
                       description: |-
-                        We only support ich9 or ac97.
+                        We only support ich9, ac97 or virtio.
                         If SoundDevice is not set: No sound card is emulated.
                         If SoundDevice is set but Model is not: ich9
                       type: string
                     name:
                       description: User's defined name for this sound device
                       type: string
+                    stream:
+                      description: |-
+                        Stream forwards the audio output of the sound device through the VNC connection.
+                        If Stream is not set the audio output is discarded.
+                      properties:
+                        maxBandwidth:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: |-
+                            MaxBandwidth caps the bandwidth of the uncompressed audio stream per second.
+                            The sample rate and the number of channels are lowered until the stream fits.
+                            Defaults to 44.1kHz 16 bit stereo, around 172Ki per second.
+                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          x-kubernetes-int-or-string: true
+                      type: object
                   required:
                   - name
                   type: object
@@ -12715,6 +13555,29 @@ var CRDsValidation map[string]string = map[string]string{
                         If not specified, the default is architecture-dependent (VGA for BIOS-based VMs, Bochs for EFI-based VMs on AMD64; virtio for Arm and s390x).
                       type: string
                   type: object
+                vsockServices:
+                  description: |-
+                    VSOCKServices are the named guest services reachable over VSOCK.
+                    Requires autoattachVSOCK. The ports which are not set are allocated
+                    when the VMI is scheduled and reported in status.vsockServices.
+                  items:
+                    description: VSOCKService is a named guest service listening on a VSOCK
+                      port
+                    properties:
+                      name:
+                        description: Name identifies the service within the VMI
+                        type: string
+                      port:
+                        description: |-
+                          Port the guest service listens on.
+                          A free port is allocated when it is not set.
+                        format: int32
+                        type: integer
+                    required:
+                    - name
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 watchdog:
                   description: Watchdog describes a watchdog device which can be added
                     to the vmi.
@@ -14256,6 +15119,13 @@ var CRDsValidation map[string]string = map[string]string{
                 description: Ephemeral is a special volume source that "wraps" specified
                   source and provides copy-on-write image on top of it.
                 properties:
+                  overlayClaimName:
+                    description: |-
+                      OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                      keeps the writable overlay of the disk across restarts of the vmi.
+                      If not set, the overlay is transient and discarded when the vmi stops.
+                      The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                    type: string
                   persistentVolumeClaim:
                     description: |-
                       PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
@@ -14311,6 +15181,10 @@ var CRDsValidation map[string]string = map[string]string{
                       claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
                       More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
                     type: string
+                  format:
+                    description: Format is the format the memory is dumped in, defaults
+                      to Raw.
+                    type: string
                   hotpluggable:
                     description: Hotpluggable indicates whether the volume can be
                       hotplugged and hotunplugged.
@@ -14329,6 +15203,13 @@ var CRDsValidation map[string]string = map[string]string{
                   Must be a DNS_LABEL and unique within the vmi.
                   More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
                 type: string
+              overlayClaimName:
+                description: |-
+                  OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                  keeps the writable overlay of the disk across restarts of the vmi.
+                  If not set, the overlay is transient and discarded when the vmi stops.
+                  The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                type: string
               persistentVolumeClaim:
                 description: |-
                   PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
@@ -14443,6 +15324,36 @@ var CRDsValidation map[string]string = map[string]string{
             ActivePods is a mapping of pod UID to node name.
             It is possible for multiple pods to be running for a single VMI during migration.
           type: object
+        bootTimes:
+          description: BootTimes records when the VirtualMachineInstance reached the
+            stages of its start.
+          properties:
+            domainStartTime:
+              description: DomainStartTime is when the domain started running and
+                the firmware took over.
+              format: date-time
+              type: string
+            guestAgentConnectTime:
+              description: GuestAgentConnectTime is when the guest agent connected,
+                once the guest OS booted.
+              format: date-time
+              type: string
+            launcherStartTime:
+              description: LauncherStartTime is when the compute container of the
+                virt-launcher pod started, once its images were pulled.
+              format: date-time
+              type: string
+            podCreationTime:
+              description: PodCreationTime is when the virt-launcher pod was created,
+                once the volumes were bound and populated.
+              format: date-time
+              type: string
+            podScheduledTime:
+              description: PodScheduledTime is when the virt-launcher pod was scheduled
+                to a node.
+              format: date-time
+              type: string
+          type: object
         changedBlockTracking:
           description: ChangedBlockTracking represents the status of the changedBlockTracking
           nullable: true
@@ -14529,6 +15440,27 @@ var CRDsValidation map[string]string = map[string]string{
             - type
             type: object
           type: array
+        cpuThreadShares:
+          description: CPUThreadShares reports the CPU shares applied to the emulator
+            and IO threads of the VMI.
+          properties:
+            emulator:
+              description: |-
+                Emulator is the CPU shares of the emulator threads.
+                Threads are left in the cgroup of the VMI if not set.
+              format: int32
+              maximum: 262144
+              minimum: 2
+              type: integer
+            ioThreads:
+              description: |-
+                IOThreads is the CPU shares of the IO threads.
+                Threads are left in the cgroup of the VMI if not set.
+              format: int32
+              maximum: 262144
+              minimum: 2
+              type: integer
+          type: object
         currentCPUTopology:
           description: |-
             CurrentCPUTopology specifies the current CPU topology used by the VM workload.
@@ -14749,6 +15681,45 @@ var CRDsValidation map[string]string = map[string]string{
           description: LauncherContainerImageVersion indicates what container image
             is currently active for the vmi.
           type: string
+        liveUpdatedResources:
+          description: |-
+            LiveUpdatedResources records the guest CPU and memory resources applied
+            to the VMI by live updates since it was started.
+          properties:
+            guest:
+              anyOf:
+              - type: integer
+              - type: string
+              description: Guest is the guest memory applied by the last memory
+                hotplug.
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            limits:
+              additionalProperties:
+                anyOf:
+                - type: integer
+                - type: string
+                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                x-kubernetes-int-or-string: true
+              description: Limits are the CPU and memory limits applied together
+                with the hotplugs.
+              type: object
+            requests:
+              additionalProperties:
+                anyOf:
+                - type: integer
+                - type: string
+                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                x-kubernetes-int-or-string: true
+              description: Requests are the CPU and memory requests applied together
+                with the hotplugs.
+              type: object
+            sockets:
+              description: Sockets is the number of CPU sockets applied by the last
+                CPU hotplug.
+              format: int32
+              type: integer
+          type: object
         machine:
           description: |-
             Machine shows the final resulting qemu machine type. This can be different
@@ -14998,6 +15969,27 @@ var CRDsValidation map[string]string = map[string]string{
                     NodeDrainTaintKey defines the taint key that indicates a node should be drained.
                     Note: this option relies on the deprecated node taint feature. Default: kubevirt.io/drain
                   type: string
+                nodeHeadroom:
+                  description: |-
+                    NodeHeadroom reserves a share of the allocatable resources of every node to receive live
+                    migrations, e.g. during node drains. New VMIs are not scheduled on nodes whose free
+                    capacity is below the headroom. Disabled by default.
+                  properties:
+                    cpuPercentage:
+                      description: CPUPercentage is the percentage of the allocatable CPU
+                        of a node kept free for incoming migrations.
+                      format: int32
+                      maximum: 100
+                      minimum: 0
+                      type: integer
+                    memoryPercentage:
+                      description: MemoryPercentage is the percentage of the allocatable
+                        memory of a node kept free for incoming migrations.
+                      format: int32
+                      maximum: 100
+                      minimum: 0
+                      type: integer
+                  type: object
                 parallelMigrationsPerCluster:
                   description: |-
                     ParallelMigrationsPerCluster is the total number of concurrent live migrations
@@ -15277,6 +16269,14 @@ var CRDsValidation map[string]string = map[string]string{
           description: SELinuxContext is the actual SELinux context of the virt-launcher
             pod
           type: string
+        sshHostKeys:
+          description: |-
+            SSHHostKeys contains the SSH host public keys reported by the guest agent,
+            in the "<key type> <base64 key>" format used by known_hosts files.
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: atomic
         topologyHints:
           properties:
             tscFrequency:
@@ -15415,6 +16415,24 @@ var CRDsValidation map[string]string = map[string]string{
             type: object
           type: array
           x-kubernetes-list-type: atomic
+        vsockServices:
+          description: VSOCKServices reports the VSOCK port of every service
+            of spec.domain.devices.vsockServices
+          items:
+            description: VSOCKServiceStatus reports the VSOCK port of a named
+              guest service
+            properties:
+              name:
+                type: string
+              port:
+                format: int32
+                type: integer
+            required:
+            - name
+            - port
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
       type: object
   required:
   - spec
@@ -15455,6 +16473,11 @@ var CRDsValidation map[string]string = map[string]string{
             are going to be preserved to ensure that addedNodeSelector
             can only restrict but not bypass constraints already set on the VM object.
           type: object
+        migrationPolicyName:
+          description: |-
+            MigrationPolicyName is the name of the MigrationPolicy to apply to this migration.
+            It takes precedence over the MigrationPolicy matched by the selectors of the policies.
+          type: string
         priority:
           description: Priority of the migration. This can be one of 'system-critical',
             'user-triggered', 'system-maintenance'.
@@ -15599,6 +16622,27 @@ var CRDsValidation map[string]string = map[string]string{
                     NodeDrainTaintKey defines the taint key that indicates a node should be drained.
                     Note: this option relies on the deprecated node taint feature. Default: kubevirt.io/drain
                   type: string
+                nodeHeadroom:
+                  description: |-
+                    NodeHeadroom reserves a share of the allocatable resources of every node to receive live
+                    migrations, e.g. during node drains. New VMIs are not scheduled on nodes whose free
+                    capacity is below the headroom. Disabled by default.
+                  properties:
+                    cpuPercentage:
+                      description: CPUPercentage is the percentage of the allocatable CPU
+                        of a node kept free for incoming migrations.
+                      format: int32
+                      maximum: 100
+                      minimum: 0
+                      type: integer
+                    memoryPercentage:
+                      description: MemoryPercentage is the percentage of the allocatable
+                        memory of a node kept free for incoming migrations.
+                      format: int32
+                      maximum: 100
+                      minimum: 0
+                      type: integer
+                  type: object
                 parallelMigrationsPerCluster:
                   description: |-
                     ParallelMigrationsPerCluster is the total number of concurrent live migrations
@@ -16085,6 +17129,28 @@ var CRDsValidation map[string]string = map[string]string{
                     Must be a value greater or equal 1.
                   format: int32
                   type: integer
+                threadShares:
+                  description: |-
+                    ThreadShares weights the CPU time of the emulator and IO threads against the vCPU threads
+                    of the VMI. Only applies to VMIs without dedicated CPUs.
+                  properties:
+                    emulator:
+                      description: |-
+                        Emulator is the CPU shares of the emulator threads.
+                        Threads are left in the cgroup of the VMI if not set.
+                      format: int32
+                      maximum: 262144
+                      minimum: 2
+                      type: integer
+                    ioThreads:
+                      description: |-
+                        IOThreads is the CPU shares of the IO threads.
+                        Threads are left in the cgroup of the VMI if not set.
+                      format: int32
+                      maximum: 262144
+                      minimum: 2
+                      type: integer
+                  type: object
                 threads:
                   description: |-
                     Threads specifies the number of threads inside the vmi.
@@ -16263,6 +17329,11 @@ var CRDsValidation map[string]string = map[string]string{
                       name:
                         description: Name is the device name
                         type: string
+                      readErrorPolicy:
+                        description: |-
+                          If specified, it can change the error policy applied to read errors on the disk.
+                          Defaults to the errorPolicy. Supported values are stop, report and ignore.
+                        type: string
                       serial:
                         description: Serial provides the ability to specify a serial
                           number for the disk device.
@@ -16275,6 +17346,12 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
                         type: string
+                      wwn:
+                        description: WWN provides the ability to specify a World Wide
+                          Name for the disk device. It must consist of 16 hexadecimal
+                          digits and is only supported for disks and CD-ROMs on the
+                          sata and scsi buses.
+                        type: string
                     required:
                     - name
                     type: object
@@ -16449,6 +17526,40 @@ var CRDsValidation map[string]string = map[string]string{
                             description: If specified will pass option 67 to interface's
                               DHCP server
                             type: string
+                          domainName:
+                            description: |-
+                              If specified will pass the configured domain name to the VM via DHCP option 015,
+                              instead of the one derived from the pod search domains.
+                            type: string
+                          domainSearch:
+                            description: |-
+                              If specified the configured DNS search suffixes are passed to the VM via DHCP option 119,
+                              ahead of the search domains inherited from the pod.
+                            items:
+                              type: string
+                            type: array
+                          netBIOS:
+                            description: If specified will pass NetBIOS over TCP/IP
+                              (WINS) settings to the VM via DHCP options 044, 046
+                              and 047.
+                            properties:
+                              nameServers:
+                                description: If specified will pass the configured
+                                  NetBIOS name servers (WINS) to the VM via DHCP option
+                                  044.
+                                items:
+                                  type: string
+                                type: array
+                              nodeType:
+                                description: |-
+                                  If specified will pass the NetBIOS node type to the VM via DHCP option 046.
+                                  Allowed values are B, P, M and H.
+                                type: string
+                              scope:
+                                description: If specified will pass the NetBIOS scope
+                                  to the VM via DHCP option 047.
+                                type: string
+                            type: object
                           ntpServers:
                             description: If specified will pass the configured NTP
                               server to the VM via DHCP option 042.
@@ -16614,13 +17725,29 @@ var CRDsValidation map[string]string = map[string]string{
                   properties:
                     model:
                       description: |-
-                        We only support ich9 or ac97.
+                        We only support ich9, ac97 or virtio.
                         If SoundDevice is not set: No sound card is emulated.
                         If SoundDevice is set but Model is not: ich9
                       type: string
                     name:
                       description: User's defined name for this sound device
                       type: string
+                    stream:
+                      description: |-
+                        Stream forwards the audio output of the sound device through the VNC connection.
+                        If Stream is not set the audio output is discarded.
+                      properties:
+                        maxBandwidth:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: |-
+                            MaxBandwidth caps the bandwidth of the uncompressed audio stream per second.
+                            The sample rate and the number of channels are lowered until the stream fits.
+                            Defaults to 44.1kHz 16 bit stereo, around 172Ki per second.
+                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          x-kubernetes-int-or-string: true
+                      type: object
                   required:
                   - name
                   type: object
@@ -16654,6 +17781,29 @@ var CRDsValidation map[string]string = map[string]string{
                         If not specified, the default is architecture-dependent (VGA for BIOS-based VMs, Bochs for EFI-based VMs on AMD64; virtio for Arm and s390x).
                       type: string
                   type: object
+                vsockServices:
+                  description: |-
+                    VSOCKServices are the named guest services reachable over VSOCK.
+                    Requires autoattachVSOCK. The ports which are not set are allocated
+                    when the VMI is scheduled and reported in status.vsockServices.
+                  items:
+                    description: VSOCKService is a named guest service listening on a VSOCK
+                      port
+                    properties:
+                      name:
+                        description: Name identifies the service within the VMI
+                        type: string
+                      port:
+                        description: |-
+                          Port the guest service listens on.
+                          A free port is allocated when it is not set.
+                        format: int32
+                        type: integer
+                    required:
+                    - name
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 watchdog:
                   description: Watchdog describes a watchdog device which can be added
                     to the vmi.
@@ -18635,6 +19785,28 @@ var CRDsValidation map[string]string = map[string]string{
                             Must be a value greater or equal 1.
                           format: int32
                           type: integer
+                        threadShares:
+                          description: |-
+                            ThreadShares weights the CPU time of the emulator and IO threads against the vCPU threads
+                            of the VMI. Only applies to VMIs without dedicated CPUs.
+                          properties:
+                            emulator:
+                              description: |-
+                                Emulator is the CPU shares of the emulator threads.
+                                Threads are left in the cgroup of the VMI if not set.
+                              format: int32
+                              maximum: 262144
+                              minimum: 2
+                              type: integer
+                            ioThreads:
+                              description: |-
+                                IOThreads is the CPU shares of the IO threads.
+                                Threads are left in the cgroup of the VMI if not set.
+                              format: int32
+                              maximum: 262144
+                              minimum: 2
+                              type: integer
+                          type: object
                         threads:
                           description: |-
                             Threads specifies the number of threads inside the vmi.
@@ -18817,6 +19989,11 @@ var CRDsValidation map[string]string = map[string]string{
                               name:
                                 description: Name is the device name
                                 type: string
+                              readErrorPolicy:
+                                description: |-
+                                  If specified, it can change the error policy applied to read errors on the disk.
+                                  Defaults to the errorPolicy. Supported values are stop, report and ignore.
+                                type: string
                               serial:
                                 description: Serial provides the ability to specify
                                   a serial number for the disk device.
@@ -18829,6 +20006,12 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: If specified, disk address and its tag
                                   will be provided to the guest via config drive metadata
                                 type: string
+                              wwn:
+                                description: WWN provides the ability to specify a World Wide
+                                  Name for the disk device. It must consist of 16 hexadecimal
+                                  digits and is only supported for disks and CD-ROMs on the
+                                  sata and scsi buses.
+                                type: string
                             required:
                             - name
                             type: object
@@ -19005,6 +20188,40 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: If specified will pass option 67
                                       to interface's DHCP server
                                     type: string
+                                  domainName:
+                                    description: |-
+                                      If specified will pass the configured domain name to the VM via DHCP option 015,
+                                      instead of the one derived from the pod search domains.
+                                    type: string
+                                  domainSearch:
+                                    description: |-
+                                      If specified the configured DNS search suffixes are passed to the VM via DHCP option 119,
+                                      ahead of the search domains inherited from the pod.
+                                    items:
+                                      type: string
+                                    type: array
+                                  netBIOS:
+                                    description: If specified will pass NetBIOS over
+                                      TCP/IP (WINS) settings to the VM via DHCP options
+                                      044, 046 and 047.
+                                    properties:
+                                      nameServers:
+                                        description: If specified will pass the configured
+                                          NetBIOS name servers (WINS) to the VM via
+                                          DHCP option 044.
+                                        items:
+                                          type: string
+                                        type: array
+                                      nodeType:
+                                        description: |-
+                                          If specified will pass the NetBIOS node type to the VM via DHCP option 046.
+                                          Allowed values are B, P, M and H.
+                                        type: string
+                                      scope:
+                                        description: If specified will pass the NetBIOS
+                                          scope to the VM via DHCP option 047.
+                                        type: string
+                                    type: object
                                   ntpServers:
                                     description: If specified will pass the configured
                                       NTP server to the VM via DHCP option 042.
@@ -19172,13 +20389,29 @@ var CRDsValidation map[string]string = map[string]string{
                           properties:
                             model:
                               description: |-
-                                We only support ich9 or ac97.
+                                We only support ich9, ac97 or virtio.
                                 If SoundDevice is not set: No sound card is emulated.
                                 If SoundDevice is set but Model is not: ich9
                               type: string
                             name:
                               description: User's defined name for this sound device
                               type: string
+                            stream:
+                              description: |-
+                                Stream forwards the audio output of the sound device through the VNC connection.
+                                If Stream is not set the audio output is discarded.
+                              properties:
+                                maxBandwidth:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: |-
+                                    MaxBandwidth caps the bandwidth of the uncompressed audio stream per second.
+                                    The sample rate and the number of channels are lowered until the stream fits.
+                                    Defaults to 44.1kHz 16 bit stereo, around 172Ki per second.
+                                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                  x-kubernetes-int-or-string: true
+                              type: object
                           required:
                           - name
                           type: object
@@ -19212,6 +20445,29 @@ var CRDsValidation map[string]string = map[string]string{
                                 If not specified, the default is architecture-dependent (VGA for BIOS-based VMs, Bochs for EFI-based VMs on AMD64; virtio for Arm and s390x).
                               type: string
                           type: object
+                        vsockServices:
+                          description: |-
+                            VSOCKServices are the named guest services reachable over VSOCK.
+                            Requires autoattachVSOCK. The ports which are not set are allocated
+                            when the VMI is scheduled and reported in status.vsockServices.
+                          items:
+                            description: VSOCKService is a named guest service listening on a VSOCK
+                              port
+                            properties:
+                              name:
+                                description: Name identifies the service within the VMI
+                                type: string
+                              port:
+                                description: |-
+                                  Port the guest service listens on.
+                                  A free port is allocated when it is not set.
+                                format: int32
+                                type: integer
+                            required:
+                            - name
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
                         watchdog:
                           description: Watchdog describes a watchdog device which
                             can be added to the vmi.
@@ -20772,6 +22028,13 @@ var CRDsValidation map[string]string = map[string]string{
                           specified source and provides copy-on-write image on top
                           of it.
                         properties:
+                          overlayClaimName:
+                            description: |-
+                              OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                              keeps the writable overlay of the disk across restarts of the vmi.
+                              If not set, the overlay is transient and discarded when the vmi stops.
+                              The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                            type: string
                           persistentVolumeClaim:
                             description: |-
                               PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
@@ -20829,6 +22092,10 @@ var CRDsValidation map[string]string = map[string]string{
                               claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
                               More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
                             type: string
+                          format:
+                            description: Format is the format the memory is dumped
+                              in, defaults to Raw.
+                            type: string
                           hotpluggable:
                             description: Hotpluggable indicates whether the volume
                               can be hotplugged and hotunplugged.
@@ -20847,6 +22114,13 @@ var CRDsValidation map[string]string = map[string]string{
                           Must be a DNS_LABEL and unique within the vmi.
                           More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
                         type: string
+                      overlayClaimName:
+                        description: |-
+                          OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                          keeps the writable overlay of the disk across restarts of the vmi.
+                          If not set, the overlay is transient and discarded when the vmi stops.
+                          The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                        type: string
                       persistentVolumeClaim:
                         description: |-
                           PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
@@ -21030,6 +22304,205 @@ var CRDsValidation map[string]string = map[string]string{
           description: Optionally defines the required Annotations to be used by the
             instance type and applied to the VirtualMachineInstance
           type: object
+        architectureVariants:
+          description: |-
+            Optionally defines architecture specific variants of the instancetype.
+
+            The variant matching the architecture of the VirtualMachineInstance, or the PreferredArchitecture of the preference if the former is not set,
+            replaces the CPU, Memory, GPUs and HostDevices it provides before the instancetype is applied.
+          items:
+            properties:
+              architecture:
+                description: Required architecture the variant applies to,
+                  e.g. amd64, arm64 or s390x.
+                type: string
+              cpu:
+                description: Optionally replaces the CPU related attributes of
+                  the instancetype.
+                properties:
+                  dedicatedCPUPlacement:
+                    description: |-
+                      DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node
+                      with enough dedicated pCPUs and pin the vCPUs to it.
+                    type: boolean
+                  guest:
+                    description: |-
+                      Required number of vCPUs to expose to the guest.
+
+                      The resulting CPU topology being derived from the optional PreferredCPUTopology attribute of CPUPreferences that itself defaults to PreferSockets.
+                    format: int32
+                    type: integer
+                  isolateEmulatorThread:
+                    description: |-
+                      IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place
+                      the emulator thread on it.
+                    type: boolean
+                  maxSockets:
+                    description: MaxSockets specifies the maximum amount of sockets that
+                      can be hotplugged
+                    format: int32
+                    type: integer
+                  model:
+                    description: |-
+                      Model specifies the CPU model inside the VMI.
+                      List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.
+                      It is possible to specify special cases like "host-passthrough" to get the same CPU as the node
+                      and "host-model" to get CPU closest to the node one.
+                      Defaults to host-model.
+                    type: string
+                  numa:
+                    description: NUMA allows specifying settings for the guest NUMA topology
+                    properties:
+                      guestMappingPassthrough:
+                        description: |-
+                          GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod.
+                          The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.
+                        type: object
+                    type: object
+                  realtime:
+                    description: Realtime instructs the virt-launcher to tune the VMI for
+                      lower latency, optional for real time workloads
+                    properties:
+                      mask:
+                        description: |-
+                          Mask defines the vcpu mask expression that defines which vcpus are used for realtime. Format matches libvirt's expressions.
+                          Example: "0-3,^1","0,2,3","2-3"
+                        type: string
+                    type: object
+                required:
+                - guest
+                type: object
+              gpus:
+                description: Optionally replaces the GPU devices associated with
+                  the instancetype.
+                items:
+                  properties:
+                    claimName:
+                      description: |-
+                        ClaimName needs to be provided from the list vmi.spec.resourceClaims[].name where this
+                        device is allocated
+                      type: string
+                    deviceName:
+                      description: DeviceName is the name of the device provisioned by device-plugins
+                      type: string
+                    name:
+                      description: Name of the GPU device as exposed by a device plugin
+                      type: string
+                    requestName:
+                      description: |-
+                        RequestName needs to be provided from resourceClaim.spec.devices.requests[].name where this
+                        device is requested
+                      type: string
+                    tag:
+                      description: If specified, the virtual network interface address and
+                        its tag will be provided to the guest via config drive
+                      type: string
+                    virtualGPUOptions:
+                      properties:
+                        display:
+                          properties:
+                            enabled:
+                              description: |-
+                                Enabled determines if a display addapter backed by a vGPU should be enabled or disabled on the guest.
+                                Defaults to true.
+                              type: boolean
+                            ramFB:
+                              description: |-
+                                Enables a boot framebuffer, until the guest OS loads a real GPU driver
+                                Defaults to true.
+                              properties:
+                                enabled:
+                                  description: |-
+                                    Enabled determines if the feature should be enabled or disabled on the guest.
+                                    Defaults to true.
+                                  type: boolean
+                              type: object
+                          type: object
+                      type: object
+                  required:
+                  - name
+                  type: object
+                type: array
+                x-kubernetes-list-type: atomic
+              hostDevices:
+                description: Optionally replaces the HostDevices associated with
+                  the instancetype.
+                items:
+                  properties:
+                    claimName:
+                      description: |-
+                        ClaimName needs to be provided from the list vmi.spec.resourceClaims[].name where this
+                        device is allocated
+                      type: string
+                    deviceName:
+                      description: DeviceName is the name of the device provisioned by device-plugins
+                      type: string
+                    name:
+                      type: string
+                    requestName:
+                      description: |-
+                        RequestName needs to be provided from resourceClaim.spec.devices.requests[].name where this
+                        device is requested
+                      type: string
+                    tag:
+                      description: If specified, the virtual network interface address and
+                        its tag will be provided to the guest via config drive
+                      type: string
+                  required:
+                  - name
+                  type: object
+                type: array
+                x-kubernetes-list-type: atomic
+              memory:
+                description: Optionally replaces the Memory related attributes
+                  of the instancetype.
+                properties:
+                  guest:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: Required amount of memory which is visible inside the guest
+                      OS.
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                  hugepages:
+                    description: Optionally enables the use of hugepages for the VirtualMachineInstance
+                      instead of regular memory.
+                    properties:
+                      pageSize:
+                        description: PageSize specifies the hugepage size, for x86_64 architecture
+                          valid values are 1Gi and 2Mi.
+                        type: string
+                    type: object
+                  maxGuest:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: |-
+                      MaxGuest allows to specify the maximum amount of memory which is visible inside the Guest OS.
+                      The delta between MaxGuest and Guest is the amount of memory that can be hot(un)plugged.
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                  overcommitPercent:
+                    description: |-
+                      OvercommitPercent is the percentage of the guest memory which will be overcommitted.
+                      This means that the VMIs parent pod (virt-launcher) will request less
+                      physical memory by a factor specified by the OvercommitPercent.
+                      Overcommits can lead to memory exhaustion, which in turn can lead to crashes. Use carefully.
+                      Defaults to 0
+                    maximum: 100
+                    minimum: 0
+                    type: integer
+                required:
+                - guest
+                type: object
+            required:
+            - architecture
+            type: object
+          type: array
+          x-kubernetes-list-map-keys:
+          - architecture
+          x-kubernetes-list-type: map
         cpu:
           description: Required CPU related attributes of the instancetype.
           properties:
@@ -21242,37 +22715,243 @@ var CRDsValidation map[string]string = map[string]string{
               x-kubernetes-int-or-string: true
             overcommitPercent:
               description: |-
-                OvercommitPercent is the percentage of the guest memory which will be overcommitted.
-                This means that the VMIs parent pod (virt-launcher) will request less
-                physical memory by a factor specified by the OvercommitPercent.
-                Overcommits can lead to memory exhaustion, which in turn can lead to crashes. Use carefully.
-                Defaults to 0
-              maximum: 100
-              minimum: 0
-              type: integer
-          required:
-          - guest
-          type: object
-        nodeSelector:
-          additionalProperties:
-            type: string
-          description: |-
-            NodeSelector is a selector which must be true for the vmi to fit on a node.
-            Selector which must match a node's labels for the vmi to be scheduled on that node.
-            More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
-
-            NodeSelector is the name of the custom node selector for the instancetype.
+                OvercommitPercent is the percentage of the guest memory which will be overcommitted.
+                This means that the VMIs parent pod (virt-launcher) will request less
+                physical memory by a factor specified by the OvercommitPercent.
+                Overcommits can lead to memory exhaustion, which in turn can lead to crashes. Use carefully.
+                Defaults to 0
+              maximum: 100
+              minimum: 0
+              type: integer
+          required:
+          - guest
+          type: object
+        nodeSelector:
+          additionalProperties:
+            type: string
+          description: |-
+            NodeSelector is a selector which must be true for the vmi to fit on a node.
+            Selector which must match a node's labels for the vmi to be scheduled on that node.
+            More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
+
+            NodeSelector is the name of the custom node selector for the instancetype.
+          type: object
+        schedulerName:
+          description: |-
+            If specified, the VMI will be dispatched by specified scheduler.
+            If not specified, the VMI will be dispatched by default scheduler.
+
+            SchedulerName is the name of the custom K8s scheduler for the instancetype.
+          type: string
+      required:
+      - cpu
+      - memory
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineoperation": `openAPIV3Schema:
+  description: VirtualMachineOperation applies an operation to every VirtualMachine
+    matching a selector
+  properties:
+    apiVersion:
+      description: |-
+        APIVersion defines the versioned schema of this representation of an object.
+        Servers should convert recognized schemas to the latest internal value, and
+        may reject unrecognized values.
+        More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+      type: string
+    kind:
+      description: |-
+        Kind is a string value representing the REST resource this object represents.
+        Servers may infer this from the endpoint the client submits requests to.
+        Cannot be updated.
+        In CamelCase.
+        More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineOperationSpec is the spec for a VirtualMachineOperation
+        resource
+      properties:
+        maxConcurrency:
+          description: |-
+            MaxConcurrency is the maximum number of targets processed at the same time.
+            Defaults to 1.
+          format: int32
+          minimum: 1
+          type: integer
+        maxFailures:
+          description: |-
+            MaxFailures is the number of failed targets tolerated. Once more targets
+            failed, no further targets are started and the remaining ones are skipped.
+            If unset, all targets are processed regardless of failures.
+          format: int32
+          minimum: 0
+          type: integer
+        operation:
+          description: Operation is the action applied to each targeted VirtualMachine
+          enum:
+          - Start
+          - Stop
+          - Migrate
+          - Snapshot
+          type: string
+        selector:
+          description: |-
+            Selector selects the VirtualMachines in the namespace of the operation.
+            The targets are resolved once, when the operation starts.
+          properties:
+            matchExpressions:
+              description: matchExpressions is a list of label selector requirements.
+                The requirements are ANDed.
+              items:
+                description: |-
+                  A label selector requirement is a selector that contains values, a key, and an operator that
+                  relates the key and values.
+                properties:
+                  key:
+                    description: key is the label key that the selector applies to.
+                    type: string
+                  operator:
+                    description: |-
+                      operator represents a key's relationship to a set of values.
+                      Valid operators are In, NotIn, Exists and DoesNotExist.
+                    type: string
+                  values:
+                    description: |-
+                      values is an array of string values. If the operator is In or NotIn,
+                      the values array must be non-empty. If the operator is Exists or DoesNotExist,
+                      the values array must be empty. This array is replaced during a strategic
+                      merge patch.
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                required:
+                - key
+                - operator
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+            matchLabels:
+              additionalProperties:
+                type: string
+              description: |-
+                matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                map is equivalent to an element of matchExpressions, whose key field is "key", the
+                operator is "In", and the values array contains only "value". The requirements are ANDed.
+              type: object
           type: object
-        schedulerName:
+          x-kubernetes-map-type: atomic
+        targetTimeout:
           description: |-
-            If specified, the VMI will be dispatched by specified scheduler.
-            If not specified, the VMI will be dispatched by default scheduler.
-
-            SchedulerName is the name of the custom K8s scheduler for the instancetype.
+            TargetTimeout is the time a single target may take before it is considered failed.
+            Defaults to 10 minutes.
           type: string
       required:
-      - cpu
-      - memory
+      - operation
+      - selector
+      type: object
+      x-kubernetes-validations:
+      - message: spec is immutable after creation
+        rule: self == oldSelf
+    status:
+      description: VirtualMachineOperationStatus is the status for a VirtualMachineOperation
+        resource
+      properties:
+        completionTime:
+          format: date-time
+          type: string
+        phase:
+          description: VirtualMachineOperationPhase is the current phase of a VirtualMachineOperation
+          type: string
+        progress:
+          description: VirtualMachineOperationProgress counts the targets of a VirtualMachineOperation
+            by phase
+          properties:
+            failed:
+              format: int32
+              type: integer
+            pending:
+              format: int32
+              type: integer
+            running:
+              format: int32
+              type: integer
+            skipped:
+              format: int32
+              type: integer
+            succeeded:
+              format: int32
+              type: integer
+            total:
+              format: int32
+              type: integer
+          required:
+          - failed
+          - pending
+          - running
+          - skipped
+          - succeeded
+          - total
+          type: object
+        startTime:
+          format: date-time
+          type: string
+        targets:
+          description: Targets holds the result of the operation for each targeted
+            VirtualMachine
+          items:
+            description: VirtualMachineOperationTarget is the result of a VirtualMachineOperation
+              for a single VirtualMachine
+            properties:
+              completionTime:
+                format: date-time
+                type: string
+              message:
+                type: string
+              name:
+                description: Name of the targeted VirtualMachine
+                type: string
+              phase:
+                description: VirtualMachineOperationTargetPhase is the phase of a
+                  single target of a VirtualMachineOperation
+                type: string
+              reference:
+                description: Reference points to the object created for the target,
+                  e.g. the migration or the snapshot
+                properties:
+                  apiGroup:
+                    description: |-
+                      APIGroup is the group for the resource being referenced.
+                      If APIGroup is not specified, the specified Kind must be in the core API group.
+                      For any other third-party types, APIGroup is required.
+                    type: string
+                  kind:
+                    description: Kind is the type of resource being referenced
+                    type: string
+                  name:
+                    description: Name is the name of resource being referenced
+                    type: string
+                required:
+                - kind
+                - name
+                type: object
+                x-kubernetes-map-type: atomic
+              startTime:
+                format: date-time
+                type: string
+            required:
+            - name
+            - phase
+            type: object
+          type: array
+          x-kubernetes-list-map-keys:
+          - name
+          x-kubernetes-list-type: map
       type: object
   required:
   - spec
@@ -22332,6 +24011,12 @@ var CRDsValidation map[string]string = map[string]string{
                     - spec
                     type: object
                   type: array
+                deletionProtection:
+                  description: |-
+                    DeletionProtection rejects the deletion of the VirtualMachine until it is unlocked, either by
+                    setting the kubevirt.io/deletion-unlock annotation to the name of the VirtualMachine, or once
+                    the time set in the kubevirt.io/deletion-unlock-after annotation passed.
+                  type: boolean
                 instancetype:
                   description: InstancetypeMatcher references a instancetype that
                     is used to fill fields in Template
@@ -23721,6 +25406,28 @@ var CRDsValidation map[string]string = map[string]string{
                                     Must be a value greater or equal 1.
                                   format: int32
                                   type: integer
+                                threadShares:
+                                  description: |-
+                                    ThreadShares weights the CPU time of the emulator and IO threads against the vCPU threads
+                                    of the VMI. Only applies to VMIs without dedicated CPUs.
+                                  properties:
+                                    emulator:
+                                      description: |-
+                                        Emulator is the CPU shares of the emulator threads.
+                                        Threads are left in the cgroup of the VMI if not set.
+                                      format: int32
+                                      maximum: 262144
+                                      minimum: 2
+                                      type: integer
+                                    ioThreads:
+                                      description: |-
+                                        IOThreads is the CPU shares of the IO threads.
+                                        Threads are left in the cgroup of the VMI if not set.
+                                      format: int32
+                                      maximum: 262144
+                                      minimum: 2
+                                      type: integer
+                                  type: object
                                 threads:
                                   description: |-
                                     Threads specifies the number of threads inside the vmi.
@@ -23908,6 +25615,11 @@ var CRDsValidation map[string]string = map[string]string{
                                       name:
                                         description: Name is the device name
                                         type: string
+                                      readErrorPolicy:
+                                        description: |-
+                                          If specified, it can change the error policy applied to read errors on the disk.
+                                          Defaults to the errorPolicy. Supported values are stop, report and ignore.
+                                        type: string
                                       serial:
                                         description: Serial provides the ability to
                                           specify a serial number for the disk device.
@@ -23922,6 +25634,12 @@ var CRDsValidation map[string]string = map[string]string{
                                           its tag will be provided to the guest via
                                           config drive metadata
                                         type: string
+                                      wwn:
+                                        description: WWN provides the ability to specify a World Wide
+                                          Name for the disk device. It must consist of 16 hexadecimal
+                                          digits and is only supported for disks and CD-ROMs on the
+                                          sata and scsi buses.
+                                        type: string
                                     required:
                                     - name
                                     type: object
@@ -24101,6 +25819,42 @@ var CRDsValidation map[string]string = map[string]string{
                                             description: If specified will pass option
                                               67 to interface's DHCP server
                                             type: string
+                                          domainName:
+                                            description: |-
+                                              If specified will pass the configured domain name to the VM via DHCP option 015,
+                                              instead of the one derived from the pod search domains.
+                                            type: string
+                                          domainSearch:
+                                            description: |-
+                                              If specified the configured DNS search suffixes are passed to the VM via DHCP option 119,
+                                              ahead of the search domains inherited from the pod.
+                                            items:
+                                              type: string
+                                            type: array
+                                          netBIOS:
+                                            description: If specified will pass NetBIOS
+                                              over TCP/IP (WINS) settings to the VM
+                                              via DHCP options 044, 046 and 047.
+                                            properties:
+                                              nameServers:
+                                                description: If specified will pass
+                                                  the configured NetBIOS name servers
+                                                  (WINS) to the VM via DHCP option
+                                                  044.
+                                                items:
+                                                  type: string
+                                                type: array
+                                              nodeType:
+                                                description: |-
+                                                  If specified will pass the NetBIOS node type to the VM via DHCP option 046.
+                                                  Allowed values are B, P, M and H.
+                                                type: string
+                                              scope:
+                                                description: If specified will pass
+                                                  the NetBIOS scope to the VM via
+                                                  DHCP option 047.
+                                                type: string
+                                            type: object
                                           ntpServers:
                                             description: If specified will pass the
                                               configured NTP server to the VM via
@@ -24275,7 +26029,7 @@ var CRDsValidation map[string]string = map[string]string{
                                   properties:
                                     model:
                                       description: |-
-                                        We only support ich9 or ac97.
+                                        We only support ich9, ac97 or virtio.
                                         If SoundDevice is not set: No sound card is emulated.
                                         If SoundDevice is set but Model is not: ich9
                                       type: string
@@ -24283,6 +26037,22 @@ var CRDsValidation map[string]string = map[string]string{
                                       description: User's defined name for this sound
                                         device
                                       type: string
+                                    stream:
+                                      description: |-
+                                        Stream forwards the audio output of the sound device through the VNC connection.
+                                        If Stream is not set the audio output is discarded.
+                                      properties:
+                                        maxBandwidth:
+                                          anyOf:
+                                          - type: integer
+                                          - type: string
+                                          description: |-
+                                            MaxBandwidth caps the bandwidth of the uncompressed audio stream per second.
+                                            The sample rate and the number of channels are lowered until the stream fits.
+                                            Defaults to 44.1kHz 16 bit stereo, around 172Ki per second.
+                                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                          x-kubernetes-int-or-string: true
+                                      type: object
                                   required:
                                   - name
                                   type: object
@@ -24316,6 +26086,29 @@ var CRDsValidation map[string]string = map[string]string{
                                         If not specified, the default is architecture-dependent (VGA for BIOS-based VMs, Bochs for EFI-based VMs on AMD64; virtio for Arm and s390x).
                                       type: string
                                   type: object
+                                vsockServices:
+                                  description: |-
+                                    VSOCKServices are the named guest services reachable over VSOCK.
+                                    Requires autoattachVSOCK. The ports which are not set are allocated
+                                    when the VMI is scheduled and reported in status.vsockServices.
+                                  items:
+                                    description: VSOCKService is a named guest service listening on a VSOCK
+                                      port
+                                    properties:
+                                      name:
+                                        description: Name identifies the service within the VMI
+                                        type: string
+                                      port:
+                                        description: |-
+                                          Port the guest service listens on.
+                                          A free port is allocated when it is not set.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - name
+                                    type: object
+                                  type: array
+                                  x-kubernetes-list-type: atomic
                                 watchdog:
                                   description: Watchdog describes a watchdog device
                                     which can be added to the vmi.
@@ -25896,6 +27689,13 @@ var CRDsValidation map[string]string = map[string]string{
                                   that "wraps" specified source and provides copy-on-write
                                   image on top of it.
                                 properties:
+                                  overlayClaimName:
+                                    description: |-
+                                      OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                                      keeps the writable overlay of the disk across restarts of the vmi.
+                                      If not set, the overlay is transient and discarded when the vmi stops.
+                                      The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                                    type: string
                                   persistentVolumeClaim:
                                     description: |-
                                       PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
@@ -25953,6 +27753,10 @@ var CRDsValidation map[string]string = map[string]string{
                                       claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
                                       More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
                                     type: string
+                                  format:
+                                    description: Format is the format the memory is
+                                      dumped in, defaults to Raw.
+                                    type: string
                                   hotpluggable:
                                     description: Hotpluggable indicates whether the
                                       volume can be hotplugged and hotunplugged.
@@ -25971,6 +27775,13 @@ var CRDsValidation map[string]string = map[string]string{
                                   Must be a DNS_LABEL and unique within the vmi.
                                   More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
                                 type: string
+                              overlayClaimName:
+                                description: |-
+                                  OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                                  keeps the writable overlay of the disk across restarts of the vmi.
+                                  If not set, the overlay is transient and discarded when the vmi stops.
+                                  The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                                type: string
                               persistentVolumeClaim:
                                 description: |-
                                   PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
@@ -26860,6 +28671,42 @@ var CRDsValidation map[string]string = map[string]string{
       description: VirtualMachineRestoreSpec is the spec for a VirtualMachineRestore
         resource
       properties:
+        identityPolicy:
+          description: |-
+            IdentityPolicy defines which identity attributes of the snapshotted VM are regenerated in the restored VM.
+            All of them are preserved by default.
+          properties:
+            firmwareUUID:
+              description: |-
+                FirmwareUUID controls the SMBIOS system UUID of the VM, which guests commonly use as a hint
+                to initialize their machine-id. A regenerated UUID is derived from the target VM name.
+              enum:
+              - Preserve
+              - Regenerate
+              type: string
+            hostname:
+              description: Hostname controls the hostname of the VM. A regenerated
+                hostname defaults to the target VM name.
+              enum:
+              - Preserve
+              - Regenerate
+              type: string
+            macAddresses:
+              description: |-
+                MacAddresses controls the MAC addresses of the VM interfaces.
+                Regenerated addresses are assigned by the cluster, e.g. by KubeMacPool.
+              enum:
+              - Preserve
+              - Regenerate
+              type: string
+            smbiosSerial:
+              description: SMBiosSerial controls the SMBIOS serial number of the
+                VM.
+              enum:
+              - Preserve
+              - Regenerate
+              type: string
+          type: object
         patches:
           description: |-
             If the target for the restore does not exist, it will be created. Patches holds JSON patches that would be
@@ -27033,6 +28880,16 @@ var CRDsValidation map[string]string = map[string]string{
             as failed.
             Defaults to DefaultFailureDeadline - 5min
           type: string
+        includeMemory:
+          description: |-
+            IncludeMemory captures the memory state of the VM in the snapshot, the VM has to be paused.
+            Restoring the snapshot resumes the VM from the memory state instead of booting it.
+          type: boolean
+        memoryStorageClassName:
+          description: |-
+            MemoryStorageClassName is the storage class of the PVC the memory state is saved to.
+            The default storage class is used if not set.
+          type: string
         source:
           description: |-
             TypedLocalObjectReference contains enough information to let you locate the
@@ -27879,6 +29736,12 @@ var CRDsValidation map[string]string = map[string]string{
                         - spec
                         type: object
                       type: array
+                    deletionProtection:
+                      description: |-
+                        DeletionProtection rejects the deletion of the VirtualMachine until it is unlocked, either by
+                        setting the kubevirt.io/deletion-unlock annotation to the name of the VirtualMachine, or once
+                        the time set in the kubevirt.io/deletion-unlock-after annotation passed.
+                      type: boolean
                     instancetype:
                       description: InstancetypeMatcher references a instancetype that
                         is used to fill fields in Template
@@ -29284,6 +31147,28 @@ var CRDsValidation map[string]string = map[string]string{
                                         Must be a value greater or equal 1.
                                       format: int32
                                       type: integer
+                                    threadShares:
+                                      description: |-
+                                        ThreadShares weights the CPU time of the emulator and IO threads against the vCPU threads
+                                        of the VMI. Only applies to VMIs without dedicated CPUs.
+                                      properties:
+                                        emulator:
+                                          description: |-
+                                            Emulator is the CPU shares of the emulator threads.
+                                            Threads are left in the cgroup of the VMI if not set.
+                                          format: int32
+                                          maximum: 262144
+                                          minimum: 2
+                                          type: integer
+                                        ioThreads:
+                                          description: |-
+                                            IOThreads is the CPU shares of the IO threads.
+                                            Threads are left in the cgroup of the VMI if not set.
+                                          format: int32
+                                          maximum: 262144
+                                          minimum: 2
+                                          type: integer
+                                      type: object
                                     threads:
                                       description: |-
                                         Threads specifies the number of threads inside the vmi.
@@ -29474,6 +31359,11 @@ var CRDsValidation map[string]string = map[string]string{
                                           name:
                                             description: Name is the device name
                                             type: string
+                                          readErrorPolicy:
+                                            description: |-
+                                              If specified, it can change the error policy applied to read errors on the disk.
+                                              Defaults to the errorPolicy. Supported values are stop, report and ignore.
+                                            type: string
                                           serial:
                                             description: Serial provides the ability
                                               to specify a serial number for the disk
@@ -29489,6 +31379,12 @@ var CRDsValidation map[string]string = map[string]string{
                                               and its tag will be provided to the
                                               guest via config drive metadata
                                             type: string
+                                          wwn:
+                                            description: WWN provides the ability to specify a World Wide
+                                              Name for the disk device. It must consist of 16 hexadecimal
+                                              digits and is only supported for disks and CD-ROMs on the
+                                              sata and scsi buses.
+                                            type: string
                                         required:
                                         - name
                                         type: object
@@ -29671,6 +31567,43 @@ var CRDsValidation map[string]string = map[string]string{
                                                 description: If specified will pass
                                                   option 67 to interface's DHCP server
                                                 type: string
+                                              domainName:
+                                                description: |-
+                                                  If specified will pass the configured domain name to the VM via DHCP option 015,
+                                                  instead of the one derived from the pod search domains.
+                                                type: string
+                                              domainSearch:
+                                                description: |-
+                                                  If specified the configured DNS search suffixes are passed to the VM via DHCP option 119,
+                                                  ahead of the search domains inherited from the pod.
+                                                items:
+                                                  type: string
+                                                type: array
+                                              netBIOS:
+                                                description: If specified will pass
+                                                  NetBIOS over TCP/IP (WINS) settings
+                                                  to the VM via DHCP options 044,
+                                                  046 and 047.
+                                                properties:
+                                                  nameServers:
+                                                    description: If specified will
+                                                      pass the configured NetBIOS
+                                                      name servers (WINS) to the VM
+                                                      via DHCP option 044.
+                                                    items:
+                                                      type: string
+                                                    type: array
+                                                  nodeType:
+                                                    description: |-
+                                                      If specified will pass the NetBIOS node type to the VM via DHCP option 046.
+                                                      Allowed values are B, P, M and H.
+                                                    type: string
+                                                  scope:
+                                                    description: If specified will
+                                                      pass the NetBIOS scope to the
+                                                      VM via DHCP option 047.
+                                                    type: string
+                                                type: object
                                               ntpServers:
                                                 description: If specified will pass
                                                   the configured NTP server to the
@@ -29846,7 +31779,7 @@ var CRDsValidation map[string]string = map[string]string{
                                       properties:
                                         model:
                                           description: |-
-                                            We only support ich9 or ac97.
+                                            We only support ich9, ac97 or virtio.
                                             If SoundDevice is not set: No sound card is emulated.
                                             If SoundDevice is set but Model is not: ich9
                                           type: string
@@ -29854,6 +31787,22 @@ var CRDsValidation map[string]string = map[string]string{
                                           description: User's defined name for this
                                             sound device
                                           type: string
+                                        stream:
+                                          description: |-
+                                            Stream forwards the audio output of the sound device through the VNC connection.
+                                            If Stream is not set the audio output is discarded.
+                                          properties:
+                                            maxBandwidth:
+                                              anyOf:
+                                              - type: integer
+                                              - type: string
+                                              description: |-
+                                                MaxBandwidth caps the bandwidth of the uncompressed audio stream per second.
+                                                The sample rate and the number of channels are lowered until the stream fits.
+                                                Defaults to 44.1kHz 16 bit stereo, around 172Ki per second.
+                                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                              x-kubernetes-int-or-string: true
+                                          type: object
                                       required:
                                       - name
                                       type: object
@@ -29887,6 +31836,29 @@ var CRDsValidation map[string]string = map[string]string{
                                             If not specified, the default is architecture-dependent (VGA for BIOS-based VMs, Bochs for EFI-based VMs on AMD64; virtio for Arm and s390x).
                                           type: string
                                       type: object
+                                    vsockServices:
+                                      description: |-
+                                        VSOCKServices are the named guest services reachable over VSOCK.
+                                        Requires autoattachVSOCK. The ports which are not set are allocated
+                                        when the VMI is scheduled and reported in status.vsockServices.
+                                      items:
+                                        description: VSOCKService is a named guest service listening on a VSOCK
+                                          port
+                                        properties:
+                                          name:
+                                            description: Name identifies the service within the VMI
+                                            type: string
+                                          port:
+                                            description: |-
+                                              Port the guest service listens on.
+                                              A free port is allocated when it is not set.
+                                            format: int32
+                                            type: integer
+                                        required:
+                                        - name
+                                        type: object
+                                      type: array
+                                      x-kubernetes-list-type: atomic
                                     watchdog:
                                       description: Watchdog describes a watchdog device
                                         which can be added to the vmi.
@@ -31480,6 +33452,13 @@ var CRDsValidation map[string]string = map[string]string{
                                       that "wraps" specified source and provides copy-on-write
                                       image on top of it.
                                     properties:
+                                      overlayClaimName:
+                                        description: |-
+                                          OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                                          keeps the writable overlay of the disk across restarts of the vmi.
+                                          If not set, the overlay is transient and discarded when the vmi stops.
+                                          The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                                        type: string
                                       persistentVolumeClaim:
                                         description: |-
                                           PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
@@ -31538,6 +33517,10 @@ var CRDsValidation map[string]string = map[string]string{
                                           claimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume.
                                           More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
                                         type: string
+                                      format:
+                                        description: Format is the format the memory
+                                          is dumped in, defaults to Raw.
+                                        type: string
                                       hotpluggable:
                                         description: Hotpluggable indicates whether
                                           the volume can be hotplugged and hotunplugged.
@@ -31556,6 +33539,13 @@ var CRDsValidation map[string]string = map[string]string{
                                       Must be a DNS_LABEL and unique within the vmi.
                                       More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
                                     type: string
+                                  overlayClaimName:
+                                    description: |-
+                                      OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                                      keeps the writable overlay of the disk across restarts of the vmi.
+                                      If not set, the overlay is transient and discarded when the vmi stops.
+                                      The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                                    type: string
                                   persistentVolumeClaim:
                                     description: |-
                                       PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
@@ -31778,6 +33768,16 @@ var CRDsValidation map[string]string = map[string]string{
                         updated through an Update() before ObservedGeneration in Status.
                       format: int64
                       type: integer
+                    guestAgentConnected:
+                      description: GuestAgentConnected mirrors whether the guest agent
+                        of the VirtualMachineInstance is connected
+                      type: boolean
+                    instanceStatusLastUpdateTime:
+                      description: InstanceStatusLastUpdateTime is the last time ipAddresses,
+                        nodeName or guestAgentConnected changed
+                      format: date-time
+                      nullable: true
+                      type: string
                     instancetypeRef:
                       description: InstancetypeRef captures the state of any referenced
                         instance type from the VirtualMachine
@@ -31807,6 +33807,13 @@ var CRDsValidation map[string]string = map[string]string{
                           description: Name is the name of resource
                           type: string
                       type: object
+                    ipAddresses:
+                      description: IPAddresses mirrors the IP addresses reported on
+                        the interfaces of the VirtualMachineInstance
+                      items:
+                        type: string
+                      type: array
+                      x-kubernetes-list-type: atomic
                     memoryDumpRequest:
                       description: |-
                         MemoryDumpRequest tracks memory dump request phase and info of getting a memory
@@ -31826,6 +33833,10 @@ var CRDsValidation map[string]string = map[string]string{
                           description: FileName represents the name of the output
                             file
                           type: string
+                        format:
+                          description: Format is the format the memory is dumped in,
+                            defaults to Raw
+                          type: string
                         message:
                           description: Message is a detailed message about failure
                             of the memory dump
@@ -31846,6 +33857,10 @@ var CRDsValidation map[string]string = map[string]string{
                       - claimName
                       - phase
                       type: object
+                    nodeName:
+                      description: NodeName mirrors the name of the node the VirtualMachineInstance
+                        is running on
+                      type: string
                     observedGeneration:
                       description: ObservedGeneration is the generation observed by
                         the vmi when started.
@@ -32087,6 +34102,11 @@ var CRDsValidation map[string]string = map[string]string{
                                   name:
                                     description: Name is the device name
                                     type: string
+                                  readErrorPolicy:
+                                    description: |-
+                                      If specified, it can change the error policy applied to read errors on the disk.
+                                      Defaults to the errorPolicy. Supported values are stop, report and ignore.
+                                    type: string
                                   serial:
                                     description: Serial provides the ability to specify
                                       a serial number for the disk device.
@@ -32100,6 +34120,12 @@ var CRDsValidation map[string]string = map[string]string{
                                       tag will be provided to the guest via config
                                       drive metadata
                                     type: string
+                                  wwn:
+                                    description: WWN provides the ability to specify a World Wide
+                                      Name for the disk device. It must consist of 16 hexadecimal
+                                      digits and is only supported for disks and CD-ROMs on the
+                                      sata and scsi buses.
+                                    type: string
                                 required:
                                 - name
                                 type: object
@@ -32140,6 +34166,13 @@ var CRDsValidation map[string]string = map[string]string{
                                     required:
                                     - name
                                     type: object
+                                  overlayClaimName:
+                                    description: |-
+                                      OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+                                      keeps the writable overlay of the disk across restarts of the vmi.
+                                      If not set, the overlay is transient and discarded when the vmi stops.
+                                      The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+                                    type: string
                                   persistentVolumeClaim:
                                     description: |-
                                       PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
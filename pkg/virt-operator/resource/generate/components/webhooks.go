@@ -24,6 +24,7 @@ import (
 	virtv1 "kubevirt.io/api/core/v1"
 	exportv1 "kubevirt.io/api/export/v1beta1"
 	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	operationsv1alpha1 "kubevirt.io/api/operations/v1alpha1"
 	poolv1 "kubevirt.io/api/pool/v1beta1"
 	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
 )
@@ -294,6 +295,7 @@ func NewVirtAPIValidatingWebhookConfiguration(installNamespace string) *admissio
 	vmiPathCreate := VMICreateValidatePath
 	vmiPathUpdate := VMIUpdateValidatePath
 	vmPath := VMValidatePath
+	vmDeletePath := VMDeleteValidatePath
 	vmirsPath := VMIRSValidatePath
 	vmpoolPath := VMPoolValidatePath
 	vmipresetPath := VMIPresetValidatePath
@@ -312,6 +314,7 @@ func NewVirtAPIValidatingWebhookConfiguration(installNamespace string) *admissio
 	statusValidatePath := StatusValidatePath
 	migrationPolicyCreateValidatePath := MigrationPolicyCreateValidatePath
 	vmCloneCreateValidatePath := VMCloneCreateValidatePath
+	vmOperationValidatePath := VMOperationValidatePath
 	failurePolicy := admissionregistrationv1.Fail
 
 	return &admissionregistrationv1.ValidatingWebhookConfiguration{
@@ -466,6 +469,37 @@ func NewVirtAPIValidatingWebhookConfiguration(installNamespace string) *admissio
 					},
 				},
 			},
+			{
+				Name:                    "virtualmachine-delete-validator.kubevirt.io",
+				AdmissionReviewVersions: []string{"v1"},
+				FailurePolicy:           &failurePolicy,
+				TimeoutSeconds:          &defaultTimeoutSeconds,
+				SideEffects:             &sideEffectNone,
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Operations: []admissionregistrationv1.OperationType{
+						admissionregistrationv1.Delete,
+					},
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{core.GroupName},
+						APIVersions: virtv1.ApiSupportedWebhookVersions,
+						Resources:   []string{"virtualmachines"},
+					},
+				}},
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Namespace: installNamespace,
+						Name:      VirtApiServiceName,
+						Path:      &vmDeletePath,
+					},
+				},
+				// Only protected VMs are sent to virt-api, the deletion of other VMs does not depend on it
+				MatchConditions: []admissionregistrationv1.MatchCondition{
+					{
+						Name:       "only-deletion-protected-vms",
+						Expression: `has(oldObject.spec.deletionProtection) && oldObject.spec.deletionProtection`,
+					},
+				},
+			},
 			{
 				Name:                    "virtualmachinereplicaset-validator.kubevirt.io",
 				AdmissionReviewVersions: []string{"v1"},
@@ -901,6 +935,30 @@ func NewVirtAPIValidatingWebhookConfiguration(installNamespace string) *admissio
 					},
 				},
 			},
+			{
+				Name:                    "virtualmachineoperation-validator.operations.kubevirt.io",
+				AdmissionReviewVersions: []string{"v1"},
+				FailurePolicy:           &failurePolicy,
+				TimeoutSeconds:          &defaultTimeoutSeconds,
+				SideEffects:             &sideEffectNone,
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Operations: []admissionregistrationv1.OperationType{
+						admissionregistrationv1.Create,
+					},
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{operationsv1alpha1.SchemeGroupVersion.Group},
+						APIVersions: []string{operationsv1alpha1.SchemeGroupVersion.Version},
+						Resources:   []string{"virtualmachineoperations"},
+					},
+				}},
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Namespace: installNamespace,
+						Name:      VirtApiServiceName,
+						Path:      &vmOperationValidatePath,
+					},
+				},
+			},
 		},
 	}
 }
@@ -915,6 +973,8 @@ const VMIUpdateValidatePath = "/virtualmachineinstances-validate-update"
 
 const VMValidatePath = "/virtualmachines-validate"
 
+const VMDeleteValidatePath = "/virtualmachines-validate-delete"
+
 const VMIRSValidatePath = "/virtualmachinereplicaset-validate"
 
 const VMPoolValidatePath = "/virtualmachinepool-validate"
@@ -983,6 +1043,8 @@ const MigrationPolicyCreateValidatePath = "/migration-policy-validate-create"
 
 const VMCloneCreateValidatePath = "/vm-clone-validate-create"
 
+const VMOperationValidatePath = "/virtualmachineoperations-validate"
+
 const VMCloneCreateMutatePath = "/vm-clone-mutate-create"
 
 const VirtLauncherPodMutatePath = "/virt-launcher-pod-mutate"
@@ -101,6 +101,17 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch", "patch", "update",
 				},
 			},
+			{
+				APIGroups: []string{
+					GroupName,
+				},
+				Resources: []string{
+					"virtualmachines",
+				},
+				Verbs: []string{
+					"create", "delete",
+				},
+			},
 			{
 				APIGroups: []string{
 					"",
@@ -241,6 +252,17 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"cdi.kubevirt.io",
+				},
+				Resources: []string{
+					"datavolumes",
+				},
+				Verbs: []string{
+					"patch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"",
@@ -290,6 +312,7 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"create",
 					"list",
 					"get",
+					"patch",
 				},
 			},
 			{
@@ -299,6 +322,19 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"nodes",
 				},
+				Verbs: []string{
+					"get",
+					"list",
+					"watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"k8s.cni.cncf.io",
+				},
+				Resources: []string{
+					"network-attachment-definitions",
+				},
 				Verbs: []string{
 					"get",
 				},
@@ -386,6 +422,31 @@ func newApiServerRole(namespace string) *rbacv1.Role {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"secrets",
+				},
+				ResourceNames: []string{
+					components.VirtApiSubresourceTokenKeySecretName,
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"secrets",
+				},
+				Verbs: []string{
+					"create",
+				},
+			},
 		},
 	}
 }
@@ -26,6 +26,7 @@ import (
 	"kubevirt.io/api/backup"
 	"kubevirt.io/api/clone"
 	"kubevirt.io/api/export"
+	"kubevirt.io/api/operations"
 	"kubevirt.io/api/pool"
 	"kubevirt.io/api/snapshot"
 
@@ -50,6 +51,8 @@ const (
 	apiVersion            = "version"
 	apiGuestFs            = "guestfs"
 	apiExpandVmSpec       = "expand-vm-spec"
+	apiVMISummaries       = "virtualmachineinstancesummaries"
+	apiBulkVMLifecycle    = "bulk-vm-lifecycle"
 	apiKubevirts          = "kubevirts"
 	apiVM                 = "virtualmachines"
 	apiVMInstances        = "virtualmachineinstances"
@@ -64,6 +67,7 @@ const (
 	apiVMExports          = "virtualmachineexports"
 	apiVMClones           = "virtualmachineclones"
 	apiVMPools            = "virtualmachinepools"
+	apiVMOperations       = "virtualmachineoperations"
 
 	apiVMExpandSpec     = "virtualmachines/expand-spec"
 	apiVMPortForward    = "virtualmachines/portforward"
@@ -76,6 +80,8 @@ const (
 	apiVMMemoryDump     = "virtualmachines/memorydump"
 	apiVMObjectGraph    = "virtualmachines/objectgraph"
 	apiVMEvacuateCancel = "virtualmachines/evacuate/cancel"
+	apiVMPreflight      = "virtualmachines/preflight"
+	apiVMRename         = "virtualmachines/rename"
 
 	apiVMInstancesConsole                   = "virtualmachineinstances/console"
 	apiVMInstancesVNC                       = "virtualmachineinstances/vnc"
@@ -89,13 +95,18 @@ const (
 	apiVMInstancesUnfreeze                  = "virtualmachineinstances/unfreeze"
 	apiVMInstancesSoftReboot                = "virtualmachineinstances/softreboot"
 	apiVMInstancesReset                     = "virtualmachineinstances/reset"
+	apiVMInstancesInjectNMI                 = "virtualmachineinstances/injectnmi"
 	apiVMInstancesGuestOSInfo               = "virtualmachineinstances/guestosinfo"
 	apiVMInstancesFileSysList               = "virtualmachineinstances/filesystemlist"
 	apiVMInstancesUserList                  = "virtualmachineinstances/userlist"
+	apiVMInstancesUsage                     = "virtualmachineinstances/usage"
+	apiVMInstancesDomainStats               = "virtualmachineinstances/domainstats"
+	apiVMInstancesDomain                    = "virtualmachineinstances/domain"
 	apiVMInstancesSEVFetchCertChain         = "virtualmachineinstances/sev/fetchcertchain"
 	apiVMInstancesSEVQueryLaunchMeasurement = "virtualmachineinstances/sev/querylaunchmeasurement"
 	apiVMInstancesSEVSetupSession           = "virtualmachineinstances/sev/setupsession"
 	apiVMInstancesSEVInjectLaunchSecret     = "virtualmachineinstances/sev/injectlaunchsecret"
+	apiVMInstancesSEVFetchAttestationReport = "virtualmachineinstances/sev/fetchattestationreport"
 	apiVMInstancesUSBRedir                  = "virtualmachineinstances/usbredir"
 	apiVMInstancesObjectGraph               = "virtualmachineinstances/objectgraph"
 	apiVMInstancesEvacuateCancel            = "virtualmachineinstances/evacuate/cancel"
@@ -214,6 +225,9 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					apiVMInstancesGuestOSInfo,
 					apiVMInstancesFileSysList,
 					apiVMInstancesUserList,
+					apiVMInstancesUsage,
+					apiVMInstancesDomainStats,
+					apiVMInstancesDomain,
 					apiVMInstancesSEVFetchCertChain,
 					apiVMInstancesSEVQueryLaunchMeasurement,
 					apiVMInstancesUSBRedir,
@@ -237,8 +251,10 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					apiVMInstancesUnfreeze,
 					apiVMInstancesSoftReboot,
 					apiVMInstancesReset,
+					apiVMInstancesInjectNMI,
 					apiVMInstancesSEVSetupSession,
 					apiVMInstancesSEVInjectLaunchSecret,
+					apiVMInstancesSEVFetchAttestationReport,
 					apiVMInstancesEvacuateCancel,
 				},
 				Verbs: []string{
@@ -280,6 +296,40 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					apiExpandVmSpec,
+					apiBulkVMLifecycle,
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
+			{
+				APIGroups: []string{
+					virtv1.SubresourceGroupName,
+				},
+				Resources: []string{
+					apiVMISummaries,
+				},
+				Verbs: []string{
+					"list",
+				},
+			},
+			{
+				APIGroups: []string{
+					virtv1.SubresourceGroupName,
+				},
+				Resources: []string{
+					apiVMPreflight,
+				},
+				Verbs: []string{
+					"create",
+				},
+			},
+			{
+				APIGroups: []string{
+					virtv1.SubresourceGroupName,
+				},
+				Resources: []string{
+					apiVMRename,
 				},
 				Verbs: []string{
 					"update",
@@ -335,6 +385,17 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
 				},
 			},
+			{
+				APIGroups: []string{
+					operations.GroupName,
+				},
+				Resources: []string{
+					apiVMOperations,
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
+				},
+			},
 			{
 				APIGroups: []string{
 					export.GroupName,
@@ -423,6 +484,9 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					apiVMInstancesGuestOSInfo,
 					apiVMInstancesFileSysList,
 					apiVMInstancesUserList,
+					apiVMInstancesUsage,
+					apiVMInstancesDomainStats,
+					apiVMInstancesDomain,
 					apiVMInstancesSEVFetchCertChain,
 					apiVMInstancesSEVQueryLaunchMeasurement,
 					apiVMInstancesUSBRedir,
@@ -446,8 +510,10 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					apiVMInstancesUnfreeze,
 					apiVMInstancesSoftReboot,
 					apiVMInstancesReset,
+					apiVMInstancesInjectNMI,
 					apiVMInstancesSEVSetupSession,
 					apiVMInstancesSEVInjectLaunchSecret,
+					apiVMInstancesSEVFetchAttestationReport,
 					apiVMInstancesEvacuateCancel,
 				},
 				Verbs: []string{
@@ -489,6 +555,40 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					apiExpandVmSpec,
+					apiBulkVMLifecycle,
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
+			{
+				APIGroups: []string{
+					virtv1.SubresourceGroupName,
+				},
+				Resources: []string{
+					apiVMISummaries,
+				},
+				Verbs: []string{
+					"list",
+				},
+			},
+			{
+				APIGroups: []string{
+					virtv1.SubresourceGroupName,
+				},
+				Resources: []string{
+					apiVMPreflight,
+				},
+				Verbs: []string{
+					"create",
+				},
+			},
+			{
+				APIGroups: []string{
+					virtv1.SubresourceGroupName,
+				},
+				Resources: []string{
+					apiVMRename,
 				},
 				Verbs: []string{
 					"update",
@@ -544,6 +644,17 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"get", "delete", "create", "update", "patch", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					operations.GroupName,
+				},
+				Resources: []string{
+					apiVMOperations,
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					export.GroupName,
@@ -690,6 +801,8 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					apiVMInstancesGuestOSInfo,
 					apiVMInstancesFileSysList,
 					apiVMInstancesUserList,
+					apiVMInstancesUsage,
+					apiVMInstancesDomainStats,
 					apiVMInstancesSEVFetchCertChain,
 					apiVMInstancesSEVQueryLaunchMeasurement,
 					apiVMObjectGraph,
@@ -710,6 +823,17 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"update",
 				},
 			},
+			{
+				APIGroups: []string{
+					virtv1.SubresourceGroupName,
+				},
+				Resources: []string{
+					apiVMISummaries,
+				},
+				Verbs: []string{
+					"list",
+				},
+			},
 			{
 				APIGroups: []string{
 					GroupName,
@@ -750,6 +874,17 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					operations.GroupName,
+				},
+				Resources: []string{
+					apiVMOperations,
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					export.GroupName,
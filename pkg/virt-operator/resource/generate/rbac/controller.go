@@ -366,6 +366,19 @@ func baseControllerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch", "create", "update", "delete", "patch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"operations.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineoperations",
+					"virtualmachineoperations/status",
+					"virtualmachineoperations/finalizers",
+				},
+				Verbs: []string{
+					"get", "list", "watch", "update", "patch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"pool.kubevirt.io",
@@ -416,6 +429,7 @@ func baseControllerClusterRole() *rbacv1.ClusterRole {
 					"subresources.kubevirt.io",
 				},
 				Resources: []string{
+					"virtualmachines/start",
 					"virtualmachines/stop",
 					"virtualmachineinstances/addvolume",
 					"virtualmachineinstances/removevolume",
@@ -502,6 +516,7 @@ func baseControllerClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"storageclasses",
+					"csistoragecapacities",
 				},
 				Verbs: []string{
 					"get",
@@ -583,6 +598,18 @@ func baseControllerClusterRole() *rbacv1.ClusterRole {
 					"delete",
 				},
 			},
+			{
+				APIGroups: []string{
+					"gateway.networking.k8s.io",
+				},
+				Resources: []string{
+					"httproutes",
+					"tcproutes",
+				},
+				Verbs: []string{
+					"get", "create", "update", "delete",
+				},
+			},
 			{
 				APIGroups: []string{
 					"resource.k8s.io",
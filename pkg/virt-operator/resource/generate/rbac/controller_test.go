@@ -57,8 +57,20 @@ var _ = Describe("RBAC", func() {
 			Entry("for vmsnapshotcontents", "snapshot.kubevirt.io", "virtualmachinesnapshotcontents"),
 			Entry("for vms", "kubevirt.io", "virtualmachines"),
 			Entry("for vmis", "kubevirt.io", "virtualmachineinstances"),
+			Entry("for vmoperations", "operations.kubevirt.io", "virtualmachineoperations"),
 		)
 
+		It("should allow to manage Gateway API routes", func() {
+			clusterRole := getObject(forController, reflect.TypeOf(&rbacv1.ClusterRole{}), components.ControllerServiceAccountName).(*rbacv1.ClusterRole)
+			Expect(clusterRole.Rules).To(
+				ContainElement(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"APIGroups": ContainElement("gateway.networking.k8s.io"),
+					"Resources": ContainElements("httproutes", "tcproutes"),
+					"Verbs":     ContainElements("get", "create", "update", "delete"),
+				})),
+			)
+		})
+
 		It("should include NAD rules when includeNADRules is true", func() {
 			clusterRole := getObject(forController, reflect.TypeOf(&rbacv1.ClusterRole{}), components.ControllerServiceAccountName).(*rbacv1.ClusterRole)
 			Expect(clusterRole.Rules).To(
@@ -545,6 +545,7 @@ func NewOperatorRole(namespace string) *rbacv1.Role {
 					components.VirtHandlerVsockClientCertSecretName,
 					components.VirtOperatorCertSecretName,
 					components.VirtApiCertSecretName,
+					components.VirtApiSubresourceTokenKeySecretName,
 					components.VirtControllerCertSecretName,
 					components.VirtExportProxyCertSecretName,
 					components.VirtSynchronizationControllerCertSecretName,
@@ -3,6 +3,7 @@ package adm
 import (
 	"github.com/spf13/cobra"
 
+	"kubevirt.io/kubevirt/pkg/virtctl/adm/instancetypes"
 	"kubevirt.io/kubevirt/pkg/virtctl/adm/logverbosity"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
@@ -19,7 +20,11 @@ func NewCommand() *cobra.Command {
 			cmd.Println(cmd.UsageString())
 		},
 	}
-	cmd.AddCommand(logverbosity.NewCommand())
+	cmd.AddCommand(
+		logverbosity.NewCommand(),
+		instancetypes.NewExportCommand(),
+		instancetypes.NewImportCommand(),
+	)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
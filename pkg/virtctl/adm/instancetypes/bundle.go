@@ -0,0 +1,124 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package instancetypes
+
+import (
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+)
+
+const (
+	BundleAPIVersion = "virtctl.kubevirt.io/v1"
+	BundleKind       = "InstancetypeBundle"
+
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// Bundle is a versioned, cluster independent snapshot of a sizing catalog. Namespaced
+// objects are stored without their namespace and are imported into the target namespace.
+type Bundle struct {
+	metav1.TypeMeta `json:",inline"`
+
+	ClusterInstancetypes []instancetypev1beta1.VirtualMachineClusterInstancetype `json:"clusterInstancetypes,omitempty"`
+	ClusterPreferences   []instancetypev1beta1.VirtualMachineClusterPreference   `json:"clusterPreferences,omitempty"`
+	Instancetypes        []instancetypev1beta1.VirtualMachineInstancetype        `json:"instancetypes,omitempty"`
+	Preferences          []instancetypev1beta1.VirtualMachinePreference          `json:"preferences,omitempty"`
+	// ControllerRevisions pin the instancetypes and preferences used by VirtualMachines
+	ControllerRevisions []appsv1.ControllerRevision `json:"controllerRevisions,omitempty"`
+}
+
+func newBundle() *Bundle {
+	return &Bundle{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: BundleAPIVersion,
+			Kind:       BundleKind,
+		},
+	}
+}
+
+func parseBundle(data []byte) (*Bundle, error) {
+	bundle := &Bundle{}
+	if err := yaml.UnmarshalStrict(data, bundle); err != nil {
+		return nil, fmt.Errorf("error parsing bundle: %v", err)
+	}
+	if bundle.Kind != BundleKind || bundle.APIVersion != BundleAPIVersion {
+		return nil, fmt.Errorf("unsupported bundle %s %s, expected %s %s", bundle.APIVersion, bundle.Kind, BundleAPIVersion, BundleKind)
+	}
+	return bundle, nil
+}
+
+type object interface {
+	metav1.Object
+	runtime.Object
+}
+
+// sanitize removes the cluster specific metadata of an object, so it can be
+// stored in a bundle or compared with the content of a bundle.
+func sanitize[T object](obj T) T {
+	obj = obj.DeepCopyObject().(T)
+	obj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+	obj.SetNamespace("")
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetDeletionTimestamp(nil)
+	obj.SetDeletionGracePeriodSeconds(nil)
+	obj.SetManagedFields(nil)
+	obj.SetOwnerReferences(nil)
+	obj.SetFinalizers(nil)
+	obj.SetSelfLink("")
+	if annotations := obj.GetAnnotations(); annotations != nil {
+		delete(annotations, lastAppliedConfigAnnotation)
+		if len(annotations) == 0 {
+			obj.SetAnnotations(nil)
+		}
+	}
+	return obj
+}
+
+// sanitizeRevision additionally normalizes the serialized object of a ControllerRevision
+func sanitizeRevision(revision *appsv1.ControllerRevision) (*appsv1.ControllerRevision, error) {
+	revision = sanitize(revision)
+	if revision.Data.Object != nil && revision.Data.Raw == nil {
+		raw, err := json.Marshal(revision.Data.Object)
+		if err != nil {
+			return nil, err
+		}
+		revision.Data = runtime.RawExtension{Raw: raw}
+	}
+	var data interface{}
+	if err := json.Unmarshal(revision.Data.Raw, &data); err != nil {
+		return nil, fmt.Errorf("error parsing the data of ControllerRevision %s: %v", revision.Name, err)
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	revision.Data = runtime.RawExtension{Raw: raw}
+	return revision, nil
+}
@@ -0,0 +1,161 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package instancetypes
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	instancetypeapi "kubevirt.io/api/instancetype"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	namespacedFlag = "namespaced"
+	revisionsFlag  = "revisions"
+	outputFileFlag = "output-file"
+)
+
+type exportCommand struct {
+	namespaced bool
+	revisions  bool
+	outputFile string
+}
+
+func NewExportCommand() *cobra.Command {
+	c := exportCommand{}
+	cmd := &cobra.Command{
+		Use:   "export-instancetypes",
+		Short: "Export the cluster wide instancetypes and preferences into a bundle.",
+		Long: `Export the cluster wide instancetypes and preferences into a bundle, which can be imported
+into another cluster with import-instancetypes to keep sizing catalogs in sync.
+
+Optionally the namespaced instancetypes and preferences and the ControllerRevisions pinning
+them for VirtualMachines of the namespace are exported as well.`,
+		Example: exportUsage(),
+		Args:    cobra.NoArgs,
+		RunE:    c.run,
+	}
+	cmd.Flags().BoolVar(&c.namespaced, namespacedFlag, false, "Include the instancetypes and preferences of the namespace.")
+	cmd.Flags().BoolVar(&c.revisions, revisionsFlag, false, "Include the ControllerRevisions of the namespace which pin instancetypes and preferences.")
+	cmd.Flags().StringVar(&c.outputFile, outputFileFlag, "", "The file to write the bundle to. Defaults to stdout.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func exportUsage() string {
+	return `  # Export the cluster wide instancetypes and preferences
+  {{ProgramName}} adm export-instancetypes --output-file=catalog.yaml
+
+  # Export the cluster wide and the namespaced instancetypes and preferences of the namespace 'team-a',
+  # including the ControllerRevisions pinning them
+  {{ProgramName}} adm export-instancetypes -n team-a --namespaced --revisions > catalog.yaml`
+}
+
+func (c *exportCommand) run(cmd *cobra.Command, _ []string) error {
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	bundle := newBundle()
+
+	clusterInstancetypes, err := virtClient.VirtualMachineClusterInstancetype().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing VirtualMachineClusterInstancetypes: %w", err)
+	}
+	for i := range clusterInstancetypes.Items {
+		bundle.ClusterInstancetypes = append(bundle.ClusterInstancetypes, *sanitize(&clusterInstancetypes.Items[i]))
+	}
+
+	clusterPreferences, err := virtClient.VirtualMachineClusterPreference().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing VirtualMachineClusterPreferences: %w", err)
+	}
+	for i := range clusterPreferences.Items {
+		bundle.ClusterPreferences = append(bundle.ClusterPreferences, *sanitize(&clusterPreferences.Items[i]))
+	}
+
+	if c.namespaced {
+		instancetypes, err := virtClient.VirtualMachineInstancetype(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("error listing VirtualMachineInstancetypes: %w", err)
+		}
+		for i := range instancetypes.Items {
+			bundle.Instancetypes = append(bundle.Instancetypes, *sanitize(&instancetypes.Items[i]))
+		}
+
+		preferences, err := virtClient.VirtualMachinePreference(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("error listing VirtualMachinePreferences: %w", err)
+		}
+		for i := range preferences.Items {
+			bundle.Preferences = append(bundle.Preferences, *sanitize(&preferences.Items[i]))
+		}
+	}
+
+	if c.revisions {
+		if bundle.ControllerRevisions, err = exportRevisions(ctx, virtClient, namespace); err != nil {
+			return err
+		}
+	}
+
+	data, err := yaml.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+	if c.outputFile == "" {
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := os.WriteFile(c.outputFile, data, 0o600); err != nil {
+		return fmt.Errorf("error writing bundle: %v", err)
+	}
+	cmd.Printf("Exported %d cluster instancetypes, %d cluster preferences, %d instancetypes, %d preferences and %d ControllerRevisions to %s\n",
+		len(bundle.ClusterInstancetypes), len(bundle.ClusterPreferences), len(bundle.Instancetypes),
+		len(bundle.Preferences), len(bundle.ControllerRevisions), c.outputFile)
+	return nil
+}
+
+func exportRevisions(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string) ([]appsv1.ControllerRevision, error) {
+	revisions, err := virtClient.AppsV1().ControllerRevisions(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: instancetypeapi.ControllerRevisionObjectKindLabel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing ControllerRevisions: %w", err)
+	}
+	var exported []appsv1.ControllerRevision
+	for i := range revisions.Items {
+		revision, err := sanitizeRevision(&revisions.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		exported = append(exported, *revision)
+	}
+	return exported, nil
+}
@@ -0,0 +1,266 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package instancetypes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	fileFlag   = "file"
+	dryRunFlag = "dry-run"
+
+	actionCreated   = "created"
+	actionUpdated   = "configured"
+	actionUnchanged = "unchanged"
+	actionConflict  = "skipped, it exists with a different content"
+)
+
+type importCommand struct {
+	file   string
+	dryRun bool
+}
+
+func NewImportCommand() *cobra.Command {
+	c := importCommand{}
+	cmd := &cobra.Command{
+		Use:   "import-instancetypes",
+		Short: "Import instancetypes and preferences from a bundle.",
+		Long: `Import instancetypes and preferences from a bundle created by export-instancetypes.
+
+Objects missing in the cluster are created and differing objects are updated, the
+differences are shown as a unified diff. Namespaced objects are imported into the
+namespace of the current context.
+
+ControllerRevisions are immutable, existing ControllerRevisions with a different content
+are skipped. Imported ControllerRevisions are not owned by a VirtualMachine and are not
+garbage collected.`,
+		Example: importUsage(),
+		Args:    cobra.NoArgs,
+		RunE:    c.run,
+	}
+	cmd.Flags().StringVarP(&c.file, fileFlag, "f", "", "The bundle to import. Use - to read it from stdin.")
+	cmd.Flags().BoolVar(&c.dryRun, dryRunFlag, false, "Only show the differences, do not change the cluster.")
+	if err := cmd.MarkFlagRequired(fileFlag); err != nil {
+		panic(err)
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func importUsage() string {
+	return `  # Show the differences between a bundle and the cluster
+  {{ProgramName}} adm import-instancetypes -f catalog.yaml --dry-run
+
+  # Import a bundle, namespaced objects are imported into the namespace 'team-a'
+  {{ProgramName}} adm import-instancetypes -f catalog.yaml -n team-a`
+}
+
+func (c *importCommand) run(cmd *cobra.Command, _ []string) error {
+	var data []byte
+	var err error
+	if c.file == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(c.file)
+	}
+	if err != nil {
+		return fmt.Errorf("error reading bundle: %v", err)
+	}
+	bundle, err := parseBundle(data)
+	if err != nil {
+		return result.NewUsageError(err)
+	}
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+	i := &importer{
+		ctx:    cmd.Context(),
+		out:    cmd.OutOrStdout(),
+		dryRun: c.dryRun,
+	}
+
+	for idx := range bundle.ClusterInstancetypes {
+		importObject(i, virtClient.VirtualMachineClusterInstancetype(), "VirtualMachineClusterInstancetype", &bundle.ClusterInstancetypes[idx])
+	}
+	for idx := range bundle.ClusterPreferences {
+		importObject(i, virtClient.VirtualMachineClusterPreference(), "VirtualMachineClusterPreference", &bundle.ClusterPreferences[idx])
+	}
+	for idx := range bundle.Instancetypes {
+		bundle.Instancetypes[idx].Namespace = namespace
+		importObject(i, virtClient.VirtualMachineInstancetype(namespace), "VirtualMachineInstancetype", &bundle.Instancetypes[idx])
+	}
+	for idx := range bundle.Preferences {
+		bundle.Preferences[idx].Namespace = namespace
+		importObject(i, virtClient.VirtualMachinePreference(namespace), "VirtualMachinePreference", &bundle.Preferences[idx])
+	}
+	for idx := range bundle.ControllerRevisions {
+		bundle.ControllerRevisions[idx].Namespace = namespace
+		i.importRevision(virtClient, &bundle.ControllerRevisions[idx])
+	}
+
+	if i.failed > 0 {
+		return fmt.Errorf("failed to import %d objects", i.failed)
+	}
+	return nil
+}
+
+type resourceClient[T object] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error)
+	Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
+}
+
+type importer struct {
+	ctx    context.Context
+	out    io.Writer
+	dryRun bool
+	failed int
+}
+
+func (i *importer) report(kind, name, action string) {
+	if i.dryRun {
+		action += " (dry run)"
+	}
+	fmt.Fprintf(i.out, "%s %s %s\n", kind, name, action)
+}
+
+func (i *importer) recordChange(action, kind string, obj metav1.Object) {
+	result.RecordChange(i.ctx, result.Change{Action: action, Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), DryRun: i.dryRun})
+}
+
+func (i *importer) reportError(kind, name string, err error) {
+	i.failed++
+	fmt.Fprintf(i.out, "%s %s failed: %v\n", kind, name, err)
+}
+
+func importObject[T object](i *importer, client resourceClient[T], kind string, desired T) {
+	name := desired.GetName()
+	existing, err := client.Get(i.ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		if !i.dryRun {
+			if _, err := client.Create(i.ctx, desired, metav1.CreateOptions{}); err != nil {
+				i.reportError(kind, name, err)
+				return
+			}
+		}
+		i.recordChange("Create", kind, desired)
+		i.report(kind, name, actionCreated)
+		return
+	}
+	if err != nil {
+		i.reportError(kind, name, err)
+		return
+	}
+
+	diff, err := objectDiff(kind, name, sanitize(existing), sanitize(desired))
+	if err != nil {
+		i.reportError(kind, name, err)
+		return
+	}
+	if diff == "" {
+		i.report(kind, name, actionUnchanged)
+		return
+	}
+
+	fmt.Fprint(i.out, diff)
+	if !i.dryRun {
+		desired.SetResourceVersion(existing.GetResourceVersion())
+		if _, err := client.Update(i.ctx, desired, metav1.UpdateOptions{}); err != nil {
+			i.reportError(kind, name, err)
+			return
+		}
+	}
+	i.recordChange("Update", kind, desired)
+	i.report(kind, name, actionUpdated)
+}
+
+func (i *importer) importRevision(virtClient kubecli.KubevirtClient, desired *appsv1.ControllerRevision) {
+	const kind = "ControllerRevision"
+	client := virtClient.AppsV1().ControllerRevisions(desired.Namespace)
+	existing, err := client.Get(i.ctx, desired.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		if !i.dryRun {
+			if _, err := client.Create(i.ctx, desired, metav1.CreateOptions{}); err != nil {
+				i.reportError(kind, desired.Name, err)
+				return
+			}
+		}
+		i.recordChange("Create", kind, desired)
+		i.report(kind, desired.Name, actionCreated)
+		return
+	}
+	if err != nil {
+		i.reportError(kind, desired.Name, err)
+		return
+	}
+
+	sanitizedExisting, err := sanitizeRevision(existing)
+	if err != nil {
+		i.reportError(kind, desired.Name, err)
+		return
+	}
+	sanitizedDesired, err := sanitizeRevision(desired)
+	if err != nil {
+		i.reportError(kind, desired.Name, err)
+		return
+	}
+	if equality.Semantic.DeepEqual(sanitizedExisting.Data.Raw, sanitizedDesired.Data.Raw) {
+		i.report(kind, desired.Name, actionUnchanged)
+		return
+	}
+	i.report(kind, desired.Name, actionConflict)
+}
+
+func objectDiff(kind, name string, existing, desired interface{}) (string, error) {
+	existingYAML, err := yaml.Marshal(existing)
+	if err != nil {
+		return "", err
+	}
+	desiredYAML, err := yaml.Marshal(desired)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(existingYAML)),
+		B:        difflib.SplitLines(string(desiredYAML)),
+		FromFile: fmt.Sprintf("%s/%s (cluster)", kind, name),
+		ToFile:   fmt.Sprintf("%s/%s (bundle)", kind, name),
+		Context:  3,
+	})
+}
@@ -0,0 +1,245 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package instancetypes_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	instancetypeapi "kubevirt.io/api/instancetype"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Instancetype bundles", func() {
+	const (
+		sourceNamespace = "source"
+		targetNamespace = "target"
+	)
+
+	var (
+		kubevirtClient *kubevirtfake.Clientset
+		k8sClient      *k8sfake.Clientset
+		bundleFile     string
+	)
+
+	newClusterInstancetype := func(name string, guest uint32) *instancetypev1beta1.VirtualMachineClusterInstancetype {
+		return &instancetypev1beta1.VirtualMachineClusterInstancetype{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				UID:             "cluster-uid",
+				ResourceVersion: "1",
+				Labels:          map[string]string{"catalog": "true"},
+			},
+			Spec: instancetypev1beta1.VirtualMachineInstancetypeSpec{
+				CPU:    instancetypev1beta1.CPUInstancetype{Guest: guest},
+				Memory: instancetypev1beta1.MemoryInstancetype{Guest: resource.MustParse("1Gi")},
+			},
+		}
+	}
+
+	newPreference := func(name, namespace string) *instancetypev1beta1.VirtualMachinePreference {
+		return &instancetypev1beta1.VirtualMachinePreference{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: instancetypev1beta1.VirtualMachinePreferenceSpec{
+				CPU: &instancetypev1beta1.CPUPreferences{
+					PreferredCPUTopology: pointer.P(instancetypev1beta1.Cores),
+				},
+			},
+		}
+	}
+
+	newRevision := func(name, namespace, data string) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					instancetypeapi.ControllerRevisionObjectKindLabel: instancetypeapi.ClusterSingularResourceName,
+				},
+			},
+			Data: runtime.RawExtension{Raw: []byte(data)},
+		}
+	}
+
+	setupClients := func() {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = virtClient
+
+		virtClient.EXPECT().VirtualMachineClusterInstancetype().Return(
+			kubevirtClient.InstancetypeV1beta1().VirtualMachineClusterInstancetypes()).AnyTimes()
+		virtClient.EXPECT().VirtualMachineClusterPreference().Return(
+			kubevirtClient.InstancetypeV1beta1().VirtualMachineClusterPreferences()).AnyTimes()
+		for _, namespace := range []string{sourceNamespace, targetNamespace} {
+			virtClient.EXPECT().VirtualMachineInstancetype(namespace).Return(
+				kubevirtClient.InstancetypeV1beta1().VirtualMachineInstancetypes(namespace)).AnyTimes()
+			virtClient.EXPECT().VirtualMachinePreference(namespace).Return(
+				kubevirtClient.InstancetypeV1beta1().VirtualMachinePreferences(namespace)).AnyTimes()
+		}
+		virtClient.EXPECT().AppsV1().Return(k8sClient.AppsV1()).AnyTimes()
+	}
+
+	export := func(args ...string) {
+		args = append([]string{"adm", "export-instancetypes", "--output-file", bundleFile}, args...)
+		Expect(testing.NewRepeatableVirtctlCommand(args...)()).To(Succeed())
+	}
+
+	importBundle := func(args ...string) (string, error) {
+		args = append([]string{"adm", "import-instancetypes", "-f", bundleFile}, args...)
+		out, err := testing.NewRepeatableVirtctlCommandWithOut(args...)()
+		return string(out), err
+	}
+
+	BeforeEach(func() {
+		kubevirtClient = kubevirtfake.NewSimpleClientset(
+			newClusterInstancetype("small", 1),
+			newPreference("windows", sourceNamespace),
+		)
+		k8sClient = k8sfake.NewSimpleClientset(
+			newRevision("vm-small-revision", sourceNamespace, `{"kind": "VirtualMachineClusterInstancetype"}`),
+		)
+		bundleFile = filepath.Join(GinkgoT().TempDir(), "bundle.yaml")
+		setupClients()
+	})
+
+	It("should export only the cluster wide objects by default", func() {
+		export()
+
+		data, err := os.ReadFile(bundleFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("kind: InstancetypeBundle"))
+		Expect(string(data)).To(ContainSubstring("name: small"))
+		Expect(string(data)).ToNot(ContainSubstring("name: windows"))
+		Expect(string(data)).ToNot(ContainSubstring("vm-small-revision"))
+		Expect(string(data)).ToNot(ContainSubstring("cluster-uid"))
+		Expect(string(data)).ToNot(ContainSubstring("resourceVersion"))
+	})
+
+	It("should create missing objects in the namespace of the context", func() {
+		export("-n", sourceNamespace, "--namespaced", "--revisions")
+		Expect(kubevirtClient.InstancetypeV1beta1().VirtualMachineClusterInstancetypes().Delete(
+			context.Background(), "small", metav1.DeleteOptions{})).To(Succeed())
+
+		out, err := importBundle("-n", targetNamespace)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("VirtualMachineClusterInstancetype small created"))
+		Expect(out).To(ContainSubstring("VirtualMachinePreference windows created"))
+		Expect(out).To(ContainSubstring("ControllerRevision vm-small-revision created"))
+
+		instancetype, err := kubevirtClient.InstancetypeV1beta1().VirtualMachineClusterInstancetypes().Get(
+			context.Background(), "small", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(instancetype.Spec.CPU.Guest).To(Equal(uint32(1)))
+		Expect(instancetype.Labels).To(HaveKeyWithValue("catalog", "true"))
+
+		_, err = kubevirtClient.InstancetypeV1beta1().VirtualMachinePreferences(targetNamespace).Get(
+			context.Background(), "windows", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = k8sClient.AppsV1().ControllerRevisions(targetNamespace).Get(
+			context.Background(), "vm-small-revision", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should report unchanged objects", func() {
+		export()
+
+		out, err := importBundle()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(Equal("VirtualMachineClusterInstancetype small unchanged\n"))
+	})
+
+	It("should show the differences and update changed objects", func() {
+		export()
+		_, err := kubevirtClient.InstancetypeV1beta1().VirtualMachineClusterInstancetypes().Update(
+			context.Background(), newClusterInstancetype("small", 2), metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		out, err := importBundle()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("--- VirtualMachineClusterInstancetype/small (cluster)"))
+		Expect(out).To(ContainSubstring("+++ VirtualMachineClusterInstancetype/small (bundle)"))
+		Expect(out).To(ContainSubstring("-    guest: 2"))
+		Expect(out).To(ContainSubstring("+    guest: 1"))
+		Expect(out).To(ContainSubstring("VirtualMachineClusterInstancetype small configured\n"))
+
+		instancetype, err := kubevirtClient.InstancetypeV1beta1().VirtualMachineClusterInstancetypes().Get(
+			context.Background(), "small", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(instancetype.Spec.CPU.Guest).To(Equal(uint32(1)))
+	})
+
+	It("should not change the cluster with --dry-run", func() {
+		export()
+		_, err := kubevirtClient.InstancetypeV1beta1().VirtualMachineClusterInstancetypes().Update(
+			context.Background(), newClusterInstancetype("small", 2), metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		out, err := importBundle("--dry-run")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("+    guest: 1"))
+		Expect(out).To(ContainSubstring("VirtualMachineClusterInstancetype small configured (dry run)\n"))
+
+		instancetype, err := kubevirtClient.InstancetypeV1beta1().VirtualMachineClusterInstancetypes().Get(
+			context.Background(), "small", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(instancetype.Spec.CPU.Guest).To(Equal(uint32(2)))
+	})
+
+	It("should skip existing ControllerRevisions with a different content", func() {
+		export("-n", sourceNamespace, "--revisions")
+		_, err := k8sClient.AppsV1().ControllerRevisions(targetNamespace).Create(context.Background(),
+			newRevision("vm-small-revision", targetNamespace, `{"kind": "VirtualMachineInstancetype"}`), metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		out, err := importBundle("-n", targetNamespace)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("ControllerRevision vm-small-revision skipped, it exists with a different content"))
+
+		revision, err := k8sClient.AppsV1().ControllerRevisions(targetNamespace).Get(
+			context.Background(), "vm-small-revision", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(revision.Data.Raw)).To(ContainSubstring("VirtualMachineInstancetype"))
+	})
+
+	It("should reject bundles with an unsupported version", func() {
+		Expect(os.WriteFile(bundleFile, []byte("apiVersion: virtctl.kubevirt.io/v2\nkind: InstancetypeBundle\n"), 0o600)).To(Succeed())
+
+		_, err := importBundle()
+		Expect(err).To(MatchError(ContainSubstring("unsupported bundle virtctl.kubevirt.io/v2 InstancetypeBundle")))
+	})
+})
@@ -18,6 +18,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -403,6 +404,7 @@ func (c *Command) RunE(cmd *cobra.Command, _ []string) error {
 		if err != nil {
 			return err
 		}
+		result.RecordChange(cmd.Context(), result.Change{Action: "Update", Kind: v1.KubeVirtGroupVersionKind.Kind, Namespace: namespace, Name: name})
 		cmd.Println("successfully set/reset the log verbosity")
 	default:
 		return fmt.Errorf("op: an unknown operation: %v", op)
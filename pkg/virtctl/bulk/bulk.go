@@ -0,0 +1,266 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package bulk
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"kubevirt.io/client-go/kubecli"
+)
+
+const (
+	selectorFlag      = "selector"
+	allNamespacesFlag = "all-namespaces"
+	concurrencyFlag   = "concurrency"
+	yesFlag           = "yes"
+
+	defaultConcurrency = 5
+)
+
+var errSkipped = errors.New("skipped")
+
+// Options select the VirtualMachines a lifecycle command acts on when it is
+// not invoked with the name of a single VirtualMachine.
+type Options struct {
+	Selector      string
+	AllNamespaces bool
+	Concurrency   int
+	Yes           bool
+}
+
+func (o *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Selector, selectorFlag, "l", "", "Act on all VirtualMachines matching the label selector instead of a single one, e.g. -l app=db.")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, allNamespacesFlag, "A", false, "Look for VirtualMachines matching the label selector in all namespaces.")
+	cmd.Flags().IntVar(&o.Concurrency, concurrencyFlag, defaultConcurrency, "The maximum number of VirtualMachines acted on in parallel when a label selector is used.")
+	cmd.Flags().BoolVarP(&o.Yes, yesFlag, "y", false, "Do not ask for confirmation, e.g. before acting on the VirtualMachines matching the label selector.")
+}
+
+// Enabled returns true if the command should act on all matching VirtualMachines.
+func (o *Options) Enabled() bool {
+	return o.Selector != ""
+}
+
+// Validate checks that either a name or a label selector was given. named
+// is true if the command was invoked with the name of a VirtualMachine.
+func (o *Options) Validate(named bool) error {
+	switch {
+	case named && o.Enabled():
+		return fmt.Errorf("a name and --%s cannot be used together", selectorFlag)
+	case !named && !o.Enabled():
+		return fmt.Errorf("either a name or --%s is required", selectorFlag)
+	case o.AllNamespaces && !o.Enabled():
+		return fmt.Errorf("--%s can only be used together with --%s", allNamespacesFlag, selectorFlag)
+	case o.Concurrency <= 0:
+		return fmt.Errorf("--%s must be greater than zero", concurrencyFlag)
+	}
+	return nil
+}
+
+func (o *Options) namespace(namespace string) string {
+	if o.AllNamespaces {
+		return metav1.NamespaceAll
+	}
+	return namespace
+}
+
+// ListVirtualMachines returns the VirtualMachines matching the label selector.
+func (o *Options) ListVirtualMachines(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string) ([]types.NamespacedName, error) {
+	list, err := virtClient.VirtualMachine(o.namespace(namespace)).List(ctx, metav1.ListOptions{LabelSelector: o.Selector})
+	if err != nil {
+		return nil, fmt.Errorf("error listing VirtualMachines: %w", err)
+	}
+	targets := make([]types.NamespacedName, 0, len(list.Items))
+	for _, vm := range list.Items {
+		targets = append(targets, types.NamespacedName{Namespace: vm.Namespace, Name: vm.Name})
+	}
+	return targets, nil
+}
+
+// ListVirtualMachineInstances returns the VirtualMachineInstances matching the label selector.
+func (o *Options) ListVirtualMachineInstances(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string) ([]types.NamespacedName, error) {
+	list, err := virtClient.VirtualMachineInstance(o.namespace(namespace)).List(ctx, metav1.ListOptions{LabelSelector: o.Selector})
+	if err != nil {
+		return nil, fmt.Errorf("error listing VirtualMachineInstances: %w", err)
+	}
+	targets := make([]types.NamespacedName, 0, len(list.Items))
+	for _, vmi := range list.Items {
+		targets = append(targets, types.NamespacedName{Namespace: vmi.Namespace, Name: vmi.Name})
+	}
+	return targets, nil
+}
+
+// Run asks for confirmation unless --yes was given, applies action to all
+// targets with at most Concurrency actions in flight and prints a table with
+// the result for each target. It fails if the action failed for any target.
+func (o *Options) Run(cmd *cobra.Command, verb string, targets []types.NamespacedName, action func(ctx context.Context, target types.NamespacedName) error) error {
+	proceed, err := o.prepare(cmd, verb, targets)
+	if err != nil || !proceed {
+		return err
+	}
+
+	errs := make([]error, len(targets))
+	semaphore := make(chan struct{}, o.Concurrency)
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			errs[i] = action(cmd.Context(), targets[i])
+		}(i)
+	}
+	wg.Wait()
+
+	return report(cmd, verb, targets, errs)
+}
+
+// RunInBatches is like Run, but applies action to batchSize targets at a time
+// and only starts the next batch once the action succeeded for all targets of
+// the current batch. The targets of all batches after a failed one are skipped.
+func (o *Options) RunInBatches(cmd *cobra.Command, verb string, targets []types.NamespacedName, batchSize int, action func(ctx context.Context, target types.NamespacedName) error) error {
+	proceed, err := o.prepare(cmd, verb, targets)
+	if err != nil || !proceed {
+		return err
+	}
+
+	errs := make([]error, len(targets))
+	batches := (len(targets) + batchSize - 1) / batchSize
+	for start := 0; start < len(targets); start += batchSize {
+		end := min(start+batchSize, len(targets))
+		batch := start/batchSize + 1
+
+		names := make([]string, 0, end-start)
+		for _, target := range targets[start:end] {
+			names = append(names, target.String())
+		}
+		cmd.Printf("Batch %d/%d: %s %s\n", batch, batches, verb, strings.Join(names, ", "))
+
+		var wg sync.WaitGroup
+		for i := start; i < end; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = action(cmd.Context(), targets[i])
+			}(i)
+		}
+		wg.Wait()
+
+		for i := start; i < end; i++ {
+			if errs[i] == nil {
+				continue
+			}
+			cmd.Printf("Batch %d/%d failed, skipping the remaining %d resources\n", batch, batches, len(targets)-end)
+			for j := end; j < len(targets); j++ {
+				errs[j] = errSkipped
+			}
+			return report(cmd, verb, targets, errs)
+		}
+	}
+
+	return report(cmd, verb, targets, errs)
+}
+
+// prepare sorts the targets and asks for confirmation unless --yes was given.
+// It returns false if there is nothing to act on.
+func (o *Options) prepare(cmd *cobra.Command, verb string, targets []types.NamespacedName) (bool, error) {
+	if len(targets) == 0 {
+		cmd.Printf("No resources match the selector %s\n", o.Selector)
+		return false, nil
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].String() < targets[j].String()
+	})
+
+	if o.Yes {
+		return true, nil
+	}
+	confirmed, err := o.confirm(cmd, verb, targets)
+	if err != nil {
+		return false, err
+	}
+	if !confirmed {
+		cmd.Println("Aborted")
+	}
+	return confirmed, nil
+}
+
+// report prints a table with the result for each target and fails if the
+// action failed for or skipped any target.
+func report(cmd *cobra.Command, verb string, targets []types.NamespacedName, errs []error) error {
+	failed, skipped := 0, 0
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tRESULT")
+	for i, target := range targets {
+		res := "OK"
+		switch {
+		case errors.Is(errs[i], errSkipped):
+			res = errs[i].Error()
+			skipped++
+		case errs[i] != nil:
+			res = errs[i].Error()
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", target.Namespace, target.Name, res)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	switch {
+	case skipped > 0:
+		return fmt.Errorf("failed to %s %d of %d resources, skipped %d", verb, failed, len(targets), skipped)
+	case failed > 0:
+		return fmt.Errorf("failed to %s %d of %d resources", verb, failed, len(targets))
+	}
+	return nil
+}
+
+func (o *Options) confirm(cmd *cobra.Command, verb string, targets []types.NamespacedName) (bool, error) {
+	cmd.Printf("The following %d resources will be affected by %s:\n", len(targets), verb)
+	for _, target := range targets {
+		cmd.Printf("  %s\n", target)
+	}
+	return AskToContinue(cmd)
+}
+
+// AskToContinue asks the user whether to continue and returns true if the
+// answer was yes.
+func AskToContinue(cmd *cobra.Command) (bool, error) {
+	cmd.Print("Do you want to continue? [y/N]: ")
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, fmt.Errorf("error reading the confirmation: %w", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
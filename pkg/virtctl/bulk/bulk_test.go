@@ -0,0 +1,210 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package bulk_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/virtctl/bulk"
+)
+
+var _ = Describe("Bulk options", func() {
+	DescribeTable("Validate should reject", func(options bulk.Options, named bool, expected string) {
+		Expect(options.Validate(named)).To(MatchError(expected))
+	},
+		Entry("a name and a selector", bulk.Options{Selector: "app=db", Concurrency: 1}, true, "a name and --selector cannot be used together"),
+		Entry("neither a name nor a selector", bulk.Options{Concurrency: 1}, false, "either a name or --selector is required"),
+		Entry("all namespaces without a selector", bulk.Options{AllNamespaces: true, Concurrency: 1}, true, "--all-namespaces can only be used together with --selector"),
+		Entry("a non-positive concurrency", bulk.Options{Selector: "app=db"}, false, "--concurrency must be greater than zero"),
+	)
+
+	It("Validate should accept a name or a selector", func() {
+		Expect((&bulk.Options{Concurrency: 1}).Validate(true)).To(Succeed())
+		Expect((&bulk.Options{Selector: "app=db", AllNamespaces: true, Concurrency: 1}).Validate(false)).To(Succeed())
+	})
+
+	Context("listing", func() {
+		var virtClient *kubevirtfake.Clientset
+
+		BeforeEach(func() {
+			newVM := func(namespace, name, app string) *v1.VirtualMachine {
+				return libvmi.NewVirtualMachine(
+					libvmi.New(libvmi.WithNamespace(namespace), libvmi.WithName(name)),
+					libvmi.WithLabels(map[string]string{"app": app}),
+				)
+			}
+			vm1 := newVM("ns1", "db1", "db")
+			vm2 := newVM("ns2", "db2", "db")
+			vm3 := newVM("ns1", "web", "web")
+			virtClient = kubevirtfake.NewSimpleClientset(vm1, vm2, vm3)
+
+			ctrl := gomock.NewController(GinkgoT())
+			client := kubecli.NewMockKubevirtClient(ctrl)
+			client.EXPECT().VirtualMachine(gomock.Any()).DoAndReturn(func(namespace string) kubecli.VirtualMachineInterface {
+				return virtClient.KubevirtV1().VirtualMachines(namespace)
+			}).AnyTimes()
+			kubecli.MockKubevirtClientInstance = client
+		})
+
+		DescribeTable("should return the VirtualMachines matching the selector", func(allNamespaces bool, expected []types.NamespacedName) {
+			options := bulk.Options{Selector: "app=db", AllNamespaces: allNamespaces}
+			targets, err := options.ListVirtualMachines(context.Background(), kubecli.MockKubevirtClientInstance, "ns1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(targets).To(ConsistOf(expected))
+		},
+			Entry("in the current namespace", false, []types.NamespacedName{{Namespace: "ns1", Name: "db1"}}),
+			Entry("in all namespaces", true, []types.NamespacedName{{Namespace: "ns1", Name: "db1"}, {Namespace: "ns2", Name: "db2"}}),
+		)
+	})
+
+	Context("Run", func() {
+		var (
+			out     *bytes.Buffer
+			cmd     *cobra.Command
+			targets []types.NamespacedName
+		)
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+			cmd = &cobra.Command{}
+			cmd.SetOut(out)
+			cmd.SetContext(context.Background())
+			targets = []types.NamespacedName{
+				{Namespace: "ns2", Name: "db2"},
+				{Namespace: "ns1", Name: "db1"},
+				{Namespace: "ns1", Name: "db3"},
+			}
+		})
+
+		It("should act on all targets after confirmation and print the results", func() {
+			cmd.SetIn(strings.NewReader("y\n"))
+			var lock sync.Mutex
+			var acted []string
+			options := bulk.Options{Selector: "app=db", Concurrency: 2}
+			err := options.Run(cmd, "stop", targets, func(_ context.Context, target types.NamespacedName) error {
+				lock.Lock()
+				defer lock.Unlock()
+				acted = append(acted, target.String())
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acted).To(ConsistOf("ns1/db1", "ns1/db3", "ns2/db2"))
+			Expect(out.String()).To(ContainSubstring("The following 3 resources will be affected by stop:\n  ns1/db1\n  ns1/db3\n  ns2/db2\n"))
+			Expect(out.String()).To(MatchRegexp(`NAMESPACE\s+NAME\s+RESULT\nns1\s+db1\s+OK\nns1\s+db3\s+OK\nns2\s+db2\s+OK\n`))
+		})
+
+		It("should not act on any target if the confirmation is declined", func() {
+			cmd.SetIn(strings.NewReader("n\n"))
+			options := bulk.Options{Selector: "app=db", Concurrency: 2}
+			err := options.Run(cmd, "stop", targets, func(context.Context, types.NamespacedName) error {
+				Fail("no target should be acted on")
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out.String()).To(HaveSuffix("Aborted\n"))
+		})
+
+		It("should report the targets it failed to act on", func() {
+			options := bulk.Options{Selector: "app=db", Concurrency: 2, Yes: true}
+			err := options.Run(cmd, "stop", targets, func(_ context.Context, target types.NamespacedName) error {
+				if target.Name == "db3" {
+					return errors.New("boom")
+				}
+				return nil
+			})
+			Expect(err).To(MatchError("failed to stop 1 of 3 resources"))
+			Expect(out.String()).ToNot(ContainSubstring("Do you want to continue?"))
+			Expect(out.String()).To(MatchRegexp(`ns1\s+db3\s+boom\n`))
+		})
+
+		It("should respect the concurrency limit", func() {
+			var lock sync.Mutex
+			inFlight, maxInFlight := 0, 0
+			options := bulk.Options{Selector: "app=db", Concurrency: 2, Yes: true}
+			for i := 0; i < 10; i++ {
+				targets = append(targets, types.NamespacedName{Namespace: "ns3", Name: strings.Repeat("x", i+1)})
+			}
+			err := options.Run(cmd, "stop", targets, func(context.Context, types.NamespacedName) error {
+				lock.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				lock.Unlock()
+				defer func() {
+					lock.Lock()
+					inFlight--
+					lock.Unlock()
+				}()
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(maxInFlight).To(BeNumerically("<=", 2))
+		})
+
+		It("should act on one batch at a time", func() {
+			var lock sync.Mutex
+			var order []string
+			options := bulk.Options{Selector: "app=db", Yes: true}
+			err := options.RunInBatches(cmd, "restart", targets, 2, func(_ context.Context, target types.NamespacedName) error {
+				lock.Lock()
+				defer lock.Unlock()
+				order = append(order, target.String())
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(order[:2]).To(ConsistOf("ns1/db1", "ns1/db3"))
+			Expect(order[2:]).To(Equal([]string{"ns2/db2"}))
+			Expect(out.String()).To(ContainSubstring("Batch 1/2: restart ns1/db1, ns1/db3\nBatch 2/2: restart ns2/db2\n"))
+		})
+
+		It("should skip the remaining batches after a failed one", func() {
+			options := bulk.Options{Selector: "app=db", Yes: true}
+			err := options.RunInBatches(cmd, "restart", targets, 1, func(_ context.Context, target types.NamespacedName) error {
+				if target.Name == "db1" {
+					return errors.New("not ready")
+				}
+				Fail("no target after the failed batch should be acted on")
+				return nil
+			})
+			Expect(err).To(MatchError("failed to restart 1 of 3 resources, skipped 2"))
+			Expect(out.String()).To(ContainSubstring("Batch 1/3 failed, skipping the remaining 2 resources\n"))
+			Expect(out.String()).To(MatchRegexp(`ns1\s+db1\s+not ready\nns1\s+db3\s+skipped\nns2\s+db2\s+skipped\n`))
+		})
+
+		It("should report when no resources match", func() {
+			options := bulk.Options{Selector: "app=db", Concurrency: 2}
+			Expect(options.Run(cmd, "stop", nil, nil)).To(Succeed())
+			Expect(out.String()).To(Equal("No resources match the selector app=db\n"))
+		})
+	})
+})
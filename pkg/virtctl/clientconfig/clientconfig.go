@@ -23,13 +23,23 @@ func NewContext(ctx context.Context, clientConfig clientcmd.ClientConfig) contex
 	return context.WithValue(ctx, clientConfigKey, clientConfig)
 }
 
+// FromContext returns the clientcmd.ClientConfig value stored in ctx, if any.
+// Otherwise, it returns an error.
+func FromContext(ctx context.Context) (clientcmd.ClientConfig, error) {
+	clientConfig, ok := ctx.Value(clientConfigKey).(clientcmd.ClientConfig)
+	if !ok {
+		return nil, fmt.Errorf("unable to get client config from context")
+	}
+	return clientConfig, nil
+}
+
 // ClientAndNamespaceFromContext tries to retrieve a clientcmd.Clientconfig value stored in ctx, if any.
 // It then creates a kubecli.KubevirtClient and gets the namespace from the client config and returns them.
 // Otherwise, it returns an error.
 func ClientAndNamespaceFromContext(ctx context.Context) (virtClient kubecli.KubevirtClient, namespace string, overridden bool, err error) {
-	clientConfig, ok := ctx.Value(clientConfigKey).(clientcmd.ClientConfig)
-	if !ok {
-		return nil, "", false, fmt.Errorf("unable to get client config from context")
+	clientConfig, err := FromContext(ctx)
+	if err != nil {
+		return nil, "", false, err
 	}
 	virtClient, err = kubecli.GetKubevirtClientFromClientConfig(clientConfig)
 	if err != nil {
@@ -0,0 +1,197 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package clone_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	clonev1 "kubevirt.io/api/clone/v1beta1"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Clone command", func() {
+	const (
+		sourceName = "source"
+		targetName = "target"
+		cloneName  = "testclone"
+	)
+
+	var virtClient *kubevirtfake.Clientset
+
+	BeforeEach(func() {
+		virtClient = kubevirtfake.NewSimpleClientset()
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineClone(metav1.NamespaceDefault).
+			Return(virtClient.CloneV1beta1().VirtualMachineClones(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault)).AnyTimes()
+	})
+
+	getClone := func() *clonev1.VirtualMachineClone {
+		vmClone, err := virtClient.CloneV1beta1().VirtualMachineClones(metav1.NamespaceDefault).
+			Get(context.Background(), cloneName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return vmClone
+	}
+
+	reactWithPhases := func(phases ...clonev1.VirtualMachineClonePhase) {
+		gets := 0
+		virtClient.PrependReactor("get", "virtualmachineclones", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+			phase := phases[min(gets, len(phases)-1)]
+			gets++
+			vmClone := &clonev1.VirtualMachineClone{
+				ObjectMeta: metav1.ObjectMeta{Name: cloneName, Namespace: metav1.NamespaceDefault},
+				Status:     clonev1.VirtualMachineCloneStatus{Phase: phase},
+			}
+			if phase == clonev1.Failed {
+				vmClone.Status.Conditions = []clonev1.Condition{{
+					Type:   clonev1.ConditionReady,
+					Status: k8sv1.ConditionFalse,
+					Reason: "source VM is running",
+				}}
+			}
+			return true, vmClone, nil
+		})
+	}
+
+	It("should create a VirtualMachineClone of the source VM", func() {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("clone", "vm", sourceName, targetName, "--name", cloneName, "--wait=false",
+			"--label-filter", "*", "--label-filter", "!some/key",
+			"--annotation-filter", "*",
+			"--template-label-filter", "!other/key",
+			"--template-annotation-filter", "*",
+			"--new-mac-address", "default:02:00:00:00:00:01",
+			"--new-smbios-serial", "serial",
+		)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("VirtualMachineClone testclone of VirtualMachine source to VirtualMachine target created"))
+
+		vmClone := getClone()
+		Expect(vmClone.Spec.Source.Kind).To(Equal("VirtualMachine"))
+		Expect(vmClone.Spec.Source.APIGroup).To(HaveValue(Equal("kubevirt.io")))
+		Expect(vmClone.Spec.Source.Name).To(Equal(sourceName))
+		Expect(vmClone.Spec.Target.Kind).To(Equal("VirtualMachine"))
+		Expect(vmClone.Spec.Target.Name).To(Equal(targetName))
+		Expect(vmClone.Spec.LabelFilters).To(Equal([]string{"*", "!some/key"}))
+		Expect(vmClone.Spec.AnnotationFilters).To(Equal([]string{"*"}))
+		Expect(vmClone.Spec.Template.LabelFilters).To(Equal([]string{"!other/key"}))
+		Expect(vmClone.Spec.Template.AnnotationFilters).To(Equal([]string{"*"}))
+		Expect(vmClone.Spec.NewMacAddresses).To(Equal(map[string]string{"default": "02:00:00:00:00:01"}))
+		Expect(vmClone.Spec.NewSMBiosSerial).To(HaveValue(Equal("serial")))
+	})
+
+	It("should generate a clone name if none is given", func() {
+		Expect(testing.NewRepeatableVirtctlCommand("clone", "vm", sourceName, targetName, "--wait=false")()).To(Succeed())
+
+		clones, err := virtClient.CloneV1beta1().VirtualMachineClones(metav1.NamespaceDefault).
+			List(context.Background(), metav1.ListOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clones.Items).To(HaveLen(1))
+		Expect(clones.Items[0].Name).To(HavePrefix(sourceName + "-clone-"))
+	})
+
+	It("should keep the MAC addresses and SMBIOS serial of the source if requested", func() {
+		source := libvmi.NewVirtualMachine(libvmi.New(
+			libvmi.WithNamespace(metav1.NamespaceDefault),
+			libvmi.WithName(sourceName),
+			libvmi.WithInterface(v1.Interface{Name: "default", MacAddress: "02:00:00:00:00:0a"}),
+			libvmi.WithInterface(v1.Interface{Name: "secondary", MacAddress: "02:00:00:00:00:0b"}),
+		))
+		source.Spec.Template.Spec.Domain.Firmware = &v1.Firmware{Serial: "source-serial"}
+		_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), source, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(testing.NewRepeatableVirtctlCommand("clone", "vm", sourceName, targetName, "--name", cloneName, "--wait=false",
+			"--mac-address-policy", "keep", "--smbios-serial-policy", "keep",
+			"--new-mac-address", "secondary:02:00:00:00:00:0c",
+		)()).To(Succeed())
+
+		vmClone := getClone()
+		Expect(vmClone.Spec.NewMacAddresses).To(Equal(map[string]string{
+			"default":   "02:00:00:00:00:0a",
+			"secondary": "02:00:00:00:00:0c",
+		}))
+		Expect(vmClone.Spec.NewSMBiosSerial).To(HaveValue(Equal("source-serial")))
+	})
+
+	It("should not read the source VM with the default policies", func() {
+		Expect(testing.NewRepeatableVirtctlCommand("clone", "vm", sourceName, targetName, "--name", cloneName, "--wait=false")()).To(Succeed())
+
+		vmClone := getClone()
+		Expect(vmClone.Spec.NewMacAddresses).To(BeEmpty())
+		Expect(vmClone.Spec.NewSMBiosSerial).To(BeNil())
+	})
+
+	It("should wait and report progress until the clone succeeded", func() {
+		reactWithPhases("", clonev1.SnapshotInProgress, clonev1.RestoreInProgress, clonev1.Succeeded)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("clone", "vm", sourceName, targetName, "--name", cloneName)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("VirtualMachineClone testclone: Pending"))
+		Expect(string(out)).To(ContainSubstring("VirtualMachineClone testclone: SnapshotInProgress"))
+		Expect(string(out)).To(ContainSubstring("VirtualMachineClone testclone: RestoreInProgress"))
+		Expect(string(out)).To(ContainSubstring("VirtualMachine source was cloned to VirtualMachine target"))
+	})
+
+	It("should fail when waiting for a failed clone", func() {
+		reactWithPhases(clonev1.Failed)
+
+		err := testing.NewRepeatableVirtctlCommand("clone", "vm", sourceName, targetName, "--name", cloneName)()
+		Expect(err).To(MatchError(ContainSubstring("VirtualMachineClone testclone failed: Failed (source VM is running)")))
+	})
+
+	DescribeTable("should reject invalid arguments", func(expected string, args ...string) {
+		args = append([]string{"clone", "vm", sourceName, targetName}, args...)
+		err := testing.NewRepeatableVirtctlCommand(args...)()
+		Expect(err).To(MatchError(ContainSubstring(expected)))
+	},
+		Entry("with a different target namespace", "the target has to be in the namespace of the source",
+			"--namespace-target", "other"),
+		Entry("with an unknown MAC address policy", `invalid value "random" for --mac-address-policy`,
+			"--mac-address-policy", "random"),
+		Entry("with an unknown SMBIOS serial policy", `invalid value "random" for --smbios-serial-policy`,
+			"--smbios-serial-policy", "random"),
+		Entry("with a malformed MAC address", `invalid value "default" for --new-mac-address`,
+			"--new-mac-address", "default"),
+		Entry("with a non-positive timeout", "the timeout must be greater than zero",
+			"--timeout", "0s"),
+	)
+
+	It("should accept the source namespace as target namespace", func() {
+		Expect(testing.NewRepeatableVirtctlCommand("clone", "vm", sourceName, targetName, "--wait=false",
+			"--namespace-target", metav1.NamespaceDefault)()).To(Succeed())
+	})
+})
@@ -0,0 +1,322 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clonev1 "kubevirt.io/api/clone/v1beta1"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	nameFlag                     = "name"
+	namespaceTargetFlag          = "namespace-target"
+	labelFilterFlag              = "label-filter"
+	annotationFilterFlag         = "annotation-filter"
+	templateLabelFilterFlag      = "template-label-filter"
+	templateAnnotationFilterFlag = "template-annotation-filter"
+	newMacAddressFlag            = "new-mac-address"
+	newSMBiosSerialFlag          = "new-smbios-serial"
+	macAddressPolicyFlag         = "mac-address-policy"
+	smbiosSerialPolicyFlag       = "smbios-serial-policy"
+	waitFlag                     = "wait"
+	timeoutFlag                  = "timeout"
+
+	// policyRegenerate lets the clone controller assign new values to the target
+	policyRegenerate = "regenerate"
+	// policyKeep copies the values of the source to the target
+	policyKeep = "keep"
+
+	defaultTimeout = 10 * time.Minute
+	pollInterval   = time.Second
+
+	timestampFormat = "20060102150405"
+
+	cloneKind = "VirtualMachineClone"
+)
+
+type vmCommand struct {
+	name                      string
+	namespaceTarget           string
+	labelFilters              []string
+	annotationFilters         []string
+	templateLabelFilters      []string
+	templateAnnotationFilters []string
+	newMacAddresses           []string
+	newSMBiosSerial           string
+	macAddressPolicy          string
+	smbiosSerialPolicy        string
+	wait                      bool
+	timeout                   time.Duration
+}
+
+func newVMCommand() *cobra.Command {
+	c := vmCommand{}
+	cmd := &cobra.Command{
+		Use:   "vm (SOURCE) (TARGET)",
+		Short: "Clone a VirtualMachine into a new VirtualMachine.",
+		Long: `Clone a VirtualMachine into a new VirtualMachine by creating a VirtualMachineClone and waiting for it to succeed.
+The target VirtualMachine is created in the namespace of the source, cloning across namespaces is not supported.`,
+		Example: vmUsage(),
+		Args:    cobra.ExactArgs(2),
+		RunE:    c.run,
+	}
+
+	const supportsMultipleFlags = "Can be provided multiple times."
+	cmd.Flags().StringVar(&c.name, nameFlag, "", "Name of the VirtualMachineClone. Defaults to '<source>-clone-<timestamp>'.")
+	cmd.Flags().StringVar(&c.namespaceTarget, namespaceTargetFlag, "",
+		"Namespace of the target VirtualMachine. Defaults to the namespace of the source and has to match it.")
+	cmd.Flags().StringArrayVar(&c.labelFilters, labelFilterFlag, nil,
+		"Filter for the labels copied to the target, for example '*' or '!some/key'. "+supportsMultipleFlags)
+	cmd.Flags().StringArrayVar(&c.annotationFilters, annotationFilterFlag, nil,
+		"Filter for the annotations copied to the target, for example '*' or '!some/key'. "+supportsMultipleFlags)
+	cmd.Flags().StringArrayVar(&c.templateLabelFilters, templateLabelFilterFlag, nil,
+		"Filter for the template labels copied to the target. "+supportsMultipleFlags)
+	cmd.Flags().StringArrayVar(&c.templateAnnotationFilters, templateAnnotationFilterFlag, nil,
+		"Filter for the template annotations copied to the target. "+supportsMultipleFlags)
+	cmd.Flags().StringArrayVar(&c.newMacAddresses, newMacAddressFlag, nil,
+		"Set the MAC address of an interface of the target, for example 'default:02:00:00:00:00:01'. "+supportsMultipleFlags)
+	cmd.Flags().StringVar(&c.newSMBiosSerial, newSMBiosSerialFlag, "", "Set the SMBIOS serial of the target.")
+	cmd.Flags().StringVar(&c.macAddressPolicy, macAddressPolicyFlag, policyRegenerate,
+		fmt.Sprintf("How to assign the MAC addresses of interfaces without --%s. Supported values: %s, %s", newMacAddressFlag, policyRegenerate, policyKeep))
+	cmd.Flags().StringVar(&c.smbiosSerialPolicy, smbiosSerialPolicyFlag, policyRegenerate,
+		fmt.Sprintf("How to assign the SMBIOS serial if --%s is not set. Supported values: %s, %s", newSMBiosSerialFlag, policyRegenerate, policyKeep))
+	cmd.Flags().BoolVar(&c.wait, waitFlag, true, "Wait until the clone succeeded and report its progress while waiting.")
+	cmd.Flags().DurationVar(&c.timeout, timeoutFlag, defaultTimeout, "The maximum time to wait when --wait is set.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func vmUsage() string {
+	return `  # Clone the VirtualMachine 'my-vm' into the VirtualMachine 'my-clone' and wait until the clone succeeded
+  {{ProgramName}} clone vm my-vm my-clone
+
+  # Clone a VirtualMachine without waiting for the clone to succeed
+  {{ProgramName}} clone vm my-vm my-clone --wait=false
+
+  # Clone a VirtualMachine and copy all labels except the ones with the prefix 'some/'
+  {{ProgramName}} clone vm my-vm my-clone --label-filter '*' --label-filter '!some/*'
+
+  # Clone a VirtualMachine and keep the MAC addresses and SMBIOS serial of the source
+  {{ProgramName}} clone vm my-vm my-clone --mac-address-policy keep --smbios-serial-policy keep
+
+  # Clone a VirtualMachine and set the MAC address of the interface 'default'
+  {{ProgramName}} clone vm my-vm my-clone --new-mac-address default:02:00:00:00:00:01`
+}
+
+func (c *vmCommand) run(cmd *cobra.Command, args []string) error {
+	if err := c.validateFlags(); err != nil {
+		return result.NewUsageError(err)
+	}
+	newMacAddresses, err := parseNewMacAddresses(c.newMacAddresses)
+	if err != nil {
+		return result.NewUsageError(err)
+	}
+	sourceName, targetName := args[0], args[1]
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if c.namespaceTarget != "" && c.namespaceTarget != namespace {
+		return result.NewUsageError(fmt.Errorf(
+			"cloning from namespace %s to namespace %s is not supported, the target has to be in the namespace of the source",
+			namespace, c.namespaceTarget))
+	}
+
+	name := c.name
+	if name == "" {
+		name = fmt.Sprintf("%s-clone-%s", sourceName, time.Now().UTC().Format(timestampFormat))
+	}
+
+	vmClone := &clonev1.VirtualMachineClone{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: clonev1.VirtualMachineCloneSpec{
+			Source:            vmReference(sourceName),
+			Target:            vmReference(targetName),
+			LabelFilters:      c.labelFilters,
+			AnnotationFilters: c.annotationFilters,
+			Template: clonev1.VirtualMachineCloneTemplateFilters{
+				LabelFilters:      c.templateLabelFilters,
+				AnnotationFilters: c.templateAnnotationFilters,
+			},
+			NewMacAddresses: newMacAddresses,
+		},
+	}
+	if c.newSMBiosSerial != "" {
+		vmClone.Spec.NewSMBiosSerial = pointer.P(c.newSMBiosSerial)
+	}
+	if err := c.applyKeepPolicies(cmd.Context(), virtClient, namespace, sourceName, &vmClone.Spec); err != nil {
+		return err
+	}
+
+	if _, err := virtClient.VirtualMachineClone(namespace).Create(cmd.Context(), vmClone, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating VirtualMachineClone %s: %w", name, err)
+	}
+	result.RecordChange(cmd.Context(), result.Change{
+		Action:    "Create",
+		Kind:      cloneKind,
+		Namespace: namespace,
+		Name:      name,
+	})
+	cmd.Printf("VirtualMachineClone %s of VirtualMachine %s to VirtualMachine %s created\n", name, sourceName, targetName)
+
+	if !c.wait {
+		return nil
+	}
+	if err := waitForClone(cmd.OutOrStdout(), virtClient, namespace, name, c.timeout); err != nil {
+		return err
+	}
+	cmd.Printf("VirtualMachine %s was cloned to VirtualMachine %s\n", sourceName, targetName)
+	return nil
+}
+
+func (c *vmCommand) validateFlags() error {
+	for flag, policy := range map[string]string{
+		macAddressPolicyFlag:   c.macAddressPolicy,
+		smbiosSerialPolicyFlag: c.smbiosSerialPolicy,
+	} {
+		if policy != policyRegenerate && policy != policyKeep {
+			return fmt.Errorf("invalid value %q for --%s, supported values: %s, %s", policy, flag, policyRegenerate, policyKeep)
+		}
+	}
+	if c.wait && c.timeout <= 0 {
+		return fmt.Errorf("the timeout must be greater than zero")
+	}
+	return nil
+}
+
+func parseNewMacAddresses(params []string) (map[string]string, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	macAddresses := map[string]string{}
+	for _, param := range params {
+		interfaceName, macAddress, found := strings.Cut(param, ":")
+		if !found || interfaceName == "" || macAddress == "" {
+			return nil, fmt.Errorf("invalid value %q for --%s, expected format is 'interface:address'", param, newMacAddressFlag)
+		}
+		macAddresses[interfaceName] = macAddress
+	}
+	return macAddresses, nil
+}
+
+// applyKeepPolicies copies the MAC addresses and the SMBIOS serial of the
+// source into the spec of the clone, unless they were set explicitly. The
+// clone controller otherwise clears them so that new ones are assigned.
+func (c *vmCommand) applyKeepPolicies(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, sourceName string, spec *clonev1.VirtualMachineCloneSpec) error {
+	if c.macAddressPolicy != policyKeep && c.smbiosSerialPolicy != policyKeep {
+		return nil
+	}
+	source, err := virtClient.VirtualMachine(namespace).Get(ctx, sourceName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting VirtualMachine %s: %w", sourceName, err)
+	}
+	if source.Spec.Template == nil {
+		return nil
+	}
+	domain := source.Spec.Template.Spec.Domain
+
+	if c.macAddressPolicy == policyKeep {
+		for _, iface := range domain.Devices.Interfaces {
+			if iface.MacAddress == "" {
+				continue
+			}
+			if _, exists := spec.NewMacAddresses[iface.Name]; exists {
+				continue
+			}
+			if spec.NewMacAddresses == nil {
+				spec.NewMacAddresses = map[string]string{}
+			}
+			spec.NewMacAddresses[iface.Name] = iface.MacAddress
+		}
+	}
+	if c.smbiosSerialPolicy == policyKeep && spec.NewSMBiosSerial == nil &&
+		domain.Firmware != nil && domain.Firmware.Serial != "" {
+		spec.NewSMBiosSerial = pointer.P(domain.Firmware.Serial)
+	}
+	return nil
+}
+
+func vmReference(name string) *k8sv1.TypedLocalObjectReference {
+	return &k8sv1.TypedLocalObjectReference{
+		APIGroup: pointer.P(v1.SchemeGroupVersion.Group),
+		Kind:     v1.VirtualMachineGroupVersionKind.Kind,
+		Name:     name,
+	}
+}
+
+func waitForClone(out io.Writer, virtClient kubecli.KubevirtClient, namespace, name string, timeout time.Duration) error {
+	var lastProgress string
+	err := virtwait.PollImmediately(pollInterval, timeout, func(ctx context.Context) (bool, error) {
+		vmClone, err := virtClient.VirtualMachineClone(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("error getting VirtualMachineClone %s: %w", name, err)
+		}
+		progress := cloneProgress(vmClone)
+		switch vmClone.Status.Phase {
+		case clonev1.Succeeded:
+			return true, nil
+		case clonev1.Failed:
+			return false, fmt.Errorf("VirtualMachineClone %s failed: %s", name, progress)
+		}
+		if progress != lastProgress {
+			lastProgress = progress
+			fmt.Fprintf(out, "%s %s: %s\n", cloneKind, name, progress)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for VirtualMachineClone %s: %w", name, err)
+	}
+	return nil
+}
+
+func cloneProgress(vmClone *clonev1.VirtualMachineClone) string {
+	phase := string(vmClone.Status.Phase)
+	if phase == "" {
+		phase = "Pending"
+	}
+	for _, condition := range vmClone.Status.Conditions {
+		if condition.Type == clonev1.ConditionReady && condition.Status != k8sv1.ConditionTrue &&
+			condition.Reason != "" && condition.Reason != "Still processing" {
+			return fmt.Sprintf("%s (%s)", phase, condition.Reason)
+		}
+	}
+	return phase
+}
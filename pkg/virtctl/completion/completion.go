@@ -0,0 +1,212 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package completion
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/api/instancetype"
+	"kubevirt.io/client-go/kubevirt"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+)
+
+// lister returns the names of the resources of a given type in the namespace
+type lister func(ctx context.Context, client kubevirt.Interface, namespace string) ([]string, error)
+
+// VM completes the first argument with the names of the VirtualMachines in the namespace
+func VM(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return FilterPrefix(listNames(cmd, listVMs), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// VMI completes the first argument with the names of the VirtualMachineInstances in the namespace
+func VMI(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return FilterPrefix(listNames(cmd, listVMIs), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// KindAndName completes commands taking a vm|vmi type argument followed by the name of the resource
+func KindAndName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return FilterPrefix([]string{"vm", "vmi"}, toComplete), cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		list := listerForKind(args[0])
+		if list == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return FilterPrefix(listNames(cmd, list), toComplete), cobra.ShellCompDirectiveNoFileComp
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Target completes the first argument of commands taking a [username@]type/name[/namespace] target
+func Target(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	username := ""
+	if i := strings.LastIndex(toComplete, "@"); i >= 0 {
+		username = toComplete[:i+1]
+	}
+	kind, name, found := strings.Cut(strings.TrimPrefix(toComplete, username), "/")
+	if !found {
+		// Let the user continue typing the name right after the type
+		return FilterPrefix([]string{username + "vm/", username + "vmi/"}, toComplete),
+			cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+
+	list := listerForKind(kind)
+	if list == nil || strings.Contains(name, "/") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var targets []string
+	for _, name := range listNames(cmd, list) {
+		targets = append(targets, username+kind+"/"+name)
+	}
+	return FilterPrefix(targets, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// Instancetype completes a [kind/]name instancetype reference, offering the cluster wide
+// instancetypes by name and the namespaced ones prefixed with their kind
+func Instancetype(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names := listNames(cmd, listClusterInstancetypes)
+	for _, name := range listNames(cmd, listInstancetypes) {
+		names = append(names, instancetype.SingularResourceName+"/"+name)
+	}
+	return FilterPrefix(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// Preference completes a [kind/]name preference reference, offering the cluster wide
+// preferences by name and the namespaced ones prefixed with their kind
+func Preference(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names := listNames(cmd, listClusterPreferences)
+	for _, name := range listNames(cmd, listPreferences) {
+		names = append(names, instancetype.SingularPreferenceResourceName+"/"+name)
+	}
+	return FilterPrefix(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// FilterPrefix returns the values starting with prefix
+func FilterPrefix(values []string, prefix string) []string {
+	var filtered []string
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			filtered = append(filtered, value)
+		}
+	}
+	return filtered
+}
+
+// listNames returns the sorted names listed in the namespace of the command.
+// Errors are swallowed, completion is best effort and must not print anything.
+func listNames(cmd *cobra.Command, list lister) []string {
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return nil
+	}
+	names, err := list(cmd.Context(), virtClient.GeneratedKubeVirtClient(), namespace)
+	if err != nil {
+		return nil
+	}
+	sort.Strings(names)
+	return names
+}
+
+func listerForKind(kind string) lister {
+	switch strings.ToLower(kind) {
+	case "vm", "vms", "virtualmachine", "virtualmachines":
+		return listVMs
+	case "vmi", "vmis", "virtualmachineinstance", "virtualmachineinstances":
+		return listVMIs
+	}
+	return nil
+}
+
+func listVMs(ctx context.Context, client kubevirt.Interface, namespace string) ([]string, error) {
+	list, err := client.KubevirtV1().VirtualMachines(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return namesOf(list.Items), nil
+}
+
+func listVMIs(ctx context.Context, client kubevirt.Interface, namespace string) ([]string, error) {
+	list, err := client.KubevirtV1().VirtualMachineInstances(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return namesOf(list.Items), nil
+}
+
+func listInstancetypes(ctx context.Context, client kubevirt.Interface, namespace string) ([]string, error) {
+	list, err := client.InstancetypeV1beta1().VirtualMachineInstancetypes(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return namesOf(list.Items), nil
+}
+
+func listClusterInstancetypes(ctx context.Context, client kubevirt.Interface, _ string) ([]string, error) {
+	list, err := client.InstancetypeV1beta1().VirtualMachineClusterInstancetypes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return namesOf(list.Items), nil
+}
+
+func listPreferences(ctx context.Context, client kubevirt.Interface, namespace string) ([]string, error) {
+	list, err := client.InstancetypeV1beta1().VirtualMachinePreferences(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return namesOf(list.Items), nil
+}
+
+func listClusterPreferences(ctx context.Context, client kubevirt.Interface, _ string) ([]string, error) {
+	list, err := client.InstancetypeV1beta1().VirtualMachineClusterPreferences().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return namesOf(list.Items), nil
+}
+
+func namesOf[T any, PT interface {
+	*T
+	GetName() string
+}](items []T) []string {
+	names := make([]string, 0, len(items))
+	for i := range items {
+		names = append(names, PT(&items[i]).GetName())
+	}
+	return names
+}
@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package completion_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Completion", func() {
+	const otherNamespace = "other"
+
+	var virtClient *kubevirtfake.Clientset
+
+	createVM := func(namespace, name string) {
+		vm := libvmi.NewVirtualMachine(libvmi.New(libvmi.WithNamespace(namespace), libvmi.WithName(name)))
+		_, err := virtClient.KubevirtV1().VirtualMachines(namespace).Create(context.Background(), vm, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	createVMI := func(namespace, name string) {
+		vmi := libvmi.New(libvmi.WithNamespace(namespace), libvmi.WithName(name))
+		_, err := virtClient.KubevirtV1().VirtualMachineInstances(namespace).Create(context.Background(), vmi, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	complete := func(args ...string) string {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut(append([]string{"__complete"}, args...)...)()
+		Expect(err).ToNot(HaveOccurred())
+		return string(out)
+	}
+
+	BeforeEach(func() {
+		virtClient = kubevirtfake.NewSimpleClientset()
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().GeneratedKubeVirtClient().Return(virtClient).AnyTimes()
+
+		createVM(metav1.NamespaceDefault, "vm-b")
+		createVM(metav1.NamespaceDefault, "vm-a")
+		createVM(otherNamespace, "vm-c")
+		createVMI(metav1.NamespaceDefault, "vmi-a")
+	})
+
+	DescribeTable("should complete VM names", func(args []string, expected string) {
+		Expect(complete(args...)).To(HavePrefix(expected))
+	},
+		Entry("of the current namespace", []string{"start", ""}, "vm-a\nvm-b\n:4\n"),
+		Entry("filtered by prefix", []string{"stop", "vm-b"}, "vm-b\n:4\n"),
+		Entry("of the selected namespace", []string{"restart", "-n", otherNamespace, ""}, "vm-c\n:4\n"),
+		Entry("only for the first argument", []string{"start", "vm-a", ""}, ":4\n"),
+	)
+
+	It("should complete VMI names", func() {
+		Expect(complete("console", "")).To(HavePrefix("vmi-a\n:4\n"))
+	})
+
+	DescribeTable("should complete resource type and name", func(args []string, expected string) {
+		Expect(complete(args...)).To(HavePrefix(expected))
+	},
+		Entry("with the resource type", []string{"pause", ""}, "vm\nvmi\n:4\n"),
+		Entry("with VM names", []string{"pause", "vm", ""}, "vm-a\nvm-b\n:4\n"),
+		Entry("with VMI names", []string{"unpause", "vmi", ""}, "vmi-a\n:4\n"),
+		Entry("with nothing for an unknown type", []string{"pause", "pod", ""}, ":4\n"),
+	)
+
+	DescribeTable("should complete targets", func(args []string, expected string) {
+		Expect(complete(args...)).To(HavePrefix(expected))
+	},
+		Entry("with the resource type", []string{"ssh", ""}, "vm/\nvmi/\n:6\n"),
+		Entry("with the resource type after the username", []string{"ssh", "jdoe@vmi"}, "jdoe@vmi/\n:6\n"),
+		Entry("with VM names", []string{"ssh", "vm/"}, "vm/vm-a\nvm/vm-b\n:4\n"),
+		Entry("with VMI names after the username", []string{"ssh", "jdoe@vmi/"}, "jdoe@vmi/vmi-a\n:4\n"),
+		Entry("with VM names of port-forward", []string{"port-forward", "vm/vm-a"}, "vm/vm-a\n:4\n"),
+	)
+
+	Context("instancetypes and preferences", func() {
+		BeforeEach(func() {
+			_, err := virtClient.InstancetypeV1beta1().VirtualMachineClusterInstancetypes().Create(context.Background(),
+				&instancetypev1beta1.VirtualMachineClusterInstancetype{ObjectMeta: metav1.ObjectMeta{Name: "u1.small"}}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = virtClient.InstancetypeV1beta1().VirtualMachineInstancetypes(metav1.NamespaceDefault).Create(context.Background(),
+				&instancetypev1beta1.VirtualMachineInstancetype{ObjectMeta: metav1.ObjectMeta{Name: "custom"}}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = virtClient.InstancetypeV1beta1().VirtualMachineClusterPreferences().Create(context.Background(),
+				&instancetypev1beta1.VirtualMachineClusterPreference{ObjectMeta: metav1.ObjectMeta{Name: "fedora"}}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = virtClient.InstancetypeV1beta1().VirtualMachinePreferences(otherNamespace).Create(context.Background(),
+				&instancetypev1beta1.VirtualMachinePreference{ObjectMeta: metav1.ObjectMeta{Name: "custom"}}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should complete instancetypes", func() {
+			Expect(complete("create", "vm", "--instancetype", "")).To(
+				HavePrefix("u1.small\nvirtualmachineinstancetype/custom\n:4\n"))
+		})
+
+		It("should complete preferences of the selected namespace", func() {
+			Expect(complete("create", "vm", "-n", otherNamespace, "--preference", "")).To(
+				HavePrefix("fedora\nvirtualmachinepreference/custom\n:4\n"))
+		})
+	})
+})
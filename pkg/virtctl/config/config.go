@@ -0,0 +1,182 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+)
+
+const (
+	// PathEnv overrides the path of the virtctl configuration file
+	PathEnv = "VIRTCTL_CONFIG"
+
+	contextFlag = "context"
+
+	// mutuallyExclusiveAnnotation is the annotation cobra stores flag groups
+	// created with MarkFlagsMutuallyExclusive in
+	mutuallyExclusiveAnnotation = "cobra_annotation_mutually_exclusive"
+)
+
+// Config is the virtctl configuration file, by default ~/.config/virtctl/config.yaml
+type Config struct {
+	// Contexts maps the names of kubeconfig contexts to the defaults used with them
+	Contexts map[string]Defaults `json:"contexts,omitempty"`
+}
+
+// Defaults are used for flags which are not set on the command line
+type Defaults struct {
+	// UploadProxyURL is the default for --uploadproxy-url
+	UploadProxyURL string `json:"uploadProxyURL,omitempty"`
+	// StorageClass is the default for --storage-class
+	StorageClass string `json:"storageClass,omitempty"`
+	// InsecureSkipTLSVerify is the default for --insecure
+	InsecureSkipTLSVerify *bool `json:"insecureSkipTLSVerify,omitempty"`
+	// Instancetype is the default for --instancetype and --default-instancetype
+	Instancetype string `json:"instancetype,omitempty"`
+	// Preference is the default for --preference and --default-preference
+	Preference string `json:"preference,omitempty"`
+}
+
+// flagValues returns the values of the defaults keyed by the flags they apply to
+func (d Defaults) flagValues() map[string]string {
+	values := map[string]string{}
+	add := func(value string, flags ...string) {
+		if value == "" {
+			return
+		}
+		for _, flag := range flags {
+			values[flag] = value
+		}
+	}
+	add(d.UploadProxyURL, "uploadproxy-url")
+	add(d.StorageClass, "storage-class")
+	if d.InsecureSkipTLSVerify != nil {
+		add(strconv.FormatBool(*d.InsecureSkipTLSVerify), "insecure")
+	}
+	add(d.Instancetype, "instancetype", "default-instancetype")
+	add(d.Preference, "preference", "default-preference")
+	return values
+}
+
+// Path returns the path of the virtctl configuration file
+func Path() (string, error) {
+	if path := os.Getenv(PathEnv); path != "" {
+		return path, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "virtctl", "config.yaml"), nil
+}
+
+// Load reads the configuration file at path, a missing file results in an empty configuration
+func Load(path string) (*Config, error) {
+	config := &Config{}
+	// #nosec G304 No risk for path injection, the file is read with the privileges of the virtctl user
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse virtctl configuration %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Apply sets the flags of cmd which were not set on the command line to the
+// defaults configured for the current kubeconfig context.
+// Defaults conflicting with flags set on the command line are skipped.
+func Apply(cmd *cobra.Command) error {
+	path, err := Path()
+	if err != nil {
+		// Without a home directory there is no configuration file
+		return nil
+	}
+	config, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if len(config.Contexts) == 0 {
+		return nil
+	}
+
+	contextName, err := currentContext(cmd)
+	if err != nil {
+		return err
+	}
+	defaults, exists := config.Contexts[contextName]
+	if !exists {
+		return nil
+	}
+
+	flags := cmd.Flags()
+	for name, value := range defaults.flagValues() {
+		flag := flags.Lookup(name)
+		if flag == nil || flag.Changed || exclusiveFlagChanged(flags, flag) {
+			continue
+		}
+		if err := flags.Set(name, value); err != nil {
+			return fmt.Errorf("failed to apply the default of --%s from %s: %w", name, path, err)
+		}
+	}
+	return nil
+}
+
+func currentContext(cmd *cobra.Command) (string, error) {
+	if contextName, _ := cmd.Flags().GetString(contextFlag); contextName != "" {
+		return contextName, nil
+	}
+	clientConfig, err := clientconfig.FromContext(cmd.Context())
+	if err != nil {
+		return "", err
+	}
+	rawConfig, err := clientConfig.RawConfig()
+	if err != nil {
+		return "", err
+	}
+	return rawConfig.CurrentContext, nil
+}
+
+// exclusiveFlagChanged reports whether a flag mutually exclusive with flag was set
+func exclusiveFlagChanged(flags *pflag.FlagSet, flag *pflag.Flag) bool {
+	for _, group := range flag.Annotations[mutuallyExclusiveAnnotation] {
+		for _, name := range strings.Split(group, " ") {
+			if other := flags.Lookup(name); other != nil && other.Changed {
+				return true
+			}
+		}
+	}
+	return false
+}
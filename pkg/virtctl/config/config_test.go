@@ -0,0 +1,185 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/cobra"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/config"
+)
+
+const (
+	kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: dev
+  context:
+    cluster: cluster
+- name: prod
+  context:
+    cluster: cluster
+current-context: dev
+`
+
+	virtctlConfig = `contexts:
+  dev:
+    uploadProxyURL: https://cdi-uploadproxy.dev.example.com
+    storageClass: local
+    insecureSkipTLSVerify: true
+    instancetype: u1.small
+  prod:
+    uploadProxyURL: https://cdi-uploadproxy.prod.example.com
+    preference: fedora
+`
+)
+
+var _ = Describe("Config", func() {
+	var (
+		dir            string
+		kubeconfigPath string
+	)
+
+	writeConfig := func(content string) {
+		path := filepath.Join(dir, "config.yaml")
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		GinkgoT().Setenv(config.PathEnv, path)
+	}
+
+	type flagValues struct {
+		uploadProxyURL string
+		storageClass   string
+		insecure       bool
+		instancetype   string
+		memory         string
+		preference     string
+	}
+
+	execute := func(args ...string) (*flagValues, error) {
+		values := &flagValues{}
+		rootCmd := &cobra.Command{
+			Use: "virtctl",
+			PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+				return config.Apply(cmd)
+			},
+		}
+		rootCmd.SetContext(clientconfig.NewContext(
+			context.Background(), kubecli.DefaultClientConfig(rootCmd.PersistentFlags()),
+		))
+		cmd := &cobra.Command{
+			Use:  "test",
+			RunE: func(_ *cobra.Command, _ []string) error { return nil },
+		}
+		cmd.Flags().StringVar(&values.uploadProxyURL, "uploadproxy-url", "", "")
+		cmd.Flags().StringVar(&values.storageClass, "storage-class", "", "")
+		cmd.Flags().BoolVar(&values.insecure, "insecure", false, "")
+		cmd.Flags().StringVar(&values.instancetype, "instancetype", "", "")
+		cmd.Flags().StringVar(&values.memory, "memory", "", "")
+		cmd.MarkFlagsMutuallyExclusive("instancetype", "memory")
+		cmd.Flags().StringVar(&values.preference, "default-preference", "", "")
+		rootCmd.AddCommand(cmd)
+
+		rootCmd.SetArgs(append([]string{"test", "--kubeconfig", kubeconfigPath}, args...))
+		return values, rootCmd.Execute()
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		kubeconfigPath = filepath.Join(dir, "kubeconfig")
+		Expect(os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0o600)).To(Succeed())
+	})
+
+	It("should apply the defaults of the current context", func() {
+		writeConfig(virtctlConfig)
+
+		values, err := execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*values).To(Equal(flagValues{
+			uploadProxyURL: "https://cdi-uploadproxy.dev.example.com",
+			storageClass:   "local",
+			insecure:       true,
+			instancetype:   "u1.small",
+		}))
+	})
+
+	It("should apply the defaults of the context selected with --context", func() {
+		writeConfig(virtctlConfig)
+
+		values, err := execute("--context", "prod")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*values).To(Equal(flagValues{
+			uploadProxyURL: "https://cdi-uploadproxy.prod.example.com",
+			preference:     "fedora",
+		}))
+	})
+
+	It("should prefer flags set on the command line", func() {
+		writeConfig(virtctlConfig)
+
+		values, err := execute("--storage-class", "fast", "--insecure=false")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(values.storageClass).To(Equal("fast"))
+		Expect(values.insecure).To(BeFalse())
+	})
+
+	It("should skip defaults conflicting with flags set on the command line", func() {
+		writeConfig(virtctlConfig)
+
+		values, err := execute("--memory", "1Gi")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(values.instancetype).To(BeEmpty())
+		Expect(values.memory).To(Equal("1Gi"))
+	})
+
+	It("should apply nothing without a configuration for the context", func() {
+		writeConfig(virtctlConfig)
+
+		values, err := execute("--context", "other")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*values).To(Equal(flagValues{}))
+	})
+
+	It("should ignore a missing configuration file", func() {
+		GinkgoT().Setenv(config.PathEnv, filepath.Join(dir, "nonexistent.yaml"))
+
+		values, err := execute()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*values).To(Equal(flagValues{}))
+	})
+
+	It("should fail with unknown fields in the configuration file", func() {
+		writeConfig("contexts:\n  dev:\n    storageClassName: local\n")
+
+		_, err := execute()
+		Expect(err).To(MatchError(ContainSubstring("failed to parse virtctl configuration")))
+	})
+})
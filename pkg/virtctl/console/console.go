@@ -20,6 +20,7 @@
 package console
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -34,26 +35,37 @@ import (
 	kvcorev1 "kubevirt.io/client-go/kubevirt/typed/core/v1"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
-const defaultTimeoutMinutes = 5
+const (
+	defaultTimeoutMinutes = 5
+	reconnectInterval     = time.Second
+)
 
 type consoleCommand struct {
-	timeout int
+	timeout     int
+	reconnect   bool
+	idleTimeout time.Duration
 }
 
 func NewCommand() *cobra.Command {
 	c := consoleCommand{}
 	cmd := &cobra.Command{
-		Use:     "console (VMI)",
-		Short:   "Connect to a console of a virtual machine instance.",
-		Example: usage(),
-		Args:    cobra.ExactArgs(1),
-		RunE:    c.run,
+		Use:               "console (VMI)",
+		Short:             "Connect to a console of a virtual machine instance.",
+		Example:           usage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		RunE:              c.run,
 	}
 	cmd.Flags().IntVar(&c.timeout, "timeout", defaultTimeoutMinutes,
 		"The number of minutes to wait for the virtual machine instance to be ready.")
+	cmd.Flags().BoolVar(&c.reconnect, "reconnect", false,
+		"Transparently reconnect to the console when the connection is lost, e.g. during a migration or a virt-api restart.")
+	cmd.Flags().DurationVar(&c.idleTimeout, "idle-timeout", 0,
+		"Disconnect from the console when nothing was typed for the given duration. Zero means no idle timeout.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -62,7 +74,9 @@ func usage() string {
 	usage := `  # Connect to the console on VirtualMachineInstance 'myvmi':
   {{ProgramName}} console myvmi
   # Configure one minute timeout (default 5 minutes)
-  {{ProgramName}} console --timeout=1 myvmi`
+  {{ProgramName}} console --timeout=1 myvmi
+  # Keep the console session across migrations and disconnect after 30 idle minutes
+  {{ProgramName}} console --reconnect --idle-timeout=30m myvmi`
 
 	return usage
 }
@@ -70,6 +84,10 @@ func usage() string {
 func (c *consoleCommand) run(cmd *cobra.Command, args []string) error {
 	vmi := args[0]
 
+	if c.idleTimeout < 0 {
+		return fmt.Errorf("idle timeout must not be negative, got %s", c.idleTimeout)
+	}
+
 	client, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("cannot obtain KubeVirt client: %v", err)
@@ -79,32 +97,62 @@ func (c *consoleCommand) run(cmd *cobra.Command, args []string) error {
 }
 
 func (c *consoleCommand) handleConsoleConnection(client kubecli.KubevirtClient, namespace, vmi string) error {
-	// in -> stdinWriter | stdinReader -> console
+	// in -> stdinWriter | stdinReader -> inputSwitch -> console
 	// out <- stdoutReader | stdoutWriter <- console
 	// Wait until the virtual machine is in running phase, user interrupt or timeout
 	stdinReader, stdinWriter := io.Pipe()
 	stdoutReader, stdoutWriter := io.Pipe()
 
-	resChan := make(chan error)
+	resChan := make(chan error, 1)
 	runningChan := make(chan error)
+	done := make(chan struct{})
+	defer close(done)
 	waitInterrupt := make(chan os.Signal, 1)
 	signal.Notify(waitInterrupt, os.Interrupt)
 
-	go func() {
-		con, err := client.VirtualMachineInstance(namespace).SerialConsole(vmi,
+	activity := make(chan struct{}, 1)
+	input := newInputSwitch(stdinReader, activity)
+
+	connect := func() (kvcorev1.StreamInterface, error) {
+		return client.VirtualMachineInstance(namespace).SerialConsole(vmi,
 			&kvcorev1.SerialConsoleOptions{ConnectionTimeout: time.Duration(c.timeout) * time.Minute})
-		runningChan <- err
+	}
 
+	go func() {
+		con, err := connect()
+		runningChan <- err
 		if err != nil {
 			return
 		}
 
-		resChan <- con.Stream(kvcorev1.StreamOptions{
-			In:  stdinReader,
-			Out: stdoutWriter,
-		})
+		for {
+			err = con.Stream(kvcorev1.StreamOptions{
+				In:  input.connect(),
+				Out: stdoutWriter,
+			})
+			input.disconnect()
+			if err == nil || !c.reconnect {
+				break
+			}
+
+			fmt.Fprint(os.Stderr, "\r\nConsole connection lost, reconnecting...\r\n")
+			con, err = c.reconnectConsole(connect, done)
+			if err != nil {
+				break
+			}
+			fmt.Fprintf(os.Stderr, "Reconnected to %s console.\r\n", vmi)
+		}
+
+		select {
+		case resChan <- err:
+		default:
+		}
 	}()
 
+	if c.idleTimeout > 0 {
+		go watchIdle(c.idleTimeout, activity, done, resChan)
+	}
+
 	select {
 	case <-waitInterrupt:
 		// Make a new line in the terminal
@@ -119,6 +167,10 @@ func (c *consoleCommand) handleConsoleConnection(client kubecli.KubevirtClient,
 		fmt.Sprintf("Successfully connected to %s console. Press Ctrl+] or Ctrl+5 to exit console.\n", vmi),
 		resChan)
 	if err != nil {
+		if errors.Is(err, errIdleTimeout) {
+			fmt.Fprintf(os.Stderr, "\nYou were disconnected from the console after being idle for %s.\n", c.idleTimeout)
+			return nil
+		}
 		if e, ok := err.(*websocket.CloseError); ok && e.Code == websocket.CloseAbnormalClosure {
 			fmt.Fprint(os.Stderr, "\n"+
 				"You were disconnected from the console. This could be caused by one of the following:"+
@@ -131,6 +183,27 @@ func (c *consoleCommand) handleConsoleConnection(client kubecli.KubevirtClient,
 	return nil
 }
 
+// reconnectConsole retries to connect to the console until it succeeds, the connection timeout expires
+// or the session is closed
+func (c *consoleCommand) reconnectConsole(connect func() (kvcorev1.StreamInterface, error), done <-chan struct{}) (kvcorev1.StreamInterface, error) {
+	deadline := time.Now().Add(time.Duration(c.timeout) * time.Minute)
+	for {
+		con, err := connect()
+		if err == nil {
+			return con, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("failed to reconnect to the console: %v", err)
+		}
+
+		select {
+		case <-done:
+			return nil, err
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
 // Attach attaches stdin and stdout to the console
 // in -> stdinWriter | stdinReader -> console
 // out <- stdoutReader | stdoutWriter <- console
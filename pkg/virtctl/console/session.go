@@ -0,0 +1,124 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package console
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+const inputBufferSize = 1024
+
+var errIdleTimeout = errors.New("console session reached the idle timeout")
+
+// inputSwitch forwards the user input to the current console connection. Every connection reads
+// from its own pipe, so that a connection which is gone doesn't swallow input meant for the next one.
+type inputSwitch struct {
+	lock     sync.Mutex
+	current  *io.PipeWriter
+	err      error
+	activity chan<- struct{}
+}
+
+func newInputSwitch(in io.Reader, activity chan<- struct{}) *inputSwitch {
+	s := &inputSwitch{activity: activity}
+	go s.forward(in)
+	return s
+}
+
+// connect returns the input of a new console connection
+func (s *inputSwitch) connect() io.Reader {
+	reader, writer := io.Pipe()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		writer.CloseWithError(s.err)
+	} else {
+		s.current = writer
+	}
+	return reader
+}
+
+// disconnect ends the input of the current console connection, input received until
+// the next connection is dropped
+func (s *inputSwitch) disconnect() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.current != nil {
+		s.current.Close()
+		s.current = nil
+	}
+}
+
+func (s *inputSwitch) forward(in io.Reader) {
+	buf := make([]byte, inputBufferSize)
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			notifyActivity(s.activity)
+			s.lock.Lock()
+			current := s.current
+			s.lock.Unlock()
+			if current != nil {
+				// The write fails if the connection is lost meanwhile, the input is dropped then
+				_, _ = current.Write(buf[:n])
+			}
+		}
+		if err != nil {
+			s.lock.Lock()
+			s.err = err
+			if s.current != nil {
+				s.current.CloseWithError(err)
+			}
+			s.lock.Unlock()
+			return
+		}
+	}
+}
+
+func notifyActivity(activity chan<- struct{}) {
+	select {
+	case activity <- struct{}{}:
+	default:
+	}
+}
+
+// watchIdle reports errIdleTimeout once the user didn't type anything for the given timeout
+func watchIdle(timeout time.Duration, activity <-chan struct{}, done <-chan struct{}, resChan chan<- error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-activity:
+			timer.Reset(timeout)
+		case <-timer.C:
+			select {
+			case resChan <- errIdleTimeout:
+			default:
+			}
+			return
+		}
+	}
+}
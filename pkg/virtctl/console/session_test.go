@@ -0,0 +1,110 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package console
+
+import (
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Console session", func() {
+	Context("inputSwitch", func() {
+		var (
+			stdinReader *io.PipeReader
+			stdinWriter *io.PipeWriter
+			activity    chan struct{}
+			input       *inputSwitch
+		)
+
+		readInput := func(reader io.Reader) string {
+			buf := make([]byte, inputBufferSize)
+			n, err := reader.Read(buf)
+			Expect(err).ToNot(HaveOccurred())
+			return string(buf[:n])
+		}
+
+		BeforeEach(func() {
+			stdinReader, stdinWriter = io.Pipe()
+			activity = make(chan struct{}, 1)
+			input = newInputSwitch(stdinReader, activity)
+			DeferCleanup(stdinWriter.Close)
+		})
+
+		It("should forward the input to the current connection only", func() {
+			first := input.connect()
+			go stdinWriter.Write([]byte("ls"))
+			Expect(readInput(first)).To(Equal("ls"))
+			Eventually(activity).Should(Receive())
+
+			input.disconnect()
+			_, err := first.Read(make([]byte, inputBufferSize))
+			Expect(err).To(MatchError(io.EOF))
+
+			second := input.connect()
+			go stdinWriter.Write([]byte("pwd"))
+			Expect(readInput(second)).To(Equal("pwd"))
+		})
+
+		It("should end the connections once the input is closed", func() {
+			current := input.connect()
+			Expect(stdinWriter.Close()).To(Succeed())
+			_, err := current.Read(make([]byte, inputBufferSize))
+			Expect(err).To(MatchError(io.EOF))
+
+			input.disconnect()
+			_, err = input.connect().Read(make([]byte, inputBufferSize))
+			Expect(err).To(MatchError(io.EOF))
+		})
+	})
+
+	Context("watchIdle", func() {
+		const idleTimeout = 50 * time.Millisecond
+
+		var (
+			activity chan struct{}
+			done     chan struct{}
+			resChan  chan error
+		)
+
+		BeforeEach(func() {
+			activity = make(chan struct{}, 1)
+			done = make(chan struct{})
+			resChan = make(chan error, 1)
+		})
+
+		It("should report the idle timeout", func() {
+			go watchIdle(idleTimeout, activity, done, resChan)
+			Eventually(resChan).Should(Receive(MatchError(errIdleTimeout)))
+		})
+
+		It("should not report the idle timeout while there is activity", func() {
+			go watchIdle(idleTimeout, activity, done, resChan)
+			for i := 0; i < 5; i++ {
+				time.Sleep(idleTimeout / 2)
+				notifyActivity(activity)
+			}
+			Expect(resChan).ToNot(Receive())
+			close(done)
+		})
+	})
+})
@@ -0,0 +1,233 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package crashdump
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+	"kubevirt.io/kubevirt/pkg/virtctl/vmexport"
+)
+
+const (
+	COMMAND_CRASH_DUMP = "crash-dump"
+
+	waitArg    = "wait"
+	timeoutArg = "timeout"
+	exportArg  = "export"
+
+	defaultTimeout   = 10 * time.Minute
+	windowsGuestOSID = "mswindows"
+	exportNameSuffix = "-crash-dump"
+)
+
+// PollInterval is the interval in which the VMI is checked while waiting for the crash dump
+var PollInterval = 2 * time.Second
+
+type command struct {
+	wait    bool
+	timeout time.Duration
+	export  bool
+}
+
+func NewCommand() *cobra.Command {
+	c := command{}
+	cmd := &cobra.Command{
+		Use:   "crash-dump (VMI)",
+		Short: "Crash the guest of a virtual machine instance with an NMI to make it write a memory dump.",
+		Long: `Inject a non-maskable interrupt (NMI) into a virtual machine instance. Guests configured accordingly crash and write a memory dump.
+
+Windows guests need the NMICrashDump and CrashDumpEnabled values of the
+HKLM\SYSTEM\CurrentControlSet\Control\CrashControl registry key to be set.
+If the guest agent is connected, these values are validated before the NMI is
+injected. Windows writes the dump to %SystemRoot%\MEMORY.DMP while it boots
+after the crash, which is detected by the guest agent connecting again.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		Example:           usage(),
+		RunE:              c.run,
+	}
+	cmd.Flags().BoolVar(&c.wait, waitArg, true, "Wait until the guest rebooted after the crash and wrote the memory dump.")
+	cmd.Flags().DurationVar(&c.timeout, timeoutArg, defaultTimeout, "The maximum time to wait for the guest to write the memory dump.")
+	cmd.Flags().BoolVar(&c.export, exportArg, false, "Stop the VM once the memory dump was written and create a VirtualMachineExport of its volumes to download the dump.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	requirements.Subresources(cmd, "virtualmachineinstances/injectnmi")
+	return cmd
+}
+
+func usage() string {
+	return `  # Crash the guest of the virtual machine instance 'myvmi' and wait until it wrote the memory dump:
+  {{ProgramName}} crash-dump myvmi
+
+  # Crash the guest without waiting for the memory dump:
+  {{ProgramName}} crash-dump myvmi --wait=false
+
+  # Crash the guest and export the volumes of the stopped VM once the memory dump was written:
+  {{ProgramName}} crash-dump myvmi --export`
+}
+
+func (c *command) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if c.export && !c.wait {
+		return result.NewUsageError(fmt.Errorf("--%s can only be used with --%s", exportArg, waitArg))
+	}
+	if c.timeout <= 0 {
+		return result.NewUsageError(fmt.Errorf("--%s must be greater than zero", timeoutArg))
+	}
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	vmi, err := virtClient.VirtualMachineInstance(namespace).Get(cmd.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting VirtualMachineInstance %s: %w", name, err)
+	}
+	if !vmi.IsRunning() {
+		return fmt.Errorf("VMI %s is not running", name)
+	}
+	if c.export && !isOwnedByVM(vmi) {
+		return fmt.Errorf("--%s requires VMI %s to be owned by a VirtualMachine", exportArg, name)
+	}
+	if !agentConnected(vmi) {
+		cmd.Printf("The guest agent of VMI %s is not connected, the crash dump prerequisites of the guest cannot be validated\n", name)
+	}
+
+	if err := virtClient.VirtualMachineInstance(namespace).InjectNMI(cmd.Context(), name); err != nil {
+		return fmt.Errorf("error injecting an NMI into VirtualMachineInstance %s: %w", name, err)
+	}
+	result.RecordChange(cmd.Context(), result.Change{Action: "InjectNMI", Kind: v1.VirtualMachineInstanceGroupVersionKind.Kind, Namespace: namespace, Name: name})
+	cmd.Printf("NMI injected into VMI %s\n", name)
+
+	if !c.wait {
+		return nil
+	}
+	cmd.Printf("Waiting for the guest to write the memory dump\n")
+	if err := waitForCrashDump(virtClient, namespace, vmi, c.timeout); err != nil {
+		return err
+	}
+	if vmi.Status.GuestOSInfo.ID == windowsGuestOSID {
+		cmd.Printf("The guest wrote the memory dump, it is stored at %%SystemRoot%%\\MEMORY.DMP by default\n")
+	} else {
+		cmd.Printf("The guest rebooted after the crash\n")
+	}
+
+	if c.export {
+		return exportVolumes(cmd, virtClient, namespace, vmi)
+	}
+	return nil
+}
+
+// waitForCrashDump waits until the guest rebooted after the crash. A guest
+// writes the memory dump while it boots, before the guest agent is started
+// again, so the dump is written once the agent disconnected and reconnected.
+func waitForCrashDump(virtClient kubecli.KubevirtClient, namespace string, vmi *v1.VirtualMachineInstance, timeout time.Duration) error {
+	crashed := !agentConnected(vmi)
+	err := virtwait.PollImmediately(PollInterval, timeout, func(ctx context.Context) (bool, error) {
+		current, err := virtClient.VirtualMachineInstance(namespace).Get(ctx, vmi.Name, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return false, fmt.Errorf("error getting VirtualMachineInstance %s: %w", vmi.Name, err)
+		}
+		if err != nil || current.UID != vmi.UID || current.IsFinal() {
+			return false, fmt.Errorf("VMI %s stopped before the guest wrote the memory dump, the guest writes it the next time it boots", vmi.Name)
+		}
+		if !agentConnected(current) {
+			crashed = true
+			return false, nil
+		}
+		return crashed, nil
+	})
+	if err != nil {
+		if wait.Interrupted(err) {
+			blocker := "the guest agent is still connected, the guest did not crash"
+			if crashed {
+				blocker = "the guest agent did not connect again after the crash"
+			}
+			return fmt.Errorf("timed out waiting for VMI %s to write the memory dump: %s", vmi.Name, blocker)
+		}
+		return err
+	}
+	return nil
+}
+
+// exportVolumes stops the VM owning the VMI and exports its volumes, which
+// contain the memory dump.
+func exportVolumes(cmd *cobra.Command, virtClient kubecli.KubevirtClient, namespace string, vmi *v1.VirtualMachineInstance) error {
+	vmName := metav1.GetControllerOf(vmi).Name
+	if err := virtClient.VirtualMachine(namespace).Stop(cmd.Context(), vmName, &v1.StopOptions{}); err != nil {
+		return fmt.Errorf("error stopping VirtualMachine %s: %w", vmName, err)
+	}
+	result.RecordChange(cmd.Context(), result.Change{Action: "Stop", Kind: v1.VirtualMachineGroupVersionKind.Kind, Namespace: namespace, Name: vmName})
+	cmd.Printf("VM %s was scheduled to stop\n", vmName)
+
+	exportName := vmName + exportNameSuffix
+	vmeInfo := &vmexport.VMExportInfo{
+		Name:      exportName,
+		Namespace: namespace,
+		ExportSource: k8sv1.TypedLocalObjectReference{
+			APIGroup: &v1.SchemeGroupVersion.Group,
+			Kind:     "VirtualMachine",
+			Name:     vmName,
+		},
+	}
+	if err := vmexport.CreateVirtualMachineExport(cmd.Context(), virtClient, vmeInfo); err != nil {
+		return fmt.Errorf("error exporting the volumes of VirtualMachine %s: %w", vmName, err)
+	}
+
+	volumes := make([]string, 0, len(vmi.Spec.Volumes))
+	for _, volume := range vmi.Spec.Volumes {
+		volumes = append(volumes, volume.Name)
+	}
+	cmd.Printf("Download the volume containing the memory dump once the VM stopped with:\n")
+	cmd.Printf("  virtctl vmexport download %s --volume=<volume> --output=<file>\n", exportName)
+	cmd.Printf("Volumes of VM %s: %s\n", vmName, strings.Join(volumes, ", "))
+	return nil
+}
+
+func isOwnedByVM(vmi *v1.VirtualMachineInstance) bool {
+	owner := metav1.GetControllerOf(vmi)
+	return owner != nil && owner.Kind == v1.VirtualMachineGroupVersionKind.Kind
+}
+
+func agentConnected(vmi *v1.VirtualMachineInstance) bool {
+	for _, condition := range vmi.Status.Conditions {
+		if condition.Type == v1.VirtualMachineInstanceAgentConnected {
+			return condition.Status == k8sv1.ConditionTrue
+		}
+	}
+	return false
+}
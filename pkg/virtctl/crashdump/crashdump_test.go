@@ -0,0 +1,221 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package crashdump_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakek8sclient "k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	libvmistatus "kubevirt.io/kubevirt/pkg/libvmi/status"
+	"kubevirt.io/kubevirt/pkg/virtctl/crashdump"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Crash dump", func() {
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+
+		pollInterval := crashdump.PollInterval
+		crashdump.PollInterval = 10 * time.Millisecond
+		DeferCleanup(func() {
+			crashdump.PollInterval = pollInterval
+		})
+	})
+
+	newVMI := func(agentConnected bool, opts ...libvmi.Option) *v1.VirtualMachineInstance {
+		agentStatus := k8sv1.ConditionFalse
+		if agentConnected {
+			agentStatus = k8sv1.ConditionTrue
+		}
+		opts = append([]libvmi.Option{
+			libvmi.WithName("testvmi"),
+			libvmi.WithUID("uid"),
+			libvmistatus.WithStatus(libvmistatus.New(
+				libvmistatus.WithPhase(v1.Running),
+				libvmistatus.WithCondition(v1.VirtualMachineInstanceCondition{
+					Type:   v1.VirtualMachineInstanceAgentConnected,
+					Status: agentStatus,
+				}),
+			)),
+		}, opts...)
+		return libvmi.New(opts...)
+	}
+
+	It("should fail with missing input parameters", func() {
+		cmd := testing.NewRepeatableVirtctlCommand(crashdump.COMMAND_CRASH_DUMP)
+		Expect(cmd()).To(HaveOccurred())
+	})
+
+	It("should fail if --export is used without --wait", func() {
+		cmd := testing.NewRepeatableVirtctlCommand(crashdump.COMMAND_CRASH_DUMP, "testvmi", "--export", "--wait=false")
+		Expect(cmd()).To(MatchError(ContainSubstring("--export can only be used with --wait")))
+	})
+
+	It("should fail if the VMI is not running", func() {
+		vmi := libvmi.New()
+		vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(vmi, nil)
+
+		cmd := testing.NewRepeatableVirtctlCommand(crashdump.COMMAND_CRASH_DUMP, vmi.Name)
+		Expect(cmd()).To(MatchError(ContainSubstring("is not running")))
+	})
+
+	It("should inject an NMI without waiting", func() {
+		vmi := newVMI(true)
+		vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(vmi, nil)
+		vmiInterface.EXPECT().InjectNMI(gomock.Any(), vmi.Name).Return(nil)
+
+		cmd := testing.NewRepeatableVirtctlCommandWithOut(crashdump.COMMAND_CRASH_DUMP, vmi.Name, "--wait=false")
+		out, err := cmd()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("NMI injected into VMI " + vmi.Name))
+	})
+
+	It("should warn that the prerequisites cannot be validated without a connected guest agent", func() {
+		vmi := newVMI(false)
+		vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(vmi, nil)
+		vmiInterface.EXPECT().InjectNMI(gomock.Any(), vmi.Name).Return(nil)
+
+		cmd := testing.NewRepeatableVirtctlCommandWithOut(crashdump.COMMAND_CRASH_DUMP, vmi.Name, "--wait=false")
+		out, err := cmd()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("prerequisites of the guest cannot be validated"))
+	})
+
+	It("should wait for the guest agent to disconnect and connect again", func() {
+		vmi := newVMI(true)
+		gomock.InOrder(
+			vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(vmi, nil),
+			vmiInterface.EXPECT().InjectNMI(gomock.Any(), vmi.Name).Return(nil),
+			vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(vmi, nil),
+			vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(newVMI(false), nil),
+			vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(newVMI(true), nil),
+		)
+
+		cmd := testing.NewRepeatableVirtctlCommandWithOut(crashdump.COMMAND_CRASH_DUMP, vmi.Name)
+		out, err := cmd()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("The guest rebooted after the crash"))
+	})
+
+	It("should point to the memory dump of Windows guests", func() {
+		vmi := newVMI(false)
+		vmi.Status.GuestOSInfo.ID = "mswindows"
+		gomock.InOrder(
+			vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(vmi, nil),
+			vmiInterface.EXPECT().InjectNMI(gomock.Any(), vmi.Name).Return(nil),
+			vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(newVMI(true), nil),
+		)
+
+		cmd := testing.NewRepeatableVirtctlCommandWithOut(crashdump.COMMAND_CRASH_DUMP, vmi.Name)
+		out, err := cmd()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring(`%SystemRoot%\MEMORY.DMP`))
+	})
+
+	DescribeTable("should fail if the VMI stops before the guest wrote the memory dump", func(current *v1.VirtualMachineInstance, err error) {
+		vmi := newVMI(true)
+		gomock.InOrder(
+			vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(vmi, nil),
+			vmiInterface.EXPECT().InjectNMI(gomock.Any(), vmi.Name).Return(nil),
+			vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(current, err),
+		)
+
+		cmd := testing.NewRepeatableVirtctlCommand(crashdump.COMMAND_CRASH_DUMP, vmi.Name)
+		Expect(cmd()).To(MatchError(ContainSubstring("stopped before the guest wrote the memory dump")))
+	},
+		Entry("when it failed", newVMI(false, libvmistatus.WithStatus(libvmistatus.New(libvmistatus.WithPhase(v1.Failed)))), nil),
+		Entry("when it was replaced", newVMI(false, libvmi.WithUID("other")), nil),
+		Entry("when it was deleted", nil, k8serrors.NewNotFound(schema.GroupResource{}, "testvmi")),
+	)
+
+	It("should time out if the guest does not crash", func() {
+		vmi := newVMI(true)
+		vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(vmi, nil).MinTimes(2)
+		vmiInterface.EXPECT().InjectNMI(gomock.Any(), vmi.Name).Return(nil)
+
+		cmd := testing.NewRepeatableVirtctlCommand(crashdump.COMMAND_CRASH_DUMP, vmi.Name, "--timeout=50ms")
+		Expect(cmd()).To(MatchError(ContainSubstring("the guest did not crash")))
+	})
+
+	Context("with --export", func() {
+		var virtClient *kubevirtfake.Clientset
+
+		BeforeEach(func() {
+			virtClient = kubevirtfake.NewSimpleClientset()
+			kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(fakek8sclient.NewSimpleClientset().CoreV1()).AnyTimes()
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineExport(metav1.NamespaceDefault).
+				Return(virtClient.ExportV1beta1().VirtualMachineExports(metav1.NamespaceDefault)).AnyTimes()
+		})
+
+		It("should fail if the VMI is not owned by a VM", func() {
+			vmi := newVMI(true)
+			vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(vmi, nil)
+
+			cmd := testing.NewRepeatableVirtctlCommand(crashdump.COMMAND_CRASH_DUMP, vmi.Name, "--export")
+			Expect(cmd()).To(MatchError(ContainSubstring("requires VMI testvmi to be owned by a VirtualMachine")))
+		})
+
+		It("should stop the VM and export its volumes once the memory dump was written", func() {
+			vm := libvmi.NewVirtualMachine(libvmi.New(libvmi.WithName("testvmi")))
+			vmi := newVMI(false, libvmi.WithDataVolume("disk0", "dv"))
+			vmi.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)}
+
+			vmInterface := kubecli.NewMockVirtualMachineInterface(gomock.NewController(GinkgoT()))
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(metav1.NamespaceDefault).Return(vmInterface)
+			gomock.InOrder(
+				vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(vmi, nil),
+				vmiInterface.EXPECT().InjectNMI(gomock.Any(), vmi.Name).Return(nil),
+				vmiInterface.EXPECT().Get(gomock.Any(), vmi.Name, gomock.Any()).Return(newVMI(true), nil),
+				vmInterface.EXPECT().Stop(gomock.Any(), vm.Name, &v1.StopOptions{}).Return(nil),
+			)
+
+			cmd := testing.NewRepeatableVirtctlCommandWithOut(crashdump.COMMAND_CRASH_DUMP, vmi.Name, "--export")
+			out, err := cmd()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("virtctl vmexport download testvmi-crash-dump --volume=<volume>"))
+			Expect(string(out)).To(ContainSubstring("Volumes of VM testvmi: disk0"))
+
+			vmExport, err := virtClient.ExportV1beta1().VirtualMachineExports(metav1.NamespaceDefault).Get(context.Background(), "testvmi-crash-dump", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmExport.Spec.Source.Kind).To(Equal("VirtualMachine"))
+			Expect(vmExport.Spec.Source.Name).To(Equal(vmi.Name))
+		})
+	})
+})
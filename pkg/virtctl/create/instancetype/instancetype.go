@@ -21,6 +21,7 @@ package instancetype
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -43,6 +44,9 @@ const (
 	IOThreadsPolicyFlag = "iothreadspolicy"
 	NameFlag            = "name"
 	NamespacedFlag      = "namespaced"
+	FromVMIFlag         = "from-vmi"
+	ObserveFlag         = "observe"
+	SampleIntervalFlag  = "sample-interval"
 
 	nameErr       = "name must be specified"
 	deviceNameErr = "deviceName must be specified"
@@ -57,6 +61,9 @@ type createInstancetype struct {
 	hostDevices     []string
 	ioThreadsPolicy string
 	namespaced      bool
+	fromVMI         string
+	observe         time.Duration
+	sampleInterval  time.Duration
 }
 
 type gpu struct {
@@ -70,7 +77,10 @@ type hostDevice struct {
 }
 
 func NewCommand() *cobra.Command {
-	c := createInstancetype{}
+	c := createInstancetype{
+		observe:        defaultObserve,
+		sampleInterval: defaultSampleInterval,
+	}
 	cmd := &cobra.Command{
 		Use:     "instancetype",
 		Short:   "Create VirtualMachineInstancetype or VirtualMachineClusterInstancetype manifest.",
@@ -84,13 +94,14 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&c.namespaced, NamespacedFlag, false, "Specify if VirtualMachineInstancetype should be created. By default VirtualMachineClusterInstancetype is created.")
 	cmd.Flags().StringArrayVar(&c.gpus, GPUFlag, c.gpus, "Specify the list of vGPUs to passthrough. Can be provided multiple times.")
 	cmd.Flags().StringArrayVar(&c.hostDevices, HostDeviceFlag, c.hostDevices, "Specify list of HostDevices to passthrough. Can be provided multiple times.")
+	cmd.Flags().StringVar(&c.fromVMI, FromVMIFlag, c.fromVMI, "Specify the name of a running VirtualMachineInstance whose observed CPU and memory usage is used to size the Instancetype.")
+	cmd.Flags().DurationVar(&c.observe, ObserveFlag, c.observe, "Specify for how long the usage of the VirtualMachineInstance given with --from-vmi is observed.")
+	cmd.Flags().DurationVar(&c.sampleInterval, SampleIntervalFlag, c.sampleInterval, "Specify the interval in which the usage of the VirtualMachineInstance given with --from-vmi is sampled.")
 
-	if err := cmd.MarkFlagRequired(CPUFlag); err != nil {
-		panic(err)
-	}
-	if err := cmd.MarkFlagRequired(MemoryFlag); err != nil {
-		panic(err)
-	}
+	cmd.MarkFlagsOneRequired(CPUFlag, FromVMIFlag)
+	cmd.MarkFlagsOneRequired(MemoryFlag, FromVMIFlag)
+	cmd.MarkFlagsMutuallyExclusive(CPUFlag, FromVMIFlag)
+	cmd.MarkFlagsMutuallyExclusive(MemoryFlag, FromVMIFlag)
 
 	return cmd
 }
@@ -195,7 +206,10 @@ func (c *createInstancetype) usage() string {
   {{ProgramName}} create instancetype --namespaced --name my-instancetype --cpu 2 --memory 256Mi
   
   # Create a manifest for a ClusterInstancetype and use it to create a resource with kubectl
-  {{ProgramName}} create instancetype --cpu 2 --memory 256Mi | kubectl create -f -`
+  {{ProgramName}} create instancetype --cpu 2 --memory 256Mi | kubectl create -f -
+
+  # Create a manifest for an Instancetype sized after the usage of the running VMI 'my-vmi' observed for 10 minutes:
+  {{ProgramName}} create instancetype --namespaced --from-vmi my-vmi --observe 10m`
 }
 
 func (c *createInstancetype) newInstancetype() *instancetypev1beta1.VirtualMachineInstancetype {
@@ -273,6 +287,12 @@ func (c *createInstancetype) run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	if c.fromVMI != "" {
+		if err := c.sizeFromVMI(cmd); err != nil {
+			return err
+		}
+	}
+
 	if err := c.validateFlags(); err != nil {
 		return err
 	}
@@ -22,9 +22,11 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8sv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +36,7 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/api/instancetype"
 	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	"kubevirt.io/client-go/kubecli"
 	generatedscheme "kubevirt.io/client-go/kubevirt/scheme"
 
 	"kubevirt.io/kubevirt/pkg/instancetype/webhooks"
@@ -52,7 +55,7 @@ var _ = Describe("create instancetype", func() {
 
 		DescribeTable("because of required cpu and memory", func(extraArgs ...string) {
 			_, err := runCmd(extraArgs...)
-			Expect(err).To(MatchError(`required flag(s) "cpu", "memory" not set`))
+			Expect(err).To(MatchError("at least one of the flags in the group [cpu from-vmi] is required"))
 		},
 			Entry("VirtualMachineInstancetype", setFlag(NamespacedFlag, "true")),
 			Entry("VirtualMachineClusterInstancetype"),
@@ -74,6 +77,14 @@ var _ = Describe("create instancetype", func() {
 			Entry("VirtualMachineClusterInstancetype invalid memory value", "2", "256My", "quantities must match the regular expression"),
 		)
 
+		DescribeTable("because of cpu or memory combined with from-vmi", func(extraArgs ...string) {
+			_, err := runCmd(append(extraArgs, setFlag(FromVMIFlag, "my-vmi"))...)
+			Expect(err).To(MatchError(ContainSubstring("were all set")))
+		},
+			Entry("with cpu", setFlag(CPUFlag, "2")),
+			Entry("with memory", setFlag(MemoryFlag, "256Mi")),
+		)
+
 		DescribeTable("with invalid arguments", func(errMsg string, extraArgs ...string) {
 			args := append([]string{
 				setFlag(CPUFlag, "1"),
@@ -199,6 +210,112 @@ var _ = Describe("create instancetype", func() {
 		)
 	})
 
+	Context("from observed VMI usage", func() {
+		const vmiName = "my-vmi"
+
+		var vmiClient *kubecli.MockVirtualMachineInstanceInterface
+
+		BeforeEach(func() {
+			ctrl := gomock.NewController(GinkgoT())
+			kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+			kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+			vmiClient = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8sv1.NamespaceDefault).Return(vmiClient).AnyTimes()
+		})
+
+		runObserveCmd := func(extraArgs ...string) ([]byte, error) {
+			args := append([]string{
+				setFlag(FromVMIFlag, vmiName),
+				setFlag(ObserveFlag, "2s"),
+				setFlag(SampleIntervalFlag, "1s"),
+			}, extraArgs...)
+			return runCmd(args...)
+		}
+
+		expectUsageSamples := func(samples ...v1.VirtualMachineInstanceResourceUsage) {
+			vmiClient.EXPECT().Get(gomock.Any(), vmiName, gomock.Any()).Return(&v1.VirtualMachineInstance{
+				Status: v1.VirtualMachineInstanceStatus{Phase: v1.Running},
+			}, nil)
+			calls := []any{}
+			for _, sample := range samples {
+				calls = append(calls, vmiClient.EXPECT().ResourceUsage(gomock.Any(), vmiName).Return(sample, nil))
+			}
+			gomock.InOrder(calls...)
+		}
+
+		newSample := func(seconds int64, cpuTime time.Duration, usedMemory string) v1.VirtualMachineInstanceResourceUsage {
+			memory := resource.MustParse(usedMemory)
+			return v1.VirtualMachineInstanceResourceUsage{
+				Timestamp:            k8sv1.NewMicroTime(time.Unix(seconds, 0)),
+				VCPUs:                8,
+				CPUTimeNanoseconds:   uint64(cpuTime.Nanoseconds()),
+				MemoryAvailableBytes: 8 * 1024 * 1024 * 1024,
+				MemoryUsedBytes:      uint64(memory.Value()),
+			}
+		}
+
+		It("should size the instancetype after the observed peak usage", func() {
+			expectUsageSamples(
+				newSample(0, 0, "900Mi"),
+				// 1.5 cores used over 10 seconds
+				newSample(10, 15*time.Second, "1000Mi"),
+				// 0.5 cores used over 10 seconds
+				newSample(20, 20*time.Second, "950Mi"),
+			)
+
+			out, err := runObserveCmd()
+			Expect(err).ToNot(HaveOccurred())
+
+			spec := getInstancetypeSpec(out)
+			Expect(spec.CPU.Guest).To(Equal(uint32(2)))
+			Expect(spec.Memory.Guest).To(Equal(resource.MustParse("1280Mi")))
+			Expect(validateInstancetypeSpec(spec)).To(BeEmpty())
+		})
+
+		It("should not exceed the vCPUs and memory of the VMI", func() {
+			expectUsageSamples(
+				newSample(0, 0, "8Gi"),
+				newSample(10, 80*time.Second, "8Gi"),
+				newSample(20, 160*time.Second, "8Gi"),
+			)
+
+			out, err := runObserveCmd()
+			Expect(err).ToNot(HaveOccurred())
+
+			spec := getInstancetypeSpec(out)
+			Expect(spec.CPU.Guest).To(Equal(uint32(8)))
+			Expect(spec.Memory.Guest).To(Equal(resource.MustParse("8Gi")))
+		})
+
+		It("should fail if the guest does not report memory usage", func() {
+			expectUsageSamples(
+				newSample(0, 0, "0"),
+				newSample(10, time.Second, "0"),
+				newSample(20, 2*time.Second, "0"),
+			)
+
+			_, err := runObserveCmd()
+			Expect(err).To(MatchError("VirtualMachineInstance my-vmi does not report guest memory usage, is the memory balloon enabled?"))
+		})
+
+		It("should fail if the VMI is not running", func() {
+			vmiClient.EXPECT().Get(gomock.Any(), vmiName, gomock.Any()).Return(&v1.VirtualMachineInstance{}, nil)
+
+			_, err := runObserveCmd()
+			Expect(err).To(MatchError("VirtualMachineInstance my-vmi is not running"))
+		})
+
+		It("should fail if the observation is shorter than the sample interval", func() {
+			_, err := runObserveCmd(setFlag(ObserveFlag, "500ms"))
+			Expect(err).To(MatchError(`failed to parse "--observe" flag: must not be shorter than --sample-interval`))
+		})
+
+		It("should fail if the sample interval is shorter than a second", func() {
+			_, err := runObserveCmd(setFlag(SampleIntervalFlag, "10ms"))
+			Expect(err).To(MatchError(`failed to parse "--sample-interval" flag: must be at least 1s`))
+		})
+	})
+
 	It("should create namespaced object and apply namespace when namespace is specified", func() {
 		const namespace = "my-namespace"
 		out, err := runCmd(
@@ -0,0 +1,138 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package instancetype
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/create/params"
+)
+
+const (
+	defaultObserve        = 10 * time.Minute
+	defaultSampleInterval = 30 * time.Second
+	// minSampleInterval keeps the error of the sampling timestamps negligible
+	minSampleInterval = time.Second
+
+	// usageHeadroom is added on top of the peak usage observed
+	usageHeadroom = 0.2
+	// memoryGranularity is the step the recommended memory is rounded up to
+	memoryGranularity = 128 * 1024 * 1024
+)
+
+// sizeFromVMI samples the CPU and memory usage of the VMI given with
+// --from-vmi for the duration of --observe and derives the guest CPU count
+// and memory of the Instancetype from the observed peaks.
+func (c *createInstancetype) sizeFromVMI(cmd *cobra.Command) error {
+	if c.sampleInterval < minSampleInterval {
+		return params.FlagErr(SampleIntervalFlag, "must be at least %s", minSampleInterval)
+	}
+	if c.observe < c.sampleInterval {
+		return params.FlagErr(ObserveFlag, "must not be shorter than --%s", SampleIntervalFlag)
+	}
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	vmi, err := virtClient.VirtualMachineInstance(namespace).Get(cmd.Context(), c.fromVMI, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if vmi.Status.Phase != v1.Running {
+		return fmt.Errorf("VirtualMachineInstance %s is not running", c.fromVMI)
+	}
+
+	prev, err := virtClient.VirtualMachineInstance(namespace).ResourceUsage(cmd.Context(), c.fromVMI)
+	if err != nil {
+		return fmt.Errorf("error sampling usage of VirtualMachineInstance %s: %v", c.fromVMI, err)
+	}
+
+	var peakCores float64
+	peakMemory := prev.MemoryUsedBytes
+	intervals := 0
+
+	ticker := time.NewTicker(c.sampleInterval)
+	defer ticker.Stop()
+	for elapsed := c.sampleInterval; elapsed <= c.observe; elapsed += c.sampleInterval {
+		select {
+		case <-cmd.Context().Done():
+			return cmd.Context().Err()
+		case <-ticker.C:
+		}
+
+		cur, err := virtClient.VirtualMachineInstance(namespace).ResourceUsage(cmd.Context(), c.fromVMI)
+		if err != nil {
+			return fmt.Errorf("error sampling usage of VirtualMachineInstance %s: %v", c.fromVMI, err)
+		}
+
+		wall := cur.Timestamp.Sub(prev.Timestamp.Time)
+		if wall > 0 && cur.CPUTimeNanoseconds >= prev.CPUTimeNanoseconds {
+			cores := float64(cur.CPUTimeNanoseconds-prev.CPUTimeNanoseconds) / float64(wall.Nanoseconds())
+			peakCores = math.Max(peakCores, cores)
+			intervals++
+		}
+		if cur.MemoryUsedBytes > peakMemory {
+			peakMemory = cur.MemoryUsedBytes
+		}
+		prev = cur
+	}
+
+	if intervals == 0 {
+		return fmt.Errorf("unable to determine CPU usage of VirtualMachineInstance %s", c.fromVMI)
+	}
+	if peakMemory == 0 {
+		return fmt.Errorf("VirtualMachineInstance %s does not report guest memory usage, is the memory balloon enabled?", c.fromVMI)
+	}
+
+	c.cpu = recommendCPU(peakCores, prev.VCPUs)
+	c.memory = recommendMemory(peakMemory, prev.MemoryAvailableBytes).String()
+
+	return nil
+}
+
+func recommendCPU(peakCores float64, vcpus uint32) uint32 {
+	cpu := uint32(math.Ceil(peakCores * (1 + usageHeadroom)))
+	if vcpus > 0 && cpu > vcpus {
+		cpu = vcpus
+	}
+	if cpu < 1 {
+		cpu = 1
+	}
+	return cpu
+}
+
+func recommendMemory(peakBytes, availableBytes uint64) *resource.Quantity {
+	memory := uint64(math.Ceil(float64(peakBytes) * (1 + usageHeadroom)))
+	memory = (memory + memoryGranularity - 1) / memoryGranularity * memoryGranularity
+	if availableBytes > 0 && memory > availableBytes {
+		memory = availableBytes
+	}
+	return resource.NewQuantity(int64(memory), resource.BinarySI)
+}
@@ -0,0 +1,162 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package preference
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "kubevirt.io/api/core/v1"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+const interfaceModelE1000e = "e1000e"
+
+type osInfo struct {
+	family     osFamily
+	efi        bool
+	secureBoot bool
+	tpm        bool
+	cpus       uint32
+	memory     string
+}
+
+type osFamily int
+
+const (
+	osFamilyLinux osFamily = iota
+	osFamilyWindows
+)
+
+// osInfos maps the libosinfo short IDs of the supported operating systems
+// to the settings recommended for running them.
+var osInfos = map[string]osInfo{
+	"win10":          {family: osFamilyWindows, efi: true, cpus: 1, memory: "2Gi"},
+	"win11":          {family: osFamilyWindows, efi: true, secureBoot: true, tpm: true, cpus: 2, memory: "4Gi"},
+	"win2k16":        {family: osFamilyWindows, cpus: 1, memory: "2Gi"},
+	"win2k19":        {family: osFamilyWindows, efi: true, cpus: 1, memory: "2Gi"},
+	"win2k22":        {family: osFamilyWindows, efi: true, secureBoot: true, tpm: true, cpus: 1, memory: "4Gi"},
+	"win2k25":        {family: osFamilyWindows, efi: true, secureBoot: true, tpm: true, cpus: 2, memory: "4Gi"},
+	"fedora39":       {family: osFamilyLinux, cpus: 1, memory: "2Gi"},
+	"fedora40":       {family: osFamilyLinux, cpus: 1, memory: "2Gi"},
+	"fedora41":       {family: osFamilyLinux, cpus: 1, memory: "2Gi"},
+	"rhel8.10":       {family: osFamilyLinux, cpus: 1, memory: "1536Mi"},
+	"rhel9.4":        {family: osFamilyLinux, cpus: 1, memory: "1536Mi"},
+	"centos-stream9": {family: osFamilyLinux, cpus: 1, memory: "1536Mi"},
+	"ubuntu22.04":    {family: osFamilyLinux, cpus: 1, memory: "2Gi"},
+	"ubuntu24.04":    {family: osFamilyLinux, cpus: 1, memory: "2Gi"},
+	"debian12":       {family: osFamilyLinux, cpus: 1, memory: "1Gi"},
+}
+
+func supportedOSInfos() []string {
+	ids := make([]string, 0, len(osInfos))
+	for id := range osInfos {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (o osInfo) applyTo(preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec) {
+	preferenceSpec.Requirements = &instancetypev1beta1.PreferenceRequirements{
+		CPU:    &instancetypev1beta1.CPUPreferenceRequirement{Guest: o.cpus},
+		Memory: &instancetypev1beta1.MemoryPreferenceRequirement{Guest: resource.MustParse(o.memory)},
+	}
+
+	switch o.family {
+	case osFamilyWindows:
+		applyWindowsDefaults(preferenceSpec)
+	default:
+		applyLinuxDefaults(preferenceSpec)
+	}
+
+	if o.efi {
+		preferenceSpec.Firmware = &instancetypev1beta1.FirmwarePreferences{
+			PreferredEfi: &v1.EFI{SecureBoot: pointer.P(o.secureBoot)},
+		}
+		if o.secureBoot {
+			if preferenceSpec.Features == nil {
+				preferenceSpec.Features = &instancetypev1beta1.FeaturePreferences{}
+			}
+			preferenceSpec.Features.PreferredSmm = &v1.FeatureState{}
+		}
+	}
+
+	if o.tpm {
+		preferenceSpec.Devices.PreferredTPM = &v1.TPMDevice{}
+	}
+}
+
+func applyLinuxDefaults(preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec) {
+	preferredCPUTopology := instancetypev1beta1.Spread
+	preferenceSpec.CPU = &instancetypev1beta1.CPUPreferences{
+		PreferredCPUTopology: &preferredCPUTopology,
+	}
+	preferenceSpec.Devices = &instancetypev1beta1.DevicePreferences{
+		PreferredDiskBus:        v1.DiskBusVirtio,
+		PreferredInterfaceModel: v1.VirtIO,
+		PreferredRng:            &v1.Rng{},
+	}
+}
+
+// applyWindowsDefaults prefers emulated disk and network devices which are
+// supported without additional drivers during installation, and enables the
+// Hyper-V enlightenments Windows guests benefit from.
+func applyWindowsDefaults(preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec) {
+	preferredCPUTopology := instancetypev1beta1.Sockets
+	preferenceSpec.CPU = &instancetypev1beta1.CPUPreferences{
+		PreferredCPUTopology: &preferredCPUTopology,
+	}
+	preferenceSpec.Clock = &instancetypev1beta1.ClockPreferences{
+		PreferredClockOffset: &v1.ClockOffset{UTC: &v1.ClockOffsetUTC{}},
+		PreferredTimer: &v1.Timer{
+			HPET:   &v1.HPETTimer{Enabled: pointer.P(false)},
+			PIT:    &v1.PITTimer{TickPolicy: v1.PITTickPolicyDelay},
+			RTC:    &v1.RTCTimer{TickPolicy: v1.RTCTickPolicyCatchup},
+			Hyperv: &v1.HypervTimer{},
+		},
+	}
+	preferenceSpec.Devices = &instancetypev1beta1.DevicePreferences{
+		PreferredDiskBus:        v1.DiskBusSATA,
+		PreferredCdromBus:       v1.DiskBusSATA,
+		PreferredInterfaceModel: interfaceModelE1000e,
+		PreferredInputBus:       v1.InputBusUSB,
+		PreferredInputType:      v1.InputTypeTablet,
+	}
+	preferenceSpec.Features = &instancetypev1beta1.FeaturePreferences{
+		PreferredAcpi: &v1.FeatureState{},
+		PreferredApic: &v1.FeatureAPIC{},
+		PreferredHyperv: &v1.FeatureHyperv{
+			Relaxed:     &v1.FeatureState{},
+			VAPIC:       &v1.FeatureState{},
+			Spinlocks:   &v1.FeatureSpinlocks{Retries: pointer.P(uint32(8191))},
+			VPIndex:     &v1.FeatureState{},
+			Runtime:     &v1.FeatureState{},
+			SyNIC:       &v1.FeatureState{},
+			SyNICTimer:  &v1.SyNICTimer{Direct: &v1.FeatureState{}},
+			Reset:       &v1.FeatureState{},
+			Frequencies: &v1.FeatureState{},
+			TLBFlush:    &v1.TLBFlush{},
+			IPI:         &v1.FeatureState{},
+		},
+	}
+}
@@ -20,6 +20,9 @@
 package preference
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
@@ -38,6 +41,7 @@ const (
 	MachineTypeFlag         = "machine-type"
 	NameFlag                = "name"
 	NamespacedFlag          = "namespaced"
+	FromOSInfoFlag          = "from-osinfo"
 	defaultNameSuffixLength = 5
 )
 
@@ -48,6 +52,7 @@ type createPreference struct {
 	cpuTopology           string
 	machineType           string
 	preferredStorageClass string
+	fromOSInfo            string
 }
 
 func NewCommand() *cobra.Command {
@@ -66,6 +71,8 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringVar(&c.preferredStorageClass, VolumeStorageClassFlag, c.preferredStorageClass, "Defines the preferred storage class")
 	cmd.Flags().StringVar(&c.machineType, MachineTypeFlag, c.machineType, "Defines the preferred machine type to use.")
 	cmd.Flags().StringVar(&c.cpuTopology, CPUTopologyFlag, c.cpuTopology, "Defines the preferred guest visible CPU topology.")
+	cmd.Flags().StringVar(&c.fromOSInfo, FromOSInfoFlag, c.fromOSInfo,
+		fmt.Sprintf("Use the recommended devices, firmware and features of the given libosinfo operating system ID. Supported values: %s", strings.Join(supportedOSInfos(), ", ")))
 
 	return cmd
 }
@@ -84,7 +91,9 @@ func (c *createPreference) setDefaults(cmd *cobra.Command) error {
 		return nil
 	}
 
-	if c.namespaced {
+	if c.fromOSInfo != "" {
+		c.name = c.fromOSInfo
+	} else if c.namespaced {
 		c.name = "preference-" + rand.String(defaultNameSuffixLength)
 	} else {
 		c.name = "clusterpreference-" + rand.String(defaultNameSuffixLength)
@@ -136,6 +145,9 @@ func (c *createPreference) usage() string {
   # Create a manifest for a Preference with a specified CPU topology:
   {{ProgramName}} create preference --cpu-topology sockets --namespaced
 	
+  # Create a manifest for a ClusterPreference with the recommended settings for Windows Server 2022:
+  {{ProgramName}} create preference --from-osinfo win2k22
+
   # Create a manifest for a ClusterPreference and use it to create a resource with kubectl
   {{ProgramName}} create preference --volume-storage-class hostpath-provisioner | kubectl create -f -`
 }
@@ -171,6 +183,14 @@ func (c *createPreference) newPreference() *instancetypev1beta1.VirtualMachinePr
 }
 
 func (c *createPreference) applyFlags(cmd *cobra.Command, preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec) error {
+	if c.fromOSInfo != "" {
+		info, exists := osInfos[c.fromOSInfo]
+		if !exists {
+			return params.FlagErr(FromOSInfoFlag, "unknown operating system %q, supported values: %s", c.fromOSInfo, strings.Join(supportedOSInfos(), ", "))
+		}
+		info.applyTo(preferenceSpec)
+	}
+
 	for flag := range c.optFns() {
 		if cmd.Flags().Changed(flag) {
 			if err := c.optFns()[flag](preferenceSpec); err != nil {
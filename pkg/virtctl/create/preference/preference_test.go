@@ -26,10 +26,12 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	k8sv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/api/instancetype"
 	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
 	generatedscheme "kubevirt.io/client-go/kubevirt/scheme"
@@ -52,6 +54,11 @@ var _ = Describe("create preference", func() {
 		Entry("VirtualMachineClusterPreference", "clusterInvalidCPU"),
 	)
 
+	It("should fail with unknown osinfo", func() {
+		_, err := runCmd(setFlag(FromOSInfoFlag, "win95"))
+		Expect(err).To(MatchError(ContainSubstring(`unknown operating system "win95"`)))
+	})
+
 	Context("should succeed", func() {
 		It("without flags", func() {
 			out, err := runCmd()
@@ -135,6 +142,60 @@ var _ = Describe("create preference", func() {
 		)
 	})
 
+	Context("from osinfo", func() {
+		It("should create a Windows preference", func() {
+			out, err := runCmd(setFlag(FromOSInfoFlag, "win2k22"))
+			Expect(err).ToNot(HaveOccurred())
+
+			decodedObj, err := runtime.Decode(generatedscheme.Codecs.UniversalDeserializer(), out)
+			Expect(err).ToNot(HaveOccurred())
+			clusterPreference, ok := decodedObj.(*instancetypev1beta1.VirtualMachineClusterPreference)
+			Expect(ok).To(BeTrue())
+			Expect(clusterPreference.Name).To(Equal("win2k22"))
+
+			spec := &clusterPreference.Spec
+			Expect(spec.Devices.PreferredDiskBus).To(Equal(v1.DiskBusSATA))
+			Expect(spec.Devices.PreferredInterfaceModel).To(Equal("e1000e"))
+			Expect(spec.Devices.PreferredTPM).ToNot(BeNil())
+			Expect(spec.Features.PreferredHyperv).ToNot(BeNil())
+			Expect(spec.Features.PreferredSmm).ToNot(BeNil())
+			Expect(spec.Firmware.PreferredEfi.SecureBoot).To(HaveValue(BeTrue()))
+			Expect(spec.Clock.PreferredTimer.Hyperv).ToNot(BeNil())
+			Expect(spec.Requirements.Memory.Guest).To(Equal(resource.MustParse("4Gi")))
+			Expect(validatePreferenceSpec(spec)).To(BeEmpty())
+		})
+
+		It("should create a Linux preference", func() {
+			out, err := runCmd(setFlag(FromOSInfoFlag, "fedora40"), setFlag(NamespacedFlag, "true"))
+			Expect(err).ToNot(HaveOccurred())
+
+			spec := getPreferenceSpec(out)
+			Expect(spec.Devices.PreferredDiskBus).To(Equal(v1.DiskBusVirtio))
+			Expect(spec.Devices.PreferredInterfaceModel).To(Equal(v1.VirtIO))
+			Expect(spec.Devices.PreferredRng).ToNot(BeNil())
+			Expect(spec.Features).To(BeNil())
+			Expect(spec.Firmware).To(BeNil())
+			Expect(*spec.CPU.PreferredCPUTopology).To(Equal(instancetypev1beta1.Spread))
+			Expect(validatePreferenceSpec(spec)).To(BeEmpty())
+		})
+
+		It("should let explicit flags take precedence", func() {
+			out, err := runCmd(
+				setFlag(FromOSInfoFlag, "win2k22"),
+				setFlag(NameFlag, "my-preference"),
+				setFlag(CPUTopologyFlag, string(instancetypev1beta1.Cores)),
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			decodedObj, err := runtime.Decode(generatedscheme.Codecs.UniversalDeserializer(), out)
+			Expect(err).ToNot(HaveOccurred())
+			clusterPreference, ok := decodedObj.(*instancetypev1beta1.VirtualMachineClusterPreference)
+			Expect(ok).To(BeTrue())
+			Expect(clusterPreference.Name).To(Equal("my-preference"))
+			Expect(*clusterPreference.Spec.CPU.PreferredCPUTopology).To(Equal(instancetypev1beta1.Cores))
+		})
+	})
+
 	It("should create namespaced object and apply namespace when namespace is specified", func() {
 		const namespace = "my-namespace"
 		out, err := runCmd(setFlag("namespace", namespace))
@@ -0,0 +1,218 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vm
+
+import (
+	"fmt"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	v1 "kubevirt.io/api/core/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/create/params"
+)
+
+const (
+	fromVMDisksClone = "clone"
+	fromVMDisksBlank = "blank"
+)
+
+// newVMFromVM generates a VM from the spec of an existing VM. The identity of the source
+// (MAC addresses, SMBIOS serial, firmware UUID and hostname) is not copied over and its
+// disks are replaced by DataVolumeTemplates either cloning the source disks or providing blank disks.
+func (c *createVM) newVMFromVM() (*v1.VirtualMachine, error) {
+	if c.fromVMDisks != fromVMDisksClone && c.fromVMDisks != fromVMDisksBlank {
+		return nil, params.FlagErr(FromVMDisksFlag, "invalid value \"%s\", supported values are: %s, %s",
+			c.fromVMDisks, fromVMDisksClone, fromVMDisksBlank)
+	}
+
+	namespace, name, err := params.SplitPrefixedName(c.fromVM)
+	if err != nil {
+		return nil, params.FlagErr(FromVMFlag, "%w", err)
+	}
+	if namespace == "" {
+		namespace = c.clientNamespace
+	}
+
+	source, err := c.client.VirtualMachine(namespace).Get(c.cmd.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, params.FlagErr(FromVMFlag, "failed to get VirtualMachine \"%s/%s\": %w", namespace, name, err)
+	}
+	if source.Spec.Template == nil {
+		return nil, params.FlagErr(FromVMFlag, "VirtualMachine \"%s/%s\" has no template", namespace, name)
+	}
+
+	vm := &v1.VirtualMachine{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1.VirtualMachineGroupVersionKind.Kind,
+			APIVersion: v1.VirtualMachineGroupVersionKind.GroupVersion().String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.name,
+			Namespace: c.namespace,
+			Labels:    source.Labels,
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+
+	if c.cmd.Flags().Changed(TerminationGracePeriodFlag) {
+		vm.Spec.Template.Spec.TerminationGracePeriodSeconds = &c.terminationGracePeriod
+	}
+
+	// The revisions are bound to the source VM and have to be recreated for the new VM
+	if vm.Spec.Instancetype != nil {
+		vm.Spec.Instancetype.RevisionName = ""
+	}
+	if vm.Spec.Preference != nil {
+		vm.Spec.Preference.RevisionName = ""
+	}
+
+	resetIdentity(&vm.Spec.Template.Spec)
+
+	if err := c.replaceDisks(vm, namespace); err != nil {
+		return nil, err
+	}
+
+	for _, disk := range vm.Spec.Template.Spec.Domain.Devices.Disks {
+		if disk.BootOrder != nil {
+			c.bootOrders[*disk.BootOrder] = disk.Name
+		}
+	}
+
+	return vm, nil
+}
+
+func resetIdentity(spec *v1.VirtualMachineInstanceSpec) {
+	// Empty MAC addresses are assigned by Kube Mac Pool if it is deployed,
+	// otherwise it is up to the user to assign new addresses.
+	for i := range spec.Domain.Devices.Interfaces {
+		spec.Domain.Devices.Interfaces[i].MacAddress = ""
+	}
+
+	if firmware := spec.Domain.Firmware; firmware != nil {
+		firmware.UUID = ""
+		if firmware.Serial != "" {
+			firmware.Serial = string(uuid.NewUUID())
+		}
+	}
+
+	spec.Hostname = ""
+}
+
+// replaceDisks replaces every DataVolume and PVC volume of the VM with a DataVolumeTemplate
+// named after the new VM, cloning the disk of the source VM or providing a blank disk of the same size.
+func (c *createVM) replaceDisks(vm *v1.VirtualMachine, namespace string) error {
+	sourceTemplates := map[string]v1.DataVolumeTemplateSpec{}
+	for _, dvt := range vm.Spec.DataVolumeTemplates {
+		sourceTemplates[dvt.Name] = dvt
+	}
+	vm.Spec.DataVolumeTemplates = nil
+
+	for i, vol := range vm.Spec.Template.Spec.Volumes {
+		var claimName string
+		switch {
+		case vol.DataVolume != nil:
+			claimName = vol.DataVolume.Name
+		case vol.PersistentVolumeClaim != nil:
+			claimName = vol.PersistentVolumeClaim.ClaimName
+		default:
+			continue
+		}
+
+		size, err := c.diskSize(sourceTemplates, namespace, claimName)
+		if err != nil {
+			return err
+		}
+
+		spec := cdiv1.DataVolumeSpec{
+			Source: &cdiv1.DataVolumeSource{},
+		}
+		if c.fromVMDisks == fromVMDisksBlank {
+			spec.Source.Blank = &cdiv1.DataVolumeBlankImage{}
+		} else {
+			spec.Source.PVC = &cdiv1.DataVolumeSourcePVC{
+				Name:      claimName,
+				Namespace: namespace,
+			}
+		}
+		spec.Storage = &cdiv1.StorageSpec{}
+		if size != nil {
+			spec.Storage.Resources = k8sv1.VolumeResourceRequirements{
+				Requests: k8sv1.ResourceList{
+					k8sv1.ResourceStorage: *size,
+				},
+			}
+		}
+		if dvt, exists := sourceTemplates[claimName]; exists && dvt.Spec.Storage != nil {
+			spec.Storage.StorageClassName = dvt.Spec.Storage.StorageClassName
+		}
+
+		dvName := fmt.Sprintf("%s-%s", vm.Name, vol.Name)
+		vm.Spec.DataVolumeTemplates = append(vm.Spec.DataVolumeTemplates, v1.DataVolumeTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: dvName,
+			},
+			Spec: spec,
+		})
+		vm.Spec.Template.Spec.Volumes[i].VolumeSource = v1.VolumeSource{
+			DataVolume: &v1.DataVolumeSource{
+				Name: dvName,
+			},
+		}
+	}
+
+	return nil
+}
+
+// diskSize returns the size requested by the DataVolumeTemplate of the source VM or,
+// if there is none, the size of the PVC. Clones can omit the size, blank disks require it.
+func (c *createVM) diskSize(sourceTemplates map[string]v1.DataVolumeTemplateSpec, namespace, claimName string) (*resource.Quantity, error) {
+	if dvt, exists := sourceTemplates[claimName]; exists {
+		if dvt.Spec.Storage != nil {
+			if size, ok := dvt.Spec.Storage.Resources.Requests[k8sv1.ResourceStorage]; ok {
+				return &size, nil
+			}
+		}
+		if dvt.Spec.PVC != nil {
+			if size, ok := dvt.Spec.PVC.Resources.Requests[k8sv1.ResourceStorage]; ok {
+				return &size, nil
+			}
+		}
+	}
+
+	if c.fromVMDisks == fromVMDisksClone {
+		return nil, nil
+	}
+
+	pvc, err := c.client.CoreV1().PersistentVolumeClaims(namespace).Get(c.cmd.Context(), claimName, metav1.GetOptions{})
+	if err != nil {
+		return nil, params.FlagErr(FromVMDisksFlag, "failed to determine size of disk \"%s/%s\": %w", namespace, claimName, err)
+	}
+	size, ok := pvc.Spec.Resources.Requests[k8sv1.ResourceStorage]
+	if !ok {
+		return nil, params.FlagErr(FromVMDisksFlag, "failed to determine size of disk \"%s/%s\"", namespace, claimName)
+	}
+
+	return &size, nil
+}
@@ -34,6 +34,22 @@ type volumeSource struct {
 	BootOrder *uint  `param:"bootorder"`
 }
 
+type gpuDevice struct {
+	Name       string `param:"name"`
+	DeviceName string `param:"devicename"`
+}
+
+type hostDevice struct {
+	Name       string `param:"name"`
+	DeviceName string `param:"devicename"`
+}
+
+type network struct {
+	Name   string `param:"name"`
+	Type   string `param:"type"`
+	Source string `param:"src"`
+}
+
 type sysprepVolumeSource struct {
 	Source string `param:"src"`
 	Type   string `param:"type"`
@@ -20,11 +20,14 @@
 package vm
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
+	"text/template"
 
 	"github.com/spf13/cobra"
 	k8sv1 "k8s.io/api/core/v1"
@@ -36,11 +39,14 @@ import (
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/api/instancetype"
+	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
 	"kubevirt.io/kubevirt/pkg/virtctl/create/params"
+	"kubevirt.io/kubevirt/pkg/virtctl/imageupload"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -62,6 +68,7 @@ const (
 	PvcVolumeFlag           = "volume-pvc"
 	VolumeImportFlag        = "volume-import"
 	SysprepVolumeFlag       = "volume-sysprep"
+	SysprepFileFlag         = "sysprep-file"
 
 	UserFlag         = "user"
 	PasswordFileFlag = "password-file"
@@ -69,9 +76,25 @@ const (
 	GAManageSSHFlag  = "ga-manage-ssh"
 	AccessCredFlag   = "access-cred"
 
+	GPUFlag        = "gpu"
+	HostDeviceFlag = "host-device"
+	NetworkFlag    = "network"
+
 	CloudInitFlag            = "cloud-init"
 	CloudInitUserDataFlag    = "cloud-init-user-data"
 	CloudInitNetworkDataFlag = "cloud-init-network-data"
+	CloudInitFileFlag        = "cloud-init-file"
+
+	SetFlag = "set"
+
+	FromImagePathFlag         = "from-image-path"
+	FromImageSizeFlag         = "from-image-size"
+	FromImageStorageClassFlag = "from-image-storage-class"
+	UploadProxyURLFlag        = "uploadproxy-url"
+	InsecureFlag              = "insecure"
+
+	FromVMFlag      = "from-vm"
+	FromVMDisksFlag = "from-vm-disks"
 
 	// Deprecated flags
 	DataSourceVolumeFlag = "volume-datasource"
@@ -81,17 +104,24 @@ const (
 	sysprepDisk      = "sysprepdisk"
 	sysprepConfigMap = "configmap"
 	sysprepSecret    = "secret"
+	sysprepKey       = "autounattend.xml"
 
 	cloudInitDisk         = "cloudinitdisk"
 	cloudInitNoCloud      = "nocloud"
 	cloudInitConfigDrive  = "configdrive"
 	cloudInitNone         = "none"
 	cloudInitConfigHeader = "#cloud-config"
+	cloudInitUserDataKey  = "userdata"
 
 	accessCredTypeSSH      = "ssh"
 	accessCredTypePassword = "password"
 	accessCredMethodGA     = "ga"
 
+	networkTypeMasquerade = "masquerade"
+	networkTypeBridge     = "bridge"
+	networkTypeSRIOV      = "sriov"
+	podNetworkName        = "default"
+
 	blank    = "blank"
 	gcs      = "gcs"
 	http     = "http"
@@ -127,6 +157,7 @@ type createVM struct {
 	pvcVolumes           []string
 	volumeImport         []string
 	sysprepVolume        string
+	sysprepFile          string
 
 	user         string
 	passwordFile string
@@ -134,9 +165,25 @@ type createVM struct {
 	gaManageSSH  bool
 	accessCreds  []string
 
+	gpus        []string
+	hostDevices []string
+	networks    []string
+
 	cloudInit            string
 	cloudInitUserData    string
 	cloudInitNetworkData string
+	cloudInitFile        string
+
+	templateVars []string
+
+	fromImagePath         string
+	fromImageSize         string
+	fromImageStorageClass string
+	uploadProxyURL        string
+	insecure              bool
+
+	fromVM      string
+	fromVMDisks string
 
 	// Deprecated fields
 	dataSourceVolumes []string
@@ -144,16 +191,19 @@ type createVM struct {
 	blankVolumes      []string
 
 	namespace                     string
+	clientNamespace               string
+	client                        kubecli.KubevirtClient
 	explicitInstancetypeInference bool
 	explicitPreferenceInference   bool
 	memoryChanged                 bool
 
 	cmd        *cobra.Command
 	bootOrders map[uint]string
+	secrets    []*k8sv1.Secret
 }
 
 // Unless the boot order is specified by the user volumes have the following fixed boot order:
-// Containerdisk > PVC > DataSource > Clone PVC > Blank > Imported volumes
+// Uploaded image > Containerdisk > PVC > DataSource > Clone PVC > Blank > Imported volumes
 // This is controlled by the order in which flags are processed.
 // Also note that flags can only change values of other flags that are processed afterward.
 // For example, the AccessCred flag can change the values of cloud-init-related flags,
@@ -162,6 +212,7 @@ var flags = []string{
 	RunStrategyFlag,
 	InstancetypeFlag,
 	PreferenceFlag,
+	FromImagePathFlag,
 	ContainerdiskVolumeFlag,
 	PvcVolumeFlag,
 	DataSourceVolumeFlag,
@@ -169,7 +220,11 @@ var flags = []string{
 	BlankVolumeFlag,
 	VolumeImportFlag,
 	SysprepVolumeFlag,
+	SysprepFileFlag,
 	AccessCredFlag,
+	GPUFlag,
+	HostDeviceFlag,
+	NetworkFlag,
 }
 
 var volumeImportOptions = map[string]func(string) (*cdiv1.DataVolumeSpec, *uint, error){
@@ -185,6 +240,9 @@ var volumeImportOptions = map[string]func(string) (*cdiv1.DataVolumeSpec, *uint,
 	ds:       withVolumeSourceRefDataSource,
 }
 
+// UploadImageFn allows overriding the upload of a local image (useful for unit testing)
+var UploadImageFn = imageupload.UploadToDataVolume
+
 var volumeImportSizeOptional = map[string]bool{
 	pvc:      true,
 	snapshot: true,
@@ -199,7 +257,7 @@ func NewCommand() *cobra.Command {
 		Short: "Create a VirtualMachine manifest.",
 		Long: "Create a VirtualMachine manifest.\n\n" +
 			"If no boot order was specified volumes have the following fixed boot order:\n" +
-			"Containerdisk > PVC > DataSource > Clone PVC > Blank > Imported volumes",
+			"Uploaded image > Containerdisk > PVC > DataSource > Clone PVC > Blank > Imported volumes",
 		Args:    cobra.NoArgs,
 		Example: c.usage(),
 		RunE:    c.run,
@@ -227,6 +285,9 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringVar(&c.inferPreferenceFrom, InferPreferenceFromFlag, c.inferPreferenceFrom,
 		"Specify the volume to infer the Preference of the VM from. Mutually exclusive with --infer-preference.")
 	cmd.MarkFlagsMutuallyExclusive(PreferenceFlag, InferPreferenceFlag, InferPreferenceFromFlag)
+	// Registration only fails for unknown or already registered flags
+	_ = cmd.RegisterFlagCompletionFunc(InstancetypeFlag, completion.Instancetype)
+	_ = cmd.RegisterFlagCompletionFunc(PreferenceFlag, completion.Preference)
 
 	cmd.Flags().StringArrayVar(&c.containerdiskVolumes, ContainerdiskVolumeFlag, c.containerdiskVolumes,
 		fmt.Sprintf("Specify a containerdisk to be used by the VM. Can be provided multiple times.\n"+
@@ -262,9 +323,35 @@ func NewCommand() *cobra.Command {
 			ds, params.Supported(dataVolumeSource{}),
 		))
 
+	cmd.Flags().StringVar(&c.fromImagePath, FromImagePathFlag, c.fromImagePath,
+		"Specify the path to a local disk image which is uploaded to a new DataVolume used by the VM.\n"+
+			"The upload is performed when the command is run, the VM is created in the namespace of the DataVolume.")
+	cmd.Flags().StringVar(&c.fromImageSize, FromImageSizeFlag, c.fromImageSize,
+		"Specify the size of the DataVolume the local disk image is uploaded to (ex. 10Gi, 500Mi).")
+	cmd.Flags().StringVar(&c.fromImageStorageClass, FromImageStorageClassFlag, c.fromImageStorageClass,
+		"Specify the storage class of the DataVolume the local disk image is uploaded to.")
+	cmd.Flags().StringVar(&c.uploadProxyURL, UploadProxyURLFlag, c.uploadProxyURL,
+		"Specify the URL of the cdi-upload proxy service used to upload the local disk image.")
+	cmd.Flags().BoolVar(&c.insecure, InsecureFlag, c.insecure,
+		"Allow insecure server connections to the cdi-upload proxy service when using HTTPS.")
+
+	cmd.Flags().StringVar(&c.fromVM, FromVMFlag, c.fromVM,
+		"Specify an existing VM in the form [namespace/]name to derive the VM from.\n"+
+			"MAC addresses, SMBIOS serial, firmware UUID and hostname of the existing VM are not copied over.")
+	cmd.Flags().StringVar(&c.fromVMDisks, FromVMDisksFlag, c.fromVMDisks,
+		fmt.Sprintf("Specify how the disks of the VM passed to --%s are replaced.\n"+
+			"Supported values: %s (clone the disks, default), %s (blank disks of the same size)",
+			FromVMFlag, fromVMDisksClone, fromVMDisksBlank))
+	cmd.MarkFlagsMutuallyExclusive(FromVMFlag, MemoryFlag)
+	_ = cmd.RegisterFlagCompletionFunc(FromVMFlag, completion.VM)
+
 	cmd.Flags().StringVar(&c.sysprepVolume, SysprepVolumeFlag, c.sysprepVolume,
 		fmt.Sprintf("Specify a ConfigMap or Secret to be used as sysprep volume by the VM.\n"+
 			"Supported parameters: %s", params.Supported(sysprepVolumeSource{})))
+	cmd.Flags().StringVar(&c.sysprepFile, SysprepFileFlag, c.sysprepFile,
+		"Specify a file to read the sysprep answer file of the VM from.\n"+
+			"A Secret containing the rendered file is generated and used as sysprep volume by the VM.")
+	cmd.MarkFlagsMutuallyExclusive(SysprepVolumeFlag, SysprepFileFlag)
 
 	cmd.Flags().StringVar(&c.user, UserFlag, c.user, "Specify the user in the cloud-init user data that is added to the VM.")
 	cmd.Flags().StringVar(&c.passwordFile, PasswordFileFlag, c.passwordFile,
@@ -278,6 +365,20 @@ func NewCommand() *cobra.Command {
 		fmt.Sprintf("Specify an access credential to be injected into the VM. Can be provided multiple times.\n"+
 			"Supported parameters: %s", params.Supported(accessCredential{})))
 
+	cmd.Flags().StringArrayVar(&c.gpus, GPUFlag, c.gpus,
+		fmt.Sprintf("Specify a GPU to be assigned to the VM. Can be provided multiple times.\n"+
+			"Supported parameters: %s", params.Supported(gpuDevice{})))
+	cmd.Flags().StringArrayVar(&c.hostDevices, HostDeviceFlag, c.hostDevices,
+		fmt.Sprintf("Specify a host device to be assigned to the VM. Can be provided multiple times.\n"+
+			"Supported parameters: %s", params.Supported(hostDevice{})))
+	cmd.Flags().StringArrayVar(&c.networks, NetworkFlag, c.networks,
+		fmt.Sprintf("Specify a network and its interface to be added to the VM. Can be provided multiple times.\n"+
+			"If src is set a Multus network referencing the NetworkAttachmentDefinition is added, otherwise the pod network.\n"+
+			"Supported parameters: %s\n"+
+			"Supported types: %s (pod network only, default), %s (default for Multus networks), %s (Multus networks only)\n"+
+			"Note that the pod network is no longer added implicitly when this flag is used.",
+			params.Supported(network{}), networkTypeMasquerade, networkTypeBridge, networkTypeSRIOV))
+
 	cmd.Flags().StringVar(&c.cloudInit, CloudInitFlag, c.cloudInit,
 		fmt.Sprintf("Specify the type of the generated cloud-init data source.\n"+
 			"Supported values: %s, %s, %s", cloudInitNoCloud, cloudInitConfigDrive, cloudInitNone))
@@ -285,10 +386,22 @@ func NewCommand() *cobra.Command {
 		"Specify the base64 encoded cloud-init user data of the VM.")
 	cmd.Flags().StringVar(&c.cloudInitNetworkData, CloudInitNetworkDataFlag, c.cloudInitNetworkData,
 		"Specify the base64 encoded cloud-init network data of the VM.")
+	cmd.Flags().StringVar(&c.cloudInitFile, CloudInitFileFlag, c.cloudInitFile,
+		"Specify a file to read the cloud-init user data of the VM from.\n"+
+			"A Secret containing the rendered user data is generated and referenced by the cloud-init data source.")
 	cmd.MarkFlagsMutuallyExclusive(CloudInitUserDataFlag, UserFlag)
 	cmd.MarkFlagsMutuallyExclusive(CloudInitUserDataFlag, PasswordFileFlag)
 	cmd.MarkFlagsMutuallyExclusive(CloudInitUserDataFlag, SSHKeyFlag)
 	cmd.MarkFlagsMutuallyExclusive(CloudInitUserDataFlag, GAManageSSHFlag)
+	cmd.MarkFlagsMutuallyExclusive(CloudInitFileFlag, CloudInitUserDataFlag)
+	cmd.MarkFlagsMutuallyExclusive(CloudInitFileFlag, UserFlag)
+	cmd.MarkFlagsMutuallyExclusive(CloudInitFileFlag, PasswordFileFlag)
+	cmd.MarkFlagsMutuallyExclusive(CloudInitFileFlag, SSHKeyFlag)
+	cmd.MarkFlagsMutuallyExclusive(CloudInitFileFlag, GAManageSSHFlag)
+
+	cmd.Flags().StringArrayVar(&c.templateVars, SetFlag, c.templateVars,
+		fmt.Sprintf("Specify a variable in the form key=value to substitute in the files passed to --%s and --%s.\n"+
+			"Variables are referenced as {{ .key }} in the files. Can be provided multiple times.", CloudInitFileFlag, SysprepFileFlag))
 
 	// Deprecated flags
 	cmd.Flags().StringArrayVar(&c.dataSourceVolumes, DataSourceVolumeFlag, c.dataSourceVolumes,
@@ -318,6 +431,7 @@ func defaultCreateVM() createVM {
 		inferInstancetype:      true,
 		inferPreference:        true,
 		cloudInit:              cloudInitNoCloud,
+		fromVMDisks:            fromVMDisksClone,
 		bootOrders:             map[uint]string{},
 	}
 }
@@ -392,7 +506,15 @@ func (c *createVM) run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	vm, err := c.newVM()
+	var (
+		vm  *v1.VirtualMachine
+		err error
+	)
+	if c.fromVM != "" {
+		vm, err = c.newVMFromVM()
+	} else {
+		vm, err = c.newVM()
+	}
 	if err != nil {
 		return err
 	}
@@ -413,11 +535,25 @@ func (c *createVM) run(cmd *cobra.Command, _ []string) error {
 		return inferErr
 	}
 
+	if uploadErr := c.uploadImage(vm); uploadErr != nil {
+		return uploadErr
+	}
+
 	out, err := yaml.Marshal(vm)
 	if err != nil {
 		return err
 	}
 
+	for _, secret := range c.secrets {
+		secret.Namespace = vm.Namespace
+		secretOut, err := yaml.Marshal(secret)
+		if err != nil {
+			return err
+		}
+		cmd.Print(string(secretOut))
+		cmd.Println("---")
+	}
+
 	cmd.Print(string(out))
 
 	return nil
@@ -426,13 +562,15 @@ func (c *createVM) run(cmd *cobra.Command, _ []string) error {
 func (c *createVM) setDefaults(cmd *cobra.Command) error {
 	c.cmd = cmd
 
-	_, namespace, overridden, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	client, namespace, overridden, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 	if overridden {
 		c.namespace = namespace
 	}
+	c.client = client
+	c.clientNamespace = namespace
 
 	if c.name == "" {
 		c.name = "vm-" + rand.String(randSuffixLength)
@@ -446,6 +584,20 @@ func (c *createVM) setDefaults(cmd *cobra.Command) error {
 
 	c.memoryChanged = cmd.Flags().Changed(MemoryFlag)
 
+	// A VM derived from an existing VM keeps its instancetype and preference unless inference is requested explicitly
+	if c.fromVM != "" {
+		c.inferInstancetype = c.inferInstancetype && c.explicitInstancetypeInference
+		c.inferPreference = c.inferPreference && c.explicitPreferenceInference
+	}
+
+	if cmd.Flags().Changed(FromVMDisksFlag) && c.fromVM == "" {
+		return params.FlagErr(FromVMDisksFlag, "requires --%s", FromVMFlag)
+	}
+
+	if cmd.Flags().Changed(SetFlag) && c.cloudInitFile == "" && c.sysprepFile == "" {
+		return params.FlagErr(SetFlag, "requires --%s or --%s", CloudInitFileFlag, SysprepFileFlag)
+	}
+
 	return nil
 }
 
@@ -454,6 +606,7 @@ func (c *createVM) optFns() map[string]func(*v1.VirtualMachine) error {
 		RunStrategyFlag:         c.withRunStrategy,
 		InstancetypeFlag:        c.withInstancetype,
 		PreferenceFlag:          c.withPreference,
+		FromImagePathFlag:       c.withUploadedImage,
 		ContainerdiskVolumeFlag: c.withContainerdiskVolume,
 		DataSourceVolumeFlag:    c.withDataSourceVolume,
 		ClonePvcVolumeFlag:      c.withClonePvcVolume,
@@ -461,7 +614,11 @@ func (c *createVM) optFns() map[string]func(*v1.VirtualMachine) error {
 		BlankVolumeFlag:         c.withBlankVolume,
 		VolumeImportFlag:        c.withImportedVolume,
 		SysprepVolumeFlag:       c.withSysprepVolume,
+		SysprepFileFlag:         c.withSysprepFile,
 		AccessCredFlag:          c.withAccessCredential,
+		GPUFlag:                 c.withGPU,
+		HostDeviceFlag:          c.withHostDevice,
+		NetworkFlag:             c.withNetwork,
 	}
 }
 
@@ -536,8 +693,29 @@ func (c *createVM) usage() string {
   # Create a manifest for a VirtualMachine with password injected into the VM from a secret called my-pws
   {{ProgramName}} create vm --access-cred=type:password,src:my-pws
 
+  # Create a manifest for a VirtualMachine with a GPU and a host device assigned
+  {{ProgramName}} create vm --gpu=devicename:nvidia.com/GP102GL --host-device=name:my-hd,devicename:vendor.com/my-device
+
+  # Create a manifest for a VirtualMachine with the pod network and an additional SR-IOV network
+  {{ProgramName}} create vm --network=name:default --network=name:sriov-net,type:sriov,src:my-ns/my-sriov-nad
+
+  # Upload a local disk image to a new DataVolume and create a manifest for a VirtualMachine using it
+  {{ProgramName}} create vm --from-image-path=/images/fedora.qcow2 --from-image-size=10Gi
+
   # Create a manifest for a VirtualMachine with a Containerdisk and a Sysprep volume (source ConfigMap needs to exist)
-  {{ProgramName}} create vm --memory=1Gi --volume-containerdisk=src:my.registry/my-image:my-tag --volume-sysprep=src:my-cm`
+  {{ProgramName}} create vm --memory=1Gi --volume-containerdisk=src:my.registry/my-image:my-tag --volume-sysprep=src:my-cm
+
+  # Create manifests for a VirtualMachine and a Secret containing cloud-init user data rendered from a local file
+  {{ProgramName}} create vm --volume-containerdisk=src:my.registry/my-image:my-tag --cloud-init-file=user-data.yaml --set hostname=my-vm
+
+  # Create manifests for a VirtualMachine and a Secret containing a sysprep answer file rendered from a local file
+  {{ProgramName}} create vm --volume-import=type:pvc,src:my-ns/my-windows-pvc --sysprep-file=autounattend.xml --set password=secret
+
+  # Create a manifest for a VirtualMachine derived from an existing VirtualMachine with cloned disks
+  {{ProgramName}} create vm --name=my-vm-copy --from-vm=my-ns/my-vm
+
+  # Create a manifest for a VirtualMachine derived from an existing VirtualMachine with blank disks of the same size
+  {{ProgramName}} create vm --from-vm=my-vm --from-vm-disks=blank`
 }
 
 func (c *createVM) newVM() (*v1.VirtualMachine, error) {
@@ -711,7 +889,8 @@ func (c *createVM) cloudInitFlagsChanged() bool {
 		c.cmd.Flags().Changed(GAManageSSHFlag) ||
 		c.cmd.Flags().Changed(CloudInitFlag) ||
 		c.cmd.Flags().Changed(CloudInitUserDataFlag) ||
-		c.cmd.Flags().Changed(CloudInitNetworkDataFlag)
+		c.cmd.Flags().Changed(CloudInitNetworkDataFlag) ||
+		c.cmd.Flags().Changed(CloudInitFileFlag)
 }
 
 func (c *createVM) cloudInitConfig(vm *v1.VirtualMachine) error {
@@ -742,7 +921,8 @@ func (c *createVM) cloudInitConfig(vm *v1.VirtualMachine) error {
 			c.cmd.Flags().Changed(SSHKeyFlag) ||
 			c.cmd.Flags().Changed(GAManageSSHFlag) ||
 			c.cmd.Flags().Changed(CloudInitUserDataFlag) ||
-			c.cmd.Flags().Changed(CloudInitNetworkDataFlag) {
+			c.cmd.Flags().Changed(CloudInitNetworkDataFlag) ||
+			c.cmd.Flags().Changed(CloudInitFileFlag) {
 			c.cmd.PrintErrf("WARNING: --%s: was set to none, not creating a data source although other cloud-init options were set", CloudInitFlag)
 		}
 		return nil
@@ -775,9 +955,16 @@ func (c *createVM) noCloudVolumeSource() (*v1.VolumeSource, error) {
 		src.NetworkDataBase64 = c.cloudInitNetworkData
 	}
 
-	if c.cloudInitUserData != "" {
+	switch {
+	case c.cloudInitFile != "":
+		name, err := c.newSecretFromFile(CloudInitFileFlag, c.cloudInitFile, "cloudinit", cloudInitUserDataKey)
+		if err != nil {
+			return nil, err
+		}
+		src.UserDataSecretRef = &k8sv1.LocalObjectReference{Name: name}
+	case c.cloudInitUserData != "":
 		src.UserDataBase64 = c.cloudInitUserData
-	} else {
+	default:
 		config, err := c.buildCloudInitConfig()
 		if err != nil {
 			return nil, err
@@ -796,9 +983,16 @@ func (c *createVM) configDriveVolumeSource() (*v1.VolumeSource, error) {
 		src.NetworkDataBase64 = c.cloudInitNetworkData
 	}
 
-	if c.cloudInitUserData != "" {
+	switch {
+	case c.cloudInitFile != "":
+		name, err := c.newSecretFromFile(CloudInitFileFlag, c.cloudInitFile, "cloudinit", cloudInitUserDataKey)
+		if err != nil {
+			return nil, err
+		}
+		src.UserDataSecretRef = &k8sv1.LocalObjectReference{Name: name}
+	case c.cloudInitUserData != "":
 		src.UserDataBase64 = c.cloudInitUserData
-	} else {
+	default:
 		config, err := c.buildCloudInitConfig()
 		if err != nil {
 			return nil, err
@@ -904,6 +1098,69 @@ func (c *createVM) withPreference(vm *v1.VirtualMachine) error {
 	return nil
 }
 
+func (c *createVM) withUploadedImage(vm *v1.VirtualMachine) error {
+	if c.fromImageSize == "" {
+		return params.FlagErr(FromImagePathFlag, "--%s must be specified", FromImageSizeFlag)
+	}
+
+	if _, err := resource.ParseQuantity(c.fromImageSize); err != nil {
+		return params.FlagErr(FromImageSizeFlag, "%w", err)
+	}
+
+	if _, err := os.Stat(c.fromImagePath); err != nil {
+		return params.FlagErr(FromImagePathFlag, "%w", err)
+	}
+
+	name := uploadedImageName(vm)
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return params.FlagErr(FromImagePathFlag, "invalid name \"%s\": %s", name, strings.Join(errs, ","))
+	}
+
+	if err := volumeShouldNotExist(FromImagePathFlag, vm, name); err != nil {
+		return err
+	}
+
+	// The uploaded DataVolume is not owned by the VM, so the VM needs to be created in its namespace
+	vm.Namespace = c.clientNamespace
+	vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+		Name: name,
+		VolumeSource: v1.VolumeSource{
+			DataVolume: &v1.DataVolumeSource{
+				Name: name,
+			},
+		},
+	})
+
+	return nil
+}
+
+// uploadedImageName returns the name of the DataVolume the local image is uploaded to.
+func uploadedImageName(vm *v1.VirtualMachine) string {
+	return vm.Name + "-disk"
+}
+
+// uploadImage uploads the local image after all other flags were validated
+// successfully, so no DataVolume is left behind if the manifest is invalid.
+func (c *createVM) uploadImage(vm *v1.VirtualMachine) error {
+	if c.fromImagePath == "" {
+		return nil
+	}
+
+	// Print progress to stderr to avoid tainting the generated manifest
+	uploadCmd := &cobra.Command{}
+	uploadCmd.SetOut(c.cmd.ErrOrStderr())
+	uploadCmd.SetErr(c.cmd.ErrOrStderr())
+
+	return UploadImageFn(uploadCmd, c.client, vm.Namespace, imageupload.UploadOptions{
+		Name:           uploadedImageName(vm),
+		Size:           c.fromImageSize,
+		StorageClass:   c.fromImageStorageClass,
+		ImagePath:      c.fromImagePath,
+		UploadProxyURL: c.uploadProxyURL,
+		Insecure:       c.insecure,
+	})
+}
+
 func (c *createVM) withContainerdiskVolume(vm *v1.VirtualMachine) error {
 	for i, containerdiskVol := range c.containerdiskVolumes {
 		src := volumeSource{}
@@ -1052,6 +1309,84 @@ func (c *createVM) withSysprepVolume(vm *v1.VirtualMachine) error {
 	return nil
 }
 
+func (c *createVM) withSysprepFile(vm *v1.VirtualMachine) error {
+	if err := volumeShouldNotExist(SysprepFileFlag, vm, sysprepDisk); err != nil {
+		return err
+	}
+
+	name, err := c.newSecretFromFile(SysprepFileFlag, c.sysprepFile, "sysprep", sysprepKey)
+	if err != nil {
+		return err
+	}
+
+	vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+		Name: sysprepDisk,
+		VolumeSource: v1.VolumeSource{
+			Sysprep: &v1.SysprepSource{
+				Secret: &k8sv1.LocalObjectReference{
+					Name: name,
+				},
+			},
+		},
+	})
+
+	return nil
+}
+
+// newSecretFromFile renders the passed file with the variables passed to --set
+// and adds a Secret containing the result to the generated manifests.
+func (c *createVM) newSecretFromFile(flag, path, suffix, key string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", params.FlagErr(flag, "%w", err)
+	}
+
+	vars, err := c.parseTemplateVars()
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return "", params.FlagErr(flag, "failed to parse template: %w", err)
+	}
+
+	rendered := &bytes.Buffer{}
+	if err := tmpl.Execute(rendered, vars); err != nil {
+		return "", params.FlagErr(flag, "failed to render template: %w", err)
+	}
+
+	secret := &k8sv1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: k8sv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.name + "-" + suffix,
+			Namespace: c.namespace,
+		},
+		Data: map[string][]byte{
+			key: rendered.Bytes(),
+		},
+	}
+	c.secrets = append(c.secrets, secret)
+
+	return secret.Name, nil
+}
+
+func (c *createVM) parseTemplateVars() (map[string]string, error) {
+	vars := map[string]string{}
+	for _, templateVar := range c.templateVars {
+		key, value, found := strings.Cut(templateVar, "=")
+		if !found || key == "" {
+			return nil, params.FlagErr(SetFlag, "invalid variable \"%s\", expected key=value", templateVar)
+		}
+		vars[key] = value
+	}
+
+	return vars, nil
+}
+
 func (c *createVM) withImportedVolume(vm *v1.VirtualMachine) error {
 	for _, volume := range c.volumeImport {
 		srcType, err := params.GetParamByName("type", volume)
@@ -1552,6 +1887,169 @@ func (c *createVM) withAccessCredentialPassword(src *accessCredential) (*v1.Acce
 	}, nil
 }
 
+func (c *createVM) withGPU(vm *v1.VirtualMachine) error {
+	for i, gpu := range c.gpus {
+		src := gpuDevice{}
+		if err := params.Map(GPUFlag, gpu, &src); err != nil {
+			return err
+		}
+
+		if src.DeviceName == "" {
+			return params.FlagErr(GPUFlag, "devicename must be specified")
+		}
+
+		if src.Name == "" {
+			src.Name = fmt.Sprintf("%s-gpu-%d", vm.Name, i)
+		}
+
+		for _, existing := range vm.Spec.Template.Spec.Domain.Devices.GPUs {
+			if existing.Name == src.Name {
+				return params.FlagErr(GPUFlag, "there is already a gpu with name \"%s\"", src.Name)
+			}
+		}
+
+		vm.Spec.Template.Spec.Domain.Devices.GPUs = append(vm.Spec.Template.Spec.Domain.Devices.GPUs, v1.GPU{
+			Name:       src.Name,
+			DeviceName: src.DeviceName,
+		})
+	}
+
+	return nil
+}
+
+func (c *createVM) withHostDevice(vm *v1.VirtualMachine) error {
+	for i, hostDev := range c.hostDevices {
+		src := hostDevice{}
+		if err := params.Map(HostDeviceFlag, hostDev, &src); err != nil {
+			return err
+		}
+
+		if src.DeviceName == "" {
+			return params.FlagErr(HostDeviceFlag, "devicename must be specified")
+		}
+
+		if src.Name == "" {
+			src.Name = fmt.Sprintf("%s-hostdevice-%d", vm.Name, i)
+		}
+
+		for _, existing := range vm.Spec.Template.Spec.Domain.Devices.HostDevices {
+			if existing.Name == src.Name {
+				return params.FlagErr(HostDeviceFlag, "there is already a host device with name \"%s\"", src.Name)
+			}
+		}
+
+		vm.Spec.Template.Spec.Domain.Devices.HostDevices = append(vm.Spec.Template.Spec.Domain.Devices.HostDevices, v1.HostDevice{
+			Name:       src.Name,
+			DeviceName: src.DeviceName,
+		})
+	}
+
+	return nil
+}
+
+func (c *createVM) withNetwork(vm *v1.VirtualMachine) error {
+	for _, networkParams := range c.networks {
+		src := network{}
+		if err := params.Map(NetworkFlag, networkParams, &src); err != nil {
+			return err
+		}
+
+		apiNetwork, err := newNetwork(&src)
+		if err != nil {
+			return err
+		}
+
+		if errs := validation.IsDNS1123Label(apiNetwork.Name); len(errs) > 0 {
+			return params.FlagErr(NetworkFlag, "invalid name \"%s\": %s", apiNetwork.Name, strings.Join(errs, ","))
+		}
+
+		for _, existing := range vm.Spec.Template.Spec.Networks {
+			if existing.Name == apiNetwork.Name {
+				return params.FlagErr(NetworkFlag, "there is already a network with name \"%s\"", apiNetwork.Name)
+			}
+			if apiNetwork.Pod != nil && existing.Pod != nil {
+				return params.FlagErr(NetworkFlag, "the pod network can only be added once")
+			}
+		}
+
+		binding, err := newInterfaceBindingMethod(&src, apiNetwork)
+		if err != nil {
+			return err
+		}
+
+		vm.Spec.Template.Spec.Networks = append(vm.Spec.Template.Spec.Networks, *apiNetwork)
+		vm.Spec.Template.Spec.Domain.Devices.Interfaces = append(vm.Spec.Template.Spec.Domain.Devices.Interfaces, v1.Interface{
+			Name:                   apiNetwork.Name,
+			InterfaceBindingMethod: *binding,
+		})
+	}
+
+	return nil
+}
+
+func newNetwork(src *network) (*v1.Network, error) {
+	if src.Source == "" {
+		name := src.Name
+		if name == "" {
+			name = podNetworkName
+		}
+		return &v1.Network{
+			Name: name,
+			NetworkSource: v1.NetworkSource{
+				Pod: &v1.PodNetwork{},
+			},
+		}, nil
+	}
+
+	_, nadName, err := params.SplitPrefixedName(src.Source)
+	if err != nil {
+		return nil, params.FlagErr(NetworkFlag, "src invalid: %w", err)
+	}
+
+	name := src.Name
+	if name == "" {
+		name = nadName
+	}
+	return &v1.Network{
+		Name: name,
+		NetworkSource: v1.NetworkSource{
+			Multus: &v1.MultusNetwork{
+				NetworkName: src.Source,
+			},
+		},
+	}, nil
+}
+
+func newInterfaceBindingMethod(src *network, apiNetwork *v1.Network) (*v1.InterfaceBindingMethod, error) {
+	netType := strings.ToLower(src.Type)
+	if netType == "" {
+		if apiNetwork.Pod != nil {
+			netType = networkTypeMasquerade
+		} else {
+			netType = networkTypeBridge
+		}
+	}
+
+	switch netType {
+	case networkTypeMasquerade:
+		if apiNetwork.Pod == nil {
+			return nil, params.FlagErr(NetworkFlag, "type %s can only be used with the pod network", networkTypeMasquerade)
+		}
+		return &v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, nil
+	case networkTypeBridge:
+		return &v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, nil
+	case networkTypeSRIOV:
+		if apiNetwork.Multus == nil {
+			return nil, params.FlagErr(NetworkFlag, "type %s can only be used with a Multus network (src)", networkTypeSRIOV)
+		}
+		return &v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}, nil
+	default:
+		return nil, params.FlagErr(NetworkFlag,
+			"invalid network type \"%s\", supported values are: %s, %s, %s",
+			src.Type, networkTypeMasquerade, networkTypeBridge, networkTypeSRIOV)
+	}
+}
+
 // Deprecated optFns
 
 func (c *createVM) withDataSourceVolume(_ *v1.VirtualMachine) error {
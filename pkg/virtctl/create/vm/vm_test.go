@@ -13,19 +13,27 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 
+	"github.com/spf13/cobra"
+	"go.uber.org/mock/gomock"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/rand"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
 
 	v1 "kubevirt.io/api/core/v1"
 	instancetypeapi "kubevirt.io/api/instancetype"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
 	generatedscheme "kubevirt.io/client-go/kubevirt/scheme"
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/virtctl/create"
 	. "kubevirt.io/kubevirt/pkg/virtctl/create/vm"
+	"kubevirt.io/kubevirt/pkg/virtctl/imageupload"
 	"kubevirt.io/kubevirt/pkg/virtctl/testing"
 )
 
@@ -717,6 +725,474 @@ chpasswd: { expire: False }`
 			Entry("with src, name and bootorder", "src:my-pvc,name:my-direct-pvc,bootorder:2", "my-direct-pvc", 2),
 		)
 
+		DescribeTable("VM with specified gpu", func(params, name string) {
+			const vmName = "my-vm"
+
+			out, err := runCmd(setFlag(NameFlag, vmName), setFlag(GPUFlag, params))
+			Expect(err).ToNot(HaveOccurred())
+			vm, err := decodeVM(out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(vm.Spec.Template.Spec.Domain.Devices.GPUs).To(ConsistOf(v1.GPU{
+				Name:       name,
+				DeviceName: "nvidia.com/GP102GL",
+			}))
+		},
+			Entry("with devicename", "devicename:nvidia.com/GP102GL", "my-vm-gpu-0"),
+			Entry("with devicename and name", "devicename:nvidia.com/GP102GL,name:my-gpu", "my-gpu"),
+		)
+
+		DescribeTable("VM with specified host device", func(params, name string) {
+			const vmName = "my-vm"
+
+			out, err := runCmd(setFlag(NameFlag, vmName), setFlag(HostDeviceFlag, params))
+			Expect(err).ToNot(HaveOccurred())
+			vm, err := decodeVM(out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(vm.Spec.Template.Spec.Domain.Devices.HostDevices).To(ConsistOf(v1.HostDevice{
+				Name:       name,
+				DeviceName: "vendor.com/my-device",
+			}))
+		},
+			Entry("with devicename", "devicename:vendor.com/my-device", "my-vm-hostdevice-0"),
+			Entry("with devicename and name", "devicename:vendor.com/my-device,name:my-hd", "my-hd"),
+		)
+
+		DescribeTable("VM with specified network", func(params string, network v1.Network, binding v1.InterfaceBindingMethod) {
+			out, err := runCmd(setFlag(NetworkFlag, params))
+			Expect(err).ToNot(HaveOccurred())
+			vm, err := decodeVM(out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(vm.Spec.Template.Spec.Networks).To(ConsistOf(network))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces).To(ConsistOf(v1.Interface{
+				Name:                   network.Name,
+				InterfaceBindingMethod: binding,
+			}))
+		},
+			Entry("pod network with default name and type", "name:default",
+				v1.Network{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+				v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+			),
+			Entry("pod network with bridge type", "type:bridge",
+				v1.Network{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}},
+				v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+			),
+			Entry("multus network with default name and type", "src:my-ns/my-nad",
+				v1.Network{Name: "my-nad", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "my-ns/my-nad"}}},
+				v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+			),
+			Entry("multus network with sriov type", "name:my-sriov,type:sriov,src:my-nad",
+				v1.Network{Name: "my-sriov", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "my-nad"}}},
+				v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}},
+			),
+		)
+
+		It("VM with pod network and multiple multus networks", func() {
+			out, err := runCmd(
+				setFlag(NetworkFlag, "name:default"),
+				setFlag(NetworkFlag, "name:net1,src:my-nad1"),
+				setFlag(NetworkFlag, "name:net2,type:sriov,src:my-nad2"),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			vm, err := decodeVM(out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(vm.Spec.Template.Spec.Networks).To(HaveLen(3))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces).To(HaveLen(3))
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces[2].SRIOV).ToNot(BeNil())
+		})
+
+		Context("VM with uploaded local image", func() {
+			var (
+				imagePath  string
+				uploadOpts *imageupload.UploadOptions
+				uploadNS   string
+			)
+
+			BeforeEach(func() {
+				imagePath = filepath.Join(GinkgoT().TempDir(), "disk.qcow2")
+				Expect(os.WriteFile(imagePath, []byte("hello world"), 0o600)).To(Succeed())
+
+				uploadOpts = nil
+				origUploadImageFn := UploadImageFn
+				UploadImageFn = func(_ *cobra.Command, _ kubecli.KubevirtClient, namespace string, opts imageupload.UploadOptions) error {
+					uploadNS = namespace
+					uploadOpts = &opts
+					return nil
+				}
+				DeferCleanup(func() {
+					UploadImageFn = origUploadImageFn
+				})
+			})
+
+			It("should upload the image and reference the created DataVolume", func() {
+				const (
+					vmName   = "my-vm"
+					diskName = "my-vm-disk"
+				)
+
+				out, err := runCmd(
+					setFlag(NameFlag, vmName),
+					setFlag(FromImagePathFlag, imagePath),
+					setFlag(FromImageSizeFlag, "10Gi"),
+					setFlag(FromImageStorageClassFlag, "my-sc"),
+					setFlag("namespace", "my-ns"),
+				)
+				Expect(err).ToNot(HaveOccurred())
+				vm, err := decodeVM(out)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(uploadNS).To(Equal("my-ns"))
+				Expect(uploadOpts).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Name":         Equal(diskName),
+					"Size":         Equal("10Gi"),
+					"StorageClass": Equal("my-sc"),
+					"ImagePath":    Equal(imagePath),
+				})))
+
+				Expect(vm.Namespace).To(Equal("my-ns"))
+				Expect(vm.Spec.Template.Spec.Volumes).To(ConsistOf(v1.Volume{
+					Name: diskName,
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{
+							Name: diskName,
+						},
+					},
+				}))
+			})
+
+			It("should not upload the image when the manifest is invalid", func() {
+				_, err := runCmd(
+					setFlag(FromImagePathFlag, imagePath),
+					setFlag(FromImageSizeFlag, "10Gi"),
+					setFlag(RunStrategyFlag, "not-a-run-strategy"),
+				)
+				Expect(err).To(HaveOccurred())
+				Expect(uploadOpts).To(BeNil())
+			})
+
+			It("should fail when the size is missing", func() {
+				_, err := runCmd(setFlag(FromImagePathFlag, imagePath))
+				Expect(err).To(MatchError("failed to parse \"--from-image-path\" flag: --from-image-size must be specified"))
+				Expect(uploadOpts).To(BeNil())
+			})
+
+			It("should fail when the image does not exist", func() {
+				_, err := runCmd(
+					setFlag(FromImagePathFlag, filepath.Join(GinkgoT().TempDir(), "does-not-exist")),
+					setFlag(FromImageSizeFlag, "10Gi"),
+				)
+				Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+				Expect(uploadOpts).To(BeNil())
+			})
+		})
+
+		Context("VM derived from an existing VM", func() {
+			const (
+				sourceNamespace = "source-ns"
+				sourceName      = "source-vm"
+				vmName          = "my-vm"
+			)
+
+			var (
+				kubeClient *k8sfake.Clientset
+				virtClient *kubevirtfake.Clientset
+			)
+
+			newSourceVM := func() *v1.VirtualMachine {
+				return &v1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        sourceName,
+						Namespace:   sourceNamespace,
+						Labels:      map[string]string{"app": "source"},
+						Annotations: map[string]string{"my-annotation": "value"},
+					},
+					Spec: v1.VirtualMachineSpec{
+						RunStrategy: pointer.P(v1.RunStrategyHalted),
+						Instancetype: &v1.InstancetypeMatcher{
+							Name:         "u1.small",
+							RevisionName: "source-vm-u1.small-revision",
+						},
+						DataVolumeTemplates: []v1.DataVolumeTemplateSpec{{
+							ObjectMeta: metav1.ObjectMeta{Name: "source-vm-rootdisk"},
+							Spec: cdiv1.DataVolumeSpec{
+								Source: &cdiv1.DataVolumeSource{Registry: &cdiv1.DataVolumeSourceRegistry{URL: pointer.P("docker://my-image")}},
+								Storage: &cdiv1.StorageSpec{
+									StorageClassName: pointer.P("my-storage-class"),
+									Resources: k8sv1.VolumeResourceRequirements{
+										Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("30Gi")},
+									},
+								},
+							},
+						}},
+						Template: &v1.VirtualMachineInstanceTemplateSpec{
+							Spec: v1.VirtualMachineInstanceSpec{
+								Hostname: "source",
+								Domain: v1.DomainSpec{
+									Firmware: &v1.Firmware{
+										UUID:   "7c7bd7a3-ae53-4ee5-b4a3-1a9b1c8d1c1e",
+										Serial: "source-serial",
+									},
+									Devices: v1.Devices{
+										Disks: []v1.Disk{{Name: "rootdisk", BootOrder: pointer.P(uint(1))}},
+										Interfaces: []v1.Interface{{
+											Name:                   "default",
+											MacAddress:             "02:00:00:00:00:01",
+											InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+										}},
+									},
+								},
+								Volumes: []v1.Volume{
+									{
+										Name: "rootdisk",
+										VolumeSource: v1.VolumeSource{
+											DataVolume: &v1.DataVolumeSource{Name: "source-vm-rootdisk"},
+										},
+									},
+									{
+										Name: "datadisk",
+										VolumeSource: v1.VolumeSource{
+											PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+												PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "source-data"},
+											},
+										},
+									},
+									{
+										Name: "containerdisk",
+										VolumeSource: v1.VolumeSource{
+											ContainerDisk: &v1.ContainerDiskSource{Image: "my-containerdisk"},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			}
+
+			BeforeEach(func() {
+				kubeClient = k8sfake.NewSimpleClientset(&k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "source-data", Namespace: sourceNamespace},
+					Spec: k8sv1.PersistentVolumeClaimSpec{
+						Resources: k8sv1.VolumeResourceRequirements{
+							Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("5Gi")},
+						},
+					},
+				})
+				virtClient = kubevirtfake.NewSimpleClientset(newSourceVM())
+
+				origGetKubevirtClientFromClientConfig := kubecli.GetKubevirtClientFromClientConfig
+				kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+				kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+				kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(sourceNamespace).
+					Return(virtClient.KubevirtV1().VirtualMachines(sourceNamespace)).AnyTimes()
+				kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+				DeferCleanup(func() {
+					kubecli.GetKubevirtClientFromClientConfig = origGetKubevirtClientFromClientConfig
+				})
+			})
+
+			It("should copy the spec and reset the identity of the source VM", func() {
+				out, err := runCmd(
+					setFlag(NameFlag, vmName),
+					setFlag(FromVMFlag, sourceNamespace+"/"+sourceName),
+				)
+				Expect(err).ToNot(HaveOccurred())
+				vm, err := decodeVM(out)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(vm.Name).To(Equal(vmName))
+				Expect(vm.Labels).To(Equal(map[string]string{"app": "source"}))
+				Expect(vm.Annotations).To(BeEmpty())
+				Expect(vm.Spec.RunStrategy).To(PointTo(Equal(v1.RunStrategyHalted)))
+				Expect(vm.Spec.Instancetype.Name).To(Equal("u1.small"))
+				Expect(vm.Spec.Instancetype.RevisionName).To(BeEmpty())
+				Expect(vm.Spec.Template.Spec.Domain.Memory).To(BeNil())
+				Expect(vm.Spec.Preference).To(BeNil())
+
+				Expect(vm.Spec.Template.Spec.Hostname).To(BeEmpty())
+				Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(BeEmpty())
+				Expect(vm.Spec.Template.Spec.Domain.Firmware.UUID).To(BeEmpty())
+				Expect(vm.Spec.Template.Spec.Domain.Firmware.Serial).ToNot(BeEmpty())
+				Expect(vm.Spec.Template.Spec.Domain.Firmware.Serial).ToNot(Equal("source-serial"))
+			})
+
+			It("should clone the disks of the source VM by default", func() {
+				out, err := runCmd(
+					setFlag(NameFlag, vmName),
+					setFlag(FromVMFlag, sourceNamespace+"/"+sourceName),
+				)
+				Expect(err).ToNot(HaveOccurred())
+				vm, err := decodeVM(out)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(vm.Spec.DataVolumeTemplates).To(HaveLen(2))
+				Expect(vm.Spec.DataVolumeTemplates[0].Name).To(Equal(vmName + "-rootdisk"))
+				Expect(vm.Spec.DataVolumeTemplates[0].Spec.Source.PVC).To(PointTo(Equal(cdiv1.DataVolumeSourcePVC{
+					Namespace: sourceNamespace,
+					Name:      "source-vm-rootdisk",
+				})))
+				Expect(vm.Spec.DataVolumeTemplates[0].Spec.Storage.StorageClassName).To(PointTo(Equal("my-storage-class")))
+				Expect(vm.Spec.DataVolumeTemplates[0].Spec.Storage.Resources.Requests).To(HaveKeyWithValue(k8sv1.ResourceStorage, resource.MustParse("30Gi")))
+				Expect(vm.Spec.DataVolumeTemplates[1].Name).To(Equal(vmName + "-datadisk"))
+				Expect(vm.Spec.DataVolumeTemplates[1].Spec.Source.PVC).To(PointTo(Equal(cdiv1.DataVolumeSourcePVC{
+					Namespace: sourceNamespace,
+					Name:      "source-data",
+				})))
+				Expect(vm.Spec.DataVolumeTemplates[1].Spec.Storage.Resources.Requests).To(BeEmpty())
+
+				Expect(vm.Spec.Template.Spec.Volumes).To(HaveLen(3))
+				Expect(vm.Spec.Template.Spec.Volumes[0].DataVolume.Name).To(Equal(vmName + "-rootdisk"))
+				Expect(vm.Spec.Template.Spec.Volumes[1].DataVolume.Name).To(Equal(vmName + "-datadisk"))
+				Expect(vm.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim).To(BeNil())
+				Expect(vm.Spec.Template.Spec.Volumes[2].ContainerDisk.Image).To(Equal("my-containerdisk"))
+			})
+
+			It("should create blank disks of the same size", func() {
+				out, err := runCmd(
+					setFlag(NameFlag, vmName),
+					setFlag(FromVMFlag, sourceNamespace+"/"+sourceName),
+					setFlag(FromVMDisksFlag, "blank"),
+				)
+				Expect(err).ToNot(HaveOccurred())
+				vm, err := decodeVM(out)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(vm.Spec.DataVolumeTemplates).To(HaveLen(2))
+				for _, dvt := range vm.Spec.DataVolumeTemplates {
+					Expect(dvt.Spec.Source.Blank).ToNot(BeNil())
+					Expect(dvt.Spec.Source.PVC).To(BeNil())
+				}
+				Expect(vm.Spec.DataVolumeTemplates[0].Spec.Storage.Resources.Requests).To(HaveKeyWithValue(k8sv1.ResourceStorage, resource.MustParse("30Gi")))
+				Expect(vm.Spec.DataVolumeTemplates[1].Spec.Storage.Resources.Requests).To(HaveKeyWithValue(k8sv1.ResourceStorage, resource.MustParse("5Gi")))
+			})
+
+			It("should not reuse the boot order of the source VM for additional volumes", func() {
+				_, err := runCmd(
+					setFlag(FromVMFlag, sourceNamespace+"/"+sourceName),
+					setFlag(ContainerdiskVolumeFlag, "src:my.registry/my-image:my-tag,bootorder:1"),
+				)
+				Expect(err).To(MatchError("failed to parse \"--volume-containerdisk\" flag: bootorder 1 was specified multiple times"))
+			})
+
+			It("should fail if the source VM does not exist", func() {
+				_, err := runCmd(setFlag(FromVMFlag, sourceNamespace+"/does-not-exist"))
+				Expect(err).To(MatchError(ContainSubstring("failed to get VirtualMachine \"source-ns/does-not-exist\"")))
+			})
+
+			It("should fail with an invalid disks mode", func() {
+				_, err := runCmd(
+					setFlag(FromVMFlag, sourceNamespace+"/"+sourceName),
+					setFlag(FromVMDisksFlag, "keep"),
+				)
+				Expect(err).To(MatchError("failed to parse \"--from-vm-disks\" flag: invalid value \"keep\", supported values are: clone, blank"))
+			})
+
+			It("should fail if the disks mode is set without a source VM", func() {
+				_, err := runCmd(setFlag(FromVMDisksFlag, "blank"))
+				Expect(err).To(MatchError("failed to parse \"--from-vm-disks\" flag: requires --from-vm"))
+			})
+		})
+
+		Context("VM with templated files", func() {
+			const vmName = "my-vm"
+
+			writeFile := func(name, content string) string {
+				path := filepath.Join(GinkgoT().TempDir(), name)
+				Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+				return path
+			}
+
+			DescribeTable("should generate a Secret from the cloud-init file", func(cloudInit string, userDataSecretRef func(*v1.VirtualMachine) *k8sv1.LocalObjectReference) {
+				path := writeFile("user-data.yaml", "#cloud-config\nhostname: {{ .hostname }}\nuser: {{ .user }}")
+
+				out, err := runCmd(
+					setFlag(NameFlag, vmName),
+					setFlag(CloudInitFlag, cloudInit),
+					setFlag(CloudInitFileFlag, path),
+					setFlag(SetFlag, "hostname=my-host"),
+					setFlag(SetFlag, "user=fedora"),
+					setFlag("namespace", "my-ns"),
+				)
+				Expect(err).ToNot(HaveOccurred())
+				secrets, vm := decodeSecretsAndVM(out)
+
+				Expect(secrets).To(HaveLen(1))
+				Expect(secrets[0].Name).To(Equal(vmName + "-cloudinit"))
+				Expect(secrets[0].Namespace).To(Equal("my-ns"))
+				Expect(secrets[0].Data).To(HaveKeyWithValue("userdata", []byte("#cloud-config\nhostname: my-host\nuser: fedora")))
+
+				Expect(vm.Spec.Template.Spec.Volumes).To(HaveLen(1))
+				Expect(vm.Spec.Template.Spec.Volumes[0].Name).To(Equal(cloudInitDisk))
+				Expect(userDataSecretRef(vm)).To(Equal(&k8sv1.LocalObjectReference{Name: secrets[0].Name}))
+			},
+				Entry("with NoCloud", cloudInitNoCloud, func(vm *v1.VirtualMachine) *k8sv1.LocalObjectReference {
+					Expect(vm.Spec.Template.Spec.Volumes[0].CloudInitNoCloud).ToNot(BeNil())
+					return vm.Spec.Template.Spec.Volumes[0].CloudInitNoCloud.UserDataSecretRef
+				}),
+				Entry("with ConfigDrive", cloudInitConfigDrive, func(vm *v1.VirtualMachine) *k8sv1.LocalObjectReference {
+					Expect(vm.Spec.Template.Spec.Volumes[0].CloudInitConfigDrive).ToNot(BeNil())
+					return vm.Spec.Template.Spec.Volumes[0].CloudInitConfigDrive.UserDataSecretRef
+				}),
+			)
+
+			It("should generate a Secret from the sysprep file", func() {
+				path := writeFile("autounattend.xml", "<AdministratorPassword>{{ .password }}</AdministratorPassword>")
+
+				out, err := runCmd(
+					setFlag(NameFlag, vmName),
+					setFlag(SysprepFileFlag, path),
+					setFlag(SetFlag, "password=secret"),
+				)
+				Expect(err).ToNot(HaveOccurred())
+				secrets, vm := decodeSecretsAndVM(out)
+
+				Expect(secrets).To(HaveLen(1))
+				Expect(secrets[0].Name).To(Equal(vmName + "-sysprep"))
+				Expect(secrets[0].Data).To(HaveKeyWithValue("autounattend.xml", []byte("<AdministratorPassword>secret</AdministratorPassword>")))
+
+				Expect(vm.Spec.Template.Spec.Volumes).To(ConsistOf(v1.Volume{
+					Name: sysprepDisk,
+					VolumeSource: v1.VolumeSource{
+						Sysprep: &v1.SysprepSource{
+							Secret: &k8sv1.LocalObjectReference{Name: secrets[0].Name},
+						},
+					},
+				}))
+			})
+
+			It("should fail when a variable is not set", func() {
+				path := writeFile("user-data.yaml", "#cloud-config\nhostname: {{ .hostname }}")
+
+				_, err := runCmd(setFlag(CloudInitFileFlag, path))
+				Expect(err).To(MatchError(ContainSubstring("map has no entry for key \"hostname\"")))
+			})
+
+			It("should fail when a variable is invalid", func() {
+				path := writeFile("user-data.yaml", "#cloud-config")
+
+				_, err := runCmd(setFlag(CloudInitFileFlag, path), setFlag(SetFlag, "novalue"))
+				Expect(err).To(MatchError("failed to parse \"--set\" flag: invalid variable \"novalue\", expected key=value"))
+			})
+
+			It("should fail when variables are set without a file", func() {
+				_, err := runCmd(setFlag(SetFlag, "key=value"))
+				Expect(err).To(MatchError("failed to parse \"--set\" flag: requires --cloud-init-file or --sysprep-file"))
+			})
+
+			It("should fail when the file does not exist", func() {
+				_, err := runCmd(setFlag(SysprepFileFlag, filepath.Join(GinkgoT().TempDir(), "does-not-exist")))
+				Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+			})
+
+			It("should fail when the sysprep file is combined with a sysprep volume", func() {
+				_, err := runCmd(setFlag(SysprepFileFlag, "file"), setFlag(SysprepVolumeFlag, "src:my-cm"))
+				Expect(err).To(MatchError("if any flags in the group [volume-sysprep sysprep-file] are set none of the others can be; [sysprep-file volume-sysprep] were all set"))
+			})
+		})
+
 		DescribeTable("VM with blank disk", func(params, blankName string) {
 			const size = "10Gi"
 
@@ -1781,6 +2257,61 @@ chpasswd: { expire: False }`
 			),
 		)
 
+		DescribeTable("Invalid parameters to GPUFlag", func(errMsg string, flags ...string) {
+			out, err := runCmd(flags...)
+			Expect(err).To(MatchError(errMsg))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Missing devicename", "failed to parse \"--gpu\" flag: devicename must be specified", setFlag(GPUFlag, "name:my-gpu")),
+			Entry("Unknown param", "failed to parse \"--gpu\" flag: unknown param(s): test:test", setFlag(GPUFlag, "devicename:my-dev,test:test")),
+			Entry("Duplicate name", "failed to parse \"--gpu\" flag: there is already a gpu with name \"my-gpu\"",
+				setFlag(GPUFlag, "devicename:my-dev,name:my-gpu"),
+				setFlag(GPUFlag, "devicename:my-dev,name:my-gpu"),
+			),
+		)
+
+		DescribeTable("Invalid parameters to HostDeviceFlag", func(errMsg string, flags ...string) {
+			out, err := runCmd(flags...)
+			Expect(err).To(MatchError(errMsg))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Missing devicename", "failed to parse \"--host-device\" flag: devicename must be specified", setFlag(HostDeviceFlag, "name:my-hd")),
+			Entry("Duplicate name", "failed to parse \"--host-device\" flag: there is already a host device with name \"my-hd\"",
+				setFlag(HostDeviceFlag, "devicename:my-dev,name:my-hd"),
+				setFlag(HostDeviceFlag, "devicename:my-dev,name:my-hd"),
+			),
+		)
+
+		DescribeTable("Invalid parameters to NetworkFlag", func(errMsg string, flags ...string) {
+			out, err := runCmd(flags...)
+			Expect(err).To(MatchError(errMsg))
+			Expect(out).To(BeEmpty())
+		},
+			Entry("Invalid type", "failed to parse \"--network\" flag: invalid network type \"test\", supported values are: masquerade, bridge, sriov",
+				setFlag(NetworkFlag, "type:test"),
+			),
+			Entry("Masquerade with multus network", "failed to parse \"--network\" flag: type masquerade can only be used with the pod network",
+				setFlag(NetworkFlag, "type:masquerade,src:my-nad"),
+			),
+			Entry("SR-IOV with pod network", "failed to parse \"--network\" flag: type sriov can only be used with a Multus network (src)",
+				setFlag(NetworkFlag, "type:sriov"),
+			),
+			Entry("Invalid src", "failed to parse \"--network\" flag: src invalid: "+invalidSlashCountError,
+				setFlag(NetworkFlag, "src:a/b/c"),
+			),
+			Entry("Invalid name", "failed to parse \"--network\" flag: "+nameUpperCaseError,
+				setFlag(NetworkFlag, "name:NOTALLOWED"),
+			),
+			Entry("Duplicate name", "failed to parse \"--network\" flag: there is already a network with name \"my-net\"",
+				setFlag(NetworkFlag, "name:my-net,src:my-nad1"),
+				setFlag(NetworkFlag, "name:my-net,src:my-nad2"),
+			),
+			Entry("Duplicate pod network", "failed to parse \"--network\" flag: the pod network can only be added once",
+				setFlag(NetworkFlag, "name:pod1"),
+				setFlag(NetworkFlag, "name:pod2"),
+			),
+		)
+
 		It("Duplicate boot orders are not allowed", func() {
 			out, err := runCmd(
 				setFlag(ContainerdiskVolumeFlag, "src:my.registry/my-image:my-tag,bootorder:1"),
@@ -1893,6 +2424,20 @@ func decodeVM(bytes []byte) (*v1.VirtualMachine, error) {
 	}
 }
 
+func decodeSecretsAndVM(bytes []byte) ([]*k8sv1.Secret, *v1.VirtualMachine) {
+	docs := strings.Split(string(bytes), "---\n")
+	var secrets []*k8sv1.Secret
+	for _, doc := range docs[:len(docs)-1] {
+		secret := &k8sv1.Secret{}
+		Expect(yaml.Unmarshal([]byte(doc), secret)).To(Succeed())
+		Expect(secret.Kind).To(Equal("Secret"))
+		secrets = append(secrets, secret)
+	}
+	vm, err := decodeVM([]byte(docs[len(docs)-1]))
+	Expect(err).ToNot(HaveOccurred())
+	return secrets, vm
+}
+
 func noCloudUserData(vm *v1.VirtualMachine) string {
 	Expect(vm.Spec.Template.Spec.Volumes[0].VolumeSource.CloudInitConfigDrive).To(BeNil())
 	Expect(vm.Spec.Template.Spec.Volumes[0].VolumeSource.CloudInitNoCloud).ToNot(BeNil())
@@ -17,6 +17,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
 	"kubevirt.io/kubevirt/pkg/virtctl/credentials/common"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -127,6 +128,7 @@ func (a *addSSHKeyFlags) addSecretWithSSHKey(
 	if err != nil {
 		return fmt.Errorf("error creating secret: %w", err)
 	}
+	result.RecordChange(cmd.Context(), result.Change{Action: "Create", Kind: "Secret", Namespace: vm.Namespace, Name: secret.Name})
 	const (
 		accessCredentialPath      = "/spec/template/spec/accessCredentials"   // #nosec
 		accessCredentialArrayPath = "/spec/template/spec/accessCredentials/-" // #nosec
@@ -142,6 +144,7 @@ func (a *addSSHKeyFlags) addSecretWithSSHKey(
 		types.JSONPatchType,
 		accessCredentialPatch,
 		metav1.PatchOptions{}); err == nil {
+		recordVMUpdate(cmd, vm)
 		return nil
 	}
 
@@ -158,10 +161,15 @@ func (a *addSSHKeyFlags) addSecretWithSSHKey(
 		fullPatch, metav1.PatchOptions{}); err != nil {
 		return fmt.Errorf("error patching virtual machine: %w", err)
 	}
+	recordVMUpdate(cmd, vm)
 
 	return nil
 }
 
+func recordVMUpdate(cmd *cobra.Command, vm *v1.VirtualMachine) {
+	result.RecordChange(cmd.Context(), result.Change{Action: "Update", Kind: v1.VirtualMachineGroupVersionKind.Kind, Namespace: vm.Namespace, Name: vm.Name})
+}
+
 func (a *addSSHKeyFlags) updateSecretWithSSHKey(
 	cmd *cobra.Command,
 	cli kubecli.KubevirtClient,
@@ -222,6 +230,7 @@ func (a *addSSHKeyFlags) updateSecretWithSSHKey(
 		}
 	}
 
+	result.RecordChange(cmd.Context(), result.Change{Action: "Update", Kind: "Secret", Namespace: vm.Namespace, Name: secretName})
 	cmd.Printf("Successfully added the key to secret \"%s\"", secretName)
 	return nil
 }
@@ -1,9 +1,17 @@
 package password
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -12,6 +20,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
 	"kubevirt.io/kubevirt/pkg/virtctl/credentials/common"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -20,7 +29,7 @@ func SetPasswordCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "set-password",
 		Short:   "Set password for a user",
-		Args:    cobra.ExactArgs(1),
+		Args:    cobra.MaximumNArgs(1),
 		Example: exampleUsage,
 		RunE:    cmdFlags.runSetPasswordCommand,
 	}
@@ -33,14 +42,28 @@ func SetPasswordCommand() *cobra.Command {
 const exampleUsage = `  # Set a user password for a virtual machine.
   {{ProgramName}} credentials set-password --user <username> --password <password> <vm-name>
 
+  # Set a user password for a virtual machine, prompting for the password.
+  {{ProgramName}} credentials set-password --user <username> --vm <vm-name>
+
+  # Set a user password taken from the "password" key of a kubernetes.io/basic-auth secret.
+  {{ProgramName}} credentials set-password --user <username> --password-from-secret <secret-name> <vm-name>
+
   # Set a user password in a secret that is not owned by the virtual machine.
   {{ProgramName}} credentials set-password --user <username> --password <password> --force <vm-name>
 `
 
+const (
+	passwordFlag           = "password"
+	passwordFromSecretFlag = "password-from-secret"
+	vmFlag                 = "vm"
+)
+
 type passwordCommandFlags struct {
 	common.CommandFlags
 
-	Password string
+	Password           string
+	PasswordFromSecret string
+	VM                 string
 
 	Force bool
 }
@@ -48,18 +71,28 @@ type passwordCommandFlags struct {
 func (p *passwordCommandFlags) AddToCommand(cmd *cobra.Command) {
 	p.CommandFlags.AddToCommand(cmd)
 
-	const passwordFlag = "password"
-	cmd.Flags().StringVarP(&p.Password, passwordFlag, "p", "", "Password for the user")
-	err := cmd.MarkFlagRequired(passwordFlag)
-	if err != nil {
-		panic(err)
-	}
+	cmd.Flags().StringVarP(&p.Password, passwordFlag, "p", "",
+		"Password for the user. If neither this nor --password-from-secret is set, the password is read from stdin.")
+	cmd.Flags().StringVar(&p.PasswordFromSecret, passwordFromSecretFlag, "",
+		fmt.Sprintf("Name of a secret in the namespace of the VM to read the password from, using its %q key.", k8sv1.BasicAuthPasswordKey))
+	cmd.MarkFlagsMutuallyExclusive(passwordFlag, passwordFromSecretFlag)
+
+	cmd.Flags().StringVar(&p.VM, vmFlag, "", "Name of the VM, as an alternative to passing it as argument.")
 
 	cmd.Flags().BoolVar(&p.Force, "force", false, "Force update of secret, even if it's not owned by the VM.")
 }
 
 func (p *passwordCommandFlags) runSetPasswordCommand(cmd *cobra.Command, args []string) error {
-	vmName := args[0]
+	vmName := p.VM
+	if len(args) == 1 {
+		if vmName != "" && vmName != args[0] {
+			return fmt.Errorf("conflicting VM names \"%s\" and \"%s\" given as argument and with --%s", args[0], vmName, vmFlag)
+		}
+		vmName = args[0]
+	}
+	if vmName == "" {
+		return fmt.Errorf("the VM name must be given as argument or with --%s", vmFlag)
+	}
 
 	cli, vmNamespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
@@ -92,8 +125,14 @@ func (p *passwordCommandFlags) runSetPasswordCommand(cmd *cobra.Command, args []
 			return fmt.Errorf("secret %s does not have an owner reference pointing to VM %s", secretName, vm.Name)
 		}
 	}
+
+	password, err := p.getPassword(cmd, cli.CoreV1().Secrets(vm.Namespace))
+	if err != nil {
+		return err
+	}
+
 	passwordPath := fmt.Sprintf("/data/%s", p.User)
-	addKeyPatch, err := patch.New(patch.WithAdd(passwordPath, []byte(p.Password))).GeneratePayload()
+	addKeyPatch, err := patch.New(patch.WithAdd(passwordPath, password)).GeneratePayload()
 	if err != nil {
 		return err
 	}
@@ -109,7 +148,7 @@ func (p *passwordCommandFlags) runSetPasswordCommand(cmd *cobra.Command, args []
 		fullPatch, err := patch.New(
 			patch.WithTest("/data", nil),
 			patch.WithAdd("/data", map[string][]byte{}),
-			patch.WithAdd(passwordPath, []byte(p.Password)),
+			patch.WithAdd(passwordPath, password),
 		).GeneratePayload()
 		if err != nil {
 			return err
@@ -120,10 +159,72 @@ func (p *passwordCommandFlags) runSetPasswordCommand(cmd *cobra.Command, args []
 		}
 	}
 
+	result.RecordChange(cmd.Context(), result.Change{Action: "Update", Kind: "Secret", Namespace: vm.Namespace, Name: secretName})
 	cmd.Printf("Successfully set password in secret \"%s\"", secretName)
 	return nil
 }
 
+func (p *passwordCommandFlags) getPassword(cmd *cobra.Command, secrets secretGetter) ([]byte, error) {
+	if p.Password != "" {
+		return []byte(p.Password), nil
+	}
+
+	if p.PasswordFromSecret != "" {
+		secret, err := secrets.Get(cmd.Context(), p.PasswordFromSecret, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting secret \"%s\": %w", p.PasswordFromSecret, err)
+		}
+		password := secret.Data[k8sv1.BasicAuthPasswordKey]
+		if len(password) == 0 {
+			return nil, fmt.Errorf("secret \"%s\" has no \"%s\" key", p.PasswordFromSecret, k8sv1.BasicAuthPasswordKey)
+		}
+		return password, nil
+	}
+
+	password, err := readPassword(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(password) == 0 {
+		return nil, fmt.Errorf("password must not be empty")
+	}
+	return password, nil
+}
+
+type secretGetter interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*k8sv1.Secret, error)
+}
+
+// readPassword prompts for the password twice without echo if stdin is a terminal,
+// otherwise it reads the first line of stdin.
+func readPassword(cmd *cobra.Command) ([]byte, error) {
+	in := cmd.InOrStdin()
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		cmd.PrintErr("Password: ")
+		password, err := term.ReadPassword(int(f.Fd()))
+		cmd.PrintErrln()
+		if err != nil {
+			return nil, fmt.Errorf("error reading password: %w", err)
+		}
+		cmd.PrintErr("Retype password: ")
+		retyped, err := term.ReadPassword(int(f.Fd()))
+		cmd.PrintErrln()
+		if err != nil {
+			return nil, fmt.Errorf("error reading password: %w", err)
+		}
+		if !bytes.Equal(password, retyped) {
+			return nil, fmt.Errorf("passwords do not match")
+		}
+		return password, nil
+	}
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error reading password: %w", err)
+	}
+	return []byte(strings.TrimRight(line, "\r\n")), nil
+}
+
 func getPasswordSecrets(accessCredentials []v1.AccessCredential) []string {
 	var result []string
 	for i := range accessCredentials {
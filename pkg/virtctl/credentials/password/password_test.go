@@ -2,6 +2,8 @@ package password_test
 
 import (
 	"context"
+	"io"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -20,6 +22,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/libvmi"
 	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virtctl"
 	"kubevirt.io/kubevirt/pkg/virtctl/testing"
 )
 
@@ -76,12 +79,118 @@ var _ = Describe("Credentials set-password", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
-	It("should fail if no password is specified", func() {
+	It("should read the password from stdin if no password is specified", func() {
+		err := runSetPasswordCommandWithIn(strings.NewReader(testPass+"\n"),
+			"--user", userName,
+			vmName,
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		expectSecretToContainUserWithPassword(kubeClient, secretName, userName, testPass)
+	})
+
+	It("should fail if the password read from stdin is empty", func() {
+		err := runSetPasswordCommandWithIn(strings.NewReader("\n"),
+			"--user", userName,
+			vmName,
+		)
+		Expect(err).To(MatchError(ContainSubstring("password must not be empty")))
+	})
+
+	It("should fail if --password and --password-from-secret are both set", func() {
+		err := runSetPasswordCommand(
+			"--user", userName,
+			"--password", testPass,
+			"--password-from-secret", "source-secret",
+			vmName,
+		)
+		Expect(err).To(MatchError(ContainSubstring("none of the others can be")))
+	})
+
+	Context("with --password-from-secret", func() {
+		const sourceSecretName = "source-secret"
+
+		It("should read the password from the secret", func() {
+			sourceSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      sourceSecretName,
+					Namespace: metav1.NamespaceDefault,
+				},
+				Type: corev1.SecretTypeBasicAuth,
+				Data: map[string][]byte{
+					corev1.BasicAuthUsernameKey: []byte("ignored"),
+					corev1.BasicAuthPasswordKey: []byte(testPass),
+				},
+			}
+			_, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(context.Background(), sourceSecret, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = runSetPasswordCommand(
+				"--user", userName,
+				"--password-from-secret", sourceSecretName,
+				vmName,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			expectSecretToContainUserWithPassword(kubeClient, secretName, userName, testPass)
+		})
+
+		It("should fail if the secret does not exist", func() {
+			err := runSetPasswordCommand(
+				"--user", userName,
+				"--password-from-secret", sourceSecretName,
+				vmName,
+			)
+			Expect(err).To(MatchError(ContainSubstring("\"source-secret\" not found")))
+		})
+
+		It("should fail if the secret has no password key", func() {
+			sourceSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      sourceSecretName,
+					Namespace: metav1.NamespaceDefault,
+				},
+				Data: map[string][]byte{"other": []byte(testPass)},
+			}
+			_, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(context.Background(), sourceSecret, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = runSetPasswordCommand(
+				"--user", userName,
+				"--password-from-secret", sourceSecretName,
+				vmName,
+			)
+			Expect(err).To(MatchError(ContainSubstring("secret \"source-secret\" has no \"password\" key")))
+		})
+	})
+
+	It("should accept the VM name with --vm", func() {
+		err := runSetPasswordCommand(
+			"--user", userName,
+			"--password", testPass,
+			"--vm", vmName,
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		expectSecretToContainUserWithPassword(kubeClient, secretName, userName, testPass)
+	})
+
+	It("should fail if no VM name is specified", func() {
 		err := runSetPasswordCommand(
 			"--user", userName,
+			"--password", testPass,
+		)
+		Expect(err).To(MatchError(ContainSubstring("the VM name must be given as argument or with --vm")))
+	})
+
+	It("should fail if conflicting VM names are specified", func() {
+		err := runSetPasswordCommand(
+			"--user", userName,
+			"--password", testPass,
+			"--vm", "other-vm",
 			vmName,
 		)
-		Expect(err).To(MatchError(ContainSubstring("required flag(s) \"password\" not set")))
+		Expect(err).To(MatchError(ContainSubstring("conflicting VM names")))
 	})
 
 	It("should fail if VMI or VM do not exist", func() {
@@ -213,3 +322,10 @@ func expectSecretToContainUserWithPassword(kubeClient kubernetes.Interface, secr
 func runSetPasswordCommand(args ...string) error {
 	return testing.NewRepeatableVirtctlCommand(append([]string{"credentials", "set-password"}, args...)...)()
 }
+
+func runSetPasswordCommandWithIn(in io.Reader, args ...string) error {
+	cmd := virtctl.NewVirtctlCommand()
+	cmd.SetArgs(append([]string{"credentials", "set-password"}, args...))
+	cmd.SetIn(in)
+	return cmd.Execute()
+}
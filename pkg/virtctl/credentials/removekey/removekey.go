@@ -15,6 +15,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
 	"kubevirt.io/kubevirt/pkg/virtctl/credentials/common"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 )
 
 func NewCommand() *cobra.Command {
@@ -143,7 +144,11 @@ func removeKeyFromSecret(
 		if errors.IsNotFound(err) {
 			return nil
 		}
-		return err
+		if err != nil {
+			return err
+		}
+		result.RecordChange(ctx, result.Change{Action: "Update", Kind: "Secret", Namespace: vm.Namespace, Name: secretName})
+		return nil
 	})
 }
 
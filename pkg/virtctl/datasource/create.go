@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+type createCommand struct {
+	source  sourceFlags
+	wait    bool
+	timeout time.Duration
+}
+
+func newCreateCommand() *cobra.Command {
+	c := createCommand{}
+	cmd := &cobra.Command{
+		Use:     "create (DATASOURCE)",
+		Short:   "Create a DataSource pointing to a PVC or VolumeSnapshot.",
+		Example: createUsage(),
+		Args:    cobra.ExactArgs(1),
+		RunE:    c.run,
+	}
+	c.source.addFlags(cmd)
+	addWaitFlags(cmd, &c.wait, &c.timeout)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func createUsage() string {
+	return `  # Create a DataSource named 'fedora' pointing to the PVC 'fedora-41'
+  {{ProgramName}} datasource create fedora --pvc=fedora-41
+
+  # Create a DataSource pointing to a VolumeSnapshot in another namespace and wait until it is ready
+  {{ProgramName}} datasource create fedora --snapshot=fedora-41 --source-namespace=images --wait`
+}
+
+func (c *createCommand) run(cmd *cobra.Command, args []string) error {
+	if err := validateTimeout(c.timeout); err != nil {
+		return result.NewUsageError(err)
+	}
+	name := args[0]
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	ds := &cdiv1.DataSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: cdiv1.DataSourceSpec{
+			Source: c.source.source(namespace),
+		},
+	}
+	if _, err := virtClient.CdiClient().CdiV1beta1().DataSources(namespace).Create(cmd.Context(), ds, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating DataSource %s: %w", name, err)
+	}
+	result.RecordChange(cmd.Context(), result.Change{
+		Action:    "Create",
+		Kind:      dataSourceKind,
+		Namespace: namespace,
+		Name:      name,
+	})
+	cmd.Printf("DataSource %s created, pointing to %s\n", name, describeSource(ds.Spec.Source))
+
+	if !c.wait {
+		return nil
+	}
+	return waitForDataSource(cmd, virtClient, namespace, name, c.timeout)
+}
+
+func waitForDataSource(cmd *cobra.Command, virtClient kubecli.KubevirtClient, namespace, name string, timeout time.Duration) error {
+	progress := &progressPrinter{out: cmd.OutOrStdout()}
+	err := virtwait.PollImmediately(pollInterval, timeout, func(ctx context.Context) (bool, error) {
+		ds, err := virtClient.CdiClient().CdiV1beta1().DataSources(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("error getting DataSource %s: %w", name, err)
+		}
+		progress.report(name, readiness(ds))
+		return isReady(ds), nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for DataSource %s: %w", name, err)
+	}
+	return nil
+}
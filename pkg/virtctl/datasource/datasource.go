@@ -0,0 +1,194 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package datasource
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	pvcFlag             = "pvc"
+	snapshotFlag        = "snapshot"
+	sourceNamespaceFlag = "source-namespace"
+	waitFlag            = "wait"
+	timeoutFlag         = "timeout"
+
+	defaultTimeout = 5 * time.Minute
+	pollInterval   = time.Second
+
+	dataSourceKind = "DataSource"
+
+	// previousSourceAnnotation holds the source a DataSource pointed to
+	// before it was last promoted, so that the promotion can be rolled back.
+	previousSourceAnnotation = "kubevirt.io/datasource-previous-source"
+
+	none = "<none>"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "datasource",
+		Short: "List, create, promote and roll back golden image DataSources.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(
+		newListCommand(),
+		newCreateCommand(),
+		newPromoteCommand(),
+		newRollbackCommand(),
+	)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+// sourceFlags selects the PVC or VolumeSnapshot a DataSource points to.
+type sourceFlags struct {
+	pvc             string
+	snapshot        string
+	sourceNamespace string
+}
+
+func (s *sourceFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&s.pvc, pvcFlag, "", "Name of the PVC the DataSource should point to.")
+	cmd.Flags().StringVar(&s.snapshot, snapshotFlag, "", "Name of the VolumeSnapshot the DataSource should point to.")
+	cmd.Flags().StringVar(&s.sourceNamespace, sourceNamespaceFlag, "", "Namespace of the PVC or VolumeSnapshot. Defaults to the namespace of the DataSource.")
+	cmd.MarkFlagsOneRequired(pvcFlag, snapshotFlag)
+	cmd.MarkFlagsMutuallyExclusive(pvcFlag, snapshotFlag)
+}
+
+func (s *sourceFlags) source(namespace string) cdiv1.DataSourceSource {
+	if s.sourceNamespace != "" {
+		namespace = s.sourceNamespace
+	}
+	if s.snapshot != "" {
+		return cdiv1.DataSourceSource{
+			Snapshot: &cdiv1.DataVolumeSourceSnapshot{Name: s.snapshot, Namespace: namespace},
+		}
+	}
+	return cdiv1.DataSourceSource{
+		PVC: &cdiv1.DataVolumeSourcePVC{Name: s.pvc, Namespace: namespace},
+	}
+}
+
+func addWaitFlags(cmd *cobra.Command, wait *bool, timeout *time.Duration) {
+	cmd.Flags().BoolVar(wait, waitFlag, false, "Wait until the DataSource is ready and report progress while waiting.")
+	cmd.Flags().DurationVar(timeout, timeoutFlag, defaultTimeout, "The maximum time to wait when --wait is set.")
+}
+
+func validateTimeout(timeout time.Duration) error {
+	if timeout <= 0 {
+		return fmt.Errorf("the timeout must be greater than zero")
+	}
+	return nil
+}
+
+func describeSource(source cdiv1.DataSourceSource) string {
+	switch {
+	case source.PVC != nil:
+		return fmt.Sprintf("pvc %s/%s", source.PVC.Namespace, source.PVC.Name)
+	case source.Snapshot != nil:
+		return fmt.Sprintf("snapshot %s/%s", source.Snapshot.Namespace, source.Snapshot.Name)
+	case source.DataSource != nil:
+		return fmt.Sprintf("datasource %s/%s", source.DataSource.Namespace, source.DataSource.Name)
+	}
+	return none
+}
+
+func previousSource(ds *cdiv1.DataSource) (*cdiv1.DataSourceSource, error) {
+	value, ok := ds.Annotations[previousSourceAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	source := &cdiv1.DataSourceSource{}
+	if err := json.Unmarshal([]byte(value), source); err != nil {
+		return nil, fmt.Errorf("error parsing the previous source of DataSource %s: %w", ds.Name, err)
+	}
+	return source, nil
+}
+
+// replaceSource points ds at source and remembers the current source as
+// the previous revision.
+func replaceSource(ds *cdiv1.DataSource, source cdiv1.DataSourceSource) error {
+	current, err := json.Marshal(ds.Spec.Source)
+	if err != nil {
+		return err
+	}
+	if ds.Annotations == nil {
+		ds.Annotations = map[string]string{}
+	}
+	ds.Annotations[previousSourceAnnotation] = string(current)
+	ds.Spec.Source = source
+	return nil
+}
+
+func findReadyCondition(ds *cdiv1.DataSource) *cdiv1.DataSourceCondition {
+	for i := range ds.Status.Conditions {
+		if ds.Status.Conditions[i].Type == cdiv1.DataSourceReady {
+			return &ds.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func isReady(ds *cdiv1.DataSource) bool {
+	condition := findReadyCondition(ds)
+	return condition != nil && condition.Status == k8sv1.ConditionTrue
+}
+
+func readiness(ds *cdiv1.DataSource) string {
+	condition := findReadyCondition(ds)
+	if condition == nil {
+		return "Pending"
+	}
+	state := "NotReady"
+	if condition.Status == k8sv1.ConditionTrue {
+		state = "Ready"
+	}
+	if condition.Reason != "" {
+		return fmt.Sprintf("%s (%s)", state, condition.Reason)
+	}
+	return state
+}
+
+// progressPrinter prints a line whenever the readiness of a DataSource
+// changes, so that polling does not repeat identical output.
+type progressPrinter struct {
+	out  io.Writer
+	last string
+}
+
+func (p *progressPrinter) report(name, progress string) {
+	if progress == p.last {
+		return
+	}
+	p.last = progress
+	fmt.Fprintf(p.out, "%s %s: %s\n", dataSourceKind, name, progress)
+}
@@ -0,0 +1,225 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package datasource_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	cdifake "kubevirt.io/client-go/containerizeddataimporter/fake"
+	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/imageupload"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("DataSource command", func() {
+	const dsName = "fedora"
+
+	var (
+		cdiClient  *cdifake.Clientset
+		kubeClient *k8sfake.Clientset
+	)
+
+	BeforeEach(func() {
+		cdiClient = cdifake.NewSimpleClientset()
+		kubeClient = k8sfake.NewSimpleClientset()
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+	})
+
+	newDataSource := func(name, pvcName string, ready bool) *cdiv1.DataSource {
+		status := k8sv1.ConditionFalse
+		if ready {
+			status = k8sv1.ConditionTrue
+		}
+		return &cdiv1.DataSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: cdiv1.DataSourceSpec{
+				Source: cdiv1.DataSourceSource{
+					PVC: &cdiv1.DataVolumeSourcePVC{Name: pvcName, Namespace: metav1.NamespaceDefault},
+				},
+			},
+			Status: cdiv1.DataSourceStatus{
+				Conditions: []cdiv1.DataSourceCondition{{
+					Type:           cdiv1.DataSourceReady,
+					ConditionState: cdiv1.ConditionState{Status: status},
+				}},
+			},
+		}
+	}
+
+	createPVC := func(name string, phase k8sv1.PersistentVolumeClaimPhase, annotations map[string]string) {
+		pvc := &k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   metav1.NamespaceDefault,
+				Annotations: annotations,
+			},
+			Status: k8sv1.PersistentVolumeClaimStatus{Phase: phase},
+		}
+		_, err := kubeClient.CoreV1().PersistentVolumeClaims(metav1.NamespaceDefault).Create(context.Background(), pvc, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	createDataSource := func(ds *cdiv1.DataSource) {
+		_, err := cdiClient.CdiV1beta1().DataSources(metav1.NamespaceDefault).Create(context.Background(), ds, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getDataSource := func() *cdiv1.DataSource {
+		ds, err := cdiClient.CdiV1beta1().DataSources(metav1.NamespaceDefault).Get(context.Background(), dsName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return ds
+	}
+
+	Context("list", func() {
+		It("should report that no DataSources exist", func() {
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("datasource", "list")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal("No DataSources found in namespace default\n"))
+		})
+
+		It("should list the DataSources with their source, readiness and previous source", func() {
+			createDataSource(newDataSource("centos", "centos-9", false))
+			ds := newDataSource(dsName, "fedora-42", true)
+			ds.Annotations = map[string]string{
+				"kubevirt.io/datasource-previous-source": `{"pvc":{"namespace":"default","name":"fedora-41"}}`,
+			}
+			createDataSource(ds)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("datasource", "list")()
+			Expect(err).ToNot(HaveOccurred())
+			lines := string(out)
+			Expect(lines).To(MatchRegexp(`NAME\s+SOURCE\s+READY\s+PREVIOUS`))
+			Expect(lines).To(MatchRegexp(`centos\s+pvc default/centos-9\s+NotReady\s+<none>`))
+			Expect(lines).To(MatchRegexp(`fedora\s+pvc default/fedora-42\s+Ready\s+pvc default/fedora-41`))
+		})
+	})
+
+	Context("create", func() {
+		It("should create a DataSource pointing to a PVC", func() {
+			Expect(testing.NewRepeatableVirtctlCommand("datasource", "create", dsName, "--pvc", "fedora-41")()).To(Succeed())
+			ds := getDataSource()
+			Expect(ds.Spec.Source.PVC).To(Equal(&cdiv1.DataVolumeSourcePVC{Name: "fedora-41", Namespace: metav1.NamespaceDefault}))
+		})
+
+		It("should create a DataSource pointing to a VolumeSnapshot in another namespace", func() {
+			Expect(testing.NewRepeatableVirtctlCommand("datasource", "create", dsName,
+				"--snapshot", "fedora-41", "--source-namespace", "images")()).To(Succeed())
+			ds := getDataSource()
+			Expect(ds.Spec.Source.Snapshot).To(Equal(&cdiv1.DataVolumeSourceSnapshot{Name: "fedora-41", Namespace: "images"}))
+		})
+
+		It("should fail if the DataSource already exists", func() {
+			createDataSource(newDataSource(dsName, "fedora-41", true))
+			err := testing.NewRepeatableVirtctlCommand("datasource", "create", dsName, "--pvc", "fedora-41")()
+			Expect(err).To(MatchError(ContainSubstring("already exists")))
+		})
+
+		DescribeTable("should reject invalid flags", func(expected string, args ...string) {
+			err := testing.NewRepeatableVirtctlCommand(append([]string{"datasource", "create", dsName}, args...)...)()
+			Expect(err).To(MatchError(ContainSubstring(expected)))
+		},
+			Entry("without a source", "at least one of the flags in the group [pvc snapshot] is required"),
+			Entry("with both a PVC and a snapshot", "if any flags in the group [pvc snapshot] are set none of the others can be", "--pvc", "a", "--snapshot", "b"),
+			Entry("with a non-positive timeout", "the timeout must be greater than zero", "--pvc", "a", "--timeout", "0s"),
+		)
+	})
+
+	Context("promote", func() {
+		BeforeEach(func() {
+			createDataSource(newDataSource(dsName, "fedora-41", true))
+		})
+
+		It("should point the DataSource to the uploaded PVC and remember the previous source", func() {
+			createPVC("fedora-42", k8sv1.ClaimBound, map[string]string{imageupload.PodPhaseAnnotation: string(k8sv1.PodSucceeded)})
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("datasource", "promote", dsName, "--pvc", "fedora-42", "--wait")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("DataSource fedora promoted to pvc default/fedora-42"))
+			Expect(string(out)).To(ContainSubstring("DataSource fedora: Ready"))
+
+			ds := getDataSource()
+			Expect(ds.Spec.Source.PVC.Name).To(Equal("fedora-42"))
+			Expect(ds.Annotations).To(HaveKeyWithValue("kubevirt.io/datasource-previous-source",
+				`{"pvc":{"namespace":"default","name":"fedora-41"}}`))
+		})
+
+		DescribeTable("should refuse to promote", func(setup func(), expected string) {
+			setup()
+			err := testing.NewRepeatableVirtctlCommand("datasource", "promote", dsName, "--pvc", "fedora-42")()
+			Expect(err).To(MatchError(expected))
+			Expect(getDataSource().Spec.Source.PVC.Name).To(Equal("fedora-41"))
+		},
+			Entry("a missing PVC", func() {}, "PVC default/fedora-42 does not exist"),
+			Entry("an unbound PVC", func() {
+				createPVC("fedora-42", k8sv1.ClaimPending, nil)
+			}, "PVC default/fedora-42 is not bound"),
+			Entry("a PVC whose upload has not completed", func() {
+				createPVC("fedora-42", k8sv1.ClaimBound, map[string]string{imageupload.PodPhaseAnnotation: string(k8sv1.PodRunning)})
+			}, "the upload to PVC default/fedora-42 has not completed (phase Running)"),
+		)
+
+		It("should refuse to promote the current source", func() {
+			createPVC("fedora-41", k8sv1.ClaimBound, nil)
+			err := testing.NewRepeatableVirtctlCommand("datasource", "promote", dsName, "--pvc", "fedora-41")()
+			Expect(err).To(MatchError("DataSource fedora already points to pvc default/fedora-41"))
+		})
+	})
+
+	Context("rollback", func() {
+		It("should swap the current and the previous source", func() {
+			createDataSource(newDataSource(dsName, "fedora-41", true))
+			createPVC("fedora-42", k8sv1.ClaimBound, nil)
+			Expect(testing.NewRepeatableVirtctlCommand("datasource", "promote", dsName, "--pvc", "fedora-42")()).To(Succeed())
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("datasource", "rollback", dsName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("DataSource fedora rolled back to pvc default/fedora-41"))
+			ds := getDataSource()
+			Expect(ds.Spec.Source.PVC.Name).To(Equal("fedora-41"))
+			Expect(ds.Annotations).To(HaveKeyWithValue("kubevirt.io/datasource-previous-source",
+				`{"pvc":{"namespace":"default","name":"fedora-42"}}`))
+
+			Expect(testing.NewRepeatableVirtctlCommand("datasource", "rollback", dsName)()).To(Succeed())
+			Expect(getDataSource().Spec.Source.PVC.Name).To(Equal("fedora-42"))
+		})
+
+		It("should fail if there is no previous source", func() {
+			createDataSource(newDataSource(dsName, "fedora-41", true))
+			err := testing.NewRepeatableVirtctlCommand("datasource", "rollback", dsName)()
+			Expect(err).To(MatchError("DataSource fedora has no previous source to roll back to"))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package datasource
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+func newListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List the DataSources in a namespace.",
+		Example: listUsage(),
+		Args:    cobra.NoArgs,
+		RunE:    runList,
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func listUsage() string {
+	return `  # List the DataSources in the 'golden-images' namespace
+  {{ProgramName}} datasource list -n golden-images`
+}
+
+func runList(cmd *cobra.Command, _ []string) error {
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	list, err := virtClient.CdiClient().CdiV1beta1().DataSources(namespace).List(cmd.Context(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing DataSources in namespace %s: %w", namespace, err)
+	}
+	if len(list.Items) == 0 {
+		cmd.Printf("No DataSources found in namespace %s\n", namespace)
+		return nil
+	}
+	sort.SliceStable(list.Items, func(i, j int) bool {
+		return list.Items[i].Name < list.Items[j].Name
+	})
+	return printDataSources(cmd.OutOrStdout(), list.Items)
+}
+
+func printDataSources(out io.Writer, dataSources []cdiv1.DataSource) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSOURCE\tREADY\tPREVIOUS")
+	for i := range dataSources {
+		ds := &dataSources[i]
+		previous := none
+		source, err := previousSource(ds)
+		if err != nil {
+			return err
+		}
+		if source != nil {
+			previous = describeSource(*source)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ds.Name, describeSource(ds.Spec.Source), readiness(ds), previous)
+	}
+	return w.Flush()
+}
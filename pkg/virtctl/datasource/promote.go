@@ -0,0 +1,129 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/imageupload"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+type promoteCommand struct {
+	source  sourceFlags
+	wait    bool
+	timeout time.Duration
+}
+
+func newPromoteCommand() *cobra.Command {
+	c := promoteCommand{}
+	cmd := &cobra.Command{
+		Use:     "promote (DATASOURCE)",
+		Short:   "Point an existing DataSource to a new PVC or VolumeSnapshot, keeping the current one for rollback.",
+		Example: promoteUsage(),
+		Args:    cobra.ExactArgs(1),
+		RunE:    c.run,
+	}
+	c.source.addFlags(cmd)
+	addWaitFlags(cmd, &c.wait, &c.timeout)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func promoteUsage() string {
+	return `  # Promote the freshly uploaded PVC 'fedora-42' to be the source of the DataSource 'fedora'
+  {{ProgramName}} datasource promote fedora --pvc=fedora-42
+
+  # Promote a VolumeSnapshot and wait until the DataSource is ready
+  {{ProgramName}} datasource promote fedora --snapshot=fedora-42 --wait`
+}
+
+func (c *promoteCommand) run(cmd *cobra.Command, args []string) error {
+	if err := validateTimeout(c.timeout); err != nil {
+		return result.NewUsageError(err)
+	}
+	name := args[0]
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	source := c.source.source(namespace)
+	if source.PVC != nil {
+		if err := checkPVCReady(cmd.Context(), virtClient, source.PVC); err != nil {
+			return err
+		}
+	}
+
+	ds, err := virtClient.CdiClient().CdiV1beta1().DataSources(namespace).Get(cmd.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting DataSource %s: %w", name, err)
+	}
+	if describeSource(ds.Spec.Source) == describeSource(source) {
+		return fmt.Errorf("DataSource %s already points to %s", name, describeSource(source))
+	}
+	if err := replaceSource(ds, source); err != nil {
+		return err
+	}
+	if _, err := virtClient.CdiClient().CdiV1beta1().DataSources(namespace).Update(cmd.Context(), ds, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating DataSource %s: %w", name, err)
+	}
+	result.RecordChange(cmd.Context(), result.Change{
+		Action:    "Update",
+		Kind:      dataSourceKind,
+		Namespace: namespace,
+		Name:      name,
+	})
+	cmd.Printf("DataSource %s promoted to %s\n", name, describeSource(source))
+
+	if !c.wait {
+		return nil
+	}
+	return waitForDataSource(cmd, virtClient, namespace, name, c.timeout)
+}
+
+// checkPVCReady refuses to promote a PVC that is not bound or whose upload
+// has not completed yet.
+func checkPVCReady(ctx context.Context, virtClient kubecli.KubevirtClient, source *cdiv1.DataVolumeSourcePVC) error {
+	pvc, err := virtClient.CoreV1().PersistentVolumeClaims(source.Namespace).Get(ctx, source.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return fmt.Errorf("PVC %s/%s does not exist", source.Namespace, source.Name)
+	} else if err != nil {
+		return fmt.Errorf("error getting PVC %s/%s: %w", source.Namespace, source.Name, err)
+	}
+	if pvc.Status.Phase != k8sv1.ClaimBound {
+		return fmt.Errorf("PVC %s/%s is not bound", source.Namespace, source.Name)
+	}
+	if phase, ok := pvc.Annotations[imageupload.PodPhaseAnnotation]; ok && phase != string(k8sv1.PodSucceeded) {
+		return fmt.Errorf("the upload to PVC %s/%s has not completed (phase %s)", source.Namespace, source.Name, phase)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package datasource
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+type rollbackCommand struct {
+	wait    bool
+	timeout time.Duration
+}
+
+func newRollbackCommand() *cobra.Command {
+	c := rollbackCommand{}
+	cmd := &cobra.Command{
+		Use:     "rollback (DATASOURCE)",
+		Short:   "Point a DataSource back to the source it had before it was last promoted.",
+		Example: rollbackUsage(),
+		Args:    cobra.ExactArgs(1),
+		RunE:    c.run,
+	}
+	addWaitFlags(cmd, &c.wait, &c.timeout)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func rollbackUsage() string {
+	return `  # Roll the DataSource 'fedora' back to its previous source
+  {{ProgramName}} datasource rollback fedora`
+}
+
+func (c *rollbackCommand) run(cmd *cobra.Command, args []string) error {
+	if err := validateTimeout(c.timeout); err != nil {
+		return result.NewUsageError(err)
+	}
+	name := args[0]
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	ds, err := virtClient.CdiClient().CdiV1beta1().DataSources(namespace).Get(cmd.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting DataSource %s: %w", name, err)
+	}
+	previous, err := previousSource(ds)
+	if err != nil {
+		return err
+	}
+	if previous == nil {
+		return fmt.Errorf("DataSource %s has no previous source to roll back to", name)
+	}
+	// Swapping the sources lets a rollback itself be undone by another rollback.
+	if err := replaceSource(ds, *previous); err != nil {
+		return err
+	}
+	if _, err := virtClient.CdiClient().CdiV1beta1().DataSources(namespace).Update(cmd.Context(), ds, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating DataSource %s: %w", name, err)
+	}
+	result.RecordChange(cmd.Context(), result.Change{
+		Action:    "Update",
+		Kind:      dataSourceKind,
+		Namespace: namespace,
+		Name:      name,
+	})
+	cmd.Printf("DataSource %s rolled back to %s\n", name, describeSource(*previous))
+
+	if !c.wait {
+		return nil
+	}
+	return waitForDataSource(cmd, virtClient, namespace, name, c.timeout)
+}
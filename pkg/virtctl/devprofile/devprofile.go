@@ -0,0 +1,180 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+// Package devprofile makes the CDI upload proxy and the KubeVirt export proxy of
+// local development clusters (e.g. kubevirtci or minikube) reachable through
+// port-forwarding, so commands work without Ingress, Route or LoadBalancer
+// configuration. The KubeVirt API itself is served by the Kubernetes API server
+// and is already reachable through the kubeconfig.
+package devprofile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"k8s.io/kubectl/pkg/util"
+
+	"kubevirt.io/client-go/kubecli"
+)
+
+const (
+	// Flag is the name of the flag enabling the development profile
+	Flag = "dev"
+	// Usage is the help text of the flag enabling the development profile
+	Usage = "Use the development profile: discover in-cluster endpoints of local clusters (e.g. kubevirtci, minikube) " +
+		"and reach them through port-forwarding instead of Ingress/Route/LoadBalancer."
+
+	uploadProxySelector = "cdi.kubevirt.io=cdi-uploadproxy"
+	uploadProxyPort     = 443
+
+	exportProxySelector = "kubevirt.io=virt-exportproxy"
+	exportProxyPort     = 443
+
+	readyTimeout = 30 * time.Second
+)
+
+// PortForwardFn allows overriding the default port-forwarder (useful for unit testing)
+var PortForwardFn = PortForward
+
+// ForwardUploadProxyFn allows overriding the discovery of the upload proxy (useful for unit testing)
+var ForwardUploadProxyFn = ForwardUploadProxy
+
+// ForwardExportProxyFn allows overriding the discovery of the export proxy (useful for unit testing)
+var ForwardExportProxyFn = ForwardExportProxy
+
+// ForwardUploadProxy discovers the cdi-uploadproxy service and forwards a random local port to it.
+// It returns the URL of the forwarded upload proxy and a function to stop the forwarding.
+func ForwardUploadProxy(client kubecli.KubevirtClient) (string, func(), error) {
+	return forwardProxy(client, uploadProxySelector, uploadProxyPort)
+}
+
+// ForwardExportProxy discovers the virt-exportproxy service and forwards a random local port to it.
+// It returns the URL of the forwarded export proxy and a function to stop the forwarding.
+func ForwardExportProxy(client kubecli.KubevirtClient) (string, func(), error) {
+	return forwardProxy(client, exportProxySelector, exportProxyPort)
+}
+
+func forwardProxy(client kubecli.KubevirtClient, selector string, port int32) (string, func(), error) {
+	service, err := discoverService(client, selector)
+	if err != nil {
+		return "", nil, err
+	}
+
+	localPort, stop, err := forwardService(client, service, port)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("https://127.0.0.1:%d", localPort), stop, nil
+}
+
+// discoverService returns the first service in any namespace matching the given selector
+func discoverService(client kubecli.KubevirtClient, selector string) (*k8sv1.Service, error) {
+	services, err := client.CoreV1().Services(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	if len(services.Items) == 0 {
+		return nil, fmt.Errorf("no service matching %s found", selector)
+	}
+	return &services.Items[0], nil
+}
+
+// forwardService forwards a random local port to the target port of the given service port on one of its pods
+func forwardService(client kubecli.KubevirtClient, service *k8sv1.Service, servicePort int32) (uint16, func(), error) {
+	podList, err := client.CoreV1().Pods(service.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+	})
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+	pod := firstRunningPod(podList.Items)
+	if pod == nil {
+		return 0, nil, fmt.Errorf("no running pods found for the service %s/%s", service.Namespace, service.Name)
+	}
+
+	targetPort, err := util.LookupContainerPortNumberByServicePort(*service, *pod, servicePort)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return PortForwardFn(client, pod, targetPort)
+}
+
+func firstRunningPod(pods []k8sv1.Pod) *k8sv1.Pod {
+	for i := range pods {
+		if pods[i].Status.Phase == k8sv1.PodRunning {
+			return &pods[i]
+		}
+	}
+	return nil
+}
+
+// PortForward forwards a random local port to the given port of a pod.
+// It returns the local port and a function to stop the forwarding.
+func PortForward(client kubecli.KubevirtClient, pod *k8sv1.Pod, port int32) (uint16, func(), error) {
+	req := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(client.Config())
+	if err != nil {
+		return 0, nil, err
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+
+	stopChan := make(chan struct{})
+	readyChan := make(chan struct{})
+	fw, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", port)}, stopChan, readyChan, io.Discard, io.Discard)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- fw.ForwardPorts()
+	}()
+
+	select {
+	case <-readyChan:
+	case err := <-errChan:
+		return 0, nil, fmt.Errorf("port-forwarding to pod %s/%s failed: %v", pod.Namespace, pod.Name, err)
+	case <-time.After(readyTimeout):
+		close(stopChan)
+		return 0, nil, fmt.Errorf("timeout waiting for port-forwarding to pod %s/%s to be ready", pod.Namespace, pod.Name)
+	}
+
+	ports, err := fw.GetPorts()
+	if err != nil {
+		close(stopChan)
+		return 0, nil, err
+	}
+
+	return ports[0].Local, func() { close(stopChan) }, nil
+}
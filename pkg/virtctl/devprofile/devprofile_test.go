@@ -0,0 +1,174 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package devprofile_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	fakek8sclient "k8s.io/client-go/kubernetes/fake"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/devprofile"
+)
+
+var _ = Describe("Development profile", func() {
+	const cdiNamespace = "cdi"
+
+	var (
+		kubeClient *fakek8sclient.Clientset
+		virtClient *kubecli.MockKubevirtClient
+		forwarded  *k8sv1.Pod
+		targetPort int32
+		stopped    bool
+	)
+
+	uploadProxyService := func() *k8sv1.Service {
+		return &k8sv1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cdi-uploadproxy",
+				Namespace: cdiNamespace,
+				Labels:    map[string]string{"cdi.kubevirt.io": "cdi-uploadproxy"},
+			},
+			Spec: k8sv1.ServiceSpec{
+				Selector: map[string]string{"cdi.kubevirt.io": "cdi-uploadproxy"},
+				Ports: []k8sv1.ServicePort{{
+					Port:       443,
+					TargetPort: intstr.FromString("https"),
+				}},
+			},
+		}
+	}
+
+	uploadProxyPod := func(name string, phase k8sv1.PodPhase) *k8sv1.Pod {
+		return &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: cdiNamespace,
+				Labels:    map[string]string{"cdi.kubevirt.io": "cdi-uploadproxy"},
+			},
+			Spec: k8sv1.PodSpec{
+				Containers: []k8sv1.Container{{
+					Name:  "cdi-uploadproxy",
+					Ports: []k8sv1.ContainerPort{{Name: "https", ContainerPort: 8443}},
+				}},
+			},
+			Status: k8sv1.PodStatus{Phase: phase},
+		}
+	}
+
+	BeforeEach(func() {
+		virtClient = kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		forwarded = nil
+		targetPort = 0
+		stopped = false
+
+		origPortForwardFn := devprofile.PortForwardFn
+		devprofile.PortForwardFn = func(_ kubecli.KubevirtClient, pod *k8sv1.Pod, port int32) (uint16, func(), error) {
+			forwarded = pod
+			targetPort = port
+			return 12345, func() { stopped = true }, nil
+		}
+		DeferCleanup(func() {
+			devprofile.PortForwardFn = origPortForwardFn
+		})
+	})
+
+	setupClient := func(objects ...runtime.Object) {
+		kubeClient = fakek8sclient.NewSimpleClientset(objects...)
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+	}
+
+	It("should forward a local port to a running upload proxy pod", func() {
+		setupClient(
+			uploadProxyService(),
+			uploadProxyPod("pending", k8sv1.PodPending),
+			uploadProxyPod("running", k8sv1.PodRunning),
+		)
+
+		url, stop, err := devprofile.ForwardUploadProxy(virtClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(url).To(Equal("https://127.0.0.1:12345"))
+		Expect(forwarded.Name).To(Equal("running"))
+		Expect(targetPort).To(Equal(int32(8443)))
+
+		stop()
+		Expect(stopped).To(BeTrue())
+	})
+
+	It("should fail if the upload proxy service does not exist", func() {
+		setupClient()
+
+		_, _, err := devprofile.ForwardUploadProxy(virtClient)
+		Expect(err).To(MatchError("no service matching cdi.kubevirt.io=cdi-uploadproxy found"))
+		Expect(forwarded).To(BeNil())
+	})
+
+	It("should forward a local port to a running export proxy pod", func() {
+		const kubevirtNamespace = "kubevirt"
+		setupClient(
+			&k8sv1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "virt-exportproxy",
+					Namespace: kubevirtNamespace,
+					Labels:    map[string]string{"kubevirt.io": "virt-exportproxy"},
+				},
+				Spec: k8sv1.ServiceSpec{
+					Selector: map[string]string{"kubevirt.io": "virt-exportproxy"},
+					Ports: []k8sv1.ServicePort{{
+						Port:       443,
+						TargetPort: intstr.FromInt32(8443),
+					}},
+				},
+			},
+			&k8sv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "virt-exportproxy",
+					Namespace: kubevirtNamespace,
+					Labels:    map[string]string{"kubevirt.io": "virt-exportproxy"},
+				},
+				Status: k8sv1.PodStatus{Phase: k8sv1.PodRunning},
+			},
+		)
+
+		url, stop, err := devprofile.ForwardExportProxy(virtClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(url).To(Equal("https://127.0.0.1:12345"))
+		Expect(forwarded.Name).To(Equal("virt-exportproxy"))
+		Expect(targetPort).To(Equal(int32(8443)))
+
+		stop()
+		Expect(stopped).To(BeTrue())
+	})
+
+	It("should fail if no upload proxy pod is running", func() {
+		setupClient(uploadProxyService(), uploadProxyPod("pending", k8sv1.PodPending))
+
+		_, _, err := devprofile.ForwardUploadProxy(virtClient)
+		Expect(err).To(MatchError("no running pods found for the service cdi/cdi-uploadproxy"))
+		Expect(forwarded).To(BeNil())
+	})
+})
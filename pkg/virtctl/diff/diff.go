@@ -0,0 +1,153 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	ignoreAddressesFlag = "ignore-addresses"
+
+	expectedLabel = "expected (generated from spec)"
+	liveLabel     = "live (libvirt)"
+)
+
+// deviceElements are the domain elements which are usually subject to hotplug
+var deviceElements = []string{"<disk", "<interface", "<hostdev", "<controller", "<filesystem"}
+
+type command struct {
+	ignoreAddresses bool
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show differences between the running state and the spec of a KubeVirt resource.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newVMICommand())
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func newVMICommand() *cobra.Command {
+	c := command{}
+	cmd := &cobra.Command{
+		Use:               "vmi (VMI)",
+		Short:             "Diff the live libvirt domain of a VirtualMachineInstance against the domain generated from its current spec.",
+		Example:           usage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		RunE:              c.run,
+	}
+	cmd.Flags().BoolVar(&c.ignoreAddresses, ignoreAddressesFlag, true, "Ignore device addresses, which libvirt assigns at define time.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	requirements.Subresources(cmd, "virtualmachineinstances/domain")
+	return cmd
+}
+
+func usage() string {
+	return `  # Show the drift between the spec and the live domain of a VirtualMachineInstance named 'my-vmi'
+  {{ProgramName}} diff vmi my-vmi
+
+  # Include device addresses in the comparison
+  {{ProgramName}} diff vmi my-vmi --ignore-addresses=false`
+}
+
+func (c *command) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	domain, err := virtClient.VirtualMachineInstance(namespace).Domain(cmd.Context(), name)
+	if err != nil {
+		return fmt.Errorf("error getting domain of VirtualMachineInstance %s: %v", name, err)
+	}
+
+	expected := c.lines(domain.Expected)
+	live := c.lines(domain.Live)
+	out, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        expected,
+		B:        live,
+		FromFile: expectedLabel,
+		ToFile:   liveLabel,
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		cmd.Printf("No drift detected for VirtualMachineInstance %s\n", name)
+		return nil
+	}
+
+	cmd.Print(out)
+	if touchesDevices(out) {
+		cmd.Println()
+		cmd.Println("Devices differ: this is usually caused by hotplug operations or out-of-band changes to the domain.")
+	}
+	return nil
+}
+
+func (c *command) lines(domainXML string) []string {
+	var lines []string
+	for _, line := range difflib.SplitLines(domainXML) {
+		if c.ignoreAddresses && strings.HasPrefix(strings.TrimSpace(line), "<address ") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func touchesDevices(unifiedDiff string) bool {
+	for _, line := range strings.Split(unifiedDiff, "\n") {
+		if !strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "-") {
+			continue
+		}
+		if strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---") {
+			continue
+		}
+		content := strings.TrimSpace(line[1:])
+		for _, element := range deviceElements {
+			if strings.HasPrefix(content, element+" ") || strings.HasPrefix(content, element+">") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package diff_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Diff command", func() {
+	const vmiName = "testvmi"
+
+	const expectedDomain = `<domain type="kvm">
+  <name>default_testvmi</name>
+  <devices>
+    <disk type="file" device="disk">
+      <source file="/var/run/kubevirt-private/vmi-disks/rootdisk/disk.img"></source>
+      <target bus="virtio" dev="vda"></target>
+    </disk>
+  </devices>
+</domain>`
+
+	const liveDomain = `<domain type="kvm">
+  <name>default_testvmi</name>
+  <devices>
+    <disk type="file" device="disk">
+      <source file="/var/run/kubevirt-private/vmi-disks/rootdisk/disk.img"></source>
+      <target bus="virtio" dev="vda"></target>
+      <address type="pci" domain="0x0000" bus="0x07" slot="0x00" function="0x0"></address>
+    </disk>
+  </devices>
+</domain>`
+
+	const liveDomainWithHotplug = `<domain type="kvm">
+  <name>default_testvmi</name>
+  <devices>
+    <disk type="file" device="disk">
+      <source file="/var/run/kubevirt-private/vmi-disks/rootdisk/disk.img"></source>
+      <target bus="virtio" dev="vda"></target>
+    </disk>
+    <disk type="block" device="disk">
+      <source dev="/var/run/kubevirt/hotplug-disks/data"></source>
+      <target bus="scsi" dev="sda"></target>
+    </disk>
+  </devices>
+</domain>`
+
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+	})
+
+	expectDomain := func(live string) {
+		vmiInterface.EXPECT().Domain(gomock.Any(), vmiName).Return(v1.VirtualMachineInstanceDomain{
+			Live:     live,
+			Expected: expectedDomain,
+		}, nil)
+	}
+
+	It("should require a VMI name", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("diff", "vmi")
+		Expect(cmd()).To(MatchError(ContainSubstring("accepts 1 arg(s), received 0")))
+	})
+
+	It("should report no drift when the domains are equal", func() {
+		expectDomain(expectedDomain)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("diff", "vmi", vmiName)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("No drift detected for VirtualMachineInstance testvmi\n"))
+	})
+
+	It("should ignore device addresses by default", func() {
+		expectDomain(liveDomain)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("diff", "vmi", vmiName)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("No drift detected"))
+	})
+
+	It("should show device addresses when requested", func() {
+		expectDomain(liveDomain)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("diff", "vmi", vmiName, "--ignore-addresses=false")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("--- expected (generated from spec)"))
+		Expect(string(out)).To(ContainSubstring("+++ live (libvirt)"))
+		Expect(string(out)).To(ContainSubstring(`+      <address type="pci"`))
+		Expect(string(out)).ToNot(ContainSubstring("Devices differ"))
+	})
+
+	It("should highlight hotplugged devices", func() {
+		expectDomain(liveDomainWithHotplug)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("diff", "vmi", vmiName)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring(`+    <disk type="block" device="disk">`))
+		Expect(string(out)).To(ContainSubstring(`+      <source dev="/var/run/kubevirt/hotplug-disks/data"></source>`))
+		Expect(string(out)).To(ContainSubstring("Devices differ"))
+	})
+
+	It("should fail when the domain cannot be fetched", func() {
+		vmiInterface.EXPECT().Domain(gomock.Any(), vmiName).Return(v1.VirtualMachineInstanceDomain{}, fmt.Errorf("VMI is not running"))
+
+		cmd := testing.NewRepeatableVirtctlCommand("diff", "vmi", vmiName)
+		Expect(cmd()).To(MatchError(ContainSubstring("VMI is not running")))
+	})
+
+})
@@ -0,0 +1,284 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	followFlag = "follow"
+
+	podKind                   = "Pod"
+	dataVolumeKind            = "DataVolume"
+	persistentVolumeClaimKind = "PersistentVolumeClaim"
+)
+
+type command struct {
+	follow bool
+}
+
+func NewCommand() *cobra.Command {
+	c := command{}
+	cmd := &cobra.Command{
+		Use:   "events (VM)",
+		Short: "Show the events of a VirtualMachine and its related objects.",
+		Long: `Show the events of a VirtualMachine and its related objects in one chronologically ordered view.
+
+Besides the events of the VirtualMachine itself, the events of its VirtualMachineInstance, launcher
+and hotplug pods, DataVolumes and their PersistentVolumeClaims and VirtualMachineInstanceMigrations
+are shown.`,
+		Example:           usage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.run,
+	}
+	cmd.Flags().BoolVarP(&c.follow, followFlag, "f", false, "Keep streaming new events after the existing events were shown.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Show the events related to a VirtualMachine named 'my-vm'
+  {{ProgramName}} events my-vm
+
+  # Show the events related to a VirtualMachine named 'my-vm' and keep streaming new events
+  {{ProgramName}} events my-vm --follow`
+}
+
+type objectReference struct {
+	kind string
+	name string
+}
+
+// relatedObjects tracks the objects whose events belong to a VirtualMachine
+type relatedObjects struct {
+	virtClient kubecli.KubevirtClient
+	namespace  string
+	vmName     string
+	objects    map[objectReference]struct{}
+}
+
+func (c *command) run(cmd *cobra.Command, args []string) error {
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	vm, err := virtClient.VirtualMachine(namespace).Get(ctx, args[0], metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting VirtualMachine %s: %v", args[0], err)
+	}
+	related := newRelatedObjects(virtClient, vm)
+	if err := related.refresh(ctx); err != nil {
+		return err
+	}
+
+	eventList, err := virtClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing events: %v", err)
+	}
+	var events []k8sv1.Event
+	for _, event := range eventList.Items {
+		if related.contains(event.InvolvedObject) {
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE")
+	for i := range events {
+		printEvent(w, &events[i])
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if !c.follow {
+		return nil
+	}
+
+	return followEvents(ctx, w, related, eventList.ResourceVersion)
+}
+
+// followEvents streams new events until the watch is closed. Events of pods and
+// migrations which are unknown yet trigger a refresh of the related objects, as
+// launcher pods and migrations are created while the VirtualMachine is running.
+func followEvents(ctx context.Context, w *tabwriter.Writer, related *relatedObjects, resourceVersion string) error {
+	watcher, err := related.virtClient.CoreV1().Events(related.namespace).Watch(ctx, metav1.ListOptions{
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("error watching events: %v", err)
+	}
+	defer watcher.Stop()
+
+	for watchEvent := range watcher.ResultChan() {
+		switch watchEvent.Type {
+		case watch.Added, watch.Modified:
+		case watch.Error:
+			return fmt.Errorf("error watching events: %v", errors.FromObject(watchEvent.Object))
+		default:
+			continue
+		}
+		event, ok := watchEvent.Object.(*k8sv1.Event)
+		if !ok {
+			continue
+		}
+		if !related.contains(event.InvolvedObject) && related.mayBecomeRelated(event.InvolvedObject) {
+			if err := related.refresh(ctx); err != nil {
+				return err
+			}
+		}
+		if related.contains(event.InvolvedObject) {
+			printEvent(w, event)
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func newRelatedObjects(virtClient kubecli.KubevirtClient, vm *v1.VirtualMachine) *relatedObjects {
+	r := &relatedObjects{
+		virtClient: virtClient,
+		namespace:  vm.Namespace,
+		vmName:     vm.Name,
+		objects:    map[objectReference]struct{}{},
+	}
+	r.add(v1.VirtualMachineGroupVersionKind.Kind, vm.Name)
+	r.add(v1.VirtualMachineInstanceGroupVersionKind.Kind, vm.Name)
+	for _, dataVolume := range vm.Spec.DataVolumeTemplates {
+		r.addDataVolume(dataVolume.Name)
+	}
+	if vm.Spec.Template != nil {
+		for _, volume := range vm.Spec.Template.Spec.Volumes {
+			if volume.DataVolume != nil {
+				r.addDataVolume(volume.DataVolume.Name)
+			}
+		}
+	}
+	return r
+}
+
+func (r *relatedObjects) add(kind, name string) {
+	r.objects[objectReference{kind: kind, name: name}] = struct{}{}
+}
+
+func (r *relatedObjects) addDataVolume(name string) {
+	r.add(dataVolumeKind, name)
+	r.add(persistentVolumeClaimKind, name)
+}
+
+func (r *relatedObjects) contains(ref k8sv1.ObjectReference) bool {
+	_, exists := r.objects[objectReference{kind: ref.Kind, name: ref.Name}]
+	return exists
+}
+
+func (r *relatedObjects) mayBecomeRelated(ref k8sv1.ObjectReference) bool {
+	return ref.Kind == podKind || ref.Kind == v1.VirtualMachineInstanceMigrationGroupVersionKind.Kind
+}
+
+// refresh adds the launcher and hotplug pods and the migrations of the VirtualMachineInstance.
+// Objects are never removed, so events of deleted pods and migrations are still shown.
+func (r *relatedObjects) refresh(ctx context.Context) error {
+	pods, err := r.virtClient.CoreV1().Pods(r.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: v1.AppLabel,
+	})
+	if err != nil {
+		return fmt.Errorf("error listing pods: %v", err)
+	}
+	launcherPods := map[string]struct{}{}
+	for _, pod := range pods.Items {
+		if isOwnedBy(&pod, v1.VirtualMachineInstanceGroupVersionKind.Kind, r.vmName) {
+			launcherPods[pod.Name] = struct{}{}
+			r.add(podKind, pod.Name)
+		}
+	}
+	for _, pod := range pods.Items {
+		for _, owner := range pod.OwnerReferences {
+			if _, ownedByLauncher := launcherPods[owner.Name]; ownedByLauncher && owner.Kind == podKind {
+				r.add(podKind, pod.Name)
+			}
+		}
+	}
+
+	migrations, err := r.virtClient.VirtualMachineInstanceMigration(r.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing VirtualMachineInstanceMigrations: %v", err)
+	}
+	for _, migration := range migrations.Items {
+		if migration.Spec.VMIName == r.vmName {
+			r.add(v1.VirtualMachineInstanceMigrationGroupVersionKind.Kind, migration.Name)
+		}
+	}
+	return nil
+}
+
+func isOwnedBy(pod *k8sv1.Pod, kind, name string) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == kind && owner.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func eventTime(event k8sv1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+func printEvent(w io.Writer, event *k8sv1.Event) {
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s/%s\t%s\n",
+		eventTime(*event).UTC().Format(time.RFC3339),
+		event.Type,
+		event.Reason,
+		event.InvolvedObject.Kind,
+		event.InvolvedObject.Name,
+		strings.TrimSpace(event.Message),
+	)
+}
@@ -0,0 +1,189 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package events_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Events command", func() {
+	const vmName = "testvm"
+
+	var (
+		virtClient *kubevirtfake.Clientset
+		k8sClient  *k8sfake.Clientset
+		now        time.Time
+	)
+
+	newEvent := func(kind, name, reason string, age time.Duration) *k8sv1.Event {
+		return &k8sv1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "." + reason,
+				Namespace: metav1.NamespaceDefault,
+			},
+			InvolvedObject: k8sv1.ObjectReference{Kind: kind, Name: name},
+			Reason:         reason,
+			Message:        reason + " message",
+			Type:           k8sv1.EventTypeNormal,
+			LastTimestamp:  metav1.NewTime(now.Add(-age)),
+		}
+	}
+
+	createEvents := func(events ...*k8sv1.Event) {
+		for _, event := range events {
+			_, err := k8sClient.CoreV1().Events(metav1.NamespaceDefault).Create(context.Background(), event, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+	}
+
+	newPod := func(name, ownerKind, ownerName string) *k8sv1.Pod {
+		return &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       metav1.NamespaceDefault,
+				Labels:          map[string]string{v1.AppLabel: "virt-launcher"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: ownerKind, Name: ownerName}},
+			},
+		}
+	}
+
+	createMigration := func(name, vmiName string) {
+		migration := &v1.VirtualMachineInstanceMigration{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Spec:       v1.VirtualMachineInstanceMigrationSpec{VMIName: vmiName},
+		}
+		_, err := virtClient.KubevirtV1().VirtualMachineInstanceMigrations(metav1.NamespaceDefault).
+			Create(context.Background(), migration, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	outputLines := func(out []byte) []string {
+		return strings.Split(strings.TrimSpace(string(out)), "\n")
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		virtClient = kubevirtfake.NewSimpleClientset()
+		k8sClient = k8sfake.NewSimpleClientset(
+			newPod("virt-launcher-testvm-abcde", v1.VirtualMachineInstanceGroupVersionKind.Kind, vmName),
+			newPod("hp-volume-fghij", "Pod", "virt-launcher-testvm-abcde"),
+			newPod("virt-launcher-othervm-klmno", v1.VirtualMachineInstanceGroupVersionKind.Kind, "othervm"),
+		)
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstanceMigration(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachineInstanceMigrations(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+
+		vm := libvmi.NewVirtualMachine(
+			libvmi.New(
+				libvmi.WithNamespace(metav1.NamespaceDefault),
+				libvmi.WithName(vmName),
+				libvmi.WithDataVolume("disk0", "testvm-disk"),
+			),
+		)
+		_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), vm, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fail if the VM does not exist", func() {
+		err := testing.NewRepeatableVirtctlCommand("events", "unknown")()
+		Expect(err).To(MatchError(ContainSubstring("error getting VirtualMachine unknown")))
+	})
+
+	It("should show the events of the VM and its related objects in chronological order", func() {
+		createMigration("testvm-migration", vmName)
+		createMigration("othervm-migration", "othervm")
+		createEvents(
+			newEvent(v1.VirtualMachineInstanceMigrationGroupVersionKind.Kind, "testvm-migration", "MigrationSucceeded", time.Minute),
+			newEvent(v1.VirtualMachineGroupVersionKind.Kind, vmName, "SuccessfulCreate", 10*time.Minute),
+			newEvent("PersistentVolumeClaim", "testvm-disk", "Provisioning", 9*time.Minute),
+			newEvent("DataVolume", "testvm-disk", "ImportSucceeded", 8*time.Minute),
+			newEvent("Pod", "virt-launcher-testvm-abcde", "Started", 6*time.Minute),
+			newEvent(v1.VirtualMachineInstanceGroupVersionKind.Kind, vmName, "Started", 5*time.Minute),
+			newEvent("Pod", "hp-volume-fghij", "Pulled", 4*time.Minute),
+			newEvent(v1.VirtualMachineGroupVersionKind.Kind, "othervm", "SuccessfulCreate", 7*time.Minute),
+			newEvent("Pod", "virt-launcher-othervm-klmno", "Started", 3*time.Minute),
+			newEvent(v1.VirtualMachineInstanceMigrationGroupVersionKind.Kind, "othervm-migration", "MigrationSucceeded", 2*time.Minute),
+		)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("events", vmName)()
+		Expect(err).ToNot(HaveOccurred())
+		lines := outputLines(out)
+		Expect(lines).To(HaveLen(8))
+		Expect(lines[0]).To(MatchRegexp(`^LAST SEEN\s+TYPE\s+REASON\s+OBJECT\s+MESSAGE$`))
+		Expect(lines[1]).To(MatchRegexp(`Normal\s+SuccessfulCreate\s+VirtualMachine/testvm\s+SuccessfulCreate message$`))
+		Expect(lines[2]).To(ContainSubstring("PersistentVolumeClaim/testvm-disk"))
+		Expect(lines[3]).To(ContainSubstring("DataVolume/testvm-disk"))
+		Expect(lines[4]).To(ContainSubstring("Pod/virt-launcher-testvm-abcde"))
+		Expect(lines[5]).To(ContainSubstring("VirtualMachineInstance/testvm"))
+		Expect(lines[6]).To(ContainSubstring("Pod/hp-volume-fghij"))
+		Expect(lines[7]).To(ContainSubstring("VirtualMachineInstanceMigration/testvm-migration"))
+	})
+
+	It("should stream new events with --follow", func() {
+		createEvents(newEvent(v1.VirtualMachineGroupVersionKind.Kind, vmName, "SuccessfulCreate", time.Minute))
+
+		// The migration is only listed once its first event is streamed, which requires a refresh
+		createMigration("testvm-migration", vmName)
+		migrationLists := 0
+		virtClient.PrependReactor("list", "virtualmachineinstancemigrations", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+			migrationLists++
+			return migrationLists == 1, &v1.VirtualMachineInstanceMigrationList{}, nil
+		})
+		watcher := watch.NewFakeWithChanSize(3, false)
+		watcher.Add(newEvent(v1.VirtualMachineGroupVersionKind.Kind, "othervm", "SuccessfulCreate", 0))
+		watcher.Add(newEvent(v1.VirtualMachineInstanceMigrationGroupVersionKind.Kind, "testvm-migration", "MigrationScheduling", 0))
+		watcher.Add(newEvent(v1.VirtualMachineInstanceGroupVersionKind.Kind, vmName, "Migrated", 0))
+		watcher.Stop()
+		k8sClient.PrependWatchReactor("events", k8stesting.DefaultWatchReactor(watcher, nil))
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("events", vmName, "--follow")()
+		Expect(err).ToNot(HaveOccurred())
+		lines := outputLines(out)
+		Expect(lines).To(HaveLen(4))
+		Expect(lines[1]).To(ContainSubstring("VirtualMachine/testvm"))
+		Expect(lines[2]).To(ContainSubstring("VirtualMachineInstanceMigration/testvm-migration"))
+		Expect(lines[3]).To(ContainSubstring("VirtualMachineInstance/testvm"))
+		Expect(migrationLists).To(Equal(2))
+	})
+})
@@ -0,0 +1,401 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package explain
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/spf13/cobra"
+	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"kubevirt.io/client-go/api"
+	"kubevirt.io/client-go/kubecli"
+	client_version "kubevirt.io/client-go/version"
+
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	recursiveFlag  = "recursive"
+	apiVersionFlag = "api-version"
+	offlineFlag    = "offline"
+
+	indentation  = "  "
+	wrapColumn   = 80
+	maxRecursion = 15
+)
+
+type command struct {
+	recursive  bool
+	apiVersion string
+	offline    bool
+
+	definitions map[string]common.OpenAPIDefinition
+}
+
+func NewCommand() *cobra.Command {
+	c := command{}
+	cmd := &cobra.Command{
+		Use:   "explain RESOURCE[.FIELD...]",
+		Short: "Describe the fields of KubeVirt resources.",
+		Long: `Describe the fields of KubeVirt resources, along with the feature gates they require.
+
+The documentation is taken from the OpenAPI schema published with this version of virtctl, it does not require access to a cluster.
+If a cluster is reachable, the resource is described at the API version preferred by the cluster and a warning is printed when the cluster runs a different KubeVirt version.
+Fields are identified with a JSONPath-like expression starting at the resource, e.g. vm.spec.template.spec.domain.devices.disks.`,
+		Example: usage(),
+		Args:    cobra.ExactArgs(1),
+		RunE:    c.run,
+	}
+	cmd.Flags().BoolVar(&c.recursive, recursiveFlag, false, "Print the names and types of all nested fields.")
+	cmd.Flags().StringVar(&c.apiVersion, apiVersionFlag, "", "API version of the resource to describe, e.g. pool.kubevirt.io/v1alpha1. Defaults to the version preferred by the cluster.")
+	cmd.Flags().BoolVar(&c.offline, offlineFlag, false, "Do not contact the cluster, describe the resource at the version preferred by virtctl.")
+	cmd.MarkFlagsMutuallyExclusive(apiVersionFlag, offlineFlag)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Describe the fields of a VirtualMachine:
+  {{ProgramName}} explain vm
+
+  # Describe the disks of a VirtualMachine:
+  {{ProgramName}} explain vm.spec.template.spec.domain.devices.disks
+
+  # Print all fields of a VirtualMachinePreference:
+  {{ProgramName}} explain vmpref --recursive
+
+  # Describe the spec of a VirtualMachineSnapshot at an older API version:
+  {{ProgramName}} explain vmsnapshot.spec --api-version=snapshot.kubevirt.io/v1alpha1`
+}
+
+func (c *command) run(cmd *cobra.Command, args []string) error {
+	path := strings.Split(args[0], ".")
+	res := lookupResource(strings.ToLower(path[0]))
+	if res == nil {
+		return fmt.Errorf("unknown resource %q, supported resources are: %s", path[0], strings.Join(resourceKinds(), ", "))
+	}
+
+	version, err := c.resolveVersion(cmd, res)
+	if err != nil {
+		return err
+	}
+
+	c.definitions = api.GetOpenAPIDefinitions(func(path string) spec.Ref {
+		return spec.MustCreateRef(path)
+	})
+
+	target, err := c.lookupField(res.kind, version.definition, path[1:])
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "KIND:       %s\n", res.kind)
+	fmt.Fprintf(out, "VERSION:    %s\n\n", version.apiVersion)
+	if target.name != "" {
+		fmt.Fprintf(out, "FIELD: %s <%s>\n", target.name, target.typeName)
+	}
+	if len(target.featureGates) > 0 {
+		fmt.Fprintf(out, "FEATURE GATES: %s\n", formatFeatureGates(target.featureGates))
+	}
+	if target.name != "" || len(target.featureGates) > 0 {
+		fmt.Fprintln(out)
+	}
+
+	fmt.Fprintln(out, "DESCRIPTION:")
+	description := target.schema.Description
+	if description == "" {
+		description = "<empty>"
+	}
+	printWrapped(out, description, indentation)
+
+	if len(target.definition.Properties) == 0 {
+		return nil
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "FIELDS:")
+	if c.recursive {
+		c.printFieldsRecursive(out, target.definitionName, indentation, map[string]bool{target.definitionName: true})
+		return nil
+	}
+	c.printFields(out, target.definitionName)
+	return nil
+}
+
+// resolveVersion selects the API version of the resource to describe. Without an explicit
+// version, the version preferred by the cluster is used if a cluster is reachable, virtctl
+// falls back to its own preferred version otherwise.
+func (c *command) resolveVersion(cmd *cobra.Command, res *resource) (*resourceVersion, error) {
+	if c.apiVersion != "" {
+		version := res.version(c.apiVersion)
+		if version == nil {
+			return nil, fmt.Errorf("unknown API version %q of %s, supported versions are: %s", c.apiVersion, res.kind, strings.Join(res.apiVersions(), ", "))
+		}
+		return version, nil
+	}
+	if c.offline {
+		return res.version(""), nil
+	}
+
+	virtClient, _, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return res.version(""), nil
+	}
+	warnVersionSkew(cmd, virtClient)
+	return clusterVersion(cmd, virtClient, res), nil
+}
+
+// clusterVersion returns the version of the resource preferred by the cluster, or the
+// first version served by the cluster if virtctl does not know the preferred one.
+func clusterVersion(cmd *cobra.Command, virtClient kubecli.KubevirtClient, res *resource) *resourceVersion {
+	fallback := res.version("")
+	groups, err := virtClient.DiscoveryClient().ServerGroups()
+	if err != nil {
+		cmd.PrintErrf("Warning: could not discover the API versions served by the cluster, using %s: %v\n", fallback.apiVersion, err)
+		return fallback
+	}
+
+	for _, group := range groups.Groups {
+		if group.Name != res.group() {
+			continue
+		}
+		if version := res.version(group.PreferredVersion.GroupVersion); version != nil {
+			return version
+		}
+		for _, served := range group.Versions {
+			if version := res.version(served.GroupVersion); version != nil {
+				return version
+			}
+		}
+		cmd.PrintErrf("Warning: the cluster serves %s at versions unknown to virtctl, using %s\n", res.kind, fallback.apiVersion)
+		return fallback
+	}
+	cmd.PrintErrf("Warning: %s is not served by the cluster, using %s\n", res.kind, fallback.apiVersion)
+	return fallback
+}
+
+// warnVersionSkew warns when the cluster runs another minor version of KubeVirt, whose
+// fields may differ from the schema of virtctl.
+func warnVersionSkew(cmd *cobra.Command, virtClient kubecli.KubevirtClient) {
+	serverInfo, err := virtClient.ServerVersion().Get()
+	if err != nil {
+		return
+	}
+	clientVersion, err := semver.NewVersion(strings.TrimPrefix(client_version.Get().GitVersion, "v"))
+	if err != nil {
+		return
+	}
+	serverVersion, err := semver.NewVersion(strings.TrimPrefix(serverInfo.GitVersion, "v"))
+	if err != nil {
+		return
+	}
+	// Development builds do not reflect the fields they contain
+	if clientVersion.Major == 0 && clientVersion.Minor == 0 || serverVersion.Major == 0 && serverVersion.Minor == 0 {
+		return
+	}
+	if clientVersion.Major != serverVersion.Major || clientVersion.Minor != serverVersion.Minor {
+		cmd.PrintErrf("Warning: the documentation is taken from virtctl %s, the cluster runs KubeVirt %s, fields may differ\n",
+			client_version.Get().GitVersion, serverInfo.GitVersion)
+	}
+}
+
+type field struct {
+	name         string
+	typeName     string
+	featureGates []string
+	required     bool
+
+	// schema is the schema of the field as declared in its parent
+	schema spec.Schema
+	// definition is the schema of the type of the field, it differs from schema when the
+	// field refers to another definition
+	definition     spec.Schema
+	definitionName string
+}
+
+func (c *command) lookupField(kind, definitionName string, path []string) (*field, error) {
+	definition, exists := c.definitions[definitionName]
+	if !exists {
+		return nil, fmt.Errorf("no OpenAPI definition found for %s", kind)
+	}
+	current := &field{
+		typeName:       kind,
+		schema:         definition.Schema,
+		definition:     definition.Schema,
+		definitionName: definitionName,
+	}
+
+	for i, name := range path {
+		property, exists := current.definition.Properties[name]
+		if !exists {
+			return nil, fmt.Errorf("field %q does not exist in %s", name, strings.Join(append([]string{kind}, path[:i]...), "."))
+		}
+		next := c.newField(current.definitionName, name, property)
+		current = &next
+	}
+	return current, nil
+}
+
+func (c *command) newField(parentDefinition, name string, property spec.Schema) field {
+	f := field{
+		name:         name,
+		schema:       property,
+		definition:   property,
+		featureGates: fieldFeatureGates[parentDefinition+"."+name],
+	}
+
+	elem, prefix := property, ""
+	switch {
+	case property.Items != nil && property.Items.Schema != nil:
+		elem, prefix = *property.Items.Schema, "[]"
+	case property.AdditionalProperties != nil && property.AdditionalProperties.Schema != nil:
+		elem, prefix = *property.AdditionalProperties.Schema, "map[string]"
+	}
+
+	if ref := elem.Ref.String(); ref != "" {
+		f.definitionName = ref
+		f.typeName = prefix + ref[strings.LastIndex(ref, ".")+1:]
+		if definition, exists := c.definitions[ref]; exists {
+			f.definition = definition.Schema
+		}
+		return f
+	}
+	f.definition = elem
+	f.typeName = prefix + primitiveType(elem)
+	return f
+}
+
+func primitiveType(schema spec.Schema) string {
+	if len(schema.Type) == 0 {
+		return "Object"
+	}
+	if schema.Type[0] == "array" && schema.Items != nil && schema.Items.Schema != nil {
+		return "[]" + primitiveType(*schema.Items.Schema)
+	}
+	return schema.Type[0]
+}
+
+func (c *command) sortedFields(definitionName string) []field {
+	definition := c.definitions[definitionName].Schema
+	required := map[string]bool{}
+	for _, name := range definition.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(definition.Properties))
+	for name := range definition.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]field, 0, len(names))
+	for _, name := range names {
+		f := c.newField(definitionName, name, definition.Properties[name])
+		f.required = required[name]
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func (c *command) printFields(out io.Writer, definitionName string) {
+	for i, f := range c.sortedFields(definitionName) {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "%s%s\t%s\n", indentation, f.name, f.formatType())
+		if len(f.featureGates) > 0 {
+			fmt.Fprintf(out, "%s%sRequires feature gates: %s\n", indentation, indentation, formatFeatureGates(f.featureGates))
+		}
+		if f.schema.Description != "" {
+			printWrapped(out, f.schema.Description, indentation+indentation)
+		}
+	}
+}
+
+func (c *command) printFieldsRecursive(out io.Writer, definitionName, indent string, visited map[string]bool) {
+	if len(visited) > maxRecursion {
+		return
+	}
+	for _, f := range c.sortedFields(definitionName) {
+		fmt.Fprintf(out, "%s%s\t%s\n", indent, f.name, f.formatType())
+		// recursive types like JSONSchemaProps are only expanded once per branch
+		if f.definitionName == "" || visited[f.definitionName] || len(f.definition.Properties) == 0 {
+			continue
+		}
+		visited[f.definitionName] = true
+		c.printFieldsRecursive(out, f.definitionName, indent+indentation, visited)
+		delete(visited, f.definitionName)
+	}
+}
+
+func (f field) formatType() string {
+	if f.required {
+		return fmt.Sprintf("<%s> -required-", f.typeName)
+	}
+	return fmt.Sprintf("<%s>", f.typeName)
+}
+
+func formatFeatureGates(names []string) string {
+	formatted := make([]string, 0, len(names))
+	for _, name := range names {
+		if fg := featuregate.FeatureGateInfo(name); fg != nil {
+			formatted = append(formatted, fmt.Sprintf("%s (%s)", name, fg.State))
+		} else {
+			formatted = append(formatted, name)
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func printWrapped(out io.Writer, text, indent string) {
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := indent
+		if strings.TrimSpace(paragraph) == "" {
+			fmt.Fprintln(out)
+			continue
+		}
+		for _, word := range strings.Fields(paragraph) {
+			if len(line) > len(indent) && len(line)+1+len(word) > wrapColumn {
+				fmt.Fprintln(out, line)
+				line = indent
+			}
+			if len(line) > len(indent) {
+				line += " "
+			}
+			line += word
+		}
+		fmt.Fprintln(out, line)
+	}
+}
+
+func resourceKinds() []string {
+	kinds := make([]string, 0, len(resources))
+	for _, res := range resources {
+		kinds = append(kinds, strings.ToLower(res.kind))
+	}
+	return kinds
+}
@@ -0,0 +1,177 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package explain_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/version"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Explain command", func() {
+	const command = "explain"
+
+	explain := func(args ...string) string {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut(append([]string{command}, args...)...)()
+		Expect(err).ToNot(HaveOccurred())
+		return string(out)
+	}
+
+	BeforeEach(func() {
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetInvalidKubevirtClientFromClientConfig
+	})
+
+	It("should fail with missing input parameters", func() {
+		cmd := testing.NewRepeatableVirtctlCommand(command)
+		Expect(cmd()).To(MatchError("accepts 1 arg(s), received 0"))
+	})
+
+	It("should fail with an unknown resource", func() {
+		cmd := testing.NewRepeatableVirtctlCommand(command, "pod.spec")
+		Expect(cmd()).To(MatchError(ContainSubstring(`unknown resource "pod"`)))
+	})
+
+	It("should fail with an unknown field", func() {
+		cmd := testing.NewRepeatableVirtctlCommand(command, "vm.spec.template.foo")
+		Expect(cmd()).To(MatchError(`field "foo" does not exist in VirtualMachine.spec.template`))
+	})
+
+	DescribeTable("should describe the resource", func(name, kind, version string) {
+		out := explain(name)
+		Expect(out).To(HavePrefix("KIND:       %s\nVERSION:    %s\n", kind, version))
+		Expect(out).To(ContainSubstring("FIELDS:\n  apiVersion\t<string>\n"))
+		Expect(out).To(ContainSubstring("  spec\t<"))
+	},
+		Entry("with its kind", "VirtualMachine", "VirtualMachine", "kubevirt.io/v1"),
+		Entry("with its short name", "vmi", "VirtualMachineInstance", "kubevirt.io/v1"),
+		Entry("with its plural", "virtualmachinepools", "VirtualMachinePool", "pool.kubevirt.io/v1beta1"),
+		Entry("of the instancetype API", "vmcf", "VirtualMachineClusterInstancetype", "instancetype.kubevirt.io/v1beta1"),
+	)
+
+	It("should describe a nested field and its fields", func() {
+		out := explain("vm.spec.template.spec.domain.devices.disks")
+		Expect(out).To(ContainSubstring("FIELD: disks <[]Disk>\n"))
+		Expect(out).To(ContainSubstring("DESCRIPTION:\n  Disks describes disks, cdroms and luns which are connected to the vmi.\n"))
+		Expect(out).To(ContainSubstring("  name\t<string> -required-\n"))
+		Expect(out).To(ContainSubstring("  bootOrder\t<integer>\n"))
+	})
+
+	It("should describe a field without nested fields", func() {
+		out := explain("vm.spec.runStrategy")
+		Expect(out).To(ContainSubstring("FIELD: runStrategy <string>\n"))
+		Expect(out).ToNot(ContainSubstring("FIELDS:"))
+	})
+
+	It("should report the feature gates required by a field", func() {
+		out := explain("vmi.spec.domain.devices.hostDevices")
+		Expect(out).To(ContainSubstring("FEATURE GATES: HostDevices (Alpha)\n"))
+	})
+
+	It("should report the feature gates required by the listed fields", func() {
+		out := explain("vmi.spec.domain.devices")
+		Expect(out).To(ContainSubstring("  autoattachVSOCK\t<boolean>\n    Requires feature gates: VSOCK (Alpha)\n"))
+	})
+
+	It("should print nested fields recursively", func() {
+		out := explain("vm.spec.template.spec.domain.cpu", "--recursive")
+		Expect(out).To(ContainSubstring("  numa\t<NUMA>\n    guestMappingPassthrough\t<NUMAGuestMappingPassthrough>\n"))
+		Expect(out).ToNot(ContainSubstring("Requires feature gates"))
+	})
+
+	It("should describe the resource at the requested API version", func() {
+		out := explain("vmsnapshot.spec", "--api-version", "snapshot.kubevirt.io/v1alpha1")
+		Expect(out).To(HavePrefix("KIND:       VirtualMachineSnapshot\nVERSION:    snapshot.kubevirt.io/v1alpha1\n"))
+	})
+
+	It("should fail with an unknown API version", func() {
+		cmd := testing.NewRepeatableVirtctlCommand(command, "vm", "--api-version", "kubevirt.io/v2")
+		Expect(cmd()).To(MatchError(`unknown API version "kubevirt.io/v2" of VirtualMachine, supported versions are: kubevirt.io/v1`))
+	})
+
+	Context("with a cluster", func() {
+		var discovery *fakediscovery.FakeDiscovery
+
+		BeforeEach(func() {
+			ctrl := gomock.NewController(GinkgoT())
+			kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+			kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+
+			discovery = &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+			kubecli.MockKubevirtClientInstance.EXPECT().DiscoveryClient().Return(discovery).AnyTimes()
+
+			serverVersion := kubecli.NewMockServerVersionInterface(ctrl)
+			serverVersion.EXPECT().Get().Return(&version.Info{GitVersion: version.Get().GitVersion}, nil).AnyTimes()
+			kubecli.MockKubevirtClientInstance.EXPECT().ServerVersion().Return(serverVersion).AnyTimes()
+		})
+
+		explainWithErr := func(args ...string) (string, string) {
+			out, errOut, err := testing.NewRepeatableVirtctlCommandWithOutAndErr(append([]string{command}, args...)...)()
+			Expect(err).ToNot(HaveOccurred())
+			return string(out), string(errOut)
+		}
+
+		It("should describe the resource at the version preferred by the cluster", func() {
+			discovery.Resources = []*metav1.APIResourceList{
+				{GroupVersion: "pool.kubevirt.io/v1alpha1"},
+				{GroupVersion: "pool.kubevirt.io/v1beta1"},
+			}
+
+			out, errOut := explainWithErr("vmpool")
+			Expect(out).To(HavePrefix("KIND:       VirtualMachinePool\nVERSION:    pool.kubevirt.io/v1alpha1\n"))
+			Expect(errOut).To(BeEmpty())
+		})
+
+		It("should not contact the cluster when offline", func() {
+			discovery.Resources = []*metav1.APIResourceList{{GroupVersion: "pool.kubevirt.io/v1alpha1"}}
+
+			out := explain("vmpool", "--offline")
+			Expect(out).To(HavePrefix("KIND:       VirtualMachinePool\nVERSION:    pool.kubevirt.io/v1beta1\n"))
+			Expect(discovery.Actions()).To(BeEmpty())
+		})
+
+		It("should warn when the cluster does not serve the resource", func() {
+			discovery.Resources = []*metav1.APIResourceList{{GroupVersion: "kubevirt.io/v1"}}
+
+			out, errOut := explainWithErr("vmpool")
+			Expect(out).To(HavePrefix("KIND:       VirtualMachinePool\nVERSION:    pool.kubevirt.io/v1beta1\n"))
+			Expect(errOut).To(ContainSubstring("Warning: VirtualMachinePool is not served by the cluster, using pool.kubevirt.io/v1beta1\n"))
+		})
+
+		It("should fall back to the version of virtctl if discovery fails", func() {
+			discovery.PrependReactor("*", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("discovery failed")
+			})
+
+			out, _ := explainWithErr("vm")
+			Expect(out).To(HavePrefix("KIND:       VirtualMachine\nVERSION:    kubevirt.io/v1\n"))
+		})
+	})
+})
@@ -0,0 +1,201 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package explain
+
+import (
+	"strings"
+
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+type resourceVersion struct {
+	apiVersion string
+	definition string
+}
+
+type resource struct {
+	kind string
+	// versions lists the API versions with a schema known to virtctl, the preferred one first
+	versions []resourceVersion
+	names    []string
+}
+
+// resources lists the explainable KubeVirt resources at the versions served by this release,
+// along with the plural and short names accepted on the command line.
+var resources = []resource{
+	{
+		kind:     "VirtualMachine",
+		versions: []resourceVersion{{apiVersion: "kubevirt.io/v1", definition: "kubevirt.io/api/core/v1.VirtualMachine"}},
+		names:    []string{"virtualmachines", "vm", "vms"},
+	},
+	{
+		kind:     "VirtualMachineInstance",
+		versions: []resourceVersion{{apiVersion: "kubevirt.io/v1", definition: "kubevirt.io/api/core/v1.VirtualMachineInstance"}},
+		names:    []string{"virtualmachineinstances", "vmi", "vmis"},
+	},
+	{
+		kind:     "VirtualMachineInstanceReplicaSet",
+		versions: []resourceVersion{{apiVersion: "kubevirt.io/v1", definition: "kubevirt.io/api/core/v1.VirtualMachineInstanceReplicaSet"}},
+		names:    []string{"virtualmachineinstancereplicasets", "vmirs", "vmirss"},
+	},
+	{
+		kind:     "VirtualMachineInstanceMigration",
+		versions: []resourceVersion{{apiVersion: "kubevirt.io/v1", definition: "kubevirt.io/api/core/v1.VirtualMachineInstanceMigration"}},
+		names:    []string{"virtualmachineinstancemigrations", "vmim", "vmims"},
+	},
+	{
+		kind:     "KubeVirt",
+		versions: []resourceVersion{{apiVersion: "kubevirt.io/v1", definition: "kubevirt.io/api/core/v1.KubeVirt"}},
+		names:    []string{"kubevirts", "kv", "kvs"},
+	},
+	{
+		kind: "VirtualMachinePool",
+		versions: []resourceVersion{
+			{apiVersion: "pool.kubevirt.io/v1beta1", definition: "kubevirt.io/api/pool/v1beta1.VirtualMachinePool"},
+			{apiVersion: "pool.kubevirt.io/v1alpha1", definition: "kubevirt.io/api/pool/v1alpha1.VirtualMachinePool"},
+		},
+		names: []string{"virtualmachinepools", "vmpool", "vmpools"},
+	},
+	{
+		kind:     "VirtualMachineInstancetype",
+		versions: []resourceVersion{{apiVersion: "instancetype.kubevirt.io/v1beta1", definition: "kubevirt.io/api/instancetype/v1beta1.VirtualMachineInstancetype"}},
+		names:    []string{"virtualmachineinstancetypes", "vminstancetype", "vminstancetypes", "vmf", "vmfs"},
+	},
+	{
+		kind:     "VirtualMachineClusterInstancetype",
+		versions: []resourceVersion{{apiVersion: "instancetype.kubevirt.io/v1beta1", definition: "kubevirt.io/api/instancetype/v1beta1.VirtualMachineClusterInstancetype"}},
+		names:    []string{"virtualmachineclusterinstancetypes", "vmclusterinstancetype", "vmclusterinstancetypes", "vmcf", "vmcfs"},
+	},
+	{
+		kind:     "VirtualMachinePreference",
+		versions: []resourceVersion{{apiVersion: "instancetype.kubevirt.io/v1beta1", definition: "kubevirt.io/api/instancetype/v1beta1.VirtualMachinePreference"}},
+		names:    []string{"virtualmachinepreferences", "vmpref", "vmprefs", "vmp", "vmps"},
+	},
+	{
+		kind:     "VirtualMachineClusterPreference",
+		versions: []resourceVersion{{apiVersion: "instancetype.kubevirt.io/v1beta1", definition: "kubevirt.io/api/instancetype/v1beta1.VirtualMachineClusterPreference"}},
+		names:    []string{"virtualmachineclusterpreferences", "vmcp", "vmcps"},
+	},
+	{
+		kind: "VirtualMachineSnapshot",
+		versions: []resourceVersion{
+			{apiVersion: "snapshot.kubevirt.io/v1beta1", definition: "kubevirt.io/api/snapshot/v1beta1.VirtualMachineSnapshot"},
+			{apiVersion: "snapshot.kubevirt.io/v1alpha1", definition: "kubevirt.io/api/snapshot/v1alpha1.VirtualMachineSnapshot"},
+		},
+		names: []string{"virtualmachinesnapshots", "vmsnapshot", "vmsnapshots"},
+	},
+	{
+		kind: "VirtualMachineRestore",
+		versions: []resourceVersion{
+			{apiVersion: "snapshot.kubevirt.io/v1beta1", definition: "kubevirt.io/api/snapshot/v1beta1.VirtualMachineRestore"},
+			{apiVersion: "snapshot.kubevirt.io/v1alpha1", definition: "kubevirt.io/api/snapshot/v1alpha1.VirtualMachineRestore"},
+		},
+		names: []string{"virtualmachinerestores", "vmrestore", "vmrestores"},
+	},
+	{
+		kind: "VirtualMachineExport",
+		versions: []resourceVersion{
+			{apiVersion: "export.kubevirt.io/v1beta1", definition: "kubevirt.io/api/export/v1beta1.VirtualMachineExport"},
+			{apiVersion: "export.kubevirt.io/v1alpha1", definition: "kubevirt.io/api/export/v1alpha1.VirtualMachineExport"},
+		},
+		names: []string{"virtualmachineexports", "vmexport", "vmexports"},
+	},
+	{
+		kind: "VirtualMachineClone",
+		versions: []resourceVersion{
+			{apiVersion: "clone.kubevirt.io/v1beta1", definition: "kubevirt.io/api/clone/v1beta1.VirtualMachineClone"},
+			{apiVersion: "clone.kubevirt.io/v1alpha1", definition: "kubevirt.io/api/clone/v1alpha1.VirtualMachineClone"},
+		},
+		names: []string{"virtualmachineclones", "vmclone", "vmclones"},
+	},
+	{
+		kind:     "VirtualMachineBackup",
+		versions: []resourceVersion{{apiVersion: "backup.kubevirt.io/v1alpha1", definition: "kubevirt.io/api/backup/v1alpha1.VirtualMachineBackup"}},
+		names:    []string{"virtualmachinebackups", "vmbackup", "vmbackups"},
+	},
+	{
+		kind:     "MigrationPolicy",
+		versions: []resourceVersion{{apiVersion: "migrations.kubevirt.io/v1alpha1", definition: "kubevirt.io/api/migrations/v1alpha1.MigrationPolicy"}},
+		names:    []string{"migrationpolicies"},
+	},
+}
+
+// fieldFeatureGates maps the fields of the API definitions to the feature gates which have
+// to be enabled in the KubeVirt CR before the field is accepted by virt-api.
+// Keys have the form <definition>.<field>, they apply wherever the definition is used.
+var fieldFeatureGates = map[string][]string{
+	"kubevirt.io/api/core/v1.Devices.autoattachVSOCK":                   {featuregate.VSOCKGate},
+	"kubevirt.io/api/core/v1.Devices.downwardMetrics":                   {featuregate.DownwardMetricsFeatureGate},
+	"kubevirt.io/api/core/v1.Devices.hostDevices":                       {featuregate.HostDevicesGate},
+	"kubevirt.io/api/core/v1.Devices.panicDevices":                      {featuregate.PanicDevicesGate},
+	"kubevirt.io/api/core/v1.Devices.video":                             {featuregate.VideoConfig},
+	"kubevirt.io/api/core/v1.Devices.vsockServices":                     {featuregate.VSOCKGate},
+	"kubevirt.io/api/core/v1.DomainSpec.rebootPolicy":                   {featuregate.RebootPolicy},
+	"kubevirt.io/api/core/v1.GPU.claimName":                             {featuregate.GPUsWithDRAGate},
+	"kubevirt.io/api/core/v1.HostDevice.claimName":                      {featuregate.HostDevicesWithDRAGate},
+	"kubevirt.io/api/core/v1.Interface.passtBinding":                    {featuregate.PasstBinding},
+	"kubevirt.io/api/core/v1.LunTarget.reservation":                     {featuregate.PersistentReservation},
+	"kubevirt.io/api/core/v1.Memory.reservedOverhead":                   {featuregate.ReservedOverheadMemlock},
+	"kubevirt.io/api/core/v1.NUMA.guestMappingPassthrough":              {featuregate.NUMAFeatureGate},
+	"kubevirt.io/api/core/v1.VirtualMachineInstanceSpec.resourceClaims": {featuregate.GPUsWithDRAGate, featuregate.HostDevicesWithDRAGate},
+	"kubevirt.io/api/core/v1.VirtualMachineInstanceSpec.utilityVolumes": {featuregate.UtilityVolumesGate},
+	"kubevirt.io/api/core/v1.Volume.downwardMetrics":                    {featuregate.DownwardMetricsFeatureGate},
+	"kubevirt.io/api/core/v1.Volume.hostDisk":                           {featuregate.HostDiskGate},
+}
+
+func lookupResource(name string) *resource {
+	for i := range resources {
+		if name == strings.ToLower(resources[i].kind) {
+			return &resources[i]
+		}
+		for _, alias := range resources[i].names {
+			if name == alias {
+				return &resources[i]
+			}
+		}
+	}
+	return nil
+}
+
+// version returns the given API version of the resource, or its preferred version if the
+// API version is empty.
+func (r *resource) version(apiVersion string) *resourceVersion {
+	if apiVersion == "" {
+		return &r.versions[0]
+	}
+	for i := range r.versions {
+		if r.versions[i].apiVersion == apiVersion {
+			return &r.versions[i]
+		}
+	}
+	return nil
+}
+
+func (r *resource) group() string {
+	group, _, _ := strings.Cut(r.versions[0].apiVersion, "/")
+	return group
+}
+
+func (r *resource) apiVersions() []string {
+	apiVersions := make([]string, 0, len(r.versions))
+	for _, version := range r.versions {
+		apiVersions = append(apiVersions, version.apiVersion)
+	}
+	return apiVersions
+}
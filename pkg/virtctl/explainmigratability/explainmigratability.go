@@ -0,0 +1,138 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package explainmigratability
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	migrationsutil "kubevirt.io/kubevirt/pkg/util/migrations"
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	compatibleSource = "source"
+	compatibleYes    = "yes"
+	compatibleNo     = "no"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "explain-migratability (VM)",
+		Short:             "Explain to which nodes a running VM can be live migrated with respect to its CPU model and features.",
+		Example:           usage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              run,
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Show the nodes a VirtualMachine named 'my-vm' can be live migrated to
+  {{ProgramName}} explain-migratability my-vm`
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	vmi, err := virtClient.VirtualMachineInstance(namespace).Get(cmd.Context(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("VirtualMachine %s is not running", name)
+	} else if err != nil {
+		return fmt.Errorf("error getting VirtualMachineInstance %s: %v", name, err)
+	}
+
+	nodeList, err := virtClient.CoreV1().Nodes().List(cmd.Context(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing nodes: %v", err)
+	}
+	nodes := nodeList.Items
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	var sourceNode *k8sv1.Node
+	for i := range nodes {
+		if nodes[i].Name == vmi.Status.NodeName {
+			sourceNode = &nodes[i]
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "VirtualMachine %s/%s is running on node %s\n", namespace, name, vmi.Status.NodeName)
+	if cond := liveMigratableCondition(vmi); cond != nil {
+		fmt.Fprintf(out, "LiveMigratable: %s", cond.Status)
+		if cond.Message != "" {
+			fmt.Fprintf(out, " (%s)", cond.Message)
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tCOMPATIBLE\tREASONS")
+	for i := range nodes {
+		node := &nodes[i]
+		compatible := compatibleYes
+		var reasons []string
+		if node.Name == vmi.Status.NodeName {
+			compatible = compatibleSource
+			reasons = migrationsutil.CPUIncompatibilities(vmi, node, nil)
+		} else {
+			reasons = migrationsutil.CPUIncompatibilities(vmi, node, sourceNode)
+			if len(reasons) > 0 {
+				compatible = compatibleNo
+			}
+		}
+		reason := "-"
+		if len(reasons) > 0 {
+			reason = strings.Join(reasons, "; ")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", node.Name, compatible, reason)
+	}
+
+	return w.Flush()
+}
+
+func liveMigratableCondition(vmi *v1.VirtualMachineInstance) *v1.VirtualMachineInstanceCondition {
+	for i := range vmi.Status.Conditions {
+		if vmi.Status.Conditions[i].Type == v1.VirtualMachineInstanceIsMigratable {
+			return &vmi.Status.Conditions[i]
+		}
+	}
+	return nil
+}
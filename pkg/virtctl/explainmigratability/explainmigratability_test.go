@@ -0,0 +1,138 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package explainmigratability_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Explain migratability command", func() {
+	const (
+		command = "explain-migratability"
+		vmName  = "testvm"
+	)
+
+	var (
+		kubeClient *fake.Clientset
+		virtClient *kubevirtfake.Clientset
+	)
+
+	newNode := func(name string, labels map[string]string) *k8sv1.Node {
+		return &k8sv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		kubeClient = fake.NewSimpleClientset()
+		virtClient = kubevirtfake.NewSimpleClientset()
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+	})
+
+	It("should fail with missing input parameters", func() {
+		cmd := testing.NewRepeatableVirtctlCommand(command)
+		Expect(cmd()).To(MatchError("accepts 1 arg(s), received 0"))
+	})
+
+	It("should fail if the VM is not running", func() {
+		cmd := testing.NewRepeatableVirtctlCommand(command, vmName)
+		Expect(cmd()).To(MatchError("VirtualMachine testvm is not running"))
+	})
+
+	It("should explain which nodes are compatible", func() {
+		vmi := libvmi.New(
+			libvmi.WithNamespace(metav1.NamespaceDefault),
+			libvmi.WithName(vmName),
+		)
+		vmi.Spec.Domain.CPU = &v1.CPU{
+			Model: "Skylake",
+			Features: []v1.CPUFeature{
+				{Name: "avx512f", Policy: "require"},
+				{Name: "svm", Policy: "forbid"},
+				{Name: "pcid", Policy: "disable"},
+			},
+		}
+		vmi.Status.NodeName = "node01"
+		vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+			Type:   v1.VirtualMachineInstanceIsMigratable,
+			Status: k8sv1.ConditionTrue,
+		}}
+		_, err := virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).
+			Create(context.Background(), vmi, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		compatibleLabels := map[string]string{
+			v1.NodeSchedulable:             "true",
+			v1.CPUModelLabel + "Skylake":   "true",
+			v1.CPUFeatureLabel + "avx512f": "true",
+		}
+		for _, node := range []*k8sv1.Node{
+			newNode("node01", compatibleLabels),
+			newNode("node02", compatibleLabels),
+			newNode("node03", map[string]string{
+				v1.NodeSchedulable:           "true",
+				v1.CPUModelLabel + "Skylake": "true",
+				v1.CPUFeatureLabel + "svm":   "true",
+			}),
+			newNode("node04", map[string]string{
+				v1.CPUFeatureLabel + "avx512f": "true",
+			}),
+		} {
+			_, err := kubeClient.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut(command, vmName)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(`VirtualMachine default/testvm is running on node node01
+LiveMigratable: True
+
+NODE     COMPATIBLE   REASONS
+node01   source       -
+node02   yes          -
+node03   no           required CPU feature "avx512f" is not supported; forbidden CPU feature "svm" is present
+node04   no           node is not schedulable for virtual machines; CPU model "Skylake" is not supported
+`))
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	routev1 "github.com/openshift/api/route/v1"
 	"github.com/spf13/cobra"
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +19,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/apimachinery"
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -37,11 +39,22 @@ type command struct {
 	strIPFamily       string
 	strIPFamilyPolicy string
 
+	ingress           bool
+	route             bool
+	host              string
+	path              string
+	ingressClass      string
+	tlsSecret         string
+	strTLSTermination string
+	gateway           string
+	gatewayRoute      string
+
 	targetPort     intstr.IntOrString
 	protocol       k8sv1.Protocol
 	serviceType    k8sv1.ServiceType
 	ipFamilies     []k8sv1.IPFamily
 	ipFamilyPolicy k8sv1.IPFamilyPolicy
+	tlsTermination routev1.TLSTerminationType
 
 	namespace string
 	client    kubecli.KubevirtClient
@@ -67,7 +80,11 @@ Also if no labels are specified, the new service will re-use the labels from the
 
 Possible types are (case insensitive, both single and plurant forms):
 
-virtualmachineinstance (vmi), virtualmachine (vm), virtualmachineinstancereplicaset (vmirs)`,
+virtualmachineinstance (vmi), virtualmachine (vm), virtualmachineinstancereplicaset (vmirs)
+
+With --ingress or --route, an Ingress or an OpenShift Route with the name of the service is created in front of it, making an HTTP service of the VM reachable from outside of the cluster.
+
+With --gateway, the service is published through a Gateway API HTTPRoute or TCPRoute attached to the given Gateway. The route is created by virt-controller and requires the GatewayAPIExpose feature gate.`,
 		Example: usage(),
 		Args:    cobra.ExactArgs(2),
 		RunE:    c.run,
@@ -80,13 +97,24 @@ virtualmachineinstance (vmi), virtualmachine (vm), virtualmachineinstancereplica
 	cmd.Flags().StringVar(&c.clusterIP, "cluster-ip", "", "ClusterIP to be assigned to the service. Leave empty to auto-allocate, or set to 'None' to create a headless service.")
 	cmd.Flags().StringVar(&c.externalIP, "external-ip", "", "Additional external IP address (not managed by the cluster) to accept for the service. If this IP is routed to a node, the service can be accessed by this IP in addition to its generated service IP. Optional.")
 	cmd.Flags().Int32Var(&c.port, "port", 0, "The port that the service should serve on.")
-	cmd.Flags().StringVar(&c.strProtocol, "protocol", "TCP", "The network protocol for the service to be created.")
+	cmd.Flags().StringVar(&c.strProtocol, "protocol", "TCP", "The network protocol for the service to be created: TCP, UDP, or SCTP.")
 	cmd.Flags().StringVar(&c.strTargetPort, "target-port", "", "Name or number for the port on the VM that the service should direct traffic to. Optional.")
 	cmd.Flags().StringVar(&c.strServiceType, "type", "ClusterIP", "Type for this service: ClusterIP, NodePort, or LoadBalancer.")
 	cmd.Flags().StringVar(&c.portName, "port-name", "", "Name of the port. Optional.")
 	cmd.Flags().StringVar(&c.strIPFamily, "ip-family", "", "IP family over which the service will be exposed. Valid values are 'IPv4', 'IPv6', 'IPv4,IPv6' or 'IPv6,IPv4'")
 	cmd.Flags().StringVar(&c.strIPFamilyPolicy, "ip-family-policy", "", "IP family policy defines whether the service can use IPv4, IPv6, or both. Valid values are 'SingleStack', 'PreferDualStack' or 'RequireDualStack'")
 
+	cmd.Flags().BoolVar(&c.ingress, ingressFlag, false, "Create an Ingress in front of the service.")
+	cmd.Flags().BoolVar(&c.route, routeFlag, false, "Create an OpenShift Route in front of the service.")
+	cmd.Flags().StringVar(&c.host, hostFlag, "", "Host name the Ingress or Route serves. Optional.")
+	cmd.Flags().StringVar(&c.path, pathFlag, "/", "Path prefix the Ingress or Route forwards to the service.")
+	cmd.Flags().StringVar(&c.ingressClass, ingressClassFlag, "", "IngressClass of the Ingress. Optional.")
+	cmd.Flags().StringVar(&c.tlsSecret, tlsSecretFlag, "", "Secret with the TLS certificate the Ingress uses for the host. Requires --host.")
+	cmd.Flags().StringVar(&c.strTLSTermination, tlsTerminationFlag, "", "TLS termination of the Route: edge, passthrough or reencrypt. Optional.")
+	cmd.Flags().StringVar(&c.gateway, gatewayFlag, "", "Gateway, as [namespace/]name, to publish the service through with a Gateway API route.")
+	cmd.Flags().StringVar(&c.gatewayRoute, gatewayRouteFlag, "", "Kind of the Gateway API route: http or tcp. Defaults to http.")
+	cmd.MarkFlagsMutuallyExclusive(ingressFlag, routeFlag, gatewayFlag)
+
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 
 	return cmd
@@ -100,7 +128,19 @@ func usage() string {
   {{ProgramName}} expose vmirs myvmirs --name=vmirs-service
 
   # Expose port 8080 as port 80 from a virtual machine instance replicaset on a service:
-  {{ProgramName}} expose vmirs myvmirs --port=80 --target-port=8080 --name=vmirs-service`
+  {{ProgramName}} expose vmirs myvmirs --port=80 --target-port=8080 --name=vmirs-service
+
+  # Expose an SCTP port of a virtual machine on a dual-stack service:
+  {{ProgramName}} expose vm myvm --port=38412 --protocol=SCTP --ip-family=IPv4,IPv6 --ip-family-policy=RequireDualStack --name=myvm-sctp
+
+  # Expose a web server of a virtual machine on a host name with TLS via an Ingress:
+  {{ProgramName}} expose vm myvm --port=80 --name=myvm-http --ingress --host=myvm.example.com --tls-secret=myvm-cert
+
+  # Expose a web server of a virtual machine via an OpenShift Route terminating TLS at the router:
+  {{ProgramName}} expose vm myvm --port=80 --name=myvm-http --route --tls-termination=edge
+
+  # Expose SSH to a virtual machine through a TCPRoute attached to the Gateway 'infra/public':
+  {{ProgramName}} expose vm myvm --port=22 --name=myvm-ssh --gateway=infra/public --gateway-route=tcp`
 }
 
 func (c *command) run(cmd *cobra.Command, args []string) error {
@@ -110,7 +150,7 @@ func (c *command) run(cmd *cobra.Command, args []string) error {
 	vmName := args[1]
 
 	if err := c.parseFlags(); err != nil {
-		return err
+		return result.NewUsageError(err)
 	}
 
 	var err error
@@ -123,11 +163,36 @@ func (c *command) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := c.createService(resInfo); err != nil {
+	if c.ingress || c.route || c.gateway != "" {
+		if _, err := httpPort(resInfo.ports); err != nil {
+			return err
+		}
+	}
+
+	service, err := c.createService(resInfo)
+	if err != nil {
 		return err
 	}
 
+	result.RecordChange(cmd.Context(), result.Change{Action: "Create", Kind: "Service", Namespace: c.namespace, Name: c.serviceName})
 	cmd.Printf("Service %s successfully created for %s %s\n", c.serviceName, vmType, vmName)
+
+	switch {
+	case c.ingress:
+		if err := c.createIngress(service); err != nil {
+			return err
+		}
+		result.RecordChange(cmd.Context(), result.Change{Action: "Create", Kind: "Ingress", Namespace: c.namespace, Name: c.serviceName})
+		cmd.Printf("Ingress %s successfully created for service %s\n", c.serviceName, c.serviceName)
+	case c.route:
+		if err := c.createRoute(service); err != nil {
+			return err
+		}
+		result.RecordChange(cmd.Context(), result.Change{Action: "Create", Kind: "Route", Namespace: c.namespace, Name: c.serviceName})
+		cmd.Printf("Route %s successfully created for service %s\n", c.serviceName, c.serviceName)
+	case c.gateway != "":
+		cmd.Printf("Service %s will be published through Gateway %s\n", c.serviceName, c.gateway)
+	}
 	return nil
 }
 
@@ -148,7 +213,7 @@ func (c *command) parseFlags() error {
 		return err
 	}
 
-	return nil
+	return c.parseIngressFlags()
 }
 
 func (c *command) getResourceInfo(vmType, vmName string) (*resourceInfo, error) {
@@ -224,7 +289,7 @@ func (c *command) getResourceInfo(vmType, vmName string) (*resourceInfo, error)
 	return info, nil
 }
 
-func (c *command) createService(resInfo *resourceInfo) error {
+func (c *command) createService(resInfo *resourceInfo) (*k8sv1.Service, error) {
 	ownerRef := metav1.NewControllerRef(resInfo.owner, resInfo.gvk)
 	ownerRef.BlockOwnerDeletion = pointer.P(false)
 
@@ -242,17 +307,21 @@ func (c *command) createService(resInfo *resourceInfo) error {
 			IPFamilies: c.ipFamilies,
 		},
 	}
+	if c.gateway != "" {
+		service.Labels, service.Annotations = c.gatewayMetadata()
+	}
 	if len(c.externalIP) > 0 {
 		service.Spec.ExternalIPs = []string{c.externalIP}
 	}
 	if c.ipFamilyPolicy != "" {
 		service.Spec.IPFamilyPolicy = &c.ipFamilyPolicy
 	}
-	if _, err := c.client.CoreV1().Services(c.namespace).Create(context.Background(), service, metav1.CreateOptions{}); err != nil {
-		return fmt.Errorf("service creation failed: %v", err)
+	service, err := c.client.CoreV1().Services(c.namespace).Create(context.Background(), service, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("service creation failed: %w", err)
 	}
 
-	return nil
+	return service, nil
 }
 
 func convertProtocol(strProtocol string) (k8sv1.Protocol, error) {
@@ -261,6 +330,8 @@ func convertProtocol(strProtocol string) (k8sv1.Protocol, error) {
 		return k8sv1.ProtocolTCP, nil
 	case strings.ToLower(string(k8sv1.ProtocolUDP)):
 		return k8sv1.ProtocolUDP, nil
+	case strings.ToLower(string(k8sv1.ProtocolSCTP)):
+		return k8sv1.ProtocolSCTP, nil
 	default:
 		return "", fmt.Errorf("unknown protocol: %s", strProtocol)
 	}
@@ -5,13 +5,18 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	routev1 "github.com/openshift/api/route/v1"
+	routev1fake "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1/fake"
 	"go.uber.org/mock/gomock"
 
 	k8sv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
@@ -31,13 +36,21 @@ const (
 
 var _ = Describe("Expose", func() {
 	var (
-		kubeClient *fake.Clientset
-		virtClient *kubevirtfake.Clientset
+		kubeClient   *fake.Clientset
+		virtClient   *kubevirtfake.Clientset
+		routeClient  *routev1fake.FakeRouteV1
+		createdRoute *routev1.Route
 	)
 
 	BeforeEach(func() {
 		kubeClient = fake.NewSimpleClientset()
 		virtClient = kubevirtfake.NewSimpleClientset()
+		createdRoute = nil
+		routeClient = &routev1fake.FakeRouteV1{Fake: &k8stesting.Fake{}}
+		routeClient.Fake.AddReactor("create", "routes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			createdRoute = action.(k8stesting.CreateAction).GetObject().(*routev1.Route)
+			return true, createdRoute, nil
+		})
 
 		ctrl := gomock.NewController(GinkgoT())
 		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
@@ -50,6 +63,8 @@ var _ = Describe("Expose", func() {
 		kubecli.MockKubevirtClientInstance.EXPECT().ReplicaSet(metav1.NamespaceDefault).
 			Return(virtClient.KubevirtV1().VirtualMachineInstanceReplicaSets(metav1.NamespaceDefault)).AnyTimes()
 		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().NetworkingV1().Return(kubeClient.NetworkingV1()).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().RouteClient().Return(routeClient).AnyTimes()
 	})
 
 	Context("should fail", func() {
@@ -88,6 +103,27 @@ var _ = Describe("Expose", func() {
 			Entry("invalid ip family policy", "--ip-family-policy=madeup", "unknown IPFamilyPolicy/s: madeup"),
 		)
 
+		DescribeTable("invalid ingress or route flags", func(errMsg string, args ...string) {
+			err := runCommand(append([]string{"vmi", "my-vm", "--name", "my-service"}, args...)...)
+			Expect(err).To(MatchError(ContainSubstring(errMsg)))
+		},
+			Entry("ingress and route", "[ingress route] were all set", "--ingress", "--route"),
+			Entry("host without ingress or route", "--host requires --ingress or --route", "--host=example.com"),
+			Entry("tls secret without ingress or route", "--tls-secret requires --ingress or --route", "--tls-secret=cert"),
+			Entry("relative path", "--path must be an absolute path: web", "--ingress", "--path=web"),
+			Entry("tls termination with ingress", "--tls-termination can only be used with --route", "--ingress", "--tls-termination=edge"),
+			Entry("tls secret without host", "--tls-secret requires --host", "--ingress", "--tls-secret=cert"),
+			Entry("ingress class with route", "--ingress-class can only be used with --ingress", "--route", "--ingress-class=nginx"),
+			Entry("tls secret with route", "--tls-secret can only be used with --ingress", "--route", "--host=example.com", "--tls-secret=cert"),
+			Entry("invalid tls termination", "unknown TLS termination: madeup", "--route", "--tls-termination=madeup"),
+			Entry("gateway and ingress", "[gateway ingress] were all set", "--gateway=gw", "--ingress"),
+			Entry("gateway route without gateway", "--gateway-route requires --gateway", "--gateway-route=tcp"),
+			Entry("invalid gateway", "--gateway must reference a Gateway as [namespace/]name: a/b/c", "--gateway=a/b/c"),
+			Entry("invalid gateway route", "unknown gateway route: madeup", "--gateway=gw", "--gateway-route=madeup"),
+			Entry("path with gateway", "--path can not be used with --gateway", "--gateway=gw", "--path=/app"),
+			Entry("host with tcp gateway route", "--host can only be used with HTTP gateway routes", "--gateway=gw", "--gateway-route=tcp", "--host=example.com"),
+		)
+
 		It("when client has an error", func() {
 			kubecli.GetKubevirtClientFromClientConfig = kubecli.GetInvalidKubevirtClientFromClientConfig
 			err := runCommand("vmi", "my-vm", "--name", "my-service")
@@ -312,10 +348,13 @@ var _ = Describe("Expose", func() {
 		},
 			Entry("with VirtualMachineInstance and protocol TCP", "vmi", k8sv1.ProtocolTCP),
 			Entry("with VirtualMachineInstance and protocol UDP", "vmi", k8sv1.ProtocolUDP),
+			Entry("with VirtualMachineInstance and protocol SCTP", "vmi", k8sv1.ProtocolSCTP),
 			Entry("with VirtualMachine and protocol TCP", "vm", k8sv1.ProtocolTCP),
 			Entry("with VirtualMachine and protocol UDP", "vm", k8sv1.ProtocolUDP),
+			Entry("with VirtualMachine and protocol SCTP", "vm", k8sv1.ProtocolSCTP),
 			Entry("with VirtualMachineInstanceReplicaSet and protocol TCP", "vmirs", k8sv1.ProtocolTCP),
 			Entry("with VirtualMachineInstanceReplicaSet and protocol UDP", "vmirs", k8sv1.ProtocolUDP),
+			Entry("with VirtualMachineInstanceReplicaSet and protocol SCTP", "vmirs", k8sv1.ProtocolSCTP),
 		)
 
 		DescribeTable("creating a service", func(resType string, targetPort string, expected intstr.IntOrString) {
@@ -459,6 +498,108 @@ var _ = Describe("Expose", func() {
 			Entry("with VirtualMachine", "vm", "VirtualMachine"),
 			Entry("with VirtualMachineInstanceReplicaSet", "vmirs", "VirtualMachineInstanceReplicaSet"),
 		)
+
+		Context("with an ingress or route", func() {
+			serviceOwner := func() metav1.OwnerReference {
+				service, err := kubeClient.CoreV1().Services(metav1.NamespaceDefault).Get(context.Background(), serviceName, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				return metav1.OwnerReference{
+					APIVersion:         "v1",
+					Kind:               "Service",
+					Name:               serviceName,
+					UID:                service.UID,
+					Controller:         pointer.P(true),
+					BlockOwnerDeletion: pointer.P(false),
+				}
+			}
+
+			It("should create an ingress with TLS in front of the service", func() {
+				err := runCommand("vm", vm.Name, "--name", serviceName, "--port", servicePortStr,
+					"--ingress", "--host", "myvm.example.com", "--path", "/app", "--ingress-class", "nginx", "--tls-secret", "myvm-cert")
+				Expect(err).ToNot(HaveOccurred())
+
+				ingress, err := kubeClient.NetworkingV1().Ingresses(metav1.NamespaceDefault).Get(context.Background(), serviceName, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ingress.OwnerReferences).To(ConsistOf(serviceOwner()))
+				Expect(ingress.Spec.IngressClassName).To(HaveValue(Equal("nginx")))
+				Expect(ingress.Spec.TLS).To(ConsistOf(networkingv1.IngressTLS{Hosts: []string{"myvm.example.com"}, SecretName: "myvm-cert"}))
+				Expect(ingress.Spec.Rules).To(HaveLen(1))
+				Expect(ingress.Spec.Rules[0].Host).To(Equal("myvm.example.com"))
+				Expect(ingress.Spec.Rules[0].HTTP.Paths).To(ConsistOf(networkingv1.HTTPIngressPath{
+					Path:     "/app",
+					PathType: pointer.P(networkingv1.PathTypePrefix),
+					Backend: networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{
+							Name: serviceName,
+							Port: networkingv1.ServiceBackendPort{Number: servicePort},
+						},
+					},
+				}))
+			})
+
+			DescribeTable("should create a route in front of the service", func(args []string, expectedPort intstr.IntOrString) {
+				err := runCommand(append([]string{"vm", vm.Name, "--name", serviceName, "--port", servicePortStr, "--route", "--tls-termination", "edge"}, args...)...)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(createdRoute).ToNot(BeNil())
+				Expect(createdRoute.OwnerReferences).To(ConsistOf(serviceOwner()))
+				Expect(createdRoute.Spec.To).To(Equal(routev1.RouteTargetReference{Kind: "Service", Name: serviceName}))
+				Expect(createdRoute.Spec.Path).To(Equal("/"))
+				Expect(createdRoute.Spec.Port).To(Equal(&routev1.RoutePort{TargetPort: expectedPort}))
+				Expect(createdRoute.Spec.TLS).To(Equal(&routev1.TLSConfig{
+					Termination:                   routev1.TLSTerminationEdge,
+					InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+				}))
+			},
+				Entry("selecting the service port", nil, intstr.FromInt32(servicePort)),
+				Entry("selecting the target port", []string{"--target-port", "8080"}, intstr.FromInt32(8080)),
+				Entry("selecting the port name", []string{"--port-name", "http"}, intstr.FromString("http")),
+			)
+
+			It("should not set a path on a passthrough route", func() {
+				err := runCommand("vm", vm.Name, "--name", serviceName, "--port", servicePortStr, "--route", "--tls-termination", "passthrough")
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(createdRoute).ToNot(BeNil())
+				Expect(createdRoute.Spec.Path).To(BeEmpty())
+				Expect(createdRoute.Spec.TLS.Termination).To(Equal(routev1.TLSTerminationPassthrough))
+			})
+
+			It("should fail without creating the service if it has multiple ports", func() {
+				vm.Spec.Template.Spec.Networks = []v1.Network{{Name: "pod", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}}}
+				vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "pod", Ports: []v1.Port{{Port: 80}, {Port: 443}}}}
+				_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Update(context.Background(), vm, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = runCommand("vm", vm.Name, "--name", serviceName, "--ingress")
+				Expect(err).To(MatchError("an ingress or route requires a service with a single port, select it with --port"))
+
+				services, err := kubeClient.CoreV1().Services(metav1.NamespaceDefault).List(context.Background(), metav1.ListOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(services.Items).To(BeEmpty())
+			})
+		})
+
+		DescribeTable("should publish the service through a gateway", func(args []string, expectedLabels, expectedAnnotations map[string]string) {
+			err := runCommand(append([]string{"vm", vm.Name, "--name", serviceName, "--port", servicePortStr}, args...)...)
+			Expect(err).ToNot(HaveOccurred())
+
+			service, err := kubeClient.CoreV1().Services(metav1.NamespaceDefault).Get(context.Background(), serviceName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Labels).To(Equal(expectedLabels))
+			Expect(service.Annotations).To(Equal(expectedAnnotations))
+		},
+			Entry("with an HTTPRoute",
+				[]string{"--gateway", "infra/public", "--host", "myvm.example.com"},
+				map[string]string{v1.GatewayRouteLabel: "http"},
+				map[string]string{v1.GatewayParentAnnotation: "infra/public", v1.GatewayHostnamesAnnotation: "myvm.example.com"},
+			),
+			Entry("with a TCPRoute",
+				[]string{"--gateway", "public", "--gateway-route", "TCP"},
+				map[string]string{v1.GatewayRouteLabel: "tcp"},
+				map[string]string{v1.GatewayParentAnnotation: "public"},
+			),
+		)
 	})
 })
 
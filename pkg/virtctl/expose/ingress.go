@@ -0,0 +1,254 @@
+package expose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	routev1 "github.com/openshift/api/route/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+const (
+	ingressFlag        = "ingress"
+	routeFlag          = "route"
+	hostFlag           = "host"
+	pathFlag           = "path"
+	ingressClassFlag   = "ingress-class"
+	tlsSecretFlag      = "tls-secret"
+	tlsTerminationFlag = "tls-termination"
+	gatewayFlag        = "gateway"
+	gatewayRouteFlag   = "gateway-route"
+
+	httpGatewayRoute = "http"
+	tcpGatewayRoute  = "tcp"
+)
+
+func (c *command) parseIngressFlags() error {
+	if c.gateway != "" {
+		return c.parseGatewayFlags()
+	}
+	if c.gatewayRoute != "" {
+		return fmt.Errorf("--%s requires --%s", gatewayRouteFlag, gatewayFlag)
+	}
+
+	if !c.ingress && !c.route {
+		for _, flag := range []struct {
+			name  string
+			isSet bool
+		}{
+			{hostFlag, c.host != ""},
+			{ingressClassFlag, c.ingressClass != ""},
+			{tlsSecretFlag, c.tlsSecret != ""},
+			{tlsTerminationFlag, c.strTLSTermination != ""},
+		} {
+			if flag.isSet {
+				return fmt.Errorf("--%s requires --%s or --%s", flag.name, ingressFlag, routeFlag)
+			}
+		}
+		return nil
+	}
+
+	if !strings.HasPrefix(c.path, "/") {
+		return fmt.Errorf("--%s must be an absolute path: %s", pathFlag, c.path)
+	}
+
+	if c.ingress {
+		if c.strTLSTermination != "" {
+			return fmt.Errorf("--%s can only be used with --%s", tlsTerminationFlag, routeFlag)
+		}
+		if c.tlsSecret != "" && c.host == "" {
+			return fmt.Errorf("--%s requires --%s", tlsSecretFlag, hostFlag)
+		}
+		return nil
+	}
+
+	if c.ingressClass != "" {
+		return fmt.Errorf("--%s can only be used with --%s", ingressClassFlag, ingressFlag)
+	}
+	if c.tlsSecret != "" {
+		return fmt.Errorf("--%s can only be used with --%s", tlsSecretFlag, ingressFlag)
+	}
+	var err error
+	c.tlsTermination, err = convertTLSTermination(c.strTLSTermination)
+	return err
+}
+
+func (c *command) parseGatewayFlags() error {
+	for _, flag := range []struct {
+		name  string
+		isSet bool
+	}{
+		{pathFlag, c.path != "/"},
+		{ingressClassFlag, c.ingressClass != ""},
+		{tlsSecretFlag, c.tlsSecret != ""},
+		{tlsTerminationFlag, c.strTLSTermination != ""},
+	} {
+		if flag.isSet {
+			return fmt.Errorf("--%s can not be used with --%s", flag.name, gatewayFlag)
+		}
+	}
+
+	if parts := strings.Split(c.gateway, "/"); len(parts) > 2 || slices.Contains(parts, "") {
+		return fmt.Errorf("--%s must reference a Gateway as [namespace/]name: %s", gatewayFlag, c.gateway)
+	}
+
+	switch strings.ToLower(c.gatewayRoute) {
+	case "", httpGatewayRoute:
+		c.gatewayRoute = httpGatewayRoute
+	case tcpGatewayRoute:
+		if c.host != "" {
+			return fmt.Errorf("--%s can only be used with HTTP gateway routes", hostFlag)
+		}
+		c.gatewayRoute = tcpGatewayRoute
+	default:
+		return fmt.Errorf("unknown gateway route: %s", c.gatewayRoute)
+	}
+	return nil
+}
+
+// gatewayMetadata returns the label and annotations which publish the service through
+// a route attached to the Gateway.
+func (c *command) gatewayMetadata() (labels, annotations map[string]string) {
+	labels = map[string]string{v1.GatewayRouteLabel: c.gatewayRoute}
+	annotations = map[string]string{v1.GatewayParentAnnotation: c.gateway}
+	if c.host != "" {
+		annotations[v1.GatewayHostnamesAnnotation] = c.host
+	}
+	return labels, annotations
+}
+
+// httpPort returns the single TCP port of the service an Ingress or Route forwards to.
+func httpPort(ports []k8sv1.ServicePort) (*k8sv1.ServicePort, error) {
+	if len(ports) != 1 {
+		return nil, errors.New("an ingress or route requires a service with a single port, select it with --port")
+	}
+	port := &ports[0]
+	if port.Protocol != "" && port.Protocol != k8sv1.ProtocolTCP {
+		return nil, fmt.Errorf("an ingress or route requires a TCP port, got %s", port.Protocol)
+	}
+	return port, nil
+}
+
+func serviceOwnerReference(service *k8sv1.Service) metav1.OwnerReference {
+	ownerRef := metav1.NewControllerRef(service, k8sv1.SchemeGroupVersion.WithKind("Service"))
+	ownerRef.BlockOwnerDeletion = pointer.P(false)
+	return *ownerRef
+}
+
+func (c *command) createIngress(service *k8sv1.Service) error {
+	port, err := httpPort(service.Spec.Ports)
+	if err != nil {
+		return err
+	}
+
+	backend := networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: service.Name,
+			Port: networkingv1.ServiceBackendPort{Number: port.Port},
+		},
+	}
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            service.Name,
+			Namespace:       c.namespace,
+			OwnerReferences: []metav1.OwnerReference{serviceOwnerReference(service)},
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{
+				Host: c.host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     c.path,
+							PathType: pointer.P(networkingv1.PathTypePrefix),
+							Backend:  backend,
+						}},
+					},
+				},
+			}},
+		},
+	}
+	if c.ingressClass != "" {
+		ingress.Spec.IngressClassName = &c.ingressClass
+	}
+	if c.tlsSecret != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{c.host}, SecretName: c.tlsSecret}}
+	}
+
+	if _, err := c.client.NetworkingV1().Ingresses(c.namespace).Create(context.Background(), ingress, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("ingress creation failed: %w", err)
+	}
+	return nil
+}
+
+func (c *command) createRoute(service *k8sv1.Service) error {
+	port, err := httpPort(service.Spec.Ports)
+	if err != nil {
+		return err
+	}
+
+	// A route selects the port by the name or number of the service's target port
+	targetPort := port.TargetPort
+	if port.Name != "" {
+		targetPort = intstr.FromString(port.Name)
+	} else if targetPort == intstr.FromInt32(0) || targetPort == intstr.FromString("") {
+		targetPort = intstr.FromInt32(port.Port)
+	}
+
+	route := &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            service.Name,
+			Namespace:       c.namespace,
+			OwnerReferences: []metav1.OwnerReference{serviceOwnerReference(service)},
+		},
+		Spec: routev1.RouteSpec{
+			Host: c.host,
+			Path: c.path,
+			To: routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: service.Name,
+			},
+			Port: &routev1.RoutePort{TargetPort: targetPort},
+		},
+	}
+	if c.tlsTermination != "" {
+		route.Spec.TLS = &routev1.TLSConfig{
+			Termination:                   c.tlsTermination,
+			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+		}
+		if c.tlsTermination == routev1.TLSTerminationPassthrough {
+			// Paths cannot be matched on encrypted traffic
+			route.Spec.Path = ""
+		}
+	}
+
+	if _, err := c.client.RouteClient().Routes(c.namespace).Create(context.Background(), route, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("route creation failed: %w", err)
+	}
+	return nil
+}
+
+func convertTLSTermination(strTLSTermination string) (routev1.TLSTerminationType, error) {
+	switch strings.ToLower(strTLSTermination) {
+	case "":
+		return "", nil
+	case string(routev1.TLSTerminationEdge):
+		return routev1.TLSTerminationEdge, nil
+	case string(routev1.TLSTerminationPassthrough):
+		return routev1.TLSTerminationPassthrough, nil
+	case string(routev1.TLSTerminationReencrypt):
+		return routev1.TLSTerminationReencrypt, nil
+	default:
+		return "", fmt.Errorf("unknown TLS termination: %s", strTLSTermination)
+	}
+}
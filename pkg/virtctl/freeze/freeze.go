@@ -0,0 +1,189 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package freeze
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	durationArg = "duration"
+	fsArg       = "fs"
+	statusArg   = "status"
+
+	defaultDuration = 5 * time.Minute
+
+	frozenState = "frozen"
+	thawedState = "thawed"
+)
+
+type freezeCommand struct {
+	duration    time.Duration
+	mountpoints []string
+	status      bool
+}
+
+func NewFreezeCommand() *cobra.Command {
+	c := freezeCommand{}
+	cmd := &cobra.Command{
+		Use:   "freeze (VMI)",
+		Short: "Freeze the guest filesystems of a virtual machine instance.",
+		Long: `Freeze the guest filesystems of a virtual machine instance through the guest agent, e.g. to take a consistent backup.
+
+The filesystems are thawed automatically once --duration elapsed, unless they were unfrozen before.
+A duration of 0 keeps the filesystems frozen until 'unfreeze' is called.
+Individual filesystems can be frozen with --fs, which requires a guest agent supporting guest-fsfreeze-freeze-list.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		Example:           freezeUsage(),
+		RunE:              c.run,
+	}
+	cmd.Flags().DurationVar(&c.duration, durationArg, defaultDuration, "Thaw the filesystems automatically after this duration. 0 disables the automatic thaw.")
+	cmd.Flags().StringArrayVar(&c.mountpoints, fsArg, nil, "Mount point of a guest filesystem to freeze, can be repeated. All filesystems are frozen if omitted.")
+	cmd.Flags().BoolVar(&c.status, statusArg, false, "Print the freeze state of the guest filesystems instead of freezing them.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewUnfreezeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "unfreeze (VMI)",
+		Short:             "Thaw the guest filesystems of a virtual machine instance.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		Example:           unfreezeUsage(),
+		RunE:              runUnfreeze,
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func freezeUsage() string {
+	return `  # Freeze all filesystems of the virtual machine instance 'myvmi', thawing them automatically after 5 minutes:
+  {{ProgramName}} freeze myvmi
+
+  # Freeze only /data, thawing it automatically after 30 seconds:
+  {{ProgramName}} freeze myvmi --fs /data --duration 30s
+
+  # Show whether the filesystems of 'myvmi' are frozen:
+  {{ProgramName}} freeze myvmi --status`
+}
+
+func unfreezeUsage() string {
+	return `  # Thaw the filesystems of the virtual machine instance 'myvmi':
+  {{ProgramName}} unfreeze myvmi`
+}
+
+func (c *freezeCommand) run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := c.validate(cmd); err != nil {
+		return result.NewUsageError(err)
+	}
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if c.status {
+		vmi, err := virtClient.VirtualMachineInstance(namespace).Get(cmd.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting VirtualMachineInstance %s: %w", name, err)
+		}
+		cmd.Printf("VMI %s filesystems: %s\n", name, freezeState(vmi))
+		return nil
+	}
+
+	freezeOptions := &v1.FreezeUnfreezeTimeout{
+		UnfreezeTimeout: &metav1.Duration{Duration: c.duration},
+		Mountpoints:     c.mountpoints,
+	}
+	if err := virtClient.VirtualMachineInstance(namespace).FreezeWithOptions(cmd.Context(), name, freezeOptions); err != nil {
+		return fmt.Errorf("error freezing VirtualMachineInstance %s: %w", name, err)
+	}
+
+	filesystems := "filesystems"
+	if len(c.mountpoints) > 0 {
+		filesystems = "filesystems " + strings.Join(c.mountpoints, ", ")
+	}
+	if c.duration > 0 {
+		cmd.Printf("VMI %s %s frozen, they will be thawed automatically in %s\n", name, filesystems, c.duration)
+	} else {
+		cmd.Printf("VMI %s %s frozen until unfrozen\n", name, filesystems)
+	}
+	result.RecordChange(cmd.Context(), result.Change{Action: "Freeze", Kind: v1.VirtualMachineInstanceGroupVersionKind.Kind, Namespace: namespace, Name: name})
+	return nil
+}
+
+func (c *freezeCommand) validate(cmd *cobra.Command) error {
+	if c.status {
+		for _, arg := range []string{durationArg, fsArg} {
+			if cmd.Flags().Changed(arg) {
+				return fmt.Errorf("--%s cannot be used with --%s", arg, statusArg)
+			}
+		}
+		return nil
+	}
+	if c.duration < 0 {
+		return fmt.Errorf("--%s must not be negative", durationArg)
+	}
+	for _, mountpoint := range c.mountpoints {
+		if !path.IsAbs(mountpoint) {
+			return fmt.Errorf("--%s must be an absolute mount point, got %q", fsArg, mountpoint)
+		}
+	}
+	return nil
+}
+
+func runUnfreeze(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if err := virtClient.VirtualMachineInstance(namespace).Unfreeze(cmd.Context(), name); err != nil {
+		return fmt.Errorf("error unfreezing VirtualMachineInstance %s: %w", name, err)
+	}
+	cmd.Printf("VMI %s filesystems thawed\n", name)
+	result.RecordChange(cmd.Context(), result.Change{Action: "Unfreeze", Kind: v1.VirtualMachineInstanceGroupVersionKind.Kind, Namespace: namespace, Name: name})
+	return nil
+}
+
+// freezeState reports the requested freeze state of the guest filesystems,
+// which the guest agent poller keeps in the VMI status.
+func freezeState(vmi *v1.VirtualMachineInstance) string {
+	if vmi.Status.FSFreezeStatus == frozenState {
+		return frozenState
+	}
+	return thawedState
+}
@@ -0,0 +1,119 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package freeze_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Freeze", func() {
+	const vmiName = "testvmi"
+
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+	})
+
+	expectFreeze := func(duration time.Duration, mountpoints []string) *gomock.Call {
+		return vmiInterface.EXPECT().FreezeWithOptions(gomock.Any(), vmiName, &v1.FreezeUnfreezeTimeout{
+			UnfreezeTimeout: &metav1.Duration{Duration: duration},
+			Mountpoints:     mountpoints,
+		})
+	}
+
+	It("should freeze all filesystems with the default automatic thaw", func() {
+		expectFreeze(5*time.Minute, nil).Return(nil)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("freeze", vmiName)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("VMI testvmi filesystems frozen, they will be thawed automatically in 5m0s\n"))
+	})
+
+	It("should freeze only the selected filesystems", func() {
+		expectFreeze(30*time.Second, []string{"/data", "/var/lib/db"}).Return(nil)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("freeze", vmiName, "--fs", "/data", "--fs", "/var/lib/db", "--duration", "30s")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("filesystems /data, /var/lib/db frozen"))
+	})
+
+	It("should keep the filesystems frozen when the automatic thaw is disabled", func() {
+		expectFreeze(0, nil).Return(nil)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("freeze", vmiName, "--duration", "0")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("VMI testvmi filesystems frozen until unfrozen\n"))
+	})
+
+	It("should return the error of a failing freeze", func() {
+		expectFreeze(5*time.Minute, []string{"/data"}).Return(errors.New("guest agent not supported"))
+
+		err := testing.NewRepeatableVirtctlCommand("freeze", vmiName, "--fs", "/data")()
+		Expect(err).To(MatchError(ContainSubstring("error freezing VirtualMachineInstance testvmi: guest agent not supported")))
+	})
+
+	DescribeTable("should print the freeze state", func(fsFreezeStatus, expected string) {
+		vmiInterface.EXPECT().Get(gomock.Any(), vmiName, gomock.Any()).Return(&v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: vmiName},
+			Status:     v1.VirtualMachineInstanceStatus{FSFreezeStatus: fsFreezeStatus},
+		}, nil)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("freeze", vmiName, "--status")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(expected))
+	},
+		Entry("when frozen", "frozen", "VMI testvmi filesystems: frozen\n"),
+		Entry("when thawed", "", "VMI testvmi filesystems: thawed\n"),
+	)
+
+	DescribeTable("should reject invalid arguments", func(expected string, args ...string) {
+		err := testing.NewRepeatableVirtctlCommand(append([]string{"freeze", vmiName}, args...)...)()
+		Expect(err).To(MatchError(ContainSubstring(expected)))
+	},
+		Entry("negative duration", "--duration must not be negative", "--duration", "-1s"),
+		Entry("relative mount point", "--fs must be an absolute mount point", "--fs", "data"),
+		Entry("status with duration", "--duration cannot be used with --status", "--status", "--duration", "1m"),
+		Entry("status with fs", "--fs cannot be used with --status", "--status", "--fs", "/data"),
+	)
+
+	It("should unfreeze the filesystems", func() {
+		vmiInterface.EXPECT().Unfreeze(gomock.Any(), vmiName).Return(nil)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("unfreeze", vmiName)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("VMI testvmi filesystems thawed\n"))
+	})
+})
@@ -0,0 +1,377 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package get
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	outputFlag        = "output"
+	selectorFlag      = "selector"
+	allNamespacesFlag = "all-namespaces"
+
+	outputJSON     = "json"
+	outputYAML     = "yaml"
+	outputName     = "name"
+	outputJSONPath = "jsonpath="
+
+	none = "<none>"
+)
+
+type command struct {
+	output        string
+	selector      string
+	allNamespaces bool
+}
+
+type target struct {
+	resource *kvResource
+	name     string
+}
+
+func NewCommand() *cobra.Command {
+	c := command{}
+	cmd := &cobra.Command{
+		Use:               "get (RESOURCE [NAME...] | RESOURCE/NAME...)",
+		Short:             "Display one or many KubeVirt resources.",
+		Example:           usage(),
+		Args:              cobra.MinimumNArgs(1),
+		RunE:              c.run,
+		ValidArgsFunction: c.complete,
+	}
+	cmd.Flags().StringVarP(&c.output, outputFlag, "o", "", "Output format. One of: json|yaml|name|jsonpath=TEMPLATE")
+	cmd.Flags().StringVarP(&c.selector, selectorFlag, "l", "", "Label selector to filter the listed resources on.")
+	cmd.Flags().BoolVarP(&c.allNamespaces, allNamespacesFlag, "A", false, "List the resources across all namespaces.")
+	cmd.AddCommand(newKVResourcesCommand())
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # List all VirtualMachines in the current namespace
+  {{ProgramName}} get vm
+
+  # Show a single VirtualMachineInstance
+  {{ProgramName}} get vmi my-vm
+
+  # Show a VirtualMachine and its VirtualMachineInstance
+  {{ProgramName}} get vm/my-vm vmi/my-vm
+
+  # List all running migrations across all namespaces
+  {{ProgramName}} get vmim -A -o jsonpath='{range .items[?(@.status.phase=="Running")]}{.metadata.name}{"\n"}{end}'
+
+  # List the known KubeVirt resource types and their abbreviations
+  {{ProgramName}} get kv-resources`
+}
+
+func (c *command) run(cmd *cobra.Command, args []string) error {
+	targets, err := parseTargets(args)
+	if err != nil {
+		return err
+	}
+	for _, t := range targets {
+		if t.name != "" && c.selector != "" {
+			return fmt.Errorf("a name cannot be provided when a selector is specified")
+		}
+		if t.name != "" && c.allNamespaces {
+			return fmt.Errorf("a resource cannot be retrieved by name across all namespaces")
+		}
+	}
+	if err := validateOutput(c.output); err != nil {
+		return err
+	}
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if c.allNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	var groups [][]map[string]interface{}
+	for _, t := range targets {
+		items, err := t.resource.get(cmd.Context(), virtClient.GeneratedKubeVirtClient(), namespace, t.name, metav1.ListOptions{LabelSelector: c.selector})
+		if err != nil {
+			if t.name != "" {
+				return fmt.Errorf("error getting %s %s: %v", t.resource.gvk.Kind, t.name, err)
+			}
+			return fmt.Errorf("error listing %s: %v", t.resource.name, err)
+		}
+		for _, item := range items {
+			item["apiVersion"] = t.resource.gvk.GroupVersion().String()
+			item["kind"] = t.resource.gvk.Kind
+		}
+		groups = append(groups, items)
+	}
+
+	out := cmd.OutOrStdout()
+	switch {
+	case c.output == "":
+		return c.printTables(cmd, targets, groups, namespace)
+	case c.output == outputName:
+		for i, items := range groups {
+			r := targets[i].resource
+			for _, item := range items {
+				fmt.Fprintf(out, "%s.%s/%s\n", r.singular, r.gvk.Group, objectName(item))
+			}
+		}
+		return nil
+	}
+
+	obj := toOutputObject(targets, groups)
+	switch {
+	case c.output == outputJSON:
+		data, err := json.MarshalIndent(obj, "", "    ")
+		if err != nil {
+			return fmt.Errorf("cannot marshal to JSON: %v", err)
+		}
+		fmt.Fprintln(out, string(data))
+	case c.output == outputYAML:
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("cannot marshal to YAML: %v", err)
+		}
+		fmt.Fprint(out, string(data))
+	default:
+		return printJSONPath(out, strings.TrimPrefix(c.output, outputJSONPath), obj)
+	}
+	return nil
+}
+
+func parseTargets(args []string) ([]target, error) {
+	if !strings.Contains(args[0], "/") {
+		r, err := findResource(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 1 {
+			return []target{{resource: r}}, nil
+		}
+		var targets []target
+		for _, name := range args[1:] {
+			if strings.Contains(name, "/") {
+				return nil, fmt.Errorf("there is no need to specify a resource type as a separate argument when passing arguments in resource/name form")
+			}
+			targets = append(targets, target{resource: r, name: name})
+		}
+		return targets, nil
+	}
+
+	var targets []target
+	for _, arg := range args {
+		resourceName, name, found := strings.Cut(arg, "/")
+		if !found || name == "" {
+			return nil, fmt.Errorf("arguments in resource/name form must have a single resource and name, got %q", arg)
+		}
+		r, err := findResource(resourceName)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target{resource: r, name: name})
+	}
+	return targets, nil
+}
+
+func validateOutput(output string) error {
+	switch {
+	case output == "", output == outputJSON, output == outputYAML, output == outputName:
+		return nil
+	case strings.HasPrefix(output, outputJSONPath):
+		if _, err := parseJSONPath(strings.TrimPrefix(output, outputJSONPath)); err != nil {
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported output format: %s (must be one of json|yaml|name|jsonpath=TEMPLATE)", output)
+}
+
+// toOutputObject returns the single requested object or, if a list or more
+// than one object was requested, a List containing all fetched objects.
+func toOutputObject(targets []target, groups [][]map[string]interface{}) interface{} {
+	if len(targets) == 1 && targets[0].name != "" && len(groups[0]) == 1 {
+		return groups[0][0]
+	}
+	items := []interface{}{}
+	for _, group := range groups {
+		for _, item := range group {
+			items = append(items, item)
+		}
+	}
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"metadata":   map[string]interface{}{},
+		"items":      items,
+	}
+}
+
+func parseJSONPath(template string) (*jsonpath.JSONPath, error) {
+	j := jsonpath.New(outputFlag).AllowMissingKeys(true)
+	if err := j.Parse(template); err != nil {
+		return nil, fmt.Errorf("error parsing jsonpath %s: %v", template, err)
+	}
+	return j, nil
+}
+
+func printJSONPath(out io.Writer, template string, obj interface{}) error {
+	j, err := parseJSONPath(template)
+	if err != nil {
+		return err
+	}
+	if err := j.Execute(out, obj); err != nil {
+		return fmt.Errorf("error executing jsonpath %s: %v", template, err)
+	}
+	return nil
+}
+
+func (c *command) printTables(cmd *cobra.Command, targets []target, groups [][]map[string]interface{}, namespace string) error {
+	out := cmd.OutOrStdout()
+	printed := 0
+	for i, items := range groups {
+		if len(items) == 0 {
+			continue
+		}
+		// Consecutive targets of the same resource share one table
+		if i > 0 && targets[i-1].resource == targets[i].resource && len(groups[i-1]) > 0 {
+			if err := printRows(out, targets[i].resource, items, c.allNamespaces, false); err != nil {
+				return err
+			}
+			continue
+		}
+		if printed > 0 {
+			fmt.Fprintln(out)
+		}
+		if err := printRows(out, targets[i].resource, items, c.allNamespaces, true); err != nil {
+			return err
+		}
+		printed++
+	}
+
+	if printed == 0 {
+		if namespace == metav1.NamespaceAll || !targets[0].resource.namespaced {
+			cmd.PrintErrln("No resources found")
+		} else {
+			cmd.PrintErrf("No resources found in %s namespace.\n", namespace)
+		}
+	}
+	return nil
+}
+
+func printRows(out io.Writer, r *kvResource, items []map[string]interface{}, allNamespaces, header bool) error {
+	columns := []column{{header: "NAME", jsonPath: "{.metadata.name}"}}
+	if allNamespaces && r.namespaced {
+		columns = append([]column{{header: "NAMESPACE", jsonPath: "{.metadata.namespace}"}}, columns...)
+	}
+	columns = append(columns, r.columns...)
+
+	parsers := make([]*jsonpath.JSONPath, 0, len(columns))
+	headers := make([]string, 0, len(columns))
+	for _, col := range columns {
+		j, err := parseJSONPath(col.jsonPath)
+		if err != nil {
+			return err
+		}
+		parsers = append(parsers, j)
+		headers = append(headers, col.header)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return objectNamespace(items[i])+"/"+objectName(items[i]) < objectNamespace(items[j])+"/"+objectName(items[j])
+	})
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if header {
+		fmt.Fprintln(w, strings.Join(headers, "\t"))
+	}
+	for _, item := range items {
+		cells := make([]string, 0, len(parsers))
+		for _, j := range parsers {
+			buf := &bytes.Buffer{}
+			if err := j.Execute(buf, item); err != nil {
+				return err
+			}
+			cell := buf.String()
+			if cell == "" {
+				cell = none
+			}
+			cells = append(cells, cell)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+func objectName(obj map[string]interface{}) string {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+func objectNamespace(obj map[string]interface{}) string {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	namespace, _ := metadata["namespace"].(string)
+	return namespace
+}
+
+func (c *command) complete(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completion.FilterPrefix(resourceNames(), toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+	if strings.Contains(args[0], "/") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	r, err := findResource(args[0])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	items, err := r.get(cmd.Context(), virtClient.GeneratedKubeVirtClient(), namespace, "", metav1.ListOptions{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, objectName(item))
+	}
+	sort.Strings(names)
+	return completion.FilterPrefix(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,221 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package get_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Get command", func() {
+	const otherNamespace = "other"
+
+	var virtClient *kubevirtfake.Clientset
+
+	createVM := func(namespace, name string, status v1.VirtualMachinePrintableStatus, labels map[string]string) {
+		vm := libvmi.NewVirtualMachine(libvmi.New(
+			libvmi.WithNamespace(namespace),
+			libvmi.WithName(name),
+		))
+		vm.Labels = labels
+		vm.Status.PrintableStatus = status
+		_, err := virtClient.KubevirtV1().VirtualMachines(namespace).Create(context.Background(), vm, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	createVMI := func(name, node string) {
+		vmi := libvmi.New(
+			libvmi.WithNamespace(metav1.NamespaceDefault),
+			libvmi.WithName(name),
+		)
+		vmi.Status.Phase = v1.Running
+		vmi.Status.NodeName = node
+		vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{{IP: "10.0.2.2"}}
+		_, err := virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).Create(context.Background(), vmi, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		virtClient = kubevirtfake.NewSimpleClientset()
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().GeneratedKubeVirtClient().Return(virtClient).AnyTimes()
+
+		createVM(metav1.NamespaceDefault, "vm-b", v1.VirtualMachineStatusStopped, map[string]string{"app": "b"})
+		createVM(metav1.NamespaceDefault, "vm-a", v1.VirtualMachineStatusRunning, map[string]string{"app": "a"})
+		createVM(otherNamespace, "vm-c", v1.VirtualMachineStatusRunning, nil)
+		createVMI("vm-a", "node01")
+	})
+
+	It("should fail with missing input parameters", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("get")
+		Expect(cmd()).To(MatchError("requires at least 1 arg(s), only received 0"))
+	})
+
+	It("should fail with an unknown resource type", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("get", "pods")
+		Expect(cmd()).To(MatchError(ContainSubstring(`doesn't have a KubeVirt resource type "pods"`)))
+	})
+
+	It("should fail with an unsupported output format", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("get", "vm", "-o", "wide")
+		Expect(cmd()).To(MatchError(ContainSubstring("unsupported output format: wide")))
+	})
+
+	It("should fail if a name is combined with a selector", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("get", "vm", "vm-a", "-l", "app=a")
+		Expect(cmd()).To(MatchError("a name cannot be provided when a selector is specified"))
+	})
+
+	It("should fail if a name is combined with all namespaces", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("get", "vm", "vm-a", "-A")
+		Expect(cmd()).To(MatchError("a resource cannot be retrieved by name across all namespaces"))
+	})
+
+	It("should fail if the resource does not exist", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("get", "vmi", "vm-b")
+		Expect(cmd()).To(MatchError(ContainSubstring("error getting VirtualMachineInstance vm-b")))
+	})
+
+	DescribeTable("should list VirtualMachines using the alias", func(alias string) {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("get", alias)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(`NAME   STATUS    READY
+vm-a   Running   <none>
+vm-b   Stopped   <none>
+`))
+	},
+		Entry("vm", "vm"),
+		Entry("vms", "vms"),
+		Entry("singular", "virtualmachine"),
+		Entry("plural", "virtualmachines"),
+		Entry("kind", "VirtualMachine"),
+		Entry("group qualified", "vm.kubevirt.io"),
+	)
+
+	It("should list VirtualMachines across all namespaces", func() {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("get", "vm", "-A")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(`NAMESPACE   NAME   STATUS    READY
+default     vm-a   Running   <none>
+default     vm-b   Stopped   <none>
+other       vm-c   Running   <none>
+`))
+	})
+
+	It("should filter by label selector", func() {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("get", "vm", "-l", "app=b", "-o", "name")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("virtualmachine.kubevirt.io/vm-b\n"))
+	})
+
+	It("should print nothing to stdout if no resources were found", func() {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("get", "vmim")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(BeEmpty())
+	})
+
+	It("should print resources in resource/name form in separate tables", func() {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("get", "vm/vm-a", "vmi/vm-a")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(`NAME   STATUS    READY
+vm-a   Running   <none>
+
+NAME   PHASE     IP         NODENAME   READY
+vm-a   Running   10.0.2.2   node01     <none>
+`))
+	})
+
+	It("should apply a JSONPath template to a single resource", func() {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("get", "vmi", "vm-a", "-o", "jsonpath={.kind}/{.metadata.name}: {.status.nodeName}")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("VirtualMachineInstance/vm-a: node01"))
+	})
+
+	It("should apply a JSONPath template to a list of resources", func() {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("get", "vm",
+			"-o", `jsonpath={range .items[?(@.status.printableStatus=="Running")]}{.metadata.name}{"\n"}{end}`)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("vm-a\n"))
+	})
+
+	It("should fail with an invalid JSONPath template", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("get", "vm", "-o", "jsonpath={.metadata.name")
+		Expect(cmd()).To(MatchError(ContainSubstring("error parsing jsonpath")))
+	})
+
+	It("should print a single resource as YAML", func() {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("get", "vm/vm-a", "-o", "yaml")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(HavePrefix("apiVersion: kubevirt.io/v1\nkind: VirtualMachine\n"))
+	})
+
+	It("should print a list of resources as JSON", func() {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("get", "vm", "vm-a", "vm-b", "-o", "json")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(HavePrefix("{\n    \"apiVersion\": \"v1\",\n    \"items\": ["))
+		Expect(string(out)).To(ContainSubstring(`"name": "vm-a"`))
+		Expect(string(out)).To(ContainSubstring(`"name": "vm-b"`))
+	})
+
+	Context("completion", func() {
+		It("should complete resource types", func() {
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("__complete", "get", "vmsnapshot")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(HavePrefix("vmsnapshot\nvmsnapshotcontent\nvmsnapshotcontents\nvmsnapshots\n:4\n"))
+		})
+
+		It("should complete resource names", func() {
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("__complete", "get", "vm", "")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(HavePrefix("vm-a\nvm-b\n:4\n"))
+		})
+	})
+
+	Context("kv-resources", func() {
+		It("should list the known resource types", func() {
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("get", "kv-resources")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(HavePrefix("NAME "))
+			Expect(string(out)).To(MatchRegexp(`(?m)^virtualmachines\s+vm,vms\s+kubevirt.io/v1\s+true\s+VirtualMachine$`))
+			Expect(string(out)).To(MatchRegexp(`(?m)^migrationpolicies\s+migrations.kubevirt.io/v1alpha1\s+false\s+MigrationPolicy$`))
+			Expect(string(out)).To(MatchRegexp(`(?m)^virtualmachineoperations\s+vmop,vmops\s+operations.kubevirt.io/v1alpha1\s+true\s+VirtualMachineOperation$`))
+		})
+
+		It("should list the names of the known resource types", func() {
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("get", "kv-resources", "-o", "name")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("virtualmachineinstancemigrations.kubevirt.io\n"))
+		})
+	})
+})
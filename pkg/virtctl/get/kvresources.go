@@ -0,0 +1,82 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package get
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+func newKVResourcesCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:     "kv-resources",
+		Short:   "List the KubeVirt resource types known to virtctl get and their abbreviations.",
+		Example: kvResourcesUsage(),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return printKVResources(cmd, output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, outputFlag, "o", "", "Output format. One of: name")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func kvResourcesUsage() string {
+	return `  # List the KubeVirt resource types
+  {{ProgramName}} get kv-resources
+
+  # List only the names of the KubeVirt resource types
+  {{ProgramName}} get kv-resources -o name`
+}
+
+func printKVResources(cmd *cobra.Command, output string) error {
+	resources := make([]*kvResource, 0, len(kvResources))
+	for i := range kvResources {
+		resources = append(resources, &kvResources[i])
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].name < resources[j].name })
+
+	out := cmd.OutOrStdout()
+	switch output {
+	case "":
+	case outputName:
+		for _, r := range resources {
+			fmt.Fprintf(out, "%s.%s\n", r.name, r.gvk.Group)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s (must be 'name')", output)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSHORTNAMES\tAPIVERSION\tNAMESPACED\tKIND")
+	for _, r := range resources {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", r.name, strings.Join(r.shortNames, ","), r.gvk.GroupVersion().String(), r.namespaced, r.gvk.Kind)
+	}
+	return w.Flush()
+}
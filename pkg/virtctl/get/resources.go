@@ -0,0 +1,392 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package get
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	backupv1alpha1 "kubevirt.io/api/backup/v1alpha1"
+	clonev1beta1 "kubevirt.io/api/clone/v1beta1"
+	v1 "kubevirt.io/api/core/v1"
+	exportv1beta1 "kubevirt.io/api/export/v1beta1"
+	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	migrationsv1alpha1 "kubevirt.io/api/migrations/v1alpha1"
+	operationsv1alpha1 "kubevirt.io/api/operations/v1alpha1"
+	poolv1beta1 "kubevirt.io/api/pool/v1beta1"
+	snapshotv1beta1 "kubevirt.io/api/snapshot/v1beta1"
+	generatedclient "kubevirt.io/client-go/kubevirt"
+)
+
+const (
+	phaseJSONPath           = "{.status.phase}"
+	readyConditionJSONPath  = "{.status.conditions[?(@.type=='Ready')].status}"
+	sourceNameJSONPath      = "{.spec.source.name}"
+	sourceKindJSONPath      = "{.spec.source.kind}"
+	readyToUseJSONPath      = "{.status.readyToUse}"
+	desiredReplicasJSONPath = "{.spec.replicas}"
+	currentReplicasJSONPath = "{.status.replicas}"
+	readyReplicasJSONPath   = "{.status.readyReplicas}"
+	errorMessageJSONPath    = "{.status.error.message}"
+)
+
+type column struct {
+	header   string
+	jsonPath string
+}
+
+type getLister[T, L runtime.Object] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	List(ctx context.Context, opts metav1.ListOptions) (L, error)
+}
+
+// accessor fetches a single object by name or lists objects of a resource
+// and returns them in their unstructured form.
+type accessor func(ctx context.Context, client generatedclient.Interface, namespace, name string, opts metav1.ListOptions) ([]map[string]interface{}, error)
+
+// kvResource describes a KubeVirt resource which can be queried with virtctl get.
+type kvResource struct {
+	name       string
+	singular   string
+	shortNames []string
+	gvk        schema.GroupVersionKind
+	namespaced bool
+	columns    []column
+	get        accessor
+}
+
+func typed[T, L runtime.Object](clientFor func(client generatedclient.Interface, namespace string) getLister[T, L]) accessor {
+	return func(ctx context.Context, client generatedclient.Interface, namespace, name string, opts metav1.ListOptions) ([]map[string]interface{}, error) {
+		c := clientFor(client, namespace)
+		if name != "" {
+			obj, err := c.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			if err != nil {
+				return nil, err
+			}
+			return []map[string]interface{}{u}, nil
+		}
+
+		list, err := c.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(list)
+		if err != nil {
+			return nil, err
+		}
+		rawItems, _ := u["items"].([]interface{})
+		items := make([]map[string]interface{}, 0, len(rawItems))
+		for _, item := range rawItems {
+			if obj, ok := item.(map[string]interface{}); ok {
+				items = append(items, obj)
+			}
+		}
+		return items, nil
+	}
+}
+
+var kvResources = []kvResource{
+	{
+		name:       "virtualmachines",
+		singular:   "virtualmachine",
+		shortNames: []string{"vm", "vms"},
+		gvk:        v1.VirtualMachineGroupVersionKind,
+		namespaced: true,
+		columns: []column{
+			{header: "STATUS", jsonPath: "{.status.printableStatus}"},
+			{header: "READY", jsonPath: readyConditionJSONPath},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*v1.VirtualMachine, *v1.VirtualMachineList] {
+			return c.KubevirtV1().VirtualMachines(ns)
+		}),
+	},
+	{
+		name:       "virtualmachineinstances",
+		singular:   "virtualmachineinstance",
+		shortNames: []string{"vmi", "vmis"},
+		gvk:        v1.VirtualMachineInstanceGroupVersionKind,
+		namespaced: true,
+		columns: []column{
+			{header: "PHASE", jsonPath: phaseJSONPath},
+			{header: "IP", jsonPath: "{.status.interfaces[0].ipAddress}"},
+			{header: "NODENAME", jsonPath: "{.status.nodeName}"},
+			{header: "READY", jsonPath: readyConditionJSONPath},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*v1.VirtualMachineInstance, *v1.VirtualMachineInstanceList] {
+			return c.KubevirtV1().VirtualMachineInstances(ns)
+		}),
+	},
+	{
+		name:       "virtualmachineinstancemigrations",
+		singular:   "virtualmachineinstancemigration",
+		shortNames: []string{"vmim", "vmims"},
+		gvk:        v1.VirtualMachineInstanceMigrationGroupVersionKind,
+		namespaced: true,
+		columns: []column{
+			{header: "PHASE", jsonPath: phaseJSONPath},
+			{header: "VMI", jsonPath: "{.spec.vmiName}"},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*v1.VirtualMachineInstanceMigration, *v1.VirtualMachineInstanceMigrationList] {
+			return c.KubevirtV1().VirtualMachineInstanceMigrations(ns)
+		}),
+	},
+	{
+		name:       "virtualmachineinstancereplicasets",
+		singular:   "virtualmachineinstancereplicaset",
+		shortNames: []string{"vmirs", "vmirss"},
+		gvk:        v1.VirtualMachineInstanceReplicaSetGroupVersionKind,
+		namespaced: true,
+		columns: []column{
+			{header: "DESIRED", jsonPath: desiredReplicasJSONPath},
+			{header: "CURRENT", jsonPath: currentReplicasJSONPath},
+			{header: "READY", jsonPath: readyReplicasJSONPath},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*v1.VirtualMachineInstanceReplicaSet, *v1.VirtualMachineInstanceReplicaSetList] {
+			return c.KubevirtV1().VirtualMachineInstanceReplicaSets(ns)
+		}),
+	},
+	{
+		name:       "virtualmachinepools",
+		singular:   "virtualmachinepool",
+		shortNames: []string{"vmpool", "vmpools"},
+		gvk:        poolv1beta1.SchemeGroupVersion.WithKind("VirtualMachinePool"),
+		namespaced: true,
+		columns: []column{
+			{header: "DESIRED", jsonPath: desiredReplicasJSONPath},
+			{header: "CURRENT", jsonPath: currentReplicasJSONPath},
+			{header: "READY", jsonPath: readyReplicasJSONPath},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*poolv1beta1.VirtualMachinePool, *poolv1beta1.VirtualMachinePoolList] {
+			return c.PoolV1beta1().VirtualMachinePools(ns)
+		}),
+	},
+	{
+		name:       "virtualmachinesnapshots",
+		singular:   "virtualmachinesnapshot",
+		shortNames: []string{"vmsnapshot", "vmsnapshots"},
+		gvk:        snapshotv1beta1.SchemeGroupVersion.WithKind("VirtualMachineSnapshot"),
+		namespaced: true,
+		columns: []column{
+			{header: "SOURCEKIND", jsonPath: sourceKindJSONPath},
+			{header: "SOURCENAME", jsonPath: sourceNameJSONPath},
+			{header: "PHASE", jsonPath: phaseJSONPath},
+			{header: "READYTOUSE", jsonPath: readyToUseJSONPath},
+			{header: "ERROR", jsonPath: errorMessageJSONPath},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*snapshotv1beta1.VirtualMachineSnapshot, *snapshotv1beta1.VirtualMachineSnapshotList] {
+			return c.SnapshotV1beta1().VirtualMachineSnapshots(ns)
+		}),
+	},
+	{
+		name:       "virtualmachinesnapshotcontents",
+		singular:   "virtualmachinesnapshotcontent",
+		shortNames: []string{"vmsnapshotcontent", "vmsnapshotcontents"},
+		gvk:        snapshotv1beta1.SchemeGroupVersion.WithKind("VirtualMachineSnapshotContent"),
+		namespaced: true,
+		columns: []column{
+			{header: "READYTOUSE", jsonPath: readyToUseJSONPath},
+			{header: "ERROR", jsonPath: errorMessageJSONPath},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*snapshotv1beta1.VirtualMachineSnapshotContent, *snapshotv1beta1.VirtualMachineSnapshotContentList] {
+			return c.SnapshotV1beta1().VirtualMachineSnapshotContents(ns)
+		}),
+	},
+	{
+		name:       "virtualmachinerestores",
+		singular:   "virtualmachinerestore",
+		shortNames: []string{"vmrestore", "vmrestores"},
+		gvk:        snapshotv1beta1.SchemeGroupVersion.WithKind("VirtualMachineRestore"),
+		namespaced: true,
+		columns: []column{
+			{header: "TARGETKIND", jsonPath: "{.spec.target.kind}"},
+			{header: "TARGETNAME", jsonPath: "{.spec.target.name}"},
+			{header: "COMPLETE", jsonPath: "{.status.complete}"},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*snapshotv1beta1.VirtualMachineRestore, *snapshotv1beta1.VirtualMachineRestoreList] {
+			return c.SnapshotV1beta1().VirtualMachineRestores(ns)
+		}),
+	},
+	{
+		name:       "virtualmachineexports",
+		singular:   "virtualmachineexport",
+		shortNames: []string{"vmexport", "vmexports"},
+		gvk:        exportv1beta1.SchemeGroupVersion.WithKind("VirtualMachineExport"),
+		namespaced: true,
+		columns: []column{
+			{header: "SOURCEKIND", jsonPath: sourceKindJSONPath},
+			{header: "SOURCENAME", jsonPath: sourceNameJSONPath},
+			{header: "PHASE", jsonPath: phaseJSONPath},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*exportv1beta1.VirtualMachineExport, *exportv1beta1.VirtualMachineExportList] {
+			return c.ExportV1beta1().VirtualMachineExports(ns)
+		}),
+	},
+	{
+		name:       "virtualmachineclones",
+		singular:   "virtualmachineclone",
+		shortNames: []string{"vmclone", "vmclones"},
+		gvk:        clonev1beta1.VirtualMachineCloneKind,
+		namespaced: true,
+		columns: []column{
+			{header: "PHASE", jsonPath: phaseJSONPath},
+			{header: "SOURCEVIRTUALMACHINE", jsonPath: sourceNameJSONPath},
+			{header: "TARGETVIRTUALMACHINE", jsonPath: "{.spec.target.name}"},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*clonev1beta1.VirtualMachineClone, *clonev1beta1.VirtualMachineCloneList] {
+			return c.CloneV1beta1().VirtualMachineClones(ns)
+		}),
+	},
+	{
+		name:       "virtualmachinebackups",
+		singular:   "virtualmachinebackup",
+		shortNames: []string{"vmbackup", "vmbackups"},
+		gvk:        backupv1alpha1.VirtualMachineBackupGroupVersionKind,
+		namespaced: true,
+		columns: []column{
+			{header: "SOURCEKIND", jsonPath: sourceKindJSONPath},
+			{header: "SOURCENAME", jsonPath: sourceNameJSONPath},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*backupv1alpha1.VirtualMachineBackup, *backupv1alpha1.VirtualMachineBackupList] {
+			return c.BackupV1alpha1().VirtualMachineBackups(ns)
+		}),
+	},
+	{
+		name:       "virtualmachineoperations",
+		singular:   "virtualmachineoperation",
+		shortNames: []string{"vmop", "vmops"},
+		gvk:        operationsv1alpha1.VirtualMachineOperationGroupVersionKind,
+		namespaced: true,
+		columns: []column{
+			{header: "OPERATION", jsonPath: "{.spec.operation}"},
+			{header: "PHASE", jsonPath: phaseJSONPath},
+			{header: "TOTAL", jsonPath: "{.status.progress.total}"},
+			{header: "SUCCEEDED", jsonPath: "{.status.progress.succeeded}"},
+			{header: "FAILED", jsonPath: "{.status.progress.failed}"},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*operationsv1alpha1.VirtualMachineOperation, *operationsv1alpha1.VirtualMachineOperationList] {
+			return c.OperationsV1alpha1().VirtualMachineOperations(ns)
+		}),
+	},
+	{
+		name:       "virtualmachineinstancetypes",
+		singular:   "virtualmachineinstancetype",
+		shortNames: []string{"vminstancetype", "vminstancetypes", "vmf", "vmfs"},
+		gvk:        instancetypev1beta1.SchemeGroupVersion.WithKind("VirtualMachineInstancetype"),
+		namespaced: true,
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*instancetypev1beta1.VirtualMachineInstancetype, *instancetypev1beta1.VirtualMachineInstancetypeList] {
+			return c.InstancetypeV1beta1().VirtualMachineInstancetypes(ns)
+		}),
+	},
+	{
+		name:       "virtualmachineclusterinstancetypes",
+		singular:   "virtualmachineclusterinstancetype",
+		shortNames: []string{"vmclusterinstancetype", "vmclusterinstancetypes", "vmcf", "vmcfs"},
+		gvk:        instancetypev1beta1.SchemeGroupVersion.WithKind("VirtualMachineClusterInstancetype"),
+		get: typed(func(c generatedclient.Interface, _ string) getLister[*instancetypev1beta1.VirtualMachineClusterInstancetype, *instancetypev1beta1.VirtualMachineClusterInstancetypeList] {
+			return c.InstancetypeV1beta1().VirtualMachineClusterInstancetypes()
+		}),
+	},
+	{
+		name:       "virtualmachinepreferences",
+		singular:   "virtualmachinepreference",
+		shortNames: []string{"vmpref", "vmprefs", "vmp", "vmps"},
+		gvk:        instancetypev1beta1.SchemeGroupVersion.WithKind("VirtualMachinePreference"),
+		namespaced: true,
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*instancetypev1beta1.VirtualMachinePreference, *instancetypev1beta1.VirtualMachinePreferenceList] {
+			return c.InstancetypeV1beta1().VirtualMachinePreferences(ns)
+		}),
+	},
+	{
+		name:       "virtualmachineclusterpreferences",
+		singular:   "virtualmachineclusterpreference",
+		shortNames: []string{"vmcp", "vmcps"},
+		gvk:        instancetypev1beta1.SchemeGroupVersion.WithKind("VirtualMachineClusterPreference"),
+		get: typed(func(c generatedclient.Interface, _ string) getLister[*instancetypev1beta1.VirtualMachineClusterPreference, *instancetypev1beta1.VirtualMachineClusterPreferenceList] {
+			return c.InstancetypeV1beta1().VirtualMachineClusterPreferences()
+		}),
+	},
+	{
+		name:     "migrationpolicies",
+		singular: "migrationpolicy",
+		gvk:      migrationsv1alpha1.MigrationPolicyKind,
+		get: typed(func(c generatedclient.Interface, _ string) getLister[*migrationsv1alpha1.MigrationPolicy, *migrationsv1alpha1.MigrationPolicyList] {
+			return c.MigrationsV1alpha1().MigrationPolicies()
+		}),
+	},
+	{
+		name:       "kubevirts",
+		singular:   "kubevirt",
+		shortNames: []string{"kv", "kvs"},
+		gvk:        v1.KubeVirtGroupVersionKind,
+		namespaced: true,
+		columns: []column{
+			{header: "PHASE", jsonPath: phaseJSONPath},
+		},
+		get: typed(func(c generatedclient.Interface, ns string) getLister[*v1.KubeVirt, *v1.KubeVirtList] {
+			return c.KubevirtV1().KubeVirts(ns)
+		}),
+	},
+}
+
+// aliases returns all names under which the resource can be referenced.
+func (r *kvResource) aliases() []string {
+	return append([]string{r.name, r.singular, strings.ToLower(r.gvk.Kind)}, r.shortNames...)
+}
+
+// findResource resolves a plural, singular, kind or short name, optionally
+// qualified with the API group (e.g. vm.kubevirt.io), to a KubeVirt resource.
+func findResource(name string) (*kvResource, error) {
+	name = strings.ToLower(name)
+	for i := range kvResources {
+		r := &kvResources[i]
+		for _, alias := range r.aliases() {
+			if name == alias || name == alias+"."+r.gvk.Group {
+				return r, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("the server doesn't have a KubeVirt resource type %q, use \"kv-resources\" to list the known resource types", name)
+}
+
+// resourceNames returns all names and aliases of the known resources in a
+// sorted order, suitable for shell completion.
+func resourceNames() []string {
+	names := map[string]struct{}{}
+	for i := range kvResources {
+		for _, alias := range kvResources[i].aliases() {
+			names[alias] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
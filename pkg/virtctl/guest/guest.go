@@ -0,0 +1,263 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package guest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	outputFlag  = "output"
+	outputTable = "table"
+	outputJSON  = "json"
+
+	none = "<none>"
+)
+
+type command struct {
+	output string
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "guest",
+		Short: "Show information reported by the guest agent of a running VirtualMachine.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(
+		newSubCommand("fs (VM)", "Show the filesystems of the guest with their usage.", fsUsage(), (*command).runFS),
+		newSubCommand("users (VM)", "Show the users logged in to the guest.", usersUsage(), (*command).runUsers),
+		newSubCommand("os (VM)", "Show the operating system of the guest.", osUsage(), (*command).runOS),
+	)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func newSubCommand(use, short, example string, run func(*command, *cobra.Command, string) error) *cobra.Command {
+	c := command{}
+	cmd := &cobra.Command{
+		Use:               use,
+		Short:             short,
+		Example:           example,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if c.output != outputTable && c.output != outputJSON {
+				return fmt.Errorf("unsupported output format: %s (must be '%s' or '%s')", c.output, outputTable, outputJSON)
+			}
+			return run(&c, cmd, args[0])
+		},
+	}
+	cmd.Flags().StringVarP(&c.output, outputFlag, "o", outputTable, "Output format. One of: table|json")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func fsUsage() string {
+	return `  # Show the filesystems of a VirtualMachine named 'my-vm'
+  {{ProgramName}} guest fs my-vm
+
+  # Show the filesystems of a VirtualMachine named 'my-vm' as JSON
+  {{ProgramName}} guest fs my-vm -o json`
+}
+
+func usersUsage() string {
+	return `  # Show the users logged in to a VirtualMachine named 'my-vm'
+  {{ProgramName}} guest users my-vm`
+}
+
+func osUsage() string {
+	return `  # Show the operating system of a VirtualMachine named 'my-vm'
+  {{ProgramName}} guest os my-vm`
+}
+
+func (c *command) runFS(cmd *cobra.Command, name string) error {
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	fsList, err := virtClient.VirtualMachineInstance(namespace).FilesystemList(cmd.Context(), name)
+	if err != nil {
+		return fmt.Errorf("error listing filesystems of VirtualMachineInstance %s: %v", name, err)
+	}
+
+	out := cmd.OutOrStdout()
+	if c.output == outputJSON {
+		return printJSON(out, fsList)
+	}
+
+	filesystems := fsList.Items
+	sort.SliceStable(filesystems, func(i, j int) bool { return filesystems[i].MountPoint < filesystems[j].MountPoint })
+	rows := make([][]string, 0, len(filesystems))
+	for _, fs := range filesystems {
+		rows = append(rows, []string{
+			fs.MountPoint,
+			fs.FileSystemType,
+			fs.DiskName,
+			formatBytes(int64(fs.UsedBytes)),
+			formatBytes(int64(fs.TotalBytes)),
+			usagePercent(fs.UsedBytes, fs.TotalBytes),
+		})
+	}
+	printTable(out, []string{"MOUNTPOINT", "TYPE", "DISK", "USED", "TOTAL", "USE%"}, rows)
+	return nil
+}
+
+func (c *command) runUsers(cmd *cobra.Command, name string) error {
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	userList, err := virtClient.VirtualMachineInstance(namespace).UserList(cmd.Context(), name)
+	if err != nil {
+		return fmt.Errorf("error listing users of VirtualMachineInstance %s: %v", name, err)
+	}
+
+	out := cmd.OutOrStdout()
+	if c.output == outputJSON {
+		return printJSON(out, userList)
+	}
+
+	rows := make([][]string, 0, len(userList.Items))
+	for _, user := range userList.Items {
+		rows = append(rows, []string{user.UserName, user.Domain, formatLoginTime(user.LoginTime)})
+	}
+	printTable(out, []string{"USER", "DOMAIN", "LOGIN TIME"}, rows)
+	return nil
+}
+
+func (c *command) runOS(cmd *cobra.Command, name string) error {
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	info, err := virtClient.VirtualMachineInstance(namespace).GuestOsInfo(cmd.Context(), name)
+	if err != nil {
+		return fmt.Errorf("error getting guest OS info of VirtualMachineInstance %s: %v", name, err)
+	}
+
+	out := cmd.OutOrStdout()
+	if c.output == outputJSON {
+		return printJSON(out, info)
+	}
+
+	printOS(out, info)
+	return nil
+}
+
+func printOS(out io.Writer, info v1.VirtualMachineInstanceGuestAgentInfo) {
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)
+	for _, field := range [][]string{
+		{"Hostname", info.Hostname},
+		{"OS", info.OS.PrettyName},
+		{"Name", info.OS.Name},
+		{"ID", info.OS.ID},
+		{"Version", info.OS.Version},
+		{"Version ID", info.OS.VersionID},
+		{"Kernel Release", info.OS.KernelRelease},
+		{"Kernel Version", info.OS.KernelVersion},
+		{"Machine", info.OS.Machine},
+		{"Timezone", info.Timezone},
+		{"Guest Agent", info.GAVersion},
+	} {
+		fmt.Fprintf(w, "%s:\t%s\n", field[0], valueOrNone(field[1]))
+	}
+	w.Flush()
+}
+
+func printTable(out io.Writer, header []string, rows [][]string) {
+	if len(rows) == 0 {
+		fmt.Fprintln(out, "No resources found.")
+		return
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		for i := range row {
+			row[i] = valueOrNone(row[i])
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
+
+func printJSON(out io.Writer, obj interface{}) error {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, string(data))
+	return nil
+}
+
+func usagePercent(used, total int) string {
+	if total <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d%%", int(math.Round(float64(used)*100/float64(total))))
+}
+
+func formatLoginTime(loginTime float64) string {
+	if loginTime <= 0 {
+		return ""
+	}
+	sec, frac := math.Modf(loginTime)
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC().Format(time.RFC3339)
+}
+
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return none
+	}
+	return value
+}
@@ -0,0 +1,165 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package guest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Guest command", func() {
+	const vmName = "testvm"
+
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+	})
+
+	DescribeTable("should fail with missing input parameters", func(subcommand string) {
+		_, err := testing.NewRepeatableVirtctlCommandWithOut("guest", subcommand)()
+		Expect(err).To(MatchError("accepts 1 arg(s), received 0"))
+	},
+		Entry("fs", "fs"),
+		Entry("users", "users"),
+		Entry("os", "os"),
+	)
+
+	It("should fail with an unsupported output format", func() {
+		_, err := testing.NewRepeatableVirtctlCommandWithOut("guest", "fs", vmName, "-o", "yaml")()
+		Expect(err).To(MatchError("unsupported output format: yaml (must be 'table' or 'json')"))
+	})
+
+	Context("fs", func() {
+		fsList := v1.VirtualMachineInstanceFileSystemList{
+			Items: []v1.VirtualMachineInstanceFileSystem{
+				{DiskName: "vdb1", MountPoint: "/data", FileSystemType: "xfs", UsedBytes: 512 * 1024 * 1024, TotalBytes: 2 * 1024 * 1024 * 1024},
+				{DiskName: "vda1", MountPoint: "/", FileSystemType: "ext4", UsedBytes: 3 * 1024 * 1024 * 1024, TotalBytes: 4 * 1024 * 1024 * 1024},
+			},
+		}
+
+		It("should print the filesystems sorted by mount point with their usage", func() {
+			vmiInterface.EXPECT().FilesystemList(gomock.Any(), vmName).Return(fsList, nil)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("guest", "fs", vmName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(MatchRegexp(`(?m)^MOUNTPOINT\s+TYPE\s+DISK\s+USED\s+TOTAL\s+USE%\n/\s+ext4\s+vda1\s+3.0GiB\s+4.0GiB\s+75%\n/data\s+xfs\s+vdb1\s+512.0MiB\s+2.0GiB\s+25%\n$`))
+		})
+
+		It("should print the filesystems as JSON", func() {
+			vmiInterface.EXPECT().FilesystemList(gomock.Any(), vmName).Return(fsList, nil)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("guest", "fs", vmName, "-o", "json")()
+			Expect(err).ToNot(HaveOccurred())
+			var printed v1.VirtualMachineInstanceFileSystemList
+			Expect(json.Unmarshal(out, &printed)).To(Succeed())
+			Expect(printed).To(Equal(fsList))
+		})
+
+		It("should report when the guest has no filesystems", func() {
+			vmiInterface.EXPECT().FilesystemList(gomock.Any(), vmName).Return(v1.VirtualMachineInstanceFileSystemList{}, nil)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("guest", "fs", vmName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal("No resources found.\n"))
+		})
+
+		It("should fail when the guest agent is not connected", func() {
+			vmiInterface.EXPECT().FilesystemList(gomock.Any(), vmName).Return(v1.VirtualMachineInstanceFileSystemList{}, fmt.Errorf("VMI does not have guest agent connected"))
+
+			_, err := testing.NewRepeatableVirtctlCommandWithOut("guest", "fs", vmName)()
+			Expect(err).To(MatchError("error listing filesystems of VirtualMachineInstance testvm: VMI does not have guest agent connected"))
+		})
+	})
+
+	Context("users", func() {
+		It("should print the logged in users", func() {
+			loginTime := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+			vmiInterface.EXPECT().UserList(gomock.Any(), vmName).Return(v1.VirtualMachineInstanceGuestOSUserList{
+				Items: []v1.VirtualMachineInstanceGuestOSUser{
+					{UserName: "alice", LoginTime: float64(loginTime.Unix())},
+					{UserName: "bob", Domain: "CORP"},
+				},
+			}, nil)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("guest", "users", vmName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(MatchRegexp(`(?m)^USER\s+DOMAIN\s+LOGIN TIME\nalice\s+<none>\s+2024-05-01T10:30:00Z\nbob\s+CORP\s+<none>\n$`))
+		})
+
+		It("should fail when the users cannot be listed", func() {
+			vmiInterface.EXPECT().UserList(gomock.Any(), vmName).Return(v1.VirtualMachineInstanceGuestOSUserList{}, fmt.Errorf("not found"))
+
+			_, err := testing.NewRepeatableVirtctlCommandWithOut("guest", "users", vmName)()
+			Expect(err).To(MatchError("error listing users of VirtualMachineInstance testvm: not found"))
+		})
+	})
+
+	Context("os", func() {
+		info := v1.VirtualMachineInstanceGuestAgentInfo{
+			GAVersion: "8.2.0",
+			Hostname:  "testvm.example.com",
+			Timezone:  "UTC, 0",
+			OS: v1.VirtualMachineInstanceGuestOSInfo{
+				Name:          "Fedora Linux",
+				PrettyName:    "Fedora Linux 40 (Cloud Edition)",
+				KernelRelease: "6.8.5-301.fc40.x86_64",
+				Machine:       "x86_64",
+			},
+		}
+
+		It("should print the operating system", func() {
+			vmiInterface.EXPECT().GuestOsInfo(gomock.Any(), vmName).Return(info, nil)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("guest", "os", vmName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(MatchRegexp(`(?m)^Hostname:\s+testvm.example.com$`))
+			Expect(string(out)).To(MatchRegexp(`(?m)^OS:\s+Fedora Linux 40 \(Cloud Edition\)$`))
+			Expect(string(out)).To(MatchRegexp(`(?m)^Kernel Release:\s+6.8.5-301.fc40.x86_64$`))
+			Expect(string(out)).To(MatchRegexp(`(?m)^Version:\s+<none>$`))
+			Expect(string(out)).To(MatchRegexp(`(?m)^Guest Agent:\s+8.2.0$`))
+		})
+
+		It("should print the operating system as JSON", func() {
+			vmiInterface.EXPECT().GuestOsInfo(gomock.Any(), vmName).Return(info, nil)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("guest", "os", vmName, "--output", "json")()
+			Expect(err).ToNot(HaveOccurred())
+			var printed v1.VirtualMachineInstanceGuestAgentInfo
+			Expect(json.Unmarshal(out, &printed)).To(Succeed())
+			Expect(printed).To(Equal(info))
+		})
+	})
+})
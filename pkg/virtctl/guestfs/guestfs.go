@@ -25,6 +25,8 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"syscall"
 	"time"
@@ -33,6 +35,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -40,9 +43,11 @@ import (
 
 	"kubevirt.io/client-go/kubecli"
 
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
 	"kubevirt.io/kubevirt/pkg/virtctl/console"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -55,6 +60,8 @@ const (
 	contName          = "libguestfs"
 	diskDir           = "/disk"
 	diskPath          = "/dev/vda"
+	additionalDiskDir = "/disks"
+	devicePathPrefix  = "/dev/vd"
 	podNamePrefix     = "libguestfs-tools"
 	applianceDir      = "/usr/local/lib/guestfs"
 	guestfsVolume     = "guestfs"
@@ -64,10 +71,13 @@ const (
 	tmpDirPath        = "/tmp/guestfs"
 	pullPolicyDefault = corev1.PullIfNotPresent
 	timeout           = 500 * time.Second
+	shellPath         = "/bin/bash"
 )
 
 type guestfsCommand struct {
 	pvc        string
+	pvcs       []string
+	script     string
 	image      string
 	kvm        bool
 	root       bool
@@ -76,6 +86,14 @@ type guestfsCommand struct {
 	gid        string
 	pullPolicy string
 	vm         string
+
+	additionalVolumes []pvcVolume
+}
+
+// pvcVolume is a PVC made available in the libguestfs-tools pod
+type pvcVolume struct {
+	name    string
+	isBlock bool
 }
 
 // Following variables allow overriding the default functions (useful for unit testing)
@@ -84,18 +102,23 @@ var CreateAttacherFunc = CreateAttacher
 var ImageSetFunc = SetImage
 var ImageInfoGetFunc = GetImageInfo
 
+// ScriptPollInterval is the interval in which the libguestfs-tools pod is checked while running a script
+var ScriptPollInterval = 5 * time.Second
+
 // NewGuestfsShellCommand returns a cobra.Command for starting libguestfs-tool pod and attach it to a pvc
 func NewGuestfsShellCommand() *cobra.Command {
 	c := guestfsCommand{}
 	cmd := &cobra.Command{
-		Use:     "guestfs",
-		Short:   "Start a shell into the libguestfs pod",
-		Long:    `Create a pod with libguestfs-tools, mount the pvc and attach a shell to it. The pvc is mounted under the /disks directory inside the pod for filesystem-based pvcs, or as /dev/vda for block-based pvcs`,
+		Use:   "guestfs",
+		Short: "Start a shell into the libguestfs pod",
+		Long: `Create a pod with libguestfs-tools, mount the pvc and attach a shell to it. The pvc is mounted under the /disk directory inside the pod for filesystem-based pvcs, or as /dev/vda for block-based pvcs.
+Additional pvcs are mounted under /disks/<pvc-name> for filesystem-based pvcs, or as /dev/vdb, /dev/vdc, ... in the given order for block-based pvcs.
+Instead of attaching a shell, a local script can be run in the pod. Its output is printed and virtctl exits with the exit code of the script.`,
 		Args:    cobra.ExactArgs(1),
 		Example: usage(),
 		RunE:    c.run,
 	}
-	cmd.PersistentFlags().StringVar(&c.image, "image", "", "libguestfs-tools container image")
+	cmd.PersistentFlags().StringVar(&c.image, "image", "", "libguestfs-tools container image, overrides the image configured in KubeVirt")
 	cmd.PersistentFlags().StringVar(&c.pullPolicy, "pull-policy", string(pullPolicyDefault), "pull policy for the libguestfs image")
 	cmd.PersistentFlags().BoolVar(&c.kvm, "kvm", true, "Use kvm for the libguestfs-tools container")
 	cmd.PersistentFlags().BoolVar(&c.root, "root", false, "Set uid 0 for the libguestfs-tool container")
@@ -104,13 +127,21 @@ func NewGuestfsShellCommand() *cobra.Command {
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	cmd.PersistentFlags().StringVar(&c.fsGroup, "fsGroup", "", "Set the fsgroup for the libguestfs-tool container")
 	cmd.PersistentFlags().StringVar(&c.vm, "vm", "", "Provide a VM to apply its scheduling constraints to the libguestfs-tool pod")
+	cmd.PersistentFlags().StringArrayVar(&c.pvcs, "pvc", nil, "Additional pvc to make available in the libguestfs-tool pod, can be specified multiple times")
+	cmd.PersistentFlags().StringVar(&c.script, "run", "", "Run the given local script in the libguestfs-tool pod instead of attaching a shell, and exit with its exit code")
 
 	return cmd
 }
 
 func usage() string {
 	usage := `  # Create a pod with libguestfs-tools, mount the pvc and attach a shell to it:
-  {{ProgramName}} guestfs <pvc-name>`
+  {{ProgramName}} guestfs <pvc-name>
+
+  # Use a custom libguestfs-tools image and make an additional pvc available under /disks/<other-pvc-name>:
+  {{ProgramName}} guestfs <pvc-name> --image=<image> --pvc=<other-pvc-name>
+
+  # Run a script against the pvc without attaching a shell:
+  {{ProgramName}} guestfs <pvc-name> --run=script.sh`
 	return usage
 }
 
@@ -127,7 +158,17 @@ func (c *guestfsCommand) run(cmd *cobra.Command, args []string) error {
 		c.pullPolicy != string(corev1.PullIfNotPresent) {
 		return fmt.Errorf("Invalid pull policy: %s", c.pullPolicy)
 	}
-	var inUse bool
+	if err := c.validateAdditionalPVCs(); err != nil {
+		return err
+	}
+	var script string
+	if c.script != "" {
+		content, err := os.ReadFile(c.script)
+		if err != nil {
+			return fmt.Errorf("failed to read script %s: %w", c.script, err)
+		}
+		script = string(content)
+	}
 	client, err := CreateClientFunc(virtClient)
 	if err != nil {
 		return err
@@ -139,23 +180,57 @@ func (c *guestfsCommand) run(cmd *cobra.Command, args []string) error {
 		}
 	}
 	fmt.Printf("Use image: %s \n", c.image)
-	exist, _ := client.existsPVC(c.pvc, namespace)
-	if !exist {
-		return fmt.Errorf("The PVC %s doesn't exist", c.pvc)
-	}
-	inUse, err = client.isPVCinUse(c.pvc, namespace)
+	isBlock, err := client.checkPVC(c.pvc, namespace)
 	if err != nil {
 		return err
 	}
-	if inUse {
-		return fmt.Errorf("PVC %s is used by another pod", c.pvc)
+	for _, pvc := range c.pvcs {
+		isAdditionalBlock, err := client.checkPVC(pvc, namespace)
+		if err != nil {
+			return err
+		}
+		c.additionalVolumes = append(c.additionalVolumes, pvcVolume{name: pvc, isBlock: isAdditionalBlock})
 	}
-	isBlock, err := client.isPVCVolumeBlock(c.pvc, namespace)
+	defer func() {
+		podName := genPodName(c.pvc)
+		if err := client.removePod(namespace, podName); err == nil {
+			result.RecordChange(cmd.Context(), result.Change{Action: "Delete", Kind: "Pod", Namespace: namespace, Name: podName})
+		}
+	}()
+	if c.script != "" {
+		return c.runScriptInPodWithPVC(cmd, client, namespace, script, isBlock)
+	}
+	return c.createInteractivePodWithPVC(cmd.Context(), client, namespace, "/entrypoint.sh", []string{}, isBlock)
+}
+
+func (c *guestfsCommand) validateAdditionalPVCs() error {
+	seen := map[string]bool{c.pvc: true}
+	for _, pvc := range c.pvcs {
+		if pvc == "" {
+			return result.NewUsageError(fmt.Errorf("--pvc must not be empty"))
+		}
+		if seen[pvc] {
+			return result.NewUsageError(fmt.Errorf("PVC %s is specified more than once", pvc))
+		}
+		seen[pvc] = true
+	}
+	return nil
+}
+
+// checkPVC verifies that the PVC exists and is not used by another pod, and returns whether it is a block volume
+func (client *K8sClient) checkPVC(pvc, ns string) (bool, error) {
+	exist, _ := client.existsPVC(pvc, ns)
+	if !exist {
+		return false, fmt.Errorf("The PVC %s doesn't exist", pvc)
+	}
+	inUse, err := client.isPVCinUse(pvc, ns)
 	if err != nil {
-		return err
+		return false, err
+	}
+	if inUse {
+		return false, fmt.Errorf("PVC %s is used by another pod", pvc)
 	}
-	defer client.removePod(namespace, genPodName(c.pvc))
-	return c.createInteractivePodWithPVC(client, namespace, "/entrypoint.sh", []string{}, isBlock)
+	return client.isPVCVolumeBlock(pvc, ns)
 }
 
 // K8sClient holds the information of the Kubernetes client
@@ -373,7 +448,7 @@ func (c *guestfsCommand) setGIDLibguestfs() (*int64, error) {
 	return nil, nil
 }
 
-func (c *guestfsCommand) createLibguestfsPod(client *K8sClient, ns, cmd string, args []string, isBlock bool) (*corev1.Pod, error) {
+func (c *guestfsCommand) createLibguestfsPod(ctx context.Context, client *K8sClient, ns, cmd string, args []string, isBlock bool) (*corev1.Pod, error) {
 	var (
 		resources    corev1.ResourceRequirements
 		tolerations  []corev1.Toleration
@@ -499,8 +574,8 @@ func (c *guestfsCommand) createLibguestfsPod(client *K8sClient, ns, cmd string,
 						},
 					},
 					ImagePullPolicy: corev1.PullPolicy(c.pullPolicy),
-					Stdin:           true,
-					TTY:             true,
+					Stdin:           c.script == "",
+					TTY:             c.script == "",
 					Resources:       resources,
 				},
 			},
@@ -527,15 +602,44 @@ func (c *guestfsCommand) createLibguestfsPod(client *K8sClient, ns, cmd string,
 		pod.Spec.Containers[0].WorkingDir = diskDir
 		fmt.Printf("The PVC has been mounted at %s \n", diskDir)
 	}
+	for i, additional := range c.additionalVolumes {
+		addAdditionalVolume(pod, fmt.Sprintf("%s-%d", volume, i+1), additional, devicePathPrefix+string(rune('b'+i)))
+	}
 
-	p, err := client.Client.CoreV1().Pods(ns).Create(context.TODO(), pod, metav1.CreateOptions{})
+	p, err := client.Client.CoreV1().Pods(ns).Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
+	result.RecordChange(ctx, result.Change{Action: "Create", Kind: "Pod", Namespace: ns, Name: p.Name})
 
 	return p, nil
 }
 
+func addAdditionalVolume(pod *corev1.Pod, volumeName string, pvc pvcVolume, devicePath string) {
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: pvc.name,
+			},
+		},
+	})
+	if pvc.isBlock {
+		pod.Spec.Containers[0].VolumeDevices = append(pod.Spec.Containers[0].VolumeDevices, corev1.VolumeDevice{
+			Name:       volumeName,
+			DevicePath: devicePath,
+		})
+		fmt.Printf("The PVC %s has been mounted at %s \n", pvc.name, devicePath)
+		return
+	}
+	mountPath := filepath.Join(additionalDiskDir, pvc.name)
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: mountPath,
+	})
+	fmt.Printf("The PVC %s has been mounted at %s \n", pvc.name, mountPath)
+}
+
 // CreateAttacher attaches the stdin, stdout, and stderr to the container shell
 func CreateAttacher(client *K8sClient, p *corev1.Pod, command string) error {
 	req := client.Client.CoreV1().RESTClient().Post().
@@ -572,8 +676,8 @@ func CreateAttacher(client *K8sClient, p *corev1.Pod, command string) error {
 		"If you don't see a command prompt, try pressing enter.", resChan)
 }
 
-func (c *guestfsCommand) createInteractivePodWithPVC(client *K8sClient, ns, command string, args []string, isblock bool) error {
-	pod, err := c.createLibguestfsPod(client, ns, command, args, isblock)
+func (c *guestfsCommand) createInteractivePodWithPVC(ctx context.Context, client *K8sClient, ns, command string, args []string, isblock bool) error {
+	pod, err := c.createLibguestfsPod(ctx, client, ns, command, args, isblock)
 	if err != nil {
 		return err
 	}
@@ -584,6 +688,64 @@ func (c *guestfsCommand) createInteractivePodWithPVC(client *K8sClient, ns, comm
 	return CreateAttacherFunc(client, pod, command)
 }
 
+// runScriptInPodWithPVC runs the script in the libguestfs-tools pod, prints its output and returns an
+// error carrying the exit code of the script if it failed
+func (c *guestfsCommand) runScriptInPodWithPVC(cmd *cobra.Command, client *K8sClient, ns, script string, isBlock bool) error {
+	pod, err := c.createLibguestfsPod(cmd.Context(), client, ns, shellPath, []string{"-c", script}, isBlock)
+	if err != nil {
+		return err
+	}
+	if err := client.waitForPodPhase(cmd.Context(), pod.Name, ns, corev1.PodRunning, corev1.PodSucceeded, corev1.PodFailed); err != nil {
+		return err
+	}
+	logs, err := client.Client.CoreV1().Pods(ns).GetLogs(pod.Name, &corev1.PodLogOptions{Container: contName, Follow: true}).Stream(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get the output of script %s: %w", c.script, err)
+	}
+	defer logs.Close()
+	if _, err := io.Copy(cmd.OutOrStdout(), logs); err != nil {
+		return fmt.Errorf("failed to get the output of script %s: %w", c.script, err)
+	}
+	if err := client.waitForPodPhase(cmd.Context(), pod.Name, ns, corev1.PodSucceeded, corev1.PodFailed); err != nil {
+		return err
+	}
+	exitCode, err := client.getExitCode(cmd.Context(), pod.Name, ns)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return result.NewExitCodeError(int(exitCode), fmt.Errorf("script %s exited with code %d", c.script, exitCode))
+	}
+	return nil
+}
+
+func (client *K8sClient) waitForPodPhase(ctx context.Context, podName, ns string, phases ...corev1.PodPhase) error {
+	err := virtwait.PollImmediately(ScriptPollInterval, timeout, func(ctx context.Context) (bool, error) {
+		pod, err := client.Client.CoreV1().Pods(ns).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return slices.Contains(phases, pod.Status.Phase), nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timeout in waiting for pod %s to reach one of the phases %v", podName, phases)
+	}
+	return err
+}
+
+func (client *K8sClient) getExitCode(ctx context.Context, podName, ns string) (int32, error) {
+	pod, err := client.Client.CoreV1().Pods(ns).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == contName && status.State.Terminated != nil {
+			return status.State.Terminated.ExitCode, nil
+		}
+	}
+	return 0, fmt.Errorf("container %s in pod %s did not terminate", contName, podName)
+}
+
 func (client *K8sClient) removePod(ns, podName string) error {
 	return client.Client.CoreV1().Pods(ns).Delete(context.TODO(), podName, metav1.DeleteOptions{})
 }
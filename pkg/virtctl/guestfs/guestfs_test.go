@@ -22,6 +22,9 @@ package guestfs_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -37,6 +40,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/libvmi"
 	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/testing"
 )
 
@@ -179,6 +183,53 @@ var _ = Describe("Guestfs shell", func() {
 			Expect(err).To(MatchError("gid requires the uid to be set"))
 		})
 
+		It("Successfully use a custom image", func() {
+			guestfs.CreateClientFunc = fakeCreateClientPVCWithMockVirtClient
+			Expect(testing.NewRepeatableVirtctlCommand(commandName, pvcName, "--image", "quay.io/custom/libguestfs-tools:test")()).To(Succeed())
+			Expect(libguestfsPod.Spec.Containers[0].Image).To(Equal("quay.io/custom/libguestfs-tools:test"))
+		})
+
+		It("Successfully mount additional PVCs", func() {
+			blockMode := v1.PersistentVolumeBlock
+			fsPVC := pvc.DeepCopy()
+			fsPVC.Name = "other-pvc"
+			blockPVC := pvc.DeepCopy()
+			blockPVC.Name = "block-pvc"
+			blockPVC.Spec.VolumeMode = &blockMode
+			guestfs.CreateClientFunc = func(virtClient kubecli.KubevirtClient) (*guestfs.K8sClient, error) {
+				client, err := fakeCreateClientPVCWithMockVirtClient(virtClient)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(kubeClient.Tracker().Add(fsPVC)).To(Succeed())
+				Expect(kubeClient.Tracker().Add(blockPVC)).To(Succeed())
+				return client, nil
+			}
+
+			Expect(testing.NewRepeatableVirtctlCommand(commandName, pvcName, "--pvc", fsPVC.Name, "--pvc", blockPVC.Name)()).To(Succeed())
+			Expect(libguestfsPod.Spec.Volumes).To(ContainElements(
+				HaveField("VolumeSource.PersistentVolumeClaim.ClaimName", pvcName),
+				HaveField("VolumeSource.PersistentVolumeClaim.ClaimName", fsPVC.Name),
+				HaveField("VolumeSource.PersistentVolumeClaim.ClaimName", blockPVC.Name),
+			))
+			Expect(libguestfsPod.Spec.Containers[0].VolumeMounts).To(ContainElements(
+				v1.VolumeMount{Name: "volume", MountPath: "/disk"},
+				v1.VolumeMount{Name: "volume-1", MountPath: "/disks/other-pvc"},
+			))
+			Expect(libguestfsPod.Spec.Containers[0].VolumeDevices).To(ConsistOf(
+				v1.VolumeDevice{Name: "volume-2", DevicePath: "/dev/vdc"},
+			))
+		})
+
+		It("Additional PVC doesn't exist", func() {
+			guestfs.CreateClientFunc = fakeCreateClientPVC
+			cmd := testing.NewRepeatableVirtctlCommand(commandName, pvcName, "--pvc", "other-pvc")
+			Expect(cmd()).To(MatchError("The PVC other-pvc doesn't exist"))
+		})
+
+		It("Additional PVC specified more than once", func() {
+			cmd := testing.NewRepeatableVirtctlCommand(commandName, pvcName, "--pvc", pvcName)
+			Expect(cmd()).To(MatchError(fmt.Sprintf("PVC %s is specified more than once", pvcName)))
+		})
+
 		It("Successfully apply VM's constraints", func() {
 			vmi := libvmi.New(
 				libvmi.WithNamespace(testNamespace),
@@ -206,6 +257,71 @@ var _ = Describe("Guestfs shell", func() {
 		})
 	})
 
+	Context("run a script", func() {
+		var script string
+
+		fakeCreateClientScript := func(exitCode int32) func(kubecli.KubevirtClient) (*guestfs.K8sClient, error) {
+			return func(_ kubecli.KubevirtClient) (*guestfs.K8sClient, error) {
+				kubeClient = fake.NewSimpleClientset(pvc)
+				kubeClient.Fake.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					libguestfsPod = action.(k8stesting.CreateAction).GetObject().(*v1.Pod)
+					return false, libguestfsPod, nil
+				})
+				kubeClient.Fake.PrependReactor("get", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					pod := libguestfsPod.DeepCopy()
+					pod.Status.Phase = v1.PodSucceeded
+					if exitCode != 0 {
+						pod.Status.Phase = v1.PodFailed
+					}
+					pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+						Name: "libguestfs",
+						State: v1.ContainerState{
+							Terminated: &v1.ContainerStateTerminated{ExitCode: exitCode},
+						},
+					}}
+					return true, pod, nil
+				})
+				return &guestfs.K8sClient{Client: kubeClient, VirtClient: kubevirtClient}, nil
+			}
+		}
+
+		BeforeEach(func() {
+			guestfs.ImageSetFunc = fakeSetImage
+			guestfs.ScriptPollInterval = 10 * time.Millisecond
+			script = filepath.Join(GinkgoT().TempDir(), "script.sh")
+			Expect(os.WriteFile(script, []byte("virt-customize -a /disk/disk.img --hostname test\n"), 0600)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			guestfs.ImageSetFunc = guestfs.SetImage
+			guestfs.CreateClientFunc = guestfs.CreateClient
+			guestfs.ScriptPollInterval = 5 * time.Second
+		})
+
+		It("Successfully run the script and print its output", func() {
+			guestfs.CreateClientFunc = fakeCreateClientScript(0)
+			out, err := testing.NewRepeatableVirtctlCommandWithOut(commandName, pvcName, "--run", script)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal("fake logs"))
+			Expect(libguestfsPod.Spec.Containers[0].Command).To(Equal([]string{"/bin/bash"}))
+			Expect(libguestfsPod.Spec.Containers[0].Args).To(Equal([]string{"-c", "virt-customize -a /disk/disk.img --hostname test\n"}))
+			Expect(libguestfsPod.Spec.Containers[0].Stdin).To(BeFalse())
+			Expect(libguestfsPod.Spec.Containers[0].TTY).To(BeFalse())
+		})
+
+		It("Propagate the exit code of the script", func() {
+			guestfs.CreateClientFunc = fakeCreateClientScript(3)
+			err := testing.NewRepeatableVirtctlCommand(commandName, pvcName, "--run", script)()
+			Expect(err).To(MatchError(fmt.Sprintf("script %s exited with code 3", script)))
+			Expect(result.ExitCode(err)).To(Equal(3))
+		})
+
+		It("Fail if the script doesn't exist", func() {
+			err := testing.NewRepeatableVirtctlCommand(commandName, pvcName, "--run", "/does/not/exist.sh")()
+			Expect(err).To(MatchError(ContainSubstring("failed to read script /does/not/exist.sh")))
+		})
+	})
+
 	Context("URL authenticity", func() {
 		fakeGetImageInfoNoCustomURL := func(virtClient kubecli.KubevirtClient) (*kubecli.GuestfsInfo, error) {
 			info := &kubecli.GuestfsInfo{
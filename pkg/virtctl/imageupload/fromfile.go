@@ -0,0 +1,342 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package imageupload
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virtctl/devprofile"
+)
+
+const (
+	fromFileFlag    = "from-file"
+	concurrencyFlag = "concurrency"
+
+	defaultConcurrency = 2
+)
+
+// imageSpec describes a single upload listed in the file passed with --from-file
+type imageSpec struct {
+	// Kind of the upload target, either dv or pvc. Defaults to dv.
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name"`
+	// Exactly one of ImagePath, ArchivePath and URL has to be set. Relative
+	// paths are resolved against the directory of the file.
+	ImagePath               string `json:"imagePath,omitempty"`
+	ArchivePath             string `json:"archivePath,omitempty"`
+	URL                     string `json:"url,omitempty"`
+	Size                    string `json:"size,omitempty"`
+	StorageClass            string `json:"storageClass,omitempty"`
+	AccessMode              string `json:"accessMode,omitempty"`
+	VolumeMode              string `json:"volumeMode,omitempty"`
+	NoCreate                bool   `json:"noCreate,omitempty"`
+	ForceBind               bool   `json:"forceBind,omitempty"`
+	DataSource              bool   `json:"dataSource,omitempty"`
+	DefaultInstancetype     string `json:"defaultInstancetype,omitempty"`
+	DefaultInstancetypeKind string `json:"defaultInstancetypeKind,omitempty"`
+	DefaultPreference       string `json:"defaultPreference,omitempty"`
+	DefaultPreferenceKind   string `json:"defaultPreferenceKind,omitempty"`
+}
+
+// perImageFlags can not be combined with --from-file, the file specifies them for every image
+var perImageFlags = []string{
+	"pvc-name", "pvc-size", "size", "storage-class", "access-mode", "block-volume", "volume-mode",
+	"image-path", "archive-path", "no-create", "force-bind", "datasource",
+	"default-instancetype", "default-instancetype-kind", "default-preference", "default-preference-kind",
+}
+
+func (c *command) addFromFileFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.fromFile, fromFileFlag, "",
+		"Path to a YAML file with a list of images to upload. Each entry accepts kind, name, imagePath, archivePath, url, size, storageClass, accessMode, volumeMode, "+
+			"noCreate, forceBind, dataSource, defaultInstancetype, defaultInstancetypeKind, defaultPreference and defaultPreferenceKind.")
+	cmd.Flags().IntVar(&c.concurrency, concurrencyFlag, defaultConcurrency, "The maximum number of images uploaded in parallel when using --from-file.")
+	for _, flag := range perImageFlags {
+		cmd.MarkFlagsMutuallyExclusive(fromFileFlag, flag)
+	}
+}
+
+func readImageSpecs(fromFile string) ([]imageSpec, error) {
+	// #nosec G304 No risk for path injection as this function executes with
+	// the same privileges as those of virtctl user who supplies the file
+	data, err := os.ReadFile(fromFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var specs []imageSpec
+	if err := yaml.UnmarshalStrict(data, &specs); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", fromFile, err)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no images found in %s", fromFile)
+	}
+
+	dir := filepath.Dir(fromFile)
+	targets := map[string]struct{}{}
+	for i := range specs {
+		spec := &specs[i]
+		if spec.Name == "" {
+			return nil, fmt.Errorf("image %d in %s: name is required", i, fromFile)
+		}
+		if spec.Kind == "" {
+			spec.Kind = "dv"
+		}
+		spec.Kind = strings.ToLower(spec.Kind)
+		if spec.Kind != "dv" && spec.Kind != "pvc" {
+			return nil, fmt.Errorf("image %s in %s: invalid kind %s, must be dv or pvc", spec.Name, fromFile, spec.Kind)
+		}
+		sources := 0
+		for _, source := range []string{spec.ImagePath, spec.ArchivePath, spec.URL} {
+			if source != "" {
+				sources++
+			}
+		}
+		if sources != 1 {
+			return nil, fmt.Errorf("image %s in %s: exactly one of imagePath, archivePath and url must be provided", spec.Name, fromFile)
+		}
+		if _, exists := targets[spec.Name]; exists {
+			return nil, fmt.Errorf("image %s in %s: name is used more than once", spec.Name, fromFile)
+		}
+		targets[spec.Name] = struct{}{}
+
+		if spec.ImagePath != "" && !filepath.IsAbs(spec.ImagePath) {
+			spec.ImagePath = filepath.Join(dir, spec.ImagePath)
+		}
+		if spec.ArchivePath != "" && !filepath.IsAbs(spec.ArchivePath) {
+			spec.ArchivePath = filepath.Join(dir, spec.ArchivePath)
+		}
+	}
+
+	return specs, nil
+}
+
+// runFromFile uploads all images listed in the file with at most --concurrency
+// uploads in flight and prints a table with the result for each image. It
+// fails if any of the uploads failed.
+func (c *command) runFromFile(args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("cannot use --%s and args", fromFileFlag)
+	}
+	if c.concurrency <= 0 {
+		return fmt.Errorf("--%s must be greater than zero", concurrencyFlag)
+	}
+
+	specs, err := readImageSpecs(c.fromFile)
+	if err != nil {
+		return err
+	}
+
+	// Forward the upload proxy only once for all uploads
+	if c.dev {
+		var stop func()
+		c.uploadProxyURL, stop, err = devprofile.ForwardUploadProxyFn(c.client)
+		if err != nil {
+			return err
+		}
+		defer stop()
+		// The certificate of the upload proxy is not valid for the forwarded local address
+		c.insecure = true
+	}
+
+	c.cmd.Printf("Uploading %d images from %s\n", len(specs), c.fromFile)
+
+	out := &syncWriter{w: c.cmd.OutOrStdout()}
+	errs := make([]error, len(specs))
+	semaphore := make(chan struct{}, c.concurrency)
+	var wg sync.WaitGroup
+	for i := range specs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			errs[i] = c.uploadImageSpec(&specs[i], out)
+		}(i)
+	}
+	wg.Wait()
+
+	return reportUploads(c.cmd, c.namespace, specs, errs)
+}
+
+// uploadImageSpec uploads a single image. Its progress is written line by
+// line to out, prefixed with the name of the image.
+func (c *command) uploadImageSpec(spec *imageSpec, out *syncWriter) error {
+	lines := &prefixWriter{prefix: fmt.Sprintf("[%s] ", spec.Name), out: out}
+	defer lines.flush()
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(c.cmd.Context())
+	cmd.SetOut(lines)
+	cmd.SetErr(lines)
+
+	upload := command{
+		cmd:                     cmd,
+		client:                  c.client,
+		namespace:               c.namespace,
+		insecure:                c.insecure,
+		transportOptions:        c.transportOptions,
+		uploadProxyURL:          c.uploadProxyURL,
+		uploadPodWaitSecs:       c.uploadPodWaitSecs,
+		uploadRetries:           c.uploadRetries,
+		size:                    spec.Size,
+		storageClass:            spec.StorageClass,
+		accessMode:              spec.AccessMode,
+		volumeMode:              spec.VolumeMode,
+		imagePath:               spec.ImagePath,
+		archivePath:             spec.ArchivePath,
+		noCreate:                spec.NoCreate,
+		forceBind:               spec.ForceBind,
+		dataSource:              spec.DataSource,
+		defaultInstancetype:     spec.DefaultInstancetype,
+		defaultInstancetypeKind: spec.DefaultInstancetypeKind,
+		defaultPreference:       spec.DefaultPreference,
+		defaultPreferenceKind:   spec.DefaultPreferenceKind,
+		// Progress bars of parallel uploads can not share a terminal
+		noProgressBar: true,
+	}
+
+	if spec.URL != "" {
+		imagePath, err := downloadImage(cmd, spec.URL)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(imagePath)
+		upload.imagePath = imagePath
+	}
+
+	return upload.run([]string{spec.Kind, spec.Name})
+}
+
+// downloadImage downloads the image to a temporary file and returns its path
+func downloadImage(cmd *cobra.Command, imageURL string) (string, error) {
+	cmd.Printf("Downloading %s\n", imageURL)
+
+	req, err := http.NewRequestWithContext(cmd.Context(), http.MethodGet, imageURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer util.CloseIOAndCheckErr(resp.Body, nil)
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error downloading %s: unexpected return value %d", imageURL, resp.StatusCode)
+	}
+
+	file, err := os.CreateTemp("", "virtctl-image-upload-")
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", fmt.Errorf("error downloading %s: %w", imageURL, err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+
+	return file.Name(), nil
+}
+
+// reportUploads prints a table with the result for each image and fails if
+// any of the uploads failed.
+func reportUploads(cmd *cobra.Command, namespace string, specs []imageSpec, errs []error) error {
+	failed := 0
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tKIND\tNAME\tRESULT")
+	for i, spec := range specs {
+		res := "OK"
+		if errs[i] != nil {
+			res = errs[i].Error()
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", namespace, spec.Kind, spec.Name, res)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to upload %d of %d images", failed, len(specs))
+	}
+	return nil
+}
+
+// syncWriter serializes the writes of parallel uploads
+type syncWriter struct {
+	lock sync.Mutex
+	w    io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.w.Write(p)
+}
+
+// prefixWriter writes complete lines prefixed with the name of an image, so
+// the output of parallel uploads does not get mixed up within a line
+type prefixWriter struct {
+	prefix string
+	out    io.Writer
+	buf    bytes.Buffer
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf.Write(b)
+	for {
+		line, err := p.buf.ReadBytes('\n')
+		if err != nil {
+			// Keep the incomplete line until it is terminated
+			p.buf.Write(line)
+			return len(b), nil
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if _, err := p.out.Write(append([]byte(p.prefix), line...)); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (p *prefixWriter) flush() {
+	if p.buf.Len() == 0 {
+		return
+	}
+	_, _ = p.Write([]byte("\n"))
+}
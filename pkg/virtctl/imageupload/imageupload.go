@@ -21,7 +21,6 @@ package imageupload
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"io"
 	"math/rand/v2"
@@ -53,6 +52,8 @@ import (
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/devprofile"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -118,14 +119,28 @@ func NewImageUploadCommand() *cobra.Command {
 			c.cmd = cmd
 			c.client = client
 			c.namespace = namespace
+			if c.fromFile != "" {
+				return c.runFromFile(args)
+			}
 			return c.run(args)
 		},
 	}
 	cmd.Flags().BoolVar(&c.insecure, "insecure", false, "Allow insecure server connections when using HTTPS.")
 	cmd.Flags().StringVar(&c.uploadProxyURL, "uploadproxy-url", "", "The URL of the cdi-upload proxy service.")
+	cmd.Flags().StringVar(&c.transportOptions.ProxyURL, "proxy-url", "",
+		"The URL of an HTTP(S) proxy to reach the cdi-upload proxy through. Defaults to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.")
+	cmd.Flags().StringVar(&c.transportOptions.ProxyUsername, "proxy-username", "",
+		fmt.Sprintf("The username for Basic authentication to the proxy. The password is read from the %s environment variable.", ProxyPasswordEnv))
+	cmd.Flags().StringVar(&c.transportOptions.ClientCert, "client-cert", "",
+		fmt.Sprintf("Path to a PEM encoded client certificate presented to the cdi-upload proxy. Defaults to the %s environment variable.", ClientCertEnv))
+	cmd.Flags().StringVar(&c.transportOptions.ClientKey, "client-key", "",
+		fmt.Sprintf("Path to the PEM encoded key of the client certificate. Defaults to the %s environment variable.", ClientKeyEnv))
+	cmd.MarkFlagsRequiredTogether("client-cert", "client-key")
+	cmd.Flags().BoolVar(&c.dev, devprofile.Flag, false, devprofile.Usage)
+	cmd.MarkFlagsMutuallyExclusive("uploadproxy-url", devprofile.Flag)
 	cmd.Flags().StringVar(&c.name, "pvc-name", "", "The destination DataVolume/PVC name.")
 	cmd.Flags().StringVar(&c.pvcSize, "pvc-size", "", "The size of the PVC to create (ex. 10Gi, 500Mi).")
-	cmd.Flags().StringVar(&c.size, "size", "", "The size of the DataVolume to create (ex. 10Gi, 500Mi).")
+	cmd.Flags().StringVar(&c.size, "size", "", "The size of the DataVolume to create (ex. 10Gi, 500Mi). When omitted, the size is inferred from the virtual size of the image.")
 	cmd.Flags().StringVar(&c.storageClass, "storage-class", "", "The storage class for the PVC.")
 	cmd.Flags().StringVar(&c.accessMode, "access-mode", "", "The access mode for the PVC.")
 	cmd.Flags().BoolVar(&c.blockVolume, "block-volume", false, "Create a PVC with VolumeMode=Block (default is the storageProfile default. for archive upload default is filesystem).")
@@ -141,6 +156,7 @@ func NewImageUploadCommand() *cobra.Command {
 	cmd.Flags().StringVar(&c.defaultInstancetypeKind, "default-instancetype-kind", "", "The default instance type kind to associate with the image.")
 	cmd.Flags().StringVar(&c.defaultPreference, "default-preference", "", "The default preference to associate with the image.")
 	cmd.Flags().StringVar(&c.defaultPreferenceKind, "default-preference-kind", "", "The default preference kind to associate with the image.")
+	c.addFromFileFlags(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	cmd.Flags().MarkDeprecated("pvc-name", "specify the name as the second argument instead.")
 	cmd.Flags().MarkDeprecated("pvc-size", "use --size instead.")
@@ -148,10 +164,42 @@ func NewImageUploadCommand() *cobra.Command {
 	return cmd
 }
 
+// UploadOptions describes an upload of a local image to a new DataVolume
+// which is triggered on behalf of another command.
+type UploadOptions struct {
+	Name           string
+	Size           string
+	StorageClass   string
+	ImagePath      string
+	UploadProxyURL string
+	Insecure       bool
+}
+
+// UploadToDataVolume uploads a local image to a newly created DataVolume in the given namespace
+// and waits for the post upload processing to complete. Progress is written to the output of cmd.
+func UploadToDataVolume(cmd *cobra.Command, client kubecli.KubevirtClient, namespace string, opts UploadOptions) error {
+	c := command{
+		cmd:               cmd,
+		client:            client,
+		namespace:         namespace,
+		size:              opts.Size,
+		storageClass:      opts.StorageClass,
+		imagePath:         opts.ImagePath,
+		uploadProxyURL:    opts.UploadProxyURL,
+		insecure:          opts.Insecure,
+		uploadPodWaitSecs: 300,
+		uploadRetries:     5,
+	}
+	return c.run([]string{"dv", opts.Name})
+}
+
 func usage() string {
 	usage := `  # Upload a local disk image to a newly created DataVolume:
   {{ProgramName}} image-upload dv fedora-dv --size=10Gi --image-path=/images/fedora30.qcow2
 
+  # Upload a local disk image to a newly created DataVolume sized after the virtual size of the image:
+  {{ProgramName}} image-upload dv fedora-dv --image-path=/images/fedora30.qcow2
+
   # Upload a local disk image to an existing DataVolume
   {{ProgramName}} image-upload dv fedora-dv --no-create --image-path=/images/fedora30.qcow2
 
@@ -167,8 +215,29 @@ func usage() string {
   # Upload to a DataVolume with explicit URL to CDI Upload Proxy
   {{ProgramName}} image-upload dv fedora-dv --uploadproxy-url=https://cdi-uploadproxy.mycluster.com --image-path=/images/fedora30.qcow2
 
+  # Upload to a DataVolume in a local development cluster by port-forwarding to the CDI Upload Proxy
+  {{ProgramName}} image-upload dv fedora-dv --size=10Gi --image-path=/images/fedora30.qcow2 --dev
+
+  # Upload through an authenticating proxy presenting a client certificate to the CDI Upload Proxy:
+  VIRTCTL_UPLOAD_PROXY_PASSWORD=secret {{ProgramName}} image-upload dv fedora-dv --size=10Gi --image-path=/images/fedora30.qcow2 \
+    --proxy-url=http://proxy.example.com:3128 --proxy-username=jdoe --client-cert=/certs/tls.crt --client-key=/certs/tls.key
+
   # Upload a local disk archive to a newly created DataVolume:
-  {{ProgramName}} image-upload dv fedora-dv --size=10Gi --archive-path=/images/fedora30.tar`
+  {{ProgramName}} image-upload dv fedora-dv --size=10Gi --archive-path=/images/fedora30.tar
+
+  # Upload all images listed in a file, at most three at a time:
+  {{ProgramName}} image-upload --from-file=images.yaml --concurrency=3
+
+  # With images.yaml listing the images, their targets and sources:
+  - name: fedora-dv
+    imagePath: /images/fedora30.qcow2
+    size: 10Gi
+    dataSource: true
+    defaultPreference: fedora
+  - kind: pvc
+    name: cirros-pvc
+    url: https://download.cirros-cloud.net/0.6.2/cirros-0.6.2-x86_64-disk.img
+    storageClass: local`
 	return usage
 }
 
@@ -176,6 +245,9 @@ type command struct {
 	cmd                     *cobra.Command
 	client                  kubecli.KubevirtClient
 	insecure                bool
+	transportOptions        TransportOptions
+	transport               *http.Transport
+	dev                     bool
 	uploadProxyURL          string
 	name                    string
 	namespace               string
@@ -198,6 +270,9 @@ type command struct {
 	forceBind               bool
 	dataSource              bool
 	archiveUpload           bool
+	noProgressBar           bool
+	fromFile                string
+	concurrency             int
 }
 
 func (c *command) parseArgs(args []string) error {
@@ -285,6 +360,12 @@ func (c *command) run(args []string) error {
 		return err
 	}
 
+	transport, err := NewTransport(c.transportOptions)
+	if err != nil {
+		return err
+	}
+	c.transport = transport
+
 	// #nosec G304 No risk for path injection as this function executes with
 	// the same privileges as those of virtctl user who supplies imagePath
 	file, err := os.Open(c.imagePath)
@@ -300,7 +381,11 @@ func (c *command) run(args []string) error {
 		}
 
 		if !c.noCreate && len(c.size) == 0 {
-			return fmt.Errorf("when creating a resource, the size must be specified")
+			c.size, err = c.inferSize(file)
+			if err != nil {
+				return fmt.Errorf("when creating a resource, the size must be specified, it could not be inferred from the image: %v", err)
+			}
+			c.cmd.Printf("Using size %s inferred from the image\n", c.size)
 		}
 
 		var obj metav1.Object
@@ -317,7 +402,9 @@ func (c *command) run(args []string) error {
 			}
 		}
 
-		c.cmd.Printf("%s %s/%s created\n", reflect.TypeOf(obj).Elem().Name(), obj.GetNamespace(), obj.GetName())
+		kind := reflect.TypeOf(obj).Elem().Name()
+		c.cmd.Printf("%s %s/%s created\n", kind, obj.GetNamespace(), obj.GetName())
+		result.RecordChange(c.cmd.Context(), result.Change{Action: "Create", Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()})
 	} else {
 		pvc, err = c.ensurePVCSupportsUpload(pvc)
 		if err != nil {
@@ -336,6 +423,16 @@ func (c *command) run(args []string) error {
 			return err
 		}
 	}
+	if c.dev {
+		var stop func()
+		c.uploadProxyURL, stop, err = devprofile.ForwardUploadProxyFn(c.client)
+		if err != nil {
+			return err
+		}
+		defer stop()
+		// The certificate of the upload proxy is not valid for the forwarded local address
+		c.insecure = true
+	}
 	if c.uploadProxyURL == "" {
 		c.uploadProxyURL, err = c.getUploadProxyURL()
 		if err != nil {
@@ -365,6 +462,7 @@ func (c *command) run(args []string) error {
 	if err := c.uploadData(token, file); err != nil {
 		return err
 	}
+	result.RecordChange(c.cmd.Context(), result.Change{Action: "Upload", Kind: "PersistentVolumeClaim", Namespace: c.namespace, Name: c.name})
 
 	if c.dataSource {
 		if err := c.handleDataSource(); err != nil {
@@ -383,19 +481,6 @@ func (c *command) run(args []string) error {
 	return err
 }
 
-func GetHTTPClient(insecure bool) *http.Client {
-	client := &http.Client{}
-
-	if insecure {
-		// #nosec cause: InsecureSkipVerify: true resolution: this method explicitly ask for insecure http client
-		client.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-	}
-
-	return client
-}
-
 // ConstructUploadProxyPath - receives uploadproxy address and concatenates to it URI
 func ConstructUploadProxyPath(uploadProxyURL string) (string, error) {
 	u, err := url.Parse(uploadProxyURL)
@@ -411,7 +496,7 @@ func ConstructUploadProxyPath(uploadProxyURL string) (string, error) {
 }
 
 // ConstructUploadProxyPathAsync - receives uploadproxy address and concatenates to it URI
-func ConstructUploadProxyPathAsync(uploadProxyURL, token string, insecure bool) (string, error) {
+func ConstructUploadProxyPathAsync(uploadProxyURL, token string, client *http.Client) (string, error) {
 	u, err := url.Parse(uploadProxyURL)
 
 	if err != nil {
@@ -423,7 +508,6 @@ func ConstructUploadProxyPathAsync(uploadProxyURL, token string, insecure bool)
 	}
 
 	// Attempt to discover async URL
-	client := GetHTTPClientFn(insecure)
 	req, _ := http.NewRequest("HEAD", u.String(), nil)
 	req.Header.Add("Authorization", "Bearer "+token)
 	resp, err := client.Do(req)
@@ -436,7 +520,8 @@ func ConstructUploadProxyPathAsync(uploadProxyURL, token string, insecure bool)
 }
 
 func (c *command) uploadData(token string, file *os.File) error {
-	uploadURL, err := ConstructUploadProxyPathAsync(c.uploadProxyURL, token, c.insecure)
+	client := GetHTTPClientFn(c.transport, c.insecure)
+	uploadURL, err := ConstructUploadProxyPathAsync(c.uploadProxyURL, token, client)
 	if err != nil {
 		return err
 	}
@@ -448,11 +533,13 @@ func (c *command) uploadData(token string, file *os.File) error {
 
 	bar := pb.New64(fi.Size())
 	bar.SetTemplate(pb.Full)
-	bar.SetWriter(os.Stdout)
+	bar.SetWriter(c.cmd.OutOrStdout())
+	if c.noProgressBar {
+		bar.SetWriter(io.Discard)
+	}
 	bar.Set(pb.Bytes, true)
 	reader := bar.NewProxyReader(file)
 
-	client := GetHTTPClientFn(c.insecure)
 	req, _ := http.NewRequest("POST", uploadURL, io.NopCloser(reader))
 
 	req.Header.Add("Authorization", "Bearer "+token)
@@ -795,6 +882,7 @@ func (c *command) ensurePVCSupportsUpload(pvc *v1.PersistentVolumeClaim) (*v1.Pe
 		if err != nil {
 			return nil, err
 		}
+		result.RecordChange(c.cmd.Context(), result.Change{Action: "Update", Kind: "PersistentVolumeClaim", Namespace: pvc.Namespace, Name: pvc.Name})
 	}
 
 	return pvc, nil
@@ -976,6 +1064,7 @@ func (c *command) createNewDataSource() error {
 	_, err := c.client.CdiClient().CdiV1beta1().DataSources(c.namespace).Create(context.Background(), ds, metav1.CreateOptions{})
 	if err == nil {
 		c.cmd.Printf("Created a new DataSource %s/%s\n", c.namespace, c.name)
+		result.RecordChange(c.cmd.Context(), result.Change{Action: "Create", Kind: "DataSource", Namespace: c.namespace, Name: c.name})
 	}
 	return err
 }
@@ -1004,6 +1093,7 @@ func (c *command) updateExistingDataSource(ds *cdiv1.DataSource) error {
 
 	if _, err = c.client.CdiClient().CdiV1beta1().DataSources(ds.Namespace).Patch(context.Background(), ds.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{}); err == nil {
 		c.cmd.Printf("Updated an existing DataSource %s/%s\n", ds.Namespace, ds.Name)
+		result.RecordChange(c.cmd.Context(), result.Change{Action: "Update", Kind: "DataSource", Namespace: ds.Namespace, Name: ds.Name})
 	}
 	return err
 }
@@ -21,6 +21,7 @@ package imageupload_test
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -49,6 +50,7 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
+	"kubevirt.io/kubevirt/pkg/virtctl/devprofile"
 	"kubevirt.io/kubevirt/pkg/virtctl/imageupload"
 	"kubevirt.io/kubevirt/pkg/virtctl/testing"
 	"kubevirt.io/kubevirt/tests/libstorage"
@@ -504,7 +506,7 @@ var _ = Describe("ImageUpload", func() {
 		updateCDIConfig(config)
 
 		imageupload.UploadProcessingCompleteFunc = waitProcessingComplete
-		imageupload.GetHTTPClientFn = func(bool) *http.Client {
+		imageupload.GetHTTPClientFn = func(*http.Transport, bool) *http.Client {
 			return server.Client()
 		}
 	}
@@ -595,6 +597,32 @@ var _ = Describe("ImageUpload", func() {
 			Expect(dvCreateCalled.Load()).To(BeFalse())
 		})
 
+		It("Use forwarded UploadProxyURL with --dev", func() {
+			testInit(http.StatusOK)
+			stopped := false
+			devprofile.ForwardUploadProxyFn = func(kubecli.KubevirtClient) (string, func(), error) {
+				return server.URL, func() { stopped = true }, nil
+			}
+			DeferCleanup(func() {
+				devprofile.ForwardUploadProxyFn = devprofile.ForwardUploadProxy
+			})
+			cmd := testing.NewRepeatableVirtctlCommand(commandName, "dv", targetName, "--size", pvcSize,
+				"--dev", "--image-path", imagePath)
+			Expect(cmd()).To(Succeed())
+			Expect(dvCreateCalled.Load()).To(BeTrue())
+			Expect(stopped).To(BeTrue())
+			validatePVC()
+			validateDataVolume()
+		})
+
+		It("--dev and --uploadproxy-url are mutually exclusive", func() {
+			testInit(http.StatusOK)
+			cmd := testing.NewRepeatableVirtctlCommand(commandName, "dv", targetName, "--size", pvcSize,
+				"--dev", "--uploadproxy-url", server.URL, "--image-path", imagePath)
+			Expect(cmd()).To(MatchError(ContainSubstring("if any flags in the group [uploadproxy-url dev] are set none of the others can be")))
+			Expect(dvCreateCalled.Load()).To(BeFalse())
+		})
+
 		It("Use CDI Config UploadProxyURL", func() {
 			testInit(http.StatusOK)
 			cmd := testing.NewRepeatableVirtctlCommand(commandName, "dv", targetName, "--size", pvcSize,
@@ -1002,7 +1030,7 @@ var _ = Describe("ImageUpload", func() {
 				[]string{"targetName", "--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-path", "/dev/null"}),
 			Entry("No name", "expecting two args",
 				[]string{"--size", pvcSize, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-path", "/dev/null"}),
-			Entry("No size", "when creating a resource, the size must be specified",
+			Entry("No size", "when creating a resource, the size must be specified, it could not be inferred from the image: the image is empty",
 				[]string{"dv", targetName, "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-path", "/dev/null"}),
 			Entry("Size invalid", "validation failed for size=500Zb: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
 				[]string{"dv", targetName, "--size", "500Zb", "--uploadproxy-url", "https://doesnotexist", "--insecure", "--image-path", "/dev/null"}),
@@ -1037,6 +1065,202 @@ var _ = Describe("ImageUpload", func() {
 		})
 	})
 
+	Context("with size inference", func() {
+		const rawImageSize = 10 * 1024 * 1024
+
+		writeImage := func(header []byte, size int64) string {
+			file, err := os.CreateTemp("", "inferred_image")
+			Expect(err).ToNot(HaveOccurred())
+			defer file.Close()
+			DeferCleanup(os.Remove, file.Name())
+			_, err = file.Write(header)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file.Truncate(size)).To(Succeed())
+			return file.Name()
+		}
+
+		qcow2Image := func(virtualSize uint64) string {
+			header := make([]byte, 32)
+			copy(header, []byte{'Q', 'F', 'I', 0xfb})
+			binary.BigEndian.PutUint32(header[4:], 3)
+			binary.BigEndian.PutUint64(header[24:], virtualSize)
+			return writeImage(header, 1024*1024)
+		}
+
+		requestedDataVolumeSize := func() string {
+			dv, err := cdiClient.CdiV1beta1().DataVolumes(targetNamespace).Get(context.Background(), targetName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			size, ok := getResourceRequestedStorageSize(dv.Spec)
+			Expect(ok).To(BeTrue())
+			return size.String()
+		}
+
+		requestedPVCSize := func() string {
+			pvc, err := kubeClient.CoreV1().PersistentVolumeClaims(targetNamespace).Get(context.Background(), targetName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			size := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+			return size.String()
+		}
+
+		AfterEach(func() {
+			testDone()
+		})
+
+		It("should use the virtual size of qcow2 images for DataVolumes", func() {
+			testInit(http.StatusOK)
+			out, err := testing.NewRepeatableVirtctlCommandWithOut(commandName, "dv", targetName,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", qcow2Image(10*1024*1024*1024))()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("Using size 10Gi inferred from the image"))
+			Expect(requestedDataVolumeSize()).To(Equal("10Gi"))
+		})
+
+		It("should add the filesystem overhead configured in CDI for PVCs", func() {
+			testInit(http.StatusOK)
+			config, err := cdiClient.CdiV1beta1().CDIConfigs().Get(context.Background(), configName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			config.Status.FilesystemOverhead = &cdiv1.FilesystemOverhead{Global: "0.1"}
+			updateCDIConfig(config)
+
+			cmd := testing.NewRepeatableVirtctlCommand(commandName, "pvc", targetName,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", writeImage(nil, rawImageSize))
+			Expect(cmd()).To(Succeed())
+			Expect(requestedPVCSize()).To(Equal("11Mi"))
+		})
+
+		It("should not add a filesystem overhead for block PVCs", func() {
+			testInit(http.StatusOK)
+			cmd := testing.NewRepeatableVirtctlCommand(commandName, "pvc", targetName, "--volume-mode", "block",
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", writeImage(nil, rawImageSize))
+			Expect(cmd()).To(Succeed())
+			Expect(requestedPVCSize()).To(Equal("10Mi"))
+		})
+
+		It("should round up to the minimum size supported by the storage profile", func() {
+			profile := &cdiv1.StorageProfile{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "local",
+					Annotations: map[string]string{"cdi.kubevirt.io/minimumSupportedPvcSize": "1Gi"},
+				},
+			}
+			testInitAsyncWithCdiObjects(http.StatusOK, true, nil, []runtime.Object{profile})
+			cmd := testing.NewRepeatableVirtctlCommand(commandName, "dv", targetName, "--storage-class", "local",
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", writeImage(nil, rawImageSize))
+			Expect(cmd()).To(Succeed())
+			Expect(requestedDataVolumeSize()).To(Equal("1Gi"))
+		})
+
+		It("should fail to infer the size of compressed images", func() {
+			testInit(http.StatusOK)
+			cmd := testing.NewRepeatableVirtctlCommand(commandName, "dv", targetName,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", writeImage([]byte{0x1f, 0x8b, 0x08}, rawImageSize))
+			Expect(cmd()).To(MatchError("when creating a resource, the size must be specified, it could not be inferred from the image: " +
+				"the virtual size of gzip compressed images cannot be inferred"))
+			Expect(dvCreateCalled.Load()).To(BeFalse())
+		})
+
+		It("should fail to infer the size of archives", func() {
+			testInit(http.StatusOK)
+			cmd := testing.NewRepeatableVirtctlCommand(commandName, "dv", targetName,
+				"--uploadproxy-url", server.URL, "--insecure", "--archive-path", archiveFilePath)
+			Expect(cmd()).To(MatchError(ContainSubstring("the size of archives cannot be inferred")))
+		})
+	})
+
+	Context("Upload from file", func() {
+		var fromFile string
+
+		writeFromFile := func(content string) {
+			file, err := os.CreateTemp("", "images-*.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			defer file.Close()
+			_, err = file.WriteString(content)
+			Expect(err).ToNot(HaveOccurred())
+			fromFile = file.Name()
+		}
+
+		AfterEach(func() {
+			os.Remove(fromFile)
+			testDone()
+		})
+
+		It("should upload all images and print a summary", func() {
+			testInit(http.StatusOK)
+			writeFromFile(fmt.Sprintf(`
+- name: %s
+  imagePath: %s
+  size: %s
+`, targetName, imagePath, dvSize))
+			cmd := testing.NewRepeatableVirtctlCommandWithOut(commandName, "--from-file", fromFile,
+				"--uploadproxy-url", server.URL, "--insecure")
+			out, err := cmd()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dvCreateCalled.Load()).To(BeTrue())
+			validatePVC()
+			validateDataVolume()
+			Expect(string(out)).To(ContainSubstring(fmt.Sprintf("[%s] Uploading data to %s", targetName, server.URL)))
+			Expect(string(out)).To(MatchRegexp(`NAMESPACE\s+KIND\s+NAME\s+RESULT\n%s\s+dv\s+%s\s+OK\n`, targetNamespace, targetName))
+		})
+
+		It("should download images from a URL and report the failed uploads", func() {
+			testInit(http.StatusOK)
+			imageServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.ServeFile(w, r, imagePath)
+			}))
+			defer imageServer.Close()
+			writeFromFile(fmt.Sprintf(`
+- kind: pvc
+  name: %s
+  url: %s/image.img
+  size: %s
+- name: missing
+  imagePath: does-not-exist.img
+  size: %s
+`, targetName, imageServer.URL, pvcSize, dvSize))
+			cmd := testing.NewRepeatableVirtctlCommandWithOut(commandName, "--from-file", fromFile,
+				"--uploadproxy-url", server.URL, "--insecure", "--concurrency", "1")
+			out, err := cmd()
+			Expect(err).To(MatchError("failed to upload 1 of 2 images"))
+			Expect(pvcCreateCalled.Load()).To(BeTrue())
+			validatePVC()
+			Expect(string(out)).To(ContainSubstring(fmt.Sprintf("[%s] Downloading %s/image.img", targetName, imageServer.URL)))
+			Expect(string(out)).To(MatchRegexp(`%s\s+pvc\s+%s\s+OK\n`, targetNamespace, targetName))
+			Expect(string(out)).To(MatchRegexp(`%s\s+dv\s+missing\s+open .*does-not-exist.img: no such file or directory\n`, targetNamespace))
+		})
+
+		DescribeTable("should reject an invalid file", func(content, errString string) {
+			testInit(http.StatusOK)
+			writeFromFile(content)
+			cmd := testing.NewRepeatableVirtctlCommand(commandName, "--from-file", fromFile,
+				"--uploadproxy-url", server.URL, "--insecure")
+			Expect(cmd()).To(MatchError(ContainSubstring(errString)))
+			Expect(dvCreateCalled.Load()).To(BeFalse())
+			Expect(pvcCreateCalled.Load()).To(BeFalse())
+		},
+			Entry("with no images", "[]", "no images found in"),
+			Entry("with an unknown field", "- name: foo\n  imagePath: /dev/null\n  foo: bar\n", `unknown field "foo"`),
+			Entry("with a missing name", "- imagePath: /dev/null\n", "image 0 in"),
+			Entry("with an invalid kind", "- kind: vm\n  name: foo\n  imagePath: /dev/null\n", "invalid kind vm, must be dv or pvc"),
+			Entry("without a source", "- name: foo\n", "exactly one of imagePath, archivePath and url must be provided"),
+			Entry("with several sources", "- name: foo\n  imagePath: /dev/null\n  url: https://doesnotexist\n",
+				"exactly one of imagePath, archivePath and url must be provided"),
+			Entry("with a duplicate name", "- name: foo\n  imagePath: /dev/null\n- name: foo\n  archivePath: /dev/null\n", "name is used more than once"),
+		)
+
+		DescribeTable("should reject invalid args", func(errString string, args ...string) {
+			testInit(http.StatusOK)
+			writeFromFile("- name: foo\n  imagePath: /dev/null\n")
+			args = append([]string{commandName, "--from-file", fromFile}, args...)
+			cmd := testing.NewRepeatableVirtctlCommand(args...)
+			Expect(cmd()).To(MatchError(ContainSubstring(errString)))
+		},
+			Entry("with args", "cannot use --from-file and args", "dv", targetName),
+			Entry("with an invalid concurrency", "--concurrency must be greater than zero", "--concurrency", "0"),
+			Entry("with --image-path", "[from-file image-path] were all set", "--image-path", "/dev/null"),
+			Entry("with --size", "[from-file size] were all set", "--size", dvSize),
+		)
+	})
+
 	Context("URL validation", func() {
 		serverURL := "http://localhost:12345"
 		DescribeTable("Server URL validations", func(serverUrl string, expected string) {
@@ -0,0 +1,180 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package imageupload
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
+)
+
+const (
+	// minimumSupportedPVCSizeAnnotation is set on StorageProfiles of provisioners which
+	// cannot provision volumes below a given size
+	minimumSupportedPVCSizeAnnotation = "cdi.kubevirt.io/minimumSupportedPvcSize"
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+
+	qcow2HeaderSize        = 32
+	qcow2VirtualSizeOffset = 24
+)
+
+var (
+	qcow2Magic = []byte{'Q', 'F', 'I', 0xfb}
+
+	// compressed images have to be decompressed by CDI before their virtual size is known
+	compressedMagics = map[string][]byte{
+		"gzip": {0x1f, 0x8b},
+		"xz":   {0xfd, '7', 'z', 'X', 'Z', 0x00},
+		"zstd": {0x28, 0xb5, 0x2f, 0xfd},
+	}
+)
+
+// inferSize computes the size of the volume required to hold the image from its virtual size.
+// The filesystem overhead is only added for PVCs, CDI already adds it to the size requested
+// by DataVolumes with a filesystem volume mode.
+func (c *command) inferSize(file *os.File) (string, error) {
+	if c.archiveUpload {
+		return "", errors.New("the size of archives cannot be inferred")
+	}
+	virtualSize, err := imageVirtualSize(file)
+	if err != nil {
+		return "", err
+	}
+
+	storageClass := c.targetStorageClass()
+	size := resource.NewQuantity(virtualSize, resource.BinarySI)
+	if c.createPVC && c.volumeMode != "block" {
+		size, err = c.sizeIncludingFSOverhead(size, storageClass)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		size, err = storagetypes.GetSizeIncludingGivenOverhead(size, "0")
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if minimum := c.minimumSupportedPVCSize(storageClass); minimum != nil && size.Cmp(*minimum) < 0 {
+		size = minimum
+	}
+	return size.String(), nil
+}
+
+// imageVirtualSize returns the virtual size from the header of qcow2 images, and the file
+// size for raw images. The file is rewound before returning.
+func imageVirtualSize(file *os.File) (int64, error) {
+	size, err := readImageVirtualSize(file)
+	if _, seekErr := file.Seek(0, io.SeekStart); seekErr != nil {
+		return 0, fmt.Errorf("failed to rewind the image: %v", seekErr)
+	}
+	return size, err
+}
+
+func readImageVirtualSize(file *os.File) (int64, error) {
+	header := make([]byte, qcow2HeaderSize)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("failed to read the image header: %v", err)
+	}
+	header = header[:n]
+
+	if n == qcow2HeaderSize && bytes.HasPrefix(header, qcow2Magic) {
+		return int64(binary.BigEndian.Uint64(header[qcow2VirtualSizeOffset:])), nil
+	}
+	for format, magic := range compressedMagics {
+		if bytes.HasPrefix(header, magic) {
+			return 0, fmt.Errorf("the virtual size of %s compressed images cannot be inferred", format)
+		}
+	}
+
+	// Seeking to the end works for block devices too, their stat size is 0
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("failed to determine the image size: %v", err)
+	}
+	if size == 0 {
+		return 0, errors.New("the image is empty")
+	}
+	return size, nil
+}
+
+func (c *command) sizeIncludingFSOverhead(size *resource.Quantity, storageClass string) (*resource.Quantity, error) {
+	cdiConfig, err := c.client.CdiClient().CdiV1beta1().CDIConfigs().Get(context.Background(), configName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return storagetypes.GetSizeIncludingDefaultFSOverhead(size)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cdiConfig.Status.FilesystemOverhead == nil {
+		return storagetypes.GetSizeIncludingDefaultFSOverhead(size)
+	}
+
+	var storageClassName *string
+	if storageClass != "" {
+		storageClassName = &storageClass
+	}
+	volumeMode := v1.PersistentVolumeFilesystem
+	return storagetypes.GetSizeIncludingFSOverhead(size, storageClassName, &volumeMode, cdiConfig)
+}
+
+// targetStorageClass returns the storage class the volume is going to be provisioned with,
+// or an empty string if it cannot be determined
+func (c *command) targetStorageClass() string {
+	if c.storageClass != "" {
+		return c.storageClass
+	}
+	storageClasses, err := c.client.StorageV1().StorageClasses().List(context.Background(), metav1.ListOptions{})
+	if err != nil || storageClasses == nil {
+		return ""
+	}
+	for _, sc := range storageClasses.Items {
+		if sc.Annotations[defaultStorageClassAnnotation] == "true" {
+			return sc.Name
+		}
+	}
+	return ""
+}
+
+func (c *command) minimumSupportedPVCSize(storageClass string) *resource.Quantity {
+	if storageClass == "" {
+		return nil
+	}
+	profile, err := c.client.CdiClient().CdiV1beta1().StorageProfiles().Get(context.Background(), storageClass, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	minimum, err := resource.ParseQuantity(profile.Annotations[minimumSupportedPVCSizeAnnotation])
+	if err != nil {
+		return nil
+	}
+	return &minimum
+}
@@ -0,0 +1,138 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package imageupload
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+const (
+	// ProxyPasswordEnv holds the password used together with --proxy-username,
+	// it is read from the environment to keep it out of the shell history
+	ProxyPasswordEnv = "VIRTCTL_UPLOAD_PROXY_PASSWORD"
+	// ClientCertEnv is the default for --client-cert
+	ClientCertEnv = "VIRTCTL_UPLOAD_CLIENT_CERT"
+	// ClientKeyEnv is the default for --client-key
+	ClientKeyEnv = "VIRTCTL_UPLOAD_CLIENT_KEY"
+)
+
+// ProxyFromEnvironmentFn looks up the proxy of a request when no proxy URL is
+// configured. http.ProxyFromEnvironment reads the environment only once per process.
+var ProxyFromEnvironmentFn = http.ProxyFromEnvironment
+
+// TransportOptions describes how the cdi-upload proxy is reached
+type TransportOptions struct {
+	// ProxyURL is the HTTP(S) proxy to send the requests through,
+	// the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables are used when empty
+	ProxyURL string
+	// ProxyUsername is used to authenticate to the proxy with Basic authentication,
+	// credentials can also be provided as user info of the proxy URL
+	ProxyUsername string
+	// ClientCert and ClientKey are the paths to a PEM encoded certificate and key
+	// presented to the cdi-upload proxy
+	ClientCert string
+	ClientKey  string
+}
+
+// NewTransport returns a transport for talking to the cdi-upload proxy through
+// the configured proxy and presenting the configured client certificate
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	proxy, err := proxyFunc(opts)
+	if err != nil {
+		return nil, err
+	}
+	transport.Proxy = proxy
+
+	certFile, keyFile := opts.ClientCert, opts.ClientKey
+	if certFile == "" && keyFile == "" {
+		certFile, keyFile = os.Getenv(ClientCertEnv), os.Getenv(ClientKeyEnv)
+	}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, fmt.Errorf("both a client certificate and a client key must be provided")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
+	return transport, nil
+}
+
+func proxyFunc(opts TransportOptions) (func(*http.Request) (*url.URL, error), error) {
+	var userinfo *url.Userinfo
+	if opts.ProxyUsername != "" {
+		userinfo = url.UserPassword(opts.ProxyUsername, os.Getenv(ProxyPasswordEnv))
+	}
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %s: scheme and host are required", opts.ProxyURL)
+		}
+		if userinfo != nil {
+			proxyURL.User = userinfo
+		}
+		return http.ProxyURL(proxyURL), nil
+	}
+
+	fromEnvironment := ProxyFromEnvironmentFn
+	if userinfo == nil {
+		return fromEnvironment, nil
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		proxyURL, err := fromEnvironment(req)
+		if err != nil || proxyURL == nil {
+			return proxyURL, err
+		}
+		// The transport sends Basic Proxy-Authorization for the user info of the proxy URL.
+		// The URL returned from the environment is shared, so the user info is set on a copy.
+		authURL := *proxyURL
+		authURL.User = userinfo
+		return &authURL, nil
+	}, nil
+}
+
+// GetHTTPClient returns a client using transport, skipping the verification
+// of the upload proxy certificate if insecure is set
+func GetHTTPClient(transport *http.Transport, insecure bool) *http.Client {
+	if insecure {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		// #nosec cause: InsecureSkipVerify: true resolution: this method explicitly ask for insecure http client
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+
+	return &http.Client{Transport: transport}
+}
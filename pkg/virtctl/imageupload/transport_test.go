@@ -0,0 +1,166 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package imageupload_test
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/util/cert"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/imageupload"
+)
+
+var _ = Describe("Upload proxy transport", func() {
+	Context("with a proxy", func() {
+		var (
+			proxy         *httptest.Server
+			proxyAuthChan chan string
+		)
+
+		BeforeEach(func() {
+			proxyAuthChan = make(chan string, 1)
+			// Plain HTTP requests are forwarded to the proxy with an absolute URI,
+			// so the proxy can answer them directly
+			proxy = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxyAuthChan <- r.Header.Get("Proxy-Authorization")
+				w.WriteHeader(http.StatusOK)
+			}))
+			DeferCleanup(proxy.Close)
+		})
+
+		basicAuth := func(user, password string) string {
+			return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+password))
+		}
+
+		get := func(opts imageupload.TransportOptions) {
+			transport, err := imageupload.NewTransport(opts)
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := imageupload.GetHTTPClient(transport, false).Get("http://cdi-uploadproxy.example.com/")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		}
+
+		It("should send requests through the proxy without credentials", func() {
+			get(imageupload.TransportOptions{ProxyURL: proxy.URL})
+			Expect(<-proxyAuthChan).To(BeEmpty())
+		})
+
+		It("should authenticate with the username and the password from the environment", func() {
+			GinkgoT().Setenv(imageupload.ProxyPasswordEnv, "secret")
+			get(imageupload.TransportOptions{ProxyURL: proxy.URL, ProxyUsername: "user"})
+			Expect(<-proxyAuthChan).To(Equal(basicAuth("user", "secret")))
+		})
+
+		It("should authenticate with the credentials of the proxy URL", func() {
+			proxyURL := strings.Replace(proxy.URL, "http://", "http://user:pass@", 1)
+			get(imageupload.TransportOptions{ProxyURL: proxyURL})
+			Expect(<-proxyAuthChan).To(Equal(basicAuth("user", "pass")))
+		})
+
+		It("should authenticate to the proxy from the environment", func() {
+			proxyURL, err := url.Parse(proxy.URL)
+			Expect(err).ToNot(HaveOccurred())
+			imageupload.ProxyFromEnvironmentFn = func(*http.Request) (*url.URL, error) {
+				return proxyURL, nil
+			}
+			DeferCleanup(func() {
+				imageupload.ProxyFromEnvironmentFn = http.ProxyFromEnvironment
+			})
+			GinkgoT().Setenv(imageupload.ProxyPasswordEnv, "secret")
+			get(imageupload.TransportOptions{ProxyUsername: "user"})
+			Expect(<-proxyAuthChan).To(Equal(basicAuth("user", "secret")))
+			Expect(proxyURL.User).To(BeNil())
+		})
+	})
+
+	Context("with a client certificate", func() {
+		var certFile, keyFile string
+
+		BeforeEach(func() {
+			certPEM, keyPEM, err := cert.GenerateSelfSignedCertKey("virtctl", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			dir := GinkgoT().TempDir()
+			certFile = filepath.Join(dir, "tls.crt")
+			keyFile = filepath.Join(dir, "tls.key")
+			Expect(os.WriteFile(certFile, certPEM, 0o600)).To(Succeed())
+			Expect(os.WriteFile(keyFile, keyPEM, 0o600)).To(Succeed())
+		})
+
+		newServer := func() *httptest.Server {
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if len(r.TLS.PeerCertificates) == 0 {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+			server.StartTLS()
+			DeferCleanup(server.Close)
+			return server
+		}
+
+		It("should present the certificate to the upload proxy", func() {
+			server := newServer()
+			transport, err := imageupload.NewTransport(imageupload.TransportOptions{ClientCert: certFile, ClientKey: keyFile})
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := imageupload.GetHTTPClient(transport, true).Get(server.URL)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("should fall back to the certificate from the environment", func() {
+			GinkgoT().Setenv(imageupload.ClientCertEnv, certFile)
+			GinkgoT().Setenv(imageupload.ClientKeyEnv, keyFile)
+			transport, err := imageupload.NewTransport(imageupload.TransportOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(transport.TLSClientConfig.Certificates).To(HaveLen(1))
+		})
+
+		It("should fail without the key", func() {
+			_, err := imageupload.NewTransport(imageupload.TransportOptions{ClientCert: certFile})
+			Expect(err).To(MatchError("both a client certificate and a client key must be provided"))
+		})
+
+		It("should fail with an invalid key", func() {
+			_, err := imageupload.NewTransport(imageupload.TransportOptions{ClientCert: certFile, ClientKey: certFile})
+			Expect(err).To(MatchError(ContainSubstring("failed to load client certificate")))
+		})
+	})
+
+	DescribeTable("should reject an invalid proxy URL", func(proxyURL string) {
+		_, err := imageupload.NewTransport(imageupload.TransportOptions{ProxyURL: proxyURL})
+		Expect(err).To(MatchError(ContainSubstring("invalid proxy URL")))
+	},
+		Entry("without scheme", "proxy.example.com"),
+		Entry("with invalid characters", "http://proxy example.com"),
+	)
+})
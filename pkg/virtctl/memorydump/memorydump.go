@@ -30,6 +30,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
@@ -37,7 +38,10 @@ import (
 	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	kutil "kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 	"kubevirt.io/kubevirt/pkg/virtctl/vmexport"
 )
@@ -86,6 +90,9 @@ func usageMemoryDump() string {
   #Create and download memory dump to the given output file.
   {{ProgramName}} memory-dump get myvm --claim-name=memoryvolume --create-claim --output=memoryDump.dump.gz
 
+  #Stream the memory dump of the vm 'myvm' to the given output file, the pvc staging the dump is created and removed on the fly.
+  {{ProgramName}} memory-dump get myvm --output=memoryDump.dump.gz
+
   #Dump memory again to the same virtual machine with an already associated pvc(existing memory dump on vm status).
   {{ProgramName}} memory-dump get myvm
 
@@ -109,6 +116,7 @@ func NewMemoryDumpCommand() *cobra.Command {
 		RunE:    c.run,
 	}
 	cmd.SetUsageTemplate(templates.UsageTemplate())
+	requirements.AnyFeatureGate(cmd, featuregate.HotplugVolumesGate, featuregate.DeclarativeHotplugVolumesGate)
 	cmd.Flags().StringVar(&claimName, ClaimNameFlag, "", "pvc name to contain the memory dump")
 	cmd.Flags().BoolVar(&createClaim, CreateClaimFlag, false, "Create the pvc that will conatin the memory dump")
 	cmd.Flags().BoolVar(&portForward, PortForwardFlag, false, "Configure and set port-forward in a random port to download the memory dump")
@@ -116,7 +124,7 @@ func NewMemoryDumpCommand() *cobra.Command {
 	cmd.Flags().StringVar(&localPort, LocalPortFlag, "0", "Specify port for port-forward")
 	cmd.Flags().StringVar(&storageClass, StorageClassFlag, "", "The storage class for the PVC.")
 	cmd.Flags().StringVar(&accessMode, AccessModeFlag, "", "The access mode for the PVC.")
-	cmd.Flags().StringVar(&outputFile, OutputFileFlag, "", "Specifies the output path of the memory dump to be downloaded.")
+	cmd.Flags().StringVar(&outputFile, OutputFileFlag, "", "Specifies the output path of the memory dump to be downloaded. Without a claim the dump is staged in a temporary pvc.")
 
 	return cmd
 }
@@ -130,11 +138,11 @@ func (c *command) run(cmd *cobra.Command, args []string) error {
 	vmName := args[1]
 	switch args[0] {
 	case "get":
-		return getMemoryDump(namespace, vmName, virtClient)
+		return getMemoryDump(cmd.Context(), namespace, vmName, virtClient)
 	case "download":
-		return downloadMemoryDump(namespace, vmName, virtClient)
+		return downloadMemoryDump(cmd.Context(), namespace, vmName, virtClient)
 	case "remove":
-		return removeMemoryDump(namespace, vmName, virtClient)
+		return removeMemoryDump(cmd.Context(), namespace, vmName, virtClient)
 	default:
 		return fmt.Errorf("invalid action type %s", args[0])
 	}
@@ -231,7 +239,7 @@ func checkNoAssociatedMemoryDump(namespace, vmName string, virtClient kubecli.Ku
 	return nil
 }
 
-func createPVCforMemoryDump(namespace, vmName, claimName string, virtClient kubecli.KubevirtClient) error {
+func createPVCforMemoryDump(ctx context.Context, namespace, vmName, claimName string, virtClient kubecli.KubevirtClient) error {
 	// Before creating a new pvc check that there is not already
 	// assocaited memory dump pvc
 	if err := checkNoAssociatedMemoryDump(namespace, vmName, virtClient); err != nil {
@@ -257,51 +265,108 @@ func createPVCforMemoryDump(namespace, vmName, claimName string, virtClient kube
 		return err
 	}
 
-	_, err = virtClient.CoreV1().PersistentVolumeClaims(namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+	_, err = virtClient.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, pvc, metav1.CreateOptions{})
 	if err != nil {
 		return err
 	}
+	result.RecordChange(ctx, result.Change{Action: "Create", Kind: "PersistentVolumeClaim", Namespace: namespace, Name: claimName})
 
 	fmt.Printf("PVC %s/%s created\n", namespace, claimName)
 
 	return nil
 }
 
-func createMemoryDump(namespace, vmName, claimName string, virtClient kubecli.KubevirtClient) error {
+func createMemoryDump(ctx context.Context, namespace, vmName, claimName string, virtClient kubecli.KubevirtClient) error {
 	memoryDumpRequest := &v1.VirtualMachineMemoryDumpRequest{
 		ClaimName: claimName,
 	}
 
-	err := virtClient.VirtualMachine(namespace).MemoryDump(context.Background(), vmName, memoryDumpRequest)
+	err := virtClient.VirtualMachine(namespace).MemoryDump(ctx, vmName, memoryDumpRequest)
 	if err != nil {
 		return fmt.Errorf("error dumping vm memory, %v", err)
 	}
+	result.RecordChange(ctx, result.Change{Action: "MemoryDump", Kind: v1.VirtualMachineGroupVersionKind.Kind, Namespace: namespace, Name: vmName})
 	fmt.Printf("Successfully submitted memory dump request of VM %s\n", vmName)
 	return nil
 }
 
-func getMemoryDump(namespace, vmName string, virtClient kubecli.KubevirtClient) error {
-	if createClaim {
-		if claimName == "" {
+func getMemoryDump(ctx context.Context, namespace, vmName string, virtClient kubecli.KubevirtClient) error {
+	claim := claimName
+	create := createClaim
+	temporaryClaim, err := needsTemporaryClaim(namespace, vmName, virtClient)
+	if err != nil {
+		return err
+	}
+	if temporaryClaim {
+		claim = fmt.Sprintf("%s-memory-dump-%s", vmName, rand.String(5))
+		create = true
+	}
+
+	if create {
+		if claim == "" {
 			return fmt.Errorf("missing claim name")
 		}
-		if err := createPVCforMemoryDump(namespace, vmName, claimName, virtClient); err != nil {
+		if err := createPVCforMemoryDump(ctx, namespace, vmName, claim, virtClient); err != nil {
 			return err
 		}
 	}
 
-	if err := createMemoryDump(namespace, vmName, claimName, virtClient); err != nil {
+	if err := createMemoryDump(ctx, namespace, vmName, claim, virtClient); err != nil {
+		return err
+	}
+
+	if outputFile == "" {
+		return nil
+	}
+
+	if err := downloadMemoryDump(ctx, namespace, vmName, virtClient); err != nil {
+		if temporaryClaim {
+			fmt.Printf("The memory dump is kept in PVC %s/%s, use the download command to retry\n", namespace, claim)
+		}
 		return err
 	}
 
-	if outputFile != "" {
-		return downloadMemoryDump(namespace, vmName, virtClient)
+	if temporaryClaim {
+		return removeTemporaryClaim(ctx, namespace, vmName, claim, virtClient)
+	}
+
+	return nil
+}
+
+// needsTemporaryClaim reports whether the memory dump has to be staged in a
+// claim created on behalf of the user. This is the case when the dump is only
+// wanted locally and neither a claim was given nor one is associated already.
+func needsTemporaryClaim(namespace, vmName string, virtClient kubecli.KubevirtClient) (bool, error) {
+	if outputFile == "" || claimName != "" || createClaim {
+		return false, nil
+	}
+
+	vm, err := virtClient.VirtualMachine(namespace).Get(context.Background(), vmName, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	return vm.Status.MemoryDumpRequest == nil, nil
+}
+
+func removeTemporaryClaim(ctx context.Context, namespace, vmName, claim string, virtClient kubecli.KubevirtClient) error {
+	if err := removeMemoryDump(ctx, namespace, vmName, virtClient); err != nil {
+		return err
+	}
+
+	err := virtClient.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, claim, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting PVC %s/%s: %v", namespace, claim, err)
+	}
+	if err == nil {
+		result.RecordChange(ctx, result.Change{Action: "Delete", Kind: "PersistentVolumeClaim", Namespace: namespace, Name: claim})
 	}
+	fmt.Printf("PVC %s/%s deleted\n", namespace, claim)
 
 	return nil
 }
 
-func downloadMemoryDump(namespace, vmName string, virtClient kubecli.KubevirtClient) error {
+func downloadMemoryDump(ctx context.Context, namespace, vmName string, virtClient kubecli.KubevirtClient) error {
 	if outputFile == "" {
 		return fmt.Errorf("missing outputFile to download the memory dump")
 	}
@@ -351,7 +416,7 @@ func downloadMemoryDump(namespace, vmName string, virtClient kubecli.KubevirtCli
 		return err
 	}
 	vmExportInfo.OutputWriter = output
-	return vmexport.DownloadVirtualMachineExport(virtClient, vmExportInfo)
+	return vmexport.DownloadVirtualMachineExport(ctx, virtClient, vmExportInfo)
 }
 
 func WaitForMemoryDumpComplete(virtClient kubecli.KubevirtClient, namespace, vmName string, interval, timeout time.Duration) (string, error) {
@@ -379,11 +444,12 @@ func WaitForMemoryDumpComplete(virtClient kubecli.KubevirtClient, namespace, vmN
 	return claimName, err
 }
 
-func removeMemoryDump(namespace, vmName string, virtClient kubecli.KubevirtClient) error {
-	err := virtClient.VirtualMachine(namespace).RemoveMemoryDump(context.Background(), vmName)
+func removeMemoryDump(ctx context.Context, namespace, vmName string, virtClient kubecli.KubevirtClient) error {
+	err := virtClient.VirtualMachine(namespace).RemoveMemoryDump(ctx, vmName)
 	if err != nil {
 		return fmt.Errorf("error removing memory dump association, %v", err)
 	}
+	result.RecordChange(ctx, result.Change{Action: "RemoveMemoryDump", Kind: v1.VirtualMachineGroupVersionKind.Kind, Namespace: namespace, Name: vmName})
 	fmt.Printf("Successfully submitted remove memory dump association of VM %s\n", vmName)
 	return nil
 }
@@ -37,6 +37,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -433,13 +434,71 @@ var _ = Describe("MemoryDump", func() {
 		})
 
 		It("should get memory dump and call download memory dump", func() {
+			vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{ClaimName: pvcName}
+			_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Update(context.Background(), vm, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
 			expectVMEndpointMemoryDump("")
 			updateVMEStatusOnCreate()
-			err := runGetCmd(
+			err = runGetCmd(
 				setFlag(memorydump.OutputFileFlag, outputPath),
 			)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(kvtesting.FilterActions(&virtClient.Fake, "put", "virtualmachines", "memorydump")).To(HaveLen(1))
+			Expect(kvtesting.FilterActions(&virtClient.Fake, "put", "virtualmachines", "removememorydump")).To(BeEmpty())
+		})
+
+		Context("with a temporary claim", func() {
+			var claimName string
+
+			BeforeEach(func() {
+				claimName = ""
+				virtClient.PrependReactor("put", "virtualmachines/memorydump", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+					put, ok := action.(kvtesting.PutAction[*v1.VirtualMachineMemoryDumpRequest])
+					Expect(ok).To(BeTrue())
+					claimName = put.GetOptions().ClaimName
+					return true, nil, nil
+				})
+				virtClient.PrependReactor("put", "virtualmachines/removememorydump", func(_ k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+					return true, nil, nil
+				})
+				memorydump.WaitForMemoryDumpCompleteFn = func(_ kubecli.KubevirtClient, _, _ string, _, _ time.Duration) (string, error) {
+					return claimName, nil
+				}
+				updateVMEStatusOnCreate()
+			})
+
+			It("should stream the memory dump and remove the claim afterwards", func() {
+				const data = "memory"
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					_, err := w.Write([]byte(data))
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				Expect(runGetCmd(setFlag(memorydump.OutputFileFlag, outputPath))).To(Succeed())
+				Expect(claimName).To(HavePrefix(vmName + "-memory-dump-"))
+				Expect(outputPath).To(BeAnExistingFile())
+				Expect(os.ReadFile(outputPath)).To(BeEquivalentTo(data))
+
+				Expect(kubeClient.Fake.Actions()).To(ContainElement(SatisfyAll(
+					HaveField("Verb", "create"),
+					HaveField("Resource", k8sv1.SchemeGroupVersion.WithResource("persistentvolumeclaims")),
+				)))
+				Expect(kvtesting.FilterActions(&virtClient.Fake, "put", "virtualmachines", "removememorydump")).To(HaveLen(1))
+				_, err := kubeClient.CoreV1().PersistentVolumeClaims(metav1.NamespaceDefault).Get(context.Background(), claimName, metav1.GetOptions{})
+				Expect(err).To(MatchError(k8serrors.IsNotFound, "k8serrors.IsNotFound"))
+			})
+
+			It("should keep the claim if the download fails", func() {
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				})
+
+				Expect(runGetCmd(setFlag(memorydump.OutputFileFlag, outputPath))).ToNot(Succeed())
+				Expect(kvtesting.FilterActions(&virtClient.Fake, "put", "virtualmachines", "removememorydump")).To(BeEmpty())
+				_, err := kubeClient.CoreV1().PersistentVolumeClaims(metav1.NamespaceDefault).Get(context.Background(), claimName, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			})
 		})
 
 		It("should call download memory dump", func() {
@@ -28,7 +28,9 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	"sigs.k8s.io/yaml"
 
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -54,6 +56,8 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringToStringVar(&c.labelSelectors, "selector", map[string]string{}, "Label selectors to filter the object graph (multiple labels can be specified).")
 	cmd.Flags().StringVarP(&c.outputFormat, "output", "o", "json", "Output format. One of: json|yaml")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
+	requirements.Subresources(cmd, "virtualmachines/objectgraph")
+	requirements.AnyFeatureGate(cmd, featuregate.ObjectGraph)
 
 	return cmd
 }
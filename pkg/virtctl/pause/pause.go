@@ -27,16 +27,22 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	kubevirtV1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/virtctl/bulk"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 type virtCommand struct {
-	dryRun bool
+	dryRun    bool
+	syncGuest bool
+	bulk      bulk.Options
 }
 
 func NewCommand() *cobra.Command {
@@ -46,60 +52,111 @@ func NewCommand() *cobra.Command {
 		Short: "Pause a virtual machine",
 		Long: `Pauses a virtual machine by freezing it. Machine state is kept in memory.
 First argument is the resource type, possible types are (case insensitive, both singular and plural forms) virtualmachineinstance (vmi) or virtualmachine (vm).
-Second argument is the name of the resource.`,
-		Args:    cobra.ExactArgs(2),
-		Example: usage(),
-		RunE:    c.Run,
+Second argument is the name of the resource. It is omitted when the resources are selected with --selector.`,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completion.KindAndName,
+		Example:           usage(),
+		RunE:              c.Run,
 	}
 
 	cmd.Flags().BoolVar(&c.dryRun, "dry-run", false, "--dry-run=false: Flag used to set whether to perform a dry run or not. If true the command will be executed without performing any changes.")
+	cmd.Flags().BoolVar(&c.syncGuest, "sync-guest", false, "--sync-guest=false: If true, the guest agent flushes and freezes the guest filesystems before the vCPUs are paused. The filesystems are thawed on unpause.")
+	c.bulk.AddFlags(cmd)
 
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
 func usage() string {
-	return "  # Pause a virtualmachine called 'myvm':\n  {{ProgramName}} pause vm myvm"
+	return `  # Pause a virtualmachine called 'myvm':
+  {{ProgramName}} pause vm myvm
+
+  # Pause a virtualmachine called 'myvm' after flushing and freezing its filesystems through the guest agent:
+  {{ProgramName}} pause vm myvm --sync-guest
+
+  # Pause all virtualmachines labeled app=db in all namespaces:
+  {{ProgramName}} pause vm -l app=db --all-namespaces`
 }
 
 func (vc *virtCommand) Run(cmd *cobra.Command, args []string) error {
+	if err := vc.bulk.Validate(len(args) == 2); err != nil {
+		return result.NewUsageError(err)
+	}
 	resourceType := strings.ToLower(args[0])
-	resourceName := args[1]
 
 	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
 	}
 
-	var dryRunOption []string
+	pauseOptions := &kubevirtV1.PauseOptions{SyncGuest: vc.syncGuest}
 	if vc.dryRun {
 		cmd.Println("Dry Run execution")
-		dryRunOption = []string{v1.DryRunAll}
+		pauseOptions.DryRun = []string{v1.DryRunAll}
+	}
+
+	if vc.bulk.Enabled() {
+		return vc.runPauseBulk(cmd, virtClient, namespace, resourceType, pauseOptions)
+	}
+
+	resourceName := args[1]
+	if err := executePauseCMD(virtClient, namespace, resourceType, resourceName, pauseOptions); err != nil {
+		return err
+	}
+	result.RecordChange(cmd.Context(), result.Change{Action: "Pause", Kind: kubevirtV1.VirtualMachineInstanceGroupVersionKind.Kind, Namespace: namespace, Name: resourceName, DryRun: vc.dryRun})
+	return nil
+}
+
+// runPauseBulk pauses the VMIs of all VMs or VMIs matching the label selector.
+func (vc *virtCommand) runPauseBulk(cmd *cobra.Command, client kubecli.KubevirtClient, namespace, resourceType string, pauseOptions *kubevirtV1.PauseOptions) error {
+	var (
+		targets []types.NamespacedName
+		err     error
+	)
+	switch resourceType {
+	case "virtualmachine", "vm":
+		targets, err = vc.bulk.ListVirtualMachines(cmd.Context(), client, namespace)
+	case "virtualmachineinstance", "vmi":
+		targets, err = vc.bulk.ListVirtualMachineInstances(cmd.Context(), client, namespace)
+	default:
+		return result.NewUsageError(fmt.Errorf("unsupported resource type %s", resourceType))
+	}
+	if err != nil {
+		return err
 	}
 
-	return executePauseCMD(virtClient, namespace, resourceType, resourceName, dryRunOption)
+	return vc.bulk.Run(cmd, "pause", targets, func(ctx context.Context, target types.NamespacedName) error {
+		err := client.VirtualMachineInstance(target.Namespace).Pause(ctx, target.Name, pauseOptions)
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("VirtualMachineInstance %s is not running", target.Name)
+		} else if err != nil {
+			return fmt.Errorf("Error pausing VirtualMachineInstance %s: %w", target.Name, err)
+		}
+		result.RecordChange(cmd.Context(), result.Change{Action: "Pause", Kind: kubevirtV1.VirtualMachineInstanceGroupVersionKind.Kind, Namespace: target.Namespace, Name: target.Name, DryRun: vc.dryRun})
+		return nil
+	})
 }
 
-func executePauseCMD(client kubecli.KubevirtClient, namespace, resourceType, resourceName string, dryRunOption []string) error {
+func executePauseCMD(client kubecli.KubevirtClient, namespace, resourceType, resourceName string, pauseOptions *kubevirtV1.PauseOptions) error {
 	switch resourceType {
 	case "virtualmachine", "vm":
 		vm, err := client.VirtualMachine(namespace).Get(context.Background(), resourceName, v1.GetOptions{})
 		if err != nil {
-			return fmt.Errorf("Error getting VirtualMachine %s: %v", resourceName, err)
+			return fmt.Errorf("Error getting VirtualMachine %s: %w", resourceName, err)
 		}
 
-		err = client.VirtualMachineInstance(namespace).Pause(context.Background(), vm.Name, &kubevirtV1.PauseOptions{DryRun: dryRunOption})
+		err = client.VirtualMachineInstance(namespace).Pause(context.Background(), vm.Name, pauseOptions)
 		if errors.IsNotFound(err) {
 			return handleNotFoundError(vm)
 		}
 		if err != nil {
-			return fmt.Errorf("Error pausing VirtualMachineInstance %s: %v", vm.Name, err)
+			return fmt.Errorf("Error pausing VirtualMachineInstance %s: %w", vm.Name, err)
 		}
 
 	case "virtualmachineinstance", "vmi":
-		err := client.VirtualMachineInstance(namespace).Pause(context.Background(), resourceName, &kubevirtV1.PauseOptions{DryRun: dryRunOption})
+		err := client.VirtualMachineInstance(namespace).Pause(context.Background(), resourceName, pauseOptions)
 		if err != nil {
-			return fmt.Errorf("Error pausing VirtualMachineInstance %s: %v", resourceName, err)
+			return fmt.Errorf("Error pausing VirtualMachineInstance %s: %w", resourceName, err)
 		}
 	}
 	fmt.Printf("VMI %s was scheduled to pause\n", resourceName)
@@ -110,7 +167,7 @@ func executePauseCMD(client kubecli.KubevirtClient, namespace, resourceType, res
 func handleNotFoundError(vm *kubevirtV1.VirtualMachine) error {
 	runningStrategy, err := vm.RunStrategy()
 	if err != nil {
-		return fmt.Errorf("Error pausing VirtualMachineInstance %s: %v", vm.Name, err)
+		return fmt.Errorf("Error pausing VirtualMachineInstance %s: %w", vm.Name, err)
 	}
 	if runningStrategy == kubevirtV1.RunStrategyHalted {
 		return fmt.Errorf("Error pausing VirtualMachineInstance %s. VirtualMachine %s is not set to run", vm.Name, vm.Name)
@@ -52,10 +52,14 @@ var _ = Describe("Pausing", func() {
 		if len(pauseOptions.DryRun) > 0 {
 			args = append(args, "--dry-run")
 		}
+		if pauseOptions.SyncGuest {
+			args = append(args, "--sync-guest")
+		}
 		Expect(testing.NewRepeatableVirtctlCommand(args...)()).To(Succeed())
 	},
 		Entry("", &v1.PauseOptions{}),
 		Entry("with dry-run option", &v1.PauseOptions{DryRun: []string{k8smetav1.DryRunAll}}),
+		Entry("with sync-guest option", &v1.PauseOptions{SyncGuest: true}),
 	)
 
 	DescribeTable("should pause VM", func(pauseOptions *v1.PauseOptions) {
@@ -75,9 +79,31 @@ var _ = Describe("Pausing", func() {
 		if len(pauseOptions.DryRun) > 0 {
 			args = append(args, "--dry-run")
 		}
+		if pauseOptions.SyncGuest {
+			args = append(args, "--sync-guest")
+		}
 		Expect(testing.NewRepeatableVirtctlCommand(args...)()).To(Succeed())
 	},
 		Entry("", &v1.PauseOptions{}),
 		Entry("with dry-run option", &v1.PauseOptions{DryRun: []string{k8smetav1.DryRunAll}}),
+		Entry("with sync-guest option", &v1.PauseOptions{SyncGuest: true}),
 	)
+
+	It("should pause the VMIs of all VMs matching the selector", func() {
+		vm1 := kubecli.NewMinimalVM("db1")
+		vm1.Namespace = k8smetav1.NamespaceDefault
+		vm2 := kubecli.NewMinimalVM("db2")
+		vm2.Namespace = k8smetav1.NamespaceDefault
+
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().List(gomock.Any(), k8smetav1.ListOptions{LabelSelector: "app=db"}).
+			Return(&v1.VirtualMachineList{Items: []v1.VirtualMachine{*vm1, *vm2}}, nil).Times(1)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(2)
+		vmiInterface.EXPECT().Pause(gomock.Any(), "db1", &v1.PauseOptions{}).Return(nil).Times(1)
+		vmiInterface.EXPECT().Pause(gomock.Any(), "db2", &v1.PauseOptions{}).Return(nil).Times(1)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut(COMMAND_PAUSE, "vm", "-l", "app=db", "--yes")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(MatchRegexp(`default\s+db1\s+OK\ndefault\s+db2\s+OK\n`))
+	})
 })
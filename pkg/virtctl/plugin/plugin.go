@@ -0,0 +1,113 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package plugin
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+)
+
+const (
+	// Prefix is the prefix of executables on PATH that are run as virtctl subcommands
+	Prefix = "virtctl-"
+
+	// NamespaceEnv is set for plugins to the namespace resolved from the client config
+	NamespaceEnv = "VIRTCTL_NAMESPACE"
+	// KubeconfigEnv is set for plugins to the kubeconfig passed to virtctl
+	KubeconfigEnv = "KUBECONFIG"
+)
+
+// LookPath allows overriding how plugin executables are found (useful for unit testing)
+var LookPath = exec.LookPath
+
+// Find returns the path of the plugin executable for args and the arguments to pass to it.
+// Like kubectl, the longest leading sequence of non-flag arguments naming an executable wins,
+// e.g. `virtctl foo bar-baz` runs `virtctl-foo-bar_baz` or else `virtctl-foo`.
+func Find(args []string) (path string, pluginArgs []string, found bool) {
+	var names []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		names = append(names, strings.ReplaceAll(arg, "-", "_"))
+	}
+
+	for n := len(names); n > 0; n-- {
+		if path, err := LookPath(Prefix + strings.Join(names[:n], "-")); err == nil {
+			return path, args[n:], true
+		}
+	}
+	return "", nil, false
+}
+
+// Handle runs the plugin for args if they do not name a built-in command of rootCmd.
+// It reports whether a plugin was run and the error it failed with.
+func Handle(rootCmd *cobra.Command, args []string) (bool, error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+	switch args[0] {
+	case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return false, nil
+	}
+	if _, _, err := rootCmd.Find(args); err == nil {
+		return false, nil
+	}
+
+	path, pluginArgs, found := Find(args)
+	if !found {
+		return false, nil
+	}
+
+	cmd := exec.Command(path, pluginArgs...)
+	cmd.Stdin = rootCmd.InOrStdin()
+	cmd.Stdout = rootCmd.OutOrStdout()
+	cmd.Stderr = rootCmd.ErrOrStderr()
+	cmd.Env = append(os.Environ(), clientConfigEnv(rootCmd, pluginArgs)...)
+	return true, cmd.Run()
+}
+
+// clientConfigEnv passes the client config virtctl would use on to the plugin.
+// The arguments are passed to the plugin as well, so it can interpret
+// client config flags like --context itself.
+func clientConfigEnv(rootCmd *cobra.Command, pluginArgs []string) []string {
+	flags := rootCmd.PersistentFlags()
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	// Errors are left to the plugin, which receives the same arguments
+	_ = flags.Parse(pluginArgs)
+
+	clientConfig, err := clientconfig.FromContext(rootCmd.Context())
+	if err != nil {
+		return nil
+	}
+
+	var env []string
+	if kubeconfig := clientConfig.ConfigAccess().GetExplicitFile(); kubeconfig != "" {
+		env = append(env, KubeconfigEnv+"="+kubeconfig)
+	}
+	if namespace, _, err := clientConfig.Namespace(); err == nil {
+		env = append(env, NamespaceEnv+"="+namespace)
+	}
+	return env
+}
@@ -0,0 +1,145 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package plugin_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/cobra"
+
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/plugin"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: context
+  context:
+    cluster: cluster
+    namespace: kubeconfig-ns
+current-context: context
+`
+
+var _ = Describe("Plugin", func() {
+	var (
+		pluginDir string
+		rootCmd   *cobra.Command
+		out       *bytes.Buffer
+	)
+
+	addPlugin := func(name, script string) {
+		path := filepath.Join(pluginDir, name)
+		Expect(os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o700)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		pluginDir = GinkgoT().TempDir()
+		GinkgoT().Setenv("PATH", pluginDir)
+		GinkgoT().Setenv("KUBECONFIG", filepath.Join(pluginDir, "nonexistent"))
+
+		rootCmd = &cobra.Command{Use: "virtctl"}
+		rootCmd.AddCommand(&cobra.Command{Use: "start", Run: func(_ *cobra.Command, _ []string) {}})
+		rootCmd.SetContext(clientconfig.NewContext(
+			context.Background(), kubecli.DefaultClientConfig(rootCmd.PersistentFlags()),
+		))
+		out = &bytes.Buffer{}
+		rootCmd.SetOut(out)
+		rootCmd.SetErr(out)
+	})
+
+	DescribeTable("Find should match the longest plugin name", func(args []string, expectedName string, expectedArgs []string) {
+		addPlugin("virtctl-foo", "")
+		addPlugin("virtctl-foo-bar_baz", "")
+
+		path, pluginArgs, found := plugin.Find(args)
+		Expect(found).To(BeTrue())
+		Expect(path).To(Equal(filepath.Join(pluginDir, expectedName)))
+		Expect(pluginArgs).To(Equal(expectedArgs))
+	},
+		Entry("with a single name", []string{"foo", "--flag"}, "virtctl-foo", []string{"--flag"}),
+		Entry("with a nested name", []string{"foo", "bar-baz", "arg"}, "virtctl-foo-bar_baz", []string{"arg"}),
+		Entry("with an argument after the name", []string{"foo", "qux", "bar-baz"}, "virtctl-foo", []string{"qux", "bar-baz"}),
+	)
+
+	It("Find should not match names after flags", func() {
+		addPlugin("virtctl-foo", "")
+
+		_, _, found := plugin.Find([]string{"--namespace", "foo"})
+		Expect(found).To(BeFalse())
+	})
+
+	It("should run the plugin with its arguments and the client config", func() {
+		kubeconfigPath := filepath.Join(pluginDir, "kubeconfig")
+		Expect(os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0o600)).To(Succeed())
+		addPlugin("virtctl-hello", `echo "$@"; echo "$KUBECONFIG $VIRTCTL_NAMESPACE"`)
+
+		handled, err := plugin.Handle(rootCmd, []string{"hello", "world", "--kubeconfig", kubeconfigPath})
+		Expect(handled).To(BeTrue())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out.String()).To(Equal("world --kubeconfig " + kubeconfigPath + "\n" + kubeconfigPath + " kubeconfig-ns\n"))
+	})
+
+	It("should pass the namespace flag to the plugin", func() {
+		addPlugin("virtctl-hello", `echo "$VIRTCTL_NAMESPACE"`)
+
+		handled, err := plugin.Handle(rootCmd, []string{"hello", "-n", "test-ns"})
+		Expect(handled).To(BeTrue())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out.String()).To(Equal("test-ns\n"))
+	})
+
+	It("should return the exit code of the plugin", func() {
+		addPlugin("virtctl-fail", "exit 3")
+
+		handled, err := plugin.Handle(rootCmd, []string{"fail"})
+		Expect(handled).To(BeTrue())
+		var exitErr *exec.ExitError
+		Expect(errors.As(err, &exitErr)).To(BeTrue())
+		Expect(exitErr.ExitCode()).To(Equal(3))
+	})
+
+	DescribeTable("should not run a plugin", func(args ...string) {
+		addPlugin("virtctl-start", "exit 1")
+		addPlugin("virtctl-help", "exit 1")
+
+		handled, err := plugin.Handle(rootCmd, args)
+		Expect(handled).To(BeFalse())
+		Expect(err).ToNot(HaveOccurred())
+	},
+		Entry("without arguments"),
+		Entry("shadowing a built-in command", "start", "my-vm"),
+		Entry("shadowing the help command", "help"),
+		Entry("if none is found", "unknown"),
+	)
+})
@@ -33,6 +33,7 @@ import (
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -67,7 +68,8 @@ func NewCommand() *cobra.Command {
 			}
 			return nil
 		},
-		RunE: c.Run,
+		ValidArgsFunction: completion.Target,
+		RunE:              c.Run,
 	}
 	cmd.Flags().BoolVar(&forwardToStdio, forwardToStdioFlag, forwardToStdio,
 		fmt.Sprintf("--%s=true: Set this to true to forward the tunnel to stdout/stdin; Only works with a single port", forwardToStdioFlag))
@@ -0,0 +1,149 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+// Package requirements records what commands and flags of virtctl need from the
+// connected cluster, so that unsupported commands can be reported upfront.
+package requirements
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+)
+
+const (
+	// SubresourcesAnnotation lists the subresources.kubevirt.io subresources a command calls
+	SubresourcesAnnotation = "virtctl.kubevirt.io/required-subresources"
+	// FeatureGatesAnnotation lists feature gates of which at least one has to be enabled
+	FeatureGatesAnnotation = "virtctl.kubevirt.io/required-feature-gates"
+	// ServerVersionAnnotation is the minimal KubeVirt version a command or flag requires
+	ServerVersionAnnotation = "virtctl.kubevirt.io/required-server-version"
+)
+
+// Subresources marks a command as calling the given subresources, e.g. virtualmachines/objectgraph
+func Subresources(cmd *cobra.Command, subresources ...string) {
+	setAnnotation(cmd, SubresourcesAnnotation, subresources...)
+}
+
+// AnyFeatureGate marks a command as requiring at least one of the given feature gates
+func AnyFeatureGate(cmd *cobra.Command, featureGates ...string) {
+	setAnnotation(cmd, FeatureGatesAnnotation, featureGates...)
+}
+
+// FlagServerVersion marks a flag as requiring at least the given KubeVirt version
+func FlagServerVersion(cmd *cobra.Command, flag, version string) {
+	if err := cmd.Flags().SetAnnotation(flag, ServerVersionAnnotation, []string{version}); err != nil {
+		panic(err)
+	}
+}
+
+func setAnnotation(cmd *cobra.Command, key string, values ...string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[key] = strings.Join(values, ",")
+}
+
+// Cluster describes what the connected cluster provides. Unknown properties are
+// left nil and the requirements depending on them are treated as met.
+type Cluster struct {
+	Version      *semver.Version
+	Subresources map[string]bool
+	FeatureGates map[string]bool
+}
+
+// Incompatibility is a command or flag which will not work against a cluster
+type Incompatibility struct {
+	Command string
+	Reason  string
+}
+
+// Check walks the command tree below root and returns the commands and flags
+// whose requirements are not met by the cluster.
+func Check(root *cobra.Command, cluster *Cluster) []Incompatibility {
+	var incompatibilities []Incompatibility
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, reason := range cluster.unmet(func(key string) []string {
+			if value, exists := cmd.Annotations[key]; exists && value != "" {
+				return strings.Split(value, ",")
+			}
+			return nil
+		}) {
+			incompatibilities = append(incompatibilities, Incompatibility{Command: cmd.CommandPath(), Reason: reason})
+		}
+		cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+			for _, reason := range cluster.unmet(func(key string) []string {
+				return flag.Annotations[key]
+			}) {
+				incompatibilities = append(incompatibilities, Incompatibility{
+					Command: fmt.Sprintf("%s --%s", cmd.CommandPath(), flag.Name),
+					Reason:  reason,
+				})
+			}
+		})
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	sort.SliceStable(incompatibilities, func(i, j int) bool {
+		return incompatibilities[i].Command < incompatibilities[j].Command
+	})
+	return incompatibilities
+}
+
+func (c *Cluster) unmet(annotation func(key string) []string) []string {
+	var reasons []string
+	if versions := annotation(ServerVersionAnnotation); c.Version != nil && len(versions) > 0 {
+		required, err := semver.NewVersion(strings.TrimPrefix(versions[0], "v"))
+		if err == nil && c.Version.LessThan(*required) {
+			reasons = append(reasons, fmt.Sprintf("requires KubeVirt %s or later", versions[0]))
+		}
+	}
+	if c.Subresources != nil {
+		for _, subresource := range annotation(SubresourcesAnnotation) {
+			if !c.Subresources[subresource] {
+				reasons = append(reasons, fmt.Sprintf("subresource %s is not served", subresource))
+			}
+		}
+	}
+	if featureGates := annotation(FeatureGatesAnnotation); c.FeatureGates != nil && len(featureGates) > 0 && !c.anyFeatureGateEnabled(featureGates) {
+		reasons = append(reasons, fmt.Sprintf("requires feature gate %s", strings.Join(featureGates, " or ")))
+	}
+	return reasons
+}
+
+func (c *Cluster) anyFeatureGateEnabled(featureGates []string) bool {
+	for _, name := range featureGates {
+		if c.FeatureGates[name] {
+			return true
+		}
+		if fg := featuregate.FeatureGateInfo(name); fg != nil && fg.State == featuregate.GA {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package requirements_test
+
+import (
+	"github.com/coreos/go-semver/semver"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
+)
+
+var _ = Describe("Requirements", func() {
+	var root *cobra.Command
+
+	BeforeEach(func() {
+		root = &cobra.Command{Use: "virtctl"}
+
+		graph := &cobra.Command{Use: "objectgraph"}
+		requirements.Subresources(graph, "virtualmachines/objectgraph")
+		requirements.AnyFeatureGate(graph, featuregate.ObjectGraph)
+
+		hotplug := &cobra.Command{Use: "addvolume"}
+		hotplug.Flags().Bool("dedicated-iothread", false, "")
+		requirements.AnyFeatureGate(hotplug, featuregate.HotplugVolumesGate, featuregate.DeclarativeHotplugVolumesGate)
+		requirements.FlagServerVersion(hotplug, "dedicated-iothread", "v1.8.0")
+
+		root.AddCommand(graph, hotplug, &cobra.Command{Use: "start"})
+	})
+
+	It("should report the unmet requirements of commands and flags", func() {
+		incompatibilities := requirements.Check(root, &requirements.Cluster{
+			Version:      semver.New("1.7.2"),
+			Subresources: map[string]bool{},
+			FeatureGates: map[string]bool{},
+		})
+		Expect(incompatibilities).To(Equal([]requirements.Incompatibility{
+			{Command: "virtctl addvolume", Reason: "requires feature gate HotplugVolumes or DeclarativeHotplugVolumes"},
+			{Command: "virtctl addvolume --dedicated-iothread", Reason: "requires KubeVirt v1.8.0 or later"},
+			{Command: "virtctl objectgraph", Reason: "subresource virtualmachines/objectgraph is not served"},
+			{Command: "virtctl objectgraph", Reason: "requires feature gate ObjectGraph"},
+		}))
+	})
+
+	It("should report nothing when all requirements are met", func() {
+		Expect(requirements.Check(root, &requirements.Cluster{
+			Version:      semver.New("1.8.0"),
+			Subresources: map[string]bool{"virtualmachines/objectgraph": true},
+			FeatureGates: map[string]bool{
+				featuregate.ObjectGraph:                   true,
+				featuregate.DeclarativeHotplugVolumesGate: true,
+			},
+		})).To(BeEmpty())
+	})
+
+	It("should not check requirements against unknown cluster properties", func() {
+		Expect(requirements.Check(root, &requirements.Cluster{})).To(BeEmpty())
+	})
+})
@@ -26,7 +26,11 @@ import (
 
 	"github.com/spf13/cobra"
 
+	v1 "kubevirt.io/api/core/v1"
+
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -36,11 +40,12 @@ const (
 
 func NewResetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "reset (VMI)",
-		Short:   "Reset a virtual machine instance",
-		Args:    cobra.ExactArgs(1),
-		Example: usage(COMMAND_RESET),
-		RunE:    Run,
+		Use:               "reset (VMI)",
+		Short:             "Reset a virtual machine instance",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		Example:           usage(COMMAND_RESET),
+		RunE:              Run,
 	}
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
@@ -61,9 +66,10 @@ func Run(cmd *cobra.Command, args []string) error {
 	}
 
 	if err = virtClient.VirtualMachineInstance(namespace).Reset(context.Background(), vmi); err != nil {
-		return fmt.Errorf("Error reseting VirtualMachineInstance %s: %v", vmi, err)
+		return fmt.Errorf("Error reseting VirtualMachineInstance %s: %w", vmi, err)
 	}
 
+	result.RecordChange(cmd.Context(), result.Change{Action: "Reset", Kind: v1.VirtualMachineInstanceGroupVersionKind.Kind, Namespace: namespace, Name: vmi})
 	cmd.Printf("VMI %s was scheduled to %s\n", vmi, COMMAND_RESET)
 
 	return nil
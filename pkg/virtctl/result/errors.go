@@ -0,0 +1,146 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package result
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// ErrorClass is a coarse classification of the error a command failed with.
+type ErrorClass string
+
+const (
+	ErrorClassUnknown      ErrorClass = "Unknown"
+	ErrorClassUsage        ErrorClass = "Usage"
+	ErrorClassNotFound     ErrorClass = "NotFound"
+	ErrorClassConflict     ErrorClass = "Conflict"
+	ErrorClassUnauthorized ErrorClass = "Unauthorized"
+	ErrorClassInvalid      ErrorClass = "Invalid"
+	ErrorClassTimeout      ErrorClass = "Timeout"
+	ErrorClassUnavailable  ErrorClass = "Unavailable"
+)
+
+// Exit codes returned by virtctl. They are part of the command line interface
+// and must not be changed.
+const (
+	ExitCodeSuccess      = 0
+	ExitCodeUnknown      = 1
+	ExitCodeUsage        = 2
+	ExitCodeNotFound     = 3
+	ExitCodeConflict     = 4
+	ExitCodeUnauthorized = 5
+	ExitCodeInvalid      = 6
+	ExitCodeTimeout      = 7
+	ExitCodeUnavailable  = 8
+)
+
+var exitCodes = map[ErrorClass]int{
+	ErrorClassUnknown:      ExitCodeUnknown,
+	ErrorClassUsage:        ExitCodeUsage,
+	ErrorClassNotFound:     ExitCodeNotFound,
+	ErrorClassConflict:     ExitCodeConflict,
+	ErrorClassUnauthorized: ExitCodeUnauthorized,
+	ErrorClassInvalid:      ExitCodeInvalid,
+	ErrorClassTimeout:      ExitCodeTimeout,
+	ErrorClassUnavailable:  ExitCodeUnavailable,
+}
+
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+// NewUsageError marks err as caused by invalid arguments or flags.
+func NewUsageError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &usageError{err: err}
+}
+
+type exitCodeError struct {
+	err  error
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// NewExitCodeError makes virtctl terminate with the given exit code, e.g. to
+// propagate the exit code of a command executed on behalf of the user.
+func NewExitCodeError(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitCodeError{err: err, code: code}
+}
+
+// Classify returns the class of the given error. API errors are only
+// recognized if commands wrap them with %w.
+func Classify(err error) ErrorClass {
+	var usageErr *usageError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &usageErr):
+		return ErrorClassUsage
+	case k8serrors.IsNotFound(err):
+		return ErrorClassNotFound
+	case k8serrors.IsAlreadyExists(err), k8serrors.IsConflict(err):
+		return ErrorClassConflict
+	case k8serrors.IsUnauthorized(err), k8serrors.IsForbidden(err):
+		return ErrorClassUnauthorized
+	case k8serrors.IsInvalid(err), k8serrors.IsBadRequest(err):
+		return ErrorClassInvalid
+	case k8serrors.IsTimeout(err), k8serrors.IsServerTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case k8serrors.IsServiceUnavailable(err), utilnet.IsConnectionRefused(err), errors.As(err, &netErr):
+		return ErrorClassUnavailable
+	}
+	return ErrorClassUnknown
+}
+
+// ExitCode returns the exit code virtctl terminates with for the given error.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+	var exitCodeErr *exitCodeError
+	if errors.As(err, &exitCodeErr) {
+		return exitCodeErr.code
+	}
+	return exitCodes[Classify(err)]
+}
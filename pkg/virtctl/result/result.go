@@ -0,0 +1,97 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package result
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// Change describes an object which was created or modified by a command.
+type Change struct {
+	Action    string `json:"action"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	DryRun    bool   `json:"dryRun,omitempty"`
+}
+
+// Result is the machine-readable outcome of a virtctl invocation.
+type Result struct {
+	Command    string     `json:"command"`
+	ExitCode   int        `json:"exitCode"`
+	ErrorClass ErrorClass `json:"errorClass,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Changes    []Change   `json:"changes"`
+}
+
+type recorder struct {
+	lock    sync.Mutex
+	changes []Change
+}
+
+type contextKey struct{}
+
+// NewContext returns a context which collects the changes recorded by a command.
+func NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, &recorder{})
+}
+
+// RecordChange records a change in the given context. It is a no-op if the
+// context was not created by NewContext.
+func RecordChange(ctx context.Context, change Change) {
+	r, ok := ctx.Value(contextKey{}).(*recorder)
+	if !ok {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.changes = append(r.changes, change)
+}
+
+// New builds the result of the command executed with the given context.
+func New(ctx context.Context, command string, err error) *Result {
+	result := &Result{
+		Command:  command,
+		ExitCode: ExitCode(err),
+		Changes:  []Change{},
+	}
+	if err != nil {
+		result.ErrorClass = Classify(err)
+		result.Error = err.Error()
+	}
+	if r, ok := ctx.Value(contextKey{}).(*recorder); ok {
+		r.lock.Lock()
+		result.Changes = append(result.Changes, r.changes...)
+		r.lock.Unlock()
+	}
+	return result
+}
+
+// WriteFile writes the result as JSON to the given path.
+func (r *Result) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
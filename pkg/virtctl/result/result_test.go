@@ -0,0 +1,107 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package result_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+)
+
+var _ = Describe("Result", func() {
+	vmResource := schema.GroupResource{Group: "kubevirt.io", Resource: "virtualmachines"}
+
+	DescribeTable("should classify errors", func(err error, class result.ErrorClass, exitCode int) {
+		Expect(result.Classify(err)).To(Equal(class))
+		Expect(result.ExitCode(err)).To(Equal(exitCode))
+	},
+		Entry("unknown error", errors.New("boom"), result.ErrorClassUnknown, result.ExitCodeUnknown),
+		Entry("usage error", result.NewUsageError(errors.New("accepts 1 arg(s), received 0")), result.ErrorClassUsage, result.ExitCodeUsage),
+		Entry("not found", k8serrors.NewNotFound(vmResource, "testvm"), result.ErrorClassNotFound, result.ExitCodeNotFound),
+		Entry("wrapped not found", fmt.Errorf("error starting VirtualMachine: %w", k8serrors.NewNotFound(vmResource, "testvm")), result.ErrorClassNotFound, result.ExitCodeNotFound),
+		Entry("already exists", k8serrors.NewAlreadyExists(vmResource, "testvm"), result.ErrorClassConflict, result.ExitCodeConflict),
+		Entry("conflict", k8serrors.NewConflict(vmResource, "testvm", errors.New("VM is already running")), result.ErrorClassConflict, result.ExitCodeConflict),
+		Entry("forbidden", k8serrors.NewForbidden(vmResource, "testvm", errors.New("denied")), result.ErrorClassUnauthorized, result.ExitCodeUnauthorized),
+		Entry("unauthorized", k8serrors.NewUnauthorized("no token"), result.ErrorClassUnauthorized, result.ExitCodeUnauthorized),
+		Entry("bad request", k8serrors.NewBadRequest("invalid spec"), result.ErrorClassInvalid, result.ExitCodeInvalid),
+		Entry("timeout", k8serrors.NewTimeoutError("timed out", 1), result.ErrorClassTimeout, result.ExitCodeTimeout),
+		Entry("deadline exceeded", fmt.Errorf("waiting: %w", context.DeadlineExceeded), result.ErrorClassTimeout, result.ExitCodeTimeout),
+		Entry("service unavailable", k8serrors.NewServiceUnavailable("down"), result.ErrorClassUnavailable, result.ExitCodeUnavailable),
+	)
+
+	It("should return the success exit code without an error", func() {
+		Expect(result.ExitCode(nil)).To(Equal(result.ExitCodeSuccess))
+		Expect(result.NewUsageError(nil)).ToNot(HaveOccurred())
+		Expect(result.NewExitCodeError(42, nil)).ToNot(HaveOccurred())
+	})
+
+	It("should propagate the exit code of an exit code error", func() {
+		err := fmt.Errorf("running script: %w", result.NewExitCodeError(42, errors.New("script exited with code 42")))
+		Expect(result.Classify(err)).To(Equal(result.ErrorClassUnknown))
+		Expect(result.ExitCode(err)).To(Equal(42))
+	})
+
+	It("should collect recorded changes", func() {
+		ctx := result.NewContext(context.Background())
+		result.RecordChange(ctx, result.Change{Action: "Start", Kind: "VirtualMachine", Namespace: "default", Name: "testvm"})
+		result.RecordChange(ctx, result.Change{Action: "Stop", Kind: "VirtualMachine", Namespace: "default", Name: "othervm", DryRun: true})
+
+		res := result.New(ctx, "virtctl start", nil)
+		Expect(res.ExitCode).To(Equal(result.ExitCodeSuccess))
+		Expect(res.ErrorClass).To(BeEmpty())
+		Expect(res.Changes).To(Equal([]result.Change{
+			{Action: "Start", Kind: "VirtualMachine", Namespace: "default", Name: "testvm"},
+			{Action: "Stop", Kind: "VirtualMachine", Namespace: "default", Name: "othervm", DryRun: true},
+		}))
+	})
+
+	It("should ignore changes recorded without a result context", func() {
+		ctx := context.Background()
+		result.RecordChange(ctx, result.Change{Action: "Start", Kind: "VirtualMachine", Name: "testvm"})
+
+		res := result.New(ctx, "virtctl start", nil)
+		Expect(res.Changes).To(BeEmpty())
+	})
+
+	It("should write the result as JSON", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "result.json")
+		res := result.New(result.NewContext(context.Background()), "virtctl start", k8serrors.NewNotFound(vmResource, "testvm"))
+		Expect(res.WriteFile(path)).To(Succeed())
+
+		data, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(json.Unmarshal(data, &map[string]interface{}{})).To(Succeed())
+		Expect(string(data)).To(ContainSubstring(`"command": "virtctl start"`))
+		Expect(string(data)).To(ContainSubstring(`"exitCode": 3`))
+		Expect(string(data)).To(ContainSubstring(`"errorClass": "NotFound"`))
+		Expect(string(data)).To(ContainSubstring(`"changes": []`))
+	})
+})
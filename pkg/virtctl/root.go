@@ -2,9 +2,11 @@ package virtctl
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -16,33 +18,53 @@ import (
 	"kubevirt.io/client-go/log"
 	client_version "kubevirt.io/client-go/version"
 
+	"kubevirt.io/kubevirt/pkg/util/errorhints"
 	"kubevirt.io/kubevirt/pkg/virtctl/adm"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/clone"
+	"kubevirt.io/kubevirt/pkg/virtctl/config"
 	"kubevirt.io/kubevirt/pkg/virtctl/configuration"
 	"kubevirt.io/kubevirt/pkg/virtctl/console"
+	"kubevirt.io/kubevirt/pkg/virtctl/crashdump"
 	"kubevirt.io/kubevirt/pkg/virtctl/create"
 	"kubevirt.io/kubevirt/pkg/virtctl/credentials"
+	"kubevirt.io/kubevirt/pkg/virtctl/datasource"
+	"kubevirt.io/kubevirt/pkg/virtctl/diff"
+	"kubevirt.io/kubevirt/pkg/virtctl/events"
+	"kubevirt.io/kubevirt/pkg/virtctl/explain"
+	"kubevirt.io/kubevirt/pkg/virtctl/explainmigratability"
 	"kubevirt.io/kubevirt/pkg/virtctl/expose"
+	"kubevirt.io/kubevirt/pkg/virtctl/freeze"
+	"kubevirt.io/kubevirt/pkg/virtctl/get"
+	"kubevirt.io/kubevirt/pkg/virtctl/guest"
 	"kubevirt.io/kubevirt/pkg/virtctl/guestfs"
 	"kubevirt.io/kubevirt/pkg/virtctl/imageupload"
 	"kubevirt.io/kubevirt/pkg/virtctl/memorydump"
 	"kubevirt.io/kubevirt/pkg/virtctl/objectgraph"
 	"kubevirt.io/kubevirt/pkg/virtctl/pause"
+	"kubevirt.io/kubevirt/pkg/virtctl/plugin"
 	"kubevirt.io/kubevirt/pkg/virtctl/portforward"
 	"kubevirt.io/kubevirt/pkg/virtctl/reset"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/scp"
+	"kubevirt.io/kubevirt/pkg/virtctl/snapshot"
 	"kubevirt.io/kubevirt/pkg/virtctl/softreboot"
 	"kubevirt.io/kubevirt/pkg/virtctl/ssh"
+	"kubevirt.io/kubevirt/pkg/virtctl/status"
 	"kubevirt.io/kubevirt/pkg/virtctl/template"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 	"kubevirt.io/kubevirt/pkg/virtctl/unpause"
 	"kubevirt.io/kubevirt/pkg/virtctl/usbredir"
+	"kubevirt.io/kubevirt/pkg/virtctl/validate"
 	"kubevirt.io/kubevirt/pkg/virtctl/version"
 	"kubevirt.io/kubevirt/pkg/virtctl/vm"
 	"kubevirt.io/kubevirt/pkg/virtctl/vmexport"
 	"kubevirt.io/kubevirt/pkg/virtctl/vnc"
+	"kubevirt.io/kubevirt/pkg/virtctl/wait"
 )
 
+const resultFileFlag = "result-file"
+
 var (
 	NewVirtctlCommand = NewVirtctlCommandFn
 
@@ -94,13 +116,21 @@ func NewVirtctlCommandFn() *cobra.Command {
 		Run: func(cmd *cobra.Command, _ []string) {
 			cmd.Printf("%s", cmd.UsageString())
 		},
+		// Default flags from the virtctl configuration file of the current kubeconfig context
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return config.Apply(cmd)
+		},
 	}
 	addVerbosityFlag(rootCmd.PersistentFlags())
+	rootCmd.PersistentFlags().String(resultFileFlag, "", "If set, write a JSON document describing the outcome of the command, the changed objects and the class of a possible error to this file.")
 	rootCmd.SetUsageTemplate(templates.MainUsageTemplate())
 	rootCmd.SetOut(os.Stdout)
-	rootCmd.SetContext(clientconfig.NewContext(
+	rootCmd.SetContext(result.NewContext(clientconfig.NewContext(
 		context.Background(), kubecli.DefaultClientConfig(rootCmd.PersistentFlags()),
-	))
+	)))
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return result.NewUsageError(err)
+	})
 
 	rootCmd.AddCommand(
 		configuration.NewListPermittedDevices(),
@@ -109,27 +139,46 @@ func NewVirtctlCommandFn() *cobra.Command {
 		vnc.NewCommand(),
 		scp.NewCommand(),
 		ssh.NewCommand(),
+		ssh.NewKeyscanCommand(),
 		portforward.NewCommand(),
 		vm.NewStartCommand(),
 		vm.NewStopCommand(),
 		vm.NewRestartCommand(),
 		vm.NewMigrateCommand(),
 		vm.NewMigrateCancelCommand(),
+		explainmigratability.NewCommand(),
+		status.NewCommand(),
+		wait.NewCommand(),
+		events.NewCommand(),
+		explain.NewCommand(),
+		diff.NewCommand(),
+		snapshot.NewCommand(),
+		clone.NewCommand(),
+		get.NewCommand(),
+		validate.NewCommand(),
 		vm.NewGuestOsInfoCommand(),
 		vm.NewUserListCommand(),
 		vm.NewFSListCommand(),
+		guest.NewCommand(),
 		vm.NewAddVolumeCommand(),
+		vm.NewAddInterfaceCommand(),
+		vm.NewRemoveInterfaceCommand(),
 		vm.NewRemoveVolumeCommand(),
 		vm.NewExpandCommand(),
 		vm.NewEvacuateCancelCommand(),
+		vm.NewRenameCommand(),
 		memorydump.NewMemoryDumpCommand(),
 		pause.NewCommand(),
 		unpause.NewCommand(),
+		freeze.NewFreezeCommand(),
+		freeze.NewUnfreezeCommand(),
 		softreboot.NewSoftRebootCommand(),
+		crashdump.NewCommand(),
 		reset.NewResetCommand(),
 		expose.NewCommand(),
 		version.VersionCommand(),
 		imageupload.NewImageUploadCommand(),
+		datasource.NewCommand(),
 		guestfs.NewGuestfsShellCommand(),
 		vmexport.NewVirtualMachineExportCommand(),
 		create.NewCommand(),
@@ -139,10 +188,24 @@ func NewVirtctlCommandFn() *cobra.Command {
 		template.NewCommand(),
 		optionsCmd,
 	)
+	markArgsErrorsAsUsageErrors(rootCmd)
 
 	return rootCmd
 }
 
+// markArgsErrorsAsUsageErrors wraps the positional argument validation of all
+// commands so that violations result in the usage exit code.
+func markArgsErrorsAsUsageErrors(cmd *cobra.Command) {
+	if validateArgs := cmd.Args; validateArgs != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			return result.NewUsageError(validateArgs(cmd, args))
+		}
+	}
+	for _, subCmd := range cmd.Commands() {
+		markArgsErrorsAsUsageErrors(subCmd)
+	}
+}
+
 func addVerbosityFlag(fs *pflag.FlagSet) {
 	// The verbosity flag is added to the default flag set
 	// by init() in staging/src/kubevirt.io/client-go/log/log.go.
@@ -157,14 +220,42 @@ func addVerbosityFlag(fs *pflag.FlagSet) {
 func Execute() int {
 	log.InitializeLogging(programName)
 	cmd := NewVirtctlCommand()
-	if err := cmd.Execute(); err != nil {
+	if handled, err := plugin.Handle(cmd, os.Args[1:]); handled {
+		return pluginExitCode(cmd, err)
+	}
+
+	executedCmd, err := cmd.ExecuteC()
+	if err != nil {
 		if versionErr := checkClientServerVersion(cmd.Context()); versionErr != nil {
 			cmd.PrintErrln(versionErr)
 		}
 		cmd.PrintErrln(err)
-		return 1
+		if hint := errorhints.Hint(err.Error()); hint != "" {
+			cmd.PrintErrln(hint)
+		}
+	}
+
+	res := result.New(cmd.Context(), executedCmd.CommandPath(), err)
+	if resultFile, _ := cmd.PersistentFlags().GetString(resultFileFlag); resultFile != "" {
+		if writeErr := res.WriteFile(resultFile); writeErr != nil {
+			cmd.PrintErrln(fmt.Errorf("failed to write result file: %w", writeErr))
+		}
+	}
+	return res.ExitCode
+}
+
+// pluginExitCode passes the exit code of a plugin on to the caller.
+// Plugins report their own errors, only failures to run them are printed.
+func pluginExitCode(cmd *cobra.Command, err error) int {
+	if err == nil {
+		return result.ExitCodeSuccess
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return exitErr.ExitCode()
 	}
-	return 0
+	cmd.PrintErrln(err)
+	return result.ExitCodeUnknown
 }
 
 func checkClientServerVersion(ctx context.Context) error {
@@ -3,8 +3,12 @@ package virtctl_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"runtime"
 	"time"
 
@@ -15,11 +19,15 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/version"
 
 	"kubevirt.io/kubevirt/pkg/virtctl"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/testing"
 )
 
@@ -80,4 +88,93 @@ var _ = Describe("virtctl", func() {
 		Expect(out.String()).To(ContainSubstring(testError))
 		Expect(out.String()).To(ContainSubstring("You are using a client virtctl version that is different from the KubeVirt version running in the cluster"))
 	})
+
+	Context("with a result file", func() {
+		const vmName = "testvm"
+
+		var (
+			vmInterface *kubecli.MockVirtualMachineInterface
+			resultFile  string
+		)
+
+		BeforeEach(func() {
+			ctrl := gomock.NewController(GinkgoT())
+			serverVersionInterface := kubecli.NewMockServerVersionInterface(ctrl)
+			serverVersionInterface.EXPECT().Get().Return(nil, errors.New("no server version")).AnyTimes()
+			kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+			kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+			kubecli.MockKubevirtClientInstance.EXPECT().ServerVersion().Return(serverVersionInterface).AnyTimes()
+			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(metav1.NamespaceDefault).Return(vmInterface).AnyTimes()
+
+			resultFile = filepath.Join(GinkgoT().TempDir(), "result.json")
+		})
+
+		execute := func(args ...string) int {
+			virtctl.NewVirtctlCommand = func() *cobra.Command {
+				cmd := virtctl.NewVirtctlCommandFn()
+				cmd.SetArgs(append([]string{"--result-file", resultFile}, args...))
+				cmd.SetErr(io.Discard)
+				return cmd
+			}
+			DeferCleanup(func() {
+				virtctl.NewVirtctlCommand = virtctl.NewVirtctlCommandFn
+			})
+			return virtctl.Execute()
+		}
+
+		readResult := func() *result.Result {
+			data, err := os.ReadFile(resultFile)
+			Expect(err).ToNot(HaveOccurred())
+			res := &result.Result{}
+			Expect(json.Unmarshal(data, res)).To(Succeed())
+			return res
+		}
+
+		It("should record the changed objects on success", func() {
+			vmInterface.EXPECT().Start(gomock.Any(), vmName, gomock.Any()).Return(nil)
+
+			Expect(execute("start", vmName)).To(Equal(result.ExitCodeSuccess))
+			res := readResult()
+			Expect(res.Command).To(Equal("virtctl start"))
+			Expect(res.ExitCode).To(Equal(result.ExitCodeSuccess))
+			Expect(res.ErrorClass).To(BeEmpty())
+			Expect(res.Changes).To(ConsistOf(result.Change{
+				Action:    "Start",
+				Kind:      "VirtualMachine",
+				Namespace: metav1.NamespaceDefault,
+				Name:      vmName,
+			}))
+		})
+
+		It("should record the changes of commands submitting subresource requests", func() {
+			vmInterface.EXPECT().RemoveMemoryDump(gomock.Any(), vmName).Return(nil)
+
+			Expect(execute("memory-dump", "remove", vmName)).To(Equal(result.ExitCodeSuccess))
+			Expect(readResult().Changes).To(ConsistOf(result.Change{
+				Action:    "RemoveMemoryDump",
+				Kind:      "VirtualMachine",
+				Namespace: metav1.NamespaceDefault,
+				Name:      vmName,
+			}))
+		})
+
+		It("should classify API errors", func() {
+			vmInterface.EXPECT().Start(gomock.Any(), vmName, gomock.Any()).Return(k8serrors.NewNotFound(v1.Resource("virtualmachine"), vmName))
+
+			Expect(execute("start", vmName)).To(Equal(result.ExitCodeNotFound))
+			res := readResult()
+			Expect(res.ErrorClass).To(Equal(result.ErrorClassNotFound))
+			Expect(res.Error).To(ContainSubstring("not found"))
+			Expect(res.Changes).To(BeEmpty())
+		})
+
+		DescribeTable("should classify usage errors", func(args ...string) {
+			Expect(execute(args...)).To(Equal(result.ExitCodeUsage))
+			Expect(readResult().ErrorClass).To(Equal(result.ErrorClassUsage))
+		},
+			Entry("with missing arguments", "start"),
+			Entry("with an unknown flag", "start", vmName, "--unknown"),
+		)
+	})
 })
@@ -0,0 +1,149 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+type createCommand struct {
+	name    string
+	wait    bool
+	timeout time.Duration
+}
+
+func newCreateCommand() *cobra.Command {
+	c := createCommand{}
+	cmd := &cobra.Command{
+		Use:               "create (VM)",
+		Short:             "Create a snapshot of a VirtualMachine.",
+		Example:           createUsage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.run,
+	}
+	cmd.Flags().StringVar(&c.name, nameFlag, "", "Name of the VirtualMachineSnapshot. Defaults to '<vm>-snapshot-<timestamp>'.")
+	addWaitFlags(cmd, &c.wait, &c.timeout, "the snapshot is ready to use")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func createUsage() string {
+	return `  # Create a snapshot of a VirtualMachine named 'my-vm'
+  {{ProgramName}} snapshot create my-vm
+
+  # Create a snapshot named 'my-snapshot' and wait until it is ready to use
+  {{ProgramName}} snapshot create my-vm --name=my-snapshot --wait`
+}
+
+func (c *createCommand) run(cmd *cobra.Command, args []string) error {
+	if err := validateTimeout(c.timeout); err != nil {
+		return result.NewUsageError(err)
+	}
+	vmName := args[0]
+	name := c.name
+	if name == "" {
+		name = generateName(vmName, "snapshot")
+	}
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	snapshot := &snapshotv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: snapshotv1.VirtualMachineSnapshotSpec{
+			Source: vmReference(vmName),
+		},
+	}
+	if _, err := virtClient.VirtualMachineSnapshot(namespace).Create(cmd.Context(), snapshot, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating VirtualMachineSnapshot %s: %w", name, err)
+	}
+	result.RecordChange(cmd.Context(), result.Change{
+		Action:    "Create",
+		Kind:      snapshotKind,
+		Namespace: namespace,
+		Name:      name,
+	})
+	cmd.Printf("VirtualMachineSnapshot %s of VirtualMachine %s created\n", name, vmName)
+
+	if !c.wait {
+		return nil
+	}
+	if err := waitForSnapshot(cmd, virtClient, namespace, name, c.timeout); err != nil {
+		return err
+	}
+	cmd.Printf("VirtualMachineSnapshot %s is ready to use\n", name)
+	return nil
+}
+
+func waitForSnapshot(cmd *cobra.Command, virtClient kubecli.KubevirtClient, namespace, name string, timeout time.Duration) error {
+	progress := &progressPrinter{out: cmd.OutOrStdout()}
+	err := virtwait.PollImmediately(pollInterval, timeout, func(ctx context.Context) (bool, error) {
+		snapshot, err := virtClient.VirtualMachineSnapshot(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("error getting VirtualMachineSnapshot %s: %w", name, err)
+		}
+		if snapshot.Status == nil {
+			progress.report(snapshotKind, name, "Pending")
+			return false, nil
+		}
+		if snapshot.Status.Phase == snapshotv1.Failed {
+			return false, fmt.Errorf("VirtualMachineSnapshot %s failed: %s", name, snapshotProgress(snapshot))
+		}
+		progress.report(snapshotKind, name, snapshotProgress(snapshot))
+		return snapshot.Status.ReadyToUse != nil && *snapshot.Status.ReadyToUse, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for VirtualMachineSnapshot %s: %w", name, err)
+	}
+	return nil
+}
+
+func snapshotProgress(snapshot *snapshotv1.VirtualMachineSnapshot) string {
+	phase := string(snapshot.Status.Phase)
+	if phase == "" {
+		phase = "Pending"
+	}
+	if snapshot.Status.Error != nil && snapshot.Status.Error.Message != nil {
+		return fmt.Sprintf("%s (%s)", phase, *snapshot.Status.Error.Message)
+	}
+	if condition := findCondition(snapshot.Status.Conditions, snapshotv1.ConditionProgressing); condition != nil && condition.Reason != "" {
+		return fmt.Sprintf("%s (%s)", phase, condition.Reason)
+	}
+	return phase
+}
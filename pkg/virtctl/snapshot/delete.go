@@ -0,0 +1,156 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const allFlag = "all"
+
+type deleteCommand struct {
+	all     bool
+	wait    bool
+	timeout time.Duration
+}
+
+func newDeleteCommand() *cobra.Command {
+	c := deleteCommand{}
+	cmd := &cobra.Command{
+		Use:               "delete (VM) [SNAPSHOT...]",
+		Short:             "Delete snapshots of a VirtualMachine.",
+		Example:           deleteUsage(),
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.run,
+	}
+	cmd.Flags().BoolVar(&c.all, allFlag, false, "Delete all snapshots of the VirtualMachine.")
+	addWaitFlags(cmd, &c.wait, &c.timeout, "the snapshots are removed")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func deleteUsage() string {
+	return `  # Delete the snapshot 'my-snapshot' of a VirtualMachine named 'my-vm'
+  {{ProgramName}} snapshot delete my-vm my-snapshot
+
+  # Delete all snapshots of a VirtualMachine named 'my-vm' and wait until they are removed
+  {{ProgramName}} snapshot delete my-vm --all --wait`
+}
+
+func (c *deleteCommand) run(cmd *cobra.Command, args []string) error {
+	if err := validateTimeout(c.timeout); err != nil {
+		return result.NewUsageError(err)
+	}
+	vmName, names := args[0], args[1:]
+	if c.all && len(names) > 0 {
+		return result.NewUsageError(fmt.Errorf("snapshot names must not be specified together with --%s", allFlag))
+	}
+	if !c.all && len(names) == 0 {
+		return result.NewUsageError(fmt.Errorf("at least one snapshot name or --%s must be specified", allFlag))
+	}
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if c.all {
+		snapshots, err := listSnapshots(cmd.Context(), virtClient, namespace, vmName)
+		if err != nil {
+			return err
+		}
+		for _, snapshot := range snapshots {
+			names = append(names, snapshot.Name)
+		}
+	} else {
+		// Verify all snapshots before deleting any of them, so that a typo
+		// does not leave the operation half done.
+		for _, name := range names {
+			snapshot, err := virtClient.VirtualMachineSnapshot(namespace).Get(cmd.Context(), name, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("error getting VirtualMachineSnapshot %s: %w", name, err)
+			}
+			if !isSnapshotOf(snapshot, vmName) {
+				return fmt.Errorf("VirtualMachineSnapshot %s is not a snapshot of VirtualMachine %s", name, vmName)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		cmd.Printf("No snapshots found for VirtualMachine %s\n", vmName)
+		return nil
+	}
+
+	for _, name := range names {
+		err := virtClient.VirtualMachineSnapshot(namespace).Delete(cmd.Context(), name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("error deleting VirtualMachineSnapshot %s: %w", name, err)
+		}
+		result.RecordChange(cmd.Context(), result.Change{
+			Action:    "Delete",
+			Kind:      snapshotKind,
+			Namespace: namespace,
+			Name:      name,
+		})
+		cmd.Printf("VirtualMachineSnapshot %s deleted\n", name)
+	}
+
+	if !c.wait {
+		return nil
+	}
+	for _, name := range names {
+		if err := waitForSnapshotRemoval(virtClient, namespace, name, c.timeout); err != nil {
+			return err
+		}
+	}
+	cmd.Println("All deleted VirtualMachineSnapshots are removed")
+	return nil
+}
+
+func waitForSnapshotRemoval(virtClient kubecli.KubevirtClient, namespace, name string, timeout time.Duration) error {
+	err := virtwait.PollImmediately(pollInterval, timeout, func(ctx context.Context) (bool, error) {
+		_, err := virtClient.VirtualMachineSnapshot(namespace).Get(ctx, name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("error getting VirtualMachineSnapshot %s: %w", name, err)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for removal of VirtualMachineSnapshot %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+func newListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list (VM)",
+		Short:             "List the snapshots of a VirtualMachine.",
+		Example:           listUsage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              runList,
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func listUsage() string {
+	return `  # List the snapshots of a VirtualMachine named 'my-vm'
+  {{ProgramName}} snapshot list my-vm`
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	vmName := args[0]
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := listSnapshots(cmd.Context(), virtClient, namespace, vmName)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		cmd.Printf("No snapshots found for VirtualMachine %s\n", vmName)
+		return nil
+	}
+	printSnapshots(cmd.OutOrStdout(), snapshots)
+	return nil
+}
+
+func listSnapshots(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, vmName string) ([]snapshotv1.VirtualMachineSnapshot, error) {
+	list, err := virtClient.VirtualMachineSnapshot(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing VirtualMachineSnapshots in namespace %s: %w", namespace, err)
+	}
+
+	var snapshots []snapshotv1.VirtualMachineSnapshot
+	for i := range list.Items {
+		if isSnapshotOf(&list.Items[i], vmName) {
+			snapshots = append(snapshots, list.Items[i])
+		}
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CreationTimestamp.Before(&snapshots[j].CreationTimestamp)
+	})
+	return snapshots, nil
+}
+
+func printSnapshots(out io.Writer, snapshots []snapshotv1.VirtualMachineSnapshot) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPHASE\tREADYTOUSE\tCREATIONTIME\tERROR")
+	for _, snapshot := range snapshots {
+		phase, readyToUse, creationTime, errorMessage := none, "false", none, none
+		if status := snapshot.Status; status != nil {
+			if status.Phase != "" {
+				phase = string(status.Phase)
+			}
+			if status.ReadyToUse != nil {
+				readyToUse = strconv.FormatBool(*status.ReadyToUse)
+			}
+			if status.CreationTime != nil {
+				creationTime = status.CreationTime.UTC().Format(time.RFC3339)
+			}
+			if status.Error != nil && status.Error.Message != nil {
+				errorMessage = *status.Error.Message
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", snapshot.Name, phase, readyToUse, creationTime, errorMessage)
+	}
+	w.Flush()
+}
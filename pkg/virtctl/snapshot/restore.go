@@ -0,0 +1,156 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+type restoreCommand struct {
+	name    string
+	wait    bool
+	timeout time.Duration
+}
+
+func newRestoreCommand() *cobra.Command {
+	c := restoreCommand{}
+	cmd := &cobra.Command{
+		Use:               "restore (VM) (SNAPSHOT)",
+		Short:             "Restore a VirtualMachine from one of its snapshots.",
+		Long:              "Restore a VirtualMachine from one of its snapshots. The VirtualMachine has to be stopped before it can be restored.",
+		Example:           restoreUsage(),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.run,
+	}
+	cmd.Flags().StringVar(&c.name, nameFlag, "", "Name of the VirtualMachineRestore. Defaults to '<vm>-restore-<timestamp>'.")
+	addWaitFlags(cmd, &c.wait, &c.timeout, "the restore is complete")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func restoreUsage() string {
+	return `  # Restore a VirtualMachine named 'my-vm' from the snapshot 'my-snapshot'
+  {{ProgramName}} snapshot restore my-vm my-snapshot
+
+  # Restore a VirtualMachine named 'my-vm' from the snapshot 'my-snapshot' and wait until the restore is complete
+  {{ProgramName}} snapshot restore my-vm my-snapshot --wait`
+}
+
+func (c *restoreCommand) run(cmd *cobra.Command, args []string) error {
+	if err := validateTimeout(c.timeout); err != nil {
+		return result.NewUsageError(err)
+	}
+	vmName, snapshotName := args[0], args[1]
+	name := c.name
+	if name == "" {
+		name = generateName(vmName, "restore")
+	}
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := virtClient.VirtualMachineSnapshot(namespace).Get(cmd.Context(), snapshotName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting VirtualMachineSnapshot %s: %w", snapshotName, err)
+	}
+	if snapshot.Status == nil || snapshot.Status.ReadyToUse == nil || !*snapshot.Status.ReadyToUse {
+		return fmt.Errorf("VirtualMachineSnapshot %s is not ready to use", snapshotName)
+	}
+
+	restore := &snapshotv1.VirtualMachineRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: snapshotv1.VirtualMachineRestoreSpec{
+			Target:                     vmReference(vmName),
+			VirtualMachineSnapshotName: snapshotName,
+		},
+	}
+	if _, err := virtClient.VirtualMachineRestore(namespace).Create(cmd.Context(), restore, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating VirtualMachineRestore %s: %w", name, err)
+	}
+	result.RecordChange(cmd.Context(), result.Change{
+		Action:    "Create",
+		Kind:      restoreKind,
+		Namespace: namespace,
+		Name:      name,
+	})
+	cmd.Printf("VirtualMachineRestore %s of VirtualMachine %s from VirtualMachineSnapshot %s created\n", name, vmName, snapshotName)
+
+	if !c.wait {
+		return nil
+	}
+	if err := waitForRestore(cmd, virtClient, namespace, name, c.timeout); err != nil {
+		return err
+	}
+	cmd.Printf("VirtualMachineRestore %s is complete\n", name)
+	return nil
+}
+
+func waitForRestore(cmd *cobra.Command, virtClient kubecli.KubevirtClient, namespace, name string, timeout time.Duration) error {
+	progress := &progressPrinter{out: cmd.OutOrStdout()}
+	err := virtwait.PollImmediately(pollInterval, timeout, func(ctx context.Context) (bool, error) {
+		restore, err := virtClient.VirtualMachineRestore(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("error getting VirtualMachineRestore %s: %w", name, err)
+		}
+		if restore.Status == nil {
+			progress.report(restoreKind, name, "Pending")
+			return false, nil
+		}
+		if condition := findCondition(restore.Status.Conditions, snapshotv1.ConditionFailure); condition != nil && condition.Status == k8sv1.ConditionTrue {
+			return false, fmt.Errorf("VirtualMachineRestore %s failed: %s", name, condition.Reason)
+		}
+		if restore.Status.Complete != nil && *restore.Status.Complete {
+			return true, nil
+		}
+		progress.report(restoreKind, name, restoreProgress(restore))
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for VirtualMachineRestore %s: %w", name, err)
+	}
+	return nil
+}
+
+func restoreProgress(restore *snapshotv1.VirtualMachineRestore) string {
+	if condition := findCondition(restore.Status.Conditions, snapshotv1.ConditionProgressing); condition != nil && condition.Reason != "" {
+		return fmt.Sprintf("InProgress (%s)", condition.Reason)
+	}
+	return "InProgress"
+}
@@ -0,0 +1,122 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	v1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	nameFlag    = "name"
+	waitFlag    = "wait"
+	timeoutFlag = "timeout"
+
+	defaultTimeout = 5 * time.Minute
+	pollInterval   = time.Second
+
+	timestampFormat = "20060102150405"
+
+	snapshotKind = "VirtualMachineSnapshot"
+	restoreKind  = "VirtualMachineRestore"
+
+	none = "<none>"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Create, restore, list and delete snapshots of a VirtualMachine.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(
+		newCreateCommand(),
+		newRestoreCommand(),
+		newListCommand(),
+		newDeleteCommand(),
+	)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func addWaitFlags(cmd *cobra.Command, wait *bool, timeout *time.Duration, what string) {
+	cmd.Flags().BoolVar(wait, waitFlag, false, fmt.Sprintf("Wait until %s and report progress while waiting.", what))
+	cmd.Flags().DurationVar(timeout, timeoutFlag, defaultTimeout, "The maximum time to wait when --wait is set.")
+}
+
+func validateTimeout(timeout time.Duration) error {
+	if timeout <= 0 {
+		return fmt.Errorf("the timeout must be greater than zero")
+	}
+	return nil
+}
+
+func vmReference(vmName string) k8sv1.TypedLocalObjectReference {
+	return k8sv1.TypedLocalObjectReference{
+		APIGroup: pointer.P(v1.SchemeGroupVersion.Group),
+		Kind:     v1.VirtualMachineGroupVersionKind.Kind,
+		Name:     vmName,
+	}
+}
+
+func isSnapshotOf(snapshot *snapshotv1.VirtualMachineSnapshot, vmName string) bool {
+	return snapshot.Spec.Source.Kind == v1.VirtualMachineGroupVersionKind.Kind &&
+		snapshot.Spec.Source.Name == vmName
+}
+
+func generateName(vmName, kind string) string {
+	return fmt.Sprintf("%s-%s-%s", vmName, kind, time.Now().UTC().Format(timestampFormat))
+}
+
+func findCondition(conditions []snapshotv1.Condition, conditionType snapshotv1.ConditionType) *snapshotv1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// progressPrinter prints a line whenever the reported progress of an
+// operation changes, so that polling does not repeat identical output.
+type progressPrinter struct {
+	out  io.Writer
+	last string
+}
+
+func (p *progressPrinter) report(kind, name, progress string) {
+	if progress == p.last {
+		return
+	}
+	p.last = progress
+	fmt.Fprintf(p.out, "%s %s: %s\n", kind, name, progress)
+}
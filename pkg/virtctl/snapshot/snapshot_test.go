@@ -0,0 +1,283 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package snapshot_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Snapshot command", func() {
+	const (
+		vmName       = "testvm"
+		snapshotName = "testsnapshot"
+		restoreName  = "testrestore"
+	)
+
+	var virtClient *kubevirtfake.Clientset
+
+	BeforeEach(func() {
+		virtClient = kubevirtfake.NewSimpleClientset()
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineSnapshot(metav1.NamespaceDefault).
+			Return(virtClient.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineRestore(metav1.NamespaceDefault).
+			Return(virtClient.SnapshotV1beta1().VirtualMachineRestores(metav1.NamespaceDefault)).AnyTimes()
+	})
+
+	newSnapshot := func(name, vmName string, readyToUse bool) *snapshotv1.VirtualMachineSnapshot {
+		return &snapshotv1.VirtualMachineSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: snapshotv1.VirtualMachineSnapshotSpec{
+				Source: k8sv1.TypedLocalObjectReference{
+					APIGroup: pointer.P("kubevirt.io"),
+					Kind:     "VirtualMachine",
+					Name:     vmName,
+				},
+			},
+			Status: &snapshotv1.VirtualMachineSnapshotStatus{
+				Phase:      snapshotv1.Succeeded,
+				ReadyToUse: pointer.P(readyToUse),
+			},
+		}
+	}
+
+	createSnapshot := func(snapshot *snapshotv1.VirtualMachineSnapshot) {
+		_, err := virtClient.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault).
+			Create(context.Background(), snapshot, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getSnapshot := func(name string) (*snapshotv1.VirtualMachineSnapshot, error) {
+		return virtClient.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault).
+			Get(context.Background(), name, metav1.GetOptions{})
+	}
+
+	Context("create", func() {
+		It("should create a snapshot of the VM", func() {
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("snapshot", "create", vmName, "--name", snapshotName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("VirtualMachineSnapshot testsnapshot of VirtualMachine testvm created"))
+
+			snapshot, err := getSnapshot(snapshotName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshot.Spec.Source.Kind).To(Equal("VirtualMachine"))
+			Expect(snapshot.Spec.Source.Name).To(Equal(vmName))
+			Expect(snapshot.Spec.Source.APIGroup).To(HaveValue(Equal("kubevirt.io")))
+		})
+
+		It("should generate a snapshot name if none is given", func() {
+			Expect(testing.NewRepeatableVirtctlCommand("snapshot", "create", vmName)()).To(Succeed())
+
+			snapshots, err := virtClient.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault).
+				List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshots.Items).To(HaveLen(1))
+			Expect(snapshots.Items[0].Name).To(HavePrefix(vmName + "-snapshot-"))
+		})
+
+		It("should wait and report progress until the snapshot is ready to use", func() {
+			gets := 0
+			virtClient.PrependReactor("get", "virtualmachinesnapshots", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+				gets++
+				snapshot := newSnapshot(snapshotName, vmName, gets > 1)
+				if gets == 1 {
+					snapshot.Status.Phase = snapshotv1.InProgress
+					snapshot.Status.Conditions = []snapshotv1.Condition{{
+						Type:   snapshotv1.ConditionProgressing,
+						Status: k8sv1.ConditionTrue,
+						Reason: "Source locked and operation in progress",
+					}}
+				}
+				return true, snapshot, nil
+			})
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("snapshot", "create", vmName, "--name", snapshotName, "--wait")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("VirtualMachineSnapshot testsnapshot: InProgress (Source locked and operation in progress)"))
+			Expect(string(out)).To(ContainSubstring("VirtualMachineSnapshot testsnapshot: Succeeded"))
+			Expect(string(out)).To(ContainSubstring("VirtualMachineSnapshot testsnapshot is ready to use"))
+		})
+
+		It("should fail when waiting for a failed snapshot", func() {
+			virtClient.PrependReactor("get", "virtualmachinesnapshots", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+				snapshot := newSnapshot(snapshotName, vmName, false)
+				snapshot.Status.Phase = snapshotv1.Failed
+				snapshot.Status.Error = &snapshotv1.Error{Message: pointer.P("snapshot deadline exceeded")}
+				return true, snapshot, nil
+			})
+
+			err := testing.NewRepeatableVirtctlCommand("snapshot", "create", vmName, "--name", snapshotName, "--wait")()
+			Expect(err).To(MatchError(ContainSubstring("VirtualMachineSnapshot testsnapshot failed: Failed (snapshot deadline exceeded)")))
+		})
+
+		It("should reject a timeout that is not positive", func() {
+			err := testing.NewRepeatableVirtctlCommand("snapshot", "create", vmName, "--timeout", "0s")()
+			Expect(err).To(MatchError("the timeout must be greater than zero"))
+		})
+	})
+
+	Context("restore", func() {
+		It("should create a restore of the VM from the snapshot", func() {
+			createSnapshot(newSnapshot(snapshotName, vmName, true))
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("snapshot", "restore", vmName, snapshotName, "--name", restoreName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("VirtualMachineRestore testrestore of VirtualMachine testvm from VirtualMachineSnapshot testsnapshot created"))
+
+			restore, err := virtClient.SnapshotV1beta1().VirtualMachineRestores(metav1.NamespaceDefault).
+				Get(context.Background(), restoreName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restore.Spec.VirtualMachineSnapshotName).To(Equal(snapshotName))
+			Expect(restore.Spec.Target.Kind).To(Equal("VirtualMachine"))
+			Expect(restore.Spec.Target.Name).To(Equal(vmName))
+		})
+
+		It("should fail if the snapshot is not ready to use", func() {
+			createSnapshot(newSnapshot(snapshotName, vmName, false))
+
+			err := testing.NewRepeatableVirtctlCommand("snapshot", "restore", vmName, snapshotName)()
+			Expect(err).To(MatchError("VirtualMachineSnapshot testsnapshot is not ready to use"))
+		})
+
+		It("should fail if the snapshot does not exist", func() {
+			err := testing.NewRepeatableVirtctlCommand("snapshot", "restore", vmName, snapshotName)()
+			Expect(err).To(MatchError(ContainSubstring("error getting VirtualMachineSnapshot testsnapshot")))
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		DescribeTable("should wait for the restore", func(status *snapshotv1.VirtualMachineRestoreStatus, expectedErr string) {
+			createSnapshot(newSnapshot(snapshotName, vmName, true))
+			virtClient.PrependReactor("get", "virtualmachinerestores", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+				return true, &snapshotv1.VirtualMachineRestore{
+					ObjectMeta: metav1.ObjectMeta{Name: restoreName, Namespace: metav1.NamespaceDefault},
+					Status:     status,
+				}, nil
+			})
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("snapshot", "restore", vmName, snapshotName, "--name", restoreName, "--wait")()
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("VirtualMachineRestore testrestore is complete"))
+		},
+			Entry("until it is complete", &snapshotv1.VirtualMachineRestoreStatus{
+				Complete: pointer.P(true),
+			}, ""),
+			Entry("and fail if it failed", &snapshotv1.VirtualMachineRestoreStatus{
+				Complete: pointer.P(false),
+				Conditions: []snapshotv1.Condition{{
+					Type:   snapshotv1.ConditionFailure,
+					Status: k8sv1.ConditionTrue,
+					Reason: "VM is running",
+				}},
+			}, "VirtualMachineRestore testrestore failed: VM is running"),
+		)
+	})
+
+	Context("list", func() {
+		It("should list only the snapshots of the VM", func() {
+			createSnapshot(newSnapshot(snapshotName, vmName, true))
+			createSnapshot(newSnapshot("othersnapshot", "othervm", true))
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("snapshot", "list", vmName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(MatchRegexp(`NAME\s+PHASE\s+READYTOUSE\s+CREATIONTIME\s+ERROR`))
+			Expect(string(out)).To(MatchRegexp(`testsnapshot\s+Succeeded\s+true\s+<none>\s+<none>`))
+			Expect(string(out)).ToNot(ContainSubstring("othersnapshot"))
+		})
+
+		It("should report when the VM has no snapshots", func() {
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("snapshot", "list", vmName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("No snapshots found for VirtualMachine testvm"))
+		})
+	})
+
+	Context("delete", func() {
+		It("should delete the given snapshots", func() {
+			createSnapshot(newSnapshot(snapshotName, vmName, true))
+			createSnapshot(newSnapshot("othersnapshot", vmName, true))
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("snapshot", "delete", vmName, snapshotName, "--wait")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("VirtualMachineSnapshot testsnapshot deleted"))
+
+			_, err = getSnapshot(snapshotName)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			_, err = getSnapshot("othersnapshot")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should delete all snapshots of the VM", func() {
+			createSnapshot(newSnapshot(snapshotName, vmName, true))
+			createSnapshot(newSnapshot("othersnapshot", "othervm", true))
+
+			Expect(testing.NewRepeatableVirtctlCommand("snapshot", "delete", vmName, "--all")()).To(Succeed())
+
+			_, err := getSnapshot(snapshotName)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			_, err = getSnapshot("othersnapshot")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should refuse to delete a snapshot of another VM", func() {
+			createSnapshot(newSnapshot(snapshotName, vmName, true))
+			createSnapshot(newSnapshot("othersnapshot", "othervm", true))
+
+			err := testing.NewRepeatableVirtctlCommand("snapshot", "delete", vmName, snapshotName, "othersnapshot")()
+			Expect(err).To(MatchError("VirtualMachineSnapshot othersnapshot is not a snapshot of VirtualMachine testvm"))
+
+			_, err = getSnapshot(snapshotName)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		DescribeTable("should reject invalid arguments", func(args []string, expectedErr string) {
+			err := testing.NewRepeatableVirtctlCommand(append([]string{"snapshot", "delete", vmName}, args...)...)()
+			Expect(err).To(MatchError(expectedErr))
+		},
+			Entry("without snapshot names", []string{}, "at least one snapshot name or --all must be specified"),
+			Entry("with snapshot names and --all", []string{snapshotName, "--all"}, "snapshot names must not be specified together with --all"),
+		)
+	})
+})
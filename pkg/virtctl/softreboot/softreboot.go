@@ -26,45 +26,67 @@ import (
 
 	"github.com/spf13/cobra"
 
+	v1 "kubevirt.io/api/core/v1"
+
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 const (
 	COMMAND_SOFT_REBOOT = "soft-reboot"
+
+	fallbackArg = "fallback"
 )
 
+type command struct {
+	fallback string
+}
+
 func NewSoftRebootCommand() *cobra.Command {
+	c := command{}
 	cmd := &cobra.Command{
-		Use:     "soft-reboot (VMI)",
-		Short:   "Soft reboot a virtual machine instance",
-		Long:    `Soft reboot a virtual machine instance`,
-		Args:    cobra.ExactArgs(1),
-		Example: usage(COMMAND_SOFT_REBOOT),
-		RunE:    Run,
+		Use:               "soft-reboot (VMI)",
+		Short:             "Soft reboot a virtual machine instance",
+		Long:              `Soft reboot a virtual machine instance through the guest agent. When the guest agent is absent or unresponsive an ACPI reboot signal is sent instead, unless --fallback=fail is passed.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		Example:           usage(COMMAND_SOFT_REBOOT),
+		RunE:              c.run,
 	}
+	cmd.Flags().StringVar(&c.fallback, fallbackArg, string(v1.SoftRebootFallbackACPI),
+		fmt.Sprintf("What to do when the guest agent is absent or unresponsive: %s sends an ACPI reboot signal, %s fails the soft reboot.", v1.SoftRebootFallbackACPI, v1.SoftRebootFallbackFail))
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
 func usage(cmd string) string {
 	usage := fmt.Sprintf("  # %s a virtualmachineinstance called 'myvmi':\n", strings.Title(cmd))
-	usage += fmt.Sprintf("  {{ProgramName}} %s myvmi", cmd)
+	usage += fmt.Sprintf("  {{ProgramName}} %s myvmi\n\n", cmd)
+	usage += fmt.Sprintf("  # %s a virtualmachineinstance called 'myvmi' only through the guest agent:\n", strings.Title(cmd))
+	usage += fmt.Sprintf("  {{ProgramName}} %s myvmi --%s=%s", cmd, fallbackArg, v1.SoftRebootFallbackFail)
 	return usage
 }
 
-func Run(cmd *cobra.Command, args []string) error {
+func (c *command) run(cmd *cobra.Command, args []string) error {
 	vmi := args[0]
 
+	fallback := v1.SoftRebootFallback(c.fallback)
+	if fallback != v1.SoftRebootFallbackACPI && fallback != v1.SoftRebootFallbackFail {
+		return fmt.Errorf("invalid --%s %q, supported values are %s and %s", fallbackArg, c.fallback, v1.SoftRebootFallbackACPI, v1.SoftRebootFallbackFail)
+	}
+
 	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
 	}
 
-	if err = virtClient.VirtualMachineInstance(namespace).SoftReboot(context.Background(), vmi); err != nil {
-		return fmt.Errorf("Error soft rebooting VirtualMachineInstance %s: %v", vmi, err)
+	if err = virtClient.VirtualMachineInstance(namespace).SoftRebootWithOptions(context.Background(), vmi, &v1.SoftRebootOptions{Fallback: fallback}); err != nil {
+		return fmt.Errorf("Error soft rebooting VirtualMachineInstance %s: %w", vmi, err)
 	}
 
+	result.RecordChange(cmd.Context(), result.Change{Action: "SoftReboot", Kind: v1.VirtualMachineInstanceGroupVersionKind.Kind, Namespace: namespace, Name: vmi})
 	fmt.Printf("VMI %s was scheduled to %s\n", vmi, COMMAND_SOFT_REBOOT)
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 
 	"kubevirt.io/kubevirt/pkg/libvmi"
@@ -39,9 +40,24 @@ var _ = Describe("Soft rebooting", func() {
 		vmi := libvmi.New()
 
 		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface).Times(1)
-		vmiInterface.EXPECT().SoftReboot(context.Background(), vmi.Name).Return(nil).Times(1)
+		vmiInterface.EXPECT().SoftRebootWithOptions(context.Background(), vmi.Name, &v1.SoftRebootOptions{Fallback: v1.SoftRebootFallbackACPI}).Return(nil).Times(1)
 
 		cmd := testing.NewRepeatableVirtctlCommand(softreboot.COMMAND_SOFT_REBOOT, vmi.Name)
 		Expect(cmd()).To(Succeed())
 	})
+
+	It("should soft reboot VMI without the ACPI fallback", func() {
+		vmi := libvmi.New()
+
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().SoftRebootWithOptions(context.Background(), vmi.Name, &v1.SoftRebootOptions{Fallback: v1.SoftRebootFallbackFail}).Return(nil).Times(1)
+
+		cmd := testing.NewRepeatableVirtctlCommand(softreboot.COMMAND_SOFT_REBOOT, vmi.Name, "--fallback=fail")
+		Expect(cmd()).To(Succeed())
+	})
+
+	It("should reject an unsupported fallback", func() {
+		cmd := testing.NewRepeatableVirtctlCommand(softreboot.COMMAND_SOFT_REBOOT, "testvmi", "--fallback=nmi")
+		Expect(cmd()).To(MatchError(ContainSubstring(`invalid --fallback "nmi"`)))
+	})
 })
@@ -0,0 +1,88 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package ssh
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/portforward"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+func NewKeyscanCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "ssh-keyscan (VM|VMI)",
+		Short:             "Print the SSH host keys reported by the guest agent of a virtual machine instance in known_hosts format.",
+		Example:           keyscanUsage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.Target,
+		RunE:              runKeyscan,
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func keyscanUsage() string {
+	return `  # Trust the host keys of 'testvmi' for future connections:
+  {{ProgramName}} ssh-keyscan vmi/testvmi >> ~/.ssh/kubevirt_known_hosts
+
+  # Print the host keys of 'testvm' in 'mynamespace' namespace:
+  {{ProgramName}} ssh-keyscan vm/testvm/mynamespace`
+}
+
+func runKeyscan(cmd *cobra.Command, args []string) error {
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	kind, targetNamespace, name, err := portforward.ParseTarget(args[0])
+	if err != nil {
+		return err
+	}
+	if targetNamespace != "" {
+		namespace = targetNamespace
+	}
+
+	// A VM and the VMI it creates share the same name
+	vmi, err := virtClient.VirtualMachineInstance(namespace).Get(cmd.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting VirtualMachineInstance %s: %v", name, err)
+	}
+
+	if len(vmi.Status.SSHHostKeys) == 0 {
+		return fmt.Errorf("no SSH host keys reported for VirtualMachineInstance %s, "+
+			"the guest agent may not be connected yet or the guest has no SSH server", name)
+	}
+
+	// virtctl ssh connects to <kind>.<name>.<namespace> through a proxy command,
+	// so the entries must use the same host name to be picked up by the client
+	host := kind + "." + name + "." + namespace
+	out := cmd.OutOrStdout()
+	for _, key := range vmi.Status.SSHHostKeys {
+		fmt.Fprintf(out, "%s %s\n", host, key)
+	}
+
+	return nil
+}
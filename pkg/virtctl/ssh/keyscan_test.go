@@ -0,0 +1,92 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package ssh_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("SSH keyscan", func() {
+	const (
+		ed25519Key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHostKey"
+		rsaKey     = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQHostKey"
+	)
+
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	})
+
+	newVMI := func(name, namespace string, keys ...string) *v1.VirtualMachineInstance {
+		vmi := libvmi.New(libvmi.WithName(name), libvmi.WithNamespace(namespace))
+		vmi.Status.SSHHostKeys = keys
+		return vmi
+	}
+
+	It("should fail with missing input parameters", func() {
+		_, err := testing.NewRepeatableVirtctlCommandWithOut("ssh-keyscan")()
+		Expect(err).To(MatchError("accepts 1 arg(s), received 0"))
+	})
+
+	DescribeTable("should print known_hosts entries matching the virtctl ssh host name", func(target, namespace, host string) {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(namespace).Return(vmiInterface)
+		vmiInterface.EXPECT().Get(gomock.Any(), "testvm", metav1.GetOptions{}).
+			Return(newVMI("testvm", namespace, ed25519Key, rsaKey), nil)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("ssh-keyscan", target)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(host + " " + ed25519Key + "\n" + host + " " + rsaKey + "\n"))
+	},
+		Entry("with a VM", "vm/testvm", metav1.NamespaceDefault, "vm.testvm.default"),
+		Entry("with a VMI", "vmi/testvm", metav1.NamespaceDefault, "vmi.testvm.default"),
+		Entry("with a VM in another namespace", "vm/testvm/mynamespace", "mynamespace", "vm.testvm.mynamespace"),
+	)
+
+	It("should fail when the guest did not report any host key", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface)
+		vmiInterface.EXPECT().Get(gomock.Any(), "testvm", metav1.GetOptions{}).
+			Return(newVMI("testvm", metav1.NamespaceDefault), nil)
+
+		_, err := testing.NewRepeatableVirtctlCommandWithOut("ssh-keyscan", "vm/testvm")()
+		Expect(err).To(MatchError(ContainSubstring("no SSH host keys reported for VirtualMachineInstance testvm")))
+	})
+
+	It("should fail when the VMI cannot be fetched", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface)
+		vmiInterface.EXPECT().Get(gomock.Any(), "testvm", metav1.GetOptions{}).Return(nil, errors.New("not found"))
+
+		_, err := testing.NewRepeatableVirtctlCommandWithOut("ssh-keyscan", "vm/testvm")()
+		Expect(err).To(MatchError("error getting VirtualMachineInstance testvm: not found"))
+	})
+})
@@ -33,6 +33,7 @@ import (
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
 	"kubevirt.io/kubevirt/pkg/virtctl/portforward"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
@@ -72,11 +73,12 @@ func NewCommand() *cobra.Command {
 	c := NewSSH(DefaultSSHOptions())
 
 	cmd := &cobra.Command{
-		Use:     "ssh (VM|VMI)",
-		Short:   "Open a SSH connection to a virtual machine instance.",
-		Example: usage(),
-		Args:    cobra.ExactArgs(1),
-		RunE:    c.run,
+		Use:               "ssh (VM|VMI)",
+		Short:             "Open a SSH connection to a virtual machine instance.",
+		Example:           usage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.Target,
+		RunE:              c.run,
 	}
 
 	AddCommandlineArgs(cmd.Flags(), c.options)
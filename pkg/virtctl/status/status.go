@@ -0,0 +1,321 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	eventsFlag    = "events"
+	defaultEvents = 10
+
+	none = "<none>"
+)
+
+type command struct {
+	events int
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show an aggregated status report of a KubeVirt resource.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newVMCommand())
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func newVMCommand() *cobra.Command {
+	c := command{}
+	cmd := &cobra.Command{
+		Use:               "vm (VM)",
+		Short:             "Show conditions, run strategy, migration, volume, network, guest agent and event information of a VirtualMachine.",
+		Example:           usage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.run,
+	}
+	cmd.Flags().IntVar(&c.events, eventsFlag, defaultEvents, "Number of most recent events to show. Set to 0 to omit events.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Show the status of a VirtualMachine named 'my-vm'
+  {{ProgramName}} status vm my-vm
+
+  # Show the status of a VirtualMachine named 'my-vm' including its 20 most recent events
+  {{ProgramName}} status vm my-vm --events=20`
+}
+
+func (c *command) run(cmd *cobra.Command, args []string) error {
+	if c.events < 0 {
+		return fmt.Errorf("the number of events must not be negative")
+	}
+	name := args[0]
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	vm, err := virtClient.VirtualMachine(namespace).Get(cmd.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting VirtualMachine %s: %v", name, err)
+	}
+
+	vmi, err := virtClient.VirtualMachineInstance(namespace).Get(cmd.Context(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		vmi = nil
+	} else if err != nil {
+		return fmt.Errorf("error getting VirtualMachineInstance %s: %v", name, err)
+	}
+
+	var migration *v1.VirtualMachineInstanceMigration
+	if vmi != nil {
+		if migration, err = activeMigration(cmd.Context(), virtClient, namespace, name); err != nil {
+			return err
+		}
+	}
+
+	var events []k8sv1.Event
+	if c.events > 0 {
+		if events, err = recentEvents(cmd.Context(), virtClient, namespace, name, c.events); err != nil {
+			return err
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	printVM(out, vm)
+	if vmi == nil {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "VirtualMachineInstance: not running")
+	} else {
+		printVMI(out, vmi, migration)
+	}
+	if c.events > 0 {
+		printEvents(out, events)
+	}
+
+	return nil
+}
+
+func activeMigration(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, name string) (*v1.VirtualMachineInstanceMigration, error) {
+	migrations, err := virtClient.VirtualMachineInstanceMigration(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing VirtualMachineInstanceMigrations: %v", err)
+	}
+	for i := range migrations.Items {
+		if migrations.Items[i].Spec.VMIName == name && !migrations.Items[i].IsFinal() {
+			return &migrations.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func recentEvents(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, name string, limit int) ([]k8sv1.Event, error) {
+	eventList, err := virtClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.name", name).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing events: %v", err)
+	}
+
+	var events []k8sv1.Event
+	for _, event := range eventList.Items {
+		if event.InvolvedObject.Name != name {
+			continue
+		}
+		switch event.InvolvedObject.Kind {
+		case v1.VirtualMachineGroupVersionKind.Kind, v1.VirtualMachineInstanceGroupVersionKind.Kind:
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	if len(events) > limit {
+		events = events[len(events)-limit:]
+	}
+	return events, nil
+}
+
+func eventTime(event k8sv1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+func printVM(out io.Writer, vm *v1.VirtualMachine) {
+	runStrategy, err := vm.RunStrategy()
+	runStrategyStr := string(runStrategy)
+	if err != nil {
+		runStrategyStr = fmt.Sprintf("%s (%v)", runStrategy, err)
+	}
+
+	fmt.Fprintf(out, "VirtualMachine: %s/%s\n", vm.Namespace, vm.Name)
+	fmt.Fprintf(out, "Status:         %s\n", vm.Status.PrintableStatus)
+	fmt.Fprintf(out, "Run Strategy:   %s\n", runStrategyStr)
+	fmt.Fprintf(out, "Ready:          %t\n", vm.Status.Ready)
+
+	conditions := make([][]string, 0, len(vm.Status.Conditions))
+	for _, cond := range vm.Status.Conditions {
+		conditions = append(conditions, []string{string(cond.Type), string(cond.Status), cond.Reason, cond.Message})
+	}
+	printSection(out, "Conditions", []string{"TYPE", "STATUS", "REASON", "MESSAGE"}, conditions)
+}
+
+func printVMI(out io.Writer, vmi *v1.VirtualMachineInstance, migration *v1.VirtualMachineInstanceMigration) {
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "VirtualMachineInstance:")
+	fmt.Fprintf(out, "  Phase:        %s\n", vmi.Status.Phase)
+	fmt.Fprintf(out, "  Node:         %s\n", valueOrNone(vmi.Status.NodeName))
+	fmt.Fprintf(out, "  Migration:    %s\n", migrationSummary(vmi, migration))
+	fmt.Fprintf(out, "  Guest Agent:  %s\n", guestAgentSummary(vmi))
+
+	conditions := make([][]string, 0, len(vmi.Status.Conditions))
+	for _, cond := range vmi.Status.Conditions {
+		conditions = append(conditions, []string{string(cond.Type), string(cond.Status), cond.Reason, cond.Message})
+	}
+	printSection(out, "Instance Conditions", []string{"TYPE", "STATUS", "REASON", "MESSAGE"}, conditions)
+
+	volumes := make([][]string, 0, len(vmi.Status.VolumeStatus))
+	for _, volume := range vmi.Status.VolumeStatus {
+		hotplug := "false"
+		if volume.HotplugVolume != nil {
+			hotplug = "true"
+		}
+		volumes = append(volumes, []string{volume.Name, volume.Target, string(volume.Phase), hotplug, volume.Message})
+	}
+	printSection(out, "Volumes", []string{"NAME", "TARGET", "PHASE", "HOTPLUG", "MESSAGE"}, volumes)
+
+	interfaces := make([][]string, 0, len(vmi.Status.Interfaces))
+	for _, iface := range vmi.Status.Interfaces {
+		ips := iface.IPs
+		if len(ips) == 0 && iface.IP != "" {
+			ips = []string{iface.IP}
+		}
+		interfaces = append(interfaces, []string{iface.Name, iface.InterfaceName, iface.MAC, strings.Join(ips, ",")})
+	}
+	printSection(out, "Interfaces", []string{"NAME", "GUEST INTERFACE", "MAC", "IPS"}, interfaces)
+}
+
+func migrationSummary(vmi *v1.VirtualMachineInstance, migration *v1.VirtualMachineInstanceMigration) string {
+	if migration == nil {
+		return none
+	}
+	summary := fmt.Sprintf("%s (%s)", migration.Name, migration.Status.Phase)
+	if state := vmi.Status.MigrationState; state != nil && state.MigrationUID == migration.UID {
+		summary += fmt.Sprintf(" from %s to %s", valueOrNone(state.SourceNode), valueOrNone(state.TargetNode))
+	}
+	return summary
+}
+
+func guestAgentSummary(vmi *v1.VirtualMachineInstance) string {
+	connected := false
+	for _, cond := range vmi.Status.Conditions {
+		if cond.Type == v1.VirtualMachineInstanceAgentConnected && cond.Status == k8sv1.ConditionTrue {
+			connected = true
+		}
+	}
+	if !connected {
+		return "not connected"
+	}
+
+	osInfo := vmi.Status.GuestOSInfo
+	osName := osInfo.PrettyName
+	if osName == "" {
+		osName = strings.TrimSpace(osInfo.Name + " " + osInfo.Version)
+	}
+	if osName == "" {
+		return "connected"
+	}
+	if osInfo.KernelRelease != "" {
+		return fmt.Sprintf("connected, %s, kernel %s", osName, osInfo.KernelRelease)
+	}
+	return fmt.Sprintf("connected, %s", osName)
+}
+
+func printEvents(out io.Writer, events []k8sv1.Event) {
+	rows := make([][]string, 0, len(events))
+	for _, event := range events {
+		rows = append(rows, []string{
+			eventTime(event).UTC().Format(time.RFC3339),
+			event.Type,
+			event.Reason,
+			fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
+			strings.TrimSpace(event.Message),
+		})
+	}
+	printSection(out, "Events", []string{"LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"}, rows)
+}
+
+func printSection(out io.Writer, title string, header []string, rows [][]string) {
+	fmt.Fprintln(out)
+	if len(rows) == 0 {
+		fmt.Fprintf(out, "%s: %s\n", title, none)
+		return
+	}
+	fmt.Fprintf(out, "%s:\n", title)
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  %s\n", strings.Join(header, "\t"))
+	for _, row := range rows {
+		for i := range row {
+			row[i] = valueOrNone(row[i])
+		}
+		fmt.Fprintf(w, "  %s\n", strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return none
+	}
+	return value
+}
@@ -0,0 +1,225 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package status_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Status command", func() {
+	const vmName = "testvm"
+
+	var (
+		kubeClient *fake.Clientset
+		virtClient *kubevirtfake.Clientset
+	)
+
+	createVM := func() {
+		vm := libvmi.NewVirtualMachine(
+			libvmi.New(libvmi.WithNamespace(metav1.NamespaceDefault), libvmi.WithName(vmName)),
+			libvmi.WithRunStrategy(v1.RunStrategyAlways),
+		)
+		vm.Status.PrintableStatus = v1.VirtualMachineStatusRunning
+		vm.Status.Ready = true
+		vm.Status.Conditions = []v1.VirtualMachineCondition{{
+			Type:   v1.VirtualMachineReady,
+			Status: k8sv1.ConditionTrue,
+		}}
+		_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).
+			Create(context.Background(), vm, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	createEvent := func(name, kind, objectName, reason string, timestamp time.Time) {
+		_, err := kubeClient.CoreV1().Events(metav1.NamespaceDefault).Create(context.Background(), &k8sv1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+			},
+			InvolvedObject: k8sv1.ObjectReference{
+				Kind:      kind,
+				Name:      objectName,
+				Namespace: metav1.NamespaceDefault,
+			},
+			Type:          k8sv1.EventTypeNormal,
+			Reason:        reason,
+			Message:       reason + " " + objectName,
+			LastTimestamp: metav1.NewTime(timestamp),
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		kubeClient = fake.NewSimpleClientset()
+		virtClient = kubevirtfake.NewSimpleClientset()
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstanceMigration(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachineInstanceMigrations(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+	})
+
+	It("should fail with missing input parameters", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("status", "vm")
+		Expect(cmd()).To(MatchError("accepts 1 arg(s), received 0"))
+	})
+
+	It("should fail if the VM does not exist", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("status", "vm", vmName)
+		Expect(cmd()).To(MatchError(ContainSubstring("error getting VirtualMachine testvm")))
+	})
+
+	It("should fail with a negative number of events", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("status", "vm", vmName, "--events=-1")
+		Expect(cmd()).To(MatchError("the number of events must not be negative"))
+	})
+
+	It("should report a stopped VM", func() {
+		createVM()
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("status", "vm", vmName, "--events=0")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(`VirtualMachine: default/testvm
+Status:         Running
+Run Strategy:   Always
+Ready:          true
+
+Conditions:
+  TYPE    STATUS   REASON   MESSAGE
+  Ready   True     <none>   <none>
+
+VirtualMachineInstance: not running
+`))
+	})
+
+	It("should aggregate the status of a running VM", func() {
+		createVM()
+
+		vmi := libvmi.New(libvmi.WithNamespace(metav1.NamespaceDefault), libvmi.WithName(vmName))
+		vmi.Status.Phase = v1.Running
+		vmi.Status.NodeName = "node01"
+		vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+			Type:   v1.VirtualMachineInstanceAgentConnected,
+			Status: k8sv1.ConditionTrue,
+		}}
+		vmi.Status.GuestOSInfo = v1.VirtualMachineInstanceGuestOSInfo{
+			PrettyName:    "Fedora Linux 40",
+			KernelRelease: "6.8.5",
+		}
+		vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+			MigrationUID: "migration-uid",
+			SourceNode:   "node01",
+			TargetNode:   "node02",
+		}
+		vmi.Status.VolumeStatus = []v1.VolumeStatus{
+			{Name: "rootdisk", Target: "vda", Phase: v1.VolumeReady},
+			{Name: "hotplug", Target: "sda", Phase: v1.HotplugVolumeMounted, HotplugVolume: &v1.HotplugVolumeStatus{}},
+		}
+		vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{{
+			Name:          "default",
+			InterfaceName: "eth0",
+			MAC:           "52:54:00:00:00:01",
+			IPs:           []string{"10.0.2.2", "fd10:0:2::2"},
+		}}
+		_, err := virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).
+			Create(context.Background(), vmi, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, migration := range []*v1.VirtualMachineInstanceMigration{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "finished", Namespace: metav1.NamespaceDefault},
+				Spec:       v1.VirtualMachineInstanceMigrationSpec{VMIName: vmName},
+				Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationSucceeded},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "active", Namespace: metav1.NamespaceDefault, UID: "migration-uid"},
+				Spec:       v1.VirtualMachineInstanceMigrationSpec{VMIName: vmName},
+				Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationRunning},
+			},
+		} {
+			_, err := virtClient.KubevirtV1().VirtualMachineInstanceMigrations(metav1.NamespaceDefault).
+				Create(context.Background(), migration, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		createEvent("oldest", "VirtualMachine", vmName, "SuccessfulCreate", now)
+		createEvent("started", "VirtualMachineInstance", vmName, "Started", now.Add(2*time.Minute))
+		createEvent("created", "VirtualMachineInstance", vmName, "Created", now.Add(time.Minute))
+		createEvent("other", "VirtualMachine", "othervm", "SuccessfulCreate", now.Add(3*time.Minute))
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("status", "vm", vmName, "--events=2")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(`VirtualMachine: default/testvm
+Status:         Running
+Run Strategy:   Always
+Ready:          true
+
+Conditions:
+  TYPE    STATUS   REASON   MESSAGE
+  Ready   True     <none>   <none>
+
+VirtualMachineInstance:
+  Phase:        Running
+  Node:         node01
+  Migration:    active (Running) from node01 to node02
+  Guest Agent:  connected, Fedora Linux 40, kernel 6.8.5
+
+Instance Conditions:
+  TYPE             STATUS   REASON   MESSAGE
+  AgentConnected   True     <none>   <none>
+
+Volumes:
+  NAME       TARGET   PHASE          HOTPLUG   MESSAGE
+  rootdisk   vda      Ready          false     <none>
+  hotplug    sda      MountedToPod   true      <none>
+
+Interfaces:
+  NAME      GUEST INTERFACE   MAC                 IPS
+  default   eth0              52:54:00:00:00:01   10.0.2.2,fd10:0:2::2
+
+Events:
+  LAST SEEN              TYPE     REASON    OBJECT                          MESSAGE
+  2024-01-01T12:01:00Z   Normal   Created   VirtualMachineInstance/testvm   Created testvm
+  2024-01-01T12:02:00Z   Normal   Started   VirtualMachineInstance/testvm   Started testvm
+`))
+	})
+})
@@ -25,17 +25,23 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	kubevirtV1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/virtctl/bulk"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 type virtCommand struct {
 	dryRun bool
+	bulk   bulk.Options
 }
 
 func NewCommand() *cobra.Command {
@@ -45,25 +51,33 @@ func NewCommand() *cobra.Command {
 		Short: "Unpause a virtual machine",
 		Long: `Unpauses a virtual machine.
 First argument is the resource type, possible types are (case insensitive, both singular and plural forms) virtualmachineinstance (vmi) or virtualmachine (vm).
-Second argument is the name of the resource.`,
-		Args:    cobra.ExactArgs(2),
-		Example: usage(),
-		RunE:    c.Run,
+Second argument is the name of the resource. It is omitted when the resources are selected with --selector.`,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completion.KindAndName,
+		Example:           usage(),
+		RunE:              c.Run,
 	}
 
 	cmd.Flags().BoolVar(&c.dryRun, "dry-run", false, "--dry-run=false: Flag used to set whether to perform a dry run or not. If true the command will be executed without performing any changes.")
+	c.bulk.AddFlags(cmd)
 
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
 func usage() string {
-	return "  # Unpause a virtualmachine called 'myvm':\n  {{ProgramName}} unpause vm myvm"
+	return `  # Unpause a virtualmachine called 'myvm':
+  {{ProgramName}} unpause vm myvm
+
+  # Unpause all virtualmachines labeled app=db in all namespaces:
+  {{ProgramName}} unpause vm -l app=db --all-namespaces`
 }
 
 func (vc *virtCommand) Run(cmd *cobra.Command, args []string) error {
+	if err := vc.bulk.Validate(len(args) == 2); err != nil {
+		return result.NewUsageError(err)
+	}
 	resourceType := strings.ToLower(args[0])
-	resourceName := args[1]
 
 	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
@@ -76,7 +90,46 @@ func (vc *virtCommand) Run(cmd *cobra.Command, args []string) error {
 		dryRunOption = []string{v1.DryRunAll}
 	}
 
-	return executeUnpauseCMD(virtClient, namespace, resourceType, resourceName, dryRunOption)
+	if vc.bulk.Enabled() {
+		return vc.runUnpauseBulk(cmd, virtClient, namespace, resourceType, dryRunOption)
+	}
+
+	resourceName := args[1]
+	if err := executeUnpauseCMD(virtClient, namespace, resourceType, resourceName, dryRunOption); err != nil {
+		return err
+	}
+	result.RecordChange(cmd.Context(), result.Change{Action: "Unpause", Kind: kubevirtV1.VirtualMachineInstanceGroupVersionKind.Kind, Namespace: namespace, Name: resourceName, DryRun: vc.dryRun})
+	return nil
+}
+
+// runUnpauseBulk unpauses the VMIs of all VMs or VMIs matching the label selector.
+func (vc *virtCommand) runUnpauseBulk(cmd *cobra.Command, client kubecli.KubevirtClient, namespace, resourceType string, dryRunOption []string) error {
+	var (
+		targets []types.NamespacedName
+		err     error
+	)
+	switch resourceType {
+	case "virtualmachine", "vm":
+		targets, err = vc.bulk.ListVirtualMachines(cmd.Context(), client, namespace)
+	case "virtualmachineinstance", "vmi":
+		targets, err = vc.bulk.ListVirtualMachineInstances(cmd.Context(), client, namespace)
+	default:
+		return result.NewUsageError(fmt.Errorf("unsupported resource type %s", resourceType))
+	}
+	if err != nil {
+		return err
+	}
+
+	return vc.bulk.Run(cmd, "unpause", targets, func(ctx context.Context, target types.NamespacedName) error {
+		err := client.VirtualMachineInstance(target.Namespace).Unpause(ctx, target.Name, &kubevirtV1.UnpauseOptions{DryRun: dryRunOption})
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("VirtualMachineInstance %s is not running", target.Name)
+		} else if err != nil {
+			return fmt.Errorf("Error unpausing VirtualMachineInstance %s: %w", target.Name, err)
+		}
+		result.RecordChange(cmd.Context(), result.Change{Action: "Unpause", Kind: kubevirtV1.VirtualMachineInstanceGroupVersionKind.Kind, Namespace: target.Namespace, Name: target.Name, DryRun: vc.dryRun})
+		return nil
+	})
 }
 
 func executeUnpauseCMD(client kubecli.KubevirtClient, namespace, resourceType, resourceName string, dryRunOption []string) error {
@@ -84,18 +137,18 @@ func executeUnpauseCMD(client kubecli.KubevirtClient, namespace, resourceType, r
 	case "virtualmachine", "vm":
 		vm, err := client.VirtualMachine(namespace).Get(context.Background(), resourceName, v1.GetOptions{})
 		if err != nil {
-			return fmt.Errorf("Error getting VirtualMachine %s: %v", resourceName, err)
+			return fmt.Errorf("Error getting VirtualMachine %s: %w", resourceName, err)
 		}
 		vmiName := vm.Name
 		err = client.VirtualMachineInstance(namespace).Unpause(context.Background(), vmiName, &kubevirtV1.UnpauseOptions{DryRun: dryRunOption})
 		if err != nil {
-			return fmt.Errorf("Error unpausing VirtualMachineInstance %s: %v", vmiName, err)
+			return fmt.Errorf("Error unpausing VirtualMachineInstance %s: %w", vmiName, err)
 		}
 		fmt.Printf("VMI %s was scheduled to unpause\n", vmiName)
 	case "virtualmachineinstance", "vmi":
 		err := client.VirtualMachineInstance(namespace).Unpause(context.Background(), resourceName, &kubevirtV1.UnpauseOptions{DryRun: dryRunOption})
 		if err != nil {
-			return fmt.Errorf("Error unpausing VirtualMachineInstance %s: %v", resourceName, err)
+			return fmt.Errorf("Error unpausing VirtualMachineInstance %s: %w", resourceName, err)
 		}
 		fmt.Printf("VMI %s was scheduled to unpause\n", resourceName)
 	}
@@ -92,6 +92,11 @@ func (k *Client) GetProxyAddress() string {
 	return k.listener.Addr().String()
 }
 
+// Close stops accepting connections from the local usbredir client
+func (k *Client) Close() error {
+	return k.listener.Close()
+}
+
 func (k *Client) withRemoteVMIStream(usbredirStream kvcorev1.StreamInterface) {
 	k.stream = make(chan error)
 
@@ -127,7 +132,7 @@ func (k *Client) withLocalTCPClient(address string) error {
 func (k *Client) proxyUSBRedir() {
 	// forward data to/from websocket after usbredir client connects.
 	k.done = make(chan struct{}, 1)
-	k.remote = make(chan error)
+	k.remote = make(chan error, 1)
 	go func() {
 		defer k.inputWriter.Close()
 		start := time.Now()
@@ -188,7 +193,7 @@ func clientConnect(ctx context.Context, device, address string) error {
 func (k *Client) Redirect(device string) error {
 	// execute local usbredir binary
 	address := k.GetProxyAddress()
-	k.local = make(chan error)
+	k.local = make(chan error, 1)
 	if k.LaunchClient {
 		go func() {
 			defer close(k.done)
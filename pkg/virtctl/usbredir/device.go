@@ -0,0 +1,75 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package usbredir
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	sysfsUSBDevices = "/sys/bus/usb/devices"
+
+	vendorProductRegexp = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{4}$`)
+	busDeviceRegexp     = regexp.MustCompile(`^[0-9]+-[0-9]+$`)
+	busPortRegexp       = regexp.MustCompile(`^([0-9]+)/([0-9]+(\.[0-9]+)*)$`)
+)
+
+// device selects a local USB device either by vendor:product, by bus-device
+// or by bus/port. Unlike the device number, the port a device is plugged into
+// survives replugging, so the selection is resolved again on every attach.
+type device string
+
+func parseDevice(arg string) (device, error) {
+	if !vendorProductRegexp.MatchString(arg) && !busDeviceRegexp.MatchString(arg) && !busPortRegexp.MatchString(arg) {
+		return "", fmt.Errorf("invalid USB device %q, expected vendor:product, bus-device or bus/port", arg)
+	}
+	return device(arg), nil
+}
+
+// resolve returns the device selection in the form understood by usbredirect
+func (d device) resolve() (string, error) {
+	match := busPortRegexp.FindStringSubmatch(string(d))
+	if match == nil {
+		return string(d), nil
+	}
+	bus, port := match[1], match[2]
+	sysfsDevice := filepath.Join(sysfsUSBDevices, fmt.Sprintf("%s-%s", strings.TrimLeft(bus, "0"), port))
+	busNum, err := readSysfsNumber(filepath.Join(sysfsDevice, "busnum"))
+	if err != nil {
+		return "", fmt.Errorf("no USB device plugged into bus %s port %s: %w", bus, port, err)
+	}
+	devNum, err := readSysfsNumber(filepath.Join(sysfsDevice, "devnum"))
+	if err != nil {
+		return "", fmt.Errorf("no USB device plugged into bus %s port %s: %w", bus, port, err)
+	}
+	return fmt.Sprintf("%d-%d", busNum, devNum), nil
+}
+
+func readSysfsNumber(path string) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(content)))
+}
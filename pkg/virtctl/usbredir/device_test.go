@@ -0,0 +1,82 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package usbredir
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("USB device selection", func() {
+	DescribeTable("should accept", func(arg string) {
+		_, err := parseDevice(arg)
+		Expect(err).ToNot(HaveOccurred())
+	},
+		Entry("vendor:product", "0951:1666"),
+		Entry("bus-device", "02-03"),
+		Entry("bus/port", "2/1"),
+		Entry("bus/port behind a hub", "2/1.4"),
+	)
+
+	DescribeTable("should reject", func(arg string) {
+		_, err := parseDevice(arg)
+		Expect(err).To(MatchError(ContainSubstring("invalid USB device")))
+	},
+		Entry("a VMI name", "testvmi"),
+		Entry("a partial vendor:product", "0951:"),
+		Entry("a malformed port", "2/1..4"),
+	)
+
+	Context("resolving", func() {
+		BeforeEach(func() {
+			origSysfsUSBDevices := sysfsUSBDevices
+			sysfsUSBDevices = GinkgoT().TempDir()
+			DeferCleanup(func() { sysfsUSBDevices = origSysfsUSBDevices })
+		})
+
+		plugDevice := func(sysfsName, busNum, devNum string) {
+			dir := filepath.Join(sysfsUSBDevices, sysfsName)
+			Expect(os.MkdirAll(dir, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "busnum"), []byte(busNum+"\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "devnum"), []byte(devNum+"\n"), 0644)).To(Succeed())
+		}
+
+		It("should pass vendor:product and bus-device through", func() {
+			Expect(device("0951:1666").resolve()).To(Equal("0951:1666"))
+			Expect(device("02-03").resolve()).To(Equal("02-03"))
+		})
+
+		It("should resolve bus/port to the device currently plugged in", func() {
+			plugDevice("2-1.4", "2", "7")
+			Expect(device("02/1.4").resolve()).To(Equal("2-7"))
+
+			By("replugging the device")
+			plugDevice("2-1.4", "2", "9")
+			Expect(device("02/1.4").resolve()).To(Equal("2-9"))
+		})
+
+		It("should fail when nothing is plugged into the port", func() {
+			_, err := device("2/3").resolve()
+			Expect(err).To(MatchError(ContainSubstring("no USB device plugged into bus 2 port 3")))
+		})
+	})
+})
@@ -20,6 +20,8 @@
 package usbredir
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
@@ -29,18 +31,20 @@ const (
 	usbredirClient = "usbredirect"
 
 	optDisableClientLaunch = "no-launch"
+	optReconnectTimeout    = "reconnect-timeout"
 )
 
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "usbredir (vendor:product)|(bus-device) (VMI)",
-		Short:   "Redirect an USB device to a virtual machine instance.",
+		Use:     "usbredir ((vendor:product)|(bus-device)|(bus/port))... (VMI)",
+		Short:   "Redirect USB devices to a virtual machine instance.",
 		Example: usage(),
-		Args:    cobra.RangeArgs(1, 2),
+		Args:    cobra.MinimumNArgs(1),
 		RunE:    Run,
 	}
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	cmd.Flags().Bool(optDisableClientLaunch, false, "If set, you should launch the usbredir client yourself")
+	cmd.Flags().Duration(optReconnectTimeout, 5*time.Minute, "How long to keep trying to re-attach a device after its redirection broke, e.g. because the VMI migrated. Zero disables re-attaching")
 	return cmd
 }
 
@@ -55,6 +59,12 @@ func usage() string {
 	# Redirect it with bus-device:
     {{ProgramName}} usbredir 02-03 testvmi
 
+	# Redirect whatever is plugged into port 1.4 of bus 2, surviving a replug:
+    {{ProgramName}} usbredir 2/1.4 testvmi
+
+	# Redirect multiple devices in a single session:
+    {{ProgramName}} usbredir 0951:1666 2/1.4 testvmi
+
 	# Disabling auto-launch of usbredir client
 	{{ProgramName}} usbredir testvmi --no-launch
 	`
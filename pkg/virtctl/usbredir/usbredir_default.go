@@ -24,15 +24,25 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
 )
 
+const reconnectInterval = 2 * time.Second
+
 func Run(cmd *cobra.Command, args []string) error {
 	disableLaunch := cmd.Flags().Changed(optDisableClientLaunch)
-	if !disableLaunch && len(args) != 2 {
+	if !disableLaunch && len(args) < 2 {
 		return fmt.Errorf("Missing argument")
 	}
 
@@ -40,23 +50,29 @@ func Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Error on finding %s in $PATH: %w", usbredirClient, err)
 	}
 
-	var vmiArg, usbdeviceArg string
+	var vmiArg string
+	var devices []device
 	if disableLaunch {
 		vmiArg = args[0]
 	} else {
-		usbdeviceArg = args[0]
-		vmiArg = args[1]
+		vmiArg = args[len(args)-1]
+		for _, arg := range args[:len(args)-1] {
+			dev, err := parseDevice(arg)
+			if err != nil {
+				return err
+			}
+			devices = append(devices, dev)
+		}
 	}
 
-	virtCli, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	reconnectTimeout, err := cmd.Flags().GetDuration(optReconnectTimeout)
 	if err != nil {
 		return err
 	}
 
-	// Get connection to the websocket for usbredir subresource
-	usbredirVMI, err := virtCli.VirtualMachineInstance(namespace).USBRedir(vmiArg)
+	virtCli, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
-		return fmt.Errorf("Can't access VMI %s: %s", vmiArg, err.Error())
+		return err
 	}
 
 	ctx, cancelFn := context.WithCancel(context.Background())
@@ -71,15 +87,108 @@ func Run(cmd *cobra.Command, args []string) error {
 		}
 	}(cancelFn)
 
-	usbredirClient, err := NewUSBRedirClient(ctx, "localhost:0", usbredirVMI)
-	if err != nil {
-		return fmt.Errorf("Can't create usbredir client: %s", err.Error())
-	}
-
 	if disableLaunch {
+		// Get connection to the websocket for usbredir subresource
+		usbredirVMI, err := virtCli.VirtualMachineInstance(namespace).USBRedir(vmiArg)
+		if err != nil {
+			return fmt.Errorf("Can't access VMI %s: %s", vmiArg, err.Error())
+		}
+
+		usbredirClient, err := NewUSBRedirClient(ctx, "localhost:0", usbredirVMI)
+		if err != nil {
+			return fmt.Errorf("Can't create usbredir client: %s", err.Error())
+		}
+
 		// This is a log to the user, should use stdout instead of default stderr of log
 		cmd.Printf("User can connect usbredir client at: %s\n", usbredirClient.GetProxyAddress())
 		usbredirClient.LaunchClient = false
+		return usbredirClient.Redirect("")
+	}
+
+	r := &redirector{
+		client:           virtCli,
+		namespace:        namespace,
+		vmi:              vmiArg,
+		reconnectTimeout: reconnectTimeout,
+	}
+	group, groupCtx := errgroup.WithContext(ctx)
+	for _, dev := range devices {
+		group.Go(func() error {
+			return r.redirect(groupCtx, dev)
+		})
+	}
+	return group.Wait()
+}
+
+// redirector redirects local USB devices to a VMI, every device over its own
+// usbredir connection, and re-attaches them when a connection breaks
+type redirector struct {
+	client           kubecli.KubevirtClient
+	namespace        string
+	vmi              string
+	reconnectTimeout time.Duration
+}
+
+func (r *redirector) redirect(ctx context.Context, dev device) error {
+	established, err := r.session(ctx, dev)
+	if !established || r.reconnectTimeout == 0 {
+		return err
+	}
+
+	deadline := time.Now().Add(r.reconnectTimeout)
+	for ctx.Err() == nil {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("failed to re-attach USB device %s within %v: %w", dev, r.reconnectTimeout, err)
+		}
+		log.Log.Warningf("USB redirection of %s ended, re-attaching: %v", dev, err)
+		if err := r.waitForVMI(ctx, time.Until(deadline)); err != nil {
+			return fmt.Errorf("failed to re-attach USB device %s: %w", dev, err)
+		}
+		if established, err = r.session(ctx, dev); established {
+			deadline = time.Now().Add(r.reconnectTimeout)
+		}
+	}
+	return ctx.Err()
+}
+
+// session redirects the device until the connection breaks and reports
+// whether the connection to the VMI could be established at all
+func (r *redirector) session(ctx context.Context, dev device) (bool, error) {
+	deviceArg, err := dev.resolve()
+	if err != nil {
+		return false, err
+	}
+
+	// Get connection to the websocket for usbredir subresource
+	usbredirVMI, err := r.client.VirtualMachineInstance(r.namespace).USBRedir(r.vmi)
+	if err != nil {
+		return false, fmt.Errorf("Can't access VMI %s: %s", r.vmi, err.Error())
+	}
+
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	usbredirClient, err := NewUSBRedirClient(sessionCtx, "localhost:0", usbredirVMI)
+	if err != nil {
+		return false, fmt.Errorf("Can't create usbredir client: %s", err.Error())
 	}
-	return usbredirClient.Redirect(usbdeviceArg)
+	defer usbredirClient.Close()
+
+	return true, usbredirClient.Redirect(deviceArg)
+}
+
+// waitForVMI waits for the VMI to run again, e.g. after a migration
+func (r *redirector) waitForVMI(ctx context.Context, timeout time.Duration) error {
+	return wait.PollUntilContextTimeout(ctx, reconnectInterval, timeout, false, func(ctx context.Context) (bool, error) {
+		vmi, err := r.client.VirtualMachineInstance(r.namespace).Get(ctx, r.vmi, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return false, err
+		} else if err != nil {
+			return false, nil
+		}
+		if vmi.IsFinal() {
+			return false, fmt.Errorf("VMI %s is %s", r.vmi, vmi.Status.Phase)
+		}
+		migrating := vmi.Status.MigrationState != nil && !vmi.Status.MigrationState.Completed && !vmi.Status.MigrationState.Failed
+		return vmi.IsRunning() && !migrating, nil
+	})
 }
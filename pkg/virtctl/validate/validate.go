@@ -0,0 +1,344 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	admissionv1 "k8s.io/api/admission/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/cache"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/defaults"
+	netadmitter "kubevirt.io/kubevirt/pkg/network/admitter"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/validating-webhook/admitters"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	filenameFlag      = "filename"
+	clusterConfigFlag = "cluster-config"
+	featureGatesFlag  = "feature-gates"
+
+	stdin = "-"
+
+	offlineKubeVirtName      = "kubevirt"
+	offlineKubeVirtNamespace = "kubevirt"
+)
+
+type command struct {
+	filenames     []string
+	clusterConfig bool
+	featureGates  []string
+}
+
+type object struct {
+	source string
+	gvk    schema.GroupVersionKind
+	name   string
+	raw    []byte
+}
+
+func NewCommand() *cobra.Command {
+	c := command{}
+	cmd := &cobra.Command{
+		Use:     "validate",
+		Short:   "Validate VirtualMachine and VirtualMachineInstance manifests with the admission logic of virt-api.",
+		Example: usage(),
+		Args:    cobra.NoArgs,
+		RunE:    c.run,
+	}
+	cmd.Flags().StringArrayVarP(&c.filenames, filenameFlag, "f", nil, "Manifest to validate. Use - to read from stdin. Can be specified multiple times.")
+	cmd.Flags().BoolVar(&c.clusterConfig, clusterConfigFlag, false, "Validate against the configuration of the KubeVirt installation in the cluster instead of the offline defaults.")
+	cmd.Flags().StringSliceVar(&c.featureGates, featureGatesFlag, nil, "Feature gates to enable for offline validation.")
+	cmd.MarkFlagsMutuallyExclusive(clusterConfigFlag, featureGatesFlag)
+	if err := cmd.MarkFlagRequired(filenameFlag); err != nil {
+		panic(err)
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Validate the manifests in vm.yaml with the default KubeVirt configuration
+  {{ProgramName}} validate -f vm.yaml
+
+  # Validate a manifest read from stdin with additional feature gates enabled
+  cat vm.yaml | {{ProgramName}} validate -f - --feature-gates=Snapshot,HostDevices
+
+  # Validate the manifests against the configuration of the KubeVirt installation in the cluster
+  {{ProgramName}} validate -f vm.yaml --cluster-config`
+}
+
+func (c *command) run(cmd *cobra.Command, _ []string) error {
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	var objects []object
+	for _, filename := range c.filenames {
+		objs, err := readObjects(cmd.InOrStdin(), filename)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, objs...)
+	}
+	if len(objects) == 0 {
+		return fmt.Errorf("no objects found in %s", strings.Join(c.filenames, ", "))
+	}
+
+	kv := offlineKubeVirt(c.featureGates)
+	if c.clusterConfig {
+		if kv, err = fetchKubeVirt(cmd.Context(), virtClient); err != nil {
+			return err
+		}
+	}
+	clusterConfig, err := newClusterConfig(kv)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	invalid := 0
+	for _, obj := range objects {
+		resp, err := admit(cmd.Context(), virtClient, clusterConfig, namespace, obj)
+		if err != nil {
+			return err
+		}
+		if !printResult(out, obj, resp) {
+			invalid++
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d objects failed validation", invalid, len(objects))
+	}
+	return nil
+}
+
+func readObjects(stdinReader io.Reader, filename string) ([]object, error) {
+	reader := stdinReader
+	if filename != stdin {
+		file, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	var objects []object
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 1024)
+	for {
+		doc := map[string]interface{}{}
+		if err := decoder.Decode(&doc); errors.Is(err, io.EOF) {
+			return objects, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("error decoding %s: %v", filename, err)
+		}
+		if len(doc) == 0 {
+			continue
+		}
+
+		raw, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding %s: %v", filename, err)
+		}
+		typeMeta := metav1.TypeMeta{}
+		objectMeta := struct {
+			Metadata metav1.ObjectMeta `json:"metadata"`
+		}{}
+		if err := json.Unmarshal(raw, &typeMeta); err != nil {
+			return nil, fmt.Errorf("error decoding %s: %v", filename, err)
+		}
+		if err := json.Unmarshal(raw, &objectMeta); err != nil {
+			return nil, fmt.Errorf("error decoding metadata in %s: %v", filename, err)
+		}
+
+		objects = append(objects, object{
+			source: filename,
+			gvk:    typeMeta.GroupVersionKind(),
+			name:   objectMeta.Metadata.Name,
+			raw:    raw,
+		})
+	}
+}
+
+// offlineKubeVirt returns a KubeVirt CR with the default configuration and
+// the given feature gates enabled.
+func offlineKubeVirt(featureGates []string) *v1.KubeVirt {
+	kv := &v1.KubeVirt{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      offlineKubeVirtName,
+			Namespace: offlineKubeVirtNamespace,
+		},
+		Status: v1.KubeVirtStatus{
+			Phase:               v1.KubeVirtPhaseDeployed,
+			DefaultArchitecture: goruntime.GOARCH,
+		},
+	}
+	if len(featureGates) > 0 {
+		kv.Spec.Configuration.DeveloperConfiguration = &v1.DeveloperConfiguration{
+			FeatureGates: featureGates,
+		}
+	}
+	return kv
+}
+
+func fetchKubeVirt(ctx context.Context, virtClient kubecli.KubevirtClient) (*v1.KubeVirt, error) {
+	kvs, err := virtClient.KubeVirt(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list KubeVirt CRs across all namespaces: %v", err)
+	}
+	if len(kvs.Items) == 0 {
+		return nil, errors.New("could not detect a KubeVirt installation")
+	}
+	if len(kvs.Items) > 1 {
+		return nil, errors.New("invalid kubevirt installation, more than one KubeVirt resource found")
+	}
+	kv := &kvs.Items[0]
+	if kv.Status.Phase == "" {
+		kv.Status.Phase = v1.KubeVirtPhaseDeployed
+	}
+	return kv, nil
+}
+
+// newClusterConfig returns a ClusterConfig backed by informers which are
+// never started and only contain the given KubeVirt CR.
+func newClusterConfig(kv *v1.KubeVirt) (*virtconfig.ClusterConfig, error) {
+	// The ClusterConfig only picks up configurations with a resource version
+	if kv.ResourceVersion == "" {
+		kv.ResourceVersion = "1"
+	}
+	crdInformer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &extv1.CustomResourceDefinition{}, 0, cache.Indexers{})
+	kubeVirtInformer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &v1.KubeVirt{}, 0, cache.Indexers{})
+	if err := kubeVirtInformer.GetStore().Add(kv); err != nil {
+		return nil, err
+	}
+	return virtconfig.NewClusterConfig(crdInformer, kubeVirtInformer, kv.Namespace)
+}
+
+func admit(ctx context.Context, virtClient kubecli.KubevirtClient, clusterConfig *virtconfig.ClusterConfig, namespace string, obj object) (*admissionv1.AdmissionResponse, error) {
+	if obj.gvk.Group != v1.GroupVersion.Group {
+		return nil, nil
+	}
+
+	ar := &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Namespace: namespace,
+			DryRun:    pointer.P(true),
+			Object:    runtime.RawExtension{Raw: obj.raw},
+		},
+	}
+
+	switch obj.gvk.Kind {
+	case v1.VirtualMachineGroupVersionKind.Kind:
+		ar.Request.Resource = webhooks.VirtualMachineGroupVersionResource
+		admitter := admitters.NewVMsAdmitter(clusterConfig, virtClient, &webhooks.Informers{}, nil)
+		return admitter.Admit(ctx, ar), nil
+	case v1.VirtualMachineInstanceGroupVersionKind.Kind:
+		// virt-api applies the defaults in the mutating webhook before the VMI is validated
+		vmi := &v1.VirtualMachineInstance{}
+		if err := json.Unmarshal(obj.raw, vmi); err != nil {
+			return nil, fmt.Errorf("error decoding VirtualMachineInstance %s: %v", obj.name, err)
+		}
+		if err := defaults.SetDefaultVirtualMachineInstance(clusterConfig, vmi); err != nil {
+			return nil, fmt.Errorf("error setting defaults of VirtualMachineInstance %s: %v", obj.name, err)
+		}
+		raw, err := json.Marshal(vmi)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding VirtualMachineInstance %s: %v", obj.name, err)
+		}
+		ar.Request.Resource = webhooks.VirtualMachineInstanceGroupVersionResource
+		ar.Request.Object.Raw = raw
+		admitter := &admitters.VMICreateAdmitter{
+			ClusterConfig: clusterConfig,
+			SpecValidators: []admitters.SpecValidator{
+				func(field *k8sfield.Path, vmiSpec *v1.VirtualMachineInstanceSpec, clusterCfg *virtconfig.ClusterConfig) []metav1.StatusCause {
+					return netadmitter.Validate(field, vmiSpec, clusterCfg)
+				},
+			},
+		}
+		return admitter.Admit(ctx, ar), nil
+	}
+	return nil, nil
+}
+
+// printResult prints the outcome of the validation of a single object and
+// returns false if the object was rejected.
+func printResult(out io.Writer, obj object, resp *admissionv1.AdmissionResponse) bool {
+	kind := obj.gvk.Kind
+	if kind == "" {
+		kind = "<unknown kind>"
+	}
+	prefix := fmt.Sprintf("%s: %s %s", obj.source, kind, obj.name)
+	if obj.source == stdin {
+		prefix = fmt.Sprintf("%s %s", kind, obj.name)
+	}
+
+	if resp == nil {
+		fmt.Fprintf(out, "%s skipped, validation of %s is not supported\n", prefix, obj.gvk.GroupKind())
+		return true
+	}
+
+	if resp.Allowed {
+		fmt.Fprintf(out, "%s is valid\n", prefix)
+	} else {
+		fmt.Fprintf(out, "%s is invalid\n", prefix)
+		if resp.Result != nil {
+			if resp.Result.Details != nil && len(resp.Result.Details.Causes) > 0 {
+				for _, cause := range resp.Result.Details.Causes {
+					if cause.Field != "" {
+						fmt.Fprintf(out, "  - %s: %s\n", cause.Field, cause.Message)
+					} else {
+						fmt.Fprintf(out, "  - %s\n", cause.Message)
+					}
+				}
+			} else {
+				fmt.Fprintf(out, "  - %s\n", resp.Result.Message)
+			}
+		}
+	}
+	for _, warning := range resp.Warnings {
+		fmt.Fprintf(out, "  warning: %s\n", warning)
+	}
+	return resp.Allowed
+}
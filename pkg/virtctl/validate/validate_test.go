@@ -0,0 +1,180 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package validate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+const (
+	validVM = `apiVersion: kubevirt.io/v1
+kind: VirtualMachine
+metadata:
+  name: valid-vm
+spec:
+  runStrategy: Always
+  template:
+    spec:
+      domain:
+        devices: {}
+        memory:
+          guest: 128Mi
+`
+	invalidVM = `apiVersion: kubevirt.io/v1
+kind: VirtualMachine
+metadata:
+  name: invalid-vm
+spec:
+  running: true
+  runStrategy: Always
+  template:
+    spec:
+      domain:
+        devices: {}
+        memory:
+          guest: 128Mi
+`
+	downwardMetricsVMI = `apiVersion: kubevirt.io/v1
+kind: VirtualMachineInstance
+metadata:
+  name: metrics-vmi
+spec:
+  domain:
+    devices:
+      downwardMetrics: {}
+    memory:
+      guest: 128Mi
+`
+	configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`
+)
+
+var _ = Describe("Validate command", func() {
+	var virtClient *kubevirtfake.Clientset
+
+	writeManifest := func(content string) string {
+		path := filepath.Join(GinkgoT().TempDir(), "manifest.yaml")
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		virtClient = kubevirtfake.NewSimpleClientset()
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().KubeVirt(metav1.NamespaceAll).
+			Return(virtClient.KubevirtV1().KubeVirts(metav1.NamespaceAll)).AnyTimes()
+	})
+
+	It("should require a filename", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("validate")
+		Expect(cmd()).To(MatchError(ContainSubstring(`required flag(s) "filename" not set`)))
+	})
+
+	It("should not allow to combine cluster config and feature gates", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("validate", "-f", "vm.yaml", "--cluster-config", "--feature-gates=Snapshot")
+		Expect(cmd()).To(MatchError(ContainSubstring("[cluster-config feature-gates] were all set")))
+	})
+
+	It("should fail if the file does not exist", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("validate", "-f", filepath.Join(GinkgoT().TempDir(), "missing.yaml"))
+		Expect(cmd()).To(MatchError(ContainSubstring("no such file or directory")))
+	})
+
+	It("should fail if the file contains no objects", func() {
+		path := writeManifest("---\n")
+		cmd := testing.NewRepeatableVirtctlCommand("validate", "-f", path)
+		Expect(cmd()).To(MatchError("no objects found in " + path))
+	})
+
+	It("should report all objects of a multi document manifest", func() {
+		path := writeManifest(validVM + "---\n" + invalidVM + "---\n" + configMap)
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("validate", "-f", path)()
+		Expect(err).To(MatchError("1 of 3 objects failed validation"))
+		Expect(string(out)).To(Equal(path + `: VirtualMachine valid-vm is valid
+` + path + `: VirtualMachine invalid-vm is invalid
+  - spec.running: Running and RunStrategy are mutually exclusive. Note that Running is deprecated, please use RunStrategy instead
+` + path + `: ConfigMap config skipped, validation of ConfigMap is not supported
+`))
+	})
+
+	It("should report gated features as invalid with the offline defaults", func() {
+		path := writeManifest(downwardMetricsVMI)
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("validate", "-f", path)()
+		Expect(err).To(MatchError("1 of 1 objects failed validation"))
+		Expect(string(out)).To(ContainSubstring("VirtualMachineInstance metrics-vmi is invalid"))
+		Expect(string(out)).To(ContainSubstring("DownwardMetrics feature gate is not enabled"))
+	})
+
+	It("should accept gated features enabled by the feature gates flag", func() {
+		path := writeManifest(downwardMetricsVMI)
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("validate", "-f", path, "--feature-gates=DownwardMetrics")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal(path + ": VirtualMachineInstance metrics-vmi is valid\n"))
+	})
+
+	Context("with cluster config", func() {
+		It("should fail if KubeVirt is not installed", func() {
+			path := writeManifest(validVM)
+			cmd := testing.NewRepeatableVirtctlCommand("validate", "-f", path, "--cluster-config")
+			Expect(cmd()).To(MatchError("could not detect a KubeVirt installation"))
+		})
+
+		It("should use the feature gates of the KubeVirt installation", func() {
+			kv := &v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						DeveloperConfiguration: &v1.DeveloperConfiguration{
+							FeatureGates: []string{"DownwardMetrics"},
+						},
+					},
+				},
+				Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeployed, DefaultArchitecture: runtime.GOARCH},
+			}
+			_, err := virtClient.KubevirtV1().KubeVirts(kv.Namespace).Create(context.Background(), kv, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			path := writeManifest(downwardMetricsVMI)
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("validate", "-f", path, "--cluster-config")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(Equal(path + ": VirtualMachineInstance metrics-vmi is valid\n"))
+		})
+	})
+})
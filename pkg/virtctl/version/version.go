@@ -1,18 +1,36 @@
 package version
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/coreos/go-semver/semver"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
 	client_version "kubevirt.io/client-go/version"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
+const (
+	clientFlag = "client"
+	checkFlag  = "check"
+
+	unknown = "unknown"
+)
+
 type version struct {
 	clientOnly bool
+	check      bool
 }
 
 func VersionCommand() *cobra.Command {
@@ -24,7 +42,8 @@ func VersionCommand() *cobra.Command {
 		Args:    cobra.NoArgs,
 		RunE:    v.Run,
 	}
-	cmd.Flags().BoolVarP(&v.clientOnly, "client", "c", v.clientOnly, "Client version only (no server required).")
+	cmd.Flags().BoolVarP(&v.clientOnly, clientFlag, "c", v.clientOnly, "Client version only (no server required).")
+	cmd.Flags().BoolVar(&v.check, checkFlag, v.check, "Check the compatibility of the client with the server, list the enabled feature gates and the commands and flags which will not work against the server.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 
 	return cmd
@@ -32,10 +51,17 @@ func VersionCommand() *cobra.Command {
 
 func usage() string {
 	return `  # Print the client and server versions for the current context:
-  {{ProgramName}} version`
+  {{ProgramName}} version
+
+  # Check whether the client works with the server of the current context:
+  {{ProgramName}} version --check`
 }
 
 func (v *version) Run(cmd *cobra.Command, _ []string) error {
+	if v.clientOnly && v.check {
+		return result.NewUsageError(fmt.Errorf("--%s and --%s are mutually exclusive", clientFlag, checkFlag))
+	}
+
 	cmd.Printf("Client Version: %#v\n", client_version.Get())
 
 	if v.clientOnly {
@@ -53,5 +79,110 @@ func (v *version) Run(cmd *cobra.Command, _ []string) error {
 	}
 
 	cmd.Printf("Server Version: %#v\n", *serverInfo)
+
+	if v.check {
+		checkCompatibility(cmd, virtClient, serverInfo)
+	}
 	return nil
 }
+
+// checkCompatibility reports the version skew, the enabled feature gates and the commands
+// and flags whose requirements are not met. Information which cannot be retrieved, e.g.
+// because of missing permissions, is reported as unknown and its requirements are not checked.
+func checkCompatibility(cmd *cobra.Command, virtClient kubecli.KubevirtClient, serverInfo *client_version.Info) {
+	out := cmd.OutOrStdout()
+	cluster := &requirements.Cluster{}
+
+	clientSemVer := parseVersion(client_version.Get().GitVersion)
+	cluster.Version = parseVersion(serverInfo.GitVersion)
+	fmt.Fprintf(out, "\nCompatibility: %s\n", versionSkew(clientSemVer, cluster.Version))
+
+	featureGates, err := enabledFeatureGates(cmd.Context(), virtClient)
+	if err != nil {
+		fmt.Fprintf(out, "Feature Gates: %s (%v)\n", unknown, err)
+	} else {
+		cluster.FeatureGates = map[string]bool{}
+		for _, featureGate := range featureGates {
+			cluster.FeatureGates[featureGate] = true
+		}
+		fmt.Fprintf(out, "Feature Gates: %s\n", valueOrNone(strings.Join(featureGates, ", ")))
+	}
+
+	cluster.Subresources, err = servedSubresources(virtClient)
+	if err != nil {
+		fmt.Fprintf(out, "Subresources: %s (%v)\n", unknown, err)
+	}
+
+	incompatibilities := requirements.Check(cmd.Root(), cluster)
+	if len(incompatibilities) == 0 {
+		fmt.Fprintln(out, "Unsupported Commands: <none>")
+		return
+	}
+	fmt.Fprintln(out, "Unsupported Commands:")
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "  COMMAND\tREASON")
+	for _, incompatibility := range incompatibilities {
+		fmt.Fprintf(w, "  %s\t%s\n", incompatibility.Command, incompatibility.Reason)
+	}
+	w.Flush()
+}
+
+// parseVersion returns nil for unparsable versions and development builds, whose
+// version does not reflect the features they contain.
+func parseVersion(gitVersion string) *semver.Version {
+	version, err := semver.NewVersion(strings.TrimPrefix(gitVersion, "v"))
+	if err != nil || (version.Major == 0 && version.Minor == 0) {
+		return nil
+	}
+	return version
+}
+
+func versionSkew(client, server *semver.Version) string {
+	switch {
+	case client == nil || server == nil:
+		return fmt.Sprintf("%s, the client or the server is a development build", unknown)
+	case client.Major == server.Major && client.Minor == server.Minor:
+		return "client and server versions match"
+	case client.LessThan(*server):
+		return fmt.Sprintf("client v%s is older than server v%s, new server features are not available", client, server)
+	default:
+		return fmt.Sprintf("client v%s is newer than server v%s, new client features may not work", client, server)
+	}
+}
+
+func enabledFeatureGates(ctx context.Context, virtClient kubecli.KubevirtClient) ([]string, error) {
+	kvs, err := virtClient.KubeVirt(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list KubeVirt CRs: %v", err)
+	}
+	if len(kvs.Items) != 1 {
+		return nil, fmt.Errorf("expected one KubeVirt CR, found %d", len(kvs.Items))
+	}
+	developerConfiguration := kvs.Items[0].Spec.Configuration.DeveloperConfiguration
+	if developerConfiguration == nil {
+		return nil, nil
+	}
+	featureGates := append([]string{}, developerConfiguration.FeatureGates...)
+	sort.Strings(featureGates)
+	return featureGates, nil
+}
+
+func servedSubresources(virtClient kubecli.KubevirtClient) (map[string]bool, error) {
+	groupVersion := v1.SubresourceGroupVersions[0].String()
+	resources, err := virtClient.DiscoveryClient().ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		return nil, fmt.Errorf("could not discover %s: %v", groupVersion, err)
+	}
+	subresources := map[string]bool{}
+	for _, resource := range resources.APIResources {
+		subresources[resource.Name] = true
+	}
+	return subresources, nil
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
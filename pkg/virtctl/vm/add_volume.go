@@ -27,24 +27,35 @@ import (
 
 	"github.com/spf13/cobra"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 const (
-	serialArg       = "serial"
-	cacheArg        = "cache"
-	diskTypeArg     = "disk-type"
-	busTypeArg      = "bus"
-	concurrentError = "the server rejected our request due to an error in our request"
-	maxRetries      = 15
+	serialArg            = "serial"
+	cacheArg             = "cache"
+	diskTypeArg          = "disk-type"
+	busTypeArg           = "bus"
+	dedicatedIOThreadArg = "dedicated-iothread"
+	createArg            = "create"
+	sizeArg              = "size"
+	storageClassArg      = "storage-class"
+	concurrentError      = "the server rejected our request due to an error in our request"
+	maxRetries           = 15
 )
 
 var (
@@ -52,25 +63,39 @@ var (
 	cache    string
 	diskType string
 	busType  string
+
+	dedicatedIOThread bool
+
+	createVolume bool
+	volumeSize   string
+	storageClass string
 )
 
 func NewAddVolumeCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "addvolume VMI",
-		Short:   "add a volume to a running VM",
-		Example: usageAddVolume(),
-		Args:    cobra.ExactArgs(1),
-		RunE:    addVolumeRun,
+		Use:               "addvolume VMI",
+		Short:             "add a volume to a running VM",
+		Example:           usageAddVolume(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		RunE:              addVolumeRun,
 	}
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	cmd.Flags().StringVar(&volumeName, volumeNameArg, "", "name used in volumes section of spec")
 	cmd.MarkFlagRequired(volumeNameArg)
 	cmd.Flags().StringVar(&serial, serialArg, "", "serial number you want to assign to the disk")
-	cmd.Flags().StringVar(&cache, cacheArg, "", "caching options attribute control the cache mechanism")
+	cmd.Flags().StringVar(&cache, cacheArg, "", fmt.Sprintf("caching options attribute control the cache mechanism (%s/%s/%s)", v1.CacheNone, v1.CacheWriteThrough, v1.CacheWriteBack))
 	cmd.Flags().BoolVar(&persist, persistArg, false, "[deprecated] this flag has no effect and will be removed in a future release")
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
 	cmd.Flags().StringVar(&diskType, diskTypeArg, "disk", "specifies disk type to be hotplugged (disk/lun). Disk by default.")
-	cmd.Flags().StringVar(&busType, busTypeArg, string(v1.DiskBusSCSI), fmt.Sprintf("specifies disk bus. %s by default.", v1.DiskBusSCSI))
+	cmd.Flags().StringVar(&busType, busTypeArg, string(v1.DiskBusSCSI), fmt.Sprintf("specifies disk bus (%s/%s). %s by default.", v1.DiskBusSCSI, v1.DiskBusVirtio, v1.DiskBusSCSI))
+	cmd.Flags().BoolVar(&dedicatedIOThread, dedicatedIOThreadArg, false, fmt.Sprintf("run the disk in its own IOThread. Requires --%s=%s.", busTypeArg, v1.DiskBusVirtio))
+	cmd.Flags().BoolVar(&createVolume, createArg, false, "create a new blank DataVolume named after --volume-name and attach it")
+	cmd.Flags().StringVar(&volumeSize, sizeArg, "", "size of the DataVolume created with --create, e.g. 10Gi")
+	cmd.Flags().StringVar(&storageClass, storageClassArg, "", "storage class of the DataVolume created with --create. The default storage class is used if omitted.")
+	requirements.Subresources(cmd, "virtualmachineinstances/addvolume")
+	requirements.AnyFeatureGate(cmd, featuregate.HotplugVolumesGate, featuregate.DeclarativeHotplugVolumesGate)
+	requirements.FlagServerVersion(cmd, dedicatedIOThreadArg, "v1.8.0")
 
 	return cmd
 }
@@ -88,6 +113,12 @@ func usageAddVolume() string {
 
   #Dynamically attach a volume with 'none' cache attribute to a running VM.
   {{ProgramName}} addvolume fedora-dv --volume-name=example-dv --cache=none
+
+  #Dynamically attach a volume on the virtio bus running in its own IOThread to a running VM.
+  {{ProgramName}} addvolume fedora-dv --volume-name=example-dv --bus=virtio --dedicated-iothread
+
+  #Create a new blank 10Gi DataVolume and dynamically attach it to a running VM.
+  {{ProgramName}} addvolume fedora-dv --volume-name=example-dv --create --size=10Gi --storage-class=local
   `
 }
 
@@ -97,9 +128,90 @@ func addVolumeRun(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if !createVolume {
+		for _, arg := range []string{sizeArg, storageClassArg} {
+			if cmd.Flags().Changed(arg) {
+				return result.NewUsageError(fmt.Errorf("--%s can only be used with --%s", arg, createArg))
+			}
+		}
+		dryRunOption := setDryRunOption(dryRun)
+		volumeSource, err := getVolumeSourceFromVolume(volumeName, namespace, virtClient)
+		if err != nil {
+			return fmt.Errorf("error adding volume, %w", err)
+		}
+		return addVolume(cmd.Context(), args[0], volumeName, namespace, virtClient, volumeSource, &dryRunOption)
+	}
+
+	if volumeSize == "" {
+		return result.NewUsageError(fmt.Errorf("--%s is required with --%s", sizeArg, createArg))
+	}
+	size, err := resource.ParseQuantity(volumeSize)
+	if err != nil {
+		return result.NewUsageError(fmt.Errorf("invalid --%s %q: %w", sizeArg, volumeSize, err))
+	}
+
 	dryRunOption := setDryRunOption(dryRun)
+	return createAndAddVolume(cmd.Context(), args[0], namespace, size, virtClient, &dryRunOption)
+}
 
-	return addVolume(args[0], volumeName, namespace, virtClient, &dryRunOption)
+// createAndAddVolume creates a blank DataVolume and hotplugs it. The
+// DataVolume is deleted again if it could not be attached.
+func createAndAddVolume(ctx context.Context, vmiName, namespace string, size resource.Quantity, virtClient kubecli.KubevirtClient, dryRunOption *[]string) error {
+	dv := newBlankDataVolume(volumeName, namespace, size, storageClass)
+	_, err := virtClient.CdiClient().CdiV1beta1().DataVolumes(namespace).Create(ctx, dv, metav1.CreateOptions{DryRun: *dryRunOption})
+	if k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("error creating volume, DataVolume %s already exists, omit --%s to attach it", volumeName, createArg)
+	}
+	if err != nil {
+		return fmt.Errorf("error creating volume, %w", err)
+	}
+	result.RecordChange(ctx, result.Change{Action: "Create", Kind: "DataVolume", Namespace: namespace, Name: volumeName, DryRun: dryRun})
+	fmt.Printf("Successfully created DataVolume %s\n", volumeName)
+
+	volumeSource := &v1.HotplugVolumeSource{
+		DataVolume: &v1.DataVolumeSource{
+			Name:         volumeName,
+			Hotpluggable: true,
+		},
+	}
+	if err := addVolume(ctx, vmiName, volumeName, namespace, virtClient, volumeSource, dryRunOption); err != nil {
+		if !dryRun {
+			if delErr := virtClient.CdiClient().CdiV1beta1().DataVolumes(namespace).Delete(context.Background(), volumeName, metav1.DeleteOptions{}); delErr != nil {
+				return fmt.Errorf("%w, failed to delete DataVolume %s: %v", err, volumeName, delErr)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func newBlankDataVolume(name, namespace string, size resource.Quantity, storageClass string) *cdiv1.DataVolume {
+	dv := &cdiv1.DataVolume{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: cdiv1.SchemeGroupVersion.String(),
+			Kind:       "DataVolume",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: cdiv1.DataVolumeSpec{
+			Source: &cdiv1.DataVolumeSource{
+				Blank: &cdiv1.DataVolumeBlankImage{},
+			},
+			Storage: &cdiv1.StorageSpec{
+				Resources: k8sv1.VolumeResourceRequirements{
+					Requests: k8sv1.ResourceList{
+						k8sv1.ResourceStorage: size,
+					},
+				},
+			},
+		},
+	}
+	if storageClass != "" {
+		dv.Spec.Storage.StorageClassName = &storageClass
+	}
+	return dv
 }
 
 func getVolumeSourceFromVolume(volumeName, namespace string, virtClient kubecli.KubevirtClient) (*v1.HotplugVolumeSource, error) {
@@ -129,11 +241,7 @@ func getVolumeSourceFromVolume(volumeName, namespace string, virtClient kubecli.
 	return nil, fmt.Errorf("Volume %s is not a DataVolume or PersistentVolumeClaim", volumeName)
 }
 
-func addVolume(vmiName, volumeName, namespace string, virtClient kubecli.KubevirtClient, dryRunOption *[]string) error {
-	volumeSource, err := getVolumeSourceFromVolume(volumeName, namespace, virtClient)
-	if err != nil {
-		return fmt.Errorf("error adding volume, %v", err)
-	}
+func addVolume(ctx context.Context, vmiName, volumeName, namespace string, virtClient kubecli.KubevirtClient, volumeSource *v1.HotplugVolumeSource, dryRunOption *[]string) error {
 	hotplugRequest := &v1.AddVolumeOptions{
 		Name: volumeName,
 		Disk: &v1.Disk{
@@ -162,6 +270,12 @@ func addVolume(vmiName, volumeName, namespace string, virtClient kubecli.Kubevir
 	default:
 		return fmt.Errorf("Invalid disk type '%s'. Only LUN and Disk are supported.", diskType)
 	}
+	if dedicatedIOThread {
+		if bus != v1.DiskBusVirtio {
+			return fmt.Errorf("--%s is only supported for disks on the '%s' bus", dedicatedIOThreadArg, v1.DiskBusVirtio)
+		}
+		hotplugRequest.Disk.DedicatedIOThread = pointer.P(true)
+	}
 
 	if serial != "" {
 		hotplugRequest.Disk.Serial = serial
@@ -177,18 +291,21 @@ func addVolume(vmiName, volumeName, namespace string, virtClient kubecli.Kubevir
 			return fmt.Errorf("error adding volume, invalid cache value %s", cache)
 		}
 	}
+	var err error
 	retry := 0
+	kind := v1.VirtualMachineGroupVersionKind.Kind
 	for retry < maxRetries {
 		// default to adding volume to both VM and VMI if owner VM exists
 		err = virtClient.VirtualMachine(namespace).AddVolume(context.Background(), vmiName, hotplugRequest)
 
 		// If VM is not found, VMI is standalone
 		if k8serrors.IsNotFound(err) {
+			kind = v1.VirtualMachineInstanceGroupVersionKind.Kind
 			err = virtClient.VirtualMachineInstance(namespace).AddVolume(context.Background(), vmiName, hotplugRequest)
 		}
 
 		if err != nil && err.Error() != concurrentError {
-			return fmt.Errorf("error adding volume, %v", err)
+			return fmt.Errorf("error adding volume, %w", err)
 		}
 		if err == nil {
 			break
@@ -201,6 +318,7 @@ func addVolume(vmiName, volumeName, namespace string, virtClient kubecli.Kubevir
 	if err != nil && retry == maxRetries {
 		return fmt.Errorf("error adding volume after %d retries", maxRetries)
 	}
+	result.RecordChange(ctx, result.Change{Action: "AddVolume", Kind: kind, Namespace: namespace, Name: vmiName, DryRun: dryRun})
 	fmt.Printf("Successfully submitted add volume request to VM %s for volume %s\n", vmiName, volumeName)
 	return nil
 }
@@ -30,6 +30,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
@@ -78,6 +79,10 @@ var _ = Describe("Add volume command", func() {
 		Entry("addvolume no args", "accepts 1 arg(s), received 0"),
 		Entry("addvolume name, missing required volume-name", "required flag(s)", vmiName),
 		Entry("addvolume name, invalid extra parameter", "unknown flag", vmiName, "--volume-name=blah", "--invalid=test"),
+		Entry("addvolume size without create", "--size can only be used with --create", vmiName, "--volume-name=blah", "--size=1Gi"),
+		Entry("addvolume storage-class without create", "--storage-class can only be used with --create", vmiName, "--volume-name=blah", "--storage-class=local"),
+		Entry("addvolume create without size", "--size is required with --create", vmiName, "--volume-name=blah", "--create"),
+		Entry("addvolume create with invalid size", "invalid --size \"lots\"", vmiName, "--volume-name=blah", "--create", "--size=lots"),
 	)
 
 	It("should fail when trying to add volume with invalid disk type", func() {
@@ -178,6 +183,8 @@ var _ = Describe("Add volume command", func() {
 				Entry("cache writethrough", "--cache=writethrough", verifyDiskSerial(volumeName), verifyCache(v1.CacheWriteThrough)),
 				Entry("cache writeback", "--cache=writeback", verifyDiskSerial(volumeName), verifyCache(v1.CacheWriteBack)),
 				Entry("virtio bus", "--bus=virtio", verifyDiskSerial(volumeName), verifyBus(v1.DiskBusVirtio)),
+				Entry("virtio bus with dedicated IOThread", "--bus=virtio --dedicated-iothread", verifyDiskSerial(volumeName), verifyBus(v1.DiskBusVirtio), verifyDedicatedIOThread),
+				Entry("all tuning options", "--bus=virtio --dedicated-iothread --cache=none", verifyBus(v1.DiskBusVirtio), verifyDedicatedIOThread, verifyCache(v1.CacheNone)),
 			)
 
 			It("should fail immediately on non concurrent error", func() {
@@ -212,6 +219,68 @@ var _ = Describe("Add volume command", func() {
 				Expect(runCmd("--disk-type=lun --bus=virtio")).To(
 					MatchError(ContainSubstring("Invalid bus type 'virtio' for LUN disk. Only 'scsi' bus is supported.")))
 			})
+
+			DescribeTable("should fail addvolume with dedicated IOThread and", func(arg string) {
+				Expect(runCmd(arg)).To(
+					MatchError(ContainSubstring("--dedicated-iothread is only supported for disks on the 'virtio' bus")))
+				Expect(kvtesting.FilterActions(&virtClient.Fake, "put", "virtualmachines", "addvolume")).To(BeEmpty())
+			},
+				Entry("default bus", "--dedicated-iothread"),
+				Entry("scsi bus", "--bus=scsi --dedicated-iothread"),
+				Entry("LUN", "--disk-type=lun --dedicated-iothread"),
+			)
+		})
+
+		Context("with --create", func() {
+			BeforeEach(func() {
+				kubecli.MockKubevirtClientInstance.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+			})
+
+			It("should create a blank DataVolume and attach it", func() {
+				expectVMEndpointAddVolume(verifyDVVolumeSource, verifyDiskSerial(volumeName))
+				Expect(runCmd("--create --size=10Gi --storage-class=local")).To(Succeed())
+				Expect(kvtesting.FilterActions(&virtClient.Fake, "put", "virtualmachines", "addvolume")).To(HaveLen(1))
+
+				dv, err := cdiClient.CdiV1beta1().DataVolumes(metav1.NamespaceDefault).Get(context.Background(), volumeName, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dv.Spec.Source.Blank).ToNot(BeNil())
+				Expect(dv.Spec.Storage.Resources.Requests).To(HaveKeyWithValue(k8sv1.ResourceStorage, resource.MustParse("10Gi")))
+				Expect(dv.Spec.Storage.StorageClassName).To(HaveValue(Equal("local")))
+			})
+
+			It("should use the default storage class when none is given", func() {
+				expectVMEndpointAddVolume(verifyDVVolumeSource)
+				Expect(runCmd("--create --size=1Gi")).To(Succeed())
+
+				dv, err := cdiClient.CdiV1beta1().DataVolumes(metav1.NamespaceDefault).Get(context.Background(), volumeName, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dv.Spec.Storage.StorageClassName).To(BeNil())
+			})
+
+			It("should create the DataVolume with dry-run", func() {
+				expectVMEndpointAddVolume(verifyDVVolumeSource, verifyDryRun)
+				Expect(runCmd("--create --size=1Gi --dry-run")).To(Succeed())
+
+				createActions := kvtesting.FilterActions(&cdiClient.Fake, "create", "datavolumes")
+				Expect(createActions).To(HaveLen(1))
+				Expect(createActions[0].(k8stesting.CreateActionImpl).GetCreateOptions().DryRun).To(Equal([]string{metav1.DryRunAll}))
+			})
+
+			It("should fail if the DataVolume already exists", func() {
+				_, err := cdiClient.CdiV1beta1().DataVolumes(metav1.NamespaceDefault).Create(context.Background(),
+					&v1beta1.DataVolume{ObjectMeta: metav1.ObjectMeta{Name: volumeName}}, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(runCmd("--create --size=1Gi")).To(MatchError(ContainSubstring("DataVolume testvolume already exists")))
+			})
+
+			It("should delete the created DataVolume if it cannot be attached", func() {
+				expectVMEndpointAddVolumeErrorFunc(func() error { return fmt.Errorf("fatal error") }, verifyDVVolumeSource)
+				Expect(runCmd("--create --size=1Gi")).To(MatchError(ContainSubstring("fatal error")))
+
+				_, err := cdiClient.CdiV1beta1().DataVolumes(metav1.NamespaceDefault).Get(context.Background(), volumeName, metav1.GetOptions{})
+				Expect(err).To(MatchError(ContainSubstring("not found")))
+			})
 		})
 
 		Context("with PVC", func() {
@@ -292,3 +361,7 @@ func verifyBus(bus v1.DiskBus) verifyFn {
 		Expect(volumeOptions.Disk.Disk.Bus).To(Equal(bus))
 	}
 }
+
+func verifyDedicatedIOThread(volumeOptions *v1.AddVolumeOptions) {
+	Expect(volumeOptions.Disk.DedicatedIOThread).To(HaveValue(BeTrue()))
+}
@@ -22,8 +22,11 @@ package vm
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/bulk"
 )
 
 const (
@@ -49,7 +52,11 @@ var (
 )
 
 type Command struct {
-	command string
+	command      string
+	bulk         bulk.Options
+	wait         bool
+	timeout      time.Duration
+	waitForAgent bool
 }
 
 func usage(cmd string) string {
@@ -60,6 +67,11 @@ func usage(cmd string) string {
 	return fmt.Sprintf("  # %s a virtual machine called 'myvm':\n  {{ProgramName}} %s myvm", strings.Title(cmd), cmd)
 }
 
+func usageBulk(cmd string) string {
+	return fmt.Sprintf("%s\n\n  # %s all virtual machines labeled app=db in all namespaces, at most 10 at a time:\n  {{ProgramName}} %s -l app=db --all-namespaces --concurrency 10",
+		usage(cmd), strings.Title(cmd), cmd)
+}
+
 func setDryRunOption(dryRun bool) []string {
 	if dryRun {
 		fmt.Printf("Dry Run execution\n")
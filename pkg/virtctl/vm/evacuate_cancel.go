@@ -29,6 +29,9 @@ import (
 	"kubevirt.io/client-go/kubecli"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -36,16 +39,18 @@ func NewEvacuateCancelCommand() *cobra.Command {
 	c := &evacuateCancelCommand{}
 
 	cmd := &cobra.Command{
-		Use:     "evacuate-cancel (vm <vm-name> | vmi <vmi-name>)",
-		Short:   "Cancel evacuation for a VM, VMI, or all VMIs on a node",
-		Example: usageEvacuateCancel(),
-		Args:    cobra.ExactArgs(2),
-		RunE:    c.Run,
+		Use:               "evacuate-cancel (vm <vm-name> | vmi <vmi-name>)",
+		Short:             "Cancel evacuation for a VM, VMI, or all VMIs on a node",
+		Example:           usageEvacuateCancel(),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.KindAndName,
+		RunE:              c.Run,
 	}
 
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
 
 	cmd.SetUsageTemplate(templates.UsageTemplate())
+	requirements.Subresources(cmd, "virtualmachines/evacuate/cancel")
 
 	return cmd
 }
@@ -97,7 +102,7 @@ func (c *evacuateCancelCommand) getHandler(kind string) (func(name, namespace st
 	case "vmi", "vmis", "virtualmachineinstance", "virtualmachineinstances":
 		return c.handleVMI, nil
 	}
-	return nil, fmt.Errorf("unsupported resource type %q", kind)
+	return nil, result.NewUsageError(fmt.Errorf("unsupported resource type %q", kind))
 }
 
 func (c *evacuateCancelCommand) handleVM(name, namespace string, opts *virtv1.EvacuateCancelOptions) error {
@@ -105,6 +110,7 @@ func (c *evacuateCancelCommand) handleVM(name, namespace string, opts *virtv1.Ev
 	if err != nil {
 		return fmt.Errorf("error canceling evacuation for VM %s/%s: %w", namespace, name, err)
 	}
+	result.RecordChange(c.cmd.Context(), result.Change{Action: "EvacuateCancel", Kind: virtv1.VirtualMachineGroupVersionKind.Kind, Namespace: namespace, Name: name, DryRun: dryRun})
 	c.cmd.Printf("VM %s/%s was canceled evacuation\n", namespace, name)
 	return nil
 }
@@ -114,6 +120,7 @@ func (c *evacuateCancelCommand) handleVMI(name, namespace string, opts *virtv1.E
 	if err != nil {
 		return fmt.Errorf("error canceling evacuation for VMI %s/%s: %w", namespace, name, err)
 	}
+	result.RecordChange(c.cmd.Context(), result.Change{Action: "EvacuateCancel", Kind: virtv1.VirtualMachineInstanceGroupVersionKind.Kind, Namespace: namespace, Name: name, DryRun: dryRun})
 	c.cmd.Printf("VMI %s/%s was canceled evacuation\n", namespace, name)
 	return nil
 }
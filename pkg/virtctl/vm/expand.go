@@ -34,6 +34,7 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -55,11 +56,12 @@ var (
 func NewExpandCommand() *cobra.Command {
 	c := Command{}
 	cmd := &cobra.Command{
-		Use:     "expand (VM)",
-		Short:   "Return the VirtualMachine object with expanded instancetype and preference.",
-		Example: usageExpand(),
-		Args:    cobra.MatchAll(cobra.ExactArgs(0), expandArgs()),
-		RunE:    c.expandRun,
+		Use:               "expand (VM)",
+		Short:             "Return the VirtualMachine object with expanded instancetype and preference.",
+		Example:           usageExpand(),
+		Args:              cobra.MatchAll(cobra.ExactArgs(0), expandArgs()),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.expandRun,
 	}
 	cmd.Flags().StringVar(&vmName, vmArg, "", "Specify VirtualMachine name that should be expanded. Mutually exclusive with \"--file\" flag.")
 	cmd.Flags().StringVarP(&filePath, filePathArg, filePathArgShort, "", "If present, the Virtual Machine spec in provided file will be expanded. Mutually exclusive with \"--vm\" flag.")
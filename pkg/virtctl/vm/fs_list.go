@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -34,11 +35,12 @@ const COMMAND_FSLIST = "fslist"
 
 func NewFSListCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "fslist (VMI)",
-		Short:   "Return full list of filesystems available on the guest machine.",
-		Example: usage(COMMAND_FSLIST),
-		Args:    cobra.ExactArgs(1),
-		RunE:    fsListRun,
+		Use:               "fslist (VMI)",
+		Short:             "Return full list of filesystems available on the guest machine.",
+		Example:           usage(COMMAND_FSLIST),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		RunE:              fsListRun,
 	}
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -34,11 +35,12 @@ const COMMAND_GUESTOSINFO = "guestosinfo"
 
 func NewGuestOsInfoCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "guestosinfo (VMI)",
-		Short:   "Return guest agent info about operating system.",
-		Example: usage(COMMAND_GUESTOSINFO),
-		Args:    cobra.ExactArgs(1),
-		RunE:    guestOsInfoRun,
+		Use:               "guestosinfo (VMI)",
+		Short:             "Return guest agent info about operating system.",
+		Example:           usage(COMMAND_GUESTOSINFO),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		RunE:              guestOsInfoRun,
 	}
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
@@ -0,0 +1,503 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
+	"kubevirt.io/kubevirt/pkg/network/vmispec"
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_ADDINTERFACE    = "addinterface"
+	COMMAND_REMOVEINTERFACE = "removeinterface"
+
+	networkArg                         = "network"
+	networkAttachmentDefinitionNameArg = "network-attachment-definition-name"
+	interfaceNameArg                   = "name"
+	waitArg                            = "wait"
+	timeoutArg                         = "timeout"
+
+	defaultInterfaceTimeout = 3 * time.Minute
+	interfacePollInterval   = time.Second
+
+	interfacesPath = "/spec/template/spec/domain/devices/interfaces"
+	networksPath   = "/spec/template/spec/networks"
+)
+
+// interfaceNameRegex matches the interface names accepted by the VM admitter.
+var interfaceNameRegex = regexp.MustCompile(`^[A-Za-z0-9-_]+$`)
+
+type interfaceCommand struct {
+	networkAttachmentDefinitionName string
+	interfaceName                   string
+	wait                            bool
+	timeout                         time.Duration
+}
+
+// interfaceStep is one of the steps an interface change goes through until
+// it is reflected in the guest. The condition is evaluated against the
+// current VM and VMI. If skip returns a reason, the step can't be observed
+// and is reported as skipped instead of being waited for.
+type interfaceStep struct {
+	description string
+	condition   func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) (bool, error)
+	skip        func(vmi *v1.VirtualMachineInstance) string
+}
+
+func NewAddInterfaceCommand() *cobra.Command {
+	c := interfaceCommand{}
+	cmd := &cobra.Command{
+		Use:               "addinterface VM",
+		Short:             "add a network interface to a VM",
+		Example:           usageAddInterface(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.addInterfaceRun,
+	}
+	cmd.Flags().StringVar(&c.networkAttachmentDefinitionName, networkArg, "",
+		"name of the NetworkAttachmentDefinition the interface is connected to, optionally prefixed by its namespace as in 'namespace/name'")
+	cmd.Flags().StringVar(&c.networkAttachmentDefinitionName, networkAttachmentDefinitionNameArg, "", fmt.Sprintf("alias of --%s", networkArg))
+	cmd.MarkFlagsOneRequired(networkArg, networkAttachmentDefinitionNameArg)
+	cmd.MarkFlagsMutuallyExclusive(networkArg, networkAttachmentDefinitionNameArg)
+	cmd.Flags().StringVar(&c.interfaceName, interfaceNameArg, "", "name of the interface and its network in the VM spec")
+	cmd.MarkFlagRequired(interfaceNameArg)
+	cmd.Flags().BoolVar(&persist, persistArg, false, "[deprecated] this flag has no effect and will be removed in a future release")
+	c.addWaitFlags(cmd, "the guest reports the interface")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewRemoveInterfaceCommand() *cobra.Command {
+	c := interfaceCommand{}
+	cmd := &cobra.Command{
+		Use:               "removeinterface VM",
+		Short:             "remove a network interface from a VM",
+		Example:           usageRemoveInterface(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.removeInterfaceRun,
+	}
+	cmd.Flags().StringVar(&c.interfaceName, interfaceNameArg, "", "name of the interface in the VM spec")
+	cmd.MarkFlagRequired(interfaceNameArg)
+	cmd.Flags().StringVar(&c.networkAttachmentDefinitionName, networkArg, "",
+		"if set, only remove the interface if it is connected to this NetworkAttachmentDefinition, optionally prefixed by its namespace as in 'namespace/name'")
+	cmd.Flags().BoolVar(&persist, persistArg, false, "[deprecated] this flag has no effect and will be removed in a future release")
+	c.addWaitFlags(cmd, "the interface is removed from the VM")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func (c *interfaceCommand) addWaitFlags(cmd *cobra.Command, what string) {
+	cmd.Flags().BoolVar(&c.wait, waitArg, false,
+		fmt.Sprintf("wait until %s, reporting each step. The change of the VM spec is rolled back if a step fails.", what))
+	cmd.Flags().DurationVar(&c.timeout, timeoutArg, defaultInterfaceTimeout, "the maximum time to wait for each step when --wait is set")
+}
+
+func usageAddInterface() string {
+	return `  #Dynamically attach a bridge interface connected to the network 'my-net' to the VM 'my-vm':
+  {{ProgramName}} addinterface my-vm --network my-net --name my-iface
+
+  #Dynamically attach an interface connected to the network 'my-net' of the namespace 'other-ns':
+  {{ProgramName}} addinterface my-vm --network other-ns/my-net --name my-iface
+
+  #Dynamically attach an interface and wait until it is reported by the guest agent. The change is rolled back on failure:
+  {{ProgramName}} addinterface my-vm --network my-net --name my-iface --wait`
+}
+
+func usageRemoveInterface() string {
+	return `  #Dynamically detach the interface 'my-iface' from the VM 'my-vm':
+  {{ProgramName}} removeinterface my-vm --name my-iface
+
+  #Dynamically detach the interface 'my-iface' only if it is connected to the network 'my-net':
+  {{ProgramName}} removeinterface my-vm --network my-net --name my-iface
+
+  #Dynamically detach an interface and wait until it is removed. The change is rolled back on failure:
+  {{ProgramName}} removeinterface my-vm --name my-iface --wait`
+}
+
+// validate rejects invalid flags before the VM is changed.
+func (c *interfaceCommand) validate() error {
+	if c.wait && c.timeout <= 0 {
+		return fmt.Errorf("the timeout must be greater than zero")
+	}
+	if !interfaceNameRegex.MatchString(c.interfaceName) {
+		return fmt.Errorf("invalid interface name %q, it can only contain alphabetical characters, numbers, dashes (-) or underscores (_)", c.interfaceName)
+	}
+	if c.networkAttachmentDefinitionName == "" {
+		return nil
+	}
+	for _, part := range strings.SplitN(c.networkAttachmentDefinitionName, "/", 2) {
+		if msgs := k8svalidation.IsDNS1123Subdomain(part); len(msgs) > 0 {
+			return fmt.Errorf("invalid network %q: %s", c.networkAttachmentDefinitionName, strings.Join(msgs, ", "))
+		}
+	}
+	return nil
+}
+
+func (c *interfaceCommand) addInterfaceRun(cmd *cobra.Command, args []string) error {
+	if err := c.validate(); err != nil {
+		return result.NewUsageError(err)
+	}
+	vmName := args[0]
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	vm, err := virtClient.VirtualMachine(namespace).Get(cmd.Context(), vmName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error adding interface, %w", err)
+	}
+	if vm.Spec.Template == nil {
+		return fmt.Errorf("error adding interface, VM %s has no template", vmName)
+	}
+	templateSpec := &vm.Spec.Template.Spec
+	if vmispec.LookupInterfaceByName(templateSpec.Domain.Devices.Interfaces, c.interfaceName) != nil ||
+		vmispec.LookupNetworkByName(templateSpec.Networks, c.interfaceName) != nil {
+		return fmt.Errorf("error adding interface, VM %s already has an interface or network named %s", vmName, c.interfaceName)
+	}
+
+	ifaces := append(append([]v1.Interface{}, templateSpec.Domain.Devices.Interfaces...), v1.Interface{
+		Name:                   c.interfaceName,
+		InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+	})
+	networks := append(append([]v1.Network{}, templateSpec.Networks...), v1.Network{
+		Name: c.interfaceName,
+		NetworkSource: v1.NetworkSource{
+			Multus: &v1.MultusNetwork{NetworkName: c.networkAttachmentDefinitionName},
+		},
+	})
+	if err := patchVMInterfaces(cmd.Context(), virtClient, vm, ifaces, networks); err != nil {
+		return fmt.Errorf("error adding interface, %w", err)
+	}
+	result.RecordChange(cmd.Context(), result.Change{
+		Action: "AddInterface", Kind: v1.VirtualMachineGroupVersionKind.Kind, Namespace: namespace, Name: vmName,
+	})
+
+	steps := []interfaceStep{
+		{
+			description: fmt.Sprintf("interface %s added to the spec of VM %s", c.interfaceName, vmName),
+		},
+		{
+			description: "hotplug requested on the VMI",
+			condition: func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) (bool, error) {
+				if err := checkLivePropagation(vm); err != nil {
+					return false, err
+				}
+				return vmispec.LookupInterfaceByName(vmi.Spec.Domain.Devices.Interfaces, c.interfaceName) != nil, nil
+			},
+		},
+		{
+			description: "interface attached to the domain",
+			condition: func(_ *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) (bool, error) {
+				status := vmispec.LookupInterfaceStatusByName(vmi.Status.Interfaces, c.interfaceName)
+				return status != nil && vmispec.ContainsInfoSource(status.InfoSource, vmispec.InfoSourceDomain), nil
+			},
+		},
+		{
+			description: "interface reported by the guest agent",
+			condition: func(_ *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) (bool, error) {
+				status := vmispec.LookupInterfaceStatusByName(vmi.Status.Interfaces, c.interfaceName)
+				return status != nil && vmispec.ContainsInfoSource(status.InfoSource, vmispec.InfoSourceGuestAgent), nil
+			},
+			skip: func(vmi *v1.VirtualMachineInstance) string {
+				if !isAgentConnected(vmi) {
+					return "the guest agent is not connected"
+				}
+				return ""
+			},
+		},
+	}
+
+	if err := c.waitForSteps(cmd, virtClient, namespace, vmName, steps); err != nil {
+		cmd.Printf("Rolling back the addition of interface %s to VM %s\n", c.interfaceName, vmName)
+		if rollbackErr := c.rollbackAddInterface(cmd.Context(), virtClient, namespace, vmName); rollbackErr != nil {
+			return errors.Join(err, fmt.Errorf("error rolling back, %w", rollbackErr))
+		}
+		cmd.Printf("Rolled back the addition of interface %s to VM %s\n", c.interfaceName, vmName)
+		return err
+	}
+	if !c.wait {
+		cmd.Printf("Successfully submitted add interface request to VM %s for interface %s\n", vmName, c.interfaceName)
+	}
+	return nil
+}
+
+func (c *interfaceCommand) removeInterfaceRun(cmd *cobra.Command, args []string) error {
+	if err := c.validate(); err != nil {
+		return result.NewUsageError(err)
+	}
+	vmName := args[0]
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	vm, err := virtClient.VirtualMachine(namespace).Get(cmd.Context(), vmName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error removing interface, %w", err)
+	}
+	if vm.Spec.Template == nil {
+		return fmt.Errorf("error removing interface, VM %s has no template", vmName)
+	}
+	templateSpec := &vm.Spec.Template.Spec
+	iface := vmispec.LookupInterfaceByName(templateSpec.Domain.Devices.Interfaces, c.interfaceName)
+	if iface == nil {
+		return fmt.Errorf("error removing interface, VM %s has no interface named %s", vmName, c.interfaceName)
+	}
+	if iface.State == v1.InterfaceStateAbsent {
+		return fmt.Errorf("error removing interface, interface %s of VM %s is already being removed", c.interfaceName, vmName)
+	}
+	if iface.Bridge == nil {
+		return fmt.Errorf("error removing interface, only interfaces with a bridge binding can be removed")
+	}
+	if c.networkAttachmentDefinitionName != "" {
+		network := vmispec.LookupNetworkByName(templateSpec.Networks, c.interfaceName)
+		if network == nil || network.Multus == nil || network.Multus.NetworkName != c.networkAttachmentDefinitionName {
+			return fmt.Errorf("error removing interface, interface %s of VM %s is not connected to network %s",
+				c.interfaceName, vmName, c.networkAttachmentDefinitionName)
+		}
+	}
+	previousState := iface.State
+
+	vmi, err := getVMI(cmd.Context(), virtClient, namespace, vmName)
+	if err != nil {
+		return fmt.Errorf("error removing interface, %w", err)
+	}
+
+	ifaces := append([]v1.Interface{}, templateSpec.Domain.Devices.Interfaces...)
+	networks := templateSpec.Networks
+	if vmi == nil {
+		// Without a running VMI there is nothing to unplug, the interface is
+		// removed from the spec right away.
+		ifaces = vmispec.FilterInterfacesSpec(ifaces, func(i v1.Interface) bool { return i.Name != c.interfaceName })
+		networks = vmispec.FilterNetworksByInterfaces(networks, ifaces)
+	} else {
+		vmispec.LookupInterfaceByName(ifaces, c.interfaceName).State = v1.InterfaceStateAbsent
+	}
+	if err := patchVMInterfaces(cmd.Context(), virtClient, vm, ifaces, networks); err != nil {
+		return fmt.Errorf("error removing interface, %w", err)
+	}
+	result.RecordChange(cmd.Context(), result.Change{
+		Action: "RemoveInterface", Kind: v1.VirtualMachineGroupVersionKind.Kind, Namespace: namespace, Name: vmName,
+	})
+
+	steps := []interfaceStep{
+		{
+			description: fmt.Sprintf("interface %s marked for removal in the spec of VM %s", c.interfaceName, vmName),
+		},
+		{
+			description: "unplug requested on the VMI",
+			condition: func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) (bool, error) {
+				if err := checkLivePropagation(vm); err != nil {
+					return false, err
+				}
+				iface := vmispec.LookupInterfaceByName(vmi.Spec.Domain.Devices.Interfaces, c.interfaceName)
+				return iface == nil || iface.State == v1.InterfaceStateAbsent, nil
+			},
+		},
+		{
+			description: "interface detached from the domain",
+			condition: func(_ *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) (bool, error) {
+				return vmispec.LookupInterfaceStatusByName(vmi.Status.Interfaces, c.interfaceName) == nil, nil
+			},
+		},
+		{
+			description: "interface removed from the spec of the VM",
+			condition: func(vm *v1.VirtualMachine, _ *v1.VirtualMachineInstance) (bool, error) {
+				return vmispec.LookupInterfaceByName(vm.Spec.Template.Spec.Domain.Devices.Interfaces, c.interfaceName) == nil, nil
+			},
+		},
+	}
+
+	if err := c.waitForSteps(cmd, virtClient, namespace, vmName, steps); err != nil {
+		cmd.Printf("Rolling back the removal of interface %s from VM %s\n", c.interfaceName, vmName)
+		if rollbackErr := c.rollbackRemoveInterface(cmd.Context(), virtClient, namespace, vmName, previousState); rollbackErr != nil {
+			return errors.Join(err, fmt.Errorf("error rolling back, %w", rollbackErr))
+		}
+		cmd.Printf("Rolled back the removal of interface %s from VM %s\n", c.interfaceName, vmName)
+		return err
+	}
+	if !c.wait {
+		cmd.Printf("Successfully submitted remove interface request to VM %s for interface %s\n", vmName, c.interfaceName)
+	}
+	return nil
+}
+
+// waitForSteps reports the steps of an interface change one after another.
+// The first step is the already applied change of the VM spec. If the VM has
+// no VMI the remaining steps are skipped, since they only happen on a running
+// VM.
+func (c *interfaceCommand) waitForSteps(cmd *cobra.Command, virtClient kubecli.KubevirtClient, namespace, vmName string, steps []interfaceStep) error {
+	if !c.wait {
+		return nil
+	}
+	total := len(steps)
+	cmd.Printf("[1/%d] %s\n", total, steps[0].description)
+
+	for i, step := range steps[1:] {
+		number := i + 2
+		skipReason := ""
+		err := virtwait.PollImmediately(interfacePollInterval, c.timeout, func(ctx context.Context) (bool, error) {
+			vm, err := virtClient.VirtualMachine(namespace).Get(ctx, vmName, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			vmi, err := getVMI(ctx, virtClient, namespace, vmName)
+			if err != nil {
+				return false, err
+			}
+			if vmi == nil {
+				return false, errVMINotRunning
+			}
+			if step.skip != nil {
+				if skipReason = step.skip(vmi); skipReason != "" {
+					return true, nil
+				}
+			}
+			return step.condition(vm, vmi)
+		})
+		switch {
+		case errors.Is(err, errVMINotRunning):
+			cmd.Printf("VM %s is not running, the change is applied on its next start\n", vmName)
+			return nil
+		case err != nil:
+			cmd.Printf("[%d/%d] failed waiting for %s: %v\n", number, total, step.description, err)
+			return fmt.Errorf("failed waiting for %s: %w", step.description, err)
+		case skipReason != "":
+			cmd.Printf("[%d/%d] skipped waiting for %s: %s\n", number, total, step.description, skipReason)
+		default:
+			cmd.Printf("[%d/%d] %s\n", number, total, step.description)
+		}
+	}
+	return nil
+}
+
+var errVMINotRunning = errors.New("the VMI does not exist")
+
+func (c *interfaceCommand) rollbackAddInterface(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, vmName string) error {
+	vm, err := virtClient.VirtualMachine(namespace).Get(ctx, vmName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	vmi, err := getVMI(ctx, virtClient, namespace, vmName)
+	if err != nil {
+		return err
+	}
+
+	ifaces := append([]v1.Interface{}, vm.Spec.Template.Spec.Domain.Devices.Interfaces...)
+	networks := vm.Spec.Template.Spec.Networks
+	if vmi != nil && vmispec.LookupInterfaceByName(vmi.Spec.Domain.Devices.Interfaces, c.interfaceName) != nil {
+		// The interface was already requested on the VMI and has to be
+		// unplugged, the VM controller removes it from the spec afterwards.
+		iface := vmispec.LookupInterfaceByName(ifaces, c.interfaceName)
+		if iface == nil {
+			return nil
+		}
+		iface.State = v1.InterfaceStateAbsent
+	} else {
+		ifaces = vmispec.FilterInterfacesSpec(ifaces, func(i v1.Interface) bool { return i.Name != c.interfaceName })
+		networks = vmispec.FilterNetworksSpec(networks, func(n v1.Network) bool { return n.Name != c.interfaceName })
+	}
+	return patchVMInterfaces(ctx, virtClient, vm, ifaces, networks)
+}
+
+func (c *interfaceCommand) rollbackRemoveInterface(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, vmName string, previousState v1.InterfaceState) error {
+	vm, err := virtClient.VirtualMachine(namespace).Get(ctx, vmName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	ifaces := append([]v1.Interface{}, vm.Spec.Template.Spec.Domain.Devices.Interfaces...)
+	iface := vmispec.LookupInterfaceByName(ifaces, c.interfaceName)
+	if iface == nil {
+		return fmt.Errorf("interface %s was already removed from VM %s", c.interfaceName, vmName)
+	}
+	iface.State = previousState
+	return patchVMInterfaces(ctx, virtClient, vm, ifaces, vm.Spec.Template.Spec.Networks)
+}
+
+// patchVMInterfaces replaces the interfaces and networks of the VM template,
+// failing if they were changed since the VM was read.
+func patchVMInterfaces(ctx context.Context, virtClient kubecli.KubevirtClient, vm *v1.VirtualMachine, ifaces []v1.Interface, networks []v1.Network) error {
+	patchBytes, err := patch.New(
+		patch.WithTest(interfacesPath, vm.Spec.Template.Spec.Domain.Devices.Interfaces),
+		patch.WithAdd(interfacesPath, ifaces),
+		patch.WithTest(networksPath, vm.Spec.Template.Spec.Networks),
+		patch.WithAdd(networksPath, networks),
+	).GeneratePayload()
+	if err != nil {
+		return err
+	}
+	_, err = virtClient.VirtualMachine(vm.Namespace).Patch(ctx, vm.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
+
+func getVMI(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, name string) (*v1.VirtualMachineInstance, error) {
+	vmi, err := virtClient.VirtualMachineInstance(namespace).Get(ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return vmi, nil
+}
+
+// checkLivePropagation fails if the interface change can't be applied to the
+// running VMI and only takes effect after a restart.
+func checkLivePropagation(vm *v1.VirtualMachine) error {
+	for _, condition := range vm.Status.Conditions {
+		if condition.Type == v1.VirtualMachineRestartRequired && condition.Status == k8sv1.ConditionTrue {
+			return fmt.Errorf("the change can't be applied to the running VM and requires a restart: %s", condition.Message)
+		}
+	}
+	return nil
+}
+
+func isAgentConnected(vmi *v1.VirtualMachineInstance) bool {
+	for _, condition := range vmi.Status.Conditions {
+		if condition.Type == v1.VirtualMachineInstanceAgentConnected {
+			return condition.Status == k8sv1.ConditionTrue
+		}
+	}
+	return false
+}
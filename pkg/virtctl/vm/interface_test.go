@@ -0,0 +1,350 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vm_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	libvmistatus "kubevirt.io/kubevirt/pkg/libvmi/status"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Interface commands", func() {
+	const (
+		vmName    = "testvm"
+		ifaceName = "secondary"
+		nadName   = "my-net"
+	)
+
+	var virtClient *kubevirtfake.Clientset
+
+	BeforeEach(func() {
+		virtClient = kubevirtfake.NewSimpleClientset()
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault)).AnyTimes()
+	})
+
+	createVM := func(opts ...libvmi.Option) *v1.VirtualMachine {
+		opts = append([]libvmi.Option{
+			libvmi.WithNamespace(metav1.NamespaceDefault),
+			libvmi.WithName(vmName),
+			libvmi.WithInterface(libvmi.InterfaceDeviceWithMasqueradeBinding()),
+			libvmi.WithNetwork(v1.DefaultPodNetwork()),
+		}, opts...)
+		vm := libvmi.NewVirtualMachine(libvmi.New(opts...))
+		vm, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), vm, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return vm
+	}
+
+	withSecondaryInterface := []libvmi.Option{
+		libvmi.WithInterface(libvmi.InterfaceDeviceWithBridgeBinding(ifaceName)),
+		libvmi.WithNetwork(libvmi.MultusNetwork(ifaceName, nadName)),
+	}
+
+	setRestartRequired := func(vm *v1.VirtualMachine) {
+		vm.Status.Conditions = []v1.VirtualMachineCondition{{
+			Type:    v1.VirtualMachineRestartRequired,
+			Status:  k8sv1.ConditionTrue,
+			Message: "a non-live-updatable field was changed",
+		}}
+		_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).UpdateStatus(context.Background(), vm, metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	agentConnected := libvmistatus.WithCondition(v1.VirtualMachineInstanceCondition{
+		Type:   v1.VirtualMachineInstanceAgentConnected,
+		Status: k8sv1.ConditionTrue,
+	})
+
+	createVMI := func(statusOpts []libvmistatus.Option, opts ...libvmi.Option) {
+		opts = append([]libvmi.Option{
+			libvmi.WithNamespace(metav1.NamespaceDefault),
+			libvmi.WithName(vmName),
+			libvmi.WithInterface(libvmi.InterfaceDeviceWithMasqueradeBinding()),
+			libvmi.WithNetwork(v1.DefaultPodNetwork()),
+			libvmistatus.WithStatus(libvmistatus.New(statusOpts...)),
+		}, opts...)
+		_, err := virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).
+			Create(context.Background(), libvmi.New(opts...), metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getVM := func() *v1.VirtualMachine {
+		vm, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Get(context.Background(), vmName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return vm
+	}
+
+	lookupInterface := func(vm *v1.VirtualMachine) *v1.Interface {
+		for i, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+			if iface.Name == ifaceName {
+				return &vm.Spec.Template.Spec.Domain.Devices.Interfaces[i]
+			}
+		}
+		return nil
+	}
+
+	Context("addinterface", func() {
+		It("should add a bridge interface and its network to the VM spec", func() {
+			createVM()
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("addinterface", vmName,
+				"--network-attachment-definition-name", nadName, "--name", ifaceName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("Successfully submitted add interface request to VM testvm for interface secondary"))
+
+			vm := getVM()
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces).To(ConsistOf(
+				libvmi.InterfaceDeviceWithMasqueradeBinding(),
+				v1.Interface{Name: ifaceName, InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			))
+			Expect(vm.Spec.Template.Spec.Networks).To(ConsistOf(
+				*v1.DefaultPodNetwork(),
+				*libvmi.MultusNetwork(ifaceName, nadName),
+			))
+		})
+
+		It("should accept the network with --network", func() {
+			createVM()
+
+			Expect(testing.NewRepeatableVirtctlCommand("addinterface", vmName,
+				"--network", "other-ns/"+nadName, "--name", ifaceName, "--persist")()).To(Succeed())
+			Expect(getVM().Spec.Template.Spec.Networks).To(ContainElement(*libvmi.MultusNetwork(ifaceName, "other-ns/"+nadName)))
+		})
+
+		DescribeTable("should reject invalid flags without changing the VM", func(expected string, args ...string) {
+			createVM()
+
+			err := testing.NewRepeatableVirtctlCommand(append([]string{"addinterface", vmName}, args...)...)()
+			Expect(err).To(MatchError(ContainSubstring(expected)))
+			Expect(lookupInterface(getVM())).To(BeNil())
+		},
+			Entry("without network", "at least one of the flags in the group", "--name", ifaceName),
+			Entry("with both network flags", "none of the others can be",
+				"--network", nadName, "--network-attachment-definition-name", nadName, "--name", ifaceName),
+			Entry("with invalid interface name", `invalid interface name "sec.ondary"`, "--network", nadName, "--name", "sec.ondary"),
+			Entry("with invalid network name", `invalid network "My_Net"`, "--network", "My_Net", "--name", ifaceName),
+			Entry("with invalid network namespace", `invalid network "-ns/my-net"`, "--network", "-ns/my-net", "--name", ifaceName),
+		)
+
+		It("should fail if the interface already exists", func() {
+			createVM(withSecondaryInterface...)
+
+			err := testing.NewRepeatableVirtctlCommand("addinterface", vmName,
+				"--network-attachment-definition-name", nadName, "--name", ifaceName)()
+			Expect(err).To(MatchError(ContainSubstring("VM testvm already has an interface or network named secondary")))
+		})
+
+		It("should report each step until the guest agent reports the interface", func() {
+			createVM()
+			createVMI([]libvmistatus.Option{
+				agentConnected,
+				libvmistatus.WithInterfaceStatus(v1.VirtualMachineInstanceNetworkInterface{
+					Name: ifaceName, InfoSource: "domain, guest-agent",
+				}),
+			}, withSecondaryInterface...)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("addinterface", vmName,
+				"--network-attachment-definition-name", nadName, "--name", ifaceName, "--wait")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("[1/4] interface secondary added to the spec of VM testvm"))
+			Expect(string(out)).To(ContainSubstring("[2/4] hotplug requested on the VMI"))
+			Expect(string(out)).To(ContainSubstring("[3/4] interface attached to the domain"))
+			Expect(string(out)).To(ContainSubstring("[4/4] interface reported by the guest agent"))
+		})
+
+		It("should skip waiting for the guest if the guest agent is not connected", func() {
+			createVM()
+			createVMI([]libvmistatus.Option{
+				libvmistatus.WithInterfaceStatus(v1.VirtualMachineInstanceNetworkInterface{
+					Name: ifaceName, InfoSource: "domain",
+				}),
+			}, withSecondaryInterface...)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("addinterface", vmName,
+				"--network-attachment-definition-name", nadName, "--name", ifaceName, "--wait")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("[4/4] skipped waiting for interface reported by the guest agent: the guest agent is not connected"))
+		})
+
+		It("should not wait for a VM which is not running", func() {
+			createVM()
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("addinterface", vmName,
+				"--network-attachment-definition-name", nadName, "--name", ifaceName, "--wait")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("VM testvm is not running, the change is applied on its next start"))
+			Expect(lookupInterface(getVM())).ToNot(BeNil())
+		})
+
+		It("should roll back the VM spec if the interface can't be hotplugged", func() {
+			setRestartRequired(createVM())
+			createVMI([]libvmistatus.Option{agentConnected})
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("addinterface", vmName,
+				"--network-attachment-definition-name", nadName, "--name", ifaceName, "--wait")()
+			Expect(err).To(MatchError(ContainSubstring("failed waiting for hotplug requested on the VMI: the change can't be applied to the running VM and requires a restart")))
+			Expect(string(out)).To(ContainSubstring("[2/4] failed waiting for hotplug requested on the VMI"))
+			Expect(string(out)).To(ContainSubstring("Rolled back the addition of interface secondary to VM testvm"))
+
+			vm := getVM()
+			Expect(lookupInterface(vm)).To(BeNil())
+			Expect(vm.Spec.Template.Spec.Networks).To(ConsistOf(*v1.DefaultPodNetwork()))
+		})
+
+		It("should unplug the interface on roll back if it was already requested on the VMI", func() {
+			createVM()
+			createVMI([]libvmistatus.Option{agentConnected}, withSecondaryInterface...)
+
+			_, err := testing.NewRepeatableVirtctlCommandWithOut("addinterface", vmName,
+				"--network-attachment-definition-name", nadName, "--name", ifaceName, "--wait", "--timeout", "1s")()
+			Expect(err).To(MatchError(ContainSubstring("failed waiting for interface attached to the domain")))
+
+			iface := lookupInterface(getVM())
+			Expect(iface).ToNot(BeNil())
+			Expect(iface.State).To(Equal(v1.InterfaceStateAbsent))
+		})
+	})
+
+	Context("removeinterface", func() {
+		It("should remove the interface from the spec of a stopped VM", func() {
+			createVM(withSecondaryInterface...)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("removeinterface", vmName, "--name", ifaceName)()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("Successfully submitted remove interface request to VM testvm for interface secondary"))
+
+			vm := getVM()
+			Expect(lookupInterface(vm)).To(BeNil())
+			Expect(vm.Spec.Template.Spec.Networks).To(ConsistOf(*v1.DefaultPodNetwork()))
+		})
+
+		It("should remove the interface if it is connected to the given network", func() {
+			createVM(withSecondaryInterface...)
+
+			Expect(testing.NewRepeatableVirtctlCommand("removeinterface", vmName,
+				"--network", nadName, "--name", ifaceName, "--persist")()).To(Succeed())
+			Expect(lookupInterface(getVM())).To(BeNil())
+		})
+
+		It("should not remove the interface if it is connected to another network", func() {
+			createVM(withSecondaryInterface...)
+
+			err := testing.NewRepeatableVirtctlCommand("removeinterface", vmName, "--network", "other-net", "--name", ifaceName)()
+			Expect(err).To(MatchError(ContainSubstring("interface secondary of VM testvm is not connected to network other-net")))
+			Expect(lookupInterface(getVM())).ToNot(BeNil())
+		})
+
+		It("should mark the interface as absent on a running VM", func() {
+			createVM(withSecondaryInterface...)
+			createVMI([]libvmistatus.Option{agentConnected}, withSecondaryInterface...)
+
+			Expect(testing.NewRepeatableVirtctlCommand("removeinterface", vmName, "--name", ifaceName)()).To(Succeed())
+
+			iface := lookupInterface(getVM())
+			Expect(iface).ToNot(BeNil())
+			Expect(iface.State).To(Equal(v1.InterfaceStateAbsent))
+		})
+
+		DescribeTable("should refuse to remove", func(expected string, opts ...libvmi.Option) {
+			createVM(opts...)
+
+			err := testing.NewRepeatableVirtctlCommand("removeinterface", vmName, "--name", ifaceName)()
+			Expect(err).To(MatchError(ContainSubstring(expected)))
+		},
+			Entry("a missing interface", "VM testvm has no interface named secondary"),
+			Entry("an interface which is already being removed", "is already being removed",
+				libvmi.WithInterface(v1.Interface{
+					Name:                   ifaceName,
+					State:                  v1.InterfaceStateAbsent,
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				}),
+				libvmi.WithNetwork(libvmi.MultusNetwork(ifaceName, nadName))),
+			Entry("an interface without a bridge binding", "only interfaces with a bridge binding can be removed",
+				libvmi.WithInterface(v1.Interface{
+					Name:                   ifaceName,
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}},
+				}),
+				libvmi.WithNetwork(libvmi.MultusNetwork(ifaceName, nadName))),
+		)
+
+		It("should report each step until the interface is removed", func() {
+			createVM(withSecondaryInterface...)
+			// The VMI already dropped the interface, as after a completed unplug
+			createVMI([]libvmistatus.Option{agentConnected})
+			// Mimic the VM controller which clears absent interfaces from the VM spec
+			virtClient.PrependReactor("get", "virtualmachines", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				obj, err := virtClient.Tracker().Get(v1.VirtualMachineGroupVersionKind.GroupVersion().WithResource("virtualmachines"),
+					metav1.NamespaceDefault, vmName)
+				if err != nil {
+					return true, nil, err
+				}
+				vm := obj.(*v1.VirtualMachine).DeepCopy()
+				if iface := lookupInterface(vm); iface != nil && iface.State == v1.InterfaceStateAbsent {
+					vm.Spec.Template.Spec.Domain.Devices.Interfaces = vm.Spec.Template.Spec.Domain.Devices.Interfaces[:1]
+					vm.Spec.Template.Spec.Networks = vm.Spec.Template.Spec.Networks[:1]
+				}
+				return true, vm, nil
+			})
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("removeinterface", vmName, "--name", ifaceName, "--wait")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("[1/4] interface secondary marked for removal in the spec of VM testvm"))
+			Expect(string(out)).To(ContainSubstring("[2/4] unplug requested on the VMI"))
+			Expect(string(out)).To(ContainSubstring("[3/4] interface detached from the domain"))
+			Expect(string(out)).To(ContainSubstring("[4/4] interface removed from the spec of the VM"))
+		})
+
+		It("should restore the interface state if the interface can't be unplugged", func() {
+			setRestartRequired(createVM(withSecondaryInterface...))
+			createVMI([]libvmistatus.Option{agentConnected}, withSecondaryInterface...)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("removeinterface", vmName, "--name", ifaceName, "--wait")()
+			Expect(err).To(MatchError(ContainSubstring("requires a restart")))
+			Expect(string(out)).To(ContainSubstring("[1/4] interface secondary marked for removal in the spec of VM testvm"))
+			Expect(string(out)).To(ContainSubstring("Rolled back the removal of interface secondary from VM testvm"))
+
+			iface := lookupInterface(getVM())
+			Expect(iface).ToNot(BeNil())
+			Expect(iface.State).To(BeEmpty())
+		})
+	})
+})
@@ -22,59 +22,334 @@ package vm
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
 
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
+	migrationsutil "kubevirt.io/kubevirt/pkg/util/migrations"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
-const COMMAND_MIGRATE = "migrate"
+const (
+	COMMAND_MIGRATE = "migrate"
+
+	nodeArg   = "node"
+	policyArg = "policy"
+
+	defaultMigrateTimeout = 30 * time.Minute
+	migratePollInterval   = time.Second
+)
 
 type migrateCommand struct {
 	command           string
 	addedNodeSelector map[string]string
+	node              string
+	policy            string
+	wait              bool
+	timeout           time.Duration
 }
 
 func NewMigrateCommand() *cobra.Command {
 	c := migrateCommand{command: COMMAND_MIGRATE}
 	cmd := &cobra.Command{
-		Use:     "migrate (VM)",
-		Short:   "Migrate a virtual machine.",
-		Example: usage(COMMAND_MIGRATE),
-		Args:    cobra.ExactArgs(1),
-		RunE:    c.migrateRun,
+		Use:               "migrate (VM)",
+		Short:             "Migrate a virtual machine.",
+		Example:           usageMigrate(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.migrateRun,
 	}
 
 	cmd.Flags().StringToStringVar(&c.addedNodeSelector, "addedNodeSelector", nil, "--addedNodeSelector=key=value1,key2=value2: configure an additional node selector for the one-off migration attempt. AddedNodeSelector can only restrict constraints already set on the VM. By default the scheduler is responsible for finding the best Node, which is the recommended way of migrating VMs.")
-	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	cmd.Flags().StringVar(&c.node, nodeArg, "", "--node=node01: migrate the VM to the given node. This is a shorthand for an addedNodeSelector on the hostname label of the node.")
+	cmd.Flags().StringVar(&c.policy, policyArg, "", "--policy=my-policy: apply the given MigrationPolicy to the migration instead of the policy matched by its selectors.")
+	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, "--dry-run=false: Flag used to set whether to perform a dry run or not. If true the command reports whether the VM is currently migratable and why not, without migrating it.")
+	cmd.Flags().BoolVar(&c.wait, waitArg, false, "--wait=false: wait until the migration finished, reporting its phase, the transferred memory, the dirty rate and the estimated time left while waiting.")
+	cmd.Flags().DurationVar(&c.timeout, timeoutArg, defaultMigrateTimeout, "the maximum time to wait for the migration when --wait is set")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
+	requirements.FlagServerVersion(cmd, policyArg, "v1.8.0")
 	return cmd
 }
 
+func usageMigrate() string {
+	return `  # Migrate a virtual machine called 'myvm':
+  {{ProgramName}} migrate myvm
+
+  # Migrate a virtual machine called 'myvm' to the node 'node01':
+  {{ProgramName}} migrate myvm --node node01
+
+  # Migrate a virtual machine called 'myvm' with the MigrationPolicy 'my-policy':
+  {{ProgramName}} migrate myvm --policy my-policy
+
+  # Report whether a virtual machine called 'myvm' can currently be migrated to the node 'node01':
+  {{ProgramName}} migrate myvm --node node01 --dry-run
+
+  # Migrate a virtual machine called 'myvm' and follow the progress until the migration finished:
+  {{ProgramName}} migrate myvm --wait`
+}
+
 func (c *migrateCommand) migrateRun(cmd *cobra.Command, args []string) error {
 	vmiName := args[0]
 
+	addedNodeSelector, err := c.nodeSelector()
+	if err != nil {
+		return result.NewUsageError(err)
+	}
+	if c.wait && c.timeout <= 0 {
+		return result.NewUsageError(fmt.Errorf("the timeout must be greater than zero"))
+	}
+
 	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 
+	if dryRun {
+		reasons, err := c.migratabilityReasons(cmd.Context(), virtClient, namespace, vmiName)
+		if err != nil {
+			return err
+		}
+		if len(reasons) > 0 {
+			cmd.Printf("VM %s is not migratable:\n", vmiName)
+			for _, reason := range reasons {
+				cmd.Printf("  - %s\n", reason)
+			}
+			return fmt.Errorf("VM %s is not migratable", vmiName)
+		}
+		cmd.Printf("VM %s is migratable\n", vmiName)
+	}
+
 	dryRunOption := setDryRunOption(dryRun)
 
 	options := &v1.MigrateOptions{
 		DryRun:            dryRunOption,
-		AddedNodeSelector: c.addedNodeSelector,
+		AddedNodeSelector: addedNodeSelector,
+	}
+	if c.policy != "" {
+		options.MigrationPolicyName = &c.policy
 	}
 
 	err = virtClient.VirtualMachine(namespace).Migrate(context.Background(), vmiName, options)
 	if err != nil {
-		return fmt.Errorf("Error migrating VirtualMachine %v", err)
+		return fmt.Errorf("Error migrating VirtualMachine %w", err)
 	}
+	result.RecordChange(cmd.Context(), result.Change{Action: "Migrate", Kind: v1.VirtualMachineGroupVersionKind.Kind, Namespace: namespace, Name: vmiName, DryRun: dryRun})
 
 	fmt.Printf("VM %s was scheduled to %s\n", vmiName, c.command)
 
+	if !c.wait || dryRun {
+		return nil
+	}
+	return waitForMigration(cmd, virtClient, namespace, vmiName, c.timeout)
+}
+
+// waitForMigration follows the most recent migration of the VMI until it
+// finished, printing a line whenever its progress changes.
+func waitForMigration(cmd *cobra.Command, virtClient kubecli.KubevirtClient, namespace, vmiName string, timeout time.Duration) error {
+	var last string
+	report := func(progress string) {
+		if progress != last {
+			cmd.Println(progress)
+			last = progress
+		}
+	}
+
+	var migration *v1.VirtualMachineInstanceMigration
+	err := virtwait.PollImmediately(migratePollInterval, timeout, func(ctx context.Context) (bool, error) {
+		var err error
+		migration, err = latestMigration(ctx, virtClient, namespace, vmiName)
+		if err != nil || migration == nil {
+			return false, err
+		}
+		vmi, err := virtClient.VirtualMachineInstance(namespace).Get(ctx, vmiName, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return false, fmt.Errorf("error getting VirtualMachineInstance %s: %w", vmiName, err)
+		}
+		report(migrationProgress(migration, vmi))
+		return migration.IsFinal(), nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for the migration of VM %s: %w", vmiName, err)
+	}
+
+	if migration.Status.Phase != v1.MigrationSucceeded {
+		reason := "unknown reason"
+		if state := migration.Status.MigrationState; state != nil && state.FailureReason != "" {
+			reason = state.FailureReason
+		}
+		return fmt.Errorf("migration %s of VM %s failed: %s", migration.Name, vmiName, reason)
+	}
+	target := ""
+	if state := migration.Status.MigrationState; state != nil && state.TargetNode != "" {
+		target = " to node " + state.TargetNode
+	}
+	cmd.Printf("VM %s was migrated%s\n", vmiName, target)
 	return nil
 }
+
+// latestMigration returns the most recently created migration of the VMI,
+// preferring one which is still active.
+func latestMigration(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, vmiName string) (*v1.VirtualMachineInstanceMigration, error) {
+	migrations, err := virtClient.VirtualMachineInstanceMigration(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s==%s", v1.MigrationSelectorLabel, vmiName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing the migrations of VM %s: %w", vmiName, err)
+	}
+	if len(migrations.Items) == 0 {
+		return nil, nil
+	}
+	items := migrations.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].IsFinal() != items[j].IsFinal() {
+			return !items[i].IsFinal()
+		}
+		return items[j].CreationTimestamp.Before(&items[i].CreationTimestamp)
+	})
+	return &items[0], nil
+}
+
+func migrationProgress(migration *v1.VirtualMachineInstanceMigration, vmi *v1.VirtualMachineInstance) string {
+	phase := migration.Status.Phase
+	if phase == "" {
+		phase = v1.MigrationPending
+	}
+	progress := fmt.Sprintf("Migration %s: %s", migration.Name, phase)
+	if phase != v1.MigrationRunning || vmi == nil || vmi.Status.MigrationState == nil ||
+		vmi.Status.MigrationState.MigrationUID != migration.UID || vmi.Status.MigrationState.TransferStatus == nil {
+		return progress
+	}
+	return progress + ", " + transferProgress(vmi.Status.MigrationState.TransferStatus)
+}
+
+func transferProgress(status *v1.VirtualMachineInstanceMigrationTransferStatus) string {
+	progress := fmt.Sprintf("transferred %s of %s, dirty rate %s/s",
+		formatBytes(status.DataProcessedBytes), formatBytes(status.DataTotalBytes), formatBytes(status.MemoryDirtyRateBytesPerSecond))
+	if status.MemoryBandwidthBytesPerSecond <= status.MemoryDirtyRateBytesPerSecond {
+		return progress + ", ETA unknown (memory is dirtied faster than it is transferred)"
+	}
+	eta := time.Duration(status.DataRemainingBytes/(status.MemoryBandwidthBytesPerSecond-status.MemoryDirtyRateBytesPerSecond)) * time.Second
+	return fmt.Sprintf("%s, ETA %s", progress, eta)
+}
+
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// nodeSelector merges the target node into the addedNodeSelector
+func (c *migrateCommand) nodeSelector() (map[string]string, error) {
+	if c.node == "" {
+		return c.addedNodeSelector, nil
+	}
+	if hostname, exists := c.addedNodeSelector[k8sv1.LabelHostname]; exists && hostname != c.node {
+		return nil, fmt.Errorf("--%s=%s conflicts with the addedNodeSelector %s=%s", nodeArg, c.node, k8sv1.LabelHostname, hostname)
+	}
+	nodeSelector := map[string]string{k8sv1.LabelHostname: c.node}
+	for key, value := range c.addedNodeSelector {
+		nodeSelector[key] = value
+	}
+	return nodeSelector, nil
+}
+
+// migratabilityReasons returns the reasons why the VMI can currently not be
+// migrated with the requested options. An empty result means it is migratable.
+func (c *migrateCommand) migratabilityReasons(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, name string) ([]string, error) {
+	vmi, err := virtClient.VirtualMachineInstance(namespace).Get(ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return []string{"the VM is not running"}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting VirtualMachineInstance %s: %w", name, err)
+	}
+	if !vmi.IsRunning() {
+		return []string{fmt.Sprintf("the VMI is in phase %s instead of %s", vmi.Status.Phase, v1.Running)}, nil
+	}
+
+	var reasons []string
+	for _, condition := range vmi.Status.Conditions {
+		if condition.Type == v1.VirtualMachineInstanceIsMigratable && condition.Status == k8sv1.ConditionFalse {
+			reasons = append(reasons, conditionReason(condition))
+		}
+	}
+	if state := vmi.Status.MigrationState; state != nil && !state.Completed && !state.Failed {
+		reasons = append(reasons, "a migration is already in progress")
+	}
+
+	if c.node != "" {
+		nodeReasons, err := targetNodeReasons(ctx, virtClient, vmi, c.node)
+		if err != nil {
+			return nil, err
+		}
+		reasons = append(reasons, nodeReasons...)
+	}
+
+	if c.policy != "" {
+		_, err := virtClient.MigrationPolicy().Get(ctx, c.policy, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			reasons = append(reasons, fmt.Sprintf("the MigrationPolicy %s does not exist", c.policy))
+		} else if err != nil {
+			return nil, fmt.Errorf("error getting MigrationPolicy %s: %w", c.policy, err)
+		}
+	}
+
+	return reasons, nil
+}
+
+func targetNodeReasons(ctx context.Context, virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance, nodeName string) ([]string, error) {
+	if nodeName == vmi.Status.NodeName {
+		return []string{fmt.Sprintf("the VM is already running on node %s", nodeName)}, nil
+	}
+	node, err := virtClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return []string{fmt.Sprintf("node %s does not exist", nodeName)}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting node %s: %w", nodeName, err)
+	}
+	sourceNode, err := virtClient.CoreV1().Nodes().Get(ctx, vmi.Status.NodeName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		sourceNode = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting node %s: %w", vmi.Status.NodeName, err)
+	}
+
+	var reasons []string
+	for _, reason := range migrationsutil.CPUIncompatibilities(vmi, node, sourceNode) {
+		reasons = append(reasons, fmt.Sprintf("node %s: %s", nodeName, reason))
+	}
+	return reasons, nil
+}
+
+func conditionReason(condition v1.VirtualMachineInstanceCondition) string {
+	parts := []string{}
+	if condition.Reason != "" {
+		parts = append(parts, condition.Reason)
+	}
+	if condition.Message != "" {
+		parts = append(parts, condition.Message)
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%s is %s", condition.Type, condition.Status)
+	}
+	return strings.Join(parts, ": ")
+}
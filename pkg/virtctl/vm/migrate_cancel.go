@@ -22,55 +22,80 @@ package vm
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
-const COMMAND_MIGRATE_CANCEL = "migrate-cancel"
+const (
+	COMMAND_MIGRATE_CANCEL = "migrate-cancel"
+
+	defaultMigrateCancelTimeout = 3 * time.Minute
+)
+
+type migrateCancelCommand struct {
+	wait    bool
+	timeout time.Duration
+}
 
 func NewMigrateCancelCommand() *cobra.Command {
+	c := migrateCancelCommand{}
 	cmd := &cobra.Command{
-		Use:     "migrate-cancel (VM)",
-		Short:   "Cancel migration of a virtual machine.",
-		Example: usage(COMMAND_MIGRATE_CANCEL),
-		Args:    cobra.ExactArgs(1),
-		RunE:    migrateCancelRun,
+		Use:               "migrate-cancel (VM)",
+		Short:             "Cancel migration of a virtual machine.",
+		Example:           usage(COMMAND_MIGRATE_CANCEL),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.run,
 	}
 
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	cmd.Flags().BoolVar(&c.wait, waitArg, false, "--wait=false: wait until the migration was aborted and confirm the result of the abortion.")
+	cmd.Flags().DurationVar(&c.timeout, timeoutArg, defaultMigrateCancelTimeout, "the maximum time to wait for the abortion when --wait is set")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
-func migrateCancelRun(cmd *cobra.Command, args []string) error {
+func (c *migrateCancelCommand) run(cmd *cobra.Command, args []string) error {
 	vmiName := args[0]
+	if c.wait && c.timeout <= 0 {
+		return result.NewUsageError(fmt.Errorf("the timeout must be greater than zero"))
+	}
 
 	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
 		return err
 	}
-	err = migrateCancel(cmd.Context(), virtClient, vmiName, namespace)
+	migration, err := migrateCancel(cmd.Context(), virtClient, vmiName, namespace)
 	if err != nil {
 		return err
 	}
 
 	cmd.Printf("VM %s was scheduled to %s\n", vmiName, COMMAND_MIGRATE_CANCEL)
-	return nil
+
+	if !c.wait || dryRun {
+		return nil
+	}
+	return waitForMigrationAbort(cmd, virtClient, namespace, vmiName, migration, c.timeout)
 }
 
-func migrateCancel(ctx context.Context, virtClient kubecli.KubevirtClient, vmiName string, namespace string) error {
+func migrateCancel(ctx context.Context, virtClient kubecli.KubevirtClient, vmiName string, namespace string) (*v1.VirtualMachineInstanceMigration, error) {
 	// get a list of migrations for vmiName (use LabelSelector filter)
 	labelSelector := fmt.Sprintf("%s==%s", v1.MigrationSelectorLabel, vmiName)
 	migrations, err := virtClient.VirtualMachineInstanceMigration(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labelSelector})
 	if err != nil {
-		return fmt.Errorf("Error fetching virtual machine instance migration list  %v", err)
+		return nil, fmt.Errorf("Error fetching virtual machine instance migration list  %w", err)
 	}
 
 	deleteOpts := metav1.DeleteOptions{
@@ -79,7 +104,8 @@ func migrateCancel(ctx context.Context, virtClient kubecli.KubevirtClient, vmiNa
 
 	// There may be a single active migrations but several completed/failed ones
 	// go over the migrations list and find the active one
-	for _, mig := range migrations.Items {
+	for i := range migrations.Items {
+		mig := &migrations.Items[i]
 		if mig.IsFinal() {
 			continue
 		}
@@ -89,11 +115,58 @@ func migrateCancel(ctx context.Context, virtClient kubecli.KubevirtClient, vmiNa
 		// Cancel the active migration by calling Delete
 		err = virtClient.VirtualMachineInstanceMigration(namespace).Delete(ctx, migName, deleteOpts)
 		if err != nil {
-			return fmt.Errorf("Error canceling migration %s of a VirtualMachine %s: %v", migName, vmiName, err)
+			return nil, fmt.Errorf("Error canceling migration %s of a VirtualMachine %s: %w", migName, vmiName, err)
 		}
+		result.RecordChange(ctx, result.Change{Action: "Delete", Kind: v1.VirtualMachineInstanceMigrationGroupVersionKind.Kind, Namespace: namespace, Name: migName, DryRun: dryRun})
 
-		return nil
+		return mig, nil
 	}
 
-	return fmt.Errorf("Found no migration to cancel for %s", vmiName)
+	return nil, fmt.Errorf("Found no migration to cancel for %s", vmiName)
+}
+
+// waitForMigrationAbort waits until the abortion of the migration is
+// confirmed by the VMI status or the migration disappeared before reaching it.
+func waitForMigrationAbort(cmd *cobra.Command, virtClient kubecli.KubevirtClient, namespace, vmiName string, migration *v1.VirtualMachineInstanceMigration, timeout time.Duration) error {
+	var last string
+	err := virtwait.PollImmediately(migratePollInterval, timeout, func(ctx context.Context) (bool, error) {
+		vmi, err := virtClient.VirtualMachineInstance(namespace).Get(ctx, vmiName, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return false, fmt.Errorf("error getting VirtualMachineInstance %s: %w", vmiName, err)
+		}
+		if vmi != nil && vmi.Status.MigrationState != nil && vmi.Status.MigrationState.MigrationUID == migration.UID {
+			state := vmi.Status.MigrationState
+			switch {
+			case state.AbortStatus == v1.MigrationAbortSucceeded:
+				return true, nil
+			case state.AbortStatus == v1.MigrationAbortFailed:
+				return false, fmt.Errorf("aborting migration %s of VM %s failed", migration.Name, vmiName)
+			case state.Completed && !state.Failed:
+				return false, fmt.Errorf("migration %s of VM %s completed before it could be aborted", migration.Name, vmiName)
+			case state.Failed:
+				return true, nil
+			}
+			abortStatus := string(state.AbortStatus)
+			if abortStatus == "" {
+				abortStatus = "Requested"
+			}
+			if progress := fmt.Sprintf("Migration %s: abortion %s", migration.Name, abortStatus); progress != last {
+				cmd.Println(progress)
+				last = progress
+			}
+			return false, nil
+		}
+
+		// The migration never reached the VMI, it is aborted once it is gone
+		_, err = virtClient.VirtualMachineInstanceMigration(namespace).Get(ctx, migration.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for the abortion of migration %s: %w", migration.Name, err)
+	}
+	cmd.Printf("Migration %s of VM %s was aborted\n", migration.Name, vmiName)
+	return nil
 }
@@ -20,6 +20,7 @@
 package vm_test
 
 import (
+	"context"
 	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -30,7 +31,9 @@ import (
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
 
+	"kubevirt.io/kubevirt/pkg/libvmi"
 	"kubevirt.io/kubevirt/pkg/virtctl/testing"
 )
 
@@ -103,4 +106,61 @@ var _ = Describe("Migrate cancel command", func() {
 		Expect(err.Error()).To(ContainSubstring(errstr))
 	})
 
+	Context("with --wait", func() {
+		var virtClient *kubevirtfake.Clientset
+
+		BeforeEach(func() {
+			vmiMigration.Namespace = k8smetav1.NamespaceDefault
+			vmiMigration.UID = "migration-uid"
+			vmiMigration.Labels = map[string]string{v1.MigrationSelectorLabel: vm.Name}
+			vmiMigration.Status.Phase = v1.MigrationRunning
+			virtClient = kubevirtfake.NewSimpleClientset(vmiMigration)
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstanceMigration(k8smetav1.NamespaceDefault).
+				Return(virtClient.KubevirtV1().VirtualMachineInstanceMigrations(k8smetav1.NamespaceDefault)).AnyTimes()
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).
+				Return(virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault)).AnyTimes()
+		})
+
+		createVMI := func(state *v1.VirtualMachineInstanceMigrationState) {
+			vmi := libvmi.New(libvmi.WithName(vm.Name), libvmi.WithNamespace(k8smetav1.NamespaceDefault))
+			vmi.Status.MigrationState = state
+			_, err := virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault).
+				Create(context.Background(), vmi, k8smetav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		DescribeTable("should confirm the abortion", func(state *v1.VirtualMachineInstanceMigrationState) {
+			createVMI(state)
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("migrate-cancel", vm.Name, "--wait")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("Migration testvm-migration of VM testvm was aborted\n"))
+		},
+			Entry("when the VMI reports a successful abortion", &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID: "migration-uid",
+				AbortStatus:  v1.MigrationAbortSucceeded,
+			}),
+			Entry("when the migration failed after the abortion was requested", &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID: "migration-uid",
+				Failed:       true,
+			}),
+			Entry("when the migration is gone before it reached the VMI", nil),
+		)
+
+		DescribeTable("should fail", func(state *v1.VirtualMachineInstanceMigrationState, expected string) {
+			createVMI(state)
+			err := testing.NewRepeatableVirtctlCommand("migrate-cancel", vm.Name, "--wait")()
+			Expect(err).To(MatchError(ContainSubstring(expected)))
+		},
+			Entry("when the abortion failed", &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID: "migration-uid",
+				AbortStatus:  v1.MigrationAbortFailed,
+			}, "aborting migration testvm-migration of VM testvm failed"),
+			Entry("when the migration completed before it could be aborted", &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID: "migration-uid",
+				Completed:    true,
+			}, "migration testvm-migration of VM testvm completed before it could be aborted"),
+		)
+	})
+
 })
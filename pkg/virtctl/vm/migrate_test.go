@@ -26,11 +26,19 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	k8sv1 "k8s.io/api/core/v1"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
 
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	libvmistatus "kubevirt.io/kubevirt/pkg/libvmi/status"
+	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/virtctl/testing"
 )
 
@@ -39,11 +47,38 @@ var _ = Describe("Migrate command", func() {
 	var ctrl *gomock.Controller
 	const vmName = "testvm"
 
+	var virtClient *kubevirtfake.Clientset
+	var kubeClient *k8sfake.Clientset
+
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
 		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
 		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+
+		vmi := libvmi.New(
+			libvmi.WithNamespace(k8smetav1.NamespaceDefault),
+			libvmi.WithName(vmName),
+			libvmistatus.WithStatus(libvmistatus.New(
+				libvmistatus.WithPhase(v1.Running),
+				libvmistatus.WithNodeName("node01"),
+				libvmistatus.WithCondition(v1.VirtualMachineInstanceCondition{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				}),
+			)),
+		)
+		virtClient = kubevirtfake.NewSimpleClientset(vmi, kubecli.NewMinimalMigrationPolicy("my-policy"))
+		kubeClient = k8sfake.NewSimpleClientset(
+			&k8sv1.Node{ObjectMeta: k8smetav1.ObjectMeta{Name: "node01", Labels: map[string]string{v1.NodeSchedulable: "true"}}},
+			&k8sv1.Node{ObjectMeta: k8smetav1.ObjectMeta{Name: "node02", Labels: map[string]string{v1.NodeSchedulable: "true"}}},
+			&k8sv1.Node{ObjectMeta: k8smetav1.ObjectMeta{Name: "node03", Labels: map[string]string{v1.NodeSchedulable: "false"}}},
+		)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().MigrationPolicy().
+			Return(virtClient.MigrationsV1alpha1().MigrationPolicies()).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
 	})
 
 	It("should fail with missing input parameters", func() {
@@ -87,6 +122,79 @@ var _ = Describe("Migrate command", func() {
 			&v1.MigrateOptions{
 				AddedNodeSelector: map[string]string{"key1": "value1", "key2": "value2"}},
 			"--addedNodeSelector", "key1=value1", "--addedNodeSelector", "key2=value2"),
+		Entry(
+			"with node option",
+			&v1.MigrateOptions{
+				AddedNodeSelector: map[string]string{k8sv1.LabelHostname: "node02"}},
+			"--node", "node02"),
+		Entry(
+			"with node and addedNodeSelector options",
+			&v1.MigrateOptions{
+				AddedNodeSelector: map[string]string{k8sv1.LabelHostname: "node02", "key1": "value1"}},
+			"--node", "node02", "--addedNodeSelector", "key1=value1"),
+		Entry(
+			"with policy option",
+			&v1.MigrateOptions{
+				MigrationPolicyName: pointer.P("my-policy")},
+			"--policy", "my-policy"),
+		Entry(
+			"with dry-run, node and policy options",
+			&v1.MigrateOptions{
+				AddedNodeSelector:   map[string]string{k8sv1.LabelHostname: "node02"},
+				MigrationPolicyName: pointer.P("my-policy"),
+				DryRun:              []string{k8smetav1.DryRunAll}},
+			"--dry-run", "--node", "node02", "--policy", "my-policy"),
+	)
+
+	It("should fail if the node conflicts with the addedNodeSelector", func() {
+		err := testing.NewRepeatableVirtctlCommand("migrate", vmName, "--node", "node02",
+			"--addedNodeSelector", k8sv1.LabelHostname+"=node01")()
+		Expect(err).To(MatchError(ContainSubstring("--node=node02 conflicts with the addedNodeSelector")))
+	})
+
+	DescribeTable("should report why the VM is not migratable on dry-run", func(update func(), expected []string, extraArgs ...string) {
+		if update != nil {
+			update()
+		}
+		args := append([]string{"migrate", vmName, "--dry-run"}, extraArgs...)
+		out, err := testing.NewRepeatableVirtctlCommandWithOut(args...)()
+		Expect(err).To(MatchError("VM testvm is not migratable"))
+		Expect(string(out)).To(ContainSubstring("VM testvm is not migratable:"))
+		for _, reason := range expected {
+			Expect(string(out)).To(ContainSubstring(reason))
+		}
+	},
+		Entry("if it is not running", func() {
+			Expect(virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault).
+				Delete(context.Background(), vmName, k8smetav1.DeleteOptions{})).To(Succeed())
+		}, []string{"the VM is not running"}),
+		Entry("if it is not live migratable", func() {
+			vmi, err := virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault).
+				Get(context.Background(), vmName, k8smetav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+				Type:    v1.VirtualMachineInstanceIsMigratable,
+				Status:  k8sv1.ConditionFalse,
+				Reason:  v1.VirtualMachineInstanceReasonDisksNotMigratable,
+				Message: "cannot migrate VMI with a local disk",
+			}}
+			_, err = virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault).
+				Update(context.Background(), vmi, k8smetav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}, []string{"DisksNotLiveMigratable: cannot migrate VMI with a local disk"}),
+		Entry("if it is already migrating", func() {
+			vmi, err := virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault).
+				Get(context.Background(), vmName, k8smetav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{}
+			_, err = virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault).
+				Update(context.Background(), vmi, k8smetav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}, []string{"a migration is already in progress"}),
+		Entry("to the node it is running on", nil, []string{"the VM is already running on node node01"}, "--node", "node01"),
+		Entry("to a missing node", nil, []string{"node missing does not exist"}, "--node", "missing"),
+		Entry("to an unschedulable node", nil, []string{"node node03: node is not schedulable for virtual machines"}, "--node", "node03"),
+		Entry("with a missing policy", nil, []string{"the MigrationPolicy missing does not exist"}, "--policy", "missing"),
 	)
 
 	DescribeTable("should fail with badly formatted addedNodeSelector", func(extraArgs ...string) {
@@ -104,4 +212,101 @@ var _ = Describe("Migrate command", func() {
 			"--addedNodeSelector", "key1,key2"),
 	)
 
+	Context("with --wait", func() {
+		newMigration := func(phase v1.VirtualMachineInstanceMigrationPhase, state *v1.VirtualMachineInstanceMigrationState) *v1.VirtualMachineInstanceMigration {
+			migration := kubecli.NewMinimalMigration("testvm-migration")
+			migration.UID = "migration-uid"
+			migration.Labels = map[string]string{v1.MigrationSelectorLabel: vmName}
+			migration.Status.Phase = phase
+			migration.Status.MigrationState = state
+			return migration
+		}
+
+		expectMigrate := func(migrations ...*v1.VirtualMachineInstanceMigration) {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Migrate(gomock.Any(), vmName, gomock.Any()).Return(nil).Times(1)
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstanceMigration(k8smetav1.NamespaceDefault).
+				Return(virtClient.KubevirtV1().VirtualMachineInstanceMigrations(k8smetav1.NamespaceDefault)).AnyTimes()
+
+			// Every list returns the next state of the migration, the last one is kept
+			calls := 0
+			virtClient.PrependReactor("list", "virtualmachineinstancemigrations", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+				migration := migrations[min(calls, len(migrations)-1)]
+				calls++
+				return true, &v1.VirtualMachineInstanceMigrationList{Items: []v1.VirtualMachineInstanceMigration{*migration}}, nil
+			})
+		}
+
+		It("should report the progress until the migration succeeded", func() {
+			vmi, err := virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault).
+				Get(context.Background(), vmName, k8smetav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID: "migration-uid",
+				TransferStatus: &v1.VirtualMachineInstanceMigrationTransferStatus{
+					DataProcessedBytes:            1024 * 1024 * 1024,
+					DataRemainingBytes:            3 * 1024 * 1024 * 1024,
+					DataTotalBytes:                4 * 1024 * 1024 * 1024,
+					MemoryDirtyRateBytesPerSecond: 10 * 1024 * 1024,
+					MemoryBandwidthBytesPerSecond: 110 * 1024 * 1024,
+				},
+			}
+			_, err = virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault).
+				Update(context.Background(), vmi, k8smetav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			expectMigrate(
+				newMigration(v1.MigrationRunning, nil),
+				newMigration(v1.MigrationSucceeded, &v1.VirtualMachineInstanceMigrationState{TargetNode: "node02"}),
+			)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("migrate", vmName, "--wait")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring(
+				"Migration testvm-migration: Running, transferred 1.0GiB of 4.0GiB, dirty rate 10.0MiB/s, ETA 30s\n"))
+			Expect(string(out)).To(ContainSubstring("Migration testvm-migration: Succeeded\n"))
+			Expect(string(out)).To(ContainSubstring("VM testvm was migrated to node node02\n"))
+		})
+
+		It("should report when the migration does not converge", func() {
+			vmi, err := virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault).
+				Get(context.Background(), vmName, k8smetav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID: "migration-uid",
+				TransferStatus: &v1.VirtualMachineInstanceMigrationTransferStatus{
+					DataProcessedBytes:            512,
+					DataTotalBytes:                2048,
+					MemoryDirtyRateBytesPerSecond: 2048,
+					MemoryBandwidthBytesPerSecond: 1024,
+				},
+			}
+			_, err = virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault).
+				Update(context.Background(), vmi, k8smetav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			expectMigrate(
+				newMigration(v1.MigrationRunning, nil),
+				newMigration(v1.MigrationSucceeded, nil),
+			)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("migrate", vmName, "--wait")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring(
+				"transferred 512B of 2.0KiB, dirty rate 2.0KiB/s, ETA unknown (memory is dirtied faster than it is transferred)"))
+			Expect(string(out)).To(ContainSubstring("VM testvm was migrated\n"))
+		})
+
+		It("should fail if the migration failed", func() {
+			expectMigrate(newMigration(v1.MigrationFailed, &v1.VirtualMachineInstanceMigrationState{FailureReason: "target pod crashed"}))
+
+			err := testing.NewRepeatableVirtctlCommand("migrate", vmName, "--wait")()
+			Expect(err).To(MatchError("migration testvm-migration of VM testvm failed: target pod crashed"))
+		})
+
+		It("should reject a non-positive timeout", func() {
+			err := testing.NewRepeatableVirtctlCommand("migrate", vmName, "--wait", "--timeout", "0s")()
+			Expect(err).To(MatchError("the timeout must be greater than zero"))
+		})
+	})
 })
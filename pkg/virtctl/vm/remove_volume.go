@@ -27,27 +27,41 @@ import (
 
 	"github.com/spf13/cobra"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
+const deleteArg = "delete"
+
+var deleteVolume bool
+
 func NewRemoveVolumeCommand() *cobra.Command {
 	c := Command{}
 	cmd := &cobra.Command{
-		Use:     "removevolume VMI",
-		Short:   "remove a volume from a running VM",
-		Example: usageRemoveVolume(),
-		Args:    cobra.ExactArgs(1),
-		RunE:    c.removeVolumeRun,
+		Use:               "removevolume VMI",
+		Short:             "remove a volume from a running VM",
+		Example:           usageRemoveVolume(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		RunE:              c.removeVolumeRun,
 	}
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	cmd.Flags().StringVar(&volumeName, volumeNameArg, "", "name used in volumes section of spec")
 	cmd.MarkFlagRequired(volumeNameArg)
 	cmd.Flags().BoolVar(&persist, persistArg, false, "[deprecated] this flag has no effect and will be removed in a future release")
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	cmd.Flags().BoolVar(&deleteVolume, deleteArg, false, "delete the DataVolume or PersistentVolumeClaim backing the volume once it is removed")
+	requirements.Subresources(cmd, "virtualmachineinstances/removevolume")
+	requirements.AnyFeatureGate(cmd, featuregate.HotplugVolumesGate, featuregate.DeclarativeHotplugVolumesGate)
 	return cmd
 }
 
@@ -58,6 +72,9 @@ func usageRemoveVolume() string {
   #[deprecated] Remove volume dynamically attached to a running VM and persisting it in the VM spec.
   --persist flag has no effect and will be removed in future release. New default behavior will be to persist in VM spec even if flag is not provided.
   {{ProgramName}} removevolume fedora-dv --volume-name=example-dv --persist
+
+  #Remove volume dynamically attached to a running VM and delete the DataVolume or PersistentVolumeClaim backing it.
+  {{ProgramName}} removevolume fedora-dv --volume-name=example-dv --delete
   `
 }
 
@@ -70,8 +87,17 @@ func (o *Command) removeVolumeRun(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	var backingVolume *v1.Volume
+	if deleteVolume {
+		backingVolume, err = getHotplugVolume(cmd.Context(), virtClient, namespace, vmiName, volumeName)
+		if err != nil {
+			return fmt.Errorf("error removing volume, %w", err)
+		}
+	}
+
 	dryRunOption := setDryRunOption(dryRun)
 	retry := 0
+	kind := v1.VirtualMachineGroupVersionKind.Kind
 	for retry < maxRetries {
 		// default to removing volume from both VM and VMI if owner VM exists
 		err = virtClient.VirtualMachine(namespace).RemoveVolume(context.Background(), vmiName, &v1.RemoveVolumeOptions{
@@ -81,6 +107,7 @@ func (o *Command) removeVolumeRun(cmd *cobra.Command, args []string) error {
 
 		// If VM is not found, VMI is standalone
 		if k8serrors.IsNotFound(err) {
+			kind = v1.VirtualMachineInstanceGroupVersionKind.Kind
 			err = virtClient.VirtualMachineInstance(namespace).RemoveVolume(context.Background(), vmiName, &v1.RemoveVolumeOptions{
 				Name:   volumeName,
 				DryRun: dryRunOption,
@@ -88,7 +115,7 @@ func (o *Command) removeVolumeRun(cmd *cobra.Command, args []string) error {
 		}
 
 		if err != nil && err.Error() != concurrentError {
-			return fmt.Errorf("error removing volume, %v", err)
+			return fmt.Errorf("error removing volume, %w", err)
 		}
 		if err == nil {
 			break
@@ -101,6 +128,62 @@ func (o *Command) removeVolumeRun(cmd *cobra.Command, args []string) error {
 	if err != nil && retry == maxRetries {
 		return fmt.Errorf("error removing volume after %d retries", maxRetries)
 	}
+	result.RecordChange(cmd.Context(), result.Change{Action: "RemoveVolume", Kind: kind, Namespace: namespace, Name: vmiName, DryRun: dryRun})
 	fmt.Printf("Successfully submitted remove volume request to VM %s for volume %s\n", vmiName, volumeName)
+
+	if backingVolume != nil {
+		return deleteBackingVolume(cmd.Context(), virtClient, namespace, backingVolume, dryRunOption)
+	}
+	return nil
+}
+
+// getHotplugVolume returns the volume of the VM, or of the VMI if it is
+// standalone, so that its backing storage can be deleted after removal.
+func getHotplugVolume(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, vmiName, volumeName string) (*v1.Volume, error) {
+	var volumes []v1.Volume
+	vm, err := virtClient.VirtualMachine(namespace).Get(ctx, vmiName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		vmi, err := virtClient.VirtualMachineInstance(namespace).Get(ctx, vmiName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		volumes = vmi.Spec.Volumes
+	} else if err != nil {
+		return nil, err
+	} else if vm.Spec.Template != nil {
+		volumes = vm.Spec.Template.Spec.Volumes
+	}
+
+	for i := range volumes {
+		volume := &volumes[i]
+		if volume.Name != volumeName {
+			continue
+		}
+		if volume.DataVolume == nil && volume.PersistentVolumeClaim == nil {
+			return nil, fmt.Errorf("volume %s is not backed by a DataVolume or PersistentVolumeClaim, cannot use --%s", volumeName, deleteArg)
+		}
+		return volume, nil
+	}
+	return nil, fmt.Errorf("volume %s not found in %s", volumeName, vmiName)
+}
+
+func deleteBackingVolume(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string, volume *v1.Volume, dryRunOption []string) error {
+	var (
+		kind, name string
+		err        error
+	)
+	deleteOptions := metav1.DeleteOptions{DryRun: dryRunOption}
+	if volume.DataVolume != nil {
+		kind, name = "DataVolume", volume.DataVolume.Name
+		err = virtClient.CdiClient().CdiV1beta1().DataVolumes(namespace).Delete(ctx, name, deleteOptions)
+	} else {
+		kind, name = "PersistentVolumeClaim", volume.PersistentVolumeClaim.ClaimName
+		err = virtClient.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, name, deleteOptions)
+	}
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting %s %s, %w", kind, name, err)
+	}
+	result.RecordChange(ctx, result.Change{Action: "Delete", Kind: kind, Namespace: namespace, Name: name, DryRun: dryRun})
+	fmt.Printf("Successfully deleted %s %s\n", kind, name)
 	return nil
 }
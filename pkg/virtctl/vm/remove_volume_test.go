@@ -20,21 +20,28 @@
 package vm_test
 
 import (
+	"context"
 	"errors"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	k8stesting "k8s.io/client-go/testing"
 
 	v1 "kubevirt.io/api/core/v1"
+	cdifake "kubevirt.io/client-go/containerizeddataimporter/fake"
 	"kubevirt.io/client-go/kubecli"
 	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
 	kvtesting "kubevirt.io/client-go/testing"
 
+	"kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
 	"kubevirt.io/kubevirt/pkg/virtctl/testing"
 )
 
@@ -183,4 +190,79 @@ var _ = Describe("Remove volume command", func() {
 		cmdRemove := testing.NewRepeatableVirtctlCommand(commandAndArgs...)
 		Expect(cmdRemove()).To(MatchError(ContainSubstring("error removing volume after 15 retries")))
 	})
+
+	Context("with --delete", func() {
+		var (
+			cdiClient  *cdifake.Clientset
+			coreClient *k8sfake.Clientset
+		)
+
+		BeforeEach(func() {
+			cdiClient = cdifake.NewSimpleClientset(&v1beta1.DataVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "testdv", Namespace: metav1.NamespaceDefault},
+			})
+			coreClient = k8sfake.NewSimpleClientset(&k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "testpvc", Namespace: metav1.NamespaceDefault},
+			})
+			kubecli.MockKubevirtClientInstance.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+			kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(coreClient.CoreV1()).AnyTimes()
+		})
+
+		createVM := func(opts ...libvmi.Option) {
+			vm := libvmi.NewVirtualMachine(libvmi.New(append([]libvmi.Option{libvmi.WithName(vmiName)}, opts...)...))
+			vm.Namespace = metav1.NamespaceDefault
+			_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).Create(context.Background(), vm, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		runCmd := func(extraArgs ...string) error {
+			args := append([]string{"removevolume", vmiName, "--volume-name=" + volumeName, "--delete"}, extraArgs...)
+			return testing.NewRepeatableVirtctlCommand(args...)()
+		}
+
+		It("should delete the DataVolume backing the removed volume", func() {
+			createVM(libvmi.WithHotplugDataVolume(volumeName, "testdv"))
+			expectVMEndpointRemoveVolumeErrorFunc(false, nil)
+
+			Expect(runCmd()).To(Succeed())
+			_, err := cdiClient.CdiV1beta1().DataVolumes(metav1.NamespaceDefault).Get(context.Background(), "testdv", metav1.GetOptions{})
+			Expect(err).To(MatchError(ContainSubstring("not found")))
+		})
+
+		It("should delete the PersistentVolumeClaim backing the removed volume", func() {
+			createVM(libvmi.WithHotplugPersistentVolumeClaim(volumeName, "testpvc"))
+			expectVMEndpointRemoveVolumeErrorFunc(false, nil)
+
+			Expect(runCmd()).To(Succeed())
+			_, err := coreClient.CoreV1().PersistentVolumeClaims(metav1.NamespaceDefault).Get(context.Background(), "testpvc", metav1.GetOptions{})
+			Expect(err).To(MatchError(ContainSubstring("not found")))
+		})
+
+		It("should delete the DataVolume with dry-run", func() {
+			createVM(libvmi.WithHotplugDataVolume(volumeName, "testdv"))
+			expectVMEndpointRemoveVolumeErrorFunc(true, nil)
+
+			Expect(runCmd("--dry-run")).To(Succeed())
+			deleteActions := kvtesting.FilterActions(&cdiClient.Fake, "delete", "datavolumes")
+			Expect(deleteActions).To(HaveLen(1))
+			Expect(deleteActions[0].(k8stesting.DeleteActionImpl).GetDeleteOptions().DryRun).To(Equal([]string{metav1.DryRunAll}))
+		})
+
+		It("should not delete anything if the volume cannot be removed", func() {
+			createVM(libvmi.WithHotplugDataVolume(volumeName, "testdv"))
+			expectVMEndpointRemoveVolumeErrorFunc(false, func() error { return errors.New("error removing") })
+
+			Expect(runCmd()).To(MatchError(ContainSubstring("error removing")))
+			Expect(kvtesting.FilterActions(&cdiClient.Fake, "delete", "datavolumes")).To(BeEmpty())
+		})
+
+		It("should fail if the volume does not exist", func() {
+			createVM()
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(metav1.NamespaceDefault).
+				Return(virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault))
+
+			Expect(runCmd()).To(MatchError(ContainSubstring("volume testvolume not found in testvmi")))
+			Expect(kvtesting.FilterActions(&virtClient.Fake, "put", "virtualmachines", "removevolume")).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,71 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vm
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const COMMAND_RENAME = "rename"
+
+func NewRenameCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "rename (VM) (NEW-NAME)",
+		Short:             "Rename a stopped virtual machine together with the DataVolumes and ControllerRevisions it controls.",
+		Example:           usageRename(),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.VM,
+		RunE:              renameRun,
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	requirements.Subresources(cmd, "virtualmachines/rename")
+	return cmd
+}
+
+func usageRename() string {
+	return `  # Rename the stopped virtual machine 'myvm' to 'mynewvm':
+  {{ProgramName}} rename myvm mynewvm`
+}
+
+func renameRun(cmd *cobra.Command, args []string) error {
+	name, newName := args[0], args[1]
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if err := virtClient.VirtualMachine(namespace).Rename(cmd.Context(), name, &v1.RenameOptions{NewName: newName}); err != nil {
+		return fmt.Errorf("error renaming VirtualMachine %s/%s: %w", namespace, name, err)
+	}
+	result.RecordChange(cmd.Context(), result.Change{Action: "Rename", Kind: v1.VirtualMachineGroupVersionKind.Kind, Namespace: namespace, Name: newName})
+	cmd.Printf("VM %s/%s was renamed to %s\n", namespace, name, newName)
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vm_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Rename command", func() {
+	const (
+		vmName    = "testvm"
+		newVMName = "renamed"
+	)
+
+	var vmInterface *kubecli.MockVirtualMachineInterface
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).AnyTimes()
+	})
+
+	It("should fail without the new name", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("rename", vmName)
+		Expect(cmd()).To(MatchError("accepts 2 arg(s), received 1"))
+	})
+
+	It("should rename the VM", func() {
+		vmInterface.EXPECT().Rename(gomock.Any(), vmName, &v1.RenameOptions{NewName: newVMName}).Return(nil)
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("rename", vmName, newVMName)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring(fmt.Sprintf("VM %s/%s was renamed to %s", k8smetav1.NamespaceDefault, vmName, newVMName)))
+	})
+
+	It("should return the error of the rename", func() {
+		vmInterface.EXPECT().Rename(gomock.Any(), vmName, &v1.RenameOptions{NewName: newVMName}).Return(fmt.Errorf("VM must be stopped to be renamed"))
+
+		cmd := testing.NewRepeatableVirtctlCommand("rename", vmName, newVMName)
+		Expect(cmd()).To(MatchError(ContainSubstring("VM must be stopped to be renamed")))
+	})
+})
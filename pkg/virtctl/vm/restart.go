@@ -24,56 +24,173 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
-const COMMAND_RESTART = "restart"
+const (
+	COMMAND_RESTART = "restart"
+
+	rollingArg        = "rolling"
+	maxUnavailableArg = "max-unavailable"
+	poolArg           = "pool"
+)
+
+type restartCommand struct {
+	Command
+	rolling        bool
+	maxUnavailable int
+	pool           string
+}
 
 func NewRestartCommand() *cobra.Command {
-	c := Command{command: COMMAND_RESTART}
+	c := restartCommand{Command: Command{command: COMMAND_RESTART}}
 	cmd := &cobra.Command{
-		Use:     "restart (VM)",
-		Short:   "Restart a virtual machine.",
-		Example: usage(COMMAND_RESTART),
-		Args:    cobra.ExactArgs(1),
-		RunE:    c.restartRun,
+		Use:               "restart (VM)",
+		Short:             "Restart a virtual machine.",
+		Example:           usageRestart(),
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.restartRun,
 	}
 	cmd.Flags().BoolVar(&forceRestart, forceArg, false, "--force=false: Only used when grace-period=0. If true, immediately remove VMI pod from API and bypass graceful deletion. Note that immediate deletion of some resources may result in inconsistency or data loss and requires confirmation.")
 	cmd.Flags().Int64Var(&gracePeriod, gracePeriodArg, -1, "--grace-period=-1: Period of time in seconds given to the VMI to terminate gracefully. Can only be set to 0 when --force is true (force deletion). Currently only setting 0 is supported.")
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	cmd.Flags().BoolVar(&c.rolling, rollingArg, false, "--rolling=false: Restart the VirtualMachines matching the label selector or pool in batches of --max-unavailable, waiting until the restarted VMs are running before restarting the next batch. Stops at the first batch which fails to become ready.")
+	cmd.Flags().IntVar(&c.maxUnavailable, maxUnavailableArg, 1, "The number of VirtualMachines restarted at the same time when --rolling is set.")
+	cmd.Flags().StringVar(&c.pool, poolArg, "", "Act on all VirtualMachines of the VirtualMachinePool instead of a single one.")
+	cmd.Flags().DurationVar(&c.timeout, timeoutArg, defaultReadyTimeout, "the maximum time to wait for each restarted VM to become ready when --rolling is set")
+	cmd.Flags().BoolVar(&c.waitForAgent, waitAgentArg, false, "--wait-for-agent=false: when --rolling is set, additionally wait until the guest agent of each restarted VMI is connected")
+	c.bulk.AddFlags(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
-func (o *Command) restartRun(cmd *cobra.Command, args []string) error {
-	vmiName := args[0]
-	errorFmt := "error restarting VirtualMachine: %v"
+func usageRestart() string {
+	return usageBulk(COMMAND_RESTART) + `
+
+  # Restart all virtual machines labeled tier=web one at a time, waiting for each to run again before restarting the next:
+  {{ProgramName}} restart -l tier=web --rolling --max-unavailable 1
+
+  # Restart the virtual machines of the pool 'web' two at a time, waiting for their guest agents to connect:
+  {{ProgramName}} restart --pool web --rolling --max-unavailable 2 --wait-for-agent`
+}
+
+func (o *restartCommand) validate(cmd *cobra.Command, named bool) error {
+	switch {
+	case o.pool != "" && named:
+		return fmt.Errorf("a name and --%s cannot be used together", poolArg)
+	case o.pool != "" && o.bulk.Enabled():
+		return fmt.Errorf("--%s and --selector cannot be used together", poolArg)
+	case o.pool != "" && o.bulk.AllNamespaces:
+		return fmt.Errorf("--%s cannot be used together with --all-namespaces", poolArg)
+	case o.rolling && named:
+		return fmt.Errorf("--%s requires --selector or --%s", rollingArg, poolArg)
+	case o.rolling && o.maxUnavailable <= 0:
+		return fmt.Errorf("--%s must be greater than zero", maxUnavailableArg)
+	case o.rolling && o.timeout <= 0:
+		return fmt.Errorf("the timeout must be greater than zero")
+	case !o.rolling && (cmd.Flags().Changed(maxUnavailableArg) || cmd.Flags().Changed(timeoutArg) || o.waitForAgent):
+		return fmt.Errorf("--%s, --%s and --%s require --%s", maxUnavailableArg, timeoutArg, waitAgentArg, rollingArg)
+	}
+	if o.pool != "" {
+		return nil
+	}
+	return o.bulk.Validate(named)
+}
+
+// poolSelector returns the label selector matching the VirtualMachines of the pool.
+func (o *restartCommand) poolSelector(ctx context.Context, virtClient kubecli.KubevirtClient, namespace string) (string, error) {
+	pool, err := virtClient.GeneratedKubeVirtClient().PoolV1beta1().VirtualMachinePools(namespace).Get(ctx, o.pool, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error getting VirtualMachinePool %s: %w", o.pool, err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(pool.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid selector of VirtualMachinePool %s: %w", o.pool, err)
+	}
+	return selector.String(), nil
+}
+
+func (o *restartCommand) restartRun(cmd *cobra.Command, args []string) error {
+	if err := o.validate(cmd, len(args) == 1); err != nil {
+		return result.NewUsageError(err)
+	}
+	errorFmt := "error restarting VirtualMachine: %w"
 
 	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 
+	if o.pool != "" {
+		o.bulk.Selector, err = o.poolSelector(cmd.Context(), virtClient, namespace)
+		if err != nil {
+			return err
+		}
+	}
+
 	dryRunOption := setDryRunOption(dryRun)
 	gracePeriodChanged := cmd.Flags().Changed(gracePeriodArg)
 
 	if gracePeriodChanged != forceRestart {
-		return fmt.Errorf("Must both use --force=true and set --grace-period.")
+		return result.NewUsageError(fmt.Errorf("Must both use --force=true and set --grace-period."))
 	}
 
 	restartOpts := &v1.RestartOptions{DryRun: dryRunOption}
 	if forceRestart {
 		restartOpts.GracePeriodSeconds = &gracePeriod
-		errorFmt = "error force restarting VirtualMachine: %v"
+		errorFmt = "error force restarting VirtualMachine: %w"
 	}
 
-	err = virtClient.VirtualMachine(namespace).Restart(context.Background(), vmiName, restartOpts)
-	if err != nil {
-		return fmt.Errorf(errorFmt, err)
+	restart := func(ctx context.Context, vm types.NamespacedName) error {
+		err := virtClient.VirtualMachine(vm.Namespace).Restart(ctx, vm.Name, restartOpts)
+		if err != nil {
+			return fmt.Errorf(errorFmt, err)
+		}
+		result.RecordChange(cmd.Context(), result.Change{Action: "Restart", Kind: v1.VirtualMachineGroupVersionKind.Kind, Namespace: vm.Namespace, Name: vm.Name, DryRun: dryRun})
+		return nil
+	}
+
+	// rollingRestart only returns once the recreated VMI is ready, so that
+	// the next batch is not restarted before this one is back.
+	rollingRestart := func(ctx context.Context, vm types.NamespacedName) error {
+		var previous types.UID
+		vmi, err := virtClient.VirtualMachineInstance(vm.Namespace).Get(ctx, vm.Name, metav1.GetOptions{})
+		if err == nil {
+			previous = vmi.UID
+		} else if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("error getting VirtualMachineInstance %s: %w", vm.Name, err)
+		}
+		if err := restart(ctx, vm); err != nil || dryRun {
+			return err
+		}
+		return waitForVMReady(virtClient, vm, o.timeout, o.waitForAgent, previous)
+	}
+
+	if o.bulk.Enabled() {
+		vms, err := o.bulk.ListVirtualMachines(cmd.Context(), virtClient, namespace)
+		if err != nil {
+			return err
+		}
+		if o.rolling {
+			return o.bulk.RunInBatches(cmd, o.command, vms, o.maxUnavailable, rollingRestart)
+		}
+		return o.bulk.Run(cmd, o.command, vms, restart)
+	}
+
+	vmiName := args[0]
+	if err := restart(context.Background(), types.NamespacedName{Namespace: namespace, Name: vmiName}); err != nil {
+		return err
 	}
 
 	fmt.Printf("VM %s was scheduled to %s\n", vmiName, o.command)
@@ -27,10 +27,14 @@ import (
 	"go.uber.org/mock/gomock"
 
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	v1 "kubevirt.io/api/core/v1"
+	poolv1beta1 "kubevirt.io/api/pool/v1beta1"
 	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
 
+	"kubevirt.io/kubevirt/pkg/libvmi"
 	"kubevirt.io/kubevirt/pkg/virtctl/testing"
 )
 
@@ -50,7 +54,7 @@ var _ = Describe("Restart command", func() {
 		cmd := testing.NewRepeatableVirtctlCommand("restart")
 		err := cmd()
 		Expect(err).To(HaveOccurred())
-		Expect(err).Should(MatchError("accepts 1 arg(s), received 0"))
+		Expect(err).Should(MatchError("either a name or --selector is required"))
 	})
 
 	DescribeTable("test", func(restartOptions v1.RestartOptions, runStrategy bool, running bool, args ...string) {
@@ -90,4 +94,83 @@ var _ = Describe("Restart command", func() {
 		cmd := testing.NewRepeatableVirtctlCommand("restart", vmName, "--force", "--grace-period=0")
 		Expect(cmd()).To(Succeed())
 	})
+
+	Context("with --rolling", func() {
+		var virtClient *kubevirtfake.Clientset
+
+		newVMI := func(name string, uid types.UID) *v1.VirtualMachineInstance {
+			vmi := libvmi.New(libvmi.WithNamespace(k8smetav1.NamespaceDefault), libvmi.WithName(name))
+			vmi.UID = uid
+			vmi.Status.Phase = v1.Running
+			return vmi
+		}
+
+		BeforeEach(func() {
+			virtClient = kubevirtfake.NewSimpleClientset(newVMI("web1", "old-web1"), newVMI("web2", "old-web2"))
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(gomock.Any()).Return(vmInterface).AnyTimes()
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).
+				Return(virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault)).AnyTimes()
+			kubecli.MockKubevirtClientInstance.EXPECT().GeneratedKubeVirtClient().Return(virtClient).AnyTimes()
+		})
+
+		expectList := func(selector string) {
+			web1, web2 := kubecli.NewMinimalVM("web1"), kubecli.NewMinimalVM("web2")
+			web1.Namespace, web2.Namespace = k8smetav1.NamespaceDefault, k8smetav1.NamespaceDefault
+			vmInterface.EXPECT().List(gomock.Any(), k8smetav1.ListOptions{LabelSelector: selector}).
+				Return(&v1.VirtualMachineList{Items: []v1.VirtualMachine{*web1, *web2}}, nil).Times(1)
+		}
+
+		// recreate replaces the VMI of a restarted VM like the VM controller would
+		recreate := func(_ context.Context, name string, _ *v1.RestartOptions) error {
+			vmis := virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault)
+			Expect(vmis.Delete(context.Background(), name, k8smetav1.DeleteOptions{})).To(Succeed())
+			_, err := vmis.Create(context.Background(), newVMI(name, types.UID("new-"+name)), k8smetav1.CreateOptions{})
+			return err
+		}
+
+		It("should restart the VMs one batch at a time", func() {
+			expectList("tier=web")
+			vmInterface.EXPECT().Restart(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(recreate).Times(2)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("restart", "-l", "tier=web", "--rolling", "--yes")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("Batch 1/2: restart default/web1\nBatch 2/2: restart default/web2\n"))
+			Expect(string(out)).To(MatchRegexp(`default\s+web1\s+OK\ndefault\s+web2\s+OK\n`))
+		})
+
+		It("should stop when a restarted VM does not become ready", func() {
+			expectList("tier=web")
+			vmInterface.EXPECT().Restart(gomock.Any(), "web1", gomock.Any()).Return(nil).Times(1)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("restart", "-l", "tier=web", "--rolling", "--yes", "--timeout", "1ms")()
+			Expect(err).To(MatchError("failed to restart 1 of 2 resources, skipped 1"))
+			Expect(string(out)).To(ContainSubstring("timed out waiting for VM web1 to become ready: the previous VMI was not terminated yet"))
+			Expect(string(out)).To(MatchRegexp(`default\s+web2\s+skipped\n`))
+		})
+
+		It("should restart the VMs of a pool", func() {
+			pool := &poolv1beta1.VirtualMachinePool{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "web", Namespace: k8smetav1.NamespaceDefault},
+				Spec: poolv1beta1.VirtualMachinePoolSpec{
+					Selector: &k8smetav1.LabelSelector{MatchLabels: map[string]string{"pool": "web"}},
+				},
+			}
+			_, err := virtClient.PoolV1beta1().VirtualMachinePools(k8smetav1.NamespaceDefault).Create(context.Background(), pool, k8smetav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			expectList("pool=web")
+			vmInterface.EXPECT().Restart(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(recreate).Times(2)
+
+			Expect(testing.NewRepeatableVirtctlCommand("restart", "--pool", "web", "--rolling", "--max-unavailable", "2", "--yes")()).To(Succeed())
+		})
+
+		DescribeTable("should reject", func(expected string, args ...string) {
+			err := testing.NewRepeatableVirtctlCommand(append([]string{"restart"}, args...)...)()
+			Expect(err).To(MatchError(expected))
+		},
+			Entry("a name", "--rolling requires --selector or --pool", vmName, "--rolling"),
+			Entry("a non-positive --max-unavailable", "--max-unavailable must be greater than zero", "-l", "tier=web", "--rolling", "--max-unavailable", "0"),
+			Entry("--max-unavailable without --rolling", "--max-unavailable, --timeout and --wait-for-agent require --rolling", "-l", "tier=web", "--max-unavailable", "2"),
+			Entry("--pool together with --selector", "--pool and --selector cannot be used together", "--pool", "web", "-l", "tier=web"),
+		)
+	})
 })
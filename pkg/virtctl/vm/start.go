@@ -22,18 +22,32 @@ package vm
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
 
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 const (
 	COMMAND_START = "start"
 	pausedArg     = "paused"
+	waitAgentArg  = "wait-for-agent"
+
+	defaultReadyTimeout = 5 * time.Minute
+	readyPollInterval   = time.Second
 )
 
 var (
@@ -43,20 +57,40 @@ var (
 func NewStartCommand() *cobra.Command {
 	c := Command{command: COMMAND_START}
 	cmd := &cobra.Command{
-		Use:     "start (VM)",
-		Short:   "Start a virtual machine.",
-		Example: usage(COMMAND_START),
-		Args:    cobra.ExactArgs(1),
-		RunE:    c.startRun,
+		Use:               "start (VM)",
+		Short:             "Start a virtual machine.",
+		Example:           usageStart(),
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.startRun,
 	}
 	cmd.Flags().BoolVar(&startPaused, pausedArg, false, "--paused=false: If set to true, start virtual machine in paused state")
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	cmd.Flags().BoolVar(&c.wait, waitArg, false, "--wait=false: wait until the VMI is running. The command fails with the condition blocking the VMI if it does not become ready in time.")
+	cmd.Flags().DurationVar(&c.timeout, timeoutArg, defaultReadyTimeout, "the maximum time to wait for each VM when --wait is set")
+	cmd.Flags().BoolVar(&c.waitForAgent, waitAgentArg, false, "--wait-for-agent=false: when --wait is set, additionally wait until the guest agent of the VMI is connected")
+	c.bulk.AddFlags(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
+func usageStart() string {
+	return usageBulk(COMMAND_START) + `
+
+  # Start a virtual machine called 'myvm' and wait up to 10 minutes until its guest agent is connected:
+  {{ProgramName}} start myvm --wait --wait-for-agent --timeout 10m`
+}
+
 func (o *Command) startRun(cmd *cobra.Command, args []string) error {
-	vmiName := args[0]
+	if err := o.bulk.Validate(len(args) == 1); err != nil {
+		return result.NewUsageError(err)
+	}
+	if o.wait && o.timeout <= 0 {
+		return result.NewUsageError(fmt.Errorf("the timeout must be greater than zero"))
+	}
+	if o.waitForAgent && !o.wait {
+		return result.NewUsageError(fmt.Errorf("--%s requires --%s", waitAgentArg, waitArg))
+	}
 
 	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
@@ -65,12 +99,117 @@ func (o *Command) startRun(cmd *cobra.Command, args []string) error {
 
 	dryRunOption := setDryRunOption(dryRun)
 
-	err = virtClient.VirtualMachine(namespace).Start(context.Background(), vmiName, &v1.StartOptions{Paused: startPaused, DryRun: dryRunOption})
-	if err != nil {
-		return fmt.Errorf("Error starting VirtualMachine %v", err)
+	start := func(ctx context.Context, vm types.NamespacedName) error {
+		err := virtClient.VirtualMachine(vm.Namespace).Start(ctx, vm.Name, &v1.StartOptions{Paused: startPaused, DryRun: dryRunOption})
+		if err != nil {
+			return fmt.Errorf("Error starting VirtualMachine %w", err)
+		}
+		result.RecordChange(cmd.Context(), result.Change{Action: "Start", Kind: v1.VirtualMachineGroupVersionKind.Kind, Namespace: vm.Namespace, Name: vm.Name, DryRun: dryRun})
+		if !o.wait || dryRun {
+			return nil
+		}
+		return waitForVMReady(virtClient, vm, o.timeout, o.waitForAgent, "")
 	}
 
-	fmt.Printf("VM %s was scheduled to %s\n", vmiName, o.command)
+	if o.bulk.Enabled() {
+		vms, err := o.bulk.ListVirtualMachines(cmd.Context(), virtClient, namespace)
+		if err != nil {
+			return err
+		}
+		return o.bulk.Run(cmd, o.command, vms, start)
+	}
+
+	vmiName := args[0]
+	if err := start(context.Background(), types.NamespacedName{Namespace: namespace, Name: vmiName}); err != nil {
+		return err
+	}
+
+	switch {
+	case !o.wait || dryRun:
+		fmt.Printf("VM %s was scheduled to %s\n", vmiName, o.command)
+	case o.waitForAgent:
+		cmd.Printf("VM %s is running and its guest agent is connected\n", vmiName)
+	default:
+		cmd.Printf("VM %s is running\n", vmiName)
+	}
 
 	return nil
 }
+
+// waitForVMReady waits until the VMI of the VM is running and, if requested,
+// its guest agent is connected. A VMI with the UID previous is not considered,
+// so that a restarted VM is only ready once it was recreated. On timeout the
+// returned error names the condition the VMI was last blocked on.
+func waitForVMReady(virtClient kubecli.KubevirtClient, vm types.NamespacedName, timeout time.Duration, waitForAgent bool, previous types.UID) error {
+	blocker := "the VMI was not created yet"
+	err := virtwait.PollImmediately(readyPollInterval, timeout, func(ctx context.Context) (bool, error) {
+		vmi, err := virtClient.VirtualMachineInstance(vm.Namespace).Get(ctx, vm.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			blocker = vmBlockingCondition(ctx, virtClient, vm)
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("error getting VirtualMachineInstance %s: %w", vm.Name, err)
+		}
+		if previous != "" && vmi.UID == previous {
+			blocker = "the previous VMI was not terminated yet"
+			return false, nil
+		}
+		if vmi.IsFinal() {
+			return false, fmt.Errorf("VMI %s is %s: %s", vm.Name, vmi.Status.Phase, vmiBlockingCondition(vmi, waitForAgent))
+		}
+		blocker = vmiBlockingCondition(vmi, waitForAgent)
+		return blocker == "", nil
+	})
+	if err != nil {
+		if wait.Interrupted(err) {
+			return fmt.Errorf("timed out waiting for VM %s to become ready: %s", vm.Name, blocker)
+		}
+		return fmt.Errorf("error waiting for VM %s to become ready: %w", vm.Name, err)
+	}
+	return nil
+}
+
+// vmBlockingCondition describes why a VM has no VMI yet, based on its
+// printable status and the failure condition reported by the VM controller.
+func vmBlockingCondition(ctx context.Context, virtClient kubecli.KubevirtClient, name types.NamespacedName) string {
+	vm, err := virtClient.VirtualMachine(name.Namespace).Get(ctx, name.Name, metav1.GetOptions{})
+	if err != nil {
+		return "the VMI was not created yet"
+	}
+	for _, condition := range vm.Status.Conditions {
+		if condition.Type == v1.VirtualMachineFailure && condition.Status == k8sv1.ConditionTrue {
+			return fmt.Sprintf("the VMI was not created yet, VM is %s: %s", vm.Status.PrintableStatus, condition.Message)
+		}
+	}
+	return fmt.Sprintf("the VMI was not created yet, VM is %s", vm.Status.PrintableStatus)
+}
+
+// vmiBlockingCondition returns an empty string if the VMI is ready, otherwise
+// a description of what the VMI is waiting for.
+func vmiBlockingCondition(vmi *v1.VirtualMachineInstance, waitForAgent bool) string {
+	if vmi.Status.Phase != v1.Running {
+		blocker := fmt.Sprintf("VMI is %s", vmi.Status.Phase)
+		if vmi.Status.Phase == "" {
+			blocker = "VMI is Pending"
+		}
+		for _, condition := range vmi.Status.Conditions {
+			if condition.Status == k8sv1.ConditionFalse && condition.Message != "" {
+				return fmt.Sprintf("%s, %s: %s", blocker, condition.Reason, condition.Message)
+			}
+		}
+		return blocker
+	}
+	if waitForAgent && !agentConnected(vmi) {
+		return "VMI is Running, the guest agent is not connected"
+	}
+	return ""
+}
+
+func agentConnected(vmi *v1.VirtualMachineInstance) bool {
+	for _, condition := range vmi.Status.Conditions {
+		if condition.Type == v1.VirtualMachineInstanceAgentConnected {
+			return condition.Status == k8sv1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -21,16 +21,20 @@ package vm_test
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	k8sv1 "k8s.io/api/core/v1"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
 
+	"kubevirt.io/kubevirt/pkg/libvmi"
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/virtctl/testing"
 )
@@ -51,7 +55,7 @@ var _ = Describe("Start command", func() {
 		cmd := testing.NewRepeatableVirtctlCommand("start")
 		err := cmd()
 		Expect(err).To(HaveOccurred())
-		Expect(err).Should(MatchError("accepts 1 arg(s), received 0"))
+		Expect(err).Should(MatchError("either a name or --selector is required"))
 	})
 
 	It("with dry-run parameter should not start VM", func() {
@@ -113,4 +117,130 @@ var _ = Describe("Start command", func() {
 		})
 	})
 
+	Context("With --selector", func() {
+		BeforeEach(func() {
+			vm1 := kubecli.NewMinimalVM("db1")
+			vm1.Namespace = "ns1"
+			vm2 := kubecli.NewMinimalVM("db2")
+			vm2.Namespace = "ns2"
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(gomock.Any()).Return(vmInterface).AnyTimes()
+			vmInterface.EXPECT().List(gomock.Any(), k8smetav1.ListOptions{LabelSelector: "app=db"}).
+				Return(&v1.VirtualMachineList{Items: []v1.VirtualMachine{*vm1, *vm2}}, nil).Times(1)
+		})
+
+		It("should start all matching VMs and print the results", func() {
+			vmInterface.EXPECT().Start(gomock.Any(), "db1", &v1.StartOptions{}).Return(nil).Times(1)
+			vmInterface.EXPECT().Start(gomock.Any(), "db2", &v1.StartOptions{}).Return(nil).Times(1)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("start", "-l", "app=db", "--all-namespaces", "--yes")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(MatchRegexp(`ns1\s+db1\s+OK\nns2\s+db2\s+OK\n`))
+		})
+
+		It("should report the VMs which failed to start", func() {
+			vmInterface.EXPECT().Start(gomock.Any(), "db1", &v1.StartOptions{}).Return(nil).Times(1)
+			vmInterface.EXPECT().Start(gomock.Any(), "db2", &v1.StartOptions{}).Return(fmt.Errorf("VM is already running")).Times(1)
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("start", "-l", "app=db", "-A", "-y")()
+			Expect(err).To(MatchError("failed to start 1 of 2 resources"))
+			Expect(string(out)).To(MatchRegexp(`ns2\s+db2\s+Error starting VirtualMachine VM is already running\n`))
+		})
+	})
+
+	It("should reject a name together with --selector", func() {
+		err := testing.NewRepeatableVirtctlCommand("start", vmName, "-l", "app=db")()
+		Expect(err).To(MatchError("a name and --selector cannot be used together"))
+	})
+
+	Context("With --wait", func() {
+		var virtClient *kubevirtfake.Clientset
+
+		expectStart := func(vmi *v1.VirtualMachineInstance) {
+			virtClient = kubevirtfake.NewSimpleClientset()
+			if vmi != nil {
+				virtClient = kubevirtfake.NewSimpleClientset(vmi)
+			}
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).AnyTimes()
+			vmInterface.EXPECT().Start(gomock.Any(), vmName, gomock.Any()).Return(nil).Times(1)
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).
+				Return(virtClient.KubevirtV1().VirtualMachineInstances(k8smetav1.NamespaceDefault)).AnyTimes()
+		}
+
+		newVMI := func(phase v1.VirtualMachineInstancePhase, conditions ...v1.VirtualMachineInstanceCondition) *v1.VirtualMachineInstance {
+			vmi := libvmi.New(libvmi.WithNamespace(k8smetav1.NamespaceDefault), libvmi.WithName(vmName))
+			vmi.Status.Phase = phase
+			vmi.Status.Conditions = conditions
+			return vmi
+		}
+
+		agentConnected := v1.VirtualMachineInstanceCondition{
+			Type:   v1.VirtualMachineInstanceAgentConnected,
+			Status: k8sv1.ConditionTrue,
+		}
+
+		It("should wait until the VMI is running", func() {
+			expectStart(newVMI(v1.Running))
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("start", vmName, "--wait")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("VM testvm is running\n"))
+		})
+
+		It("should wait until the guest agent is connected", func() {
+			expectStart(newVMI(v1.Running, agentConnected))
+
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("start", vmName, "--wait", "--wait-for-agent")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("VM testvm is running and its guest agent is connected\n"))
+		})
+
+		It("should fail if the VMI failed", func() {
+			expectStart(newVMI(v1.Failed))
+
+			err := testing.NewRepeatableVirtctlCommand("start", vmName, "--wait")()
+			Expect(err).To(MatchError(ContainSubstring("VMI testvm is Failed")))
+		})
+
+		DescribeTable("should report the blocking condition on timeout", func(vmi *v1.VirtualMachineInstance, expected string, extraArgs ...string) {
+			expectStart(vmi)
+
+			args := append([]string{"start", vmName, "--wait", "--timeout", "1ms"}, extraArgs...)
+			err := testing.NewRepeatableVirtctlCommand(args...)()
+			Expect(err).To(MatchError("timed out waiting for VM testvm to become ready: " + expected))
+		},
+			Entry("when the VMI is not scheduled",
+				newVMI(v1.Scheduling, v1.VirtualMachineInstanceCondition{
+					Type:    v1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled),
+					Status:  k8sv1.ConditionFalse,
+					Reason:  k8sv1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available: 3 Insufficient memory.",
+				}),
+				"VMI is Scheduling, Unschedulable: 0/3 nodes are available: 3 Insufficient memory.",
+			),
+			Entry("when the guest agent is not connected",
+				newVMI(v1.Running), "VMI is Running, the guest agent is not connected", "--wait-for-agent",
+			),
+		)
+
+		It("should report the VM status if the VMI was not created", func() {
+			expectStart(nil)
+			vm := kubecli.NewMinimalVM(vmName)
+			vm.Status.PrintableStatus = v1.VirtualMachineStatusDataVolumeError
+			vmInterface.EXPECT().Get(gomock.Any(), vmName, gomock.Any()).Return(vm, nil).AnyTimes()
+
+			err := testing.NewRepeatableVirtctlCommand("start", vmName, "--wait", "--timeout", "1ms")()
+			Expect(err).To(MatchError("timed out waiting for VM testvm to become ready: the VMI was not created yet, VM is DataVolumeError"))
+		})
+
+		It("should reject a non-positive timeout", func() {
+			err := testing.NewRepeatableVirtctlCommand("start", vmName, "--wait", "--timeout", "0s")()
+			Expect(err).To(MatchError("the timeout must be greater than zero"))
+		})
+
+		It("should reject --wait-for-agent without --wait", func() {
+			err := testing.NewRepeatableVirtctlCommand("start", vmName, "--wait-for-agent")()
+			Expect(err).To(MatchError("--wait-for-agent requires --wait"))
+		})
+	})
 })
@@ -24,10 +24,15 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
 
 	v1 "kubevirt.io/api/core/v1"
 
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virtctl/bulk"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -36,23 +41,27 @@ const COMMAND_STOP = "stop"
 func NewStopCommand() *cobra.Command {
 	c := Command{command: COMMAND_STOP}
 	cmd := &cobra.Command{
-		Use:     "stop (VM)",
-		Short:   "Stop a virtual machine.",
-		Example: usage(COMMAND_STOP),
-		Args:    cobra.ExactArgs(1),
-		RunE:    c.stopRun,
+		Use:               "stop (VM)",
+		Short:             "Stop a virtual machine.",
+		Example:           usageBulk(COMMAND_STOP),
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.stopRun,
 	}
 
-	cmd.Flags().BoolVar(&forceRestart, forceArg, false, "--force=false: Only used when grace-period=0. If true, immediately remove VMI pod from API and bypass graceful deletion. Note that immediate deletion of some resources may result in inconsistency or data loss and requires confirmation.")
-	cmd.Flags().Int64Var(&gracePeriod, gracePeriodArg, -1, "--grace-period=-1: Period of time in seconds given to the VMI to terminate gracefully. Can only be set to 0 when --force is true (force deletion). Currently only setting 0 is supported.")
+	cmd.Flags().BoolVar(&forceRestart, forceArg, false, "--force=false: If true, stop the VM immediately without a graceful shutdown of the guest. Implies --grace-period=0. Data which was not written to the disks yet may be lost, which is why confirmation is required unless --yes is given.")
+	cmd.Flags().Int64Var(&gracePeriod, gracePeriodArg, -1, "--grace-period=-1: Period of time in seconds given to the VM to shut down gracefully, overriding its terminationGracePeriodSeconds. The virt-launcher pod is terminated accordingly. Must be 0 when --force is true.")
 	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	c.bulk.AddFlags(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
 func (o *Command) stopRun(cmd *cobra.Command, args []string) error {
-	vmiName := args[0]
-	errorFmt := "error stopping VirtualMachine %v"
+	if err := o.bulk.Validate(len(args) == 1); err != nil {
+		return result.NewUsageError(err)
+	}
+	errorFmt := "error stopping VirtualMachine %w"
 
 	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
 	if err != nil {
@@ -62,22 +71,70 @@ func (o *Command) stopRun(cmd *cobra.Command, args []string) error {
 	dryRunOption := setDryRunOption(dryRun)
 	gracePeriodChanged := cmd.Flags().Changed(gracePeriodArg)
 
-	if gracePeriodChanged != forceRestart {
-		return fmt.Errorf("Must both use --force=true and set --grace-period.")
+	switch {
+	case gracePeriodChanged && gracePeriod < 0:
+		return result.NewUsageError(fmt.Errorf("--%s must not be negative", gracePeriodArg))
+	case forceRestart && gracePeriodChanged && gracePeriod != 0:
+		return result.NewUsageError(fmt.Errorf("--%s can only be used with --%s=0", forceArg, gracePeriodArg))
 	}
 
 	stopOpts := &v1.StopOptions{DryRun: dryRunOption}
-	if forceRestart {
+	if gracePeriodChanged {
 		stopOpts.GracePeriod = &gracePeriod
-		errorFmt = "error force stopping VirtualMachine: %v"
+	}
+	if forceRestart {
+		stopOpts.GracePeriod = pointer.P(int64(0))
+		errorFmt = "error force stopping VirtualMachine: %w"
+
+		if !o.bulk.Yes {
+			printForceStopImplications(cmd)
+			// With a label selector the affected VMs are listed and
+			// confirmed together by the bulk run.
+			if !o.bulk.Enabled() {
+				confirmed, err := bulk.AskToContinue(cmd)
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					cmd.Println("Aborted")
+					return nil
+				}
+			}
+		}
 	}
 
-	err = virtClient.VirtualMachine(namespace).Stop(context.Background(), vmiName, stopOpts)
-	if err != nil {
-		return fmt.Errorf(errorFmt, err)
+	stop := func(ctx context.Context, vm types.NamespacedName) error {
+		err := virtClient.VirtualMachine(vm.Namespace).Stop(ctx, vm.Name, stopOpts)
+		if err != nil {
+			return fmt.Errorf(errorFmt, err)
+		}
+		result.RecordChange(cmd.Context(), result.Change{Action: "Stop", Kind: v1.VirtualMachineGroupVersionKind.Kind, Namespace: vm.Namespace, Name: vm.Name, DryRun: dryRun})
+		return nil
+	}
+
+	if o.bulk.Enabled() {
+		vms, err := o.bulk.ListVirtualMachines(cmd.Context(), virtClient, namespace)
+		if err != nil {
+			return err
+		}
+		return o.bulk.Run(cmd, o.command, vms, stop)
+	}
+
+	vmiName := args[0]
+	if err := stop(context.Background(), types.NamespacedName{Namespace: namespace, Name: vmiName}); err != nil {
+		return err
 	}
 
 	fmt.Printf("VM %s was scheduled to %s\n", vmiName, o.command)
 
 	return nil
 }
+
+// printForceStopImplications explains what may be lost when a VM is stopped
+// without a graceful shutdown of the guest.
+func printForceStopImplications(cmd *cobra.Command) {
+	cmd.Println("Force stopping immediately terminates the guest without a graceful shutdown:")
+	cmd.Println("  - data which was not yet written to the disks of the VM is lost")
+	cmd.Println("  - file systems in the guest may be left in an inconsistent state")
+	cmd.Println("  - applications in the guest are not notified and may lose in-flight work")
+}
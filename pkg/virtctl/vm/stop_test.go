@@ -21,6 +21,7 @@ package vm_test
 
 import (
 	"context"
+	"os"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -51,7 +52,7 @@ var _ = Describe("Stop command", func() {
 		cmd := testing.NewRepeatableVirtctlCommand("stop")
 		err := cmd()
 		Expect(err).To(HaveOccurred())
-		Expect(err).Should(MatchError("accepts 1 arg(s), received 0"))
+		Expect(err).Should(MatchError("either a name or --selector is required"))
 	})
 
 	It("with dry-run parameter should not stop VM", func() {
@@ -76,10 +77,75 @@ var _ = Describe("Stop command", func() {
 		}
 		vmInterface.EXPECT().Stop(context.Background(), vm.Name, &stopOptions).Return(nil).Times(1)
 
-		cmd := testing.NewRepeatableVirtctlCommand("stop", vmName, "--force", "--grace-period=0")
+		cmd := testing.NewRepeatableVirtctlCommand("stop", vmName, "--force", "--grace-period=0", "--yes")
 		Expect(cmd()).To(Succeed())
 	})
 
+	It("should force stop vm with an implied grace period of 0", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().Stop(context.Background(), vmName, &v1.StopOptions{GracePeriod: pointer.P(int64(0))}).Return(nil).Times(1)
+
+		cmd := testing.NewRepeatableVirtctlCommand("stop", vmName, "--force", "--yes")
+		Expect(cmd()).To(Succeed())
+	})
+
+	It("should stop vm with an overridden grace period", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().Stop(context.Background(), vmName, &v1.StopOptions{GracePeriod: pointer.P(int64(10))}).Return(nil).Times(1)
+
+		cmd := testing.NewRepeatableVirtctlCommand("stop", vmName, "--grace-period=10")
+		Expect(cmd()).To(Succeed())
+	})
+
+	DescribeTable("should fail with invalid grace period", func(expectedErr string, args ...string) {
+		cmd := testing.NewRepeatableVirtctlCommand(append([]string{"stop", vmName}, args...)...)
+		Expect(cmd()).To(MatchError(expectedErr))
+	},
+		Entry("when negative", "--grace-period must not be negative", "--grace-period=-5"),
+		Entry("when not 0 with --force", "--force can only be used with --grace-period=0", "--force", "--grace-period=10"),
+	)
+
+	Context("when force stopping without --yes", func() {
+		var stdinWriter *os.File
+
+		BeforeEach(func() {
+			r, w, err := os.Pipe()
+			Expect(err).ToNot(HaveOccurred())
+			stdinWriter = w
+			oldStdin := os.Stdin
+			os.Stdin = r
+			DeferCleanup(func() {
+				os.Stdin = oldStdin
+				r.Close()
+			})
+		})
+
+		answer := func(answer string) {
+			go func() {
+				defer stdinWriter.Close()
+				_, _ = stdinWriter.WriteString(answer)
+			}()
+		}
+
+		It("should list the data loss implications and stop the vm if confirmed", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Stop(context.Background(), vmName, &v1.StopOptions{GracePeriod: pointer.P(int64(0))}).Return(nil).Times(1)
+
+			answer("y\n")
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("stop", vmName, "--force")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("data which was not yet written to the disks of the VM is lost"))
+			Expect(string(out)).To(ContainSubstring("Do you want to continue? [y/N]: "))
+		})
+
+		It("should not stop the vm if not confirmed", func() {
+			answer("n\n")
+			out, err := testing.NewRepeatableVirtctlCommandWithOut("stop", vmName, "--force")()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("Aborted"))
+		})
+	})
+
 	DescribeTable("should patch VM", func(modifyFn func(vm *v1.VirtualMachine), args ...string) {
 		vm := kubecli.NewMinimalVM(vmName)
 		modifyFn(vm)
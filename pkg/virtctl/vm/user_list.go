@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -34,11 +35,12 @@ const COMMAND_USERLIST = "userlist"
 
 func NewUserListCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "userlist (VMI)",
-		Short:   "Return full list of logged in users on the guest machine.",
-		Example: usage(COMMAND_USERLIST),
-		Args:    cobra.ExactArgs(1),
-		RunE:    userListRun,
+		Use:               "userlist (VMI)",
+		Short:             "Return full list of logged in users on the guest machine.",
+		Example:           usage(COMMAND_USERLIST),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		RunE:              userListRun,
 	}
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
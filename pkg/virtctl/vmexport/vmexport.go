@@ -20,10 +20,12 @@
 package vmexport
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -35,15 +37,18 @@ import (
 	"time"
 
 	"github.com/cheggaaa/pb/v3"
+	"github.com/klauspost/compress/zstd"
 	"github.com/spf13/cobra"
 	k8sv1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 	kubectlutil "k8s.io/kubectl/pkg/util"
+	"sigs.k8s.io/yaml"
 
 	virtv1 "kubevirt.io/api/core/v1"
 	exportv1 "kubevirt.io/api/export/v1beta1"
@@ -53,7 +58,11 @@ import (
 	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-config/featuregate"
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/devprofile"
+	"kubevirt.io/kubevirt/pkg/virtctl/requirements"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -74,10 +83,13 @@ const (
 	FORMAT_FLAG            = "--format"
 	PVC_FLAG               = "--pvc"
 	TTL_FLAG               = "--ttl"
+	AUTO_RENEW_FLAG        = "--auto-renew"
+	MAX_LIFETIME_FLAG      = "--max-lifetime"
 	MANIFEST_FLAG          = "--manifest"
 	OUTPUT_FORMAT_FLAG     = "--manifest-output-format"
 	SERVICE_URL_FLAG       = "--service-url"
 	INCLUDE_SECRET_FLAG    = "--include-secret"
+	INCLUDE_CONTENT_FLAG   = "--include-snapshot-content"
 	PORT_FORWARD_FLAG      = "--port-forward"
 	LOCAL_PORT_FLAG        = "--local-port"
 	RETRY_FLAG             = "--retry"
@@ -97,6 +109,13 @@ const (
 	APPLICATION_YAML = "application/yaml"
 	APPLICATION_JSON = "application/json"
 
+	ACCEPT_ENCODING  = "Accept-Encoding"
+	CONTENT_ENCODING = "Content-Encoding"
+	ENCODING_ZSTD    = "zstd"
+
+	// sparseBlockSize is the granularity used to detect zeroed blocks which are skipped when writing raw images
+	sparseBlockSize = 64 * 1024
+
 	// processingWaitInterval is the time interval used to wait for a virtualMachineExport to be ready
 	processingWaitInterval = 2 * time.Second
 	// DefaultProcessingWaitTotal is the default maximum time used to wait for a virtualMachineExport to be ready
@@ -117,6 +136,8 @@ const (
 	ErrIncompatibleExportType = "should not specify export kind"
 	// ErrIncompatibleExportTypeManifest serves as error message when a PVC kind is defined when getting manifest
 	ErrIncompatibleExportTypeManifest = "cannot get manifest for PVC export"
+	// ErrIncompatibleExportTypeSnapshotContent serves as error message when the snapshot content is requested for a non snapshot export
+	ErrIncompatibleExportTypeSnapshotContent = "cannot include snapshot content for a %s export, the '%s' flag requires a VirtualMachineSnapshot export"
 	// ErrInvalidValue ensures that the value provided in a flag is one of the acceptable values
 	ErrInvalidValue = "%s is not a valid value, acceptable values are %s"
 
@@ -135,13 +156,17 @@ var (
 	deleteVme            bool
 	shouldCreate         bool
 	includeSecret        bool
+	includeContent       bool
 	exportManifest       bool
 	portForward          bool
+	dev                  bool
 	format               string
 	localPort            string
 	serviceUrl           string
 	volumeName           string
 	ttl                  string
+	autoRenew            bool
+	maxLifetime          string
 	manifestOutputFormat string
 	downloadRetries      int
 	resourceLabels       []string
@@ -155,9 +180,11 @@ type VMExportInfo struct {
 	KeepVme          bool
 	DeleteVme        bool
 	IncludeSecret    bool
+	IncludeContent   bool
 	ExportManifest   bool
 	Decompress       bool
 	PortForward      bool
+	Dev              bool
 	LocalPort        string
 	OutputFile       string
 	OutputWriter     io.Writer
@@ -166,8 +193,11 @@ type VMExportInfo struct {
 	Name             string
 	OutputFormat     string
 	ServiceURL       string
+	ExportProxyPath  string
 	ExportSource     k8sv1.TypedLocalObjectReference
 	TTL              metav1.Duration
+	AutoRenew        *bool
+	MaxLifetime      metav1.Duration
 	DownloadRetries  int
 	ReadinessTimeout time.Duration
 	Labels           map[string]string
@@ -190,7 +220,7 @@ var HandleHTTPGetRequestFn = HandleHTTPGetRequest
 // RunPortForwardFn allows overriding the default port-forwarder (useful for unit testing)
 var RunPortForwardFn = RunPortForward
 
-var exportFunction func(client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error
+var exportFunction func(ctx context.Context, client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error
 
 // TODO Should use cmd.Printf and cmd.SetOut
 var printToOutput = fmt.Printf
@@ -215,6 +245,9 @@ func usage() string {
 	# Download a volume as before but through local port 5410
 	{{ProgramName}} vmexport download vm1-export --volume=volume1 --output=disk.img.gz --port-forward --local-port=5410
 
+	# Download a volume from a local development cluster without ingress/route configuration
+	{{ProgramName}} vmexport download vm1-export --volume=volume1 --output=disk.img.gz --dev
+
 	# Create a VirtualMachineExport and download the requested volume from it
 	{{ProgramName}} vmexport download vm1-export --vm=vm1 --volume=volume1 --output=disk.img.gz
 
@@ -222,7 +255,10 @@ func usage() string {
 	{{ProgramName}} vmexport download vm1-export --vm=vm1 --manifest
 
 	# Get the VirtualMachine manifest in Yaml format from an existing VirtualMachineExport including CDI header secret
-	{{ProgramName}} vmexport download existing-export --include-secret --manifest`
+	{{ProgramName}} vmexport download existing-export --include-secret --manifest
+
+	# Create a VirtualMachineExport from a snapshot and get a restorable manifest bundling the snapshot content and its Secrets
+	{{ProgramName}} vmexport download snap1-export --snapshot=snap1 --manifest --include-snapshot-content`
 	return usage
 }
 
@@ -250,18 +286,25 @@ func NewVirtualMachineExportCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&keepVme, "keep-vme", false, "When used with the 'download' option, specifies that the vmexport object should always be retained after the download finishes.")
 	cmd.Flags().BoolVar(&deleteVme, "delete-vme", false, "When used with the 'download' option, specifies that the vmexport object should always be deleted after the download finishes.")
 	cmd.MarkFlagsMutuallyExclusive("keep-vme", "delete-vme")
-	cmd.Flags().StringVar(&ttl, "ttl", "", "The time after the export was created that it is eligible to be automatically deleted, defaults to 2 hours by the server side if not specified")
+	cmd.Flags().StringVar(&ttl, "ttl", "", "The time after the export was created, or last renewed, that it is eligible to be automatically deleted, defaults to the KubeVirt configuration or 2 hours if not specified")
+	cmd.Flags().BoolVar(&autoRenew, "auto-renew", false, "Renews the export before it expires while downloads are in progress, defaults to the KubeVirt configuration if not specified")
+	cmd.Flags().StringVar(&maxLifetime, "max-lifetime", "", "The maximum time after the export was created that it is deleted, regardless of renewals")
 	cmd.Flags().StringVar(&manifestOutputFormat, "manifest-output-format", "", "Manifest output format, defaults to Yaml. Valid options are yaml or json")
 	cmd.Flags().StringVar(&serviceUrl, "service-url", "", "Specify service url to use in the returned manifest, instead of the external URL in the Virtual Machine export status. This is useful for NodePorts or if you don't have an external URL configured")
 	cmd.Flags().BoolVar(&portForward, "port-forward", false, "Configures port-forwarding on a random port. Useful to download without proper ingress/route configuration")
 	cmd.Flags().StringVar(&localPort, "local-port", "0", "Defines the specific port to be used in port-forward.")
+	cmd.Flags().BoolVar(&dev, devprofile.Flag, false, devprofile.Usage+" Downloads through the export proxy.")
+	cmd.MarkFlagsMutuallyExclusive("service-url", devprofile.Flag)
+	cmd.MarkFlagsMutuallyExclusive("port-forward", devprofile.Flag)
 	cmd.Flags().IntVar(&downloadRetries, "retry", 0, "When export server returns a transient error, we retry this number of times before giving up")
 	cmd.Flags().BoolVar(&includeSecret, "include-secret", false, "When used with manifest and set to true include a secret that contains proper headers for CDI to import using the manifest")
+	cmd.Flags().BoolVar(&includeContent, "include-snapshot-content", false, "When used with manifest on a VirtualMachineSnapshot export, include the VirtualMachineSnapshotContent and the Secrets referenced by the VM at snapshot time")
 	cmd.Flags().BoolVar(&exportManifest, "manifest", false, "Instead of downloading a volume, retrieve the VM manifest")
 	cmd.Flags().StringSliceVar(&resourceLabels, "labels", nil, "Specify custom labels to VM export object and its associated pod")
 	cmd.Flags().StringSliceVar(&resourceAnnotations, "annotations", nil, "Specify custom annotations to VM export object and its associated pod")
 	cmd.Flags().StringVar(&readinessTimeout, "readiness-timeout", "", "Specify maximum wait for VM export object to be ready")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
+	requirements.AnyFeatureGate(cmd, featuregate.VMExportGate)
 
 	return cmd
 }
@@ -286,7 +329,7 @@ func (c *command) run(cmd *cobra.Command, args []string) error {
 	vmeInfo.Namespace = namespace
 
 	// Finally, run the vmexport function (create|delete|download)
-	if err := exportFunction(virtClient, &vmeInfo); err != nil {
+	if err := exportFunction(cmd.Context(), virtClient, &vmeInfo); err != nil {
 		return err
 	}
 
@@ -363,7 +406,13 @@ func (c *command) initVMExportInfo(vmeInfo *VMExportInfo) error {
 	vmeInfo.ServiceURL = serviceUrl
 	vmeInfo.OutputFormat = manifestOutputFormat
 	vmeInfo.IncludeSecret = includeSecret
+	vmeInfo.IncludeContent = includeContent
 	vmeInfo.ExportManifest = exportManifest
+	if dev {
+		vmeInfo.Dev = dev
+		// The export proxy serves a certificate for its in-cluster service name
+		vmeInfo.Insecure = true
+	}
 	if portForward {
 		vmeInfo.PortForward = portForward
 		vmeInfo.Insecure = true
@@ -381,6 +430,17 @@ func (c *command) initVMExportInfo(vmeInfo *VMExportInfo) error {
 		}
 		vmeInfo.TTL = metav1.Duration{Duration: duration}
 	}
+	if c.cmd.Flags().Changed("auto-renew") {
+		vmeInfo.AutoRenew = &autoRenew
+	}
+	vmeInfo.MaxLifetime = metav1.Duration{}
+	if maxLifetime != "" {
+		duration, err := time.ParseDuration(maxLifetime)
+		if err != nil {
+			return err
+		}
+		vmeInfo.MaxLifetime = metav1.Duration{Duration: duration}
+	}
 	vmeInfo.ReadinessTimeout = DefaultProcessingWaitTotal
 	if readinessTimeout != "" {
 		duration, err := time.ParseDuration(readinessTimeout)
@@ -422,7 +482,7 @@ func getVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExportInf
 }
 
 // CreateVirtualMachineExport serves as a wrapper to create the virtualMachineExport object and, if needed, do error handling
-func CreateVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error {
+func CreateVirtualMachineExport(ctx context.Context, client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error {
 	vmexport, err := getVirtualMachineExport(client, vmeInfo)
 	if err != nil {
 		return err
@@ -447,11 +507,16 @@ func CreateVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExport
 	if vmeInfo.TTL.Duration > 0 {
 		vmexport.Spec.TTLDuration = &vmeInfo.TTL
 	}
+	vmexport.Spec.AutoRenew = vmeInfo.AutoRenew
+	if vmeInfo.MaxLifetime.Duration > 0 {
+		vmexport.Spec.MaxLifetime = &vmeInfo.MaxLifetime
+	}
 
-	vmexport, err = client.VirtualMachineExport(vmeInfo.Namespace).Create(context.TODO(), vmexport, metav1.CreateOptions{})
+	vmexport, err = client.VirtualMachineExport(vmeInfo.Namespace).Create(ctx, vmexport, metav1.CreateOptions{})
 	if err != nil {
 		return err
 	}
+	result.RecordChange(ctx, result.Change{Action: "Create", Kind: "VirtualMachineExport", Namespace: vmeInfo.Namespace, Name: vmeInfo.Name})
 
 	// Generate/get secret to be used with the vmexport
 	_, err = getOrCreateTokenSecret(client, vmexport)
@@ -464,8 +529,8 @@ func CreateVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExport
 }
 
 // DeleteVirtualMachineExport serves as a wrapper to delete the virtualMachineExport object
-func DeleteVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error {
-	if err := client.VirtualMachineExport(vmeInfo.Namespace).Delete(context.TODO(), vmeInfo.Name, metav1.DeleteOptions{}); err != nil {
+func DeleteVirtualMachineExport(ctx context.Context, client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error {
+	if err := client.VirtualMachineExport(vmeInfo.Namespace).Delete(ctx, vmeInfo.Name, metav1.DeleteOptions{}); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
 		}
@@ -473,14 +538,15 @@ func DeleteVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExport
 		return nil
 	}
 
+	result.RecordChange(ctx, result.Change{Action: "Delete", Kind: "VirtualMachineExport", Namespace: vmeInfo.Namespace, Name: vmeInfo.Name})
 	printToOutput("VirtualMachineExport '%s/%s' deleted succesfully\n", vmeInfo.Namespace, vmeInfo.Name)
 	return nil
 }
 
 // DownloadVirtualMachineExport handles the process of downloading the requested volume from a VirtualMachineExport object
-func DownloadVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error {
+func DownloadVirtualMachineExport(ctx context.Context, client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error {
 	for attempt := 0; attempt <= vmeInfo.DownloadRetries; attempt++ {
-		succeeded, err := downloadVirtualMachineExport(client, vmeInfo)
+		succeeded, err := downloadVirtualMachineExport(ctx, client, vmeInfo)
 		if err != nil {
 			return err
 		}
@@ -495,9 +561,9 @@ func DownloadVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExpo
 	return fmt.Errorf("retry count reached, exiting unsuccesfully")
 }
 
-func downloadVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExportInfo) (bool, error) {
+func downloadVirtualMachineExport(ctx context.Context, client kubecli.KubevirtClient, vmeInfo *VMExportInfo) (bool, error) {
 	if vmeInfo.ShouldCreate {
-		if err := CreateVirtualMachineExport(client, vmeInfo); err != nil {
+		if err := CreateVirtualMachineExport(ctx, client, vmeInfo); err != nil {
 			if errExportAlreadyExists(err) {
 				// Don't delete VMExports that already exist unless specified explicitely
 				vmeInfo.KeepVme = true
@@ -508,7 +574,7 @@ func downloadVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExpo
 	}
 
 	if shouldDeleteVMExport(vmeInfo) {
-		defer DeleteVirtualMachineExport(client, vmeInfo)
+		defer DeleteVirtualMachineExport(ctx, client, vmeInfo)
 	}
 
 	if vmeInfo.PortForward {
@@ -519,6 +585,14 @@ func downloadVirtualMachineExport(client kubecli.KubevirtClient, vmeInfo *VMExpo
 		defer close(stopChan)
 	}
 
+	if vmeInfo.Dev {
+		stop, err := setupExportProxy(client, vmeInfo)
+		if err != nil {
+			return false, err
+		}
+		defer stop()
+	}
+
 	// Wait for the vmexport object to be ready
 	if err := WaitForVirtualMachineExportFn(client, vmeInfo, processingWaitInterval, vmeInfo.ReadinessTimeout); err != nil {
 		return false, err
@@ -576,11 +650,155 @@ func getVirtualMachineManifest(client kubecli.KubevirtClient, vmexport *exportv1
 		return false, err
 	}
 	if vmeInfo.IncludeSecret {
-		return printRequestBody(client, vmexport, vmeInfo, manifestMap[exportv1.AuthHeader], headers)
+		succeeded, err = printRequestBody(client, vmexport, vmeInfo, manifestMap[exportv1.AuthHeader], headers)
+		if err != nil || !succeeded {
+			return false, err
+		}
+	}
+	if vmeInfo.IncludeContent {
+		if err := printSnapshotContent(client, vmexport, vmeInfo); err != nil {
+			return false, err
+		}
 	}
 	return true, nil
 }
 
+// printSnapshotContent appends the VirtualMachineSnapshotContent of the exported snapshot, along with the Secrets
+// referenced by the VM at snapshot time, to the manifest so the VM can be restored from a single archive
+func printSnapshotContent(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) error {
+	source := vmexport.Spec.Source
+	if source.Kind != "VirtualMachineSnapshot" {
+		return fmt.Errorf(ErrIncompatibleExportTypeSnapshotContent, source.Kind, INCLUDE_CONTENT_FLAG)
+	}
+
+	vmSnapshot, err := client.VirtualMachineSnapshot(vmexport.Namespace).Get(context.Background(), source.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if vmSnapshot.Status == nil || vmSnapshot.Status.VirtualMachineSnapshotContentName == nil {
+		return fmt.Errorf("VirtualMachineSnapshot '%s/%s' has no content yet", vmSnapshot.Namespace, vmSnapshot.Name)
+	}
+	content, err := client.VirtualMachineSnapshotContent(vmexport.Namespace).Get(context.Background(), *vmSnapshot.Status.VirtualMachineSnapshotContentName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	content.TypeMeta = metav1.TypeMeta{
+		APIVersion: snapshotv1.SchemeGroupVersion.String(),
+		Kind:       "VirtualMachineSnapshotContent",
+	}
+	content.ObjectMeta = cleanObjectMeta(content.ObjectMeta)
+	content.Status = nil
+	resources := []runtime.Object{content}
+
+	if content.Spec.Source.VirtualMachine != nil {
+		for _, secretName := range getReferencedSecretNames(&content.Spec.Source.VirtualMachine.Spec) {
+			secret, err := client.CoreV1().Secrets(vmexport.Namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+			if k8serrors.IsNotFound(err) {
+				printToOutput("Secret '%s/%s' referenced by the VirtualMachineSnapshot no longer exists, skipping\n", vmexport.Namespace, secretName)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			secret.TypeMeta = metav1.TypeMeta{
+				APIVersion: k8sv1.SchemeGroupVersion.String(),
+				Kind:       "Secret",
+			}
+			secret.ObjectMeta = cleanObjectMeta(secret.ObjectMeta)
+			resources = append(resources, secret)
+		}
+	}
+
+	return printResources(vmeInfo.OutputWriter, vmeInfo.OutputFormat, resources)
+}
+
+// cleanObjectMeta keeps only the metadata needed to recreate an object in another cluster
+func cleanObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        meta.Name,
+		Namespace:   meta.Namespace,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+}
+
+// getReferencedSecretNames returns the names of all the Secrets the VM spec depends on, without duplicates
+func getReferencedSecretNames(vmSpec *virtv1.VirtualMachineSpec) []string {
+	if vmSpec.Template == nil {
+		return nil
+	}
+	var names []string
+	seen := map[string]struct{}{}
+	add := func(name string) {
+		if _, exists := seen[name]; name != "" && !exists {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	addRef := func(ref *k8sv1.LocalObjectReference) {
+		if ref != nil {
+			add(ref.Name)
+		}
+	}
+
+	for _, volume := range vmSpec.Template.Spec.Volumes {
+		switch {
+		case volume.Secret != nil:
+			add(volume.Secret.SecretName)
+		case volume.CloudInitNoCloud != nil:
+			addRef(volume.CloudInitNoCloud.UserDataSecretRef)
+			addRef(volume.CloudInitNoCloud.NetworkDataSecretRef)
+		case volume.CloudInitConfigDrive != nil:
+			addRef(volume.CloudInitConfigDrive.UserDataSecretRef)
+			addRef(volume.CloudInitConfigDrive.NetworkDataSecretRef)
+		case volume.Sysprep != nil:
+			addRef(volume.Sysprep.Secret)
+		}
+	}
+	for _, accessCred := range vmSpec.Template.Spec.AccessCredentials {
+		if accessCred.SSHPublicKey != nil && accessCred.SSHPublicKey.Source.Secret != nil {
+			add(accessCred.SSHPublicKey.Source.Secret.SecretName)
+		}
+		if accessCred.UserPassword != nil && accessCred.UserPassword.Source.Secret != nil {
+			add(accessCred.UserPassword.Source.Secret.SecretName)
+		}
+	}
+	return names
+}
+
+// printResources writes the resources in the same layout the export server uses for its manifests,
+// a yaml document per resource or a json v1.List
+func printResources(writer io.Writer, outputFormat string, resources []runtime.Object) error {
+	if strings.ToLower(outputFormat) == OUTPUT_FORMAT_JSON {
+		list := k8sv1.List{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "List",
+			},
+		}
+		for _, resource := range resources {
+			list.Items = append(list.Items, runtime.RawExtension{Object: resource})
+		}
+		listBytes, err := json.MarshalIndent(list, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(writer, "%s\n", listBytes)
+		return err
+	}
+
+	for _, resource := range resources {
+		resourceBytes, err := yaml.Marshal(resource)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(writer, "%s---\n", resourceBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // downloadVolume handles the process of downloading the requested volume from a VirtualMachineExport
 func downloadVolume(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) (bool, error) {
 	// Extract the URL from the vmexport
@@ -589,7 +807,9 @@ func downloadVolume(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMac
 		return false, err
 	}
 
-	resp, err := HandleHTTPGetRequestFn(client, vmexport, downloadUrl, vmeInfo.Insecure, vmeInfo.ServiceURL, nil)
+	// Raw images are streamed zstd compressed if the export server supports it
+	headers := map[string]string{ACCEPT_ENCODING: ENCODING_ZSTD}
+	resp, err := HandleHTTPGetRequestFn(client, vmexport, downloadUrl, vmeInfo.Insecure, vmeInfo.ServiceURL, headers)
 	if err != nil {
 		return false, err
 	}
@@ -625,6 +845,7 @@ func replaceUrlWithServiceUrl(manifestUrl string, vmeInfo *VMExportInfo) (string
 	}
 	if vmeInfo.ServiceURL != "" {
 		manUrl.Host = vmeInfo.ServiceURL
+		manUrl.Path = vmeInfo.ExportProxyPath + manUrl.Path
 	}
 	return manUrl.String(), nil
 }
@@ -661,8 +882,12 @@ func GetUrlFromVirtualMachineExport(vmexport *exportv1.VirtualMachineExport, vme
 					}
 				}
 				// By default, we always attempt to find and get the compressed file URL,
-				// so we only break the loop when one is found.
-				if format.Format == exportv1.KubeVirtGz || format.Format == exportv1.ArchiveGz {
+				// so we only break the loop when one is found. If a raw image is requested,
+				// the raw URL is preferred as it can be streamed zstd compressed and sparse.
+				if vmeInfo.Decompress && format.Format == exportv1.KubeVirtRaw {
+					break
+				}
+				if !vmeInfo.Decompress && (format.Format == exportv1.KubeVirtGz || format.Format == exportv1.ArchiveGz) {
 					break
 				}
 			}
@@ -704,6 +929,7 @@ func GetManifestUrlsFromVirtualMachineExport(vmexport *exportv1.VirtualMachineEx
 				return nil, err
 			}
 			manUrl.Host = vmeInfo.ServiceURL
+			manUrl.Path = vmeInfo.ExportProxyPath + manUrl.Path
 			res[manifest.Type] = manUrl.String()
 		}
 	}
@@ -817,10 +1043,62 @@ func copyFileWithProgressBar(output io.Writer, resp *http.Response, decompress b
 		printToOutput("Decompressing image:\n")
 	}
 
+	if resp.Header.Get(CONTENT_ENCODING) == ENCODING_ZSTD {
+		zstdReader, err := zstd.NewReader(barRd)
+		if err != nil {
+			return err
+		}
+		defer zstdReader.Close()
+		rd = zstdReader
+		decompress = true
+	}
+
+	// Only raw images are worth writing sparse
+	if file, ok := output.(*os.File); ok && decompress && isRegularFile(file) {
+		return copySparse(file, rd)
+	}
+
 	_, err := io.Copy(output, rd)
 	return err
 }
 
+func isRegularFile(file *os.File) bool {
+	fi, err := file.Stat()
+	return err == nil && fi.Mode().IsRegular()
+}
+
+// copySparse copies the reader to the file, seeking over zeroed blocks instead
+// of writing them so that they end up as holes in the file.
+func copySparse(file *os.File, rd io.Reader) error {
+	buf := make([]byte, sparseBlockSize)
+	zeroes := make([]byte, sparseBlockSize)
+	for {
+		n, err := io.ReadFull(rd, buf)
+		if n > 0 {
+			if bytes.Equal(buf[:n], zeroes[:n]) {
+				if _, err := file.Seek(int64(n), io.SeekCurrent); err != nil {
+					return err
+				}
+			} else if _, err := file.Write(buf[:n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Trailing holes are not allocated by seeking, extend the file to its full size
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	return file.Truncate(size)
+}
+
 // getOrCreateTokenSecret obtains a token secret to be used along with the virtualMachineExport
 func getOrCreateTokenSecret(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport) (*k8sv1.Secret, error) {
 	// Securely randomize a 20 char string to be used as a token
@@ -1026,6 +1304,12 @@ func handleDownloadFlags() error {
 		if pvc != "" {
 			return fmt.Errorf(ErrIncompatibleFlag, PVC_FLAG, MANIFEST_FLAG)
 		}
+
+		if includeContent && vm != "" {
+			return fmt.Errorf(ErrIncompatibleFlag, VM_FLAG, INCLUDE_CONTENT_FLAG)
+		}
+	} else if includeContent {
+		return fmt.Errorf(ErrRequiredFlag, MANIFEST_FLAG, INCLUDE_CONTENT_FLAG)
 	}
 	if !exportManifest && outputFile == "" {
 		return fmt.Errorf("warning: Binary output can mess up your terminal. Use '%s -' to output into stdout anyway or consider '%s <FILE>' to save to a file", OUTPUT_FLAG, OUTPUT_FLAG)
@@ -1147,6 +1431,24 @@ func setupPortForward(client kubecli.KubevirtClient, vmeInfo *VMExportInfo) (cha
 	return stopChan, nil
 }
 
+// setupExportProxy forwards a local port to the export proxy of a development cluster.
+// The internal links of the export are reached through the proxy path of the export.
+func setupExportProxy(client kubecli.KubevirtClient, vmeInfo *VMExportInfo) (func(), error) {
+	proxyURL, stop, err := devprofile.ForwardExportProxyFn(client)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	vmeInfo.ServiceURL = u.Host
+	vmeInfo.ExportProxyPath = fmt.Sprintf("/api/%s/namespaces/%s/virtualmachineexports/%s", exportv1.SchemeGroupVersion.String(), vmeInfo.Namespace, vmeInfo.Name)
+	printToOutput("Forwarding to the export proxy at %s.\n", proxyURL)
+	return stop, nil
+}
+
 // RunPortForward is the actual function that runs the port-forward. Meant to be run concurrently
 func RunPortForward(client kubecli.KubevirtClient, pod k8sv1.Pod, namespace string, ports []string, stopChan, readyChan chan struct{}, portChan chan uint16) error {
 	// Create a port forwarding request
@@ -22,6 +22,7 @@ package vmexport_test
 import (
 	"context"
 	cryptorand "crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -32,6 +33,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gstruct"
@@ -43,12 +45,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	fakek8sclient "k8s.io/client-go/kubernetes/fake"
 	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
 
 	v1 "kubevirt.io/api/core/v1"
 	exportv1 "kubevirt.io/api/export/v1beta1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1beta1"
 	"kubevirt.io/client-go/kubecli"
 	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
 
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/virtctl/devprofile"
 	"kubevirt.io/kubevirt/pkg/virtctl/testing"
 	"kubevirt.io/kubevirt/pkg/virtctl/vmexport"
 )
@@ -93,6 +99,8 @@ var _ = Describe("vmexport", func() {
 		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
 		kubecli.MockKubevirtClientInstance.EXPECT().StorageV1().Return(kubeClient.StorageV1()).AnyTimes()
 		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineExport(metav1.NamespaceDefault).Return(virtClient.ExportV1beta1().VirtualMachineExports(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineSnapshot(metav1.NamespaceDefault).Return(virtClient.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineSnapshotContent(metav1.NamespaceDefault).Return(virtClient.SnapshotV1beta1().VirtualMachineSnapshotContents(metav1.NamespaceDefault)).AnyTimes()
 
 		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 			w.WriteHeader(http.StatusOK)
@@ -382,6 +390,8 @@ var _ = Describe("vmexport", func() {
 			Entry("Using 'manifest' with pvc flag", fmt.Sprintf(vmexport.ErrIncompatibleFlag, vmexport.PVC_FLAG, vmexport.MANIFEST_FLAG), runDownloadCmd, vmexport.MANIFEST_FLAG, setFlag(vmexport.PVC_FLAG, "test")),
 			Entry("Using 'manifest' with volume type", fmt.Sprintf(vmexport.ErrIncompatibleFlag, vmexport.VOLUME_FLAG, vmexport.MANIFEST_FLAG), runDownloadCmd, vmexport.MANIFEST_FLAG, setFlag(vmexport.VM_FLAG, "test"), setFlag(vmexport.VOLUME_FLAG, "volume")),
 			Entry("Using 'manifest' with invalid output_format_flag", fmt.Sprintf(vmexport.ErrInvalidValue, vmexport.OUTPUT_FORMAT_FLAG, "json/yaml"), runDownloadCmd, vmexport.MANIFEST_FLAG, setFlag(vmexport.OUTPUT_FORMAT_FLAG, "invalid")),
+			Entry("Using 'include-snapshot-content' without manifest", fmt.Sprintf(vmexport.ErrRequiredFlag, vmexport.MANIFEST_FLAG, vmexport.INCLUDE_CONTENT_FLAG), runDownloadCmd, vmexport.INCLUDE_CONTENT_FLAG, setFlag(vmexport.OUTPUT_FLAG, "-")),
+			Entry("Using 'include-snapshot-content' with vm flag", fmt.Sprintf(vmexport.ErrIncompatibleFlag, vmexport.VM_FLAG, vmexport.INCLUDE_CONTENT_FLAG), runDownloadCmd, vmexport.MANIFEST_FLAG, vmexport.INCLUDE_CONTENT_FLAG, setFlag(vmexport.VM_FLAG, "test")),
 			Entry("Using 'port-forward' with invalid port", fmt.Sprintf(vmexport.ErrInvalidValue, vmexport.LOCAL_PORT_FLAG, "valid port numbers"), runDownloadCmd, vmexport.PORT_FORWARD_FLAG, setFlag(vmexport.LOCAL_PORT_FLAG, "test")),
 			Entry("Using 'format' with invalid download format", fmt.Sprintf(vmexport.ErrInvalidValue, vmexport.FORMAT_FLAG, "gzip/raw"), runDownloadCmd, setFlag(vmexport.FORMAT_FLAG, "test")),
 			Entry("Downloading volume without specifying output", fmt.Sprintf("warning: Binary output can mess up your terminal. Use '%s -' to output into stdout anyway or consider '%s <FILE>' to save to a file", vmexport.OUTPUT_FLAG, vmexport.OUTPUT_FLAG), runDownloadCmd),
@@ -579,6 +589,47 @@ var _ = Describe("vmexport", func() {
 				)
 				Expect(err).ToNot(HaveOccurred())
 			})
+
+			It("a VirtualMachineExport with raw format streamed zstd compressed and written sparse", func() {
+				data := make([]byte, 1024*1024)
+				copy(data[512*1024:], "kubevirt")
+
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.URL.Path).To(Equal("/raw"))
+					Expect(r.Header.Get(vmexport.ACCEPT_ENCODING)).To(Equal(vmexport.ENCODING_ZSTD))
+					w.Header().Set(vmexport.CONTENT_ENCODING, vmexport.ENCODING_ZSTD)
+					encoder, err := zstd.NewWriter(w)
+					Expect(err).ToNot(HaveOccurred())
+					_, err = encoder.Write(data)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(encoder.Close()).To(Succeed())
+				})
+
+				virtClient.Fake.PrependReactor("create", "virtualmachineexports", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					vme := action.(k8stesting.CreateAction).GetObject().(*exportv1.VirtualMachineExport)
+					vme.Status = vmeStatusReady([]exportv1.VirtualMachineExportVolume{{
+						Name: volumeName,
+						Formats: []exportv1.VirtualMachineExportVolumeFormat{
+							{Format: exportv1.KubeVirtGz, Url: server.URL + "/gz"},
+							{Format: exportv1.KubeVirtRaw, Url: server.URL + "/raw"},
+						}},
+					})
+					return false, vme, nil
+				})
+
+				err := runDownloadCmd(
+					setFlag(vmexport.FORMAT_FLAG, vmexport.RAW_FORMAT),
+					setFlag(vmexport.PVC_FLAG, pvcName),
+					setFlag(vmexport.VOLUME_FLAG, volumeName),
+					setFlag(vmexport.OUTPUT_FLAG, outputPath),
+					vmexport.INSECURE_FLAG,
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				outputData, err := os.ReadFile(outputPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(outputData).To(Equal(data))
+			})
 		})
 
 		It("Succesfully download a VirtualMachineExport with just 'raw' links", func() {
@@ -657,6 +708,33 @@ var _ = Describe("vmexport", func() {
 			Expect(*vme.Spec.TTLDuration).To(Equal(ttl))
 		})
 
+		It("Succesfully create VirtualMachineExport with an expiration policy", func() {
+			maxLifetime := metav1.Duration{Duration: 24 * time.Hour}
+			err := runCreateCmd(
+				setFlag(vmexport.PVC_FLAG, pvcName),
+				setFlag(vmexport.AUTO_RENEW_FLAG, "true"),
+				setFlag(vmexport.MAX_LIFETIME_FLAG, maxLifetime.Duration.String()),
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			vme, err := virtClient.ExportV1beta1().VirtualMachineExports(metav1.NamespaceDefault).Get(context.Background(), vme.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vme.Spec.AutoRenew).To(HaveValue(BeTrue()))
+			Expect(*vme.Spec.MaxLifetime).To(Equal(maxLifetime))
+		})
+
+		It("Succesfully create VirtualMachineExport without overriding the expiration policy", func() {
+			err := runCreateCmd(
+				setFlag(vmexport.PVC_FLAG, pvcName),
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			vme, err := virtClient.ExportV1beta1().VirtualMachineExports(metav1.NamespaceDefault).Get(context.Background(), vme.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vme.Spec.AutoRenew).To(BeNil())
+			Expect(vme.Spec.MaxLifetime).To(BeNil())
+		})
+
 		It("Succesfully create VirtualMachineExport with custom labels and annotations", func() {
 			const (
 				labelKey        = "label-key"
@@ -804,6 +882,221 @@ var _ = Describe("vmexport", func() {
 			)
 			Expect(err).To(MatchError("retry count reached, exiting unsuccesfully"))
 		})
+
+		Context("with snapshot content", func() {
+			const (
+				snapshotName    = "test-snapshot"
+				contentName     = "test-snapshot-content"
+				userDataSecret  = "userdata-secret"
+				sshKeySecret    = "sshkey-secret"
+				missingSecret   = "missing-secret"
+				snapshotVMName  = "test-vm"
+				snapshotVMUID   = "test-vm-uid"
+				contentUID      = "test-content-uid"
+				secretUID       = "test-secret-uid"
+				resourceVersion = "12345"
+			)
+
+			createSecret := func(name string) {
+				_, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(context.Background(), &k8sv1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            name,
+						Namespace:       metav1.NamespaceDefault,
+						UID:             secretUID,
+						ResourceVersion: resourceVersion,
+					},
+					Data: map[string][]byte{"key": []byte(name)},
+				}, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			BeforeEach(func() {
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.URL.String()).To(Equal(manifestUrl))
+					w.WriteHeader(http.StatusOK)
+				})
+
+				vme.Spec.Source = k8sv1.TypedLocalObjectReference{
+					APIGroup: &snapshotv1.SchemeGroupVersion.Group,
+					Kind:     "VirtualMachineSnapshot",
+					Name:     snapshotName,
+				}
+				vme.Status = vmeStatusReady([]exportv1.VirtualMachineExportVolume{{
+					Name: volumeName,
+					Formats: []exportv1.VirtualMachineExportVolumeFormat{{
+						Format: exportv1.KubeVirtGz,
+						Url:    server.URL,
+					}}},
+				})
+				vme.Status.Links.External.Manifests = append(vme.Status.Links.External.Manifests,
+					exportv1.VirtualMachineExportManifest{
+						Type: exportv1.AllManifests,
+						Url:  server.URL + manifestUrl,
+					},
+				)
+				_, err := virtClient.ExportV1beta1().VirtualMachineExports(metav1.NamespaceDefault).Create(context.Background(), vme, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(context.Background(), secret, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = virtClient.SnapshotV1beta1().VirtualMachineSnapshots(metav1.NamespaceDefault).Create(context.Background(), &snapshotv1.VirtualMachineSnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      snapshotName,
+						Namespace: metav1.NamespaceDefault,
+					},
+					Status: &snapshotv1.VirtualMachineSnapshotStatus{
+						VirtualMachineSnapshotContentName: pointer.P(contentName),
+					},
+				}, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = virtClient.SnapshotV1beta1().VirtualMachineSnapshotContents(metav1.NamespaceDefault).Create(context.Background(), &snapshotv1.VirtualMachineSnapshotContent{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            contentName,
+						Namespace:       metav1.NamespaceDefault,
+						UID:             contentUID,
+						ResourceVersion: resourceVersion,
+					},
+					Spec: snapshotv1.VirtualMachineSnapshotContentSpec{
+						VirtualMachineSnapshotName: pointer.P(snapshotName),
+						Source: snapshotv1.SourceSpec{
+							VirtualMachine: &snapshotv1.VirtualMachine{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: snapshotVMName,
+									UID:  snapshotVMUID,
+								},
+								Spec: v1.VirtualMachineSpec{
+									Template: &v1.VirtualMachineInstanceTemplateSpec{
+										Spec: v1.VirtualMachineInstanceSpec{
+											Volumes: []v1.Volume{
+												{
+													Name: "cloudinit",
+													VolumeSource: v1.VolumeSource{
+														CloudInitNoCloud: &v1.CloudInitNoCloudSource{
+															UserDataSecretRef: &k8sv1.LocalObjectReference{Name: userDataSecret},
+														},
+													},
+												},
+												{
+													Name: "secret",
+													VolumeSource: v1.VolumeSource{
+														Secret: &v1.SecretVolumeSource{SecretName: missingSecret},
+													},
+												},
+											},
+											AccessCredentials: []v1.AccessCredential{{
+												SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+													Source: v1.SSHPublicKeyAccessCredentialSource{
+														Secret: &v1.AccessCredentialSecretSource{SecretName: sshKeySecret},
+													},
+												},
+											}, {
+												SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+													Source: v1.SSHPublicKeyAccessCredentialSource{
+														Secret: &v1.AccessCredentialSecretSource{SecretName: userDataSecret},
+													},
+												},
+											}},
+										},
+									},
+								},
+							},
+						},
+					},
+					Status: &snapshotv1.VirtualMachineSnapshotContentStatus{
+						ReadyToUse: pointer.P(true),
+					},
+				}, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				createSecret(userDataSecret)
+				createSecret(sshKeySecret)
+			})
+
+			It("should append the snapshot content and referenced secrets as yaml", func() {
+				err := runDownloadCmd(
+					vmexport.MANIFEST_FLAG,
+					vmexport.INCLUDE_CONTENT_FLAG,
+					setFlag(vmexport.OUTPUT_FLAG, outputPath),
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				output, err := os.ReadFile(outputPath)
+				Expect(err).ToNot(HaveOccurred())
+				docs := strings.Split(strings.TrimSuffix(string(output), "---\n"), "---\n")
+				Expect(docs).To(HaveLen(3))
+
+				content := &snapshotv1.VirtualMachineSnapshotContent{}
+				Expect(yaml.Unmarshal([]byte(docs[0]), content)).To(Succeed())
+				Expect(content.Kind).To(Equal("VirtualMachineSnapshotContent"))
+				Expect(content.Name).To(Equal(contentName))
+				Expect(content.UID).To(BeEmpty())
+				Expect(content.ResourceVersion).To(BeEmpty())
+				Expect(content.Status).To(BeNil())
+				Expect(content.Spec.Source.VirtualMachine.Name).To(Equal(snapshotVMName))
+
+				var secretNames []string
+				for _, doc := range docs[1:] {
+					s := &k8sv1.Secret{}
+					Expect(yaml.Unmarshal([]byte(doc), s)).To(Succeed())
+					Expect(s.Kind).To(Equal("Secret"))
+					Expect(s.UID).To(BeEmpty())
+					Expect(s.ResourceVersion).To(BeEmpty())
+					Expect(s.Data).To(HaveKeyWithValue("key", []byte(s.Name)))
+					secretNames = append(secretNames, s.Name)
+				}
+				Expect(secretNames).To(Equal([]string{userDataSecret, sshKeySecret}))
+			})
+
+			It("should append the snapshot content and referenced secrets as a json list", func() {
+				err := runDownloadCmd(
+					vmexport.MANIFEST_FLAG,
+					vmexport.INCLUDE_CONTENT_FLAG,
+					setFlag(vmexport.OUTPUT_FORMAT_FLAG, vmexport.OUTPUT_FORMAT_JSON),
+					setFlag(vmexport.OUTPUT_FLAG, outputPath),
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				output, err := os.ReadFile(outputPath)
+				Expect(err).ToNot(HaveOccurred())
+				list := &k8sv1.List{}
+				Expect(json.Unmarshal(output, list)).To(Succeed())
+				Expect(list.Kind).To(Equal("List"))
+				Expect(list.Items).To(HaveLen(3))
+
+				content := &snapshotv1.VirtualMachineSnapshotContent{}
+				Expect(json.Unmarshal(list.Items[0].Raw, content)).To(Succeed())
+				Expect(content.Kind).To(Equal("VirtualMachineSnapshotContent"))
+				Expect(content.Name).To(Equal(contentName))
+
+				var secretNames []string
+				for _, item := range list.Items[1:] {
+					s := &k8sv1.Secret{}
+					Expect(json.Unmarshal(item.Raw, s)).To(Succeed())
+					Expect(s.Kind).To(Equal("Secret"))
+					secretNames = append(secretNames, s.Name)
+				}
+				Expect(secretNames).To(Equal([]string{userDataSecret, sshKeySecret}))
+			})
+
+			It("should fail if the export source is not a VirtualMachineSnapshot", func() {
+				vme.Spec.Source = k8sv1.TypedLocalObjectReference{
+					APIGroup: &v1.SchemeGroupVersion.Group,
+					Kind:     "VirtualMachine",
+					Name:     snapshotVMName,
+				}
+				_, err := virtClient.ExportV1beta1().VirtualMachineExports(metav1.NamespaceDefault).Update(context.Background(), vme, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = runDownloadCmd(
+					vmexport.MANIFEST_FLAG,
+					vmexport.INCLUDE_CONTENT_FLAG,
+					setFlag(vmexport.OUTPUT_FLAG, outputPath),
+				)
+				Expect(err).To(MatchError(fmt.Sprintf(vmexport.ErrIncompatibleExportTypeSnapshotContent, "VirtualMachine", vmexport.INCLUDE_CONTENT_FLAG)))
+			})
+		})
 	})
 
 	Context("Port-forward", func() {
@@ -918,6 +1211,38 @@ var _ = Describe("vmexport", func() {
 			)
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		It("VirtualMachineExport download with the development profile goes through the export proxy", func() {
+			proxyStopped := false
+			devprofile.ForwardExportProxyFn = func(kubecli.KubevirtClient) (string, func(), error) {
+				return "https://127.0.0.1:54321", func() { proxyStopped = true }, nil
+			}
+			DeferCleanup(func() {
+				devprofile.ForwardExportProxyFn = devprofile.ForwardExportProxy
+			})
+			vmexport.HandleHTTPGetRequestFn = func(_ kubecli.KubevirtClient, _ *exportv1.VirtualMachineExport, downloadUrl string, insecure bool, _ string, _ map[string]string) (*http.Response, error) {
+				Expect(downloadUrl).To(Equal("https://127.0.0.1:54321/api/export.kubevirt.io/v1beta1/namespaces/default/virtualmachineexports/" + vmeName))
+				Expect(insecure).To(BeTrue())
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("data")),
+				}, nil
+			}
+
+			vme, err := virtClient.ExportV1beta1().VirtualMachineExports(metav1.NamespaceDefault).Get(context.Background(), vme.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			vme.Status.Links.Internal = vme.Status.Links.External
+			_, err = virtClient.ExportV1beta1().VirtualMachineExports(metav1.NamespaceDefault).Update(context.Background(), vme, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = runDownloadCmd(
+				"--dev",
+				setFlag(vmexport.VOLUME_FLAG, volumeName),
+				setFlag(vmexport.OUTPUT_FLAG, outputPath),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(proxyStopped).To(BeTrue())
+		})
 	})
 
 	Context("getUrlFromVirtualMachineExport", func() {
@@ -10,17 +10,19 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 func NewScreenshotCommand() *cobra.Command {
 	s := Screenshot{}
 	cmd := &cobra.Command{
-		Use:     "screenshot (VMI)",
-		Short:   "Create a VNC screenshot of a virtual machine instance.",
-		Example: usage(),
-		Args:    cobra.ExactArgs(1),
-		RunE:    s.Run,
+		Use:               "screenshot (VMI)",
+		Short:             "Create a VNC screenshot of a virtual machine instance.",
+		Example:           usage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		RunE:              s.Run,
 	}
 	cmd.Flags().StringVarP(&s.fileName, "file", "f", "", "where to store the VNC screenshot in PNG format. User '-' for stdout")
 	cmd.Flags().BoolVarP(&s.moveCursor, "move-cursor", "m", false, "move the cursor to wake up the screen in case of screensavers")
@@ -0,0 +1,155 @@
+//go:build windows
+
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package vnc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const namedPipePrefix = `\\.\pipe\`
+
+// listenSocket listens on a named pipe if the path is in the pipe namespace, and on
+// a Unix domain socket otherwise. Named pipes are only accessible by the current user.
+func listenSocket(path string) (net.Listener, error) {
+	if !strings.HasPrefix(strings.ToLower(path), namedPipePrefix) {
+		return net.Listen("unix", path)
+	}
+
+	user, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return nil, err
+	}
+	sd, err := windows.SecurityDescriptorFromString(fmt.Sprintf("D:P(A;;GA;;;%s)", user.User.Sid.String()))
+	if err != nil {
+		return nil, err
+	}
+	ln := &pipeListener{
+		path:  path,
+		flags: windows.FILE_FLAG_FIRST_PIPE_INSTANCE,
+		sa: &windows.SecurityAttributes{
+			Length:             uint32(unsafe.Sizeof(windows.SecurityAttributes{})),
+			SecurityDescriptor: sd,
+		},
+	}
+
+	// Create the first instance right away so that an existing pipe is reported
+	// before the address is handed out
+	if ln.pending, err = ln.createInstance(); err != nil {
+		return nil, err
+	}
+	return ln, nil
+}
+
+type pipeListener struct {
+	path  string
+	flags uint32
+	sa    *windows.SecurityAttributes
+
+	lock    sync.Mutex
+	pending windows.Handle
+	closed  bool
+}
+
+func (l *pipeListener) createInstance() (windows.Handle, error) {
+	name, err := windows.UTF16PtrFromString(l.path)
+	if err != nil {
+		return windows.InvalidHandle, err
+	}
+	return windows.CreateNamedPipe(name,
+		windows.PIPE_ACCESS_DUPLEX|l.flags,
+		windows.PIPE_TYPE_BYTE|windows.PIPE_READMODE_BYTE|windows.PIPE_WAIT|windows.PIPE_REJECT_REMOTE_CLIENTS,
+		windows.PIPE_UNLIMITED_INSTANCES, 4096, 4096, 0, l.sa)
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	l.lock.Lock()
+	if l.closed {
+		l.lock.Unlock()
+		return nil, net.ErrClosed
+	}
+	handle := l.pending
+	if handle == windows.InvalidHandle {
+		var err error
+		l.flags = 0
+		if handle, err = l.createInstance(); err != nil {
+			l.lock.Unlock()
+			return nil, err
+		}
+		l.pending = handle
+	}
+	l.lock.Unlock()
+
+	if err := windows.ConnectNamedPipe(handle, nil); err != nil && err != windows.ERROR_PIPE_CONNECTED {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		if l.closed {
+			return nil, net.ErrClosed
+		}
+		return nil, err
+	}
+
+	l.lock.Lock()
+	l.pending = windows.InvalidHandle
+	l.lock.Unlock()
+	return &pipeConn{File: os.NewFile(uintptr(handle), l.path), addr: pipeAddr(l.path)}, nil
+}
+
+func (l *pipeListener) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	if l.pending != windows.InvalidHandle {
+		return windows.CloseHandle(l.pending)
+	}
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr {
+	return pipeAddr(l.path)
+}
+
+type pipeAddr string
+
+func (a pipeAddr) Network() string { return "pipe" }
+func (a pipeAddr) String() string  { return string(a) }
+
+type pipeConn struct {
+	*os.File
+	addr pipeAddr
+}
+
+func (c *pipeConn) LocalAddr() net.Addr                { return c.addr }
+func (c *pipeConn) RemoteAddr() net.Addr               { return c.addr }
+func (c *pipeConn) SetDeadline(_ time.Time) error      { return nil }
+func (c *pipeConn) SetReadDeadline(_ time.Time) error  { return nil }
+func (c *pipeConn) SetWriteDeadline(_ time.Time) error { return nil }
@@ -39,6 +39,7 @@ import (
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 	"kubevirt.io/kubevirt/pkg/virtctl/vnc/screenshot"
 )
@@ -66,6 +67,7 @@ const (
 var listenAddressFmt string
 var listenAddress = "127.0.0.1"
 var proxyOnly bool
+var listenSocketPath string
 var preserveSession bool
 var customPort = 0
 var vncType string
@@ -75,14 +77,17 @@ func NewCommand() *cobra.Command {
 	log.InitializeLogging("vnc")
 	c := VNC{}
 	cmd := &cobra.Command{
-		Use:     "vnc (VMI)",
-		Short:   "Open a vnc connection to a virtual machine instance.",
-		Example: usage(),
-		Args:    cobra.ExactArgs(1),
-		RunE:    c.Run,
+		Use:               "vnc (VMI)",
+		Short:             "Open a vnc connection to a virtual machine instance.",
+		Example:           usage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VMI,
+		RunE:              c.Run,
 	}
 	cmd.Flags().StringVar(&listenAddress, "address", listenAddress, "--address=127.0.0.1: Setting this will change the listening address of the VNC server. Example: --address=0.0.0.0 will make the server listen on all interfaces.")
 	cmd.Flags().BoolVar(&proxyOnly, "proxy-only", proxyOnly, "--proxy-only=false: Setting this true will run only the virtctl vnc proxy and show the port where VNC viewers can connect")
+	cmd.Flags().StringVar(&listenSocketPath, "listen-socket", "",
+		"--listen-socket=/path/to/vnc.sock: Setting this will make the proxy listen on a Unix domain socket (or a named pipe like \\\\.\\pipe\\vnc on Windows) accessible only by the current user instead of a TCP port. Requires --proxy-only")
 	cmd.Flags().BoolVar(&preserveSession, "preserve-session", false,
 		"--preserve-session: This option will preserve an existing VNC session instead of dropping it.")
 	cmd.Flags().IntVar(&customPort, "port", customPort,
@@ -90,6 +95,8 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringVar(&vncType, "vnc-type", "", "--vnc-type=tiger: Specify the type of VNC viewer to use (tiger, chicken, real, remote-viewer). Must provide --vnc-path")
 	cmd.Flags().StringVar(&vncPath, "vnc-path", "", "--vnc-path=/path/to/vnc: Specify the path to the VNC viewer executable. Must provide --vnc-type")
 	cmd.MarkFlagsRequiredTogether("vnc-type", "vnc-path")
+	cmd.MarkFlagsMutuallyExclusive("listen-socket", "address")
+	cmd.MarkFlagsMutuallyExclusive("listen-socket", "port")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	cmd.AddCommand(screenshot.NewScreenshotCommand())
 	return cmd
@@ -108,28 +115,21 @@ func (o *VNC) Run(cmd *cobra.Command, args []string) error {
 
 	vmi := args[0]
 
+	if listenSocketPath != "" && !proxyOnly {
+		return fmt.Errorf("--listen-socket can only be used together with --proxy-only")
+	}
+
 	// setup connection with VM
 	vnc, err := virtCli.VirtualMachineInstance(namespace).VNC(vmi, preserveSession)
 	if err != nil {
 		return fmt.Errorf("can't access VMI %s: %s", vmi, err.Error())
 	}
-	// Format the listening address to account for the port (ex: 127.0.0.0:5900)
-	// Set listenAddress to localhost if proxy-only flag is not set
-	if !proxyOnly {
-		listenAddress = "127.0.0.1"
-		log.Log.V(2).Infof("--proxy-only is set to false, listening on %s\n", listenAddress)
-	}
-	listenAddressFmt = listenAddress + ":%d"
-	lnAddr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf(listenAddressFmt, customPort))
+	// The local server is used to proxy the podExec websock connection to vnc client
+	ln, err := listen()
 	if err != nil {
-		return fmt.Errorf("can't resolve the address: %s", err.Error())
-	}
-
-	// The local tcp server is used to proxy the podExec websock connection to vnc client
-	ln, err := net.ListenTCP("tcp", lnAddr)
-	if err != nil {
-		return fmt.Errorf("can't listen on unix socket: %s", err.Error())
+		return err
 	}
+	defer ln.Close()
 	// End of pre-flight checks. Everything looks good, we can start
 	// the goroutines and let the data flow
 
@@ -158,7 +158,7 @@ func (o *VNC) Run(cmd *cobra.Command, args []string) error {
 		// Don't set deadline if only proxy is running and VNC is to be connected manually
 		if !proxyOnly {
 			// exit early if spawning vnc client fails
-			ln.SetDeadline(time.Now().Add(LISTEN_TIMEOUT))
+			ln.(*net.TCPListener).SetDeadline(time.Now().Add(LISTEN_TIMEOUT))
 		}
 		fd, err := ln.Accept()
 		if err != nil {
@@ -187,12 +187,16 @@ func (o *VNC) Run(cmd *cobra.Command, args []string) error {
 		<-ctx.Done()
 	}()
 
-	port := ln.Addr().(*net.TCPAddr).Port
+	port := 0
+	if tcpAddr, ok := ln.Addr().(*net.TCPAddr); ok {
+		port = tcpAddr.Port
+	}
 
 	if proxyOnly {
 		optionString, err := json.Marshal(struct {
-			Port int `json:"port"`
-		}{port})
+			Port   int    `json:"port,omitempty"`
+			Socket string `json:"socket,omitempty"`
+		}{port, listenSocketPath})
 		if err != nil {
 			return fmt.Errorf("error encountered: %s", err.Error())
 		}
@@ -219,6 +223,36 @@ func (o *VNC) Run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// listen creates the local server VNC viewers connect to, either on a TCP port or on the
+// socket given with --listen-socket.
+func listen() (net.Listener, error) {
+	if listenSocketPath != "" {
+		ln, err := listenSocket(listenSocketPath)
+		if err != nil {
+			return nil, fmt.Errorf("can't listen on %s: %s", listenSocketPath, err.Error())
+		}
+		return ln, nil
+	}
+
+	// Format the listening address to account for the port (ex: 127.0.0.0:5900)
+	// Set listenAddress to localhost if proxy-only flag is not set
+	if !proxyOnly {
+		listenAddress = "127.0.0.1"
+		log.Log.V(2).Infof("--proxy-only is set to false, listening on %s\n", listenAddress)
+	}
+	listenAddressFmt = listenAddress + ":%d"
+	lnAddr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf(listenAddressFmt, customPort))
+	if err != nil {
+		return nil, fmt.Errorf("can't resolve the address: %s", err.Error())
+	}
+
+	ln, err := net.ListenTCP("tcp", lnAddr)
+	if err != nil {
+		return nil, fmt.Errorf("can't listen on unix socket: %s", err.Error())
+	}
+	return ln, nil
+}
+
 func getUserSpecifiedVnc(ctx context.Context, osType, vncType, vncPath string, port int) (string, []string, error) {
 	if _, err := os.Stat(vncPath); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
@@ -357,5 +391,8 @@ func remoteViewerArgs(port int) (args []string) {
 
 func usage() string {
 	return `  # Connect to 'testvmi' via remote-viewer:
-   {{ProgramName}} vnc testvmi`
+   {{ProgramName}} vnc testvmi
+
+  # Only run the proxy and let VNC viewers connect through a Unix domain socket:
+   {{ProgramName}} vnc testvmi --proxy-only --listen-socket=/run/user/1000/testvmi-vnc.sock`
 }
@@ -0,0 +1,308 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	virtwait "kubevirt.io/kubevirt/pkg/apimachinery/wait"
+	"kubevirt.io/kubevirt/pkg/virtctl/clientconfig"
+	"kubevirt.io/kubevirt/pkg/virtctl/completion"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	forFlag     = "for"
+	timeoutFlag = "timeout"
+
+	defaultTimeout = 30 * time.Second
+	pollInterval   = time.Second
+
+	forConditionPrefix = "condition="
+	forJSONPathPrefix  = "jsonpath="
+	forDelete          = "delete"
+)
+
+type command struct {
+	forSpec string
+	timeout time.Duration
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for a specific condition on a KubeVirt resource.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newVMCommand())
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func newVMCommand() *cobra.Command {
+	c := command{}
+	cmd := &cobra.Command{
+		Use:   "vm (VM)",
+		Short: "Wait for a condition of a VirtualMachine or its VirtualMachineInstance.",
+		Long: `Wait for a condition of a VirtualMachine or its VirtualMachineInstance.
+
+Conditions are looked up on the VirtualMachine first and then on its VirtualMachineInstance,
+e.g. Ready is reported by the VirtualMachine while AgentConnected is only reported by the
+VirtualMachineInstance. The wait fails early if a condition can not become true anymore
+because the run strategy of the VirtualMachine will not (re)start it.
+
+JSONPath expressions are evaluated against the VirtualMachine.`,
+		Example:           usage(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.VM,
+		RunE:              c.run,
+	}
+	cmd.Flags().StringVar(&c.forSpec, forFlag, "", "The condition to wait for: condition=TYPE[=STATUS], jsonpath='{JSONPATH}'[=VALUE] or delete.")
+	cmd.Flags().DurationVar(&c.timeout, timeoutFlag, defaultTimeout, "The maximum time to wait. Zero means to check once and not wait.")
+	if err := cmd.MarkFlagRequired(forFlag); err != nil {
+		panic(err)
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	return `  # Wait for the VirtualMachine 'my-vm' to be ready
+  {{ProgramName}} wait vm my-vm --for condition=Ready
+
+  # Wait up to five minutes for the guest agent of the VirtualMachine 'my-vm' to connect
+  {{ProgramName}} wait vm my-vm --for condition=AgentConnected --timeout=5m
+
+  # Wait for the VirtualMachine 'my-vm' to be stopped
+  {{ProgramName}} wait vm my-vm --for jsonpath='{.status.printableStatus}'=Stopped
+
+  # Wait for the VirtualMachine 'my-vm' to be deleted
+  {{ProgramName}} wait vm my-vm --for delete`
+}
+
+// waitCondition is a parsed --for flag
+type waitCondition struct {
+	spec string
+
+	conditionType   string
+	conditionStatus k8sv1.ConditionStatus
+
+	jsonPath      *jsonpath.JSONPath
+	jsonPathValue *string
+
+	deletion bool
+}
+
+func parseCondition(spec string) (*waitCondition, error) {
+	switch {
+	case spec == forDelete:
+		return &waitCondition{spec: spec, deletion: true}, nil
+	case strings.HasPrefix(spec, forConditionPrefix):
+		conditionType, status, hasStatus := strings.Cut(strings.TrimPrefix(spec, forConditionPrefix), "=")
+		if conditionType == "" {
+			return nil, fmt.Errorf("condition type must not be empty in %q", spec)
+		}
+		c := &waitCondition{spec: spec, conditionType: conditionType, conditionStatus: k8sv1.ConditionTrue}
+		if hasStatus {
+			switch {
+			case strings.EqualFold(status, string(k8sv1.ConditionTrue)):
+			case strings.EqualFold(status, string(k8sv1.ConditionFalse)):
+				c.conditionStatus = k8sv1.ConditionFalse
+			case strings.EqualFold(status, string(k8sv1.ConditionUnknown)):
+				c.conditionStatus = k8sv1.ConditionUnknown
+			default:
+				return nil, fmt.Errorf("condition status must be one of True, False or Unknown, got %q", status)
+			}
+		}
+		return c, nil
+	case strings.HasPrefix(spec, forJSONPathPrefix):
+		expression := strings.TrimPrefix(spec, forJSONPathPrefix)
+		end := strings.LastIndex(expression, "}")
+		if !strings.HasPrefix(expression, "{") || end < 0 {
+			return nil, fmt.Errorf("jsonpath expression must be enclosed in braces, e.g. jsonpath='{.status.printableStatus}'=Running")
+		}
+		c := &waitCondition{spec: spec, jsonPath: jsonpath.New(forFlag).AllowMissingKeys(true)}
+		if err := c.jsonPath.Parse(expression[:end+1]); err != nil {
+			return nil, fmt.Errorf("error parsing jsonpath %s: %v", expression[:end+1], err)
+		}
+		if rest := expression[end+1:]; rest != "" {
+			value, found := strings.CutPrefix(rest, "=")
+			if !found {
+				return nil, fmt.Errorf("unexpected %q after the jsonpath expression, expected =VALUE", rest)
+			}
+			c.jsonPathValue = &value
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("unsupported condition %q (must be one of condition=TYPE[=STATUS], jsonpath='{JSONPATH}'[=VALUE] or delete)", spec)
+}
+
+func (c *command) run(cmd *cobra.Command, args []string) error {
+	condition, err := parseCondition(c.forSpec)
+	if err != nil {
+		return result.NewUsageError(err)
+	}
+	if c.timeout < 0 {
+		return result.NewUsageError(fmt.Errorf("the timeout must not be negative"))
+	}
+	name := args[0]
+
+	virtClient, namespace, _, err := clientconfig.ClientAndNamespaceFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	observed := ""
+	err = virtwait.PollImmediately(pollInterval, c.timeout, func(ctx context.Context) (bool, error) {
+		met, lastObserved, err := condition.check(ctx, virtClient, namespace, name)
+		observed = lastObserved
+		return met, err
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("timed out waiting for VirtualMachine %s to meet %s, observed %s: %w", name, condition.spec, observed, err)
+		}
+		return err
+	}
+
+	cmd.Printf("%s/%s condition met\n", strings.ToLower(v1.VirtualMachineGroupVersionKind.GroupKind().String()), name)
+	return nil
+}
+
+// check reports whether the condition is met and what was observed instead.
+// An error is returned if the condition can not be met anymore.
+func (c *waitCondition) check(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, name string) (bool, string, error) {
+	vm, err := virtClient.VirtualMachine(namespace).Get(ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) && c.deletion {
+		return true, "", nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("error getting VirtualMachine %s: %w", name, err)
+	}
+
+	switch {
+	case c.deletion:
+		return false, "the VirtualMachine still exists", nil
+	case c.jsonPath != nil:
+		return c.checkJSONPath(vm)
+	}
+
+	vmi, err := virtClient.VirtualMachineInstance(namespace).Get(ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		vmi = nil
+	} else if err != nil {
+		return false, "", fmt.Errorf("error getting VirtualMachineInstance %s: %w", name, err)
+	}
+
+	status, reason, found := c.lookupCondition(vm, vmi)
+	if found && status == c.conditionStatus {
+		return true, "", nil
+	}
+
+	observed := fmt.Sprintf("condition %s not present", c.conditionType)
+	if found {
+		observed = fmt.Sprintf("condition %s=%s", c.conditionType, status)
+		if reason != "" {
+			observed += fmt.Sprintf(" (%s)", reason)
+		}
+	}
+	if c.conditionStatus == k8sv1.ConditionTrue {
+		if err := checkRunStrategy(vm, vmi); err != nil {
+			return false, observed, fmt.Errorf("condition %s of VirtualMachine %s can not become true: %v", c.conditionType, name, err)
+		}
+	}
+	return false, observed, nil
+}
+
+// lookupCondition looks up the condition on the VirtualMachine first and on its
+// VirtualMachineInstance second. Condition types are matched case-insensitively.
+func (c *waitCondition) lookupCondition(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) (k8sv1.ConditionStatus, string, bool) {
+	for _, cond := range vm.Status.Conditions {
+		if strings.EqualFold(string(cond.Type), c.conditionType) {
+			return cond.Status, cond.Reason, true
+		}
+	}
+	if vmi == nil {
+		return "", "", false
+	}
+	for _, cond := range vmi.Status.Conditions {
+		if strings.EqualFold(string(cond.Type), c.conditionType) {
+			return cond.Status, cond.Reason, true
+		}
+	}
+	return "", "", false
+}
+
+func (c *waitCondition) checkJSONPath(vm *v1.VirtualMachine) (bool, string, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(vm)
+	if err != nil {
+		return false, "", err
+	}
+	var out bytes.Buffer
+	if err := c.jsonPath.Execute(&out, obj); err != nil {
+		return false, "", fmt.Errorf("error executing jsonpath: %v", err)
+	}
+	value := out.String()
+	observed := fmt.Sprintf("value %q", value)
+	if c.jsonPathValue == nil {
+		return value != "", observed, nil
+	}
+	return value == *c.jsonPathValue, observed, nil
+}
+
+// checkRunStrategy returns an error if the run strategy of the VirtualMachine
+// will not start or restart its VirtualMachineInstance.
+func checkRunStrategy(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) error {
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return nil
+	}
+	if runStrategy == v1.RunStrategyHalted {
+		return fmt.Errorf("the VirtualMachine is stopped by its run strategy %s", runStrategy)
+	}
+	if vmi == nil || !vmi.IsFinal() {
+		return nil
+	}
+	switch {
+	case runStrategy == v1.RunStrategyAlways:
+		return nil
+	case runStrategy == v1.RunStrategyRerunOnFailure && vmi.Status.Phase == v1.Failed:
+		return nil
+	}
+	return fmt.Errorf("the VirtualMachineInstance is %s and will not be restarted by the run strategy %s", vmi.Status.Phase, runStrategy)
+}
@@ -0,0 +1,154 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+package wait_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/virtctl/result"
+	"kubevirt.io/kubevirt/pkg/virtctl/testing"
+)
+
+var _ = Describe("Wait command", func() {
+	const vmName = "testvm"
+
+	var virtClient *kubevirtfake.Clientset
+
+	createVM := func(runStrategy v1.VirtualMachineRunStrategy, conditions ...v1.VirtualMachineCondition) {
+		vm := libvmi.NewVirtualMachine(
+			libvmi.New(libvmi.WithNamespace(metav1.NamespaceDefault), libvmi.WithName(vmName)),
+			libvmi.WithRunStrategy(runStrategy),
+		)
+		vm.Status.PrintableStatus = v1.VirtualMachineStatusStopped
+		vm.Status.Conditions = conditions
+		_, err := virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault).
+			Create(context.Background(), vm, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	createVMI := func(phase v1.VirtualMachineInstancePhase, conditions ...v1.VirtualMachineInstanceCondition) {
+		vmi := libvmi.New(libvmi.WithNamespace(metav1.NamespaceDefault), libvmi.WithName(vmName))
+		vmi.Status.Phase = phase
+		vmi.Status.Conditions = conditions
+		_, err := virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault).
+			Create(context.Background(), vmi, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		virtClient = kubevirtfake.NewSimpleClientset()
+
+		ctrl := gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachines(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).
+			Return(virtClient.KubevirtV1().VirtualMachineInstances(metav1.NamespaceDefault)).AnyTimes()
+	})
+
+	It("should require the --for flag", func() {
+		cmd := testing.NewRepeatableVirtctlCommand("wait", "vm", vmName)
+		Expect(cmd()).To(MatchError(ContainSubstring(`required flag(s) "for" not set`)))
+	})
+
+	DescribeTable("should reject an invalid condition", func(forSpec, expected string) {
+		err := testing.NewRepeatableVirtctlCommand("wait", "vm", vmName, "--for", forSpec)()
+		Expect(err).To(MatchError(ContainSubstring(expected)))
+		Expect(result.ExitCode(err)).To(Equal(result.ExitCodeUsage))
+	},
+		Entry("with an unknown kind", "ready", "unsupported condition"),
+		Entry("with an empty condition type", "condition=", "condition type must not be empty"),
+		Entry("with an invalid condition status", "condition=Ready=maybe", "condition status must be one of True, False or Unknown"),
+		Entry("with a jsonpath without braces", "jsonpath=.status.ready", "must be enclosed in braces"),
+		Entry("with a jsonpath followed by garbage", "jsonpath={.status.ready}true", "expected =VALUE"),
+	)
+
+	It("should fail if the VM does not exist", func() {
+		err := testing.NewRepeatableVirtctlCommand("wait", "vm", vmName, "--for", "condition=Ready")()
+		Expect(err).To(MatchError(ContainSubstring("error getting VirtualMachine testvm")))
+		Expect(result.ExitCode(err)).To(Equal(result.ExitCodeNotFound))
+	})
+
+	DescribeTable("should succeed when the condition is met", func(forSpec string) {
+		createVM(v1.RunStrategyAlways, v1.VirtualMachineCondition{Type: v1.VirtualMachineReady, Status: k8sv1.ConditionTrue})
+		createVMI(v1.Running, v1.VirtualMachineInstanceCondition{Type: v1.VirtualMachineInstanceAgentConnected, Status: k8sv1.ConditionTrue})
+
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("wait", "vm", vmName, "--for", forSpec)()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("virtualmachine.kubevirt.io/testvm condition met\n"))
+	},
+		Entry("with a VM condition", "condition=Ready"),
+		Entry("with a VM condition in lower case", "condition=ready=true"),
+		Entry("with a VMI condition", "condition=AgentConnected"),
+		Entry("with a jsonpath value", "jsonpath={.status.printableStatus}=Stopped"),
+		Entry("with a jsonpath without value", "jsonpath={.spec.runStrategy}"),
+	)
+
+	It("should succeed when waiting for a deleted VM", func() {
+		out, err := testing.NewRepeatableVirtctlCommandWithOut("wait", "vm", vmName, "--for", "delete")()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("virtualmachine.kubevirt.io/testvm condition met\n"))
+	})
+
+	DescribeTable("should time out when the condition is not met", func(forSpec, observed string) {
+		createVM(v1.RunStrategyAlways, v1.VirtualMachineCondition{Type: v1.VirtualMachineReady, Status: k8sv1.ConditionFalse, Reason: "PodNotReady"})
+
+		err := testing.NewRepeatableVirtctlCommand("wait", "vm", vmName, "--for", forSpec, "--timeout=0")()
+		Expect(err).To(MatchError(ContainSubstring("timed out waiting for VirtualMachine testvm to meet " + forSpec + ", observed " + observed)))
+		Expect(result.ExitCode(err)).To(Equal(result.ExitCodeTimeout))
+	},
+		Entry("with a condition", "condition=Ready", "condition Ready=False (PodNotReady)"),
+		Entry("with a missing condition", "condition=AgentConnected", "condition AgentConnected not present"),
+		Entry("with a jsonpath", "jsonpath={.status.printableStatus}=Running", `value "Stopped"`),
+		Entry("with delete", "delete", "the VirtualMachine still exists"),
+	)
+
+	DescribeTable("should fail early if the run strategy will not start the VM", func(runStrategy v1.VirtualMachineRunStrategy, phase v1.VirtualMachineInstancePhase, expected string) {
+		createVM(runStrategy)
+		if phase != "" {
+			createVMI(phase)
+		}
+
+		err := testing.NewRepeatableVirtctlCommand("wait", "vm", vmName, "--for", "condition=Ready", "--timeout=1m")()
+		Expect(err).To(MatchError(ContainSubstring("condition Ready of VirtualMachine testvm can not become true: " + expected)))
+	},
+		Entry("with Halted", v1.RunStrategyHalted, v1.VirtualMachineInstancePhase(""), "the VirtualMachine is stopped by its run strategy Halted"),
+		Entry("with Once and a succeeded VMI", v1.RunStrategyOnce, v1.Succeeded, "the VirtualMachineInstance is Succeeded and will not be restarted by the run strategy Once"),
+		Entry("with RerunOnFailure and a succeeded VMI", v1.RunStrategyRerunOnFailure, v1.Succeeded, "the VirtualMachineInstance is Succeeded and will not be restarted by the run strategy RerunOnFailure"),
+	)
+
+	It("should not fail early when waiting for a condition to become false on a halted VM", func() {
+		createVM(v1.RunStrategyHalted, v1.VirtualMachineCondition{Type: v1.VirtualMachineReady, Status: k8sv1.ConditionFalse})
+
+		err := testing.NewRepeatableVirtctlCommand("wait", "vm", vmName, "--for", "condition=Ready=False")()
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,222 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package agent_test
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/vsock/agent"
+)
+
+const testCID = 3
+
+var _ = Describe("Alternative guest agent", func() {
+	var handlers map[uint32]agent.Handler
+
+	dial := func(cid, port uint32) (net.Conn, error) {
+		Expect(cid).To(Equal(uint32(testCID)))
+		handler, exists := handlers[port]
+		if !exists {
+			return nil, fmt.Errorf("connection refused")
+		}
+		clientConn, serverConn := net.Pipe()
+		go agent.ServeConn(serverConn, handler)
+		return clientConn, nil
+	}
+
+	BeforeEach(func() {
+		handlers = map[uint32]agent.Handler{}
+	})
+
+	Context("negotiation", func() {
+		It("should pick the first alternative which answers", func() {
+			handlers[2000] = agent.NewMinimalAgentWithFreezer("v1.0.0", &fakeFreezer{})
+			alternatives := []v1.GuestAgentAlternative{
+				{Name: agent.MinimalAgentName, VSOCKPort: 1000},
+				{Name: agent.MinimalAgentName, VSOCKPort: 2000},
+			}
+
+			negotiation, err := agent.Negotiate(dial, testCID, alternatives)
+			Expect(err).ToNot(HaveOccurred())
+			defer negotiation.Agent.Close()
+			Expect(negotiation.Alternative).To(Equal(alternatives[1]))
+			Expect(negotiation.Info.Version).To(Equal("v1.0.0"))
+			Expect(negotiation.Info.FSFreezeStatus).To(Equal(agent.FSThawed))
+			Expect(negotiation.Supports(agent.CapabilityFSFreeze)).To(BeTrue())
+			Expect(negotiation.CapabilitiesString()).To(Equal("network-interfaces, fsfreeze"))
+		})
+
+		It("should reject an agent announcing another name", func() {
+			handlers[1000] = &fakeHandler{info: agent.Info{Name: "other-agent"}}
+
+			_, err := agent.Negotiate(dial, testCID, []v1.GuestAgentAlternative{{Name: "my-agent", VSOCKPort: 1000}})
+			Expect(err).To(MatchError(ContainSubstring(`my-agent: agent announced itself as "other-agent"`)))
+		})
+
+		It("should only keep the capabilities known to virt-handler", func() {
+			handlers[1000] = &fakeHandler{info: agent.Info{
+				Name:         "my-agent",
+				Capabilities: []agent.Capability{"file-transfer", agent.CapabilityNetworkInterfaces, agent.CapabilityNetworkInterfaces},
+			}}
+
+			negotiation, err := agent.Negotiate(dial, testCID, []v1.GuestAgentAlternative{{Name: "my-agent", VSOCKPort: 1000}})
+			Expect(err).ToNot(HaveOccurred())
+			defer negotiation.Agent.Close()
+			Expect(negotiation.Capabilities).To(ConsistOf(agent.CapabilityNetworkInterfaces))
+			Expect(negotiation.Supports(agent.CapabilityFSFreeze)).To(BeFalse())
+		})
+
+		It("should fail when no alternative is configured", func() {
+			_, err := agent.Negotiate(dial, testCID, nil)
+			Expect(err).To(MatchError("no alternative guest agent configured"))
+		})
+	})
+
+	Context("commands", func() {
+		var handler *fakeHandler
+		var client agent.Agent
+
+		BeforeEach(func() {
+			handler = &fakeHandler{
+				info:       agent.Info{Name: "my-agent"},
+				interfaces: []agent.Interface{{Name: "eth0", MAC: "02:00:00:00:00:01", IPAddresses: []string{"10.0.2.2", "fd10:0:2::2"}}},
+			}
+			handlers[1000] = handler
+			conn, err := dial(testCID, 1000)
+			Expect(err).ToNot(HaveOccurred())
+			client = agent.NewClient(conn)
+			DeferCleanup(client.Close)
+		})
+
+		It("should return the interfaces", func() {
+			Expect(client.Interfaces()).To(Equal(handler.interfaces))
+		})
+
+		It("should pass the freeze arguments", func() {
+			args := agent.FreezeArguments{Mountpoints: []string{"/", "/data"}, UnfreezeTimeoutSeconds: 60}
+			Expect(client.Freeze(args)).To(Succeed())
+			Expect(handler.freezeArgs).To(Equal(&args))
+			Expect(client.Thaw()).To(Succeed())
+			Expect(handler.thawed).To(BeTrue())
+		})
+
+		It("should return the errors of the agent", func() {
+			handler.err = fmt.Errorf("not permitted")
+			Expect(client.Thaw()).To(MatchError("guest-fsfreeze-thaw failed: not permitted"))
+		})
+	})
+
+	Context("minimal agent", func() {
+		var freezer *fakeFreezer
+		var minimal *agent.MinimalAgent
+
+		BeforeEach(func() {
+			freezer = &fakeFreezer{mountpoints: []string{"/", "/boot"}}
+			minimal = agent.NewMinimalAgentWithFreezer("v1.0.0", freezer)
+		})
+
+		It("should freeze all filesystems when no mountpoint is given and thaw them in reverse order", func() {
+			Expect(minimal.Freeze(agent.FreezeArguments{})).To(Succeed())
+			Expect(freezer.frozen).To(Equal([]string{"/", "/boot"}))
+			Expect(minimal.Info()).To(HaveField("FSFreezeStatus", agent.FSFrozen))
+
+			Expect(minimal.Thaw()).To(Succeed())
+			Expect(freezer.thawed).To(Equal([]string{"/boot", "/"}))
+			Expect(minimal.Info()).To(HaveField("FSFreezeStatus", agent.FSThawed))
+		})
+
+		It("should refuse to freeze twice", func() {
+			Expect(minimal.Freeze(agent.FreezeArguments{Mountpoints: []string{"/data"}})).To(Succeed())
+			Expect(minimal.Freeze(agent.FreezeArguments{})).To(MatchError("the filesystems are already frozen"))
+		})
+
+		It("should thaw the frozen filesystems when freezing one fails", func() {
+			freezer.failOn = "/boot"
+			Expect(minimal.Freeze(agent.FreezeArguments{})).To(MatchError(ContainSubstring("failed to freeze /boot")))
+			Expect(freezer.thawed).To(Equal([]string{"/"}))
+			Expect(minimal.Info()).To(HaveField("FSFreezeStatus", agent.FSThawed))
+		})
+
+		It("should thaw on its own once the unfreeze timeout elapsed", func() {
+			Expect(minimal.Freeze(agent.FreezeArguments{UnfreezeTimeoutSeconds: 1})).To(Succeed())
+			Eventually(func() (*agent.Info, error) {
+				return minimal.Info()
+			}).WithTimeout(5 * time.Second).Should(HaveField("FSFreezeStatus", agent.FSThawed))
+			Expect(freezer.thawed).To(Equal([]string{"/boot", "/"}))
+		})
+	})
+})
+
+type fakeHandler struct {
+	info       agent.Info
+	interfaces []agent.Interface
+	freezeArgs *agent.FreezeArguments
+	thawed     bool
+	err        error
+}
+
+func (h *fakeHandler) Info() (*agent.Info, error) {
+	return &h.info, h.err
+}
+
+func (h *fakeHandler) Interfaces() ([]agent.Interface, error) {
+	return h.interfaces, h.err
+}
+
+func (h *fakeHandler) Freeze(args agent.FreezeArguments) error {
+	h.freezeArgs = &args
+	return h.err
+}
+
+func (h *fakeHandler) Thaw() error {
+	h.thawed = true
+	return h.err
+}
+
+type fakeFreezer struct {
+	mountpoints []string
+	failOn      string
+	frozen      []string
+	thawed      []string
+}
+
+func (f *fakeFreezer) Mountpoints() ([]string, error) {
+	return f.mountpoints, nil
+}
+
+func (f *fakeFreezer) Freeze(mountpoint string) error {
+	if mountpoint == f.failOn {
+		return fmt.Errorf("device busy")
+	}
+	f.frozen = append(f.frozen, mountpoint)
+	return nil
+}
+
+func (f *fakeFreezer) Thaw(mountpoint string) error {
+	f.thawed = append(f.thawed, mountpoint)
+	return nil
+}
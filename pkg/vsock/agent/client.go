@@ -0,0 +1,200 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mdlayher/vsock"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+const requestTimeout = 5 * time.Second
+
+// Agent is an in-guest agent used instead of qemu-guest-agent.
+type Agent interface {
+	Info() (*Info, error)
+	Interfaces() ([]Interface, error)
+	Freeze(args FreezeArguments) error
+	Thaw() error
+	Close() error
+}
+
+// Dialer connects to the given port of the guest with the given VSOCK CID.
+type Dialer func(cid, port uint32) (net.Conn, error)
+
+func VSOCKDialer(cid, port uint32) (net.Conn, error) {
+	return vsock.Dial(cid, port, &vsock.Config{})
+}
+
+type client struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func NewClient(conn net.Conn) Agent {
+	return &client{conn: conn, reader: bufio.NewReader(conn)}
+}
+
+func (c *client) Info() (*Info, error) {
+	info := &Info{}
+	if err := c.execute(commandInfo, nil, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (c *client) Interfaces() ([]Interface, error) {
+	var interfaces []Interface
+	if err := c.execute(commandInterfaces, nil, &interfaces); err != nil {
+		return nil, err
+	}
+	return interfaces, nil
+}
+
+func (c *client) Freeze(args FreezeArguments) error {
+	return c.execute(commandFreeze, args, nil)
+}
+
+func (c *client) Thaw() error {
+	return c.execute(commandThaw, nil, nil)
+}
+
+func (c *client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *client) execute(command string, args interface{}, result interface{}) error {
+	req := request{Execute: command}
+	if args != nil {
+		raw, err := json.Marshal(args)
+		if err != nil {
+			return err
+		}
+		req.Arguments = raw
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	if err := c.conn.SetDeadline(time.Now().Add(requestTimeout)); err != nil {
+		return err
+	}
+	if _, err := c.conn.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to send %s: %v", command, err)
+	}
+	line, err := c.reader.ReadBytes('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read the response to %s: %v", command, err)
+	}
+
+	resp := response{}
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return fmt.Errorf("invalid response to %s: %v", command, err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("%s failed: %s", command, resp.Error.Desc)
+	}
+	if result == nil || len(resp.Return) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Return, result)
+}
+
+// Negotiation is the outcome of a successful capability negotiation with an alternative agent.
+type Negotiation struct {
+	Agent       Agent
+	Alternative v1.GuestAgentAlternative
+	Info        *Info
+	// Capabilities are the capabilities announced by the agent which virt-handler supports
+	Capabilities []Capability
+}
+
+func (n *Negotiation) Supports(capability Capability) bool {
+	return slices.Contains(n.Capabilities, capability)
+}
+
+// CapabilitiesString returns the negotiated capabilities in a human readable form.
+func (n *Negotiation) CapabilitiesString() string {
+	capabilities := make([]string, 0, len(n.Capabilities))
+	for _, capability := range n.Capabilities {
+		capabilities = append(capabilities, string(capability))
+	}
+	return strings.Join(capabilities, ", ")
+}
+
+// Negotiate tries the alternatives in order and returns the negotiation with the first agent
+// which answers and announces the expected name. The caller has to close the agent.
+func Negotiate(dial Dialer, cid uint32, alternatives []v1.GuestAgentAlternative) (*Negotiation, error) {
+	var errs []error
+	for _, alternative := range alternatives {
+		negotiation, err := negotiate(dial, cid, alternative)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", alternative.Name, err))
+			continue
+		}
+		return negotiation, nil
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no alternative guest agent configured")
+	}
+	return nil, errors.Join(errs...)
+}
+
+func negotiate(dial Dialer, cid uint32, alternative v1.GuestAgentAlternative) (*Negotiation, error) {
+	conn, err := dial(cid, alternative.VSOCKPort)
+	if err != nil {
+		return nil, err
+	}
+	agent := NewClient(conn)
+
+	info, err := agent.Info()
+	if err != nil {
+		agent.Close()
+		return nil, err
+	}
+	if info.Name != alternative.Name {
+		agent.Close()
+		return nil, fmt.Errorf("agent announced itself as %q", info.Name)
+	}
+
+	var capabilities []Capability
+	for _, capability := range info.Capabilities {
+		if slices.Contains(SupportedCapabilities, capability) && !slices.Contains(capabilities, capability) {
+			capabilities = append(capabilities, capability)
+		}
+	}
+
+	return &Negotiation{
+		Agent:        agent,
+		Alternative:  alternative,
+		Info:         info,
+		Capabilities: capabilities,
+	}, nil
+}
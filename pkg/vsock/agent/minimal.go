@@ -0,0 +1,215 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package agent
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// MinimalAgentName is the name the minimal agent shipped with KubeVirt announces
+	MinimalAgentName = "kubevirt-minimal-agent"
+	// MinimalAgentVSOCKPort is the port the minimal agent listens on by default
+	MinimalAgentVSOCKPort = 1030
+)
+
+// ioctl request numbers of FIFREEZE and FITHAW from linux/fs.h
+const (
+	ioctlFIFREEZE = 0xC0045877
+	ioctlFITHAW   = 0xC0045878
+)
+
+// Freezer freezes and thaws single filesystems.
+type Freezer interface {
+	Mountpoints() ([]string, error)
+	Freeze(mountpoint string) error
+	Thaw(mountpoint string) error
+}
+
+// MinimalAgent is a small agent which can be embedded in guests where qemu-guest-agent
+// cannot be installed. It reports the network interfaces and freezes the filesystems.
+type MinimalAgent struct {
+	version string
+	freezer Freezer
+
+	lock      sync.Mutex
+	frozen    []string
+	thawTimer *time.Timer
+}
+
+func NewMinimalAgent(version string) *MinimalAgent {
+	return NewMinimalAgentWithFreezer(version, ioctlFreezer{})
+}
+
+func NewMinimalAgentWithFreezer(version string, freezer Freezer) *MinimalAgent {
+	return &MinimalAgent{version: version, freezer: freezer}
+}
+
+func (a *MinimalAgent) Info() (*Info, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	status := FSThawed
+	if len(a.frozen) > 0 {
+		status = FSFrozen
+	}
+	return &Info{
+		Name:           MinimalAgentName,
+		Version:        a.version,
+		Capabilities:   []Capability{CapabilityNetworkInterfaces, CapabilityFSFreeze},
+		FSFreezeStatus: status,
+	}, nil
+}
+
+func (a *MinimalAgent) Interfaces() ([]Interface, error) {
+	links, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var interfaces []Interface
+	for _, link := range links {
+		if link.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := link.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		iface := Interface{Name: link.Name, MAC: link.HardwareAddr.String()}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				iface.IPAddresses = append(iface.IPAddresses, ipNet.IP.String())
+			}
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces, nil
+}
+
+func (a *MinimalAgent) Freeze(args FreezeArguments) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if len(a.frozen) > 0 {
+		return fmt.Errorf("the filesystems are already frozen")
+	}
+
+	mountpoints := args.Mountpoints
+	if len(mountpoints) == 0 {
+		var err error
+		if mountpoints, err = a.freezer.Mountpoints(); err != nil {
+			return err
+		}
+	}
+
+	for _, mountpoint := range mountpoints {
+		if err := a.freezer.Freeze(mountpoint); err != nil {
+			a.thaw()
+			return fmt.Errorf("failed to freeze %s: %v", mountpoint, err)
+		}
+		a.frozen = append(a.frozen, mountpoint)
+	}
+
+	if args.UnfreezeTimeoutSeconds > 0 {
+		a.thawTimer = time.AfterFunc(time.Duration(args.UnfreezeTimeoutSeconds)*time.Second, func() {
+			a.Thaw()
+		})
+	}
+	return nil
+}
+
+func (a *MinimalAgent) Thaw() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.thaw()
+}
+
+func (a *MinimalAgent) thaw() error {
+	if a.thawTimer != nil {
+		a.thawTimer.Stop()
+		a.thawTimer = nil
+	}
+
+	var failed []string
+	for i := len(a.frozen) - 1; i >= 0; i-- {
+		if err := a.freezer.Thaw(a.frozen[i]); err != nil {
+			failed = append(failed, a.frozen[i])
+		}
+	}
+	a.frozen = nil
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to thaw %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+type ioctlFreezer struct{}
+
+// Mountpoints returns one mountpoint per filesystem backed by a block device, a filesystem
+// mounted more than once can only be frozen once.
+func (ioctlFreezer) Mountpoints() ([]string, error) {
+	file, err := os.Open("/proc/self/mounts")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var mountpoints []string
+	devices := map[string]struct{}{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || !strings.HasPrefix(fields[0], "/dev/") {
+			continue
+		}
+		if _, exists := devices[fields[0]]; exists {
+			continue
+		}
+		devices[fields[0]] = struct{}{}
+		mountpoints = append(mountpoints, fields[1])
+	}
+	return mountpoints, scanner.Err()
+}
+
+func (ioctlFreezer) Freeze(mountpoint string) error {
+	return ioctlMountpoint(mountpoint, ioctlFIFREEZE)
+}
+
+func (ioctlFreezer) Thaw(mountpoint string) error {
+	return ioctlMountpoint(mountpoint, ioctlFITHAW)
+}
+
+func ioctlMountpoint(mountpoint string, request uint) error {
+	dir, err := os.Open(mountpoint)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return unix.IoctlSetInt(int(dir.Fd()), request, 0)
+}
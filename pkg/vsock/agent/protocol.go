@@ -0,0 +1,87 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+// Package agent implements the protocol spoken between virt-handler and in-guest agents
+// which are used instead of qemu-guest-agent. The protocol is modeled after the one of
+// qemu-guest-agent: every request and response is a single JSON document terminated by
+// a newline, sent over a VSOCK connection.
+package agent
+
+import "encoding/json"
+
+// Capability is a feature an agent announces during the negotiation.
+type Capability string
+
+const (
+	// CapabilityNetworkInterfaces means the agent reports the network interfaces of the guest
+	CapabilityNetworkInterfaces Capability = "network-interfaces"
+	// CapabilityFSFreeze means the agent can freeze and thaw the filesystems of the guest
+	CapabilityFSFreeze Capability = "fsfreeze"
+)
+
+// SupportedCapabilities lists the capabilities virt-handler knows how to use.
+var SupportedCapabilities = []Capability{CapabilityNetworkInterfaces, CapabilityFSFreeze}
+
+const (
+	commandInfo       = "guest-info"
+	commandInterfaces = "guest-network-get-interfaces"
+	commandFreeze     = "guest-fsfreeze-freeze"
+	commandThaw       = "guest-fsfreeze-thaw"
+)
+
+const (
+	FSFrozen = "frozen"
+	FSThawed = "thawed"
+)
+
+// Info is returned by an agent during the capability negotiation.
+type Info struct {
+	Name           string       `json:"name"`
+	Version        string       `json:"version,omitempty"`
+	Capabilities   []Capability `json:"capabilities,omitempty"`
+	FSFreezeStatus string       `json:"fsFreezeStatus,omitempty"`
+}
+
+// Interface is a network interface of the guest.
+type Interface struct {
+	Name        string   `json:"name"`
+	MAC         string   `json:"mac,omitempty"`
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+}
+
+// FreezeArguments are passed along with the freeze command. The agent thaws the
+// filesystems on its own once UnfreezeTimeoutSeconds elapsed, unless it is zero.
+type FreezeArguments struct {
+	Mountpoints            []string `json:"mountpoints,omitempty"`
+	UnfreezeTimeoutSeconds int32    `json:"unfreezeTimeoutSeconds,omitempty"`
+}
+
+type request struct {
+	Execute   string          `json:"execute"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+type response struct {
+	Return json.RawMessage `json:"return,omitempty"`
+	Error  *responseError  `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Desc string `json:"desc"`
+}
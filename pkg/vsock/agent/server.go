@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+
+	"kubevirt.io/client-go/log"
+)
+
+// Handler implements the commands of an agent.
+type Handler interface {
+	Info() (*Info, error)
+	Interfaces() ([]Interface, error)
+	Freeze(args FreezeArguments) error
+	Thaw() error
+}
+
+// Serve accepts connections on the listener and answers the requests with the handler
+// until the listener is closed.
+func Serve(listener net.Listener, handler Handler) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go ServeConn(conn, handler)
+	}
+}
+
+// ServeConn answers the requests received on the connection until it is closed.
+func ServeConn(conn net.Conn, handler Handler) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		data, err := json.Marshal(handle(line, handler))
+		if err != nil {
+			log.Log.Reason(err).Error("failed to marshal the response")
+			return
+		}
+		if _, err := conn.Write(append(data, '\n')); err != nil {
+			log.Log.Reason(err).Error("failed to send the response")
+			return
+		}
+	}
+}
+
+func handle(line []byte, handler Handler) response {
+	req := request{}
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(fmt.Errorf("invalid request: %v", err))
+	}
+
+	var result interface{}
+	var err error
+	switch req.Execute {
+	case commandInfo:
+		result, err = handler.Info()
+	case commandInterfaces:
+		result, err = handler.Interfaces()
+	case commandFreeze:
+		args := FreezeArguments{}
+		if len(req.Arguments) > 0 {
+			if err := json.Unmarshal(req.Arguments, &args); err != nil {
+				return errorResponse(fmt.Errorf("invalid arguments: %v", err))
+			}
+		}
+		err = handler.Freeze(args)
+	case commandThaw:
+		err = handler.Thaw()
+	default:
+		err = fmt.Errorf("unsupported command %q", req.Execute)
+	}
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	resp := response{}
+	if result != nil {
+		if resp.Return, err = json.Marshal(result); err != nil {
+			return errorResponse(err)
+		}
+	}
+	return resp
+}
+
+func errorResponse(err error) response {
+	return response{Error: &responseError{Desc: err.Error()}}
+}
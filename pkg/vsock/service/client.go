@@ -0,0 +1,62 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+// Dial connects to the named guest service of the VMI through the vsock subresource
+// and performs the handshake. The caller has to close the connection.
+func Dial(client kubecli.KubevirtClient, namespace, name, service string) (net.Conn, error) {
+	vmi, err := client.VirtualMachineInstance(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	port, exists := PortFor(vmi, service)
+	if !exists {
+		return nil, fmt.Errorf("VMI %s/%s has no vsock service %s", namespace, name, service)
+	}
+
+	stream, err := client.VirtualMachineInstance(namespace).VSOCK(name, &v1.VSOCKOptions{TargetPort: port, UseTLS: pointer.P(true)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to vsock port %d of VMI %s/%s: %v", port, namespace, name, err)
+	}
+	return NewClientConn(stream.AsConn(), service)
+}
+
+// NewClientConn performs the handshake for the named service on an established
+// connection. The connection is closed when the handshake fails.
+func NewClientConn(conn net.Conn, service string) (net.Conn, error) {
+	if err := clientHandshake(conn, service); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
@@ -0,0 +1,89 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package service
+
+import (
+	v1 "kubevirt.io/api/core/v1"
+)
+
+const (
+	// MinPort is the lowest port a service can listen on. Lower ports are reserved
+	// for the host system service and privileged guest processes.
+	MinPort uint32 = 1024
+	// firstAllocatedPort is where the search for a free port of services without an
+	// explicit port starts, to keep them apart from the ports picked by users.
+	firstAllocatedPort uint32 = 10000
+)
+
+// AllocatePorts reports the port of every service of the VMI in its status. Explicit
+// ports are kept and the other services get the lowest free port, in the order they
+// are declared. Ports already reported are kept, so that calling it again is a no-op.
+func AllocatePorts(vmi *v1.VirtualMachineInstance) {
+	services := vmi.Spec.Domain.Devices.VSOCKServices
+	if len(services) == 0 {
+		vmi.Status.VSOCKServices = nil
+		return
+	}
+
+	allocated := make(map[string]uint32, len(vmi.Status.VSOCKServices))
+	for _, status := range vmi.Status.VSOCKServices {
+		allocated[status.Name] = status.Port
+	}
+
+	used := make(map[uint32]struct{}, len(services))
+	for _, service := range services {
+		if service.Port != nil {
+			used[*service.Port] = struct{}{}
+		} else if port, exists := allocated[service.Name]; exists {
+			used[port] = struct{}{}
+		}
+	}
+
+	statuses := make([]v1.VSOCKServiceStatus, 0, len(services))
+	next := firstAllocatedPort
+	for _, service := range services {
+		port, exists := allocated[service.Name]
+		switch {
+		case service.Port != nil:
+			port = *service.Port
+		case !exists:
+			for {
+				if _, taken := used[next]; !taken {
+					break
+				}
+				next++
+			}
+			port = next
+			used[port] = struct{}{}
+		}
+		statuses = append(statuses, v1.VSOCKServiceStatus{Name: service.Name, Port: port})
+	}
+	vmi.Status.VSOCKServices = statuses
+}
+
+// PortFor returns the port of the named service reported in the status of the VMI.
+func PortFor(vmi *v1.VirtualMachineInstance, name string) (uint32, bool) {
+	for _, status := range vmi.Status.VSOCKServices {
+		if status.Name == name {
+			return status.Port, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,88 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package service_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/vsock/service"
+)
+
+var _ = Describe("Port allocation", func() {
+	newVMI := func(services ...v1.VSOCKService) *v1.VirtualMachineInstance {
+		vmi := libvmi.New()
+		vmi.Spec.Domain.Devices.VSOCKServices = services
+		return vmi
+	}
+
+	It("should keep explicit ports and allocate the others in declaration order", func() {
+		vmi := newVMI(
+			v1.VSOCKService{Name: "logs"},
+			v1.VSOCKService{Name: "metrics", Port: pointer.P(uint32(10000))},
+			v1.VSOCKService{Name: "exec"},
+		)
+
+		service.AllocatePorts(vmi)
+
+		Expect(vmi.Status.VSOCKServices).To(Equal([]v1.VSOCKServiceStatus{
+			{Name: "logs", Port: 10001},
+			{Name: "metrics", Port: 10000},
+			{Name: "exec", Port: 10002},
+		}))
+	})
+
+	It("should keep the ports which are already allocated", func() {
+		vmi := newVMI(v1.VSOCKService{Name: "logs"}, v1.VSOCKService{Name: "exec"})
+		vmi.Status.VSOCKServices = []v1.VSOCKServiceStatus{{Name: "exec", Port: 10000}}
+
+		service.AllocatePorts(vmi)
+
+		Expect(vmi.Status.VSOCKServices).To(Equal([]v1.VSOCKServiceStatus{
+			{Name: "logs", Port: 10001},
+			{Name: "exec", Port: 10000},
+		}))
+	})
+
+	It("should clear the status when there are no services", func() {
+		vmi := newVMI()
+		vmi.Status.VSOCKServices = []v1.VSOCKServiceStatus{{Name: "exec", Port: 10000}}
+
+		service.AllocatePorts(vmi)
+
+		Expect(vmi.Status.VSOCKServices).To(BeEmpty())
+	})
+
+	It("should look up the port of a service", func() {
+		vmi := newVMI(v1.VSOCKService{Name: "logs", Port: pointer.P(uint32(2048))})
+		service.AllocatePorts(vmi)
+
+		port, exists := service.PortFor(vmi, "logs")
+		Expect(exists).To(BeTrue())
+		Expect(port).To(Equal(uint32(2048)))
+
+		_, exists = service.PortFor(vmi, "exec")
+		Expect(exists).To(BeFalse())
+	})
+})
@@ -0,0 +1,129 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+// Package service lets third parties write host-side integrations talking to guest
+// services over VSOCK, without touching virt-launcher internals. The guest services
+// are declared in spec.domain.devices.vsockServices and get their port reported in
+// status.vsockServices. Connections are opened through the vsock subresource of
+// virt-api, which authenticates the host side with a TLS client certificate signed
+// by the KubeVirt CA. Once TLS is established the host sends a hello naming the
+// service it wants to reach and the guest confirms it before any payload is exchanged.
+// Both messages are single JSON documents terminated by a newline.
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// ProtocolVersion is the version of the handshake spoken by this package.
+const ProtocolVersion = 1
+
+const handshakeTimeout = 10 * time.Second
+
+// maxHandshakeLength bounds the hello and the reply, which are tiny documents
+const maxHandshakeLength = 4096
+
+type hello struct {
+	Service string `json:"service"`
+	Version int    `json:"version"`
+}
+
+type reply struct {
+	Accepted bool   `json:"accepted"`
+	Error    string `json:"error,omitempty"`
+}
+
+// clientHandshake announces the service on the connection and waits for the guest
+// to accept it.
+func clientHandshake(conn net.Conn, service string) error {
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return err
+	}
+	if err := writeMessage(conn, hello{Service: service, Version: ProtocolVersion}); err != nil {
+		return fmt.Errorf("failed to send the hello: %v", err)
+	}
+	r := reply{}
+	if err := readMessage(conn, &r); err != nil {
+		return fmt.Errorf("failed to read the reply to the hello: %v", err)
+	}
+	if !r.Accepted {
+		return fmt.Errorf("service %s rejected the connection: %s", service, r.Error)
+	}
+	return conn.SetDeadline(time.Time{})
+}
+
+// serverHandshake reads the hello from the connection and accepts it when it
+// targets the given service with a supported protocol version.
+func serverHandshake(conn net.Conn, service string) error {
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return err
+	}
+	h := hello{}
+	if err := readMessage(conn, &h); err != nil {
+		return fmt.Errorf("failed to read the hello: %v", err)
+	}
+
+	var rejection error
+	switch {
+	case h.Version != ProtocolVersion:
+		rejection = fmt.Errorf("unsupported protocol version %d", h.Version)
+	case h.Service != service:
+		rejection = fmt.Errorf("unknown service %q", h.Service)
+	}
+	if rejection != nil {
+		// The rejection is best effort, the client fails either way
+		_ = writeMessage(conn, reply{Error: rejection.Error()})
+		return rejection
+	}
+
+	if err := writeMessage(conn, reply{Accepted: true}); err != nil {
+		return fmt.Errorf("failed to send the reply: %v", err)
+	}
+	return conn.SetDeadline(time.Time{})
+}
+
+func writeMessage(conn net.Conn, message interface{}) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Write(append(data, '\n'))
+	return err
+}
+
+// readMessage reads byte by byte, so that nothing beyond the message is consumed
+// from the connection which is handed over to the caller afterwards.
+func readMessage(conn net.Conn, message interface{}) error {
+	var line []byte
+	b := make([]byte, 1)
+	for len(line) < maxHandshakeLength {
+		if _, err := io.ReadFull(conn, b); err != nil {
+			return err
+		}
+		if b[0] == '\n' {
+			return json.Unmarshal(line, message)
+		}
+		line = append(line, b[0])
+	}
+	return fmt.Errorf("message exceeds %d bytes", maxHandshakeLength)
+}
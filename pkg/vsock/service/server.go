@@ -0,0 +1,187 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/mdlayher/vsock"
+	"google.golang.org/grpc"
+
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/certificates/triple/cert"
+	systemv1 "kubevirt.io/kubevirt/pkg/vsock/system/v1"
+)
+
+// clientCommonNamePrefix is the common name prefix of the client certificates
+// virt-handler presents when it connects on behalf of the vsock subresource
+const clientCommonNamePrefix = "kubevirt.io:system:client"
+
+// hostSystemServicePort is where the host system service answers on the host CID
+const hostSystemServicePort = 1
+
+// CABundleFunc returns the CA bundle the client certificates are verified against.
+type CABundleFunc func() (*x509.CertPool, error)
+
+// HostCABundle fetches the KubeVirt CA bundle from the host system service.
+func HostCABundle() (*x509.CertPool, error) {
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return vsock.Dial(vsock.Host, hostSystemServicePort, &vsock.Config{})
+	})
+	conn, err := grpc.Dial("vsock", dialer, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the host system service: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+	bundle, err := systemv1.NewSystemClient(conn).CABundle(ctx, &systemv1.EmptyRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the CA bundle: %v", err)
+	}
+	return ParseCABundle(bundle.Raw)
+}
+
+// ParseCABundle parses a PEM encoded CA bundle.
+func ParseCABundle(raw []byte) (*x509.CertPool, error) {
+	certs, err := cert.ParseCertsPEM(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA bundle: %v", err)
+	}
+	pool := x509.NewCertPool()
+	for _, crt := range certs {
+		pool.AddCert(crt)
+	}
+	return pool, nil
+}
+
+// TLSConfig returns the server configuration of a guest service. The CA bundle is
+// fetched for every connection, so that CA rotations are picked up.
+func TLSConfig(serverCert *tls.Certificate, caBundle CABundleFunc) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		GetCertificate: func(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return serverCert, nil
+		},
+		GetConfigForClient: func(_ *tls.ClientHelloInfo) (*tls.Config, error) {
+			pool, err := caBundle()
+			if err != nil {
+				return nil, err
+			}
+			return &tls.Config{
+				MinVersion: tls.VersionTLS13,
+				GetCertificate: func(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+					return serverCert, nil
+				},
+				// The client certificates carry neither a DNS name nor an IP, so they
+				// are verified by VerifyPeerCertificate
+				ClientAuth: tls.RequireAnyClientCert,
+				VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+					return verifyClientCertificate(rawCerts, pool)
+				},
+			}, nil
+		},
+	}
+}
+
+func verifyClientCertificate(rawCerts [][]byte, pool *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("no client certificate provided")
+	}
+	crt, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse the client certificate: %v", err)
+	}
+	intermediates := x509.NewCertPool()
+	for _, raw := range rawCerts[1:] {
+		if intermediate, err := x509.ParseCertificate(raw); err == nil {
+			intermediates.AddCert(intermediate)
+		}
+	}
+	if _, err := crt.Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return fmt.Errorf("could not verify the client certificate: %v", err)
+	}
+	if !strings.HasPrefix(crt.Subject.CommonName, clientCommonNamePrefix) {
+		return fmt.Errorf("client common name %s does not have the prefix %s", crt.Subject.CommonName, clientCommonNamePrefix)
+	}
+	return nil
+}
+
+// Listen listens on the VSOCK port of the named service. The connections are
+// authenticated against the CA bundle of the host system service.
+func Listen(port uint32, service string, serverCert *tls.Certificate) (net.Listener, error) {
+	listener, err := vsock.Listen(port, &vsock.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return NewListener(listener, service, TLSConfig(serverCert, HostCABundle)), nil
+}
+
+type listener struct {
+	net.Listener
+	service   string
+	tlsConfig *tls.Config
+}
+
+// NewListener wraps the listener, so that Accept only returns connections which
+// completed the TLS and the service handshakes.
+func NewListener(inner net.Listener, service string, tlsConfig *tls.Config) net.Listener {
+	return &listener{Listener: inner, service: service, tlsConfig: tlsConfig}
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		serverConn, err := l.handshake(conn)
+		if err != nil {
+			log.Log.Reason(err).Warningf("rejected a connection to vsock service %s", l.service)
+			conn.Close()
+			continue
+		}
+		return serverConn, nil
+	}
+}
+
+func (l *listener) handshake(conn net.Conn) (net.Conn, error) {
+	tlsConn := tls.Server(conn, l.tlsConfig)
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return nil, fmt.Errorf("TLS handshake failed: %v", err)
+	}
+	if err := serverHandshake(tlsConn, l.service); err != nil {
+		return nil, err
+	}
+	return tlsConn, nil
+}
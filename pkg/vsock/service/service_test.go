@@ -0,0 +1,143 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package service_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/certificates/triple"
+	"kubevirt.io/kubevirt/pkg/certificates/triple/cert"
+	"kubevirt.io/kubevirt/pkg/vsock/service"
+)
+
+var _ = Describe("Authenticated vsock services", func() {
+	const serviceName = "logs"
+
+	var (
+		ca         *triple.KeyPair
+		serverCert tls.Certificate
+		listener   net.Listener
+	)
+
+	toTLSCertificate := func(pair *triple.KeyPair) tls.Certificate {
+		return tls.Certificate{Certificate: [][]byte{pair.Cert.Raw}, PrivateKey: pair.Key}
+	}
+
+	newClientCert := func(signer *triple.KeyPair, commonName string) tls.Certificate {
+		pair, err := triple.NewClientKeyPair(signer, commonName, nil, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		return toTLSCertificate(pair)
+	}
+
+	dial := func(clientCert tls.Certificate, name string) (net.Conn, error) {
+		conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{
+			MinVersion:         tls.VersionTLS13,
+			Certificates:       []tls.Certificate{clientCert},
+			InsecureSkipVerify: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return service.NewClientConn(conn, name)
+	}
+
+	// serveEcho mirrors the data of every accepted connection
+	serveEcho := func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		ca, err = triple.NewCA("kubevirt.io", time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		serverPair, err := triple.NewServerKeyPair(ca, "guest", "guest", "default", "cluster.local", nil, nil, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		serverCert = toTLSCertificate(serverPair)
+
+		caBundle := func() (*x509.CertPool, error) {
+			pool := x509.NewCertPool()
+			pool.AddCert(ca.Cert)
+			return pool, nil
+		}
+
+		inner, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		listener = service.NewListener(inner, serviceName, service.TLSConfig(&serverCert, caBundle))
+		DeferCleanup(listener.Close)
+		go serveEcho()
+	})
+
+	It("should hand over the connection once the service accepted it", func() {
+		conn, err := dial(newClientCert(ca, "kubevirt.io:system:client:virt-handler"), serviceName)
+		Expect(err).ToNot(HaveOccurred())
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("hello guest"))
+		Expect(err).ToNot(HaveOccurred())
+		buf := make([]byte, len("hello guest"))
+		_, err = io.ReadFull(conn, buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(buf)).To(Equal("hello guest"))
+	})
+
+	It("should reject connections to another service", func() {
+		_, err := dial(newClientCert(ca, "kubevirt.io:system:client:virt-handler"), "exec")
+		Expect(err).To(MatchError(ContainSubstring(`unknown service "exec"`)))
+	})
+
+	It("should reject client certificates with an unexpected common name", func() {
+		_, err := dial(newClientCert(ca, "someone"), serviceName)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject client certificates signed by another CA", func() {
+		otherCA, err := triple.NewCA("other", time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = dial(newClientCert(otherCA, "kubevirt.io:system:client:virt-handler"), serviceName)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should parse a PEM encoded CA bundle", func() {
+		pool, err := service.ParseCABundle(cert.EncodeCertPEM(ca.Cert))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pool.Equal(func() *x509.CertPool {
+			expected := x509.NewCertPool()
+			expected.AddCert(ca.Cert)
+			return expected
+		}())).To(BeTrue())
+	})
+})
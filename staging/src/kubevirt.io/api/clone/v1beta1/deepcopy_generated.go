@@ -26,6 +26,7 @@ package v1beta1
 import (
 	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	snapshotv1beta1 "kubevirt.io/api/snapshot/v1beta1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -74,6 +75,37 @@ func (in *VirtualMachineClone) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineCloneCustomization) DeepCopyInto(out *VirtualMachineCloneCustomization) {
+	*out = *in
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResetMachineID != nil {
+		in, out := &in.ResetMachineID, &out.ResetMachineID
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RegenerateSSHHostKeys != nil {
+		in, out := &in.RegenerateSSHHostKeys, &out.RegenerateSSHHostKeys
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCloneCustomization.
+func (in *VirtualMachineCloneCustomization) DeepCopy() *VirtualMachineCloneCustomization {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineCloneCustomization)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineCloneList) DeepCopyInto(out *VirtualMachineCloneList) {
 	*out = *in
@@ -153,6 +185,16 @@ func (in *VirtualMachineCloneSpec) DeepCopyInto(out *VirtualMachineCloneSpec) {
 		*out = new(VolumeNamePolicy)
 		**out = **in
 	}
+	if in.IdentityPolicy != nil {
+		in, out := &in.IdentityPolicy, &out.IdentityPolicy
+		*out = new(snapshotv1beta1.IdentityPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Customization != nil {
+		in, out := &in.Customization, &out.Customization
+		*out = new(VirtualMachineCloneCustomization)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -195,6 +237,11 @@ func (in *VirtualMachineCloneStatus) DeepCopyInto(out *VirtualMachineCloneStatus
 		*out = new(string)
 		**out = **in
 	}
+	if in.CustomizationJobName != nil {
+		in, out := &in.CustomizationJobName, &out.CustomizationJobName
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -99,6 +99,29 @@ type VirtualMachineCloneSpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum=RandomizeNames;PrefixTargetName
 	VolumeNamePolicy *VolumeNamePolicy `json:"volumeNamePolicy,omitempty"`
+	// IdentityPolicy defines which identity attributes of the source VM are regenerated in the target.
+	// MAC addresses, SMBIOS serial and firmware UUID are regenerated by default while the hostname is preserved.
+	// NewMacAddresses and NewSMBiosSerial take precedence over this policy.
+	// +optional
+	IdentityPolicy *snapshotv1beta1.IdentityPolicy `json:"identityPolicy,omitempty"`
+	// Customization defines guest customizations applied to the target's disks with virt-sysprep
+	// after they were restored, so that the clone doesn't collide with its source on the network.
+	// The target is kept halted until the customization finished.
+	// +optional
+	Customization *VirtualMachineCloneCustomization `json:"customization,omitempty"`
+}
+
+// VirtualMachineCloneCustomization defines the guest customizations applied offline to a clone
+type VirtualMachineCloneCustomization struct {
+	// Hostname sets the hostname of the guest.
+	// +optional
+	Hostname *string `json:"hostname,omitempty"`
+	// ResetMachineID removes the machine-id of the guest so that a new one is generated on the next boot.
+	// +optional
+	ResetMachineID *bool `json:"resetMachineID,omitempty"`
+	// RegenerateSSHHostKeys removes the SSH host keys of the guest so that new ones are generated on the next boot.
+	// +optional
+	RegenerateSSHHostKeys *bool `json:"regenerateSSHHostKeys,omitempty"`
 }
 
 // VolumeNamePolicy defines how to handle volume naming during the clone operation
@@ -131,13 +154,14 @@ func (p VolumeNamePolicy) ToVolumeRestorePolicy() snapshotv1beta1.VolumeRestoreP
 type VirtualMachineClonePhase string
 
 const (
-	PhaseUnset         VirtualMachineClonePhase = ""
-	SnapshotInProgress VirtualMachineClonePhase = "SnapshotInProgress"
-	CreatingTargetVM   VirtualMachineClonePhase = "CreatingTargetVM"
-	RestoreInProgress  VirtualMachineClonePhase = "RestoreInProgress"
-	Succeeded          VirtualMachineClonePhase = "Succeeded"
-	Failed             VirtualMachineClonePhase = "Failed"
-	Unknown            VirtualMachineClonePhase = "Unknown"
+	PhaseUnset              VirtualMachineClonePhase = ""
+	SnapshotInProgress      VirtualMachineClonePhase = "SnapshotInProgress"
+	CreatingTargetVM        VirtualMachineClonePhase = "CreatingTargetVM"
+	RestoreInProgress       VirtualMachineClonePhase = "RestoreInProgress"
+	CustomizationInProgress VirtualMachineClonePhase = "CustomizationInProgress"
+	Succeeded               VirtualMachineClonePhase = "Succeeded"
+	Failed                  VirtualMachineClonePhase = "Failed"
+	Unknown                 VirtualMachineClonePhase = "Unknown"
 )
 
 type VirtualMachineCloneStatus struct {
@@ -163,6 +187,10 @@ type VirtualMachineCloneStatus struct {
 	// +optional
 	// +nullable
 	TargetName *string `json:"targetName,omitempty"`
+
+	// +optional
+	// +nullable
+	CustomizationJobName *string `json:"customizationJobName,omitempty"`
 }
 
 // ConditionType is the const type for Conditions
@@ -26,17 +26,29 @@ func (VirtualMachineCloneSpec) SwaggerDoc() map[string]string {
 		"newSMBiosSerial":   "NewSMBiosSerial manually sets that target's SMbios serial. If this field is not specified, a new serial will\nbe generated automatically.\n+optional",
 		"patches":           "Patches holds JSON patches to apply to target. Patches should fit the target's Kind.\nExample: '{\"op\": \"add\", \"path\": \"/spec/template/metadata/labels/example\", \"value\": \"new-label\"}'\n+optional\n+listType=atomic",
 		"volumeNamePolicy":  "VolumeNamePolicy defines how to handle volume naming during the clone operation\n+optional\n+kubebuilder:validation:Enum=RandomizeNames;PrefixTargetName",
+		"identityPolicy":    "IdentityPolicy defines which identity attributes of the source VM are regenerated in the target.\nMAC addresses, SMBIOS serial and firmware UUID are regenerated by default while the hostname is preserved.\nNewMacAddresses and NewSMBiosSerial take precedence over this policy.\n+optional",
+		"customization":     "Customization defines guest customizations applied to the target's disks with virt-sysprep\nafter they were restored, so that the clone doesn't collide with its source on the network.\nThe target is kept halted until the customization finished.\n+optional",
+	}
+}
+
+func (VirtualMachineCloneCustomization) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "VirtualMachineCloneCustomization defines the guest customizations applied offline to a clone",
+		"hostname":              "Hostname sets the hostname of the guest.\n+optional",
+		"resetMachineID":        "ResetMachineID removes the machine-id of the guest so that a new one is generated on the next boot.\n+optional",
+		"regenerateSSHHostKeys": "RegenerateSSHHostKeys removes the SSH host keys of the guest so that new ones are generated on the next boot.\n+optional",
 	}
 }
 
 func (VirtualMachineCloneStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"creationTime": "+optional\n+nullable",
-		"phase":        "+optional",
-		"conditions":   "+optional\n+listType=atomic",
-		"snapshotName": "+optional\n+nullable",
-		"restoreName":  "+optional\n+nullable",
-		"targetName":   "+optional\n+nullable",
+		"creationTime":         "+optional\n+nullable",
+		"phase":                "+optional",
+		"conditions":           "+optional\n+listType=atomic",
+		"snapshotName":         "+optional\n+nullable",
+		"restoreName":          "+optional\n+nullable",
+		"targetName":           "+optional\n+nullable",
+		"customizationJobName": "+optional\n+nullable",
 	}
 }
 
@@ -266,6 +266,108 @@ func (in *Bootloader) DeepCopy() *Bootloader {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkLifecycleOptions) DeepCopyInto(out *BulkLifecycleOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartOptions != nil {
+		in, out := &in.StartOptions, &out.StartOptions
+		*out = new(StartOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StopOptions != nil {
+		in, out := &in.StopOptions, &out.StopOptions
+		*out = new(StopOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartOptions != nil {
+		in, out := &in.RestartOptions, &out.RestartOptions
+		*out = new(RestartOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkLifecycleOptions.
+func (in *BulkLifecycleOptions) DeepCopy() *BulkLifecycleOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkLifecycleOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkLifecycleResult) DeepCopyInto(out *BulkLifecycleResult) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]BulkLifecycleVirtualMachineResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkLifecycleResult.
+func (in *BulkLifecycleResult) DeepCopy() *BulkLifecycleResult {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkLifecycleResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BulkLifecycleResult) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkLifecycleVirtualMachineResult) DeepCopyInto(out *BulkLifecycleVirtualMachineResult) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]DryRunChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkLifecycleVirtualMachineResult.
+func (in *BulkLifecycleVirtualMachineResult) DeepCopy() *BulkLifecycleVirtualMachineResult {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkLifecycleVirtualMachineResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDRomTarget) DeepCopyInto(out *CDRomTarget) {
 	*out = *in
@@ -305,6 +407,11 @@ func (in *CPU) DeepCopyInto(out *CPU) {
 		*out = new(Realtime)
 		**out = **in
 	}
+	if in.ThreadShares != nil {
+		in, out := &in.ThreadShares, &out.ThreadShares
+		*out = new(CPUThreadShares)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -334,6 +441,32 @@ func (in *CPUFeature) DeepCopy() *CPUFeature {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUThreadShares) DeepCopyInto(out *CPUThreadShares) {
+	*out = *in
+	if in.Emulator != nil {
+		in, out := &in.Emulator, &out.Emulator
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.IOThreads != nil {
+		in, out := &in.IOThreads, &out.IOThreads
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUThreadShares.
+func (in *CPUThreadShares) DeepCopy() *CPUThreadShares {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUThreadShares)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CPUTopology) DeepCopyInto(out *CPUTopology) {
 	*out = *in
@@ -641,6 +774,66 @@ func (in *ClusterProfilerResults) DeepCopy() *ClusterProfilerResults {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRecoveryConfiguration) DeepCopyInto(out *ClusterRecoveryConfiguration) {
+	*out = *in
+	if in.OutageThresholdPercentage != nil {
+		in, out := &in.OutageThresholdPercentage, &out.OutageThresholdPercentage
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxStartsPerNode != nil {
+		in, out := &in.MaxStartsPerNode, &out.MaxStartsPerNode
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.StorageReadinessCheck != nil {
+		in, out := &in.StorageReadinessCheck, &out.StorageReadinessCheck
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRecoveryConfiguration.
+func (in *ClusterRecoveryConfiguration) DeepCopy() *ClusterRecoveryConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRecoveryConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRecoveryStatus) DeepCopyInto(out *ClusterRecoveryStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CurrentPriority != nil {
+		in, out := &in.CurrentPriority, &out.CurrentPriority
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRecoveryStatus.
+func (in *ClusterRecoveryStatus) DeepCopy() *ClusterRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonInstancetypesDeployment) DeepCopyInto(out *CommonInstancetypesDeployment) {
 	*out = *in
@@ -900,6 +1093,27 @@ func (in *CustomizeComponentsPatch) DeepCopy() *CustomizeComponentsPatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPNetBIOSOptions) DeepCopyInto(out *DHCPNetBIOSOptions) {
+	*out = *in
+	if in.NameServers != nil {
+		in, out := &in.NameServers, &out.NameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPNetBIOSOptions.
+func (in *DHCPNetBIOSOptions) DeepCopy() *DHCPNetBIOSOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPNetBIOSOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DHCPOptions) DeepCopyInto(out *DHCPOptions) {
 	*out = *in
@@ -913,6 +1127,16 @@ func (in *DHCPOptions) DeepCopyInto(out *DHCPOptions) {
 		*out = make([]DHCPPrivateOptions, len(*in))
 		copy(*out, *in)
 	}
+	if in.DomainSearch != nil {
+		in, out := &in.DomainSearch, &out.DomainSearch
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetBIOS != nil {
+		in, out := &in.NetBIOS, &out.NetBIOS
+		*out = new(DHCPNetBIOSOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1071,6 +1295,11 @@ func (in *DeveloperConfiguration) DeepCopyInto(out *DeveloperConfiguration) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.TSCFrequencyTolerancePPM != nil {
+		in, out := &in.TSCFrequencyTolerancePPM, &out.TSCFrequencyTolerancePPM
+		*out = new(int64)
+		**out = **in
+	}
 	if in.DiskVerification != nil {
 		in, out := &in.DiskVerification, &out.DiskVerification
 		*out = new(DiskVerification)
@@ -1269,6 +1498,13 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.VSOCKServices != nil {
+		in, out := &in.VSOCKServices, &out.VSOCKServices
+		*out = make([]VSOCKService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Rng != nil {
 		in, out := &in.Rng, &out.Rng
 		*out = new(Rng)
@@ -1325,7 +1561,7 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 	if in.Sound != nil {
 		in, out := &in.Sound, &out.Sound
 		*out = new(SoundDevice)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TPM != nil {
 		in, out := &in.TPM, &out.TPM
@@ -1427,6 +1663,11 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(DiskErrorPolicy)
 		**out = **in
 	}
+	if in.ReadErrorPolicy != nil {
+		in, out := &in.ReadErrorPolicy, &out.ReadErrorPolicy
+		*out = new(DiskErrorPolicy)
+		**out = **in
+	}
 	if in.ChangedBlockTracking != nil {
 		in, out := &in.ChangedBlockTracking, &out.ChangedBlockTracking
 		*out = new(bool)
@@ -1631,6 +1872,86 @@ func (in *DomainSpec) DeepCopy() *DomainSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainStatsCPU) DeepCopyInto(out *DomainStatsCPU) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainStatsCPU.
+func (in *DomainStatsCPU) DeepCopy() *DomainStatsCPU {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainStatsCPU)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainStatsDisk) DeepCopyInto(out *DomainStatsDisk) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainStatsDisk.
+func (in *DomainStatsDisk) DeepCopy() *DomainStatsDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainStatsDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainStatsInterface) DeepCopyInto(out *DomainStatsInterface) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainStatsInterface.
+func (in *DomainStatsInterface) DeepCopy() *DomainStatsInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainStatsInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainStatsMemory) DeepCopyInto(out *DomainStatsMemory) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainStatsMemory.
+func (in *DomainStatsMemory) DeepCopy() *DomainStatsMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainStatsMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainStatsVCPU) DeepCopyInto(out *DomainStatsVCPU) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainStatsVCPU.
+func (in *DomainStatsVCPU) DeepCopy() *DomainStatsVCPU {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainStatsVCPU)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DownwardAPIVolumeSource) DeepCopyInto(out *DownwardAPIVolumeSource) {
 	*out = *in
@@ -1686,6 +2007,59 @@ func (in *DownwardMetricsVolumeSource) DeepCopy() *DownwardMetricsVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunChange) DeepCopyInto(out *DryRunChange) {
+	*out = *in
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunChange.
+func (in *DryRunChange) DeepCopy() *DryRunChange {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunResult) DeepCopyInto(out *DryRunResult) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]DryRunChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunResult.
+func (in *DryRunResult) DeepCopy() *DryRunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DryRunResult) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EFI) DeepCopyInto(out *EFI) {
 	*out = *in
@@ -2111,6 +2485,11 @@ func (in *FreezeUnfreezeTimeout) DeepCopyInto(out *FreezeUnfreezeTimeout) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Mountpoints != nil {
+		in, out := &in.Mountpoints, &out.Mountpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2151,33 +2530,103 @@ func (in *GPU) DeepCopy() *GPU {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GenerationStatus) DeepCopyInto(out *GenerationStatus) {
+func (in *GenerationStatus) DeepCopyInto(out *GenerationStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenerationStatus.
+func (in *GenerationStatus) DeepCopy() *GenerationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GenerationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestAgentAlternative) DeepCopyInto(out *GuestAgentAlternative) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestAgentAlternative.
+func (in *GuestAgentAlternative) DeepCopy() *GuestAgentAlternative {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestAgentAlternative)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestAgentCommandInfo) DeepCopyInto(out *GuestAgentCommandInfo) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestAgentCommandInfo.
+func (in *GuestAgentCommandInfo) DeepCopy() *GuestAgentCommandInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestAgentCommandInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestAgentConfiguration) DeepCopyInto(out *GuestAgentConfiguration) {
 	*out = *in
+	if in.Alternatives != nil {
+		in, out := &in.Alternatives, &out.Alternatives
+		*out = make([]GuestAgentAlternative, len(*in))
+		copy(*out, *in)
+	}
+	if in.DataPolicies != nil {
+		in, out := &in.DataPolicies, &out.DataPolicies
+		*out = make([]GuestAgentDataPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenerationStatus.
-func (in *GenerationStatus) DeepCopy() *GenerationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestAgentConfiguration.
+func (in *GuestAgentConfiguration) DeepCopy() *GuestAgentConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(GenerationStatus)
+	out := new(GuestAgentConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GuestAgentCommandInfo) DeepCopyInto(out *GuestAgentCommandInfo) {
+func (in *GuestAgentDataPolicy) DeepCopyInto(out *GuestAgentDataPolicy) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Redact != nil {
+		in, out := &in.Redact, &out.Redact
+		*out = make([]GuestAgentDataKind, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestAgentCommandInfo.
-func (in *GuestAgentCommandInfo) DeepCopy() *GuestAgentCommandInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestAgentDataPolicy.
+func (in *GuestAgentDataPolicy) DeepCopy() *GuestAgentDataPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(GuestAgentCommandInfo)
+	out := new(GuestAgentDataPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3089,6 +3538,31 @@ func (in *KubeVirtConfiguration) DeepCopyInto(out *KubeVirtConfiguration) {
 		*out = new(RoleAggregationStrategy)
 		**out = **in
 	}
+	if in.VolumePermissionRepairPolicy != nil {
+		in, out := &in.VolumePermissionRepairPolicy, &out.VolumePermissionRepairPolicy
+		*out = new(VolumePermissionRepairPolicy)
+		**out = **in
+	}
+	if in.ClusterRecovery != nil {
+		in, out := &in.ClusterRecovery, &out.ClusterRecovery
+		*out = new(ClusterRecoveryConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuestAgent != nil {
+		in, out := &in.GuestAgent, &out.GuestAgent
+		*out = new(GuestAgentConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubresourceLimits != nil {
+		in, out := &in.SubresourceLimits, &out.SubresourceLimits
+		*out = new(SubresourceLimitsConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VirtualMachineExport != nil {
+		in, out := &in.VirtualMachineExport, &out.VirtualMachineExport
+		*out = new(VirtualMachineExportConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3241,6 +3715,11 @@ func (in *KubeVirtStatus) DeepCopyInto(out *KubeVirtStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClusterRecovery != nil {
+		in, out := &in.ClusterRecovery, &out.ClusterRecovery
+		*out = new(ClusterRecoveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3342,6 +3821,46 @@ func (in *LiveUpdateConfiguration) DeepCopy() *LiveUpdateConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LiveUpdatedResources) DeepCopyInto(out *LiveUpdatedResources) {
+	*out = *in
+	if in.Sockets != nil {
+		in, out := &in.Sockets, &out.Sockets
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Guest != nil {
+		in, out := &in.Guest, &out.Guest
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LiveUpdatedResources.
+func (in *LiveUpdatedResources) DeepCopy() *LiveUpdatedResources {
+	if in == nil {
+		return nil
+	}
+	out := new(LiveUpdatedResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogVerbosity) DeepCopyInto(out *LogVerbosity) {
 	*out = *in
@@ -3438,6 +3957,11 @@ func (in *MediatedDevicesConfiguration) DeepCopy() *MediatedDevicesConfiguration
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MediatedHostDevice) DeepCopyInto(out *MediatedHostDevice) {
 	*out = *in
+	if in.SchedulingPolicy != nil {
+		in, out := &in.SchedulingPolicy, &out.SchedulingPolicy
+		*out = new(VGPUSchedulingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3556,6 +4080,11 @@ func (in *MigrateOptions) DeepCopyInto(out *MigrateOptions) {
 			(*out)[key] = val
 		}
 	}
+	if in.MigrationPolicyName != nil {
+		in, out := &in.MigrationPolicyName, &out.MigrationPolicyName
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -3642,6 +4171,11 @@ func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.NodeHeadroom != nil {
+		in, out := &in.NodeHeadroom, &out.NodeHeadroom
+		*out = new(MigrationNodeHeadroom)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3655,6 +4189,32 @@ func (in *MigrationConfiguration) DeepCopy() *MigrationConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationNodeHeadroom) DeepCopyInto(out *MigrationNodeHeadroom) {
+	*out = *in
+	if in.CPUPercentage != nil {
+		in, out := &in.CPUPercentage, &out.CPUPercentage
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MemoryPercentage != nil {
+		in, out := &in.MemoryPercentage, &out.MemoryPercentage
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationNodeHeadroom.
+func (in *MigrationNodeHeadroom) DeepCopy() *MigrationNodeHeadroom {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationNodeHeadroom)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultusNetwork) DeepCopyInto(out *MultusNetwork) {
 	*out = *in
@@ -4030,7 +4590,9 @@ func (in *PermittedHostDevices) DeepCopyInto(out *PermittedHostDevices) {
 	if in.MediatedDevices != nil {
 		in, out := &in.MediatedDevices, &out.MediatedDevices
 		*out = make([]MediatedHostDevice, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.USB != nil {
 		in, out := &in.USB, &out.USB
@@ -4389,6 +4951,23 @@ func (in *RemoveVolumeOptions) DeepCopy() *RemoveVolumeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RenameOptions) DeepCopyInto(out *RenameOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenameOptions.
+func (in *RenameOptions) DeepCopy() *RenameOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RenameOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReservedOverhead) DeepCopyInto(out *ReservedOverhead) {
 	*out = *in
@@ -4647,6 +5226,47 @@ func (in *SEVSNP) DeepCopy() *SEVSNP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SEVSNPAttestationReport) DeepCopyInto(out *SEVSNPAttestationReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SEVSNPAttestationReport.
+func (in *SEVSNPAttestationReport) DeepCopy() *SEVSNPAttestationReport {
+	if in == nil {
+		return nil
+	}
+	out := new(SEVSNPAttestationReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SEVSNPAttestationReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SEVSNPAttestationReportOptions) DeepCopyInto(out *SEVSNPAttestationReportOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SEVSNPAttestationReportOptions.
+func (in *SEVSNPAttestationReportOptions) DeepCopy() *SEVSNPAttestationReportOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SEVSNPAttestationReportOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SEVSecretOptions) DeepCopyInto(out *SEVSecretOptions) {
 	*out = *in
@@ -4839,9 +5459,31 @@ func (in *ServiceAccountVolumeSource) DeepCopy() *ServiceAccountVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SoftRebootOptions) DeepCopyInto(out *SoftRebootOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoftRebootOptions.
+func (in *SoftRebootOptions) DeepCopy() *SoftRebootOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SoftRebootOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SoundDevice) DeepCopyInto(out *SoundDevice) {
 	*out = *in
+	if in.Stream != nil {
+		in, out := &in.Stream, &out.Stream
+		*out = new(SoundStream)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4855,6 +5497,27 @@ func (in *SoundDevice) DeepCopy() *SoundDevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SoundStream) DeepCopyInto(out *SoundStream) {
+	*out = *in
+	if in.MaxBandwidth != nil {
+		in, out := &in.MaxBandwidth, &out.MaxBandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoundStream.
+func (in *SoundStream) DeepCopy() *SoundStream {
+	if in == nil {
+		return nil
+	}
+	out := new(SoundStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StartOptions) DeepCopyInto(out *StartOptions) {
 	*out = *in
@@ -4930,6 +5593,63 @@ func (in *StorageMigratedVolumeInfo) DeepCopy() *StorageMigratedVolumeInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubresourceClientLimits) DeepCopyInto(out *SubresourceClientLimits) {
+	*out = *in
+	if in.MaxConcurrentStreams != nil {
+		in, out := &in.MaxConcurrentStreams, &out.MaxConcurrentStreams
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.RequestsPerSecond != nil {
+		in, out := &in.RequestsPerSecond, &out.RequestsPerSecond
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubresourceClientLimits.
+func (in *SubresourceClientLimits) DeepCopy() *SubresourceClientLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(SubresourceClientLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubresourceLimitsConfiguration) DeepCopyInto(out *SubresourceLimitsConfiguration) {
+	*out = *in
+	if in.PerUser != nil {
+		in, out := &in.PerUser, &out.PerUser
+		*out = new(SubresourceClientLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PerNamespace != nil {
+		in, out := &in.PerNamespace, &out.PerNamespace
+		*out = new(SubresourceClientLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubresourceLimitsConfiguration.
+func (in *SubresourceLimitsConfiguration) DeepCopy() *SubresourceLimitsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SubresourceLimitsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SupportContainerResources) DeepCopyInto(out *SupportContainerResources) {
 	*out = *in
@@ -5189,6 +5909,44 @@ func (in *TokenBucketRateLimiter) DeepCopy() *TokenBucketRateLimiter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenExchangeOptions) DeepCopyInto(out *TokenExchangeOptions) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenExchangeOptions.
+func (in *TokenExchangeOptions) DeepCopy() *TokenExchangeOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenExchangeOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenExchangeResult) DeepCopyInto(out *TokenExchangeResult) {
+	*out = *in
+	in.ExpirationTimestamp.DeepCopyInto(&out.ExpirationTimestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenExchangeResult.
+func (in *TokenExchangeResult) DeepCopy() *TokenExchangeResult {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenExchangeResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologyHints) DeepCopyInto(out *TopologyHints) {
 	*out = *in
@@ -5372,28 +6130,49 @@ func (in *VGPUDisplayOptions) DeepCopy() *VGPUDisplayOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(VGPUDisplayOptions)
+	out := new(VGPUDisplayOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VGPUOptions) DeepCopyInto(out *VGPUOptions) {
+	*out = *in
+	if in.Display != nil {
+		in, out := &in.Display, &out.Display
+		*out = new(VGPUDisplayOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUOptions.
+func (in *VGPUOptions) DeepCopy() *VGPUOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(VGPUOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VGPUOptions) DeepCopyInto(out *VGPUOptions) {
+func (in *VGPUSchedulingPolicy) DeepCopyInto(out *VGPUSchedulingPolicy) {
 	*out = *in
-	if in.Display != nil {
-		in, out := &in.Display, &out.Display
-		*out = new(VGPUDisplayOptions)
-		(*in).DeepCopyInto(*out)
+	if in.TimeSliceMicroseconds != nil {
+		in, out := &in.TimeSliceMicroseconds, &out.TimeSliceMicroseconds
+		*out = new(uint32)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUOptions.
-func (in *VGPUOptions) DeepCopy() *VGPUOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUSchedulingPolicy.
+func (in *VGPUSchedulingPolicy) DeepCopy() *VGPUSchedulingPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(VGPUOptions)
+	out := new(VGPUSchedulingPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -5435,6 +6214,43 @@ func (in *VSOCKOptions) DeepCopy() *VSOCKOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSOCKService) DeepCopyInto(out *VSOCKService) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSOCKService.
+func (in *VSOCKService) DeepCopy() *VSOCKService {
+	if in == nil {
+		return nil
+	}
+	out := new(VSOCKService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSOCKServiceStatus) DeepCopyInto(out *VSOCKServiceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSOCKServiceStatus.
+func (in *VSOCKServiceStatus) DeepCopy() *VSOCKServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSOCKServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VideoDevice) DeepCopyInto(out *VideoDevice) {
 	*out = *in
@@ -5518,6 +6334,42 @@ func (in *VirtualMachineCondition) DeepCopy() *VirtualMachineCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportConfiguration) DeepCopyInto(out *VirtualMachineExportConfiguration) {
+	*out = *in
+	if in.DefaultTTL != nil {
+		in, out := &in.DefaultTTL, &out.DefaultTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxLifetime != nil {
+		in, out := &in.MaxLifetime, &out.MaxLifetime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.AutoRenew != nil {
+		in, out := &in.AutoRenew, &out.AutoRenew
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExpiryWarning != nil {
+		in, out := &in.ExpiryWarning, &out.ExpiryWarning
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportConfiguration.
+func (in *VirtualMachineExportConfiguration) DeepCopy() *VirtualMachineExportConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstance) DeepCopyInto(out *VirtualMachineInstance) {
 	*out = *in
@@ -5626,6 +6478,42 @@ func (in *VirtualMachineInstanceCommonMigrationState) DeepCopy() *VirtualMachine
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceBootTimes) DeepCopyInto(out *VirtualMachineInstanceBootTimes) {
+	*out = *in
+	if in.PodCreationTime != nil {
+		in, out := &in.PodCreationTime, &out.PodCreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PodScheduledTime != nil {
+		in, out := &in.PodScheduledTime, &out.PodScheduledTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LauncherStartTime != nil {
+		in, out := &in.LauncherStartTime, &out.LauncherStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DomainStartTime != nil {
+		in, out := &in.DomainStartTime, &out.DomainStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.GuestAgentConnectTime != nil {
+		in, out := &in.GuestAgentConnectTime, &out.GuestAgentConnectTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceBootTimes.
+func (in *VirtualMachineInstanceBootTimes) DeepCopy() *VirtualMachineInstanceBootTimes {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceBootTimes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceCondition) DeepCopyInto(out *VirtualMachineInstanceCondition) {
 	*out = *in
@@ -5644,6 +6532,64 @@ func (in *VirtualMachineInstanceCondition) DeepCopy() *VirtualMachineInstanceCon
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceDomain) DeepCopyInto(out *VirtualMachineInstanceDomain) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceDomain.
+func (in *VirtualMachineInstanceDomain) DeepCopy() *VirtualMachineInstanceDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceDomainStats) DeepCopyInto(out *VirtualMachineInstanceDomainStats) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(DomainStatsCPU)
+		**out = **in
+	}
+	if in.VCPUs != nil {
+		in, out := &in.VCPUs, &out.VCPUs
+		*out = make([]DomainStatsVCPU, len(*in))
+		copy(*out, *in)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(DomainStatsMemory)
+		**out = **in
+	}
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]DomainStatsDisk, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]DomainStatsInterface, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceDomainStats.
+func (in *VirtualMachineInstanceDomainStats) DeepCopy() *VirtualMachineInstanceDomainStats {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceDomainStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceFileSystem) DeepCopyInto(out *VirtualMachineInstanceFileSystem) {
 	*out = *in
@@ -6016,6 +6962,11 @@ func (in *VirtualMachineInstanceMigrationSpec) DeepCopyInto(out *VirtualMachineI
 			(*out)[key] = val
 		}
 	}
+	if in.MigrationPolicyName != nil {
+		in, out := &in.MigrationPolicyName, &out.MigrationPolicyName
+		*out = new(string)
+		**out = **in
+	}
 	if in.SendTo != nil {
 		in, out := &in.SendTo, &out.SendTo
 		*out = new(VirtualMachineInstanceMigrationSource)
@@ -6066,6 +7017,11 @@ func (in *VirtualMachineInstanceMigrationState) DeepCopyInto(out *VirtualMachine
 			(*out)[key] = val
 		}
 	}
+	if in.TransferStatus != nil {
+		in, out := &in.TransferStatus, &out.TransferStatus
+		*out = new(VirtualMachineInstanceMigrationTransferStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MigrationPolicyName != nil {
 		in, out := &in.MigrationPolicyName, &out.MigrationPolicyName
 		*out = new(string)
@@ -6213,6 +7169,26 @@ func (in *VirtualMachineInstanceMigrationTargetState) DeepCopy() *VirtualMachine
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceMigrationTransferStatus) DeepCopyInto(out *VirtualMachineInstanceMigrationTransferStatus) {
+	*out = *in
+	if in.LastUpdateTimestamp != nil {
+		in, out := &in.LastUpdateTimestamp, &out.LastUpdateTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceMigrationTransferStatus.
+func (in *VirtualMachineInstanceMigrationTransferStatus) DeepCopy() *VirtualMachineInstanceMigrationTransferStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceMigrationTransferStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceNetworkInterface) DeepCopyInto(out *VirtualMachineInstanceNetworkInterface) {
 	*out = *in
@@ -6487,6 +7463,23 @@ func (in *VirtualMachineInstanceReplicaSetStatus) DeepCopy() *VirtualMachineInst
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceResourceUsage) DeepCopyInto(out *VirtualMachineInstanceResourceUsage) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceResourceUsage.
+func (in *VirtualMachineInstanceResourceUsage) DeepCopy() *VirtualMachineInstanceResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSpec) {
 	*out = *in
@@ -6649,6 +7642,11 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		*out = new(KernelBootStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SSHHostKeys != nil {
+		in, out := &in.SSHHostKeys, &out.SSHHostKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.TopologyHints != nil {
 		in, out := &in.TopologyHints, &out.TopologyHints
 		*out = new(TopologyHints)
@@ -6659,6 +7657,11 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		*out = new(uint32)
 		**out = **in
 	}
+	if in.VSOCKServices != nil {
+		in, out := &in.VSOCKServices, &out.VSOCKServices
+		*out = make([]VSOCKServiceStatus, len(*in))
+		copy(*out, *in)
+	}
 	if in.Machine != nil {
 		in, out := &in.Machine, &out.Machine
 		*out = new(Machine)
@@ -6674,6 +7677,16 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		*out = new(MemoryStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LiveUpdatedResources != nil {
+		in, out := &in.LiveUpdatedResources, &out.LiveUpdatedResources
+		*out = new(LiveUpdatedResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CPUThreadShares != nil {
+		in, out := &in.CPUThreadShares, &out.CPUThreadShares
+		*out = new(CPUThreadShares)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MigratedVolumes != nil {
 		in, out := &in.MigratedVolumes, &out.MigratedVolumes
 		*out = make([]StorageMigratedVolumeInfo, len(*in))
@@ -6691,6 +7704,11 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		*out = new(ChangedBlockTrackingStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BootTimes != nil {
+		in, out := &in.BootTimes, &out.BootTimes
+		*out = new(VirtualMachineInstanceBootTimes)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -6704,6 +7722,63 @@ func (in *VirtualMachineInstanceStatus) DeepCopy() *VirtualMachineInstanceStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceSummary) DeepCopyInto(out *VirtualMachineInstanceSummary) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceSummary.
+func (in *VirtualMachineInstanceSummary) DeepCopy() *VirtualMachineInstanceSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceSummaryList) DeepCopyInto(out *VirtualMachineInstanceSummaryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.PhaseCounts != nil {
+		in, out := &in.PhaseCounts, &out.PhaseCounts
+		*out = make(map[VirtualMachineInstancePhase]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineInstanceSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceSummaryList.
+func (in *VirtualMachineInstanceSummaryList) DeepCopy() *VirtualMachineInstanceSummaryList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceSummaryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineInstanceSummaryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceTemplateSpec) DeepCopyInto(out *VirtualMachineInstanceTemplateSpec) {
 	*out = *in
@@ -6810,6 +7885,52 @@ func (in *VirtualMachineOptions) DeepCopy() *VirtualMachineOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePreflightCheck) DeepCopyInto(out *VirtualMachinePreflightCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePreflightCheck.
+func (in *VirtualMachinePreflightCheck) DeepCopy() *VirtualMachinePreflightCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePreflightCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePreflightReport) DeepCopyInto(out *VirtualMachinePreflightReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]VirtualMachinePreflightCheck, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePreflightReport.
+func (in *VirtualMachinePreflightReport) DeepCopy() *VirtualMachinePreflightReport {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePreflightReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachinePreflightReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 	*out = *in
@@ -6850,6 +7971,11 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(UpdateVolumesStrategy)
 		**out = **in
 	}
+	if in.DeletionProtection != nil {
+		in, out := &in.DeletionProtection, &out.DeletionProtection
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -6980,6 +8106,15 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		*out = new(InstancetypeStatusRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstanceStatusLastUpdateTime != nil {
+		in, out := &in.InstanceStatusLastUpdateTime, &out.InstanceStatusLastUpdateTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
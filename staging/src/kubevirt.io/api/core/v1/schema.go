@@ -387,6 +387,28 @@ type CPU struct {
 	// Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads
 	// +optional
 	Realtime *Realtime `json:"realtime,omitempty"`
+	// ThreadShares weights the CPU time of the emulator and IO threads against the vCPU threads
+	// of the VMI. Only applies to VMIs without dedicated CPUs.
+	// +optional
+	ThreadShares *CPUThreadShares `json:"threadShares,omitempty"`
+}
+
+// CPUThreadShares holds the relative CPU shares of the emulator and IO threads of a VMI.
+// The values follow the semantics of the cgroup v1 cpu.shares, where every vCPU thread
+// has 1024 shares, and are converted to cpu.weight on cgroup v2 hosts.
+type CPUThreadShares struct {
+	// Emulator is the CPU shares of the emulator threads.
+	// Threads are left in the cgroup of the VMI if not set.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=262144
+	// +optional
+	Emulator *uint32 `json:"emulator,omitempty"`
+	// IOThreads is the CPU shares of the IO threads.
+	// Threads are left in the cgroup of the VMI if not set.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=262144
+	// +optional
+	IOThreads *uint32 `json:"ioThreads,omitempty"`
 }
 
 // Realtime holds the tuning knobs specific for realtime workloads.
@@ -460,6 +482,23 @@ type MemoryStatus struct {
 	MemoryOverhead *resource.Quantity `json:"memoryOverhead,omitempty"`
 }
 
+// LiveUpdatedResources describes the guest CPU and memory resources which were
+// applied to a running VirtualMachineInstance without a restart.
+type LiveUpdatedResources struct {
+	// Sockets is the number of CPU sockets applied by the last CPU hotplug.
+	// +optional
+	Sockets *uint32 `json:"sockets,omitempty"`
+	// Guest is the guest memory applied by the last memory hotplug.
+	// +optional
+	Guest *resource.Quantity `json:"guest,omitempty"`
+	// Requests are the CPU and memory requests applied together with the hotplugs.
+	// +optional
+	Requests v1.ResourceList `json:"requests,omitempty"`
+	// Limits are the CPU and memory limits applied together with the hotplugs.
+	// +optional
+	Limits v1.ResourceList `json:"limits,omitempty"`
+}
+
 // Hugepages allow to use hugepages for the VirtualMachineInstance instead of regular memory.
 type Hugepages struct {
 	// PageSize specifies the hugepage size, for x86_64 architecture valid values are 1Gi and 2Mi.
@@ -570,6 +609,12 @@ type Devices struct {
 	// Whether to attach the VSOCK CID to the VM or not.
 	// VSOCK access will be available if set to true. Defaults to false.
 	AutoattachVSOCK *bool `json:"autoattachVSOCK,omitempty"`
+	// VSOCKServices are the named guest services reachable over VSOCK.
+	// Requires autoattachVSOCK. The ports which are not set are allocated
+	// when the VMI is scheduled and reported in status.vsockServices.
+	// +optional
+	// +listType=atomic
+	VSOCKServices []VSOCKService `json:"vsockServices,omitempty"`
 	// Whether to have random number generator from host
 	// +optional
 	Rng *Rng `json:"rng,omitempty"`
@@ -632,11 +677,24 @@ const (
 type SoundDevice struct {
 	// User's defined name for this sound device
 	Name string `json:"name"`
-	// We only support ich9 or ac97.
+	// We only support ich9, ac97 or virtio.
 	// If SoundDevice is not set: No sound card is emulated.
 	// If SoundDevice is set but Model is not: ich9
 	// +optional
 	Model string `json:"model,omitempty"`
+	// Stream forwards the audio output of the sound device through the VNC connection.
+	// If Stream is not set the audio output is discarded.
+	// +optional
+	Stream *SoundStream `json:"stream,omitempty"`
+}
+
+// SoundStream configures forwarding the audio output through the graphics connection.
+type SoundStream struct {
+	// MaxBandwidth caps the bandwidth of the uncompressed audio stream per second.
+	// The sample rate and the number of channels are lowered until the stream fits.
+	// Defaults to 44.1kHz 16 bit stereo, around 172Ki per second.
+	// +optional
+	MaxBandwidth *resource.Quantity `json:"maxBandwidth,omitempty"`
 }
 
 type TPMDevice struct {
@@ -771,6 +829,11 @@ type Disk struct {
 	// Serial provides the ability to specify a serial number for the disk device.
 	// +optional
 	Serial string `json:"serial,omitempty"`
+	// WWN provides the ability to specify a World Wide Name for the disk device.
+	// It must consist of 16 hexadecimal digits and is only supported for disks
+	// and CD-ROMs on the sata and scsi buses.
+	// +optional
+	WWN string `json:"wwn,omitempty"`
 	// dedicatedIOThread indicates this disk should have an exclusive IO Thread.
 	// Enabling this implies useIOThreads = true.
 	// Defaults to false.
@@ -800,6 +863,10 @@ type Disk struct {
 	// If specified, it can change the default error policy (stop) for the disk
 	// +optional
 	ErrorPolicy *DiskErrorPolicy `json:"errorPolicy,omitempty"`
+	// If specified, it can change the error policy applied to read errors on the disk.
+	// Defaults to the errorPolicy. Supported values are stop, report and ignore.
+	// +optional
+	ReadErrorPolicy *DiskErrorPolicy `json:"readErrorPolicy,omitempty"`
 	// ChangedBlockTracking indicates this disk should have CBT option
 	// Defaults to false.
 	// +optional
@@ -1041,6 +1108,9 @@ type MemoryDumpVolumeSource struct {
 	// Directly attached to the virt launcher
 	// +optional
 	PersistentVolumeClaimVolumeSource `json:",inline"`
+	// Format is the format the memory is dumped in, defaults to Raw.
+	// +optional
+	Format MemoryDumpFormat `json:"format,omitempty"`
 }
 
 type EphemeralVolumeSource struct {
@@ -1049,6 +1119,12 @@ type EphemeralVolumeSource struct {
 	// More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
 	// +optional
 	PersistentVolumeClaim *v1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+	// OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which
+	// keeps the writable overlay of the disk across restarts of the vmi.
+	// If not set, the overlay is transient and discarded when the vmi stops.
+	// The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.
+	// +optional
+	OverlayClaimName string `json:"overlayClaimName,omitempty"`
 }
 
 // EmptyDisk represents a temporary disk which shares the vmis lifecycle.
@@ -1510,6 +1586,17 @@ type DHCPOptions struct {
 	// If specified will pass extra DHCP options for private use, range: 224-254
 	// +optional
 	PrivateOptions []DHCPPrivateOptions `json:"privateOptions,omitempty"`
+	// If specified will pass the configured domain name to the VM via DHCP option 015,
+	// instead of the one derived from the pod search domains.
+	// +optional
+	DomainName string `json:"domainName,omitempty"`
+	// If specified the configured DNS search suffixes are passed to the VM via DHCP option 119,
+	// ahead of the search domains inherited from the pod.
+	// +optional
+	DomainSearch []string `json:"domainSearch,omitempty"`
+	// If specified will pass NetBIOS over TCP/IP (WINS) settings to the VM via DHCP options 044, 046 and 047.
+	// +optional
+	NetBIOS *DHCPNetBIOSOptions `json:"netBIOS,omitempty"`
 }
 
 func (d *DHCPOptions) UnmarshalJSON(data []byte) error {
@@ -1526,10 +1613,46 @@ func (d *DHCPOptions) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if dhcpOptionsAlias.NetBIOS != nil {
+		for i, nameServer := range dhcpOptionsAlias.NetBIOS.NameServers {
+			if sanitizedIP, err := sanitizeIP(nameServer); err == nil {
+				dhcpOptionsAlias.NetBIOS.NameServers[i] = sanitizedIP
+			}
+		}
+	}
+
 	*d = DHCPOptions(dhcpOptionsAlias)
 	return nil
 }
 
+// NetBIOSNodeType is the NetBIOS over TCP/IP node type, as defined by RFC 1001/1002.
+type NetBIOSNodeType string
+
+const (
+	// NetBIOSNodeTypeBroadcast resolves names using broadcasts only (B-node).
+	NetBIOSNodeTypeBroadcast NetBIOSNodeType = "B"
+	// NetBIOSNodeTypePeer resolves names using the name servers only (P-node).
+	NetBIOSNodeTypePeer NetBIOSNodeType = "P"
+	// NetBIOSNodeTypeMixed tries broadcasts first, then the name servers (M-node).
+	NetBIOSNodeTypeMixed NetBIOSNodeType = "M"
+	// NetBIOSNodeTypeHybrid tries the name servers first, then broadcasts (H-node).
+	NetBIOSNodeTypeHybrid NetBIOSNodeType = "H"
+)
+
+// DHCPNetBIOSOptions defines the NetBIOS over TCP/IP settings passed to the VM.
+type DHCPNetBIOSOptions struct {
+	// If specified will pass the configured NetBIOS name servers (WINS) to the VM via DHCP option 044.
+	// +optional
+	NameServers []string `json:"nameServers,omitempty"`
+	// If specified will pass the NetBIOS node type to the VM via DHCP option 046.
+	// Allowed values are B, P, M and H.
+	// +optional
+	NodeType NetBIOSNodeType `json:"nodeType,omitempty"`
+	// If specified will pass the NetBIOS scope to the VM via DHCP option 047.
+	// +optional
+	Scope string `json:"scope,omitempty"`
+}
+
 // DHCPExtraOptions defines Extra DHCP options for a VM.
 type DHCPPrivateOptions struct {
 	// Option is an Integer value from 224-254
@@ -1773,6 +1896,16 @@ func (podNet *PodNetwork) UnmarshalJSON(data []byte) error {
 type Rng struct {
 }
 
+// VSOCKService is a named guest service listening on a VSOCK port
+type VSOCKService struct {
+	// Name identifies the service within the VMI
+	Name string `json:"name"`
+	// Port the guest service listens on.
+	// A free port is allocated when it is not set.
+	// +optional
+	Port *uint32 `json:"port,omitempty"`
+}
+
 // Represents the multus cni network.
 type MultusNetwork struct {
 	// References to a NetworkAttachmentDefinition CRD object. Format:
@@ -177,6 +177,15 @@ func (CPU) SwaggerDoc() map[string]string {
 		"numa":                  "NUMA allows specifying settings for the guest NUMA topology\n+optional",
 		"isolateEmulatorThread": "IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place\nthe emulator thread on it.\n+optional",
 		"realtime":              "Realtime instructs the virt-launcher to tune the VMI for lower latency, optional for real time workloads\n+optional",
+		"threadShares":          "ThreadShares weights the CPU time of the emulator and IO threads against the vCPU threads\nof the VMI. Only applies to VMIs without dedicated CPUs.\n+optional",
+	}
+}
+
+func (CPUThreadShares) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "CPUThreadShares holds the relative CPU shares of the emulator and IO threads of a VMI.\nThe values follow the semantics of the cgroup v1 cpu.shares, where every vCPU thread\nhas 1024 shares, and are converted to cpu.weight on cgroup v2 hosts.",
+		"emulator":  "Emulator is the CPU shares of the emulator threads.\nThreads are left in the cgroup of the VMI if not set.\n+kubebuilder:validation:Minimum=2\n+kubebuilder:validation:Maximum=262144\n+optional",
+		"ioThreads": "IOThreads is the CPU shares of the IO threads.\nThreads are left in the cgroup of the VMI if not set.\n+kubebuilder:validation:Minimum=2\n+kubebuilder:validation:Maximum=262144\n+optional",
 	}
 }
 
@@ -226,6 +235,16 @@ func (MemoryStatus) SwaggerDoc() map[string]string {
 	}
 }
 
+func (LiveUpdatedResources) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "LiveUpdatedResources describes the guest CPU and memory resources which were\napplied to a running VirtualMachineInstance without a restart.",
+		"sockets":  "Sockets is the number of CPU sockets applied by the last CPU hotplug.\n+optional",
+		"guest":    "Guest is the guest memory applied by the last memory hotplug.\n+optional",
+		"requests": "Requests are the CPU and memory requests applied together with the hotplugs.\n+optional",
+		"limits":   "Limits are the CPU and memory limits applied together with the hotplugs.\n+optional",
+	}
+}
+
 func (Hugepages) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "Hugepages allow to use hugepages for the VirtualMachineInstance instead of regular memory.",
@@ -278,6 +297,7 @@ func (Devices) SwaggerDoc() map[string]string {
 		"autoattachMemBalloon":       "Whether to attach the Memory balloon device with default period.\nPeriod can be adjusted in virt-config.\nDefaults to true.\n+optional",
 		"autoattachInputDevice":      "Whether to attach an Input Device.\nDefaults to false.\n+optional",
 		"autoattachVSOCK":            "Whether to attach the VSOCK CID to the VM or not.\nVSOCK access will be available if set to true. Defaults to false.",
+		"vsockServices":              "VSOCKServices are the named guest services reachable over VSOCK.\nRequires autoattachVSOCK. The ports which are not set are allocated\nwhen the VMI is scheduled and reported in status.vsockServices.\n+optional\n+listType=atomic",
 		"rng":                        "Whether to have random number generator from host\n+optional",
 		"blockMultiQueue":            "Whether or not to enable virtio multi-queue for block devices.\nDefaults to false.\n+optional",
 		"networkInterfaceMultiqueue": "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.\n+optional",
@@ -301,9 +321,17 @@ func (ClientPassthroughDevices) SwaggerDoc() map[string]string {
 
 func (SoundDevice) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":      "Represents the user's configuration to emulate sound cards in the VMI.",
-		"name":  "User's defined name for this sound device",
-		"model": "We only support ich9 or ac97.\nIf SoundDevice is not set: No sound card is emulated.\nIf SoundDevice is set but Model is not: ich9\n+optional",
+		"":       "Represents the user's configuration to emulate sound cards in the VMI.",
+		"name":   "User's defined name for this sound device",
+		"model":  "We only support ich9, ac97 or virtio.\nIf SoundDevice is not set: No sound card is emulated.\nIf SoundDevice is set but Model is not: ich9\n+optional",
+		"stream": "Stream forwards the audio output of the sound device through the VNC connection.\nIf Stream is not set the audio output is discarded.\n+optional",
+	}
+}
+
+func (SoundStream) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "SoundStream configures forwarding the audio output through the graphics connection.",
+		"maxBandwidth": "MaxBandwidth caps the bandwidth of the uncompressed audio stream per second.\nThe sample rate and the number of channels are lowered until the stream fits.\nDefaults to 44.1kHz 16 bit stereo, around 172Ki per second.\n+optional",
 	}
 }
 
@@ -387,6 +415,7 @@ func (Disk) SwaggerDoc() map[string]string {
 		"name":                 "Name is the device name",
 		"bootOrder":            "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach disk or interface that has a boot order must have a unique value.\nDisks without a boot order are not tried if a disk with a boot order exists.\n+optional",
 		"serial":               "Serial provides the ability to specify a serial number for the disk device.\n+optional",
+		"wwn":                  "WWN provides the ability to specify a World Wide Name for the disk device.\nIt must consist of 16 hexadecimal digits and is only supported for disks\nand CD-ROMs on the sata and scsi buses.\n+optional",
 		"dedicatedIOThread":    "dedicatedIOThread indicates this disk should have an exclusive IO Thread.\nEnabling this implies useIOThreads = true.\nDefaults to false.\n+optional",
 		"cache":                "Cache specifies which kvm disk cache mode should be used.\nSupported values are:\nnone: Guest I/O not cached on the host, but may be kept in a disk cache.\nwritethrough: Guest I/O cached on the host but written through to the physical medium. Slowest but with most guarantees.\nwriteback: Guest I/O cached on the host.\nDefaults to none if the storage supports O_DIRECT, otherwise writethrough.\n+optional",
 		"io":                   "IO specifies which QEMU disk IO mode should be used.\nSupported values are: native, default, threads.\n+optional",
@@ -394,6 +423,7 @@ func (Disk) SwaggerDoc() map[string]string {
 		"blockSize":            "If specified, the virtual disk will be presented with the given block sizes.\n+optional",
 		"shareable":            "If specified the disk is made sharable and multiple write from different VMs are permitted\n+optional",
 		"errorPolicy":          "If specified, it can change the default error policy (stop) for the disk\n+optional",
+		"readErrorPolicy":      "If specified, it can change the error policy applied to read errors on the disk.\nDefaults to the errorPolicy. Supported values are stop, report and ignore.\n+optional",
 		"changedBlockTracking": "ChangedBlockTracking indicates this disk should have CBT option\nDefaults to false.\n+optional",
 	}
 }
@@ -530,12 +560,15 @@ func (PersistentVolumeClaimVolumeSource) SwaggerDoc() map[string]string {
 }
 
 func (MemoryDumpVolumeSource) SwaggerDoc() map[string]string {
-	return map[string]string{}
+	return map[string]string{
+		"format": "Format is the format the memory is dumped in, defaults to Raw.\n+optional",
+	}
 }
 
 func (EphemeralVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"persistentVolumeClaim": "PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.\nDirectly attached to the vmi via qemu.\nMore info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims\n+optional",
+		"overlayClaimName":      "OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which\nkeeps the writable overlay of the disk across restarts of the vmi.\nIf not set, the overlay is transient and discarded when the vmi stops.\nThe base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.\n+optional",
 	}
 }
 
@@ -764,6 +797,18 @@ func (DHCPOptions) SwaggerDoc() map[string]string {
 		"tftpServerName": "If specified will pass option 66 to interface's DHCP server\n+optional",
 		"ntpServers":     "If specified will pass the configured NTP server to the VM via DHCP option 042.\n+optional",
 		"privateOptions": "If specified will pass extra DHCP options for private use, range: 224-254\n+optional",
+		"domainName":     "If specified will pass the configured domain name to the VM via DHCP option 015,\ninstead of the one derived from the pod search domains.\n+optional",
+		"domainSearch":   "If specified the configured DNS search suffixes are passed to the VM via DHCP option 119,\nahead of the search domains inherited from the pod.\n+optional",
+		"netBIOS":        "If specified will pass NetBIOS over TCP/IP (WINS) settings to the VM via DHCP options 044, 046 and 047.\n+optional",
+	}
+}
+
+func (DHCPNetBIOSOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "DHCPNetBIOSOptions defines the NetBIOS over TCP/IP settings passed to the VM.",
+		"nameServers": "If specified will pass the configured NetBIOS name servers (WINS) to the VM via DHCP option 044.\n+optional",
+		"nodeType":    "If specified will pass the NetBIOS node type to the VM via DHCP option 046.\nAllowed values are B, P, M and H.\n+optional",
+		"scope":       "If specified will pass the NetBIOS scope to the VM via DHCP option 047.\n+optional",
 	}
 }
 
@@ -954,6 +999,14 @@ func (Rng) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VSOCKService) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "VSOCKService is a named guest service listening on a VSOCK port",
+		"name": "Name identifies the service within the VMI",
+		"port": "Port the guest service listens on.\nA free port is allocated when it is not set.\n+optional",
+	}
+}
+
 func (MultusNetwork) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":            "Represents the multus cni network.",
@@ -30,6 +30,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -288,6 +289,12 @@ type VirtualMachineInstanceStatus struct {
 	// +optional
 	FSFreezeStatus string `json:"fsFreezeStatus,omitempty"`
 
+	// SSHHostKeys contains the SSH host public keys reported by the guest agent,
+	// in the "<key type> <base64 key>" format used by known_hosts files.
+	// +optional
+	// +listType=atomic
+	SSHHostKeys []string `json:"sshHostKeys,omitempty"`
+
 	// +optional
 	TopologyHints *TopologyHints `json:"topologyHints,omitempty"`
 
@@ -304,6 +311,11 @@ type VirtualMachineInstanceStatus struct {
 	// +optional
 	VSOCKCID *uint32 `json:"VSOCKCID,omitempty"`
 
+	// VSOCKServices reports the VSOCK port of every service of spec.domain.devices.vsockServices
+	// +optional
+	// +listType=atomic
+	VSOCKServices []VSOCKServiceStatus `json:"vsockServices,omitempty"`
+
 	// SELinuxContext is the actual SELinux context of the virt-launcher pod
 	// +optional
 	SelinuxContext string `json:"selinuxContext,omitempty"`
@@ -321,6 +333,15 @@ type VirtualMachineInstanceStatus struct {
 	// +optional
 	Memory *MemoryStatus `json:"memory,omitempty"`
 
+	// LiveUpdatedResources records the guest CPU and memory resources applied
+	// to the VMI by live updates since it was started.
+	// +optional
+	LiveUpdatedResources *LiveUpdatedResources `json:"liveUpdatedResources,omitempty"`
+
+	// CPUThreadShares reports the CPU shares applied to the emulator and IO threads of the VMI.
+	// +optional
+	CPUThreadShares *CPUThreadShares `json:"cpuThreadShares,omitempty"`
+
 	// MigratedVolumes lists the source and destination volumes during the volume migration
 	// +listType=atomic
 	// +optional
@@ -336,6 +357,36 @@ type VirtualMachineInstanceStatus struct {
 	// +nullable
 	// +optional
 	ChangedBlockTracking *ChangedBlockTrackingStatus `json:"changedBlockTracking,omitempty" optional:"true"`
+
+	// BootTimes records when the VirtualMachineInstance reached the stages of its start.
+	// +optional
+	BootTimes *VirtualMachineInstanceBootTimes `json:"bootTimes,omitempty"`
+}
+
+// VirtualMachineInstanceBootTimes records when a VirtualMachineInstance reached the stages of its start.
+// Each time is recorded once, the difference between two consecutive times is the duration of a stage.
+type VirtualMachineInstanceBootTimes struct {
+	// PodCreationTime is when the virt-launcher pod was created, once the volumes were bound and populated.
+	// +optional
+	PodCreationTime *metav1.Time `json:"podCreationTime,omitempty"`
+	// PodScheduledTime is when the virt-launcher pod was scheduled to a node.
+	// +optional
+	PodScheduledTime *metav1.Time `json:"podScheduledTime,omitempty"`
+	// LauncherStartTime is when the compute container of the virt-launcher pod started, once its images were pulled.
+	// +optional
+	LauncherStartTime *metav1.Time `json:"launcherStartTime,omitempty"`
+	// DomainStartTime is when the domain started running and the firmware took over.
+	// +optional
+	DomainStartTime *metav1.Time `json:"domainStartTime,omitempty"`
+	// GuestAgentConnectTime is when the guest agent connected, once the guest OS booted.
+	// +optional
+	GuestAgentConnectTime *metav1.Time `json:"guestAgentConnectTime,omitempty"`
+}
+
+// VSOCKServiceStatus reports the VSOCK port of a named guest service
+type VSOCKServiceStatus struct {
+	Name string `json:"name"`
+	Port uint32 `json:"port"`
 }
 
 // DeviceStatus has the information of all devices allocated spec.domain.devices
@@ -707,6 +758,10 @@ const (
 	// If the VMI was paused by the user, this is reported as true.
 	VirtualMachineInstancePaused VirtualMachineInstanceConditionType = "Paused"
 
+	// If the VMI was paused by the hypervisor because of an I/O error on one of its disks, this is reported as true.
+	// The VMI is resumed automatically once the storage backend recovers.
+	VirtualMachineInstancePausedOnIOError VirtualMachineInstanceConditionType = "PausedOnIOError"
+
 	// Reflects whether the QEMU guest agent is connected through the channel
 	VirtualMachineInstanceAgentConnected VirtualMachineInstanceConditionType = "AgentConnected"
 
@@ -742,6 +797,17 @@ const (
 
 	// VirtualMachineInstanceEvictionRequested indicates that an eviction has been requested for the VMI
 	VirtualMachineInstanceEvictionRequested VirtualMachineInstanceConditionType = "EvictionRequested"
+
+	// VirtualMachineInstanceNetworkGatewaysReachable indicates whether the gateways of the probed secondary networks answer
+	VirtualMachineInstanceNetworkGatewaysReachable VirtualMachineInstanceConditionType = "NetworkGatewaysReachable"
+
+	// VirtualMachineInstanceAlternativeAgentConnected indicates that an alternative guest agent answered
+	// over VSOCK. The reason holds the name of the agent and the message the negotiated capabilities.
+	VirtualMachineInstanceAlternativeAgentConnected VirtualMachineInstanceConditionType = "AlternativeAgentConnected"
+
+	// VirtualMachineInstanceGuestQuiesced indicates that the guest filesystems were flushed and frozen
+	// through the guest agent before the VMI was paused. The filesystems are thawed on unpause.
+	VirtualMachineInstanceGuestQuiesced VirtualMachineInstanceConditionType = "GuestQuiesced"
 )
 
 // These are valid reasons for VMI conditions.
@@ -789,6 +855,11 @@ const (
 
 	// Indicates that an eviction has been requested for the VMI
 	VirtualMachineInstanceReasonEvictionRequested = "EvictionRequested"
+
+	// Reason means that the gateways of all probed secondary networks answer
+	VirtualMachineInstanceReasonGatewaysReachable = "GatewaysReachable"
+	// Reason means that the gateway of at least one probed secondary network stopped answering
+	VirtualMachineInstanceReasonGatewayUnreachable = "GatewayUnreachable"
 )
 
 const (
@@ -823,6 +894,8 @@ const (
 	VirtualMachineInstanceDecentralizedMigrationBlocked VirtualMachineInstanceMigrationConditionType = "decentralizedMigrationBlocked"
 	// VirtualMachineInstanceMigrationBlockedByBackup indicates that migration is waiting for backup to complete or abort
 	VirtualMachineInstanceMigrationBlockedByBackup VirtualMachineInstanceMigrationConditionType = "migrationBlockedByBackup"
+	// VirtualMachineInstanceMigrationBlockedByCPUFeatures indicates that no node satisfies the CPU model and CPU feature policies of the VMI
+	VirtualMachineInstanceMigrationBlockedByCPUFeatures VirtualMachineInstanceMigrationConditionType = "migrationBlockedByCPUFeatures"
 )
 
 type VirtualMachineInstanceCondition struct {
@@ -1033,6 +1106,9 @@ type VirtualMachineInstanceMigrationState struct {
 	MigrationUID types.UID `json:"migrationUid,omitempty"`
 	// Lets us know if the vmi is currently running pre or post copy migration
 	Mode MigrationMode `json:"mode,omitempty"`
+	// TransferStatus reports the progress of the data transfer as last seen by the source
+	// +optional
+	TransferStatus *VirtualMachineInstanceMigrationTransferStatus `json:"transferStatus,omitempty"`
 	// Name of the migration policy. If string is empty, no policy is matched
 	MigrationPolicyName *string `json:"migrationPolicyName,omitempty"`
 	// Migration configurations to apply
@@ -1060,6 +1136,24 @@ type VirtualMachineInstanceMigrationState struct {
 	TargetMemoryOverhead *resource.Quantity `json:"targetMemoryOverhead,omitempty"`
 }
 
+// VirtualMachineInstanceMigrationTransferStatus holds the progress of the memory and disk transfer of a live migration
+type VirtualMachineInstanceMigrationTransferStatus struct {
+	// The amount of data already transferred, in bytes
+	DataProcessedBytes int64 `json:"dataProcessedBytes,omitempty"`
+	// The amount of data still to be transferred, in bytes
+	DataRemainingBytes int64 `json:"dataRemainingBytes,omitempty"`
+	// The total amount of data to transfer, in bytes
+	DataTotalBytes int64 `json:"dataTotalBytes,omitempty"`
+	// The rate at which the guest dirties its memory, in bytes per second
+	MemoryDirtyRateBytesPerSecond int64 `json:"memoryDirtyRateBytesPerSecond,omitempty"`
+	// The bandwidth used to transfer the memory, in bytes per second
+	MemoryBandwidthBytesPerSecond int64 `json:"memoryBandwidthBytesPerSecond,omitempty"`
+	// The number of memory transfer iterations completed so far
+	MemoryIteration int64 `json:"memoryIteration,omitempty"`
+	// The time at which the transfer status was last updated
+	LastUpdateTimestamp *metav1.Time `json:"lastUpdateTimestamp,omitempty"`
+}
+
 type MigrationAbortStatus string
 
 const (
@@ -1189,6 +1283,10 @@ const (
 	// This label declares whether a particular node is available for
 	// scheduling virtual machine instances on it. Used on Node.
 	NodeSchedulable string = "kubevirt.io/schedulable"
+	// This label is set by virt-controller on nodes whose free capacity is below the
+	// migration headroom. New virtual machine instances are not scheduled on such nodes,
+	// migration targets are. Used on Node.
+	NodeMigrationHeadroomExhaustedLabel string = "kubevirt.io/migration-headroom-exhausted"
 	// This annotation is regularly updated by virt-handler to help determine
 	// if a particular node is alive and hence should be available for new
 	// virtual machine instance scheduling. Used on Node.
@@ -1250,6 +1348,10 @@ const (
 	VirtualMachineInstanceMigrationFinalizer string = "kubevirt.io/migrationJobFinalize"
 	DeprecatedCPUManager                     string = "cpumanager"
 	CPUManager                               string = "kubevirt.io/cpumanager"
+
+	// Set by the volume protection controller on PVCs and DataVolumes which are used by a VMI
+	VolumeProtectionFinalizer string = "kubevirt.io/volume-protection"
+
 	// This annotation is used to inject ignition data
 	// Used on VirtualMachineInstance.
 	IgnitionAnnotation           string = "kubevirt.io/ignitiondata"
@@ -1429,6 +1531,37 @@ const (
 	// Even if the VM is halted
 	ImmediateDataVolumeCreation string = "kubevirt.io/immediate-data-volume-creation"
 
+	// ClusterRecoveryPriorityAnnotation sets the priority tier of a VirtualMachine when
+	// VirtualMachines are started after a cluster-wide outage. VirtualMachines with a
+	// higher priority are started first. Defaults to 0.
+	ClusterRecoveryPriorityAnnotation string = "kubevirt.io/cluster-recovery-priority"
+
+	// MinAvailableCapacityAnnotation sets on a StorageClass the capacity, as reported by its
+	// CSIStorageCapacity objects, which has to remain available for VirtualMachines with volumes
+	// of this StorageClass to be started. Requires the StorageCapacityAwareness feature gate.
+	MinAvailableCapacityAnnotation string = "storageclass.kubevirt.io/min-available-capacity"
+
+	// IgnoreStorageCapacityAnnotation allows a VirtualMachine to be started even if the
+	// available capacity of the StorageClass of one of its volumes is below its minimum.
+	IgnoreStorageCapacityAnnotation string = "kubevirt.io/ignore-storage-capacity"
+
+	// GatewayRouteLabel marks a Service to be published through a Gateway API route.
+	// The value selects the kind of the route, either "http" or "tcp".
+	// Requires the GatewayAPIExpose feature gate.
+	GatewayRouteLabel string = "kubevirt.io/gateway-route"
+
+	// GatewayParentAnnotation references the Gateway, as [namespace/]name, to which the
+	// route of a Service labeled with GatewayRouteLabel is attached.
+	GatewayParentAnnotation string = "kubevirt.io/gateway-parent"
+
+	// GatewayHostnamesAnnotation holds a comma separated list of hostnames matched by the
+	// HTTPRoute of a Service labeled with GatewayRouteLabel.
+	GatewayHostnamesAnnotation string = "kubevirt.io/gateway-hostnames"
+
+	// RenamedFromAnnotation holds the UID of the VirtualMachine a VirtualMachine was renamed from,
+	// so that an interrupted rename can be resumed.
+	RenamedFromAnnotation string = "kubevirt.io/renamed-from"
+
 	// DisablePCIHole64 indicates that the 64-Bit PCI hole should be disabled on a VirtualMachineInstance.
 	// This annotation might be deprecated in the future if we decided to add a struct for it.
 	DisablePCIHole64 string = "kubevirt.io/disablePCIHole64"
@@ -1442,6 +1575,25 @@ const (
 	// This annotation is set by virt-handler based on the cluster configuration.
 	QGSSocketPathAnnotation = "kubevirt.io/qgs-socket-path"
 
+	// LocalScratchCapacityAnnotation requests the ephemeral disks of a VirtualMachineInstance, such as
+	// the containerDisk overlays, to be placed on the node-local scratch pool. The value is the
+	// capacity to account against the pool, rounded up to whole GiB.
+	LocalScratchCapacityAnnotation = "kubevirt.io/local-scratch-capacity"
+
+	// VolumeInUseByAnnotation is set on a PersistentVolumeClaim or DataVolume whose deletion is
+	// blocked by the VolumeProtectionFinalizer. It lists the VirtualMachines and
+	// VirtualMachineInstances which still use the volume.
+	VolumeInUseByAnnotation = "kubevirt.io/volume-in-use-by"
+
+	// DeletionUnlockAnnotation unlocks the deletion of a VirtualMachine with DeletionProtection.
+	// The value has to match the name of the VirtualMachine, so that copying the annotation
+	// between manifests does not unlock other VirtualMachines.
+	DeletionUnlockAnnotation = "kubevirt.io/deletion-unlock"
+
+	// DeletionUnlockAfterAnnotation unlocks the deletion of a VirtualMachine with DeletionProtection
+	// once the RFC3339 timestamp it holds passed.
+	DeletionUnlockAfterAnnotation = "kubevirt.io/deletion-unlock-after"
+
 	// AllowAccessClusterServicesNPLabel is a pod label to be set by virt-components to indicate that they require
 	// access to cluster services otherwise blocked by the strict network policy (NP).
 	// This label will be applied to the following virt pods:
@@ -1764,6 +1916,11 @@ type VirtualMachineInstanceMigrationSpec struct {
 	// +optional
 	AddedNodeSelector map[string]string `json:"addedNodeSelector,omitempty"`
 
+	// MigrationPolicyName is the name of the MigrationPolicy to apply to this migration.
+	// It takes precedence over the MigrationPolicy matched by the selectors of the policies.
+	// +optional
+	MigrationPolicyName *string `json:"migrationPolicyName,omitempty"`
+
 	// If sendTo is specified, this VirtualMachineInstanceMigration will be considered the source
 	SendTo *VirtualMachineInstanceMigrationSource `json:"sendTo,omitempty"`
 	// If receieve is specified, this VirtualMachineInstanceMigration will be considered the target
@@ -2011,6 +2168,12 @@ type VirtualMachineSpec struct {
 
 	// UpdateVolumesStrategy is the strategy to apply on volumes updates
 	UpdateVolumesStrategy *UpdateVolumesStrategy `json:"updateVolumesStrategy,omitempty"`
+
+	// DeletionProtection rejects the deletion of the VirtualMachine until it is unlocked, either by
+	// setting the kubevirt.io/deletion-unlock annotation to the name of the VirtualMachine, or once
+	// the time set in the kubevirt.io/deletion-unlock-after annotation passed.
+	// +optional
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
 }
 
 // StateChangeRequestType represents the existing state change requests that are possible
@@ -2155,6 +2318,24 @@ type VirtualMachineStatus struct {
 	//+nullable
 	//+optional
 	PreferenceRef *InstancetypeStatusRef `json:"preferenceRef,omitempty"`
+
+	// IPAddresses mirrors the IP addresses reported on the interfaces of the VirtualMachineInstance
+	// +optional
+	// +listType=atomic
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// NodeName mirrors the name of the node the VirtualMachineInstance is running on
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// GuestAgentConnected mirrors whether the guest agent of the VirtualMachineInstance is connected
+	// +optional
+	GuestAgentConnected bool `json:"guestAgentConnected,omitempty"`
+
+	// InstanceStatusLastUpdateTime is the last time ipAddresses, nodeName or guestAgentConnected changed
+	// +nullable
+	// +optional
+	InstanceStatusLastUpdateTime *metav1.Time `json:"instanceStatusLastUpdateTime,omitempty"`
 }
 
 type ControllerRevisionRef struct {
@@ -2315,6 +2496,27 @@ const (
 
 	// VirtualMachineManualRecoveryRequired is added when the VM spec needs to be manually recovered by the user
 	VirtualMachineManualRecoveryRequired VirtualMachineConditionType = "ManualRecoveryRequired"
+
+	// VirtualMachineDegraded is added when one or more of the resources backing the VM are unhealthy.
+	// The reason reflects the first problem found, the message lists all of them.
+	VirtualMachineDegraded VirtualMachineConditionType = "Degraded"
+
+	// VirtualMachineStorageCapacityExhausted is added while the start of the VM is delayed because a
+	// StorageClass of its volumes is below its minimum available capacity
+	VirtualMachineStorageCapacityExhausted VirtualMachineConditionType = "StorageCapacityExhausted"
+)
+
+const (
+	// VirtualMachineDegradedGuestAgentDisconnected is set when a previously connected guest agent is no longer connected
+	VirtualMachineDegradedGuestAgentDisconnected = "GuestAgentDisconnected"
+	// VirtualMachineDegradedPausedOnIOError is set when the VMI was paused by the hypervisor because of an I/O error
+	VirtualMachineDegradedPausedOnIOError = "PausedOnIOError"
+	// VirtualMachineDegradedMigrationFailed is set when the last migration of the VMI failed
+	VirtualMachineDegradedMigrationFailed = "MigrationFailed"
+	// VirtualMachineDegradedHotplugPending is set when volume hotplug or unplug requests were not yet applied to the VMI
+	VirtualMachineDegradedHotplugPending = "HotplugPending"
+	// VirtualMachineDegradedSnapshotStale is set when the snapshot in progress is gone, failed or exceeded its failure deadline
+	VirtualMachineDegradedSnapshotStale = "SnapshotStale"
 )
 
 type HostDiskType string
@@ -2662,6 +2864,46 @@ type KubeVirtStatus struct {
 	// +optional
 	// +listType=atomic
 	SynchronizationAddresses []string `json:"synchronizationAddresses,omitempty" optional:"true"`
+	// ClusterRecovery reports the progress of starting VirtualMachines after a cluster-wide outage.
+	// +optional
+	ClusterRecovery *ClusterRecoveryStatus `json:"clusterRecovery,omitempty" optional:"true"`
+}
+
+// ClusterRecoveryPhase is the phase of the recovery from a cluster-wide outage.
+type ClusterRecoveryPhase string
+
+const (
+	// ClusterRecoveryRecovering means that VirtualMachines are being started in a staggered way
+	ClusterRecoveryRecovering ClusterRecoveryPhase = "Recovering"
+	// ClusterRecoveryCompleted means that all VirtualMachines which were down have been started
+	ClusterRecoveryCompleted ClusterRecoveryPhase = "Completed"
+)
+
+// ClusterRecoveryStatus reports the progress of the staggered start of VirtualMachines after a
+// cluster-wide outage.
+type ClusterRecoveryStatus struct {
+	Phase ClusterRecoveryPhase `json:"phase"`
+	// StartTime is the time at which the outage was detected.
+	// +optional
+	// +nullable
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time at which all VirtualMachines were started.
+	// +optional
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// CurrentPriority is the priority tier which is currently being started.
+	// +optional
+	CurrentPriority *int32 `json:"currentPriority,omitempty"`
+	// VirtualMachines is the number of VirtualMachines with the Always or RerunOnFailure run strategy.
+	VirtualMachines int32 `json:"virtualMachines"`
+	// Running is the number of those VirtualMachines which are running.
+	Running int32 `json:"running"`
+	// Starting is the number of those VirtualMachines which are being started.
+	Starting int32 `json:"starting"`
+	// Pending is the number of those VirtualMachines which are waiting to be started.
+	Pending int32 `json:"pending"`
+	// WaitingForStorage is the number of pending VirtualMachines whose PersistentVolumeClaims are not bound yet.
+	WaitingForStorage int32 `json:"waitingForStorage"`
 }
 
 // KubeVirtPhase is a label for the phase of a KubeVirt deployment at the current time.
@@ -2769,6 +3011,11 @@ type PauseOptions struct {
 	// +optional
 	// +listType=atomic
 	DryRun []string `json:"dryRun,omitempty" protobuf:"bytes,1,rep,name=dryRun"`
+
+	// SyncGuest asks the guest agent to flush and freeze the guest filesystems
+	// before the vCPUs are paused. The filesystems are thawed on unpause.
+	// +optional
+	SyncGuest bool `json:"syncGuest,omitempty"`
 }
 
 // UnpauseOptions may be provided on unpause request.
@@ -2785,6 +3032,26 @@ type UnpauseOptions struct {
 	DryRun []string `json:"dryRun,omitempty" protobuf:"bytes,1,rep,name=dryRun"`
 }
 
+// SoftRebootFallback defines what happens when the guest agent cannot reboot the guest
+type SoftRebootFallback string
+
+const (
+	// SoftRebootFallbackACPI sends an ACPI reboot signal when the guest agent is absent or unresponsive
+	SoftRebootFallbackACPI SoftRebootFallback = "acpi"
+	// SoftRebootFallbackFail fails the soft reboot when the guest agent is absent or unresponsive
+	SoftRebootFallbackFail SoftRebootFallback = "fail"
+)
+
+// SoftRebootOptions may be provided on soft reboot request.
+type SoftRebootOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Fallback defines what happens when the guest agent is absent or unresponsive.
+	// Defaults to acpi.
+	// +optional
+	Fallback SoftRebootFallback `json:"fallback,omitempty"`
+}
+
 const (
 	StartRequestDataPausedKey  string = "paused"
 	StartRequestDataPausedTrue string = "true"
@@ -2827,6 +3094,121 @@ type MigrateOptions struct {
 	// can only restrict but not bypass constraints already set on the VM object.
 	// +optional
 	AddedNodeSelector map[string]string `json:"addedNodeSelector,omitempty"`
+
+	// MigrationPolicyName is the name of the MigrationPolicy to apply to the migration
+	// instead of the MigrationPolicy matched by the selectors of the policies.
+	// +optional
+	MigrationPolicyName *string `json:"migrationPolicyName,omitempty"`
+}
+
+// DryRunOperation is the operation a dry-run request would apply to an object.
+type DryRunOperation string
+
+const (
+	DryRunOperationPatch       DryRunOperation = "Patch"
+	DryRunOperationPatchStatus DryRunOperation = "PatchStatus"
+	DryRunOperationCreate      DryRunOperation = "Create"
+	DryRunOperationDelete      DryRunOperation = "Delete"
+)
+
+// DryRunResult is returned by the start, stop, restart, migrate, addvolume and removevolume
+// subresources for dry-run requests. The request passed the validation of the subresource
+// and of the API server, and the listed changes would have been applied.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DryRunResult struct {
+	metav1.TypeMeta `json:",inline"`
+	// Changes lists the changes the request would apply, in the order in which they would be applied.
+	// +optional
+	// +listType=atomic
+	Changes []DryRunChange `json:"changes,omitempty"`
+}
+
+// DryRunChange describes a change a dry-run request would apply to an object.
+type DryRunChange struct {
+	// Kind of the object which would be changed.
+	Kind string `json:"kind"`
+	// Name of the object which would be changed. Empty for objects created with a generated name.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Operation which would be applied to the object.
+	Operation DryRunOperation `json:"operation"`
+	// Patch is the JSON patch which would be applied by the Patch and PatchStatus operations.
+	// +optional
+	Patch string `json:"patch,omitempty"`
+	// Object is the object returned by the API server for the dry-run request, including
+	// the mutations of admission webhooks. It is not set for the Delete operation.
+	// +optional
+	Object *runtime.RawExtension `json:"object,omitempty"`
+}
+
+// BulkLifecycleAction is the lifecycle action a bulk lifecycle request applies to VirtualMachines.
+type BulkLifecycleAction string
+
+const (
+	BulkLifecycleActionStart   BulkLifecycleAction = "start"
+	BulkLifecycleActionStop    BulkLifecycleAction = "stop"
+	BulkLifecycleActionRestart BulkLifecycleAction = "restart"
+)
+
+// BulkLifecycleOptions are provided on bulk lifecycle request.
+// The VirtualMachines of the namespace are either selected by their names or by a label selector.
+type BulkLifecycleOptions struct {
+	metav1.TypeMeta `json:",inline"`
+	// Action is the lifecycle action applied to every selected VirtualMachine: start, stop or restart.
+	Action BulkLifecycleAction `json:"action"`
+	// Names of the VirtualMachines the action is applied to.
+	// +optional
+	// +listType=atomic
+	Names []string `json:"names,omitempty"`
+	// LabelSelector selects the VirtualMachines the action is applied to by their labels.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// StartOptions are used for every VirtualMachine when the action is start.
+	// +optional
+	StartOptions *StartOptions `json:"startOptions,omitempty"`
+	// StopOptions are used for every VirtualMachine when the action is stop.
+	// +optional
+	StopOptions *StopOptions `json:"stopOptions,omitempty"`
+	// RestartOptions are used for every VirtualMachine when the action is restart.
+	// +optional
+	RestartOptions *RestartOptions `json:"restartOptions,omitempty"`
+	// When present, indicates that modifications should not be
+	// persisted. It replaces the dryRun of the options of the action.
+	// Valid values are:
+	// - All: all dry run stages will be processed
+	// +optional
+	// +listType=atomic
+	DryRun []string `json:"dryRun,omitempty"`
+}
+
+// BulkLifecycleResult is returned by the bulk lifecycle subresource.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BulkLifecycleResult struct {
+	metav1.TypeMeta `json:",inline"`
+	// Results lists the outcome of the action for every selected VirtualMachine, ordered by name.
+	// +optional
+	// +listType=atomic
+	Results []BulkLifecycleVirtualMachineResult `json:"results,omitempty"`
+}
+
+// BulkLifecycleVirtualMachineResult is the outcome of a bulk lifecycle action for a single VirtualMachine.
+type BulkLifecycleVirtualMachineResult struct {
+	// Name of the VirtualMachine.
+	Name string `json:"name"`
+	// Code is the HTTP status code the single lifecycle request would have returned.
+	Code int32 `json:"code"`
+	// Reason is the machine-readable reason of a failure.
+	// +optional
+	Reason metav1.StatusReason `json:"reason,omitempty"`
+	// Message is the human-readable description of a failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Changes lists the changes the action would apply for dry-run requests.
+	// +optional
+	// +listType=atomic
+	Changes []DryRunChange `json:"changes,omitempty"`
 }
 
 // EvacuateCancelOptions may be provided on evacuate cancel request.
@@ -2844,6 +3226,13 @@ type EvacuateCancelOptions struct {
 	EvacuationNodeName string `json:"evacuationNodeName"`
 }
 
+// RenameOptions are provided on rename request.
+type RenameOptions struct {
+	metav1.TypeMeta `json:",inline"`
+	// NewName is the name the VirtualMachine is renamed to.
+	NewName string `json:"newName"`
+}
+
 // VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -2924,9 +3313,43 @@ type VirtualMachineInstanceFileSystem struct {
 	Disk           []VirtualMachineInstanceFileSystemDisk `json:"disk,omitempty"`
 }
 
+// VirtualMachineInstanceSummaryList is a page of VirtualMachineInstance summaries served from the cache of virt-api
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineInstanceSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// Total is the number of VirtualMachineInstances matching the request across all pages
+	Total int `json:"total"`
+	// PhaseCounts is the number of VirtualMachineInstances matching the request per phase across all pages
+	// +optional
+	PhaseCounts map[VirtualMachineInstancePhase]int `json:"phaseCounts,omitempty"`
+	Items       []VirtualMachineInstanceSummary     `json:"items"`
+}
+
+// VirtualMachineInstanceSummary is a condensed view of a VirtualMachineInstance
+type VirtualMachineInstanceSummary struct {
+	Name              string                      `json:"name"`
+	Namespace         string                      `json:"namespace"`
+	CreationTimestamp metav1.Time                 `json:"creationTimestamp"`
+	Phase             VirtualMachineInstancePhase `json:"phase,omitempty"`
+	NodeName          string                      `json:"nodeName,omitempty"`
+	// Ready reflects the Ready condition of the VirtualMachineInstance
+	Ready bool `json:"ready"`
+	// IPAddress is the first IP address reported for the VirtualMachineInstance
+	// +optional
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
 // FreezeUnfreezeTimeout represent the time unfreeze will be triggered if guest was not unfrozen by unfreeze command
 type FreezeUnfreezeTimeout struct {
 	UnfreezeTimeout *metav1.Duration `json:"unfreezeTimeout"`
+	// Mountpoints restricts the freeze to the given guest mount points.
+	// When empty, all freezable guest filesystems are frozen.
+	// Requires a guest agent supporting guest-fsfreeze-freeze-list.
+	// +optional
+	// +listType=atomic
+	Mountpoints []string `json:"mountpoints,omitempty"`
 }
 
 // VirtualMachineMemoryDumpRequest represent the memory dump request phase and info
@@ -2950,6 +3373,9 @@ type VirtualMachineMemoryDumpRequest struct {
 	// Message is a detailed message about failure of the memory dump
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Format is the format the memory is dumped in, defaults to Raw
+	// +optional
+	Format MemoryDumpFormat `json:"format,omitempty"`
 }
 
 type MemoryDumpPhase string
@@ -2969,6 +3395,16 @@ const (
 	MemoryDumpFailed MemoryDumpPhase = "Failed"
 )
 
+type MemoryDumpFormat string
+
+const (
+	// MemoryDumpFormatRaw dumps the guest memory in the raw core dump format, meant for analysis
+	MemoryDumpFormatRaw MemoryDumpFormat = "Raw"
+	// MemoryDumpFormatSavedState saves the memory state of the vmi in the libvirt save image format,
+	// the vmi can be resumed from it
+	MemoryDumpFormatSavedState MemoryDumpFormat = "SavedState"
+)
+
 // AddVolumeOptions is provided when dynamically hot plugging a volume and disk
 type AddVolumeOptions struct {
 	// Name represents the name that will be used to map the
@@ -3148,6 +3584,171 @@ type KubeVirtConfiguration struct {
 	// +optional
 	// +kubebuilder:validation:Enum=AggregateToDefault;Manual
 	RoleAggregationStrategy *RoleAggregationStrategy `json:"roleAggregationStrategy,omitempty"`
+
+	// VolumePermissionRepairPolicy controls whether virt-handler repairs the ownership
+	// and SELinux labels of filesystem volumes which are not accessible by the VM,
+	// e.g. because the backing storage was moved from another node.
+	// When set to "Disabled" (default) or not specified, mismatches are only reported.
+	// When set to "Ownership", the disk images are chowned to the qemu user.
+	// When set to "OwnershipAndSELinux", the disk images are additionally relabeled.
+	// +optional
+	// +kubebuilder:validation:Enum=Disabled;Ownership;OwnershipAndSELinux
+	VolumePermissionRepairPolicy *VolumePermissionRepairPolicy `json:"volumePermissionRepairPolicy,omitempty"`
+
+	// ClusterRecovery configures how virt-controller staggers the automatic start of
+	// VirtualMachines after a cluster-wide outage. When not specified, VirtualMachines
+	// are started as soon as possible.
+	// +optional
+	ClusterRecovery *ClusterRecoveryConfiguration `json:"clusterRecovery,omitempty"`
+
+	// GuestAgent configures the in-guest agents which can be used when qemu-guest-agent
+	// is not available in the guest.
+	// +optional
+	GuestAgent *GuestAgentConfiguration `json:"guestAgent,omitempty"`
+
+	// SubresourceLimits restricts the console, VNC, usbredir and port-forward streams and the
+	// subresource requests which each virt-api replica accepts per user and per namespace.
+	// Requests exceeding the limits are rejected with 429 Too Many Requests. When not specified,
+	// subresources are not limited.
+	// +optional
+	SubresourceLimits *SubresourceLimitsConfiguration `json:"subresourceLimits,omitempty"`
+
+	// VirtualMachineExport configures the expiration and renewal of VirtualMachineExports.
+	// +optional
+	VirtualMachineExport *VirtualMachineExportConfiguration `json:"virtualMachineExport,omitempty"`
+}
+
+// GuestAgentConfiguration configures alternative in-guest agents.
+type GuestAgentConfiguration struct {
+	// Alternatives lists the agents virt-handler talks to over VSOCK when qemu-guest-agent
+	// is not connected. They are tried in order and the first one answering is used for
+	// IP reporting and filesystem freeze/thaw, depending on the capabilities it announces.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Alternatives []GuestAgentAlternative `json:"alternatives,omitempty"`
+
+	// DataPolicies restrict the guest data collected from the guest agents of the
+	// VirtualMachineInstances per namespace. Redacted data is neither stored in the status of
+	// the VirtualMachineInstances nor returned by their guest agent subresources. When several
+	// policies apply to a namespace, the data redacted by any of them is redacted.
+	// +listType=atomic
+	// +optional
+	DataPolicies []GuestAgentDataPolicy `json:"dataPolicies,omitempty"`
+}
+
+// GuestAgentDataPolicy redacts guest data reported by the guest agents in a set of namespaces.
+type GuestAgentDataPolicy struct {
+	// Namespaces the policy applies to. A policy without namespaces applies to all namespaces.
+	// +listType=set
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Redact lists the kinds of guest data which are not collected.
+	// +listType=set
+	Redact []GuestAgentDataKind `json:"redact"`
+}
+
+// GuestAgentDataKind is a kind of guest data reported by the guest agent.
+// +kubebuilder:validation:Enum=Users;Filesystems;OSInfo
+type GuestAgentDataKind string
+
+const (
+	// GuestAgentDataUsers are the users logged into the guest.
+	GuestAgentDataUsers GuestAgentDataKind = "Users"
+	// GuestAgentDataFilesystems are the mounted filesystems of the guest along with their paths.
+	GuestAgentDataFilesystems GuestAgentDataKind = "Filesystems"
+	// GuestAgentDataOSInfo is the inventory of the guest operating system.
+	GuestAgentDataOSInfo GuestAgentDataKind = "OSInfo"
+)
+
+// GuestAgentAlternative describes an in-guest agent reachable over VSOCK.
+type GuestAgentAlternative struct {
+	// Name of the agent. The agent has to announce the same name during the capability negotiation.
+	// The minimal agent shipped with KubeVirt announces itself as kubevirt-minimal-agent.
+	Name string `json:"name"`
+	// VSOCKPort is the port the agent listens on inside the guest.
+	// +kubebuilder:validation:Minimum=1024
+	VSOCKPort uint32 `json:"vsockPort"`
+}
+
+// ClusterRecoveryConfiguration configures the staggered start of VirtualMachines after a
+// cluster-wide outage. The start order is controlled by the kubevirt.io/cluster-recovery-priority
+// annotation of the VirtualMachines.
+type ClusterRecoveryConfiguration struct {
+	// OutageThresholdPercentage is the percentage of VirtualMachines with the Always or
+	// RerunOnFailure run strategy which have to be down at the same time for the cluster
+	// to be considered recovering from an outage. Defaults to 50.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	OutageThresholdPercentage *uint32 `json:"outageThresholdPercentage,omitempty"`
+
+	// MaxStartsPerNode is the maximum number of VirtualMachineInstances per schedulable
+	// node which may be starting at the same time during recovery. Defaults to 2.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxStartsPerNode *uint32 `json:"maxStartsPerNode,omitempty"`
+
+	// StorageReadinessCheck delays the start of a VirtualMachine during recovery until
+	// all of its PersistentVolumeClaims are bound. Defaults to true.
+	// +optional
+	StorageReadinessCheck *bool `json:"storageReadinessCheck,omitempty"`
+}
+
+// SubresourceLimitsConfiguration holds the limits on the subresource usage of the clients of
+// virt-api. The limits are enforced by each virt-api replica separately.
+type SubresourceLimitsConfiguration struct {
+	// PerUser limits the subresource usage of every user.
+	// +optional
+	PerUser *SubresourceClientLimits `json:"perUser,omitempty"`
+
+	// PerNamespace limits the subresource usage in every namespace.
+	// +optional
+	PerNamespace *SubresourceClientLimits `json:"perNamespace,omitempty"`
+}
+
+// SubresourceClientLimits holds the subresource limits of a single user or namespace.
+type SubresourceClientLimits struct {
+	// MaxConcurrentStreams is the maximum number of console, VNC, usbredir and port-forward
+	// streams which can be open at the same time. Not limited if not specified.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentStreams *uint32 `json:"maxConcurrentStreams,omitempty"`
+
+	// RequestsPerSecond is the sustained rate of subresource requests which is accepted.
+	// Not limited if not specified.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond *uint32 `json:"requestsPerSecond,omitempty"`
+
+	// Burst is the number of subresource requests which can be issued at once before
+	// RequestsPerSecond applies. Defaults to RequestsPerSecond.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Burst *uint32 `json:"burst,omitempty"`
+}
+
+// VirtualMachineExportConfiguration holds the cluster-wide expiration policy of VirtualMachineExports.
+type VirtualMachineExportConfiguration struct {
+	// DefaultTTL is the time to live of VirtualMachineExports which do not specify a ttlDuration.
+	// Defaults to 2h.
+	// +optional
+	DefaultTTL *metav1.Duration `json:"defaultTTL,omitempty"`
+
+	// MaxLifetime is the maximum time a VirtualMachineExport can exist, counting from its creation
+	// and including its renewals. Not limited if not specified.
+	// +optional
+	MaxLifetime *metav1.Duration `json:"maxLifetime,omitempty"`
+
+	// AutoRenew extends the expiration of VirtualMachineExports which do not specify autoRenew
+	// while downloads are in progress. Defaults to false.
+	// +optional
+	AutoRenew *bool `json:"autoRenew,omitempty"`
+
+	// ExpiryWarning is the time before the expiration of a VirtualMachineExport at which a
+	// warning event is emitted, and at which in progress downloads renew it. Defaults to 10m.
+	// +optional
+	ExpiryWarning *metav1.Duration `json:"expiryWarning,omitempty"`
 }
 
 // QGSConfiguration holds QGS configuration
@@ -3239,6 +3840,19 @@ const (
 	RoleAggregationStrategyManual RoleAggregationStrategy = "Manual"
 )
 
+// VolumePermissionRepairPolicy represents how inaccessible volumes are handled
+// +kubebuilder:validation:Enum=Disabled;Ownership;OwnershipAndSELinux
+type VolumePermissionRepairPolicy string
+
+const (
+	// VolumePermissionRepairDisabled only reports volumes with mismatching ownership or SELinux labels
+	VolumePermissionRepairDisabled VolumePermissionRepairPolicy = "Disabled"
+	// VolumePermissionRepairOwnership repairs the ownership of volumes
+	VolumePermissionRepairOwnership VolumePermissionRepairPolicy = "Ownership"
+	// VolumePermissionRepairOwnershipAndSELinux repairs the ownership and SELinux labels of volumes
+	VolumePermissionRepairOwnershipAndSELinux VolumePermissionRepairPolicy = "OwnershipAndSELinux"
+)
+
 type VMRolloutStrategy string
 
 const (
@@ -3413,6 +4027,25 @@ type MigrationConfiguration struct {
 	// That will ensure the target virt-launcher doesn't share categories with another pod on the node.
 	// However, migrations will fail when using RWX volumes that don't automatically deal with SELinux levels.
 	MatchSELinuxLevelOnMigration *bool `json:"matchSELinuxLevelOnMigration,omitempty"`
+	// NodeHeadroom reserves a share of the allocatable resources of every node to receive live
+	// migrations, e.g. during node drains. New VMIs are not scheduled on nodes whose free
+	// capacity is below the headroom. Disabled by default.
+	// +optional
+	NodeHeadroom *MigrationNodeHeadroom `json:"nodeHeadroom,omitempty"`
+}
+
+// MigrationNodeHeadroom holds the share of the allocatable resources of a node reserved for incoming migrations.
+type MigrationNodeHeadroom struct {
+	// CPUPercentage is the percentage of the allocatable CPU of a node kept free for incoming migrations.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CPUPercentage *uint32 `json:"cpuPercentage,omitempty"`
+	// MemoryPercentage is the percentage of the allocatable memory of a node kept free for incoming migrations.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MemoryPercentage *uint32 `json:"memoryPercentage,omitempty"`
 }
 
 // DiskVerification holds container disks verification limits
@@ -3462,9 +4095,17 @@ type DeveloperConfiguration struct {
 	CPUAllocationRatio int `json:"cpuAllocationRatio,omitempty"`
 	// Allow overriding the automatically determined minimum TSC frequency of the cluster
 	// and fixate the minimum to this frequency.
-	MinimumClusterTSCFrequency *int64            `json:"minimumClusterTSCFrequency,omitempty"`
-	DiskVerification           *DiskVerification `json:"diskVerification,omitempty"`
-	LogVerbosity               *LogVerbosity     `json:"logVerbosity,omitempty"`
+	MinimumClusterTSCFrequency *int64 `json:"minimumClusterTSCFrequency,omitempty"`
+	// TSCFrequencyTolerancePPM is the deviation, in parts per million, which is accepted between
+	// the TSC frequency of a VMI and the TSC frequency of a node that cannot scale it.
+	// The same tolerance is used when a migration target is validated.
+	// QEMU refuses deviations above 250 PPM.
+	// Defaults to 250
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=250
+	TSCFrequencyTolerancePPM *int64            `json:"tscFrequencyTolerancePPM,omitempty"`
+	DiskVerification         *DiskVerification `json:"diskVerification,omitempty"`
+	LogVerbosity             *LogVerbosity     `json:"logVerbosity,omitempty"`
 
 	// Enable the ability to pprof profile KubeVirt control plane
 	ClusterProfiler bool `json:"clusterProfiler,omitempty"`
@@ -3532,8 +4173,32 @@ type MediatedHostDevice struct {
 	MDEVNameSelector         string `json:"mdevNameSelector"`
 	ResourceName             string `json:"resourceName"`
 	ExternalResourceProvider bool   `json:"externalResourceProvider,omitempty"`
+	// SchedulingPolicy configures how the physical GPUs backing devices of
+	// this type are time-sliced between the VMs using them
+	// +optional
+	SchedulingPolicy *VGPUSchedulingPolicy `json:"schedulingPolicy,omitempty"`
 }
 
+// VGPUSchedulingPolicy configures the time-slicing of a physical GPU between its vGPUs
+type VGPUSchedulingPolicy struct {
+	// Scheduler selects the time-slicing policy of the vendor driver.
+	// BestEffort hands the GPU to any vGPU with pending work, FixedShare
+	// reserves an equal share of the GPU for every vGPU, busy or not
+	// +kubebuilder:validation:Enum=BestEffort;FixedShare
+	Scheduler VGPUScheduler `json:"scheduler"`
+	// TimeSliceMicroseconds overrides the length of the time slice given
+	// to a vGPU by the vendor driver
+	// +optional
+	TimeSliceMicroseconds *uint32 `json:"timeSliceMicroseconds,omitempty"`
+}
+
+type VGPUScheduler string
+
+const (
+	VGPUSchedulerBestEffort VGPUScheduler = "BestEffort"
+	VGPUSchedulerFixedShare VGPUScheduler = "FixedShare"
+)
+
 // MediatedDevicesConfiguration holds information about MDEV types to be defined, if available
 type MediatedDevicesConfiguration struct {
 	// Deprecated. Use mediatedDeviceTypes instead.
@@ -3874,6 +4539,23 @@ type SEVSecretOptions struct {
 	Secret string `json:"secret,omitempty"`
 }
 
+// SEVSNPAttestationReportOptions is used to request an attestation report from a SEV-SNP guest.
+type SEVSNPAttestationReportOptions struct {
+	// Base64 encoded data of up to 64 bytes to include in the report, usually a nonce chosen by the guest owner.
+	ReportData string `json:"reportData,omitempty"`
+}
+
+// SEVSNPAttestationReport contains an attestation report of a SEV-SNP guest.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SEVSNPAttestationReport struct {
+	metav1.TypeMeta `json:",inline"`
+	// Base64 encoded attestation report signed by the AMD secure processor.
+	Report string `json:"report,omitempty"`
+	// Base64 encoded certificate table provided by the host, containing the VCEK or VLEK certificate chain.
+	CertChain string `json:"certChain,omitempty"`
+}
+
 // ObjectGraphNode represents an individual node in the graph.
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -3895,3 +4577,256 @@ type ObjectGraphOptions struct {
 	// LabelSelector is used to filter nodes in the graph based on their labels.
 	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
 }
+
+// TokenExchangeOptions holds the parameters to exchange an OIDC ID token for a subresource token.
+type TokenExchangeOptions struct {
+	// IDToken is the OIDC ID token of the user the subresource token is issued for.
+	IDToken string `json:"idToken"`
+	// Subresource is the subresource the token grants access to, either console or vnc.
+	Subresource string `json:"subresource"`
+	// ExpirationSeconds is the requested lifetime of the token.
+	// Defaults to 300 and must not exceed 600.
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// TokenExchangeResult holds a subresource token issued for a VirtualMachineInstance.
+type TokenExchangeResult struct {
+	// Token grants access to the requested subresource when passed in the X-KubeVirt-Subresource-Token header.
+	Token string `json:"token"`
+	// ExpirationTimestamp is the time the token expires.
+	ExpirationTimestamp metav1.Time `json:"expirationTimestamp"`
+}
+
+// VirtualMachineInstanceResourceUsage is a point in time sample of the CPU and memory consumed by the guest of a VirtualMachineInstance.
+type VirtualMachineInstanceResourceUsage struct {
+	// Timestamp is the time the sample was taken.
+	Timestamp metav1.MicroTime `json:"timestamp"`
+	// VCPUs is the number of vCPUs of the guest.
+	VCPUs uint32 `json:"vcpus"`
+	// CPUTimeNanoseconds is the cumulative CPU time consumed by all vCPUs of the guest.
+	CPUTimeNanoseconds uint64 `json:"cpuTimeNanoseconds"`
+	// MemoryAvailableBytes is the amount of memory visible to the guest.
+	MemoryAvailableBytes uint64 `json:"memoryAvailableBytes"`
+	// MemoryUsedBytes is the amount of memory in use by the guest as reported by the memory balloon driver.
+	// It is zero if the guest does not report memory statistics.
+	MemoryUsedBytes uint64 `json:"memoryUsedBytes"`
+}
+
+// VirtualMachineInstanceDomainStats is a point in time sample of the statistics libvirt reports for the domain of a VirtualMachineInstance.
+type VirtualMachineInstanceDomainStats struct {
+	// Timestamp is the time the sample was taken.
+	Timestamp metav1.MicroTime `json:"timestamp"`
+	// CPU holds the CPU time consumed by the domain.
+	// +optional
+	CPU *DomainStatsCPU `json:"cpu,omitempty"`
+	// VCPUs holds the statistics of every vCPU of the guest.
+	// +optional
+	// +listType=atomic
+	VCPUs []DomainStatsVCPU `json:"vcpus,omitempty"`
+	// Memory holds the memory and balloon statistics of the guest.
+	// +optional
+	Memory *DomainStatsMemory `json:"memory,omitempty"`
+	// Disks holds the statistics of every disk of the guest.
+	// +optional
+	// +listType=atomic
+	Disks []DomainStatsDisk `json:"disks,omitempty"`
+	// Interfaces holds the statistics of every network interface of the guest.
+	// +optional
+	// +listType=atomic
+	Interfaces []DomainStatsInterface `json:"interfaces,omitempty"`
+}
+
+// DomainStatsCPU holds the CPU time consumed by a domain.
+type DomainStatsCPU struct {
+	// TimeNanoseconds is the total CPU time consumed by the domain.
+	// +optional
+	TimeNanoseconds uint64 `json:"timeNanoseconds,omitempty"`
+	// UserNanoseconds is the CPU time the domain spent in user mode.
+	// +optional
+	UserNanoseconds uint64 `json:"userNanoseconds,omitempty"`
+	// SystemNanoseconds is the CPU time the domain spent in kernel mode.
+	// +optional
+	SystemNanoseconds uint64 `json:"systemNanoseconds,omitempty"`
+}
+
+// DomainStatsVCPU holds the statistics of a single vCPU.
+type DomainStatsVCPU struct {
+	// ID is the index of the vCPU.
+	ID uint32 `json:"id"`
+	// State is the state of the vCPU, one of offline, running or blocked.
+	// +optional
+	State string `json:"state,omitempty"`
+	// TimeNanoseconds is the CPU time consumed by the vCPU.
+	// +optional
+	TimeNanoseconds uint64 `json:"timeNanoseconds,omitempty"`
+	// WaitNanoseconds is the time the vCPU spent waiting for I/O.
+	// +optional
+	WaitNanoseconds uint64 `json:"waitNanoseconds,omitempty"`
+	// DelayNanoseconds is the time the vCPU spent waiting in the run queue of the host.
+	// +optional
+	DelayNanoseconds uint64 `json:"delayNanoseconds,omitempty"`
+}
+
+// DomainStatsMemory holds the memory and balloon statistics of a domain.
+// The statistics reported from within the guest are only set if the guest runs the memory balloon driver.
+type DomainStatsMemory struct {
+	// ActualBalloonBytes is the current size of the memory balloon.
+	// +optional
+	ActualBalloonBytes uint64 `json:"actualBalloonBytes,omitempty"`
+	// AvailableBytes is the amount of memory visible to the guest.
+	// +optional
+	AvailableBytes uint64 `json:"availableBytes,omitempty"`
+	// UsableBytes is the amount of memory the guest can use without swapping.
+	// +optional
+	UsableBytes uint64 `json:"usableBytes,omitempty"`
+	// UnusedBytes is the amount of memory left completely unused by the guest.
+	// +optional
+	UnusedBytes uint64 `json:"unusedBytes,omitempty"`
+	// CachedBytes is the amount of memory the guest uses for caches which can be reclaimed.
+	// +optional
+	CachedBytes uint64 `json:"cachedBytes,omitempty"`
+	// RSSBytes is the resident set size of the domain on the host.
+	// +optional
+	RSSBytes uint64 `json:"rssBytes,omitempty"`
+	// SwapInBytes is the amount of memory the guest read from swap space.
+	// +optional
+	SwapInBytes uint64 `json:"swapInBytes,omitempty"`
+	// SwapOutBytes is the amount of memory the guest wrote to swap space.
+	// +optional
+	SwapOutBytes uint64 `json:"swapOutBytes,omitempty"`
+	// MajorPageFaults is the number of page faults of the guest which required disk I/O.
+	// +optional
+	MajorPageFaults uint64 `json:"majorPageFaults,omitempty"`
+	// MinorPageFaults is the number of page faults of the guest which did not require disk I/O.
+	// +optional
+	MinorPageFaults uint64 `json:"minorPageFaults,omitempty"`
+}
+
+// DomainStatsDisk holds the statistics of a single disk.
+type DomainStatsDisk struct {
+	// Name is the name of the disk in the VirtualMachineInstance spec or the target device if the disk has no alias.
+	Name string `json:"name"`
+	// Path is the path of the disk source on the host.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// ReadRequests is the number of read requests.
+	// +optional
+	ReadRequests uint64 `json:"readRequests,omitempty"`
+	// ReadBytes is the number of bytes read.
+	// +optional
+	ReadBytes uint64 `json:"readBytes,omitempty"`
+	// ReadTimeNanoseconds is the total time spent on read requests.
+	// +optional
+	ReadTimeNanoseconds uint64 `json:"readTimeNanoseconds,omitempty"`
+	// WriteRequests is the number of write requests.
+	// +optional
+	WriteRequests uint64 `json:"writeRequests,omitempty"`
+	// WriteBytes is the number of bytes written.
+	// +optional
+	WriteBytes uint64 `json:"writeBytes,omitempty"`
+	// WriteTimeNanoseconds is the total time spent on write requests.
+	// +optional
+	WriteTimeNanoseconds uint64 `json:"writeTimeNanoseconds,omitempty"`
+	// FlushRequests is the number of flush requests.
+	// +optional
+	FlushRequests uint64 `json:"flushRequests,omitempty"`
+	// FlushTimeNanoseconds is the total time spent on flush requests.
+	// +optional
+	FlushTimeNanoseconds uint64 `json:"flushTimeNanoseconds,omitempty"`
+	// Errors is the number of failed requests.
+	// +optional
+	Errors uint64 `json:"errors,omitempty"`
+	// CapacityBytes is the size of the disk as seen by the guest.
+	// +optional
+	CapacityBytes uint64 `json:"capacityBytes,omitempty"`
+	// AllocationBytes is the highest offset written to the disk source.
+	// +optional
+	AllocationBytes uint64 `json:"allocationBytes,omitempty"`
+	// PhysicalBytes is the size the disk source takes on the host.
+	// +optional
+	PhysicalBytes uint64 `json:"physicalBytes,omitempty"`
+}
+
+// DomainStatsInterface holds the statistics of a single network interface.
+type DomainStatsInterface struct {
+	// Name is the name of the interface in the VirtualMachineInstance spec or the host side device if the interface has no alias.
+	Name string `json:"name"`
+	// RxBytes is the number of bytes received.
+	// +optional
+	RxBytes uint64 `json:"rxBytes,omitempty"`
+	// RxPackets is the number of packets received.
+	// +optional
+	RxPackets uint64 `json:"rxPackets,omitempty"`
+	// RxErrors is the number of receive errors.
+	// +optional
+	RxErrors uint64 `json:"rxErrors,omitempty"`
+	// RxDropped is the number of received packets which were dropped.
+	// +optional
+	RxDropped uint64 `json:"rxDropped,omitempty"`
+	// TxBytes is the number of bytes transmitted.
+	// +optional
+	TxBytes uint64 `json:"txBytes,omitempty"`
+	// TxPackets is the number of packets transmitted.
+	// +optional
+	TxPackets uint64 `json:"txPackets,omitempty"`
+	// TxErrors is the number of transmit errors.
+	// +optional
+	TxErrors uint64 `json:"txErrors,omitempty"`
+	// TxDropped is the number of transmitted packets which were dropped.
+	// +optional
+	TxDropped uint64 `json:"txDropped,omitempty"`
+}
+
+// VirtualMachineInstanceDomain holds the libvirt domain definition of a running VirtualMachineInstance
+// next to the definition which is generated from its current spec.
+type VirtualMachineInstanceDomain struct {
+	// Live is the XML definition of the running domain.
+	Live string `json:"live"`
+	// Expected is the XML definition generated from the current VirtualMachineInstance spec.
+	Expected string `json:"expected"`
+}
+
+// VirtualMachinePreflightReport is the result of simulating the start of a VirtualMachine without creating its VirtualMachineInstance.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachinePreflightReport struct {
+	metav1.TypeMeta `json:",inline"`
+	// Ready is true if none of the checks failed.
+	Ready bool `json:"ready"`
+	// Checks holds the result of every check that was performed.
+	// +listType=atomic
+	// +optional
+	Checks []VirtualMachinePreflightCheck `json:"checks,omitempty"`
+}
+
+// VirtualMachinePreflightCheck is the result of a single check of a VirtualMachine start simulation.
+type VirtualMachinePreflightCheck struct {
+	// Category is the area the check belongs to, one of Image, Storage, Device, Compute or Network.
+	Category VirtualMachinePreflightCheckCategory `json:"category"`
+	// Name identifies what was checked, e.g. the name of a volume or a network.
+	Name string `json:"name"`
+	// Status is the outcome of the check, one of Passed, Warning or Failed.
+	Status VirtualMachinePreflightCheckStatus `json:"status"`
+	// Message describes the outcome of the check.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type VirtualMachinePreflightCheckCategory string
+
+const (
+	PreflightCheckCategoryImage   VirtualMachinePreflightCheckCategory = "Image"
+	PreflightCheckCategoryStorage VirtualMachinePreflightCheckCategory = "Storage"
+	PreflightCheckCategoryDevice  VirtualMachinePreflightCheckCategory = "Device"
+	PreflightCheckCategoryCompute VirtualMachinePreflightCheckCategory = "Compute"
+	PreflightCheckCategoryNetwork VirtualMachinePreflightCheckCategory = "Network"
+)
+
+type VirtualMachinePreflightCheckStatus string
+
+const (
+	PreflightCheckPassed  VirtualMachinePreflightCheckStatus = "Passed"
+	PreflightCheckWarning VirtualMachinePreflightCheckStatus = "Warning"
+	PreflightCheckFailed  VirtualMachinePreflightCheckStatus = "Failed"
+)
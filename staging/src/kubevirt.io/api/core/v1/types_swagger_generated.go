@@ -76,17 +76,39 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"volumeStatus":                  "VolumeStatus contains the statuses of all the volumes\n+optional\n+listType=atomic",
 		"kernelBootStatus":              "KernelBootStatus contains info about the kernelBootContainer\n+optional",
 		"fsFreezeStatus":                "FSFreezeStatus indicates whether a freeze operation was requested for the guest filesystem.\nIt will be set to \"frozen\" if the request was made, or unset otherwise.\nThis does not reflect the actual state of the guest filesystem.\n+optional",
+		"sshHostKeys":                   "SSHHostKeys contains the SSH host public keys reported by the guest agent,\nin the \"<key type> <base64 key>\" format used by known_hosts files.\n+optional\n+listType=atomic",
 		"topologyHints":                 "+optional",
 		"virtualMachineRevisionName":    "VirtualMachineRevisionName is used to get the vm revision of the vmi when doing\nan online vm snapshot\n+optional",
 		"runtimeUser":                   "RuntimeUser is used to determine what user will be used in launcher\n+optional",
 		"VSOCKCID":                      "VSOCKCID is used to track the allocated VSOCK CID in the VM.\n+optional",
+		"vsockServices":                 "VSOCKServices reports the VSOCK port of every service of spec.domain.devices.vsockServices\n+optional\n+listType=atomic",
 		"selinuxContext":                "SELinuxContext is the actual SELinux context of the virt-launcher pod\n+optional",
 		"machine":                       "Machine shows the final resulting qemu machine type. This can be different\nthan the machine type selected in the spec, due to qemus machine type alias mechanism.\n+optional",
 		"currentCPUTopology":            "CurrentCPUTopology specifies the current CPU topology used by the VM workload.\nCurrent topology may differ from the desired topology in the spec while CPU hotplug\ntakes place.",
 		"memory":                        "Memory shows various informations about the VirtualMachine memory.\n+optional",
+		"liveUpdatedResources":          "LiveUpdatedResources records the guest CPU and memory resources applied\nto the VMI by live updates since it was started.\n+optional",
+		"cpuThreadShares":               "CPUThreadShares reports the CPU shares applied to the emulator and IO threads of the VMI.\n+optional",
 		"migratedVolumes":               "MigratedVolumes lists the source and destination volumes during the volume migration\n+listType=atomic\n+optional",
 		"deviceStatus":                  "DeviceStatus reflects the state of devices requested in spec.domain.devices. This is an optional field available\nonly when DRA feature gate is enabled\nThis field will only be populated if one of the feature-gates GPUsWithDRA or HostDevicesWithDRA is enabled.\nThis feature is in alpha.\n+optional",
 		"changedBlockTracking":          "ChangedBlockTracking represents the status of the changedBlockTracking\n+nullable\n+optional",
+		"bootTimes":                     "BootTimes records when the VirtualMachineInstance reached the stages of its start.\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceBootTimes) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "VirtualMachineInstanceBootTimes records when a VirtualMachineInstance reached the stages of its start.\nEach time is recorded once, the difference between two consecutive times is the duration of a stage.",
+		"podCreationTime":       "PodCreationTime is when the virt-launcher pod was created, once the volumes were bound and populated.\n+optional",
+		"podScheduledTime":      "PodScheduledTime is when the virt-launcher pod was scheduled to a node.\n+optional",
+		"launcherStartTime":     "LauncherStartTime is when the compute container of the virt-launcher pod started, once its images were pulled.\n+optional",
+		"domainStartTime":       "DomainStartTime is when the domain started running and the firmware took over.\n+optional",
+		"guestAgentConnectTime": "GuestAgentConnectTime is when the guest agent connected, once the guest OS booted.\n+optional",
+	}
+}
+
+func (VSOCKServiceStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VSOCKServiceStatus reports the VSOCK port of a named guest service",
 	}
 }
 
@@ -302,6 +324,7 @@ func (VirtualMachineInstanceMigrationState) SwaggerDoc() map[string]string {
 		"failureReason":                  "Contains the reason why the migration failed",
 		"migrationUid":                   "The VirtualMachineInstanceMigration object associated with this migration",
 		"mode":                           "Lets us know if the vmi is currently running pre or post copy migration",
+		"transferStatus":                 "TransferStatus reports the progress of the data transfer as last seen by the source\n+optional",
 		"migrationPolicyName":            "Name of the migration policy. If string is empty, no policy is matched",
 		"migrationConfiguration":         "Migration configurations to apply",
 		"targetCPUSet":                   "If the VMI requires dedicated CPUs, this field will\nhold the dedicated CPU set on the target node\n+listType=atomic",
@@ -315,6 +338,19 @@ func (VirtualMachineInstanceMigrationState) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineInstanceMigrationTransferStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                              "VirtualMachineInstanceMigrationTransferStatus holds the progress of the memory and disk transfer of a live migration",
+		"dataProcessedBytes":            "The amount of data already transferred, in bytes",
+		"dataRemainingBytes":            "The amount of data still to be transferred, in bytes",
+		"dataTotalBytes":                "The total amount of data to transfer, in bytes",
+		"memoryDirtyRateBytesPerSecond": "The rate at which the guest dirties its memory, in bytes per second",
+		"memoryBandwidthBytesPerSecond": "The bandwidth used to transfer the memory, in bytes per second",
+		"memoryIteration":               "The number of memory transfer iterations completed so far",
+		"lastUpdateTimestamp":           "The time at which the transfer status was last updated",
+	}
+}
+
 func (VMISelector) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"name": "Name of the VirtualMachineInstance to migrate",
@@ -391,11 +427,12 @@ func (VirtualMachineInstanceMigrationList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineInstanceMigrationSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"vmiName":           "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
-		"addedNodeSelector": "AddedNodeSelector is an additional selector that can be used to\ncomplement a NodeSelector or NodeAffinity as set on the VM\nto restrict the set of allowed target nodes for a migration.\nIn case of key collisions, values set on the VM objects\nare going to be preserved to ensure that addedNodeSelector\ncan only restrict but not bypass constraints already set on the VM object.\n+optional",
-		"sendTo":            "If sendTo is specified, this VirtualMachineInstanceMigration will be considered the source",
-		"receive":           "If receieve is specified, this VirtualMachineInstanceMigration will be considered the target",
-		"priority":          "Priority of the migration. This can be one of `system-critical`, `user-triggered`, `system-maintenance`.\n+optional",
+		"vmiName":             "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+		"addedNodeSelector":   "AddedNodeSelector is an additional selector that can be used to\ncomplement a NodeSelector or NodeAffinity as set on the VM\nto restrict the set of allowed target nodes for a migration.\nIn case of key collisions, values set on the VM objects\nare going to be preserved to ensure that addedNodeSelector\ncan only restrict but not bypass constraints already set on the VM object.\n+optional",
+		"migrationPolicyName": "MigrationPolicyName is the name of the MigrationPolicy to apply to this migration.\nIt takes precedence over the MigrationPolicy matched by the selectors of the policies.\n+optional",
+		"sendTo":              "If sendTo is specified, this VirtualMachineInstanceMigration will be considered the source",
+		"receive":             "If receieve is specified, this VirtualMachineInstanceMigration will be considered the target",
+		"priority":            "Priority of the migration. This can be one of `system-critical`, `user-triggered`, `system-maintenance`.\n+optional",
 	}
 }
 
@@ -473,6 +510,7 @@ func (VirtualMachineSpec) SwaggerDoc() map[string]string {
 		"template":              "Template is the direct specification of VirtualMachineInstance",
 		"dataVolumeTemplates":   "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.\nDataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
 		"updateVolumesStrategy": "UpdateVolumesStrategy is the strategy to apply on volumes updates",
+		"deletionProtection":    "DeletionProtection rejects the deletion of the VirtualMachine until it is unlocked, either by\nsetting the kubevirt.io/deletion-unlock annotation to the name of the VirtualMachine, or once\nthe time set in the kubevirt.io/deletion-unlock-after annotation passed.\n+optional",
 	}
 }
 
@@ -484,25 +522,29 @@ func (VirtualMachineStartFailure) SwaggerDoc() map[string]string {
 
 func (VirtualMachineStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                       "VirtualMachineStatus represents the status returned by the\ncontroller to describe how the VirtualMachine is doing",
-		"snapshotInProgress":     "SnapshotInProgress is the name of the VirtualMachineSnapshot currently executing",
-		"restoreInProgress":      "RestoreInProgress is the name of the VirtualMachineRestore currently executing",
-		"created":                "Created indicates if the virtual machine is created in the cluster",
-		"ready":                  "Ready indicates if the virtual machine is running and ready",
-		"printableStatus":        "PrintableStatus is a human readable, high-level representation of the status of the virtual machine\n+kubebuilder:default=Stopped",
-		"conditions":             "Hold the state information of the VirtualMachine and its VirtualMachineInstance",
-		"stateChangeRequests":    "StateChangeRequests indicates a list of actions that should be taken on a VMI\ne.g. stop a specific VMI then start a new one.",
-		"volumeRequests":         "VolumeRequests indicates a list of volumes add or remove from the VMI template and\nhotplug on an active running VMI.\n+listType=atomic",
-		"volumeSnapshotStatuses": "VolumeSnapshotStatuses indicates a list of statuses whether snapshotting is\nsupported by each volume.",
-		"startFailure":           "StartFailure tracks consecutive VMI startup failures for the purposes of\ncrash loop backoffs\n+nullable\n+optional",
-		"memoryDumpRequest":      "MemoryDumpRequest tracks memory dump request phase and info of getting a memory\ndump to the given pvc\n+nullable\n+optional",
-		"observedGeneration":     "ObservedGeneration is the generation observed by the vmi when started.\n+optional",
-		"desiredGeneration":      "DesiredGeneration is the generation which is desired for the VMI.\nThis will be used in comparisons with ObservedGeneration to understand when\nthe VMI is out of sync. This will be changed at the same time as\nObservedGeneration to remove errors which could occur if Generation is\nupdated through an Update() before ObservedGeneration in Status.\n+optional",
-		"runStrategy":            "RunStrategy tracks the last recorded RunStrategy used by the VM.\nThis is needed to correctly process the next strategy (for now only the RerunOnFailure)",
-		"volumeUpdateState":      "VolumeUpdateState contains the information about the volumes set\nupdates related to the volumeUpdateStrategy",
-		"changedBlockTracking":   "ChangedBlockTracking represents the status of the changedBlockTracking\n+nullable\n+optional",
-		"instancetypeRef":        "InstancetypeRef captures the state of any referenced instance type from the VirtualMachine\n+nullable\n+optional",
-		"preferenceRef":          "PreferenceRef captures the state of any referenced preference from the VirtualMachine\n+nullable\n+optional",
+		"":                             "VirtualMachineStatus represents the status returned by the\ncontroller to describe how the VirtualMachine is doing",
+		"snapshotInProgress":           "SnapshotInProgress is the name of the VirtualMachineSnapshot currently executing",
+		"restoreInProgress":            "RestoreInProgress is the name of the VirtualMachineRestore currently executing",
+		"created":                      "Created indicates if the virtual machine is created in the cluster",
+		"ready":                        "Ready indicates if the virtual machine is running and ready",
+		"printableStatus":              "PrintableStatus is a human readable, high-level representation of the status of the virtual machine\n+kubebuilder:default=Stopped",
+		"conditions":                   "Hold the state information of the VirtualMachine and its VirtualMachineInstance",
+		"stateChangeRequests":          "StateChangeRequests indicates a list of actions that should be taken on a VMI\ne.g. stop a specific VMI then start a new one.",
+		"volumeRequests":               "VolumeRequests indicates a list of volumes add or remove from the VMI template and\nhotplug on an active running VMI.\n+listType=atomic",
+		"volumeSnapshotStatuses":       "VolumeSnapshotStatuses indicates a list of statuses whether snapshotting is\nsupported by each volume.",
+		"startFailure":                 "StartFailure tracks consecutive VMI startup failures for the purposes of\ncrash loop backoffs\n+nullable\n+optional",
+		"memoryDumpRequest":            "MemoryDumpRequest tracks memory dump request phase and info of getting a memory\ndump to the given pvc\n+nullable\n+optional",
+		"observedGeneration":           "ObservedGeneration is the generation observed by the vmi when started.\n+optional",
+		"desiredGeneration":            "DesiredGeneration is the generation which is desired for the VMI.\nThis will be used in comparisons with ObservedGeneration to understand when\nthe VMI is out of sync. This will be changed at the same time as\nObservedGeneration to remove errors which could occur if Generation is\nupdated through an Update() before ObservedGeneration in Status.\n+optional",
+		"runStrategy":                  "RunStrategy tracks the last recorded RunStrategy used by the VM.\nThis is needed to correctly process the next strategy (for now only the RerunOnFailure)",
+		"volumeUpdateState":            "VolumeUpdateState contains the information about the volumes set\nupdates related to the volumeUpdateStrategy",
+		"changedBlockTracking":         "ChangedBlockTracking represents the status of the changedBlockTracking\n+nullable\n+optional",
+		"instancetypeRef":              "InstancetypeRef captures the state of any referenced instance type from the VirtualMachine\n+nullable\n+optional",
+		"preferenceRef":                "PreferenceRef captures the state of any referenced preference from the VirtualMachine\n+nullable\n+optional",
+		"ipAddresses":                  "IPAddresses mirrors the IP addresses reported on the interfaces of the VirtualMachineInstance\n+optional\n+listType=atomic",
+		"nodeName":                     "NodeName mirrors the name of the node the VirtualMachineInstance is running on\n+optional",
+		"guestAgentConnected":          "GuestAgentConnected mirrors whether the guest agent of the VirtualMachineInstance is connected\n+optional",
+		"instanceStatusLastUpdateTime": "InstanceStatusLastUpdateTime is the last time ipAddresses, nodeName or guestAgentConnected changed\n+nullable\n+optional",
 	}
 }
 
@@ -709,6 +751,21 @@ func (KubeVirtStatus) SwaggerDoc() map[string]string {
 		"":                         "KubeVirtStatus represents information pertaining to a KubeVirt deployment.",
 		"generations":              "+listType=atomic",
 		"synchronizationAddresses": "+optional\n+listType=atomic",
+		"clusterRecovery":          "ClusterRecovery reports the progress of starting VirtualMachines after a cluster-wide outage.\n+optional",
+	}
+}
+
+func (ClusterRecoveryStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "ClusterRecoveryStatus reports the progress of the staggered start of VirtualMachines after a\ncluster-wide outage.",
+		"startTime":         "StartTime is the time at which the outage was detected.\n+optional\n+nullable",
+		"completionTime":    "CompletionTime is the time at which all VirtualMachines were started.\n+optional\n+nullable",
+		"currentPriority":   "CurrentPriority is the priority tier which is currently being started.\n+optional",
+		"virtualMachines":   "VirtualMachines is the number of VirtualMachines with the Always or RerunOnFailure run strategy.",
+		"running":           "Running is the number of those VirtualMachines which are running.",
+		"starting":          "Starting is the number of those VirtualMachines which are being started.",
+		"pending":           "Pending is the number of those VirtualMachines which are waiting to be started.",
+		"waitingForStorage": "WaitingForStorage is the number of pending VirtualMachines whose PersistentVolumeClaims are not bound yet.",
 	}
 }
 
@@ -738,8 +795,9 @@ func (StartOptions) SwaggerDoc() map[string]string {
 
 func (PauseOptions) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":       "PauseOptions may be provided on pause request.",
-		"dryRun": "When present, indicates that modifications should not be\npersisted. An invalid or unrecognized dryRun directive will\nresult in an error response and no further processing of the\nrequest. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+		"":          "PauseOptions may be provided on pause request.",
+		"dryRun":    "When present, indicates that modifications should not be\npersisted. An invalid or unrecognized dryRun directive will\nresult in an error response and no further processing of the\nrequest. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+		"syncGuest": "SyncGuest asks the guest agent to flush and freeze the guest filesystems\nbefore the vCPUs are paused. The filesystems are thawed on unpause.\n+optional",
 	}
 }
 
@@ -750,6 +808,13 @@ func (UnpauseOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (SoftRebootOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "SoftRebootOptions may be provided on soft reboot request.",
+		"fallback": "Fallback defines what happens when the guest agent is absent or unresponsive.\nDefaults to acpi.\n+optional",
+	}
+}
+
 func (StopOptions) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":            "StopOptions may be provided when deleting an API object.",
@@ -760,9 +825,59 @@ func (StopOptions) SwaggerDoc() map[string]string {
 
 func (MigrateOptions) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                  "MigrateOptions may be provided on migrate request.",
-		"dryRun":            "When present, indicates that modifications should not be\npersisted. An invalid or unrecognized dryRun directive will\nresult in an error response and no further processing of the\nrequest. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
-		"addedNodeSelector": "AddedNodeSelector is an additional selector that can be used to\ncomplement a NodeSelector or NodeAffinity as set on the VM\nto restrict the set of allowed target nodes for a migration.\nIn case of key collisions, values set on the VM objects\nare going to be preserved to ensure that addedNodeSelector\ncan only restrict but not bypass constraints already set on the VM object.\n+optional",
+		"":                    "MigrateOptions may be provided on migrate request.",
+		"dryRun":              "When present, indicates that modifications should not be\npersisted. An invalid or unrecognized dryRun directive will\nresult in an error response and no further processing of the\nrequest. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+		"addedNodeSelector":   "AddedNodeSelector is an additional selector that can be used to\ncomplement a NodeSelector or NodeAffinity as set on the VM\nto restrict the set of allowed target nodes for a migration.\nIn case of key collisions, values set on the VM objects\nare going to be preserved to ensure that addedNodeSelector\ncan only restrict but not bypass constraints already set on the VM object.\n+optional",
+		"migrationPolicyName": "MigrationPolicyName is the name of the MigrationPolicy to apply to the migration\ninstead of the MigrationPolicy matched by the selectors of the policies.\n+optional",
+	}
+}
+
+func (DryRunResult) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "DryRunResult is returned by the start, stop, restart, migrate, addvolume and removevolume\nsubresources for dry-run requests. The request passed the validation of the subresource\nand of the API server, and the listed changes would have been applied.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"changes": "Changes lists the changes the request would apply, in the order in which they would be applied.\n+optional\n+listType=atomic",
+	}
+}
+
+func (DryRunChange) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DryRunChange describes a change a dry-run request would apply to an object.",
+		"kind":      "Kind of the object which would be changed.",
+		"name":      "Name of the object which would be changed. Empty for objects created with a generated name.\n+optional",
+		"operation": "Operation which would be applied to the object.",
+		"patch":     "Patch is the JSON patch which would be applied by the Patch and PatchStatus operations.\n+optional",
+		"object":    "Object is the object returned by the API server for the dry-run request, including\nthe mutations of admission webhooks. It is not set for the Delete operation.\n+optional",
+	}
+}
+
+func (BulkLifecycleOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "BulkLifecycleOptions are provided on bulk lifecycle request.\nThe VirtualMachines of the namespace are either selected by their names or by a label selector.",
+		"action":         "Action is the lifecycle action applied to every selected VirtualMachine: start, stop or restart.",
+		"names":          "Names of the VirtualMachines the action is applied to.\n+optional\n+listType=atomic",
+		"labelSelector":  "LabelSelector selects the VirtualMachines the action is applied to by their labels.\n+optional",
+		"startOptions":   "StartOptions are used for every VirtualMachine when the action is start.\n+optional",
+		"stopOptions":    "StopOptions are used for every VirtualMachine when the action is stop.\n+optional",
+		"restartOptions": "RestartOptions are used for every VirtualMachine when the action is restart.\n+optional",
+		"dryRun":         "When present, indicates that modifications should not be\npersisted. It replaces the dryRun of the options of the action.\nValid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+	}
+}
+
+func (BulkLifecycleResult) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "BulkLifecycleResult is returned by the bulk lifecycle subresource.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"results": "Results lists the outcome of the action for every selected VirtualMachine, ordered by name.\n+optional\n+listType=atomic",
+	}
+}
+
+func (BulkLifecycleVirtualMachineResult) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "BulkLifecycleVirtualMachineResult is the outcome of a bulk lifecycle action for a single VirtualMachine.",
+		"name":    "Name of the VirtualMachine.",
+		"code":    "Code is the HTTP status code the single lifecycle request would have returned.",
+		"reason":  "Reason is the machine-readable reason of a failure.\n+optional",
+		"message": "Message is the human-readable description of a failure.\n+optional",
+		"changes": "Changes lists the changes the action would apply for dry-run requests.\n+optional\n+listType=atomic",
 	}
 }
 
@@ -773,6 +888,13 @@ func (EvacuateCancelOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (RenameOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "RenameOptions are provided on rename request.",
+		"newName": "NewName is the name the VirtualMachine is renamed to.",
+	}
+}
+
 func (VirtualMachineInstanceGuestAgentInfo) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                  "VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
@@ -830,9 +952,26 @@ func (VirtualMachineInstanceFileSystem) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineInstanceSummaryList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "VirtualMachineInstanceSummaryList is a page of VirtualMachineInstance summaries served from the cache of virt-api\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"total":       "Total is the number of VirtualMachineInstances matching the request across all pages",
+		"phaseCounts": "PhaseCounts is the number of VirtualMachineInstances matching the request per phase across all pages\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceSummary) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VirtualMachineInstanceSummary is a condensed view of a VirtualMachineInstance",
+		"ready":     "Ready reflects the Ready condition of the VirtualMachineInstance",
+		"ipAddress": "IPAddress is the first IP address reported for the VirtualMachineInstance\n+optional",
+	}
+}
+
 func (FreezeUnfreezeTimeout) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "FreezeUnfreezeTimeout represent the time unfreeze will be triggered if guest was not unfrozen by unfreeze command",
+		"":            "FreezeUnfreezeTimeout represent the time unfreeze will be triggered if guest was not unfrozen by unfreeze command",
+		"mountpoints": "Mountpoints restricts the freeze to the given guest mount points.\nWhen empty, all freezable guest filesystems are frozen.\nRequires a guest agent supporting guest-fsfreeze-freeze-list.\n+optional\n+listType=atomic",
 	}
 }
 
@@ -846,6 +985,7 @@ func (VirtualMachineMemoryDumpRequest) SwaggerDoc() map[string]string {
 		"endTimestamp":   "EndTimestamp represents the time the memory dump was completed\n+optional",
 		"fileName":       "FileName represents the name of the output file\n+optional",
 		"message":        "Message is a detailed message about failure of the memory dump\n+optional",
+		"format":         "Format is the format the memory is dumped in, defaults to Raw\n+optional",
 	}
 }
 
@@ -923,6 +1063,71 @@ func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 		"changedBlockTrackingLabelSelectors": "ChangedBlockTrackingLabelSelectors defines label selectors. VMs matching these selectors will have changed block tracking enabled.\nEnabling changedBlockTracking is mandatory for performing storage-agnostic backups and incremental backups.\n+nullable",
 		"confidentialCompute":                "QGS configuration for attestation on the Intel TDX Platform\n+nullable",
 		"roleAggregationStrategy":            "RoleAggregationStrategy controls whether RBAC cluster roles should be aggregated\nto the default Kubernetes roles (admin, edit, view).\nWhen set to \"AggregateToDefault\" (default) or not specified, the aggregate-to-* labels are added to the cluster roles.\nWhen set to \"Manual\", the labels are not added, and roles will not be aggregated to the default roles.\nSetting this field to \"Manual\" requires the OptOutRoleAggregation feature gate to be enabled.\nThis is an Alpha feature and subject to change.\n+optional\n+kubebuilder:validation:Enum=AggregateToDefault;Manual",
+		"volumePermissionRepairPolicy":       "VolumePermissionRepairPolicy controls whether virt-handler repairs the ownership\nand SELinux labels of filesystem volumes which are not accessible by the VM,\ne.g. because the backing storage was moved from another node.\nWhen set to \"Disabled\" (default) or not specified, mismatches are only reported.\nWhen set to \"Ownership\", the disk images are chowned to the qemu user.\nWhen set to \"OwnershipAndSELinux\", the disk images are additionally relabeled.\n+optional\n+kubebuilder:validation:Enum=Disabled;Ownership;OwnershipAndSELinux",
+		"clusterRecovery":                    "ClusterRecovery configures how virt-controller staggers the automatic start of\nVirtualMachines after a cluster-wide outage. When not specified, VirtualMachines\nare started as soon as possible.\n+optional",
+		"guestAgent":                         "GuestAgent configures the in-guest agents which can be used when qemu-guest-agent\nis not available in the guest.\n+optional",
+		"subresourceLimits":                  "SubresourceLimits restricts the console, VNC, usbredir and port-forward streams and the\nsubresource requests which each virt-api replica accepts per user and per namespace.\nRequests exceeding the limits are rejected with 429 Too Many Requests. When not specified,\nsubresources are not limited.\n+optional",
+		"virtualMachineExport":               "VirtualMachineExport configures the expiration and renewal of VirtualMachineExports.\n+optional",
+	}
+}
+
+func (GuestAgentConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "GuestAgentConfiguration configures alternative in-guest agents.",
+		"alternatives": "Alternatives lists the agents virt-handler talks to over VSOCK when qemu-guest-agent\nis not connected. They are tried in order and the first one answering is used for\nIP reporting and filesystem freeze/thaw, depending on the capabilities it announces.\n+listType=map\n+listMapKey=name\n+optional",
+		"dataPolicies": "DataPolicies restrict the guest data collected from the guest agents of the\nVirtualMachineInstances per namespace. Redacted data is neither stored in the status of\nthe VirtualMachineInstances nor returned by their guest agent subresources. When several\npolicies apply to a namespace, the data redacted by any of them is redacted.\n+listType=atomic\n+optional",
+	}
+}
+
+func (GuestAgentDataPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "GuestAgentDataPolicy redacts guest data reported by the guest agents in a set of namespaces.",
+		"namespaces": "Namespaces the policy applies to. A policy without namespaces applies to all namespaces.\n+listType=set\n+optional",
+		"redact":     "Redact lists the kinds of guest data which are not collected.\n+listType=set",
+	}
+}
+
+func (GuestAgentAlternative) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "GuestAgentAlternative describes an in-guest agent reachable over VSOCK.",
+		"name":      "Name of the agent. The agent has to announce the same name during the capability negotiation.\nThe minimal agent shipped with KubeVirt announces itself as kubevirt-minimal-agent.",
+		"vsockPort": "VSOCKPort is the port the agent listens on inside the guest.\n+kubebuilder:validation:Minimum=1024",
+	}
+}
+
+func (ClusterRecoveryConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                          "ClusterRecoveryConfiguration configures the staggered start of VirtualMachines after a\ncluster-wide outage. The start order is controlled by the kubevirt.io/cluster-recovery-priority\nannotation of the VirtualMachines.",
+		"outageThresholdPercentage": "OutageThresholdPercentage is the percentage of VirtualMachines with the Always or\nRerunOnFailure run strategy which have to be down at the same time for the cluster\nto be considered recovering from an outage. Defaults to 50.\n+optional\n+kubebuilder:validation:Minimum=1\n+kubebuilder:validation:Maximum=100",
+		"maxStartsPerNode":          "MaxStartsPerNode is the maximum number of VirtualMachineInstances per schedulable\nnode which may be starting at the same time during recovery. Defaults to 2.\n+optional\n+kubebuilder:validation:Minimum=1",
+		"storageReadinessCheck":     "StorageReadinessCheck delays the start of a VirtualMachine during recovery until\nall of its PersistentVolumeClaims are bound. Defaults to true.\n+optional",
+	}
+}
+
+func (SubresourceLimitsConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "SubresourceLimitsConfiguration holds the limits on the subresource usage of the clients of\nvirt-api. The limits are enforced by each virt-api replica separately.",
+		"perUser":      "PerUser limits the subresource usage of every user.\n+optional",
+		"perNamespace": "PerNamespace limits the subresource usage in every namespace.\n+optional",
+	}
+}
+
+func (SubresourceClientLimits) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "SubresourceClientLimits holds the subresource limits of a single user or namespace.",
+		"maxConcurrentStreams": "MaxConcurrentStreams is the maximum number of console, VNC, usbredir and port-forward\nstreams which can be open at the same time. Not limited if not specified.\n+optional\n+kubebuilder:validation:Minimum=1",
+		"requestsPerSecond":    "RequestsPerSecond is the sustained rate of subresource requests which is accepted.\nNot limited if not specified.\n+optional\n+kubebuilder:validation:Minimum=1",
+		"burst":                "Burst is the number of subresource requests which can be issued at once before\nRequestsPerSecond applies. Defaults to RequestsPerSecond.\n+optional\n+kubebuilder:validation:Minimum=1",
+	}
+}
+
+func (VirtualMachineExportConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "VirtualMachineExportConfiguration holds the cluster-wide expiration policy of VirtualMachineExports.",
+		"defaultTTL":    "DefaultTTL is the time to live of VirtualMachineExports which do not specify a ttlDuration.\nDefaults to 2h.\n+optional",
+		"maxLifetime":   "MaxLifetime is the maximum time a VirtualMachineExport can exist, counting from its creation\nand including its renewals. Not limited if not specified.\n+optional",
+		"autoRenew":     "AutoRenew extends the expiration of VirtualMachineExports which do not specify autoRenew\nwhile downloads are in progress. Defaults to false.\n+optional",
+		"expiryWarning": "ExpiryWarning is the time before the expiration of a VirtualMachineExport at which a\nwarning event is emitted, and at which in progress downloads renew it. Defaults to 10m.\n+optional",
 	}
 }
 
@@ -1056,6 +1261,15 @@ func (MigrationConfiguration) SwaggerDoc() map[string]string {
 		"disableTLS":                        "When set to true, DisableTLS will disable the additional layer of live migration encryption\nprovided by KubeVirt. This is usually a bad idea. Defaults to false",
 		"network":                           "Network is the name of the CNI network to use for live migrations. By default, migrations go\nthrough the pod network.",
 		"matchSELinuxLevelOnMigration":      "By default, the SELinux level of target virt-launcher pods is forced to the level of the source virt-launcher.\nWhen set to true, MatchSELinuxLevelOnMigration lets the CRI auto-assign a random level to the target.\nThat will ensure the target virt-launcher doesn't share categories with another pod on the node.\nHowever, migrations will fail when using RWX volumes that don't automatically deal with SELinux levels.",
+		"nodeHeadroom":                      "NodeHeadroom reserves a share of the allocatable resources of every node to receive live\nmigrations, e.g. during node drains. New VMIs are not scheduled on nodes whose free\ncapacity is below the headroom. Disabled by default.\n+optional",
+	}
+}
+
+func (MigrationNodeHeadroom) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "MigrationNodeHeadroom holds the share of the allocatable resources of a node reserved for incoming migrations.",
+		"cpuPercentage":    "CPUPercentage is the percentage of the allocatable CPU of a node kept free for incoming migrations.\n+kubebuilder:validation:Minimum=0\n+kubebuilder:validation:Maximum=100\n+optional",
+		"memoryPercentage": "MemoryPercentage is the percentage of the allocatable memory of a node kept free for incoming migrations.\n+kubebuilder:validation:Minimum=0\n+kubebuilder:validation:Maximum=100\n+optional",
 	}
 }
 
@@ -1077,6 +1291,7 @@ func (DeveloperConfiguration) SwaggerDoc() map[string]string {
 		"useEmulation":                    "UseEmulation can be set to true to allow fallback to software emulation\nin case hardware-assisted emulation is not available. Defaults to false",
 		"cpuAllocationRatio":              "For each requested virtual CPU, CPUAllocationRatio defines how much physical CPU to request per VMI\nfrom the hosting node. The value is in fraction of a CPU thread (or core on non-hyperthreaded nodes).\nFor example, a value of 1 means 1 physical CPU thread per VMI CPU thread.\nA value of 100 would be 1% of a physical thread allocated for each requested VMI thread.\nThis option has no effect on VMIs that request dedicated CPUs. More information at:\nhttps://kubevirt.io/user-guide/operations/node_overcommit/#node-cpu-allocation-ratio\nDefaults to 10",
 		"minimumClusterTSCFrequency":      "Allow overriding the automatically determined minimum TSC frequency of the cluster\nand fixate the minimum to this frequency.",
+		"tscFrequencyTolerancePPM":        "TSCFrequencyTolerancePPM is the deviation, in parts per million, which is accepted between\nthe TSC frequency of a VMI and the TSC frequency of a node that cannot scale it.\nThe same tolerance is used when a migration target is validated.\nQEMU refuses deviations above 250 PPM.\nDefaults to 250\n+kubebuilder:validation:Minimum:=0\n+kubebuilder:validation:Maximum:=250",
 		"clusterProfiler":                 "Enable the ability to pprof profile KubeVirt control plane",
 	}
 }
@@ -1120,7 +1335,16 @@ func (PciHostDevice) SwaggerDoc() map[string]string {
 
 func (MediatedHostDevice) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "MediatedHostDevice represents a host mediated device allowed for passthrough",
+		"":                 "MediatedHostDevice represents a host mediated device allowed for passthrough",
+		"schedulingPolicy": "SchedulingPolicy configures how the physical GPUs backing devices of\nthis type are time-sliced between the VMs using them\n+optional",
+	}
+}
+
+func (VGPUSchedulingPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "VGPUSchedulingPolicy configures the time-slicing of a physical GPU between its vGPUs",
+		"scheduler":             "Scheduler selects the time-slicing policy of the vendor driver.\nBestEffort hands the GPU to any vGPU with pending work, FixedShare\nreserves an equal share of the GPU for every vGPU, busy or not\n+kubebuilder:validation:Enum=BestEffort;FixedShare",
+		"timeSliceMicroseconds": "TimeSliceMicroseconds overrides the length of the time slice given\nto a vGPU by the vendor driver\n+optional",
 	}
 }
 
@@ -1266,6 +1490,21 @@ func (SEVSecretOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (SEVSNPAttestationReportOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "SEVSNPAttestationReportOptions is used to request an attestation report from a SEV-SNP guest.",
+		"reportData": "Base64 encoded data of up to 64 bytes to include in the report, usually a nonce chosen by the guest owner.",
+	}
+}
+
+func (SEVSNPAttestationReport) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "SEVSNPAttestationReport contains an attestation report of a SEV-SNP guest.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"report":    "Base64 encoded attestation report signed by the AMD secure processor.",
+		"certChain": "Base64 encoded certificate table provided by the host, containing the VCEK or VLEK certificate chain.",
+	}
+}
+
 func (ObjectGraphNode) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "ObjectGraphNode represents an individual node in the graph.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
@@ -1281,3 +1520,139 @@ func (ObjectGraphOptions) SwaggerDoc() map[string]string {
 		"labelSelector":        "LabelSelector is used to filter nodes in the graph based on their labels.",
 	}
 }
+
+func (TokenExchangeOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "TokenExchangeOptions holds the parameters to exchange an OIDC ID token for a subresource token.",
+		"idToken":           "IDToken is the OIDC ID token of the user the subresource token is issued for.",
+		"subresource":       "Subresource is the subresource the token grants access to, either console or vnc.",
+		"expirationSeconds": "ExpirationSeconds is the requested lifetime of the token.\nDefaults to 300 and must not exceed 600.\n+optional",
+	}
+}
+
+func (TokenExchangeResult) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "TokenExchangeResult holds a subresource token issued for a VirtualMachineInstance.",
+		"token":               "Token grants access to the requested subresource when passed in the X-KubeVirt-Subresource-Token header.",
+		"expirationTimestamp": "ExpirationTimestamp is the time the token expires.",
+	}
+}
+
+func (VirtualMachineInstanceResourceUsage) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "VirtualMachineInstanceResourceUsage is a point in time sample of the CPU and memory consumed by the guest of a VirtualMachineInstance.",
+		"timestamp":            "Timestamp is the time the sample was taken.",
+		"vcpus":                "VCPUs is the number of vCPUs of the guest.",
+		"cpuTimeNanoseconds":   "CPUTimeNanoseconds is the cumulative CPU time consumed by all vCPUs of the guest.",
+		"memoryAvailableBytes": "MemoryAvailableBytes is the amount of memory visible to the guest.",
+		"memoryUsedBytes":      "MemoryUsedBytes is the amount of memory in use by the guest as reported by the memory balloon driver.\nIt is zero if the guest does not report memory statistics.",
+	}
+}
+
+func (VirtualMachineInstanceDomainStats) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "VirtualMachineInstanceDomainStats is a point in time sample of the statistics libvirt reports for the domain of a VirtualMachineInstance.",
+		"timestamp":  "Timestamp is the time the sample was taken.",
+		"cpu":        "CPU holds the CPU time consumed by the domain.\n+optional",
+		"vcpus":      "VCPUs holds the statistics of every vCPU of the guest.\n+optional\n+listType=atomic",
+		"memory":     "Memory holds the memory and balloon statistics of the guest.\n+optional",
+		"disks":      "Disks holds the statistics of every disk of the guest.\n+optional\n+listType=atomic",
+		"interfaces": "Interfaces holds the statistics of every network interface of the guest.\n+optional\n+listType=atomic",
+	}
+}
+
+func (DomainStatsCPU) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "DomainStatsCPU holds the CPU time consumed by a domain.",
+		"timeNanoseconds":   "TimeNanoseconds is the total CPU time consumed by the domain.\n+optional",
+		"userNanoseconds":   "UserNanoseconds is the CPU time the domain spent in user mode.\n+optional",
+		"systemNanoseconds": "SystemNanoseconds is the CPU time the domain spent in kernel mode.\n+optional",
+	}
+}
+
+func (DomainStatsVCPU) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "DomainStatsVCPU holds the statistics of a single vCPU.",
+		"id":               "ID is the index of the vCPU.",
+		"state":            "State is the state of the vCPU, one of offline, running or blocked.\n+optional",
+		"timeNanoseconds":  "TimeNanoseconds is the CPU time consumed by the vCPU.\n+optional",
+		"waitNanoseconds":  "WaitNanoseconds is the time the vCPU spent waiting for I/O.\n+optional",
+		"delayNanoseconds": "DelayNanoseconds is the time the vCPU spent waiting in the run queue of the host.\n+optional",
+	}
+}
+
+func (DomainStatsMemory) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "DomainStatsMemory holds the memory and balloon statistics of a domain.\nThe statistics reported from within the guest are only set if the guest runs the memory balloon driver.",
+		"actualBalloonBytes": "ActualBalloonBytes is the current size of the memory balloon.\n+optional",
+		"availableBytes":     "AvailableBytes is the amount of memory visible to the guest.\n+optional",
+		"usableBytes":        "UsableBytes is the amount of memory the guest can use without swapping.\n+optional",
+		"unusedBytes":        "UnusedBytes is the amount of memory left completely unused by the guest.\n+optional",
+		"cachedBytes":        "CachedBytes is the amount of memory the guest uses for caches which can be reclaimed.\n+optional",
+		"rssBytes":           "RSSBytes is the resident set size of the domain on the host.\n+optional",
+		"swapInBytes":        "SwapInBytes is the amount of memory the guest read from swap space.\n+optional",
+		"swapOutBytes":       "SwapOutBytes is the amount of memory the guest wrote to swap space.\n+optional",
+		"majorPageFaults":    "MajorPageFaults is the number of page faults of the guest which required disk I/O.\n+optional",
+		"minorPageFaults":    "MinorPageFaults is the number of page faults of the guest which did not require disk I/O.\n+optional",
+	}
+}
+
+func (DomainStatsDisk) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "DomainStatsDisk holds the statistics of a single disk.",
+		"name":                 "Name is the name of the disk in the VirtualMachineInstance spec or the target device if the disk has no alias.",
+		"path":                 "Path is the path of the disk source on the host.\n+optional",
+		"readRequests":         "ReadRequests is the number of read requests.\n+optional",
+		"readBytes":            "ReadBytes is the number of bytes read.\n+optional",
+		"readTimeNanoseconds":  "ReadTimeNanoseconds is the total time spent on read requests.\n+optional",
+		"writeRequests":        "WriteRequests is the number of write requests.\n+optional",
+		"writeBytes":           "WriteBytes is the number of bytes written.\n+optional",
+		"writeTimeNanoseconds": "WriteTimeNanoseconds is the total time spent on write requests.\n+optional",
+		"flushRequests":        "FlushRequests is the number of flush requests.\n+optional",
+		"flushTimeNanoseconds": "FlushTimeNanoseconds is the total time spent on flush requests.\n+optional",
+		"errors":               "Errors is the number of failed requests.\n+optional",
+		"capacityBytes":        "CapacityBytes is the size of the disk as seen by the guest.\n+optional",
+		"allocationBytes":      "AllocationBytes is the highest offset written to the disk source.\n+optional",
+		"physicalBytes":        "PhysicalBytes is the size the disk source takes on the host.\n+optional",
+	}
+}
+
+func (DomainStatsInterface) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DomainStatsInterface holds the statistics of a single network interface.",
+		"name":      "Name is the name of the interface in the VirtualMachineInstance spec or the host side device if the interface has no alias.",
+		"rxBytes":   "RxBytes is the number of bytes received.\n+optional",
+		"rxPackets": "RxPackets is the number of packets received.\n+optional",
+		"rxErrors":  "RxErrors is the number of receive errors.\n+optional",
+		"rxDropped": "RxDropped is the number of received packets which were dropped.\n+optional",
+		"txBytes":   "TxBytes is the number of bytes transmitted.\n+optional",
+		"txPackets": "TxPackets is the number of packets transmitted.\n+optional",
+		"txErrors":  "TxErrors is the number of transmit errors.\n+optional",
+		"txDropped": "TxDropped is the number of transmitted packets which were dropped.\n+optional",
+	}
+}
+func (VirtualMachineInstanceDomain) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "VirtualMachineInstanceDomain holds the libvirt domain definition of a running VirtualMachineInstance\nnext to the definition which is generated from its current spec.",
+		"live":     "Live is the XML definition of the running domain.",
+		"expected": "Expected is the XML definition generated from the current VirtualMachineInstance spec.",
+	}
+}
+
+func (VirtualMachinePreflightReport) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachinePreflightReport is the result of simulating the start of a VirtualMachine without creating its VirtualMachineInstance.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"ready":  "Ready is true if none of the checks failed.",
+		"checks": "Checks holds the result of every check that was performed.\n+listType=atomic\n+optional",
+	}
+}
+
+func (VirtualMachinePreflightCheck) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "VirtualMachinePreflightCheck is the result of a single check of a VirtualMachine start simulation.",
+		"category": "Category is the area the check belongs to, one of Image, Storage, Device, Compute or Network.",
+		"name":     "Name identifies what was checked, e.g. the name of a volume or a network.",
+		"status":   "Status is the outcome of the check, one of Passed, Warning or Failed.",
+		"message":  "Message describes the outcome of the check.\n+optional",
+	}
+}
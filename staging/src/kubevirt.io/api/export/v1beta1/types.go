@@ -67,6 +67,16 @@ type VirtualMachineExportSpec struct {
 	// If this field is omitted, a reasonable default is applied.
 	// +optional
 	TTLDuration *metav1.Duration `json:"ttlDuration,omitempty"`
+
+	// autoRenew extends the expiration of the export by ttlDuration while downloads are in progress.
+	// If this field is omitted, the autoRenew setting of the KubeVirt configuration is applied.
+	// +optional
+	AutoRenew *bool `json:"autoRenew,omitempty"`
+
+	// maxLifetime limits the lifetime of an export including its renewals, counting from CreationTimestamp.
+	// It is capped by the maxLifetime of the KubeVirt configuration.
+	// +optional
+	MaxLifetime *metav1.Duration `json:"maxLifetime,omitempty"`
 }
 
 // VirtualMachineExportPhase is the current phase of the VirtualMachineExport
@@ -96,9 +106,14 @@ type VirtualMachineExportStatus struct {
 	TokenSecretRef *string `json:"tokenSecretRef,omitempty"`
 
 	// The time at which the VM Export will be completely removed according to specified TTL
-	// Formula is CreationTimestamp + TTL
+	// Formula is CreationTimestamp + TTL, or LastRenewalTime + TTL once renewed, capped by the max lifetime
 	TTLExpirationTime *metav1.Time `json:"ttlExpirationTime,omitempty"`
 
+	// +optional
+	// LastRenewalTime is the time at which the expiration of the VM Export was last extended
+	// because of downloads in progress
+	LastRenewalTime *metav1.Time `json:"lastRenewalTime,omitempty"`
+
 	// +optional
 	// ServiceName is the name of the service created associated with the Virtual Machine export. It will be used to
 	// create the internal URLs for downloading the images
@@ -200,6 +215,8 @@ const (
 	ConditionPVC ConditionType = "PVCReady"
 	// ConditionVolumesCreated is the condition to see if volumes are created from volume snapshots
 	ConditionVolumesCreated ConditionType = "VolumesCreated"
+	// ConditionExpiring is the condition to see if the export is about to expire
+	ConditionExpiring ConditionType = "Expiring"
 )
 
 // Condition defines conditions
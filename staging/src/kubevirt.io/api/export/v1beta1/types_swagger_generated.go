@@ -21,6 +21,8 @@ func (VirtualMachineExportSpec) SwaggerDoc() map[string]string {
 		"":               "VirtualMachineExportSpec is the spec for a VirtualMachineExport resource",
 		"tokenSecretRef": "+optional\nTokenSecretRef is the name of the custom-defined secret that contains the token used by the export server pod",
 		"ttlDuration":    "ttlDuration limits the lifetime of an export\nIf this field is set, after this duration has passed from counting from CreationTimestamp,\nthe export is eligible to be automatically deleted.\nIf this field is omitted, a reasonable default is applied.\n+optional",
+		"autoRenew":      "autoRenew extends the expiration of the export by ttlDuration while downloads are in progress.\nIf this field is omitted, the autoRenew setting of the KubeVirt configuration is applied.\n+optional",
+		"maxLifetime":    "maxLifetime limits the lifetime of an export including its renewals, counting from CreationTimestamp.\nIt is capped by the maxLifetime of the KubeVirt configuration.\n+optional",
 	}
 }
 
@@ -30,7 +32,8 @@ func (VirtualMachineExportStatus) SwaggerDoc() map[string]string {
 		"phase":              "+optional",
 		"links":              "+optional",
 		"tokenSecretRef":     "+optional\nTokenSecretRef is the name of the secret that contains the token used by the export server pod",
-		"ttlExpirationTime":  "The time at which the VM Export will be completely removed according to specified TTL\nFormula is CreationTimestamp + TTL",
+		"ttlExpirationTime":  "The time at which the VM Export will be completely removed according to specified TTL\nFormula is CreationTimestamp + TTL, or LastRenewalTime + TTL once renewed, capped by the max lifetime",
+		"lastRenewalTime":    "+optional\nLastRenewalTime is the time at which the expiration of the VM Export was last extended\nbecause of downloads in progress",
 		"serviceName":        "+optional\nServiceName is the name of the service created associated with the Virtual Machine export. It will be used to\ncreate the internal URLs for downloading the images",
 		"virtualMachineName": "+optional\nVirtualMachineName shows the name of the source virtual machine if the source is either a VirtualMachine or\na VirtualMachineSnapshot. This is mainly to easily identify the source VirtualMachine in case of a\nVirtualMachineSnapshot",
 		"conditions":         "+optional\n+listType=atomic",
@@ -335,6 +335,46 @@ func (in *FirmwarePreferences) DeepCopy() *FirmwarePreferences {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstancetypeArchitectureVariant) DeepCopyInto(out *InstancetypeArchitectureVariant) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(CPUInstancetype)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(MemoryInstancetype)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GPUs != nil {
+		in, out := &in.GPUs, &out.GPUs
+		*out = make([]v1.GPU, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostDevices != nil {
+		in, out := &in.HostDevices, &out.HostDevices
+		*out = make([]v1.HostDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstancetypeArchitectureVariant.
+func (in *InstancetypeArchitectureVariant) DeepCopy() *InstancetypeArchitectureVariant {
+	if in == nil {
+		return nil
+	}
+	out := new(InstancetypeArchitectureVariant)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePreferences) DeepCopyInto(out *MachinePreferences) {
 	*out = *in
@@ -680,6 +720,13 @@ func (in *VirtualMachineInstancetypeSpec) DeepCopyInto(out *VirtualMachineInstan
 			(*out)[key] = val
 		}
 	}
+	if in.ArchitectureVariants != nil {
+		in, out := &in.ArchitectureVariants, &out.ArchitectureVariants
+		*out = make([]InstancetypeArchitectureVariant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -125,6 +125,44 @@ type VirtualMachineInstancetypeSpec struct {
 	//
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Optionally defines architecture specific variants of the instancetype.
+	//
+	// The variant matching the architecture of the VirtualMachineInstance, or the PreferredArchitecture of the preference if the former is not set,
+	// replaces the CPU, Memory, GPUs and HostDevices it provides before the instancetype is applied.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=architecture
+	ArchitectureVariants []InstancetypeArchitectureVariant `json:"architectureVariants,omitempty"`
+}
+
+// InstancetypeArchitectureVariant contains the architecture specific configuration of a given VirtualMachineInstancetypeSpec.
+type InstancetypeArchitectureVariant struct {
+	// Required architecture the variant applies to, e.g. amd64, arm64 or s390x.
+	Architecture string `json:"architecture"`
+
+	// Optionally replaces the CPU related attributes of the instancetype.
+	//
+	// +optional
+	CPU *CPUInstancetype `json:"cpu,omitempty"`
+
+	// Optionally replaces the Memory related attributes of the instancetype.
+	//
+	// +optional
+	Memory *MemoryInstancetype `json:"memory,omitempty"`
+
+	// Optionally replaces the GPU devices associated with the instancetype.
+	//
+	// +optional
+	// +listType=atomic
+	GPUs []v1.GPU `json:"gpus,omitempty"`
+
+	// Optionally replaces the HostDevices associated with the instancetype.
+	//
+	// +optional
+	// +listType=atomic
+	HostDevices []v1.HostDevice `json:"hostDevices,omitempty"`
 }
 
 // CPUInstancetype contains the CPU related configuration of a given VirtualMachineInstancetypeSpec.
@@ -30,17 +30,29 @@ func (VirtualMachineClusterInstancetypeList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineInstancetypeSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                "VirtualMachineInstancetypeSpec is a description of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype.\n\nCPU and Memory are required attributes with both requiring that their Guest attribute is defined, ensuring a number of vCPUs and amount of RAM is always provided by each instancetype.",
-		"nodeSelector":    "NodeSelector is a selector which must be true for the vmi to fit on a node.\nSelector which must match a node's labels for the vmi to be scheduled on that node.\nMore info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/\n\nNodeSelector is the name of the custom node selector for the instancetype.\n+optional",
-		"schedulerName":   "If specified, the VMI will be dispatched by specified scheduler.\nIf not specified, the VMI will be dispatched by default scheduler.\n\nSchedulerName is the name of the custom K8s scheduler for the instancetype.\n+optional",
-		"cpu":             "Required CPU related attributes of the instancetype.",
-		"memory":          "Required Memory related attributes of the instancetype.",
-		"gpus":            "Optionally defines any GPU devices associated with the instancetype.\n\n+optional\n+listType=atomic",
-		"hostDevices":     "Optionally defines any HostDevices associated with the instancetype.\n\n+optional\n+listType=atomic",
-		"ioThreadsPolicy": "Optionally defines the IOThreadsPolicy to be used by the instancetype.\n\n+optional",
-		"ioThreads":       "Optionally specifies the IOThreads options to be used by the instancetype.\n+optional",
-		"launchSecurity":  "Optionally defines the LaunchSecurity to be used by the instancetype.\n\n+optional",
-		"annotations":     "Optionally defines the required Annotations to be used by the instance type and applied to the VirtualMachineInstance\n\n+optional",
+		"":                     "VirtualMachineInstancetypeSpec is a description of the VirtualMachineInstancetype or VirtualMachineClusterInstancetype.\n\nCPU and Memory are required attributes with both requiring that their Guest attribute is defined, ensuring a number of vCPUs and amount of RAM is always provided by each instancetype.",
+		"nodeSelector":         "NodeSelector is a selector which must be true for the vmi to fit on a node.\nSelector which must match a node's labels for the vmi to be scheduled on that node.\nMore info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/\n\nNodeSelector is the name of the custom node selector for the instancetype.\n+optional",
+		"schedulerName":        "If specified, the VMI will be dispatched by specified scheduler.\nIf not specified, the VMI will be dispatched by default scheduler.\n\nSchedulerName is the name of the custom K8s scheduler for the instancetype.\n+optional",
+		"cpu":                  "Required CPU related attributes of the instancetype.",
+		"memory":               "Required Memory related attributes of the instancetype.",
+		"gpus":                 "Optionally defines any GPU devices associated with the instancetype.\n\n+optional\n+listType=atomic",
+		"hostDevices":          "Optionally defines any HostDevices associated with the instancetype.\n\n+optional\n+listType=atomic",
+		"ioThreadsPolicy":      "Optionally defines the IOThreadsPolicy to be used by the instancetype.\n\n+optional",
+		"ioThreads":            "Optionally specifies the IOThreads options to be used by the instancetype.\n+optional",
+		"launchSecurity":       "Optionally defines the LaunchSecurity to be used by the instancetype.\n\n+optional",
+		"annotations":          "Optionally defines the required Annotations to be used by the instance type and applied to the VirtualMachineInstance\n\n+optional",
+		"architectureVariants": "Optionally defines architecture specific variants of the instancetype.\n\nThe variant matching the architecture of the VirtualMachineInstance, or the PreferredArchitecture of the preference if the former is not set,\nreplaces the CPU, Memory, GPUs and HostDevices it provides before the instancetype is applied.\n\n+optional\n+listType=map\n+listMapKey=architecture",
+	}
+}
+
+func (InstancetypeArchitectureVariant) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "InstancetypeArchitectureVariant contains the architecture specific configuration of a given VirtualMachineInstancetypeSpec.",
+		"architecture": "Required architecture the variant applies to, e.g. amd64, arm64 or s390x.",
+		"cpu":          "Optionally replaces the CPU related attributes of the instancetype.\n\n+optional",
+		"memory":       "Optionally replaces the Memory related attributes of the instancetype.\n\n+optional",
+		"gpus":         "Optionally replaces the GPU devices associated with the instancetype.\n\n+optional\n+listType=atomic",
+		"hostDevices":  "Optionally replaces the HostDevices associated with the instancetype.\n\n+optional\n+listType=atomic",
 	}
 }
 
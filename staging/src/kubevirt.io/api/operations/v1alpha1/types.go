@@ -0,0 +1,164 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtualMachineOperation applies an operation to every VirtualMachine matching a selector
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineOperation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineOperationSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineOperationStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineOperationList is a list of VirtualMachineOperation resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// +listType=atomic
+	Items []VirtualMachineOperation `json:"items"`
+}
+
+// VirtualMachineOperationType is the action applied to the targeted VirtualMachines
+type VirtualMachineOperationType string
+
+const (
+	// OperationStart starts the targeted VirtualMachines
+	OperationStart VirtualMachineOperationType = "Start"
+	// OperationStop stops the targeted VirtualMachines
+	OperationStop VirtualMachineOperationType = "Stop"
+	// OperationMigrate live migrates the targeted VirtualMachines
+	OperationMigrate VirtualMachineOperationType = "Migrate"
+	// OperationSnapshot takes a snapshot of the targeted VirtualMachines
+	OperationSnapshot VirtualMachineOperationType = "Snapshot"
+)
+
+// VirtualMachineOperationSpec is the spec for a VirtualMachineOperation resource
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable after creation"
+type VirtualMachineOperationSpec struct {
+	// Operation is the action applied to each targeted VirtualMachine
+	// +kubebuilder:validation:Enum=Start;Stop;Migrate;Snapshot
+	Operation VirtualMachineOperationType `json:"operation"`
+	// Selector selects the VirtualMachines in the namespace of the operation.
+	// The targets are resolved once, when the operation starts.
+	Selector metav1.LabelSelector `json:"selector"`
+	// MaxConcurrency is the maximum number of targets processed at the same time.
+	// Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrency *int32 `json:"maxConcurrency,omitempty"`
+	// MaxFailures is the number of failed targets tolerated. Once more targets
+	// failed, no further targets are started and the remaining ones are skipped.
+	// If unset, all targets are processed regardless of failures.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxFailures *int32 `json:"maxFailures,omitempty"`
+	// TargetTimeout is the time a single target may take before it is considered failed.
+	// Defaults to 10 minutes.
+	// +optional
+	TargetTimeout *metav1.Duration `json:"targetTimeout,omitempty"`
+}
+
+// VirtualMachineOperationPhase is the current phase of a VirtualMachineOperation
+type VirtualMachineOperationPhase string
+
+const (
+	// OperationPending means the targets were not resolved yet
+	OperationPending VirtualMachineOperationPhase = "Pending"
+	// OperationRunning means targets are being processed
+	OperationRunning VirtualMachineOperationPhase = "Running"
+	// OperationSucceeded means the operation succeeded on all targets
+	OperationSucceeded VirtualMachineOperationPhase = "Succeeded"
+	// OperationPartiallyFailed means all targets were processed and some of them failed
+	OperationPartiallyFailed VirtualMachineOperationPhase = "PartiallyFailed"
+	// OperationFailed means the operation failed on all targets or was aborted
+	// because MaxFailures was exceeded
+	OperationFailed VirtualMachineOperationPhase = "Failed"
+)
+
+// VirtualMachineOperationStatus is the status for a VirtualMachineOperation resource
+type VirtualMachineOperationStatus struct {
+	// +optional
+	Phase VirtualMachineOperationPhase `json:"phase,omitempty"`
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// +optional
+	Progress VirtualMachineOperationProgress `json:"progress,omitempty"`
+	// Targets holds the result of the operation for each targeted VirtualMachine
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Targets []VirtualMachineOperationTarget `json:"targets,omitempty"`
+}
+
+// VirtualMachineOperationProgress counts the targets of a VirtualMachineOperation by phase
+type VirtualMachineOperationProgress struct {
+	Total     int32 `json:"total"`
+	Pending   int32 `json:"pending"`
+	Running   int32 `json:"running"`
+	Succeeded int32 `json:"succeeded"`
+	Failed    int32 `json:"failed"`
+	Skipped   int32 `json:"skipped"`
+}
+
+// VirtualMachineOperationTargetPhase is the phase of a single target of a VirtualMachineOperation
+type VirtualMachineOperationTargetPhase string
+
+const (
+	// TargetPending means the operation was not started on the target yet
+	TargetPending VirtualMachineOperationTargetPhase = "Pending"
+	// TargetRunning means the operation was started on the target and did not complete yet
+	TargetRunning VirtualMachineOperationTargetPhase = "Running"
+	// TargetSucceeded means the operation completed on the target
+	TargetSucceeded VirtualMachineOperationTargetPhase = "Succeeded"
+	// TargetFailed means the operation failed on the target
+	TargetFailed VirtualMachineOperationTargetPhase = "Failed"
+	// TargetSkipped means the target was not processed because MaxFailures was exceeded
+	TargetSkipped VirtualMachineOperationTargetPhase = "Skipped"
+)
+
+// VirtualMachineOperationTarget is the result of a VirtualMachineOperation for a single VirtualMachine
+type VirtualMachineOperationTarget struct {
+	// Name of the targeted VirtualMachine
+	Name  string                             `json:"name"`
+	Phase VirtualMachineOperationTargetPhase `json:"phase"`
+	// Reference points to the object created for the target, e.g. the migration or the snapshot
+	// +optional
+	Reference *corev1.TypedLocalObjectReference `json:"reference,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
@@ -72,6 +72,42 @@ func (in *Error) DeepCopy() *Error {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityPolicy) DeepCopyInto(out *IdentityPolicy) {
+	*out = *in
+	if in.MacAddresses != nil {
+		in, out := &in.MacAddresses, &out.MacAddresses
+		*out = new(IdentityRegenerationPolicy)
+		**out = **in
+	}
+	if in.SMBiosSerial != nil {
+		in, out := &in.SMBiosSerial, &out.SMBiosSerial
+		*out = new(IdentityRegenerationPolicy)
+		**out = **in
+	}
+	if in.FirmwareUUID != nil {
+		in, out := &in.FirmwareUUID, &out.FirmwareUUID
+		*out = new(IdentityRegenerationPolicy)
+		**out = **in
+	}
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(IdentityRegenerationPolicy)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityPolicy.
+func (in *IdentityPolicy) DeepCopy() *IdentityPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PersistentVolumeClaim) DeepCopyInto(out *PersistentVolumeClaim) {
 	*out = *in
@@ -263,6 +299,11 @@ func (in *VirtualMachineRestoreSpec) DeepCopyInto(out *VirtualMachineRestoreSpec
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.IdentityPolicy != nil {
+		in, out := &in.IdentityPolicy, &out.IdentityPolicy
+		*out = new(IdentityPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Patches != nil {
 		in, out := &in.Patches, &out.Patches
 		*out = make([]string, len(*in))
@@ -535,6 +576,16 @@ func (in *VirtualMachineSnapshotSpec) DeepCopyInto(out *VirtualMachineSnapshotSp
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.IncludeMemory != nil {
+		in, out := &in.IncludeMemory, &out.IncludeMemory
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MemoryStorageClassName != nil {
+		in, out := &in.MemoryStorageClassName, &out.MemoryStorageClassName
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
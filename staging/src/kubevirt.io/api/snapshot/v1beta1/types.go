@@ -72,6 +72,16 @@ type VirtualMachineSnapshotSpec struct {
 	// Defaults to DefaultFailureDeadline - 5min
 	// +optional
 	FailureDeadline *metav1.Duration `json:"failureDeadline,omitempty"`
+
+	// IncludeMemory captures the memory state of the VM in the snapshot, the VM has to be paused.
+	// Restoring the snapshot resumes the VM from the memory state instead of booting it.
+	// +optional
+	IncludeMemory *bool `json:"includeMemory,omitempty"`
+
+	// MemoryStorageClassName is the storage class of the PVC the memory state is saved to.
+	// The default storage class is used if not set.
+	// +optional
+	MemoryStorageClassName *string `json:"memoryStorageClassName,omitempty"`
 }
 
 // Indication is a way to indicate the state of the vm when taking the snapshot
@@ -83,6 +93,7 @@ const (
 	VMSnapshotGuestAgentIndication     Indication = "GuestAgent"
 	VMSnapshotQuiesceTimeoutIndication Indication = "QuiesceTimeout"
 	VMSnapshotPausedIndication         Indication = "Paused"
+	VMSnapshotMemoryIndication         Indication = "Memory"
 )
 
 // SourceIndication provides an indication of the source VM with its description message
@@ -379,6 +390,44 @@ const (
 	VolumeOwnershipPolicyNone VolumeOwnershipPolicy = "None"
 )
 
+// IdentityRegenerationPolicy defines whether an identity attribute of the source VM is kept or regenerated
+type IdentityRegenerationPolicy string
+
+const (
+	// IdentityPreserve keeps the attribute as it was in the source VM
+	IdentityPreserve IdentityRegenerationPolicy = "Preserve"
+
+	// IdentityRegenerate drops the attribute from the target VM so that a new value is generated for it
+	IdentityRegenerate IdentityRegenerationPolicy = "Regenerate"
+)
+
+// IdentityPolicy defines which identity attributes of the source VM are regenerated versus preserved
+// when a VM is restored from a snapshot or cloned. Attributes that are not set fall back to the
+// default of the operation: a restore preserves all of them, a clone regenerates all of them but the hostname.
+type IdentityPolicy struct {
+	// MacAddresses controls the MAC addresses of the VM interfaces.
+	// Regenerated addresses are assigned by the cluster, e.g. by KubeMacPool.
+	// +optional
+	// +kubebuilder:validation:Enum=Preserve;Regenerate
+	MacAddresses *IdentityRegenerationPolicy `json:"macAddresses,omitempty"`
+
+	// SMBiosSerial controls the SMBIOS serial number of the VM.
+	// +optional
+	// +kubebuilder:validation:Enum=Preserve;Regenerate
+	SMBiosSerial *IdentityRegenerationPolicy `json:"smbiosSerial,omitempty"`
+
+	// FirmwareUUID controls the SMBIOS system UUID of the VM, which guests commonly use as a hint
+	// to initialize their machine-id. A regenerated UUID is derived from the target VM name.
+	// +optional
+	// +kubebuilder:validation:Enum=Preserve;Regenerate
+	FirmwareUUID *IdentityRegenerationPolicy `json:"firmwareUUID,omitempty"`
+
+	// Hostname controls the hostname of the VM. A regenerated hostname defaults to the target VM name.
+	// +optional
+	// +kubebuilder:validation:Enum=Preserve;Regenerate
+	Hostname *IdentityRegenerationPolicy `json:"hostname,omitempty"`
+}
+
 // VirtualMachineRestoreSpec is the spec for a VirtualMachineRestore resource
 type VirtualMachineRestoreSpec struct {
 	// initially only VirtualMachine type supported
@@ -401,6 +450,11 @@ type VirtualMachineRestoreSpec struct {
 	// +listType=atomic
 	VolumeRestoreOverrides []VolumeRestoreOverride `json:"volumeRestoreOverrides,omitempty"`
 
+	// IdentityPolicy defines which identity attributes of the snapshotted VM are regenerated in the restored VM.
+	// All of them are preserved by default.
+	// +optional
+	IdentityPolicy *IdentityPolicy `json:"identityPolicy,omitempty"`
+
 	// If the target for the restore does not exist, it will be created. Patches holds JSON patches that would be
 	// applied to the target manifest before it's created. Patches should fit the target's Kind.
 	//
@@ -11,9 +11,11 @@ func (VirtualMachineSnapshot) SwaggerDoc() map[string]string {
 
 func (VirtualMachineSnapshotSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                "VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot resource",
-		"deletionPolicy":  "+optional",
-		"failureDeadline": "This time represents the number of seconds we permit the vm snapshot\nto take. In case we pass this deadline we mark this snapshot\nas failed.\nDefaults to DefaultFailureDeadline - 5min\n+optional",
+		"":                       "VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot resource",
+		"deletionPolicy":         "+optional",
+		"failureDeadline":        "This time represents the number of seconds we permit the vm snapshot\nto take. In case we pass this deadline we mark this snapshot\nas failed.\nDefaults to DefaultFailureDeadline - 5min\n+optional",
+		"includeMemory":          "IncludeMemory captures the memory state of the VM in the snapshot, the VM has to be paused.\nRestoring the snapshot resumes the VM from the memory state instead of booting it.\n+optional",
+		"memoryStorageClassName": "MemoryStorageClassName is the storage class of the PVC the memory state is saved to.\nThe default storage class is used if not set.\n+optional",
 	}
 }
 
@@ -146,6 +148,16 @@ func (VirtualMachineRestore) SwaggerDoc() map[string]string {
 	}
 }
 
+func (IdentityPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "IdentityPolicy defines which identity attributes of the source VM are regenerated versus preserved\nwhen a VM is restored from a snapshot or cloned. Attributes that are not set fall back to the\ndefault of the operation: a restore preserves all of them, a clone regenerates all of them but the hostname.",
+		"macAddresses": "MacAddresses controls the MAC addresses of the VM interfaces.\nRegenerated addresses are assigned by the cluster, e.g. by KubeMacPool.\n+optional\n+kubebuilder:validation:Enum=Preserve;Regenerate",
+		"smbiosSerial": "SMBiosSerial controls the SMBIOS serial number of the VM.\n+optional\n+kubebuilder:validation:Enum=Preserve;Regenerate",
+		"firmwareUUID": "FirmwareUUID controls the SMBIOS system UUID of the VM, which guests commonly use as a hint\nto initialize their machine-id. A regenerated UUID is derived from the target VM name.\n+optional\n+kubebuilder:validation:Enum=Preserve;Regenerate",
+		"hostname":     "Hostname controls the hostname of the VM. A regenerated hostname defaults to the target VM name.\n+optional\n+kubebuilder:validation:Enum=Preserve;Regenerate",
+	}
+}
+
 func (VirtualMachineRestoreSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                       "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestore resource",
@@ -154,6 +166,7 @@ func (VirtualMachineRestoreSpec) SwaggerDoc() map[string]string {
 		"volumeRestorePolicy":    "+optional",
 		"volumeOwnershipPolicy":  "+optional",
 		"volumeRestoreOverrides": "VolumeRestoreOverrides gives the option to change properties of each restored volume\nFor example, specifying the name of the restored volume, or adding labels/annotations to it\n+optional\n+listType=atomic",
+		"identityPolicy":         "IdentityPolicy defines which identity attributes of the snapshotted VM are regenerated in the restored VM.\nAll of them are preserved by default.\n+optional",
 		"patches":                "If the target for the restore does not exist, it will be created. Patches holds JSON patches that would be\napplied to the target manifest before it's created. Patches should fit the target's Kind.\n\nExample for a patch: {\"op\": \"replace\", \"path\": \"/metadata/name\", \"value\": \"new-vm-name\"}\n\n+optional\n+listType=atomic",
 	}
 }
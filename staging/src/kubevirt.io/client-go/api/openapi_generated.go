@@ -345,6 +345,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/clone/v1alpha1.VirtualMachineCloneTemplateFilters":                               schema_kubevirtio_api_clone_v1alpha1_VirtualMachineCloneTemplateFilters(ref),
 		"kubevirt.io/api/clone/v1beta1.Condition":                                                         schema_kubevirtio_api_clone_v1beta1_Condition(ref),
 		"kubevirt.io/api/clone/v1beta1.VirtualMachineClone":                                               schema_kubevirtio_api_clone_v1beta1_VirtualMachineClone(ref),
+		"kubevirt.io/api/clone/v1beta1.VirtualMachineCloneCustomization":                                  schema_kubevirtio_api_clone_v1beta1_VirtualMachineCloneCustomization(ref),
 		"kubevirt.io/api/clone/v1beta1.VirtualMachineCloneList":                                           schema_kubevirtio_api_clone_v1beta1_VirtualMachineCloneList(ref),
 		"kubevirt.io/api/clone/v1beta1.VirtualMachineCloneSpec":                                           schema_kubevirtio_api_clone_v1beta1_VirtualMachineCloneSpec(ref),
 		"kubevirt.io/api/clone/v1beta1.VirtualMachineCloneStatus":                                         schema_kubevirtio_api_clone_v1beta1_VirtualMachineCloneStatus(ref),
@@ -359,9 +360,13 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.BIOS":                                                                    schema_kubevirtio_api_core_v1_BIOS(ref),
 		"kubevirt.io/api/core/v1.BlockSize":                                                               schema_kubevirtio_api_core_v1_BlockSize(ref),
 		"kubevirt.io/api/core/v1.Bootloader":                                                              schema_kubevirtio_api_core_v1_Bootloader(ref),
+		"kubevirt.io/api/core/v1.BulkLifecycleOptions":                                                    schema_kubevirtio_api_core_v1_BulkLifecycleOptions(ref),
+		"kubevirt.io/api/core/v1.BulkLifecycleResult":                                                     schema_kubevirtio_api_core_v1_BulkLifecycleResult(ref),
+		"kubevirt.io/api/core/v1.BulkLifecycleVirtualMachineResult":                                       schema_kubevirtio_api_core_v1_BulkLifecycleVirtualMachineResult(ref),
 		"kubevirt.io/api/core/v1.CDRomTarget":                                                             schema_kubevirtio_api_core_v1_CDRomTarget(ref),
 		"kubevirt.io/api/core/v1.CPU":                                                                     schema_kubevirtio_api_core_v1_CPU(ref),
 		"kubevirt.io/api/core/v1.CPUFeature":                                                              schema_kubevirtio_api_core_v1_CPUFeature(ref),
+		"kubevirt.io/api/core/v1.CPUThreadShares":                                                         schema_kubevirtio_api_core_v1_CPUThreadShares(ref),
 		"kubevirt.io/api/core/v1.CPUTopology":                                                             schema_kubevirtio_api_core_v1_CPUTopology(ref),
 		"kubevirt.io/api/core/v1.CertConfig":                                                              schema_kubevirtio_api_core_v1_CertConfig(ref),
 		"kubevirt.io/api/core/v1.ChangedBlockTrackingSelectors":                                           schema_kubevirtio_api_core_v1_ChangedBlockTrackingSelectors(ref),
@@ -376,6 +381,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.CloudInitNoCloudSource":                                                  schema_kubevirtio_api_core_v1_CloudInitNoCloudSource(ref),
 		"kubevirt.io/api/core/v1.ClusterProfilerRequest":                                                  schema_kubevirtio_api_core_v1_ClusterProfilerRequest(ref),
 		"kubevirt.io/api/core/v1.ClusterProfilerResults":                                                  schema_kubevirtio_api_core_v1_ClusterProfilerResults(ref),
+		"kubevirt.io/api/core/v1.ClusterRecoveryConfiguration":                                            schema_kubevirtio_api_core_v1_ClusterRecoveryConfiguration(ref),
+		"kubevirt.io/api/core/v1.ClusterRecoveryStatus":                                                   schema_kubevirtio_api_core_v1_ClusterRecoveryStatus(ref),
 		"kubevirt.io/api/core/v1.CommonInstancetypesDeployment":                                           schema_kubevirtio_api_core_v1_CommonInstancetypesDeployment(ref),
 		"kubevirt.io/api/core/v1.ComponentConfig":                                                         schema_kubevirtio_api_core_v1_ComponentConfig(ref),
 		"kubevirt.io/api/core/v1.ConfidentialComputeConfiguration":                                        schema_kubevirtio_api_core_v1_ConfidentialComputeConfiguration(ref),
@@ -389,6 +396,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.CustomProfile":                                                           schema_kubevirtio_api_core_v1_CustomProfile(ref),
 		"kubevirt.io/api/core/v1.CustomizeComponents":                                                     schema_kubevirtio_api_core_v1_CustomizeComponents(ref),
 		"kubevirt.io/api/core/v1.CustomizeComponentsPatch":                                                schema_kubevirtio_api_core_v1_CustomizeComponentsPatch(ref),
+		"kubevirt.io/api/core/v1.DHCPNetBIOSOptions":                                                      schema_kubevirtio_api_core_v1_DHCPNetBIOSOptions(ref),
 		"kubevirt.io/api/core/v1.DHCPOptions":                                                             schema_kubevirtio_api_core_v1_DHCPOptions(ref),
 		"kubevirt.io/api/core/v1.DHCPPrivateOptions":                                                      schema_kubevirtio_api_core_v1_DHCPPrivateOptions(ref),
 		"kubevirt.io/api/core/v1.DataVolumeSource":                                                        schema_kubevirtio_api_core_v1_DataVolumeSource(ref),
@@ -413,9 +421,16 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.DiskVerification":                                                        schema_kubevirtio_api_core_v1_DiskVerification(ref),
 		"kubevirt.io/api/core/v1.DomainMemoryDumpInfo":                                                    schema_kubevirtio_api_core_v1_DomainMemoryDumpInfo(ref),
 		"kubevirt.io/api/core/v1.DomainSpec":                                                              schema_kubevirtio_api_core_v1_DomainSpec(ref),
+		"kubevirt.io/api/core/v1.DomainStatsCPU":                                                          schema_kubevirtio_api_core_v1_DomainStatsCPU(ref),
+		"kubevirt.io/api/core/v1.DomainStatsDisk":                                                         schema_kubevirtio_api_core_v1_DomainStatsDisk(ref),
+		"kubevirt.io/api/core/v1.DomainStatsInterface":                                                    schema_kubevirtio_api_core_v1_DomainStatsInterface(ref),
+		"kubevirt.io/api/core/v1.DomainStatsMemory":                                                       schema_kubevirtio_api_core_v1_DomainStatsMemory(ref),
+		"kubevirt.io/api/core/v1.DomainStatsVCPU":                                                         schema_kubevirtio_api_core_v1_DomainStatsVCPU(ref),
 		"kubevirt.io/api/core/v1.DownwardAPIVolumeSource":                                                 schema_kubevirtio_api_core_v1_DownwardAPIVolumeSource(ref),
 		"kubevirt.io/api/core/v1.DownwardMetrics":                                                         schema_kubevirtio_api_core_v1_DownwardMetrics(ref),
 		"kubevirt.io/api/core/v1.DownwardMetricsVolumeSource":                                             schema_kubevirtio_api_core_v1_DownwardMetricsVolumeSource(ref),
+		"kubevirt.io/api/core/v1.DryRunChange":                                                            schema_kubevirtio_api_core_v1_DryRunChange(ref),
+		"kubevirt.io/api/core/v1.DryRunResult":                                                            schema_kubevirtio_api_core_v1_DryRunResult(ref),
 		"kubevirt.io/api/core/v1.EFI":                                                                     schema_kubevirtio_api_core_v1_EFI(ref),
 		"kubevirt.io/api/core/v1.EmptyDiskSource":                                                         schema_kubevirtio_api_core_v1_EmptyDiskSource(ref),
 		"kubevirt.io/api/core/v1.EphemeralVolumeSource":                                                   schema_kubevirtio_api_core_v1_EphemeralVolumeSource(ref),
@@ -434,7 +449,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.FreezeUnfreezeTimeout":                                                   schema_kubevirtio_api_core_v1_FreezeUnfreezeTimeout(ref),
 		"kubevirt.io/api/core/v1.GPU":                                                                     schema_kubevirtio_api_core_v1_GPU(ref),
 		"kubevirt.io/api/core/v1.GenerationStatus":                                                        schema_kubevirtio_api_core_v1_GenerationStatus(ref),
+		"kubevirt.io/api/core/v1.GuestAgentAlternative":                                                   schema_kubevirtio_api_core_v1_GuestAgentAlternative(ref),
 		"kubevirt.io/api/core/v1.GuestAgentCommandInfo":                                                   schema_kubevirtio_api_core_v1_GuestAgentCommandInfo(ref),
+		"kubevirt.io/api/core/v1.GuestAgentConfiguration":                                                 schema_kubevirtio_api_core_v1_GuestAgentConfiguration(ref),
+		"kubevirt.io/api/core/v1.GuestAgentDataPolicy":                                                    schema_kubevirtio_api_core_v1_GuestAgentDataPolicy(ref),
 		"kubevirt.io/api/core/v1.GuestAgentPing":                                                          schema_kubevirtio_api_core_v1_GuestAgentPing(ref),
 		"kubevirt.io/api/core/v1.HPETTimer":                                                               schema_kubevirtio_api_core_v1_HPETTimer(ref),
 		"kubevirt.io/api/core/v1.Handler":                                                                 schema_kubevirtio_api_core_v1_Handler(ref),
@@ -477,6 +495,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.KubeVirtWorkloadUpdateStrategy":                                          schema_kubevirtio_api_core_v1_KubeVirtWorkloadUpdateStrategy(ref),
 		"kubevirt.io/api/core/v1.LaunchSecurity":                                                          schema_kubevirtio_api_core_v1_LaunchSecurity(ref),
 		"kubevirt.io/api/core/v1.LiveUpdateConfiguration":                                                 schema_kubevirtio_api_core_v1_LiveUpdateConfiguration(ref),
+		"kubevirt.io/api/core/v1.LiveUpdatedResources":                                                    schema_kubevirtio_api_core_v1_LiveUpdatedResources(ref),
 		"kubevirt.io/api/core/v1.LogVerbosity":                                                            schema_kubevirtio_api_core_v1_LogVerbosity(ref),
 		"kubevirt.io/api/core/v1.LunTarget":                                                               schema_kubevirtio_api_core_v1_LunTarget(ref),
 		"kubevirt.io/api/core/v1.Machine":                                                                 schema_kubevirtio_api_core_v1_Machine(ref),
@@ -487,6 +506,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.MemoryStatus":                                                            schema_kubevirtio_api_core_v1_MemoryStatus(ref),
 		"kubevirt.io/api/core/v1.MigrateOptions":                                                          schema_kubevirtio_api_core_v1_MigrateOptions(ref),
 		"kubevirt.io/api/core/v1.MigrationConfiguration":                                                  schema_kubevirtio_api_core_v1_MigrationConfiguration(ref),
+		"kubevirt.io/api/core/v1.MigrationNodeHeadroom":                                                   schema_kubevirtio_api_core_v1_MigrationNodeHeadroom(ref),
 		"kubevirt.io/api/core/v1.MultusNetwork":                                                           schema_kubevirtio_api_core_v1_MultusNetwork(ref),
 		"kubevirt.io/api/core/v1.NUMA":                                                                    schema_kubevirtio_api_core_v1_NUMA(ref),
 		"kubevirt.io/api/core/v1.NUMAGuestMappingPassthrough":                                             schema_kubevirtio_api_core_v1_NUMAGuestMappingPassthrough(ref),
@@ -519,6 +539,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.Realtime":                                                                schema_kubevirtio_api_core_v1_Realtime(ref),
 		"kubevirt.io/api/core/v1.ReloadableComponentConfiguration":                                        schema_kubevirtio_api_core_v1_ReloadableComponentConfiguration(ref),
 		"kubevirt.io/api/core/v1.RemoveVolumeOptions":                                                     schema_kubevirtio_api_core_v1_RemoveVolumeOptions(ref),
+		"kubevirt.io/api/core/v1.RenameOptions":                                                           schema_kubevirtio_api_core_v1_RenameOptions(ref),
 		"kubevirt.io/api/core/v1.ReservedOverhead":                                                        schema_kubevirtio_api_core_v1_ReservedOverhead(ref),
 		"kubevirt.io/api/core/v1.ResourceRequirements":                                                    schema_kubevirtio_api_core_v1_ResourceRequirements(ref),
 		"kubevirt.io/api/core/v1.ResourceRequirementsWithoutClaims":                                       schema_kubevirtio_api_core_v1_ResourceRequirementsWithoutClaims(ref),
@@ -530,6 +551,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.SEVPlatformInfo":                                                         schema_kubevirtio_api_core_v1_SEVPlatformInfo(ref),
 		"kubevirt.io/api/core/v1.SEVPolicy":                                                               schema_kubevirtio_api_core_v1_SEVPolicy(ref),
 		"kubevirt.io/api/core/v1.SEVSNP":                                                                  schema_kubevirtio_api_core_v1_SEVSNP(ref),
+		"kubevirt.io/api/core/v1.SEVSNPAttestationReport":                                                 schema_kubevirtio_api_core_v1_SEVSNPAttestationReport(ref),
+		"kubevirt.io/api/core/v1.SEVSNPAttestationReportOptions":                                          schema_kubevirtio_api_core_v1_SEVSNPAttestationReportOptions(ref),
 		"kubevirt.io/api/core/v1.SEVSecretOptions":                                                        schema_kubevirtio_api_core_v1_SEVSecretOptions(ref),
 		"kubevirt.io/api/core/v1.SEVSessionOptions":                                                       schema_kubevirtio_api_core_v1_SEVSessionOptions(ref),
 		"kubevirt.io/api/core/v1.SMBiosConfiguration":                                                     schema_kubevirtio_api_core_v1_SMBiosConfiguration(ref),
@@ -540,10 +563,14 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.SeccompConfiguration":                                                    schema_kubevirtio_api_core_v1_SeccompConfiguration(ref),
 		"kubevirt.io/api/core/v1.SecretVolumeSource":                                                      schema_kubevirtio_api_core_v1_SecretVolumeSource(ref),
 		"kubevirt.io/api/core/v1.ServiceAccountVolumeSource":                                              schema_kubevirtio_api_core_v1_ServiceAccountVolumeSource(ref),
+		"kubevirt.io/api/core/v1.SoftRebootOptions":                                                       schema_kubevirtio_api_core_v1_SoftRebootOptions(ref),
 		"kubevirt.io/api/core/v1.SoundDevice":                                                             schema_kubevirtio_api_core_v1_SoundDevice(ref),
+		"kubevirt.io/api/core/v1.SoundStream":                                                             schema_kubevirtio_api_core_v1_SoundStream(ref),
 		"kubevirt.io/api/core/v1.StartOptions":                                                            schema_kubevirtio_api_core_v1_StartOptions(ref),
 		"kubevirt.io/api/core/v1.StopOptions":                                                             schema_kubevirtio_api_core_v1_StopOptions(ref),
 		"kubevirt.io/api/core/v1.StorageMigratedVolumeInfo":                                               schema_kubevirtio_api_core_v1_StorageMigratedVolumeInfo(ref),
+		"kubevirt.io/api/core/v1.SubresourceClientLimits":                                                 schema_kubevirtio_api_core_v1_SubresourceClientLimits(ref),
+		"kubevirt.io/api/core/v1.SubresourceLimitsConfiguration":                                          schema_kubevirtio_api_core_v1_SubresourceLimitsConfiguration(ref),
 		"kubevirt.io/api/core/v1.SupportContainerResources":                                               schema_kubevirtio_api_core_v1_SupportContainerResources(ref),
 		"kubevirt.io/api/core/v1.SyNICTimer":                                                              schema_kubevirtio_api_core_v1_SyNICTimer(ref),
 		"kubevirt.io/api/core/v1.SysprepSource":                                                           schema_kubevirtio_api_core_v1_SysprepSource(ref),
@@ -555,6 +582,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.TPMDevice":                                                               schema_kubevirtio_api_core_v1_TPMDevice(ref),
 		"kubevirt.io/api/core/v1.Timer":                                                                   schema_kubevirtio_api_core_v1_Timer(ref),
 		"kubevirt.io/api/core/v1.TokenBucketRateLimiter":                                                  schema_kubevirtio_api_core_v1_TokenBucketRateLimiter(ref),
+		"kubevirt.io/api/core/v1.TokenExchangeOptions":                                                    schema_kubevirtio_api_core_v1_TokenExchangeOptions(ref),
+		"kubevirt.io/api/core/v1.TokenExchangeResult":                                                     schema_kubevirtio_api_core_v1_TokenExchangeResult(ref),
 		"kubevirt.io/api/core/v1.TopologyHints":                                                           schema_kubevirtio_api_core_v1_TopologyHints(ref),
 		"kubevirt.io/api/core/v1.USBHostDevice":                                                           schema_kubevirtio_api_core_v1_USBHostDevice(ref),
 		"kubevirt.io/api/core/v1.USBSelector":                                                             schema_kubevirtio_api_core_v1_USBSelector(ref),
@@ -565,16 +594,23 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.UtilityVolume":                                                           schema_kubevirtio_api_core_v1_UtilityVolume(ref),
 		"kubevirt.io/api/core/v1.VGPUDisplayOptions":                                                      schema_kubevirtio_api_core_v1_VGPUDisplayOptions(ref),
 		"kubevirt.io/api/core/v1.VGPUOptions":                                                             schema_kubevirtio_api_core_v1_VGPUOptions(ref),
+		"kubevirt.io/api/core/v1.VGPUSchedulingPolicy":                                                    schema_kubevirtio_api_core_v1_VGPUSchedulingPolicy(ref),
 		"kubevirt.io/api/core/v1.VMISelector":                                                             schema_kubevirtio_api_core_v1_VMISelector(ref),
 		"kubevirt.io/api/core/v1.VSOCKOptions":                                                            schema_kubevirtio_api_core_v1_VSOCKOptions(ref),
+		"kubevirt.io/api/core/v1.VSOCKService":                                                            schema_kubevirtio_api_core_v1_VSOCKService(ref),
+		"kubevirt.io/api/core/v1.VSOCKServiceStatus":                                                      schema_kubevirtio_api_core_v1_VSOCKServiceStatus(ref),
 		"kubevirt.io/api/core/v1.VideoDevice":                                                             schema_kubevirtio_api_core_v1_VideoDevice(ref),
 		"kubevirt.io/api/core/v1.VirtTemplateDeployment":                                                  schema_kubevirtio_api_core_v1_VirtTemplateDeployment(ref),
 		"kubevirt.io/api/core/v1.VirtualMachine":                                                          schema_kubevirtio_api_core_v1_VirtualMachine(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineCondition":                                                 schema_kubevirtio_api_core_v1_VirtualMachineCondition(ref),
+		"kubevirt.io/api/core/v1.VirtualMachineExportConfiguration":                                       schema_kubevirtio_api_core_v1_VirtualMachineExportConfiguration(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstance":                                                  schema_kubevirtio_api_core_v1_VirtualMachineInstance(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceBackupStatus":                                      schema_kubevirtio_api_core_v1_VirtualMachineInstanceBackupStatus(ref),
+		"kubevirt.io/api/core/v1.VirtualMachineInstanceBootTimes":                                         schema_kubevirtio_api_core_v1_VirtualMachineInstanceBootTimes(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceCommonMigrationState":                              schema_kubevirtio_api_core_v1_VirtualMachineInstanceCommonMigrationState(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceCondition":                                         schema_kubevirtio_api_core_v1_VirtualMachineInstanceCondition(ref),
+		"kubevirt.io/api/core/v1.VirtualMachineInstanceDomain":                                            schema_kubevirtio_api_core_v1_VirtualMachineInstanceDomain(ref),
+		"kubevirt.io/api/core/v1.VirtualMachineInstanceDomainStats":                                       schema_kubevirtio_api_core_v1_VirtualMachineInstanceDomainStats(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceFileSystem":                                        schema_kubevirtio_api_core_v1_VirtualMachineInstanceFileSystem(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceFileSystemDisk":                                    schema_kubevirtio_api_core_v1_VirtualMachineInstanceFileSystemDisk(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceFileSystemInfo":                                    schema_kubevirtio_api_core_v1_VirtualMachineInstanceFileSystemInfo(ref),
@@ -595,6 +631,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationStatus":                                   schema_kubevirtio_api_core_v1_VirtualMachineInstanceMigrationStatus(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationTarget":                                   schema_kubevirtio_api_core_v1_VirtualMachineInstanceMigrationTarget(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationTargetState":                              schema_kubevirtio_api_core_v1_VirtualMachineInstanceMigrationTargetState(ref),
+		"kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationTransferStatus":                           schema_kubevirtio_api_core_v1_VirtualMachineInstanceMigrationTransferStatus(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceNetworkInterface":                                  schema_kubevirtio_api_core_v1_VirtualMachineInstanceNetworkInterface(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstancePhaseTransitionTimestamp":                          schema_kubevirtio_api_core_v1_VirtualMachineInstancePhaseTransitionTimestamp(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstancePreset":                                            schema_kubevirtio_api_core_v1_VirtualMachineInstancePreset(ref),
@@ -606,12 +643,17 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceReplicaSetList":                                    schema_kubevirtio_api_core_v1_VirtualMachineInstanceReplicaSetList(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceReplicaSetSpec":                                    schema_kubevirtio_api_core_v1_VirtualMachineInstanceReplicaSetSpec(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceReplicaSetStatus":                                  schema_kubevirtio_api_core_v1_VirtualMachineInstanceReplicaSetStatus(ref),
+		"kubevirt.io/api/core/v1.VirtualMachineInstanceResourceUsage":                                     schema_kubevirtio_api_core_v1_VirtualMachineInstanceResourceUsage(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceSpec":                                              schema_kubevirtio_api_core_v1_VirtualMachineInstanceSpec(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceStatus":                                            schema_kubevirtio_api_core_v1_VirtualMachineInstanceStatus(ref),
+		"kubevirt.io/api/core/v1.VirtualMachineInstanceSummary":                                           schema_kubevirtio_api_core_v1_VirtualMachineInstanceSummary(ref),
+		"kubevirt.io/api/core/v1.VirtualMachineInstanceSummaryList":                                       schema_kubevirtio_api_core_v1_VirtualMachineInstanceSummaryList(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineInstanceTemplateSpec":                                      schema_kubevirtio_api_core_v1_VirtualMachineInstanceTemplateSpec(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineList":                                                      schema_kubevirtio_api_core_v1_VirtualMachineList(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineMemoryDumpRequest":                                         schema_kubevirtio_api_core_v1_VirtualMachineMemoryDumpRequest(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineOptions":                                                   schema_kubevirtio_api_core_v1_VirtualMachineOptions(ref),
+		"kubevirt.io/api/core/v1.VirtualMachinePreflightCheck":                                            schema_kubevirtio_api_core_v1_VirtualMachinePreflightCheck(ref),
+		"kubevirt.io/api/core/v1.VirtualMachinePreflightReport":                                           schema_kubevirtio_api_core_v1_VirtualMachinePreflightReport(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineSpec":                                                      schema_kubevirtio_api_core_v1_VirtualMachineSpec(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineStartFailure":                                              schema_kubevirtio_api_core_v1_VirtualMachineStartFailure(ref),
 		"kubevirt.io/api/core/v1.VirtualMachineStateChangeRequest":                                        schema_kubevirtio_api_core_v1_VirtualMachineStateChangeRequest(ref),
@@ -652,6 +694,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/instancetype/v1beta1.DevicePreferences":                                          schema_kubevirtio_api_instancetype_v1beta1_DevicePreferences(ref),
 		"kubevirt.io/api/instancetype/v1beta1.FeaturePreferences":                                         schema_kubevirtio_api_instancetype_v1beta1_FeaturePreferences(ref),
 		"kubevirt.io/api/instancetype/v1beta1.FirmwarePreferences":                                        schema_kubevirtio_api_instancetype_v1beta1_FirmwarePreferences(ref),
+		"kubevirt.io/api/instancetype/v1beta1.InstancetypeArchitectureVariant":                            schema_kubevirtio_api_instancetype_v1beta1_InstancetypeArchitectureVariant(ref),
 		"kubevirt.io/api/instancetype/v1beta1.MachinePreferences":                                         schema_kubevirtio_api_instancetype_v1beta1_MachinePreferences(ref),
 		"kubevirt.io/api/instancetype/v1beta1.MemoryInstancetype":                                         schema_kubevirtio_api_instancetype_v1beta1_MemoryInstancetype(ref),
 		"kubevirt.io/api/instancetype/v1beta1.MemoryPreferenceRequirement":                                schema_kubevirtio_api_instancetype_v1beta1_MemoryPreferenceRequirement(ref),
@@ -673,6 +716,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/migrations/v1alpha1.MigrationPolicySpec":                                         schema_kubevirtio_api_migrations_v1alpha1_MigrationPolicySpec(ref),
 		"kubevirt.io/api/migrations/v1alpha1.MigrationPolicyStatus":                                       schema_kubevirtio_api_migrations_v1alpha1_MigrationPolicyStatus(ref),
 		"kubevirt.io/api/migrations/v1alpha1.Selectors":                                                   schema_kubevirtio_api_migrations_v1alpha1_Selectors(ref),
+		"kubevirt.io/api/operations/v1alpha1.VirtualMachineOperation":                                     schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperation(ref),
+		"kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationList":                                 schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperationList(ref),
+		"kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationProgress":                             schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperationProgress(ref),
+		"kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationSpec":                                 schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperationSpec(ref),
+		"kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationStatus":                               schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperationStatus(ref),
+		"kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationTarget":                               schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperationTarget(ref),
 		"kubevirt.io/api/pool/v1alpha1.VirtualMachineOpportunisticUpdateStrategy":                         schema_kubevirtio_api_pool_v1alpha1_VirtualMachineOpportunisticUpdateStrategy(ref),
 		"kubevirt.io/api/pool/v1alpha1.VirtualMachinePool":                                                schema_kubevirtio_api_pool_v1alpha1_VirtualMachinePool(ref),
 		"kubevirt.io/api/pool/v1alpha1.VirtualMachinePoolAutohealingStrategy":                             schema_kubevirtio_api_pool_v1alpha1_VirtualMachinePoolAutohealingStrategy(ref),
@@ -731,6 +780,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/api/snapshot/v1alpha1.VolumeSnapshotStatus":                                          schema_kubevirtio_api_snapshot_v1alpha1_VolumeSnapshotStatus(ref),
 		"kubevirt.io/api/snapshot/v1beta1.Condition":                                                      schema_kubevirtio_api_snapshot_v1beta1_Condition(ref),
 		"kubevirt.io/api/snapshot/v1beta1.Error":                                                          schema_kubevirtio_api_snapshot_v1beta1_Error(ref),
+		"kubevirt.io/api/snapshot/v1beta1.IdentityPolicy":                                                 schema_kubevirtio_api_snapshot_v1beta1_IdentityPolicy(ref),
 		"kubevirt.io/api/snapshot/v1beta1.PersistentVolumeClaim":                                          schema_kubevirtio_api_snapshot_v1beta1_PersistentVolumeClaim(ref),
 		"kubevirt.io/api/snapshot/v1beta1.SnapshotVolumesLists":                                           schema_kubevirtio_api_snapshot_v1beta1_SnapshotVolumesLists(ref),
 		"kubevirt.io/api/snapshot/v1beta1.SourceIndication":                                               schema_kubevirtio_api_snapshot_v1beta1_SourceIndication(ref),
@@ -18263,6 +18313,40 @@ func schema_kubevirtio_api_clone_v1beta1_VirtualMachineClone(ref common.Referenc
 	}
 }
 
+func schema_kubevirtio_api_clone_v1beta1_VirtualMachineCloneCustomization(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineCloneCustomization defines the guest customizations applied offline to a clone",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"hostname": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hostname sets the hostname of the guest.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resetMachineID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResetMachineID removes the machine-id of the guest so that a new one is generated on the next boot.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"regenerateSSHHostKeys": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RegenerateSSHHostKeys removes the SSH host keys of the guest so that new ones are generated on the next boot.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_api_clone_v1beta1_VirtualMachineCloneList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -18432,12 +18516,24 @@ func schema_kubevirtio_api_clone_v1beta1_VirtualMachineCloneSpec(ref common.Refe
 							Format:      "",
 						},
 					},
+					"identityPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IdentityPolicy defines which identity attributes of the source VM are regenerated in the target. MAC addresses, SMBIOS serial and firmware UUID are regenerated by default while the hostname is preserved. NewMacAddresses and NewSMBiosSerial take precedence over this policy.",
+							Ref:         ref("kubevirt.io/api/snapshot/v1beta1.IdentityPolicy"),
+						},
+					},
+					"customization": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Customization defines guest customizations applied to the target's disks with virt-sysprep after they were restored, so that the clone doesn't collide with its source on the network. The target is kept halted until the customization finished.",
+							Ref:         ref("kubevirt.io/api/clone/v1beta1.VirtualMachineCloneCustomization"),
+						},
+					},
 				},
 				Required: []string{"source"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.TypedLocalObjectReference", "kubevirt.io/api/clone/v1beta1.VirtualMachineCloneTemplateFilters"},
+			"k8s.io/api/core/v1.TypedLocalObjectReference", "kubevirt.io/api/clone/v1beta1.VirtualMachineCloneCustomization", "kubevirt.io/api/clone/v1beta1.VirtualMachineCloneTemplateFilters", "kubevirt.io/api/snapshot/v1beta1.IdentityPolicy"},
 	}
 }
 
@@ -18494,6 +18590,12 @@ func schema_kubevirtio_api_clone_v1beta1_VirtualMachineCloneStatus(ref common.Re
 							Format: "",
 						},
 					},
+					"customizationJobName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
 				},
 			},
 		},
@@ -18862,6 +18964,221 @@ func schema_kubevirtio_api_core_v1_Bootloader(ref common.ReferenceCallback) comm
 	}
 }
 
+func schema_kubevirtio_api_core_v1_BulkLifecycleOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BulkLifecycleOptions are provided on bulk lifecycle request. The VirtualMachines of the namespace are either selected by their names or by a label selector.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Action is the lifecycle action applied to every selected VirtualMachine: start, stop or restart.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"names": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Names of the VirtualMachines the action is applied to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"labelSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LabelSelector selects the VirtualMachines the action is applied to by their labels.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"startOptions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartOptions are used for every VirtualMachine when the action is start.",
+							Ref:         ref("kubevirt.io/api/core/v1.StartOptions"),
+						},
+					},
+					"stopOptions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StopOptions are used for every VirtualMachine when the action is stop.",
+							Ref:         ref("kubevirt.io/api/core/v1.StopOptions"),
+						},
+					},
+					"restartOptions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartOptions are used for every VirtualMachine when the action is restart.",
+							Ref:         ref("kubevirt.io/api/core/v1.RestartOptions"),
+						},
+					},
+					"dryRun": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "When present, indicates that modifications should not be persisted. It replaces the dryRun of the options of the action. Valid values are: - All: all dry run stages will be processed",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"action"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "kubevirt.io/api/core/v1.RestartOptions", "kubevirt.io/api/core/v1.StartOptions", "kubevirt.io/api/core/v1.StopOptions"},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_BulkLifecycleResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BulkLifecycleResult is returned by the bulk lifecycle subresource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"results": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Results lists the outcome of the action for every selected VirtualMachine, ordered by name.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.BulkLifecycleVirtualMachineResult"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/api/core/v1.BulkLifecycleVirtualMachineResult"},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_BulkLifecycleVirtualMachineResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BulkLifecycleVirtualMachineResult is the outcome of a bulk lifecycle action for a single VirtualMachine.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the VirtualMachine.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"code": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Code is the HTTP status code the single lifecycle request would have returned.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is the machine-readable reason of a failure.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the human-readable description of a failure.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"changes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Changes lists the changes the action would apply for dry-run requests.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.DryRunChange"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name", "code"},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/api/core/v1.DryRunChange"},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_CDRomTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -18977,11 +19294,17 @@ func schema_kubevirtio_api_core_v1_CPU(ref common.ReferenceCallback) common.Open
 							Ref:         ref("kubevirt.io/api/core/v1.Realtime"),
 						},
 					},
+					"threadShares": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ThreadShares weights the CPU time of the emulator and IO threads against the vCPU threads of the VMI. Only applies to VMIs without dedicated CPUs.",
+							Ref:         ref("kubevirt.io/api/core/v1.CPUThreadShares"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/api/core/v1.CPUFeature", "kubevirt.io/api/core/v1.NUMA", "kubevirt.io/api/core/v1.Realtime"},
+			"kubevirt.io/api/core/v1.CPUFeature", "kubevirt.io/api/core/v1.CPUThreadShares", "kubevirt.io/api/core/v1.NUMA", "kubevirt.io/api/core/v1.Realtime"},
 	}
 }
 
@@ -19014,6 +19337,33 @@ func schema_kubevirtio_api_core_v1_CPUFeature(ref common.ReferenceCallback) comm
 	}
 }
 
+func schema_kubevirtio_api_core_v1_CPUThreadShares(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CPUThreadShares holds the relative CPU shares of the emulator and IO threads of a VMI. The values follow the semantics of the cgroup v1 cpu.shares, where every vCPU thread has 1024 shares, and are converted to cpu.weight on cgroup v2 hosts.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"emulator": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Emulator is the CPU shares of the emulator threads. Threads are left in the cgroup of the VMI if not set.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"ioThreads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IOThreads is the CPU shares of the IO threads. Threads are left in the cgroup of the VMI if not set.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_CPUTopology(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -19470,6 +19820,122 @@ func schema_kubevirtio_api_core_v1_ClusterProfilerResults(ref common.ReferenceCa
 	}
 }
 
+func schema_kubevirtio_api_core_v1_ClusterRecoveryConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterRecoveryConfiguration configures the staggered start of VirtualMachines after a cluster-wide outage. The start order is controlled by the kubevirt.io/cluster-recovery-priority annotation of the VirtualMachines.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"outageThresholdPercentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OutageThresholdPercentage is the percentage of VirtualMachines with the Always or RerunOnFailure run strategy which have to be down at the same time for the cluster to be considered recovering from an outage. Defaults to 50.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxStartsPerNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxStartsPerNode is the maximum number of VirtualMachineInstances per schedulable node which may be starting at the same time during recovery. Defaults to 2.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"storageReadinessCheck": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageReadinessCheck delays the start of a VirtualMachine during recovery until all of its PersistentVolumeClaims are bound. Defaults to true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_ClusterRecoveryStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterRecoveryStatus reports the progress of the staggered start of VirtualMachines after a cluster-wide outage.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time at which the outage was detected.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time at which all VirtualMachines were started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"currentPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentPriority is the priority tier which is currently being started.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"virtualMachines": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VirtualMachines is the number of VirtualMachines with the Always or RerunOnFailure run strategy.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"running": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Running is the number of those VirtualMachines which are running.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"starting": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Starting is the number of those VirtualMachines which are being started.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"pending": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pending is the number of those VirtualMachines which are waiting to be started.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"waitingForStorage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WaitingForStorage is the number of pending VirtualMachines whose PersistentVolumeClaims are not bound yet.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"phase", "virtualMachines", "running", "starting", "pending", "waitingForStorage"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_CommonInstancetypesDeployment(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -19827,6 +20293,48 @@ func schema_kubevirtio_api_core_v1_CustomizeComponentsPatch(ref common.Reference
 	}
 }
 
+func schema_kubevirtio_api_core_v1_DHCPNetBIOSOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DHCPNetBIOSOptions defines the NetBIOS over TCP/IP settings passed to the VM.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nameServers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified will pass the configured NetBIOS name servers (WINS) to the VM via DHCP option 044.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"nodeType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified will pass the NetBIOS node type to the VM via DHCP option 046. Allowed values are B, P, M and H.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scope": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified will pass the NetBIOS scope to the VM via DHCP option 047.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_DHCPOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -19877,11 +20385,39 @@ func schema_kubevirtio_api_core_v1_DHCPOptions(ref common.ReferenceCallback) com
 							},
 						},
 					},
+					"domainName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified will pass the configured domain name to the VM via DHCP option 015, instead of the one derived from the pod search domains.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"domainSearch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified the configured DNS search suffixes are passed to the VM via DHCP option 119, ahead of the search domains inherited from the pod.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"netBIOS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified will pass NetBIOS over TCP/IP (WINS) settings to the VM via DHCP options 044, 046 and 047.",
+							Ref:         ref("kubevirt.io/api/core/v1.DHCPNetBIOSOptions"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/api/core/v1.DHCPPrivateOptions"},
+			"kubevirt.io/api/core/v1.DHCPNetBIOSOptions", "kubevirt.io/api/core/v1.DHCPPrivateOptions"},
 	}
 }
 
@@ -20139,6 +20675,13 @@ func schema_kubevirtio_api_core_v1_DeveloperConfiguration(ref common.ReferenceCa
 							Format:      "int64",
 						},
 					},
+					"tscFrequencyTolerancePPM": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TSCFrequencyTolerancePPM is the deviation, in parts per million, which is accepted between the TSC frequency of a VMI and the TSC frequency of a node that cannot scale it. The same tolerance is used when a migration target is validated. QEMU refuses deviations above 250 PPM. Defaults to 250",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"diskVerification": {
 						SchemaProps: spec.SchemaProps{
 							Ref: ref("kubevirt.io/api/core/v1.DiskVerification"),
@@ -20425,6 +20968,25 @@ func schema_kubevirtio_api_core_v1_Devices(ref common.ReferenceCallback) common.
 							Format:      "",
 						},
 					},
+					"vsockServices": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "VSOCKServices are the named guest services reachable over VSOCK. Requires autoattachVSOCK. The ports which are not set are allocated when the VMI is scheduled and reported in status.vsockServices.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.VSOCKService"),
+									},
+								},
+							},
+						},
+					},
 					"rng": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Whether to have random number generator from host",
@@ -20550,7 +21112,7 @@ func schema_kubevirtio_api_core_v1_Devices(ref common.ReferenceCallback) common.
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/api/core/v1.ClientPassthroughDevices", "kubevirt.io/api/core/v1.Disk", "kubevirt.io/api/core/v1.DownwardMetrics", "kubevirt.io/api/core/v1.Filesystem", "kubevirt.io/api/core/v1.GPU", "kubevirt.io/api/core/v1.HostDevice", "kubevirt.io/api/core/v1.Input", "kubevirt.io/api/core/v1.Interface", "kubevirt.io/api/core/v1.PanicDevice", "kubevirt.io/api/core/v1.Rng", "kubevirt.io/api/core/v1.SoundDevice", "kubevirt.io/api/core/v1.TPMDevice", "kubevirt.io/api/core/v1.VideoDevice", "kubevirt.io/api/core/v1.Watchdog"},
+			"kubevirt.io/api/core/v1.ClientPassthroughDevices", "kubevirt.io/api/core/v1.Disk", "kubevirt.io/api/core/v1.DownwardMetrics", "kubevirt.io/api/core/v1.Filesystem", "kubevirt.io/api/core/v1.GPU", "kubevirt.io/api/core/v1.HostDevice", "kubevirt.io/api/core/v1.Input", "kubevirt.io/api/core/v1.Interface", "kubevirt.io/api/core/v1.PanicDevice", "kubevirt.io/api/core/v1.Rng", "kubevirt.io/api/core/v1.SoundDevice", "kubevirt.io/api/core/v1.TPMDevice", "kubevirt.io/api/core/v1.VSOCKService", "kubevirt.io/api/core/v1.VideoDevice", "kubevirt.io/api/core/v1.Watchdog"},
 	}
 }
 
@@ -20640,6 +21202,13 @@ func schema_kubevirtio_api_core_v1_Disk(ref common.ReferenceCallback) common.Ope
 							Format:      "",
 						},
 					},
+					"wwn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WWN provides the ability to specify a World Wide Name for the disk device. It must consist of 16 hexadecimal digits and is only supported for disks and CD-ROMs on the sata and scsi buses.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"dedicatedIOThread": {
 						SchemaProps: spec.SchemaProps{
 							Description: "dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.",
@@ -20688,6 +21257,13 @@ func schema_kubevirtio_api_core_v1_Disk(ref common.ReferenceCallback) common.Ope
 							Format:      "",
 						},
 					},
+					"readErrorPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified, it can change the error policy applied to read errors on the disk. Defaults to the errorPolicy. Supported values are stop, report and ignore.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"changedBlockTracking": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ChangedBlockTracking indicates this disk should have CBT option Defaults to false.",
@@ -20948,6 +21524,359 @@ func schema_kubevirtio_api_core_v1_DomainSpec(ref common.ReferenceCallback) comm
 	}
 }
 
+func schema_kubevirtio_api_core_v1_DomainStatsCPU(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DomainStatsCPU holds the CPU time consumed by a domain.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"timeNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeNanoseconds is the total CPU time consumed by the domain.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"userNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UserNanoseconds is the CPU time the domain spent in user mode.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"systemNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SystemNanoseconds is the CPU time the domain spent in kernel mode.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+func schema_kubevirtio_api_core_v1_DomainStatsDisk(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DomainStatsDisk holds the statistics of a single disk.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the disk in the VirtualMachineInstance spec or the target device if the disk has no alias.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the path of the disk source on the host.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"readRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadRequests is the number of read requests.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"readBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadBytes is the number of bytes read.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"readTimeNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadTimeNanoseconds is the total time spent on read requests.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"writeRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WriteRequests is the number of write requests.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"writeBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WriteBytes is the number of bytes written.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"writeTimeNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WriteTimeNanoseconds is the total time spent on write requests.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"flushRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FlushRequests is the number of flush requests.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"flushTimeNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FlushTimeNanoseconds is the total time spent on flush requests.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"errors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Errors is the number of failed requests.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"capacityBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CapacityBytes is the size of the disk as seen by the guest.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"allocationBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllocationBytes is the highest offset written to the disk source.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"physicalBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PhysicalBytes is the size the disk source takes on the host.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+func schema_kubevirtio_api_core_v1_DomainStatsInterface(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DomainStatsInterface holds the statistics of a single network interface.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the interface in the VirtualMachineInstance spec or the host side device if the interface has no alias.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rxBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RxBytes is the number of bytes received.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"rxPackets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RxPackets is the number of packets received.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"rxErrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RxErrors is the number of receive errors.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"rxDropped": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RxDropped is the number of received packets which were dropped.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"txBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TxBytes is the number of bytes transmitted.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"txPackets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TxPackets is the number of packets transmitted.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"txErrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TxErrors is the number of transmit errors.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"txDropped": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TxDropped is the number of transmitted packets which were dropped.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+func schema_kubevirtio_api_core_v1_DomainStatsMemory(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DomainStatsMemory holds the memory and balloon statistics of a domain. The statistics reported from within the guest are only set if the guest runs the memory balloon driver.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"actualBalloonBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActualBalloonBytes is the current size of the memory balloon.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"availableBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AvailableBytes is the amount of memory visible to the guest.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"usableBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UsableBytes is the amount of memory the guest can use without swapping.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"unusedBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UnusedBytes is the amount of memory left completely unused by the guest.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"cachedBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CachedBytes is the amount of memory the guest uses for caches which can be reclaimed.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"rssBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RSSBytes is the resident set size of the domain on the host.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"swapInBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SwapInBytes is the amount of memory the guest read from swap space.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"swapOutBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SwapOutBytes is the amount of memory the guest wrote to swap space.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"majorPageFaults": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MajorPageFaults is the number of page faults of the guest which required disk I/O.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"minorPageFaults": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinorPageFaults is the number of page faults of the guest which did not require disk I/O.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+func schema_kubevirtio_api_core_v1_DomainStatsVCPU(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DomainStatsVCPU holds the statistics of a single vCPU.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID is the index of the vCPU.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State is the state of the vCPU, one of offline, running or blocked.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeNanoseconds is the CPU time consumed by the vCPU.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"waitNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WaitNanoseconds is the time the vCPU spent waiting for I/O.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"delayNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DelayNanoseconds is the time the vCPU spent waiting in the run queue of the host.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"id"},
+			},
+		},
+	}
+}
 func schema_kubevirtio_api_core_v1_DownwardAPIVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -21005,6 +21934,106 @@ func schema_kubevirtio_api_core_v1_DownwardMetricsVolumeSource(ref common.Refere
 	}
 }
 
+func schema_kubevirtio_api_core_v1_DryRunChange(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DryRunChange describes a change a dry-run request would apply to an object.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the object which would be changed.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the object which would be changed. Empty for objects created with a generated name.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"operation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Operation which would be applied to the object.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"patch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Patch is the JSON patch which would be applied by the Patch and PatchStatus operations.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"object": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Object is the object returned by the API server for the dry-run request, including the mutations of admission webhooks. It is not set for the Delete operation.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+				},
+				Required: []string{"kind", "operation"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_DryRunResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DryRunResult is returned by the start, stop, restart, migrate, addvolume and removevolume subresources for dry-run requests. The request passed the validation of the subresource and of the API server, and the listed changes would have been applied.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"changes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Changes lists the changes the request would apply, in the order in which they would be applied.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.DryRunChange"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/api/core/v1.DryRunChange"},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_EFI(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -21066,6 +22095,13 @@ func schema_kubevirtio_api_core_v1_EphemeralVolumeSource(ref common.ReferenceCal
 							Ref:         ref("k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource"),
 						},
 					},
+					"overlayClaimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OverlayClaimName references a filesystem PersistentVolumeClaim in the same namespace which keeps the writable overlay of the disk across restarts of the vmi. If not set, the overlay is transient and discarded when the vmi stops. The base PersistentVolumeClaim is always attached read-only, which allows sharing it between many vmis.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -21557,6 +22593,26 @@ func schema_kubevirtio_api_core_v1_FreezeUnfreezeTimeout(ref common.ReferenceCal
 							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"mountpoints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Mountpoints restricts the freeze to the given guest mount points. When empty, all freezable guest filesystems are frozen. Requires a guest agent supporting guest-fsfreeze-freeze-list.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"unfreezeTimeout"},
 			},
@@ -21682,6 +22738,36 @@ func schema_kubevirtio_api_core_v1_GenerationStatus(ref common.ReferenceCallback
 	}
 }
 
+func schema_kubevirtio_api_core_v1_GuestAgentAlternative(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GuestAgentAlternative describes an in-guest agent reachable over VSOCK.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the agent. The agent has to announce the same name during the capability negotiation. The minimal agent shipped with KubeVirt announces itself as kubevirt-minimal-agent.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"vsockPort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VSOCKPort is the port the agent listens on inside the guest.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"name", "vsockPort"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_GuestAgentCommandInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -21709,6 +22795,116 @@ func schema_kubevirtio_api_core_v1_GuestAgentCommandInfo(ref common.ReferenceCal
 	}
 }
 
+func schema_kubevirtio_api_core_v1_GuestAgentConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GuestAgentConfiguration configures alternative in-guest agents.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"alternatives": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Alternatives lists the agents virt-handler talks to over VSOCK when qemu-guest-agent is not connected. They are tried in order and the first one answering is used for IP reporting and filesystem freeze/thaw, depending on the capabilities it announces.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.GuestAgentAlternative"),
+									},
+								},
+							},
+						},
+					},
+					"dataPolicies": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "DataPolicies restrict the guest data collected from the guest agents of the VirtualMachineInstances per namespace. Redacted data is neither stored in the status of the VirtualMachineInstances nor returned by their guest agent subresources. When several policies apply to a namespace, the data redacted by any of them is redacted.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.GuestAgentDataPolicy"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/api/core/v1.GuestAgentAlternative", "kubevirt.io/api/core/v1.GuestAgentDataPolicy"},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_GuestAgentDataPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GuestAgentDataPolicy redacts guest data reported by the guest agents in a set of namespaces.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespaces": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces the policy applies to. A policy without namespaces applies to all namespaces.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"redact": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Redact lists the kinds of guest data which are not collected.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"redact"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_GuestAgentPing(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23101,11 +24297,42 @@ func schema_kubevirtio_api_core_v1_KubeVirtConfiguration(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
+					"volumePermissionRepairPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumePermissionRepairPolicy controls whether virt-handler repairs the ownership and SELinux labels of filesystem volumes which are not accessible by the VM, e.g. because the backing storage was moved from another node. When set to \"Disabled\" (default) or not specified, mismatches are only reported. When set to \"Ownership\", the disk images are chowned to the qemu user. When set to \"OwnershipAndSELinux\", the disk images are additionally relabeled.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterRecovery": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterRecovery configures how virt-controller staggers the automatic start of VirtualMachines after a cluster-wide outage. When not specified, VirtualMachines are started as soon as possible.",
+							Ref:         ref("kubevirt.io/api/core/v1.ClusterRecoveryConfiguration"),
+						},
+					},
+					"guestAgent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestAgent configures the in-guest agents which can be used when qemu-guest-agent is not available in the guest.",
+							Ref:         ref("kubevirt.io/api/core/v1.GuestAgentConfiguration"),
+						},
+					},
+					"subresourceLimits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SubresourceLimits restricts the console, VNC, usbredir and port-forward streams and the subresource requests which each virt-api replica accepts per user and per namespace. Requests exceeding the limits are rejected with 429 Too Many Requests. When not specified, subresources are not limited.",
+							Ref:         ref("kubevirt.io/api/core/v1.SubresourceLimitsConfiguration"),
+						},
+					},
+					"virtualMachineExport": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VirtualMachineExport configures the expiration and renewal of VirtualMachineExports.",
+							Ref:         ref("kubevirt.io/api/core/v1.VirtualMachineExportConfiguration"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "kubevirt.io/api/core/v1.ArchConfiguration", "kubevirt.io/api/core/v1.ChangedBlockTrackingSelectors", "kubevirt.io/api/core/v1.CommonInstancetypesDeployment", "kubevirt.io/api/core/v1.ConfidentialComputeConfiguration", "kubevirt.io/api/core/v1.DeveloperConfiguration", "kubevirt.io/api/core/v1.HypervisorConfiguration", "kubevirt.io/api/core/v1.InstancetypeConfiguration", "kubevirt.io/api/core/v1.KSMConfiguration", "kubevirt.io/api/core/v1.LiveUpdateConfiguration", "kubevirt.io/api/core/v1.MediatedDevicesConfiguration", "kubevirt.io/api/core/v1.MigrationConfiguration", "kubevirt.io/api/core/v1.NetworkConfiguration", "kubevirt.io/api/core/v1.PermittedHostDevices", "kubevirt.io/api/core/v1.ReloadableComponentConfiguration", "kubevirt.io/api/core/v1.SMBiosConfiguration", "kubevirt.io/api/core/v1.SeccompConfiguration", "kubevirt.io/api/core/v1.SupportContainerResources", "kubevirt.io/api/core/v1.TLSConfiguration", "kubevirt.io/api/core/v1.VirtTemplateDeployment", "kubevirt.io/api/core/v1.VirtualMachineOptions"},
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "kubevirt.io/api/core/v1.ArchConfiguration", "kubevirt.io/api/core/v1.ChangedBlockTrackingSelectors", "kubevirt.io/api/core/v1.ClusterRecoveryConfiguration", "kubevirt.io/api/core/v1.CommonInstancetypesDeployment", "kubevirt.io/api/core/v1.ConfidentialComputeConfiguration", "kubevirt.io/api/core/v1.DeveloperConfiguration", "kubevirt.io/api/core/v1.GuestAgentConfiguration", "kubevirt.io/api/core/v1.HypervisorConfiguration", "kubevirt.io/api/core/v1.InstancetypeConfiguration", "kubevirt.io/api/core/v1.KSMConfiguration", "kubevirt.io/api/core/v1.LiveUpdateConfiguration", "kubevirt.io/api/core/v1.MediatedDevicesConfiguration", "kubevirt.io/api/core/v1.MigrationConfiguration", "kubevirt.io/api/core/v1.NetworkConfiguration", "kubevirt.io/api/core/v1.PermittedHostDevices", "kubevirt.io/api/core/v1.ReloadableComponentConfiguration", "kubevirt.io/api/core/v1.SMBiosConfiguration", "kubevirt.io/api/core/v1.SeccompConfiguration", "kubevirt.io/api/core/v1.SubresourceLimitsConfiguration", "kubevirt.io/api/core/v1.SupportContainerResources", "kubevirt.io/api/core/v1.TLSConfiguration", "kubevirt.io/api/core/v1.VirtTemplateDeployment", "kubevirt.io/api/core/v1.VirtualMachineExportConfiguration", "kubevirt.io/api/core/v1.VirtualMachineOptions"},
 	}
 }
 
@@ -23486,11 +24713,17 @@ func schema_kubevirtio_api_core_v1_KubeVirtStatus(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"clusterRecovery": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterRecovery reports the progress of starting VirtualMachines after a cluster-wide outage.",
+							Ref:         ref("kubevirt.io/api/core/v1.ClusterRecoveryStatus"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/api/core/v1.GenerationStatus", "kubevirt.io/api/core/v1.KubeVirtCondition"},
+			"kubevirt.io/api/core/v1.ClusterRecoveryStatus", "kubevirt.io/api/core/v1.GenerationStatus", "kubevirt.io/api/core/v1.KubeVirtCondition"},
 	}
 }
 
@@ -23608,6 +24841,62 @@ func schema_kubevirtio_api_core_v1_LiveUpdateConfiguration(ref common.ReferenceC
 	}
 }
 
+func schema_kubevirtio_api_core_v1_LiveUpdatedResources(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LiveUpdatedResources describes the guest CPU and memory resources which were applied to a running VirtualMachineInstance without a restart.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sockets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sockets is the number of CPU sockets applied by the last CPU hotplug.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"guest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Guest is the guest memory applied by the last memory hotplug.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"requests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Requests are the CPU and memory requests applied together with the hotplugs.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"limits": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limits are the CPU and memory limits applied together with the hotplugs.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_LogVerbosity(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23832,10 +25121,18 @@ func schema_kubevirtio_api_core_v1_MediatedHostDevice(ref common.ReferenceCallba
 							Format: "",
 						},
 					},
+					"schedulingPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SchedulingPolicy configures how the physical GPUs backing devices of this type are time-sliced between the VMs using them",
+							Ref:         ref("kubevirt.io/api/core/v1.VGPUSchedulingPolicy"),
+						},
+					},
 				},
 				Required: []string{"mdevNameSelector", "resourceName"},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/api/core/v1.VGPUSchedulingPolicy"},
 	}
 }
 
@@ -23906,6 +25203,13 @@ func schema_kubevirtio_api_core_v1_MemoryDumpVolumeSource(ref common.ReferenceCa
 							Format:      "",
 						},
 					},
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Format is the format the memory is dumped in, defaults to Raw.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"claimName"},
 			},
@@ -24008,6 +25312,13 @@ func schema_kubevirtio_api_core_v1_MigrateOptions(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"migrationPolicyName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MigrationPolicyName is the name of the MigrationPolicy to apply to the migration instead of the MigrationPolicy matched by the selectors of the policies.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -24118,11 +25429,44 @@ func schema_kubevirtio_api_core_v1_MigrationConfiguration(ref common.ReferenceCa
 							Format:      "",
 						},
 					},
+					"nodeHeadroom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeHeadroom reserves a share of the allocatable resources of every node to receive live migrations, e.g. during node drains. New VMIs are not scheduled on nodes whose free capacity is below the headroom. Disabled by default.",
+							Ref:         ref("kubevirt.io/api/core/v1.MigrationNodeHeadroom"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "kubevirt.io/api/core/v1.MigrationNodeHeadroom"},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_MigrationNodeHeadroom(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MigrationNodeHeadroom holds the share of the allocatable resources of a node reserved for incoming migrations.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cpuPercentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPUPercentage is the percentage of the allocatable CPU of a node kept free for incoming migrations.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memoryPercentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryPercentage is the percentage of the allocatable memory of a node kept free for incoming migrations.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -24612,6 +25956,13 @@ func schema_kubevirtio_api_core_v1_PauseOptions(ref common.ReferenceCallback) co
 							},
 						},
 					},
+					"syncGuest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SyncGuest asks the guest agent to flush and freeze the guest filesystems before the vCPUs are paused. The filesystems are thawed on unpause.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -25285,6 +26636,42 @@ func schema_kubevirtio_api_core_v1_RemoveVolumeOptions(ref common.ReferenceCallb
 	}
 }
 
+func schema_kubevirtio_api_core_v1_RenameOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RenameOptions are provided on rename request.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"newName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NewName is the name the VirtualMachine is renamed to.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"newName"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_ReservedOverhead(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -25658,6 +27045,67 @@ func schema_kubevirtio_api_core_v1_SEVSNP(ref common.ReferenceCallback) common.O
 	}
 }
 
+func schema_kubevirtio_api_core_v1_SEVSNPAttestationReport(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SEVSNPAttestationReport contains an attestation report of a SEV-SNP guest.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"report": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base64 encoded attestation report signed by the AMD secure processor.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"certChain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base64 encoded certificate table provided by the host, containing the VCEK or VLEK certificate chain.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_SEVSNPAttestationReportOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SEVSNPAttestationReportOptions is used to request an attestation report from a SEV-SNP guest.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"reportData": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Base64 encoded data of up to 64 bytes to include in the report, usually a nonce chosen by the guest owner.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_SEVSecretOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -25933,6 +27381,40 @@ func schema_kubevirtio_api_core_v1_ServiceAccountVolumeSource(ref common.Referen
 	}
 }
 
+func schema_kubevirtio_api_core_v1_SoftRebootOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SoftRebootOptions may be provided on soft reboot request.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"fallback": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Fallback defines what happens when the guest agent is absent or unresponsive. Defaults to acpi.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_SoundDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -25950,15 +27432,44 @@ func schema_kubevirtio_api_core_v1_SoundDevice(ref common.ReferenceCallback) com
 					},
 					"model": {
 						SchemaProps: spec.SchemaProps{
-							Description: "We only support ich9 or ac97. If SoundDevice is not set: No sound card is emulated. If SoundDevice is set but Model is not: ich9",
+							Description: "We only support ich9, ac97 or virtio. If SoundDevice is not set: No sound card is emulated. If SoundDevice is set but Model is not: ich9",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"stream": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Stream forwards the audio output of the sound device through the VNC connection. If Stream is not set the audio output is discarded.",
+							Ref:         ref("kubevirt.io/api/core/v1.SoundStream"),
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/api/core/v1.SoundStream"},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_SoundStream(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SoundStream configures forwarding the audio output through the graphics connection.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxBandwidth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxBandwidth caps the bandwidth of the uncompressed audio stream per second. The sample rate and the number of channels are lowered until the stream fits. Defaults to 44.1kHz 16 bit stereo, around 172Ki per second.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -26106,6 +27617,67 @@ func schema_kubevirtio_api_core_v1_StorageMigratedVolumeInfo(ref common.Referenc
 	}
 }
 
+func schema_kubevirtio_api_core_v1_SubresourceClientLimits(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SubresourceClientLimits holds the subresource limits of a single user or namespace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxConcurrentStreams": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxConcurrentStreams is the maximum number of console, VNC, usbredir and port-forward streams which can be open at the same time. Not limited if not specified.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"requestsPerSecond": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RequestsPerSecond is the sustained rate of subresource requests which is accepted. Not limited if not specified.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"burst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Burst is the number of subresource requests which can be issued at once before RequestsPerSecond applies. Defaults to RequestsPerSecond.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_SubresourceLimitsConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SubresourceLimitsConfiguration holds the limits on the subresource usage of the clients of virt-api. The limits are enforced by each virt-api replica separately.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"perUser": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerUser limits the subresource usage of every user.",
+							Ref:         ref("kubevirt.io/api/core/v1.SubresourceClientLimits"),
+						},
+					},
+					"perNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerNamespace limits the subresource usage in every namespace.",
+							Ref:         ref("kubevirt.io/api/core/v1.SubresourceClientLimits"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/api/core/v1.SubresourceClientLimits"},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_SupportContainerResources(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -26416,6 +27988,73 @@ func schema_kubevirtio_api_core_v1_TokenBucketRateLimiter(ref common.ReferenceCa
 	}
 }
 
+func schema_kubevirtio_api_core_v1_TokenExchangeOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TokenExchangeOptions holds the parameters to exchange an OIDC ID token for a subresource token.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"idToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IDToken is the OIDC ID token of the user the subresource token is issued for.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"subresource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subresource is the subresource the token grants access to, either console or vnc.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"expirationSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExpirationSeconds is the requested lifetime of the token. Defaults to 300 and must not exceed 600.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"idToken", "subresource"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_TokenExchangeResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TokenExchangeResult holds a subresource token issued for a VirtualMachineInstance.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"token": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Token grants access to the requested subresource when passed in the X-KubeVirt-Subresource-Token header.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"expirationTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExpirationTimestamp is the time the token expires.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"token", "expirationTimestamp"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_TopologyHints(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -26717,6 +28356,35 @@ func schema_kubevirtio_api_core_v1_VGPUOptions(ref common.ReferenceCallback) com
 	}
 }
 
+func schema_kubevirtio_api_core_v1_VGPUSchedulingPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VGPUSchedulingPolicy configures the time-slicing of a physical GPU between its vGPUs",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"scheduler": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scheduler selects the time-slicing policy of the vendor driver. BestEffort hands the GPU to any vGPU with pending work, FixedShare reserves an equal share of the GPU for every vGPU, busy or not",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeSliceMicroseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeSliceMicroseconds overrides the length of the time slice given to a vGPU by the vendor driver",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"scheduler"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_VMISelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -26764,6 +28432,63 @@ func schema_kubevirtio_api_core_v1_VSOCKOptions(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_kubevirtio_api_core_v1_VSOCKService(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VSOCKService is a named guest service listening on a VSOCK port",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name identifies the service within the VMI",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port the guest service listens on. A free port is allocated when it is not set.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_VSOCKServiceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VSOCKServiceStatus reports the VSOCK port of a named guest service",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+				},
+				Required: []string{"name", "port"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_VideoDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -26904,6 +28629,46 @@ func schema_kubevirtio_api_core_v1_VirtualMachineCondition(ref common.ReferenceC
 	}
 }
 
+func schema_kubevirtio_api_core_v1_VirtualMachineExportConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineExportConfiguration holds the cluster-wide expiration policy of VirtualMachineExports.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"defaultTTL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefaultTTL is the time to live of VirtualMachineExports which do not specify a ttlDuration. Defaults to 2h.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"maxLifetime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxLifetime is the maximum time a VirtualMachineExport can exist, counting from its creation and including its renewals. Not limited if not specified.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"autoRenew": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AutoRenew extends the expiration of VirtualMachineExports which do not specify autoRenew while downloads are in progress. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"expiryWarning": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExpiryWarning is the time before the expiration of a VirtualMachineExport at which a warning event is emitted, and at which in progress downloads renew it. Defaults to 10m.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_VirtualMachineInstance(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -27034,6 +28799,51 @@ func schema_kubevirtio_api_core_v1_VirtualMachineInstanceBackupStatus(ref common
 	}
 }
 
+func schema_kubevirtio_api_core_v1_VirtualMachineInstanceBootTimes(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceBootTimes records when a VirtualMachineInstance reached the stages of its start. Each time is recorded once, the difference between two consecutive times is the duration of a stage.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"podCreationTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodCreationTime is when the virt-launcher pod was created, once the volumes were bound and populated.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"podScheduledTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodScheduledTime is when the virt-launcher pod was scheduled to a node.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"launcherStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LauncherStartTime is when the compute container of the virt-launcher pod started, once its images were pulled.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"domainStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DomainStartTime is when the domain started running and the firmware took over.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"guestAgentConnectTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestAgentConnectTime is when the guest agent connected, once the guest OS booted.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_VirtualMachineInstanceCommonMigrationState(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -27160,6 +28970,126 @@ func schema_kubevirtio_api_core_v1_VirtualMachineInstanceCondition(ref common.Re
 	}
 }
 
+func schema_kubevirtio_api_core_v1_VirtualMachineInstanceDomain(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceDomain holds the libvirt domain definition of a running VirtualMachineInstance next to the definition which is generated from its current spec.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"live": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Live is the XML definition of the running domain.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"expected": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Expected is the XML definition generated from the current VirtualMachineInstance spec.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"live", "expected"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_VirtualMachineInstanceDomainStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceDomainStats is a point in time sample of the statistics libvirt reports for the domain of a VirtualMachineInstance.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"timestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timestamp is the time the sample was taken.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime"),
+						},
+					},
+					"cpu": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPU holds the CPU time consumed by the domain.",
+							Ref:         ref("kubevirt.io/api/core/v1.DomainStatsCPU"),
+						},
+					},
+					"vcpus": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "VCPUs holds the statistics of every vCPU of the guest.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.DomainStatsVCPU"),
+									},
+								},
+							},
+						},
+					},
+					"memory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Memory holds the memory and balloon statistics of the guest.",
+							Ref:         ref("kubevirt.io/api/core/v1.DomainStatsMemory"),
+						},
+					},
+					"disks": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Disks holds the statistics of every disk of the guest.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.DomainStatsDisk"),
+									},
+								},
+							},
+						},
+					},
+					"interfaces": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Interfaces holds the statistics of every network interface of the guest.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.DomainStatsInterface"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"timestamp"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime", "kubevirt.io/api/core/v1.DomainStatsCPU", "kubevirt.io/api/core/v1.DomainStatsDisk", "kubevirt.io/api/core/v1.DomainStatsInterface", "kubevirt.io/api/core/v1.DomainStatsMemory", "kubevirt.io/api/core/v1.DomainStatsVCPU"},
+	}
+}
 func schema_kubevirtio_api_core_v1_VirtualMachineInstanceFileSystem(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -27958,6 +29888,13 @@ func schema_kubevirtio_api_core_v1_VirtualMachineInstanceMigrationSpec(ref commo
 							},
 						},
 					},
+					"migrationPolicyName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MigrationPolicyName is the name of the MigrationPolicy to apply to this migration. It takes precedence over the MigrationPolicy matched by the selectors of the policies.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"sendTo": {
 						SchemaProps: spec.SchemaProps{
 							Description: "If sendTo is specified, this VirtualMachineInstanceMigration will be considered the source",
@@ -28122,6 +30059,12 @@ func schema_kubevirtio_api_core_v1_VirtualMachineInstanceMigrationState(ref comm
 							Format:      "",
 						},
 					},
+					"transferStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TransferStatus reports the progress of the data transfer as last seen by the source",
+							Ref:         ref("kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationTransferStatus"),
+						},
+					},
 					"migrationPolicyName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Name of the migration policy. If string is empty, no policy is matched",
@@ -28205,7 +30148,7 @@ func schema_kubevirtio_api_core_v1_VirtualMachineInstanceMigrationState(ref comm
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/api/core/v1.MigrationConfiguration", "kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationSourceState", "kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationTargetState"},
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/api/core/v1.MigrationConfiguration", "kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationSourceState", "kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationTargetState", "kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationTransferStatus"},
 	}
 }
 
@@ -28456,6 +30399,69 @@ func schema_kubevirtio_api_core_v1_VirtualMachineInstanceMigrationTargetState(re
 	}
 }
 
+func schema_kubevirtio_api_core_v1_VirtualMachineInstanceMigrationTransferStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceMigrationTransferStatus holds the progress of the memory and disk transfer of a live migration",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"dataProcessedBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The amount of data already transferred, in bytes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"dataRemainingBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The amount of data still to be transferred, in bytes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"dataTotalBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The total amount of data to transfer, in bytes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memoryDirtyRateBytesPerSecond": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The rate at which the guest dirties its memory, in bytes per second",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memoryBandwidthBytesPerSecond": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The bandwidth used to transfer the memory, in bytes per second",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memoryIteration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The number of memory transfer iterations completed so far",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastUpdateTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The time at which the transfer status was last updated",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_VirtualMachineInstanceNetworkInterface(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -28945,6 +30951,60 @@ func schema_kubevirtio_api_core_v1_VirtualMachineInstanceReplicaSetStatus(ref co
 	}
 }
 
+func schema_kubevirtio_api_core_v1_VirtualMachineInstanceResourceUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceResourceUsage is a point in time sample of the CPU and memory consumed by the guest of a VirtualMachineInstance.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"timestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timestamp is the time the sample was taken.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime"),
+						},
+					},
+					"vcpus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VCPUs is the number of vCPUs of the guest.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"cpuTimeNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPUTimeNanoseconds is the cumulative CPU time consumed by all vCPUs of the guest.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memoryAvailableBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryAvailableBytes is the amount of memory visible to the guest.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memoryUsedBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryUsedBytes is the amount of memory in use by the guest as reported by the memory balloon driver. It is zero if the guest does not report memory statistics.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"timestamp", "vcpus", "cpuTimeNanoseconds", "memoryAvailableBytes", "memoryUsedBytes"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime"},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_VirtualMachineInstanceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -29374,6 +31434,26 @@ func schema_kubevirtio_api_core_v1_VirtualMachineInstanceStatus(ref common.Refer
 							Format:      "",
 						},
 					},
+					"sshHostKeys": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "SSHHostKeys contains the SSH host public keys reported by the guest agent, in the \"<key type> <base64 key>\" format used by known_hosts files.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"topologyHints": {
 						SchemaProps: spec.SchemaProps{
 							Ref: ref("kubevirt.io/api/core/v1.TopologyHints"),
@@ -29401,6 +31481,25 @@ func schema_kubevirtio_api_core_v1_VirtualMachineInstanceStatus(ref common.Refer
 							Format:      "int64",
 						},
 					},
+					"vsockServices": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "VSOCKServices reports the VSOCK port of every service of spec.domain.devices.vsockServices",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.VSOCKServiceStatus"),
+									},
+								},
+							},
+						},
+					},
 					"selinuxContext": {
 						SchemaProps: spec.SchemaProps{
 							Description: "SELinuxContext is the actual SELinux context of the virt-launcher pod",
@@ -29426,6 +31525,18 @@ func schema_kubevirtio_api_core_v1_VirtualMachineInstanceStatus(ref common.Refer
 							Ref:         ref("kubevirt.io/api/core/v1.MemoryStatus"),
 						},
 					},
+					"liveUpdatedResources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LiveUpdatedResources records the guest CPU and memory resources applied to the VMI by live updates since it was started.",
+							Ref:         ref("kubevirt.io/api/core/v1.LiveUpdatedResources"),
+						},
+					},
+					"cpuThreadShares": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPUThreadShares reports the CPU shares applied to the emulator and IO threads of the VMI.",
+							Ref:         ref("kubevirt.io/api/core/v1.CPUThreadShares"),
+						},
+					},
 					"migratedVolumes": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -29457,11 +31568,152 @@ func schema_kubevirtio_api_core_v1_VirtualMachineInstanceStatus(ref common.Refer
 							Ref:         ref("kubevirt.io/api/core/v1.ChangedBlockTrackingStatus"),
 						},
 					},
+					"bootTimes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BootTimes records when the VirtualMachineInstance reached the stages of its start.",
+							Ref:         ref("kubevirt.io/api/core/v1.VirtualMachineInstanceBootTimes"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/api/core/v1.CPUThreadShares", "kubevirt.io/api/core/v1.CPUTopology", "kubevirt.io/api/core/v1.ChangedBlockTrackingStatus", "kubevirt.io/api/core/v1.DeviceStatus", "kubevirt.io/api/core/v1.KernelBootStatus", "kubevirt.io/api/core/v1.LiveUpdatedResources", "kubevirt.io/api/core/v1.Machine", "kubevirt.io/api/core/v1.MemoryStatus", "kubevirt.io/api/core/v1.StorageMigratedVolumeInfo", "kubevirt.io/api/core/v1.TopologyHints", "kubevirt.io/api/core/v1.VSOCKServiceStatus", "kubevirt.io/api/core/v1.VirtualMachineInstanceBootTimes", "kubevirt.io/api/core/v1.VirtualMachineInstanceCondition", "kubevirt.io/api/core/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationState", "kubevirt.io/api/core/v1.VirtualMachineInstanceNetworkInterface", "kubevirt.io/api/core/v1.VirtualMachineInstancePhaseTransitionTimestamp", "kubevirt.io/api/core/v1.VolumeStatus"},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_VirtualMachineInstanceSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceSummary is a condensed view of a VirtualMachineInstance",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"creationTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"ready": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ready reflects the Ready condition of the VirtualMachineInstance",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"ipAddress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IPAddress is the first IP address reported for the VirtualMachineInstance",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
+				Required: []string{"name", "namespace", "creationTimestamp", "ready"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/api/core/v1.CPUTopology", "kubevirt.io/api/core/v1.ChangedBlockTrackingStatus", "kubevirt.io/api/core/v1.DeviceStatus", "kubevirt.io/api/core/v1.KernelBootStatus", "kubevirt.io/api/core/v1.Machine", "kubevirt.io/api/core/v1.MemoryStatus", "kubevirt.io/api/core/v1.StorageMigratedVolumeInfo", "kubevirt.io/api/core/v1.TopologyHints", "kubevirt.io/api/core/v1.VirtualMachineInstanceCondition", "kubevirt.io/api/core/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/api/core/v1.VirtualMachineInstanceMigrationState", "kubevirt.io/api/core/v1.VirtualMachineInstanceNetworkInterface", "kubevirt.io/api/core/v1.VirtualMachineInstancePhaseTransitionTimestamp", "kubevirt.io/api/core/v1.VolumeStatus"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_VirtualMachineInstanceSummaryList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceSummaryList is a page of VirtualMachineInstance summaries served from the cache of virt-api",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"total": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Total is the number of VirtualMachineInstances matching the request across all pages",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"phaseCounts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PhaseCounts is the number of VirtualMachineInstances matching the request per phase across all pages",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int32",
+									},
+								},
+							},
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.VirtualMachineInstanceSummary"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"total", "items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/api/core/v1.VirtualMachineInstanceSummary"},
 	}
 }
 
@@ -29596,6 +31848,13 @@ func schema_kubevirtio_api_core_v1_VirtualMachineMemoryDumpRequest(ref common.Re
 							Format:      "",
 						},
 					},
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Format is the format the memory is dumped in, defaults to Raw",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"claimName", "phase"},
 			},
@@ -29632,6 +31891,108 @@ func schema_kubevirtio_api_core_v1_VirtualMachineOptions(ref common.ReferenceCal
 	}
 }
 
+func schema_kubevirtio_api_core_v1_VirtualMachinePreflightCheck(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachinePreflightCheck is the result of a single check of a VirtualMachine start simulation.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"category": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Category is the area the check belongs to, one of Image, Storage, Device, Compute or Network.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name identifies what was checked, e.g. the name of a volume or a network.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the outcome of the check, one of Passed, Warning or Failed.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message describes the outcome of the check.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"category", "name", "status"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_api_core_v1_VirtualMachinePreflightReport(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachinePreflightReport is the result of simulating the start of a VirtualMachine without creating its VirtualMachineInstance.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ready": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ready is true if none of the checks failed.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"checks": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Checks holds the result of every check that was performed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.VirtualMachinePreflightCheck"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"ready"},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/api/core/v1.VirtualMachinePreflightCheck"},
+	}
+}
+
 func schema_kubevirtio_api_core_v1_VirtualMachineSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -29692,6 +32053,13 @@ func schema_kubevirtio_api_core_v1_VirtualMachineSpec(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
+					"deletionProtection": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeletionProtection rejects the deletion of the VirtualMachine until it is unlocked, either by\nsetting the kubevirt.io/deletion-unlock annotation to the name of the VirtualMachine, or once\nthe time set in the kubevirt.io/deletion-unlock-after annotation passed.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"template"},
 			},
@@ -29937,11 +32305,51 @@ func schema_kubevirtio_api_core_v1_VirtualMachineStatus(ref common.ReferenceCall
 							Ref:         ref("kubevirt.io/api/core/v1.InstancetypeStatusRef"),
 						},
 					},
+					"ipAddresses": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "IPAddresses mirrors the IP addresses reported on the interfaces of the VirtualMachineInstance",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeName mirrors the name of the node the VirtualMachineInstance is running on",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"guestAgentConnected": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestAgentConnected mirrors whether the guest agent of the VirtualMachineInstance is connected",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"instanceStatusLastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InstanceStatusLastUpdateTime is the last time ipAddresses, nodeName or guestAgentConnected changed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/api/core/v1.ChangedBlockTrackingStatus", "kubevirt.io/api/core/v1.InstancetypeStatusRef", "kubevirt.io/api/core/v1.VirtualMachineCondition", "kubevirt.io/api/core/v1.VirtualMachineMemoryDumpRequest", "kubevirt.io/api/core/v1.VirtualMachineStartFailure", "kubevirt.io/api/core/v1.VirtualMachineStateChangeRequest", "kubevirt.io/api/core/v1.VirtualMachineVolumeRequest", "kubevirt.io/api/core/v1.VolumeSnapshotStatus", "kubevirt.io/api/core/v1.VolumeUpdateState"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/api/core/v1.ChangedBlockTrackingStatus", "kubevirt.io/api/core/v1.InstancetypeStatusRef", "kubevirt.io/api/core/v1.VirtualMachineCondition", "kubevirt.io/api/core/v1.VirtualMachineMemoryDumpRequest", "kubevirt.io/api/core/v1.VirtualMachineStartFailure", "kubevirt.io/api/core/v1.VirtualMachineStateChangeRequest", "kubevirt.io/api/core/v1.VirtualMachineVolumeRequest", "kubevirt.io/api/core/v1.VolumeSnapshotStatus", "kubevirt.io/api/core/v1.VolumeUpdateState"},
 	}
 }
 
@@ -30740,6 +33148,19 @@ func schema_kubevirtio_api_export_v1alpha1_VirtualMachineExportSpec(ref common.R
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"autoRenew": {
+						SchemaProps: spec.SchemaProps{
+							Description: "autoRenew extends the expiration of the export by ttlDuration while downloads are in progress. If this field is omitted, the autoRenew setting of the KubeVirt configuration is applied.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"maxLifetime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "maxLifetime limits the lifetime of an export including its renewals, counting from CreationTimestamp. It is capped by the maxLifetime of the KubeVirt configuration.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 				},
 				Required: []string{"source"},
 			},
@@ -30776,7 +33197,13 @@ func schema_kubevirtio_api_export_v1alpha1_VirtualMachineExportStatus(ref common
 					},
 					"ttlExpirationTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The time at which the VM Export will be completely removed according to specified TTL Formula is CreationTimestamp + TTL",
+							Description: "The time at which the VM Export will be completely removed according to specified TTL Formula is CreationTimestamp + TTL, or LastRenewalTime + TTL once renewed, capped by the max lifetime",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastRenewalTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastRenewalTime is the time at which the expiration of the VM Export was last extended because of downloads in progress",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
@@ -31197,6 +33624,19 @@ func schema_kubevirtio_api_export_v1beta1_VirtualMachineExportSpec(ref common.Re
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"autoRenew": {
+						SchemaProps: spec.SchemaProps{
+							Description: "autoRenew extends the expiration of the export by ttlDuration while downloads are in progress. If this field is omitted, the autoRenew setting of the KubeVirt configuration is applied.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"maxLifetime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "maxLifetime limits the lifetime of an export including its renewals, counting from CreationTimestamp. It is capped by the maxLifetime of the KubeVirt configuration.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 				},
 				Required: []string{"source"},
 			},
@@ -31233,7 +33673,13 @@ func schema_kubevirtio_api_export_v1beta1_VirtualMachineExportStatus(ref common.
 					},
 					"ttlExpirationTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The time at which the VM Export will be completely removed according to specified TTL Formula is CreationTimestamp + TTL",
+							Description: "The time at which the VM Export will be completely removed according to specified TTL Formula is CreationTimestamp + TTL, or LastRenewalTime + TTL once renewed, capped by the max lifetime",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastRenewalTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastRenewalTime is the time at which the expiration of the VM Export was last extended because of downloads in progress",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
@@ -31797,6 +34243,80 @@ func schema_kubevirtio_api_instancetype_v1beta1_FirmwarePreferences(ref common.R
 	}
 }
 
+func schema_kubevirtio_api_instancetype_v1beta1_InstancetypeArchitectureVariant(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InstancetypeArchitectureVariant contains the architecture specific configuration of a given VirtualMachineInstancetypeSpec.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"architecture": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Required architecture the variant applies to, e.g. amd64, arm64 or s390x.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cpu": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optionally replaces the CPU related attributes of the instancetype.",
+							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.CPUInstancetype"),
+						},
+					},
+					"memory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optionally replaces the Memory related attributes of the instancetype.",
+							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.MemoryInstancetype"),
+						},
+					},
+					"gpus": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Optionally replaces the GPU devices associated with the instancetype.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.GPU"),
+									},
+								},
+							},
+						},
+					},
+					"hostDevices": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Optionally replaces the HostDevices associated with the instancetype.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/core/v1.HostDevice"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"architecture"},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/api/core/v1.GPU", "kubevirt.io/api/core/v1.HostDevice", "kubevirt.io/api/instancetype/v1beta1.CPUInstancetype", "kubevirt.io/api/instancetype/v1beta1.MemoryInstancetype"},
+	}
+}
+
 func schema_kubevirtio_api_instancetype_v1beta1_MachinePreferences(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -32336,12 +34856,34 @@ func schema_kubevirtio_api_instancetype_v1beta1_VirtualMachineInstancetypeSpec(r
 							},
 						},
 					},
+					"architectureVariants": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"architecture",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Optionally defines architecture specific variants of the instancetype.\n\nThe variant matching the architecture of the VirtualMachineInstance, or the PreferredArchitecture of the preference if the former is not set, replaces the CPU, Memory, GPUs and HostDevices it provides before the instancetype is applied.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/instancetype/v1beta1.InstancetypeArchitectureVariant"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"cpu", "memory"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/api/core/v1.DiskIOThreads", "kubevirt.io/api/core/v1.GPU", "kubevirt.io/api/core/v1.HostDevice", "kubevirt.io/api/core/v1.LaunchSecurity", "kubevirt.io/api/instancetype/v1beta1.CPUInstancetype", "kubevirt.io/api/instancetype/v1beta1.MemoryInstancetype"},
+			"kubevirt.io/api/core/v1.DiskIOThreads", "kubevirt.io/api/core/v1.GPU", "kubevirt.io/api/core/v1.HostDevice", "kubevirt.io/api/core/v1.LaunchSecurity", "kubevirt.io/api/instancetype/v1beta1.CPUInstancetype", "kubevirt.io/api/instancetype/v1beta1.InstancetypeArchitectureVariant", "kubevirt.io/api/instancetype/v1beta1.MemoryInstancetype"},
 	}
 }
 
@@ -32418,7 +34960,437 @@ func schema_kubevirtio_api_instancetype_v1beta1_VirtualMachinePreferenceList(ref
 					"items": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
-								"x-kubernetes-list-type": "set",
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/instancetype/v1beta1.VirtualMachinePreference"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/api/instancetype/v1beta1.VirtualMachinePreference"},
+	}
+}
+
+func schema_kubevirtio_api_instancetype_v1beta1_VirtualMachinePreferenceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachinePreferenceSpec is a description of the VirtualMachinePreference or VirtualMachineClusterPreference.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"clock": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Clock optionally defines preferences associated with the Clock attribute of a VirtualMachineInstance DomainSpec",
+							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.ClockPreferences"),
+						},
+					},
+					"cpu": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPU optionally defines preferences associated with the CPU attribute of a VirtualMachineInstance DomainSpec",
+							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.CPUPreferences"),
+						},
+					},
+					"devices": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Devices optionally defines preferences associated with the Devices attribute of a VirtualMachineInstance DomainSpec",
+							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.DevicePreferences"),
+						},
+					},
+					"features": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Features optionally defines preferences associated with the Features attribute of a VirtualMachineInstance DomainSpec",
+							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.FeaturePreferences"),
+						},
+					},
+					"firmware": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Firmware optionally defines preferences associated with the Firmware attribute of a VirtualMachineInstance DomainSpec",
+							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.FirmwarePreferences"),
+						},
+					},
+					"machine": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Machine optionally defines preferences associated with the Machine attribute of a VirtualMachineInstance DomainSpec",
+							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.MachinePreferences"),
+						},
+					},
+					"volumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Volumes optionally defines preferences associated with the Volumes attribute of a VirtualMachineInstace DomainSpec",
+							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.VolumePreferences"),
+						},
+					},
+					"preferredSubdomain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subdomain of the VirtualMachineInstance",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"preferredTerminationGracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Grace period observed after signalling a VirtualMachineInstance to stop after which the VirtualMachineInstance is force terminated.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"requirements": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Requirements defines the minium amount of instance type defined resources required by a set of preferences",
+							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.PreferenceRequirements"),
+						},
+					},
+					"annotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Optionally defines preferred Annotations to be applied to the VirtualMachineInstance",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"preferSpreadSocketToCoreRatio": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreferSpreadSocketToCoreRatio defines the ratio to spread vCPUs between cores and sockets, it defaults to 2.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"preferredArchitecture": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreferredArchitecture defines a prefeerred architecture for the VirtualMachine",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/api/instancetype/v1beta1.CPUPreferences", "kubevirt.io/api/instancetype/v1beta1.ClockPreferences", "kubevirt.io/api/instancetype/v1beta1.DevicePreferences", "kubevirt.io/api/instancetype/v1beta1.FeaturePreferences", "kubevirt.io/api/instancetype/v1beta1.FirmwarePreferences", "kubevirt.io/api/instancetype/v1beta1.MachinePreferences", "kubevirt.io/api/instancetype/v1beta1.PreferenceRequirements", "kubevirt.io/api/instancetype/v1beta1.VolumePreferences"},
+	}
+}
+
+func schema_kubevirtio_api_instancetype_v1beta1_VolumePreferences(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"preferredStorageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreffereedStorageClassName optionally defines the preferred storageClass",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_api_migrations_v1alpha1_MigrationPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MigrationPolicy holds migration policy (i.e. configurations) to apply to a VM or group of VMs",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("kubevirt.io/api/migrations/v1alpha1.MigrationPolicySpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("kubevirt.io/api/migrations/v1alpha1.MigrationPolicyStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/api/migrations/v1alpha1.MigrationPolicySpec", "kubevirt.io/api/migrations/v1alpha1.MigrationPolicyStatus"},
+	}
+}
+
+func schema_kubevirtio_api_migrations_v1alpha1_MigrationPolicyList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MigrationPolicyList is a list of MigrationPolicy",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/api/migrations/v1alpha1.MigrationPolicy"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/api/migrations/v1alpha1.MigrationPolicy"},
+	}
+}
+
+func schema_kubevirtio_api_migrations_v1alpha1_MigrationPolicySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selectors": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/api/migrations/v1alpha1.Selectors"),
+						},
+					},
+					"allowAutoConverge": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"bandwidthPerMigration": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"completionTimeoutPerGiB": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"allowPostCopy": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"allowWorkloadDisruption": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"selectors"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "kubevirt.io/api/migrations/v1alpha1.Selectors"},
+	}
+}
+
+func schema_kubevirtio_api_migrations_v1alpha1_MigrationPolicyStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_api_migrations_v1alpha1_Selectors(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"virtualMachineInstanceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineOperation applies an operation to every VirtualMachine matching a selector",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationSpec", "kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationStatus"},
+	}
+}
+
+func schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperationList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineOperationList is a list of VirtualMachineOperation resources",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
 							},
 						},
 						SchemaProps: spec.SchemaProps{
@@ -32427,347 +35399,237 @@ func schema_kubevirtio_api_instancetype_v1beta1_VirtualMachinePreferenceList(ref
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("kubevirt.io/api/instancetype/v1beta1.VirtualMachinePreference"),
+										Ref:     ref("kubevirt.io/api/operations/v1alpha1.VirtualMachineOperation"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"items"},
+				Required: []string{"metadata", "items"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/api/instancetype/v1beta1.VirtualMachinePreference"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/api/operations/v1alpha1.VirtualMachineOperation"},
 	}
 }
 
-func schema_kubevirtio_api_instancetype_v1beta1_VirtualMachinePreferenceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperationProgress(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachinePreferenceSpec is a description of the VirtualMachinePreference or VirtualMachineClusterPreference.",
+				Description: "VirtualMachineOperationProgress counts the targets of a VirtualMachineOperation by phase",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"clock": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Clock optionally defines preferences associated with the Clock attribute of a VirtualMachineInstance DomainSpec",
-							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.ClockPreferences"),
-						},
-					},
-					"cpu": {
-						SchemaProps: spec.SchemaProps{
-							Description: "CPU optionally defines preferences associated with the CPU attribute of a VirtualMachineInstance DomainSpec",
-							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.CPUPreferences"),
-						},
-					},
-					"devices": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Devices optionally defines preferences associated with the Devices attribute of a VirtualMachineInstance DomainSpec",
-							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.DevicePreferences"),
-						},
-					},
-					"features": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Features optionally defines preferences associated with the Features attribute of a VirtualMachineInstance DomainSpec",
-							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.FeaturePreferences"),
-						},
-					},
-					"firmware": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Firmware optionally defines preferences associated with the Firmware attribute of a VirtualMachineInstance DomainSpec",
-							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.FirmwarePreferences"),
-						},
-					},
-					"machine": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Machine optionally defines preferences associated with the Machine attribute of a VirtualMachineInstance DomainSpec",
-							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.MachinePreferences"),
-						},
-					},
-					"volumes": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Volumes optionally defines preferences associated with the Volumes attribute of a VirtualMachineInstace DomainSpec",
-							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.VolumePreferences"),
-						},
-					},
-					"preferredSubdomain": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Subdomain of the VirtualMachineInstance",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"preferredTerminationGracePeriodSeconds": {
+					"total": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Grace period observed after signalling a VirtualMachineInstance to stop after which the VirtualMachineInstance is force terminated.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
 						},
 					},
-					"requirements": {
+					"pending": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Requirements defines the minium amount of instance type defined resources required by a set of preferences",
-							Ref:         ref("kubevirt.io/api/instancetype/v1beta1.PreferenceRequirements"),
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
 						},
 					},
-					"annotations": {
+					"running": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Optionally defines preferred Annotations to be applied to the VirtualMachineInstance",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
 						},
 					},
-					"preferSpreadSocketToCoreRatio": {
+					"succeeded": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PreferSpreadSocketToCoreRatio defines the ratio to spread vCPUs between cores and sockets, it defaults to 2.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
 						},
 					},
-					"preferredArchitecture": {
+					"failed": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PreferredArchitecture defines a prefeerred architecture for the VirtualMachine",
-							Type:        []string{"string"},
-							Format:      "",
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"kubevirt.io/api/instancetype/v1beta1.CPUPreferences", "kubevirt.io/api/instancetype/v1beta1.ClockPreferences", "kubevirt.io/api/instancetype/v1beta1.DevicePreferences", "kubevirt.io/api/instancetype/v1beta1.FeaturePreferences", "kubevirt.io/api/instancetype/v1beta1.FirmwarePreferences", "kubevirt.io/api/instancetype/v1beta1.MachinePreferences", "kubevirt.io/api/instancetype/v1beta1.PreferenceRequirements", "kubevirt.io/api/instancetype/v1beta1.VolumePreferences"},
-	}
-}
-
-func schema_kubevirtio_api_instancetype_v1beta1_VolumePreferences(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"preferredStorageClassName": {
+					"skipped": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PreffereedStorageClassName optionally defines the preferred storageClass",
-							Type:        []string{"string"},
-							Format:      "",
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
 						},
 					},
 				},
+				Required: []string{"total", "pending", "running", "succeeded", "failed", "skipped"},
 			},
 		},
 	}
 }
 
-func schema_kubevirtio_api_migrations_v1alpha1_MigrationPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "MigrationPolicy holds migration policy (i.e. configurations) to apply to a VM or group of VMs",
+				Description: "VirtualMachineOperationSpec is the spec for a VirtualMachineOperation resource",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"operation": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Description: "Operation is the action applied to each targeted VirtualMachine",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"apiVersion": {
+					"selector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Selector selects the VirtualMachines in the namespace of the operation. The targets are resolved once, when the operation starts.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
 						},
 					},
-					"metadata": {
+					"maxConcurrency": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "MaxConcurrency is the maximum number of targets processed at the same time. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"spec": {
+					"maxFailures": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("kubevirt.io/api/migrations/v1alpha1.MigrationPolicySpec"),
+							Description: "MaxFailures is the number of failed targets tolerated. Once more targets failed, no further targets are started and the remaining ones are skipped. If unset, all targets are processed regardless of failures.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"status": {
+					"targetTimeout": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("kubevirt.io/api/migrations/v1alpha1.MigrationPolicyStatus"),
+							Description: "TargetTimeout is the time a single target may take before it is considered failed. Defaults to 10 minutes.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
 				},
-				Required: []string{"spec"},
+				Required: []string{"operation", "selector"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/api/migrations/v1alpha1.MigrationPolicySpec", "kubevirt.io/api/migrations/v1alpha1.MigrationPolicyStatus"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
-func schema_kubevirtio_api_migrations_v1alpha1_MigrationPolicyList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "MigrationPolicyList is a list of MigrationPolicy",
+				Description: "VirtualMachineOperationStatus is the status for a VirtualMachineOperation resource",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"phase": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"apiVersion": {
+					"startTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"metadata": {
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"progress": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+							Ref:     ref("kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationProgress"),
 						},
 					},
-					"items": {
+					"targets": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
-								"x-kubernetes-list-type": "atomic",
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "Targets holds the result of the operation for each targeted VirtualMachine",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("kubevirt.io/api/migrations/v1alpha1.MigrationPolicy"),
+										Ref:     ref("kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationTarget"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"items"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/api/migrations/v1alpha1.MigrationPolicy"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationProgress", "kubevirt.io/api/operations/v1alpha1.VirtualMachineOperationTarget"},
 	}
 }
 
-func schema_kubevirtio_api_migrations_v1alpha1_MigrationPolicySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_api_operations_v1alpha1_VirtualMachineOperationTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "VirtualMachineOperationTarget is the result of a VirtualMachineOperation for a single VirtualMachine",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"selectors": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("kubevirt.io/api/migrations/v1alpha1.Selectors"),
-						},
-					},
-					"allowAutoConverge": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
-						},
-					},
-					"bandwidthPerMigration": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+							Description: "Name of the targeted VirtualMachine",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"completionTimeoutPerGiB": {
+					"phase": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int64",
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
-					"allowPostCopy": {
+					"reference": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Reference points to the object created for the target, e.g. the migration or the snapshot",
+							Ref:         ref("k8s.io/api/core/v1.TypedLocalObjectReference"),
 						},
 					},
-					"allowWorkloadDisruption": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
+							Type:   []string{"string"},
 							Format: "",
 						},
 					},
-				},
-				Required: []string{"selectors"},
-			},
-		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity", "kubevirt.io/api/migrations/v1alpha1.Selectors"},
-	}
-}
-
-func schema_kubevirtio_api_migrations_v1alpha1_MigrationPolicyStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-			},
-		},
-	}
-}
-
-func schema_kubevirtio_api_migrations_v1alpha1_Selectors(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"namespaceSelector": {
+					"startTime": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"virtualMachineInstanceSelector": {
+					"completionTime": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
 				},
+				Required: []string{"name", "phase"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.TypedLocalObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -34965,6 +37827,47 @@ func schema_kubevirtio_api_snapshot_v1beta1_Error(ref common.ReferenceCallback)
 	}
 }
 
+func schema_kubevirtio_api_snapshot_v1beta1_IdentityPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IdentityPolicy defines which identity attributes of the source VM are regenerated versus preserved when a VM is restored from a snapshot or cloned. Attributes that are not set fall back to the default of the operation: a restore preserves all of them, a clone regenerates all of them but the hostname.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"macAddresses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MacAddresses controls the MAC addresses of the VM interfaces. Regenerated addresses are assigned by the cluster, e.g. by KubeMacPool.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"smbiosSerial": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SMBiosSerial controls the SMBIOS serial number of the VM.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"firmwareUUID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FirmwareUUID controls the SMBIOS system UUID of the VM, which guests commonly use as a hint to initialize their machine-id. A regenerated UUID is derived from the target VM name.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hostname": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hostname controls the hostname of the VM. A regenerated hostname defaults to the target VM name.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_api_snapshot_v1beta1_PersistentVolumeClaim(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -35282,6 +38185,12 @@ func schema_kubevirtio_api_snapshot_v1beta1_VirtualMachineRestoreSpec(ref common
 							},
 						},
 					},
+					"identityPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IdentityPolicy defines which identity attributes of the snapshotted VM are regenerated in the restored VM. All of them are preserved by default.",
+							Ref:         ref("kubevirt.io/api/snapshot/v1beta1.IdentityPolicy"),
+						},
+					},
 					"patches": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -35307,7 +38216,7 @@ func schema_kubevirtio_api_snapshot_v1beta1_VirtualMachineRestoreSpec(ref common
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.TypedLocalObjectReference", "kubevirt.io/api/snapshot/v1beta1.VolumeRestoreOverride"},
+			"k8s.io/api/core/v1.TypedLocalObjectReference", "kubevirt.io/api/snapshot/v1beta1.IdentityPolicy", "kubevirt.io/api/snapshot/v1beta1.VolumeRestoreOverride"},
 	}
 }
 
@@ -35704,6 +38613,20 @@ func schema_kubevirtio_api_snapshot_v1beta1_VirtualMachineSnapshotSpec(ref commo
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"includeMemory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludeMemory captures the memory state of the VM in the snapshot, the VM has to be paused. Restoring the snapshot resumes the VM from the memory state instead of booting it.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"memoryStorageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryStorageClassName is the storage class of the PVC the memory state is saved to. The default storage class is used if not set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"source"},
 			},
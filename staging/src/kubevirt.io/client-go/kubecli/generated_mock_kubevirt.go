@@ -91,6 +91,7 @@ import (
 	v1beta118 "kubevirt.io/client-go/kubevirt/typed/export/v1beta1"
 	v1beta119 "kubevirt.io/client-go/kubevirt/typed/instancetype/v1beta1"
 	v1alpha110 "kubevirt.io/client-go/kubevirt/typed/migrations/v1alpha1"
+	v1alpha111 "kubevirt.io/client-go/kubevirt/typed/operations/v1alpha1"
 	v1beta120 "kubevirt.io/client-go/kubevirt/typed/pool/v1beta1"
 	v1beta121 "kubevirt.io/client-go/kubevirt/typed/snapshot/v1beta1"
 	networkattachmentdefinitionclient "kubevirt.io/client-go/networkattachmentdefinitionclient"
@@ -1355,6 +1356,20 @@ func (mr *MockKubevirtClientMockRecorder) VirtualMachineInstancetype(namespace a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VirtualMachineInstancetype", reflect.TypeOf((*MockKubevirtClient)(nil).VirtualMachineInstancetype), namespace)
 }
 
+// VirtualMachineOperation mocks base method.
+func (m *MockKubevirtClient) VirtualMachineOperation(namespace string) v1alpha111.VirtualMachineOperationInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VirtualMachineOperation", namespace)
+	ret0, _ := ret[0].(v1alpha111.VirtualMachineOperationInterface)
+	return ret0
+}
+
+// VirtualMachineOperation indicates an expected call of VirtualMachineOperation.
+func (mr *MockKubevirtClientMockRecorder) VirtualMachineOperation(namespace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VirtualMachineOperation", reflect.TypeOf((*MockKubevirtClient)(nil).VirtualMachineOperation), namespace)
+}
+
 // VirtualMachinePool mocks base method.
 func (m *MockKubevirtClient) VirtualMachinePool(namespace string) v1beta120.VirtualMachinePoolInterface {
 	m.ctrl.T.Helper()
@@ -1520,6 +1535,36 @@ func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) DeleteCollection(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).DeleteCollection), ctx, opts, listOpts)
 }
 
+// Domain mocks base method.
+func (m *MockVirtualMachineInstanceInterface) Domain(ctx context.Context, name string) (v122.VirtualMachineInstanceDomain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Domain", ctx, name)
+	ret0, _ := ret[0].(v122.VirtualMachineInstanceDomain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Domain indicates an expected call of Domain.
+func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) Domain(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Domain", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).Domain), ctx, name)
+}
+
+// DomainStats mocks base method.
+func (m *MockVirtualMachineInstanceInterface) DomainStats(ctx context.Context, name string) (v122.VirtualMachineInstanceDomainStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DomainStats", ctx, name)
+	ret0, _ := ret[0].(v122.VirtualMachineInstanceDomainStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DomainStats indicates an expected call of DomainStats.
+func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) DomainStats(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DomainStats", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).DomainStats), ctx, name)
+}
+
 // EvacuateCancel mocks base method.
 func (m *MockVirtualMachineInstanceInterface) EvacuateCancel(ctx context.Context, name string, evacuateCancelOptions *v122.EvacuateCancelOptions) error {
 	m.ctrl.T.Helper()
@@ -1563,6 +1608,20 @@ func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) Freeze(ctx, name, unf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Freeze", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).Freeze), ctx, name, unfreezeTimeout)
 }
 
+// FreezeWithOptions mocks base method.
+func (m *MockVirtualMachineInstanceInterface) FreezeWithOptions(ctx context.Context, name string, freezeOptions *v122.FreezeUnfreezeTimeout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeWithOptions", ctx, name, freezeOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FreezeWithOptions indicates an expected call of FreezeWithOptions.
+func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) FreezeWithOptions(ctx, name, freezeOptions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeWithOptions", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).FreezeWithOptions), ctx, name, freezeOptions)
+}
+
 // Get mocks base method.
 func (m *MockVirtualMachineInstanceInterface) Get(ctx context.Context, name string, opts v12.GetOptions) (*v122.VirtualMachineInstance, error) {
 	m.ctrl.T.Helper()
@@ -1593,6 +1652,20 @@ func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) GuestOsInfo(ctx, name
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GuestOsInfo", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).GuestOsInfo), ctx, name)
 }
 
+// InjectNMI mocks base method.
+func (m *MockVirtualMachineInstanceInterface) InjectNMI(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectNMI", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectNMI indicates an expected call of InjectNMI.
+func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) InjectNMI(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectNMI", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).InjectNMI), ctx, name)
+}
+
 // List mocks base method.
 func (m *MockVirtualMachineInstanceInterface) List(ctx context.Context, opts v12.ListOptions) (*v122.VirtualMachineInstanceList, error) {
 	m.ctrl.T.Helper()
@@ -1714,6 +1787,36 @@ func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) Reset(ctx, name any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).Reset), ctx, name)
 }
 
+// ResourceUsage mocks base method.
+func (m *MockVirtualMachineInstanceInterface) ResourceUsage(ctx context.Context, name string) (v122.VirtualMachineInstanceResourceUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceUsage", ctx, name)
+	ret0, _ := ret[0].(v122.VirtualMachineInstanceResourceUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResourceUsage indicates an expected call of ResourceUsage.
+func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) ResourceUsage(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceUsage", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).ResourceUsage), ctx, name)
+}
+
+// SEVFetchAttestationReport mocks base method.
+func (m *MockVirtualMachineInstanceInterface) SEVFetchAttestationReport(ctx context.Context, name string, options *v122.SEVSNPAttestationReportOptions) (v122.SEVSNPAttestationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SEVFetchAttestationReport", ctx, name, options)
+	ret0, _ := ret[0].(v122.SEVSNPAttestationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SEVFetchAttestationReport indicates an expected call of SEVFetchAttestationReport.
+func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) SEVFetchAttestationReport(ctx, name, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SEVFetchAttestationReport", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).SEVFetchAttestationReport), ctx, name, options)
+}
+
 // SEVFetchCertChain mocks base method.
 func (m *MockVirtualMachineInstanceInterface) SEVFetchCertChain(ctx context.Context, name string) (v122.SEVPlatformInfo, error) {
 	m.ctrl.T.Helper()
@@ -1816,6 +1919,20 @@ func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) SoftReboot(ctx, name
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftReboot", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).SoftReboot), ctx, name)
 }
 
+// SoftRebootWithOptions mocks base method.
+func (m *MockVirtualMachineInstanceInterface) SoftRebootWithOptions(ctx context.Context, name string, softRebootOptions *v122.SoftRebootOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftRebootWithOptions", ctx, name, softRebootOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftRebootWithOptions indicates an expected call of SoftRebootWithOptions.
+func (mr *MockVirtualMachineInstanceInterfaceMockRecorder) SoftRebootWithOptions(ctx, name, softRebootOptions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftRebootWithOptions", reflect.TypeOf((*MockVirtualMachineInstanceInterface)(nil).SoftRebootWithOptions), ctx, name, softRebootOptions)
+}
+
 // USBRedir mocks base method.
 func (m *MockVirtualMachineInstanceInterface) USBRedir(vmiName string) (v123.StreamInterface, error) {
 	m.ctrl.T.Helper()
@@ -2506,6 +2623,21 @@ func (mr *MockVirtualMachineInterfaceMockRecorder) PatchStatus(ctx, name, pt, da
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchStatus", reflect.TypeOf((*MockVirtualMachineInterface)(nil).PatchStatus), ctx, name, pt, data, patchOptions)
 }
 
+// Preflight mocks base method.
+func (m *MockVirtualMachineInterface) Preflight(ctx context.Context, name string) (*v122.VirtualMachinePreflightReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preflight", ctx, name)
+	ret0, _ := ret[0].(*v122.VirtualMachinePreflightReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preflight indicates an expected call of Preflight.
+func (mr *MockVirtualMachineInterfaceMockRecorder) Preflight(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preflight", reflect.TypeOf((*MockVirtualMachineInterface)(nil).Preflight), ctx, name)
+}
+
 // PortForward mocks base method.
 func (m *MockVirtualMachineInterface) PortForward(name string, port int, protocol string) (v123.StreamInterface, error) {
 	m.ctrl.T.Helper()
@@ -2549,6 +2681,20 @@ func (mr *MockVirtualMachineInterfaceMockRecorder) RemoveVolume(ctx, name, remov
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVolume", reflect.TypeOf((*MockVirtualMachineInterface)(nil).RemoveVolume), ctx, name, removeVolumeOptions)
 }
 
+// Rename mocks base method.
+func (m *MockVirtualMachineInterface) Rename(ctx context.Context, name string, renameOptions *v122.RenameOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", ctx, name, renameOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockVirtualMachineInterfaceMockRecorder) Rename(ctx, name, renameOptions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockVirtualMachineInterface)(nil).Rename), ctx, name, renameOptions)
+}
+
 // Restart mocks base method.
 func (m *MockVirtualMachineInterface) Restart(ctx context.Context, name string, restartOptions *v122.RestartOptions) error {
 	m.ctrl.T.Helper()
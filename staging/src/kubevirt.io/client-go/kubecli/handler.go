@@ -32,14 +32,19 @@ const (
 	unfreezeTemplateURI           = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/unfreeze"
 	resetTemplateURI              = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/reset"
 	softRebootTemplateURI         = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/softreboot"
+	injectNMITemplateURI          = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/injectnmi"
 	guestInfoTemplateURI          = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/guestosinfo"
 	userListTemplateURI           = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/userlist"
 	filesystemListTemplateURI     = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/filesystemlist"
+	resourceUsageTemplateURI      = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/usage"
+	domainStatsTemplateURI        = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/domainstats"
+	domainTemplateURI             = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/domain"
 	screenshotTemplateURI         = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/vnc/screenshot"
 
 	sevFetchCertChainTemplateURI         = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/sev/fetchcertchain"
 	sevQueryLaunchMeasurementTemplateURI = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/sev/querylaunchmeasurement"
 	sevInjectLaunchSecretTemplateURI     = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/sev/injectlaunchsecret"
+	sevFetchAttestationReportTemplateURI = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/sev/fetchattestationreport"
 )
 
 func NewVirtHandlerClient(virtCli KubevirtClient, httpCli *http.Client) VirtHandlerClient {
@@ -70,15 +75,21 @@ type VirtHandlerConn interface {
 	UnfreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	ResetURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	SoftRebootURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	InjectNMIURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	SEVFetchCertChainURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	SEVQueryLaunchMeasurementURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	SEVInjectLaunchSecretURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	SEVFetchAttestationReportURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	Pod() (pod *v1.Pod, err error)
 	Put(url string, body io.ReadCloser) error
+	PutWithResponse(url string, body io.ReadCloser, contentType string) (string, error)
 	Get(url, contentType string) (string, error)
 	GuestInfoURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	UserListURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	FilesystemListURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	ResourceUsageURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	DomainStatsURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	DomainURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	BackupURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	RedefineCheckpointURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 }
@@ -242,6 +253,10 @@ func (v *virtHandlerConn) SoftRebootURI(vmi *virtv1.VirtualMachineInstance) (str
 	return v.formatURI(softRebootTemplateURI, vmi)
 }
 
+func (v *virtHandlerConn) InjectNMIURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	return v.formatURI(injectNMITemplateURI, vmi)
+}
+
 func (v *virtHandlerConn) PauseURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
 	return v.formatURI(pauseTemplateURI, vmi)
 }
@@ -282,17 +297,20 @@ func (v *virtHandlerConn) doRequest(req *http.Request) (response string, err err
 }
 
 func (v *virtHandlerConn) Put(url string, body io.ReadCloser) error {
+	_, err := v.PutWithResponse(url, body, "")
+	return err
+}
+
+func (v *virtHandlerConn) PutWithResponse(url string, body io.ReadCloser, contentType string) (string, error) {
 	req, err := http.NewRequest(http.MethodPut, url, body)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = v.doRequest(req)
-	if err != nil {
-		return err
+	if contentType != "" {
+		req.Header.Add("Accept", contentType)
 	}
-
-	return nil
+	return v.doRequest(req)
 }
 
 func (v *virtHandlerConn) Get(url, contentType string) (string, error) {
@@ -331,6 +349,18 @@ func (v *virtHandlerConn) FilesystemListURI(vmi *virtv1.VirtualMachineInstance)
 	return v.formatURI(filesystemListTemplateURI, vmi)
 }
 
+func (v *virtHandlerConn) ResourceUsageURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	return v.formatURI(resourceUsageTemplateURI, vmi)
+}
+
+func (v *virtHandlerConn) DomainStatsURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	return v.formatURI(domainStatsTemplateURI, vmi)
+}
+
+func (v *virtHandlerConn) DomainURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	return v.formatURI(domainTemplateURI, vmi)
+}
+
 func (v *virtHandlerConn) SEVFetchCertChainURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
 	return v.formatURI(sevFetchCertChainTemplateURI, vmi)
 }
@@ -342,3 +372,7 @@ func (v *virtHandlerConn) SEVQueryLaunchMeasurementURI(vmi *virtv1.VirtualMachin
 func (v *virtHandlerConn) SEVInjectLaunchSecretURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
 	return v.formatURI(sevInjectLaunchSecretTemplateURI, vmi)
 }
+
+func (v *virtHandlerConn) SEVFetchAttestationReportURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	return v.formatURI(sevFetchAttestationReportTemplateURI, vmi)
+}
@@ -48,6 +48,7 @@ import (
 	exportv1 "kubevirt.io/client-go/kubevirt/typed/export/v1beta1"
 	instancetypev1beta1 "kubevirt.io/client-go/kubevirt/typed/instancetype/v1beta1"
 	migrationsv1 "kubevirt.io/client-go/kubevirt/typed/migrations/v1alpha1"
+	operationsv1 "kubevirt.io/client-go/kubevirt/typed/operations/v1alpha1"
 	poolv1 "kubevirt.io/client-go/kubevirt/typed/pool/v1beta1"
 	snapshotv1 "kubevirt.io/client-go/kubevirt/typed/snapshot/v1beta1"
 	networkclient "kubevirt.io/client-go/networkattachmentdefinitionclient"
@@ -77,6 +78,7 @@ type KubevirtClient interface {
 	ExpandSpec(namespace string) ExpandSpecInterface
 	ServerVersion() ServerVersionInterface
 	VirtualMachineClone(namespace string) clone.VirtualMachineCloneInterface
+	VirtualMachineOperation(namespace string) operationsv1.VirtualMachineOperationInterface
 	ClusterProfiler() *ClusterProfiler
 	GuestfsVersion() *GuestfsVersion
 	RestClient() *rest.RESTClient
@@ -234,6 +236,10 @@ func (k kubevirtClient) VirtualMachineClone(namespace string) clone.VirtualMachi
 	return k.generatedKubeVirtClient.CloneV1beta1().VirtualMachineClones(namespace)
 }
 
+func (k kubevirtClient) VirtualMachineOperation(namespace string) operationsv1.VirtualMachineOperationInterface {
+	return k.generatedKubeVirtClient.OperationsV1alpha1().VirtualMachineOperations(namespace)
+}
+
 func (k kubevirtClient) VirtualMachineCloneClient() *clone.CloneV1beta1Client {
 	return k.cloneClient // TODO ihol3 delete function? who's using it?
 }
@@ -329,6 +329,28 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Entry("with proxied server URL", proxyPath),
 	)
 
+	DescribeTable("should freeze selected filesystems of a VirtualMachineInstance", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		freezeOptions := &v1.FreezeUnfreezeTimeout{
+			UnfreezeTimeout: &k8smetav1.Duration{Duration: 5 * time.Minute},
+			Mountpoints:     []string{"/data"},
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", path.Join(proxyPath, subVMIPath, "freeze")),
+			ghttp.VerifyBody([]byte(`{"unfreezeTimeout":"5m0s","mountpoints":["/data"]}`)),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err = client.VirtualMachineInstance(k8sv1.NamespaceDefault).FreezeWithOptions(context.Background(), "testvm", freezeOptions)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
 	DescribeTable("should unfreeze a VirtualMachineInstance", func(proxyPath string) {
 		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
 		Expect(err).ToNot(HaveOccurred())
@@ -380,6 +402,41 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Entry("with proxied server URL", proxyPath),
 	)
 
+	DescribeTable("should soft reboot a VirtualMachineInstance with options", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", path.Join(proxyPath, subVMIPath, "softreboot")),
+			ghttp.VerifyBody([]byte(`{"fallback":"fail"}`)),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err = client.VirtualMachineInstance(k8sv1.NamespaceDefault).SoftRebootWithOptions(context.Background(), "testvm", &v1.SoftRebootOptions{Fallback: v1.SoftRebootFallbackFail})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
+	DescribeTable("should inject an NMI into a VirtualMachineInstance", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", path.Join(proxyPath, subVMIPath, "injectnmi")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err = client.VirtualMachineInstance(k8sv1.NamespaceDefault).InjectNMI(context.Background(), "testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
 	DescribeTable("should fetch GuestOSInfo from VirtualMachineInstance via subresource", func(proxyPath string) {
 		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
 		Expect(err).ToNot(HaveOccurred())
@@ -449,6 +506,80 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Entry("with proxied server URL", proxyPath),
 	)
 
+	DescribeTable("should fetch ResourceUsage from VirtualMachineInstance via subresource", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		usage := v1.VirtualMachineInstanceResourceUsage{
+			VCPUs:                2,
+			CPUTimeNanoseconds:   1000000000,
+			MemoryAvailableBytes: 2147483648,
+			MemoryUsedBytes:      536870912,
+		}
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", path.Join(proxyPath, subVMIPath, "usage")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, usage),
+		))
+		fetchedUsage, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).ResourceUsage(context.Background(), "testvm")
+
+		Expect(err).ToNot(HaveOccurred(), "should fetch usage normally")
+		Expect(fetchedUsage).To(Equal(usage), "fetched usage should be the same as passed in")
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
+	DescribeTable("should fetch DomainStats from VirtualMachineInstance via subresource", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		domainStats := v1.VirtualMachineInstanceDomainStats{
+			CPU: &v1.DomainStatsCPU{TimeNanoseconds: 1000000000},
+			VCPUs: []v1.DomainStatsVCPU{
+				{ID: 0, State: "running", TimeNanoseconds: 500000000},
+				{ID: 1, State: "blocked", TimeNanoseconds: 500000000},
+			},
+			Memory:     &v1.DomainStatsMemory{AvailableBytes: 2147483648, UnusedBytes: 1073741824},
+			Disks:      []v1.DomainStatsDisk{{Name: "rootdisk", ReadBytes: 4096, WriteBytes: 8192}},
+			Interfaces: []v1.DomainStatsInterface{{Name: "default", RxBytes: 1500, TxBytes: 3000}},
+		}
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", path.Join(proxyPath, subVMIPath, "domainstats")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, domainStats),
+		))
+		fetchedDomainStats, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).DomainStats(context.Background(), "testvm")
+
+		Expect(err).ToNot(HaveOccurred(), "should fetch domain stats normally")
+		Expect(fetchedDomainStats).To(Equal(domainStats), "fetched domain stats should be the same as passed in")
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
+	DescribeTable("should fetch live and expected domain from VirtualMachineInstance via subresource", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		domain := v1.VirtualMachineInstanceDomain{
+			Live:     "<domain type=\"kvm\"><name>default_testvm</name></domain>",
+			Expected: "<domain type=\"kvm\"><name>default_testvm</name></domain>",
+		}
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", path.Join(proxyPath, subVMIPath, "domain")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, domain),
+		))
+		fetchedDomain, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Domain(context.Background(), "testvm")
+
+		Expect(err).ToNot(HaveOccurred(), "should fetch domain normally")
+		Expect(fetchedDomain).To(Equal(domain), "fetched domain should be the same as passed in")
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
 	DescribeTable("should fetch SEV platform info via subresource", func(proxyPath string) {
 		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
 		Expect(err).ToNot(HaveOccurred())
@@ -496,6 +627,29 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Entry("with proxied server URL", proxyPath),
 	)
 
+	DescribeTable("should fetch SEV-SNP attestation report via subresource", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		attestationReport := v1.SEVSNPAttestationReport{
+			Report:    "AAABBB",
+			CertChain: "CCCDDD",
+		}
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", path.Join(proxyPath, subVMIPath, "sev/fetchattestationreport")),
+			ghttp.VerifyBody([]byte(`{"reportData":"EEEFFF"}`)),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, attestationReport),
+		))
+		fetchedReport, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).SEVFetchAttestationReport(context.Background(), "testvm", &v1.SEVSNPAttestationReportOptions{ReportData: "EEEFFF"})
+
+		Expect(err).ToNot(HaveOccurred(), "should fetch report normally")
+		Expect(fetchedReport).To(Equal(attestationReport), "fetched report should be the same as passed in")
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
 	DescribeTable("should setup SEV session for a VirtualMachineInstance", func(proxyPath string) {
 		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
 		Expect(err).ToNot(HaveOccurred())
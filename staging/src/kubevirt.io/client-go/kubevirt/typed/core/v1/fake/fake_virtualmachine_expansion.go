@@ -118,3 +118,20 @@ func (c *fakeVirtualMachines) EvacuateCancel(ctx context.Context, name string, e
 
 	return err
 }
+
+func (c *fakeVirtualMachines) Preflight(ctx context.Context, name string) (*v1.VirtualMachinePreflightReport, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateSubresourceAction(c.Resource(), name, "preflight", c.Namespace(), nil), &v1.VirtualMachinePreflightReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.VirtualMachinePreflightReport), err
+}
+
+func (c *fakeVirtualMachines) Rename(ctx context.Context, name string, renameOptions *v1.RenameOptions) error {
+	_, err := c.Fake.
+		Invokes(fake2.NewPutSubresourceAction(c.Resource(), c.Namespace(), "rename", name, renameOptions), nil)
+
+	return err
+}
@@ -72,6 +72,13 @@ func (c *fakeVirtualMachineInstances) Freeze(ctx context.Context, name string, u
 	return err
 }
 
+func (c *fakeVirtualMachineInstances) FreezeWithOptions(ctx context.Context, name string, freezeOptions *v1.FreezeUnfreezeTimeout) error {
+	_, err := c.Fake.
+		Invokes(fake2.NewPutSubresourceAction(c.Resource(), c.Namespace(), "freeze", name, freezeOptions), nil)
+
+	return err
+}
+
 func (c *fakeVirtualMachineInstances) Unfreeze(ctx context.Context, name string) error {
 	_, err := c.Fake.
 		Invokes(fake2.NewPutSubresourceAction(c.Resource(), c.Namespace(), "unfreeze", name, struct{}{}), nil)
@@ -93,6 +100,20 @@ func (c *fakeVirtualMachineInstances) SoftReboot(ctx context.Context, name strin
 	return err
 }
 
+func (c *fakeVirtualMachineInstances) SoftRebootWithOptions(ctx context.Context, name string, softRebootOptions *v1.SoftRebootOptions) error {
+	_, err := c.Fake.
+		Invokes(fake2.NewPutSubresourceAction(c.Resource(), c.Namespace(), "softreboot", name, softRebootOptions), nil)
+
+	return err
+}
+
+func (c *fakeVirtualMachineInstances) InjectNMI(ctx context.Context, name string) error {
+	_, err := c.Fake.
+		Invokes(fake2.NewPutSubresourceAction(c.Resource(), c.Namespace(), "injectnmi", name, struct{}{}), nil)
+
+	return err
+}
+
 func (c *fakeVirtualMachineInstances) GuestOsInfo(ctx context.Context, name string) (v1.VirtualMachineInstanceGuestAgentInfo, error) {
 	_, err := c.Fake.
 		Invokes(testing.NewGetSubresourceAction(c.Resource(), c.Namespace(), "guestosinfo", name), &v1.VirtualMachineInstanceGuestAgentInfo{})
@@ -115,6 +136,27 @@ func (c *fakeVirtualMachineInstances) FilesystemList(ctx context.Context, name s
 	return v1.VirtualMachineInstanceFileSystemList{}, err
 }
 
+func (c *fakeVirtualMachineInstances) ResourceUsage(ctx context.Context, name string) (v1.VirtualMachineInstanceResourceUsage, error) {
+	_, err := c.Fake.
+		Invokes(testing.NewGetSubresourceAction(c.Resource(), c.Namespace(), "usage", name), nil)
+
+	return v1.VirtualMachineInstanceResourceUsage{}, err
+}
+
+func (c *fakeVirtualMachineInstances) Domain(ctx context.Context, name string) (v1.VirtualMachineInstanceDomain, error) {
+	_, err := c.Fake.
+		Invokes(testing.NewGetSubresourceAction(c.Resource(), c.Namespace(), "domain", name), nil)
+
+	return v1.VirtualMachineInstanceDomain{}, err
+}
+
+func (c *fakeVirtualMachineInstances) DomainStats(ctx context.Context, name string) (v1.VirtualMachineInstanceDomainStats, error) {
+	_, err := c.Fake.
+		Invokes(testing.NewGetSubresourceAction(c.Resource(), c.Namespace(), "domainstats", name), nil)
+
+	return v1.VirtualMachineInstanceDomainStats{}, err
+}
+
 func (c *fakeVirtualMachineInstances) AddVolume(ctx context.Context, name string, addVolumeOptions *v1.AddVolumeOptions) error {
 	_, err := c.Fake.
 		Invokes(fake2.NewPutSubresourceAction(c.Resource(), c.Namespace(), "addvolume", name, addVolumeOptions), nil)
@@ -161,6 +203,13 @@ func (c *fakeVirtualMachineInstances) SEVInjectLaunchSecret(ctx context.Context,
 	return err
 }
 
+func (c *fakeVirtualMachineInstances) SEVFetchAttestationReport(ctx context.Context, name string, options *v1.SEVSNPAttestationReportOptions) (v1.SEVSNPAttestationReport, error) {
+	_, err := c.Fake.
+		Invokes(fake2.NewPutSubresourceAction(c.Resource(), c.Namespace(), "sev/fetchattestationreport", name, options), &v1.SEVSNPAttestationReport{})
+
+	return v1.SEVSNPAttestationReport{}, err
+}
+
 func (c *fakeVirtualMachineInstances) ObjectGraph(ctx context.Context, name string, objectGraphOptions *v1.ObjectGraphOptions) (v1.ObjectGraphNode, error) {
 	obj, err := c.Fake.
 		Invokes(fake2.NewGetSubresourceAction(c.Resource(), c.Namespace(), "objectgraph", name, objectGraphOptions), nil)
@@ -48,6 +48,8 @@ type VirtualMachineExpansion interface {
 	RemoveMemoryDump(ctx context.Context, name string) error
 	ObjectGraph(ctx context.Context, name string, objectGraphOptions *v1.ObjectGraphOptions) (v1.ObjectGraphNode, error)
 	EvacuateCancel(ctx context.Context, name string, evacuateCancelOptions *v1.EvacuateCancelOptions) error
+	Preflight(ctx context.Context, name string) (*v1.VirtualMachinePreflightReport, error)
+	Rename(ctx context.Context, name string, renameOptions *v1.RenameOptions) error
 }
 
 func (c *virtualMachines) GetWithExpandedSpec(ctx context.Context, name string) (*v1.VirtualMachine, error) {
@@ -237,3 +239,36 @@ func (c *virtualMachines) EvacuateCancel(ctx context.Context, name string, evacu
 		Do(ctx).
 		Error()
 }
+
+func (c *virtualMachines) Preflight(ctx context.Context, name string) (*v1.VirtualMachinePreflightReport, error) {
+	report := &v1.VirtualMachinePreflightReport{}
+	err := c.GetClient().Post().
+		AbsPath(fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion)).
+		Namespace(c.GetNamespace()).
+		Resource("virtualmachines").
+		Name(name).
+		SubResource("preflight").
+		Do(ctx).
+		Into(report)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (c *virtualMachines) Rename(ctx context.Context, name string, renameOptions *v1.RenameOptions) error {
+	body, err := json.Marshal(renameOptions)
+	if err != nil {
+		return err
+	}
+
+	return c.GetClient().Put().
+		AbsPath(fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion)).
+		Namespace(c.GetNamespace()).
+		Resource("virtualmachines").
+		Name(name).
+		SubResource("rename").
+		Body(body).
+		Do(ctx).
+		Error()
+}
@@ -49,12 +49,18 @@ type VirtualMachineInstanceExpansion interface {
 	Pause(ctx context.Context, name string, pauseOptions *v1.PauseOptions) error
 	Unpause(ctx context.Context, name string, unpauseOptions *v1.UnpauseOptions) error
 	Freeze(ctx context.Context, name string, unfreezeTimeout time.Duration) error
+	FreezeWithOptions(ctx context.Context, name string, freezeOptions *v1.FreezeUnfreezeTimeout) error
 	Unfreeze(ctx context.Context, name string) error
 	Reset(ctx context.Context, name string) error
 	SoftReboot(ctx context.Context, name string) error
+	SoftRebootWithOptions(ctx context.Context, name string, softRebootOptions *v1.SoftRebootOptions) error
+	InjectNMI(ctx context.Context, name string) error
 	GuestOsInfo(ctx context.Context, name string) (v1.VirtualMachineInstanceGuestAgentInfo, error)
 	UserList(ctx context.Context, name string) (v1.VirtualMachineInstanceGuestOSUserList, error)
 	FilesystemList(ctx context.Context, name string) (v1.VirtualMachineInstanceFileSystemList, error)
+	ResourceUsage(ctx context.Context, name string) (v1.VirtualMachineInstanceResourceUsage, error)
+	DomainStats(ctx context.Context, name string) (v1.VirtualMachineInstanceDomainStats, error)
+	Domain(ctx context.Context, name string) (v1.VirtualMachineInstanceDomain, error)
 	ObjectGraph(ctx context.Context, name string, objectGraphOptions *v1.ObjectGraphOptions) (v1.ObjectGraphNode, error)
 	AddVolume(ctx context.Context, name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(ctx context.Context, name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
@@ -63,6 +69,7 @@ type VirtualMachineInstanceExpansion interface {
 	SEVQueryLaunchMeasurement(ctx context.Context, name string) (v1.SEVMeasurementInfo, error)
 	SEVSetupSession(ctx context.Context, name string, sevSessionOptions *v1.SEVSessionOptions) error
 	SEVInjectLaunchSecret(ctx context.Context, name string, sevSecretOptions *v1.SEVSecretOptions) error
+	SEVFetchAttestationReport(ctx context.Context, name string, options *v1.SEVSNPAttestationReportOptions) (v1.SEVSNPAttestationReport, error)
 	EvacuateCancel(ctx context.Context, name string, evacuateCancelOptions *v1.EvacuateCancelOptions) error
 }
 
@@ -183,14 +190,16 @@ func (c *virtualMachineInstances) Unpause(ctx context.Context, name string, unpa
 }
 
 func (c *virtualMachineInstances) Freeze(ctx context.Context, name string, unfreezeTimeout time.Duration) error {
-	log.Log.Infof("Freeze VMI %s", name)
-	freezeUnfreezeTimeout := &v1.FreezeUnfreezeTimeout{
+	return c.FreezeWithOptions(ctx, name, &v1.FreezeUnfreezeTimeout{
 		UnfreezeTimeout: &metav1.Duration{
 			Duration: unfreezeTimeout,
 		},
-	}
+	})
+}
 
-	body, err := json.Marshal(freezeUnfreezeTimeout)
+func (c *virtualMachineInstances) FreezeWithOptions(ctx context.Context, name string, freezeOptions *v1.FreezeUnfreezeTimeout) error {
+	log.Log.Infof("Freeze VMI %s", name)
+	body, err := json.Marshal(freezeOptions)
 	if err != nil {
 		return err
 	}
@@ -231,13 +240,34 @@ func (c *virtualMachineInstances) Reset(ctx context.Context, name string) error
 }
 
 func (c *virtualMachineInstances) SoftReboot(ctx context.Context, name string) error {
+	return c.SoftRebootWithOptions(ctx, name, &v1.SoftRebootOptions{})
+}
+
+func (c *virtualMachineInstances) SoftRebootWithOptions(ctx context.Context, name string, softRebootOptions *v1.SoftRebootOptions) error {
 	log.Log.Infof("SoftReboot VMI")
+	body, err := json.Marshal(softRebootOptions)
+	if err != nil {
+		return err
+	}
+
 	return c.GetClient().Put().
 		AbsPath(fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion)).
 		Namespace(c.GetNamespace()).
 		Resource("virtualmachineinstances").
 		Name(name).
 		SubResource("softreboot").
+		Body(body).
+		Do(ctx).
+		Error()
+}
+
+func (c *virtualMachineInstances) InjectNMI(ctx context.Context, name string) error {
+	return c.GetClient().Put().
+		AbsPath(fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion)).
+		Namespace(c.GetNamespace()).
+		Resource("virtualmachineinstances").
+		Name(name).
+		SubResource("injectnmi").
 		Do(ctx).
 		Error()
 }
@@ -316,6 +346,60 @@ func (c *virtualMachineInstances) FilesystemList(ctx context.Context, name strin
 	return fsList, err
 }
 
+func (c *virtualMachineInstances) ResourceUsage(ctx context.Context, name string) (v1.VirtualMachineInstanceResourceUsage, error) {
+	usage := v1.VirtualMachineInstanceResourceUsage{}
+	rawUsage, err := c.GetClient().Get().
+		AbsPath(fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion)).
+		Namespace(c.GetNamespace()).
+		Resource("virtualmachineinstances").
+		Name(name).
+		SubResource("usage").
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return usage, err
+	}
+
+	err = json.Unmarshal(rawUsage, &usage)
+	return usage, err
+}
+
+func (c *virtualMachineInstances) DomainStats(ctx context.Context, name string) (v1.VirtualMachineInstanceDomainStats, error) {
+	domainStats := v1.VirtualMachineInstanceDomainStats{}
+	rawDomainStats, err := c.GetClient().Get().
+		AbsPath(fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion)).
+		Namespace(c.GetNamespace()).
+		Resource("virtualmachineinstances").
+		Name(name).
+		SubResource("domainstats").
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return domainStats, err
+	}
+
+	err = json.Unmarshal(rawDomainStats, &domainStats)
+	return domainStats, err
+}
+
+func (c *virtualMachineInstances) Domain(ctx context.Context, name string) (v1.VirtualMachineInstanceDomain, error) {
+	domain := v1.VirtualMachineInstanceDomain{}
+	rawDomain, err := c.GetClient().Get().
+		AbsPath(fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion)).
+		Namespace(c.GetNamespace()).
+		Resource("virtualmachineinstances").
+		Name(name).
+		SubResource("domain").
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return domain, err
+	}
+
+	err = json.Unmarshal(rawDomain, &domain)
+	return domain, err
+}
+
 func (c *virtualMachineInstances) ObjectGraph(ctx context.Context, name string, objectGraphOptions *v1.ObjectGraphOptions) (v1.ObjectGraphNode, error) {
 	objectGraph := v1.ObjectGraphNode{}
 
@@ -438,6 +522,26 @@ func (c *virtualMachineInstances) SEVInjectLaunchSecret(ctx context.Context, nam
 		Error()
 }
 
+func (c *virtualMachineInstances) SEVFetchAttestationReport(ctx context.Context, name string, options *v1.SEVSNPAttestationReportOptions) (v1.SEVSNPAttestationReport, error) {
+	attestationReport := v1.SEVSNPAttestationReport{}
+	body, err := json.Marshal(options)
+	if err != nil {
+		return attestationReport, fmt.Errorf("cannot Marshal to json: %s", err)
+	}
+
+	err = c.GetClient().Put().
+		AbsPath(fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion)).
+		Namespace(c.GetNamespace()).
+		Resource("virtualmachineinstances").
+		Name(name).
+		SubResource("sev", "fetchattestationreport").
+		Body(body).
+		Do(ctx).
+		Into(&attestationReport)
+
+	return attestationReport, err
+}
+
 func (c *virtualMachineInstances) EvacuateCancel(ctx context.Context, name string, evacuateCancelOptions *v1.EvacuateCancelOptions) error {
 	body, err := json.Marshal(evacuateCancelOptions)
 	if err != nil {
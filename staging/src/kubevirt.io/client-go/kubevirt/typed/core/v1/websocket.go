@@ -23,6 +23,7 @@ import (
 	"crypto/tls"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/websocket"
 
@@ -31,6 +32,8 @@ import (
 
 const (
 	WebsocketMessageBufferSize = 10240
+
+	compressionExtension = "permessage-deflate"
 )
 
 func NewUpgrader() *websocket.Upgrader {
@@ -45,13 +48,37 @@ func NewUpgrader() *websocket.Upgrader {
 }
 
 func Dial(address string, tlsConfig *tls.Config) (*websocket.Conn, *http.Response, error) {
-	dialer := &websocket.Dialer{
-		ReadBufferSize:  WebsocketMessageBufferSize,
-		WriteBufferSize: WebsocketMessageBufferSize,
-		Subprotocols:    []string{subresources.PlainStreamProtocolName},
-		TLSClientConfig: tlsConfig,
+	return newDialer(tlsConfig, false).Dial(address, nil)
+}
+
+// DialWithCompression behaves like Dial, but additionally offers the permessage-deflate
+// extension to the server. Whether it was accepted is reported in the handshake response.
+func DialWithCompression(address string, tlsConfig *tls.Config) (*websocket.Conn, *http.Response, error) {
+	return newDialer(tlsConfig, true).Dial(address, nil)
+}
+
+func newDialer(tlsConfig *tls.Config, enableCompression bool) *websocket.Dialer {
+	return &websocket.Dialer{
+		ReadBufferSize:    WebsocketMessageBufferSize,
+		WriteBufferSize:   WebsocketMessageBufferSize,
+		Subprotocols:      []string{subresources.PlainStreamProtocolName},
+		TLSClientConfig:   tlsConfig,
+		EnableCompression: enableCompression,
+	}
+}
+
+// HasCompressionExtension reports whether the permessage-deflate extension is listed in the
+// Sec-WebSocket-Extensions header of a handshake request (offered) or response (accepted).
+func HasCompressionExtension(header http.Header) bool {
+	for _, value := range header.Values("Sec-WebSocket-Extensions") {
+		for _, extension := range strings.Split(value, ",") {
+			name, _, _ := strings.Cut(extension, ";")
+			if strings.EqualFold(strings.TrimSpace(name), compressionExtension) {
+				return true
+			}
+		}
 	}
-	return dialer.Dial(address, nil)
+	return false
 }
 
 func Copy(dst *websocket.Conn, src *websocket.Conn) (int64, error) {
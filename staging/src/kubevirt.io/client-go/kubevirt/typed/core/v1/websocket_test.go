@@ -51,8 +51,8 @@ var _ = ginkgo.Describe("Websocket", func() {
 			proxy.Close()
 			target.Close()
 		})
-		ginkgo.It("should transfer arbitrary sized packets which are bigger and smaller than the websocket buffer", func() {
-			proxyCon := dial(proxy)
+		ginkgo.DescribeTable("should transfer arbitrary sized packets which are bigger and smaller than the websocket buffer", func(compress bool) {
+			proxyCon := dial(proxy, compress)
 			defer proxyCon.Close()
 			messages := [][]byte{
 				[]byte(rand.String(WebsocketMessageBufferSize - 10)),
@@ -73,15 +73,32 @@ var _ = ginkgo.Describe("Websocket", func() {
 			err = <-done
 			gomega.Expect(err).ToNot(gomega.HaveOccurred(), "target server did not receive a propler close message")
 			gomega.Expect(fmt.Sprintf("%x", expectedDataHash.Sum(nil))).To(gomega.Equal(fmt.Sprintf("%x", receivedDataHash.Sum(nil))))
-		})
+		},
+			ginkgo.Entry("without compression", false),
+			ginkgo.Entry("with compression negotiated end to end", true),
+		)
 	})
 
+	ginkgo.DescribeTable("should detect the compression extension", func(extensions []string, expected bool) {
+		header := http.Header{}
+		for _, extension := range extensions {
+			header.Add("Sec-WebSocket-Extensions", extension)
+		}
+		gomega.Expect(HasCompressionExtension(header)).To(gomega.Equal(expected))
+	},
+		ginkgo.Entry("when the header is missing", nil, false),
+		ginkgo.Entry("when only other extensions are listed", []string{"x-webkit-deflate-frame"}, false),
+		ginkgo.Entry("when offered with parameters", []string{"permessage-deflate; client_max_window_bits"}, true),
+		ginkgo.Entry("when listed among other extensions", []string{"x-foo, Permessage-Deflate"}, true),
+		ginkgo.Entry("when listed in a second header value", []string{"x-foo", "permessage-deflate"}, true),
+	)
 })
 
 func newTargetServer(writer io.Writer, done chan error) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer ginkgo.GinkgoRecover()
 		upgrader := NewUpgrader()
+		upgrader.EnableCompression = true
 		targetCon, err := upgrader.Upgrade(w, r, nil)
 		gomega.Expect(err).ToNot(gomega.HaveOccurred())
 		_, err = CopyFrom(writer, targetCon)
@@ -92,20 +109,31 @@ func newTargetServer(writer io.Writer, done chan error) *httptest.Server {
 func newProxyServer(target *httptest.Server) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer ginkgo.GinkgoRecover()
-		upgrader := NewUpgrader()
-		src, err := upgrader.Upgrade(w, r, nil)
-		gomega.Expect(err).ToNot(gomega.HaveOccurred())
 		targetURL := "ws" + strings.TrimPrefix(target.URL, "http")
-		dst, _, err := Dial(targetURL, nil)
+		dialTarget := Dial
+		if HasCompressionExtension(r.Header) {
+			dialTarget = DialWithCompression
+		}
+		dst, resp, err := dialTarget(targetURL, nil)
 		gomega.Expect(err).ToNot(gomega.HaveOccurred())
 		defer dst.Close()
+		// frames are copied as is, so the client may only compress if the target agreed to
+		upgrader := NewUpgrader()
+		upgrader.EnableCompression = HasCompressionExtension(resp.Header)
+		src, err := upgrader.Upgrade(w, r, nil)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
 		_, _ = Copy(dst, src)
 	}))
 }
 
-func dial(proxy *httptest.Server) *websocket.Conn {
+func dial(proxy *httptest.Server, compress bool) *websocket.Conn {
 	u := "ws" + strings.TrimPrefix(proxy.URL, "http")
-	proxyCon, _, err := Dial(u, nil)
+	dialProxy := Dial
+	if compress {
+		dialProxy = DialWithCompression
+	}
+	proxyCon, resp, err := dialProxy(u, nil)
 	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	gomega.Expect(HasCompressionExtension(resp.Header)).To(gomega.Equal(compress))
 	return proxyCon
 }
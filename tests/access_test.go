@@ -448,6 +448,10 @@ var _ = Describe("[rfe_id:500][crit:high][vendor:cnv-qe@redhat.com][level:compon
 				"virtualmachineinstances", "sev/querylaunchmeasurement",
 				allowGetFor("admin", "edit", "view"),
 				denyAllFor("migrate", "default")),
+			Entry("on vmi sev/fetchattestationreport",
+				"virtualmachineinstances", "sev/fetchattestationreport",
+				allowUpdateFor("admin", "edit"),
+				denyAllFor("view", "migrate", "default")),
 			Entry("on vmi sev/setupsession",
 				"virtualmachineinstances", "sev/setupsession",
 				allowUpdateFor("admin", "edit"),
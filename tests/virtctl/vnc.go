@@ -81,7 +81,7 @@ var _ = Describe(SIG("[sig-compute]VNC", decorators.SigCompute, decorators.WgArm
 			return json.NewDecoder(r).Decode(&result)
 		}, 60*time.Second).Should(Succeed())
 
-		verifyProxyConnection(fmt.Sprintf("%v", result["port"]), vmi.Name)
+		verifyProxyConnection("tcp", fmt.Sprintf("127.0.0.1:%v", result["port"]), vmi.Name)
 	})
 
 	It("[rfe_id:127][crit:medium][vendor:cnv-qe@redhat.com][level:component]"+
@@ -107,7 +107,39 @@ var _ = Describe(SIG("[sig-compute]VNC", decorators.SigCompute, decorators.WgArm
 			Expect(err).ToNot(HaveOccurred())
 		}()
 
-		verifyProxyConnection(testPort, vmi.Name)
+		verifyProxyConnection("tcp", "127.0.0.1:"+testPort, vmi.Name)
+	})
+
+	It("should connect to vnc with --proxy-only flag through a Unix domain socket", func() {
+		socketPath := filepath.Join(GinkgoT().TempDir(), "vnc.sock")
+
+		By("Invoking virtctl vnc with --proxy-only and --listen-socket")
+		r, w, _ := os.Pipe()
+		cmd := newVirtctlCommand(
+			"vnc",
+			vmi.Name,
+			"--namespace", vmi.Namespace,
+			"--proxy-only",
+			"--listen-socket", socketPath,
+		)
+		cmd.SetOut(w)
+
+		go func() {
+			defer GinkgoRecover()
+			Expect(cmd.Execute()).To(Succeed())
+		}()
+
+		var result map[string]interface{}
+		Eventually(func() error {
+			return json.NewDecoder(r).Decode(&result)
+		}, 60*time.Second).Should(Succeed())
+		Expect(result).To(HaveKeyWithValue("socket", socketPath))
+
+		info, err := os.Stat(socketPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		verifyProxyConnection("unix", socketPath, vmi.Name)
 	})
 
 	It("[rfe_id:127][crit:medium][vendor:cnv-qe@redhat.com][level:component]"+
@@ -141,9 +173,9 @@ var _ = Describe(SIG("[sig-compute]VNC", decorators.SigCompute, decorators.WgArm
 	})
 }))
 
-func verifyProxyConnection(port, vmiName string) {
+func verifyProxyConnection(network, address, vmiName string) {
 	Eventually(func(g Gomega) {
-		conn, err := net.Dial("tcp", "127.0.0.1:"+port)
+		conn, err := net.Dial(network, address)
 		g.Expect(err).ToNot(HaveOccurred())
 		defer conn.Close()
 